@@ -1,6 +1,7 @@
 package accessor
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -21,8 +22,20 @@ type Access interface {
 	TeamRoles() map[string][]string
 	Claims() Claims
 	UserInfo() atc.UserInfo
+	Impersonation() (Impersonation, bool)
 }
 
+// Impersonation is the team and role an admin is acting as for a request.
+type Impersonation struct {
+	Team string
+	Role string
+}
+
+var (
+	ErrImpersonationNotAllowed = errors.New("only admins may impersonate a team")
+	ErrInvalidImpersonation    = errors.New("invalid impersonation")
+)
+
 type Claims struct {
 	Sub               string
 	UserID            string
@@ -46,6 +59,7 @@ type access struct {
 	teams                  []db.Team
 	teamRoles              map[string][]string
 	isAdmin                bool
+	impersonation          *Impersonation
 	displayUserIdGenerator atc.DisplayUserIdGenerator
 }
 
@@ -83,6 +97,43 @@ func (a *access) computeTeamRoles() {
 	}
 }
 
+// impersonate replaces the admin's own access with the given role on the given
+// team, so that the request is authorized exactly as a member of that team
+// would be.
+func (a *access) impersonate(teamName string, role string) error {
+	if !a.isAdmin {
+		return ErrImpersonationNotAllowed
+	}
+
+	if role == "" {
+		role = OwnerRole
+	}
+
+	switch role {
+	case OwnerRole, MemberRole, OperatorRole, ViewerRole:
+	default:
+		return fmt.Errorf("%w: unknown role '%s'", ErrInvalidImpersonation, role)
+	}
+
+	found := false
+	for _, team := range a.teams {
+		if team.Name() == teamName {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%w: unknown team '%s'", ErrInvalidImpersonation, teamName)
+	}
+
+	a.isAdmin = false
+	a.teamRoles = map[string][]string{teamName: {role}}
+	a.impersonation = &Impersonation{Team: teamName, Role: role}
+
+	return nil
+}
+
 func contains(arr []string, val string) bool {
 	for _, v := range arr {
 		if v == val {
@@ -268,6 +319,14 @@ func (a *access) IsSystem() bool {
 	return false
 }
 
+func (a *access) Impersonation() (Impersonation, bool) {
+	if a.impersonation == nil {
+		return Impersonation{}, false
+	}
+
+	return *a.impersonation, true
+}
+
 func (a *access) TeamRoles() map[string][]string {
 	return a.teamRoles
 }
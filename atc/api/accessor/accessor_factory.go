@@ -47,7 +47,19 @@ func (a *accessFactory) Create(req *http.Request, role string) (Access, error) {
 	if err != nil {
 		return nil, fmt.Errorf("fetch teams: %w", err)
 	}
-	return NewAccessor(a.verifyToken(req), role, a.systemClaimKey, a.systemClaimValues, teams, a.displayUserIdGenerator), nil
+
+	acc := NewAccessor(a.verifyToken(req), role, a.systemClaimKey, a.systemClaimValues, teams, a.displayUserIdGenerator)
+
+	// there is nobody to impersonate as on unauthenticated requests, which
+	// only ever get at public resources, so the header is ignored on them
+	if team := req.Header.Get(atc.ImpersonateTeamHeader); team != "" && acc.IsAuthenticated() {
+		err := acc.impersonate(team, req.Header.Get(atc.ImpersonateRoleHeader))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return acc, nil
 }
 
 func (a *accessFactory) verifyToken(req *http.Request) Verification {
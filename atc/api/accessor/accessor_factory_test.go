@@ -84,6 +84,106 @@ var _ = Describe("AccessorFactory", func() {
 			})
 		})
 
+		Context("when impersonating a team", func() {
+			var isAdmin bool
+
+			BeforeEach(func() {
+				isAdmin = true
+				role = "member"
+
+				dummyRequest.Header.Set(atc.ImpersonateTeamHeader, "t2")
+
+				fakeTokenVerifier.VerifyReturns(map[string]interface{}{
+					"preferred_username": "admin",
+					"federated_claims": map[string]interface{}{
+						"connector_id": "github",
+					},
+				}, nil)
+
+				mainTeam := new(dbfakes.FakeTeam)
+				mainTeam.NameReturns("main")
+				mainTeam.AdminStub = func() bool { return isAdmin }
+				mainTeam.AuthReturns(atc.TeamAuth{"owner": map[string][]string{
+					"users": {"github:admin"},
+				}})
+
+				otherTeam := new(dbfakes.FakeTeam)
+				otherTeam.NameReturns("t2")
+				otherTeam.AuthReturns(atc.TeamAuth{"owner": map[string][]string{
+					"users": {"github:someone-else"},
+				}})
+
+				fakeTeamFetcher.GetTeamsReturns([]db.Team{mainTeam, otherTeam}, nil)
+			})
+
+			It("acts as an owner of the team instead of an admin", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(access.IsAdmin()).To(BeFalse())
+				Expect(access.IsAuthorized("t2")).To(BeTrue())
+				Expect(access.IsAuthorized("main")).To(BeFalse())
+				Expect(access.TeamNames()).To(ConsistOf("t2"))
+
+				impersonation, ok := access.Impersonation()
+				Expect(ok).To(BeTrue())
+				Expect(impersonation).To(Equal(accessor.Impersonation{Team: "t2", Role: "owner"}))
+			})
+
+			Context("with a role", func() {
+				BeforeEach(func() {
+					dummyRequest.Header.Set(atc.ImpersonateRoleHeader, "viewer")
+				})
+
+				It("is only authorized for actions the role can perform", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(access.IsAuthorized("t2")).To(BeFalse())
+				})
+			})
+
+			Context("with an unknown role", func() {
+				BeforeEach(func() {
+					dummyRequest.Header.Set(atc.ImpersonateRoleHeader, "bogus")
+				})
+
+				It("returns an error", func() {
+					Expect(errors.Is(err, accessor.ErrInvalidImpersonation)).To(BeTrue())
+				})
+			})
+
+			Context("with an unknown team", func() {
+				BeforeEach(func() {
+					dummyRequest.Header.Set(atc.ImpersonateTeamHeader, "bogus")
+				})
+
+				It("returns an error", func() {
+					Expect(errors.Is(err, accessor.ErrInvalidImpersonation)).To(BeTrue())
+				})
+			})
+
+			Context("when the user is not an admin", func() {
+				BeforeEach(func() {
+					isAdmin = false
+				})
+
+				It("returns an error", func() {
+					Expect(err).To(Equal(accessor.ErrImpersonationNotAllowed))
+				})
+			})
+
+			Context("when the request is not authenticated", func() {
+				BeforeEach(func() {
+					fakeTokenVerifier.VerifyReturns(nil, accessor.ErrVerificationNoToken)
+				})
+
+				It("ignores the impersonation", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(access.IsAuthenticated()).To(BeFalse())
+
+					_, ok := access.Impersonation()
+					Expect(ok).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when the team fetcher returns an error", func() {
 			BeforeEach(func() {
 				fakeTeamFetcher.GetTeamsReturns(nil, errors.New("nope"))
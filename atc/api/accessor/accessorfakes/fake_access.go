@@ -29,6 +29,18 @@ type FakeAccess struct {
 	hasTokenReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	ImpersonationStub        func() (accessor.Impersonation, bool)
+	impersonationMutex       sync.RWMutex
+	impersonationArgsForCall []struct {
+	}
+	impersonationReturns struct {
+		result1 accessor.Impersonation
+		result2 bool
+	}
+	impersonationReturnsOnCall map[int]struct {
+		result1 accessor.Impersonation
+		result2 bool
+	}
 	IsAdminStub        func() bool
 	isAdminMutex       sync.RWMutex
 	isAdminArgsForCall []struct {
@@ -210,6 +222,62 @@ func (fake *FakeAccess) HasTokenReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeAccess) Impersonation() (accessor.Impersonation, bool) {
+	fake.impersonationMutex.Lock()
+	ret, specificReturn := fake.impersonationReturnsOnCall[len(fake.impersonationArgsForCall)]
+	fake.impersonationArgsForCall = append(fake.impersonationArgsForCall, struct {
+	}{})
+	stub := fake.ImpersonationStub
+	fakeReturns := fake.impersonationReturns
+	fake.recordInvocation("Impersonation", []interface{}{})
+	fake.impersonationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAccess) ImpersonationCallCount() int {
+	fake.impersonationMutex.RLock()
+	defer fake.impersonationMutex.RUnlock()
+	return len(fake.impersonationArgsForCall)
+}
+
+func (fake *FakeAccess) ImpersonationCalls(stub func() (accessor.Impersonation, bool)) {
+	fake.impersonationMutex.Lock()
+	defer fake.impersonationMutex.Unlock()
+	fake.ImpersonationStub = stub
+}
+
+func (fake *FakeAccess) ImpersonationReturns(result1 accessor.Impersonation, result2 bool) {
+	fake.impersonationMutex.Lock()
+	defer fake.impersonationMutex.Unlock()
+	fake.ImpersonationStub = nil
+	fake.impersonationReturns = struct {
+		result1 accessor.Impersonation
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeAccess) ImpersonationReturnsOnCall(i int, result1 accessor.Impersonation, result2 bool) {
+	fake.impersonationMutex.Lock()
+	defer fake.impersonationMutex.Unlock()
+	fake.ImpersonationStub = nil
+	if fake.impersonationReturnsOnCall == nil {
+		fake.impersonationReturnsOnCall = make(map[int]struct {
+			result1 accessor.Impersonation
+			result2 bool
+		})
+	}
+	fake.impersonationReturnsOnCall[i] = struct {
+		result1 accessor.Impersonation
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeAccess) IsAdmin() bool {
 	fake.isAdminMutex.Lock()
 	ret, specificReturn := fake.isAdminReturnsOnCall[len(fake.isAdminArgsForCall)]
@@ -596,6 +664,8 @@ func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	defer fake.claimsMutex.RUnlock()
 	fake.hasTokenMutex.RLock()
 	defer fake.hasTokenMutex.RUnlock()
+	fake.impersonationMutex.RLock()
+	defer fake.impersonationMutex.RUnlock()
 	fake.isAdminMutex.RLock()
 	defer fake.isAdminMutex.RUnlock()
 	fake.isAuthenticatedMutex.RLock()
@@ -2,6 +2,8 @@ package accessor
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -53,8 +55,16 @@ func (h *accessorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	acc, err := h.accessFactory.Create(r, requiredRole)
 	if err != nil {
-		h.logger.Error("failed-to-construct-accessor", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, ErrImpersonationNotAllowed):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Is(err, ErrInvalidImpersonation):
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+		default:
+			h.logger.Error("failed-to-construct-accessor", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -63,6 +73,10 @@ func (h *accessorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := context.WithValue(r.Context(), accessorContextKey, acc)
 
 	h.auditor.Audit(h.action, claims.UserName, r)
+
+	if impersonation, ok := acc.Impersonation(); ok {
+		h.auditor.AuditImpersonation(h.action, claims.UserName, impersonation.Team, impersonation.Role, r)
+	}
 	h.handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
@@ -2,6 +2,7 @@ package accessor_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 
@@ -42,6 +43,7 @@ var _ = Describe("Handler", func() {
 
 		action = "some-action"
 		customRoles = map[string]string{"some-action": "some-role"}
+		createAccessError = nil
 
 		var err error
 		r, err = http.NewRequest("GET", "localhost:8080", nil)
@@ -161,6 +163,59 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when an admin is impersonating a team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{UserName: "some-admin"})
+				fakeAccess.ImpersonationReturns(accessor.Impersonation{
+					Team: "some-team",
+					Role: "member",
+				}, true)
+			})
+
+			It("audits the impersonation", func() {
+				Expect(fakeAuditor.AuditImpersonationCallCount()).To(Equal(1))
+				action, userName, team, role, req := fakeAuditor.AuditImpersonationArgsForCall(0)
+				Expect(action).To(Equal("some-action"))
+				Expect(userName).To(Equal("some-admin"))
+				Expect(team).To(Equal("some-team"))
+				Expect(role).To(Equal("member"))
+				Expect(req).To(Equal(r))
+			})
+
+			It("invokes the handler", func() {
+				Expect(fakeHandler.ServeHTTPCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the request is not impersonating a team", func() {
+			It("does not audit an impersonation", func() {
+				Expect(fakeAuditor.AuditImpersonationCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when impersonation is not allowed", func() {
+			BeforeEach(func() {
+				createAccessError = accessor.ErrImpersonationNotAllowed
+			})
+
+			It("returns forbidden", func() {
+				Expect(w.Result().StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeHandler.ServeHTTPCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the impersonation is invalid", func() {
+			BeforeEach(func() {
+				createAccessError = fmt.Errorf("%w: unknown team 'bogus'", accessor.ErrInvalidImpersonation)
+			})
+
+			It("returns bad request", func() {
+				Expect(w.Result().StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("unknown team 'bogus'"))
+			})
+		})
+
 		Context("when the accessor factory errors", func() {
 			BeforeEach(func() {
 				createAccessError = errors.New("<<something bad here>>")
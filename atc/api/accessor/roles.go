@@ -14,14 +14,18 @@ const (
 var DefaultRoles = map[string]string{
 	atc.SaveConfig:                     MemberRole,
 	atc.GetConfig:                      ViewerRole,
+	atc.PreviewPipeline:                MemberRole,
 	atc.GetCC:                          ViewerRole,
 	atc.GetBuild:                       ViewerRole,
 	atc.GetBuildPlan:                   ViewerRole,
+	atc.GetBuildPlanDAG:                ViewerRole,
 	atc.CreateBuild:                    MemberRole,
 	atc.ListBuilds:                     ViewerRole,
 	atc.BuildEvents:                    ViewerRole,
 	atc.BuildResources:                 ViewerRole,
 	atc.AbortBuild:                     OperatorRole,
+	atc.PauseBuild:                     OperatorRole,
+	atc.ResumeBuild:                    OperatorRole,
 	atc.GetBuildPreparation:            ViewerRole,
 	atc.GetJob:                         ViewerRole,
 	atc.CreateJobBuild:                 OperatorRole,
@@ -45,8 +49,13 @@ var DefaultRoles = map[string]string{
 	atc.GetResource:                    ViewerRole,
 	atc.UnpinResource:                  OperatorRole,
 	atc.SetPinCommentOnResource:        OperatorRole,
+	atc.PauseResourceChecks:            OperatorRole,
+	atc.UnpauseResourceChecks:          OperatorRole,
 	atc.CheckResource:                  OperatorRole,
 	atc.CheckResourceWebHook:           OperatorRole,
+	atc.CreateResourceWebhookToken:     MemberRole,
+	atc.ListResourceWebhookTokens:      MemberRole,
+	atc.RotateResourceWebhookTokens:    MemberRole,
 	atc.CheckResourceType:              OperatorRole,
 	atc.CheckPrototype:                 OperatorRole,
 	atc.ListResourceVersions:           ViewerRole,
@@ -81,6 +90,8 @@ var DefaultRoles = map[string]string{
 	atc.HeartbeatWorker:                MemberRole,
 	atc.ListWorkers:                    ViewerRole,
 	atc.DeleteWorker:                   MemberRole,
+	atc.EnrollWorker:                   ViewerRole,
+	atc.ListEnrolledWorkerKeys:         MemberRole,
 	atc.SetLogLevel:                    MemberRole,
 	atc.GetLogLevel:                    ViewerRole,
 	atc.DownloadCLI:                    ViewerRole,
@@ -103,5 +114,6 @@ var DefaultRoles = map[string]string{
 	atc.CreateArtifact:                 MemberRole,
 	atc.GetArtifact:                    MemberRole,
 	atc.ListBuildArtifacts:             ViewerRole,
+	atc.GetBuildTestResults:            ViewerRole,
 	atc.GetWall:                        ViewerRole,
 }
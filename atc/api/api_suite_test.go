@@ -71,6 +71,8 @@ var (
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
 	dbWall                  *dbfakes.FakeWall
+	dbEnrollmentFactory     *dbfakes.FakeWorkerEnrollmentFactory
+	fakeAuditor             *auditorfakes.FakeAuditor
 	fakeSecretManager       *credsfakes.FakeSecrets
 	fakeVarSourcePool       *credsfakes.FakeVarSourcePool
 	fakePolicyChecker       *policycheckerfakes.FakePolicyChecker
@@ -119,6 +121,8 @@ var _ = BeforeEach(func() {
 	dbUserFactory = new(dbfakes.FakeUserFactory)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
+	dbEnrollmentFactory = new(dbfakes.FakeWorkerEnrollmentFactory)
+	fakeAuditor = new(auditorfakes.FakeAuditor)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -213,6 +217,7 @@ var _ = BeforeEach(func() {
 		constructedEventHandler.Construct,
 
 		fakeWorkerPool,
+		nil,
 
 		sink,
 
@@ -227,6 +232,8 @@ var _ = BeforeEach(func() {
 		interceptTimeoutFactory,
 		time.Second,
 		dbWall,
+		dbEnrollmentFactory,
+		fakeAuditor,
 		fakeClock,
 	)
 
@@ -239,7 +246,7 @@ var _ = BeforeEach(func() {
 		"some-action",
 		handler,
 		fakeAccessor,
-		new(auditorfakes.FakeAuditor),
+		fakeAuditor,
 		map[string]string{},
 	)
 
@@ -0,0 +1,13 @@
+package artifactserver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestArtifactServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Artifact Server Suite")
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/artifactstore"
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker"
@@ -20,6 +21,34 @@ func (s *Server) CreateArtifact(team db.Team) http.Handler {
 		// which we can lookup in the checksum field
 		// that way we don't have to create another volume.
 
+		if s.store != nil {
+			key, err := artifactstore.NewKey()
+			if err != nil {
+				hLog.Error("failed-to-generate-store-key", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			err = s.store.Put(r.Context(), key, r.Body)
+			if err != nil {
+				hLog.Error("failed-to-store-artifact", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			artifact, err := team.CreateStoredArtifact(key)
+			if err != nil {
+				hLog.Error("failed-to-create-artifact", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+
+			json.NewEncoder(w).Encode(present.WorkerArtifact(artifact))
+			return
+		}
+
 		workerSpec := worker.Spec{
 			TeamID:   team.ID(),
 			Platform: r.FormValue("platform"),
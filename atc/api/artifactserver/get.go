@@ -1,10 +1,13 @@
 package artifactserver
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/artifactstore"
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -23,6 +26,20 @@ func (s *Server) GetArtifact(team db.Team) http.Handler {
 			return
 		}
 
+		if s.store != nil {
+			artifact, found, err := team.FindStoredWorkerArtifact(artifactID)
+			if err != nil {
+				logger.Error("failed-to-get-stored-artifact", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if found {
+				s.streamStoredArtifact(w, r, logger, artifact.StoreKey())
+				return
+			}
+		}
+
 		artifactVolume, found, err := team.FindVolumeForWorkerArtifact(artifactID)
 		if err != nil {
 			logger.Error("failed-to-get-artifact-volume", err)
@@ -64,3 +81,25 @@ func (s *Server) GetArtifact(team db.Team) http.Handler {
 		}
 	})
 }
+
+func (s *Server) streamStoredArtifact(w http.ResponseWriter, r *http.Request, logger lager.Logger, key string) {
+	blob, err := s.store.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, artifactstore.ErrNotFound) {
+			logger.Info("stored-artifact-not-found", lager.Data{"store-key": key})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Error("failed-to-get-stored-artifact-contents", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	defer blob.Close()
+
+	_, err = io.Copy(w, blob)
+	if err != nil {
+		logger.Error("failed-to-encode-artifact", err)
+	}
+}
@@ -2,6 +2,7 @@ package artifactserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/artifactstore"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
@@ -15,14 +16,17 @@ type Pool interface {
 type Server struct {
 	logger     lager.Logger
 	workerPool Pool
+	store      artifactstore.Store
 }
 
 func NewServer(
 	logger lager.Logger,
 	workerPool Pool,
+	store artifactstore.Store,
 ) *Server {
 	return &Server{
 		logger:     logger,
 		workerPool: workerPool,
+		store:      store,
 	}
 }
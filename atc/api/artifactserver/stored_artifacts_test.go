@@ -0,0 +1,167 @@
+package artifactserver_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/concourse/atc/api/artifactserver"
+	"github.com/concourse/concourse/atc/artifactstore"
+	"github.com/concourse/concourse/atc/artifactstore/artifactstorefakes"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stored artifacts", func() {
+	var (
+		fakeStore *artifactstorefakes.FakeStore
+		fakeTeam  *dbfakes.FakeTeam
+		server    *Server
+		recorder  *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		fakeStore = new(artifactstorefakes.FakeStore)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.IDReturns(734)
+
+		server = NewServer(lagertest.NewTestLogger("test"), nil, fakeStore)
+		recorder = httptest.NewRecorder()
+	})
+
+	Describe("CreateArtifact", func() {
+		JustBeforeEach(func() {
+			request := httptest.NewRequest("POST", "/api/v1/teams/some-team/artifacts", strings.NewReader("some-tgz"))
+			server.CreateArtifact(fakeTeam).ServeHTTP(recorder, request)
+		})
+
+		Context("when the artifact is stored", func() {
+			BeforeEach(func() {
+				fakeArtifact := new(dbfakes.FakeWorkerArtifact)
+				fakeArtifact.IDReturns(17)
+				fakeArtifact.CreatedAtReturns(time.Unix(42, 0))
+				fakeTeam.CreateStoredArtifactReturns(fakeArtifact, nil)
+			})
+
+			It("uploads the request body to the store", func() {
+				Expect(fakeStore.PutCallCount()).To(Equal(1))
+				_, key, body := fakeStore.PutArgsForCall(0)
+				Expect(key).To(HavePrefix("artifacts/"))
+
+				contents, err := ioutil.ReadAll(body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-tgz"))
+			})
+
+			It("creates the artifact with the key it was stored under", func() {
+				Expect(fakeTeam.CreateStoredArtifactCallCount()).To(Equal(1))
+
+				_, putKey, _ := fakeStore.PutArgsForCall(0)
+				Expect(fakeTeam.CreateStoredArtifactArgsForCall(0)).To(Equal(putKey))
+			})
+
+			It("returns 201 Created", func() {
+				Expect(recorder.Code).To(Equal(http.StatusCreated))
+				Expect(recorder.Body.String()).To(MatchJSON(`{
+					"id": 17,
+					"name": "",
+					"build_id": 0,
+					"created_at": 42
+				}`))
+			})
+		})
+
+		Context("when uploading to the store fails", func() {
+			BeforeEach(func() {
+				fakeStore.PutReturns(errors.New("nope"))
+			})
+
+			It("returns 500 Internal Server Error without creating the artifact", func() {
+				Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+				Expect(fakeTeam.CreateStoredArtifactCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when creating the artifact fails", func() {
+			BeforeEach(func() {
+				fakeTeam.CreateStoredArtifactReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GetArtifact", func() {
+		JustBeforeEach(func() {
+			request := httptest.NewRequest("GET", "/api/v1/teams/some-team/artifacts/18?:artifact_id=18", nil)
+			server.GetArtifact(fakeTeam).ServeHTTP(recorder, request)
+		})
+
+		Context("when the artifact is stored", func() {
+			BeforeEach(func() {
+				fakeArtifact := new(dbfakes.FakeWorkerArtifact)
+				fakeArtifact.StoreKeyReturns("artifacts/some-key.tgz")
+				fakeTeam.FindStoredWorkerArtifactReturns(fakeArtifact, true, nil)
+
+				fakeStore.GetReturns(ioutil.NopCloser(strings.NewReader("some-tgz")), nil)
+			})
+
+			It("looks up the artifact for the team", func() {
+				Expect(fakeTeam.FindStoredWorkerArtifactCallCount()).To(Equal(1))
+				Expect(fakeTeam.FindStoredWorkerArtifactArgsForCall(0)).To(Equal(18))
+			})
+
+			It("streams the artifact from the store", func() {
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+				Expect(recorder.Body.String()).To(Equal("some-tgz"))
+
+				_, key := fakeStore.GetArgsForCall(0)
+				Expect(key).To(Equal("artifacts/some-key.tgz"))
+			})
+
+			It("does not look for a volume", func() {
+				Expect(fakeTeam.FindVolumeForWorkerArtifactCallCount()).To(BeZero())
+			})
+
+			Context("when the artifact is gone from the store", func() {
+				BeforeEach(func() {
+					fakeStore.GetReturns(nil, artifactstore.ErrNotFound)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(recorder.Code).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the artifact from the store fails", func() {
+				BeforeEach(func() {
+					fakeStore.GetReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the artifact is not stored", func() {
+			BeforeEach(func() {
+				fakeTeam.FindStoredWorkerArtifactReturns(nil, false, nil)
+				fakeTeam.FindVolumeForWorkerArtifactReturns(nil, false, nil)
+			})
+
+			It("falls back to looking for a volume", func() {
+				Expect(fakeTeam.FindVolumeForWorkerArtifactCallCount()).To(Equal(1))
+				Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -1113,6 +1114,196 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/builds/:build_id/pause", func() {
+		var (
+			response *http.Response
+		)
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/pause", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when looking up the build fails", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when pausing the build fails", func() {
+						BeforeEach(func() {
+							build.PauseReturns(errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when pausing succeeds", func() {
+						BeforeEach(func() {
+							build.PauseReturns(nil)
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/resume", func() {
+		var (
+			response *http.Response
+		)
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/resume", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when looking up the build fails", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when resuming the build fails", func() {
+						BeforeEach(func() {
+							build.ResumeReturns(errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when resuming succeeds", func() {
+						BeforeEach(func() {
+							build.ResumeReturns(nil)
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1530,4 +1721,201 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/plan/dag", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/plan/dag")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.TeamNameReturns("some-team")
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the build has a plan", func() {
+					BeforeEach(func() {
+						build.HasPlanReturns(true)
+						build.PrivatePlanReturns(atc.Plan{
+							ID: "do",
+							Do: &atc.DoPlan{
+								{ID: "get", Get: &atc.GetPlan{Name: "some-input"}},
+								{ID: "task", Task: &atc.TaskPlan{Name: "some-task"}},
+							},
+						})
+
+						data := json.RawMessage(`{"origin":{"id":"get"},"time":1,"exit_status":0}`)
+						build.SavedEventsReturns([]event.Envelope{
+							{Event: event.EventTypeFinishGet, Version: "5.1", Data: &data},
+						}, nil)
+					})
+
+					It("returns 200 with the DAG of the plan", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response).Should(IncludeHeaderEntries(map[string]string{
+							"Content-Type": "application/json",
+						}))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"nodes": [
+								{"id": "do", "type": "do", "status": "started"},
+								{"id": "get", "parent": "do", "type": "get", "name": "some-input", "status": "succeeded", "end_time": 1},
+								{"id": "task", "parent": "do", "type": "task", "name": "some-task", "status": "pending"}
+							],
+							"edges": [
+								{"from": "get", "to": "task"}
+							]
+						}`))
+					})
+				})
+
+				Context("when the build has no plan", func() {
+					BeforeEach(func() {
+						build.HasPlanReturns(false)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when fetching the events fails", func() {
+					BeforeEach(func() {
+						build.HasPlanReturns(true)
+						build.SavedEventsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/test_results", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/test_results")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.TeamNameReturns("some-team")
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the build has test results", func() {
+					BeforeEach(func() {
+						build.TestResultsReturns([]atc.TestResult{
+							{PlanID: "some-plan", Suite: "suite", Name: "passes", Status: atc.TestStatusPassed, Duration: 1.5},
+							{PlanID: "some-plan", Suite: "suite", Name: "fails", Status: atc.TestStatusFailed, Message: "boom"},
+						}, nil)
+					})
+
+					It("returns 200 with the results and a summary", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response).Should(IncludeHeaderEntries(map[string]string{
+							"Content-Type": "application/json",
+						}))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"summary": {"total": 2, "passed": 1, "failed": 1, "errored": 0, "skipped": 0},
+							"results": [
+								{"plan_id": "some-plan", "suite": "suite", "name": "passes", "status": "passed", "duration": 1.5},
+								{"plan_id": "some-plan", "suite": "suite", "name": "fails", "status": "failed", "message": "boom"}
+							]
+						}`))
+					})
+				})
+
+				Context("when the build has no test results", func() {
+					It("returns an empty list", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"summary": {"total": 0, "passed": 0, "failed": 0, "errored": 0, "skipped": 0},
+							"results": []
+						}`))
+					})
+				})
+
+				Context("when fetching the test results fails", func() {
+					BeforeEach(func() {
+						build.TestResultsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
 })
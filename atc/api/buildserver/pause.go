@@ -0,0 +1,37 @@
+package buildserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) PauseBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pLog := s.logger.Session("pause", build.LagerData())
+
+		err := build.Pause()
+		if err != nil {
+			pLog.Error("failed-to-pause-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) ResumeBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rLog := s.logger.Session("resume", build.LagerData())
+
+		err := build.Resume()
+		if err != nil {
+			rLog.Error("failed-to-resume-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,36 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetBuildPlanDAG(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-plan-dag")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !build.HasPlan() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		events, err := build.SavedEvents()
+		if err != nil {
+			logger.Error("failed-to-get-build-events", err, lager.Data{"buildID": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(builds.PlanDAG(build.PrivatePlan(), events))
+		if err != nil {
+			logger.Error("failed-to-encode-build-plan-dag", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetBuildTestResults(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-test-results")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results, err := build.TestResults()
+		if err != nil {
+			logger.Error("failed-to-fetch-build-test-results", err, lager.Data{"buildID": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if results == nil {
+			results = []atc.TestResult{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(atc.BuildTestResults{
+			Summary: atc.SummarizeTestResults(results),
+			Results: results,
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-build-test-results", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
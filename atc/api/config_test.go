@@ -1319,4 +1319,80 @@ jobs:
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipeline_preview", func() {
+		var (
+			previewRequest atc.PlanPreviewRequest
+			response       *http.Response
+		)
+
+		BeforeEach(func() {
+			pipelineConfig.Jobs[0].PlanSequence[0].Config.(*atc.GetStep).Trigger = true
+
+			previewRequest = atc.PlanPreviewRequest{
+				Config: pipelineConfig,
+				Versions: map[string]atc.Version{
+					"some-resource": {"ref": "abc"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(previewRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := requestGenerator.CreateRequest(atc.PreviewPipeline, rata.Params{
+				"team_name": "a-team",
+			}, bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200 with the jobs that would trigger", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var preview atc.PlanPreview
+				Expect(json.NewDecoder(response.Body).Decode(&preview)).To(Succeed())
+
+				Expect(preview.Jobs).To(HaveLen(1))
+				Expect(preview.Jobs[0].Name).To(Equal("some-job"))
+				Expect(preview.Jobs[0].TriggeredBy).To(Equal([]string{"some-input"}))
+				Expect(preview.Jobs[0].Plan).ToNot(BeNil())
+				Expect(preview.Jobs[0].Error).To(BeEmpty())
+			})
+
+			It("does not save anything", func() {
+				Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					previewRequest.Config.Jobs = append(previewRequest.Config.Jobs, previewRequest.Config.Jobs[0])
+				})
+
+				It("returns 400 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("have the same name"))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
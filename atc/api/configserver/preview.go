@@ -0,0 +1,45 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/configvalidate"
+)
+
+func (s *Server) PreviewPipeline(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("preview-pipeline")
+
+	var request atc.PlanPreviewRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		session.Info("malformed-request", lager.Data{"error": err.Error()})
+		HandleBadRequest(w, fmt.Sprintf("malformed request: %s", err))
+		return
+	}
+
+	_, errorMessages := configvalidate.Validate(request.Config)
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
+		HandleBadRequest(w, errorMessages...)
+		return
+	}
+
+	planner := builds.NewPlanner(atc.NewPlanFactory(time.Now().Unix()))
+	preview := planner.Preview(request.Config, request.Versions)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(preview)
+	if err != nil {
+		session.Error("failed-to-encode-preview", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -178,7 +178,7 @@ func validateCredParams(credMgrVars vars.Variables, config atc.Config, session l
 		}
 	}
 
-	for _, job := range config.Jobs {
+	for _, job := range config.JobsWithPipelineHooks() {
 		_ = job.StepConfig().Visit(atc.StepRecursor{
 			OnTask: func(step *atc.TaskStep) error {
 				err := creds.NewTaskEnvValidator(credMgrVars, step.Params).Validate()
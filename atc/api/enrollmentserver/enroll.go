@@ -0,0 +1,84 @@
+package enrollmentserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"golang.org/x/crypto/ssh"
+)
+
+// EnrollWorker does not require authentication; the enrollment token in the
+// request body is the credential.
+func (s *Server) EnrollWorker(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("enroll-worker")
+
+	var req atc.WorkerEnrollmentRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Error("failed-to-decode-json", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.PublicKey))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid public key: %s", err)
+		return
+	}
+
+	normalizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+
+	key, found, err := s.enrollmentFactory.Enroll(req.Token, normalizedKey)
+	if err != nil {
+		logger.Error("failed-to-enroll", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("invalid-token")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	logger.Info("enrolled", lager.Data{"team": key.Team, "tags": key.Tags})
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(key)
+	if err != nil {
+		logger.Error("failed-to-encode-json", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) ListEnrolledWorkerKeys(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-enrolled-worker-keys")
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsSystem() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	keys, err := s.enrollmentFactory.EnrolledKeys()
+	if err != nil {
+		logger.Error("failed-to-list-keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(keys)
+	if err != nil {
+		logger.Error("failed-to-encode-json", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
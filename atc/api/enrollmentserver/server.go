@@ -0,0 +1,24 @@
+package enrollmentserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger            lager.Logger
+	teamFactory       db.TeamFactory
+	enrollmentFactory db.WorkerEnrollmentFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	teamFactory db.TeamFactory,
+	enrollmentFactory db.WorkerEnrollmentFactory,
+) *Server {
+	return &Server{
+		logger:            logger,
+		teamFactory:       teamFactory,
+		enrollmentFactory: enrollmentFactory,
+	}
+}
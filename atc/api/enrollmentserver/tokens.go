@@ -0,0 +1,111 @@
+package enrollmentserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+func (s *Server) CreateWorkerEnrollmentToken(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("create-worker-enrollment-token")
+
+	var req atc.WorkerEnrollmentTokenRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Error("failed-to-decode-json", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if req.TTL < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "ttl must not be negative")
+		return
+	}
+
+	if req.TTL == 0 {
+		req.TTL = atc.DefaultWorkerEnrollmentTokenTTL
+	}
+
+	var teamID int
+	if req.Team != "" {
+		team, found, err := s.teamFactory.FindTeam(req.Team)
+		if err != nil {
+			logger.Error("failed-to-find-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "team '%s' not found", req.Team)
+			return
+		}
+
+		teamID = team.ID()
+	}
+
+	token, err := s.enrollmentFactory.CreateToken(teamID, req.Tags, req.TTL)
+	if err != nil {
+		logger.Error("failed-to-create-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("created", lager.Data{"id": token.ID, "team": token.Team, "tags": token.Tags})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(token)
+	if err != nil {
+		logger.Error("failed-to-encode-json", err)
+	}
+}
+
+func (s *Server) ListWorkerEnrollmentTokens(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-worker-enrollment-tokens")
+
+	tokens, err := s.enrollmentFactory.Tokens()
+	if err != nil {
+		logger.Error("failed-to-list-tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(tokens)
+	if err != nil {
+		logger.Error("failed-to-encode-json", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) DeleteWorkerEnrollmentToken(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("delete-worker-enrollment-token")
+
+	id, err := strconv.Atoi(r.FormValue(":token_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := s.enrollmentFactory.DeleteToken(id)
+	if err != nil {
+		logger.Error("failed-to-delete-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
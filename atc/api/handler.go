@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/concourse/atc/api/cliserver"
 	"github.com/concourse/concourse/atc/api/configserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/enrollmentserver"
 	"github.com/concourse/concourse/atc/api/infoserver"
 	"github.com/concourse/concourse/atc/api/jobserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
@@ -25,6 +26,8 @@ import (
 	"github.com/concourse/concourse/atc/api/volumeserver"
 	"github.com/concourse/concourse/atc/api/wallserver"
 	"github.com/concourse/concourse/atc/api/workerserver"
+	"github.com/concourse/concourse/atc/artifactstore"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
@@ -65,6 +68,7 @@ func NewHandler(
 	eventHandlerFactory buildserver.EventHandlerFactory,
 
 	workerPool Pool,
+	artifactStore artifactstore.Store,
 
 	sink *lager.ReconfigurableSink,
 
@@ -79,6 +83,8 @@ func NewHandler(
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
 	dbWall db.Wall,
+	dbWorkerEnrollmentFactory db.WorkerEnrollmentFactory,
+	auditor auditor.Auditor,
 	clock clock.Clock,
 ) (http.Handler, error) {
 
@@ -93,7 +99,7 @@ func NewHandler(
 
 	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
 	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory)
-	resourceServer := resourceserver.NewServer(logger, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
+	resourceServer := resourceserver.NewServer(logger, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory, auditor)
 
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
@@ -106,13 +112,15 @@ func NewHandler(
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
 	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
 	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
-	artifactServer := artifactserver.NewServer(logger, workerPool)
+	artifactServer := artifactserver.NewServer(logger, workerPool, artifactStore)
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
 	wallServer := wallserver.NewServer(dbWall, logger)
+	enrollmentServer := enrollmentserver.NewServer(logger, dbTeamFactory, dbWorkerEnrollmentFactory)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:       http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:      http.HandlerFunc(configServer.SaveConfig),
+		atc.PreviewPipeline: http.HandlerFunc(configServer.PreviewPipeline),
 
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
@@ -121,11 +129,15 @@ func NewHandler(
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.PauseBuild:          buildHandlerFactory.HandlerFor(buildServer.PauseBuild),
+		atc.ResumeBuild:         buildHandlerFactory.HandlerFor(buildServer.ResumeBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildPlanDAG:     buildHandlerFactory.HandlerFor(buildServer.GetBuildPlanDAG),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
 		atc.SetBuildComment:     buildHandlerFactory.HandlerFor(buildServer.SetBuildComment),
+		atc.GetBuildTestResults: buildHandlerFactory.HandlerFor(buildServer.GetBuildTestResults),
 
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
@@ -169,12 +181,18 @@ func NewHandler(
 		atc.GetResource:             pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
 		atc.UnpinResource:           pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
 		atc.SetPinCommentOnResource: pipelineHandlerFactory.HandlerFor(resourceServer.SetPinCommentOnResource),
+		atc.PauseResourceChecks:     pipelineHandlerFactory.HandlerFor(resourceServer.PauseResourceChecks),
+		atc.UnpauseResourceChecks:   pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResourceChecks),
 		atc.CheckResource:           pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook:    pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 		atc.CheckResourceType:       pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
 		atc.CheckPrototype:          pipelineHandlerFactory.HandlerFor(resourceServer.CheckPrototype),
 		atc.ClearResourceCache:      pipelineHandlerFactory.HandlerFor(resourceServer.ClearResourceCache),
 
+		atc.CreateResourceWebhookToken:  pipelineHandlerFactory.HandlerFor(resourceServer.CreateWebhookToken),
+		atc.ListResourceWebhookTokens:   pipelineHandlerFactory.HandlerFor(resourceServer.ListWebhookTokens),
+		atc.RotateResourceWebhookTokens: pipelineHandlerFactory.HandlerFor(resourceServer.RotateWebhookTokens),
+
 		atc.ListResourceVersions:           pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.GetResourceVersion:             pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:          pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
@@ -226,6 +244,12 @@ func NewHandler(
 		atc.GetWall:   http.HandlerFunc(wallServer.GetWall),
 		atc.SetWall:   http.HandlerFunc(wallServer.SetWall),
 		atc.ClearWall: http.HandlerFunc(wallServer.ClearWall),
+
+		atc.CreateWorkerEnrollmentToken: http.HandlerFunc(enrollmentServer.CreateWorkerEnrollmentToken),
+		atc.ListWorkerEnrollmentTokens:  http.HandlerFunc(enrollmentServer.ListWorkerEnrollmentTokens),
+		atc.DeleteWorkerEnrollmentToken: http.HandlerFunc(enrollmentServer.DeleteWorkerEnrollmentToken),
+		atc.EnrollWorker:                http.HandlerFunc(enrollmentServer.EnrollWorker),
+		atc.ListEnrolledWorkerKeys:      http.HandlerFunc(enrollmentServer.ListEnrolledWorkerKeys),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -2198,6 +2198,76 @@ var _ = Describe("Jobs API", func() {
 						}`))
 							})
 						})
+
+						Context("when rerunning from the failed step", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/some-build?from_failure=true", nil)
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							Context("when the build failed", func() {
+								BeforeEach(func() {
+									fakeBuild.StatusReturns(db.BuildStatusFailed)
+
+									build := new(dbfakes.FakeBuild)
+									build.IDReturns(2)
+									build.NameReturns("1.1")
+									build.RerunOfReturns(1)
+									build.RerunOfNameReturns("1")
+									build.RerunNumberReturns(1)
+									build.ResumedFromReturns(1)
+									build.JobNameReturns("some-job")
+									build.PipelineNameReturns("a-pipeline")
+									build.TeamNameReturns("some-team")
+									build.StatusReturns(db.BuildStatusPending)
+
+									fakeJob.RerunBuildFromFailureReturns(build, nil)
+								})
+
+								It("resumes the rerun from the build", func() {
+									Expect(fakeJob.RerunBuildCallCount()).To(BeZero())
+									Expect(fakeJob.RerunBuildFromFailureCallCount()).To(Equal(1))
+
+									buildToRerun, _ := fakeJob.RerunBuildFromFailureArgsForCall(0)
+									Expect(buildToRerun).To(Equal(fakeBuild))
+								})
+
+								It("returns the build", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{
+							"id": 2,
+							"name": "1.1",
+							"job_name": "some-job",
+							"status": "pending",
+							"api_url": "/api/v1/builds/2",
+							"pipeline_name": "a-pipeline",
+							"team_name": "some-team",
+							"rerun_number": 1,
+							"rerun_of": {
+								"id": 1,
+								"name": "1"
+							},
+							"resumed_from": 1
+						}`))
+								})
+							})
+
+							Context("when the build did not fail", func() {
+								BeforeEach(func() {
+									fakeBuild.StatusReturns(db.BuildStatusSucceeded)
+								})
+
+								It("returns a 409 and does not rerun the build", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusConflict))
+									Expect(fakeJob.RerunBuildFromFailureCallCount()).To(BeZero())
+								})
+							})
+						})
 					})
 				})
 			})
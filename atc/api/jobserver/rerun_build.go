@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -48,8 +49,24 @@ func (s *Server) RerunJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		fromFailure := r.FormValue("from_failure") == "true"
+		if fromFailure {
+			status := buildToRerun.Status()
+			if status != db.BuildStatusFailed && status != db.BuildStatusErrored {
+				logger.Info("build-to-rerun-did-not-fail", lager.Data{"status": status})
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+
 		acc := accessor.GetAccessor(r)
-		build, err := job.RerunBuild(buildToRerun, acc.UserInfo().DisplayUserId)
+
+		var build db.Build
+		if fromFailure {
+			build, err = job.RerunBuildFromFailure(buildToRerun, acc.UserInfo().DisplayUserId)
+		} else {
+			build, err = job.RerunBuild(buildToRerun, acc.UserInfo().DisplayUserId)
+		}
 		if err != nil {
 			logger.Error("failed-to-retrigger-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
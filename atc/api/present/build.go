@@ -30,6 +30,10 @@ func Build(build db.Build, job db.Job, access accessor.Access) atc.Build {
 		TeamName:             build.TeamName(),
 		Status:               atc.BuildStatus(build.Status()),
 		APIURL:               apiURL,
+		Priority:             build.Priority(),
+		PreemptedBy:          build.PreemptedBy(),
+		Paused:               build.IsPaused(),
+		Queued:               build.IsQueued(),
 		CreatedBy:            build.CreatedBy(),
 	}
 
@@ -52,6 +56,8 @@ func Build(build db.Build, job db.Job, access accessor.Access) atc.Build {
 			Name: build.RerunOfName(),
 			ID:   build.RerunOf(),
 		}
+		atcBuild.AutoRerunReason = build.AutoRerunReason()
+		atcBuild.ResumedFrom = build.ResumedFrom()
 	}
 
 	if !build.StartTime().IsZero() {
@@ -52,4 +52,16 @@ var _ = Describe("Build", func() {
 			})
 		}
 	})
+
+	Describe("Queued", func() {
+		It("is set when the build is waiting for its team to have room for it", func() {
+			dbBuild.IsQueuedReturns(true)
+			Expect(present.Build(&dbBuild, nil, nil).Queued).To(BeTrue())
+		})
+
+		It("is not set otherwise", func() {
+			dbBuild.IsQueuedReturns(false)
+			Expect(present.Build(&dbBuild, nil, nil).Queued).To(BeFalse())
+		})
+	})
 })
@@ -15,13 +15,31 @@ func Resource(resource db.Resource) atc.Resource {
 		Type:                 resource.Type(),
 		Icon:                 resource.Icon(),
 
+		LastCheckError: resource.LastCheckError(),
+
 		PinComment: resource.PinComment(),
 
+		ChecksPaused: resource.ChecksPaused(),
+
 		Build: resource.BuildSummary(),
 	}
 
+	if !resource.LastCheckStartTime().IsZero() {
+		atcResource.LastCheckStartTime = resource.LastCheckStartTime().Unix()
+	}
+
 	if !resource.LastCheckEndTime().IsZero() {
 		atcResource.LastChecked = resource.LastCheckEndTime().Unix()
+		atcResource.LastCheckEndTime = resource.LastCheckEndTime().Unix()
+
+		// the start time is ahead of the end time while a check is running
+		if !resource.LastCheckStartTime().IsZero() && !resource.LastCheckEndTime().Before(resource.LastCheckStartTime()) {
+			atcResource.LastCheckDuration = resource.LastCheckEndTime().Sub(resource.LastCheckStartTime()).Seconds()
+		}
+	}
+
+	if !resource.NextCheckTime().IsZero() {
+		atcResource.NextCheckTime = resource.NextCheckTime().Unix()
 	}
 
 	if resource.ConfigPinnedVersion() != nil {
@@ -40,6 +40,9 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		State:            string(workerInfo.State()),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		GPUs:             workerInfo.GPUs(),
+		CPUCapacity:      workerInfo.Capacity().CPU,
+		MemoryCapacity:   workerInfo.Capacity().Memory,
 	}
 
 	if !workerInfo.StartTime().IsZero() {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -110,7 +111,8 @@ var _ = Describe("Resources API", func() {
 							"pipeline_name": "a-pipeline",
 							"team_name": "some-team",
 							"type": "type-1",
-							"last_checked": 1513364881
+							"last_checked": 1513364881,
+							"last_check_end_time": 1513364881
 						},
 						{
 							"name": "resource-2",
@@ -284,7 +286,8 @@ var _ = Describe("Resources API", func() {
 						"pipeline_name": "a-pipeline",
 						"team_name": "a-team",
 						"type": "type-1",
-						"last_checked": 1513364881
+						"last_checked": 1513364881,
+						"last_check_end_time": 1513364881
 					},
 					{
 						"name": "resource-2",
@@ -336,7 +339,8 @@ var _ = Describe("Resources API", func() {
 							"pipeline_name": "a-pipeline",
 							"team_name": "a-team",
 							"type": "type-1",
-							"last_checked": 1513364881
+							"last_checked": 1513364881,
+							"last_check_end_time": 1513364881
 						},
 						{
 							"name": "resource-2",
@@ -494,6 +498,210 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause-checks", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/pause-checks", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated ", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				It("tries to find the resource", func() {
+					resourceName := fakePipeline.ResourceArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+				})
+
+				Context("when finding the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource = new(dbfakes.FakeResource)
+						fakeResource.IDReturns(1)
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+					})
+
+					Context("when pausing the resource's checks succeeds", func() {
+						BeforeEach(func() {
+							fakeResource.PauseChecksReturns(nil)
+						})
+
+						It("pauses the resource's checks", func() {
+							Expect(fakeResource.PauseChecksCallCount()).To(Equal(1))
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when pausing the resource's checks fails", func() {
+						BeforeEach(func() {
+							fakeResource.PauseChecksReturns(errors.New("welp"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when it fails to find the resource", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, errors.New("welp"))
+					})
+
+					It("returns Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause-checks", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpause-checks", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated ", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				It("tries to find the resource", func() {
+					resourceName := fakePipeline.ResourceArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+				})
+
+				Context("when finding the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource = new(dbfakes.FakeResource)
+						fakeResource.IDReturns(1)
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+					})
+
+					Context("when unpausing the resource's checks succeeds", func() {
+						BeforeEach(func() {
+							fakeResource.UnpauseChecksReturns(nil)
+						})
+
+						It("unpauses the resource's checks", func() {
+							Expect(fakeResource.UnpauseChecksCallCount()).To(Equal(1))
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when unpausing the resource's checks fails", func() {
+						BeforeEach(func() {
+							fakeResource.UnpauseChecksReturns(errors.New("welp"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when it fails to find the resource", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, errors.New("welp"))
+					})
+
+					It("returns Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin_comment", func() {
 		var response *http.Response
 		var pinCommentRequestBody atc.SetPinCommentRequestBody
@@ -1030,6 +1238,7 @@ var _ = Describe("Resources API", func() {
 						"team_name": "a-team",
 						"type": "type-1",
 						"last_checked": 1513364881,
+						"last_check_end_time": 1513364881,
 						"build": {
 							"id": 123,
 							"name": "123",
@@ -1117,6 +1326,7 @@ var _ = Describe("Resources API", func() {
 								"team_name": "a-team",
 								"type": "type-1",
 								"last_checked": 1513364881,
+								"last_check_end_time": 1513364881,
 								"pinned_version": {"version": "v1"},
 								"pinned_in_config": true
 							}`))
@@ -1160,6 +1370,7 @@ var _ = Describe("Resources API", func() {
 								"team_name": "a-team",
 								"type": "type-1",
 								"last_checked": 1513364881,
+								"last_check_end_time": 1513364881,
 								"pinned_version": {"version": "v1"}
 							}`))
 					})
@@ -1191,11 +1402,48 @@ var _ = Describe("Resources API", func() {
 								"team_name": "a-team",
 								"type": "type-1",
 								"last_checked": 1513364881,
+								"last_check_end_time": 1513364881,
 								"pinned_version": {"version": "v1"},
 								"pin_comment": "a pin comment"
 							}`))
 					})
 				})
+
+				Context("when the resource's last check failed", func() {
+					BeforeEach(func() {
+						resource1 := new(dbfakes.FakeResource)
+						resource1.TeamNameReturns("a-team")
+						resource1.PipelineIDReturns(1)
+						resource1.PipelineNameReturns("a-pipeline")
+						resource1.NameReturns("resource-1")
+						resource1.TypeReturns("type-1")
+						resource1.LastCheckStartTimeReturns(time.Unix(1513364871, 500000000))
+						resource1.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+						resource1.LastCheckErrorReturns("check script exited with status 1")
+						resource1.NextCheckTimeReturns(time.Unix(1513364941, 0))
+						fakePipeline.ResourceReturns(resource1, true, nil)
+					})
+
+					It("returns the details of the last check in the response json", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"pipeline_id": 1,
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"type": "type-1",
+								"last_checked": 1513364881,
+								"last_check_start_time": 1513364871,
+								"last_check_end_time": 1513364881,
+								"last_check_duration": 9.5,
+								"last_check_error": "check script exited with status 1",
+								"next_check_time": 1513364941
+							}`))
+					})
+				})
 			})
 		})
 
@@ -1253,7 +1501,8 @@ var _ = Describe("Resources API", func() {
 						"pipeline_name": "a-pipeline",
 						"team_name": "a-team",
 						"type": "type-1",
-						"last_checked": 1513364881
+						"last_checked": 1513364881,
+						"last_check_end_time": 1513364881
 					}`))
 				})
 			})
@@ -1799,6 +2048,331 @@ var _ = Describe("Resources API", func() {
 			It("returns 401", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 			})
+
+			It("looks for a webhook token with the given value", func() {
+				Expect(fakeResource.UseWebhookTokenCallCount()).To(Equal(1))
+				Expect(fakeResource.UseWebhookTokenArgsForCall(0)).To(Equal("fake-token"))
+			})
+
+			Context("when the token is one of the resource's webhook tokens", func() {
+				BeforeEach(func() {
+					fakeResource.UseWebhookTokenReturns(atc.WebhookToken{ID: 3}, true, nil)
+					dbCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+				})
+
+				It("returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("audits the use of the token", func() {
+					action, userName, _ := fakeAuditor.AuditArgsForCall(fakeAuditor.AuditCallCount() - 1)
+					Expect(action).To(Equal(atc.CheckResourceWebHook))
+					Expect(userName).To(Equal("webhook-token:3"))
+				})
+			})
+
+			Context("when looking up the webhook token fails", func() {
+				BeforeEach(func() {
+					fakeResource.UseWebhookTokenReturns(atc.WebhookToken{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook-tokens", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.CreateWebhookTokenReturns(atc.WebhookToken{
+				ID:        1,
+				Token:     "some-token",
+				CreatedAt: 123,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/webhook-tokens", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the resource is found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				It("returns 201 with the token", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"id": 1,
+						"token": "some-token",
+						"created_at": 123
+					}`))
+				})
+
+				Context("when creating the token fails", func() {
+					BeforeEach(func() {
+						fakeResource.CreateWebhookTokenReturns(atc.WebhookToken{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook-tokens", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.WebhookTokensReturns([]atc.WebhookToken{
+				{ID: 1, CreatedAt: 123, ExpiresAt: 456, LastUsedAt: 234},
+				{ID: 2, CreatedAt: 345},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/webhook-tokens", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			It("returns the tokens without their values", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"id": 1, "created_at": 123, "expires_at": 456, "last_used_at": 234},
+					{"id": 2, "created_at": 345}
+				]`))
+			})
+
+			Context("when listing the tokens fails", func() {
+				BeforeEach(func() {
+					fakeResource.WebhookTokensReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook-tokens/rotate", func() {
+		var (
+			response     *http.Response
+			requestBody  string
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"grace_period": 60}`
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.RotateWebhookTokensReturns(atc.WebhookToken{
+				ID:        2,
+				Token:     "new-token",
+				CreatedAt: 123,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/webhook-tokens/rotate", strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			It("returns 201 with the new token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"id": 2,
+					"token": "new-token",
+					"created_at": 123
+				}`))
+			})
+
+			It("keeps the old tokens valid for the grace period", func() {
+				Expect(fakeResource.RotateWebhookTokensCallCount()).To(Equal(1))
+				Expect(fakeResource.RotateWebhookTokensArgsForCall(0)).To(Equal(time.Minute))
+			})
+
+			Context("when no grace period is given", func() {
+				BeforeEach(func() {
+					requestBody = ""
+				})
+
+				It("uses the default grace period", func() {
+					Expect(fakeResource.RotateWebhookTokensArgsForCall(0)).To(Equal(atc.DefaultWebhookTokenGracePeriod))
+				})
+			})
+
+			Context("when the grace period is given as a duration", func() {
+				BeforeEach(func() {
+					requestBody = `{"grace_period": "1h30m"}`
+				})
+
+				It("keeps the old tokens valid for the grace period", func() {
+					Expect(fakeResource.RotateWebhookTokensArgsForCall(0)).To(Equal(90 * time.Minute))
+				})
+			})
+
+			Context("when the grace period is negative", func() {
+				BeforeEach(func() {
+					requestBody = `{"grace_period": -1}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeResource.RotateWebhookTokensCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the grace period is zero", func() {
+				BeforeEach(func() {
+					requestBody = `{"grace_period": 0}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeResource.RotateWebhookTokensCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the grace period is less than a second", func() {
+				BeforeEach(func() {
+					requestBody = `{"grace_period": "500ms"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("grace_period must be at least 1s"))
+					Expect(fakeResource.RotateWebhookTokensCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the grace period is not a duration", func() {
+				BeforeEach(func() {
+					requestBody = `{"grace_period": "soon"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeResource.RotateWebhookTokensCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the grace period is a fractional number of seconds", func() {
+				BeforeEach(func() {
+					requestBody = `{"grace_period": 1.5}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeResource.RotateWebhookTokensCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when rotating the tokens fails", func() {
+				BeforeEach(func() {
+					fakeResource.RotateWebhookTokensReturns(atc.WebhookToken{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
 		})
 	})
 
@@ -3,10 +3,12 @@ package resourceserver
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -54,10 +56,21 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		if token != webhookToken {
-			logger.Info("invalid-token", lager.Data{"token": webhookToken})
-			w.WriteHeader(http.StatusUnauthorized)
-			return
+		if token == "" || token != webhookToken {
+			used, found, err := dbResource.UseWebhookToken(webhookToken)
+			if err != nil {
+				logger.Error("failed-to-use-webhook-token", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				logger.Info("invalid-token", lager.Data{"token": webhookToken})
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			s.auditor.Audit(atc.CheckResourceWebHook, fmt.Sprintf("webhook-token:%d", used.ID), r)
 		}
 
 		dbResourceTypes, err := dbPipeline.ResourceTypes()
@@ -0,0 +1,70 @@
+package resourceserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) PauseResourceChecks(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		logger := s.logger.Session("pause-resource-checks", lager.Data{
+			"resource": resourceName,
+		})
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = resource.PauseChecks()
+		if err != nil {
+			logger.Error("failed-to-pause-resource-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (s *Server) UnpauseResourceChecks(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		logger := s.logger.Session("unpause-resource-checks", lager.Data{
+			"resource": resourceName,
+		})
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = resource.UnpauseChecks()
+		if err != nil {
+			logger.Error("failed-to-unpause-resource-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
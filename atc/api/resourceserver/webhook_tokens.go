@@ -0,0 +1,151 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) CreateWebhookToken(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		logger := s.logger.Session("create-webhook-token", lager.Data{
+			"resource": resourceName,
+		})
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		token, err := resource.CreateWebhookToken()
+		if err != nil {
+			logger.Error("failed-to-create-webhook-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("created", lager.Data{"id": token.ID})
+
+		writeWebhookToken(logger, w, token)
+	})
+}
+
+func (s *Server) ListWebhookTokens(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		logger := s.logger.Session("list-webhook-tokens", lager.Data{
+			"resource": resourceName,
+		})
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		tokens, err := resource.WebhookTokens()
+		if err != nil {
+			logger.Error("failed-to-list-webhook-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(tokens)
+		if err != nil {
+			logger.Error("failed-to-encode-json", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) RotateWebhookTokens(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		logger := s.logger.Session("rotate-webhook-tokens", lager.Data{
+			"resource": resourceName,
+		})
+
+		var req atc.WebhookTokenRotationRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		gracePeriod := atc.DefaultWebhookTokenGracePeriod
+		if req.GracePeriod != nil {
+			gracePeriod = time.Duration(*req.GracePeriod)
+
+			// the old tokens expire on a whole number of seconds, so a shorter
+			// grace period would expire them immediately
+			if gracePeriod < time.Second {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, "grace_period must be at least 1s")
+				return
+			}
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		token, err := resource.RotateWebhookTokens(gracePeriod)
+		if err != nil {
+			logger.Error("failed-to-rotate-webhook-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("rotated", lager.Data{"id": token.ID, "grace-period": gracePeriod.String()})
+
+		writeWebhookToken(logger, w, token)
+	})
+}
+
+func writeWebhookToken(logger lager.Logger, w http.ResponseWriter, token atc.WebhookToken) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err := json.NewEncoder(w).Encode(token)
+	if err != nil {
+		logger.Error("failed-to-encode-json", err)
+	}
+}
@@ -0,0 +1,279 @@
+package api_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Worker Enrollment API", func() {
+	var response *http.Response
+
+	Describe("POST /api/v1/worker_enrollment_tokens", func() {
+		var request atc.WorkerEnrollmentTokenRequest
+
+		BeforeEach(func() {
+			request = atc.WorkerEnrollmentTokenRequest{
+				Tags: []string{"some-tag"},
+				TTL:  time.Minute,
+			}
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/worker_enrollment_tokens", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				dbEnrollmentFactory.CreateTokenReturns(atc.WorkerEnrollmentToken{
+					ID:        1,
+					Token:     "some-token",
+					Tags:      []string{"some-tag"},
+					ExpiresAt: 123,
+				}, nil)
+			})
+
+			It("returns 201 with the token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"id": 1,
+					"token": "some-token",
+					"tags": ["some-tag"],
+					"expires_at": 123
+				}`))
+			})
+
+			It("creates a global token", func() {
+				Expect(dbEnrollmentFactory.CreateTokenCallCount()).To(Equal(1))
+				teamID, tags, ttl := dbEnrollmentFactory.CreateTokenArgsForCall(0)
+				Expect(teamID).To(BeZero())
+				Expect(tags).To(Equal([]string{"some-tag"}))
+				Expect(ttl).To(Equal(time.Minute))
+			})
+
+			Context("when no ttl is given", func() {
+				BeforeEach(func() {
+					request.TTL = 0
+				})
+
+				It("uses the default ttl", func() {
+					_, _, ttl := dbEnrollmentFactory.CreateTokenArgsForCall(0)
+					Expect(ttl).To(Equal(atc.DefaultWorkerEnrollmentTokenTTL))
+				})
+			})
+
+			Context("when the token is restricted to a team", func() {
+				BeforeEach(func() {
+					request.Team = "some-team"
+				})
+
+				It("creates the token for the team", func() {
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+
+					teamID, _, _ := dbEnrollmentFactory.CreateTokenArgsForCall(0)
+					Expect(teamID).To(Equal(734))
+				})
+
+				Context("when the team does not exist", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindTeamReturns(nil, false, nil)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbEnrollmentFactory.CreateTokenCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when creating the token fails", func() {
+				BeforeEach(func() {
+					dbEnrollmentFactory.CreateTokenReturns(atc.WorkerEnrollmentToken{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/worker_enrollment_tokens/:token_id", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/worker_enrollment_tokens/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			Context("when the token exists", func() {
+				BeforeEach(func() {
+					dbEnrollmentFactory.DeleteTokenReturns(true, nil)
+				})
+
+				It("deletes the token", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(dbEnrollmentFactory.DeleteTokenArgsForCall(0)).To(Equal(42))
+				})
+			})
+
+			Context("when the token does not exist", func() {
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/worker_enrollments", func() {
+		var (
+			publicKey string
+			request   atc.WorkerEnrollmentRequest
+		)
+
+		BeforeEach(func() {
+			pub, _, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			sshKey, err := ssh.NewPublicKey(pub)
+			Expect(err).NotTo(HaveOccurred())
+
+			publicKey = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshKey)))
+
+			request = atc.WorkerEnrollmentRequest{
+				Token:     "some-token",
+				PublicKey: publicKey + " worker@host",
+			}
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/worker_enrollments", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the token is valid", func() {
+			BeforeEach(func() {
+				dbEnrollmentFactory.EnrollReturns(atc.EnrolledWorkerKey{
+					PublicKey: publicKey,
+					Team:      "some-team",
+				}, true, nil)
+			})
+
+			It("enrolls the key without its comment", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				token, key := dbEnrollmentFactory.EnrollArgsForCall(0)
+				Expect(token).To(Equal("some-token"))
+				Expect(key).To(Equal(publicKey))
+			})
+		})
+
+		Context("when the token is invalid", func() {
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when the public key is malformed", func() {
+			BeforeEach(func() {
+				request.PublicKey = "bogus"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbEnrollmentFactory.EnrollCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/worker_enrollments", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/worker_enrollments", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsSystemReturns(true)
+
+				dbEnrollmentFactory.EnrolledKeysReturns([]atc.EnrolledWorkerKey{
+					{PublicKey: "some-key", Team: "some-team", Tags: []string{"some-tag"}},
+				}, nil)
+			})
+
+			It("returns the enrolled keys", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[{
+					"public_key": "some-key",
+					"team": "some-team",
+					"tags": ["some-tag"]
+				}]`))
+			})
+		})
+
+		Context("when authenticated as a regular user", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+})
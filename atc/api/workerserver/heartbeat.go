@@ -57,6 +57,14 @@ func (s *Server) HeartbeatWorker(w http.ResponseWriter, r *http.Request) {
 		Tags:       registration.Tags,
 	}.Emit(s.logger)
 
+	if registration.Stats != nil {
+		metric.WorkerStats{
+			WorkerName: registration.Name,
+			Platform:   registration.Platform,
+			Stats:      *registration.Stats,
+		}.Emit(s.logger)
+	}
+
 	savedWorker, err := s.dbWorkerFactory.HeartbeatWorker(registration, ttl)
 	if err == db.ErrWorkerNotPresent {
 		logger.Error("failed-to-find-worker", err)
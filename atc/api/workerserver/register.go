@@ -85,6 +85,14 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		Platform:   registration.Platform,
 	}.Emit(s.logger)
 
+	if registration.Stats != nil {
+		metric.WorkerStats{
+			WorkerName: registration.Name,
+			Platform:   registration.Platform,
+			Stats:      *registration.Stats,
+		}.Emit(s.logger)
+	}
+
 	if registration.Team != "" {
 		team, found, err := s.teamFactory.FindTeam(registration.Team)
 		if err != nil {
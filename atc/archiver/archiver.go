@@ -0,0 +1,41 @@
+package archiver
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+type Config struct {
+	S3Bucket   string        `long:"artifact-archive-s3-bucket" description:"S3 bucket to which task outputs are uploaded when a task step sets archive_outputs."`
+	S3Prefix   string        `long:"artifact-archive-s3-prefix" description:"Prefix of the keys under which task outputs are uploaded."`
+	S3Region   string        `long:"artifact-archive-s3-region" description:"Region of the S3 bucket. Credentials are taken from the environment."`
+	S3Endpoint string        `long:"artifact-archive-s3-endpoint" description:"Endpoint of an S3-compatible store to use instead of AWS."`
+	Timeout    time.Duration `long:"artifact-archive-timeout" default:"30m" description:"Maximum duration of uploading a single artifact."`
+}
+
+func (c Config) IsConfigured() bool {
+	return c.S3Bucket != ""
+}
+
+// NewArchiver constructs the configured Archiver, or returns nil if archiving
+// is not configured.
+func (c Config) NewArchiver() (Archiver, error) {
+	if c.S3Bucket != "" {
+		return NewS3Archiver(c.S3Bucket, c.S3Prefix, c.S3Region, c.S3Endpoint, c.Timeout)
+	}
+
+	return nil, nil
+}
+
+//counterfeiter:generate . Archiver
+
+// Archiver uploads artifacts, streamed as gzipped tar archives, to an external
+// store.
+type Archiver interface {
+	// Archive uploads the archive under the given key and returns a reference
+	// from which it can be retrieved.
+	Archive(ctx context.Context, key string, tgzStream io.Reader) (string, error)
+}
@@ -0,0 +1,67 @@
+package archiver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+type s3Archiver struct {
+	bucket   string
+	prefix   string
+	timeout  time.Duration
+	uploader *s3manager.Uploader
+}
+
+// NewS3Archiver constructs an Archiver which uploads artifacts to an S3
+// bucket, using the credentials from the environment.
+func NewS3Archiver(bucket string, prefix string, region string, endpoint string, timeout time.Duration) (Archiver, error) {
+	config := aws.NewConfig()
+	if region != "" {
+		config = config.WithRegion(region)
+	}
+
+	if endpoint != "" {
+		config = config.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("create aws session: %w", err)
+	}
+
+	return s3Archiver{
+		bucket:   bucket,
+		prefix:   prefix,
+		timeout:  timeout,
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (a s3Archiver) Archive(ctx context.Context, key string, tgzStream io.Reader) (string, error) {
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+
+	key = path.Join(a.prefix, key)
+
+	_, err := a.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(a.bucket),
+		Key:         aws.String(key),
+		Body:        tgzStream,
+		ContentType: aws.String("application/gzip"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("s3://%s/%s", a.bucket, key), nil
+}
@@ -0,0 +1,75 @@
+package archiver_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc/archiver"
+)
+
+var _ = Describe("S3", func() {
+	var (
+		server *ghttp.Server
+
+		location   string
+		archiveErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		os.Setenv("AWS_ACCESS_KEY_ID", "some-access-key")
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "some-secret-key")
+	})
+
+	AfterEach(func() {
+		server.Close()
+
+		os.Unsetenv("AWS_ACCESS_KEY_ID")
+		os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+	})
+
+	JustBeforeEach(func() {
+		s3Archiver, err := archiver.NewS3Archiver("some-bucket", "some-prefix", "us-east-1", server.URL(), time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+
+		location, archiveErr = s3Archiver.Archive(context.Background(), "some/key.tgz", strings.NewReader("some-content"))
+	})
+
+	Context("when the upload succeeds", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/some-bucket/some-prefix/some/key.tgz"),
+					ghttp.VerifyHeaderKV("Content-Type", "application/gzip"),
+					ghttp.VerifyBody([]byte("some-content")),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+		})
+
+		It("returns the location of the archive", func() {
+			Expect(archiveErr).ToNot(HaveOccurred())
+			Expect(location).To(Equal("s3://some-bucket/some-prefix/some/key.tgz"))
+		})
+	})
+
+	Context("when the upload fails", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusForbidden, ""),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(archiveErr).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package artifactstore
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Artifact is an artifact kept in a Store. Its contents are downloaded from
+// the store whenever they are streamed out, e.g. to the volume of a step
+// which uses it as an input.
+type Artifact struct {
+	Store Store
+	Key   string
+}
+
+// StreamOut streams the contents under dir as a tar archive compressed with
+// the given compression, like streaming out of a volume would.
+func (artifact Artifact) StreamOut(ctx context.Context, dir string, comp compression.Compression) (io.ReadCloser, error) {
+	blob, err := artifact.Store.Get(ctx, artifact.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	gzipReader, err := gzip.NewReader(blob)
+	if err != nil {
+		blob.Close()
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		defer blob.Close()
+		writer.CloseWithError(repack(tar.NewReader(gzipReader), writer, cleanPath(dir), comp.Encoding()))
+	}()
+
+	return reader, nil
+}
+
+// repack writes the entries of the archive under dir, relative to dir, to a
+// new archive.
+func repack(src *tar.Reader, dst io.Writer, dir string, encoding baggageclaim.Encoding) error {
+	compressed, err := compressor(dst, encoding)
+	if err != nil {
+		return err
+	}
+
+	tarWriter := tar.NewWriter(compressed)
+
+	found := false
+	for {
+		header, err := src.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		name := cleanPath(header.Name)
+
+		switch {
+		case dir == ".":
+		case name == dir && header.Typeflag == tar.TypeDir:
+			name = "."
+		case name == dir:
+			name = path.Base(name)
+		case strings.HasPrefix(name, dir+"/"):
+			name = strings.TrimPrefix(name, dir+"/")
+		default:
+			continue
+		}
+
+		found = true
+
+		header.Name = name
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(tarWriter, src)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !found && dir != "." {
+		return baggageclaim.ErrFileNotFound
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return compressed.Close()
+}
+
+func compressor(dst io.Writer, encoding baggageclaim.Encoding) (io.WriteCloser, error) {
+	switch encoding {
+	case baggageclaim.GzipEncoding:
+		return gzip.NewWriter(dst), nil
+	case baggageclaim.ZstdEncoding:
+		return zstd.NewWriter(dst)
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
+
+func cleanPath(p string) string {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if p == "" {
+		return "."
+	}
+
+	return p
+}
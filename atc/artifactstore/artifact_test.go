@@ -0,0 +1,133 @@
+package artifactstore_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc/artifactstore"
+	"github.com/concourse/concourse/atc/artifactstore/artifactstorefakes"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/worker/baggageclaim"
+)
+
+var _ = Describe("Artifact", func() {
+	var (
+		fakeStore *artifactstorefakes.FakeStore
+		artifact  artifactstore.Artifact
+	)
+
+	BeforeEach(func() {
+		buf := new(bytes.Buffer)
+		gzipWriter := gzip.NewWriter(buf)
+		tarWriter := tar.NewWriter(gzipWriter)
+
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0755})).To(Succeed())
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: "./some-dir/", Typeflag: tar.TypeDir, Mode: 0755})).To(Succeed())
+		writeFile(tarWriter, "./some-dir/some-file", "some-content")
+		writeFile(tarWriter, "./other-file", "other-content")
+
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzipWriter.Close()).To(Succeed())
+
+		fakeStore = new(artifactstorefakes.FakeStore)
+		fakeStore.GetReturns(ioutil.NopCloser(buf), nil)
+
+		artifact = artifactstore.Artifact{Store: fakeStore, Key: "some-key"}
+	})
+
+	files := func(out io.ReadCloser, comp compression.Compression) map[string]string {
+		defer out.Close()
+
+		reader, err := comp.NewReader(out)
+		Expect(err).ToNot(HaveOccurred())
+
+		contents := map[string]string{}
+
+		tarReader := tar.NewReader(reader)
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+
+			content, err := ioutil.ReadAll(tarReader)
+			Expect(err).ToNot(HaveOccurred())
+
+			contents[header.Name] = string(content)
+		}
+
+		return contents
+	}
+
+	It("streams out the whole artifact from the store", func() {
+		out, err := artifact.StreamOut(context.Background(), ".", compression.NewGzipCompression())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(files(out, compression.NewGzipCompression())).To(Equal(map[string]string{
+			".":                  "",
+			"some-dir":           "",
+			"some-dir/some-file": "some-content",
+			"other-file":         "other-content",
+		}))
+
+		_, key := fakeStore.GetArgsForCall(0)
+		Expect(key).To(Equal("some-key"))
+	})
+
+	It("streams out a directory relative to the directory", func() {
+		out, err := artifact.StreamOut(context.Background(), "some-dir", compression.NewZstdCompression())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(files(out, compression.NewZstdCompression())).To(Equal(map[string]string{
+			".":         "",
+			"some-file": "some-content",
+		}))
+	})
+
+	It("streams out a single file", func() {
+		out, err := artifact.StreamOut(context.Background(), "/some-dir/some-file", compression.NewGzipCompression())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(files(out, compression.NewGzipCompression())).To(Equal(map[string]string{
+			"some-file": "some-content",
+		}))
+	})
+
+	It("fails when streaming out a path which does not exist", func() {
+		out, err := artifact.StreamOut(context.Background(), "missing", compression.NewGzipCompression())
+		Expect(err).ToNot(HaveOccurred())
+
+		defer out.Close()
+
+		_, err = ioutil.ReadAll(out)
+		Expect(err).To(Equal(baggageclaim.ErrFileNotFound))
+	})
+
+	It("fails when the artifact is not in the store", func() {
+		fakeStore.GetReturns(nil, artifactstore.ErrNotFound)
+
+		_, err := artifact.StreamOut(context.Background(), ".", compression.NewGzipCompression())
+		Expect(err).To(Equal(artifactstore.ErrNotFound))
+	})
+})
+
+func writeFile(tarWriter *tar.Writer, name string, content string) {
+	Expect(tarWriter.WriteHeader(&tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     int64(len(content)),
+	})).To(Succeed())
+
+	_, err := tarWriter.Write([]byte(content))
+	Expect(err).ToNot(HaveOccurred())
+}
@@ -0,0 +1,170 @@
+package artifactstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	azureVersion = "2020-10-02"
+
+	// AzureBlockSize is the size of the blocks in which archives are uploaded
+	// to Azure, so that they can be streamed without knowing their size up
+	// front.
+	AzureBlockSize = 4 * 1024 * 1024
+)
+
+type azureStore struct {
+	endpoint  string
+	container string
+	prefix    string
+	sasToken  string
+
+	client *http.Client
+}
+
+// NewAzureStore constructs a Store which keeps artifacts in a container of
+// an Azure storage account, authorized by a shared access signature.
+func NewAzureStore(endpoint string, container string, prefix string, sasToken string, client *http.Client) Store {
+	return azureStore{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		container: container,
+		prefix:    prefix,
+		sasToken:  strings.TrimPrefix(sasToken, "?"),
+		client:    client,
+	}
+}
+
+type azureBlockList struct {
+	XMLName xml.Name `xml:"BlockList"`
+	Latest  []string `xml:"Latest"`
+}
+
+func (s azureStore) Put(ctx context.Context, key string, tgzStream io.Reader) error {
+	blockList := azureBlockList{}
+
+	buf := make([]byte, AzureBlockSize)
+	for {
+		n, err := io.ReadFull(tgzStream, buf)
+		if n > 0 {
+			blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", len(blockList.Latest))))
+
+			err := s.do(ctx, http.MethodPut, key, url.Values{
+				"comp":    {"block"},
+				"blockid": {blockID},
+			}, nil, bytes.NewReader(buf[:n]))
+			if err != nil {
+				return fmt.Errorf("put block: %w", err)
+			}
+
+			blockList.Latest = append(blockList.Latest, blockID)
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	payload, err := xml.Marshal(blockList)
+	if err != nil {
+		return err
+	}
+
+	err = s.do(ctx, http.MethodPut, key, url.Values{
+		"comp": {"blocklist"},
+	}, http.Header{
+		"X-Ms-Blob-Content-Type": {"application/gzip"},
+	}, bytes.NewReader(append([]byte(xml.Header), payload...)))
+	if err != nil {
+		return fmt.Errorf("put block list: %w", err)
+	}
+
+	return nil
+}
+
+func (s azureStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer res.Body.Close()
+		return nil, azureError(res)
+	}
+}
+
+func (s azureStore) do(ctx context.Context, method string, key string, query url.Values, header http.Header, body io.Reader) error {
+	req, err := s.request(ctx, method, key, query, header, body)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return azureError(res)
+	}
+
+	return nil
+}
+
+func (s azureStore) request(ctx context.Context, method string, key string, query url.Values, header http.Header, body io.Reader) (*http.Request, error) {
+	rawQuery := s.sasToken
+	if len(query) > 0 {
+		if rawQuery != "" {
+			rawQuery += "&"
+		}
+
+		rawQuery += query.Encode()
+	}
+
+	blobURL := fmt.Sprintf("%s/%s/%s?%s", s.endpoint, s.container, path.Join(s.prefix, key), rawQuery)
+
+	req, err := http.NewRequestWithContext(ctx, method, blobURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	req.Header.Set("X-Ms-Version", azureVersion)
+
+	return req, nil
+}
+
+func azureError(res *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("unexpected response from azure: %s: %s", res.Status, strings.TrimSpace(string(body)))
+}
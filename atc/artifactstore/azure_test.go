@@ -0,0 +1,128 @@
+package artifactstore_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc/artifactstore"
+)
+
+var _ = Describe("Azure", func() {
+	var (
+		server *ghttp.Server
+		store  artifactstore.Store
+	)
+
+	blockID := func(i string) string {
+		return base64.StdEncoding.EncodeToString([]byte(i))
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		store = artifactstore.NewAzureStore(server.URL(), "some-container", "some-prefix", "?sv=some-sig", http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Put", func() {
+		var (
+			content string
+			putErr  error
+		)
+
+		BeforeEach(func() {
+			content = "some-content"
+		})
+
+		JustBeforeEach(func() {
+			putErr = store.Put(context.Background(), "some/key.tgz", strings.NewReader(content))
+		})
+
+		Context("when the upload succeeds", func() {
+			BeforeEach(func() {
+				content = strings.Repeat("x", artifactstore.AzureBlockSize) + "y"
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/some-container/some-prefix/some/key.tgz", "sv=some-sig&blockid="+blockID("00000000")+"&comp=block"),
+						ghttp.VerifyHeaderKV("X-Ms-Version", "2020-10-02"),
+						func(w http.ResponseWriter, r *http.Request) {
+							body, err := ioutil.ReadAll(r.Body)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(body).To(HaveLen(artifactstore.AzureBlockSize))
+						},
+						ghttp.RespondWith(http.StatusCreated, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/some-container/some-prefix/some/key.tgz", "sv=some-sig&blockid="+blockID("00000001")+"&comp=block"),
+						ghttp.VerifyBody([]byte("y")),
+						ghttp.RespondWith(http.StatusCreated, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/some-container/some-prefix/some/key.tgz", "sv=some-sig&comp=blocklist"),
+						ghttp.VerifyHeaderKV("X-Ms-Blob-Content-Type", "application/gzip"),
+						func(w http.ResponseWriter, r *http.Request) {
+							body, err := ioutil.ReadAll(r.Body)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(bytes.Contains(body, []byte("<BlockList><Latest>"+blockID("00000000")+"</Latest><Latest>"+blockID("00000001")+"</Latest></BlockList>"))).To(BeTrue())
+						},
+						ghttp.RespondWith(http.StatusCreated, ""),
+					),
+				)
+			})
+
+			It("uploads the archive in blocks", func() {
+				Expect(putErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+
+		Context("when uploading a block fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusForbidden, "nope"),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(putErr).To(MatchError(ContainSubstring("403 Forbidden: nope")))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		It("streams the archive", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/some-container/some-prefix/some/key.tgz", "sv=some-sig"),
+					ghttp.RespondWith(http.StatusOK, "some-content"),
+				),
+			)
+
+			blob, err := store.Get(context.Background(), "some/key.tgz")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer blob.Close()
+			Expect(ioutil.ReadAll(blob)).To(Equal([]byte("some-content")))
+		})
+
+		It("returns ErrNotFound when there is no such archive", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, ""),
+			)
+
+			_, err := store.Get(context.Background(), "some/key.tgz")
+			Expect(err).To(Equal(artifactstore.ErrNotFound))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package artifactstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+type s3Store struct {
+	bucket   string
+	prefix   string
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+// NewS3Store constructs a Store which keeps artifacts in an S3 bucket, or in a
+// bucket of any S3-compatible store. If no credentials are given, they are
+// taken from the environment.
+func NewS3Store(bucket string, prefix string, region string, endpoint string, creds *credentials.Credentials) (Store, error) {
+	config := aws.NewConfig()
+	if region != "" {
+		config = config.WithRegion(region)
+	}
+
+	if endpoint != "" {
+		config = config.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	if creds != nil {
+		config = config.WithCredentials(creds)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("create aws session: %w", err)
+	}
+
+	return s3Store{
+		bucket:   bucket,
+		prefix:   prefix,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (s s3Store) Put(ctx context.Context, key string, tgzStream io.Reader) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(path.Join(s.prefix, key)),
+		Body:        tgzStream,
+		ContentType: aws.String("application/gzip"),
+	})
+
+	return err
+}
+
+func (s s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path.Join(s.prefix, key)),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return output.Body, nil
+}
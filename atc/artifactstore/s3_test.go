@@ -0,0 +1,103 @@
+package artifactstore_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc/artifactstore"
+)
+
+var _ = Describe("S3", func() {
+	var (
+		server *ghttp.Server
+		store  artifactstore.Store
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		os.Setenv("AWS_ACCESS_KEY_ID", "some-access-key")
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "some-secret-key")
+
+		var err error
+		store, err = artifactstore.NewS3Store("some-bucket", "some-prefix", "us-east-1", server.URL(), nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+
+		os.Unsetenv("AWS_ACCESS_KEY_ID")
+		os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+	})
+
+	Describe("Put", func() {
+		var putErr error
+
+		JustBeforeEach(func() {
+			putErr = store.Put(context.Background(), "some/key.tgz", strings.NewReader("some-content"))
+		})
+
+		Context("when the upload succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/some-bucket/some-prefix/some/key.tgz"),
+						ghttp.VerifyHeaderKV("Content-Type", "application/gzip"),
+						ghttp.VerifyBody([]byte("some-content")),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				Expect(putErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the upload fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusForbidden, ""),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(putErr).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		It("streams the archive", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/some-bucket/some-prefix/some/key.tgz"),
+					ghttp.RespondWith(http.StatusOK, "some-content"),
+				),
+			)
+
+			blob, err := store.Get(context.Background(), "some/key.tgz")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer blob.Close()
+			Expect(ioutil.ReadAll(blob)).To(Equal([]byte("some-content")))
+		})
+
+		It("returns ErrNotFound when there is no such archive", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code></Error>`),
+			)
+
+			_, err := store.Get(context.Background(), "some/key.tgz")
+			Expect(err).To(Equal(artifactstore.ErrNotFound))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package artifactstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+const (
+	DriverS3    = "s3"
+	DriverGCS   = "gcs"
+	DriverAzure = "azure"
+)
+
+// GCSEndpoint is the endpoint of the S3-compatible XML API of Google Cloud
+// Storage, which is used with HMAC keys.
+const GCSEndpoint = "https://storage.googleapis.com"
+
+var ErrNotFound = errors.New("artifact not found in store")
+
+type Config struct {
+	Driver string `long:"artifact-store" choice:"s3" choice:"gcs" choice:"azure" description:"External blob store in which to keep artifacts uploaded by fly execute and produced by artifact outputs, so that they outlive the worker volumes they were created on. Artifacts are removed from the database after 12 hours, so configure the bucket to expire them as well."`
+	Bucket string `long:"artifact-store-bucket" description:"Bucket (or Azure container) in which to keep artifacts."`
+	Prefix string `long:"artifact-store-prefix" description:"Prefix of the keys under which artifacts are kept."`
+
+	S3Region   string `long:"artifact-store-s3-region" description:"Region of the S3 bucket. Credentials are taken from the environment."`
+	S3Endpoint string `long:"artifact-store-s3-endpoint" description:"Endpoint of an S3-compatible store to use instead of AWS."`
+
+	GCSAccessID string `long:"artifact-store-gcs-access-id" description:"Access ID of the HMAC key used to access the GCS bucket."`
+	GCSSecret   string `long:"artifact-store-gcs-secret" description:"Secret of the HMAC key used to access the GCS bucket."`
+
+	AzureAccount  string `long:"artifact-store-azure-account" description:"Name of the Azure storage account."`
+	AzureSASToken string `long:"artifact-store-azure-sas-token" description:"Shared access signature granting read and write access to the Azure container."`
+	AzureEndpoint string `long:"artifact-store-azure-endpoint" description:"Endpoint of the Azure blob service to use instead of the storage account's default."`
+}
+
+func (c Config) IsConfigured() bool {
+	return c.Driver != ""
+}
+
+// NewStore constructs the configured Store, or returns nil if no store is
+// configured.
+func (c Config) NewStore() (Store, error) {
+	if c.Driver != "" && c.Bucket == "" {
+		return nil, errors.New("artifact store bucket must be configured")
+	}
+
+	switch c.Driver {
+	case "":
+		return nil, nil
+	case DriverS3:
+		return NewS3Store(c.Bucket, c.Prefix, c.S3Region, c.S3Endpoint, nil)
+	case DriverGCS:
+		return NewS3Store(c.Bucket, c.Prefix, "auto", GCSEndpoint, credentials.NewStaticCredentials(c.GCSAccessID, c.GCSSecret, ""))
+	case DriverAzure:
+		endpoint := c.AzureEndpoint
+		if endpoint == "" {
+			if c.AzureAccount == "" {
+				return nil, errors.New("azure storage account must be configured")
+			}
+
+			endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", c.AzureAccount)
+		}
+
+		return NewAzureStore(endpoint, c.Bucket, c.Prefix, c.AzureSASToken, http.DefaultClient), nil
+	default:
+		return nil, fmt.Errorf("unknown artifact store: %s", c.Driver)
+	}
+}
+
+//counterfeiter:generate . Store
+
+// Store keeps artifacts, streamed as gzipped tar archives, in an external
+// blob store.
+type Store interface {
+	// Put uploads the archive under the given key.
+	Put(ctx context.Context, key string, tgzStream io.Reader) error
+
+	// Get downloads the archive kept under the given key. If there is no such
+	// archive, ErrNotFound is returned.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// NewKey generates a unique key under which to keep an artifact.
+func NewKey() (string, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("artifacts/%s.tgz", id), nil
+}
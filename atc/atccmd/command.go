@@ -2,6 +2,7 @@ package atccmd
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
@@ -28,10 +29,13 @@ import (
 	"github.com/concourse/concourse/atc/api/containerserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/policychecker"
+	"github.com/concourse/concourse/atc/archiver"
+	"github.com/concourse/concourse/atc/artifactstore"
 	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/component"
 	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/cosign"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
@@ -39,10 +43,16 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration"
 	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
+	"github.com/concourse/concourse/atc/mainframe"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/preemption"
+	"github.com/concourse/concourse/atc/prewarm"
+	"github.com/concourse/concourse/atc/replication"
+	"github.com/concourse/concourse/atc/scanner"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/algorithm"
 	"github.com/concourse/concourse/atc/syslog"
@@ -56,6 +66,7 @@ import (
 	"github.com/concourse/concourse/skymarshal/token"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/web"
+	"github.com/concourse/concourse/worker/baggageclaim"
 	"github.com/concourse/flag"
 	"gopkg.in/square/go-jose.v2/jwt"
 
@@ -143,17 +154,36 @@ type RunCommand struct {
 
 	ComponentRunnerInterval time.Duration `long:"component-runner-interval" default:"10s" description:"Interval on which runners are kicked off for builds, locks, scans, and checks"`
 
-	LidarScannerInterval time.Duration `long:"lidar-scanner-interval" default:"10s" description:"Interval on which the resource scanner will run to see if new checks need to be scheduled"`
+	LidarScannerInterval  time.Duration `long:"lidar-scanner-interval" default:"10s" description:"Interval on which the resource scanner will run to see if new checks need to be scheduled"`
+	LidarFullScanInterval time.Duration `long:"lidar-full-scan-interval" default:"5m" description:"Interval on which the resource scanner will look at every resource, rather than only those which are due to be checked or have changed. Set to 0 to look at every resource on each run."`
+
+	StepMetadataEnv []string `long:"step-metadata-env" description:"Name of a build metadata environment variable (e.g. BUILD_ID, BUILD_PIPELINE_INSTANCE_VARS) to expose to the containers of get, put, check and task steps. Can be specified multiple times. All of them are exposed if none are specified."`
+
+	NotifyAllowedHosts []string `long:"notify-allowed-host" description:"Host which notify steps may post to. Can be specified multiple times. If none are specified, notify steps may post to any host which does not resolve to a private, loopback or link-local address."`
+
+	SetPipelineAcrossTeams []string `long:"set-pipeline-across-teams" description:"Allow set_pipeline steps in builds of one team to set pipelines in another team, given as SOURCE:TARGET. Can be specified multiple times. If a policy agent checks the SetPipelineAcrossTeams action it may still deny an allowed pair."`
 
 	GlobalResourceCheckTimeout          time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval            time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources that has webhook defined."`
 	MaxChecksPerSecond                  int           `long:"max-checks-per-second" description:"Maximum number of checks that can be started per second. If not specified, this will be calculated as (# of resources)/(resource checking interval). -1 value will remove this maximum limit of checks per second."`
 
+	DefaultTeamMaxChecksPerSecond float64            `long:"default-team-max-checks-per-second" description:"Maximum number of periodic checks each team's resources may start per second, in addition to --max-checks-per-second. 0 means unlimited."`
+	TeamMaxChecksPerSecond        map[string]float64 `long:"team-max-checks-per-second" description:"Maximum number of periodic checks the given team's resources may start per second, overriding the default. Can be specified multiple times." value-name:"TEAM:RATE"`
+
+	MaxConcurrentChecksPerPipeline int `long:"max-concurrent-checks-per-pipeline" description:"Maximum number of periodic checks of a single pipeline's resources which may be running at once. 0 means unlimited."`
+
+	MinimumResourceCheckingInterval time.Duration            `long:"minimum-resource-checking-interval" description:"Minimum interval on which any resource may be checked. Shorter check_every intervals configured by pipelines are raised to it."`
+	TeamResourceCheckingIntervals   map[string]time.Duration `long:"team-resource-checking-interval" description:"Interval on which to check resources of the given team which do not configure check_every, in place of --resource-checking-interval. Can be specified multiple times." value-name:"TEAM:DURATION"`
+	ResourceCheckingJitter          time.Duration            `long:"resource-checking-jitter" description:"Longest random delay added to the interval of each periodic resource check, so that resources with the same interval don't all check at the same time."`
+	BaseResourceTypeCheckTimeouts   map[string]time.Duration `long:"base-resource-type-check-timeout" description:"Time limit on checking for new versions of resources of the given base resource type, in place of --global-resource-check-timeout. Overridden by check_timeout in pipeline configs. Can be specified multiple times." value-name:"TYPE:DURATION"`
+
 	ContainerPlacementStrategyOptions worker.PlacementOptions `group:"Container Placement Strategy"`
 
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 	StreamingArtifactsCompression     string        `long:"streaming-artifacts-compression" default:"gzip" choice:"gzip" choice:"zstd" description:"Compression algorithm for internal streaming."`
+	StreamingArtifactsZstdLevel       int           `long:"streaming-artifacts-zstd-level" description:"Level (1-22) of zstd compression for internal streaming. Higher levels trade worker CPU for less network traffic. Defaults to zstd's default level."`
+	StreamingBandwidthLimit           int           `long:"streaming-bandwidth-limit" description:"Maximum rate, in bytes per second, at which each volume is streamed from one worker to another. 0 means no limit. Workers can also limit the rate across all of their streams."`
 
 	GardenRequestTimeout time.Duration `long:"garden-request-timeout" default:"5m" description:"How long to wait for requests to Garden to complete. 0 means no timeout."`
 
@@ -165,6 +195,7 @@ type RunCommand struct {
 		Attributes          map[string]string `long:"metrics-attribute" description:"A key-value attribute to attach to emitted metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
 		BufferSize          uint32            `long:"metrics-buffer-size" default:"1000" description:"The size of the buffer used in emitting event metrics."`
 		CaptureErrorMetrics bool              `long:"capture-error-metrics" description:"Enable capturing of error log metrics"`
+		BuildTraceDir       flag.Dir          `long:"build-trace-dir" description:"Directory in which to record a trace of each build's var lookups and artifact registrations, for replaying with 'concourse replay-build-trace'. Credential values are not recorded when secret redaction is enabled."`
 	} `group:"Metrics & Diagnostics"`
 
 	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
@@ -173,6 +204,14 @@ type RunCommand struct {
 		Filter policy.Filter
 	} `group:"Policy Checking"`
 
+	ArtifactScanning scanner.Config `group:"Artifact Scanning"`
+
+	ImageSignatures cosign.Config `group:"Image Signature Verification"`
+
+	ArtifactArchive archiver.Config `group:"Artifact Archive"`
+
+	ArtifactStore artifactstore.Config `group:"Artifact Store"`
+
 	Server struct {
 		XFrameOptions         string `long:"x-frame-options" default:"deny" description:"The value to set for the X-Frame-Options header."`
 		ContentSecurityPolicy string `long:"content-security-policy" default:"frame-ancestors 'none'" description:"The value to set for the Content-Security-Policy header."`
@@ -193,10 +232,25 @@ type RunCommand struct {
 		FailedGracePeriod      time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
 		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
 		VarSourceRecyclePeriod time.Duration `long:"var-source-recycle-period" default:"5m" description:"Period after which to reap var_sources that are not used."`
+
+		CheckBuildsToRetain       int           `long:"check-builds-to-retain" default:"1" description:"Number of completed check builds to keep for each resource and resource type, along with their events."`
+		CheckBuildRetentionPeriod time.Duration `long:"check-build-retention-period" description:"Period after which completed check builds are deleted even if within --gc-check-builds-to-retain. The latest check build is always kept. 0 means unlimited."`
+
+		DefaultTeamCacheQuota int            `long:"default-team-cache-quota" description:"Maximum number of resource caches, including image caches, each team may keep on a single worker. The least recently used caches beyond the quota are evicted. 0 means unlimited."`
+		TeamCacheQuotas       map[string]int `long:"team-cache-quota" description:"Maximum number of resource caches the given team may keep on a single worker, overriding the default. Can be specified multiple times." value-name:"TEAM:COUNT"`
+
+		WorkerResourceCacheSizeLimit string `long:"worker-resource-cache-size-limit" description:"Maximum total size of the resource caches, including image caches, kept on a single worker, e.g. 100GB. The least recently used caches beyond the limit are evicted, unless they are in use. 0 means unlimited."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
+	DefaultTeamMaxRunningBuilds int            `long:"default-team-max-running-builds" description:"Maximum number of builds each team may have running at the same time. Builds started beyond the limit are queued until one of the team's builds finishes. 0 means unlimited."`
+	TeamMaxRunningBuilds        map[string]int `long:"team-max-running-builds" description:"Maximum number of builds the given team may have running at the same time, overriding the default. Can be specified multiple times." value-name:"TEAM:COUNT"`
+
+	DefaultHookTimeout time.Duration `long:"default-hook-timeout" default:"1h" description:"Maximum duration of step hooks (ensure, on_failure, etc.) that do not configure their own timeout. 0 means unlimited."`
+
+	MaxStepReschedules int `long:"max-step-reschedules" default:"0" description:"Number of times a get, check or task step is re-run from the start on another worker when the worker running it is retired, landed or lost, instead of failing the build. 0 disables rescheduling."`
+
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 
 	DefaultBuildLogsToRetain uint64 `long:"default-build-logs-to-retain" description:"Default build logs to retain, 0 means all"`
@@ -205,11 +259,15 @@ type RunCommand struct {
 	DefaultDaysToRetainBuildLogs uint64 `long:"default-days-to-retain-build-logs" description:"Default days to retain build logs. 0 means unlimited"`
 	MaxDaysToRetainBuildLogs     uint64 `long:"max-days-to-retain-build-logs" description:"Maximum days to retain build logs, 0 means not specified. Will override values configured in jobs"`
 
-	JobSchedulingMaxInFlight uint64 `long:"job-scheduling-max-in-flight" default:"32" description:"Maximum number of jobs to be scheduling at the same time"`
+	JobSchedulingMaxInFlight uint64         `long:"job-scheduling-max-in-flight" default:"32" description:"Maximum number of jobs to be scheduling at the same time"`
+	InstanceGroupMaxInFlight map[string]int `long:"instance-group-max-in-flight" description:"Maximum number of builds to run at the same time across all instances of the given instance group. Builds are admitted round-robin across the instances with pending builds. Can be specified multiple times." value-name:"TEAM/GROUP:LIMIT"`
 
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
 
+	OutputVolumeQuota string `long:"output-volume-quota" description:"Maximum size of each volume created for the outputs of tasks, e.g. 10GB. Only enforced on workers whose volume driver supports quotas. 0 means unlimited"`
+	CacheVolumeQuota  string `long:"cache-volume-quota"  description:"Maximum size of each volume created for the caches of tasks, e.g. 10GB. Only enforced on workers whose volume driver supports quotas. 0 means unlimited"`
+
 	Auditor struct {
 		EnableBuildAuditLog     bool `long:"enable-build-auditing" description:"Enable auditing for all api requests connected to builds."`
 		EnableContainerAuditLog bool `long:"enable-container-auditing" description:"Enable auditing for all api requests connected to containers."`
@@ -230,6 +288,34 @@ type RunCommand struct {
 		CACerts       []string      `long:"syslog-ca-cert"              description:"Paths to PEM-encoded CA cert files to use to verify the Syslog server SSL cert."`
 	} ` group:"Syslog Drainer Configuration"`
 
+	Prewarm struct {
+		Interval            time.Duration `long:"prewarm-interval" description:"Interval on which to import the most used base resource types and task images onto idle workers ahead of builds needing them. Disabled if not specified."`
+		Images              int           `long:"prewarm-images" default:"10" description:"Number of most used task images to prewarm."`
+		BaseResourceTypes   int           `long:"prewarm-base-resource-types" default:"5" description:"Number of most used base resource types to prewarm."`
+		UsageWindow         time.Duration `long:"prewarm-usage-window" default:"24h" description:"How far back to look at builds when ranking task images by usage."`
+		MaxActiveContainers int           `long:"prewarm-max-active-containers" default:"0" description:"Workers with more active containers than this are not considered idle, and are not prewarmed."`
+	} `group:"Image Prewarming"`
+
+	Replication struct {
+		Interval time.Duration `long:"resource-cache-replication-interval" description:"Interval on which to replicate the resource caches fetched by get steps to other workers their builds may run on. Disabled if not specified."`
+		Factor   int           `long:"resource-cache-replication-factor" default:"2" description:"Number of compatible workers each recently fetched resource cache is replicated to."`
+		Window   time.Duration `long:"resource-cache-replication-window" default:"1h" description:"How long a resource cache is replicated for after it was last fetched."`
+	} `group:"Resource Cache Replication"`
+
+	Preemption struct {
+		Threshold time.Duration `long:"build-preemption-threshold" description:"How long a pending job build may wait before a running build of a lower-priority job in the same team is aborted and re-queued to make room for it. Disabled if not specified."`
+		Interval  time.Duration `long:"build-preemption-interval" default:"30s" description:"Interval on which to look for builds to preempt."`
+	} `group:"Build Preemption"`
+
+	Mainframe struct {
+		ConfigDir flag.Dir      `long:"mainframe-config-dir" description:"Directory containing the teams and seed pipelines of the install. They are reconciled at startup and whenever the directory changes."`
+		GitURI    string        `long:"mainframe-git-uri" description:"Git repository to clone the teams and seed pipelines from, instead of a local directory."`
+		GitBranch string        `long:"mainframe-git-branch" default:"master" description:"Branch of the git repository to clone."`
+		GitPath   string        `long:"mainframe-git-path" description:"Directory within the git repository containing the configuration."`
+		PublicKey flag.File     `long:"mainframe-public-key" description:"File containing a PEM-encoded ed25519 public key. If set, the configuration is only applied if it carries a valid signature made with the matching private key."`
+		Interval  time.Duration `long:"mainframe-interval" default:"1m" description:"Interval on which to check the configuration for changes."`
+	} `group:"Declarative Configuration"`
+
 	Auth struct {
 		AuthFlags     skycmd.AuthFlags
 		MainTeamFlags skycmd.AuthTeamFlags `group:"Authentication (Main Team)" namespace:"main-team"`
@@ -247,10 +333,14 @@ type RunCommand struct {
 		EnableAcrossStep                     bool `long:"enable-across-step" description:"Enable the experimental across step to be used in jobs. The API is subject to change."`
 		EnablePipelineInstances              bool `long:"enable-pipeline-instances" description:"Enable pipeline instances"`
 		EnableP2PVolumeStreaming             bool `long:"enable-p2p-volume-streaming" description:"Enable P2P volume streaming. NOTE: All workers must be on the same LAN network"`
+		EnableDeltaVolumeStreaming           bool `long:"enable-delta-volume-streaming" description:"Stream resource caches as the difference from a previous version of the resource cached on the destination worker, when there is one."`
 		EnableCacheStreamedVolumes           bool `long:"enable-cache-streamed-volumes" description:"When enabled, streamed resource volumes will be cached on the destination worker."`
 		EnableResourceCausality              bool `long:"enable-resource-causality" description:"Enable the resource causality page. Computing causality can be expensive for the database. "`
+		EnablePersistentResourceCaches       bool `long:"enable-persistent-resource-caches" description:"Persist fetched resource caches to the object store of the worker's baggageclaim, and hydrate them from there rather than fetching or streaming them again. Workers must be configured with an S3 bucket to persist volumes in."`
 	} `group:"Feature Flags"`
 
+	TeamsWithUniqueVersionHistory []string `long:"team-with-unique-version-history" description:"Never share check results or version history between the given team's resources and identical resources elsewhere, even with global resources enabled. Can be specified multiple times." value-name:"TEAM"`
+
 	BaseResourceTypeDefaults flag.File `long:"base-resource-type-defaults" description:"Base resource type defaults"`
 
 	P2pVolumeStreamingTimeout time.Duration `long:"p2p-volume-streaming-timeout" description:"Timeout value of p2p volume streaming" default:"15m"`
@@ -521,8 +611,28 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.EnablePipelineInstances = cmd.FeatureFlags.EnablePipelineInstances
 	atc.EnableCacheStreamedVolumes = cmd.FeatureFlags.EnableCacheStreamedVolumes
 	atc.EnableResourceCausality = cmd.FeatureFlags.EnableResourceCausality
+	atc.EnablePersistentResourceCaches = cmd.FeatureFlags.EnablePersistentResourceCaches
 	atc.DefaultCheckInterval = cmd.ResourceCheckingInterval
 	atc.DefaultWebhookInterval = cmd.ResourceWithWebhookCheckingInterval
+	atc.MinimumCheckInterval = cmd.MinimumResourceCheckingInterval
+	atc.TeamDefaultCheckIntervals = cmd.TeamResourceCheckingIntervals
+	atc.CheckIntervalJitter = cmd.ResourceCheckingJitter
+	atc.BaseResourceTypeCheckTimeouts = cmd.BaseResourceTypeCheckTimeouts
+	atc.InstanceGroupMaxInFlight = cmd.InstanceGroupMaxInFlight
+	atc.DefaultHookTimeout = cmd.DefaultHookTimeout
+	atc.MaxStepReschedules = cmd.MaxStepReschedules
+
+	if cmd.Replication.Interval > 0 {
+		atc.ResourceCacheReplicationFactor = cmd.Replication.Factor
+	}
+
+	if err := cmd.setVolumeQuotas(); err != nil {
+		return nil, err
+	}
+
+	for _, team := range cmd.TeamsWithUniqueVersionHistory {
+		atc.TeamsWithUniqueVersionHistory[team] = true
+	}
 
 	if cmd.BaseResourceTypeDefaults.Path() != "" {
 		content, err := ioutil.ReadFile(cmd.BaseResourceTypeDefaults.Path())
@@ -798,6 +908,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
 	dbClock := db.NewClock()
 	dbWall := db.NewWall(dbConn, &dbClock)
+	dbWorkerEnrollmentFactory := db.NewWorkerEnrollmentFactory(dbConn, &dbClock)
 
 	tokenVerifier := cmd.constructTokenVerifier(dbAccessTokenFactory)
 
@@ -845,6 +956,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		credsManagers,
 		accessFactory,
 		dbWall,
+		dbWorkerEnrollmentFactory,
 		policyChecker,
 	)
 	if err != nil {
@@ -1025,6 +1137,21 @@ func (cmd *RunCommand) backendComponents(
 		clock.NewClock(),
 	)
 
+	artifactScanner, err := cmd.ArtifactScanning.NewScanner()
+	if err != nil {
+		return nil, err
+	}
+
+	artifactArchiver, err := cmd.ArtifactArchive.NewArchiver()
+	if err != nil {
+		return nil, err
+	}
+
+	artifactStore, err := cmd.ArtifactStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
 	engine := cmd.constructEngine(
 		pool,
 		dbWorkerFactory,
@@ -1037,7 +1164,11 @@ func (cmd *RunCommand) backendComponents(
 		buildContainerStrategy,
 		lockFactory,
 		rateLimiter,
+		cmd.teamRateLimiter(),
 		policyChecker,
+		artifactScanner,
+		artifactArchiver,
+		artifactStore,
 	)
 
 	// In case that a user configures resource-checking-interval, but forgets to
@@ -1054,6 +1185,12 @@ func (cmd *RunCommand) backendComponents(
 		cmd.ResourceWithWebhookCheckingInterval = cmd.ResourceCheckingInterval
 	}
 
+	// notified of changes to resources by the resources_upsert_trigger
+	scannerNotifications, err := dbConn.Bus().Listen(atc.ComponentLidarScanner, 4096)
+	if err != nil {
+		return nil, err
+	}
+
 	components := []RunnableComponent{
 		{
 			Component: atc.Component{
@@ -1063,6 +1200,9 @@ func (cmd *RunCommand) backendComponents(
 			Runnable: lidar.NewScanner(
 				dbCheckFactory,
 				atc.NewPlanFactory(time.Now().Unix()),
+				cmd.MaxConcurrentChecksPerPipeline,
+				scannerNotifications,
+				cmd.LidarFullScanInterval,
 			),
 		},
 		{
@@ -1087,7 +1227,10 @@ func (cmd *RunCommand) backendComponents(
 				Name:     atc.ComponentBuildTracker,
 				Interval: cmd.BuildTrackerInterval,
 			},
-			Runnable: builds.NewTracker(dbBuildFactory, engine),
+			Runnable: builds.NewTracker(dbBuildFactory, engine, builds.TeamLimits{
+				Default: cmd.DefaultTeamMaxRunningBuilds,
+				Teams:   cmd.TeamMaxRunningBuilds,
+			}),
 		},
 		{
 			Component: atc.Component{
@@ -1125,9 +1268,100 @@ func (cmd *RunCommand) backendComponents(
 		})
 	}
 
+	if cmd.Mainframe.ConfigDir != "" || cmd.Mainframe.GitURI != "" {
+		reconciler, err := cmd.constructMainframeReconciler(teamFactory)
+		if err != nil {
+			return nil, err
+		}
+
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentMainframe,
+				Interval: cmd.Mainframe.Interval,
+			},
+			Runnable: reconciler,
+		})
+	}
+
+	if cmd.Prewarm.Interval > 0 {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentPrewarmer,
+				Interval: cmd.Prewarm.Interval,
+			},
+			Runnable: prewarm.NewPrewarmer(
+				db.NewPrewarmFactory(dbConn, lockFactory),
+				dbWorkerFactory,
+				pool,
+				cmd.streamer(dbResourceCacheFactory),
+				prewarm.Config{
+					Images:              cmd.Prewarm.Images,
+					BaseResourceTypes:   cmd.Prewarm.BaseResourceTypes,
+					UsageWindow:         cmd.Prewarm.UsageWindow,
+					MaxActiveContainers: cmd.Prewarm.MaxActiveContainers,
+				},
+			),
+		})
+	}
+
+	if cmd.Replication.Interval > 0 {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentResourceCacheReplicator,
+				Interval: cmd.Replication.Interval,
+			},
+			Runnable: replication.NewReplicator(
+				db.NewReplicationFactory(dbConn, lockFactory),
+				pool,
+				cmd.streamer(dbResourceCacheFactory),
+				replication.Config{
+					Factor: cmd.Replication.Factor,
+					Window: cmd.Replication.Window,
+				},
+			),
+		})
+	}
+
+	if cmd.Preemption.Threshold > 0 {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentBuildPreemptor,
+				Interval: cmd.Preemption.Interval,
+			},
+			Runnable: preemption.NewPreemptor(
+				db.NewPreemptionFactory(dbConn, lockFactory),
+				cmd.Preemption.Threshold,
+			),
+		})
+	}
+
 	return components, err
 }
 
+func (cmd *RunCommand) constructMainframeReconciler(teamFactory db.TeamFactory) (*mainframe.Reconciler, error) {
+	var source mainframe.Source
+	if cmd.Mainframe.GitURI != "" {
+		source = mainframe.NewGitSource(cmd.Mainframe.GitURI, cmd.Mainframe.GitBranch, cmd.Mainframe.GitPath)
+	} else {
+		source = mainframe.NewDirSource(cmd.Mainframe.ConfigDir.Path())
+	}
+
+	var publicKey ed25519.PublicKey
+	if cmd.Mainframe.PublicKey != "" {
+		payload, err := ioutil.ReadFile(cmd.Mainframe.PublicKey.Path())
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey, err = mainframe.ParsePublicKey(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mainframe public key: %w", err)
+		}
+	}
+
+	return mainframe.NewReconciler(source, publicKey, teamFactory), nil
+}
+
 func (cmd *RunCommand) compression() compression.Compression {
 	if cmd.StreamingArtifactsCompression == "zstd" {
 		return compression.NewZstdCompression()
@@ -1140,6 +1374,10 @@ func (cmd *RunCommand) streamer(cacheFactory db.ResourceCacheFactory) worker.Str
 	return worker.NewStreamer(cacheFactory, cmd.compression(), worker.P2PConfig{
 		Enabled: cmd.FeatureFlags.EnableP2PVolumeStreaming,
 		Timeout: cmd.P2pVolumeStreamingTimeout,
+	}, worker.DeltaConfig{
+		Enabled: cmd.FeatureFlags.EnableDeltaVolumeStreaming,
+	}, worker.BandwidthConfig{
+		Limit: cmd.StreamingBandwidthLimit,
 	})
 }
 
@@ -1157,6 +1395,11 @@ func (cmd *RunCommand) constructPool(dbConn db.Conn, lockFactory lock.LockFactor
 		return worker.Pool{}, err
 	}
 
+	imageVerifier, err := cmd.ImageSignatures.NewVerifier()
+	if err != nil {
+		return worker.Pool{}, err
+	}
+
 	db := worker.NewDB(
 		dbWorkerFactory,
 		dbTeamFactory,
@@ -1174,7 +1417,10 @@ func (cmd *RunCommand) constructPool(dbConn db.Conn, lockFactory lock.LockFactor
 			GardenRequestTimeout:              cmd.GardenRequestTimeout,
 			BaggageclaimResponseHeaderTimeout: cmd.BaggageclaimResponseHeaderTimeout,
 			HTTPRetryTimeout:                  5 * time.Minute,
+			StreamingZstdLevel:                cmd.StreamingArtifactsZstdLevel,
+			StreamingBandwidthLimit:           cmd.StreamingBandwidthLimit,
 			Streamer:                          cmd.streamer(dbResourceCacheFactory),
+			ImageVerifier:                     imageVerifier,
 		},
 		db,
 		workerVersion,
@@ -1220,7 +1466,9 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorCheckSessions:     gc.NewResourceConfigCheckSessionCollector(resourceConfigCheckSessionLifecycle),
 		atc.ComponentCollectorPipelines:         gc.NewPipelineCollector(dbPipelineLifecycle),
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
-		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle),
+		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle, db.CheckBuildRetention{Count: cmd.GC.CheckBuildsToRetain, MaxAge: cmd.GC.CheckBuildRetentionPeriod}),
+		atc.ComponentCollectorTeamCacheQuotas:   gc.NewTeamCacheQuotaCollector(dbResourceCacheLifecycle, cmd.GC.DefaultTeamCacheQuota, cmd.GC.TeamCacheQuotas),
+		atc.ComponentCollectorWorkerCacheSizes:  gc.NewWorkerCacheSizeCollector(dbResourceCacheLifecycle, atc.WorkerResourceCacheSizeLimit),
 	}
 
 	var components []RunnableComponent
@@ -1482,6 +1730,31 @@ func (cmd *RunCommand) parseDefaultLimits() (atc.ContainerLimits, error) {
 	return limits, nil
 }
 
+func (cmd *RunCommand) setVolumeQuotas() error {
+	if cmd.OutputVolumeQuota != "" {
+		quota, err := atc.ParseMemoryLimit(cmd.OutputVolumeQuota)
+		if err != nil {
+			return fmt.Errorf("invalid output volume quota: %w", err)
+		}
+		atc.OutputVolumeQuota = uint64(quota)
+	}
+	if cmd.CacheVolumeQuota != "" {
+		quota, err := atc.ParseMemoryLimit(cmd.CacheVolumeQuota)
+		if err != nil {
+			return fmt.Errorf("invalid cache volume quota: %w", err)
+		}
+		atc.CacheVolumeQuota = uint64(quota)
+	}
+	if cmd.GC.WorkerResourceCacheSizeLimit != "" {
+		limit, err := atc.ParseMemoryLimit(cmd.GC.WorkerResourceCacheSizeLimit)
+		if err != nil {
+			return fmt.Errorf("invalid worker resource cache size limit: %w", err)
+		}
+		atc.WorkerResourceCacheSizeLimit = uint64(limit)
+	}
+	return nil
+}
+
 func (cmd *RunCommand) defaultBindIP() net.IP {
 	URL := cmd.BindIP.String()
 	if URL == "0.0.0.0" {
@@ -1555,13 +1828,83 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	if cmd.Mainframe.ConfigDir != "" && cmd.Mainframe.GitURI != "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("cannot specify both --mainframe-config-dir and --mainframe-git-uri"),
+		)
+	}
+
 	if err := cmd.validateCustomRoles(); err != nil {
 		errs = multierror.Append(errs, err)
 	}
 
+	if level := cmd.StreamingArtifactsZstdLevel; level != 0 && (level < baggageclaim.MinZstdLevel || level > baggageclaim.MaxZstdLevel) {
+		errs = multierror.Append(
+			errs,
+			fmt.Errorf("--streaming-artifacts-zstd-level must be between %d and %d", baggageclaim.MinZstdLevel, baggageclaim.MaxZstdLevel),
+		)
+	}
+
+	if cmd.StreamingBandwidthLimit < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--streaming-bandwidth-limit must not be negative"),
+		)
+	}
+
+	if cmd.Replication.Interval > 0 && cmd.Replication.Factor < 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--resource-cache-replication-factor must be at least 1"),
+		)
+	}
+
+	for _, name := range cmd.StepMetadataEnv {
+		if !isStepMetadataEnv(name) {
+			errs = multierror.Append(
+				errs,
+				fmt.Errorf("unknown --step-metadata-env: %s (must be one of %s)", name, strings.Join(exec.StepMetadataEnv, ", ")),
+			)
+		}
+	}
+
+	for _, pair := range cmd.SetPipelineAcrossTeams {
+		teams := strings.Split(pair, ":")
+		if len(teams) != 2 || teams[0] == "" || teams[1] == "" {
+			errs = multierror.Append(
+				errs,
+				fmt.Errorf("invalid --set-pipeline-across-teams: %s (must be SOURCE:TARGET)", pair),
+			)
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
+func (cmd *RunCommand) teamRateLimiter() engine.TeamRateLimiter {
+	limits := engine.TeamRateLimits{
+		Default: rate.Limit(cmd.DefaultTeamMaxChecksPerSecond),
+		Teams:   map[string]rate.Limit{},
+	}
+
+	for team, limit := range cmd.TeamMaxChecksPerSecond {
+		limits.Teams[team] = rate.Limit(limit)
+	}
+
+	return engine.NewTeamRateLimiter(limits, clock.NewClock())
+}
+
+func isStepMetadataEnv(name string) bool {
+	for _, env := range exec.StepMetadataEnv {
+		if env == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (cmd *RunCommand) nonTLSBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
 }
@@ -1668,7 +2011,11 @@ func (cmd *RunCommand) constructEngine(
 	strategy worker.PlacementStrategy,
 	lockFactory lock.LockFactory,
 	rateLimiter engine.RateLimiter,
+	teamRateLimiter engine.TeamRateLimiter,
 	policyChecker policy.Checker,
+	artifactScanner scanner.Scanner,
+	artifactArchiver archiver.Archiver,
+	artifactStore artifactstore.Store,
 ) engine.Engine {
 	return engine.NewEngine(
 		engine.NewStepperFactory(
@@ -1683,9 +2030,17 @@ func (cmd *RunCommand) constructEngine(
 				defaultLimits,
 				strategy,
 				cmd.GlobalResourceCheckTimeout,
+				artifactScanner,
+				cmd.ArtifactScanning.Action,
+				artifactArchiver,
+				artifactStore,
+				cmd.NotifyAllowedHosts,
 			),
 			cmd.ExternalURL.String(),
+			cmd.StepMetadataEnv,
+			cmd.SetPipelineAcrossTeams,
 			rateLimiter,
+			teamRateLimiter,
 			policyChecker,
 			workerFactory,
 			resourceCacheFactory,
@@ -1693,6 +2048,7 @@ func (cmd *RunCommand) constructEngine(
 		),
 		secretManager,
 		cmd.varSourcePool,
+		cmd.Metrics.BuildTraceDir.Path(),
 	)
 }
 
@@ -1866,6 +2222,7 @@ func (cmd *RunCommand) constructAPIHandler(
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
 	dbWall db.Wall,
+	dbWorkerEnrollmentFactory db.WorkerEnrollmentFactory,
 	policyChecker policy.Checker,
 ) (http.Handler, error) {
 
@@ -1894,6 +2251,11 @@ func (cmd *RunCommand) constructAPIHandler(
 		return nil, err
 	}
 
+	artifactStore, err := cmd.ArtifactStore.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewConcurrentRequestLimitsWrappa(
 			logger,
@@ -1941,6 +2303,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		buildserver.NewEventHandler,
 
 		workerPool,
+		artifactStore,
 
 		reconfigurableSink,
 
@@ -1955,6 +2318,8 @@ func (cmd *RunCommand) constructAPIHandler(
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 		time.Minute,
 		dbWall,
+		dbWorkerEnrollmentFactory,
+		aud,
 		clock.NewClock(),
 	)
 }
@@ -39,6 +39,11 @@ func NewAuditor(
 
 type Auditor interface {
 	Audit(action string, userName string, r *http.Request)
+
+	// AuditImpersonation records an action taken by an admin acting as a team.
+	// Unlike Audit, these are always recorded regardless of which audit logs
+	// are enabled.
+	AuditImpersonation(action string, userName string, team string, role string, r *http.Request)
 }
 
 type auditor struct {
@@ -58,6 +63,7 @@ func (a *auditor) ValidateAction(action string) bool {
 	switch action {
 	case atc.GetBuild,
 		atc.GetBuildPlan,
+		atc.GetBuildPlanDAG,
 		atc.CreateBuild,
 		atc.RerunJobBuild,
 		atc.SetBuildComment,
@@ -65,12 +71,15 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.BuildEvents,
 		atc.BuildResources,
 		atc.AbortBuild,
+		atc.PauseBuild,
+		atc.ResumeBuild,
 		atc.GetBuildPreparation,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
 		atc.GetArtifact,
-		atc.ListBuildArtifacts:
+		atc.ListBuildArtifacts,
+		atc.GetBuildTestResults:
 		return a.EnableBuildAuditLog
 	case atc.ListContainers,
 		atc.GetContainer,
@@ -113,8 +122,13 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.GetResource,
 		atc.UnpinResource,
 		atc.SetPinCommentOnResource,
+		atc.PauseResourceChecks,
+		atc.UnpauseResourceChecks,
 		atc.CheckResource,
 		atc.CheckResourceWebHook,
+		atc.CreateResourceWebhookToken,
+		atc.ListResourceWebhookTokens,
+		atc.RotateResourceWebhookTokens,
 		atc.CheckResourceType,
 		atc.CheckPrototype,
 		atc.ListResourceVersions,
@@ -129,6 +143,7 @@ func (a *auditor) ValidateAction(action string) bool {
 	case
 		atc.SaveConfig,
 		atc.GetConfig,
+		atc.PreviewPipeline,
 		atc.GetCC,
 		atc.GetVersionsDB,
 		atc.ClearTaskCache,
@@ -156,7 +171,12 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.PruneWorker,
 		atc.HeartbeatWorker,
 		atc.ListWorkers,
-		atc.DeleteWorker:
+		atc.DeleteWorker,
+		atc.CreateWorkerEnrollmentToken,
+		atc.ListWorkerEnrollmentTokens,
+		atc.DeleteWorkerEnrollmentToken,
+		atc.EnrollWorker,
+		atc.ListEnrolledWorkerKeys:
 		return a.EnableWorkerAuditLog
 	case atc.ListVolumes,
 		atc.ListDestroyingVolumes,
@@ -173,3 +193,18 @@ func (a *auditor) Audit(action string, userName string, r *http.Request) {
 		a.logger.Info("audit", lager.Data{"action": action, "user": userName, "parameters": r.Form})
 	}
 }
+
+func (a *auditor) AuditImpersonation(action string, userName string, team string, role string, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		a.logger.Error("failed-to-parse-form", err)
+	}
+
+	a.logger.Info("audit-impersonation", lager.Data{
+		"action":     action,
+		"user":       userName,
+		"team":       team,
+		"role":       role,
+		"parameters": r.Form,
+	})
+}
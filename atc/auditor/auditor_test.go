@@ -590,4 +590,16 @@ var _ = Describe("Audit", func() {
 			})
 		})
 	})
+
+	Describe("AuditImpersonation", func() {
+		It("creates a log even when no audit logs are enabled", func() {
+			aud.AuditImpersonation("SaveConfig", userName, "some-team", "member", req)
+			logs := logger.Logs()
+			Expect(logs).To(HaveLen(1))
+			Expect(logs[0].Data["action"]).To(Equal("SaveConfig"))
+			Expect(logs[0].Data["user"]).To(Equal(userName))
+			Expect(logs[0].Data["team"]).To(Equal("some-team"))
+			Expect(logs[0].Data["role"]).To(Equal("member"))
+		})
+	})
 })
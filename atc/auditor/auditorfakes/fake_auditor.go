@@ -16,6 +16,15 @@ type FakeAuditor struct {
 		arg2 string
 		arg3 *http.Request
 	}
+	AuditImpersonationStub        func(string, string, string, string, *http.Request)
+	auditImpersonationMutex       sync.RWMutex
+	auditImpersonationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 *http.Request
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -54,11 +63,49 @@ func (fake *FakeAuditor) AuditArgsForCall(i int) (string, string, *http.Request)
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeAuditor) AuditImpersonation(arg1 string, arg2 string, arg3 string, arg4 string, arg5 *http.Request) {
+	fake.auditImpersonationMutex.Lock()
+	fake.auditImpersonationArgsForCall = append(fake.auditImpersonationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 *http.Request
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.AuditImpersonationStub
+	fake.recordInvocation("AuditImpersonation", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.auditImpersonationMutex.Unlock()
+	if stub != nil {
+		fake.AuditImpersonationStub(arg1, arg2, arg3, arg4, arg5)
+	}
+}
+
+func (fake *FakeAuditor) AuditImpersonationCallCount() int {
+	fake.auditImpersonationMutex.RLock()
+	defer fake.auditImpersonationMutex.RUnlock()
+	return len(fake.auditImpersonationArgsForCall)
+}
+
+func (fake *FakeAuditor) AuditImpersonationCalls(stub func(string, string, string, string, *http.Request)) {
+	fake.auditImpersonationMutex.Lock()
+	defer fake.auditImpersonationMutex.Unlock()
+	fake.AuditImpersonationStub = stub
+}
+
+func (fake *FakeAuditor) AuditImpersonationArgsForCall(i int) (string, string, string, string, *http.Request) {
+	fake.auditImpersonationMutex.RLock()
+	defer fake.auditImpersonationMutex.RUnlock()
+	argsForCall := fake.auditImpersonationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
 func (fake *FakeAuditor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.auditMutex.RLock()
 	defer fake.auditMutex.RUnlock()
+	fake.auditImpersonationMutex.RLock()
+	defer fake.auditImpersonationMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
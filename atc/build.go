@@ -32,6 +32,12 @@ type Build struct {
 	ReapTime             int64         `json:"reap_time,omitempty"`
 	RerunNumber          int           `json:"rerun_number,omitempty"`
 	RerunOf              *RerunOfBuild `json:"rerun_of,omitempty"`
+	AutoRerunReason      string        `json:"auto_rerun_reason,omitempty"`
+	ResumedFrom          int           `json:"resumed_from,omitempty"`
+	Priority             int           `json:"priority,omitempty"`
+	PreemptedBy          int           `json:"preempted_by,omitempty"`
+	Paused               bool          `json:"paused,omitempty"`
+	Queued               bool          `json:"queued,omitempty"`
 	CreatedBy            *string       `json:"created_by,omitempty"`
 }
 
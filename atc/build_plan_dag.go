@@ -0,0 +1,44 @@
+package atc
+
+type BuildPlanNodeStatus string
+
+const (
+	BuildPlanNodePending   BuildPlanNodeStatus = "pending"
+	BuildPlanNodeStarted   BuildPlanNodeStatus = "started"
+	BuildPlanNodeSucceeded BuildPlanNodeStatus = "succeeded"
+	BuildPlanNodeFailed    BuildPlanNodeStatus = "failed"
+	BuildPlanNodeErrored   BuildPlanNodeStatus = "errored"
+)
+
+// BuildPlanDAG is a build's plan laid out as a graph for visualizing it.
+//
+// Every step of the plan is a node, including the steps which only structure
+// other steps, such as do and in_parallel. Nodes are nested within the step
+// they belong to through their parent, and edges connect nodes which run one
+// after the other within the same parent.
+type BuildPlanDAG struct {
+	Nodes []BuildPlanNode `json:"nodes"`
+	Edges []BuildPlanEdge `json:"edges"`
+}
+
+type BuildPlanNode struct {
+	ID     PlanID `json:"id"`
+	Parent PlanID `json:"parent,omitempty"`
+	Type   string `json:"type"`
+	Name   string `json:"name,omitempty"`
+
+	Status    BuildPlanNodeStatus `json:"status"`
+	StartTime int64               `json:"start_time,omitempty"`
+	EndTime   int64               `json:"end_time,omitempty"`
+	Worker    string              `json:"worker,omitempty"`
+
+	// Seconds spent in each phase of a step which runs on a worker, e.g.
+	// waiting-for-worker, creating-container and running.
+	PhaseDurations map[string]float64 `json:"phase_durations,omitempty"`
+}
+
+// BuildPlanEdge means that the To node runs after the From node.
+type BuildPlanEdge struct {
+	From PlanID `json:"from"`
+	To   PlanID `json:"to"`
+}
@@ -0,0 +1,419 @@
+package builds
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// PlanDAG lays out a build's plan as a DAG, with the status, timing and
+// worker placement of each step filled in from the events saved for the build
+// so far.
+//
+// Events which cannot be parsed, or which do not belong to a step of the
+// plan, are skipped.
+func PlanDAG(plan atc.Plan, events []event.Envelope) atc.BuildPlanDAG {
+	dag := &planDAG{
+		edges:    []atc.BuildPlanEdge{},
+		index:    map[atc.PlanID]int{},
+		children: map[atc.PlanID][]atc.PlanID{},
+	}
+
+	dag.add(plan, "")
+
+	finished := false
+	for _, envelope := range events {
+		if envelope.Data == nil {
+			continue
+		}
+
+		ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			continue
+		}
+
+		if status, ok := ev.(event.Status); ok {
+			finished = status.Status != atc.StatusPending && status.Status != atc.StatusStarted
+			continue
+		}
+
+		dag.apply(ev)
+	}
+
+	dag.aggregate(finished)
+
+	return atc.BuildPlanDAG{
+		Nodes: dag.nodes,
+		Edges: dag.edges,
+	}
+}
+
+type planDAG struct {
+	nodes []atc.BuildPlanNode
+	edges []atc.BuildPlanEdge
+
+	index    map[atc.PlanID]int
+	children map[atc.PlanID][]atc.PlanID
+
+	// structural nodes only structure other steps, and so get their status
+	// from the steps nested within them
+	structural map[atc.PlanID]bool
+}
+
+func (dag *planDAG) node(id atc.PlanID) *atc.BuildPlanNode {
+	i, found := dag.index[id]
+	if !found {
+		return nil
+	}
+
+	return &dag.nodes[i]
+}
+
+func (dag *planDAG) edge(from atc.PlanID, to atc.PlanID) {
+	dag.edges = append(dag.edges, atc.BuildPlanEdge{From: from, To: to})
+}
+
+func (dag *planDAG) add(plan atc.Plan, parent atc.PlanID) {
+	if _, found := dag.index[plan.ID]; found {
+		return
+	}
+
+	typ, name := planNodeType(plan)
+
+	dag.index[plan.ID] = len(dag.nodes)
+	dag.nodes = append(dag.nodes, atc.BuildPlanNode{
+		ID:     plan.ID,
+		Parent: parent,
+		Type:   typ,
+		Name:   name,
+		Status: atc.BuildPlanNodePending,
+	})
+
+	if parent != "" {
+		dag.children[parent] = append(dag.children[parent], plan.ID)
+	}
+
+	sequence := func(plans []atc.Plan) {
+		for i, p := range plans {
+			dag.add(p, plan.ID)
+
+			if i > 0 {
+				dag.edge(plans[i-1].ID, p.ID)
+			}
+		}
+	}
+
+	hook := func(step atc.Plan, next atc.Plan) {
+		sequence([]atc.Plan{step, next})
+	}
+
+	image := func(image atc.TypeImage) {
+		var plans []atc.Plan
+		if image.CheckPlan != nil {
+			plans = append(plans, *image.CheckPlan)
+		}
+		if image.GetPlan != nil {
+			plans = append(plans, *image.GetPlan)
+		}
+
+		sequence(plans)
+	}
+
+	switch {
+	case plan.Do != nil:
+		sequence(*plan.Do)
+	case plan.InParallel != nil:
+		for _, p := range plan.InParallel.Steps {
+			dag.add(p, plan.ID)
+		}
+	case plan.Across != nil:
+		// the substeps are only known once the across step has run, and are
+		// added when their event is applied
+	case plan.OnSuccess != nil:
+		hook(plan.OnSuccess.Step, plan.OnSuccess.Next)
+	case plan.OnFailure != nil:
+		hook(plan.OnFailure.Step, plan.OnFailure.Next)
+	case plan.OnAbort != nil:
+		hook(plan.OnAbort.Step, plan.OnAbort.Next)
+	case plan.OnError != nil:
+		hook(plan.OnError.Step, plan.OnError.Next)
+	case plan.OnTimeout != nil:
+		hook(plan.OnTimeout.Step, plan.OnTimeout.Next)
+	case plan.Ensure != nil:
+		hook(plan.Ensure.Step, plan.Ensure.Next)
+	case plan.Try != nil:
+		dag.add(plan.Try.Step, plan.ID)
+	case plan.Timeout != nil:
+		dag.add(plan.Timeout.Step, plan.ID)
+	case plan.Mute != nil:
+		dag.add(plan.Mute.Step, plan.ID)
+	case plan.When != nil:
+		dag.add(plan.When.Step, plan.ID)
+	case plan.Lock != nil:
+		dag.add(plan.Lock.Step, plan.ID)
+	case plan.Retry != nil:
+		sequence(plan.Retry.Steps)
+	case plan.While != nil:
+		sequence(plan.While.Steps)
+	case plan.Get != nil:
+		image(plan.Get.TypeImage)
+	case plan.Put != nil:
+		image(plan.Put.TypeImage)
+	case plan.Check != nil:
+		image(plan.Check.TypeImage)
+	case plan.Run != nil:
+		image(plan.Run.TypeImage)
+	}
+
+	if isStructural(plan) {
+		if dag.structural == nil {
+			dag.structural = map[atc.PlanID]bool{}
+		}
+
+		dag.structural[plan.ID] = true
+	}
+}
+
+func (dag *planDAG) apply(ev atc.Event) {
+	switch e := ev.(type) {
+	case event.Initialize:
+		dag.started(e.Origin, e.Time)
+	case event.InitializeTask:
+		dag.started(e.Origin, e.Time)
+	case event.InitializeGet:
+		dag.started(e.Origin, e.Time)
+	case event.InitializePut:
+		dag.started(e.Origin, e.Time)
+	case event.InitializeCheck:
+		dag.started(e.Origin, e.Time)
+	case event.SelectedWorker:
+		if node := dag.node(atc.PlanID(e.Origin.ID)); node != nil {
+			node.Worker = e.WorkerName
+		}
+	case event.StepPhase:
+		if node := dag.node(atc.PlanID(e.Origin.ID)); node != nil {
+			if node.PhaseDurations == nil {
+				node.PhaseDurations = map[string]float64{}
+			}
+
+			node.PhaseDurations[e.Phase] += e.Duration
+		}
+	case event.FinishTask:
+		dag.finished(e.Origin, e.Time, e.ExitStatus == 0)
+	case event.FinishGet:
+		dag.finished(e.Origin, e.Time, e.ExitStatus == 0)
+	case event.FinishPut:
+		dag.finished(e.Origin, e.Time, e.ExitStatus == 0)
+	case event.Finish:
+		dag.finished(e.Origin, e.Time, e.Succeeded)
+	case event.Error:
+		if node := dag.node(atc.PlanID(e.Origin.ID)); node != nil {
+			node.Status = atc.BuildPlanNodeErrored
+			node.EndTime = e.Time
+		}
+	case event.AcrossSubsteps:
+		origin := atc.PlanID(e.Origin.ID)
+		if dag.node(origin) == nil {
+			return
+		}
+
+		for _, substep := range e.Substeps {
+			if substep == nil {
+				continue
+			}
+
+			var plan atc.Plan
+			if err := json.Unmarshal(*substep, &plan); err != nil || plan.ID == "" {
+				continue
+			}
+
+			dag.add(plan, origin)
+		}
+	}
+}
+
+func (dag *planDAG) started(origin event.Origin, time int64) {
+	node := dag.node(atc.PlanID(origin.ID))
+	if node == nil {
+		return
+	}
+
+	if node.Status == atc.BuildPlanNodePending {
+		node.Status = atc.BuildPlanNodeStarted
+	}
+
+	if node.StartTime == 0 {
+		node.StartTime = time
+	}
+}
+
+func (dag *planDAG) finished(origin event.Origin, time int64, succeeded bool) {
+	node := dag.node(atc.PlanID(origin.ID))
+	if node == nil {
+		return
+	}
+
+	if succeeded {
+		node.Status = atc.BuildPlanNodeSucceeded
+	} else {
+		node.Status = atc.BuildPlanNodeFailed
+	}
+
+	node.EndTime = time
+}
+
+// aggregate determines the status and timing of the structural nodes from
+// the nodes nested within them. Nodes are always added after their parent, so
+// going through them backwards visits the children first.
+func (dag *planDAG) aggregate(finished bool) {
+	for i := len(dag.nodes) - 1; i >= 0; i-- {
+		node := &dag.nodes[i]
+		if !dag.structural[node.ID] {
+			continue
+		}
+
+		var children []*atc.BuildPlanNode
+		for _, id := range dag.children[node.ID] {
+			children = append(children, dag.node(id))
+		}
+
+		if node.Status != atc.BuildPlanNodeErrored {
+			node.Status = aggregateStatus(node.Type, children, finished)
+		}
+
+		for _, child := range children {
+			if child.StartTime != 0 && (node.StartTime == 0 || child.StartTime < node.StartTime) {
+				node.StartTime = child.StartTime
+			}
+		}
+
+		switch node.Status {
+		case atc.BuildPlanNodeSucceeded, atc.BuildPlanNodeFailed, atc.BuildPlanNodeErrored:
+			for _, child := range children {
+				if child.EndTime > node.EndTime {
+					node.EndTime = child.EndTime
+				}
+			}
+		}
+	}
+}
+
+func aggregateStatus(typ string, children []*atc.BuildPlanNode, finished bool) atc.BuildPlanNodeStatus {
+	var ran []*atc.BuildPlanNode
+	pending := false
+	for _, child := range children {
+		if child.Status == atc.BuildPlanNodePending {
+			pending = true
+		} else {
+			ran = append(ran, child)
+		}
+	}
+
+	if len(ran) == 0 {
+		return atc.BuildPlanNodePending
+	}
+
+	// only the last attempt or iteration counts
+	if typ == "retry" || typ == "while" {
+		ran = ran[len(ran)-1:]
+	}
+
+	statuses := map[atc.BuildPlanNodeStatus]bool{}
+	for _, child := range ran {
+		statuses[child.Status] = true
+	}
+
+	switch {
+	case statuses[atc.BuildPlanNodeStarted]:
+		return atc.BuildPlanNodeStarted
+	case statuses[atc.BuildPlanNodeErrored]:
+		return atc.BuildPlanNodeErrored
+	case pending && !finished:
+		return atc.BuildPlanNodeStarted
+	case statuses[atc.BuildPlanNodeFailed] && typ != "try":
+		return atc.BuildPlanNodeFailed
+	default:
+		return atc.BuildPlanNodeSucceeded
+	}
+}
+
+func isStructural(plan atc.Plan) bool {
+	return plan.Do != nil ||
+		plan.InParallel != nil ||
+		plan.Across != nil ||
+		plan.OnSuccess != nil ||
+		plan.OnFailure != nil ||
+		plan.OnAbort != nil ||
+		plan.OnError != nil ||
+		plan.OnTimeout != nil ||
+		plan.Ensure != nil ||
+		plan.Try != nil ||
+		plan.Timeout != nil ||
+		plan.Mute != nil ||
+		plan.When != nil ||
+		plan.Lock != nil ||
+		plan.Retry != nil ||
+		plan.While != nil
+}
+
+func planNodeType(plan atc.Plan) (string, string) {
+	switch {
+	case plan.Get != nil:
+		return "get", plan.Get.Name
+	case plan.Put != nil:
+		return "put", plan.Put.Name
+	case plan.Check != nil:
+		return "check", plan.Check.Name
+	case plan.Task != nil:
+		return "task", plan.Task.Name
+	case plan.Run != nil:
+		return "run", plan.Run.Message
+	case plan.SetPipeline != nil:
+		return "set_pipeline", plan.SetPipeline.Name
+	case plan.LoadVar != nil:
+		return "load_var", plan.LoadVar.Name
+	case plan.Notify != nil:
+		return "notify", plan.Notify.Name
+	case plan.Do != nil:
+		return "do", ""
+	case plan.InParallel != nil:
+		return "in_parallel", ""
+	case plan.Across != nil:
+		return "across", ""
+	case plan.OnSuccess != nil:
+		return "on_success", ""
+	case plan.OnFailure != nil:
+		return "on_failure", ""
+	case plan.OnAbort != nil:
+		return "on_abort", ""
+	case plan.OnError != nil:
+		return "on_error", ""
+	case plan.OnTimeout != nil:
+		return "on_timeout", ""
+	case plan.Ensure != nil:
+		return "ensure", ""
+	case plan.Try != nil:
+		return "try", ""
+	case plan.Timeout != nil:
+		return "timeout", ""
+	case plan.Mute != nil:
+		return "mute", ""
+	case plan.When != nil:
+		return "when", ""
+	case plan.Lock != nil:
+		return "lock", plan.Lock.Name
+	case plan.Retry != nil:
+		return "retry", ""
+	case plan.While != nil:
+		return "while", ""
+	case plan.ArtifactInput != nil:
+		return "artifact_input", plan.ArtifactInput.Name
+	case plan.ArtifactOutput != nil:
+		return "artifact_output", plan.ArtifactOutput.Name
+	case plan.DependentGet != nil:
+		return "get", plan.DependentGet.Name
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,166 @@
+package builds_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/event"
+)
+
+func dagEvent(s *PlannerSuite, ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	s.NoError(err)
+
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Data:    &data,
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+	}
+}
+
+func (s *PlannerSuite) TestPlanDAG() {
+	plan := atc.Plan{
+		ID: "ensure",
+		Ensure: &atc.EnsurePlan{
+			Step: atc.Plan{
+				ID: "do",
+				Do: &atc.DoPlan{
+					{
+						ID: "parallel",
+						InParallel: &atc.InParallelPlan{
+							Steps: []atc.Plan{
+								{ID: "get-a", Get: &atc.GetPlan{Name: "a"}},
+								{ID: "get-b", Get: &atc.GetPlan{Name: "b"}},
+							},
+						},
+					},
+					{
+						ID: "try",
+						Try: &atc.TryPlan{
+							Step: atc.Plan{ID: "flaky", Task: &atc.TaskPlan{Name: "flaky"}},
+						},
+					},
+					{ID: "test", Task: &atc.TaskPlan{Name: "test"}},
+				},
+			},
+			Next: atc.Plan{ID: "notify", Put: &atc.PutPlan{Name: "notify"}},
+		},
+	}
+
+	s.Run("lays out the plan with nesting and sequence edges", func() {
+		dag := builds.PlanDAG(plan, nil)
+
+		s.Equal([]atc.BuildPlanNode{
+			{ID: "ensure", Type: "ensure", Status: atc.BuildPlanNodePending},
+			{ID: "do", Parent: "ensure", Type: "do", Status: atc.BuildPlanNodePending},
+			{ID: "parallel", Parent: "do", Type: "in_parallel", Status: atc.BuildPlanNodePending},
+			{ID: "get-a", Parent: "parallel", Type: "get", Name: "a", Status: atc.BuildPlanNodePending},
+			{ID: "get-b", Parent: "parallel", Type: "get", Name: "b", Status: atc.BuildPlanNodePending},
+			{ID: "try", Parent: "do", Type: "try", Status: atc.BuildPlanNodePending},
+			{ID: "flaky", Parent: "try", Type: "task", Name: "flaky", Status: atc.BuildPlanNodePending},
+			{ID: "test", Parent: "do", Type: "task", Name: "test", Status: atc.BuildPlanNodePending},
+			{ID: "notify", Parent: "ensure", Type: "put", Name: "notify", Status: atc.BuildPlanNodePending},
+		}, dag.Nodes)
+
+		s.Equal([]atc.BuildPlanEdge{
+			{From: "parallel", To: "try"},
+			{From: "try", To: "test"},
+			{From: "do", To: "notify"},
+		}, dag.Edges)
+	})
+
+	s.Run("fills in the status of each step from the events", func() {
+		dag := builds.PlanDAG(plan, []event.Envelope{
+			dagEvent(s, event.Status{Status: atc.StatusStarted, Time: 1}),
+			dagEvent(s, event.InitializeGet{Origin: event.Origin{ID: "get-a"}, Time: 2}),
+			dagEvent(s, event.SelectedWorker{Origin: event.Origin{ID: "get-a"}, WorkerName: "some-worker"}),
+			dagEvent(s, event.StepPhase{Origin: event.Origin{ID: "get-a"}, Phase: "waiting-for-worker", Duration: 1.5}),
+			dagEvent(s, event.StepPhase{Origin: event.Origin{ID: "get-a"}, Phase: "running", Duration: 0.5}),
+			dagEvent(s, event.FinishGet{Origin: event.Origin{ID: "get-a"}, Time: 3, ExitStatus: 0}),
+			dagEvent(s, event.InitializeGet{Origin: event.Origin{ID: "get-b"}, Time: 2}),
+			dagEvent(s, event.FinishGet{Origin: event.Origin{ID: "get-b"}, Time: 4, ExitStatus: 0}),
+			dagEvent(s, event.InitializeTask{Origin: event.Origin{ID: "flaky"}, Time: 5}),
+			dagEvent(s, event.FinishTask{Origin: event.Origin{ID: "flaky"}, Time: 6, ExitStatus: 1}),
+			dagEvent(s, event.InitializeTask{Origin: event.Origin{ID: "test"}, Time: 7}),
+		})
+
+		nodes := map[atc.PlanID]atc.BuildPlanNode{}
+		for _, node := range dag.Nodes {
+			nodes[node.ID] = node
+		}
+
+		s.Equal(atc.BuildPlanNode{
+			ID:        "get-a",
+			Parent:    "parallel",
+			Type:      "get",
+			Name:      "a",
+			Status:    atc.BuildPlanNodeSucceeded,
+			StartTime: 2,
+			EndTime:   3,
+			Worker:    "some-worker",
+			PhaseDurations: map[string]float64{
+				"waiting-for-worker": 1.5,
+				"running":            0.5,
+			},
+		}, nodes["get-a"])
+
+		s.Equal(atc.BuildPlanNodeSucceeded, nodes["parallel"].Status)
+		s.Equal(int64(2), nodes["parallel"].StartTime)
+		s.Equal(int64(4), nodes["parallel"].EndTime)
+
+		s.Equal(atc.BuildPlanNodeFailed, nodes["flaky"].Status)
+		s.Equal(atc.BuildPlanNodeSucceeded, nodes["try"].Status)
+
+		s.Equal(atc.BuildPlanNodeStarted, nodes["test"].Status)
+		s.Equal(atc.BuildPlanNodeStarted, nodes["do"].Status)
+		s.Zero(nodes["do"].EndTime)
+
+		s.Equal(atc.BuildPlanNodePending, nodes["notify"].Status)
+		s.Equal(atc.BuildPlanNodeStarted, nodes["ensure"].Status)
+	})
+
+	s.Run("marks errored steps and the steps they are nested within", func() {
+		dag := builds.PlanDAG(plan, []event.Envelope{
+			dagEvent(s, event.InitializeTask{Origin: event.Origin{ID: "test"}, Time: 7}),
+			dagEvent(s, event.Error{Origin: event.Origin{ID: "test"}, Time: 8, Message: "boom"}),
+			dagEvent(s, event.Status{Status: atc.StatusErrored, Time: 9}),
+		})
+
+		nodes := map[atc.PlanID]atc.BuildPlanNode{}
+		for _, node := range dag.Nodes {
+			nodes[node.ID] = node
+		}
+
+		s.Equal(atc.BuildPlanNodeErrored, nodes["test"].Status)
+		s.Equal(atc.BuildPlanNodeErrored, nodes["do"].Status)
+		s.Equal(atc.BuildPlanNodeErrored, nodes["ensure"].Status)
+		s.Equal(atc.BuildPlanNodePending, nodes["notify"].Status)
+	})
+
+	s.Run("adds the substeps of across steps once they are known", func() {
+		acrossPlan := atc.Plan{
+			ID:     "across",
+			Across: &atc.AcrossPlan{},
+		}
+
+		substep, err := json.Marshal(atc.Plan{ID: "across/0", Task: &atc.TaskPlan{Name: "some-task"}})
+		s.NoError(err)
+
+		raw := json.RawMessage(substep)
+
+		dag := builds.PlanDAG(acrossPlan, []event.Envelope{
+			dagEvent(s, event.AcrossSubsteps{Origin: event.Origin{ID: "across"}, Substeps: []*json.RawMessage{&raw}}),
+			dagEvent(s, event.FinishTask{Origin: event.Origin{ID: "across/0"}, Time: 2, ExitStatus: 0}),
+			dagEvent(s, event.Status{Status: atc.StatusSucceeded, Time: 3}),
+		})
+
+		s.Equal([]atc.BuildPlanNode{
+			{ID: "across", Type: "across", Status: atc.BuildPlanNodeSucceeded, EndTime: 2},
+			{ID: "across/0", Parent: "across", Type: "task", Name: "some-task", Status: atc.BuildPlanNodeSucceeded, EndTime: 2},
+		}, dag.Nodes)
+		s.Empty(dag.Edges)
+	})
+}
@@ -66,6 +66,11 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
 		Timeout:           step.Timeout,
+		TestReports:       step.TestReports,
+		Hermetic:          step.Hermetic,
+		User:              step.User,
+		ArchiveOutputs:    step.ArchiveOutputs,
+		Priority:          step.Priority,
 
 		ResourceTypes: visitor.resourceTypes,
 	})
@@ -89,8 +94,16 @@ func (visitor *planVisitor) VisitRun(step *atc.RunStep) error {
 		Tags:       step.Tags,
 		Limits:     step.Limits,
 		Timeout:    step.Timeout,
+		Priority:   step.Priority,
+
+		Inputs:        step.Inputs,
+		Outputs:       step.Outputs,
+		InputMapping:  step.InputMapping,
+		OutputMapping: step.OutputMapping,
 	})
 
+	visitor.plan.Run.TypeImage = visitor.resourceTypes.ImageForPrototype(visitor.plan.ID, prototype, step.Tags)
+
 	return nil
 }
 
@@ -129,6 +142,10 @@ func (visitor *planVisitor) VisitGet(step *atc.GetStep) error {
 		Version:  &version,
 		Tags:     step.Tags,
 		Timeout:  step.Timeout,
+		Limits:   step.Limits,
+		Priority: step.Priority,
+		Depth:    step.Depth,
+		Verify:   step.Verify,
 	})
 
 	plan.Get.TypeImage = visitor.resourceTypes.ImageForType(plan.ID, resource.Type, step.Tags, false)
@@ -160,12 +177,23 @@ func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 		Tags:     step.Tags,
 		Inputs:   step.Inputs,
 		Timeout:  step.Timeout,
+		Limits:   step.Limits,
+		Priority: step.Priority,
+		NoGet:    step.NoGet,
+		Targets:  step.Targets,
 
 		ExposeBuildCreatedBy: resource.ExposeBuildCreatedBy,
 	})
 
 	plan.Put.TypeImage = visitor.resourceTypes.ImageForType(plan.ID, resource.Type, step.Tags, false)
 
+	// Each target creates a version of a different resource config, so there
+	// is no single version to get.
+	if step.NoGet || len(step.Targets) > 0 {
+		visitor.plan = plan
+		return nil
+	}
+
 	dependentGetPlan := visitor.planFactory.NewPlan(atc.GetPlan{
 		Type:        resource.Type,
 		Name:        logicalName,
@@ -174,8 +202,10 @@ func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 		Params:      step.GetParams,
 		VersionFrom: &plan.ID,
 
-		Tags:    step.Tags,
-		Timeout: step.Timeout,
+		Tags:     step.Tags,
+		Timeout:  step.Timeout,
+		Limits:   step.Limits,
+		Priority: step.Priority,
 	})
 
 	dependentGetPlan.Get.TypeImage = visitor.resourceTypes.ImageForType(dependentGetPlan.ID, resource.Type, step.Tags, false)
@@ -201,6 +231,7 @@ func (visitor *planVisitor) VisitDo(step *atc.DoStep) error {
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(do)
+	visitor.plan.ContinueOnFailure = step.ContinueOnFailure
 
 	return nil
 }
@@ -244,6 +275,8 @@ func (visitor *planVisitor) VisitAcross(step *atc.AcrossStep) error {
 	acrossPlan := atc.AcrossPlan{
 		Vars:            vars,
 		SubStepTemplate: string(template),
+		Exclude:         step.Exclude,
+		Include:         step.Include,
 		FailFast:        step.FailFast,
 	}
 
@@ -253,13 +286,22 @@ func (visitor *planVisitor) VisitAcross(step *atc.AcrossStep) error {
 }
 
 func (visitor *planVisitor) VisitSetPipeline(step *atc.SetPipelineStep) error {
+	var instanceVarsList []map[string]interface{}
+	for _, instanceVars := range step.InstanceVarsList {
+		instanceVarsList = append(instanceVarsList, instanceVars)
+	}
+
 	visitor.plan = visitor.planFactory.NewPlan(atc.SetPipelinePlan{
-		Name:         step.Name,
-		File:         step.File,
-		Team:         step.Team,
-		Vars:         step.Vars,
-		VarFiles:     step.VarFiles,
-		InstanceVars: step.InstanceVars,
+		Name:             step.Name,
+		File:             step.File,
+		Team:             step.Team,
+		Vars:             step.Vars,
+		VarFiles:         step.VarFiles,
+		InstanceVars:     step.InstanceVars,
+		InstanceVarsList: instanceVarsList,
+		InstanceVarsFile: step.InstanceVarsFile,
+		PruneInstances:   step.PruneInstances,
+		DryRun:           step.DryRun,
 	})
 
 	return nil
@@ -267,10 +309,21 @@ func (visitor *planVisitor) VisitSetPipeline(step *atc.SetPipelineStep) error {
 
 func (visitor *planVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
 	visitor.plan = visitor.planFactory.NewPlan(atc.LoadVarPlan{
-		Name:   step.Name,
-		File:   step.File,
-		Format: step.Format,
-		Reveal: step.Reveal,
+		Name:          step.Name,
+		File:          step.File,
+		Format:        step.Format,
+		Reveal:        step.Reveal,
+		MultiDocument: step.MultiDocument,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitNotify(step *atc.NotifyStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.NotifyPlan{
+		Name:    step.Name,
+		Message: step.Message,
+		Targets: step.Targets,
 	})
 
 	return nil
@@ -296,15 +349,36 @@ func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(atc.TimeoutPlan{
-		Duration: step.Duration,
+		Duration:  step.Duration,
+		WarnAfter: step.WarnAfter,
+		Build:     step.Build,
+		Step:      visitor.plan,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitMute(step *atc.MuteStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.MutePlan{
 		Step:     visitor.plan,
+		Stdout:   step.Config.Stdout,
+		LogLimit: step.Config.LogLimit,
 	})
 
 	return nil
 }
 
 func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
-	retryStep := make(atc.RetryPlan, step.Attempts)
+	retryStep := atc.RetryPlan{
+		Steps:   make([]atc.Plan, step.Attempts),
+		Backoff: step.Backoff,
+		RetryOn: step.RetryOn,
+	}
 
 	for i := 0; i < step.Attempts; i++ {
 		err := step.Step.Visit(visitor)
@@ -312,7 +386,7 @@ func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 			return err
 		}
 
-		retryStep[i] = visitor.plan
+		retryStep.Steps[i] = visitor.plan
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(retryStep)
@@ -320,8 +394,64 @@ func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitWhile(step *atc.WhileStep) error {
+	iterations := step.Config.MaxIterations
+	if iterations == 0 {
+		iterations = atc.DefaultWhileMaxIterations
+	}
+
+	whileStep := atc.WhilePlan{
+		Steps: make([]atc.Plan, iterations),
+		Var:   step.Config.Var,
+	}
+
+	for i := 0; i < iterations; i++ {
+		err := step.Step.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		whileStep.Steps[i] = visitor.plan
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(whileStep)
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitWhen(step *atc.WhenStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.WhenPlan{
+		Step:      visitor.plan,
+		Condition: step.Condition,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitLock(step *atc.LockStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.LockPlan{
+		Step:  visitor.plan,
+		Name:  step.Config.Name,
+		Count: step.Config.Count,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitOnSuccess(step *atc.OnSuccessStep) error {
-	plan := atc.OnSuccessPlan{}
+	plan := atc.OnSuccessPlan{
+		Timeout: step.Timeout,
+	}
 
 	err := step.Step.Visit(visitor)
 	if err != nil {
@@ -343,7 +473,9 @@ func (visitor *planVisitor) VisitOnSuccess(step *atc.OnSuccessStep) error {
 }
 
 func (visitor *planVisitor) VisitOnFailure(step *atc.OnFailureStep) error {
-	plan := atc.OnFailurePlan{}
+	plan := atc.OnFailurePlan{
+		Timeout: step.Timeout,
+	}
 
 	err := step.Step.Visit(visitor)
 	if err != nil {
@@ -365,7 +497,9 @@ func (visitor *planVisitor) VisitOnFailure(step *atc.OnFailureStep) error {
 }
 
 func (visitor *planVisitor) VisitOnAbort(step *atc.OnAbortStep) error {
-	plan := atc.OnAbortPlan{}
+	plan := atc.OnAbortPlan{
+		Timeout: step.Timeout,
+	}
 
 	err := step.Step.Visit(visitor)
 	if err != nil {
@@ -387,7 +521,33 @@ func (visitor *planVisitor) VisitOnAbort(step *atc.OnAbortStep) error {
 }
 
 func (visitor *planVisitor) VisitOnError(step *atc.OnErrorStep) error {
-	plan := atc.OnErrorPlan{}
+	plan := atc.OnErrorPlan{
+		Timeout: step.Timeout,
+	}
+
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	plan.Step = visitor.plan
+
+	err = step.Hook.Config.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	plan.Next = visitor.plan
+
+	visitor.plan = visitor.planFactory.NewPlan(plan)
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitOnTimeout(step *atc.OnTimeoutStep) error {
+	plan := atc.OnTimeoutPlan{
+		Timeout: step.Timeout,
+	}
 
 	err := step.Step.Visit(visitor)
 	if err != nil {
@@ -408,7 +568,9 @@ func (visitor *planVisitor) VisitOnError(step *atc.OnErrorStep) error {
 	return nil
 }
 func (visitor *planVisitor) VisitEnsure(step *atc.EnsureStep) error {
-	plan := atc.EnsurePlan{}
+	plan := atc.EnsurePlan{
+		Timeout: step.Timeout,
+	}
 
 	err := step.Step.Visit(visitor)
 	if err != nil {
@@ -576,6 +576,68 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "get step with depth",
+		Config: &atc.GetStep{
+			Name:     "some-name",
+			Resource: "some-base-resource",
+			Depth:    5,
+		},
+		Inputs: []db.BuildInput{
+			{
+				Name:    "some-name",
+				Version: atc.Version{"some": "version"},
+			},
+		},
+		PlanJSON: `{
+			"id": "(unique)",
+			"get": {
+				"name": "some-name",
+				"type": "some-base-resource-type",
+				"resource": "some-base-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"version": {"some":"version"},
+				"depth": 5,
+				"image": {
+					"base_type": "some-base-resource-type"
+				}
+			}
+		}`,
+	},
+	{
+		Title: "get step with verify",
+		Config: &atc.GetStep{
+			Name:     "some-name",
+			Resource: "some-base-resource",
+			Verify: &atc.VerifyConfig{
+				File:   "some-file.tgz",
+				SHA512: "some-digest",
+			},
+		},
+		Inputs: []db.BuildInput{
+			{
+				Name:    "some-name",
+				Version: atc.Version{"some": "version"},
+			},
+		},
+		PlanJSON: `{
+			"id": "(unique)",
+			"get": {
+				"name": "some-name",
+				"type": "some-base-resource-type",
+				"resource": "some-base-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"version": {"some":"version"},
+				"verify": {
+					"file": "some-file.tgz",
+					"sha512": "some-digest"
+				},
+				"image": {
+					"base_type": "some-base-resource-type"
+				}
+			}
+		}`,
+	},
 	{
 		Title: "get step with unknown resource",
 		Config: &atc.GetStep{
@@ -602,6 +664,7 @@ var factoryTests = []PlannerTest{
 			Inputs:    &atc.InputsConfig{All: true},
 			GetParams: atc.Params{"some": "get-params"},
 			Timeout:   "1h",
+			Limits:    &atc.ContainerLimits{CPU: newCPULimit(10), Memory: newMemoryLimit(1024)},
 		},
 		Inputs: []db.BuildInput{
 			{
@@ -628,7 +691,8 @@ var factoryTests = []PlannerTest{
 						"image": {
 							"base_type": "some-base-resource-type"
 						},
-						"timeout": "1h"
+						"timeout": "1h",
+						"container_limits": {"cpu": 10, "memory": 1024}
 					}
 				},
 				"on_success": {
@@ -644,12 +708,55 @@ var factoryTests = []PlannerTest{
 						"image": {
 							"base_type": "some-base-resource-type"
 						},
-						"timeout": "1h"
+						"timeout": "1h",
+						"container_limits": {"cpu": 10, "memory": 1024}
 					}
 				}
 			}
 		}`,
 	},
+	{
+		Title: "put step with no_get",
+		Config: &atc.PutStep{
+			Name:     "some-name",
+			Resource: "some-base-resource",
+			NoGet:    true,
+		},
+		PlanJSON: `{
+			"id": "(unique)",
+			"put": {
+				"name": "some-name",
+				"type": "some-base-resource-type",
+				"resource": "some-base-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"no_get": true,
+				"image": {
+					"base_type": "some-base-resource-type"
+				}
+			}
+		}`,
+	},
+	{
+		Title: "put step with targets",
+		Config: &atc.PutStep{
+			Name:     "some-name",
+			Resource: "some-base-resource",
+			Targets:  []atc.Source{{"space": "some-space"}, {"space": "other-space"}},
+		},
+		PlanJSON: `{
+			"id": "(unique)",
+			"put": {
+				"name": "some-name",
+				"type": "some-base-resource-type",
+				"resource": "some-base-resource",
+				"source": {"some":"source","default-key":"default-value"},
+				"targets": [{"space":"some-space"},{"space":"other-space"}],
+				"image": {
+					"base_type": "some-base-resource-type"
+				}
+			}
+		}`,
+	},
 	{
 		Title: "put step with nested resource type",
 		Config: &atc.PutStep{
@@ -901,6 +1008,10 @@ var factoryTests = []PlannerTest{
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
+			Hermetic:          true,
+			User:              "nobody",
+			ArchiveOutputs:    true,
+			Priority:          10,
 		},
 
 		PlanJSON: `{
@@ -920,6 +1031,10 @@ var factoryTests = []PlannerTest{
 				"output_mapping": {"specific": "generic"},
 				"image": "some-image",
 				"timeout": "1h",
+				"hermetic": true,
+				"user": "nobody",
+				"archive_outputs": true,
+				"priority": 10,
 				"resource_types": [
 					{
 						"name": "some-resource-type",
@@ -1001,10 +1116,16 @@ var factoryTests = []PlannerTest{
 				Memory: newMemoryLimit(2048),
 			},
 			Timeout: "1h",
+
+			Inputs:        []string{"some-input"},
+			Outputs:       []string{"some-output"},
+			InputMapping:  map[string]string{"some-input": "some-source"},
+			OutputMapping: map[string]string{"some-output": "some-destination"},
 		},
 
+		CompareIDs: true,
 		PlanJSON: `{
-			"id": "(unique)",
+			"id": "1",
 			"run": {
 				"message": "some-message",
 				"type": "some-prototype",
@@ -1016,7 +1137,37 @@ var factoryTests = []PlannerTest{
 				"privileged": true,
 				"tags": ["tag-1", "tag-2"],
 				"container_limits": {"cpu": 456, "memory": 2048},
-				"timeout": "1h"
+				"timeout": "1h",
+				"inputs": ["some-input"],
+				"outputs": ["some-output"],
+				"input_mapping": {"some-input": "some-source"},
+				"output_mapping": {"some-output": "some-destination"},
+				"image": {
+					"base_type": "some-base-resource-type",
+					"check_plan": {
+						"id": "1/image-check",
+						"check": {
+							"name": "some-prototype",
+							"type": "some-base-resource-type",
+							"prototype": "some-prototype",
+							"interval": "1m0s",
+							"source": {"some": "prototype-source"},
+							"image": {"base_type": "some-base-resource-type"},
+							"tags": ["tag-1", "tag-2"]
+						}
+					},
+					"get_plan": {
+						"id": "1/image-get",
+						"get": {
+							"name": "some-prototype",
+							"type": "some-base-resource-type",
+							"source": {"some": "prototype-source"},
+							"image": {"base_type": "some-base-resource-type"},
+							"version_from": "1/image-check",
+							"tags": ["tag-1", "tag-2"]
+						}
+					}
+				}
 			}
 		}`,
 	},
@@ -1029,6 +1180,7 @@ var factoryTests = []PlannerTest{
 			Vars:         atc.Params{"some": "vars"},
 			VarFiles:     []string{"file-1", "file-2"},
 			InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+			DryRun:       true,
 		},
 
 		PlanJSON: `{
@@ -1038,7 +1190,8 @@ var factoryTests = []PlannerTest{
 				"file": "some-pipeline-file",
 				"vars": {"some": "vars"},
 				"var_files": ["file-1", "file-2"],
-				"instance_vars": {"branch": "feature/foo"}
+				"instance_vars": {"branch": "feature/foo"},
+				"dry_run": true
 			}
 		}`,
 	},
@@ -1062,6 +1215,44 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "load_var step with multi_document",
+
+		Config: &atc.LoadVarStep{
+			Name:          "some-var",
+			File:          "some-var-file",
+			MultiDocument: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"load_var": {
+				"name": "some-var",
+				"file": "some-var-file",
+				"multi_document": true
+			}
+		}`,
+	},
+	{
+		Title: "notify step",
+
+		Config: &atc.NotifyStep{
+			Name:    "some-notification",
+			Message: "some-message",
+			Targets: []atc.NotifyTarget{
+				{Type: "slack", URL: "some-url"},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"notify": {
+				"name": "some-notification",
+				"message": "some-message",
+				"targets": [{"type": "slack", "url": "some-url"}]
+			}
+		}`,
+	},
 	{
 		Title: "try step",
 
@@ -1127,12 +1318,41 @@ var factoryTests = []PlannerTest{
 			]
 		}`,
 	},
+	{
+		Title: "do step with continue_on_failure",
+
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-var",
+						File: "some-file",
+					},
+				},
+			},
+			ContinueOnFailure: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"do": [
+				{
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				}
+			],
+			"continue_on_failure": true
+		}`,
+	},
 	{
 		Title: "in_parallel step",
 
 		Config: &atc.InParallelStep{
 			Config: atc.InParallelConfig{
-				Limit:    3,
+				Limit:    &atc.InParallelLimit{Limit: 3},
 				FailFast: true,
 				Steps: []atc.Step{
 					{
@@ -1216,6 +1436,43 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "across step with exclude and include",
+
+		Config: &atc.AcrossStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:    "var1",
+					Values: []interface{}{"a1", "a2"},
+				},
+			},
+			Exclude: []atc.AcrossCombination{
+				{"var1": "a2"},
+			},
+			Include: []atc.AcrossCombination{
+				{"var1": "a3"},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"across": {
+				"vars": [
+					{
+						"name": "var1",
+						"values": ["a1", "a2"]
+					}
+				],
+				"substep_template": "{\"id\":\"1\",\"load_var\":{\"name\":\"some-var\",\"file\":\"some-file\"}}",
+				"exclude": [{"var1": "a2"}],
+				"include": [{"var1": "a3"}]
+			}
+		}`,
+	},
 	{
 		Title: "timeout modifier",
 
@@ -1242,42 +1499,306 @@ var factoryTests = []PlannerTest{
 		}`,
 	},
 	{
-		Title: "attempts modifier",
+		Title: "timeout modifier with warn_after",
 
-		Config: &atc.RetryStep{
+		Config: &atc.TimeoutStep{
 			Step: &atc.LoadVarStep{
 				Name: "some-var",
 				File: "some-file",
 			},
-			Attempts: 3,
+			Duration:  "1h",
+			WarnAfter: "45m",
 		},
 
-		CompareIDs: true,
 		PlanJSON: `{
-			"id": "4",
-			"retry": [
-				{
-					"id": "1",
+			"id": "(unique)",
+			"timeout": {
+				"step": {
+					"id": "(unique)",
 					"load_var": {
 						"name": "some-var",
 						"file": "some-file"
 					}
 				},
-				{
-					"id": "2",
+				"duration": "1h",
+				"warn_after": "45m"
+			}
+		}`,
+	},
+	{
+		Title: "build timeout",
+
+		Config: &atc.TimeoutStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Duration: "1h",
+			Build:    true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"timeout": {
+				"step": {
+					"id": "(unique)",
 					"load_var": {
 						"name": "some-var",
 						"file": "some-file"
 					}
 				},
-				{
-					"id": "3",
+				"duration": "1h",
+				"build": true
+			}
+		}`,
+	},
+	{
+		Title: "mute modifier",
+
+		Config: &atc.MuteStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Config: atc.MuteConfig{
+				Stdout:   true,
+				LogLimit: "1MB",
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"mute": {
+				"step": {
+					"id": "(unique)",
 					"load_var": {
 						"name": "some-var",
 						"file": "some-file"
 					}
+				},
+				"stdout": true,
+				"log_limit": "1MB"
+			}
+		}`,
+	},
+	{
+		Title: "while modifier",
+
+		Config: &atc.WhileStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Config: atc.WhileConfig{
+				Var:           "some-var",
+				MaxIterations: 2,
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"while": {
+				"steps": [
+					{
+						"id": "(unique)",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					},
+					{
+						"id": "(unique)",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					}
+				],
+				"var": "some-var"
+			}
+		}`,
+	},
+	{
+		Title: "when modifier",
+
+		Config: &atc.WhenStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: `((branch)) == "main"`,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"when": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"condition": "((branch)) == \"main\""
+			}
+		}`,
+	},
+	{
+		Title: "lock modifier",
+
+		Config: &atc.LockStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Config: atc.LockConfig{
+				Name:  "staging",
+				Count: 2,
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"lock": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"name": "staging",
+				"count": 2
+			}
+		}`,
+	},
+	{
+		Title: "attempts modifier",
+
+		Config: &atc.RetryStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Attempts: 3,
+		},
+
+		CompareIDs: true,
+		PlanJSON: `{
+			"id": "4",
+			"retry": {
+				"steps": [
+					{
+						"id": "1",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					},
+					{
+						"id": "2",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					},
+					{
+						"id": "3",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					}
+				]
+			}
+		}`,
+	},
+	{
+		Title: "attempts modifier with backoff",
+
+		Config: &atc.RetryStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Attempts: 2,
+			Backoff: &atc.RetryBackoff{
+				Initial:    "10s",
+				Multiplier: 3,
+				Max:        "1m",
+				Jitter:     true,
+			},
+		},
+
+		CompareIDs: true,
+		PlanJSON: `{
+			"id": "3",
+			"retry": {
+				"steps": [
+					{
+						"id": "1",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					},
+					{
+						"id": "2",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					}
+				],
+				"backoff": {
+					"initial": "10s",
+					"multiplier": 3,
+					"max": "1m",
+					"jitter": true
 				}
-			]
+			}
+		}`,
+	},
+	{
+		Title: "attempts modifier with retry_on",
+
+		Config: &atc.RetryStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Attempts: 2,
+			RetryOn: []atc.RetryCondition{
+				{Class: "timeout"},
+				{Message: "connection reset"},
+			},
+		},
+
+		CompareIDs: true,
+		PlanJSON: `{
+			"id": "3",
+			"retry": {
+				"steps": [
+					{
+						"id": "1",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					},
+					{
+						"id": "2",
+						"load_var": {
+							"name": "some-var",
+							"file": "some-file"
+						}
+					}
+				],
+				"retry_on": [
+					"timeout",
+					{"message": "connection reset"}
+				]
+			}
 		}`,
 	},
 	{
@@ -1388,6 +1909,44 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "on_timeout step",
+
+		Config: &atc.OnTimeoutStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Hook: atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "some-other-var",
+					File: "some-other-file",
+				},
+			},
+			Timeout: "5m",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"on_timeout": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"on_timeout": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-other-var",
+						"file": "some-other-file"
+					}
+				},
+				"timeout": "5m"
+			}
+		}`,
+	},
 	{
 		Title: "on_abort step",
 
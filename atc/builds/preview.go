@@ -0,0 +1,125 @@
+package builds
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Preview simulates the arrival of the given resource versions against the
+// pipeline config, returning each job that would trigger along with the plan
+// its build would run. Jobs downstream of a triggered job are assumed to see
+// its build succeed, and any resources it puts to are treated as having new
+// versions too. Nothing is persisted or run.
+//
+// Inputs without a known version are planned with an empty version.
+func (planner Planner) Preview(config atc.Config, versions map[string]atc.Version) atc.PlanPreview {
+	newResources := map[string]bool{}
+	for name := range versions {
+		resource, found := config.Resources.Lookup(name)
+		if found && resource.Version != nil {
+			// pinned resources never pick up new versions
+			continue
+		}
+
+		newResources[name] = true
+	}
+
+	jobs := config.JobsWithPipelineHooks()
+
+	triggeredBy := map[string][]string{}
+	for changed := true; changed; {
+		changed = false
+
+		for _, job := range jobs {
+			if _, triggered := triggeredBy[job.Name]; triggered {
+				continue
+			}
+
+			var triggers []string
+			for _, input := range job.Inputs() {
+				if !input.Trigger || !newResources[input.Resource] {
+					continue
+				}
+
+				if input.Version != nil && input.Version.Pinned != nil {
+					continue
+				}
+
+				passed := true
+				for _, upstream := range input.Passed {
+					if _, triggered := triggeredBy[upstream]; !triggered {
+						passed = false
+						break
+					}
+				}
+
+				if passed {
+					triggers = append(triggers, input.Name)
+				}
+			}
+
+			if len(triggers) == 0 {
+				continue
+			}
+
+			triggeredBy[job.Name] = triggers
+			for _, output := range job.Outputs() {
+				newResources[output.Resource] = true
+			}
+
+			changed = true
+		}
+	}
+
+	var resources db.SchedulerResources
+	for _, resource := range config.Resources {
+		resources = append(resources, db.SchedulerResource{
+			Name:                 resource.Name,
+			Type:                 resource.Type,
+			Source:               resource.Source,
+			ExposeBuildCreatedBy: resource.ExposeBuildCreatedBy,
+		})
+	}
+
+	preview := atc.PlanPreview{Jobs: []atc.JobPlanPreview{}}
+	for _, job := range jobs {
+		triggers, triggered := triggeredBy[job.Name]
+		if !triggered {
+			continue
+		}
+
+		var inputs []db.BuildInput
+		for _, input := range job.Inputs() {
+			version := atc.Version{}
+			resource, _ := config.Resources.Lookup(input.Resource)
+			if input.Version != nil && input.Version.Pinned != nil {
+				version = input.Version.Pinned
+			} else if resource.Version != nil {
+				version = resource.Version
+			} else if v, found := versions[input.Resource]; found {
+				version = v
+			}
+
+			inputs = append(inputs, db.BuildInput{
+				Name:    input.Name,
+				Version: version,
+			})
+		}
+
+		jobPreview := atc.JobPlanPreview{
+			Name:        job.Name,
+			TriggeredBy: triggers,
+		}
+
+		plan, err := planner.Create(job.BuildStepConfig(), resources, config.ResourceTypes, config.Prototypes, inputs)
+		if err != nil {
+			jobPreview.Error = err.Error()
+		} else {
+			jobPreview.Plan = &plan
+		}
+
+		preview.Jobs = append(preview.Jobs, jobPreview)
+	}
+
+	return preview
+}
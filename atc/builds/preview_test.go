@@ -0,0 +1,103 @@
+package builds_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/builds"
+)
+
+var previewConfig = atc.Config{
+	Resources: atc.ResourceConfigs{
+		{Name: "repo", Type: "git"},
+		{Name: "image", Type: "registry-image"},
+		{Name: "pinned", Type: "git", Version: atc.Version{"ref": "pinned"}},
+	},
+	Jobs: atc.JobConfigs{
+		{
+			Name: "unit",
+			PlanSequence: []atc.Step{
+				{Config: &atc.GetStep{Name: "repo", Trigger: true}},
+				{Config: &atc.GetStep{Name: "pinned", Trigger: true}},
+				{Config: &atc.TaskStep{Name: "test", ConfigPath: "repo/ci/test.yml"}},
+			},
+		},
+		{
+			Name: "build",
+			PlanSequence: []atc.Step{
+				{Config: &atc.GetStep{Name: "repo", Trigger: true, Passed: []string{"unit"}}},
+				{Config: &atc.PutStep{Name: "image"}},
+			},
+		},
+		{
+			Name: "deploy",
+			PlanSequence: []atc.Step{
+				{Config: &atc.GetStep{Name: "image", Trigger: true}},
+			},
+		},
+		{
+			Name: "manual",
+			PlanSequence: []atc.Step{
+				{Config: &atc.GetStep{Name: "repo"}},
+			},
+		},
+	},
+}
+
+func (s *PlannerSuite) TestPreview() {
+	planner := builds.NewPlanner(atc.NewPlanFactory(0))
+
+	s.Run("new versions trigger jobs downstream through passed constraints and puts", func() {
+		preview := planner.Preview(previewConfig, map[string]atc.Version{
+			"repo": {"ref": "abc"},
+		})
+
+		s.Len(preview.Jobs, 3)
+
+		s.Equal("unit", preview.Jobs[0].Name)
+		s.Equal([]string{"repo"}, preview.Jobs[0].TriggeredBy)
+		s.Empty(preview.Jobs[0].Error)
+		s.NotNil(preview.Jobs[0].Plan)
+
+		var versions []atc.Version
+		preview.Jobs[0].Plan.Each(func(plan *atc.Plan) {
+			if plan.Get != nil {
+				versions = append(versions, *plan.Get.Version)
+			}
+		})
+		s.Equal([]atc.Version{{"ref": "abc"}, {"ref": "pinned"}}, versions)
+
+		s.Equal("build", preview.Jobs[1].Name)
+		s.Equal([]string{"repo"}, preview.Jobs[1].TriggeredBy)
+
+		s.Equal("deploy", preview.Jobs[2].Name)
+		s.Equal([]string{"image"}, preview.Jobs[2].TriggeredBy)
+	})
+
+	s.Run("new versions of pinned resources trigger nothing", func() {
+		preview := planner.Preview(previewConfig, map[string]atc.Version{
+			"pinned": {"ref": "new"},
+		})
+
+		s.Empty(preview.Jobs)
+	})
+
+	s.Run("plan errors are reported per job", func() {
+		config := atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "broken",
+					PlanSequence: []atc.Step{
+						{Config: &atc.GetStep{Name: "missing", Trigger: true}},
+					},
+				},
+			},
+		}
+
+		preview := planner.Preview(config, map[string]atc.Version{
+			"missing": {"ref": "abc"},
+		})
+
+		s.Len(preview.Jobs, 1)
+		s.Nil(preview.Jobs[0].Plan)
+		s.Equal("unknown resource: missing", preview.Jobs[0].Error)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
@@ -24,13 +25,35 @@ type Runnable interface {
 	Run(context.Context)
 }
 
+// TeamLimits bounds the number of builds each team may have running at once,
+// so that one team's builds cannot starve everyone else's.
+type TeamLimits struct {
+	// Default is the limit of teams without a limit of their own. 0 means
+	// unlimited.
+	Default int
+
+	// Teams are the limits of specific teams, by team name.
+	Teams map[string]int
+}
+
+// For returns the limit of the given team.
+func (limits TeamLimits) For(teamName string) int {
+	if limit, found := limits.Teams[teamName]; found {
+		return limit
+	}
+
+	return limits.Default
+}
+
 func NewTracker(
 	buildFactory db.BuildFactory,
 	engine Engine,
+	limits TeamLimits,
 ) *Tracker {
 	return &Tracker{
 		buildFactory: buildFactory,
 		engine:       engine,
+		limits:       limits,
 		running:      &sync.Map{},
 	}
 }
@@ -38,6 +61,7 @@ func NewTracker(
 type Tracker struct {
 	buildFactory db.BuildFactory
 	engine       Engine
+	limits       TeamLimits
 
 	running *sync.Map
 }
@@ -55,6 +79,10 @@ func (bt *Tracker) Run(ctx context.Context) error {
 	}
 
 	for _, b := range builds {
+		if !bt.admit(logger, b) {
+			continue
+		}
+
 		if _, exists := bt.running.LoadOrStore(b.ID(), true); !exists {
 			go func(build db.Build) {
 				loggerData := build.LagerData()
@@ -90,6 +118,27 @@ func (bt *Tracker) Run(ctx context.Context) error {
 	return nil
 }
 
+// admit returns whether the build may run, admitting it if its team has room
+// for it. Builds which are not admitted stay queued until a later run.
+// Aborted builds always run, as running them is what finishes them.
+func (bt *Tracker) admit(logger lager.Logger, build db.Build) bool {
+	if build.IsAdmitted() || build.IsAborted() || build.Name() == db.CheckBuildName {
+		return true
+	}
+
+	admitted, err := build.Admit(bt.limits.For(build.TeamName()))
+	if err != nil {
+		logger.Error("failed-to-admit-build", err, build.LagerData())
+		return false
+	}
+
+	if !admitted {
+		logger.Debug("build-queued", build.LagerData())
+	}
+
+	return admitted
+}
+
 func (bt *Tracker) Drain(ctx context.Context) {
 	bt.engine.Drain(ctx)
 }
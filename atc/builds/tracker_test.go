@@ -43,6 +43,10 @@ func (s *TrackerSuite) SetupTest() {
 	s.tracker = builds.NewTracker(
 		s.fakeBuildFactory,
 		s.fakeEngine,
+		builds.TeamLimits{
+			Default: 2,
+			Teams:   map[string]int{"big-team": 10},
+		},
 	)
 }
 
@@ -51,6 +55,7 @@ func (s *TrackerSuite) TestTrackRunsStartedBuilds() {
 	for i := 0; i < 3; i++ {
 		fakeBuild := new(dbfakes.FakeBuild)
 		fakeBuild.IDReturns(i + 1)
+		fakeBuild.IsAdmittedReturns(true)
 		startedBuilds = append(startedBuilds, fakeBuild)
 	}
 
@@ -84,12 +89,14 @@ func (s *TrackerSuite) TestTrackerDoesntCrashWhenOneBuildPanic() {
 	startedBuilds := []db.Build{}
 	fakeBuild1 := new(dbfakes.FakeBuild)
 	fakeBuild1.IDReturns(1)
+	fakeBuild1.IsAdmittedReturns(true)
 	startedBuilds = append(startedBuilds, fakeBuild1)
 
 	// build 2 and 3 are normal running build
 	for i := 1; i < 3; i++ {
 		fakeBuild := new(dbfakes.FakeBuild)
 		fakeBuild.IDReturns(i + 1)
+		fakeBuild.IsAdmittedReturns(true)
 		startedBuilds = append(startedBuilds, fakeBuild)
 	}
 
@@ -132,6 +139,7 @@ func (s *TrackerSuite) TestTrackerDoesntCrashWhenOneBuildPanic() {
 func (s *TrackerSuite) TestTrackDoesntTrackAlreadyRunningBuilds() {
 	fakeBuild := new(dbfakes.FakeBuild)
 	fakeBuild.IDReturns(1)
+	fakeBuild.IsAdmittedReturns(true)
 	s.fakeBuildFactory.GetAllStartedBuildsReturns([]db.Build{fakeBuild}, nil)
 
 	wait := make(chan struct{})
@@ -163,6 +171,101 @@ func (s *TrackerSuite) TestTrackDoesntTrackAlreadyRunningBuilds() {
 	}
 }
 
+func (s *TrackerSuite) TestTrackAdmitsBuildsWithinTheirTeamsLimit() {
+	admittedBuild := new(dbfakes.FakeBuild)
+	admittedBuild.IDReturns(1)
+	admittedBuild.TeamNameReturns("some-team")
+	admittedBuild.AdmitReturns(true, nil)
+
+	queuedBuild := new(dbfakes.FakeBuild)
+	queuedBuild.IDReturns(2)
+	queuedBuild.TeamNameReturns("some-team")
+	queuedBuild.AdmitReturns(false, nil)
+
+	bigTeamBuild := new(dbfakes.FakeBuild)
+	bigTeamBuild.IDReturns(3)
+	bigTeamBuild.TeamNameReturns("big-team")
+	bigTeamBuild.AdmitReturns(true, nil)
+
+	s.fakeBuildFactory.GetAllStartedBuildsReturns([]db.Build{admittedBuild, queuedBuild, bigTeamBuild}, nil)
+
+	running := make(chan db.Build, 3)
+	s.fakeEngine.NewBuildStub = func(build db.Build) builds.Runnable {
+		engineBuild := new(buildsfakes.FakeRunnable)
+		engineBuild.RunStub = func(context.Context) {
+			running <- build
+		}
+
+		return engineBuild
+	}
+
+	err := s.tracker.Run(context.TODO())
+	s.NoError(err)
+
+	s.Equal(1, admittedBuild.AdmitCallCount())
+	s.Equal(2, admittedBuild.AdmitArgsForCall(0))
+	s.Equal(2, queuedBuild.AdmitArgsForCall(0))
+	s.Equal(10, bigTeamBuild.AdmitArgsForCall(0))
+
+	s.ElementsMatch([]int{
+		admittedBuild.ID(),
+		bigTeamBuild.ID(),
+	}, []int{
+		(<-running).ID(),
+		(<-running).ID(),
+	})
+
+	s.Equal(2, s.fakeEngine.NewBuildCallCount())
+}
+
+func (s *TrackerSuite) TestTrackRunsAbortedBuildsRegardlessOfTheirTeamsLimit() {
+	abortedBuild := new(dbfakes.FakeBuild)
+	abortedBuild.IDReturns(1)
+	abortedBuild.TeamNameReturns("some-team")
+	abortedBuild.IsAbortedReturns(true)
+	abortedBuild.AdmitReturns(false, nil)
+	s.fakeBuildFactory.GetAllStartedBuildsReturns([]db.Build{abortedBuild}, nil)
+
+	running := make(chan db.Build, 1)
+	s.fakeEngine.NewBuildStub = func(build db.Build) builds.Runnable {
+		engineBuild := new(buildsfakes.FakeRunnable)
+		engineBuild.RunStub = func(context.Context) {
+			running <- build
+		}
+
+		return engineBuild
+	}
+
+	err := s.tracker.Run(context.TODO())
+	s.NoError(err)
+
+	s.Equal(abortedBuild.ID(), (<-running).ID())
+	s.Zero(abortedBuild.AdmitCallCount())
+}
+
+func (s *TrackerSuite) TestTrackDoesNotLimitCheckBuilds() {
+	checkBuild := new(dbfakes.FakeBuild)
+	checkBuild.IDReturns(1)
+	checkBuild.NameReturns(db.CheckBuildName)
+	s.fakeBuildFactory.GetAllStartedBuildsReturns([]db.Build{checkBuild}, nil)
+
+	running := make(chan db.Build, 1)
+	s.fakeEngine.NewBuildStub = func(build db.Build) builds.Runnable {
+		engineBuild := new(buildsfakes.FakeRunnable)
+		engineBuild.RunStub = func(context.Context) {
+			running <- build
+		}
+
+		return engineBuild
+	}
+
+	err := s.tracker.Run(context.TODO())
+	s.NoError(err)
+
+	s.Equal(checkBuild.ID(), (<-running).ID())
+	s.Zero(checkBuild.AdmitCallCount())
+}
+
 func (s *TrackerSuite) TestTrackerDrainsEngine() {
 	var _ component.Drainable = s.tracker
 
@@ -8,6 +8,10 @@ const (
 	ComponentLidarScanner               = "scanner"
 	ComponentBuildReaper                = "reaper"
 	ComponentSyslogDrainer              = "drainer"
+	ComponentPrewarmer                  = "prewarmer"
+	ComponentResourceCacheReplicator    = "replicator"
+	ComponentBuildPreemptor             = "preemptor"
+	ComponentMainframe                  = "mainframe"
 	ComponentCollectorAccessTokens      = "collector_access_tokens"
 	ComponentCollectorArtifacts         = "collector_artifacts"
 	ComponentCollectorBuilds            = "collector_builds"
@@ -17,7 +21,9 @@ const (
 	ComponentCollectorResourceCacheUses = "collector_resource_cache_uses"
 	ComponentCollectorResourceCaches    = "collector_resource_caches"
 	ComponentCollectorResourceConfigs   = "collector_resource_configs"
+	ComponentCollectorTeamCacheQuotas   = "collector_team_cache_quotas"
 	ComponentCollectorVolumes           = "collector_volumes"
+	ComponentCollectorWorkerCacheSizes  = "collector_worker_cache_sizes"
 	ComponentCollectorWorkers           = "collector_workers"
 	ComponentCollectorPipelines         = "collector_pipelines"
 )
@@ -0,0 +1,100 @@
+package atc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Condition is a parsed `when:` expression. It is either a comparison of two
+// operands with == or !=, or a single operand that must be a boolean.
+//
+// Operands are compared as strings. Double-quoted operands are unquoted; bare
+// operands have surrounding whitespace trimmed.
+type Condition struct {
+	Left     string
+	Operator string
+	Right    string
+}
+
+// ParseCondition parses a `when:` expression, e.g. `((branch)) == "main"`.
+func ParseCondition(expr string) (Condition, error) {
+	var operands []string
+	var operators []string
+
+	start := 0
+	inQuotes := false
+	for i := 0; i < len(expr); i++ {
+		switch {
+		case expr[i] == '\\' && inQuotes:
+			i++
+		case expr[i] == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && i+1 < len(expr) && (expr[i:i+2] == "==" || expr[i:i+2] == "!="):
+			operands = append(operands, expr[start:i])
+			operators = append(operators, expr[i:i+2])
+			start = i + 2
+			i++
+		}
+	}
+
+	if inQuotes {
+		return Condition{}, fmt.Errorf("unterminated quote in condition '%s'", expr)
+	}
+
+	operands = append(operands, expr[start:])
+
+	if len(operators) > 1 {
+		return Condition{}, fmt.Errorf("condition '%s' must contain at most one comparison", expr)
+	}
+
+	values := make([]string, len(operands))
+	for i, operand := range operands {
+		value, err := parseOperand(operand)
+		if err != nil {
+			return Condition{}, fmt.Errorf("invalid operand in condition '%s': %w", expr, err)
+		}
+
+		values[i] = value
+	}
+
+	if len(operators) == 0 {
+		return Condition{Left: values[0]}, nil
+	}
+
+	return Condition{
+		Left:     values[0],
+		Operator: operators[0],
+		Right:    values[1],
+	}, nil
+}
+
+func parseOperand(operand string) (string, error) {
+	operand = strings.TrimSpace(operand)
+	if operand == "" {
+		return "", fmt.Errorf("missing operand")
+	}
+
+	if strings.HasPrefix(operand, `"`) {
+		return strconv.Unquote(operand)
+	}
+
+	return operand, nil
+}
+
+// Evaluate returns whether the condition holds.
+func (condition Condition) Evaluate() (bool, error) {
+	switch condition.Operator {
+	case "==":
+		return condition.Left == condition.Right, nil
+	case "!=":
+		return condition.Left != condition.Right, nil
+	}
+
+	value, err := strconv.ParseBool(condition.Left)
+	if err != nil {
+		return false, fmt.Errorf("condition '%s' is not a boolean", condition.Left)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,87 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseCondition", func() {
+	type testCase struct {
+		description string
+		expr        string
+		holds       bool
+		parseErr    string
+		evalErr     string
+	}
+
+	for _, test := range []testCase{
+		{
+			description: "compares a bare operand to a quoted one",
+			expr:        `main == "main"`,
+			holds:       true,
+		},
+		{
+			description: "compares two bare operands",
+			expr:        `feature != main`,
+			holds:       true,
+		},
+		{
+			description: "keeps operators within quotes",
+			expr:        `"a == b" == "a == b"`,
+			holds:       true,
+		},
+		{
+			description: "unescapes quoted operands",
+			expr:        `"say \"hi\"" == "say \"hi\""`,
+			holds:       true,
+		},
+		{
+			description: "evaluates a lone boolean",
+			expr:        ` false `,
+			holds:       false,
+		},
+		{
+			description: "rejects a lone operand that is not a boolean",
+			expr:        `main`,
+			evalErr:     "condition 'main' is not a boolean",
+		},
+		{
+			description: "rejects a missing operand",
+			expr:        `== main`,
+			parseErr:    "invalid operand in condition '== main': missing operand",
+		},
+		{
+			description: "rejects chained comparisons",
+			expr:        `a == b == c`,
+			parseErr:    "condition 'a == b == c' must contain at most one comparison",
+		},
+		{
+			description: "rejects an unterminated quote",
+			expr:        `a == "b`,
+			parseErr:    `unterminated quote in condition 'a == "b'`,
+		},
+	} {
+		test := test
+
+		It(test.description, func() {
+			condition, err := atc.ParseCondition(test.expr)
+			if test.parseErr != "" {
+				Expect(err).To(MatchError(test.parseErr))
+				return
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+
+			holds, err := condition.Evaluate()
+			if test.evalErr != "" {
+				Expect(err).To(MatchError(test.evalErr))
+				return
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(holds).To(Equal(test.holds))
+		})
+	}
+})
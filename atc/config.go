@@ -15,6 +15,13 @@ import (
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
+
+// Admins may set these headers to act as the given role on a team. Every
+// request made this way is recorded in the audit log.
+const (
+	ImpersonateTeamHeader = "X-Concourse-Impersonate-Team"
+	ImpersonateRoleHeader = "X-Concourse-Impersonate-Role"
+)
 const DefaultTeamName = "main"
 
 type Config struct {
@@ -25,6 +32,24 @@ type Config struct {
 	Prototypes    Prototypes       `json:"prototypes,omitempty"`
 	Jobs          JobConfigs       `json:"jobs,omitempty"`
 	Display       *DisplayConfig   `json:"display,omitempty"`
+
+	PipelineHooks
+}
+
+// JobsWithPipelineHooks returns the pipeline's jobs with the pipeline-level
+// hooks attached, so that each job's StepConfig includes them.
+func (c Config) JobsWithPipelineHooks() JobConfigs {
+	if c.PipelineHooks.IsZero() {
+		return c.Jobs
+	}
+
+	jobs := make(JobConfigs, len(c.Jobs))
+	for i, job := range c.Jobs {
+		job.PipelineHooks = c.PipelineHooks
+		jobs[i] = job
+	}
+
+	return jobs
 }
 
 func UnmarshalConfig(payload []byte, config interface{}) error {
@@ -37,6 +62,9 @@ func UnmarshalConfig(payload []byte, config interface{}) error {
 		Prototypes    interface{} `json:"prototypes,omitempty"`
 		Jobs          interface{} `json:"jobs,omitempty"`
 		Display       interface{} `json:"display,omitempty"`
+		OnSuccess     interface{} `json:"on_success,omitempty"`
+		OnFailure     interface{} `json:"on_failure,omitempty"`
+		OnError       interface{} `json:"on_error,omitempty"`
 	}
 
 	var stripped skeletonConfig
@@ -176,29 +204,33 @@ func (c VarSourceConfigs) OrderByDependency() (VarSourceConfigs, error) {
 }
 
 type ResourceConfig struct {
-	Name                 string      `json:"name"`
-	OldName              string      `json:"old_name,omitempty"`
-	Public               bool        `json:"public,omitempty"`
-	WebhookToken         string      `json:"webhook_token,omitempty"`
-	Type                 string      `json:"type"`
-	Source               Source      `json:"source"`
-	CheckEvery           *CheckEvery `json:"check_every,omitempty"`
-	CheckTimeout         string      `json:"check_timeout,omitempty"`
-	Tags                 Tags        `json:"tags,omitempty"`
-	Version              Version     `json:"version,omitempty"`
-	Icon                 string      `json:"icon,omitempty"`
-	ExposeBuildCreatedBy bool        `json:"expose_build_created_by,omitempty"`
+	Name                 string           `json:"name"`
+	OldName              string           `json:"old_name,omitempty"`
+	Public               bool             `json:"public,omitempty"`
+	WebhookToken         string           `json:"webhook_token,omitempty"`
+	Type                 string           `json:"type"`
+	Source               Source           `json:"source"`
+	CheckEvery           *CheckEvery      `json:"check_every,omitempty"`
+	CheckTimeout         string           `json:"check_timeout,omitempty"`
+	CheckLimits          *ContainerLimits `json:"check_container_limits,omitempty"`
+	CheckTagFallback     *TagFallback     `json:"check_tag_fallback,omitempty"`
+	Tags                 Tags             `json:"tags,omitempty"`
+	Version              Version          `json:"version,omitempty"`
+	Icon                 string           `json:"icon,omitempty"`
+	ExposeBuildCreatedBy bool             `json:"expose_build_created_by,omitempty"`
+	UniqueVersionHistory bool             `json:"unique_version_history,omitempty"`
 }
 
 type ResourceType struct {
-	Name       string      `json:"name"`
-	Type       string      `json:"type"`
-	Source     Source      `json:"source"`
-	Defaults   Source      `json:"defaults,omitempty"`
-	Privileged bool        `json:"privileged,omitempty"`
-	CheckEvery *CheckEvery `json:"check_every,omitempty"`
-	Tags       Tags        `json:"tags,omitempty"`
-	Params     Params      `json:"params,omitempty"`
+	Name         string      `json:"name"`
+	Type         string      `json:"type"`
+	Source       Source      `json:"source"`
+	Defaults     Source      `json:"defaults,omitempty"`
+	Privileged   bool        `json:"privileged,omitempty"`
+	CheckEvery   *CheckEvery `json:"check_every,omitempty"`
+	CheckTimeout string      `json:"check_timeout,omitempty"`
+	Tags         Tags        `json:"tags,omitempty"`
+	Params       Params      `json:"params,omitempty"`
 }
 
 type Prototype struct {
@@ -212,6 +244,25 @@ type Prototype struct {
 	Params     Params      `json:"params,omitempty"`
 }
 
+type TagFallbackPolicy string
+
+const (
+	// TagFallbackFail fails the check if no worker with the resource's tags
+	// becomes available in time.
+	TagFallbackFail TagFallbackPolicy = "fail"
+
+	// TagFallbackUntagged runs the check on an untagged worker if no worker
+	// with the resource's tags becomes available in time.
+	TagFallbackUntagged TagFallbackPolicy = "untagged"
+)
+
+// TagFallback configures how long a check waits for a worker with the
+// resource's tags, and what to do once that time has passed.
+type TagFallback struct {
+	Policy TagFallbackPolicy `json:"policy"`
+	After  string            `json:"after"`
+}
+
 type DisplayConfig struct {
 	BackgroundImage string `json:"background_image,omitempty"`
 }
@@ -318,6 +369,7 @@ func (types ResourceTypes) ImageForType(planID PlanID, resourceType string, step
 
 	getPlan, checkPlan := FetchImagePlan(planID, imageResource, types.Without(parent.Name), stepTags, skipInterval, parent.CheckEvery)
 	checkPlan.Check.ResourceType = resourceType
+	checkPlan.Check.Timeout = CheckTimeoutForType(parent.Type, parent.CheckTimeout)
 
 	return TypeImage{
 		// Set the base type as the base type of its parent. The value of the base
@@ -337,6 +389,31 @@ func (types ResourceTypes) ImageForType(planID PlanID, resourceType string, step
 	}
 }
 
+// ImageForPrototype plans the image used to run messages against the given
+// prototype. The prototype's image is fetched like a custom resource type's,
+// except that its versions are checked and saved against the prototype.
+func (types ResourceTypes) ImageForPrototype(planID PlanID, prototype Prototype, stepTags Tags) TypeImage {
+	imageResource := ImageResource{
+		Name:   prototype.Name,
+		Type:   prototype.Type,
+		Source: prototype.Source,
+		Params: prototype.Params,
+		Tags:   prototype.Tags,
+	}
+
+	getPlan, checkPlan := FetchImagePlan(planID, imageResource, types, stepTags, false, prototype.CheckEvery)
+	checkPlan.Check.Prototype = prototype.Name
+
+	return TypeImage{
+		BaseType: getPlan.Get.TypeImage.BaseType,
+
+		Privileged: prototype.Privileged,
+
+		GetPlan:   &getPlan,
+		CheckPlan: checkPlan,
+	}
+}
+
 func FetchImagePlan(planID PlanID, image ImageResource, resourceTypes ResourceTypes, stepTags Tags, skipInterval bool, checkEvery *CheckEvery) (Plan, *Plan) {
 	// If resource type is a custom type, recurse in order to resolve nested resource types
 	getPlanID := planID + "/image-get"
@@ -373,6 +450,8 @@ func FetchImagePlan(planID PlanID, image ImageResource, resourceTypes ResourceTy
 			interval = *checkEvery
 		}
 
+		interval = EnforceMinimumCheckInterval(interval)
+
 		checkPlan := Plan{
 			ID: checkPlanID,
 			Check: &CheckPlan{
@@ -335,5 +335,15 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		displayDiff.Render(indent)
 	}
 
+	if practicallyDifferent(c.PipelineHooks, newConfig.PipelineHooks) {
+		diffExists = true
+		fmt.Fprintln(out, ansi.Color("pipeline hooks have changed:", "yellow"))
+
+		payloadA, _ := yaml.Marshal(c.PipelineHooks)
+		payloadB, _ := yaml.Marshal(newConfig.PipelineHooks)
+
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
 	return diffExists
 }
@@ -216,4 +216,44 @@ var _ = Describe("Config diff", func() {
 			})
 		})
 	})
+
+	Describe("pipeline hooks", func() {
+		var hooks PipelineHooks
+		BeforeEach(func() {
+			hooks = PipelineHooks{
+				OnFailure: &Step{
+					Config: &PutStep{
+						Name: "notify",
+					},
+				},
+			}
+		})
+
+		Context("when there are no pipeline hooks", func() {
+			It("does not print anything about pipeline hooks", func() {
+				buffer := NewBuffer()
+				diff := Config{}.Diff(buffer, Config{})
+				Expect(diff).To(BeFalse())
+				Consistently(buffer).ShouldNot(Say("pipeline hooks"))
+			})
+		})
+
+		Context("when pipeline hooks are added", func() {
+			It("says the pipeline hooks have changed", func() {
+				buffer := NewBuffer()
+				diff := Config{}.Diff(buffer, Config{PipelineHooks: hooks})
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("pipeline hooks have changed:"))
+				Eventually(buffer).Should(Say(`\+.*on_failure:`))
+				Eventually(buffer).Should(Say(`\+.*put: notify`))
+			})
+		})
+
+		Context("when the pipeline hooks are unchanged", func() {
+			It("says there are no changes to apply", func() {
+				diff := Config{PipelineHooks: hooks}.Diff(GinkgoWriter, Config{PipelineHooks: hooks})
+				Expect(diff).To(BeFalse())
+			})
+		})
+	})
 })
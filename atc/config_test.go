@@ -222,5 +222,80 @@ var _ = Describe("Config", func() {
 				})
 			})
 		})
+
+		Context("enforcing the minimum interval", func() {
+			BeforeEach(func() {
+				MinimumCheckInterval = time.Minute
+			})
+
+			AfterEach(func() {
+				MinimumCheckInterval = 0
+			})
+
+			It("raises shorter intervals to the minimum", func() {
+				Expect(EnforceMinimumCheckInterval(CheckEvery{Interval: 10 * time.Second})).To(Equal(CheckEvery{Interval: time.Minute}))
+			})
+
+			It("leaves longer intervals alone", func() {
+				Expect(EnforceMinimumCheckInterval(CheckEvery{Interval: time.Hour})).To(Equal(CheckEvery{Interval: time.Hour}))
+			})
+
+			It("leaves never alone", func() {
+				Expect(EnforceMinimumCheckInterval(CheckEvery{Never: true})).To(Equal(CheckEvery{Never: true}))
+			})
+		})
+	})
+
+	Describe("DefaultCheckIntervalForTeam", func() {
+		BeforeEach(func() {
+			DefaultCheckInterval = time.Minute
+			TeamDefaultCheckIntervals = map[string]time.Duration{"some-team": time.Hour}
+		})
+
+		AfterEach(func() {
+			DefaultCheckInterval = 0
+			TeamDefaultCheckIntervals = nil
+		})
+
+		It("returns the team's default interval", func() {
+			Expect(DefaultCheckIntervalForTeam("some-team")).To(Equal(time.Hour))
+		})
+
+		It("falls back to the global default interval", func() {
+			Expect(DefaultCheckIntervalForTeam("other-team")).To(Equal(time.Minute))
+		})
+	})
+
+	Describe("pipeline hooks", func() {
+		var config Config
+
+		BeforeEach(func() {
+			err := UnmarshalConfig([]byte(`
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+on_failure:
+  put: notify
+`), &config)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("parses them from the top level of the pipeline", func() {
+			Expect(config.OnFailure).To(Equal(&Step{
+				Config: &PutStep{Name: "notify"},
+			}))
+			Expect(config.OnSuccess).To(BeNil())
+			Expect(config.OnError).To(BeNil())
+		})
+
+		It("attaches them to the jobs", func() {
+			Expect(config.Jobs[0].PipelineHooks).To(BeZero())
+
+			jobs := config.JobsWithPipelineHooks()
+			Expect(jobs).To(HaveLen(1))
+			Expect(jobs[0].PipelineHooks).To(Equal(config.PipelineHooks))
+			Expect(jobs[0].Outputs()).To(ConsistOf(JobOutput{Name: "notify", Resource: "notify"}))
+		})
 	})
 })
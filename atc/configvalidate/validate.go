@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
@@ -80,6 +81,12 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	hooksWarnings, hooksErr := validatePipelineHooks(c)
+	if hooksErr != nil {
+		errorMessages = append(errorMessages, formatErr("pipeline hooks", hooksErr))
+	}
+	warnings = append(warnings, hooksWarnings...)
+
 	displayWarnings, displayErr := validateDisplay(c)
 	if displayErr != nil {
 		errorMessages = append(errorMessages, formatErr("display config", displayErr))
@@ -206,6 +213,14 @@ func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if err := validateCheckTimeout(resource.CheckTimeout); err != nil {
+			errorMessages = append(errorMessages, identifier+" "+err.Error())
+		}
+
+		if err := validateCheckTagFallback(resource); err != nil {
+			errorMessages = append(errorMessages, identifier+" "+err.Error())
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -245,6 +260,10 @@ func validateResourceTypes(c atc.Config, seenTypes map[string]location) ([]atc.C
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if err := validateCheckTimeout(resourceType.CheckTimeout); err != nil {
+			errorMessages = append(errorMessages, identifier+" "+err.Error())
+		}
 	}
 
 	return warnings, compositeErr(errorMessages)
@@ -287,6 +306,43 @@ func validatePrototypes(c atc.Config, seenTypes map[string]location) ([]atc.Conf
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateCheckTimeout(checkTimeout string) error {
+	if checkTimeout == "" {
+		return nil
+	}
+
+	timeout, err := time.ParseDuration(checkTimeout)
+	if err != nil || timeout <= 0 {
+		return fmt.Errorf("has invalid check_timeout: '%s'", checkTimeout)
+	}
+
+	return nil
+}
+
+func validateCheckTagFallback(resource atc.ResourceConfig) error {
+	fallback := resource.CheckTagFallback
+	if fallback == nil {
+		return nil
+	}
+
+	if len(resource.Tags) == 0 {
+		return errors.New("has a check_tag_fallback but no tags")
+	}
+
+	switch fallback.Policy {
+	case atc.TagFallbackFail, atc.TagFallbackUntagged:
+	default:
+		return fmt.Errorf("has invalid check_tag_fallback policy: '%s'", fallback.Policy)
+	}
+
+	after, err := time.ParseDuration(fallback.After)
+	if err != nil || after <= 0 {
+		return fmt.Errorf("has invalid check_tag_fallback after: '%s'", fallback.After)
+	}
+
+	return nil
+}
+
 func validateResourcesUnused(c atc.Config) []string {
 	usedResources := usedResources(c)
 
@@ -304,7 +360,7 @@ func validateResourcesUnused(c atc.Config) []string {
 func usedResources(c atc.Config) map[string]bool {
 	usedResources := make(map[string]bool)
 
-	for _, job := range c.Jobs {
+	for _, job := range c.JobsWithPipelineHooks() {
 		_ = job.StepConfig().Visit(atc.StepRecursor{
 			OnGet: func(step *atc.GetStep) error {
 				usedResources[step.ResourceName()] = true
@@ -395,6 +451,54 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 			}
 		}
 
+		if job.TestReportWebhook != "" {
+			webhookURL, err := url.Parse(job.TestReportWebhook)
+			if err != nil || webhookURL.Scheme == "" || webhookURL.Host == "" {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has invalid test_report_webhook: '%s'", job.TestReportWebhook),
+				)
+			}
+		}
+
+		if job.AutoRerun != nil {
+			if job.AutoRerun.Attempts <= 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has non-positive auto_rerun.attempts: %d", job.AutoRerun.Attempts),
+				)
+			}
+
+			if job.AutoRerun.Delay != "" {
+				delay, err := time.ParseDuration(job.AutoRerun.Delay)
+				if err != nil || delay < 0 {
+					errorMessages = append(
+						errorMessages,
+						identifier+fmt.Sprintf(" has invalid auto_rerun.delay: '%s'", job.AutoRerun.Delay),
+					)
+				}
+			}
+
+			for _, trigger := range job.AutoRerun.On {
+				if !isValidAutoRerunTrigger(trigger) {
+					errorMessages = append(
+						errorMessages,
+						identifier+fmt.Sprintf(" has unknown auto_rerun.on value: '%s'", trigger),
+					)
+				}
+			}
+		}
+
+		if job.BuildTimeout != "" {
+			timeout, err := time.ParseDuration(job.BuildTimeout)
+			if err != nil || timeout <= 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has invalid build_timeout: '%s'", job.BuildTimeout),
+				)
+			}
+		}
+
 		step := job.Step()
 
 		validator := atc.NewStepValidator(c, []string{identifier, ".plan"})
@@ -409,6 +513,36 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validatePipelineHooks(c atc.Config) ([]atc.ConfigWarning, error) {
+	var errorMessages []string
+	var warnings []atc.ConfigWarning
+
+	hooks := []struct {
+		name string
+		step *atc.Step
+	}{
+		{"on_success", c.PipelineHooks.OnSuccess},
+		{"on_failure", c.PipelineHooks.OnFailure},
+		{"on_error", c.PipelineHooks.OnError},
+	}
+
+	for _, hook := range hooks {
+		if hook.step == nil {
+			continue
+		}
+
+		validator := atc.NewStepValidator(c, []string{hook.name})
+
+		_ = validator.Validate(*hook.step)
+
+		warnings = append(warnings, validator.Warnings...)
+
+		errorMessages = append(errorMessages, validator.Errors...)
+	}
+
+	return warnings, compositeErr(errorMessages)
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -544,3 +678,13 @@ func validateCycle(c atc.Config) error {
 	}
 	return nil
 }
+
+func isValidAutoRerunTrigger(trigger atc.AutoRerunTrigger) bool {
+	for _, t := range atc.AutoRerunTriggers {
+		if t == trigger {
+			return true
+		}
+	}
+
+	return false
+}
@@ -524,6 +524,65 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has an invalid check_timeout", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckTimeout = "bogus"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid check_timeout: 'bogus'"))
+			})
+		})
+
+		Context("when a resource has a check_tag_fallback", func() {
+			BeforeEach(func() {
+				config.Resources[0].Tags = atc.Tags{"some-tag"}
+				config.Resources[0].CheckTagFallback = &atc.TagFallback{
+					Policy: atc.TagFallbackUntagged,
+					After:  "5m",
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+
+			Context("when the resource has no tags", func() {
+				BeforeEach(func() {
+					config.Resources[0].Tags = nil
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a check_tag_fallback but no tags"))
+				})
+			})
+
+			Context("when the policy is invalid", func() {
+				BeforeEach(func() {
+					config.Resources[0].CheckTagFallback.Policy = "bogus"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid check_tag_fallback policy: 'bogus'"))
+				})
+			})
+
+			Context("when the fallback time is invalid", func() {
+				BeforeEach(func() {
+					config.Resources[0].CheckTagFallback.After = "bogus"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid check_tag_fallback after: 'bogus'"))
+				})
+			})
+		})
+
 		Context("when a resource has no type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, atc.ResourceConfig{
@@ -683,7 +742,7 @@ var _ = Describe("ValidateConfig", func() {
 												},
 											},
 										},
-										Limit:    1,
+										Limit:    &atc.InParallelLimit{Limit: 1},
 										FailFast: true,
 									},
 								},
@@ -815,6 +874,18 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource type has a non-positive check_timeout", func() {
+			BeforeEach(func() {
+				config.ResourceTypes[0].CheckTimeout = "-1m"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resource types:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-resource-type has invalid check_timeout: '-1m'"))
+			})
+		})
+
 		Context("when a resource type has no type", func() {
 			BeforeEach(func() {
 				config.ResourceTypes = append(config.ResourceTypes, atc.ResourceType{
@@ -1030,6 +1101,81 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a put step has get_params with no_get", func() {
+			BeforeEach(func() {
+				job.PlanSequence = append(job.PlanSequence, atc.Step{
+					Config: &atc.PutStep{
+						Name:      "some-resource",
+						GetParams: atc.Params{"some": "params"},
+						NoGet:     true,
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].put(some-resource): `get_params:` cannot be used with `no_get:`"))
+			})
+		})
+
+		Context("when a put step has get_params with targets", func() {
+			BeforeEach(func() {
+				job.PlanSequence = append(job.PlanSequence, atc.Step{
+					Config: &atc.PutStep{
+						Name:      "some-resource",
+						GetParams: atc.Params{"some": "params"},
+						Targets:   []atc.Source{{"space": "some-space"}},
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].put(some-resource): `get_params:` cannot be used with `targets:`"))
+			})
+		})
+
+		Context("when a get step has a negative depth", func() {
+			BeforeEach(func() {
+				job.PlanSequence = append(job.PlanSequence, atc.Step{
+					Config: &atc.GetStep{
+						Name:  "some-resource",
+						Depth: -1,
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource): depth must not be negative"))
+			})
+		})
+
+		Context("when a get step has an incomplete verify", func() {
+			BeforeEach(func() {
+				job.PlanSequence = append(job.PlanSequence, atc.Step{
+					Config: &atc.GetStep{
+						Name:   "some-resource",
+						Verify: &atc.VerifyConfig{},
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).verify: no file specified"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).verify: no sha256 or sha512 digest specified"))
+			})
+		})
+
 		Context("when a job gets the same resource multiple times but with different names", func() {
 			BeforeEach(func() {
 				job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1115,7 +1261,25 @@ var _ = Describe("ValidateConfig", func() {
 					Expect(errorMessages).To(HaveLen(1))
 					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
 					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-resource).config: missing 'platform'"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-resource).config: missing path to executable to run"))
+				})
+			})
+
+			Context("when a task plan has a test report outside of an artifact", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:        "lol",
+							ConfigPath:  "task.yml",
+							TestReports: []string{"junit.xml"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol): test_reports[0]: 'junit.xml' does not specify which artifact the report lives in"))
 				})
 			})
 
@@ -1756,199 +1920,530 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
-			Context("when a retry plan has a negative attempts number", func() {
+			Context("when a plan has a timeout warning longer than the timeout", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.RetryStep{
-							Step: &atc.PutStep{
+						Config: &atc.TimeoutStep{
+							Step: &atc.GetStep{
 								Name: "some-resource",
 							},
-							Attempts: 0,
+							Duration:  "1h",
+							WarnAfter: "2h",
 						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("does return an error", func() {
+				It("throws a validation error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].attempts: must be greater than 0"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].timeout: warn_after '2h' must be shorter than the timeout '1h'"))
 				})
 			})
 
-			Context("when a set_pipeline step has no name or file configured", func() {
+			Context("when a plan has an invalid log limit in a step", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.SetPipelineStep{},
+						Config: &atc.MuteStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Config: atc.MuteConfig{
+								LogLimit: "lots",
+							},
+						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("does return an error", func() {
+				It("throws a validation error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].set_pipeline(): no file specified"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].set_pipeline(): identifier cannot be an empty string"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].mute: invalid log_limit 'lots'"))
 				})
 			})
 
-			Context("when a job's input's passed constraints reference a bogus job", func() {
+			Context("when a plan loops on a var that is never loaded", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.GetStep{
-							Name:   "lol",
-							Passed: []string{"bogus-job"},
+						Config: &atc.WhileStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Config: atc.WhileConfig{
+								Var: "keep-going",
+							},
 						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("returns an error", func() {
+				It("throws a validation error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(lol).passed: unknown job 'bogus-job'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].while.var: local var 'keep-going' is not set by a load_var step"))
 				})
 			})
 
-			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
+			Context("when a plan loops too many times", func() {
 				BeforeEach(func() {
-					config.Jobs[0].PlanSequence = append(config.Jobs[0].PlanSequence, atc.Step{
-						Config: &atc.PutStep{
-							Name:     "custom-name",
-							Resource: "some-resource",
-						},
-					})
-
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.GetStep{
-							Name:   "some-resource",
-							Passed: []string{"some-job"},
+						Config: &atc.WhileStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Config: atc.WhileConfig{
+								MaxIterations: 1000,
+							},
 						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("does not return an error", func() {
-					Expect(errorMessages).To(HaveLen(0))
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].while.max_iterations: must not be greater than 100"))
 				})
 			})
 
-			Context("when a job's input's passed constraints references a valid job that has the resource as an input", func() {
+			Context("when a plan has an invalid condition", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.GetStep{
-							Name:   "some-resource",
-							Passed: []string{"some-job"},
+						Config: &atc.WhenStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Condition: `((branch)) == "main`,
 						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("does not return an error", func() {
-					Expect(errorMessages).To(HaveLen(0))
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring(`jobs.some-other-job.plan.do[0].when: unterminated quote in condition '((branch)) == "main'`))
 				})
 			})
 
-			Context("when a job's input's passed constraints references a valid job that has the resource (with a custom name) as an input", func() {
+			Context("when a plan has a lock without a name", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.GetStep{
-							Name:     "custom-name",
-							Resource: "some-resource",
-							Passed:   []string{"some-job"},
+						Config: &atc.LockStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Config: atc.LockConfig{
+								Count: -1,
+							},
 						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("does not return an error", func() {
-					Expect(errorMessages).To(HaveLen(0))
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].lock: missing name"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].lock: count must be a positive number"))
 				})
 			})
 
-			Context("when a job's input's passed constraints references a valid job that does not have the resource as an input or output", func() {
+			Context("when a plan has an invalid hook timeout", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.GetStep{
-							Name:   "some-resource",
-							Passed: []string{"some-empty-job"},
+						Config: &atc.EnsureStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Hook: atc.Step{
+								Config: &atc.GetStep{
+									Name: "some-resource",
+								},
+							},
+							Timeout: "nope",
 						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("returns an error", func() {
+				It("throws a validation error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).passed: job 'some-empty-job' does not interact with resource 'some-resource'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].ensure_timeout: invalid duration 'nope'"))
 				})
 			})
 
-			Context("when a load_var has no name or file defined", func() {
+			Context("when a retry plan has a negative attempts number", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.LoadVarStep{},
+						Config: &atc.RetryStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Attempts: 0,
+						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("returns an error", func() {
+				It("does return an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_var(): no file specified"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_var(): identifier cannot be an empty string"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].attempts: must be greater than 0"))
 				})
 			})
 
-			Context("when two load_var steps have same name", func() {
+			Context("when a retry plan has an invalid backoff", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.LoadVarStep{
-							Name: "a-var",
-							File: "file1",
-						},
-					}, atc.Step{
-						Config: &atc.LoadVarStep{
-							Name: "a-var",
-							File: "file1",
+						Config: &atc.RetryStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Attempts: 3,
+							Backoff: &atc.RetryBackoff{
+								Initial:    "nope",
+								Multiplier: 0.5,
+								Max:        "1m",
+							},
 						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("returns an error", func() {
+				It("does return an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[1].load_var(a-var): repeated var name"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].backoff: invalid initial delay 'nope'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].backoff: multiplier must be at least 1"))
 				})
 			})
 
-			Context("when a step has unknown fields", func() {
+			Context("when an in_parallel step has an invalid limit", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.TaskStep{
-							Name:       "task",
-							ConfigPath: "some-file",
+						Config: &atc.InParallelStep{
+							Config: atc.InParallelConfig{
+								Steps: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name: "some-resource",
+										},
+									},
+								},
+								Limit: &atc.InParallelLimit{Var: "lots"},
+							},
 						},
-						UnknownFields: map[string]*json.RawMessage{"bogus": nil},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("returns an error", func() {
+				It("does return an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring(`jobs.some-other-job.plan.do[0]: unknown fields ["bogus"]`))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].in_parallel.limit: must be a number or a var, got 'lots'"))
 				})
 			})
 
-			Context("when an across step is valid", func() {
+			Context("when a retry plan has invalid retry conditions", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.AcrossStep{
+						Config: &atc.RetryStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Attempts: 3,
+							RetryOn: []atc.RetryCondition{
+								{Class: "flakes"},
+								{Message: "("},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].retry_on[0]: unknown class of failure 'flakes' (must be one of timeout, worker-disappeared, resource-script-failure)"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].retry_on[1]: invalid message pattern"))
+				})
+			})
+
+			Context("when a set_pipeline step has no name or file configured", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.SetPipelineStep{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].set_pipeline(): no file specified"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].set_pipeline(): identifier cannot be an empty string"))
+				})
+			})
+
+			Context("when a set_pipeline step mixes instance_vars with a fan-out", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.SetPipelineStep{
+							Name:             "some-pipeline",
+							File:             "some-resource/pipeline.yml",
+							InstanceVars:     atc.InstanceVars{"branch": "main"},
+							InstanceVarsFile: "some-resource/instances.yml",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].set_pipeline(some-pipeline): cannot specify `instance_vars:` alongside `instance_vars_list:` or `instance_vars_file:`"))
+				})
+			})
+
+			Context("when a set_pipeline step prunes instances without fanning out", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.SetPipelineStep{
+							Name:           "some-pipeline",
+							File:           "some-resource/pipeline.yml",
+							PruneInstances: true,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].set_pipeline(some-pipeline): `prune_instances:` requires `instance_vars_list:` or `instance_vars_file:`"))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a bogus job", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:   "lol",
+							Passed: []string{"bogus-job"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(lol).passed: unknown job 'bogus-job'"))
+				})
+			})
+
+			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
+				BeforeEach(func() {
+					config.Jobs[0].PlanSequence = append(config.Jobs[0].PlanSequence, atc.Step{
+						Config: &atc.PutStep{
+							Name:     "custom-name",
+							Resource: "some-resource",
+						},
+					})
+
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:   "some-resource",
+							Passed: []string{"some-job"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints references a valid job that has the resource as an input", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:   "some-resource",
+							Passed: []string{"some-job"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints references a valid job that has the resource (with a custom name) as an input", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:     "custom-name",
+							Resource: "some-resource",
+							Passed:   []string{"some-job"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints references a valid job that does not have the resource as an input or output", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:   "some-resource",
+							Passed: []string{"some-empty-job"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).passed: job 'some-empty-job' does not interact with resource 'some-resource'"))
+				})
+			})
+
+			Context("when a load_var has no name or file defined", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarStep{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_var(): no file specified"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_var(): identifier cannot be an empty string"))
+				})
+			})
+
+			Context("when a load_var with multi_document has a non-yaml format", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarStep{
+							Name:          "some-var",
+							File:          "some-file",
+							Format:        "json",
+							MultiDocument: true,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_var(some-var): `multi_document:` cannot be used with format 'json'"))
+				})
+			})
+
+			Context("when a notify step has no targets", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.NotifyStep{
+							Name: "some-notification",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].notify(some-notification): no targets specified"))
+				})
+			})
+
+			Context("when a notify step has invalid targets", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.NotifyStep{
+							Name: "some-notification",
+							Targets: []atc.NotifyTarget{
+								{Type: "slack", URL: "some-url"},
+								{Type: "carrier-pigeon"},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].notify(some-notification).targets[1]: unknown type 'carrier-pigeon' (must be one of: slack, http)"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].notify(some-notification).targets[1]: no url specified"))
+					Expect(errorMessages[0]).ToNot(ContainSubstring("targets[0]"))
+				})
+			})
+
+			Context("when two load_var steps have same name", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarStep{
+							Name: "a-var",
+							File: "file1",
+						},
+					}, atc.Step{
+						Config: &atc.LoadVarStep{
+							Name: "a-var",
+							File: "file1",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[1].load_var(a-var): repeated var name"))
+				})
+			})
+
+			Context("when a step has unknown fields", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "task",
+							ConfigPath: "some-file",
+						},
+						UnknownFields: map[string]*json.RawMessage{"bogus": nil},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring(`jobs.some-other-job.plan.do[0]: unknown fields ["bogus"]`))
+				})
+			})
+
+			Context("when an across step is valid", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
 							Step: &atc.PutStep{
 								Name: "some-resource",
 							},
@@ -2112,6 +2607,132 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when an across step takes values from an unknown resource", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var: "var",
+									ValuesFrom: &atc.AcrossValuesFrom{
+										Resource: "bogus-resource",
+									},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].values_from: unknown resource 'bogus-resource'"))
+				})
+			})
+
+			Context("when an across step takes values from a var source without naming the var", func() {
+				BeforeEach(func() {
+					config.VarSources = append(config.VarSources, atc.VarSourceConfig{
+						Name: "some-source",
+						Type: "dummy",
+						Config: map[string]interface{}{
+							"vars": map[string]interface{}{"branches": []interface{}{"main"}},
+						},
+					})
+
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var: "var",
+									ValuesFrom: &atc.AcrossValuesFrom{
+										VarSource: "some-source",
+									},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].values_from: var_source requires var"))
+				})
+			})
+
+			Context("when an across step takes values from both values and values_from", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:    "var",
+									Values: []interface{}{"a"},
+									ValuesFrom: &atc.AcrossValuesFrom{
+										Resource: "some-resource",
+									},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0]: cannot specify both values and values_from"))
+				})
+			})
+
+			Context("when an across step has invalid include and exclude rules", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:    "os",
+									Values: []interface{}{"linux", "windows"},
+								},
+								{
+									Var:    "arch",
+									Values: []interface{}{"amd64", "arm64"},
+								},
+							},
+							Exclude: []atc.AcrossCombination{
+								{"os": "windows", "bogus": "value"},
+							},
+							Include: []atc.AcrossCombination{
+								{"os": "darwin"},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across.exclude[0]: unknown var 'bogus'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across.include[0]: missing var 'arch'"))
+				})
+			})
+
 			Context("when the across step is not enabled", func() {
 				BeforeEach(func() {
 					atc.EnableAcrossStep = false
@@ -2190,6 +2811,121 @@ var _ = Describe("ValidateConfig", func() {
 				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has negative build_log_retention.days: -1"))
 			})
 		})
+
+		Context("when a job has an invalid test_report_webhook", func() {
+			BeforeEach(func() {
+				config.Jobs[0].TestReportWebhook = "not-a-url"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has invalid test_report_webhook: 'not-a-url'"))
+			})
+		})
+
+		Context("when a job has a valid auto_rerun", func() {
+			BeforeEach(func() {
+				config.Jobs[0].AutoRerun = &atc.AutoRerunConfig{
+					Attempts: 2,
+					Delay:    "1m",
+					On:       []atc.AutoRerunTrigger{atc.AutoRerunOnWorkerLost},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job has an invalid auto_rerun", func() {
+			BeforeEach(func() {
+				config.Jobs[0].AutoRerun = &atc.AutoRerunConfig{
+					Attempts: 0,
+					Delay:    "soon",
+					On:       []atc.AutoRerunTrigger{"bogus"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has non-positive auto_rerun.attempts: 0"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has invalid auto_rerun.delay: 'soon'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has unknown auto_rerun.on value: 'bogus'"))
+			})
+		})
+
+		Context("when a job has an invalid build_timeout", func() {
+			BeforeEach(func() {
+				config.Jobs[0].BuildTimeout = "forever"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has invalid build_timeout: 'forever'"))
+			})
+		})
+
+		Context("when a job has a non-positive build_timeout", func() {
+			BeforeEach(func() {
+				config.Jobs[0].BuildTimeout = "-1h"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has invalid build_timeout: '-1h'"))
+			})
+		})
+	})
+
+	Describe("validating pipeline hooks", func() {
+		Context("when a hook puts to a resource that exists", func() {
+			BeforeEach(func() {
+				config.PipelineHooks.OnFailure = &atc.Step{
+					Config: &atc.PutStep{
+						Name: "some-resource",
+					},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a hook puts to a resource that does not exist", func() {
+			BeforeEach(func() {
+				config.PipelineHooks.OnSuccess = &atc.Step{
+					Config: &atc.PutStep{
+						Name: "some-nonexistent-resource",
+					},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid pipeline hooks:"))
+				Expect(errorMessages[0]).To(ContainSubstring("on_success.put(some-nonexistent-resource): unknown resource 'some-nonexistent-resource'"))
+			})
+		})
+
+		Context("when a resource is only used by a hook", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, atc.ResourceConfig{
+					Name: "some-notification",
+					Type: "some-type",
+				})
+
+				config.PipelineHooks.OnError = &atc.Step{
+					Config: &atc.PutStep{
+						Name: "some-notification",
+					},
+				}
+			})
+
+			It("does not consider it unused", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
 	})
 
 	Describe("validating display config", func() {
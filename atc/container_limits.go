@@ -10,6 +10,19 @@ import (
 
 var memoryRegex = regexp.MustCompile(`^([0-9]+)([GMK]?[B])?$`)
 
+var (
+	// OutputVolumeQuota and CacheVolumeQuota are the number of bytes the
+	// volumes created for the outputs and caches of steps are limited to, on
+	// workers whose volume driver can enforce it. 0 means no limit.
+	OutputVolumeQuota uint64
+	CacheVolumeQuota  uint64
+
+	// WorkerResourceCacheSizeLimit is the number of bytes the resource caches
+	// on each worker may take up before the least recently used ones are
+	// evicted. 0 means no limit.
+	WorkerResourceCacheSizeLimit uint64
+)
+
 type ContainerLimits struct {
 	CPU    *CPULimit    `json:"cpu,omitempty"`
 	Memory *MemoryLimit `json:"memory,omitempty"`
@@ -0,0 +1,124 @@
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/flag"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// ErrUnverifiableImage is returned for images which must be signed but whose
+// digest isn't known, e.g. rootfs_uri images or images produced by tasks.
+var ErrUnverifiableImage = errors.New("image signature cannot be verified: only images pulled from registries, either by digest or by image resources, can be verified")
+
+// UnsignedImageError is returned when an image does not carry a valid
+// signature from any of the keys it must be signed with.
+type UnsignedImageError struct {
+	Repository string
+	Digest     string
+	Reason     string
+}
+
+func (err UnsignedImageError) Error() string {
+	return fmt.Sprintf("image %s@%s is not signed by a trusted key: %s", err.Repository, err.Digest, err.Reason)
+}
+
+type Config struct {
+	PublicKeys     []flag.File       `long:"image-signature-public-key" description:"File containing a PEM-encoded ECDSA, RSA or ed25519 public key. Images for tasks and custom resource types of every team must carry a valid cosign signature from one of the keys. Can be specified multiple times."`
+	TeamPublicKeys map[string]string `long:"team-image-signature-public-key" description:"File containing a PEM-encoded public key which the given team's images may also be signed with. Teams given a key must use signed images even if no --image-signature-public-key is given. Can be specified once per team." value-name:"TEAM:PATH"`
+	Timeout        time.Duration     `long:"image-signature-timeout" default:"1m" description:"Maximum duration of fetching an image's signatures from its registry."`
+}
+
+func (c Config) IsConfigured() bool {
+	return len(c.PublicKeys) > 0 || len(c.TeamPublicKeys) > 0
+}
+
+// NewVerifier loads the configured keys into a Verifier, or returns nil if
+// image signatures are not verified.
+func (c Config) NewVerifier() (Verifier, error) {
+	if !c.IsConfigured() {
+		return nil, nil
+	}
+
+	var keys []crypto.PublicKey
+	for _, file := range c.PublicKeys {
+		key, err := loadPublicKey(file.Path())
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	teamKeys := map[string][]crypto.PublicKey{}
+	for team, path := range c.TeamPublicKeys {
+		key, err := loadPublicKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", team, err)
+		}
+
+		teamKeys[team] = append(teamKeys[team], key)
+	}
+
+	return NewVerifier(keys, teamKeys, c.Timeout), nil
+}
+
+//counterfeiter:generate . Verifier
+
+// Verifier checks that images carry cosign signatures from the keys their
+// team's images must be signed with.
+type Verifier interface {
+	// Required returns whether the team's images must be signed.
+	Required(teamName string) bool
+
+	// Verify returns an UnsignedImageError unless the image has a valid
+	// signature from one of the team's keys.
+	Verify(ctx context.Context, teamName string, image atc.RegistryImage) error
+}
+
+// ParsePublicKey parses a PEM-encoded ECDSA, RSA or ed25519 public key, as
+// generated by `cosign generate-key-pair`.
+func ParsePublicKey(payload []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(payload)
+	if block == nil {
+		return nil, errors.New("public key is not PEM-encoded")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("public key must be ECDSA, RSA or ed25519, got %T", key)
+	}
+}
+
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParsePublicKey(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image signature public key %s: %w", path, err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,13 @@
+package cosign_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCosign(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cosign Suite")
+}
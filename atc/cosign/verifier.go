@@ -0,0 +1,176 @@
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
+)
+
+const (
+	// SignatureAnnotation holds the base64-encoded signature of each layer
+	// of a signature manifest.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// signatureType is the type of the payloads cosign signs.
+	signatureType = "cosign container image signature"
+)
+
+// SignatureTag is the tag cosign pushes an image's signatures to, in the
+// same repository as the image.
+func SignatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sig"
+}
+
+// Payload is the "simple signing" payload cosign signs, which claims the
+// image with the digest.
+type Payload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+type verifier struct {
+	keys       []crypto.PublicKey
+	teamKeys   map[string][]crypto.PublicKey
+	httpClient *http.Client
+}
+
+// NewVerifier constructs a Verifier which requires every team's images to be
+// signed by one of the keys, and the images of the teams in teamKeys to be
+// signed by one of those keys or one of theirs.
+func NewVerifier(keys []crypto.PublicKey, teamKeys map[string][]crypto.PublicKey, timeout time.Duration) Verifier {
+	return &verifier{
+		keys:       keys,
+		teamKeys:   teamKeys,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+func (v *verifier) Required(teamName string) bool {
+	return len(v.keysFor(teamName)) > 0
+}
+
+func (v *verifier) Verify(ctx context.Context, teamName string, image atc.RegistryImage) error {
+	keys := v.keysFor(teamName)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	err := registry.ValidateDigest(image.Digest)
+	if err != nil {
+		return err
+	}
+
+	reference, err := registry.ParseRepository(image.Repository)
+	if err != nil {
+		return err
+	}
+
+	client := registry.NewClient(v.httpClient, reference, image.Username, image.Password, image.Insecure)
+
+	manifest, err := client.TaggedManifest(ctx, SignatureTag(image.Digest))
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return v.unsigned(image, "no signatures found")
+		}
+
+		return fmt.Errorf("fetch signatures: %w", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, found := layer.Annotations[SignatureAnnotation]
+		if !found {
+			continue
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+
+		// the payload is fetched by its digest, so it's verified even though
+		// the manifest listing it can't be
+		payload, err := client.ReadBlob(ctx, layer.Digest)
+		if err != nil {
+			return fmt.Errorf("fetch signature payload: %w", err)
+		}
+
+		if !claimsImage(payload, image.Digest) {
+			continue
+		}
+
+		for _, key := range keys {
+			if verifySignature(key, payload, signature) {
+				return nil
+			}
+		}
+	}
+
+	return v.unsigned(image, "no valid signatures found")
+}
+
+func (v *verifier) keysFor(teamName string) []crypto.PublicKey {
+	teamKeys := v.teamKeys[teamName]
+	if len(teamKeys) == 0 {
+		return v.keys
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(v.keys)+len(teamKeys))
+	keys = append(keys, v.keys...)
+	return append(keys, teamKeys...)
+}
+
+func (v *verifier) unsigned(image atc.RegistryImage, reason string) error {
+	return UnsignedImageError{
+		Repository: image.Repository,
+		Digest:     image.Digest,
+		Reason:     reason,
+	}
+}
+
+// claimsImage returns whether the payload is a signature of the image with
+// the digest, rather than e.g. another image in the same repository.
+func claimsImage(payload []byte, digest string) bool {
+	var claim Payload
+	err := json.Unmarshal(payload, &claim)
+	if err != nil {
+		return false
+	}
+
+	return claim.Critical.Type == signatureType &&
+		claim.Critical.Image.DockerManifestDigest == digest
+}
+
+func verifySignature(key crypto.PublicKey, payload []byte, signature []byte) bool {
+	hash := sha256.Sum256(payload)
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, hash[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	default:
+		return false
+	}
+}
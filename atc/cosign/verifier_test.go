@@ -0,0 +1,195 @@
+package cosign_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/cosign"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry/registrytest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Verifier", func() {
+	var (
+		reg *registrytest.Registry
+
+		trustedKey   *ecdsa.PrivateKey
+		teamKey      ed25519.PrivateKey
+		untrustedKey *ecdsa.PrivateKey
+
+		verifier cosign.Verifier
+		image    atc.RegistryImage
+	)
+
+	payloadFor := func(digest string) []byte {
+		var payload cosign.Payload
+		payload.Critical.Identity.DockerReference = image.Repository
+		payload.Critical.Image.DockerManifestDigest = digest
+		payload.Critical.Type = "cosign container image signature"
+
+		bytes, err := json.Marshal(payload)
+		Expect(err).ToNot(HaveOccurred())
+
+		return bytes
+	}
+
+	signECDSA := func(key *ecdsa.PrivateKey, payload []byte) []byte {
+		hash := sha256.Sum256(payload)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		Expect(err).ToNot(HaveOccurred())
+		return signature
+	}
+
+	// sign pushes a signature manifest for the image, as cosign does
+	sign := func(payload []byte, signature []byte) {
+		manifest := registry.Manifest{
+			MediaType: registry.MediaTypeOCIManifest,
+			Config: registry.Descriptor{
+				MediaType: "application/vnd.oci.image.config.v1+json",
+				Digest:    reg.AddBlob([]byte(`{}`), "application/octet-stream"),
+				Size:      2,
+			},
+			Layers: []registry.Descriptor{{
+				MediaType: "application/vnd.dev.cosign.simplesigning.v1+json",
+				Digest:    reg.AddBlob(payload, "application/octet-stream"),
+				Size:      int64(len(payload)),
+				Annotations: map[string]string{
+					cosign.SignatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+				},
+			}},
+		}
+
+		manifestBytes, err := json.Marshal(manifest)
+		Expect(err).ToNot(HaveOccurred())
+
+		reg.Tag(cosign.SignatureTag(image.Digest), reg.AddBlob(manifestBytes, registry.MediaTypeOCIManifest))
+	}
+
+	BeforeEach(func() {
+		reg = registrytest.NewRegistry()
+
+		var err error
+		trustedKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		untrustedKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, teamKey, err = ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		verifier = cosign.NewVerifier(
+			[]crypto.PublicKey{&trustedKey.PublicKey},
+			map[string][]crypto.PublicKey{"some-team": {teamKey.Public()}},
+			time.Minute,
+		)
+
+		image = atc.RegistryImage{
+			Repository: reg.Host() + "/some/image",
+			Digest:     reg.AddImage([]byte(`{}`), registrytest.Layer(map[string]string{"some-file": "some-content"})),
+			Insecure:   true,
+		}
+	})
+
+	AfterEach(func() {
+		reg.Close()
+	})
+
+	It("accepts images signed by a trusted key", func() {
+		payload := payloadFor(image.Digest)
+		sign(payload, signECDSA(trustedKey, payload))
+
+		Expect(verifier.Verify(context.Background(), "other-team", image)).To(Succeed())
+	})
+
+	It("accepts images signed by the team's key", func() {
+		payload := payloadFor(image.Digest)
+		sign(payload, ed25519.Sign(teamKey, payload))
+
+		Expect(verifier.Verify(context.Background(), "some-team", image)).To(Succeed())
+	})
+
+	It("rejects images signed by another team's key", func() {
+		payload := payloadFor(image.Digest)
+		sign(payload, ed25519.Sign(teamKey, payload))
+
+		err := verifier.Verify(context.Background(), "other-team", image)
+		Expect(err).To(BeAssignableToTypeOf(cosign.UnsignedImageError{}))
+	})
+
+	It("rejects images signed by an untrusted key", func() {
+		payload := payloadFor(image.Digest)
+		sign(payload, signECDSA(untrustedKey, payload))
+
+		err := verifier.Verify(context.Background(), "other-team", image)
+		Expect(err).To(Equal(cosign.UnsignedImageError{
+			Repository: image.Repository,
+			Digest:     image.Digest,
+			Reason:     "no valid signatures found",
+		}))
+	})
+
+	It("rejects signatures of other images", func() {
+		payload := payloadFor("sha256:" + fmt.Sprintf("%064d", 0))
+		sign(payload, signECDSA(trustedKey, payload))
+
+		err := verifier.Verify(context.Background(), "other-team", image)
+		Expect(err).To(BeAssignableToTypeOf(cosign.UnsignedImageError{}))
+	})
+
+	It("rejects images without signatures", func() {
+		err := verifier.Verify(context.Background(), "other-team", image)
+		Expect(err).To(Equal(cosign.UnsignedImageError{
+			Repository: image.Repository,
+			Digest:     image.Digest,
+			Reason:     "no signatures found",
+		}))
+	})
+
+	Context("when no keys apply to the team", func() {
+		BeforeEach(func() {
+			verifier = cosign.NewVerifier(nil, map[string][]crypto.PublicKey{"some-team": {teamKey.Public()}}, time.Minute)
+		})
+
+		It("does not require its images to be signed", func() {
+			Expect(verifier.Required("other-team")).To(BeFalse())
+			Expect(verifier.Verify(context.Background(), "other-team", image)).To(Succeed())
+
+			Expect(verifier.Required("some-team")).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("ParsePublicKey", func() {
+	It("parses PEM-encoded ECDSA keys", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		parsed, err := cosign.ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed).To(Equal(&key.PublicKey))
+	})
+
+	It("rejects keys which aren't PEM-encoded", func() {
+		_, err := cosign.ParsePublicKey([]byte("nope"))
+		Expect(err).To(HaveOccurred())
+	})
+})
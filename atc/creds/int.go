@@ -0,0 +1,46 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/vars"
+)
+
+type Int struct {
+	variablesResolver vars.Variables
+	rawCredInt        string
+}
+
+func NewInt(variables vars.Variables, credInt string) Int {
+	return Int{
+		variablesResolver: variables,
+		rawCredInt:        credInt,
+	}
+}
+
+// Evaluate resolves the vars in the raw value, which must resolve to either a
+// number or a string holding one.
+func (i Int) Evaluate() (int, error) {
+	var value interface{}
+
+	err := evaluate(i.variablesResolver, i.rawCredInt, &value)
+	if err != nil {
+		return 0, err
+	}
+
+	switch actual := value.(type) {
+	case json.Number:
+		n, err := actual.Int64()
+		if err != nil {
+			return 0, err
+		}
+
+		return int(n), nil
+	case string:
+		return strconv.Atoi(actual)
+	default:
+		return 0, fmt.Errorf("'%v' is not a number", value)
+	}
+}
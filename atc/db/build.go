@@ -90,11 +90,18 @@ var buildsQuery = psql.Select(`
 		b.nonce,
 		b.drained,
 		b.aborted,
+		b.paused,
+		b.admitted,
 		b.completed,
 		b.inputs_ready,
 		b.rerun_of,
 		rb.name,
 		b.rerun_number,
+		b.auto_rerun_reason,
+		b.resumed_from,
+		b.priority,
+		b.preempted_by,
+		b.start_after,
 		b.span_context,
 		COALESCE(bc.comment, '')
 	`).
@@ -124,6 +131,7 @@ type Build interface {
 	TeamName() string
 
 	Job() (Job, bool, error)
+	ResumedFromBuild() (Build, bool, error)
 	JobID() int
 	JobName() string
 
@@ -151,6 +159,11 @@ type Build interface {
 	RerunOf() int
 	RerunOfName() string
 	RerunNumber() int
+	AutoRerunReason() string
+	ResumedFrom() int
+	Priority() int
+	PreemptedBy() int
+	StartAfter() time.Time
 	CreatedBy() *string
 
 	LagerData() lager.Data
@@ -176,10 +189,15 @@ type Build interface {
 	SetInterceptible(bool) error
 
 	Events(uint) (EventSource, error)
+	SavedEvents() ([]event.Envelope, error)
 	SaveEvent(event atc.Event) error
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
+	SaveStoredArtifact(name string, storeKey string) (WorkerArtifact, error)
+
+	SaveTestResults([]atc.TestResult) error
+	TestResults() ([]atc.TestResult, error)
 
 	SaveOutput(string, ResourceCache, atc.Source, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
@@ -190,9 +208,21 @@ type Build interface {
 
 	Delete() (bool, error)
 	MarkAsAborted() error
+	Preempt(byBuildID int) (bool, error)
 	IsAborted() bool
 	AbortNotifier() (Notifier, error)
 
+	Admit(limit int) (bool, error)
+	IsAdmitted() bool
+	IsQueued() bool
+	RunningDuration() (time.Duration, error)
+	SetWaitingForWorker(planID atc.PlanID, waiting bool) error
+
+	Pause() error
+	Resume() error
+	IsPaused() bool
+	ResumeNotifier() (Notifier, error)
+
 	IsDrained() bool
 	SetDrained(bool) error
 
@@ -236,6 +266,12 @@ type build struct {
 	rerunOfName string
 	rerunNumber int
 
+	autoRerunReason string
+	resumedFrom     int
+	priority        int
+	preemptedBy     int
+	startAfter      time.Time
+
 	schema      string
 	privatePlan atc.Plan
 	publicPlan  *json.RawMessage
@@ -247,6 +283,8 @@ type build struct {
 
 	drained   bool
 	aborted   bool
+	paused    bool
+	admitted  bool
 	completed bool
 
 	spanContext SpanContext
@@ -357,22 +395,29 @@ func (b *build) HasPlan() bool                { return string(*b.publicPlan) !=
 func (b *build) IsNewerThanLastCheckOf(input Resource) bool {
 	return b.createTime.After(input.LastCheckEndTime())
 }
-func (b *build) CreateTime() time.Time { return b.createTime }
-func (b *build) StartTime() time.Time  { return b.startTime }
-func (b *build) EndTime() time.Time    { return b.endTime }
-func (b *build) ReapTime() time.Time   { return b.reapTime }
-func (b *build) Comment() string       { return b.comment }
-func (b *build) Status() BuildStatus   { return b.status }
-func (b *build) IsScheduled() bool     { return b.scheduled }
-func (b *build) IsDrained() bool       { return b.drained }
-func (b *build) IsRunning() bool       { return !b.completed }
-func (b *build) IsAborted() bool       { return b.aborted }
-func (b *build) IsCompleted() bool     { return b.completed }
-func (b *build) InputsReady() bool     { return b.inputsReady }
-func (b *build) RerunOf() int          { return b.rerunOf }
-func (b *build) RerunOfName() string   { return b.rerunOfName }
-func (b *build) RerunNumber() int      { return b.rerunNumber }
-func (b *build) CreatedBy() *string    { return b.createdBy }
+func (b *build) CreateTime() time.Time   { return b.createTime }
+func (b *build) StartTime() time.Time    { return b.startTime }
+func (b *build) EndTime() time.Time      { return b.endTime }
+func (b *build) ReapTime() time.Time     { return b.reapTime }
+func (b *build) Comment() string         { return b.comment }
+func (b *build) Status() BuildStatus     { return b.status }
+func (b *build) IsScheduled() bool       { return b.scheduled }
+func (b *build) IsDrained() bool         { return b.drained }
+func (b *build) IsRunning() bool         { return !b.completed }
+func (b *build) IsAborted() bool         { return b.aborted }
+func (b *build) IsPaused() bool          { return b.paused }
+func (b *build) IsAdmitted() bool        { return b.admitted }
+func (b *build) IsCompleted() bool       { return b.completed }
+func (b *build) InputsReady() bool       { return b.inputsReady }
+func (b *build) RerunOf() int            { return b.rerunOf }
+func (b *build) RerunOfName() string     { return b.rerunOfName }
+func (b *build) RerunNumber() int        { return b.rerunNumber }
+func (b *build) AutoRerunReason() string { return b.autoRerunReason }
+func (b *build) ResumedFrom() int        { return b.resumedFrom }
+func (b *build) Priority() int           { return b.priority }
+func (b *build) PreemptedBy() int        { return b.preemptedBy }
+func (b *build) StartAfter() time.Time   { return b.startAfter }
+func (b *build) CreatedBy() *string      { return b.createdBy }
 
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
@@ -428,6 +473,30 @@ func (b *build) Job() (Job, bool, error) {
 	return job, true, nil
 }
 
+// ResumedFromBuild returns the build that this build was rerun from the failed
+// step of, if any.
+func (b *build) ResumedFromBuild() (Build, bool, error) {
+	if b.resumedFrom == 0 {
+		return nil, false, nil
+	}
+
+	row := buildsQuery.Where(sq.Eq{"b.id": b.resumedFrom}).
+		RunWith(b.conn).
+		QueryRow()
+
+	resumedFrom := newEmptyBuild(b.conn, b.lockFactory)
+	err := scanBuild(resumedFrom, row, b.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return resumedFrom, true, nil
+}
+
 func (b *build) SetComment(comment string) error {
 	_, err := psql.Insert("build_comments").
 		Columns("build_id", "comment").
@@ -611,6 +680,11 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	err = clearWorkerWaits(tx, b.id)
+	if err != nil {
+		return err
+	}
+
 	if b.jobID != 0 && status == BuildStatusSucceeded {
 		_, err = psql.Delete("build_image_resource_caches").
 			Where(sq.And{
@@ -817,6 +891,30 @@ func (b *build) SetDrained(drained bool) error {
 	return err
 }
 
+// SetWaitingForWorker records whether the given step of the build is waiting
+// for a worker with room for it. The time a step started waiting is kept until
+// it stops waiting, so that preemption can tell how long the build has waited.
+func (b *build) SetWaitingForWorker(planID atc.PlanID, waiting bool) error {
+	if !waiting {
+		_, err := psql.Delete("build_worker_waits").
+			Where(sq.Eq{
+				"build_id": b.id,
+				"plan_id":  string(planID),
+			}).
+			RunWith(b.conn).
+			Exec()
+		return err
+	}
+
+	_, err := psql.Insert("build_worker_waits").
+		Columns("build_id", "plan_id").
+		Values(b.id, string(planID)).
+		Suffix("ON CONFLICT (build_id, plan_id) DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -863,6 +961,11 @@ func (b *build) MarkAsAborted() error {
 		return err
 	}
 
+	err = clearWorkerWaits(tx, b.id)
+	if err != nil {
+		return err
+	}
+
 	if b.status == BuildStatusPending {
 		err = requestSchedule(tx, b.jobID)
 		if err != nil {
@@ -878,6 +981,67 @@ func (b *build) MarkAsAborted() error {
 	return b.conn.Bus().Notify(buildAbortChannel(b.id))
 }
 
+// Preempt aborts the running build to make room for the given build of a
+// higher-priority job, recording which build it was preempted by so that it
+// can be re-queued once it has finished aborting. It returns false if the build
+// has already finished or been aborted.
+func (b *build) Preempt(byBuildID int) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("builds").
+		Set("aborted", true).
+		Set("preempted_by", byBuildID).
+		Where(sq.Eq{
+			"id":        b.id,
+			"aborted":   false,
+			"completed": false,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = clearWorkerWaits(tx, b.id)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	b.aborted = true
+	b.preemptedBy = byBuildID
+
+	return true, b.conn.Bus().Notify(buildAbortChannel(b.id))
+}
+
+// clearWorkerWaits forgets the steps of a build which were waiting for a
+// worker, as nothing will stop them waiting once the build is over.
+func clearWorkerWaits(tx Tx, buildID int) error {
+	_, err := psql.Delete("build_worker_waits").
+		Where(sq.Eq{"build_id": buildID}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
 // AbortNotifier returns a Notifier that can be watched for when the build
 // is marked as aborted. Once the build is marked as aborted it will send a
 // notification to finish the build to ATC that is tracking this build.
@@ -895,6 +1059,153 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+// IsQueued returns whether the build has been started, but is waiting for
+// the team to have room for it to run.
+func (b *build) IsQueued() bool {
+	return b.status == BuildStatusStarted && !b.admitted && !b.isForCheck()
+}
+
+// Admit marks the started build as admitted to run, unless the team already
+// has the given number of admitted builds running. A limit of 0 means the
+// team's builds are not limited. Check builds do not count towards the limit.
+func (b *build) Admit(limit int) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	if limit > 0 {
+		// serialize admissions for the team, so that concurrent admissions
+		// cannot exceed the limit
+		_, err = psql.Select("id").
+			From("teams").
+			Where(sq.Eq{"id": b.teamID}).
+			Suffix("FOR UPDATE").
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return false, err
+		}
+
+		var running int
+		err = psql.Select("COUNT(*)").
+			From("builds").
+			Where(sq.Eq{
+				"team_id":          b.teamID,
+				"status":           BuildStatusStarted,
+				"admitted":         true,
+				"resource_id":      nil,
+				"resource_type_id": nil,
+			}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&running)
+		if err != nil {
+			return false, err
+		}
+
+		if running >= limit {
+			return false, nil
+		}
+	}
+
+	// the build's running time counts from when it is admitted, so any time it
+	// spent paused while queued is not counted again
+	_, err = psql.Update("builds").
+		Set("admitted", true).
+		Set("admit_time", sq.Expr("now()")).
+		Set("pause_time", sq.Expr("CASE WHEN paused THEN now() END")).
+		Set("paused_duration", sq.Expr("'0'")).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	b.admitted = true
+
+	return true, nil
+}
+
+// RunningDuration returns how long the build has been running since it was
+// admitted, not counting the time it spent paused. Builds which were never
+// admitted, such as check builds, count from when they started.
+func (b *build) RunningDuration() (time.Duration, error) {
+	var seconds float64
+	err := psql.Select("EXTRACT(EPOCH FROM now() - COALESCE(admit_time, start_time, now()) - paused_duration - COALESCE(now() - pause_time, '0'))").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&seconds)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// Pause marks the build as paused. A running build will not start any more
+// steps until it is resumed.
+func (b *build) Pause() error {
+	return b.setPaused(true)
+}
+
+// Resume marks the build as no longer paused and notifies the ATC tracking the
+// build, so that it can continue running its steps.
+func (b *build) Resume() error {
+	return b.setPaused(false)
+}
+
+func (b *build) setPaused(paused bool) error {
+	update := psql.Update("builds").
+		Set("paused", paused)
+
+	if paused {
+		update = update.Set("pause_time", sq.Expr("COALESCE(pause_time, now())"))
+	} else {
+		update = update.
+			Set("paused_duration", sq.Expr("paused_duration + COALESCE(now() - pause_time, '0')")).
+			Set("pause_time", nil)
+	}
+
+	_, err := update.
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	b.paused = paused
+
+	return b.conn.Bus().Notify(buildPauseChannel(b.id))
+}
+
+// ResumeNotifier returns a Notifier that can be watched for when the build is
+// not paused. It notifies right away if the build is not paused to begin with.
+func (b *build) ResumeNotifier() (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildPauseChannel(b.id), func() (bool, error) {
+		var resumed bool
+		err := psql.Select("paused = false").
+			From("builds").
+			Where(sq.Eq{"id": b.id}).
+			RunWith(b.conn).
+			QueryRow().
+			Scan(&resumed)
+
+		return resumed, err
+	})
+}
+
 func (b *build) SaveImageResourceVersion(rc ResourceCache) error {
 	var jobID sql.NullInt64
 	if b.jobID != 0 {
@@ -1111,6 +1422,49 @@ func (b *build) Events(from uint) (EventSource, error) {
 	), nil
 }
 
+// SavedEvents returns the events saved for the build so far. Unlike Events,
+// it does not wait for any more events to be saved while the build is running.
+func (b *build) SavedEvents() ([]event.Envelope, error) {
+	rows, err := psql.Select("event_id", "type", "version", "payload").
+		From(b.eventsTable()).
+		Where(sq.Or{
+			sq.Eq{"build_id": b.id},
+			sq.Eq{"build_id_old": b.id},
+		}).
+		OrderBy("event_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	envelopes := []event.Envelope{}
+	for rows.Next() {
+		var (
+			id      int
+			t, v, p string
+		)
+
+		err := rows.Scan(&id, &t, &v, &p)
+		if err != nil {
+			return nil, err
+		}
+
+		data := json.RawMessage(p)
+
+		envelopes = append(envelopes, event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+			EventID: strconv.Itoa(id),
+		})
+	}
+
+	return envelopes, rows.Err()
+}
+
 func (b *build) SaveEvent(event atc.Event) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -1138,21 +1492,53 @@ func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 		conn: b.conn,
 	}
 
-	err := psql.Select("id", "name", "created_at").
+	var storeKey sql.NullString
+	var teamID sql.NullInt64
+
+	err := psql.Select("id", "name", "created_at", "store_key", "team_id").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"id": artifactID,
 		}).
 		RunWith(b.conn).
-		Scan(&artifact.id, &artifact.name, &artifact.createdAt)
+		Scan(&artifact.id, &artifact.name, &artifact.createdAt, &storeKey, &teamID)
+
+	artifact.storeKey = storeKey.String
+	artifact.teamID = int(teamID.Int64)
 
 	return &artifact, err
 }
 
+// SaveStoredArtifact saves an artifact of the build which is kept in the
+// external artifact store under storeKey.
+func (b *build) SaveStoredArtifact(name string, storeKey string) (WorkerArtifact, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	artifact, err := saveStoredWorkerArtifact(tx, b.conn, atc.WorkerArtifact{
+		Name:    name,
+		BuildID: b.id,
+	}, storeKey, b.teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
 func (b *build) Artifacts() ([]WorkerArtifact, error) {
 	artifacts := []WorkerArtifact{}
 
-	rows, err := psql.Select("id", "name", "created_at").
+	rows, err := psql.Select("id", "name", "created_at", "store_key", "team_id").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"build_id": b.id,
@@ -1171,17 +1557,99 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 			buildID: b.id,
 		}
 
-		err = rows.Scan(&wa.id, &wa.name, &wa.createdAt)
+		var storeKey sql.NullString
+		var teamID sql.NullInt64
+
+		err = rows.Scan(&wa.id, &wa.name, &wa.createdAt, &storeKey, &teamID)
 		if err != nil {
 			return nil, err
 		}
 
+		wa.storeKey = storeKey.String
+		wa.teamID = int(teamID.Int64)
+
 		artifacts = append(artifacts, &wa)
 	}
 
 	return artifacts, nil
 }
 
+// testResultsBatchSize is how many test results are inserted per statement,
+// keeping well below postgres' limit of 65535 parameters per statement.
+const testResultsBatchSize = 1000
+
+func (b *build) SaveTestResults(results []atc.TestResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for start := 0; start < len(results); start += testResultsBatchSize {
+		end := start + testResultsBatchSize
+		if end > len(results) {
+			end = len(results)
+		}
+
+		query := psql.Insert("build_test_results").
+			Columns("build_id", "plan_id", "suite", "class_name", "name", "status", "duration", "message")
+
+		for _, result := range results[start:end] {
+			query = query.Values(
+				b.id,
+				string(result.PlanID),
+				result.Suite,
+				result.ClassName,
+				result.Name,
+				string(result.Status),
+				result.Duration,
+				result.Message,
+			)
+		}
+
+		_, err = query.RunWith(tx).Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) TestResults() ([]atc.TestResult, error) {
+	rows, err := psql.Select("plan_id", "suite", "class_name", "name", "status", "duration", "message").
+		From("build_test_results").
+		Where(sq.Eq{
+			"build_id": b.id,
+		}).
+		OrderBy("id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	results := []atc.TestResult{}
+	for rows.Next() {
+		var result atc.TestResult
+		err = rows.Scan(&result.PlanID, &result.Suite, &result.ClassName, &result.Name, &result.Status, &result.Duration, &result.Message)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 func (b *build) SaveOutput(
 	resourceType string,
 	imageResourceCache ResourceCache,
@@ -1792,13 +2260,14 @@ func buildEventSeq(buildid int) string {
 
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber               sql.NullInt64
+		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber, resumedFrom  sql.NullInt64
+		preemptedBy                                                                       sql.NullInt64
 		schema, privatePlan, jobName, resourceName, pipelineName, publicPlan, rerunOfName sql.NullString
-		createTime, startTime, endTime, reapTime                                          pq.NullTime
+		createTime, startTime, endTime, reapTime, startAfter                              pq.NullTime
 		nonce, spanContext, createdBy                                                     sql.NullString
-		drained, aborted, completed                                                       bool
+		drained, aborted, paused, admitted, completed                                     bool
 		status                                                                            string
-		pipelineInstanceVars, comment                                                     sql.NullString
+		pipelineInstanceVars, comment, autoRerunReason                                    sql.NullString
 	)
 
 	err := row.Scan(
@@ -1828,11 +2297,18 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&nonce,
 		&drained,
 		&aborted,
+		&paused,
+		&admitted,
 		&completed,
 		&b.inputsReady,
 		&rerunOf,
 		&rerunOfName,
 		&rerunNumber,
+		&autoRerunReason,
+		&resumedFrom,
+		&b.priority,
+		&preemptedBy,
+		&startAfter,
 		&spanContext,
 		&comment,
 	)
@@ -1855,10 +2331,16 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.reapTime = reapTime.Time
 	b.drained = drained
 	b.aborted = aborted
+	b.paused = paused
+	b.admitted = admitted
 	b.completed = completed
 	b.rerunOf = int(rerunOf.Int64)
 	b.rerunOfName = rerunOfName.String
 	b.rerunNumber = int(rerunNumber.Int64)
+	b.autoRerunReason = autoRerunReason.String
+	b.resumedFrom = int(resumedFrom.Int64)
+	b.preemptedBy = int(preemptedBy.Int64)
+	b.startAfter = startAfter.Time
 	b.comment = comment.String
 
 	var (
@@ -2044,6 +2526,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildPauseChannel(buildID int) string {
+	return fmt.Sprintf("build_pause_%d", buildID)
+}
+
 func latestCompletedNonRerunBuild(tx Tx, jobID int) (int, error) {
 	var latestNonRerunId int
 	err := latestCompletedBuildQuery.
@@ -125,9 +125,11 @@ func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 }
 
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
+	// oldest first, so that queued builds are admitted in the order they were
+	// started
 	query := buildsQuery.Where(sq.Eq{
 		"b.status": BuildStatusStarted,
-	})
+	}).OrderBy("b.id ASC")
 
 	return getBuilds(query, f.conn, f.lockFactory)
 }
@@ -999,6 +999,169 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Admit", func() {
+		var otherBuild db.Build
+
+		BeforeEach(func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			otherBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err = otherBuild.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			found, err := otherBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("is queued until it is admitted", func() {
+			Expect(otherBuild.IsQueued()).To(BeTrue())
+
+			admitted, err := otherBuild.Admit(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(admitted).To(BeTrue())
+
+			found, err := otherBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(otherBuild.IsAdmitted()).To(BeTrue())
+			Expect(otherBuild.IsQueued()).To(BeFalse())
+		})
+
+		Context("when the team has as many builds running as its limit", func() {
+			BeforeEach(func() {
+				admitted, err := build.Admit(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+			})
+
+			It("does not admit the build", func() {
+				admitted, err := otherBuild.Admit(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeFalse())
+			})
+
+			It("admits the build once a running build finishes", func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				admitted, err := otherBuild.Admit(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+			})
+
+			It("admits the build if the limit is higher", func() {
+				admitted, err := otherBuild.Admit(2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Preempt", func() {
+		var otherBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			otherBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("aborts the build, recording the build it was preempted by", func() {
+			preempted, err := build.Preempt(otherBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preempted).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(build.IsAborted()).To(BeTrue())
+			Expect(build.PreemptedBy()).To(Equal(otherBuild.ID()))
+		})
+
+		It("notifies that the build is aborted", func() {
+			notifier, err := build.AbortNotifier()
+			Expect(err).NotTo(HaveOccurred())
+			defer notifier.Close()
+
+			_, err = build.Preempt(otherBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(notifier.Notify()).Should(Receive())
+		})
+
+		Context("when the build has already been aborted", func() {
+			BeforeEach(func() {
+				err := build.MarkAsAborted()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not preempt it", func() {
+				preempted, err := build.Preempt(otherBuild.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(preempted).To(BeFalse())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.PreemptedBy()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("Pause", func() {
+		JustBeforeEach(func() {
+			err := build.Pause()
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("updates paused to true", func() {
+			Expect(build.IsPaused()).To(BeTrue())
+		})
+
+		It("does not notify that the build is resumed", func() {
+			notifier, err := build.ResumeNotifier()
+			Expect(err).NotTo(HaveOccurred())
+			defer notifier.Close()
+
+			Consistently(notifier.Notify()).ShouldNot(Receive())
+		})
+
+		Context("when the build is resumed", func() {
+			It("updates paused to false", func() {
+				err := build.Resume()
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(build.IsPaused()).To(BeFalse())
+			})
+
+			It("notifies that the build is resumed", func() {
+				notifier, err := build.ResumeNotifier()
+				Expect(err).NotTo(HaveOccurred())
+				defer notifier.Close()
+
+				err = build.Resume()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			By("allowing you to subscribe when no events have yet occurred")
@@ -1062,6 +1225,54 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SavedEvents", func() {
+		It("returns the events saved so far without waiting for the build to finish", func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = build.SaveEvent(event.Log{Payload: "hello"})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.SavedEvents()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]event.Envelope{
+				envelope(event.Status{
+					Status: atc.StatusStarted,
+					Time:   build.StartTime().Unix(),
+				}, "0"),
+				envelope(event.Log{Payload: "hello"}, "1"),
+			}))
+		})
+	})
+
+	Describe("SaveTestResults", func() {
+		It("saves more results than fit in a single statement", func() {
+			var results []atc.TestResult
+			for i := 0; i < 10000; i++ {
+				results = append(results, atc.TestResult{
+					PlanID:    "some-plan",
+					Suite:     "some-suite",
+					ClassName: "some-class",
+					Name:      fmt.Sprintf("test-%d", i),
+					Status:    atc.TestStatusPassed,
+					Duration:  1.5,
+				})
+			}
+
+			err := build.SaveTestResults(results)
+			Expect(err).NotTo(HaveOccurred())
+
+			saved, err := build.TestResults()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(Equal(results))
+		})
+	})
+
 	Describe("SaveEvent", func() {
 		It("saves and propagates events correctly", func() {
 			By("allowing you to subscribe when no events have yet occurred")
@@ -38,7 +38,9 @@ type Checkable interface {
 type CheckFactory interface {
 	TryCreateCheck(context.Context, Checkable, ResourceTypes, atc.Version, bool, bool) (Build, bool, error)
 	Resources() ([]Resource, error)
+	ResourcesByIDs([]int) ([]Resource, error)
 	ResourceTypesByPipeline() (map[int]ResourceTypes, error)
+	RunningChecksByPipeline() (map[int]int, error)
 }
 
 type checkFactory struct {
@@ -68,6 +70,24 @@ func NewCheckFactory(
 	}
 }
 
+// checkInterval returns the interval on which the checkable is checked
+// periodically.
+func checkInterval(checkable Checkable) atc.CheckEvery {
+	interval := atc.CheckEvery{
+		Interval: atc.DefaultCheckIntervalForTeam(checkable.TeamName()),
+	}
+
+	if checkable.HasWebhook() {
+		interval.Interval = atc.DefaultWebhookInterval
+	}
+
+	if checkable.CheckEvery() != nil {
+		interval = *checkable.CheckEvery()
+	}
+
+	return atc.EnforceMinimumCheckInterval(interval)
+}
+
 func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable, resourceTypes ResourceTypes, from atc.Version, manuallyTriggered bool, skipIntervalRecursively bool) (Build, bool, error) {
 	logger := lagerctx.FromContext(ctx)
 
@@ -84,20 +104,11 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 		}
 	}
 
-	interval := atc.CheckEvery{
-		Interval: atc.DefaultCheckInterval,
-	}
-
-	if checkable.HasWebhook() {
-		interval.Interval = atc.DefaultWebhookInterval
-	}
-
-	if checkable.CheckEvery() != nil {
-		interval = *checkable.CheckEvery()
-	}
+	interval := checkInterval(checkable)
 
 	skipInterval := manuallyTriggered
-	if !skipInterval && time.Now().Before(checkable.LastCheckEndTime().Add(interval.Interval)) {
+	lastCheckEnd := checkable.LastCheckEndTime()
+	if !skipInterval && time.Now().Before(lastCheckEnd.Add(atc.JitterCheckInterval(interval.Interval, checkable.ResourceConfigScopeID(), lastCheckEnd))) {
 		// skip creating the check if its interval hasn't elapsed yet
 		return nil, false, nil
 	}
@@ -119,6 +130,16 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 }
 
 func (c *checkFactory) Resources() ([]Resource, error) {
+	return c.checkableResources(sq.And{})
+}
+
+// ResourcesByIDs returns those of the given resources which would be returned
+// by Resources, so that they can be checked without loading every resource.
+func (c *checkFactory) ResourcesByIDs(ids []int) ([]Resource, error) {
+	return c.checkableResources(sq.Eq{"r.id": ids})
+}
+
+func (c *checkFactory) checkableResources(filter sq.Sqlizer) ([]Resource, error) {
 	var resources []Resource
 
 	rows, err := resourcesQuery.
@@ -138,6 +159,7 @@ func (c *checkFactory) Resources() ([]Resource, error) {
 				sq.Eq{"ji.resource_id": nil},
 			},
 		}).
+		Where(filter).
 		RunWith(c.conn).
 		Query()
 
@@ -188,3 +210,36 @@ func (c *checkFactory) ResourceTypesByPipeline() (map[int]ResourceTypes, error)
 
 	return resourceTypes, nil
 }
+
+// RunningChecksByPipeline returns the number of check builds of each pipeline
+// which have not completed yet, keyed by pipeline ID.
+func (c *checkFactory) RunningChecksByPipeline() (map[int]int, error) {
+	rows, err := psql.Select("pipeline_id", "COUNT(1)").
+		From("builds").
+		Where(sq.Eq{
+			"name":      CheckBuildName,
+			"completed": false,
+		}).
+		Where(sq.NotEq{"pipeline_id": nil}).
+		GroupBy("pipeline_id").
+		RunWith(c.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	running := map[int]int{}
+	for rows.Next() {
+		var pipelineID, count int
+		err = rows.Scan(&pipelineID, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		running[pipelineID] = count
+	}
+
+	return running, nil
+}
@@ -27,6 +27,8 @@ var _ = Describe("CheckFactory", func() {
 	AfterEach(func() {
 		atc.DefaultCheckInterval = 0
 		atc.DefaultWebhookInterval = 0
+		atc.MinimumCheckInterval = 0
+		atc.TeamDefaultCheckIntervals = nil
 	})
 
 	Describe("TryCreateCheck", func() {
@@ -177,6 +179,46 @@ var _ = Describe("CheckFactory", func() {
 				})
 			})
 
+			Context("when the interval is shorter than the minimum", func() {
+				BeforeEach(func() {
+					atc.MinimumCheckInterval = time.Minute
+					fakeResource.CheckEveryReturns(&atc.CheckEvery{Interval: 10 * time.Second})
+				})
+
+				It("raises it to the minimum in the check plan", func() {
+					Expect(fakeResource.CheckPlanCallCount()).To(Equal(1))
+					_, _, _, interval, _, _, _ := fakeResource.CheckPlanArgsForCall(0)
+					Expect(interval.Interval).To(Equal(time.Minute))
+				})
+			})
+
+			Context("when the resource's team has a default interval", func() {
+				BeforeEach(func() {
+					fakeResource.TeamNameReturns("some-team")
+					atc.TeamDefaultCheckIntervals = map[string]time.Duration{
+						"some-team": 5 * time.Minute,
+					}
+				})
+
+				It("uses the team's default interval in the check plan", func() {
+					Expect(fakeResource.CheckPlanCallCount()).To(Equal(1))
+					_, _, _, interval, _, _, _ := fakeResource.CheckPlanArgsForCall(0)
+					Expect(interval.Interval).To(Equal(5 * time.Minute))
+				})
+
+				Context("when an interval is specified", func() {
+					BeforeEach(func() {
+						fakeResource.CheckEveryReturns(&atc.CheckEvery{Interval: 42 * time.Second})
+					})
+
+					It("uses the specified interval", func() {
+						Expect(fakeResource.CheckPlanCallCount()).To(Equal(1))
+						_, _, _, interval, _, _, _ := fakeResource.CheckPlanArgsForCall(0)
+						Expect(interval.Interval).To(Equal(42 * time.Second))
+					})
+				})
+			})
+
 			Context("when CheckEvery is never", func() {
 				BeforeEach(func() {
 					fakeResource.CheckEveryReturns(&atc.CheckEvery{Never: true})
@@ -400,6 +442,128 @@ var _ = Describe("CheckFactory", func() {
 		})
 	})
 
+	Describe("ResourcesByIDs", func() {
+		var (
+			resources    []db.Resource
+			ids          []int
+			someResource db.Resource
+		)
+
+		BeforeEach(func() {
+			defaultPipelineConfig = atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{Config: &atc.GetStep{Name: "some-resource"}},
+							{Config: &atc.GetStep{Name: "some-other-resource"}},
+							{Config: &atc.PutStep{Name: "some-put-only-resource"}},
+						},
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "source"},
+					},
+					{
+						Name:   "some-other-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "other-source"},
+					},
+					{
+						Name:   "some-put-only-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "source"},
+					},
+				},
+			}
+
+			defaultPipelineRef = atc.PipelineRef{Name: "default-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+			defaultPipeline, _, err = defaultTeam.SavePipeline(defaultPipelineRef, defaultPipelineConfig, db.ConfigVersion(1), false)
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			someResource, found, err = defaultPipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			putOnlyResource, found, err := defaultPipeline.Resource("some-put-only-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			ids = []int{someResource.ID(), putOnlyResource.ID()}
+		})
+
+		JustBeforeEach(func() {
+			resources, err = checkFactory.ResourcesByIDs(ids)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns only the given resources which are in use", func() {
+			Expect(resources).To(HaveLen(1))
+			Expect(resources[0].ID()).To(Equal(someResource.ID()))
+		})
+
+		Context("when the resource pipeline is paused", func() {
+			BeforeEach(func() {
+				_, err = dbConn.Exec(`UPDATE pipelines SET paused = true`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return the resource", func() {
+				Expect(resources).To(BeEmpty())
+			})
+		})
+
+		Context("when given no resources", func() {
+			BeforeEach(func() {
+				ids = nil
+			})
+
+			It("returns nothing", func() {
+				Expect(resources).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("RunningChecksByPipeline", func() {
+		var running map[int]int
+
+		JustBeforeEach(func() {
+			running, err = checkFactory.RunningChecksByPipeline()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when there are no check builds", func() {
+			It("returns no pipelines", func() {
+				Expect(running).To(BeEmpty())
+			})
+		})
+
+		Context("when there are check builds", func() {
+			BeforeEach(func() {
+				build, created, err = defaultResource.CreateBuild(context.TODO(), true, atc.Plan{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				build, created, err = defaultResource.CreateBuild(context.TODO(), true, atc.Plan{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				finished, created, err := defaultResource.CreateBuild(context.TODO(), true, atc.Plan{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(finished.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			})
+
+			It("counts the ones which have not completed", func() {
+				Expect(running).To(Equal(map[int]int{defaultPipeline.ID(): 2}))
+			})
+		})
+	})
+
 	Describe("ResourceTypes", func() {
 		var (
 			resourceTypes    map[int]db.ResourceTypes
@@ -1,12 +1,28 @@
 package db
 
-import "code.cloudfoundry.org/lager"
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
 
 var CheckDeleteBatchSize = 500
 
+// CheckBuildRetention bounds the completed check builds kept for each
+// resource and resource type. The latest check build of each is always kept.
+type CheckBuildRetention struct {
+	// Count is the number of check builds to keep. Values below 1 keep only
+	// the latest one.
+	Count int
+
+	// MaxAge is the age past which check builds are deleted even if they are
+	// within Count. 0 means no limit.
+	MaxAge time.Duration
+}
+
 //counterfeiter:generate . CheckLifecycle
 type CheckLifecycle interface {
-	DeleteCompletedChecks(logger lager.Logger) error
+	DeleteCompletedChecks(logger lager.Logger, retention CheckBuildRetention) error
 }
 
 type checkLifecycle struct {
@@ -19,7 +35,14 @@ func NewCheckLifecycle(conn Conn) CheckLifecycle {
 	}
 }
 
-func (cl *checkLifecycle) DeleteCompletedChecks(logger lager.Logger) error {
+func (cl *checkLifecycle) DeleteCompletedChecks(logger lager.Logger, retention CheckBuildRetention) error {
+	count := retention.Count
+	if count < 1 {
+		count = 1
+	}
+
+	maxAge := int64(retention.MaxAge.Seconds())
+
 	var counter int
 	for {
 		var numChecksDeleted int
@@ -29,25 +52,27 @@ func (cl *checkLifecycle) DeleteCompletedChecks(logger lager.Logger) error {
         FROM resources
         WHERE build_id IS NOT NULL
       ),
+      check_builds AS (
+        SELECT id, completed, end_time,
+          row_number() OVER (PARTITION BY resource_id, resource_type_id ORDER BY id DESC) AS position
+        FROM builds
+        WHERE resource_id IS NOT NULL OR resource_type_id IS NOT NULL
+      ),
       deleted_builds AS (
         DELETE FROM builds USING (
-          (SELECT id
-          FROM builds b
-          WHERE completed AND resource_id IS NOT NULL
-          AND NOT EXISTS ( SELECT 1 FROM resource_builds WHERE build_id = b.id )
-					LIMIT $1)
-            UNION ALL
           SELECT id
-          FROM builds b
-          WHERE completed AND resource_type_id IS NOT NULL
-          AND EXISTS (SELECT * FROM builds b2 WHERE b.resource_type_id = b2.resource_type_id AND b.id < b2.id)
-    ) AS deletable_builds WHERE builds.id = deletable_builds.id
-      RETURNING builds.id
+          FROM check_builds b
+          WHERE completed AND position > 1
+          AND NOT EXISTS ( SELECT 1 FROM resource_builds WHERE build_id = b.id )
+          AND (position > $2 OR ($3 > 0 AND end_time < now() - $3 * interval '1 second'))
+          LIMIT $1
+        ) AS deletable_builds WHERE builds.id = deletable_builds.id
+        RETURNING builds.id
       ), deleted_events AS (
         DELETE FROM check_build_events USING deleted_builds WHERE build_id = deleted_builds.id
       )
       SELECT COUNT(*) FROM deleted_builds
-    `, CheckDeleteBatchSize).Scan(&numChecksDeleted)
+    `, CheckDeleteBatchSize, count, maxAge).Scan(&numChecksDeleted)
 		if err != nil {
 			return err
 		}
@@ -2,6 +2,7 @@ package db_test
 
 import (
 	"context"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -13,11 +14,13 @@ import (
 var _ = Describe("Check Lifecycle", func() {
 	var (
 		lifecycle db.CheckLifecycle
+		retention db.CheckBuildRetention
 		plan      atc.Plan
 	)
 
 	BeforeEach(func() {
 		lifecycle = db.NewCheckLifecycle(dbConn)
+		retention = db.CheckBuildRetention{}
 		plan = atc.Plan{
 			ID: "some-plan",
 			Check: &atc.CheckPlan{
@@ -60,7 +63,7 @@ var _ = Describe("Check Lifecycle", func() {
 		resourceTypeBuild := createFinishedCheck(defaultResourceType, plan)
 
 		By("attempting to delete completed checks when there are no newer checks")
-		err := lifecycle.DeleteCompletedChecks(logger)
+		err := lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(resourceBuild)).To(BeTrue())
 		Expect(exists(resourceTypeBuild)).To(BeTrue())
@@ -69,7 +72,7 @@ var _ = Describe("Check Lifecycle", func() {
 		createFinishedCheck(defaultResource, plan)
 
 		By("deleting completed checks")
-		err = lifecycle.DeleteCompletedChecks(logger)
+		err = lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 
 		Expect(exists(resourceBuild)).To(BeFalse())
@@ -81,7 +84,7 @@ var _ = Describe("Check Lifecycle", func() {
 		createFinishedCheck(defaultResourceType, plan)
 
 		By("deleting completed checks")
-		err = lifecycle.DeleteCompletedChecks(logger)
+		err = lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 
 		Expect(exists(resourceTypeBuild)).To(BeFalse())
@@ -91,7 +94,7 @@ var _ = Describe("Check Lifecycle", func() {
 		createFinishedCheck(defaultPrototype, plan)
 
 		By("deleting completed checks")
-		err = lifecycle.DeleteCompletedChecks(logger)
+		err = lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 	})
 
@@ -102,7 +105,7 @@ var _ = Describe("Check Lifecycle", func() {
 		}
 
 		By("deleting completed checks")
-		err := lifecycle.DeleteCompletedChecks(logger)
+		err := lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 
 		By("verifying all checks but the latest were deleted")
@@ -117,7 +120,7 @@ var _ = Describe("Check Lifecycle", func() {
 		c1 := createUnfinishedCheck(defaultResource, plan)
 		c2 := createUnfinishedCheck(defaultResource, plan)
 
-		err := lifecycle.DeleteCompletedChecks(logger)
+		err := lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(c1)).To(BeTrue())
 		Expect(exists(c2)).To(BeTrue())
@@ -125,7 +128,7 @@ var _ = Describe("Check Lifecycle", func() {
 		By("finishing the first check should allow it to be deleted")
 		finish(c1)
 
-		err = lifecycle.DeleteCompletedChecks(logger)
+		err = lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(c1)).To(BeFalse())
 		Expect(exists(c2)).To(BeTrue())
@@ -133,7 +136,7 @@ var _ = Describe("Check Lifecycle", func() {
 		By("finishing the second check should NOT allow it to be deleted")
 		finish(c2)
 
-		err = lifecycle.DeleteCompletedChecks(logger)
+		err = lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(c2)).To(BeTrue())
 	})
@@ -144,12 +147,56 @@ var _ = Describe("Check Lifecycle", func() {
 
 		createFinishedCheck(defaultResource, plan)
 
-		err := lifecycle.DeleteCompletedChecks(logger)
+		err := lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(c1)).To(BeFalse())
 		Expect(exists(c2)).To(BeFalse())
 	})
 
+	Context("when retaining more than one check build", func() {
+		BeforeEach(func() {
+			retention.Count = 2
+		})
+
+		It("keeps that many of the latest check builds", func() {
+			c1 := createFinishedCheck(defaultResource, plan)
+			c2 := createFinishedCheck(defaultResource, plan)
+			c3 := createFinishedCheck(defaultResource, plan)
+
+			t1 := createFinishedCheck(defaultResourceType, plan)
+			t2 := createFinishedCheck(defaultResourceType, plan)
+
+			err := lifecycle.DeleteCompletedChecks(logger, retention)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists(c1)).To(BeFalse())
+			Expect(exists(c2)).To(BeTrue())
+			Expect(exists(c3)).To(BeTrue())
+			Expect(exists(t1)).To(BeTrue())
+			Expect(exists(t2)).To(BeTrue())
+		})
+
+		Context("when check builds are older than the max age", func() {
+			BeforeEach(func() {
+				retention.MaxAge = time.Hour
+			})
+
+			It("deletes them, except for the latest", func() {
+				c1 := createFinishedCheck(defaultResource, plan)
+				c2 := createFinishedCheck(defaultResource, plan)
+				c3 := createFinishedCheck(defaultResource, plan)
+
+				_, err := dbConn.Exec(`UPDATE builds SET end_time = now() - interval '2 hours' WHERE id IN ($1, $2)`, c2.ID(), c3.ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				err = lifecycle.DeleteCompletedChecks(logger, retention)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists(c1)).To(BeFalse())
+				Expect(exists(c2)).To(BeFalse())
+				Expect(exists(c3)).To(BeTrue())
+			})
+		})
+	})
+
 	It("ignores job builds", func() {
 		build, err := defaultJob.CreateBuild("foo")
 		Expect(err).ToNot(HaveOccurred())
@@ -161,7 +208,7 @@ var _ = Describe("Check Lifecycle", func() {
 		_, err = defaultJob.CreateBuild("foo")
 		Expect(err).ToNot(HaveOccurred())
 
-		err = lifecycle.DeleteCompletedChecks(logger)
+		err = lifecycle.DeleteCompletedChecks(logger, retention)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(build)).To(BeTrue())
 	})
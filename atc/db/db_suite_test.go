@@ -47,6 +47,7 @@ var (
 	workerTaskCacheFactory              db.WorkerTaskCacheFactory
 	userFactory                         db.UserFactory
 	dbWall                              db.Wall
+	workerEnrollmentFactory             db.WorkerEnrollmentFactory
 	fakeClock                           dbfakes.FakeClock
 
 	builder dbtest.Builder
@@ -124,6 +125,7 @@ var _ = BeforeEach(func() {
 	workerTaskCacheFactory = db.NewWorkerTaskCacheFactory(dbConn)
 	userFactory = db.NewUserFactory(dbConn)
 	dbWall = db.NewWall(dbConn, &fakeClock)
+	workerEnrollmentFactory = db.NewWorkerEnrollmentFactory(dbConn, &fakeClock)
 
 	builder = dbtest.NewBuilder(dbConn, lockFactory)
 
@@ -46,6 +46,19 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	AdmitStub        func(int) (bool, error)
+	admitMutex       sync.RWMutex
+	admitArgsForCall []struct {
+		arg1 int
+	}
+	admitReturns struct {
+		result1 bool
+		result2 error
+	}
+	admitReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	AdoptInputsAndPipesStub        func() ([]db.BuildInput, bool, error)
 	adoptInputsAndPipesMutex       sync.RWMutex
 	adoptInputsAndPipesArgsForCall []struct {
@@ -99,6 +112,16 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	AutoRerunReasonStub        func() string
+	autoRerunReasonMutex       sync.RWMutex
+	autoRerunReasonArgsForCall []struct {
+	}
+	autoRerunReasonReturns struct {
+		result1 string
+	}
+	autoRerunReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
 	CommentStub        func() string
 	commentMutex       sync.RWMutex
 	commentArgsForCall []struct {
@@ -227,6 +250,16 @@ type FakeBuild struct {
 	isAbortedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsAdmittedStub        func() bool
+	isAdmittedMutex       sync.RWMutex
+	isAdmittedArgsForCall []struct {
+	}
+	isAdmittedReturns struct {
+		result1 bool
+	}
+	isAdmittedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsCompletedStub        func() bool
 	isCompletedMutex       sync.RWMutex
 	isCompletedArgsForCall []struct {
@@ -268,6 +301,26 @@ type FakeBuild struct {
 	isNewerThanLastCheckOfReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsPausedStub        func() bool
+	isPausedMutex       sync.RWMutex
+	isPausedArgsForCall []struct {
+	}
+	isPausedReturns struct {
+		result1 bool
+	}
+	isPausedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	IsQueuedStub        func() bool
+	isQueuedMutex       sync.RWMutex
+	isQueuedArgsForCall []struct {
+	}
+	isQueuedReturns struct {
+		result1 bool
+	}
+	isQueuedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsRunningStub        func() bool
 	isRunningMutex       sync.RWMutex
 	isRunningArgsForCall []struct {
@@ -352,6 +405,16 @@ type FakeBuild struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PauseStub        func() error
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+	}
+	pauseReturns struct {
+		result1 error
+	}
+	pauseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -406,6 +469,29 @@ type FakeBuild struct {
 	pipelineRefReturnsOnCall map[int]struct {
 		result1 atc.PipelineRef
 	}
+	PreemptStub        func(int) (bool, error)
+	preemptMutex       sync.RWMutex
+	preemptArgsForCall []struct {
+		arg1 int
+	}
+	preemptReturns struct {
+		result1 bool
+		result2 error
+	}
+	preemptReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	PreemptedByStub        func() int
+	preemptedByMutex       sync.RWMutex
+	preemptedByArgsForCall []struct {
+	}
+	preemptedByReturns struct {
+		result1 int
+	}
+	preemptedByReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PreparationStub        func() (db.BuildPreparation, bool, error)
 	preparationMutex       sync.RWMutex
 	preparationArgsForCall []struct {
@@ -420,6 +506,16 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct {
+	}
+	priorityReturns struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PrivatePlanStub        func() atc.Plan
 	privatePlanMutex       sync.RWMutex
 	privatePlanArgsForCall []struct {
@@ -548,6 +644,64 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	ResumeStub        func() error
+	resumeMutex       sync.RWMutex
+	resumeArgsForCall []struct {
+	}
+	resumeReturns struct {
+		result1 error
+	}
+	resumeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResumeNotifierStub        func() (db.Notifier, error)
+	resumeNotifierMutex       sync.RWMutex
+	resumeNotifierArgsForCall []struct {
+	}
+	resumeNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	resumeNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	ResumedFromStub        func() int
+	resumedFromMutex       sync.RWMutex
+	resumedFromArgsForCall []struct {
+	}
+	resumedFromReturns struct {
+		result1 int
+	}
+	resumedFromReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ResumedFromBuildStub        func() (db.Build, bool, error)
+	resumedFromBuildMutex       sync.RWMutex
+	resumedFromBuildArgsForCall []struct {
+	}
+	resumedFromBuildReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	resumedFromBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	RunningDurationStub        func() (time.Duration, error)
+	runningDurationMutex       sync.RWMutex
+	runningDurationArgsForCall []struct {
+	}
+	runningDurationReturns struct {
+		result1 time.Duration
+		result2 error
+	}
+	runningDurationReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 error
+	}
 	SaveEventStub        func(atc.Event) error
 	saveEventMutex       sync.RWMutex
 	saveEventArgsForCall []struct {
@@ -606,6 +760,43 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveStoredArtifactStub        func(string, string) (db.WorkerArtifact, error)
+	saveStoredArtifactMutex       sync.RWMutex
+	saveStoredArtifactArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	saveStoredArtifactReturns struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	saveStoredArtifactReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	SaveTestResultsStub        func([]atc.TestResult) error
+	saveTestResultsMutex       sync.RWMutex
+	saveTestResultsArgsForCall []struct {
+		arg1 []atc.TestResult
+	}
+	saveTestResultsReturns struct {
+		result1 error
+	}
+	saveTestResultsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SavedEventsStub        func() ([]event.Envelope, error)
+	savedEventsMutex       sync.RWMutex
+	savedEventsArgsForCall []struct {
+	}
+	savedEventsReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	savedEventsReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -649,6 +840,18 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetWaitingForWorkerStub        func(atc.PlanID, bool) error
+	setWaitingForWorkerMutex       sync.RWMutex
+	setWaitingForWorkerArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 bool
+	}
+	setWaitingForWorkerReturns struct {
+		result1 error
+	}
+	setWaitingForWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SpanContextStub        func() propagation.TextMapCarrier
 	spanContextMutex       sync.RWMutex
 	spanContextArgsForCall []struct {
@@ -672,6 +875,16 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	StartAfterStub        func() time.Time
+	startAfterMutex       sync.RWMutex
+	startAfterArgsForCall []struct {
+	}
+	startAfterReturns struct {
+		result1 time.Time
+	}
+	startAfterReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -723,6 +936,18 @@ type FakeBuild struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TestResultsStub        func() ([]atc.TestResult, error)
+	testResultsMutex       sync.RWMutex
+	testResultsArgsForCall []struct {
+	}
+	testResultsReturns struct {
+		result1 []atc.TestResult
+		result2 error
+	}
+	testResultsReturnsOnCall map[int]struct {
+		result1 []atc.TestResult
+		result2 error
+	}
 	TracingAttrsStub        func() tracing.Attrs
 	tracingAttrsMutex       sync.RWMutex
 	tracingAttrsArgsForCall []struct {
@@ -876,6 +1101,70 @@ func (fake *FakeBuild) AcquireTrackingLockReturnsOnCall(i int, result1 lock.Lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Admit(arg1 int) (bool, error) {
+	fake.admitMutex.Lock()
+	ret, specificReturn := fake.admitReturnsOnCall[len(fake.admitArgsForCall)]
+	fake.admitArgsForCall = append(fake.admitArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.AdmitStub
+	fakeReturns := fake.admitReturns
+	fake.recordInvocation("Admit", []interface{}{arg1})
+	fake.admitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) AdmitCallCount() int {
+	fake.admitMutex.RLock()
+	defer fake.admitMutex.RUnlock()
+	return len(fake.admitArgsForCall)
+}
+
+func (fake *FakeBuild) AdmitCalls(stub func(int) (bool, error)) {
+	fake.admitMutex.Lock()
+	defer fake.admitMutex.Unlock()
+	fake.AdmitStub = stub
+}
+
+func (fake *FakeBuild) AdmitArgsForCall(i int) int {
+	fake.admitMutex.RLock()
+	defer fake.admitMutex.RUnlock()
+	argsForCall := fake.admitArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) AdmitReturns(result1 bool, result2 error) {
+	fake.admitMutex.Lock()
+	defer fake.admitMutex.Unlock()
+	fake.AdmitStub = nil
+	fake.admitReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) AdmitReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.admitMutex.Lock()
+	defer fake.admitMutex.Unlock()
+	fake.AdmitStub = nil
+	if fake.admitReturnsOnCall == nil {
+		fake.admitReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.admitReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) AdoptInputsAndPipes() ([]db.BuildInput, bool, error) {
 	fake.adoptInputsAndPipesMutex.Lock()
 	ret, specificReturn := fake.adoptInputsAndPipesReturnsOnCall[len(fake.adoptInputsAndPipesArgsForCall)]
@@ -1114,6 +1403,59 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) AutoRerunReason() string {
+	fake.autoRerunReasonMutex.Lock()
+	ret, specificReturn := fake.autoRerunReasonReturnsOnCall[len(fake.autoRerunReasonArgsForCall)]
+	fake.autoRerunReasonArgsForCall = append(fake.autoRerunReasonArgsForCall, struct {
+	}{})
+	stub := fake.AutoRerunReasonStub
+	fakeReturns := fake.autoRerunReasonReturns
+	fake.recordInvocation("AutoRerunReason", []interface{}{})
+	fake.autoRerunReasonMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AutoRerunReasonCallCount() int {
+	fake.autoRerunReasonMutex.RLock()
+	defer fake.autoRerunReasonMutex.RUnlock()
+	return len(fake.autoRerunReasonArgsForCall)
+}
+
+func (fake *FakeBuild) AutoRerunReasonCalls(stub func() string) {
+	fake.autoRerunReasonMutex.Lock()
+	defer fake.autoRerunReasonMutex.Unlock()
+	fake.AutoRerunReasonStub = stub
+}
+
+func (fake *FakeBuild) AutoRerunReasonReturns(result1 string) {
+	fake.autoRerunReasonMutex.Lock()
+	defer fake.autoRerunReasonMutex.Unlock()
+	fake.AutoRerunReasonStub = nil
+	fake.autoRerunReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AutoRerunReasonReturnsOnCall(i int, result1 string) {
+	fake.autoRerunReasonMutex.Lock()
+	defer fake.autoRerunReasonMutex.Unlock()
+	fake.AutoRerunReasonStub = nil
+	if fake.autoRerunReasonReturnsOnCall == nil {
+		fake.autoRerunReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.autoRerunReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) Comment() string {
 	fake.commentMutex.Lock()
 	ret, specificReturn := fake.commentReturnsOnCall[len(fake.commentArgsForCall)]
@@ -1775,6 +2117,59 @@ func (fake *FakeBuild) IsAbortedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) IsAdmitted() bool {
+	fake.isAdmittedMutex.Lock()
+	ret, specificReturn := fake.isAdmittedReturnsOnCall[len(fake.isAdmittedArgsForCall)]
+	fake.isAdmittedArgsForCall = append(fake.isAdmittedArgsForCall, struct {
+	}{})
+	stub := fake.IsAdmittedStub
+	fakeReturns := fake.isAdmittedReturns
+	fake.recordInvocation("IsAdmitted", []interface{}{})
+	fake.isAdmittedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IsAdmittedCallCount() int {
+	fake.isAdmittedMutex.RLock()
+	defer fake.isAdmittedMutex.RUnlock()
+	return len(fake.isAdmittedArgsForCall)
+}
+
+func (fake *FakeBuild) IsAdmittedCalls(stub func() bool) {
+	fake.isAdmittedMutex.Lock()
+	defer fake.isAdmittedMutex.Unlock()
+	fake.IsAdmittedStub = stub
+}
+
+func (fake *FakeBuild) IsAdmittedReturns(result1 bool) {
+	fake.isAdmittedMutex.Lock()
+	defer fake.isAdmittedMutex.Unlock()
+	fake.IsAdmittedStub = nil
+	fake.isAdmittedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsAdmittedReturnsOnCall(i int, result1 bool) {
+	fake.isAdmittedMutex.Lock()
+	defer fake.isAdmittedMutex.Unlock()
+	fake.IsAdmittedStub = nil
+	if fake.isAdmittedReturnsOnCall == nil {
+		fake.isAdmittedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isAdmittedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) IsCompleted() bool {
 	fake.isCompletedMutex.Lock()
 	ret, specificReturn := fake.isCompletedReturnsOnCall[len(fake.isCompletedArgsForCall)]
@@ -1995,6 +2390,112 @@ func (fake *FakeBuild) IsNewerThanLastCheckOfReturnsOnCall(i int, result1 bool)
 	}{result1}
 }
 
+func (fake *FakeBuild) IsPaused() bool {
+	fake.isPausedMutex.Lock()
+	ret, specificReturn := fake.isPausedReturnsOnCall[len(fake.isPausedArgsForCall)]
+	fake.isPausedArgsForCall = append(fake.isPausedArgsForCall, struct {
+	}{})
+	stub := fake.IsPausedStub
+	fakeReturns := fake.isPausedReturns
+	fake.recordInvocation("IsPaused", []interface{}{})
+	fake.isPausedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IsPausedCallCount() int {
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
+	return len(fake.isPausedArgsForCall)
+}
+
+func (fake *FakeBuild) IsPausedCalls(stub func() bool) {
+	fake.isPausedMutex.Lock()
+	defer fake.isPausedMutex.Unlock()
+	fake.IsPausedStub = stub
+}
+
+func (fake *FakeBuild) IsPausedReturns(result1 bool) {
+	fake.isPausedMutex.Lock()
+	defer fake.isPausedMutex.Unlock()
+	fake.IsPausedStub = nil
+	fake.isPausedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsPausedReturnsOnCall(i int, result1 bool) {
+	fake.isPausedMutex.Lock()
+	defer fake.isPausedMutex.Unlock()
+	fake.IsPausedStub = nil
+	if fake.isPausedReturnsOnCall == nil {
+		fake.isPausedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isPausedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsQueued() bool {
+	fake.isQueuedMutex.Lock()
+	ret, specificReturn := fake.isQueuedReturnsOnCall[len(fake.isQueuedArgsForCall)]
+	fake.isQueuedArgsForCall = append(fake.isQueuedArgsForCall, struct {
+	}{})
+	stub := fake.IsQueuedStub
+	fakeReturns := fake.isQueuedReturns
+	fake.recordInvocation("IsQueued", []interface{}{})
+	fake.isQueuedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IsQueuedCallCount() int {
+	fake.isQueuedMutex.RLock()
+	defer fake.isQueuedMutex.RUnlock()
+	return len(fake.isQueuedArgsForCall)
+}
+
+func (fake *FakeBuild) IsQueuedCalls(stub func() bool) {
+	fake.isQueuedMutex.Lock()
+	defer fake.isQueuedMutex.Unlock()
+	fake.IsQueuedStub = stub
+}
+
+func (fake *FakeBuild) IsQueuedReturns(result1 bool) {
+	fake.isQueuedMutex.Lock()
+	defer fake.isQueuedMutex.Unlock()
+	fake.IsQueuedStub = nil
+	fake.isQueuedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsQueuedReturnsOnCall(i int, result1 bool) {
+	fake.isQueuedMutex.Lock()
+	defer fake.isQueuedMutex.Unlock()
+	fake.IsQueuedStub = nil
+	if fake.isQueuedReturnsOnCall == nil {
+		fake.isQueuedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isQueuedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) IsRunning() bool {
 	fake.isRunningMutex.Lock()
 	ret, specificReturn := fake.isRunningReturnsOnCall[len(fake.isRunningArgsForCall)]
@@ -2425,22 +2926,75 @@ func (fake *FakeBuild) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
-	fake.pipelineMutex.Lock()
-	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
-	fake.pipelineArgsForCall = append(fake.pipelineArgsForCall, struct {
+func (fake *FakeBuild) Pause() error {
+	fake.pauseMutex.Lock()
+	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
 	}{})
-	stub := fake.PipelineStub
-	fakeReturns := fake.pipelineReturns
-	fake.recordInvocation("Pipeline", []interface{}{})
-	fake.pipelineMutex.Unlock()
+	stub := fake.PauseStub
+	fakeReturns := fake.pauseReturns
+	fake.recordInvocation("Pause", []interface{}{})
+	fake.pauseMutex.Unlock()
 	if stub != nil {
 		return stub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *FakeBuild) PauseCalls(stub func() error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = stub
+}
+
+func (fake *FakeBuild) PauseReturns(result1 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) PauseReturnsOnCall(i int, result1 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	if fake.pauseReturnsOnCall == nil {
+		fake.pauseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
+	fake.pipelineMutex.Lock()
+	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
+	fake.pipelineArgsForCall = append(fake.pipelineArgsForCall, struct {
+	}{})
+	stub := fake.PipelineStub
+	fakeReturns := fake.pipelineReturns
+	fake.recordInvocation("Pipeline", []interface{}{})
+	fake.pipelineMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
 func (fake *FakeBuild) PipelineCallCount() int {
@@ -2696,6 +3250,123 @@ func (fake *FakeBuild) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef)
 	}{result1}
 }
 
+func (fake *FakeBuild) Preempt(arg1 int) (bool, error) {
+	fake.preemptMutex.Lock()
+	ret, specificReturn := fake.preemptReturnsOnCall[len(fake.preemptArgsForCall)]
+	fake.preemptArgsForCall = append(fake.preemptArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.PreemptStub
+	fakeReturns := fake.preemptReturns
+	fake.recordInvocation("Preempt", []interface{}{arg1})
+	fake.preemptMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) PreemptCallCount() int {
+	fake.preemptMutex.RLock()
+	defer fake.preemptMutex.RUnlock()
+	return len(fake.preemptArgsForCall)
+}
+
+func (fake *FakeBuild) PreemptCalls(stub func(int) (bool, error)) {
+	fake.preemptMutex.Lock()
+	defer fake.preemptMutex.Unlock()
+	fake.PreemptStub = stub
+}
+
+func (fake *FakeBuild) PreemptArgsForCall(i int) int {
+	fake.preemptMutex.RLock()
+	defer fake.preemptMutex.RUnlock()
+	argsForCall := fake.preemptArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) PreemptReturns(result1 bool, result2 error) {
+	fake.preemptMutex.Lock()
+	defer fake.preemptMutex.Unlock()
+	fake.PreemptStub = nil
+	fake.preemptReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PreemptReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.preemptMutex.Lock()
+	defer fake.preemptMutex.Unlock()
+	fake.PreemptStub = nil
+	if fake.preemptReturnsOnCall == nil {
+		fake.preemptReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.preemptReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PreemptedBy() int {
+	fake.preemptedByMutex.Lock()
+	ret, specificReturn := fake.preemptedByReturnsOnCall[len(fake.preemptedByArgsForCall)]
+	fake.preemptedByArgsForCall = append(fake.preemptedByArgsForCall, struct {
+	}{})
+	stub := fake.PreemptedByStub
+	fakeReturns := fake.preemptedByReturns
+	fake.recordInvocation("PreemptedBy", []interface{}{})
+	fake.preemptedByMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PreemptedByCallCount() int {
+	fake.preemptedByMutex.RLock()
+	defer fake.preemptedByMutex.RUnlock()
+	return len(fake.preemptedByArgsForCall)
+}
+
+func (fake *FakeBuild) PreemptedByCalls(stub func() int) {
+	fake.preemptedByMutex.Lock()
+	defer fake.preemptedByMutex.Unlock()
+	fake.PreemptedByStub = stub
+}
+
+func (fake *FakeBuild) PreemptedByReturns(result1 int) {
+	fake.preemptedByMutex.Lock()
+	defer fake.preemptedByMutex.Unlock()
+	fake.PreemptedByStub = nil
+	fake.preemptedByReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) PreemptedByReturnsOnCall(i int, result1 int) {
+	fake.preemptedByMutex.Lock()
+	defer fake.preemptedByMutex.Unlock()
+	fake.PreemptedByStub = nil
+	if fake.preemptedByReturnsOnCall == nil {
+		fake.preemptedByReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.preemptedByReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) Preparation() (db.BuildPreparation, bool, error) {
 	fake.preparationMutex.Lock()
 	ret, specificReturn := fake.preparationReturnsOnCall[len(fake.preparationArgsForCall)]
@@ -2755,6 +3426,59 @@ func (fake *FakeBuild) PreparationReturnsOnCall(i int, result1 db.BuildPreparati
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct {
+	}{})
+	stub := fake.PriorityStub
+	fakeReturns := fake.priorityReturns
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeBuild) PriorityCalls(stub func() int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = stub
+}
+
+func (fake *FakeBuild) PriorityReturns(result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) PriorityReturnsOnCall(i int, result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) PrivatePlan() atc.Plan {
 	fake.privatePlanMutex.Lock()
 	ret, specificReturn := fake.privatePlanReturnsOnCall[len(fake.privatePlanArgsForCall)]
@@ -3403,6 +4127,283 @@ func (fake *FakeBuild) ResourcesCheckedReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Resume() error {
+	fake.resumeMutex.Lock()
+	ret, specificReturn := fake.resumeReturnsOnCall[len(fake.resumeArgsForCall)]
+	fake.resumeArgsForCall = append(fake.resumeArgsForCall, struct {
+	}{})
+	stub := fake.ResumeStub
+	fakeReturns := fake.resumeReturns
+	fake.recordInvocation("Resume", []interface{}{})
+	fake.resumeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ResumeCallCount() int {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return len(fake.resumeArgsForCall)
+}
+
+func (fake *FakeBuild) ResumeCalls(stub func() error) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = stub
+}
+
+func (fake *FakeBuild) ResumeReturns(result1 error) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = nil
+	fake.resumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ResumeReturnsOnCall(i int, result1 error) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = nil
+	if fake.resumeReturnsOnCall == nil {
+		fake.resumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ResumeNotifier() (db.Notifier, error) {
+	fake.resumeNotifierMutex.Lock()
+	ret, specificReturn := fake.resumeNotifierReturnsOnCall[len(fake.resumeNotifierArgsForCall)]
+	fake.resumeNotifierArgsForCall = append(fake.resumeNotifierArgsForCall, struct {
+	}{})
+	stub := fake.ResumeNotifierStub
+	fakeReturns := fake.resumeNotifierReturns
+	fake.recordInvocation("ResumeNotifier", []interface{}{})
+	fake.resumeNotifierMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ResumeNotifierCallCount() int {
+	fake.resumeNotifierMutex.RLock()
+	defer fake.resumeNotifierMutex.RUnlock()
+	return len(fake.resumeNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ResumeNotifierCalls(stub func() (db.Notifier, error)) {
+	fake.resumeNotifierMutex.Lock()
+	defer fake.resumeNotifierMutex.Unlock()
+	fake.ResumeNotifierStub = stub
+}
+
+func (fake *FakeBuild) ResumeNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.resumeNotifierMutex.Lock()
+	defer fake.resumeNotifierMutex.Unlock()
+	fake.ResumeNotifierStub = nil
+	fake.resumeNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResumeNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.resumeNotifierMutex.Lock()
+	defer fake.resumeNotifierMutex.Unlock()
+	fake.ResumeNotifierStub = nil
+	if fake.resumeNotifierReturnsOnCall == nil {
+		fake.resumeNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.resumeNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResumedFrom() int {
+	fake.resumedFromMutex.Lock()
+	ret, specificReturn := fake.resumedFromReturnsOnCall[len(fake.resumedFromArgsForCall)]
+	fake.resumedFromArgsForCall = append(fake.resumedFromArgsForCall, struct {
+	}{})
+	stub := fake.ResumedFromStub
+	fakeReturns := fake.resumedFromReturns
+	fake.recordInvocation("ResumedFrom", []interface{}{})
+	fake.resumedFromMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ResumedFromCallCount() int {
+	fake.resumedFromMutex.RLock()
+	defer fake.resumedFromMutex.RUnlock()
+	return len(fake.resumedFromArgsForCall)
+}
+
+func (fake *FakeBuild) ResumedFromCalls(stub func() int) {
+	fake.resumedFromMutex.Lock()
+	defer fake.resumedFromMutex.Unlock()
+	fake.ResumedFromStub = stub
+}
+
+func (fake *FakeBuild) ResumedFromReturns(result1 int) {
+	fake.resumedFromMutex.Lock()
+	defer fake.resumedFromMutex.Unlock()
+	fake.ResumedFromStub = nil
+	fake.resumedFromReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) ResumedFromReturnsOnCall(i int, result1 int) {
+	fake.resumedFromMutex.Lock()
+	defer fake.resumedFromMutex.Unlock()
+	fake.ResumedFromStub = nil
+	if fake.resumedFromReturnsOnCall == nil {
+		fake.resumedFromReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.resumedFromReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) ResumedFromBuild() (db.Build, bool, error) {
+	fake.resumedFromBuildMutex.Lock()
+	ret, specificReturn := fake.resumedFromBuildReturnsOnCall[len(fake.resumedFromBuildArgsForCall)]
+	fake.resumedFromBuildArgsForCall = append(fake.resumedFromBuildArgsForCall, struct {
+	}{})
+	stub := fake.ResumedFromBuildStub
+	fakeReturns := fake.resumedFromBuildReturns
+	fake.recordInvocation("ResumedFromBuild", []interface{}{})
+	fake.resumedFromBuildMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ResumedFromBuildCallCount() int {
+	fake.resumedFromBuildMutex.RLock()
+	defer fake.resumedFromBuildMutex.RUnlock()
+	return len(fake.resumedFromBuildArgsForCall)
+}
+
+func (fake *FakeBuild) ResumedFromBuildCalls(stub func() (db.Build, bool, error)) {
+	fake.resumedFromBuildMutex.Lock()
+	defer fake.resumedFromBuildMutex.Unlock()
+	fake.ResumedFromBuildStub = stub
+}
+
+func (fake *FakeBuild) ResumedFromBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.resumedFromBuildMutex.Lock()
+	defer fake.resumedFromBuildMutex.Unlock()
+	fake.ResumedFromBuildStub = nil
+	fake.resumedFromBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ResumedFromBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.resumedFromBuildMutex.Lock()
+	defer fake.resumedFromBuildMutex.Unlock()
+	fake.ResumedFromBuildStub = nil
+	if fake.resumedFromBuildReturnsOnCall == nil {
+		fake.resumedFromBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resumedFromBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) RunningDuration() (time.Duration, error) {
+	fake.runningDurationMutex.Lock()
+	ret, specificReturn := fake.runningDurationReturnsOnCall[len(fake.runningDurationArgsForCall)]
+	fake.runningDurationArgsForCall = append(fake.runningDurationArgsForCall, struct {
+	}{})
+	stub := fake.RunningDurationStub
+	fakeReturns := fake.runningDurationReturns
+	fake.recordInvocation("RunningDuration", []interface{}{})
+	fake.runningDurationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) RunningDurationCallCount() int {
+	fake.runningDurationMutex.RLock()
+	defer fake.runningDurationMutex.RUnlock()
+	return len(fake.runningDurationArgsForCall)
+}
+
+func (fake *FakeBuild) RunningDurationCalls(stub func() (time.Duration, error)) {
+	fake.runningDurationMutex.Lock()
+	defer fake.runningDurationMutex.Unlock()
+	fake.RunningDurationStub = stub
+}
+
+func (fake *FakeBuild) RunningDurationReturns(result1 time.Duration, result2 error) {
+	fake.runningDurationMutex.Lock()
+	defer fake.runningDurationMutex.Unlock()
+	fake.RunningDurationStub = nil
+	fake.runningDurationReturns = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RunningDurationReturnsOnCall(i int, result1 time.Duration, result2 error) {
+	fake.runningDurationMutex.Lock()
+	defer fake.runningDurationMutex.Unlock()
+	fake.RunningDurationStub = nil
+	if fake.runningDurationReturnsOnCall == nil {
+		fake.runningDurationReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 error
+		})
+	}
+	fake.runningDurationReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
 	fake.saveEventMutex.Lock()
 	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
@@ -3663,6 +4664,193 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveStoredArtifact(arg1 string, arg2 string) (db.WorkerArtifact, error) {
+	fake.saveStoredArtifactMutex.Lock()
+	ret, specificReturn := fake.saveStoredArtifactReturnsOnCall[len(fake.saveStoredArtifactArgsForCall)]
+	fake.saveStoredArtifactArgsForCall = append(fake.saveStoredArtifactArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SaveStoredArtifactStub
+	fakeReturns := fake.saveStoredArtifactReturns
+	fake.recordInvocation("SaveStoredArtifact", []interface{}{arg1, arg2})
+	fake.saveStoredArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SaveStoredArtifactCallCount() int {
+	fake.saveStoredArtifactMutex.RLock()
+	defer fake.saveStoredArtifactMutex.RUnlock()
+	return len(fake.saveStoredArtifactArgsForCall)
+}
+
+func (fake *FakeBuild) SaveStoredArtifactCalls(stub func(string, string) (db.WorkerArtifact, error)) {
+	fake.saveStoredArtifactMutex.Lock()
+	defer fake.saveStoredArtifactMutex.Unlock()
+	fake.SaveStoredArtifactStub = stub
+}
+
+func (fake *FakeBuild) SaveStoredArtifactArgsForCall(i int) (string, string) {
+	fake.saveStoredArtifactMutex.RLock()
+	defer fake.saveStoredArtifactMutex.RUnlock()
+	argsForCall := fake.saveStoredArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveStoredArtifactReturns(result1 db.WorkerArtifact, result2 error) {
+	fake.saveStoredArtifactMutex.Lock()
+	defer fake.saveStoredArtifactMutex.Unlock()
+	fake.SaveStoredArtifactStub = nil
+	fake.saveStoredArtifactReturns = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveStoredArtifactReturnsOnCall(i int, result1 db.WorkerArtifact, result2 error) {
+	fake.saveStoredArtifactMutex.Lock()
+	defer fake.saveStoredArtifactMutex.Unlock()
+	fake.SaveStoredArtifactStub = nil
+	if fake.saveStoredArtifactReturnsOnCall == nil {
+		fake.saveStoredArtifactReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 error
+		})
+	}
+	fake.saveStoredArtifactReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveTestResults(arg1 []atc.TestResult) error {
+	var arg1Copy []atc.TestResult
+	if arg1 != nil {
+		arg1Copy = make([]atc.TestResult, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveTestResultsMutex.Lock()
+	ret, specificReturn := fake.saveTestResultsReturnsOnCall[len(fake.saveTestResultsArgsForCall)]
+	fake.saveTestResultsArgsForCall = append(fake.saveTestResultsArgsForCall, struct {
+		arg1 []atc.TestResult
+	}{arg1Copy})
+	stub := fake.SaveTestResultsStub
+	fakeReturns := fake.saveTestResultsReturns
+	fake.recordInvocation("SaveTestResults", []interface{}{arg1Copy})
+	fake.saveTestResultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveTestResultsCallCount() int {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return len(fake.saveTestResultsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTestResultsCalls(stub func([]atc.TestResult) error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = stub
+}
+
+func (fake *FakeBuild) SaveTestResultsArgsForCall(i int) []atc.TestResult {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	argsForCall := fake.saveTestResultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveTestResultsReturns(result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	fake.saveTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTestResultsReturnsOnCall(i int, result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	if fake.saveTestResultsReturnsOnCall == nil {
+		fake.saveTestResultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTestResultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SavedEvents() ([]event.Envelope, error) {
+	fake.savedEventsMutex.Lock()
+	ret, specificReturn := fake.savedEventsReturnsOnCall[len(fake.savedEventsArgsForCall)]
+	fake.savedEventsArgsForCall = append(fake.savedEventsArgsForCall, struct {
+	}{})
+	stub := fake.SavedEventsStub
+	fakeReturns := fake.savedEventsReturns
+	fake.recordInvocation("SavedEvents", []interface{}{})
+	fake.savedEventsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SavedEventsCallCount() int {
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
+	return len(fake.savedEventsArgsForCall)
+}
+
+func (fake *FakeBuild) SavedEventsCalls(stub func() ([]event.Envelope, error)) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = stub
+}
+
+func (fake *FakeBuild) SavedEventsReturns(result1 []event.Envelope, result2 error) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = nil
+	fake.savedEventsReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SavedEventsReturnsOnCall(i int, result1 []event.Envelope, result2 error) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = nil
+	if fake.savedEventsReturnsOnCall == nil {
+		fake.savedEventsReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 error
+		})
+	}
+	fake.savedEventsReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -3899,6 +5087,68 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetWaitingForWorker(arg1 atc.PlanID, arg2 bool) error {
+	fake.setWaitingForWorkerMutex.Lock()
+	ret, specificReturn := fake.setWaitingForWorkerReturnsOnCall[len(fake.setWaitingForWorkerArgsForCall)]
+	fake.setWaitingForWorkerArgsForCall = append(fake.setWaitingForWorkerArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.SetWaitingForWorkerStub
+	fakeReturns := fake.setWaitingForWorkerReturns
+	fake.recordInvocation("SetWaitingForWorker", []interface{}{arg1, arg2})
+	fake.setWaitingForWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCallCount() int {
+	fake.setWaitingForWorkerMutex.RLock()
+	defer fake.setWaitingForWorkerMutex.RUnlock()
+	return len(fake.setWaitingForWorkerArgsForCall)
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCalls(stub func(atc.PlanID, bool) error) {
+	fake.setWaitingForWorkerMutex.Lock()
+	defer fake.setWaitingForWorkerMutex.Unlock()
+	fake.SetWaitingForWorkerStub = stub
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerArgsForCall(i int) (atc.PlanID, bool) {
+	fake.setWaitingForWorkerMutex.RLock()
+	defer fake.setWaitingForWorkerMutex.RUnlock()
+	argsForCall := fake.setWaitingForWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerReturns(result1 error) {
+	fake.setWaitingForWorkerMutex.Lock()
+	defer fake.setWaitingForWorkerMutex.Unlock()
+	fake.SetWaitingForWorkerStub = nil
+	fake.setWaitingForWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerReturnsOnCall(i int, result1 error) {
+	fake.setWaitingForWorkerMutex.Lock()
+	defer fake.setWaitingForWorkerMutex.Unlock()
+	fake.SetWaitingForWorkerStub = nil
+	if fake.setWaitingForWorkerReturnsOnCall == nil {
+		fake.setWaitingForWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setWaitingForWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SpanContext() propagation.TextMapCarrier {
 	fake.spanContextMutex.Lock()
 	ret, specificReturn := fake.spanContextReturnsOnCall[len(fake.spanContextArgsForCall)]
@@ -4016,6 +5266,59 @@ func (fake *FakeBuild) StartReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) StartAfter() time.Time {
+	fake.startAfterMutex.Lock()
+	ret, specificReturn := fake.startAfterReturnsOnCall[len(fake.startAfterArgsForCall)]
+	fake.startAfterArgsForCall = append(fake.startAfterArgsForCall, struct {
+	}{})
+	stub := fake.StartAfterStub
+	fakeReturns := fake.startAfterReturns
+	fake.recordInvocation("StartAfter", []interface{}{})
+	fake.startAfterMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) StartAfterCallCount() int {
+	fake.startAfterMutex.RLock()
+	defer fake.startAfterMutex.RUnlock()
+	return len(fake.startAfterArgsForCall)
+}
+
+func (fake *FakeBuild) StartAfterCalls(stub func() time.Time) {
+	fake.startAfterMutex.Lock()
+	defer fake.startAfterMutex.Unlock()
+	fake.StartAfterStub = stub
+}
+
+func (fake *FakeBuild) StartAfterReturns(result1 time.Time) {
+	fake.startAfterMutex.Lock()
+	defer fake.startAfterMutex.Unlock()
+	fake.StartAfterStub = nil
+	fake.startAfterReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) StartAfterReturnsOnCall(i int, result1 time.Time) {
+	fake.startAfterMutex.Lock()
+	defer fake.startAfterMutex.Unlock()
+	fake.StartAfterStub = nil
+	if fake.startAfterReturnsOnCall == nil {
+		fake.startAfterReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.startAfterReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeBuild) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -4289,6 +5592,62 @@ func (fake *FakeBuild) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TestResults() ([]atc.TestResult, error) {
+	fake.testResultsMutex.Lock()
+	ret, specificReturn := fake.testResultsReturnsOnCall[len(fake.testResultsArgsForCall)]
+	fake.testResultsArgsForCall = append(fake.testResultsArgsForCall, struct {
+	}{})
+	stub := fake.TestResultsStub
+	fakeReturns := fake.testResultsReturns
+	fake.recordInvocation("TestResults", []interface{}{})
+	fake.testResultsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) TestResultsCallCount() int {
+	fake.testResultsMutex.RLock()
+	defer fake.testResultsMutex.RUnlock()
+	return len(fake.testResultsArgsForCall)
+}
+
+func (fake *FakeBuild) TestResultsCalls(stub func() ([]atc.TestResult, error)) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = stub
+}
+
+func (fake *FakeBuild) TestResultsReturns(result1 []atc.TestResult, result2 error) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = nil
+	fake.testResultsReturns = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TestResultsReturnsOnCall(i int, result1 []atc.TestResult, result2 error) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = nil
+	if fake.testResultsReturnsOnCall == nil {
+		fake.testResultsReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestResult
+			result2 error
+		})
+	}
+	fake.testResultsReturnsOnCall[i] = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) TracingAttrs() tracing.Attrs {
 	fake.tracingAttrsMutex.Lock()
 	ret, specificReturn := fake.tracingAttrsReturnsOnCall[len(fake.tracingAttrsArgsForCall)]
@@ -4415,6 +5774,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
+	fake.admitMutex.RLock()
+	defer fake.admitMutex.RUnlock()
 	fake.adoptInputsAndPipesMutex.RLock()
 	defer fake.adoptInputsAndPipesMutex.RUnlock()
 	fake.adoptRerunInputsAndPipesMutex.RLock()
@@ -4423,6 +5784,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.autoRerunReasonMutex.RLock()
+	defer fake.autoRerunReasonMutex.RUnlock()
 	fake.commentMutex.RLock()
 	defer fake.commentMutex.RUnlock()
 	fake.createTimeMutex.RLock()
@@ -4447,6 +5810,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.interceptibleMutex.RUnlock()
 	fake.isAbortedMutex.RLock()
 	defer fake.isAbortedMutex.RUnlock()
+	fake.isAdmittedMutex.RLock()
+	defer fake.isAdmittedMutex.RUnlock()
 	fake.isCompletedMutex.RLock()
 	defer fake.isCompletedMutex.RUnlock()
 	fake.isDrainedMutex.RLock()
@@ -4455,6 +5820,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isManuallyTriggeredMutex.RUnlock()
 	fake.isNewerThanLastCheckOfMutex.RLock()
 	defer fake.isNewerThanLastCheckOfMutex.RUnlock()
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
+	fake.isQueuedMutex.RLock()
+	defer fake.isQueuedMutex.RUnlock()
 	fake.isRunningMutex.RLock()
 	defer fake.isRunningMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
@@ -4471,6 +5840,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.markAsAbortedMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -4481,8 +5852,14 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.pipelineRefMutex.RLock()
 	defer fake.pipelineRefMutex.RUnlock()
+	fake.preemptMutex.RLock()
+	defer fake.preemptMutex.RUnlock()
+	fake.preemptedByMutex.RLock()
+	defer fake.preemptedByMutex.RUnlock()
 	fake.preparationMutex.RLock()
 	defer fake.preparationMutex.RUnlock()
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
 	fake.privatePlanMutex.RLock()
 	defer fake.privatePlanMutex.RUnlock()
 	fake.publicPlanMutex.RLock()
@@ -4507,6 +5884,16 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.resourcesMutex.RUnlock()
 	fake.resourcesCheckedMutex.RLock()
 	defer fake.resourcesCheckedMutex.RUnlock()
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	fake.resumeNotifierMutex.RLock()
+	defer fake.resumeNotifierMutex.RUnlock()
+	fake.resumedFromMutex.RLock()
+	defer fake.resumedFromMutex.RUnlock()
+	fake.resumedFromBuildMutex.RLock()
+	defer fake.resumedFromBuildMutex.RUnlock()
+	fake.runningDurationMutex.RLock()
+	defer fake.runningDurationMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
@@ -4515,6 +5902,12 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveStoredArtifactMutex.RLock()
+	defer fake.saveStoredArtifactMutex.RUnlock()
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setCommentMutex.RLock()
@@ -4523,10 +5916,14 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.setDrainedMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setWaitingForWorkerMutex.RLock()
+	defer fake.setWaitingForWorkerMutex.RUnlock()
 	fake.spanContextMutex.RLock()
 	defer fake.spanContextMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
+	fake.startAfterMutex.RLock()
+	defer fake.startAfterMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
@@ -4537,6 +5934,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.testResultsMutex.RLock()
+	defer fake.testResultsMutex.RUnlock()
 	fake.tracingAttrsMutex.RLock()
 	defer fake.tracingAttrsMutex.RUnlock()
 	fake.variablesMutex.RLock()
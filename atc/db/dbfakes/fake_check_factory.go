@@ -34,6 +34,31 @@ type FakeCheckFactory struct {
 		result1 []db.Resource
 		result2 error
 	}
+	ResourcesByIDsStub        func([]int) ([]db.Resource, error)
+	resourcesByIDsMutex       sync.RWMutex
+	resourcesByIDsArgsForCall []struct {
+		arg1 []int
+	}
+	resourcesByIDsReturns struct {
+		result1 []db.Resource
+		result2 error
+	}
+	resourcesByIDsReturnsOnCall map[int]struct {
+		result1 []db.Resource
+		result2 error
+	}
+	RunningChecksByPipelineStub        func() (map[int]int, error)
+	runningChecksByPipelineMutex       sync.RWMutex
+	runningChecksByPipelineArgsForCall []struct {
+	}
+	runningChecksByPipelineReturns struct {
+		result1 map[int]int
+		result2 error
+	}
+	runningChecksByPipelineReturnsOnCall map[int]struct {
+		result1 map[int]int
+		result2 error
+	}
 	TryCreateCheckStub        func(context.Context, db.Checkable, db.ResourceTypes, atc.Version, bool, bool) (db.Build, bool, error)
 	tryCreateCheckMutex       sync.RWMutex
 	tryCreateCheckArgsForCall []struct {
@@ -170,6 +195,131 @@ func (fake *FakeCheckFactory) ResourcesReturnsOnCall(i int, result1 []db.Resourc
 	}{result1, result2}
 }
 
+func (fake *FakeCheckFactory) ResourcesByIDs(arg1 []int) ([]db.Resource, error) {
+	var arg1Copy []int
+	if arg1 != nil {
+		arg1Copy = make([]int, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.resourcesByIDsMutex.Lock()
+	ret, specificReturn := fake.resourcesByIDsReturnsOnCall[len(fake.resourcesByIDsArgsForCall)]
+	fake.resourcesByIDsArgsForCall = append(fake.resourcesByIDsArgsForCall, struct {
+		arg1 []int
+	}{arg1Copy})
+	stub := fake.ResourcesByIDsStub
+	fakeReturns := fake.resourcesByIDsReturns
+	fake.recordInvocation("ResourcesByIDs", []interface{}{arg1Copy})
+	fake.resourcesByIDsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckFactory) ResourcesByIDsCallCount() int {
+	fake.resourcesByIDsMutex.RLock()
+	defer fake.resourcesByIDsMutex.RUnlock()
+	return len(fake.resourcesByIDsArgsForCall)
+}
+
+func (fake *FakeCheckFactory) ResourcesByIDsCalls(stub func([]int) ([]db.Resource, error)) {
+	fake.resourcesByIDsMutex.Lock()
+	defer fake.resourcesByIDsMutex.Unlock()
+	fake.ResourcesByIDsStub = stub
+}
+
+func (fake *FakeCheckFactory) ResourcesByIDsArgsForCall(i int) []int {
+	fake.resourcesByIDsMutex.RLock()
+	defer fake.resourcesByIDsMutex.RUnlock()
+	argsForCall := fake.resourcesByIDsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckFactory) ResourcesByIDsReturns(result1 []db.Resource, result2 error) {
+	fake.resourcesByIDsMutex.Lock()
+	defer fake.resourcesByIDsMutex.Unlock()
+	fake.ResourcesByIDsStub = nil
+	fake.resourcesByIDsReturns = struct {
+		result1 []db.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckFactory) ResourcesByIDsReturnsOnCall(i int, result1 []db.Resource, result2 error) {
+	fake.resourcesByIDsMutex.Lock()
+	defer fake.resourcesByIDsMutex.Unlock()
+	fake.ResourcesByIDsStub = nil
+	if fake.resourcesByIDsReturnsOnCall == nil {
+		fake.resourcesByIDsReturnsOnCall = make(map[int]struct {
+			result1 []db.Resource
+			result2 error
+		})
+	}
+	fake.resourcesByIDsReturnsOnCall[i] = struct {
+		result1 []db.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckFactory) RunningChecksByPipeline() (map[int]int, error) {
+	fake.runningChecksByPipelineMutex.Lock()
+	ret, specificReturn := fake.runningChecksByPipelineReturnsOnCall[len(fake.runningChecksByPipelineArgsForCall)]
+	fake.runningChecksByPipelineArgsForCall = append(fake.runningChecksByPipelineArgsForCall, struct {
+	}{})
+	stub := fake.RunningChecksByPipelineStub
+	fakeReturns := fake.runningChecksByPipelineReturns
+	fake.recordInvocation("RunningChecksByPipeline", []interface{}{})
+	fake.runningChecksByPipelineMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckFactory) RunningChecksByPipelineCallCount() int {
+	fake.runningChecksByPipelineMutex.RLock()
+	defer fake.runningChecksByPipelineMutex.RUnlock()
+	return len(fake.runningChecksByPipelineArgsForCall)
+}
+
+func (fake *FakeCheckFactory) RunningChecksByPipelineCalls(stub func() (map[int]int, error)) {
+	fake.runningChecksByPipelineMutex.Lock()
+	defer fake.runningChecksByPipelineMutex.Unlock()
+	fake.RunningChecksByPipelineStub = stub
+}
+
+func (fake *FakeCheckFactory) RunningChecksByPipelineReturns(result1 map[int]int, result2 error) {
+	fake.runningChecksByPipelineMutex.Lock()
+	defer fake.runningChecksByPipelineMutex.Unlock()
+	fake.RunningChecksByPipelineStub = nil
+	fake.runningChecksByPipelineReturns = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckFactory) RunningChecksByPipelineReturnsOnCall(i int, result1 map[int]int, result2 error) {
+	fake.runningChecksByPipelineMutex.Lock()
+	defer fake.runningChecksByPipelineMutex.Unlock()
+	fake.RunningChecksByPipelineStub = nil
+	if fake.runningChecksByPipelineReturnsOnCall == nil {
+		fake.runningChecksByPipelineReturnsOnCall = make(map[int]struct {
+			result1 map[int]int
+			result2 error
+		})
+	}
+	fake.runningChecksByPipelineReturnsOnCall[i] = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCheckFactory) TryCreateCheck(arg1 context.Context, arg2 db.Checkable, arg3 db.ResourceTypes, arg4 atc.Version, arg5 bool, arg6 bool) (db.Build, bool, error) {
 	fake.tryCreateCheckMutex.Lock()
 	ret, specificReturn := fake.tryCreateCheckReturnsOnCall[len(fake.tryCreateCheckArgsForCall)]
@@ -249,6 +399,10 @@ func (fake *FakeCheckFactory) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypesByPipelineMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.resourcesByIDsMutex.RLock()
+	defer fake.resourcesByIDsMutex.RUnlock()
+	fake.runningChecksByPipelineMutex.RLock()
+	defer fake.runningChecksByPipelineMutex.RUnlock()
 	fake.tryCreateCheckMutex.RLock()
 	defer fake.tryCreateCheckMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -9,10 +9,11 @@ import (
 )
 
 type FakeCheckLifecycle struct {
-	DeleteCompletedChecksStub        func(lager.Logger) error
+	DeleteCompletedChecksStub        func(lager.Logger, db.CheckBuildRetention) error
 	deleteCompletedChecksMutex       sync.RWMutex
 	deleteCompletedChecksArgsForCall []struct {
 		arg1 lager.Logger
+		arg2 db.CheckBuildRetention
 	}
 	deleteCompletedChecksReturns struct {
 		result1 error
@@ -24,18 +25,19 @@ type FakeCheckLifecycle struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeCheckLifecycle) DeleteCompletedChecks(arg1 lager.Logger) error {
+func (fake *FakeCheckLifecycle) DeleteCompletedChecks(arg1 lager.Logger, arg2 db.CheckBuildRetention) error {
 	fake.deleteCompletedChecksMutex.Lock()
 	ret, specificReturn := fake.deleteCompletedChecksReturnsOnCall[len(fake.deleteCompletedChecksArgsForCall)]
 	fake.deleteCompletedChecksArgsForCall = append(fake.deleteCompletedChecksArgsForCall, struct {
 		arg1 lager.Logger
-	}{arg1})
+		arg2 db.CheckBuildRetention
+	}{arg1, arg2})
 	stub := fake.DeleteCompletedChecksStub
 	fakeReturns := fake.deleteCompletedChecksReturns
-	fake.recordInvocation("DeleteCompletedChecks", []interface{}{arg1})
+	fake.recordInvocation("DeleteCompletedChecks", []interface{}{arg1, arg2})
 	fake.deleteCompletedChecksMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -49,17 +51,17 @@ func (fake *FakeCheckLifecycle) DeleteCompletedChecksCallCount() int {
 	return len(fake.deleteCompletedChecksArgsForCall)
 }
 
-func (fake *FakeCheckLifecycle) DeleteCompletedChecksCalls(stub func(lager.Logger) error) {
+func (fake *FakeCheckLifecycle) DeleteCompletedChecksCalls(stub func(lager.Logger, db.CheckBuildRetention) error) {
 	fake.deleteCompletedChecksMutex.Lock()
 	defer fake.deleteCompletedChecksMutex.Unlock()
 	fake.DeleteCompletedChecksStub = stub
 }
 
-func (fake *FakeCheckLifecycle) DeleteCompletedChecksArgsForCall(i int) lager.Logger {
+func (fake *FakeCheckLifecycle) DeleteCompletedChecksArgsForCall(i int) (lager.Logger, db.CheckBuildRetention) {
 	fake.deleteCompletedChecksMutex.RLock()
 	defer fake.deleteCompletedChecksMutex.RUnlock()
 	argsForCall := fake.deleteCompletedChecksArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeCheckLifecycle) DeleteCompletedChecksReturns(result1 error) {
@@ -159,6 +159,17 @@ type FakeCreatedVolume struct {
 		result1 *db.VolumeResourceType
 		result2 error
 	}
+	SetResourceCacheSizeStub        func(uint64) error
+	setResourceCacheSizeMutex       sync.RWMutex
+	setResourceCacheSizeArgsForCall []struct {
+		arg1 uint64
+	}
+	setResourceCacheSizeReturns struct {
+		result1 error
+	}
+	setResourceCacheSizeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	TaskIdentifierStub        func() (int, atc.PipelineRef, string, string, error)
 	taskIdentifierMutex       sync.RWMutex
 	taskIdentifierArgsForCall []struct {
@@ -970,6 +981,67 @@ func (fake *FakeCreatedVolume) ResourceTypeReturnsOnCall(i int, result1 *db.Volu
 	}{result1, result2}
 }
 
+func (fake *FakeCreatedVolume) SetResourceCacheSize(arg1 uint64) error {
+	fake.setResourceCacheSizeMutex.Lock()
+	ret, specificReturn := fake.setResourceCacheSizeReturnsOnCall[len(fake.setResourceCacheSizeArgsForCall)]
+	fake.setResourceCacheSizeArgsForCall = append(fake.setResourceCacheSizeArgsForCall, struct {
+		arg1 uint64
+	}{arg1})
+	stub := fake.SetResourceCacheSizeStub
+	fakeReturns := fake.setResourceCacheSizeReturns
+	fake.recordInvocation("SetResourceCacheSize", []interface{}{arg1})
+	fake.setResourceCacheSizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) SetResourceCacheSizeCallCount() int {
+	fake.setResourceCacheSizeMutex.RLock()
+	defer fake.setResourceCacheSizeMutex.RUnlock()
+	return len(fake.setResourceCacheSizeArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) SetResourceCacheSizeCalls(stub func(uint64) error) {
+	fake.setResourceCacheSizeMutex.Lock()
+	defer fake.setResourceCacheSizeMutex.Unlock()
+	fake.SetResourceCacheSizeStub = stub
+}
+
+func (fake *FakeCreatedVolume) SetResourceCacheSizeArgsForCall(i int) uint64 {
+	fake.setResourceCacheSizeMutex.RLock()
+	defer fake.setResourceCacheSizeMutex.RUnlock()
+	argsForCall := fake.setResourceCacheSizeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCreatedVolume) SetResourceCacheSizeReturns(result1 error) {
+	fake.setResourceCacheSizeMutex.Lock()
+	defer fake.setResourceCacheSizeMutex.Unlock()
+	fake.SetResourceCacheSizeStub = nil
+	fake.setResourceCacheSizeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) SetResourceCacheSizeReturnsOnCall(i int, result1 error) {
+	fake.setResourceCacheSizeMutex.Lock()
+	defer fake.setResourceCacheSizeMutex.Unlock()
+	fake.SetResourceCacheSizeStub = nil
+	if fake.setResourceCacheSizeReturnsOnCall == nil {
+		fake.setResourceCacheSizeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setResourceCacheSizeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) TaskIdentifier() (int, atc.PipelineRef, string, string, error) {
 	fake.taskIdentifierMutex.Lock()
 	ret, specificReturn := fake.taskIdentifierReturnsOnCall[len(fake.taskIdentifierArgsForCall)]
@@ -1276,6 +1348,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.pathMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
+	fake.setResourceCacheSizeMutex.RLock()
+	defer fake.setResourceCacheSizeMutex.RUnlock()
 	fake.taskIdentifierMutex.RLock()
 	defer fake.taskIdentifierMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -40,6 +40,24 @@ type FakeJob struct {
 		result1 db.InputConfigs
 		result2 error
 	}
+	AutoRerunBuildStub        func(db.Build, string, time.Duration, int) (db.Build, bool, error)
+	autoRerunBuildMutex       sync.RWMutex
+	autoRerunBuildArgsForCall []struct {
+		arg1 db.Build
+		arg2 string
+		arg3 time.Duration
+		arg4 int
+	}
+	autoRerunBuildReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	autoRerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	BuildStub        func(string) (db.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -412,6 +430,20 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	RerunBuildFromFailureStub        func(db.Build, string) (db.Build, error)
+	rerunBuildFromFailureMutex       sync.RWMutex
+	rerunBuildFromFailureArgsForCall []struct {
+		arg1 db.Build
+		arg2 string
+	}
+	rerunBuildFromFailureReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunBuildFromFailureReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	SaveNextInputMappingStub        func(db.InputMapping, bool) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -647,6 +679,76 @@ func (fake *FakeJob) AlgorithmInputsReturnsOnCall(i int, result1 db.InputConfigs
 	}{result1, result2}
 }
 
+func (fake *FakeJob) AutoRerunBuild(arg1 db.Build, arg2 string, arg3 time.Duration, arg4 int) (db.Build, bool, error) {
+	fake.autoRerunBuildMutex.Lock()
+	ret, specificReturn := fake.autoRerunBuildReturnsOnCall[len(fake.autoRerunBuildArgsForCall)]
+	fake.autoRerunBuildArgsForCall = append(fake.autoRerunBuildArgsForCall, struct {
+		arg1 db.Build
+		arg2 string
+		arg3 time.Duration
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AutoRerunBuildStub
+	fakeReturns := fake.autoRerunBuildReturns
+	fake.recordInvocation("AutoRerunBuild", []interface{}{arg1, arg2, arg3, arg4})
+	fake.autoRerunBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeJob) AutoRerunBuildCallCount() int {
+	fake.autoRerunBuildMutex.RLock()
+	defer fake.autoRerunBuildMutex.RUnlock()
+	return len(fake.autoRerunBuildArgsForCall)
+}
+
+func (fake *FakeJob) AutoRerunBuildCalls(stub func(db.Build, string, time.Duration, int) (db.Build, bool, error)) {
+	fake.autoRerunBuildMutex.Lock()
+	defer fake.autoRerunBuildMutex.Unlock()
+	fake.AutoRerunBuildStub = stub
+}
+
+func (fake *FakeJob) AutoRerunBuildArgsForCall(i int) (db.Build, string, time.Duration, int) {
+	fake.autoRerunBuildMutex.RLock()
+	defer fake.autoRerunBuildMutex.RUnlock()
+	argsForCall := fake.autoRerunBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeJob) AutoRerunBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.autoRerunBuildMutex.Lock()
+	defer fake.autoRerunBuildMutex.Unlock()
+	fake.AutoRerunBuildStub = nil
+	fake.autoRerunBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) AutoRerunBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.autoRerunBuildMutex.Lock()
+	defer fake.autoRerunBuildMutex.Unlock()
+	fake.AutoRerunBuildStub = nil
+	if fake.autoRerunBuildReturnsOnCall == nil {
+		fake.autoRerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.autoRerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) Build(arg1 string) (db.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -2472,6 +2574,71 @@ func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeJob) RerunBuildFromFailure(arg1 db.Build, arg2 string) (db.Build, error) {
+	fake.rerunBuildFromFailureMutex.Lock()
+	ret, specificReturn := fake.rerunBuildFromFailureReturnsOnCall[len(fake.rerunBuildFromFailureArgsForCall)]
+	fake.rerunBuildFromFailureArgsForCall = append(fake.rerunBuildFromFailureArgsForCall, struct {
+		arg1 db.Build
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RerunBuildFromFailureStub
+	fakeReturns := fake.rerunBuildFromFailureReturns
+	fake.recordInvocation("RerunBuildFromFailure", []interface{}{arg1, arg2})
+	fake.rerunBuildFromFailureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) RerunBuildFromFailureCallCount() int {
+	fake.rerunBuildFromFailureMutex.RLock()
+	defer fake.rerunBuildFromFailureMutex.RUnlock()
+	return len(fake.rerunBuildFromFailureArgsForCall)
+}
+
+func (fake *FakeJob) RerunBuildFromFailureCalls(stub func(db.Build, string) (db.Build, error)) {
+	fake.rerunBuildFromFailureMutex.Lock()
+	defer fake.rerunBuildFromFailureMutex.Unlock()
+	fake.RerunBuildFromFailureStub = stub
+}
+
+func (fake *FakeJob) RerunBuildFromFailureArgsForCall(i int) (db.Build, string) {
+	fake.rerunBuildFromFailureMutex.RLock()
+	defer fake.rerunBuildFromFailureMutex.RUnlock()
+	argsForCall := fake.rerunBuildFromFailureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) RerunBuildFromFailureReturns(result1 db.Build, result2 error) {
+	fake.rerunBuildFromFailureMutex.Lock()
+	defer fake.rerunBuildFromFailureMutex.Unlock()
+	fake.RerunBuildFromFailureStub = nil
+	fake.rerunBuildFromFailureReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RerunBuildFromFailureReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.rerunBuildFromFailureMutex.Lock()
+	defer fake.rerunBuildFromFailureMutex.Unlock()
+	fake.RerunBuildFromFailureStub = nil
+	if fake.rerunBuildFromFailureReturnsOnCall == nil {
+		fake.rerunBuildFromFailureReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.rerunBuildFromFailureReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) SaveNextInputMapping(arg1 db.InputMapping, arg2 bool) error {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
@@ -3053,6 +3220,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.acquireSchedulingLockMutex.RUnlock()
 	fake.algorithmInputsMutex.RLock()
 	defer fake.algorithmInputsMutex.RUnlock()
+	fake.autoRerunBuildMutex.RLock()
+	defer fake.autoRerunBuildMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.buildsMutex.RLock()
@@ -3117,6 +3286,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.requestScheduleMutex.RUnlock()
 	fake.rerunBuildMutex.RLock()
 	defer fake.rerunBuildMutex.RUnlock()
+	fake.rerunBuildFromFailureMutex.RLock()
+	defer fake.rerunBuildFromFailureMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	fake.scheduleBuildMutex.RLock()
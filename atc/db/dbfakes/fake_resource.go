@@ -84,6 +84,16 @@ type FakeResource struct {
 	checkTimeoutReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ChecksPausedStub        func() bool
+	checksPausedMutex       sync.RWMutex
+	checksPausedArgsForCall []struct {
+	}
+	checksPausedReturns struct {
+		result1 bool
+	}
+	checksPausedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	ClearResourceCacheStub        func(atc.Version) (int64, error)
 	clearResourceCacheMutex       sync.RWMutex
 	clearResourceCacheArgsForCall []struct {
@@ -134,6 +144,18 @@ type FakeResource struct {
 		result2 bool
 		result3 error
 	}
+	CreateWebhookTokenStub        func() (atc.WebhookToken, error)
+	createWebhookTokenMutex       sync.RWMutex
+	createWebhookTokenArgsForCall []struct {
+	}
+	createWebhookTokenReturns struct {
+		result1 atc.WebhookToken
+		result2 error
+	}
+	createWebhookTokenReturnsOnCall map[int]struct {
+		result1 atc.WebhookToken
+		result2 error
+	}
 	CurrentPinnedVersionStub        func() atc.Version
 	currentPinnedVersionMutex       sync.RWMutex
 	currentPinnedVersionArgsForCall []struct {
@@ -221,6 +243,16 @@ type FakeResource struct {
 	lastCheckEndTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	LastCheckErrorStub        func() string
+	lastCheckErrorMutex       sync.RWMutex
+	lastCheckErrorArgsForCall []struct {
+	}
+	lastCheckErrorReturns struct {
+		result1 string
+	}
+	lastCheckErrorReturnsOnCall map[int]struct {
+		result1 string
+	}
 	LastCheckStartTimeStub        func() time.Time
 	lastCheckStartTimeMutex       sync.RWMutex
 	lastCheckStartTimeArgsForCall []struct {
@@ -241,6 +273,16 @@ type FakeResource struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NextCheckTimeStub        func() time.Time
+	nextCheckTimeMutex       sync.RWMutex
+	nextCheckTimeArgsForCall []struct {
+	}
+	nextCheckTimeReturns struct {
+		result1 time.Time
+	}
+	nextCheckTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	NotifyScanStub        func() error
 	notifyScanMutex       sync.RWMutex
 	notifyScanArgsForCall []struct {
@@ -251,6 +293,16 @@ type FakeResource struct {
 	notifyScanReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PauseChecksStub        func() error
+	pauseChecksMutex       sync.RWMutex
+	pauseChecksArgsForCall []struct {
+	}
+	pauseChecksReturns struct {
+		result1 error
+	}
+	pauseChecksReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PinCommentStub        func() string
 	pinCommentMutex       sync.RWMutex
 	pinCommentArgsForCall []struct {
@@ -370,6 +422,19 @@ type FakeResource struct {
 	resourceConfigScopeIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	RotateWebhookTokensStub        func(time.Duration) (atc.WebhookToken, error)
+	rotateWebhookTokensMutex       sync.RWMutex
+	rotateWebhookTokensArgsForCall []struct {
+		arg1 time.Duration
+	}
+	rotateWebhookTokensReturns struct {
+		result1 atc.WebhookToken
+		result2 error
+	}
+	rotateWebhookTokensReturnsOnCall map[int]struct {
+		result1 atc.WebhookToken
+		result2 error
+	}
 	SetPinCommentStub        func(string) error
 	setPinCommentMutex       sync.RWMutex
 	setPinCommentArgsForCall []struct {
@@ -442,6 +507,16 @@ type FakeResource struct {
 	typeReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UnpauseChecksStub        func() error
+	unpauseChecksMutex       sync.RWMutex
+	unpauseChecksArgsForCall []struct {
+	}
+	unpauseChecksReturns struct {
+		result1 error
+	}
+	unpauseChecksReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UnpinVersionStub        func() error
 	unpinVersionMutex       sync.RWMutex
 	unpinVersionArgsForCall []struct {
@@ -466,6 +541,21 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	UseWebhookTokenStub        func(string) (atc.WebhookToken, bool, error)
+	useWebhookTokenMutex       sync.RWMutex
+	useWebhookTokenArgsForCall []struct {
+		arg1 string
+	}
+	useWebhookTokenReturns struct {
+		result1 atc.WebhookToken
+		result2 bool
+		result3 error
+	}
+	useWebhookTokenReturnsOnCall map[int]struct {
+		result1 atc.WebhookToken
+		result2 bool
+		result3 error
+	}
 	VersionsStub        func(db.Page, atc.Version) ([]atc.ResourceVersion, db.Pagination, bool, error)
 	versionsMutex       sync.RWMutex
 	versionsArgsForCall []struct {
@@ -494,6 +584,18 @@ type FakeResource struct {
 	webhookTokenReturnsOnCall map[int]struct {
 		result1 string
 	}
+	WebhookTokensStub        func() ([]atc.WebhookToken, error)
+	webhookTokensMutex       sync.RWMutex
+	webhookTokensArgsForCall []struct {
+	}
+	webhookTokensReturns struct {
+		result1 []atc.WebhookToken
+		result2 error
+	}
+	webhookTokensReturnsOnCall map[int]struct {
+		result1 []atc.WebhookToken
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -845,6 +947,59 @@ func (fake *FakeResource) CheckTimeoutReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) ChecksPaused() bool {
+	fake.checksPausedMutex.Lock()
+	ret, specificReturn := fake.checksPausedReturnsOnCall[len(fake.checksPausedArgsForCall)]
+	fake.checksPausedArgsForCall = append(fake.checksPausedArgsForCall, struct {
+	}{})
+	stub := fake.ChecksPausedStub
+	fakeReturns := fake.checksPausedReturns
+	fake.recordInvocation("ChecksPaused", []interface{}{})
+	fake.checksPausedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) ChecksPausedCallCount() int {
+	fake.checksPausedMutex.RLock()
+	defer fake.checksPausedMutex.RUnlock()
+	return len(fake.checksPausedArgsForCall)
+}
+
+func (fake *FakeResource) ChecksPausedCalls(stub func() bool) {
+	fake.checksPausedMutex.Lock()
+	defer fake.checksPausedMutex.Unlock()
+	fake.ChecksPausedStub = stub
+}
+
+func (fake *FakeResource) ChecksPausedReturns(result1 bool) {
+	fake.checksPausedMutex.Lock()
+	defer fake.checksPausedMutex.Unlock()
+	fake.ChecksPausedStub = nil
+	fake.checksPausedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) ChecksPausedReturnsOnCall(i int, result1 bool) {
+	fake.checksPausedMutex.Lock()
+	defer fake.checksPausedMutex.Unlock()
+	fake.ChecksPausedStub = nil
+	if fake.checksPausedReturnsOnCall == nil {
+		fake.checksPausedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.checksPausedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeResource) ClearResourceCache(arg1 atc.Version) (int64, error) {
 	fake.clearResourceCacheMutex.Lock()
 	ret, specificReturn := fake.clearResourceCacheReturnsOnCall[len(fake.clearResourceCacheArgsForCall)]
@@ -1084,6 +1239,62 @@ func (fake *FakeResource) CreateBuildReturnsOnCall(i int, result1 db.Build, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResource) CreateWebhookToken() (atc.WebhookToken, error) {
+	fake.createWebhookTokenMutex.Lock()
+	ret, specificReturn := fake.createWebhookTokenReturnsOnCall[len(fake.createWebhookTokenArgsForCall)]
+	fake.createWebhookTokenArgsForCall = append(fake.createWebhookTokenArgsForCall, struct {
+	}{})
+	stub := fake.CreateWebhookTokenStub
+	fakeReturns := fake.createWebhookTokenReturns
+	fake.recordInvocation("CreateWebhookToken", []interface{}{})
+	fake.createWebhookTokenMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) CreateWebhookTokenCallCount() int {
+	fake.createWebhookTokenMutex.RLock()
+	defer fake.createWebhookTokenMutex.RUnlock()
+	return len(fake.createWebhookTokenArgsForCall)
+}
+
+func (fake *FakeResource) CreateWebhookTokenCalls(stub func() (atc.WebhookToken, error)) {
+	fake.createWebhookTokenMutex.Lock()
+	defer fake.createWebhookTokenMutex.Unlock()
+	fake.CreateWebhookTokenStub = stub
+}
+
+func (fake *FakeResource) CreateWebhookTokenReturns(result1 atc.WebhookToken, result2 error) {
+	fake.createWebhookTokenMutex.Lock()
+	defer fake.createWebhookTokenMutex.Unlock()
+	fake.CreateWebhookTokenStub = nil
+	fake.createWebhookTokenReturns = struct {
+		result1 atc.WebhookToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) CreateWebhookTokenReturnsOnCall(i int, result1 atc.WebhookToken, result2 error) {
+	fake.createWebhookTokenMutex.Lock()
+	defer fake.createWebhookTokenMutex.Unlock()
+	fake.CreateWebhookTokenStub = nil
+	if fake.createWebhookTokenReturnsOnCall == nil {
+		fake.createWebhookTokenReturnsOnCall = make(map[int]struct {
+			result1 atc.WebhookToken
+			result2 error
+		})
+	}
+	fake.createWebhookTokenReturnsOnCall[i] = struct {
+		result1 atc.WebhookToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) CurrentPinnedVersion() atc.Version {
 	fake.currentPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.currentPinnedVersionReturnsOnCall[len(fake.currentPinnedVersionArgsForCall)]
@@ -1538,6 +1749,59 @@ func (fake *FakeResource) LastCheckEndTimeReturnsOnCall(i int, result1 time.Time
 	}{result1}
 }
 
+func (fake *FakeResource) LastCheckError() string {
+	fake.lastCheckErrorMutex.Lock()
+	ret, specificReturn := fake.lastCheckErrorReturnsOnCall[len(fake.lastCheckErrorArgsForCall)]
+	fake.lastCheckErrorArgsForCall = append(fake.lastCheckErrorArgsForCall, struct {
+	}{})
+	stub := fake.LastCheckErrorStub
+	fakeReturns := fake.lastCheckErrorReturns
+	fake.recordInvocation("LastCheckError", []interface{}{})
+	fake.lastCheckErrorMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastCheckErrorCallCount() int {
+	fake.lastCheckErrorMutex.RLock()
+	defer fake.lastCheckErrorMutex.RUnlock()
+	return len(fake.lastCheckErrorArgsForCall)
+}
+
+func (fake *FakeResource) LastCheckErrorCalls(stub func() string) {
+	fake.lastCheckErrorMutex.Lock()
+	defer fake.lastCheckErrorMutex.Unlock()
+	fake.LastCheckErrorStub = stub
+}
+
+func (fake *FakeResource) LastCheckErrorReturns(result1 string) {
+	fake.lastCheckErrorMutex.Lock()
+	defer fake.lastCheckErrorMutex.Unlock()
+	fake.LastCheckErrorStub = nil
+	fake.lastCheckErrorReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckErrorReturnsOnCall(i int, result1 string) {
+	fake.lastCheckErrorMutex.Lock()
+	defer fake.lastCheckErrorMutex.Unlock()
+	fake.LastCheckErrorStub = nil
+	if fake.lastCheckErrorReturnsOnCall == nil {
+		fake.lastCheckErrorReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.lastCheckErrorReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeResource) LastCheckStartTime() time.Time {
 	fake.lastCheckStartTimeMutex.Lock()
 	ret, specificReturn := fake.lastCheckStartTimeReturnsOnCall[len(fake.lastCheckStartTimeArgsForCall)]
@@ -1644,6 +1908,59 @@ func (fake *FakeResource) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) NextCheckTime() time.Time {
+	fake.nextCheckTimeMutex.Lock()
+	ret, specificReturn := fake.nextCheckTimeReturnsOnCall[len(fake.nextCheckTimeArgsForCall)]
+	fake.nextCheckTimeArgsForCall = append(fake.nextCheckTimeArgsForCall, struct {
+	}{})
+	stub := fake.NextCheckTimeStub
+	fakeReturns := fake.nextCheckTimeReturns
+	fake.recordInvocation("NextCheckTime", []interface{}{})
+	fake.nextCheckTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) NextCheckTimeCallCount() int {
+	fake.nextCheckTimeMutex.RLock()
+	defer fake.nextCheckTimeMutex.RUnlock()
+	return len(fake.nextCheckTimeArgsForCall)
+}
+
+func (fake *FakeResource) NextCheckTimeCalls(stub func() time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = stub
+}
+
+func (fake *FakeResource) NextCheckTimeReturns(result1 time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = nil
+	fake.nextCheckTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) NextCheckTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = nil
+	if fake.nextCheckTimeReturnsOnCall == nil {
+		fake.nextCheckTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nextCheckTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeResource) NotifyScan() error {
 	fake.notifyScanMutex.Lock()
 	ret, specificReturn := fake.notifyScanReturnsOnCall[len(fake.notifyScanArgsForCall)]
@@ -1697,6 +2014,59 @@ func (fake *FakeResource) NotifyScanReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) PauseChecks() error {
+	fake.pauseChecksMutex.Lock()
+	ret, specificReturn := fake.pauseChecksReturnsOnCall[len(fake.pauseChecksArgsForCall)]
+	fake.pauseChecksArgsForCall = append(fake.pauseChecksArgsForCall, struct {
+	}{})
+	stub := fake.PauseChecksStub
+	fakeReturns := fake.pauseChecksReturns
+	fake.recordInvocation("PauseChecks", []interface{}{})
+	fake.pauseChecksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PauseChecksCallCount() int {
+	fake.pauseChecksMutex.RLock()
+	defer fake.pauseChecksMutex.RUnlock()
+	return len(fake.pauseChecksArgsForCall)
+}
+
+func (fake *FakeResource) PauseChecksCalls(stub func() error) {
+	fake.pauseChecksMutex.Lock()
+	defer fake.pauseChecksMutex.Unlock()
+	fake.PauseChecksStub = stub
+}
+
+func (fake *FakeResource) PauseChecksReturns(result1 error) {
+	fake.pauseChecksMutex.Lock()
+	defer fake.pauseChecksMutex.Unlock()
+	fake.PauseChecksStub = nil
+	fake.pauseChecksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) PauseChecksReturnsOnCall(i int, result1 error) {
+	fake.pauseChecksMutex.Lock()
+	defer fake.pauseChecksMutex.Unlock()
+	fake.PauseChecksStub = nil
+	if fake.pauseChecksReturnsOnCall == nil {
+		fake.pauseChecksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseChecksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) PinComment() string {
 	fake.pinCommentMutex.Lock()
 	ret, specificReturn := fake.pinCommentReturnsOnCall[len(fake.pinCommentArgsForCall)]
@@ -2300,6 +2670,70 @@ func (fake *FakeResource) ResourceConfigScopeIDReturnsOnCall(i int, result1 int)
 	}{result1}
 }
 
+func (fake *FakeResource) RotateWebhookTokens(arg1 time.Duration) (atc.WebhookToken, error) {
+	fake.rotateWebhookTokensMutex.Lock()
+	ret, specificReturn := fake.rotateWebhookTokensReturnsOnCall[len(fake.rotateWebhookTokensArgsForCall)]
+	fake.rotateWebhookTokensArgsForCall = append(fake.rotateWebhookTokensArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.RotateWebhookTokensStub
+	fakeReturns := fake.rotateWebhookTokensReturns
+	fake.recordInvocation("RotateWebhookTokens", []interface{}{arg1})
+	fake.rotateWebhookTokensMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) RotateWebhookTokensCallCount() int {
+	fake.rotateWebhookTokensMutex.RLock()
+	defer fake.rotateWebhookTokensMutex.RUnlock()
+	return len(fake.rotateWebhookTokensArgsForCall)
+}
+
+func (fake *FakeResource) RotateWebhookTokensCalls(stub func(time.Duration) (atc.WebhookToken, error)) {
+	fake.rotateWebhookTokensMutex.Lock()
+	defer fake.rotateWebhookTokensMutex.Unlock()
+	fake.RotateWebhookTokensStub = stub
+}
+
+func (fake *FakeResource) RotateWebhookTokensArgsForCall(i int) time.Duration {
+	fake.rotateWebhookTokensMutex.RLock()
+	defer fake.rotateWebhookTokensMutex.RUnlock()
+	argsForCall := fake.rotateWebhookTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) RotateWebhookTokensReturns(result1 atc.WebhookToken, result2 error) {
+	fake.rotateWebhookTokensMutex.Lock()
+	defer fake.rotateWebhookTokensMutex.Unlock()
+	fake.RotateWebhookTokensStub = nil
+	fake.rotateWebhookTokensReturns = struct {
+		result1 atc.WebhookToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) RotateWebhookTokensReturnsOnCall(i int, result1 atc.WebhookToken, result2 error) {
+	fake.rotateWebhookTokensMutex.Lock()
+	defer fake.rotateWebhookTokensMutex.Unlock()
+	fake.RotateWebhookTokensStub = nil
+	if fake.rotateWebhookTokensReturnsOnCall == nil {
+		fake.rotateWebhookTokensReturnsOnCall = make(map[int]struct {
+			result1 atc.WebhookToken
+			result2 error
+		})
+	}
+	fake.rotateWebhookTokensReturnsOnCall[i] = struct {
+		result1 atc.WebhookToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) SetPinComment(arg1 string) error {
 	fake.setPinCommentMutex.Lock()
 	ret, specificReturn := fake.setPinCommentReturnsOnCall[len(fake.setPinCommentArgsForCall)]
@@ -2687,6 +3121,59 @@ func (fake *FakeResource) TypeReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) UnpauseChecks() error {
+	fake.unpauseChecksMutex.Lock()
+	ret, specificReturn := fake.unpauseChecksReturnsOnCall[len(fake.unpauseChecksArgsForCall)]
+	fake.unpauseChecksArgsForCall = append(fake.unpauseChecksArgsForCall, struct {
+	}{})
+	stub := fake.UnpauseChecksStub
+	fakeReturns := fake.unpauseChecksReturns
+	fake.recordInvocation("UnpauseChecks", []interface{}{})
+	fake.unpauseChecksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) UnpauseChecksCallCount() int {
+	fake.unpauseChecksMutex.RLock()
+	defer fake.unpauseChecksMutex.RUnlock()
+	return len(fake.unpauseChecksArgsForCall)
+}
+
+func (fake *FakeResource) UnpauseChecksCalls(stub func() error) {
+	fake.unpauseChecksMutex.Lock()
+	defer fake.unpauseChecksMutex.Unlock()
+	fake.UnpauseChecksStub = stub
+}
+
+func (fake *FakeResource) UnpauseChecksReturns(result1 error) {
+	fake.unpauseChecksMutex.Lock()
+	defer fake.unpauseChecksMutex.Unlock()
+	fake.UnpauseChecksStub = nil
+	fake.unpauseChecksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) UnpauseChecksReturnsOnCall(i int, result1 error) {
+	fake.unpauseChecksMutex.Lock()
+	defer fake.unpauseChecksMutex.Unlock()
+	fake.UnpauseChecksStub = nil
+	if fake.unpauseChecksReturnsOnCall == nil {
+		fake.unpauseChecksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unpauseChecksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) UnpinVersion() error {
 	fake.unpinVersionMutex.Lock()
 	ret, specificReturn := fake.unpinVersionReturnsOnCall[len(fake.unpinVersionArgsForCall)]
@@ -2805,6 +3292,73 @@ func (fake *FakeResource) UpdateMetadataReturnsOnCall(i int, result1 bool, resul
 	}{result1, result2}
 }
 
+func (fake *FakeResource) UseWebhookToken(arg1 string) (atc.WebhookToken, bool, error) {
+	fake.useWebhookTokenMutex.Lock()
+	ret, specificReturn := fake.useWebhookTokenReturnsOnCall[len(fake.useWebhookTokenArgsForCall)]
+	fake.useWebhookTokenArgsForCall = append(fake.useWebhookTokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.UseWebhookTokenStub
+	fakeReturns := fake.useWebhookTokenReturns
+	fake.recordInvocation("UseWebhookToken", []interface{}{arg1})
+	fake.useWebhookTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) UseWebhookTokenCallCount() int {
+	fake.useWebhookTokenMutex.RLock()
+	defer fake.useWebhookTokenMutex.RUnlock()
+	return len(fake.useWebhookTokenArgsForCall)
+}
+
+func (fake *FakeResource) UseWebhookTokenCalls(stub func(string) (atc.WebhookToken, bool, error)) {
+	fake.useWebhookTokenMutex.Lock()
+	defer fake.useWebhookTokenMutex.Unlock()
+	fake.UseWebhookTokenStub = stub
+}
+
+func (fake *FakeResource) UseWebhookTokenArgsForCall(i int) string {
+	fake.useWebhookTokenMutex.RLock()
+	defer fake.useWebhookTokenMutex.RUnlock()
+	argsForCall := fake.useWebhookTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) UseWebhookTokenReturns(result1 atc.WebhookToken, result2 bool, result3 error) {
+	fake.useWebhookTokenMutex.Lock()
+	defer fake.useWebhookTokenMutex.Unlock()
+	fake.UseWebhookTokenStub = nil
+	fake.useWebhookTokenReturns = struct {
+		result1 atc.WebhookToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) UseWebhookTokenReturnsOnCall(i int, result1 atc.WebhookToken, result2 bool, result3 error) {
+	fake.useWebhookTokenMutex.Lock()
+	defer fake.useWebhookTokenMutex.Unlock()
+	fake.UseWebhookTokenStub = nil
+	if fake.useWebhookTokenReturnsOnCall == nil {
+		fake.useWebhookTokenReturnsOnCall = make(map[int]struct {
+			result1 atc.WebhookToken
+			result2 bool
+			result3 error
+		})
+	}
+	fake.useWebhookTokenReturnsOnCall[i] = struct {
+		result1 atc.WebhookToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResource) Versions(arg1 db.Page, arg2 atc.Version) ([]atc.ResourceVersion, db.Pagination, bool, error) {
 	fake.versionsMutex.Lock()
 	ret, specificReturn := fake.versionsReturnsOnCall[len(fake.versionsArgsForCall)]
@@ -2929,6 +3483,62 @@ func (fake *FakeResource) WebhookTokenReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) WebhookTokens() ([]atc.WebhookToken, error) {
+	fake.webhookTokensMutex.Lock()
+	ret, specificReturn := fake.webhookTokensReturnsOnCall[len(fake.webhookTokensArgsForCall)]
+	fake.webhookTokensArgsForCall = append(fake.webhookTokensArgsForCall, struct {
+	}{})
+	stub := fake.WebhookTokensStub
+	fakeReturns := fake.webhookTokensReturns
+	fake.recordInvocation("WebhookTokens", []interface{}{})
+	fake.webhookTokensMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) WebhookTokensCallCount() int {
+	fake.webhookTokensMutex.RLock()
+	defer fake.webhookTokensMutex.RUnlock()
+	return len(fake.webhookTokensArgsForCall)
+}
+
+func (fake *FakeResource) WebhookTokensCalls(stub func() ([]atc.WebhookToken, error)) {
+	fake.webhookTokensMutex.Lock()
+	defer fake.webhookTokensMutex.Unlock()
+	fake.WebhookTokensStub = stub
+}
+
+func (fake *FakeResource) WebhookTokensReturns(result1 []atc.WebhookToken, result2 error) {
+	fake.webhookTokensMutex.Lock()
+	defer fake.webhookTokensMutex.Unlock()
+	fake.WebhookTokensStub = nil
+	fake.webhookTokensReturns = struct {
+		result1 []atc.WebhookToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) WebhookTokensReturnsOnCall(i int, result1 []atc.WebhookToken, result2 error) {
+	fake.webhookTokensMutex.Lock()
+	defer fake.webhookTokensMutex.Unlock()
+	fake.WebhookTokensStub = nil
+	if fake.webhookTokensReturnsOnCall == nil {
+		fake.webhookTokensReturnsOnCall = make(map[int]struct {
+			result1 []atc.WebhookToken
+			result2 error
+		})
+	}
+	fake.webhookTokensReturnsOnCall[i] = struct {
+		result1 []atc.WebhookToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2944,6 +3554,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.checkPlanMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
+	fake.checksPausedMutex.RLock()
+	defer fake.checksPausedMutex.RUnlock()
 	fake.clearResourceCacheMutex.RLock()
 	defer fake.clearResourceCacheMutex.RUnlock()
 	fake.configMutex.RLock()
@@ -2952,6 +3564,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.configPinnedVersionMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createWebhookTokenMutex.RLock()
+	defer fake.createWebhookTokenMutex.RUnlock()
 	fake.currentPinnedVersionMutex.RLock()
 	defer fake.currentPinnedVersionMutex.RUnlock()
 	fake.disableVersionMutex.RLock()
@@ -2968,12 +3582,18 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.iconMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
+	fake.lastCheckErrorMutex.RLock()
+	defer fake.lastCheckErrorMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
 	defer fake.lastCheckStartTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.nextCheckTimeMutex.RLock()
+	defer fake.nextCheckTimeMutex.RUnlock()
 	fake.notifyScanMutex.RLock()
 	defer fake.notifyScanMutex.RUnlock()
+	fake.pauseChecksMutex.RLock()
+	defer fake.pauseChecksMutex.RUnlock()
 	fake.pinCommentMutex.RLock()
 	defer fake.pinCommentMutex.RUnlock()
 	fake.pinVersionMutex.RLock()
@@ -2996,6 +3616,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.resourceConfigIDMutex.RUnlock()
 	fake.resourceConfigScopeIDMutex.RLock()
 	defer fake.resourceConfigScopeIDMutex.RUnlock()
+	fake.rotateWebhookTokensMutex.RLock()
+	defer fake.rotateWebhookTokensMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
 	fake.setResourceConfigScopeMutex.RLock()
@@ -3010,14 +3632,20 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.typeMutex.RLock()
 	defer fake.typeMutex.RUnlock()
+	fake.unpauseChecksMutex.RLock()
+	defer fake.unpauseChecksMutex.RUnlock()
 	fake.unpinVersionMutex.RLock()
 	defer fake.unpinVersionMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
 	defer fake.updateMetadataMutex.RUnlock()
+	fake.useWebhookTokenMutex.RLock()
+	defer fake.useWebhookTokenMutex.RUnlock()
 	fake.versionsMutex.RLock()
 	defer fake.versionsMutex.RUnlock()
 	fake.webhookTokenMutex.RLock()
 	defer fake.webhookTokenMutex.RUnlock()
+	fake.webhookTokensMutex.RLock()
+	defer fake.webhookTokensMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
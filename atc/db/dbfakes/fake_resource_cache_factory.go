@@ -42,6 +42,19 @@ type FakeResourceCacheFactory struct {
 		result2 bool
 		result3 error
 	}
+	RequestReplicationStub        func(db.ResourceCache, int, []string) error
+	requestReplicationMutex       sync.RWMutex
+	requestReplicationArgsForCall []struct {
+		arg1 db.ResourceCache
+		arg2 int
+		arg3 []string
+	}
+	requestReplicationReturns struct {
+		result1 error
+	}
+	requestReplicationReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ResourceCacheMetadataStub        func(db.ResourceCache) (db.ResourceConfigMetadataFields, error)
 	resourceCacheMetadataMutex       sync.RWMutex
 	resourceCacheMetadataArgsForCall []struct {
@@ -207,6 +220,69 @@ func (fake *FakeResourceCacheFactory) FindResourceCacheByIDReturnsOnCall(i int,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceCacheFactory) RequestReplication(arg1 db.ResourceCache, arg2 int, arg3 []string) error {
+	fake.requestReplicationMutex.Lock()
+	ret, specificReturn := fake.requestReplicationReturnsOnCall[len(fake.requestReplicationArgsForCall)]
+	fake.requestReplicationArgsForCall = append(fake.requestReplicationArgsForCall, struct {
+		arg1 db.ResourceCache
+		arg2 int
+		arg3 []string
+	}{arg1, arg2, arg3})
+	stub := fake.RequestReplicationStub
+	fakeReturns := fake.requestReplicationReturns
+	fake.recordInvocation("RequestReplication", []interface{}{arg1, arg2, arg3})
+	fake.requestReplicationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceCacheFactory) RequestReplicationCallCount() int {
+	fake.requestReplicationMutex.RLock()
+	defer fake.requestReplicationMutex.RUnlock()
+	return len(fake.requestReplicationArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) RequestReplicationCalls(stub func(db.ResourceCache, int, []string) error) {
+	fake.requestReplicationMutex.Lock()
+	defer fake.requestReplicationMutex.Unlock()
+	fake.RequestReplicationStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) RequestReplicationArgsForCall(i int) (db.ResourceCache, int, []string) {
+	fake.requestReplicationMutex.RLock()
+	defer fake.requestReplicationMutex.RUnlock()
+	argsForCall := fake.requestReplicationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceCacheFactory) RequestReplicationReturns(result1 error) {
+	fake.requestReplicationMutex.Lock()
+	defer fake.requestReplicationMutex.Unlock()
+	fake.RequestReplicationStub = nil
+	fake.requestReplicationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceCacheFactory) RequestReplicationReturnsOnCall(i int, result1 error) {
+	fake.requestReplicationMutex.Lock()
+	defer fake.requestReplicationMutex.Unlock()
+	fake.RequestReplicationStub = nil
+	if fake.requestReplicationReturnsOnCall == nil {
+		fake.requestReplicationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.requestReplicationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceCacheFactory) ResourceCacheMetadata(arg1 db.ResourceCache) (db.ResourceConfigMetadataFields, error) {
 	fake.resourceCacheMetadataMutex.Lock()
 	ret, specificReturn := fake.resourceCacheMetadataReturnsOnCall[len(fake.resourceCacheMetadataArgsForCall)]
@@ -345,6 +421,8 @@ func (fake *FakeResourceCacheFactory) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateResourceCacheMutex.RUnlock()
 	fake.findResourceCacheByIDMutex.RLock()
 	defer fake.findResourceCacheByIDMutex.RUnlock()
+	fake.requestReplicationMutex.RLock()
+	defer fake.requestReplicationMutex.RUnlock()
 	fake.resourceCacheMetadataMutex.RLock()
 	defer fake.resourceCacheMetadataMutex.RUnlock()
 	fake.updateResourceCacheMetadataMutex.RLock()
@@ -42,6 +42,35 @@ type FakeResourceCacheLifecycle struct {
 	cleanUsesForFinishedBuildsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EvictCachesOverTeamQuotaStub        func(lager.Logger, int, map[string]int) ([]db.TeamCacheEviction, error)
+	evictCachesOverTeamQuotaMutex       sync.RWMutex
+	evictCachesOverTeamQuotaArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 map[string]int
+	}
+	evictCachesOverTeamQuotaReturns struct {
+		result1 []db.TeamCacheEviction
+		result2 error
+	}
+	evictCachesOverTeamQuotaReturnsOnCall map[int]struct {
+		result1 []db.TeamCacheEviction
+		result2 error
+	}
+	EvictCachesOverWorkerSizeLimitStub        func(lager.Logger, uint64) ([]db.WorkerCacheEviction, error)
+	evictCachesOverWorkerSizeLimitMutex       sync.RWMutex
+	evictCachesOverWorkerSizeLimitArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 uint64
+	}
+	evictCachesOverWorkerSizeLimitReturns struct {
+		result1 []db.WorkerCacheEviction
+		result2 error
+	}
+	evictCachesOverWorkerSizeLimitReturnsOnCall map[int]struct {
+		result1 []db.WorkerCacheEviction
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -229,6 +258,137 @@ func (fake *FakeResourceCacheLifecycle) CleanUsesForFinishedBuildsReturnsOnCall(
 	}{result1}
 }
 
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverTeamQuota(arg1 lager.Logger, arg2 int, arg3 map[string]int) ([]db.TeamCacheEviction, error) {
+	fake.evictCachesOverTeamQuotaMutex.Lock()
+	ret, specificReturn := fake.evictCachesOverTeamQuotaReturnsOnCall[len(fake.evictCachesOverTeamQuotaArgsForCall)]
+	fake.evictCachesOverTeamQuotaArgsForCall = append(fake.evictCachesOverTeamQuotaArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 map[string]int
+	}{arg1, arg2, arg3})
+	stub := fake.EvictCachesOverTeamQuotaStub
+	fakeReturns := fake.evictCachesOverTeamQuotaReturns
+	fake.recordInvocation("EvictCachesOverTeamQuota", []interface{}{arg1, arg2, arg3})
+	fake.evictCachesOverTeamQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverTeamQuotaCallCount() int {
+	fake.evictCachesOverTeamQuotaMutex.RLock()
+	defer fake.evictCachesOverTeamQuotaMutex.RUnlock()
+	return len(fake.evictCachesOverTeamQuotaArgsForCall)
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverTeamQuotaCalls(stub func(lager.Logger, int, map[string]int) ([]db.TeamCacheEviction, error)) {
+	fake.evictCachesOverTeamQuotaMutex.Lock()
+	defer fake.evictCachesOverTeamQuotaMutex.Unlock()
+	fake.EvictCachesOverTeamQuotaStub = stub
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverTeamQuotaArgsForCall(i int) (lager.Logger, int, map[string]int) {
+	fake.evictCachesOverTeamQuotaMutex.RLock()
+	defer fake.evictCachesOverTeamQuotaMutex.RUnlock()
+	argsForCall := fake.evictCachesOverTeamQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverTeamQuotaReturns(result1 []db.TeamCacheEviction, result2 error) {
+	fake.evictCachesOverTeamQuotaMutex.Lock()
+	defer fake.evictCachesOverTeamQuotaMutex.Unlock()
+	fake.EvictCachesOverTeamQuotaStub = nil
+	fake.evictCachesOverTeamQuotaReturns = struct {
+		result1 []db.TeamCacheEviction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverTeamQuotaReturnsOnCall(i int, result1 []db.TeamCacheEviction, result2 error) {
+	fake.evictCachesOverTeamQuotaMutex.Lock()
+	defer fake.evictCachesOverTeamQuotaMutex.Unlock()
+	fake.EvictCachesOverTeamQuotaStub = nil
+	if fake.evictCachesOverTeamQuotaReturnsOnCall == nil {
+		fake.evictCachesOverTeamQuotaReturnsOnCall = make(map[int]struct {
+			result1 []db.TeamCacheEviction
+			result2 error
+		})
+	}
+	fake.evictCachesOverTeamQuotaReturnsOnCall[i] = struct {
+		result1 []db.TeamCacheEviction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverWorkerSizeLimit(arg1 lager.Logger, arg2 uint64) ([]db.WorkerCacheEviction, error) {
+	fake.evictCachesOverWorkerSizeLimitMutex.Lock()
+	ret, specificReturn := fake.evictCachesOverWorkerSizeLimitReturnsOnCall[len(fake.evictCachesOverWorkerSizeLimitArgsForCall)]
+	fake.evictCachesOverWorkerSizeLimitArgsForCall = append(fake.evictCachesOverWorkerSizeLimitArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 uint64
+	}{arg1, arg2})
+	stub := fake.EvictCachesOverWorkerSizeLimitStub
+	fakeReturns := fake.evictCachesOverWorkerSizeLimitReturns
+	fake.recordInvocation("EvictCachesOverWorkerSizeLimit", []interface{}{arg1, arg2})
+	fake.evictCachesOverWorkerSizeLimitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverWorkerSizeLimitCallCount() int {
+	fake.evictCachesOverWorkerSizeLimitMutex.RLock()
+	defer fake.evictCachesOverWorkerSizeLimitMutex.RUnlock()
+	return len(fake.evictCachesOverWorkerSizeLimitArgsForCall)
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverWorkerSizeLimitCalls(stub func(lager.Logger, uint64) ([]db.WorkerCacheEviction, error)) {
+	fake.evictCachesOverWorkerSizeLimitMutex.Lock()
+	defer fake.evictCachesOverWorkerSizeLimitMutex.Unlock()
+	fake.EvictCachesOverWorkerSizeLimitStub = stub
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverWorkerSizeLimitArgsForCall(i int) (lager.Logger, uint64) {
+	fake.evictCachesOverWorkerSizeLimitMutex.RLock()
+	defer fake.evictCachesOverWorkerSizeLimitMutex.RUnlock()
+	argsForCall := fake.evictCachesOverWorkerSizeLimitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverWorkerSizeLimitReturns(result1 []db.WorkerCacheEviction, result2 error) {
+	fake.evictCachesOverWorkerSizeLimitMutex.Lock()
+	defer fake.evictCachesOverWorkerSizeLimitMutex.Unlock()
+	fake.EvictCachesOverWorkerSizeLimitStub = nil
+	fake.evictCachesOverWorkerSizeLimitReturns = struct {
+		result1 []db.WorkerCacheEviction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheLifecycle) EvictCachesOverWorkerSizeLimitReturnsOnCall(i int, result1 []db.WorkerCacheEviction, result2 error) {
+	fake.evictCachesOverWorkerSizeLimitMutex.Lock()
+	defer fake.evictCachesOverWorkerSizeLimitMutex.Unlock()
+	fake.EvictCachesOverWorkerSizeLimitStub = nil
+	if fake.evictCachesOverWorkerSizeLimitReturnsOnCall == nil {
+		fake.evictCachesOverWorkerSizeLimitReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerCacheEviction
+			result2 error
+		})
+	}
+	fake.evictCachesOverWorkerSizeLimitReturnsOnCall[i] = struct {
+		result1 []db.WorkerCacheEviction
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceCacheLifecycle) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -238,6 +398,10 @@ func (fake *FakeResourceCacheLifecycle) Invocations() map[string][][]interface{}
 	defer fake.cleanUpInvalidCachesMutex.RUnlock()
 	fake.cleanUsesForFinishedBuildsMutex.RLock()
 	defer fake.cleanUsesForFinishedBuildsMutex.RUnlock()
+	fake.evictCachesOverTeamQuotaMutex.RLock()
+	defer fake.evictCachesOverTeamQuotaMutex.RUnlock()
+	fake.evictCachesOverWorkerSizeLimitMutex.RLock()
+	defer fake.evictCachesOverWorkerSizeLimitMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -109,6 +109,18 @@ type FakeResourceConfigScope struct {
 	saveVersionsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SiblingScopesStub        func() ([]db.ResourceConfigScope, error)
+	siblingScopesMutex       sync.RWMutex
+	siblingScopesArgsForCall []struct {
+	}
+	siblingScopesReturns struct {
+		result1 []db.ResourceConfigScope
+		result2 error
+	}
+	siblingScopesReturnsOnCall map[int]struct {
+		result1 []db.ResourceConfigScope
+		result2 error
+	}
 	UpdateLastCheckEndTimeStub        func(bool) (bool, error)
 	updateLastCheckEndTimeMutex       sync.RWMutex
 	updateLastCheckEndTimeArgsForCall []struct {
@@ -122,6 +134,19 @@ type FakeResourceConfigScope struct {
 		result1 bool
 		result2 error
 	}
+	UpdateLastCheckErrorStub        func(string) (bool, error)
+	updateLastCheckErrorMutex       sync.RWMutex
+	updateLastCheckErrorArgsForCall []struct {
+		arg1 string
+	}
+	updateLastCheckErrorReturns struct {
+		result1 bool
+		result2 error
+	}
+	updateLastCheckErrorReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UpdateLastCheckStartTimeStub        func() (bool, error)
 	updateLastCheckStartTimeMutex       sync.RWMutex
 	updateLastCheckStartTimeArgsForCall []struct {
@@ -613,6 +638,62 @@ func (fake *FakeResourceConfigScope) SaveVersionsReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *FakeResourceConfigScope) SiblingScopes() ([]db.ResourceConfigScope, error) {
+	fake.siblingScopesMutex.Lock()
+	ret, specificReturn := fake.siblingScopesReturnsOnCall[len(fake.siblingScopesArgsForCall)]
+	fake.siblingScopesArgsForCall = append(fake.siblingScopesArgsForCall, struct {
+	}{})
+	stub := fake.SiblingScopesStub
+	fakeReturns := fake.siblingScopesReturns
+	fake.recordInvocation("SiblingScopes", []interface{}{})
+	fake.siblingScopesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) SiblingScopesCallCount() int {
+	fake.siblingScopesMutex.RLock()
+	defer fake.siblingScopesMutex.RUnlock()
+	return len(fake.siblingScopesArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) SiblingScopesCalls(stub func() ([]db.ResourceConfigScope, error)) {
+	fake.siblingScopesMutex.Lock()
+	defer fake.siblingScopesMutex.Unlock()
+	fake.SiblingScopesStub = stub
+}
+
+func (fake *FakeResourceConfigScope) SiblingScopesReturns(result1 []db.ResourceConfigScope, result2 error) {
+	fake.siblingScopesMutex.Lock()
+	defer fake.siblingScopesMutex.Unlock()
+	fake.SiblingScopesStub = nil
+	fake.siblingScopesReturns = struct {
+		result1 []db.ResourceConfigScope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) SiblingScopesReturnsOnCall(i int, result1 []db.ResourceConfigScope, result2 error) {
+	fake.siblingScopesMutex.Lock()
+	defer fake.siblingScopesMutex.Unlock()
+	fake.SiblingScopesStub = nil
+	if fake.siblingScopesReturnsOnCall == nil {
+		fake.siblingScopesReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceConfigScope
+			result2 error
+		})
+	}
+	fake.siblingScopesReturnsOnCall[i] = struct {
+		result1 []db.ResourceConfigScope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceConfigScope) UpdateLastCheckEndTime(arg1 bool) (bool, error) {
 	fake.updateLastCheckEndTimeMutex.Lock()
 	ret, specificReturn := fake.updateLastCheckEndTimeReturnsOnCall[len(fake.updateLastCheckEndTimeArgsForCall)]
@@ -677,6 +758,70 @@ func (fake *FakeResourceConfigScope) UpdateLastCheckEndTimeReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeResourceConfigScope) UpdateLastCheckError(arg1 string) (bool, error) {
+	fake.updateLastCheckErrorMutex.Lock()
+	ret, specificReturn := fake.updateLastCheckErrorReturnsOnCall[len(fake.updateLastCheckErrorArgsForCall)]
+	fake.updateLastCheckErrorArgsForCall = append(fake.updateLastCheckErrorArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.UpdateLastCheckErrorStub
+	fakeReturns := fake.updateLastCheckErrorReturns
+	fake.recordInvocation("UpdateLastCheckError", []interface{}{arg1})
+	fake.updateLastCheckErrorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorCallCount() int {
+	fake.updateLastCheckErrorMutex.RLock()
+	defer fake.updateLastCheckErrorMutex.RUnlock()
+	return len(fake.updateLastCheckErrorArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorCalls(stub func(string) (bool, error)) {
+	fake.updateLastCheckErrorMutex.Lock()
+	defer fake.updateLastCheckErrorMutex.Unlock()
+	fake.UpdateLastCheckErrorStub = stub
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorArgsForCall(i int) string {
+	fake.updateLastCheckErrorMutex.RLock()
+	defer fake.updateLastCheckErrorMutex.RUnlock()
+	argsForCall := fake.updateLastCheckErrorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorReturns(result1 bool, result2 error) {
+	fake.updateLastCheckErrorMutex.Lock()
+	defer fake.updateLastCheckErrorMutex.Unlock()
+	fake.UpdateLastCheckErrorStub = nil
+	fake.updateLastCheckErrorReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.updateLastCheckErrorMutex.Lock()
+	defer fake.updateLastCheckErrorMutex.Unlock()
+	fake.UpdateLastCheckErrorStub = nil
+	if fake.updateLastCheckErrorReturnsOnCall == nil {
+		fake.updateLastCheckErrorReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.updateLastCheckErrorReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceConfigScope) UpdateLastCheckStartTime() (bool, error) {
 	fake.updateLastCheckStartTimeMutex.Lock()
 	ret, specificReturn := fake.updateLastCheckStartTimeReturnsOnCall[len(fake.updateLastCheckStartTimeArgsForCall)]
@@ -752,8 +897,12 @@ func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	defer fake.resourceConfigMutex.RUnlock()
 	fake.saveVersionsMutex.RLock()
 	defer fake.saveVersionsMutex.RUnlock()
+	fake.siblingScopesMutex.RLock()
+	defer fake.siblingScopesMutex.RUnlock()
 	fake.updateLastCheckEndTimeMutex.RLock()
 	defer fake.updateLastCheckEndTimeMutex.RUnlock()
+	fake.updateLastCheckErrorMutex.RLock()
+	defer fake.updateLastCheckErrorMutex.RUnlock()
 	fake.updateLastCheckStartTimeMutex.RLock()
 	defer fake.updateLastCheckStartTimeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -98,6 +98,19 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateStoredArtifactStub        func(string) (db.WorkerArtifact, error)
+	createStoredArtifactMutex       sync.RWMutex
+	createStoredArtifactArgsForCall []struct {
+		arg1 string
+	}
+	createStoredArtifactReturns struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	createStoredArtifactReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -168,6 +181,21 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	FindStoredWorkerArtifactStub        func(int) (db.WorkerArtifact, bool, error)
+	findStoredWorkerArtifactMutex       sync.RWMutex
+	findStoredWorkerArtifactArgsForCall []struct {
+		arg1 int
+	}
+	findStoredWorkerArtifactReturns struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}
+	findStoredWorkerArtifactReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}
 	FindVolumeForWorkerArtifactStub        func(int) (db.CreatedVolume, bool, error)
 	findVolumeForWorkerArtifactMutex       sync.RWMutex
 	findVolumeForWorkerArtifactArgsForCall []struct {
@@ -850,6 +878,70 @@ func (fake *FakeTeam) CreateStartedBuildReturnsOnCall(i int, result1 db.Build, r
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateStoredArtifact(arg1 string) (db.WorkerArtifact, error) {
+	fake.createStoredArtifactMutex.Lock()
+	ret, specificReturn := fake.createStoredArtifactReturnsOnCall[len(fake.createStoredArtifactArgsForCall)]
+	fake.createStoredArtifactArgsForCall = append(fake.createStoredArtifactArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CreateStoredArtifactStub
+	fakeReturns := fake.createStoredArtifactReturns
+	fake.recordInvocation("CreateStoredArtifact", []interface{}{arg1})
+	fake.createStoredArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateStoredArtifactCallCount() int {
+	fake.createStoredArtifactMutex.RLock()
+	defer fake.createStoredArtifactMutex.RUnlock()
+	return len(fake.createStoredArtifactArgsForCall)
+}
+
+func (fake *FakeTeam) CreateStoredArtifactCalls(stub func(string) (db.WorkerArtifact, error)) {
+	fake.createStoredArtifactMutex.Lock()
+	defer fake.createStoredArtifactMutex.Unlock()
+	fake.CreateStoredArtifactStub = stub
+}
+
+func (fake *FakeTeam) CreateStoredArtifactArgsForCall(i int) string {
+	fake.createStoredArtifactMutex.RLock()
+	defer fake.createStoredArtifactMutex.RUnlock()
+	argsForCall := fake.createStoredArtifactArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateStoredArtifactReturns(result1 db.WorkerArtifact, result2 error) {
+	fake.createStoredArtifactMutex.Lock()
+	defer fake.createStoredArtifactMutex.Unlock()
+	fake.CreateStoredArtifactStub = nil
+	fake.createStoredArtifactReturns = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateStoredArtifactReturnsOnCall(i int, result1 db.WorkerArtifact, result2 error) {
+	fake.createStoredArtifactMutex.Lock()
+	defer fake.createStoredArtifactMutex.Unlock()
+	fake.CreateStoredArtifactStub = nil
+	if fake.createStoredArtifactReturnsOnCall == nil {
+		fake.createStoredArtifactReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 error
+		})
+	}
+	fake.createStoredArtifactReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Delete() error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -1170,6 +1262,73 @@ func (fake *FakeTeam) FindCreatedContainerByHandleReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) FindStoredWorkerArtifact(arg1 int) (db.WorkerArtifact, bool, error) {
+	fake.findStoredWorkerArtifactMutex.Lock()
+	ret, specificReturn := fake.findStoredWorkerArtifactReturnsOnCall[len(fake.findStoredWorkerArtifactArgsForCall)]
+	fake.findStoredWorkerArtifactArgsForCall = append(fake.findStoredWorkerArtifactArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.FindStoredWorkerArtifactStub
+	fakeReturns := fake.findStoredWorkerArtifactReturns
+	fake.recordInvocation("FindStoredWorkerArtifact", []interface{}{arg1})
+	fake.findStoredWorkerArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) FindStoredWorkerArtifactCallCount() int {
+	fake.findStoredWorkerArtifactMutex.RLock()
+	defer fake.findStoredWorkerArtifactMutex.RUnlock()
+	return len(fake.findStoredWorkerArtifactArgsForCall)
+}
+
+func (fake *FakeTeam) FindStoredWorkerArtifactCalls(stub func(int) (db.WorkerArtifact, bool, error)) {
+	fake.findStoredWorkerArtifactMutex.Lock()
+	defer fake.findStoredWorkerArtifactMutex.Unlock()
+	fake.FindStoredWorkerArtifactStub = stub
+}
+
+func (fake *FakeTeam) FindStoredWorkerArtifactArgsForCall(i int) int {
+	fake.findStoredWorkerArtifactMutex.RLock()
+	defer fake.findStoredWorkerArtifactMutex.RUnlock()
+	argsForCall := fake.findStoredWorkerArtifactArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) FindStoredWorkerArtifactReturns(result1 db.WorkerArtifact, result2 bool, result3 error) {
+	fake.findStoredWorkerArtifactMutex.Lock()
+	defer fake.findStoredWorkerArtifactMutex.Unlock()
+	fake.FindStoredWorkerArtifactStub = nil
+	fake.findStoredWorkerArtifactReturns = struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) FindStoredWorkerArtifactReturnsOnCall(i int, result1 db.WorkerArtifact, result2 bool, result3 error) {
+	fake.findStoredWorkerArtifactMutex.Lock()
+	defer fake.findStoredWorkerArtifactMutex.Unlock()
+	fake.FindStoredWorkerArtifactStub = nil
+	if fake.findStoredWorkerArtifactReturnsOnCall == nil {
+		fake.findStoredWorkerArtifactReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findStoredWorkerArtifactReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) FindVolumeForWorkerArtifact(arg1 int) (db.CreatedVolume, bool, error) {
 	fake.findVolumeForWorkerArtifactMutex.Lock()
 	ret, specificReturn := fake.findVolumeForWorkerArtifactReturnsOnCall[len(fake.findVolumeForWorkerArtifactArgsForCall)]
@@ -2444,6 +2603,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
+	fake.createStoredArtifactMutex.RLock()
+	defer fake.createStoredArtifactMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.findCheckContainersMutex.RLock()
@@ -2454,6 +2615,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.findContainersByMetadataMutex.RUnlock()
 	fake.findCreatedContainerByHandleMutex.RLock()
 	defer fake.findCreatedContainerByHandleMutex.RUnlock()
+	fake.findStoredWorkerArtifactMutex.RLock()
+	defer fake.findStoredWorkerArtifactMutex.RUnlock()
 	fake.findVolumeForWorkerArtifactMutex.RLock()
 	defer fake.findVolumeForWorkerArtifactMutex.RUnlock()
 	fake.findWorkerForContainerMutex.RLock()
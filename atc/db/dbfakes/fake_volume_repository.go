@@ -188,6 +188,22 @@ type FakeVolumeRepository struct {
 		result2 db.CreatedVolume
 		result3 error
 	}
+	FindResourceConfigVolumeStub        func(string, db.ResourceCache) (db.CreatedVolume, bool, error)
+	findResourceConfigVolumeMutex       sync.RWMutex
+	findResourceConfigVolumeArgsForCall []struct {
+		arg1 string
+		arg2 db.ResourceCache
+	}
+	findResourceConfigVolumeReturns struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
+	findResourceConfigVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
 	FindTaskCacheVolumeStub        func(int, string, db.UsedTaskCache) (db.CreatedVolume, bool, error)
 	findTaskCacheVolumeMutex       sync.RWMutex
 	findTaskCacheVolumeArgsForCall []struct {
@@ -1107,6 +1123,74 @@ func (fake *FakeVolumeRepository) FindResourceCertsVolumeReturnsOnCall(i int, re
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeRepository) FindResourceConfigVolume(arg1 string, arg2 db.ResourceCache) (db.CreatedVolume, bool, error) {
+	fake.findResourceConfigVolumeMutex.Lock()
+	ret, specificReturn := fake.findResourceConfigVolumeReturnsOnCall[len(fake.findResourceConfigVolumeArgsForCall)]
+	fake.findResourceConfigVolumeArgsForCall = append(fake.findResourceConfigVolumeArgsForCall, struct {
+		arg1 string
+		arg2 db.ResourceCache
+	}{arg1, arg2})
+	stub := fake.FindResourceConfigVolumeStub
+	fakeReturns := fake.findResourceConfigVolumeReturns
+	fake.recordInvocation("FindResourceConfigVolume", []interface{}{arg1, arg2})
+	fake.findResourceConfigVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeRepository) FindResourceConfigVolumeCallCount() int {
+	fake.findResourceConfigVolumeMutex.RLock()
+	defer fake.findResourceConfigVolumeMutex.RUnlock()
+	return len(fake.findResourceConfigVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) FindResourceConfigVolumeCalls(stub func(string, db.ResourceCache) (db.CreatedVolume, bool, error)) {
+	fake.findResourceConfigVolumeMutex.Lock()
+	defer fake.findResourceConfigVolumeMutex.Unlock()
+	fake.FindResourceConfigVolumeStub = stub
+}
+
+func (fake *FakeVolumeRepository) FindResourceConfigVolumeArgsForCall(i int) (string, db.ResourceCache) {
+	fake.findResourceConfigVolumeMutex.RLock()
+	defer fake.findResourceConfigVolumeMutex.RUnlock()
+	argsForCall := fake.findResourceConfigVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeRepository) FindResourceConfigVolumeReturns(result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.findResourceConfigVolumeMutex.Lock()
+	defer fake.findResourceConfigVolumeMutex.Unlock()
+	fake.FindResourceConfigVolumeStub = nil
+	fake.findResourceConfigVolumeReturns = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeRepository) FindResourceConfigVolumeReturnsOnCall(i int, result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.findResourceConfigVolumeMutex.Lock()
+	defer fake.findResourceConfigVolumeMutex.Unlock()
+	fake.FindResourceConfigVolumeStub = nil
+	if fake.findResourceConfigVolumeReturnsOnCall == nil {
+		fake.findResourceConfigVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatedVolume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findResourceConfigVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeRepository) FindTaskCacheVolume(arg1 int, arg2 string, arg3 db.UsedTaskCache) (db.CreatedVolume, bool, error) {
 	fake.findTaskCacheVolumeMutex.Lock()
 	ret, specificReturn := fake.findTaskCacheVolumeReturnsOnCall[len(fake.findTaskCacheVolumeArgsForCall)]
@@ -1719,6 +1803,8 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.findResourceCacheVolumeMutex.RUnlock()
 	fake.findResourceCertsVolumeMutex.RLock()
 	defer fake.findResourceCertsVolumeMutex.RUnlock()
+	fake.findResourceConfigVolumeMutex.RLock()
+	defer fake.findResourceConfigVolumeMutex.RUnlock()
 	fake.findTaskCacheVolumeMutex.RLock()
 	defer fake.findTaskCacheVolumeMutex.RUnlock()
 	fake.findVolumeMutex.RLock()
@@ -52,6 +52,28 @@ type FakeWorker struct {
 	baggageclaimURLReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	BuildPipelinesStub        func() ([]db.WorkerPipeline, error)
+	buildPipelinesMutex       sync.RWMutex
+	buildPipelinesArgsForCall []struct {
+	}
+	buildPipelinesReturns struct {
+		result1 []db.WorkerPipeline
+		result2 error
+	}
+	buildPipelinesReturnsOnCall map[int]struct {
+		result1 []db.WorkerPipeline
+		result2 error
+	}
+	CapacityStub        func() db.WorkerResources
+	capacityMutex       sync.RWMutex
+	capacityArgsForCall []struct {
+	}
+	capacityReturns struct {
+		result1 db.WorkerResources
+	}
+	capacityReturnsOnCall map[int]struct {
+		result1 db.WorkerResources
+	}
 	CertsPathStub        func() *string
 	certsPathMutex       sync.RWMutex
 	certsPathArgsForCall []struct {
@@ -133,6 +155,16 @@ type FakeWorker struct {
 		result2 db.CreatedContainer
 		result3 error
 	}
+	GPUsStub        func() int
+	gPUsMutex       sync.RWMutex
+	gPUsArgsForCall []struct {
+	}
+	gPUsReturns struct {
+		result1 int
+	}
+	gPUsReturnsOnCall map[int]struct {
+		result1 int
+	}
 	GardenAddrStub        func() *string
 	gardenAddrMutex       sync.RWMutex
 	gardenAddrArgsForCall []struct {
@@ -185,6 +217,16 @@ type FakeWorker struct {
 	landReturnsOnCall map[int]struct {
 		result1 error
 	}
+	LoadStub        func() db.WorkerLoad
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct {
+	}
+	loadReturns struct {
+		result1 db.WorkerLoad
+	}
+	loadReturnsOnCall map[int]struct {
+		result1 db.WorkerLoad
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -237,6 +279,29 @@ type FakeWorker struct {
 		result1 bool
 		result2 error
 	}
+	ReserveStub        func(db.WorkerResources) (bool, error)
+	reserveMutex       sync.RWMutex
+	reserveArgsForCall []struct {
+		arg1 db.WorkerResources
+	}
+	reserveReturns struct {
+		result1 bool
+		result2 error
+	}
+	reserveReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ReservedStub        func() db.WorkerResources
+	reservedMutex       sync.RWMutex
+	reservedArgsForCall []struct {
+	}
+	reservedReturns struct {
+		result1 db.WorkerResources
+	}
+	reservedReturnsOnCall map[int]struct {
+		result1 db.WorkerResources
+	}
 	ResourceCertsStub        func() (*db.UsedWorkerResourceCerts, bool, error)
 	resourceCertsMutex       sync.RWMutex
 	resourceCertsArgsForCall []struct {
@@ -321,6 +386,17 @@ type FakeWorker struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UnreserveStub        func(db.WorkerResources) error
+	unreserveMutex       sync.RWMutex
+	unreserveArgsForCall []struct {
+		arg1 db.WorkerResources
+	}
+	unreserveReturns struct {
+		result1 error
+	}
+	unreserveReturnsOnCall map[int]struct {
+		result1 error
+	}
 	VersionStub        func() *string
 	versionMutex       sync.RWMutex
 	versionArgsForCall []struct {
@@ -550,6 +626,115 @@ func (fake *FakeWorker) BaggageclaimURLReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) BuildPipelines() ([]db.WorkerPipeline, error) {
+	fake.buildPipelinesMutex.Lock()
+	ret, specificReturn := fake.buildPipelinesReturnsOnCall[len(fake.buildPipelinesArgsForCall)]
+	fake.buildPipelinesArgsForCall = append(fake.buildPipelinesArgsForCall, struct {
+	}{})
+	stub := fake.BuildPipelinesStub
+	fakeReturns := fake.buildPipelinesReturns
+	fake.recordInvocation("BuildPipelines", []interface{}{})
+	fake.buildPipelinesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) BuildPipelinesCallCount() int {
+	fake.buildPipelinesMutex.RLock()
+	defer fake.buildPipelinesMutex.RUnlock()
+	return len(fake.buildPipelinesArgsForCall)
+}
+
+func (fake *FakeWorker) BuildPipelinesCalls(stub func() ([]db.WorkerPipeline, error)) {
+	fake.buildPipelinesMutex.Lock()
+	defer fake.buildPipelinesMutex.Unlock()
+	fake.BuildPipelinesStub = stub
+}
+
+func (fake *FakeWorker) BuildPipelinesReturns(result1 []db.WorkerPipeline, result2 error) {
+	fake.buildPipelinesMutex.Lock()
+	defer fake.buildPipelinesMutex.Unlock()
+	fake.BuildPipelinesStub = nil
+	fake.buildPipelinesReturns = struct {
+		result1 []db.WorkerPipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) BuildPipelinesReturnsOnCall(i int, result1 []db.WorkerPipeline, result2 error) {
+	fake.buildPipelinesMutex.Lock()
+	defer fake.buildPipelinesMutex.Unlock()
+	fake.BuildPipelinesStub = nil
+	if fake.buildPipelinesReturnsOnCall == nil {
+		fake.buildPipelinesReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerPipeline
+			result2 error
+		})
+	}
+	fake.buildPipelinesReturnsOnCall[i] = struct {
+		result1 []db.WorkerPipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) Capacity() db.WorkerResources {
+	fake.capacityMutex.Lock()
+	ret, specificReturn := fake.capacityReturnsOnCall[len(fake.capacityArgsForCall)]
+	fake.capacityArgsForCall = append(fake.capacityArgsForCall, struct {
+	}{})
+	stub := fake.CapacityStub
+	fakeReturns := fake.capacityReturns
+	fake.recordInvocation("Capacity", []interface{}{})
+	fake.capacityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CapacityCallCount() int {
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
+	return len(fake.capacityArgsForCall)
+}
+
+func (fake *FakeWorker) CapacityCalls(stub func() db.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = stub
+}
+
+func (fake *FakeWorker) CapacityReturns(result1 db.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = nil
+	fake.capacityReturns = struct {
+		result1 db.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) CapacityReturnsOnCall(i int, result1 db.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = nil
+	if fake.capacityReturnsOnCall == nil {
+		fake.capacityReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerResources
+		})
+	}
+	fake.capacityReturnsOnCall[i] = struct {
+		result1 db.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsPath() *string {
 	fake.certsPathMutex.Lock()
 	ret, specificReturn := fake.certsPathReturnsOnCall[len(fake.certsPathArgsForCall)]
@@ -950,6 +1135,59 @@ func (fake *FakeWorker) FindContainerReturnsOnCall(i int, result1 db.CreatingCon
 	}{result1, result2, result3}
 }
 
+func (fake *FakeWorker) GPUs() int {
+	fake.gPUsMutex.Lock()
+	ret, specificReturn := fake.gPUsReturnsOnCall[len(fake.gPUsArgsForCall)]
+	fake.gPUsArgsForCall = append(fake.gPUsArgsForCall, struct {
+	}{})
+	stub := fake.GPUsStub
+	fakeReturns := fake.gPUsReturns
+	fake.recordInvocation("GPUs", []interface{}{})
+	fake.gPUsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) GPUsCallCount() int {
+	fake.gPUsMutex.RLock()
+	defer fake.gPUsMutex.RUnlock()
+	return len(fake.gPUsArgsForCall)
+}
+
+func (fake *FakeWorker) GPUsCalls(stub func() int) {
+	fake.gPUsMutex.Lock()
+	defer fake.gPUsMutex.Unlock()
+	fake.GPUsStub = stub
+}
+
+func (fake *FakeWorker) GPUsReturns(result1 int) {
+	fake.gPUsMutex.Lock()
+	defer fake.gPUsMutex.Unlock()
+	fake.GPUsStub = nil
+	fake.gPUsReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) GPUsReturnsOnCall(i int, result1 int) {
+	fake.gPUsMutex.Lock()
+	defer fake.gPUsMutex.Unlock()
+	fake.GPUsStub = nil
+	if fake.gPUsReturnsOnCall == nil {
+		fake.gPUsReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.gPUsReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) GardenAddr() *string {
 	fake.gardenAddrMutex.Lock()
 	ret, specificReturn := fake.gardenAddrReturnsOnCall[len(fake.gardenAddrArgsForCall)]
@@ -1218,6 +1456,59 @@ func (fake *FakeWorker) LandReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Load() db.WorkerLoad {
+	fake.loadMutex.Lock()
+	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct {
+	}{})
+	stub := fake.LoadStub
+	fakeReturns := fake.loadReturns
+	fake.recordInvocation("Load", []interface{}{})
+	fake.loadMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
+}
+
+func (fake *FakeWorker) LoadCalls(stub func() db.WorkerLoad) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = stub
+}
+
+func (fake *FakeWorker) LoadReturns(result1 db.WorkerLoad) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
+		result1 db.WorkerLoad
+	}{result1}
+}
+
+func (fake *FakeWorker) LoadReturnsOnCall(i int, result1 db.WorkerLoad) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = nil
+	if fake.loadReturnsOnCall == nil {
+		fake.loadReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerLoad
+		})
+	}
+	fake.loadReturnsOnCall[i] = struct {
+		result1 db.WorkerLoad
+	}{result1}
+}
+
 func (fake *FakeWorker) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1486,6 +1777,123 @@ func (fake *FakeWorker) ReloadReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) Reserve(arg1 db.WorkerResources) (bool, error) {
+	fake.reserveMutex.Lock()
+	ret, specificReturn := fake.reserveReturnsOnCall[len(fake.reserveArgsForCall)]
+	fake.reserveArgsForCall = append(fake.reserveArgsForCall, struct {
+		arg1 db.WorkerResources
+	}{arg1})
+	stub := fake.ReserveStub
+	fakeReturns := fake.reserveReturns
+	fake.recordInvocation("Reserve", []interface{}{arg1})
+	fake.reserveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ReserveCallCount() int {
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	return len(fake.reserveArgsForCall)
+}
+
+func (fake *FakeWorker) ReserveCalls(stub func(db.WorkerResources) (bool, error)) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = stub
+}
+
+func (fake *FakeWorker) ReserveArgsForCall(i int) db.WorkerResources {
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	argsForCall := fake.reserveArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) ReserveReturns(result1 bool, result2 error) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = nil
+	fake.reserveReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReserveReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = nil
+	if fake.reserveReturnsOnCall == nil {
+		fake.reserveReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.reserveReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) Reserved() db.WorkerResources {
+	fake.reservedMutex.Lock()
+	ret, specificReturn := fake.reservedReturnsOnCall[len(fake.reservedArgsForCall)]
+	fake.reservedArgsForCall = append(fake.reservedArgsForCall, struct {
+	}{})
+	stub := fake.ReservedStub
+	fakeReturns := fake.reservedReturns
+	fake.recordInvocation("Reserved", []interface{}{})
+	fake.reservedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ReservedCallCount() int {
+	fake.reservedMutex.RLock()
+	defer fake.reservedMutex.RUnlock()
+	return len(fake.reservedArgsForCall)
+}
+
+func (fake *FakeWorker) ReservedCalls(stub func() db.WorkerResources) {
+	fake.reservedMutex.Lock()
+	defer fake.reservedMutex.Unlock()
+	fake.ReservedStub = stub
+}
+
+func (fake *FakeWorker) ReservedReturns(result1 db.WorkerResources) {
+	fake.reservedMutex.Lock()
+	defer fake.reservedMutex.Unlock()
+	fake.ReservedStub = nil
+	fake.reservedReturns = struct {
+		result1 db.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) ReservedReturnsOnCall(i int, result1 db.WorkerResources) {
+	fake.reservedMutex.Lock()
+	defer fake.reservedMutex.Unlock()
+	fake.ReservedStub = nil
+	if fake.reservedReturnsOnCall == nil {
+		fake.reservedReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerResources
+		})
+	}
+	fake.reservedReturnsOnCall[i] = struct {
+		result1 db.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) ResourceCerts() (*db.UsedWorkerResourceCerts, bool, error) {
 	fake.resourceCertsMutex.Lock()
 	ret, specificReturn := fake.resourceCertsReturnsOnCall[len(fake.resourceCertsArgsForCall)]
@@ -1916,6 +2324,67 @@ func (fake *FakeWorker) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Unreserve(arg1 db.WorkerResources) error {
+	fake.unreserveMutex.Lock()
+	ret, specificReturn := fake.unreserveReturnsOnCall[len(fake.unreserveArgsForCall)]
+	fake.unreserveArgsForCall = append(fake.unreserveArgsForCall, struct {
+		arg1 db.WorkerResources
+	}{arg1})
+	stub := fake.UnreserveStub
+	fakeReturns := fake.unreserveReturns
+	fake.recordInvocation("Unreserve", []interface{}{arg1})
+	fake.unreserveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) UnreserveCallCount() int {
+	fake.unreserveMutex.RLock()
+	defer fake.unreserveMutex.RUnlock()
+	return len(fake.unreserveArgsForCall)
+}
+
+func (fake *FakeWorker) UnreserveCalls(stub func(db.WorkerResources) error) {
+	fake.unreserveMutex.Lock()
+	defer fake.unreserveMutex.Unlock()
+	fake.UnreserveStub = stub
+}
+
+func (fake *FakeWorker) UnreserveArgsForCall(i int) db.WorkerResources {
+	fake.unreserveMutex.RLock()
+	defer fake.unreserveMutex.RUnlock()
+	argsForCall := fake.unreserveArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) UnreserveReturns(result1 error) {
+	fake.unreserveMutex.Lock()
+	defer fake.unreserveMutex.Unlock()
+	fake.UnreserveStub = nil
+	fake.unreserveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) UnreserveReturnsOnCall(i int, result1 error) {
+	fake.unreserveMutex.Lock()
+	defer fake.unreserveMutex.Unlock()
+	fake.UnreserveStub = nil
+	if fake.unreserveReturnsOnCall == nil {
+		fake.unreserveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unreserveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) Version() *string {
 	fake.versionMutex.Lock()
 	ret, specificReturn := fake.versionReturnsOnCall[len(fake.versionArgsForCall)]
@@ -1980,6 +2449,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeVolumesMutex.RUnlock()
 	fake.baggageclaimURLMutex.RLock()
 	defer fake.baggageclaimURLMutex.RUnlock()
+	fake.buildPipelinesMutex.RLock()
+	defer fake.buildPipelinesMutex.RUnlock()
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
 	fake.certsPathMutex.RLock()
 	defer fake.certsPathMutex.RUnlock()
 	fake.createContainerMutex.RLock()
@@ -1994,6 +2467,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.expiresAtMutex.RUnlock()
 	fake.findContainerMutex.RLock()
 	defer fake.findContainerMutex.RUnlock()
+	fake.gPUsMutex.RLock()
+	defer fake.gPUsMutex.RUnlock()
 	fake.gardenAddrMutex.RLock()
 	defer fake.gardenAddrMutex.RUnlock()
 	fake.hTTPProxyURLMutex.RLock()
@@ -2004,6 +2479,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.increaseActiveTasksMutex.RUnlock()
 	fake.landMutex.RLock()
 	defer fake.landMutex.RUnlock()
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.noProxyMutex.RLock()
@@ -2014,6 +2491,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	fake.reservedMutex.RLock()
+	defer fake.reservedMutex.RUnlock()
 	fake.resourceCertsMutex.RLock()
 	defer fake.resourceCertsMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
@@ -2030,6 +2511,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.unreserveMutex.RLock()
+	defer fake.unreserveMutex.RUnlock()
 	fake.versionMutex.RLock()
 	defer fake.versionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -49,6 +49,26 @@ type FakeWorkerArtifact struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	StoreKeyStub        func() string
+	storeKeyMutex       sync.RWMutex
+	storeKeyArgsForCall []struct {
+	}
+	storeKeyReturns struct {
+		result1 string
+	}
+	storeKeyReturnsOnCall map[int]struct {
+		result1 string
+	}
+	TeamIDStub        func() int
+	teamIDMutex       sync.RWMutex
+	teamIDArgsForCall []struct {
+	}
+	teamIDReturns struct {
+		result1 int
+	}
+	teamIDReturnsOnCall map[int]struct {
+		result1 int
+	}
 	VolumeStub        func(int) (db.CreatedVolume, bool, error)
 	volumeMutex       sync.RWMutex
 	volumeArgsForCall []struct {
@@ -280,6 +300,112 @@ func (fake *FakeWorkerArtifact) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorkerArtifact) StoreKey() string {
+	fake.storeKeyMutex.Lock()
+	ret, specificReturn := fake.storeKeyReturnsOnCall[len(fake.storeKeyArgsForCall)]
+	fake.storeKeyArgsForCall = append(fake.storeKeyArgsForCall, struct {
+	}{})
+	stub := fake.StoreKeyStub
+	fakeReturns := fake.storeKeyReturns
+	fake.recordInvocation("StoreKey", []interface{}{})
+	fake.storeKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerArtifact) StoreKeyCallCount() int {
+	fake.storeKeyMutex.RLock()
+	defer fake.storeKeyMutex.RUnlock()
+	return len(fake.storeKeyArgsForCall)
+}
+
+func (fake *FakeWorkerArtifact) StoreKeyCalls(stub func() string) {
+	fake.storeKeyMutex.Lock()
+	defer fake.storeKeyMutex.Unlock()
+	fake.StoreKeyStub = stub
+}
+
+func (fake *FakeWorkerArtifact) StoreKeyReturns(result1 string) {
+	fake.storeKeyMutex.Lock()
+	defer fake.storeKeyMutex.Unlock()
+	fake.StoreKeyStub = nil
+	fake.storeKeyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorkerArtifact) StoreKeyReturnsOnCall(i int, result1 string) {
+	fake.storeKeyMutex.Lock()
+	defer fake.storeKeyMutex.Unlock()
+	fake.StoreKeyStub = nil
+	if fake.storeKeyReturnsOnCall == nil {
+		fake.storeKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.storeKeyReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorkerArtifact) TeamID() int {
+	fake.teamIDMutex.Lock()
+	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
+	fake.teamIDArgsForCall = append(fake.teamIDArgsForCall, struct {
+	}{})
+	stub := fake.TeamIDStub
+	fakeReturns := fake.teamIDReturns
+	fake.recordInvocation("TeamID", []interface{}{})
+	fake.teamIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerArtifact) TeamIDCallCount() int {
+	fake.teamIDMutex.RLock()
+	defer fake.teamIDMutex.RUnlock()
+	return len(fake.teamIDArgsForCall)
+}
+
+func (fake *FakeWorkerArtifact) TeamIDCalls(stub func() int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = stub
+}
+
+func (fake *FakeWorkerArtifact) TeamIDReturns(result1 int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = nil
+	fake.teamIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorkerArtifact) TeamIDReturnsOnCall(i int, result1 int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = nil
+	if fake.teamIDReturnsOnCall == nil {
+		fake.teamIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.teamIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorkerArtifact) Volume(arg1 int) (db.CreatedVolume, bool, error) {
 	fake.volumeMutex.Lock()
 	ret, specificReturn := fake.volumeReturnsOnCall[len(fake.volumeArgsForCall)]
@@ -358,6 +484,10 @@ func (fake *FakeWorkerArtifact) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.storeKeyMutex.RLock()
+	defer fake.storeKeyMutex.RUnlock()
+	fake.teamIDMutex.RLock()
+	defer fake.teamIDMutex.RUnlock()
 	fake.volumeMutex.RLock()
 	defer fake.volumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -713,7 +713,15 @@ func (builder Builder) WithJobBuildContainer(assign *db.CreatingContainer, jobNa
 			return fmt.Errorf("worker '%s' not set in the scenario", workerName)
 		}
 
-		containerMetadata := db.ContainerMetadata{}
+		containerMetadata := db.ContainerMetadata{
+			Type:         db.ContainerTypeTask,
+			PipelineID:   build.PipelineID(),
+			PipelineName: build.PipelineName(),
+			JobID:        build.JobID(),
+			JobName:      build.JobName(),
+			BuildID:      build.ID(),
+			BuildName:    build.Name(),
+		}
 
 		*assign, err = worker.CreateContainer(owner, containerMetadata)
 		if err != nil {
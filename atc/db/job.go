@@ -82,6 +82,8 @@ type Job interface {
 	ScheduleBuild(Build) (bool, error)
 	CreateBuild(createdBy string) (Build, error)
 	RerunBuild(build Build, createdBy string) (Build, error)
+	RerunBuildFromFailure(build Build, createdBy string) (Build, error)
+	AutoRerunBuild(build Build, reason string, delay time.Duration, attempts int) (Build, bool, error)
 
 	RequestSchedule() error
 	UpdateLastScheduled(time.Time) error
@@ -125,7 +127,8 @@ var jobsQuery = psql.Select(
 	"j.max_in_flight",
 	"j.disable_manual_trigger",
 	"j.paused_by",
-	"j.paused_at").
+	"j.paused_at",
+	"j.priority").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -157,6 +160,7 @@ type job struct {
 	scheduleRequestedTime time.Time
 	maxInFlight           int
 	disableManualTrigger  bool
+	priority              int
 
 	config    *atc.JobConfig
 	rawConfig *string
@@ -237,8 +241,7 @@ func (j *job) Config() (atc.JobConfig, error) {
 		return atc.JobConfig{}, err
 	}
 
-	var config atc.JobConfig
-	err = json.Unmarshal(decryptedConfig, &config)
+	config, err := unmarshalJobConfig(decryptedConfig)
 	if err != nil {
 		return atc.JobConfig{}, err
 	}
@@ -247,6 +250,39 @@ func (j *job) Config() (atc.JobConfig, error) {
 	return config, nil
 }
 
+// storedJobConfig is how a job's config is persisted. The hooks of the
+// job's pipeline are stored alongside it, so that they are encrypted along
+// with it and loaded whenever the job's plan is.
+type storedJobConfig struct {
+	atc.JobConfig
+
+	PipelineHooks *atc.PipelineHooks `json:"pipeline_hooks,omitempty"`
+}
+
+func marshalJobConfig(config atc.JobConfig) ([]byte, error) {
+	stored := storedJobConfig{JobConfig: config}
+	if !config.PipelineHooks.IsZero() {
+		stored.PipelineHooks = &config.PipelineHooks
+	}
+
+	return json.Marshal(stored)
+}
+
+func unmarshalJobConfig(payload []byte) (atc.JobConfig, error) {
+	var stored storedJobConfig
+	err := json.Unmarshal(payload, &stored)
+	if err != nil {
+		return atc.JobConfig{}, err
+	}
+
+	config := stored.JobConfig
+	if stored.PipelineHooks != nil {
+		config.PipelineHooks = *stored.PipelineHooks
+	}
+
+	return config, nil
+}
+
 func (j *job) AlgorithmInputs() (InputConfigs, error) {
 	rows, err := psql.Select("ji.name", "ji.resource_id", "array_agg(ji.passed_job_id)", "ji.version", "rp.version", "ji.trigger").
 		From("job_inputs ji").
@@ -615,6 +651,13 @@ func (j *job) ScheduleBuild(build Build) (bool, error) {
 		return false, err
 	}
 
+	if !reached {
+		reached, err = j.isInstanceGroupMaxInFlightReached(tx)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	result, err := psql.Update("jobs").
 		Set("max_in_flight_reached", reached).
 		Where(sq.Eq{
@@ -745,12 +788,12 @@ func (j *job) EnsurePendingBuildExists(ctx context.Context) error {
 	}
 
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, needs_v6_migration, span_context)
-		SELECT $1, $2, $3, $4, 'pending', false, $5
+		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, needs_v6_migration, span_context, priority)
+		SELECT $1, $2, $3, $4, 'pending', false, $5, $6
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
-	`, buildName, j.id, j.pipelineID, j.teamID, string(spanContextJSON))
+	`, buildName, j.id, j.pipelineID, j.teamID, string(spanContextJSON), j.priority)
 	if err != nil {
 		return err
 	}
@@ -854,6 +897,7 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
 		"created_by":         createdBy,
+		"priority":           j.priority,
 	})
 	if err != nil {
 		return nil, err
@@ -884,7 +928,30 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 
 func (j *job) RerunBuild(buildToRerun Build, createdBy string) (Build, error) {
 	for {
-		rerunBuild, err := j.tryRerunBuild(buildToRerun, createdBy)
+		rerunBuild, _, err := j.tryRerunBuild(buildToRerun, map[string]interface{}{
+			"created_by": createdBy,
+		}, 0)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+				continue
+			}
+
+			return nil, err
+		}
+
+		return rerunBuild, nil
+	}
+}
+
+// RerunBuildFromFailure reruns a build which failed or errored, resuming it
+// from the steps which did not succeed. The steps which did succeed are
+// replayed from the original build rather than run again.
+func (j *job) RerunBuildFromFailure(buildToRerun Build, createdBy string) (Build, error) {
+	for {
+		rerunBuild, _, err := j.tryRerunBuild(buildToRerun, map[string]interface{}{
+			"created_by":   createdBy,
+			"resumed_from": buildToRerun.ID(),
+		}, 0)
 		if err != nil {
 			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
 				continue
@@ -897,10 +964,41 @@ func (j *job) RerunBuild(buildToRerun Build, createdBy string) (Build, error) {
 	}
 }
 
-func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error) {
+// PreemptedRerunReason is the reason given for the automatic rerun of a build
+// which was preempted. These reruns do not count towards a job's auto_rerun
+// attempts, as the build was not at fault.
+const PreemptedRerunReason = "preempted"
+
+// AutoRerunBuild reruns a build which errored for the given reason, unless
+// the original build has already been automatically rerun the given number
+// of times. The rerun will not be started until the delay has passed.
+func (j *job) AutoRerunBuild(buildToRerun Build, reason string, delay time.Duration, attempts int) (Build, bool, error) {
+	vals := map[string]interface{}{
+		"auto_rerun_reason": reason,
+	}
+
+	if delay > 0 {
+		vals["start_after"] = sq.Expr("now() + make_interval(secs => ?)", delay.Seconds())
+	}
+
+	for {
+		rerunBuild, created, err := j.tryRerunBuild(buildToRerun, vals, attempts)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+				continue
+			}
+
+			return nil, false, err
+		}
+
+		return rerunBuild, created, nil
+	}
+}
+
+func (j *job) tryRerunBuild(buildToRerun Build, vals map[string]interface{}, maxAutoReruns int) (Build, bool, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	defer Rollback(tx)
@@ -910,13 +1008,31 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		buildToRerunID = buildToRerun.RerunOf()
 	}
 
+	if maxAutoReruns > 0 {
+		var autoReruns int
+		err = psql.Select("COUNT(*)").
+			From("builds").
+			Where(sq.Eq{"rerun_of": buildToRerunID}).
+			Where(sq.NotEq{"auto_rerun_reason": nil}).
+			Where(sq.NotEq{"auto_rerun_reason": PreemptedRerunReason}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&autoReruns)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if autoReruns >= maxAutoReruns {
+			return nil, false, nil
+		}
+	}
+
 	rerunBuildName, rerunNumber, err := j.getNewRerunBuildName(tx, buildToRerunID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, rerunBuild, map[string]interface{}{
+	buildVals := map[string]interface{}{
 		"name":         rerunBuildName,
 		"job_id":       j.id,
 		"pipeline_id":  j.pipelineID,
@@ -924,33 +1040,39 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		"status":       BuildStatusPending,
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
-		"created_by":   createdBy,
-	})
+		"priority":     j.priority,
+	}
+	for name, value := range vals {
+		buildVals[name] = value
+	}
+
+	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, rerunBuild, buildVals)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	latestNonRerunID, err := latestCompletedNonRerunBuild(tx, j.id)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	err = updateNextBuildForJob(tx, j.id, latestNonRerunID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	err = requestSchedule(tx, j.id)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return rerunBuild, nil
+	return rerunBuild, true, nil
 }
 
 func (j *job) ClearTaskCache(stepName string, cachePath string) (int64, error) {
@@ -1033,6 +1155,81 @@ func (j *job) isMaxInFlightReached(tx Tx, buildID int) (bool, error) {
 	return false, nil
 }
 
+// isInstanceGroupMaxInFlightReached determines whether the instance group the
+// job's pipeline belongs to is at its limit. Free slots in a group go to the
+// instances with the fewest running builds first, so that builds are admitted
+// round-robin across the instances with pending builds.
+func (j *job) isInstanceGroupMaxInFlightReached(tx Tx) (bool, error) {
+	if j.pipelineInstanceVars == nil {
+		return false, nil
+	}
+
+	maxInFlight := atc.MaxInFlightForInstanceGroup(j.teamName, j.pipelineName)
+	if maxInFlight == 0 {
+		return false, nil
+	}
+
+	rows, err := psql.Select(
+		"p.id",
+		"COUNT(b.id) FILTER (WHERE b.scheduled)",
+		"COUNT(b.id) FILTER (WHERE NOT b.scheduled AND b.status = 'pending' AND NOT j.paused)",
+	).
+		From("pipelines p").
+		LeftJoin("builds b ON b.pipeline_id = p.id AND b.job_id IS NOT NULL AND NOT b.completed").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		Where(sq.Eq{
+			"p.team_id":  j.teamID,
+			"p.name":     j.pipelineName,
+			"p.paused":   false,
+			"p.archived": false,
+		}).
+		GroupBy("p.id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return false, err
+	}
+
+	defer Close(rows)
+
+	type instanceLoad struct {
+		running int
+		pending int
+	}
+
+	var load instanceLoad
+	var otherLoads []instanceLoad
+	var totalRunning int
+	for rows.Next() {
+		var pipelineID int
+		var instance instanceLoad
+		err = rows.Scan(&pipelineID, &instance.running, &instance.pending)
+		if err != nil {
+			return false, err
+		}
+
+		totalRunning += instance.running
+
+		if pipelineID == j.pipelineID {
+			load = instance
+		} else {
+			otherLoads = append(otherLoads, instance)
+		}
+	}
+
+	if totalRunning >= maxInFlight {
+		return true, nil
+	}
+
+	for _, other := range otherLoads {
+		if other.pending > 0 && other.running < load.running {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (j *job) getSerialGroups(tx Tx) ([]string, error) {
 	rows, err := psql.Select("serial_group").
 		From("jobs_serial_groups").
@@ -1414,7 +1611,7 @@ func scanJob(j *job, row scannable) error {
 		pausedAt             sql.NullTime
 	)
 
-	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger, &pausedBy, &pausedAt)
+	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger, &pausedBy, &pausedAt, &j.priority)
 	if err != nil {
 		return err
 	}
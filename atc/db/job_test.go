@@ -89,6 +89,10 @@ var _ = Describe("Job", func() {
 					Name:                 "non-triggerable-job",
 					DisableManualTrigger: true,
 				},
+				{
+					Name:     "some-priority-job",
+					Priority: 10,
+				},
 			},
 			Resources: atc.ResourceConfigs{
 				{
@@ -697,6 +701,128 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("RerunBuildFromFailure", func() {
+		var (
+			firstBuild db.Build
+			rerunBuild db.Build
+			rerunErr   error
+		)
+
+		BeforeEach(func() {
+			var err error
+			firstBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			rerunBuild, rerunErr = job.RerunBuildFromFailure(firstBuild, defaultBuildCreatedBy)
+		})
+
+		It("creates a rerun which resumes from the build", func() {
+			Expect(rerunErr).ToNot(HaveOccurred())
+			Expect(rerunBuild.Name()).To(Equal(fmt.Sprintf("%s.1", firstBuild.Name())))
+			Expect(rerunBuild.RerunOf()).To(Equal(firstBuild.ID()))
+			Expect(rerunBuild.ResumedFrom()).To(Equal(firstBuild.ID()))
+
+			resumedFrom, found, err := rerunBuild.ResumedFromBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resumedFrom.ID()).To(Equal(firstBuild.ID()))
+		})
+
+		Context("when resuming from a rerun build", func() {
+			var rerun1 db.Build
+
+			BeforeEach(func() {
+				var err error
+				rerun1, err = job.RerunBuild(firstBuild, defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				firstBuild = rerun1
+			})
+
+			It("resumes from the rerun build", func() {
+				Expect(rerunErr).ToNot(HaveOccurred())
+				Expect(rerunBuild.RerunOf()).To(Equal(rerun1.RerunOf()))
+				Expect(rerunBuild.ResumedFrom()).To(Equal(rerun1.ID()))
+			})
+		})
+	})
+
+	Describe("AutoRerunBuild", func() {
+		var (
+			firstBuild db.Build
+			rerunBuild db.Build
+			created    bool
+			rerunErr   error
+		)
+
+		BeforeEach(func() {
+			var err error
+			firstBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			rerunBuild, created, rerunErr = job.AutoRerunBuild(firstBuild, "worker_lost", time.Minute, 2)
+		})
+
+		It("creates a delayed rerun of the build recording the reason", func() {
+			Expect(rerunErr).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(rerunBuild.RerunOf()).To(Equal(firstBuild.ID()))
+			Expect(rerunBuild.AutoRerunReason()).To(Equal("worker_lost"))
+			Expect(rerunBuild.CreatedBy()).To(BeNil())
+			Expect(rerunBuild.StartAfter()).To(BeTemporally("~", time.Now().Add(time.Minute), 10*time.Second))
+		})
+
+		Context("when the build has been automatically rerun too many times", func() {
+			BeforeEach(func() {
+				for i := 0; i < 2; i++ {
+					_, created, err := job.AutoRerunBuild(firstBuild, "worker_lost", 0, 2)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(created).To(BeTrue())
+				}
+			})
+
+			It("does not create a rerun", func() {
+				Expect(rerunErr).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+			})
+		})
+
+		Context("when the build has only been manually rerun", func() {
+			BeforeEach(func() {
+				for i := 0; i < 2; i++ {
+					_, err := job.RerunBuild(firstBuild, defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+
+			It("creates a rerun", func() {
+				Expect(rerunErr).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(rerunBuild.RerunNumber()).To(Equal(3))
+			})
+		})
+
+		Context("when the build has only been rerun after being preempted", func() {
+			BeforeEach(func() {
+				for i := 0; i < 2; i++ {
+					_, created, err := job.AutoRerunBuild(firstBuild, db.PreemptedRerunReason, 0, 0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(created).To(BeTrue())
+				}
+			})
+
+			It("creates a rerun", func() {
+				Expect(rerunErr).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(rerunBuild.RerunNumber()).To(Equal(3))
+			})
+		})
+	})
+
 	Describe("ScheduleBuild", func() {
 		var (
 			schedulingBuild            db.Build
@@ -1304,6 +1430,116 @@ var _ = Describe("Job", func() {
 				})
 			})
 		})
+
+		Context("when the job's pipeline is an instance of a group with a max in flight", func() {
+			var otherInstanceJob db.Job
+
+			BeforeEach(func() {
+				atc.InstanceGroupMaxInFlight = map[string]int{team.Name() + "/some-group": 2}
+
+				config := atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "some-job"},
+					},
+				}
+
+				var err error
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{
+					Name:         "some-group",
+					InstanceVars: atc.InstanceVars{"branch": "some-branch"},
+				}, config, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				otherInstance, _, err := team.SavePipeline(atc.PipelineRef{
+					Name:         "some-group",
+					InstanceVars: atc.InstanceVars{"branch": "other-branch"},
+				}, config, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				otherInstanceJob, found, err = otherInstance.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			AfterEach(func() {
+				atc.InstanceGroupMaxInFlight = nil
+			})
+
+			runBuild := func(job db.Job) {
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				scheduled, err := job.ScheduleBuild(build)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				_, err = build.Start(atc.Plan{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			createSchedulingBuild := func() {
+				job, found, err := pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Context("when the group is at its limit", func() {
+				BeforeEach(func() {
+					runBuild(otherInstanceJob)
+					runBuild(otherInstanceJob)
+
+					createSchedulingBuild()
+				})
+
+				It("does not schedule the build", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeFalse())
+					Expect(reloadFound).To(BeTrue())
+				})
+			})
+
+			Context("when another instance with pending builds has fewer builds running", func() {
+				BeforeEach(func() {
+					job, found, err := pipeline.Job("some-job")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					runBuild(job)
+
+					_, err = otherInstanceJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+
+					createSchedulingBuild()
+				})
+
+				It("leaves the slot to the other instance", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeFalse())
+					Expect(reloadFound).To(BeTrue())
+				})
+			})
+
+			Context("when the instance has the fewest builds running", func() {
+				BeforeEach(func() {
+					runBuild(otherInstanceJob)
+
+					_, err := otherInstanceJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+
+					createSchedulingBuild()
+				})
+
+				It("schedules the build", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeTrue())
+					Expect(reloadFound).To(BeTrue())
+				})
+			})
+		})
 	})
 
 	Describe("GetNextBuildInputs", func() {
@@ -1949,6 +2185,49 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("Priority", func() {
+		var priorityJob db.Job
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			priorityJob, found, err = pipeline.Job("some-priority-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("is given to manually triggered builds", func() {
+			build, err := priorityJob.CreateBuild("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.Priority()).To(Equal(10))
+		})
+
+		It("is given to scheduled builds", func() {
+			err := priorityJob.EnsurePendingBuildExists(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+
+			pendingBuilds, err := priorityJob.GetPendingBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].Priority()).To(Equal(10))
+		})
+
+		It("is given to reruns", func() {
+			build, err := priorityJob.CreateBuild("some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			rerun, err := priorityJob.RerunBuild(build, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rerun.Priority()).To(Equal(10))
+		})
+
+		It("defaults to zero", func() {
+			build, err := job.CreateBuild("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.Priority()).To(BeZero())
+		})
+	})
+
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			It("creates a build and updates the next build for the job", func() {
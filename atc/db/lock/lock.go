@@ -23,6 +23,7 @@ const (
 	LockTypeDatabaseMigration
 	LockTypeResourceScanning
 	LockTypeJobScheduling
+	LockTypeStep
 )
 
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
@@ -55,6 +56,12 @@ func NewJobSchedulingLockID(jobID int) LockID {
 	return LockID{LockTypeJobScheduling, jobID}
 }
 
+// NewStepLockID identifies one of the slots of a lock held by lock steps. A
+// lock which may be held by more than one step at once has a slot for each.
+func NewStepLockID(teamID int, name string, slot int) LockID {
+	return LockID{LockTypeStep, lockIDFromString(fmt.Sprintf("%d/%s/%d", teamID, name, slot))}
+}
+
 //counterfeiter:generate . LockFactory
 type LockFactory interface {
 	Acquire(logger lager.Logger, ids LockID) (Lock, bool, error)
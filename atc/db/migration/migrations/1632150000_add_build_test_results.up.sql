@@ -0,0 +1,13 @@
+CREATE TABLE build_test_results (
+    id BIGSERIAL PRIMARY KEY,
+    build_id INTEGER NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id TEXT NOT NULL,
+    suite TEXT NOT NULL DEFAULT '',
+    class_name TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    status TEXT NOT NULL,
+    duration DOUBLE PRECISION NOT NULL DEFAULT 0,
+    message TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX build_test_results_build_id_idx ON build_test_results (build_id);
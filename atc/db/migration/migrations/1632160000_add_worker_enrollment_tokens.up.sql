@@ -0,0 +1,9 @@
+CREATE TABLE worker_enrollment_tokens (
+    id SERIAL PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    team_id INTEGER REFERENCES teams (id) ON DELETE CASCADE,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    public_key TEXT UNIQUE,
+    enrolled_at TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE builds
+  DROP COLUMN auto_rerun_reason,
+  DROP COLUMN start_after;
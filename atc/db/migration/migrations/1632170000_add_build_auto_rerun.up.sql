@@ -0,0 +1,3 @@
+ALTER TABLE builds
+  ADD COLUMN auto_rerun_reason text,
+  ADD COLUMN start_after timestamp with time zone;
@@ -0,0 +1,5 @@
+DROP INDEX worker_resource_caches_team_id_worker_name_idx;
+
+ALTER TABLE worker_resource_caches
+  DROP COLUMN team_id,
+  DROP COLUMN last_used;
@@ -0,0 +1,5 @@
+ALTER TABLE worker_resource_caches
+  ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE SET NULL,
+  ADD COLUMN last_used timestamp with time zone NOT NULL DEFAULT now();
+
+CREATE INDEX worker_resource_caches_team_id_worker_name_idx ON worker_resource_caches (team_id, worker_name);
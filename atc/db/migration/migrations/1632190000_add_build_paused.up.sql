@@ -0,0 +1,2 @@
+ALTER TABLE builds
+  ADD COLUMN paused boolean NOT NULL DEFAULT false;
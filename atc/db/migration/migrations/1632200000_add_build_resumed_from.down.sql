@@ -0,0 +1,2 @@
+ALTER TABLE builds
+  DROP COLUMN resumed_from;
@@ -0,0 +1,2 @@
+ALTER TABLE builds
+  ADD COLUMN resumed_from integer REFERENCES builds (id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE worker_artifacts
+  DROP COLUMN store_key,
+  DROP COLUMN team_id;
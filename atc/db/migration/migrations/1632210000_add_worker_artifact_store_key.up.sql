@@ -0,0 +1,3 @@
+ALTER TABLE worker_artifacts
+  ADD COLUMN store_key text,
+  ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+ALTER TABLE builds
+  DROP COLUMN priority,
+  DROP COLUMN preempted_by;
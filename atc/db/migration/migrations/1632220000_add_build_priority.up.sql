@@ -0,0 +1,3 @@
+ALTER TABLE builds
+  ADD COLUMN priority integer NOT NULL DEFAULT 0,
+  ADD COLUMN preempted_by integer REFERENCES builds (id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE builds
+  ADD COLUMN admitted boolean NOT NULL DEFAULT false;
+
+UPDATE builds SET admitted = true WHERE status = 'started';
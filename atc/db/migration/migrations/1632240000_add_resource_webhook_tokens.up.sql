@@ -0,0 +1,10 @@
+CREATE TABLE resource_webhook_tokens (
+    id SERIAL PRIMARY KEY,
+    resource_id INTEGER NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX resource_webhook_tokens_resource_id_idx ON resource_webhook_tokens (resource_id);
@@ -0,0 +1,2 @@
+ALTER TABLE resource_config_scopes
+  DROP COLUMN isolated;
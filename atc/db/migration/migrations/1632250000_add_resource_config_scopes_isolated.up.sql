@@ -0,0 +1,2 @@
+ALTER TABLE resource_config_scopes
+  ADD COLUMN isolated boolean NOT NULL DEFAULT false;
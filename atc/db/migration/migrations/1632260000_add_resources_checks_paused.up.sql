@@ -0,0 +1,2 @@
+ALTER TABLE resources
+  ADD COLUMN checks_paused boolean NOT NULL DEFAULT false;
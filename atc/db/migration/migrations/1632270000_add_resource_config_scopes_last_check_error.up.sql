@@ -0,0 +1,2 @@
+ALTER TABLE resource_config_scopes
+  ADD COLUMN last_check_error text;
@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS resources_upsert_trigger ON resources;
@@ -0,0 +1,2 @@
+CREATE TRIGGER resources_upsert_trigger AFTER INSERT OR UPDATE OF config, active, checks_paused ON resources
+  FOR EACH ROW EXECUTE PROCEDURE notify_trigger(scanner, id);
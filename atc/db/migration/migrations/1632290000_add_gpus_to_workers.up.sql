@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  ADD COLUMN gpus integer NOT NULL DEFAULT 0;
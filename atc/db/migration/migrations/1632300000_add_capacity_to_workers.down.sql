@@ -0,0 +1,5 @@
+ALTER TABLE workers
+  DROP COLUMN cpu_capacity,
+  DROP COLUMN memory_capacity,
+  DROP COLUMN reserved_cpu,
+  DROP COLUMN reserved_memory;
@@ -0,0 +1,5 @@
+ALTER TABLE workers
+  ADD COLUMN cpu_capacity bigint NOT NULL DEFAULT 0,
+  ADD COLUMN memory_capacity bigint NOT NULL DEFAULT 0,
+  ADD COLUMN reserved_cpu bigint NOT NULL DEFAULT 0,
+  ADD COLUMN reserved_memory bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE workers
+  DROP COLUMN cpu_load,
+  DROP COLUMN memory_usage,
+  DROP COLUMN disk_usage;
@@ -0,0 +1,4 @@
+ALTER TABLE workers
+  ADD COLUMN cpu_load double precision NOT NULL DEFAULT 0,
+  ADD COLUMN memory_usage double precision NOT NULL DEFAULT 0,
+  ADD COLUMN disk_usage double precision NOT NULL DEFAULT 0;
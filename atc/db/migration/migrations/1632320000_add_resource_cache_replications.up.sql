@@ -0,0 +1,10 @@
+CREATE TABLE resource_cache_replications (
+    id SERIAL PRIMARY KEY,
+    resource_cache_id INTEGER NOT NULL REFERENCES resource_caches (id) ON DELETE CASCADE,
+    team_id INTEGER NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    last_fetched TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (resource_cache_id, team_id, tags)
+);
+
+CREATE INDEX resource_cache_replications_last_fetched_idx ON resource_cache_replications (last_fetched);
@@ -0,0 +1,2 @@
+ALTER TABLE worker_resource_caches
+  DROP COLUMN size;
@@ -0,0 +1,8 @@
+CREATE TABLE build_worker_waits (
+    build_id INTEGER NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id TEXT NOT NULL,
+    since TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (build_id, plan_id)
+);
+
+CREATE INDEX build_worker_waits_since_idx ON build_worker_waits (since);
@@ -0,0 +1,12 @@
+package migrations
+
+func (m *migrations) Down_1632350000() error {
+	tx := m.Tx
+
+	_, err := tx.Exec("ALTER TABLE jobs DROP COLUMN priority")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type V7JobPriority struct {
+	Priority int `json:"priority,omitempty"`
+}
+
+func (m *migrations) Up_1632350000() error {
+	tx := m.Tx
+
+	_, err := tx.Exec("ALTER TABLE jobs ADD COLUMN priority integer NOT NULL DEFAULT 0")
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query("SELECT id, config, nonce FROM jobs WHERE active = true")
+	if err != nil {
+		return err
+	}
+
+	priorities := make(map[int]int)
+	for rows.Next() {
+		var configBlob []byte
+		var nonce sql.NullString
+		var jobID int
+
+		err = rows.Scan(&jobID, &configBlob, &nonce)
+		if err != nil {
+			return err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decrypted, err := m.Strategy.Decrypt(string(configBlob), noncense)
+		if err != nil {
+			return err
+		}
+
+		var config V7JobPriority
+		err = json.Unmarshal(decrypted, &config)
+		if err != nil {
+			return err
+		}
+
+		if config.Priority != 0 {
+			priorities[jobID] = config.Priority
+		}
+	}
+
+	for jobID, priority := range priorities {
+		_, err = tx.Exec("UPDATE jobs SET priority = $1 WHERE id = $2", priority, jobID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
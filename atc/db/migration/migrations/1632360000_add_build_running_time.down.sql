@@ -0,0 +1,4 @@
+ALTER TABLE builds
+  DROP COLUMN admit_time,
+  DROP COLUMN pause_time,
+  DROP COLUMN paused_duration;
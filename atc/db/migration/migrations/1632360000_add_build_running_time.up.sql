@@ -0,0 +1,4 @@
+ALTER TABLE builds
+  ADD COLUMN admit_time timestamp with time zone,
+  ADD COLUMN pause_time timestamp with time zone,
+  ADD COLUMN paused_duration interval NOT NULL DEFAULT '0';
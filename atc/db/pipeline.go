@@ -265,6 +265,11 @@ func (p *pipeline) Config() (atc.Config, error) {
 		Display:       p.Display(),
 	}
 
+	// every job carries the pipeline's hooks
+	if len(jobConfigs) > 0 {
+		config.PipelineHooks = jobConfigs[0].PipelineHooks
+	}
+
 	return config, nil
 }
 
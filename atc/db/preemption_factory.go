@@ -0,0 +1,72 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+//counterfeiter:generate . PreemptionFactory
+type PreemptionFactory interface {
+	// WaitingBuilds returns the running job builds which have been held back
+	// since before the given time, either by their team's limit on running
+	// builds or by one of their steps waiting for a worker with room for it, and
+	// have not preempted a build yet. Pending builds are not returned, as
+	// they're held back by their job (e.g. serial or max_in_flight), which
+	// preemption can't make room in. They are ordered by priority, highest
+	// first, and then by age.
+	WaitingBuilds(since time.Time) ([]Build, error)
+
+	// PreemptibleBuilds returns the team's running job builds with a priority
+	// lower than the given one, lowest priority and most recently started
+	// first. Queued builds are not returned, as preempting them would not make
+	// room for anything.
+	PreemptibleBuilds(teamID int, priority int) ([]Build, error)
+}
+
+type preemptionFactory struct {
+	conn        Conn
+	lockFactory lock.LockFactory
+}
+
+func NewPreemptionFactory(conn Conn, lockFactory lock.LockFactory) PreemptionFactory {
+	return &preemptionFactory{
+		conn:        conn,
+		lockFactory: lockFactory,
+	}
+}
+
+func (f *preemptionFactory) WaitingBuilds(since time.Time) ([]Build, error) {
+	return getBuilds(buildsQuery.
+		Where(sq.Eq{
+			"b.status":  BuildStatusStarted,
+			"b.aborted": false,
+		}).
+		Where(sq.NotEq{"b.job_id": nil}).
+		Where(sq.Or{
+			// queued by the team's limit
+			sq.And{
+				sq.Eq{"b.admitted": false},
+				sq.LtOrEq{"b.start_time": since},
+			},
+			sq.Expr("EXISTS (SELECT 1 FROM build_worker_waits w WHERE w.build_id = b.id AND w.since <= ?)", since),
+		}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM builds pb WHERE pb.preempted_by = b.id)")).
+		OrderBy("b.priority DESC", "b.create_time ASC", "b.id ASC"),
+		f.conn, f.lockFactory)
+}
+
+func (f *preemptionFactory) PreemptibleBuilds(teamID int, priority int) ([]Build, error) {
+	return getBuilds(buildsQuery.
+		Where(sq.Eq{
+			"b.team_id":  teamID,
+			"b.status":   BuildStatusStarted,
+			"b.admitted": true,
+			"b.aborted":  false,
+		}).
+		Where(sq.NotEq{"b.job_id": nil}).
+		Where(sq.Lt{"b.priority": priority}).
+		OrderBy("b.priority ASC", "b.start_time DESC", "b.id DESC"),
+		f.conn, f.lockFactory)
+}
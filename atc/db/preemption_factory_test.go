@@ -0,0 +1,228 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreemptionFactory", func() {
+	var (
+		preemptionFactory db.PreemptionFactory
+
+		urgentBuild db.Build
+		normalBuild db.Build
+		lowBuild    db.Build
+	)
+
+	createBuild := func(pipeline db.Pipeline, jobName string) db.Build {
+		job, found, err := pipeline.Job(jobName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		build, err := job.CreateBuild("some-user")
+		Expect(err).ToNot(HaveOccurred())
+
+		return build
+	}
+
+	startBuild := func(build db.Build) {
+		started, err := build.Start(atc.Plan{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(started).To(BeTrue())
+	}
+
+	admitBuild := func(build db.Build) {
+		startBuild(build)
+
+		admitted, err := build.Admit(0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(admitted).To(BeTrue())
+	}
+
+	BeforeEach(func() {
+		preemptionFactory = db.NewPreemptionFactory(dbConn, lockFactory)
+
+		pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "priority-pipeline"}, atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "urgent-job", Priority: 10},
+				{Name: "normal-job"},
+				{Name: "low-job", Priority: -5},
+			},
+		}, db.ConfigVersion(0), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		// queued by the team's limit
+		urgentBuild = createBuild(pipeline, "urgent-job")
+		startBuild(urgentBuild)
+
+		normalBuild = createBuild(pipeline, "normal-job")
+		admitBuild(normalBuild)
+
+		lowBuild = createBuild(pipeline, "low-job")
+		admitBuild(lowBuild)
+	})
+
+	Describe("WaitingBuilds", func() {
+		It("returns builds queued by the team's limit since before the given time", func() {
+			builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(urgentBuild.ID()))
+			Expect(builds[0].Priority()).To(Equal(10))
+		})
+
+		It("ignores builds queued after the given time", func() {
+			builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(-time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		Context("when a pending build has its inputs ready", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec("UPDATE builds SET status = 'pending', start_time = NULL, inputs_ready = true WHERE id = $1", urgentBuild.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is not returned, as it's held back by its job", func() {
+				builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(time.Minute))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+		})
+
+		Context("when a step of an admitted build is waiting for a worker", func() {
+			BeforeEach(func() {
+				Expect(normalBuild.SetWaitingForWorker("some-step", true)).To(Succeed())
+			})
+
+			It("is returned after the builds of higher priority", func() {
+				builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(time.Minute))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(HaveLen(2))
+				Expect(builds[0].ID()).To(Equal(urgentBuild.ID()))
+				Expect(builds[1].ID()).To(Equal(normalBuild.ID()))
+			})
+
+			It("keeps the time it started waiting when waiting again", func() {
+				since := time.Now()
+				Expect(normalBuild.SetWaitingForWorker("some-step", true)).To(Succeed())
+
+				builds, err := preemptionFactory.WaitingBuilds(since)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(HaveLen(2))
+				Expect(builds[1].ID()).To(Equal(normalBuild.ID()))
+			})
+
+			Context("when the step is no longer waiting", func() {
+				BeforeEach(func() {
+					Expect(normalBuild.SetWaitingForWorker("some-step", false)).To(Succeed())
+				})
+
+				It("is no longer returned", func() {
+					builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(time.Minute))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(builds).To(HaveLen(1))
+					Expect(builds[0].ID()).To(Equal(urgentBuild.ID()))
+				})
+			})
+
+			Context("when another step selects a worker", func() {
+				BeforeEach(func() {
+					Expect(normalBuild.SetWaitingForWorker("other-step", true)).To(Succeed())
+					Expect(normalBuild.SetWaitingForWorker("other-step", false)).To(Succeed())
+				})
+
+				It("is still returned", func() {
+					builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(time.Minute))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(builds).To(HaveLen(2))
+					Expect(builds[1].ID()).To(Equal(normalBuild.ID()))
+				})
+			})
+
+			Context("when the build finishes", func() {
+				BeforeEach(func() {
+					Expect(normalBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+					_, err := dbConn.Exec("UPDATE builds SET status = 'started' WHERE id = $1", normalBuild.ID())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("forgets that it was waiting", func() {
+					builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(time.Minute))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(builds).To(HaveLen(1))
+					Expect(builds[0].ID()).To(Equal(urgentBuild.ID()))
+				})
+			})
+
+			Context("when the build is aborted", func() {
+				BeforeEach(func() {
+					Expect(normalBuild.MarkAsAborted()).To(Succeed())
+					_, err := dbConn.Exec("UPDATE builds SET aborted = false WHERE id = $1", normalBuild.ID())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("forgets that it was waiting", func() {
+					builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(time.Minute))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(builds).To(HaveLen(1))
+					Expect(builds[0].ID()).To(Equal(urgentBuild.ID()))
+				})
+			})
+		})
+
+		Context("when the build has already preempted a build", func() {
+			BeforeEach(func() {
+				preempted, err := lowBuild.Preempt(urgentBuild.ID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(preempted).To(BeTrue())
+			})
+
+			It("is no longer returned", func() {
+				builds, err := preemptionFactory.WaitingBuilds(time.Now().Add(time.Minute))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("PreemptibleBuilds", func() {
+		It("returns the team's running builds of lower priority, lowest first", func() {
+			builds, err := preemptionFactory.PreemptibleBuilds(defaultTeam.ID(), 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(lowBuild.ID()))
+			Expect(builds[1].ID()).To(Equal(normalBuild.ID()))
+		})
+
+		It("ignores builds of the same or higher priority", func() {
+			builds, err := preemptionFactory.PreemptibleBuilds(defaultTeam.ID(), 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(lowBuild.ID()))
+		})
+
+		It("ignores builds of other teams", func() {
+			builds, err := preemptionFactory.PreemptibleBuilds(defaultTeam.ID()+1, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		Context("when a build has been aborted", func() {
+			BeforeEach(func() {
+				Expect(lowBuild.MarkAsAborted()).To(Succeed())
+			})
+
+			It("is no longer returned", func() {
+				builds, err := preemptionFactory.PreemptibleBuilds(defaultTeam.ID(), 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(normalBuild.ID()))
+			})
+		})
+	})
+})
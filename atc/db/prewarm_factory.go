@@ -0,0 +1,117 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+//counterfeiter:generate . PrewarmFactory
+type PrewarmFactory interface {
+	// MostUsedImageResourceCaches ranks the resource caches which have been
+	// used as the image of a build created since the given time by the number
+	// of builds that used them.
+	MostUsedImageResourceCaches(since time.Time, limit int) ([]ResourceCache, error)
+
+	// MostUsedBaseResourceTypes ranks base resource types by the number of
+	// active resources which use them.
+	MostUsedBaseResourceTypes(limit int) ([]string, error)
+}
+
+type prewarmFactory struct {
+	conn        Conn
+	lockFactory lock.LockFactory
+}
+
+func NewPrewarmFactory(conn Conn, lockFactory lock.LockFactory) PrewarmFactory {
+	return &prewarmFactory{
+		conn:        conn,
+		lockFactory: lockFactory,
+	}
+}
+
+func (f *prewarmFactory) MostUsedImageResourceCaches(since time.Time, limit int) ([]ResourceCache, error) {
+	rows, err := psql.Select("bi.resource_cache_id").
+		From("build_image_resource_caches bi").
+		Join("builds b ON b.id = bi.build_id").
+		Where(sq.NotEq{"bi.resource_cache_id": nil}).
+		Where(sq.GtOrEq{"b.create_time": since}).
+		GroupBy("bi.resource_cache_id").
+		OrderBy("count(*) DESC", "bi.resource_cache_id").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	Close(rows)
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	var caches []ResourceCache
+	for _, id := range ids {
+		cache, found, err := findResourceCacheByID(tx, id, f.lockFactory, f.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		// the cache may have been garbage collected in the meantime
+		if !found {
+			continue
+		}
+
+		caches = append(caches, cache)
+	}
+
+	return caches, nil
+}
+
+func (f *prewarmFactory) MostUsedBaseResourceTypes(limit int) ([]string, error) {
+	rows, err := psql.Select("brt.name").
+		From("resources r").
+		Join("resource_configs rc ON rc.id = r.resource_config_id").
+		Join("base_resource_types brt ON brt.id = rc.base_resource_type_id").
+		Where(sq.Eq{"r.active": true}).
+		GroupBy("brt.name").
+		OrderBy("count(*) DESC", "brt.name").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var names []string
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
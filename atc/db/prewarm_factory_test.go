@@ -0,0 +1,89 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrewarmFactory", func() {
+	var prewarmFactory db.PrewarmFactory
+
+	BeforeEach(func() {
+		prewarmFactory = db.NewPrewarmFactory(dbConn, lockFactory)
+	})
+
+	Describe("MostUsedImageResourceCaches", func() {
+		var popularCache, unpopularCache db.ResourceCache
+
+		BeforeEach(func() {
+			popularCache, _ = resourceCacheForOneOffBuild()
+			unpopularCache, _ = resourceCacheForOneOffBuild()
+
+			for i := 0; i < 2; i++ {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.SaveImageResourceVersion(popularCache)).To(Succeed())
+			}
+
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.SaveImageResourceVersion(unpopularCache)).To(Succeed())
+		})
+
+		It("ranks the caches by the number of builds which used them", func() {
+			caches, err := prewarmFactory.MostUsedImageResourceCaches(time.Now().Add(-time.Hour), 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(caches).To(HaveLen(2))
+			Expect(caches[0].ID()).To(Equal(popularCache.ID()))
+			Expect(caches[1].ID()).To(Equal(unpopularCache.ID()))
+		})
+
+		It("limits the number of caches returned", func() {
+			caches, err := prewarmFactory.MostUsedImageResourceCaches(time.Now().Add(-time.Hour), 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(caches).To(HaveLen(1))
+			Expect(caches[0].ID()).To(Equal(popularCache.ID()))
+		})
+
+		It("ignores builds created before the given time", func() {
+			caches, err := prewarmFactory.MostUsedImageResourceCaches(time.Now().Add(time.Hour), 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(caches).To(BeEmpty())
+		})
+	})
+
+	Describe("MostUsedBaseResourceTypes", func() {
+		BeforeEach(func() {
+			resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(defaultResource.Type(), defaultResource.Source(), nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			scope, err := resourceConfig.FindOrCreateScope(defaultResource)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(defaultResource.SetResourceConfigScope(scope)).To(Succeed())
+		})
+
+		It("returns the base resource types of active resources", func() {
+			names, err := prewarmFactory.MostUsedBaseResourceTypes(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{"some-base-resource-type"}))
+		})
+
+		Context("when the pipeline no longer has the resource", func() {
+			BeforeEach(func() {
+				_, _, err := defaultTeam.SavePipeline(defaultPipelineRef, atc.Config{}, defaultPipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not count it", func() {
+				names, err := prewarmFactory.MostUsedBaseResourceTypes(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(names).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -179,10 +179,11 @@ func (p *prototype) SetResourceConfigScope(scope ResourceConfigScope) error {
 
 func (p *prototype) CheckPlan(planFactory atc.PlanFactory, imagePlanner atc.ImagePlanner, from atc.Version, interval atc.CheckEvery, sourceDefaults atc.Source, skipInterval bool, skipIntervalRecursively bool) atc.Plan {
 	plan := planFactory.NewPlan(atc.CheckPlan{
-		Name:   p.Name(),
-		Type:   p.Type(),
-		Source: sourceDefaults.Merge(p.Source()),
-		Tags:   p.Tags(),
+		Name:    p.Name(),
+		Type:    p.Type(),
+		Source:  sourceDefaults.Merge(p.Source()),
+		Tags:    p.Tags(),
+		Timeout: atc.CheckTimeoutForType(p.Type(), ""),
 
 		FromVersion: from,
 		Interval:    interval,
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
+)
+
+// A ResourceCacheReplication is a resource cache fetched by a build of the
+// team, onto a worker with the given tags.
+type ResourceCacheReplication struct {
+	ResourceCache ResourceCache
+	TeamID        int
+	Tags          []string
+}
+
+//counterfeiter:generate . ReplicationFactory
+type ReplicationFactory interface {
+	// RecentlyFetchedResourceCaches returns the resource caches which have
+	// been fetched since the given time, most recently fetched first.
+	RecentlyFetchedResourceCaches(since time.Time) ([]ResourceCacheReplication, error)
+
+	// PruneResourceCacheReplications forgets the resource caches which
+	// haven't been fetched since the given time.
+	PruneResourceCacheReplications(before time.Time) error
+}
+
+type replicationFactory struct {
+	conn        Conn
+	lockFactory lock.LockFactory
+}
+
+func NewReplicationFactory(conn Conn, lockFactory lock.LockFactory) ReplicationFactory {
+	return &replicationFactory{
+		conn:        conn,
+		lockFactory: lockFactory,
+	}
+}
+
+func (f *replicationFactory) RecentlyFetchedResourceCaches(since time.Time) ([]ResourceCacheReplication, error) {
+	rows, err := psql.Select("resource_cache_id", "team_id", "tags").
+		From("resource_cache_replications").
+		Where(sq.GtOrEq{"last_fetched": since}).
+		OrderBy("last_fetched DESC", "id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	type fetch struct {
+		resourceCacheID int
+		teamID          int
+		tags            []string
+	}
+
+	var fetches []fetch
+	for rows.Next() {
+		var r fetch
+		err = rows.Scan(&r.resourceCacheID, &r.teamID, pq.Array(&r.tags))
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		fetches = append(fetches, r)
+	}
+
+	Close(rows)
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	var replications []ResourceCacheReplication
+	for _, fetch := range fetches {
+		cache, found, err := findResourceCacheByID(tx, fetch.resourceCacheID, f.lockFactory, f.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		// the cache may have been garbage collected in the meantime
+		if !found {
+			continue
+		}
+
+		replications = append(replications, ResourceCacheReplication{
+			ResourceCache: cache,
+			TeamID:        fetch.teamID,
+			Tags:          fetch.tags,
+		})
+	}
+
+	return replications, nil
+}
+
+func (f *replicationFactory) PruneResourceCacheReplications(before time.Time) error {
+	_, err := psql.Delete("resource_cache_replications").
+		Where(sq.Lt{"last_fetched": before}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,62 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplicationFactory", func() {
+	var (
+		replicationFactory db.ReplicationFactory
+		cache              db.ResourceCache
+	)
+
+	BeforeEach(func() {
+		replicationFactory = db.NewReplicationFactory(dbConn, lockFactory)
+
+		cache, _ = resourceCacheForOneOffBuild()
+
+		err := resourceCacheFactory.RequestReplication(cache, defaultTeam.ID(), []string{"b", "a"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("RecentlyFetchedResourceCaches", func() {
+		It("returns the caches fetched since the given time, with sorted tags", func() {
+			replications, err := replicationFactory.RecentlyFetchedResourceCaches(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replications).To(HaveLen(1))
+			Expect(replications[0].ResourceCache.ID()).To(Equal(cache.ID()))
+			Expect(replications[0].TeamID).To(Equal(defaultTeam.ID()))
+			Expect(replications[0].Tags).To(Equal([]string{"a", "b"}))
+		})
+
+		It("records fetches with the same tags in another order once", func() {
+			err := resourceCacheFactory.RequestReplication(cache, defaultTeam.ID(), []string{"a", "b"})
+			Expect(err).ToNot(HaveOccurred())
+
+			replications, err := replicationFactory.RecentlyFetchedResourceCaches(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replications).To(HaveLen(1))
+		})
+
+		It("ignores caches fetched before the given time", func() {
+			replications, err := replicationFactory.RecentlyFetchedResourceCaches(time.Now().Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replications).To(BeEmpty())
+		})
+	})
+
+	Describe("PruneResourceCacheReplications", func() {
+		It("forgets caches fetched before the given time", func() {
+			err := replicationFactory.PruneResourceCacheReplications(time.Now().Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			replications, err := replicationFactory.RecentlyFetchedResourceCaches(time.Time{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replications).To(BeEmpty())
+		})
+	})
+})
@@ -78,6 +78,8 @@ type Resource interface {
 	CheckTimeout() string
 	LastCheckStartTime() time.Time
 	LastCheckEndTime() time.Time
+	LastCheckError() string
+	NextCheckTime() time.Time
 	Tags() atc.Tags
 	WebhookToken() string
 	Config() atc.ResourceConfig
@@ -85,6 +87,7 @@ type Resource interface {
 	APIPinnedVersion() atc.Version
 	PinComment() string
 	SetPinComment(string) error
+	ChecksPaused() bool
 	ResourceConfigID() int
 	ResourceConfigScopeID() int
 	Icon() string
@@ -105,6 +108,9 @@ type Resource interface {
 	PinVersion(rcvID int) (bool, error)
 	UnpinVersion() error
 
+	PauseChecks() error
+	UnpauseChecks() error
+
 	Causality(rcvID int, direction CausalityDirection) (atc.Causality, bool, error)
 
 	SetResourceConfigScope(ResourceConfigScope) error
@@ -116,6 +122,11 @@ type Resource interface {
 
 	ClearResourceCache(atc.Version) (int64, error)
 
+	CreateWebhookToken() (atc.WebhookToken, error)
+	WebhookTokens() ([]atc.WebhookToken, error)
+	RotateWebhookTokens(gracePeriod time.Duration) (atc.WebhookToken, error)
+	UseWebhookToken(token string) (atc.WebhookToken, bool, error)
+
 	Reload() (bool, error)
 }
 
@@ -127,10 +138,12 @@ var (
 		"r.config",
 		"rs.last_check_start_time",
 		"rs.last_check_end_time",
+		"rs.last_check_error",
 		"r.pipeline_id",
 		"r.nonce",
 		"r.resource_config_id",
 		"r.resource_config_scope_id",
+		"r.checks_paused",
 		"p.name",
 		"p.instance_vars",
 		"t.id",
@@ -163,10 +176,12 @@ type resource struct {
 	type_                 string
 	lastCheckStartTime    time.Time
 	lastCheckEndTime      time.Time
+	lastCheckError        string
 	config                atc.ResourceConfig
 	configPinnedVersion   atc.Version
 	apiPinnedVersion      atc.Version
 	pinComment            string
+	checksPaused          bool
 	resourceConfigID      int
 	resourceConfigScopeID int
 	buildSummary          *atc.BuildSummary
@@ -215,18 +230,32 @@ func (r *resource) CheckEvery() *atc.CheckEvery      { return r.config.CheckEver
 func (r *resource) CheckTimeout() string             { return r.config.CheckTimeout }
 func (r *resource) LastCheckStartTime() time.Time    { return r.lastCheckStartTime }
 func (r *resource) LastCheckEndTime() time.Time      { return r.lastCheckEndTime }
+func (r *resource) LastCheckError() string           { return r.lastCheckError }
 func (r *resource) Tags() atc.Tags                   { return r.config.Tags }
 func (r *resource) WebhookToken() string             { return r.config.WebhookToken }
 func (r *resource) Config() atc.ResourceConfig       { return r.config }
 func (r *resource) ConfigPinnedVersion() atc.Version { return r.configPinnedVersion }
 func (r *resource) APIPinnedVersion() atc.Version    { return r.apiPinnedVersion }
 func (r *resource) PinComment() string               { return r.pinComment }
+func (r *resource) ChecksPaused() bool               { return r.checksPaused }
 func (r *resource) ResourceConfigID() int            { return r.resourceConfigID }
 func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
 func (r *resource) Icon() string                     { return r.config.Icon }
 
 func (r *resource) HasWebhook() bool { return r.WebhookToken() != "" }
 
+// NextCheckTime predicts when the resource will next be checked periodically,
+// following the same interval logic as the check factory. It is zero if the
+// resource is not checked periodically or has never been checked.
+func (r *resource) NextCheckTime() time.Time {
+	interval := checkInterval(r)
+	if interval.Never || r.checksPaused || r.lastCheckEndTime.IsZero() {
+		return time.Time{}
+	}
+
+	return r.lastCheckEndTime.Add(atc.JitterCheckInterval(interval.Interval, r.resourceConfigScopeID, r.lastCheckEndTime))
+}
+
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
 		RunWith(r.conn).
@@ -302,7 +331,10 @@ func (r *resource) CheckPlan(planFactory atc.PlanFactory, imagePlanner atc.Image
 		Type:    r.type_,
 		Source:  sourceDefaults.Merge(r.config.Source),
 		Tags:    r.config.Tags,
-		Timeout: r.config.CheckTimeout,
+		Timeout: atc.CheckTimeoutForType(r.type_, r.config.CheckTimeout),
+		Limits:  r.config.CheckLimits,
+
+		TagFallback: r.config.CheckTagFallback,
 
 		FromVersion: from,
 		Interval:    interval,
@@ -464,6 +496,31 @@ func (r *resource) SetPinComment(comment string) error {
 	return err
 }
 
+// PauseChecks stops the resource from being checked periodically. It can
+// still be checked manually, e.g. with fly check-resource.
+func (r *resource) PauseChecks() error {
+	return r.setChecksPaused(true)
+}
+
+func (r *resource) UnpauseChecks() error {
+	return r.setChecksPaused(false)
+}
+
+func (r *resource) setChecksPaused(paused bool) error {
+	_, err := psql.Update("resources").
+		Set("checks_paused", paused).
+		Where(sq.Eq{"id": r.id}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	r.checksPaused = paused
+
+	return nil
+}
+
 func (r *resource) CurrentPinnedVersion() atc.Version {
 	if r.configPinnedVersion != nil {
 		return r.configPinnedVersion
@@ -843,6 +900,7 @@ func scanResource(r *resource, row scannable) error {
 		configBlob                                        sql.NullString
 		nonce, rcID, rcScopeID, pinnedVersion, pinComment sql.NullString
 		lastCheckStartTime, lastCheckEndTime              pq.NullTime
+		lastCheckError                                    sql.NullString
 		pinnedThroughConfig                               sql.NullBool
 		pipelineInstanceVars                              sql.NullString
 	)
@@ -855,13 +913,14 @@ func scanResource(r *resource, row scannable) error {
 		endTime   pq.NullTime
 	}
 
-	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &pipelineInstanceVars, &r.teamID, &r.teamName, &pinnedVersion, &pinComment, &pinnedThroughConfig, &build.id, &build.name, &build.status, &build.startTime, &build.endTime)
+	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &lastCheckStartTime, &lastCheckEndTime, &lastCheckError, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.checksPaused, &r.pipelineName, &pipelineInstanceVars, &r.teamID, &r.teamName, &pinnedVersion, &pinComment, &pinnedThroughConfig, &build.id, &build.name, &build.status, &build.startTime, &build.endTime)
 	if err != nil {
 		return err
 	}
 
 	r.lastCheckStartTime = lastCheckStartTime.Time
 	r.lastCheckEndTime = lastCheckEndTime.Time
+	r.lastCheckError = lastCheckError.String
 
 	es := r.conn.EncryptionStrategy()
 
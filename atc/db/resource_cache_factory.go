@@ -3,10 +3,12 @@ package db
 import (
 	"database/sql"
 	"encoding/json"
+	"sort"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
 )
 
 //counterfeiter:generate . ResourceCacheFactory
@@ -28,6 +30,11 @@ type ResourceCacheFactory interface {
 	ResourceCacheMetadata(ResourceCache) (ResourceConfigMetadataFields, error)
 
 	FindResourceCacheByID(id int) (ResourceCache, bool, error)
+
+	// RequestReplication records that a build of the team fetched the
+	// resource cache onto a worker with the given tags, so that it can be
+	// replicated to the other workers its builds may run on.
+	RequestReplication(resourceCache ResourceCache, teamID int, tags []string) error
 }
 
 type resourceCacheFactory struct {
@@ -208,6 +215,21 @@ func (f *resourceCacheFactory) FindResourceCacheByID(id int) (ResourceCache, boo
 	return findResourceCacheByID(tx, id, f.lockFactory, f.conn)
 }
 
+func (f *resourceCacheFactory) RequestReplication(resourceCache ResourceCache, teamID int, tags []string) error {
+	// tags are sorted so that fetches with the same tags in another order
+	// are recorded once
+	sortedTags := append([]string{}, tags...)
+	sort.Strings(sortedTags)
+
+	_, err := psql.Insert("resource_cache_replications").
+		Columns("resource_cache_id", "team_id", "tags").
+		Values(resourceCache.ID(), teamID, pq.Array(sortedTags)).
+		Suffix("ON CONFLICT (resource_cache_id, team_id, tags) DO UPDATE SET last_fetched = now()").
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
 func findResourceCacheByID(tx Tx, resourceCacheID int, lock lock.LockFactory, conn Conn) (ResourceCache, bool, error) {
 	var rcID int
 	var versionBytes string
@@ -13,6 +13,25 @@ type ResourceCacheLifecycle interface {
 	CleanUsesForFinishedBuilds(lager.Logger) error
 	CleanBuildImageResourceCaches(lager.Logger) error
 	CleanUpInvalidCaches(lager.Logger) error
+	EvictCachesOverTeamQuota(logger lager.Logger, defaultQuota int, quotas map[string]int) ([]TeamCacheEviction, error)
+	EvictCachesOverWorkerSizeLimit(logger lager.Logger, limit uint64) ([]WorkerCacheEviction, error)
+}
+
+// TeamCacheEviction records how many of a team's resource caches were evicted
+// from a worker for exceeding the team's cache quota.
+type TeamCacheEviction struct {
+	TeamName   string
+	WorkerName string
+	Count      int
+}
+
+// WorkerCacheEviction records how many resource caches, taking up how many
+// bytes, were evicted from a worker for exceeding the size limit on its
+// caches.
+type WorkerCacheEviction struct {
+	WorkerName string
+	Count      int
+	Bytes      uint64
 }
 
 type resourceCacheLifecycle struct {
@@ -130,3 +149,190 @@ func (f *resourceCacheLifecycle) CleanUpInvalidCaches(logger lager.Logger) error
 
 	return nil
 }
+
+// EvictCachesOverTeamQuota removes each team's least recently used resource
+// caches from every worker on which the team holds more caches than its quota
+// allows. A team's quota is looked up by name in quotas, falling back to
+// defaultQuota; a quota of 0 means unlimited. Caches that are still in use by
+// a build count towards the quota but are never evicted.
+//
+// Evicted caches lose their worker_resource_cache, leaving their volumes to
+// be reaped by the volume collector.
+func (f *resourceCacheLifecycle) EvictCachesOverTeamQuota(logger lager.Logger, defaultQuota int, quotas map[string]int) ([]TeamCacheEviction, error) {
+	rows, err := psql.Select(
+		"wrc.id",
+		"t.name",
+		"wrc.worker_name",
+		"EXISTS (SELECT 1 FROM resource_cache_uses rcu WHERE rcu.resource_cache_id = wrc.resource_cache_id)",
+	).
+		From("worker_resource_caches wrc").
+		Join("teams t ON t.id = wrc.team_id").
+		OrderBy("wrc.last_used DESC", "wrc.id DESC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	type teamWorker struct {
+		team   string
+		worker string
+	}
+
+	held := map[teamWorker]int{}
+	evicted := map[teamWorker]int{}
+
+	var order []teamWorker
+	var evictIDs []int
+	for rows.Next() {
+		var id int
+		var key teamWorker
+		var inUse bool
+		err = rows.Scan(&id, &key.team, &key.worker, &inUse)
+		if err != nil {
+			return nil, err
+		}
+
+		quota, found := quotas[key.team]
+		if !found {
+			quota = defaultQuota
+		}
+
+		held[key]++
+		if quota <= 0 || held[key] <= quota || inUse {
+			continue
+		}
+
+		if evicted[key] == 0 {
+			order = append(order, key)
+		}
+
+		evicted[key]++
+		evictIDs = append(evictIDs, id)
+	}
+
+	if len(evictIDs) == 0 {
+		return nil, nil
+	}
+
+	_, err = psql.Delete("worker_resource_caches").
+		Where(sq.Expr("id = ANY(?)", pq.Array(evictIDs))).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("evicted-worker-resource-caches", lager.Data{"id": evictIDs})
+
+	var evictions []TeamCacheEviction
+	for _, key := range order {
+		evictions = append(evictions, TeamCacheEviction{
+			TeamName:   key.team,
+			WorkerName: key.worker,
+			Count:      evicted[key],
+		})
+	}
+
+	return evictions, nil
+}
+
+// EvictCachesOverWorkerSizeLimit removes the least recently used resource
+// caches from every worker whose caches take up more than limit bytes, until
+// the rest fit within it. Caches that are still in use by a build count
+// towards the limit but are never evicted, and caches whose size hasn't been
+// recorded count as empty. A limit of 0 means unlimited.
+//
+// Evicted caches lose their worker_resource_cache, leaving their volumes to
+// be reaped by the volume collector.
+func (f *resourceCacheLifecycle) EvictCachesOverWorkerSizeLimit(logger lager.Logger, limit uint64) ([]WorkerCacheEviction, error) {
+	if limit == 0 {
+		return nil, nil
+	}
+
+	rows, err := psql.Select(
+		"wrc.id",
+		"wrc.worker_name",
+		"COALESCE(wrc.size, 0)",
+		"EXISTS (SELECT 1 FROM resource_cache_uses rcu WHERE rcu.resource_cache_id = wrc.resource_cache_id)",
+	).
+		From("worker_resource_caches wrc").
+		OrderBy("wrc.last_used DESC", "wrc.id DESC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	type cache struct {
+		id    int
+		size  uint64
+		inUse bool
+	}
+
+	// the caches on each worker, from the most to the least recently used
+	caches := map[string][]cache{}
+	used := map[string]uint64{}
+
+	var workers []string
+	for rows.Next() {
+		var worker string
+		var c cache
+		err = rows.Scan(&c.id, &worker, &c.size, &c.inUse)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, found := caches[worker]; !found {
+			workers = append(workers, worker)
+		}
+
+		caches[worker] = append(caches[worker], c)
+		used[worker] += c.size
+	}
+
+	var evictIDs []int
+	var evictions []WorkerCacheEviction
+	for _, worker := range workers {
+		eviction := WorkerCacheEviction{
+			WorkerName: worker,
+		}
+
+		workerCaches := caches[worker]
+		for i := len(workerCaches) - 1; i >= 0 && used[worker] > limit; i-- {
+			if workerCaches[i].inUse {
+				continue
+			}
+
+			used[worker] -= workerCaches[i].size
+			evictIDs = append(evictIDs, workerCaches[i].id)
+
+			eviction.Count++
+			eviction.Bytes += workerCaches[i].size
+		}
+
+		if eviction.Count > 0 {
+			evictions = append(evictions, eviction)
+		}
+	}
+
+	if len(evictIDs) == 0 {
+		return nil, nil
+	}
+
+	_, err = psql.Delete("worker_resource_caches").
+		Where(sq.Expr("id = ANY(?)", pq.Array(evictIDs))).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("evicted-worker-resource-caches", lager.Data{"id": evictIDs})
+
+	return evictions, nil
+}
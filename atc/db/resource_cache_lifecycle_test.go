@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbtest"
@@ -20,6 +21,255 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 		resourceCacheLifecycle = db.NewResourceCacheLifecycle(dbConn)
 	})
 
+	Describe("EvictCachesOverTeamQuota", func() {
+		var caches []db.ResourceCache
+		var evictions []db.TeamCacheEviction
+
+		cacheOnWorker := func(worker db.Worker) db.ResourceCache {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			resourceCache := createResourceCacheWithUser(db.ForBuild(build.ID()))
+
+			container, err := worker.CreateContainer(
+				db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+				db.ContainerMetadata{Type: "get"},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), worker.Name(), container, "some-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = createdVolume.InitializeResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			return resourceCache
+		}
+
+		usedAgo := func(resourceCache db.ResourceCache, ago time.Duration) {
+			_, err := psql.Update("worker_resource_caches").
+				Set("last_used", time.Now().Add(-ago)).
+				Where(sq.Eq{"resource_cache_id": resourceCache.ID()}).
+				RunWith(dbConn).
+				Exec()
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		release := func(resourceCache db.ResourceCache) {
+			_, err := psql.Delete("resource_cache_uses").
+				Where(sq.Eq{"resource_cache_id": resourceCache.ID()}).
+				RunWith(dbConn).
+				Exec()
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		cachedOnWorker := func(resourceCache db.ResourceCache) bool {
+			_, found, err := db.WorkerResourceCache{
+				WorkerName:    defaultWorker.Name(),
+				ResourceCache: resourceCache,
+			}.Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+			return found
+		}
+
+		BeforeEach(func() {
+			caches = nil
+			for i := 0; i < 3; i++ {
+				resourceCache := cacheOnWorker(defaultWorker)
+				usedAgo(resourceCache, time.Duration(i)*time.Hour)
+				release(resourceCache)
+				caches = append(caches, resourceCache)
+			}
+		})
+
+		Context("when the team is within its quota", func() {
+			It("does not evict any caches", func() {
+				var err error
+				evictions, err = resourceCacheLifecycle.EvictCachesOverTeamQuota(logger, 3, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(evictions).To(BeEmpty())
+
+				for _, resourceCache := range caches {
+					Expect(cachedOnWorker(resourceCache)).To(BeTrue())
+				}
+			})
+		})
+
+		Context("when the quota is unlimited", func() {
+			It("does not evict any caches", func() {
+				var err error
+				evictions, err = resourceCacheLifecycle.EvictCachesOverTeamQuota(logger, 0, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(evictions).To(BeEmpty())
+			})
+		})
+
+		Context("when the team exceeds its quota", func() {
+			It("evicts the least recently used caches", func() {
+				var err error
+				evictions, err = resourceCacheLifecycle.EvictCachesOverTeamQuota(logger, 0, map[string]int{
+					defaultTeam.Name(): 1,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(evictions).To(Equal([]db.TeamCacheEviction{
+					{
+						TeamName:   defaultTeam.Name(),
+						WorkerName: defaultWorker.Name(),
+						Count:      2,
+					},
+				}))
+
+				Expect(cachedOnWorker(caches[0])).To(BeTrue())
+				Expect(cachedOnWorker(caches[1])).To(BeFalse())
+				Expect(cachedOnWorker(caches[2])).To(BeFalse())
+			})
+
+			Context("when a cache is still in use", func() {
+				BeforeEach(func() {
+					inUse := cacheOnWorker(defaultWorker)
+					usedAgo(inUse, 24*time.Hour)
+					caches = append(caches, inUse)
+				})
+
+				It("counts it towards the quota without evicting it", func() {
+					var err error
+					evictions, err = resourceCacheLifecycle.EvictCachesOverTeamQuota(logger, 2, nil)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(evictions).To(HaveLen(1))
+					Expect(evictions[0].Count).To(Equal(1))
+
+					Expect(cachedOnWorker(caches[0])).To(BeTrue())
+					Expect(cachedOnWorker(caches[1])).To(BeTrue())
+					Expect(cachedOnWorker(caches[2])).To(BeFalse())
+					Expect(cachedOnWorker(caches[3])).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("EvictCachesOverWorkerSizeLimit", func() {
+		var caches []db.ResourceCache
+
+		cacheOfSize := func(size uint64, ago time.Duration) db.ResourceCache {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			resourceCache := createResourceCacheWithUser(db.ForBuild(build.ID()))
+
+			container, err := defaultWorker.CreateContainer(
+				db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+				db.ContainerMetadata{Type: "get"},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), container, "some-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = createdVolume.InitializeResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = createdVolume.SetResourceCacheSize(size)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = psql.Update("worker_resource_caches").
+				Set("last_used", time.Now().Add(-ago)).
+				Where(sq.Eq{"resource_cache_id": resourceCache.ID()}).
+				RunWith(dbConn).
+				Exec()
+			Expect(err).ToNot(HaveOccurred())
+
+			return resourceCache
+		}
+
+		release := func(resourceCache db.ResourceCache) {
+			_, err := psql.Delete("resource_cache_uses").
+				Where(sq.Eq{"resource_cache_id": resourceCache.ID()}).
+				RunWith(dbConn).
+				Exec()
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		cachedOnWorker := func(resourceCache db.ResourceCache) bool {
+			_, found, err := db.WorkerResourceCache{
+				WorkerName:    defaultWorker.Name(),
+				ResourceCache: resourceCache,
+			}.Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+			return found
+		}
+
+		BeforeEach(func() {
+			caches = nil
+			for i := 0; i < 3; i++ {
+				resourceCache := cacheOfSize(100, time.Duration(i)*time.Hour)
+				release(resourceCache)
+				caches = append(caches, resourceCache)
+			}
+		})
+
+		Context("when the worker's caches fit within the limit", func() {
+			It("does not evict any caches", func() {
+				evictions, err := resourceCacheLifecycle.EvictCachesOverWorkerSizeLimit(logger, 300)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(evictions).To(BeEmpty())
+
+				for _, resourceCache := range caches {
+					Expect(cachedOnWorker(resourceCache)).To(BeTrue())
+				}
+			})
+		})
+
+		Context("when the limit is unlimited", func() {
+			It("does not evict any caches", func() {
+				evictions, err := resourceCacheLifecycle.EvictCachesOverWorkerSizeLimit(logger, 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(evictions).To(BeEmpty())
+			})
+		})
+
+		Context("when the worker's caches exceed the limit", func() {
+			It("evicts the least recently used caches until the rest fit", func() {
+				evictions, err := resourceCacheLifecycle.EvictCachesOverWorkerSizeLimit(logger, 150)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(evictions).To(Equal([]db.WorkerCacheEviction{
+					{
+						WorkerName: defaultWorker.Name(),
+						Count:      2,
+						Bytes:      200,
+					},
+				}))
+
+				Expect(cachedOnWorker(caches[0])).To(BeTrue())
+				Expect(cachedOnWorker(caches[1])).To(BeFalse())
+				Expect(cachedOnWorker(caches[2])).To(BeFalse())
+			})
+
+			Context("when a cache is still in use", func() {
+				BeforeEach(func() {
+					caches = append(caches, cacheOfSize(100, 24*time.Hour))
+				})
+
+				It("counts it towards the limit without evicting it", func() {
+					evictions, err := resourceCacheLifecycle.EvictCachesOverWorkerSizeLimit(logger, 300)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(evictions).To(HaveLen(1))
+					Expect(evictions[0].Count).To(Equal(1))
+
+					Expect(cachedOnWorker(caches[0])).To(BeTrue())
+					Expect(cachedOnWorker(caches[1])).To(BeTrue())
+					Expect(cachedOnWorker(caches[2])).To(BeFalse())
+					Expect(cachedOnWorker(caches[3])).To(BeTrue())
+				})
+			})
+		})
+	})
+
 	Describe("CleanUpInvalidCaches", func() {
 		Context("the resource cache is used by a build", func() {
 
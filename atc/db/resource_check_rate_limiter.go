@@ -88,6 +88,27 @@ func (limiter *ResourceCheckRateLimiter) Wait(ctx context.Context) error {
 	}
 }
 
+// WaitPriority takes the next check slot without waiting for it, so that a
+// manually triggered check runs ahead of the periodic checks queued up in
+// Wait. Periodic checks reserving a slot afterwards are pushed back by one.
+func (limiter *ResourceCheckRateLimiter) WaitPriority(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	err := limiter.refreshCheckLimiterIfNeeded()
+	if err != nil {
+		return fmt.Errorf("refresh: %w", err)
+	}
+
+	reservation := limiter.checkLimiter.ReserveN(limiter.clock.Now(), 1)
+
+	delay := reservation.DelayFrom(limiter.clock.Now())
+	if delay > 0 {
+		logger.Debug("resource-rate-limit-jumped", lager.Data{"skipped": delay.String()})
+	}
+
+	return nil
+}
+
 func (limiter *ResourceCheckRateLimiter) Limit() rate.Limit {
 	return limiter.checkLimiter.Limit()
 }
@@ -187,6 +187,32 @@ var _ = Describe("ResourceCheckRateLimiter", func() {
 		})
 	})
 
+	Context("when a manually triggered check jumps the queue", func() {
+		BeforeEach(func() {
+			checksPerSecond = 1
+		})
+
+		It("returns immediately and pushes back periodic checks", func() {
+			Expect(<-wait(limiter)).To(Succeed())
+
+			By("not waiting for the next slot")
+			Expect(limiter.WaitPriority(ctx)).To(Succeed())
+
+			done := wait(limiter)
+			fakeClock.WaitForWatcherAndIncrement(time.Second)
+
+			select {
+			case <-done:
+				Fail("should not have returned yet")
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			By("unblocking after the slot taken by the manual check")
+			fakeClock.Increment(time.Second)
+			Expect(<-done).To(Succeed())
+		})
+	})
+
 	Context("when a negative static checks per second value is provided", func() {
 		BeforeEach(func() {
 			checksPerSecond = -1
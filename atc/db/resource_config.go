@@ -110,16 +110,21 @@ func findOrCreateResourceConfigScope(
 	var uniqueResource Resource
 	var resourceID *int
 
+	// isolated scopes don't share check results with the other scopes of the
+	// resource config, see SiblingScopes
+	var isolated bool
+
 	if resource != nil {
-		var unique bool
-		if !atc.EnableGlobalResources {
-			unique = true
-		} else {
-			if brt := resourceConfig.CreatedByBaseResourceType(); brt != nil {
-				unique = brt.UniqueVersionHistory
-			}
+		if brt := resourceConfig.CreatedByBaseResourceType(); brt != nil {
+			isolated = brt.UniqueVersionHistory
 		}
 
+		if atc.RequiresUniqueVersionHistory(resource.TeamName(), resource.Config()) {
+			isolated = true
+		}
+
+		unique := isolated || !atc.EnableGlobalResources
+
 		if unique {
 			id := resource.ID()
 
@@ -152,6 +157,18 @@ func findOrCreateResourceConfigScope(
 		if err != nil {
 			return nil, err
 		}
+
+		if uniqueResource != nil {
+			_, err = psql.Update("resource_config_scopes").
+				Set("isolated", isolated).
+				Where(sq.Eq{"id": scopeID}).
+				Where(sq.NotEq{"isolated": isolated}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, err
+			}
+		}
 	} else if uniqueResource != nil {
 		// This `SELECT ... FOR UPDATE` on the resource is just to avoid a
 		// deadlock, which occurs when concurrently setting a pipeline and
@@ -201,14 +218,15 @@ func findOrCreateResourceConfigScope(
 		}
 
 		err = psql.Insert("resource_config_scopes").
-			Columns("resource_id", "resource_config_id").
-			Values(resource.ID(), resourceConfig.ID()).
+			Columns("resource_id", "resource_config_id", "isolated").
+			Values(resource.ID(), resourceConfig.ID(), isolated).
 			Suffix(`
 				ON CONFLICT (resource_id, resource_config_id) WHERE resource_id IS NOT NULL DO UPDATE SET
 					resource_id = ?,
-					resource_config_id = ?
+					resource_config_id = ?,
+					isolated = ?
 				RETURNING id
-			`, resource.ID(), resourceConfig.ID()).
+			`, resource.ID(), resourceConfig.ID(), isolated).
 			RunWith(tx).
 			QueryRow().
 			Scan(&scopeID)
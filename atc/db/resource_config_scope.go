@@ -42,6 +42,9 @@ type ResourceConfigScope interface {
 	LastCheck() (LastCheck, error)
 	UpdateLastCheckStartTime() (bool, error)
 	UpdateLastCheckEndTime(bool) (bool, error)
+	UpdateLastCheckError(string) (bool, error)
+
+	SiblingScopes() ([]ResourceConfigScope, error)
 }
 
 type resourceConfigScope struct {
@@ -196,6 +199,58 @@ func (r *resourceConfigScope) AcquireResourceCheckingLock(
 	)
 }
 
+// SiblingScopes returns the other scopes of the same resource config which
+// are in use by an active resource. Checking any one of these scopes checks
+// them all, so the result of a check is fanned out to its siblings rather
+// than each of them running an identical check. This is safe to do while
+// holding the resource checking lock, which is shared by every scope of the
+// resource config.
+//
+// Scopes only have siblings when global resources are enabled, as otherwise
+// the check results of resources in different teams must stay separate.
+// Isolated scopes, i.e. those of resources which require a unique version
+// history, have no siblings and are never the sibling of another scope.
+// Neither are the scopes of resources whose checks are paused.
+func (r *resourceConfigScope) SiblingScopes() ([]ResourceConfigScope, error) {
+	if !atc.EnableGlobalResources {
+		return nil, nil
+	}
+
+	rows, err := psql.Select("rcs.id").
+		From("resource_config_scopes rcs").
+		Where(sq.Eq{"rcs.resource_config_id": r.resourceConfig.ID()}).
+		Where(sq.NotEq{"rcs.id": r.id}).
+		Where(sq.Eq{"rcs.isolated": false}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM resource_config_scopes s WHERE s.id = ? AND s.isolated)", r.id)).
+		Where(sq.Expr("EXISTS (SELECT 1 FROM resources r WHERE r.resource_config_scope_id = rcs.id AND r.active AND NOT r.checks_paused)")).
+		OrderBy("rcs.id").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var scopes []ResourceConfigScope
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		scopes = append(scopes, &resourceConfigScope{
+			id:             id,
+			resourceConfig: r.resourceConfig,
+			conn:           r.conn,
+			lockFactory:    r.lockFactory,
+		})
+	}
+
+	return scopes, nil
+}
+
 func (r *resourceConfigScope) UpdateLastCheckStartTime() (bool, error) {
 	tx, err := r.conn.Begin()
 	if err != nil {
@@ -235,7 +290,7 @@ func (r *resourceConfigScope) UpdateLastCheckEndTime(succeeded bool) (bool, erro
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resource_config_scopes
-		SET last_check_end_time = now(), last_check_succeeded = $1
+		SET last_check_end_time = now(), last_check_succeeded = $1, last_check_error = NULL
 		WHERE id = $2
 	`, succeeded, r.id)
 	if err != nil {
@@ -254,6 +309,37 @@ func (r *resourceConfigScope) UpdateLastCheckEndTime(succeeded bool) (bool, erro
 	return true, nil
 }
 
+// UpdateLastCheckError records why the last check failed. It is cleared when
+// the check's end time is next updated.
+func (r *resourceConfigScope) UpdateLastCheckError(message string) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE resource_config_scopes
+		SET last_check_error = $1
+		WHERE id = $2
+	`, message, r.id)
+	if err != nil {
+		return false, err
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func saveResourceVersion(tx Tx, rcsID int, version atc.Version, metadata ResourceConfigMetadataFields, spanContext SpanContext) (bool, error) {
 	versionJSON, err := json.Marshal(version)
 	if err != nil {
@@ -318,6 +318,114 @@ var _ = Describe("Resource Config Scope", func() {
 
 			Expect(scenario.Resource("some-resource").LastCheckEndTime()).To(BeTemporally(">", lastTime))
 		})
+
+		It("clears the last check error", func() {
+			_, err := resourceScope.UpdateLastCheckError("some-error")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resourceScope.UpdateLastCheckEndTime(true)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(scenario.Resource("some-resource").LastCheckError()).To(BeEmpty())
+		})
+	})
+
+	Describe("UpdateLastCheckError", func() {
+		It("updates last check error", func() {
+			updated, err := resourceScope.UpdateLastCheckError("some-error")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+
+			Expect(scenario.Resource("some-resource").LastCheckError()).To(Equal("some-error"))
+		})
+	})
+
+	Describe("SiblingScopes", func() {
+		It("returns nothing when no other resource has the same config", func() {
+			siblings, err := resourceScope.SiblingScopes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(siblings).To(BeEmpty())
+		})
+
+		Context("when a resource in another pipeline has the same config", func() {
+			BeforeEach(func() {
+				dbtest.Setup(
+					builder.WithPipeline(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name: "some-resource",
+								Type: "some-base-resource-type",
+								Source: atc.Source{
+									"some": "source",
+								},
+							},
+						},
+					}),
+					builder.WithResourceVersions("some-resource"),
+				)
+			})
+
+			It("returns nothing while global resources are disabled", func() {
+				siblings, err := resourceScope.SiblingScopes()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(siblings).To(BeEmpty())
+			})
+		})
+
+		Context("when global resources are enabled and a resource in another pipeline has its own scope for the same config", func() {
+			var otherScenario *dbtest.Scenario
+
+			BeforeEach(func() {
+				// the other resource's scope was created before global
+				// resources were enabled
+				otherScenario = dbtest.Setup(
+					builder.WithPipeline(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name: "some-resource",
+								Type: "some-base-resource-type",
+								Source: atc.Source{
+									"some": "source",
+								},
+							},
+						},
+					}),
+					builder.WithResourceVersions("some-resource"),
+				)
+
+				atc.EnableGlobalResources = true
+			})
+
+			AfterEach(func() {
+				atc.EnableGlobalResources = false
+			})
+
+			It("returns the other resource's scope", func() {
+				otherResource := otherScenario.Resource("some-resource")
+				Expect(otherResource.ResourceConfigID()).To(Equal(scenario.Resource("some-resource").ResourceConfigID()))
+				Expect(otherResource.ResourceConfigScopeID()).ToNot(Equal(resourceScope.ID()))
+
+				siblings, err := resourceScope.SiblingScopes()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(siblings).To(HaveLen(1))
+				Expect(siblings[0].ID()).To(Equal(otherResource.ResourceConfigScopeID()))
+			})
+
+			It("shares the resource checking lock with the other scope", func() {
+				siblings, err := resourceScope.SiblingScopes()
+				Expect(err).ToNot(HaveOccurred())
+
+				lock, acquired, err := resourceScope.AcquireResourceCheckingLock(logger)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				_, acquired, err = siblings[0].AcquireResourceCheckingLock(logger)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+
+				Expect(lock.Release()).To(Succeed())
+			})
+		})
 	})
 
 	Describe("AcquireResourceCheckingLock", func() {
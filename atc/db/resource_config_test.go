@@ -70,6 +70,31 @@ var _ = Describe("ResourceConfig", func() {
 						Expect(err).ToNot(HaveOccurred())
 						Expect(foundScope.ID()).To(Equal(createdScope.ID()))
 					})
+
+					Context("when the resource's team requires a unique version history", func() {
+						BeforeEach(func() {
+							atc.TeamsWithUniqueVersionHistory = map[string]bool{defaultResource.TeamName(): true}
+						})
+
+						AfterEach(func() {
+							atc.TeamsWithUniqueVersionHistory = map[string]bool{}
+						})
+
+						It("finds or creates an isolated unique scope", func() {
+							createdScope, err := resourceConfig.FindOrCreateScope(defaultResource)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(createdScope.Resource()).ToNot(BeNil())
+							Expect(createdScope.Resource().ID()).To(Equal(defaultResource.ID()))
+
+							siblings, err := createdScope.SiblingScopes()
+							Expect(err).ToNot(HaveOccurred())
+							Expect(siblings).To(BeEmpty())
+
+							foundScope, err := resourceConfig.FindOrCreateScope(defaultResource)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(foundScope.ID()).To(Equal(createdScope.ID()))
+						})
+					})
 				})
 			})
 		})
@@ -959,6 +959,103 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("PauseChecks/UnpauseChecks", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "repository"},
+						},
+					},
+				}),
+			)
+		})
+
+		It("does not pause checks by default", func() {
+			Expect(scenario.Resource("some-resource").ChecksPaused()).To(BeFalse())
+		})
+
+		It("pauses and unpauses the resource's checks", func() {
+			resource := scenario.Resource("some-resource")
+
+			Expect(resource.PauseChecks()).To(Succeed())
+			Expect(resource.ChecksPaused()).To(BeTrue())
+			Expect(scenario.Resource("some-resource").ChecksPaused()).To(BeTrue())
+
+			Expect(resource.UnpauseChecks()).To(Succeed())
+			Expect(resource.ChecksPaused()).To(BeFalse())
+			Expect(scenario.Resource("some-resource").ChecksPaused()).To(BeFalse())
+		})
+	})
+
+	Describe("NextCheckTime", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			atc.DefaultCheckInterval = time.Minute
+		})
+
+		AfterEach(func() {
+			atc.DefaultCheckInterval = 0
+		})
+
+		setup := func(checkEvery *atc.CheckEvery) {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:       "some-resource",
+							Type:       "some-base-resource-type",
+							Source:     atc.Source{"some": "repository"},
+							CheckEvery: checkEvery,
+						},
+					},
+				}),
+			)
+		}
+
+		It("is zero if the resource has never been checked", func() {
+			setup(nil)
+			Expect(scenario.Resource("some-resource").NextCheckTime()).To(BeZero())
+		})
+
+		It("is one interval after the end of the last check", func() {
+			setup(&atc.CheckEvery{Interval: time.Hour})
+			scenario.Run(builder.WithResourceVersions("some-resource"))
+
+			resource := scenario.Resource("some-resource")
+			Expect(resource.NextCheckTime()).To(Equal(resource.LastCheckEndTime().Add(time.Hour)))
+		})
+
+		It("defaults to the default check interval", func() {
+			setup(nil)
+			scenario.Run(builder.WithResourceVersions("some-resource"))
+
+			resource := scenario.Resource("some-resource")
+			Expect(resource.NextCheckTime()).To(Equal(resource.LastCheckEndTime().Add(time.Minute)))
+		})
+
+		It("is zero if the resource is never checked periodically", func() {
+			setup(&atc.CheckEvery{Never: true})
+			scenario.Run(builder.WithResourceVersions("some-resource"))
+
+			Expect(scenario.Resource("some-resource").NextCheckTime()).To(BeZero())
+		})
+
+		It("is zero if the resource's checks are paused", func() {
+			setup(nil)
+			scenario.Run(builder.WithResourceVersions("some-resource"))
+			Expect(scenario.Resource("some-resource").PauseChecks()).To(Succeed())
+
+			Expect(scenario.Resource("some-resource").NextCheckTime()).To(BeZero())
+		})
+	})
+
 	Describe("PinVersion/UnpinVersion", func() {
 		var (
 			scenario *dbtest.Scenario
@@ -1224,6 +1321,8 @@ var _ = Describe("Resource", func() {
 		}
 
 		Context("when there is a resource using a base type", func() {
+			checkMemoryLimit := atc.MemoryLimit(1024)
+
 			BeforeEach(func() {
 				setupCheckPlan("pipeline-with-resource-base-type",
 					atc.Config{
@@ -1232,6 +1331,11 @@ var _ = Describe("Resource", func() {
 							Type:         "some-base-resource-type",
 							Tags:         []string{"tag"},
 							CheckTimeout: "1h",
+							CheckLimits:  &atc.ContainerLimits{Memory: &checkMemoryLimit},
+							CheckTagFallback: &atc.TagFallback{
+								Policy: atc.TagFallbackUntagged,
+								After:  "5m",
+							},
 							Source: atc.Source{
 								"some": "source",
 							},
@@ -1255,6 +1359,11 @@ var _ = Describe("Resource", func() {
 						},
 						Tags:    resource.Tags(),
 						Timeout: resource.CheckTimeout(),
+						Limits:  &atc.ContainerLimits{Memory: &checkMemoryLimit},
+						TagFallback: &atc.TagFallback{
+							Policy: atc.TagFallbackUntagged,
+							After:  "5m",
+						},
 						TypeImage: atc.TypeImage{
 							BaseType: resource.Type(),
 						},
@@ -1821,4 +1930,109 @@ var _ = Describe("Resource", func() {
 			})
 		})
 	})
+
+	Describe("Webhook tokens", func() {
+		var (
+			scenario *dbtest.Scenario
+			resource db.Resource
+		)
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "repository"},
+						},
+						{
+							Name:   "some-other-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "other-repository"},
+						},
+					},
+				}),
+			)
+
+			resource = scenario.Resource("some-resource")
+		})
+
+		It("can use a created token", func() {
+			created, err := resource.CreateWebhookToken()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created.Token).ToNot(BeEmpty())
+			Expect(created.ExpiresAt).To(BeZero())
+
+			used, found, err := resource.UseWebhookToken(created.Token)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(used.ID).To(Equal(created.ID))
+			Expect(used.Token).To(BeEmpty())
+			Expect(used.LastUsedAt).ToNot(BeZero())
+		})
+
+		It("does not accept a token for another resource", func() {
+			created, err := scenario.Resource("some-other-resource").CreateWebhookToken()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := resource.UseWebhookToken(created.Token)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("lists the tokens without their values", func() {
+			created, err := resource.CreateWebhookToken()
+			Expect(err).ToNot(HaveOccurred())
+
+			tokens, err := resource.WebhookTokens()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].ID).To(Equal(created.ID))
+			Expect(tokens[0].Token).To(BeEmpty())
+		})
+
+		Context("when the tokens are rotated", func() {
+			var oldToken atc.WebhookToken
+
+			BeforeEach(func() {
+				var err error
+				oldToken, err = resource.CreateWebhookToken()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("accepts both tokens during the grace period", func() {
+				newToken, err := resource.RotateWebhookTokens(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, found, err := resource.UseWebhookToken(oldToken.Token)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, found, err = resource.UseWebhookToken(newToken.Token)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				tokens, err := resource.WebhookTokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tokens).To(HaveLen(2))
+				Expect(tokens[0].ExpiresAt).ToNot(BeZero())
+				Expect(tokens[1].ExpiresAt).To(BeZero())
+			})
+
+			It("no longer accepts the old token after the grace period", func() {
+				newToken, err := resource.RotateWebhookTokens(0)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, found, err := resource.UseWebhookToken(oldToken.Token)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				tokens, err := resource.WebhookTokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tokens).To(HaveLen(1))
+				Expect(tokens[0].ID).To(Equal(newToken.ID))
+			})
+		})
+	})
 })
@@ -181,6 +181,7 @@ type resourceType struct {
 	params                atc.Params
 	tags                  atc.Tags
 	checkEvery            *atc.CheckEvery
+	checkTimeout          string
 	lastCheckStartTime    time.Time
 	lastCheckEndTime      time.Time
 }
@@ -192,7 +193,7 @@ func (t *resourceType) Name() string                  { return t.name }
 func (t *resourceType) Type() string                  { return t.type_ }
 func (t *resourceType) Privileged() bool              { return t.privileged }
 func (t *resourceType) CheckEvery() *atc.CheckEvery   { return t.checkEvery }
-func (t *resourceType) CheckTimeout() string          { return "" }
+func (t *resourceType) CheckTimeout() string          { return t.checkTimeout }
 func (r *resourceType) LastCheckStartTime() time.Time { return r.lastCheckStartTime }
 func (r *resourceType) LastCheckEndTime() time.Time   { return r.lastCheckEndTime }
 func (t *resourceType) Source() atc.Source            { return t.source }
@@ -245,10 +246,11 @@ func (r *resourceType) SetResourceConfigScope(scope ResourceConfigScope) error {
 
 func (r *resourceType) CheckPlan(planFactory atc.PlanFactory, imagePlanner atc.ImagePlanner, from atc.Version, interval atc.CheckEvery, sourceDefaults atc.Source, skipInterval bool, skipIntervalRecursively bool) atc.Plan {
 	plan := planFactory.NewPlan(atc.CheckPlan{
-		Name:   r.name,
-		Type:   r.type_,
-		Source: sourceDefaults.Merge(r.source),
-		Tags:   r.tags,
+		Name:    r.name,
+		Type:    r.type_,
+		Source:  sourceDefaults.Merge(r.source),
+		Tags:    r.tags,
+		Timeout: atc.CheckTimeoutForType(r.type_, r.checkTimeout),
 
 		FromVersion: from,
 		Interval:    interval,
@@ -367,6 +369,7 @@ func scanResourceType(t *resourceType, row scannable) error {
 	t.privileged = config.Privileged
 	t.tags = config.Tags
 	t.checkEvery = config.CheckEvery
+	t.checkTimeout = config.CheckTimeout
 
 	if resourceConfigID.Valid {
 		t.resourceConfigID = int(resourceConfigID.Int64)
@@ -590,6 +590,30 @@ var _ = Describe("ResourceType", func() {
 			})
 		})
 
+		Context("when the resource type has a check timeout", func() {
+			BeforeEach(func() {
+				setupCheckPlan(
+					"pipeline-with-resource-type-check-timeout",
+					atc.Config{
+						ResourceTypes: atc.ResourceTypes{{
+							Name:         "some-resource-type",
+							Type:         "some-base-resource-type",
+							Source:       atc.Source{"some": "source"},
+							CheckTimeout: "5m",
+						}},
+					},
+					"some-resource-type",
+					atc.Source{},
+					atc.ResourceTypes{},
+				)
+			})
+
+			It("sets the timeout of the check plan", func() {
+				Expect(resourceType.CheckTimeout()).To(Equal("5m"))
+				Expect(createdCheckPlan.Check.Timeout).To(Equal("5m"))
+			})
+		})
+
 		Context("when there is a resource type using a custom type", func() {
 			BeforeEach(func() {
 				setupCheckPlan(
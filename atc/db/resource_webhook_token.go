@@ -0,0 +1,168 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+var webhookTokensQuery = psql.Select(
+	"id",
+	"created_at",
+	"expires_at",
+	"last_used_at",
+).
+	From("resource_webhook_tokens")
+
+func (r *resource) CreateWebhookToken() (atc.WebhookToken, error) {
+	return r.createWebhookToken(r.conn)
+}
+
+func (r *resource) WebhookTokens() ([]atc.WebhookToken, error) {
+	rows, err := webhookTokensQuery.
+		Where(sq.Eq{"resource_id": r.id}).
+		Where(sq.Or{
+			sq.Eq{"expires_at": nil},
+			sq.Expr("expires_at > now()"),
+		}).
+		OrderBy("id").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	tokens := []atc.WebhookToken{}
+	for rows.Next() {
+		token, err := scanWebhookToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// RotateWebhookTokens creates a new webhook token and expires all of the
+// resource's other tokens once the grace period has passed, so that both the
+// old and new tokens are accepted while webhooks are being updated.
+func (r *resource) RotateWebhookTokens(gracePeriod time.Duration) (atc.WebhookToken, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return atc.WebhookToken{}, err
+	}
+
+	defer Rollback(tx)
+
+	expiresAt := fmt.Sprintf("now() + '%d seconds'::interval", int(gracePeriod.Seconds()))
+
+	_, err = psql.Update("resource_webhook_tokens").
+		Set("expires_at", sq.Expr(expiresAt)).
+		Where(sq.Eq{"resource_id": r.id}).
+		Where(sq.Or{
+			sq.Eq{"expires_at": nil},
+			sq.Expr("expires_at > " + expiresAt),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return atc.WebhookToken{}, err
+	}
+
+	token, err := r.createWebhookToken(tx)
+	if err != nil {
+		return atc.WebhookToken{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.WebhookToken{}, err
+	}
+
+	return token, nil
+}
+
+// UseWebhookToken finds the resource's unexpired webhook token matching the
+// given value and records that it has been used.
+func (r *resource) UseWebhookToken(token string) (atc.WebhookToken, bool, error) {
+	row := psql.Update("resource_webhook_tokens").
+		Set("last_used_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"resource_id": r.id,
+			"token_hash":  hashToken(token),
+		}).
+		Where(sq.Or{
+			sq.Eq{"expires_at": nil},
+			sq.Expr("expires_at > now()"),
+		}).
+		Suffix("RETURNING id, created_at, expires_at, last_used_at").
+		RunWith(r.conn).
+		QueryRow()
+
+	used, err := scanWebhookToken(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.WebhookToken{}, false, nil
+		}
+
+		return atc.WebhookToken{}, false, err
+	}
+
+	return used, true, nil
+}
+
+func (r *resource) createWebhookToken(runner sq.Runner) (atc.WebhookToken, error) {
+	token, err := generateToken()
+	if err != nil {
+		return atc.WebhookToken{}, err
+	}
+
+	row := psql.Insert("resource_webhook_tokens").
+		Columns("resource_id", "token_hash").
+		Values(r.id, hashToken(token)).
+		Suffix("RETURNING id, created_at, expires_at, last_used_at").
+		RunWith(runner).
+		QueryRow()
+
+	created, err := scanWebhookToken(row)
+	if err != nil {
+		return atc.WebhookToken{}, err
+	}
+
+	created.Token = token
+
+	return created, nil
+}
+
+func scanWebhookToken(row scannable) (atc.WebhookToken, error) {
+	var (
+		token      atc.WebhookToken
+		createdAt  time.Time
+		expiresAt  sql.NullTime
+		lastUsedAt sql.NullTime
+	)
+
+	err := row.Scan(&token.ID, &createdAt, &expiresAt, &lastUsedAt)
+	if err != nil {
+		return atc.WebhookToken{}, err
+	}
+
+	token.CreatedAt = createdAt.Unix()
+
+	if expiresAt.Valid {
+		token.ExpiresAt = expiresAt.Time.Unix()
+	}
+
+	if lastUsedAt.Valid {
+		token.LastUsedAt = lastUsedAt.Time.Unix()
+	}
+
+	return token, nil
+}
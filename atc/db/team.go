@@ -61,6 +61,8 @@ type Team interface {
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
 	FindVolumeForWorkerArtifact(int) (CreatedVolume, bool, error)
+	CreateStoredArtifact(storeKey string) (WorkerArtifact, error)
+	FindStoredWorkerArtifact(int) (WorkerArtifact, bool, error)
 
 	Containers() ([]Container, error)
 	IsCheckContainer(string) (bool, error)
@@ -124,6 +126,56 @@ func (t *team) Workers() ([]Worker, error) {
 	}))
 }
 
+// CreateStoredArtifact saves an artifact owned by the team which is kept in
+// the external artifact store under storeKey.
+func (t *team) CreateStoredArtifact(storeKey string) (WorkerArtifact, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	artifact, err := saveStoredWorkerArtifact(tx, t.conn, atc.WorkerArtifact{}, storeKey, t.id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
+// FindStoredWorkerArtifact finds an artifact owned by the team which is kept
+// in the external artifact store.
+func (t *team) FindStoredWorkerArtifact(artifactID int) (WorkerArtifact, bool, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Rollback(tx)
+
+	artifact, found, err := getWorkerArtifact(tx, t.conn, artifactID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found || artifact.StoreKey() == "" || artifact.TeamID() != t.id {
+		return nil, false, nil
+	}
+
+	return artifact, true, nil
+}
+
 func (t *team) FindVolumeForWorkerArtifact(artifactID int) (CreatedVolume, bool, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -590,17 +642,19 @@ func savePipeline(
 		return 0, false, err
 	}
 
-	jobNameToID, err := saveJobsAndSerialGroups(tx, config.Jobs, config.Groups, pipelineID)
+	jobs := config.JobsWithPipelineHooks()
+
+	jobNameToID, err := saveJobsAndSerialGroups(tx, jobs, config.Groups, pipelineID)
 	if err != nil {
 		return 0, false, err
 	}
 
-	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs)
+	err = removeUnusedWorkerTaskCaches(tx, pipelineID, jobs)
 	if err != nil {
 		return 0, false, err
 	}
 
-	err = insertJobPipes(tx, config.Jobs, resourceNameToID, jobNameToID, pipelineID)
+	err = insertJobPipes(tx, jobs, resourceNameToID, jobNameToID, pipelineID)
 	if err != nil {
 		return 0, false, err
 	}
@@ -1206,7 +1260,7 @@ func sortUpdateNames(updateNames []UpdateName) []UpdateName {
 }
 
 func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, error) {
-	configPayload, err := json.Marshal(job)
+	configPayload, err := marshalJobConfig(job)
 	if err != nil {
 		return 0, err
 	}
@@ -1219,9 +1273,9 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 
 	var jobID int
 	err = psql.Insert("jobs").
-		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "interruptible", "active", "nonce", "tags").
-		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups)).
-		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags").
+		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "interruptible", "active", "nonce", "tags", "priority").
+		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups), job.Priority).
+		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags, priority = EXCLUDED.priority").
 		Suffix("RETURNING id").
 		RunWith(tx).
 		QueryRow().
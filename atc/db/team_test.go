@@ -733,6 +733,45 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("FindStoredWorkerArtifact", func() {
+		Context("when the artifact is kept in the artifact store", func() {
+			var artifact db.WorkerArtifact
+
+			BeforeEach(func() {
+				var err error
+				artifact, err = defaultTeam.CreateStoredArtifact("artifacts/some-key.tgz")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the artifact", func() {
+				found, exists, err := defaultTeam.FindStoredWorkerArtifact(artifact.ID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists).To(BeTrue())
+				Expect(found.StoreKey()).To(Equal("artifacts/some-key.tgz"))
+				Expect(found.TeamID()).To(Equal(defaultTeam.ID()))
+			})
+
+			It("is not found by other teams", func() {
+				_, exists, err := otherTeam.FindStoredWorkerArtifact(artifact.ID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			})
+		})
+
+		Context("when the artifact is kept on a volume", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec("INSERT INTO worker_artifacts (id, name) VALUES ($1, '')", 18)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns not found", func() {
+				_, exists, err := defaultTeam.FindStoredWorkerArtifact(18)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("FindWorkerForContainer", func() {
 		var containerMetadata db.ContainerMetadata
 		var defaultBuild db.Build
@@ -2244,6 +2283,45 @@ var _ = Describe("Team", func() {
 			Expect(job.Config()).To(Equal(config.Jobs[0]))
 		})
 
+		Context("when the pipeline has hooks", func() {
+			BeforeEach(func() {
+				config.PipelineHooks = atc.PipelineHooks{
+					OnFailure: &atc.Step{
+						Config: &atc.PutStep{
+							Name:     "notify",
+							Resource: "some-resource",
+						},
+					},
+				}
+			})
+
+			It("loads them with every job", func() {
+				savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := savedPipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				jobConfig, err := job.Config()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobConfig.PipelineHooks).To(Equal(config.PipelineHooks))
+
+				outputs, err := job.Outputs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(outputs).To(ContainElement(atc.JobOutput{Name: "notify", Resource: "some-resource"}))
+			})
+
+			It("returns them with the pipeline's config", func() {
+				savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				savedConfig, err := savedPipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(savedConfig.PipelineHooks).To(Equal(config.PipelineHooks))
+			})
+		})
+
 		It("updates job config", func() {
 			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
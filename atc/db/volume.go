@@ -159,6 +159,7 @@ type CreatedVolume interface {
 
 	InitializeResourceCache(ResourceCache) error
 	InitializeStreamedResourceCache(ResourceCache, string) error
+	SetResourceCacheSize(size uint64) error
 	GetResourceCacheID() int
 	InitializeArtifact(name string, buildID int) (WorkerArtifact, error)
 	InitializeTaskCache(jobID int, stepName string, path string) error
@@ -433,7 +434,7 @@ func (volume *createdVolume) initializeResourceCache(tx Tx, resourceCache Resour
 	workerResourceCache, valid, err := WorkerResourceCache{
 		WorkerName:    volume.WorkerName(),
 		ResourceCache: resourceCache,
-	}.FindOrCreate(tx, workerBaseResourceTypeID)
+	}.FindOrCreate(tx, workerBaseResourceTypeID, volume.teamID)
 	if err != nil {
 		return false, err
 	}
@@ -476,6 +477,18 @@ func (volume *createdVolume) initializeResourceCache(tx Tx, resourceCache Resour
 	return true, nil
 }
 
+// SetResourceCacheSize records how many bytes the resource cache the volume
+// holds takes up on its worker. It does nothing if the volume doesn't hold a
+// resource cache.
+func (volume *createdVolume) SetResourceCacheSize(size uint64) error {
+	_, err := psql.Update("worker_resource_caches").
+		Set("size", size).
+		Where(sq.Expr("id = (SELECT worker_resource_cache_id FROM volumes WHERE id = ?)", volume.id)).
+		RunWith(volume.conn).
+		Exec()
+	return err
+}
+
 func (volume *createdVolume) GetResourceCacheID() int {
 	return volume.resourceCacheID
 }
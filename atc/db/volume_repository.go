@@ -20,6 +20,7 @@ type VolumeRepository interface {
 	CreateBaseResourceTypeVolume(*UsedWorkerBaseResourceType) (CreatingVolume, error)
 
 	FindResourceCacheVolume(workerName string, resourceCache ResourceCache) (CreatedVolume, bool, error)
+	FindResourceConfigVolume(workerName string, resourceCache ResourceCache) (CreatedVolume, bool, error)
 
 	FindTaskCacheVolume(teamID int, workerName string, taskCache UsedTaskCache) (CreatedVolume, bool, error)
 	CreateTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, error)
@@ -462,6 +463,56 @@ func (repository *volumeRepository) FindResourceCacheVolume(workerName string, r
 		return nil, false, nil
 	}
 
+	// keep track of when the cache was last used so that the least recently
+	// used caches are evicted first when a team exceeds its cache quota
+	_, err = psql.Update("worker_resource_caches").
+		Set("last_used", sq.Expr("now()")).
+		Where(sq.Eq{"id": workerResourceCache.ID}).
+		RunWith(repository.conn).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return createdVolume, true, nil
+}
+
+// FindResourceConfigVolume finds a volume on the worker for another cache of
+// the resource cache's config, e.g. for a previous version of the resource,
+// preferring the most recently used one.
+func (repository *volumeRepository) FindResourceConfigVolume(workerName string, resourceCache ResourceCache) (CreatedVolume, bool, error) {
+	row := psql.Select(volumeColumns...).
+		From("volumes v").
+		LeftJoin("workers w ON v.worker_name = w.name").
+		LeftJoin("containers c ON v.container_id = c.id").
+		LeftJoin("volumes pv ON v.parent_id = pv.id").
+		Join("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
+		Join("resource_caches rc ON rc.id = wrc.resource_cache_id").
+		Where(sq.Eq{
+			"v.worker_name":         workerName,
+			"v.state":               VolumeStateCreated,
+			"rc.resource_config_id": resourceCache.ResourceConfig().ID(),
+		}).
+		Where(sq.NotEq{
+			"rc.id": resourceCache.ID(),
+		}).
+		OrderBy("wrc.last_used DESC").
+		Limit(1).
+		RunWith(repository.conn).
+		QueryRow()
+
+	_, createdVolume, _, _, err := scanVolume(row, repository.conn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if createdVolume == nil {
+		return nil, false, nil
+	}
+
 	return createdVolume, true, nil
 }
 
@@ -558,6 +558,117 @@ var _ = Describe("VolumeRepository", func() {
 		})
 	})
 
+	Describe("FindResourceConfigVolume", func() {
+		var usedResourceCache db.ResourceCache
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultPipeline.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			usedResourceCache, err = resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-type",
+				atc.Version{"some": "new-version"},
+				atc.Source{
+					"some": "source",
+				},
+				atc.Params{"some": "params"},
+				resourceTypeCache,
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		createCacheVolume := func(cache db.ResourceCache, mountPath string) db.CreatedVolume {
+			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID(mountPath), defaultTeam.ID()), db.ContainerMetadata{
+				Type:     "get",
+				StepName: "some-resource",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, mountPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = createdVolume.InitializeResourceCache(cache)
+			Expect(err).NotTo(HaveOccurred())
+
+			return createdVolume
+		}
+
+		Context("when there is a volume for another version of the resource", func() {
+			var existingVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				otherResourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+					db.ForBuild(build.ID()),
+					"some-type",
+					atc.Version{"some": "old-version"},
+					atc.Source{
+						"some": "source",
+					},
+					atc.Params{"some": "params"},
+					resourceTypeCache,
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				existingVolume = createCacheVolume(otherResourceCache, "some-path-1")
+			})
+
+			It("returns the volume", func() {
+				createdVolume, found, err := volumeRepository.FindResourceConfigVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(createdVolume.Handle()).To(Equal(existingVolume.Handle()))
+			})
+
+			It("does not return it for another worker", func() {
+				_, found, err := volumeRepository.FindResourceConfigVolume(otherWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when there is only a volume for the resource cache itself", func() {
+			BeforeEach(func() {
+				createCacheVolume(usedResourceCache, "some-path-2")
+			})
+
+			It("does not return it", func() {
+				_, found, err := volumeRepository.FindResourceConfigVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when there is a volume for another resource", func() {
+			BeforeEach(func() {
+				otherResourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+					db.ForBuild(build.ID()),
+					"some-type",
+					atc.Version{"some": "old-version"},
+					atc.Source{
+						"some": "other-source",
+					},
+					atc.Params{"some": "params"},
+					resourceTypeCache,
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				createCacheVolume(otherResourceCache, "some-path-3")
+			})
+
+			It("does not return it", func() {
+				_, found, err := volumeRepository.FindResourceConfigVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("RemoveDestroyingVolumes", func() {
 		var failedErr error
 		var numDeleted int
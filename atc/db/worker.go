@@ -68,6 +68,10 @@ type Worker interface {
 	StartTime() time.Time
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	GPUs() int
+	Capacity() WorkerResources
+	Reserved() WorkerResources
+	Load() WorkerLoad
 
 	Reload() (bool, error)
 
@@ -80,6 +84,11 @@ type Worker interface {
 	IncreaseActiveTasks() (int, error)
 	DecreaseActiveTasks() (int, error)
 
+	Reserve(WorkerResources) (bool, error)
+	Unreserve(WorkerResources) error
+
+	BuildPipelines() ([]WorkerPipeline, error)
+
 	FindContainer(owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 }
@@ -107,6 +116,59 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	gpus             int
+	capacity         WorkerResources
+	reserved         WorkerResources
+	load             WorkerLoad
+}
+
+// WorkerResources are amounts of CPU shares and bytes of memory on a worker.
+type WorkerResources struct {
+	CPU    uint64
+	Memory uint64
+}
+
+// WorkerLoad is the load last reported by a worker. Each is zero if the worker
+// did not report it.
+type WorkerLoad struct {
+	// CPU is the 1-minute load average divided by the number of CPUs.
+	CPU float64
+	// Memory is the fraction of memory in use.
+	Memory float64
+	// Disk is the fraction of the volumes disk in use.
+	Disk float64
+}
+
+func workerLoad(stats *atc.WorkerStats) WorkerLoad {
+	var load WorkerLoad
+	if stats == nil {
+		return load
+	}
+
+	load.CPU = stats.CPULoad
+
+	if stats.MemoryTotalBytes > 0 {
+		load.Memory = 1 - float64(stats.MemoryAvailableBytes)/float64(stats.MemoryTotalBytes)
+	}
+
+	if stats.DiskTotalBytes > 0 {
+		load.Disk = 1 - float64(stats.DiskFreeBytes)/float64(stats.DiskTotalBytes)
+	}
+
+	return load
+}
+
+// WorkerPipeline is a pipeline with build containers on a worker.
+type WorkerPipeline struct {
+	PipelineID   int
+	PipelineName string
+	TeamName     string
+	Containers   int
+}
+
+// IsZero reports whether no CPU or memory is given.
+func (r WorkerResources) IsZero() bool {
+	return r.CPU == 0 && r.Memory == 0
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -127,6 +189,10 @@ func (worker *worker) Tags() []string                          { return worker.t
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
+func (worker *worker) GPUs() int                               { return worker.gpus }
+func (worker *worker) Capacity() WorkerResources               { return worker.capacity }
+func (worker *worker) Reserved() WorkerResources               { return worker.reserved }
+func (worker *worker) Load() WorkerLoad                        { return worker.load }
 
 func (worker *worker) StartTime() time.Time { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
@@ -411,3 +477,89 @@ func (worker *worker) DecreaseActiveTasks() (int, error) {
 	}
 	return worker.activeTasks, nil
 }
+
+// Reserve reserves resources on the worker for a container, unless the
+// worker's capacity would be exceeded. A worker with no capacity for a
+// resource can have any amount of it reserved.
+func (worker *worker) Reserve(resources WorkerResources) (bool, error) {
+	var cpu, memory int64
+	err := psql.Update("workers").
+		Set("reserved_cpu", sq.Expr("reserved_cpu + ?", resources.CPU)).
+		Set("reserved_memory", sq.Expr("reserved_memory + ?", resources.Memory)).
+		Where(sq.Eq{"name": worker.name}).
+		Where(sq.Or{
+			sq.Eq{"cpu_capacity": 0},
+			sq.Expr("reserved_cpu + ? <= cpu_capacity", resources.CPU),
+		}).
+		Where(sq.Or{
+			sq.Eq{"memory_capacity": 0},
+			sq.Expr("reserved_memory + ? <= memory_capacity", resources.Memory),
+		}).
+		Suffix("RETURNING reserved_cpu, reserved_memory").
+		RunWith(worker.conn).
+		QueryRow().
+		Scan(&cpu, &memory)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	worker.reserved = WorkerResources{CPU: uint64(cpu), Memory: uint64(memory)}
+
+	return true, nil
+}
+
+// Unreserve releases resources reserved on the worker by Reserve.
+func (worker *worker) Unreserve(resources WorkerResources) error {
+	var cpu, memory int64
+	err := psql.Update("workers").
+		Set("reserved_cpu", sq.Expr("GREATEST(reserved_cpu - ?, 0)", resources.CPU)).
+		Set("reserved_memory", sq.Expr("GREATEST(reserved_memory - ?, 0)", resources.Memory)).
+		Where(sq.Eq{"name": worker.name}).
+		Suffix("RETURNING reserved_cpu, reserved_memory").
+		RunWith(worker.conn).
+		QueryRow().
+		Scan(&cpu, &memory)
+	if err != nil {
+		return err
+	}
+
+	worker.reserved = WorkerResources{CPU: uint64(cpu), Memory: uint64(memory)}
+
+	return nil
+}
+
+// BuildPipelines lists the pipelines which have build containers on the
+// worker, along with how many containers each has.
+func (worker *worker) BuildPipelines() ([]WorkerPipeline, error) {
+	rows, err := psql.Select("c.meta_pipeline_id, c.meta_pipeline_name, t.name, COUNT(*)").
+		From("containers c").
+		Join("teams t ON t.id = c.team_id").
+		Where(sq.Eq{"c.worker_name": worker.name}).
+		Where(sq.NotEq{"c.build_id": nil}).
+		Where(sq.NotEq{"c.meta_pipeline_id": 0}).
+		GroupBy("c.meta_pipeline_id, c.meta_pipeline_name, t.name").
+		RunWith(worker.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var pipelines []WorkerPipeline
+	for rows.Next() {
+		var pipeline WorkerPipeline
+		err := rows.Scan(&pipeline.PipelineID, &pipeline.PipelineName, &pipeline.TeamName, &pipeline.Containers)
+		if err != nil {
+			return nil, err
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines, nil
+}
@@ -19,6 +19,14 @@ type WorkerArtifact interface {
 	BuildID() int
 	CreatedAt() time.Time
 	Volume(teamID int) (CreatedVolume, bool, error)
+
+	// StoreKey is the key under which the artifact is kept in the external
+	// artifact store, if it is kept there rather than on a worker volume.
+	StoreKey() string
+
+	// TeamID is the team owning an artifact kept in the external artifact
+	// store. Other artifacts belong to the team owning their volume.
+	TeamID() int
 }
 
 type artifact struct {
@@ -28,12 +36,16 @@ type artifact struct {
 	name      string
 	buildID   int
 	createdAt time.Time
+	storeKey  string
+	teamID    int
 }
 
 func (a *artifact) ID() int              { return a.id }
 func (a *artifact) Name() string         { return a.name }
 func (a *artifact) BuildID() int         { return a.buildID }
 func (a *artifact) CreatedAt() time.Time { return a.createdAt }
+func (a *artifact) StoreKey() string     { return a.storeKey }
+func (a *artifact) TeamID() int          { return a.teamID }
 
 func (a *artifact) Volume(teamID int) (CreatedVolume, bool, error) {
 	where := map[string]interface{}{
@@ -54,6 +66,12 @@ func (a *artifact) Volume(teamID int) (CreatedVolume, bool, error) {
 }
 
 func saveWorkerArtifact(tx Tx, conn Conn, atcArtifact atc.WorkerArtifact) (WorkerArtifact, error) {
+	return saveStoredWorkerArtifact(tx, conn, atcArtifact, "", 0)
+}
+
+// saveStoredWorkerArtifact saves an artifact which, if storeKey is set, is
+// kept in the external artifact store rather than on a volume.
+func saveStoredWorkerArtifact(tx Tx, conn Conn, atcArtifact atc.WorkerArtifact, storeKey string, teamID int) (WorkerArtifact, error) {
 
 	var artifactID int
 
@@ -65,6 +83,11 @@ func saveWorkerArtifact(tx Tx, conn Conn, atcArtifact atc.WorkerArtifact) (Worke
 		values["build_id"] = atcArtifact.BuildID
 	}
 
+	if storeKey != "" {
+		values["store_key"] = storeKey
+		values["team_id"] = teamID
+	}
+
 	err := psql.Insert("worker_artifacts").
 		SetMap(values).
 		Suffix("RETURNING id").
@@ -93,18 +116,20 @@ func getWorkerArtifact(tx Tx, conn Conn, id int) (WorkerArtifact, bool, error) {
 	var (
 		createdAtTime pq.NullTime
 		buildID       sql.NullInt64
+		storeKey      sql.NullString
+		teamID        sql.NullInt64
 	)
 
 	artifact := &artifact{conn: conn}
 
-	err := psql.Select("id", "created_at", "name", "build_id").
+	err := psql.Select("id", "created_at", "name", "build_id", "store_key", "team_id").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"id": id,
 		}).
 		RunWith(tx).
 		QueryRow().
-		Scan(&artifact.id, &createdAtTime, &artifact.name, &buildID)
+		Scan(&artifact.id, &createdAtTime, &artifact.name, &buildID, &storeKey, &teamID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -115,6 +140,8 @@ func getWorkerArtifact(tx Tx, conn Conn, id int) (WorkerArtifact, bool, error) {
 
 	artifact.createdAt = createdAtTime.Time
 	artifact.buildID = int(buildID.Int64)
+	artifact.storeKey = storeKey.String
+	artifact.teamID = int(teamID.Int64)
 
 	return artifact, true, nil
 }
@@ -0,0 +1,263 @@
+package db
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+//counterfeiter:generate . WorkerEnrollmentFactory
+type WorkerEnrollmentFactory interface {
+	CreateToken(teamID int, tags []string, ttl time.Duration) (atc.WorkerEnrollmentToken, error)
+	Tokens() ([]atc.WorkerEnrollmentToken, error)
+	DeleteToken(id int) (bool, error)
+
+	Enroll(token string, publicKey string) (atc.EnrolledWorkerKey, bool, error)
+	EnrolledKeys() ([]atc.EnrolledWorkerKey, error)
+}
+
+type workerEnrollmentFactory struct {
+	conn  Conn
+	clock Clock
+}
+
+func NewWorkerEnrollmentFactory(conn Conn, clock Clock) WorkerEnrollmentFactory {
+	return &workerEnrollmentFactory{
+		conn:  conn,
+		clock: clock,
+	}
+}
+
+var workerEnrollmentTokensQuery = psql.Select(
+	"t.id",
+	"tm.name",
+	"t.tags",
+	"t.expires_at",
+	"t.public_key",
+	"t.enrolled_at",
+).
+	From("worker_enrollment_tokens t").
+	LeftJoin("teams tm ON tm.id = t.team_id")
+
+func (f *workerEnrollmentFactory) CreateToken(teamID int, tags []string, ttl time.Duration) (atc.WorkerEnrollmentToken, error) {
+	token, err := generateToken()
+	if err != nil {
+		return atc.WorkerEnrollmentToken{}, err
+	}
+
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var team sql.NullInt64
+	if teamID != 0 {
+		team = sql.NullInt64{Int64: int64(teamID), Valid: true}
+	}
+
+	expiresAt := f.clock.Now().Add(ttl)
+
+	var id int
+	err = psql.Insert("worker_enrollment_tokens").
+		Columns("token_hash", "team_id", "tags", "expires_at").
+		Values(hashToken(token), team, pq.Array(tags), expiresAt).
+		Suffix("RETURNING id").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return atc.WorkerEnrollmentToken{}, err
+	}
+
+	row := workerEnrollmentTokensQuery.
+		Where(sq.Eq{"t.id": id}).
+		RunWith(f.conn).
+		QueryRow()
+
+	created, err := scanWorkerEnrollmentToken(row)
+	if err != nil {
+		return atc.WorkerEnrollmentToken{}, err
+	}
+
+	created.Token = token
+
+	return created, nil
+}
+
+func (f *workerEnrollmentFactory) Tokens() ([]atc.WorkerEnrollmentToken, error) {
+	rows, err := workerEnrollmentTokensQuery.
+		OrderBy("t.id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	tokens := []atc.WorkerEnrollmentToken{}
+	for rows.Next() {
+		token, err := scanWorkerEnrollmentToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (f *workerEnrollmentFactory) DeleteToken(id int) (bool, error) {
+	result, err := psql.Delete("worker_enrollment_tokens").
+		Where(sq.Eq{"id": id}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected != 0, nil
+}
+
+// Enroll exchanges an unexpired enrollment token for the given public key.
+// Each token can only be used by a single key, though enrolling the same key
+// again with the same token is allowed so that workers can be restarted.
+func (f *workerEnrollmentFactory) Enroll(token string, publicKey string) (atc.EnrolledWorkerKey, bool, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return atc.EnrolledWorkerKey{}, false, err
+	}
+
+	defer Rollback(tx)
+
+	row := workerEnrollmentTokensQuery.
+		Where(sq.Eq{"t.token_hash": hashToken(token)}).
+		Suffix("FOR UPDATE OF t").
+		RunWith(tx).
+		QueryRow()
+
+	enrollment, err := scanWorkerEnrollmentToken(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.EnrolledWorkerKey{}, false, nil
+		}
+
+		return atc.EnrolledWorkerKey{}, false, err
+	}
+
+	key := atc.EnrolledWorkerKey{
+		PublicKey: publicKey,
+		Team:      enrollment.Team,
+		Tags:      enrollment.Tags,
+	}
+
+	if enrollment.PublicKey != "" {
+		return key, enrollment.PublicKey == publicKey, nil
+	}
+
+	if !f.clock.Now().Before(time.Unix(enrollment.ExpiresAt, 0)) {
+		return atc.EnrolledWorkerKey{}, false, nil
+	}
+
+	_, err = psql.Update("worker_enrollment_tokens").
+		Set("public_key", publicKey).
+		Set("enrolled_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": enrollment.ID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return atc.EnrolledWorkerKey{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.EnrolledWorkerKey{}, false, err
+	}
+
+	return key, true, nil
+}
+
+func (f *workerEnrollmentFactory) EnrolledKeys() ([]atc.EnrolledWorkerKey, error) {
+	rows, err := workerEnrollmentTokensQuery.
+		Where(sq.NotEq{"t.public_key": nil}).
+		OrderBy("t.id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	keys := []atc.EnrolledWorkerKey{}
+	for rows.Next() {
+		token, err := scanWorkerEnrollmentToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, atc.EnrolledWorkerKey{
+			PublicKey: token.PublicKey,
+			Team:      token.Team,
+			Tags:      token.Tags,
+		})
+	}
+
+	return keys, nil
+}
+
+func scanWorkerEnrollmentToken(row scannable) (atc.WorkerEnrollmentToken, error) {
+	var (
+		token      atc.WorkerEnrollmentToken
+		team       sql.NullString
+		expiresAt  time.Time
+		publicKey  sql.NullString
+		enrolledAt sql.NullTime
+	)
+
+	err := row.Scan(&token.ID, &team, pq.Array(&token.Tags), &expiresAt, &publicKey, &enrolledAt)
+	if err != nil {
+		return atc.WorkerEnrollmentToken{}, err
+	}
+
+	token.Team = team.String
+	token.ExpiresAt = expiresAt.Unix()
+	token.PublicKey = publicKey.String
+
+	if enrolledAt.Valid {
+		token.EnrolledAt = enrolledAt.Time.Unix()
+	}
+
+	if len(token.Tags) == 0 {
+		token.Tags = nil
+	}
+
+	return token, nil
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
@@ -0,0 +1,118 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerEnrollmentFactory", func() {
+	var startTime time.Time
+
+	BeforeEach(func() {
+		startTime = time.Now()
+
+		fakeClock = dbfakes.FakeClock{}
+		fakeClock.NowReturns(startTime)
+	})
+
+	Describe("CreateToken", func() {
+		It("returns the token along with its restrictions", func() {
+			token, err := workerEnrollmentFactory.CreateToken(defaultTeam.ID(), []string{"some-tag"}, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(token.Token).ToNot(BeEmpty())
+			Expect(token.Team).To(Equal(defaultTeam.Name()))
+			Expect(token.Tags).To(Equal([]string{"some-tag"}))
+			Expect(token.ExpiresAt).To(Equal(startTime.Add(time.Hour).Unix()))
+		})
+
+		It("does not list the token value", func() {
+			_, err := workerEnrollmentFactory.CreateToken(0, nil, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			tokens, err := workerEnrollmentFactory.Tokens()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].Token).To(BeEmpty())
+			Expect(tokens[0].Team).To(BeEmpty())
+		})
+	})
+
+	Describe("Enroll", func() {
+		var token atc.WorkerEnrollmentToken
+
+		BeforeEach(func() {
+			var err error
+			token, err = workerEnrollmentFactory.CreateToken(defaultTeam.ID(), []string{"some-tag"}, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("enrolls the key with the token's restrictions", func() {
+			key, found, err := workerEnrollmentFactory.Enroll(token.Token, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(key).To(Equal(atc.EnrolledWorkerKey{
+				PublicKey: "some-key",
+				Team:      defaultTeam.Name(),
+				Tags:      []string{"some-tag"},
+			}))
+
+			keys, err := workerEnrollmentFactory.EnrolledKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(ConsistOf(key))
+		})
+
+		It("allows the same key to enroll again", func() {
+			_, found, err := workerEnrollmentFactory.Enroll(token.Token, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = workerEnrollmentFactory.Enroll(token.Token, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("rejects a different key once the token is used", func() {
+			_, found, err := workerEnrollmentFactory.Enroll(token.Token, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = workerEnrollmentFactory.Enroll(token.Token, "other-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("rejects expired tokens", func() {
+			fakeClock.NowReturns(startTime.Add(2 * time.Hour))
+
+			_, found, err := workerEnrollmentFactory.Enroll(token.Token, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("rejects unknown tokens", func() {
+			_, found, err := workerEnrollmentFactory.Enroll("bogus", "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("revokes the key when the token is deleted", func() {
+			_, found, err := workerEnrollmentFactory.Enroll(token.Token, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			deleted, err := workerEnrollmentFactory.DeleteToken(token.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			keys, err := workerEnrollmentFactory.EnrolledKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(BeEmpty())
+		})
+	})
+})
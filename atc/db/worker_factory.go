@@ -55,7 +55,15 @@ var workersQuery = psql.Select(`
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.gpus,
+		w.cpu_capacity,
+		w.memory_capacity,
+		w.reserved_cpu,
+		w.reserved_memory,
+		w.cpu_load,
+		w.memory_usage,
+		w.disk_usage
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -167,6 +175,9 @@ func scanWorker(worker *worker, row scannable) error {
 		startTime     pq.NullTime
 		expiresAt     pq.NullTime
 		ephemeral     sql.NullBool
+
+		cpuCapacity, memoryCapacity int64
+		reservedCPU, reservedMemory int64
 	)
 
 	err := row.Scan(
@@ -189,6 +200,14 @@ func scanWorker(worker *worker, row scannable) error {
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&worker.gpus,
+		&cpuCapacity,
+		&memoryCapacity,
+		&reservedCPU,
+		&reservedMemory,
+		&worker.load.CPU,
+		&worker.load.Memory,
+		&worker.load.Disk,
 	)
 	if err != nil {
 		return err
@@ -242,6 +261,9 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	worker.capacity = WorkerResources{CPU: uint64(cpuCapacity), Memory: uint64(memoryCapacity)}
+	worker.reserved = WorkerResources{CPU: uint64(reservedCPU), Memory: uint64(reservedMemory)}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -279,10 +301,18 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		return nil, err
 	}
 
+	load := workerLoad(atcWorker.Stats)
+
 	_, err = psql.Update("workers").
 		Set("expires", sq.Expr(expires)).
 		Set("active_containers", atcWorker.ActiveContainers).
 		Set("active_volumes", atcWorker.ActiveVolumes).
+		Set("gpus", atcWorker.GPUs).
+		Set("cpu_capacity", atcWorker.CPUCapacity).
+		Set("memory_capacity", atcWorker.MemoryCapacity).
+		Set("cpu_load", load.CPU).
+		Set("memory_usage", load.Memory).
+		Set("disk_usage", load.Disk).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -395,6 +425,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		workerVersion = &atcWorker.Version
 	}
 
+	load := workerLoad(atcWorker.Stats)
+
 	values := []interface{}{
 		atcWorker.GardenAddr,
 		atcWorker.ActiveContainers,
@@ -412,6 +444,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		atcWorker.GPUs,
+		atcWorker.CPUCapacity,
+		atcWorker.MemoryCapacity,
+		load.CPU,
+		load.Memory,
+		load.Disk,
 	}
 
 	conflictValues := values
@@ -443,6 +481,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"gpus",
+			"cpu_capacity",
+			"memory_capacity",
+			"cpu_load",
+			"memory_usage",
+			"disk_usage",
 		).
 		Values(append([]interface{}{
 			sq.Expr(expires),
@@ -467,7 +511,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				version = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				gpus = ?,
+				cpu_capacity = ?,
+				memory_capacity = ?,
+				cpu_load = ?,
+				memory_usage = ?,
+				disk_usage = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -510,6 +560,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		teamID:           workerTeamID,
 		startTime:        time.Unix(atcWorker.StartTime, 0),
 		ephemeral:        atcWorker.Ephemeral,
+		gpus:             atcWorker.GPUs,
+		capacity:         WorkerResources{CPU: atcWorker.CPUCapacity, Memory: atcWorker.MemoryCapacity},
+		load:             load,
 		conn:             conn,
 	}
 
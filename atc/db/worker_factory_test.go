@@ -27,6 +27,7 @@ var _ = Describe("WorkerFactory", func() {
 			HTTPSProxyURL:    "some-https-proxy-url",
 			NoProxy:          "some-no-proxy",
 			Ephemeral:        true,
+			GPUs:             2,
 			ActiveContainers: 140,
 			ActiveVolumes:    550,
 			ResourceTypes: []atc.WorkerResourceType{
@@ -226,6 +227,7 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.HTTPSProxyURL()).To(Equal("some-https-proxy-url"))
 				Expect(foundWorker.NoProxy()).To(Equal("some-no-proxy"))
 				Expect(foundWorker.Ephemeral()).To(Equal(true))
+				Expect(foundWorker.GPUs()).To(Equal(2))
 				Expect(foundWorker.ActiveContainers()).To(Equal(140))
 				Expect(foundWorker.ActiveVolumes()).To(Equal(550))
 				Expect(foundWorker.ResourceTypes()).To(Equal([]atc.WorkerResourceType{
@@ -436,6 +438,14 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
 
+			It("updates the number of GPUs", func() {
+				atcWorker.GPUs = 4
+
+				foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.GPUs()).To(Equal(4))
+			})
+
 			Context("when the current state is landing", func() {
 				BeforeEach(func() {
 					atcWorker.State = string(db.WorkerStateLanding)
@@ -31,7 +31,10 @@ var ErrWorkerBaseResourceTypeDisappeared = errors.New("worker base resource type
 // streamed to a worker simultaneously from multiple other "source" workers -
 // we only want a single worker_resource_cache in the end for the destination
 // worker, so the "first write wins".
-func (workerResourceCache WorkerResourceCache) FindOrCreate(tx Tx, sourceWorkerBaseResourceTypeID int) (*UsedWorkerResourceCache, bool, error) {
+//
+// A newly created worker_resource_cache is attributed to the given team (if
+// any) so that it counts towards the team's cache quota on the worker.
+func (workerResourceCache WorkerResourceCache) FindOrCreate(tx Tx, sourceWorkerBaseResourceTypeID int, teamID int) (*UsedWorkerResourceCache, bool, error) {
 	uwrc, found, err := workerResourceCache.find(tx)
 	if err != nil {
 		return nil, false, err
@@ -41,17 +44,24 @@ func (workerResourceCache WorkerResourceCache) FindOrCreate(tx Tx, sourceWorkerB
 		return uwrc, valid, nil
 	}
 
+	var ownerTeamID interface{}
+	if teamID != 0 {
+		ownerTeamID = teamID
+	}
+
 	var id int
 	err = psql.Insert("worker_resource_caches").
 		Columns(
 			"resource_cache_id",
 			"worker_base_resource_type_id",
 			"worker_name",
+			"team_id",
 		).
 		Values(
 			workerResourceCache.ResourceCache.ID(),
 			sourceWorkerBaseResourceTypeID,
 			workerResourceCache.WorkerName,
+			ownerTeamID,
 		).
 		Suffix(`RETURNING id`).
 		RunWith(tx).
@@ -461,4 +461,40 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+
+	Describe("Reserve", func() {
+		BeforeEach(func() {
+			atcWorker.CPUCapacity = 2048
+			atcWorker.MemoryCapacity = 1024
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reserves resources within the worker's capacity", func() {
+			reserved, err := worker.Reserve(WorkerResources{CPU: 1024, Memory: 512})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+			Expect(worker.Reserved()).To(Equal(WorkerResources{CPU: 1024, Memory: 512}))
+
+			reserved, err = worker.Reserve(WorkerResources{CPU: 1024, Memory: 1024})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reserved).To(BeFalse())
+			Expect(worker.Reserved()).To(Equal(WorkerResources{CPU: 1024, Memory: 512}))
+		})
+
+		It("releases reserved resources", func() {
+			_, err := worker.Reserve(WorkerResources{CPU: 2048, Memory: 1024})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = worker.Unreserve(WorkerResources{CPU: 2048, Memory: 1024})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(worker.Reserved()).To(Equal(WorkerResources{}))
+
+			reserved, err := worker.Reserve(WorkerResources{CPU: 2048})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+		})
+	})
 })
@@ -11,10 +11,12 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/tracing"
@@ -68,6 +70,11 @@ func (delegate *buildStepDelegate) Stdout() io.Writer {
 	if delegate.stdout != nil {
 		return delegate.stdout
 	}
+	policy := delegate.state.OutputPolicy()
+	if policy.DiscardStdout {
+		delegate.stdout = discardWriter{}
+		return delegate.stdout
+	}
 	if delegate.state.RedactionEnabled() {
 		delegate.stdout = newDBEventWriterWithSecretRedaction(
 			delegate.build,
@@ -88,6 +95,9 @@ func (delegate *buildStepDelegate) Stdout() io.Writer {
 			delegate.clock,
 		)
 	}
+	if policy.LogLimit > 0 {
+		delegate.stdout = newTruncatingWriter(delegate.stdout, policy.LogLimit)
+	}
 	return delegate.stdout
 }
 
@@ -95,6 +105,7 @@ func (delegate *buildStepDelegate) Stderr() io.Writer {
 	if delegate.stderr != nil {
 		return delegate.stderr
 	}
+	policy := delegate.state.OutputPolicy()
 	if delegate.state.RedactionEnabled() {
 		delegate.stderr = newDBEventWriterWithSecretRedaction(
 			delegate.build,
@@ -115,6 +126,9 @@ func (delegate *buildStepDelegate) Stderr() io.Writer {
 			delegate.clock,
 		)
 	}
+	if policy.LogLimit > 0 {
+		delegate.stderr = newTruncatingWriter(delegate.stderr, policy.LogLimit)
+	}
 	return delegate.stderr
 }
 
@@ -153,7 +167,12 @@ func (delegate *buildStepDelegate) Finished(logger lager.Logger, succeeded bool)
 	delegate.Stdout().(io.Closer).Close()
 	delegate.Stderr().(io.Closer).Close()
 
-	err := delegate.build.SaveEvent(event.Finish{
+	err := delegate.build.SetWaitingForWorker(delegate.planID, false)
+	if err != nil {
+		logger.Error("failed-to-unset-waiting-for-worker", err)
+	}
+
+	err = delegate.build.SaveEvent(event.Finish{
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
 		},
@@ -169,7 +188,12 @@ func (delegate *buildStepDelegate) Finished(logger lager.Logger, succeeded bool)
 }
 
 func (delegate *buildStepDelegate) WaitingForWorker(logger lager.Logger) {
-	err := delegate.build.SaveEvent(event.WaitingForWorker{
+	err := delegate.build.SetWaitingForWorker(delegate.planID, true)
+	if err != nil {
+		logger.Error("failed-to-set-waiting-for-worker", err)
+	}
+
+	err = delegate.build.SaveEvent(event.WaitingForWorker{
 		Time: time.Now().Unix(),
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
@@ -182,7 +206,12 @@ func (delegate *buildStepDelegate) WaitingForWorker(logger lager.Logger) {
 }
 
 func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, worker string) {
-	err := delegate.build.SaveEvent(event.SelectedWorker{
+	err := delegate.build.SetWaitingForWorker(delegate.planID, false)
+	if err != nil {
+		logger.Error("failed-to-unset-waiting-for-worker", err)
+	}
+
+	err = delegate.build.SaveEvent(event.SelectedWorker{
 		Time: time.Now().Unix(),
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
@@ -196,8 +225,33 @@ func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, worker st
 	}
 }
 
+func (delegate *buildStepDelegate) PhaseFinished(logger lager.Logger, phase exec.StepPhase, duration time.Duration) {
+	err := delegate.build.SaveEvent(event.StepPhase{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Phase:    string(phase),
+		Duration: duration.Seconds(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-step-phase-event", err)
+	}
+
+	metric.StepPhaseDuration{
+		Build:    delegate.build,
+		Phase:    string(phase),
+		Duration: duration,
+	}.Emit(logger)
+}
+
 func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string) {
-	err := delegate.build.SaveEvent(event.Error{
+	err := delegate.build.SetWaitingForWorker(delegate.planID, false)
+	if err != nil {
+		logger.Error("failed-to-unset-waiting-for-worker", err)
+	}
+
+	err = delegate.build.SaveEvent(event.Error{
 		Message: message,
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
@@ -209,6 +263,67 @@ func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+// BuildTimedOut records that the build was interrupted for exceeding its job's
+// build_timeout, along with an error giving that as the reason it failed.
+func (delegate *buildStepDelegate) BuildTimedOut(logger lager.Logger, duration time.Duration) {
+	err := delegate.build.SaveEvent(event.BuildTimeout{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:     delegate.clock.Now().Unix(),
+		Duration: duration.String(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-build-timeout-event", err)
+	}
+
+	delegate.Errored(logger, fmt.Sprintf("build exceeded its build_timeout of %s", duration))
+}
+
+func (delegate *buildStepDelegate) HookTimedOut(logger lager.Logger, duration time.Duration) {
+	err := delegate.build.SaveEvent(event.HookTimeout{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:     delegate.clock.Now().Unix(),
+		Duration: duration.String(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-hook-timeout-event", err)
+	}
+}
+
+func (delegate *buildStepDelegate) Rescheduled(logger lager.Logger, reason string) {
+	err := delegate.build.SaveEvent(event.Rescheduled{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:   delegate.clock.Now().Unix(),
+		Reason: reason,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-rescheduled-event", err)
+	}
+}
+
+func (delegate *buildStepDelegate) TimeoutWarning(logger lager.Logger, warnAfter time.Duration) {
+	err := delegate.build.SaveEvent(event.TimeoutWarning{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:     delegate.clock.Now().Unix(),
+		Duration: warnAfter.String(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-timeout-warning-event", err)
+	}
+
+	metric.StepTimeoutWarning{
+		Build:     delegate.build,
+		WarnAfter: warnAfter,
+	}.Emit(logger)
+}
+
 func (delegate *buildStepDelegate) FetchImage(
 	ctx context.Context,
 	getPlan atc.Plan,
@@ -220,6 +335,20 @@ func (delegate *buildStepDelegate) FetchImage(
 		return runtime.ImageSpec{}, nil, err
 	}
 
+	imageSpec, resourceCache, err := delegate.fetchImage(ctx, getPlan, checkPlan, privileged)
+	if err != nil {
+		return runtime.ImageSpec{}, nil, exec.ImageFetchError{Cause: err}
+	}
+
+	return imageSpec, resourceCache, nil
+}
+
+func (delegate *buildStepDelegate) fetchImage(
+	ctx context.Context,
+	getPlan atc.Plan,
+	checkPlan *atc.Plan,
+	privileged bool,
+) (runtime.ImageSpec, db.ResourceCache, error) {
 	fetchState := delegate.state.NewLocalScope()
 
 	if checkPlan != nil {
@@ -258,11 +387,66 @@ func (delegate *buildStepDelegate) FetchImage(
 	}
 
 	return runtime.ImageSpec{
-		ImageArtifact: artifact,
-		Privileged:    privileged,
+		ImageArtifact:       artifact,
+		ImageArtifactSource: delegate.imageArtifactSource(getPlan, result.ResourceCache),
+		Privileged:          privileged,
 	}, result.ResourceCache, nil
 }
 
+// imageArtifactSource is the registry image an image resource fetched, so
+// that its signature can be verified. Only the registry-image and
+// docker-image base types are known to fetch the image their source and
+// version describe; the images fetched by any other type are unverifiable.
+func (delegate *buildStepDelegate) imageArtifactSource(getPlan atc.Plan, resourceCache db.ResourceCache) *atc.RegistryImage {
+	if resourceCache == nil {
+		return nil
+	}
+
+	// a custom type may share its name with a base type, in which case it's
+	// fetched with an image of its own
+	if getPlan.Get.TypeImage.GetPlan != nil {
+		return nil
+	}
+
+	switch getPlan.Get.TypeImage.BaseType {
+	case "registry-image", "docker-image":
+	default:
+		return nil
+	}
+
+	digest := resourceCache.Version()["digest"]
+	if digest == "" {
+		return nil
+	}
+
+	source, err := creds.NewSource(delegate.state, getPlan.Get.Source).Evaluate()
+	if err != nil {
+		return nil
+	}
+
+	repository, _ := source["repository"].(string)
+	if repository == "" {
+		return nil
+	}
+
+	// docker-image resources allow the tag to be given with the repository
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+
+	username, _ := source["username"].(string)
+	password, _ := source["password"].(string)
+	insecure, _ := source["insecure"].(bool)
+
+	return &atc.RegistryImage{
+		Repository: repository,
+		Digest:     digest,
+		Username:   username,
+		Password:   password,
+		Insecure:   insecure,
+	}
+}
+
 func (delegate *buildStepDelegate) ConstructAcrossSubsteps(templateBytes []byte, acrossVars []atc.AcrossVar, valueCombinations [][]interface{}) ([]atc.VarScopedPlan, error) {
 	template := vars.NewTemplate(templateBytes)
 	substeps := make([]atc.VarScopedPlan, len(valueCombinations))
@@ -326,6 +510,52 @@ func (delegate *buildStepDelegate) ConstructAcrossSubsteps(templateBytes []byte,
 	return substeps, nil
 }
 
+func (delegate *buildStepDelegate) ListResourceVersions(resourceName string, limit int) ([]atc.Version, error) {
+	pipeline, found, err := delegate.build.Pipeline()
+	if err != nil {
+		return nil, fmt.Errorf("find pipeline: %w", err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("pipeline not found")
+	}
+
+	resource, found, err := pipeline.Resource(resourceName)
+	if err != nil {
+		return nil, fmt.Errorf("find resource: %w", err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("resource '%s' not found", resourceName)
+	}
+
+	// disabled versions are skipped, so keep paging through older versions
+	// until there are enough enabled ones
+	var versions []atc.Version
+	page := &db.Page{Limit: limit}
+	for page != nil && len(versions) < limit {
+		resourceVersions, pagination, _, err := resource.Versions(*page, nil)
+		if err != nil {
+			return nil, fmt.Errorf("find versions: %w", err)
+		}
+
+		for _, resourceVersion := range resourceVersions {
+			if !resourceVersion.Enabled {
+				continue
+			}
+
+			versions = append(versions, resourceVersion.Version)
+			if len(versions) == limit {
+				break
+			}
+		}
+
+		page = pagination.Older
+	}
+
+	return versions, nil
+}
+
 func (delegate *buildStepDelegate) checkImagePolicy(imageSource atc.Source, imageType string, privileged bool) error {
 	if !delegate.policyChecker.ShouldCheckAction(policy.ActionUseImage) {
 		return nil
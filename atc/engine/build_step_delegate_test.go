@@ -28,6 +28,7 @@ import (
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -91,6 +92,13 @@ var _ = Describe("BuildStepDelegate", func() {
 			event := fakeBuild.SaveEventArgsForCall(0)
 			Expect(event.EventType()).To(Equal(atc.EventType("finish")))
 		})
+
+		It("marks the step as no longer waiting for a worker", func() {
+			Expect(fakeBuild.SetWaitingForWorkerCallCount()).To(Equal(1))
+			planID, waiting := fakeBuild.SetWaitingForWorkerArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(waiting).To(BeFalse())
+		})
 	})
 
 	Describe("FetchImage", func() {
@@ -99,6 +107,7 @@ var _ = Describe("BuildStepDelegate", func() {
 		var expectedCheckPlan, expectedGetPlan *atc.Plan
 		var volume *runtimetest.Volume
 		var fakeResourceCache *dbfakes.FakeResourceCache
+		var cacheVersion atc.Version
 
 		var privileged bool
 
@@ -148,6 +157,7 @@ var _ = Describe("BuildStepDelegate", func() {
 
 				fakeResourceCache = new(dbfakes.FakeResourceCache)
 				fakeResourceCache.IDReturns(123)
+				fakeResourceCache.VersionReturns(cacheVersion)
 				volume = runtimetest.NewVolume("image-handle")
 
 				step := new(execfakes.FakeStep)
@@ -164,6 +174,7 @@ var _ = Describe("BuildStepDelegate", func() {
 
 			parentRunState = exec.NewRunState(stepper, nil, true)
 
+			cacheVersion = atc.Version{"some": "version"}
 			privileged = false
 
 		})
@@ -213,6 +224,71 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 		})
 
+		Context("when the image is fetched from a registry by digest", func() {
+			BeforeEach(func() {
+				expectedGetPlan.Get.Type = "registry-image"
+				expectedGetPlan.Get.TypeImage.BaseType = "registry-image"
+				expectedGetPlan.Get.Source = atc.Source{
+					"repository": "registry.example.com:5000/some/image:latest",
+					"username":   "some-user",
+					"password":   "((source-var))",
+				}
+				cacheVersion = atc.Version{"digest": "sha256:some-digest"}
+
+				parentRunState = exec.NewRunState(stepper, vars.StaticVariables{
+					"source-var": "super-secret-source",
+				}, true)
+			})
+
+			It("records where the image came from, with its credentials", func() {
+				Expect(imageSpec.ImageArtifactSource).To(Equal(&atc.RegistryImage{
+					Repository: "registry.example.com:5000/some/image",
+					Digest:     "sha256:some-digest",
+					Username:   "some-user",
+					Password:   "super-secret-source",
+				}))
+			})
+
+			Context("when the image is fetched with the docker-image type", func() {
+				BeforeEach(func() {
+					expectedGetPlan.Get.Type = "docker-image"
+					expectedGetPlan.Get.TypeImage.BaseType = "docker-image"
+				})
+
+				It("records where the image came from", func() {
+					Expect(imageSpec.ImageArtifactSource).ToNot(BeNil())
+					Expect(imageSpec.ImageArtifactSource.Repository).To(Equal("registry.example.com:5000/some/image"))
+				})
+			})
+
+			Context("when the image is fetched with any other base type", func() {
+				BeforeEach(func() {
+					expectedGetPlan.Get.Type = "s3"
+					expectedGetPlan.Get.TypeImage.BaseType = "s3"
+				})
+
+				It("does not record where the image came from", func() {
+					Expect(imageSpec.ImageArtifactSource).To(BeNil())
+				})
+			})
+
+			Context("when the image is fetched with a custom type", func() {
+				BeforeEach(func() {
+					expectedGetPlan.Get.TypeImage.GetPlan = &atc.Plan{
+						ID: "custom-type-get",
+						Get: &atc.GetPlan{
+							Name: "registry-image",
+							Type: "registry-image",
+						},
+					}
+				})
+
+				It("does not record where the image came from", func() {
+					Expect(imageSpec.ImageArtifactSource).To(BeNil())
+				})
+			})
+		})
+
 		Describe("policy checking", func() {
 			BeforeEach(func() {
 				fakeBuild.TeamNameReturns("some-team")
@@ -629,6 +705,94 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("ListResourceVersions", func() {
+		var (
+			fakePipeline *dbfakes.FakePipeline
+			fakeResource *dbfakes.FakeResource
+
+			limit    int
+			versions []atc.Version
+			listErr  error
+		)
+
+		BeforeEach(func() {
+			limit = 10
+
+			fakePipeline = new(dbfakes.FakePipeline)
+			fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+			fakeResource.VersionsReturns([]atc.ResourceVersion{
+				{Version: atc.Version{"ref": "v3"}, Enabled: true},
+				{Version: atc.Version{"ref": "v2"}, Enabled: false},
+				{Version: atc.Version{"ref": "v1"}, Enabled: true},
+			}, db.Pagination{}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			versions, listErr = delegate.ListResourceVersions("some-resource", limit)
+		})
+
+		It("looks up the resource in the build's pipeline", func() {
+			Expect(fakePipeline.ResourceCallCount()).To(Equal(1))
+			Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("some-resource"))
+		})
+
+		It("fetches the newest versions up to the limit", func() {
+			Expect(fakeResource.VersionsCallCount()).To(Equal(1))
+			page, filter := fakeResource.VersionsArgsForCall(0)
+			Expect(page).To(Equal(db.Page{Limit: 10}))
+			Expect(filter).To(BeNil())
+		})
+
+		It("returns the enabled versions", func() {
+			Expect(listErr).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]atc.Version{
+				{"ref": "v3"},
+				{"ref": "v1"},
+			}))
+		})
+
+		Context("when disabled versions leave fewer enabled versions than the limit", func() {
+			BeforeEach(func() {
+				limit = 2
+
+				fakeResource.VersionsReturnsOnCall(0, []atc.ResourceVersion{
+					{Version: atc.Version{"ref": "v4"}, Enabled: true},
+					{Version: atc.Version{"ref": "v3"}, Enabled: false},
+				}, db.Pagination{Older: &db.Page{To: db.NewIntPtr(2), Limit: 2}}, true, nil)
+
+				fakeResource.VersionsReturnsOnCall(1, []atc.ResourceVersion{
+					{Version: atc.Version{"ref": "v2"}, Enabled: true},
+					{Version: atc.Version{"ref": "v1"}, Enabled: true},
+				}, db.Pagination{}, true, nil)
+			})
+
+			It("fetches older versions until the limit is reached", func() {
+				Expect(fakeResource.VersionsCallCount()).To(Equal(2))
+				page, _ := fakeResource.VersionsArgsForCall(1)
+				Expect(page).To(Equal(db.Page{To: db.NewIntPtr(2), Limit: 2}))
+
+				Expect(versions).To(Equal([]atc.Version{
+					{"ref": "v4"},
+					{"ref": "v2"},
+				}))
+			})
+		})
+
+		Context("when the resource is not found", func() {
+			BeforeEach(func() {
+				fakePipeline.ResourceReturns(nil, false, nil)
+			})
+
+			It("errors", func() {
+				Expect(listErr).To(MatchError("resource 'some-resource' not found"))
+			})
+		})
+	})
+
 	Describe("Stdout", func() {
 		var writer io.Writer
 
@@ -691,6 +855,76 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("output policy", func() {
+		Context("when stdout is discarded", func() {
+			BeforeEach(func() {
+				runState.OutputPolicyReturns(exec.OutputPolicy{DiscardStdout: true})
+			})
+
+			It("does not save stdout", func() {
+				writer := delegate.Stdout()
+				writtenBytes, writeErr := writer.Write([]byte("progress\n"))
+				Expect(writtenBytes).To(Equal(len("progress\n")))
+				Expect(writeErr).ToNot(HaveOccurred())
+				Expect(writer.(io.Closer).Close()).To(Succeed())
+
+				Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+			})
+
+			It("still saves stderr", func() {
+				writer := delegate.Stderr()
+				writer.Write([]byte("oh no\n"))
+				writer.(io.Closer).Close()
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Time:    now.Unix(),
+					Payload: "oh no\n",
+					Origin: event.Origin{
+						Source: event.OriginSourceStderr,
+						ID:     "some-plan-id",
+					},
+				}))
+			})
+		})
+
+		Context("when the log is limited", func() {
+			BeforeEach(func() {
+				runState.OutputPolicyReturns(exec.OutputPolicy{LogLimit: 8})
+			})
+
+			It("saves output up to the limit followed by a truncation marker", func() {
+				writer := delegate.Stdout()
+				writtenBytes, writeErr := writer.Write([]byte("hello\nworld"))
+				Expect(writtenBytes).To(Equal(len("hello\nworld")))
+				Expect(writeErr).ToNot(HaveOccurred())
+
+				writtenBytes, writeErr = writer.Write([]byte("more\n"))
+				Expect(writtenBytes).To(Equal(len("more\n")))
+				Expect(writeErr).ToNot(HaveOccurred())
+				writer.(io.Closer).Close()
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Time:    now.Unix(),
+					Payload: "hello\n",
+					Origin: event.Origin{
+						Source: event.OriginSourceStdout,
+						ID:     "some-plan-id",
+					},
+				}))
+				Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+					Time:    now.Unix(),
+					Payload: "wo\n[output truncated after 8 bytes]\n",
+					Origin: event.Origin{
+						Source: event.OriginSourceStdout,
+						ID:     "some-plan-id",
+					},
+				}))
+			})
+		})
+	})
+
 	Describe("Stderr", func() {
 		var writer io.Writer
 
@@ -750,6 +984,13 @@ var _ = Describe("BuildStepDelegate", func() {
 			delegate.Errored(logger, "fake error message")
 		})
 
+		It("marks the step as no longer waiting for a worker", func() {
+			Expect(fakeBuild.SetWaitingForWorkerCallCount()).To(Equal(1))
+			planID, waiting := fakeBuild.SetWaitingForWorkerArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(waiting).To(BeFalse())
+		})
+
 		Context("when saving the event succeeds", func() {
 			BeforeEach(func() {
 				fakeBuild.SaveEventReturns(nil)
@@ -783,6 +1024,124 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("BuildTimedOut", func() {
+		JustBeforeEach(func() {
+			delegate.BuildTimedOut(logger, time.Hour)
+		})
+
+		It("saves a build-timeout event and an error giving the reason", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.BuildTimeout{
+				Time:     now.Unix(),
+				Duration: "1h0m0s",
+				Origin: event.Origin{
+					ID: "some-plan-id",
+				},
+			}))
+			Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Error{
+				Time:    now.Unix(),
+				Message: "build exceeded its build_timeout of 1h0m0s",
+				Origin: event.Origin{
+					ID: "some-plan-id",
+				},
+			}))
+		})
+	})
+
+	Describe("TimeoutWarning", func() {
+		JustBeforeEach(func() {
+			delegate.TimeoutWarning(logger, 45*time.Minute)
+		})
+
+		It("saves an event with the current time", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.TimeoutWarning{
+				Time:     now.Unix(),
+				Duration: "45m0s",
+				Origin: event.Origin{
+					ID: "some-plan-id",
+				},
+			}))
+		})
+	})
+
+	Describe("WaitingForWorker", func() {
+		JustBeforeEach(func() {
+			delegate.WaitingForWorker(logger)
+		})
+
+		It("marks the step as waiting for a worker", func() {
+			Expect(fakeBuild.SetWaitingForWorkerCallCount()).To(Equal(1))
+			planID, waiting := fakeBuild.SetWaitingForWorkerArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(waiting).To(BeTrue())
+		})
+
+		It("saves an event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			event := fakeBuild.SaveEventArgsForCall(0)
+			Expect(event.EventType()).To(Equal(atc.EventType("waiting-for-worker")))
+		})
+	})
+
+	Describe("SelectedWorker", func() {
+		JustBeforeEach(func() {
+			delegate.SelectedWorker(logger, "some-worker")
+		})
+
+		It("marks the step as no longer waiting for a worker", func() {
+			Expect(fakeBuild.SetWaitingForWorkerCallCount()).To(Equal(1))
+			planID, waiting := fakeBuild.SetWaitingForWorkerArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(waiting).To(BeFalse())
+		})
+
+		It("saves an event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			event := fakeBuild.SaveEventArgsForCall(0)
+			Expect(event.EventType()).To(Equal(atc.EventType("selected-worker")))
+		})
+	})
+
+	Describe("Rescheduled", func() {
+		JustBeforeEach(func() {
+			delegate.Rescheduled(logger, "worker disappeared")
+		})
+
+		It("saves an event with the reason", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Rescheduled{
+				Time:   now.Unix(),
+				Reason: "worker disappeared",
+				Origin: event.Origin{
+					ID: "some-plan-id",
+				},
+			}))
+		})
+	})
+
+	Describe("PhaseFinished", func() {
+		BeforeEach(func() {
+			fakeBuild.TracingAttrsReturns(tracing.Attrs{})
+		})
+
+		JustBeforeEach(func() {
+			delegate.PhaseFinished(logger, exec.StepPhaseCreatingContainer, 1500*time.Millisecond)
+		})
+
+		It("saves an event with the duration in seconds", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.StepPhase{
+				Time:     now.Unix(),
+				Phase:    "creating-container",
+				Duration: 1.5,
+				Origin: event.Origin{
+					ID: "some-plan-id",
+				},
+			}))
+		})
+	})
+
 	Describe("No line buffer without secrets redaction", func() {
 		var runState exec.RunState
 
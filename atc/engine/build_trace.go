@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/tracing"
+)
+
+// BuildTrace is a replayable record of the var lookups and artifact
+// registrations made while running a build.
+type BuildTrace struct {
+	Build BuildTraceMetadata  `json:"build"`
+	Plan  atc.Plan            `json:"plan"`
+	State *exec.RunStateTrace `json:"state"`
+}
+
+type BuildTraceMetadata struct {
+	ID                   int              `json:"id"`
+	Name                 string           `json:"name"`
+	Schema               string           `json:"schema"`
+	TeamID               int              `json:"team_id"`
+	TeamName             string           `json:"team_name"`
+	PipelineID           int              `json:"pipeline_id,omitempty"`
+	PipelineName         string           `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobID                int              `json:"job_id,omitempty"`
+	JobName              string           `json:"job_name,omitempty"`
+	CreatedBy            *string          `json:"created_by,omitempty"`
+}
+
+func BuildTracePath(dir string, buildID int) string {
+	return filepath.Join(dir, fmt.Sprintf("build-%d.json", buildID))
+}
+
+func writeBuildTrace(dir string, build db.Build, trace *exec.RunStateTrace) error {
+	payload, err := json.Marshal(BuildTrace{
+		Build: BuildTraceMetadata{
+			ID:                   build.ID(),
+			Name:                 build.Name(),
+			Schema:               build.Schema(),
+			TeamID:               build.TeamID(),
+			TeamName:             build.TeamName(),
+			PipelineID:           build.PipelineID(),
+			PipelineName:         build.PipelineName(),
+			PipelineInstanceVars: build.PipelineInstanceVars(),
+			JobID:                build.JobID(),
+			JobName:              build.JobName(),
+			CreatedBy:            build.CreatedBy(),
+		},
+		Plan:  build.PrivatePlan(),
+		State: trace,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(BuildTracePath(dir, build.ID()), payload, 0600)
+}
+
+func LoadBuildTrace(path string) (BuildTrace, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return BuildTrace{}, err
+	}
+
+	var trace BuildTrace
+	err = json.Unmarshal(payload, &trace)
+	if err != nil {
+		return BuildTrace{}, fmt.Errorf("parse build trace: %w", err)
+	}
+
+	if trace.State == nil {
+		trace.State = exec.NewRunStateTrace()
+	}
+
+	return trace, nil
+}
+
+// ReplayBuildTrace constructs the steps of a traced build with the same
+// builder used to run it, and runs them against the recorded vars and
+// artifacts. Steps which would run on a worker instead only resolve their
+// vars, and describe themselves to out.
+func ReplayBuildTrace(ctx context.Context, trace BuildTrace, out io.Writer) (bool, error) {
+	stepperFactory := NewStepperFactory(
+		NewReplayStepFactory(out, trace.State),
+		"",
+		nil,
+		nil,
+		nil,
+		nil,
+		policy.NoopChecker{},
+		nil,
+		nil,
+		nil,
+	)
+
+	stepper, err := stepperFactory.StepperForBuild(replayBuild{metadata: trace.Build})
+	if err != nil {
+		return false, err
+	}
+
+	return exec.NewReplayRunState(stepper, trace.State).Run(ctx, trace.Plan)
+}
+
+// replayBuild satisfies the parts of db.Build used while constructing steps
+// from the recorded metadata. Build events are discarded, and the build is
+// never paused.
+type replayBuild struct {
+	db.Build
+
+	metadata BuildTraceMetadata
+}
+
+func (b replayBuild) ID() int                                { return b.metadata.ID }
+func (b replayBuild) Name() string                           { return b.metadata.Name }
+func (b replayBuild) Schema() string                         { return b.metadata.Schema }
+func (b replayBuild) TeamID() int                            { return b.metadata.TeamID }
+func (b replayBuild) TeamName() string                       { return b.metadata.TeamName }
+func (b replayBuild) PipelineID() int                        { return b.metadata.PipelineID }
+func (b replayBuild) PipelineName() string                   { return b.metadata.PipelineName }
+func (b replayBuild) PipelineInstanceVars() atc.InstanceVars { return b.metadata.PipelineInstanceVars }
+func (b replayBuild) JobID() int                             { return b.metadata.JobID }
+func (b replayBuild) JobName() string                        { return b.metadata.JobName }
+func (b replayBuild) CreatedBy() *string                     { return b.metadata.CreatedBy }
+func (b replayBuild) LagerData() lager.Data                  { return lager.Data{"build-id": b.metadata.ID} }
+func (b replayBuild) TracingAttrs() tracing.Attrs            { return tracing.Attrs{} }
+func (b replayBuild) SaveEvent(atc.Event) error              { return nil }
+
+// RunningDuration is always zero, so that a replayed build never times out.
+func (b replayBuild) RunningDuration() (time.Duration, error) {
+	return 0, nil
+}
+
+func (b replayBuild) ResumeNotifier() (db.Notifier, error) {
+	return resumedNotifier{}, nil
+}
+
+// resumedNotifier notifies right away, as a replayed build is never paused.
+type resumedNotifier struct{}
+
+func (resumedNotifier) Notify() <-chan struct{} {
+	notify := make(chan struct{})
+	close(notify)
+	return notify
+}
+
+func (resumedNotifier) Close() error { return nil }
@@ -0,0 +1,96 @@
+package engine_test
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ReplayBuildTrace", func() {
+	var (
+		trace engine.BuildTrace
+		out   *gbytes.Buffer
+
+		ok  bool
+		err error
+	)
+
+	BeforeEach(func() {
+		out = gbytes.NewBuffer()
+
+		trace = engine.BuildTrace{
+			Build: engine.BuildTraceMetadata{
+				ID:       42,
+				Name:     "1",
+				Schema:   "exec.v2",
+				TeamID:   1,
+				TeamName: "main",
+			},
+			Plan: atc.Plan{
+				ID: "do",
+				Do: &atc.DoPlan{
+					{
+						ID: "load-var",
+						LoadVar: &atc.LoadVarPlan{
+							Name: "branch",
+							File: "repo/branch",
+						},
+					},
+					{
+						ID: "get",
+						Get: &atc.GetPlan{
+							Name:   "repo",
+							Type:   "git",
+							Source: atc.Source{"uri": "((uri))", "branch": "((.:branch))"},
+						},
+					},
+				},
+			},
+			State: &exec.RunStateTrace{
+				Events: []exec.RunStateTraceEvent{
+					{Type: exec.RunStateTraceAddLocalVar, Name: "branch", Value: "main"},
+					{Type: exec.RunStateTraceGetVar, Var: &vars.Reference{Source: ".", Path: "branch"}, Found: true},
+					{Type: exec.RunStateTraceGetVar, Var: &vars.Reference{Path: "uri"}, Value: "https://example.com/repo.git", Found: true},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ok, err = engine.ReplayBuildTrace(context.Background(), trace, out)
+	})
+
+	It("replays each step against the recorded state", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(out).To(gbytes.Say(`load_var branch \(load-var\)`))
+		Expect(out).To(gbytes.Say(`get repo \(get\)`))
+	})
+
+	Context("when a step refers to a var which was not recorded", func() {
+		BeforeEach(func() {
+			trace.State.Events = trace.State.Events[:2]
+		})
+
+		It("reproduces the error", func() {
+			Expect(err).To(MatchError(ContainSubstring("var uri was not recorded in the trace")))
+			Expect(out).To(gbytes.Say(`error: .*uri`))
+		})
+	})
+
+	Context("when a load_var step was not recorded", func() {
+		BeforeEach(func() {
+			trace.State.Events = nil
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError("load_var branch was not recorded in the trace"))
+		})
+	})
+})
@@ -24,6 +24,8 @@ type CoreStepFactory interface {
 	CheckStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
 	SetPipelineStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	LoadVarStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	NotifyStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	TaskOutputVarsStep(atc.Plan, []atc.TaskOutputVarConfig) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build) exec.Step
 }
@@ -36,7 +38,10 @@ type StepperFactory interface {
 func NewStepperFactory(
 	coreFactory CoreStepFactory,
 	externalURL string,
+	metadataEnvAllowlist []string,
+	setPipelineAcrossTeams []string,
 	rateLimiter RateLimiter,
+	teamRateLimiter TeamRateLimiter,
 	policyChecker policy.Checker,
 	dbWorkerFactory db.WorkerFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
@@ -45,7 +50,10 @@ func NewStepperFactory(
 	return &stepperFactory{
 		coreFactory:            coreFactory,
 		externalURL:            externalURL,
+		metadataEnvAllowlist:   metadataEnvAllowlist,
+		setPipelineAcrossTeams: setPipelineAcrossTeams,
 		rateLimiter:            rateLimiter,
+		teamRateLimiter:        teamRateLimiter,
 		policyChecker:          policyChecker,
 		dbWorkerFactory:        dbWorkerFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
@@ -56,7 +64,10 @@ func NewStepperFactory(
 type stepperFactory struct {
 	coreFactory            CoreStepFactory
 	externalURL            string
+	metadataEnvAllowlist   []string
+	setPipelineAcrossTeams []string
 	rateLimiter            RateLimiter
+	teamRateLimiter        TeamRateLimiter
 	policyChecker          policy.Checker
 	dbWorkerFactory        db.WorkerFactory
 	dbResourceCacheFactory db.ResourceCacheFactory
@@ -78,7 +89,9 @@ func (factory *stepperFactory) buildDelegateFactory(build db.Build, plan atc.Pla
 		build:                  build,
 		plan:                   plan,
 		rateLimiter:            factory.rateLimiter,
+		teamRateLimiter:        factory.teamRateLimiter,
 		policyChecker:          factory.policyChecker,
+		setPipelineAcrossTeams: factory.setPipelineAcrossTeams,
 		dbWorkerFactory:        factory.dbWorkerFactory,
 		dbResourceCacheFactory: factory.dbResourceCacheFactory,
 		lockFactory:            factory.lockFactory,
@@ -102,10 +115,22 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildTimeoutStep(build, plan)
 	}
 
+	if plan.Mute != nil {
+		return factory.buildMuteStep(build, plan)
+	}
+
 	if plan.Try != nil {
 		return factory.buildTryStep(build, plan)
 	}
 
+	if plan.When != nil {
+		return factory.buildWhenStep(build, plan)
+	}
+
+	if plan.Lock != nil {
+		return factory.buildLockStep(build, plan)
+	}
+
 	if plan.OnAbort != nil {
 		return factory.buildOnAbortStep(build, plan)
 	}
@@ -114,6 +139,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildOnErrorStep(build, plan)
 	}
 
+	if plan.OnTimeout != nil {
+		return factory.buildOnTimeoutStep(build, plan)
+	}
+
 	if plan.OnSuccess != nil {
 		return factory.buildOnSuccessStep(build, plan)
 	}
@@ -127,19 +156,23 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 	}
 
 	if plan.Run != nil {
-		return factory.buildRunStep(build, plan)
+		return pausable(build, factory.resumable(build, plan, factory.buildRunStep(build, plan)))
 	}
 
 	if plan.Task != nil {
-		return factory.buildTaskStep(build, plan)
+		return pausable(build, factory.resumable(build, plan, factory.buildTaskStep(build, plan)))
 	}
 
 	if plan.SetPipeline != nil {
-		return factory.buildSetPipelineStep(build, plan)
+		return pausable(build, factory.resumable(build, plan, factory.buildSetPipelineStep(build, plan)))
 	}
 
 	if plan.LoadVar != nil {
-		return factory.buildLoadVarStep(build, plan)
+		return pausable(build, factory.buildLoadVarStep(build, plan))
+	}
+
+	if plan.Notify != nil {
+		return pausable(build, factory.resumable(build, plan, factory.buildNotifyStep(build, plan)))
 	}
 
 	if plan.Check != nil {
@@ -147,17 +180,21 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 	}
 
 	if plan.Get != nil {
-		return factory.buildGetStep(build, plan)
+		return pausable(build, factory.buildGetStep(build, plan))
 	}
 
 	if plan.Put != nil {
-		return factory.buildPutStep(build, plan)
+		return pausable(build, factory.resumable(build, plan, factory.buildPutStep(build, plan)))
 	}
 
 	if plan.Retry != nil {
 		return factory.buildRetryStep(build, plan)
 	}
 
+	if plan.While != nil {
+		return factory.buildWhileStep(build, plan)
+	}
+
 	if plan.ArtifactInput != nil {
 		return factory.buildArtifactInputStep(build, plan)
 	}
@@ -205,7 +242,11 @@ func (factory *stepperFactory) buildDoStep(build db.Build, plan atc.Plan) exec.S
 		innerPlan := (*plan.Do)[i]
 		innerPlan.Attempts = plan.Attempts
 		previous := factory.buildStep(build, innerPlan)
-		step = exec.OnSuccess(previous, step)
+		if plan.ContinueOnFailure {
+			step = exec.ContinueOnFailure(previous, step)
+		} else {
+			step = exec.OnSuccess(previous, step)
+		}
 	}
 
 	return step
@@ -215,7 +256,48 @@ func (factory *stepperFactory) buildTimeoutStep(build db.Build, plan atc.Plan) e
 	innerPlan := plan.Timeout.Step
 	innerPlan.Attempts = plan.Attempts
 	step := factory.buildStep(build, innerPlan)
-	return exec.Timeout(step, plan.Timeout.Duration)
+
+	if plan.Timeout.Build {
+		return exec.BuildTimeout(
+			step,
+			plan.Timeout.Duration,
+			build.RunningDuration,
+			factory.buildDelegateFactory(build, plan),
+		)
+	}
+
+	return exec.TimeoutWithWarning(
+		step,
+		plan.Timeout.Duration,
+		plan.Timeout.WarnAfter,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildMuteStep(build db.Build, plan atc.Plan) exec.Step {
+	innerPlan := plan.Mute.Step
+	innerPlan.Attempts = plan.Attempts
+	step := factory.buildStep(build, innerPlan)
+	return exec.Mute(step, *plan.Mute)
+}
+
+func (factory *stepperFactory) buildWhenStep(build db.Build, plan atc.Plan) exec.Step {
+	innerPlan := plan.When.Step
+	innerPlan.Attempts = plan.Attempts
+	step := factory.buildStep(build, innerPlan)
+	conditionalStep := exec.Conditional(step, plan.When.Condition)
+	return exec.LogError(conditionalStep, factory.buildDelegateFactory(build, plan))
+}
+
+func (factory *stepperFactory) buildLockStep(build db.Build, plan atc.Plan) exec.Step {
+	innerPlan := plan.Lock.Step
+	innerPlan.Attempts = plan.Attempts
+	step := factory.buildStep(build, innerPlan)
+
+	// the lock plan is rendered as the step it wraps, so anything written
+	// while waiting for the lock is written to that step's log
+	lockStep := exec.Lock(step, *plan.Lock, build.TeamID(), factory.lockFactory, factory.buildDelegateFactory(build, innerPlan))
+	return exec.LogError(lockStep, factory.buildDelegateFactory(build, plan))
 }
 
 func (factory *stepperFactory) buildTryStep(build db.Build, plan atc.Plan) exec.Step {
@@ -229,7 +311,7 @@ func (factory *stepperFactory) buildOnAbortStep(build db.Build, plan atc.Plan) e
 	plan.OnAbort.Step.Attempts = plan.Attempts
 	step := factory.buildStep(build, plan.OnAbort.Step)
 	plan.OnAbort.Next.Attempts = plan.Attempts
-	next := factory.buildStep(build, plan.OnAbort.Next)
+	next := factory.buildHookStep(build, plan.OnAbort.Next, plan.OnAbort.Timeout)
 	return exec.OnAbort(step, next)
 }
 
@@ -237,15 +319,23 @@ func (factory *stepperFactory) buildOnErrorStep(build db.Build, plan atc.Plan) e
 	plan.OnError.Step.Attempts = plan.Attempts
 	step := factory.buildStep(build, plan.OnError.Step)
 	plan.OnError.Next.Attempts = plan.Attempts
-	next := factory.buildStep(build, plan.OnError.Next)
+	next := factory.buildHookStep(build, plan.OnError.Next, plan.OnError.Timeout)
 	return exec.OnError(step, next)
 }
 
+func (factory *stepperFactory) buildOnTimeoutStep(build db.Build, plan atc.Plan) exec.Step {
+	plan.OnTimeout.Step.Attempts = plan.Attempts
+	step := factory.buildStep(build, plan.OnTimeout.Step)
+	plan.OnTimeout.Next.Attempts = plan.Attempts
+	next := factory.buildHookStep(build, plan.OnTimeout.Next, plan.OnTimeout.Timeout)
+	return exec.OnTimeout(step, next)
+}
+
 func (factory *stepperFactory) buildOnSuccessStep(build db.Build, plan atc.Plan) exec.Step {
 	plan.OnSuccess.Step.Attempts = plan.Attempts
 	step := factory.buildStep(build, plan.OnSuccess.Step)
 	plan.OnSuccess.Next.Attempts = plan.Attempts
-	next := factory.buildStep(build, plan.OnSuccess.Next)
+	next := factory.buildHookStep(build, plan.OnSuccess.Next, plan.OnSuccess.Timeout)
 	return exec.OnSuccess(step, next)
 }
 
@@ -253,7 +343,7 @@ func (factory *stepperFactory) buildOnFailureStep(build db.Build, plan atc.Plan)
 	plan.OnFailure.Step.Attempts = plan.Attempts
 	step := factory.buildStep(build, plan.OnFailure.Step)
 	plan.OnFailure.Next.Attempts = plan.Attempts
-	next := factory.buildStep(build, plan.OnFailure.Next)
+	next := factory.buildHookStep(build, plan.OnFailure.Next, plan.OnFailure.Timeout)
 	return exec.OnFailure(step, next)
 }
 
@@ -261,21 +351,44 @@ func (factory *stepperFactory) buildEnsureStep(build db.Build, plan atc.Plan) ex
 	plan.Ensure.Step.Attempts = plan.Attempts
 	step := factory.buildStep(build, plan.Ensure.Step)
 	plan.Ensure.Next.Attempts = plan.Attempts
-	next := factory.buildStep(build, plan.Ensure.Next)
+	next := factory.buildHookStep(build, plan.Ensure.Next, plan.Ensure.Timeout)
 	return exec.Ensure(step, next)
 }
 
+func (factory *stepperFactory) buildHookStep(build db.Build, plan atc.Plan, timeout string) exec.Step {
+	return exec.HookTimeout(
+		factory.buildStep(build, plan),
+		timeout,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
 func (factory *stepperFactory) buildRetryStep(build db.Build, plan atc.Plan) exec.Step {
 	steps := []exec.Step{}
 
-	for index, innerPlan := range *plan.Retry {
+	for index, innerPlan := range plan.Retry.Steps {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
 
 		step := factory.buildStep(build, innerPlan)
 		steps = append(steps, step)
 	}
 
-	return exec.Retry(steps...)
+	return exec.Retry(plan.Retry.Backoff, plan.Retry.RetryOn, steps...)
+}
+
+func (factory *stepperFactory) buildWhileStep(build db.Build, plan atc.Plan) exec.Step {
+	steps := []exec.Step{}
+
+	for _, innerPlan := range plan.While.Steps {
+		innerPlan.Attempts = plan.Attempts
+
+		step := factory.buildStep(build, innerPlan)
+		steps = append(steps, step)
+	}
+
+	whileStep := exec.While(plan.While.Var, steps...)
+
+	return exec.LogError(whileStep, factory.buildDelegateFactory(build, plan))
 }
 
 func (factory *stepperFactory) buildGetStep(build db.Build, plan atc.Plan) exec.Step {
@@ -421,6 +534,20 @@ func (factory *stepperFactory) buildLoadVarStep(build db.Build, plan atc.Plan) e
 	)
 }
 
+func (factory *stepperFactory) buildNotifyStep(build db.Build, plan atc.Plan) exec.Step {
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.NotifyStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
 func (factory *stepperFactory) buildArtifactInputStep(build db.Build, plan atc.Plan) exec.Step {
 	return factory.coreFactory.ArtifactInputStep(
 		plan,
@@ -485,6 +612,7 @@ func (factory *stepperFactory) stepMetadata(
 		PipelineName:         build.PipelineName(),
 		PipelineInstanceVars: build.PipelineInstanceVars(),
 		ExternalURL:          externalURL,
+		EnvAllowlist:         factory.metadataEnvAllowlist,
 	}
 	if exposeBuildCreatedBy && build.CreatedBy() != nil {
 		meta.CreatedBy = *build.CreatedBy()
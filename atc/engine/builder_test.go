@@ -1,6 +1,8 @@
 package engine_test
 
 import (
+	"context"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -8,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/enginefakes"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -40,7 +43,10 @@ var _ = Describe("Builder", func() {
 			stepperFactory = engine.NewStepperFactory(
 				fakeCoreStepFactory,
 				"http://example.com",
+				nil,
+				nil,
 				fakeRateLimiter,
+				nil,
 				fakePolicyChecker,
 				fakeWorkerFactory,
 				fakeResourceCacheFactory,
@@ -247,7 +253,7 @@ var _ = Describe("Builder", func() {
 									Next: otherDependentGetPlan,
 								}),
 							},
-							Limit:    1,
+							Limit:    &atc.InParallelLimit{Limit: 1},
 							FailFast: true,
 						})
 					})
@@ -315,15 +321,17 @@ var _ = Describe("Builder", func() {
 						})
 
 						retryPlanTwo = planFactory.NewPlan(atc.RetryPlan{
-							taskPlan,
-							taskPlan,
+							Steps: []atc.Plan{
+								taskPlan,
+								taskPlan,
+							},
 						})
 
 						inParallelPlan = planFactory.NewPlan(atc.InParallelPlan{Steps: []atc.Plan{retryPlanTwo}})
 
 						parallelPlan = planFactory.NewPlan(atc.InParallelPlan{
 							Steps:    []atc.Plan{inParallelPlan},
-							Limit:    1,
+							Limit:    &atc.InParallelLimit{Limit: 1},
 							FailFast: true,
 						})
 
@@ -335,14 +343,16 @@ var _ = Describe("Builder", func() {
 						})
 
 						expectedPlan = planFactory.NewPlan(atc.RetryPlan{
-							getPlan,
-							timeoutPlan,
-							getPlan,
+							Steps: []atc.Plan{
+								getPlan,
+								timeoutPlan,
+								getPlan,
+							},
 						})
 					})
 
 					It("constructs the retry correctly", func() {
-						Expect(*expectedPlan.Retry).To(HaveLen(3))
+						Expect(expectedPlan.Retry.Steps).To(HaveLen(3))
 					})
 
 					It("constructs the first get correctly", func() {
@@ -386,7 +396,7 @@ var _ = Describe("Builder", func() {
 					})
 
 					It("constructs nested retries correctly", func() {
-						Expect(*retryPlanTwo.Retry).To(HaveLen(2))
+						Expect(retryPlanTwo.Retry.Steps).To(HaveLen(2))
 					})
 
 					It("constructs nested steps correctly", func() {
@@ -472,7 +482,9 @@ var _ = Describe("Builder", func() {
 						})
 
 						expectedPlan = planFactory.NewPlan(atc.RetryPlan{
-							ensurePlan,
+							Steps: []atc.Plan{
+								ensurePlan,
+							},
 						})
 					})
 
@@ -553,6 +565,82 @@ var _ = Describe("Builder", func() {
 						})
 					})
 
+					Context("when the build is paused", func() {
+						var (
+							fakeTaskStep *execfakes.FakeStep
+							fakeNotifier *dbfakes.FakeNotifier
+							resumed      chan struct{}
+						)
+
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.TaskPlan{
+								Name:       "some-task",
+								ConfigPath: "some-input/build.yml",
+							})
+
+							fakeTaskStep = new(execfakes.FakeStep)
+							fakeTaskStep.RunReturns(true, nil)
+							fakeCoreStepFactory.TaskStepReturns(fakeTaskStep)
+
+							resumed = make(chan struct{}, 1)
+							fakeNotifier = new(dbfakes.FakeNotifier)
+							fakeNotifier.NotifyReturns(resumed)
+							fakeBuild.ResumeNotifierReturns(fakeNotifier, nil)
+						})
+
+						It("waits for the build to be resumed before running the step", func() {
+							stepper, err := stepperFactory.StepperForBuild(fakeBuild)
+							Expect(err).ToNot(HaveOccurred())
+
+							step := stepper(expectedPlan)
+
+							done := make(chan bool, 1)
+							go func() {
+								defer GinkgoRecover()
+
+								ok, err := step.Run(context.Background(), nil)
+								Expect(err).ToNot(HaveOccurred())
+								done <- ok
+							}()
+
+							Consistently(fakeTaskStep.RunCallCount).Should(BeZero())
+
+							resumed <- struct{}{}
+							Eventually(done).Should(Receive(BeTrue()))
+							Expect(fakeTaskStep.RunCallCount()).To(Equal(1))
+							Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+						})
+
+						It("aborts without running the step", func() {
+							stepper, err := stepperFactory.StepperForBuild(fakeBuild)
+							Expect(err).ToNot(HaveOccurred())
+
+							ctx, cancel := context.WithCancel(context.Background())
+							cancel()
+
+							_, err = stepper(expectedPlan).Run(ctx, nil)
+							Expect(err).To(Equal(context.Canceled))
+							Expect(fakeTaskStep.RunCallCount()).To(BeZero())
+						})
+
+						It("does not run the step once the build has been aborted, even if it is not paused", func() {
+							close(resumed)
+
+							stepper, err := stepperFactory.StepperForBuild(fakeBuild)
+							Expect(err).ToNot(HaveOccurred())
+
+							ctx, cancel := context.WithCancel(context.Background())
+							cancel()
+
+							for i := 0; i < 10; i++ {
+								_, err = stepper(expectedPlan).Run(ctx, nil)
+								Expect(err).To(Equal(context.Canceled))
+							}
+
+							Expect(fakeTaskStep.RunCallCount()).To(BeZero())
+						})
+					})
+
 					Context("that contains a run step", func() {
 						BeforeEach(func() {
 							expectedPlan = planFactory.NewPlan(atc.RunPlan{
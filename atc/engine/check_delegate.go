@@ -18,7 +18,13 @@ import (
 
 //counterfeiter:generate . RateLimiter
 type RateLimiter interface {
+	// Wait blocks until a periodic check may run.
 	Wait(context.Context) error
+
+	// WaitPriority lets a manually triggered check run ahead of the periodic
+	// checks blocked in Wait. The check still counts against the limit, so
+	// the periodic checks make room for it rather than the other way around.
+	WaitPriority(context.Context) error
 }
 
 func NewCheckDelegate(
@@ -27,6 +33,7 @@ func NewCheckDelegate(
 	state exec.RunState,
 	clock clock.Clock,
 	limiter RateLimiter,
+	teamLimiter TeamRateLimiter,
 	policyChecker policy.Checker,
 ) exec.CheckDelegate {
 	return &checkDelegate{
@@ -37,7 +44,8 @@ func NewCheckDelegate(
 		eventOrigin: event.Origin{ID: event.OriginID(plan.ID)},
 		clock:       clock,
 
-		limiter: limiter,
+		limiter:     limiter,
+		teamLimiter: teamLimiter,
 	}
 }
 
@@ -55,7 +63,8 @@ type checkDelegate struct {
 	cachedResourceType db.ResourceType
 	cachedPrototype    db.Prototype
 
-	limiter RateLimiter
+	limiter     RateLimiter
+	teamLimiter TeamRateLimiter
 }
 
 func (d *checkDelegate) Initializing(logger lager.Logger) {
@@ -72,6 +81,20 @@ func (d *checkDelegate) Initializing(logger lager.Logger) {
 	logger.Info("initializing")
 }
 
+func (d *checkDelegate) CheckTimedOut(logger lager.Logger, timeout time.Duration) {
+	err := d.build.SaveEvent(event.CheckTimeout{
+		Origin:   d.eventOrigin,
+		Time:     d.clock.Now().Unix(),
+		Duration: timeout.String(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-check-timeout-event", err)
+		return
+	}
+
+	logger.Info("timed-out", lager.Data{"timeout": timeout.String()})
+}
+
 func (d *checkDelegate) FindOrCreateScope(config db.ResourceConfig) (db.ResourceConfigScope, error) {
 	resource, _, err := d.resource()
 	if err != nil {
@@ -96,16 +119,46 @@ func (d *checkDelegate) FindOrCreateScope(config db.ResourceConfig) (db.Resource
 func (d *checkDelegate) WaitToRun(ctx context.Context, scope db.ResourceConfigScope) (lock.Lock, bool, error) {
 	logger := lagerctx.FromContext(ctx)
 
-	if !d.plan.SkipInterval {
+	if d.plan.SkipInterval {
+		if d.plan.Resource != "" {
+			// manually triggered checks (fly check-resource, webhooks) skip the
+			// queue of periodic checks, as someone is waiting on them.
+			err := d.limiter.WaitPriority(ctx)
+			if err != nil {
+				return nil, false, fmt.Errorf("rate limit: %w", err)
+			}
+		}
+	} else {
 		if d.plan.Interval.Never == true {
 			// exit early if user specified to never run periodic checks
 			return nil, false, nil
 		} else if d.plan.Resource != "" {
+			resource, _, err := d.resource()
+			if err != nil {
+				return nil, false, fmt.Errorf("get resource: %w", err)
+			}
+
+			if resource.ChecksPaused() {
+				// exit early if periodic checks of the resource are paused; it can
+				// still be checked manually
+				return nil, false, nil
+			}
+
 			// rate limit periodic resource checks so worker load (plus load on
 			// external services) isn't too spiky. note that we don't rate limit
 			// resource type or prototype checks, because they are created every time a
 			// resource is used (rather than periodically).
-			err := d.limiter.Wait(ctx)
+			//
+			// the team's limit is waited on first so that a throttled team doesn't
+			// hold up the cluster-wide budget while it waits.
+			if d.teamLimiter != nil {
+				err := d.teamLimiter.Wait(ctx, d.build.TeamName())
+				if err != nil {
+					return nil, false, fmt.Errorf("team rate limit: %w", err)
+				}
+			}
+
+			err = d.limiter.Wait(ctx)
 			if err != nil {
 				return nil, false, fmt.Errorf("rate limit: %w", err)
 			}
@@ -135,7 +188,7 @@ func (d *checkDelegate) WaitToRun(ctx context.Context, scope db.ResourceConfigSc
 			} else {
 				// For periodic checks, if the current time is before the end of the last
 				// check + the interval, do not run
-				if d.clock.Now().Before(lastCheck.EndTime.Add(interval)) {
+				if d.clock.Now().Before(lastCheck.EndTime.Add(atc.JitterCheckInterval(interval, scope.ID(), lastCheck.EndTime))) {
 					return nil, false, nil
 				}
 			}
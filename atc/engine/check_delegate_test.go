@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/enginefakes"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/vars"
@@ -24,10 +25,11 @@ import (
 
 var _ = Describe("CheckDelegate", func() {
 	var (
-		fakeBuild         *dbfakes.FakeBuild
-		fakeClock         *fakeclock.FakeClock
-		fakeRateLimiter   *enginefakes.FakeRateLimiter
-		fakePolicyChecker *policyfakes.FakeChecker
+		fakeBuild           *dbfakes.FakeBuild
+		fakeClock           *fakeclock.FakeClock
+		fakeRateLimiter     *enginefakes.FakeRateLimiter
+		fakeTeamRateLimiter *enginefakes.FakeTeamRateLimiter
+		fakePolicyChecker   *policyfakes.FakeChecker
 
 		state exec.RunState
 
@@ -44,6 +46,7 @@ var _ = Describe("CheckDelegate", func() {
 		fakeBuild = new(dbfakes.FakeBuild)
 		fakeClock = fakeclock.NewFakeClock(now)
 		fakeRateLimiter = new(enginefakes.FakeRateLimiter)
+		fakeTeamRateLimiter = new(enginefakes.FakeTeamRateLimiter)
 		credVars := vars.StaticVariables{
 			"source-param": "super-secret-source",
 			"git-key":      "{\n123\n456\n789\n}\n",
@@ -60,13 +63,28 @@ var _ = Describe("CheckDelegate", func() {
 		fakeBuild.NameReturns(db.CheckBuildName)
 		fakeBuild.ResourceIDReturns(88)
 
-		delegate = engine.NewCheckDelegate(fakeBuild, plan, state, fakeClock, fakeRateLimiter, fakePolicyChecker)
+		delegate = engine.NewCheckDelegate(fakeBuild, plan, state, fakeClock, fakeRateLimiter, fakeTeamRateLimiter, fakePolicyChecker)
 
 		fakeResourceConfig = new(dbfakes.FakeResourceConfig)
 		fakeResourceConfigScope = new(dbfakes.FakeResourceConfigScope)
 		fakeResourceConfig.FindOrCreateScopeReturns(fakeResourceConfigScope, nil)
 	})
 
+	Describe("CheckTimedOut", func() {
+		JustBeforeEach(func() {
+			delegate.CheckTimedOut(lager.NewLogger("test"), time.Minute)
+		})
+
+		It("saves a check-timeout event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.CheckTimeout{
+				Origin:   event.Origin{ID: "some-plan-id"},
+				Time:     now.Unix(),
+				Duration: "1m0s",
+			}))
+		})
+	})
+
 	Describe("FindOrCreateScope", func() {
 		var saveErr error
 		var scope db.ResourceConfigScope
@@ -180,14 +198,45 @@ var _ = Describe("CheckDelegate", func() {
 
 		Context("when running for a resource", func() {
 			var fakeLock *lockfakes.FakeLock
+			var fakeResource *dbfakes.FakeResource
 
 			BeforeEach(func() {
 				plan.Check.Resource = "some-resource"
 
+				fakePipeline := new(dbfakes.FakePipeline)
+				fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+				fakeResource = new(dbfakes.FakeResource)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+
 				fakeLock = new(lockfakes.FakeLock)
 				fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
 			})
 
+			Context("when the resource's checks are paused", func() {
+				BeforeEach(func() {
+					fakeResource.ChecksPausedReturns(true)
+				})
+
+				It("returns false without rate limiting", func() {
+					Expect(runErr).ToNot(HaveOccurred())
+					Expect(run).To(BeFalse())
+					Expect(fakeRateLimiter.WaitCallCount()).To(Equal(0))
+					Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(0))
+				})
+
+				Context("when the check is manually triggered", func() {
+					BeforeEach(func() {
+						plan.Check.SkipInterval = true
+					})
+
+					It("runs", func() {
+						Expect(runErr).ToNot(HaveOccurred())
+						Expect(run).To(BeTrue())
+					})
+				})
+			})
+
 			It("returns a lock", func() {
 				Expect(runLock).To(Equal(fakeLock))
 			})
@@ -205,13 +254,54 @@ var _ = Describe("CheckDelegate", func() {
 				})
 			})
 
+			Context("before rate limiting", func() {
+				BeforeEach(func() {
+					fakeBuild.TeamNameReturns("some-team")
+					fakeTeamRateLimiter.WaitStub = func(context.Context, string) error {
+						Expect(fakeRateLimiter.WaitCallCount()).To(Equal(0))
+						return nil
+					}
+				})
+
+				It("rate limits by team", func() {
+					Expect(fakeTeamRateLimiter.WaitCallCount()).To(Equal(1))
+					_, teamName := fakeTeamRateLimiter.WaitArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+				})
+			})
+
+			Context("when waiting on the team's rate limit fails", func() {
+				BeforeEach(func() {
+					fakeTeamRateLimiter.WaitReturns(context.Canceled)
+				})
+
+				It("returns the error without running", func() {
+					Expect(runErr).To(MatchError(context.Canceled))
+					Expect(run).To(BeFalse())
+					Expect(fakeRateLimiter.WaitCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the check plan is configured to skip interval", func() {
 				BeforeEach(func() {
 					plan.Check.SkipInterval = true
 				})
 
-				It("does not rate limit", func() {
+				It("rate limits ahead of periodic checks", func() {
+					Expect(fakeRateLimiter.WaitPriorityCallCount()).To(Equal(1))
 					Expect(fakeRateLimiter.WaitCallCount()).To(Equal(0))
+					Expect(fakeTeamRateLimiter.WaitCallCount()).To(Equal(0))
+				})
+
+				Context("when rate limiting fails", func() {
+					BeforeEach(func() {
+						fakeRateLimiter.WaitPriorityReturns(context.Canceled)
+					})
+
+					It("returns the error without running", func() {
+						Expect(runErr).To(MatchError(context.Canceled))
+						Expect(run).To(BeFalse())
+					})
 				})
 
 				Context("when fail to get scope last start time", func() {
@@ -335,6 +425,30 @@ var _ = Describe("CheckDelegate", func() {
 					})
 				})
 
+				Context("when the interval has elapsed but the jitter has not", func() {
+					BeforeEach(func() {
+						atc.CheckIntervalJitter = time.Hour
+						fakeResourceConfigScope.IDReturns(42)
+
+						lastCheckEnd := now.Add(-(interval + 1))
+						Expect(atc.JitterCheckInterval(interval, 42, lastCheckEnd)).To(BeNumerically(">", interval+1))
+
+						fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+							StartTime: now.Add(-(interval + 10)),
+							EndTime:   lastCheckEnd,
+							Succeeded: true,
+						}, nil)
+					})
+
+					AfterEach(func() {
+						atc.CheckIntervalJitter = 0
+					})
+
+					It("returns false", func() {
+						Expect(run).To(BeFalse())
+					})
+				})
+
 				Context("when the last check value gets updated after the first loop of attempting to acquire lock", func() {
 					BeforeEach(func() {
 						fakeResourceConfigScope.LastCheckReturnsOnCall(0, db.LastCheck{
@@ -389,6 +503,7 @@ var _ = Describe("CheckDelegate", func() {
 
 			It("does not rate limit", func() {
 				Expect(fakeRateLimiter.WaitCallCount()).To(Equal(0))
+				Expect(fakeRateLimiter.WaitPriorityCallCount()).To(Equal(0))
 			})
 
 			It("does not acquire a lock", func() {
@@ -14,7 +14,9 @@ type DelegateFactory struct {
 	build                  db.Build
 	plan                   atc.Plan
 	rateLimiter            RateLimiter
+	teamRateLimiter        TeamRateLimiter
 	policyChecker          policy.Checker
+	setPipelineAcrossTeams []string
 	dbWorkerFactory        db.WorkerFactory
 	lockFactory            lock.LockFactory
 	dbResourceCacheFactory db.ResourceCacheFactory
@@ -37,7 +39,7 @@ func (delegate DelegateFactory) RunDelegate(state exec.RunState) exec.RunDelegat
 }
 
 func (delegate DelegateFactory) CheckDelegate(state exec.RunState) exec.CheckDelegate {
-	return NewCheckDelegate(delegate.build, delegate.plan, state, clock.NewClock(), delegate.rateLimiter, delegate.policyChecker)
+	return NewCheckDelegate(delegate.build, delegate.plan, state, clock.NewClock(), delegate.rateLimiter, delegate.teamRateLimiter, delegate.policyChecker)
 }
 
 func (delegate DelegateFactory) BuildStepDelegate(state exec.RunState) exec.BuildStepDelegate {
@@ -45,5 +47,9 @@ func (delegate DelegateFactory) BuildStepDelegate(state exec.RunState) exec.Buil
 }
 
 func (delegate DelegateFactory) SetPipelineStepDelegate(state exec.RunState) exec.SetPipelineStepDelegate {
-	return NewSetPipelineStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
+	return NewSetPipelineStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.setPipelineAcrossTeams)
+}
+
+func (delegate DelegateFactory) NotifyDelegate(state exec.RunState) exec.NotifyDelegate {
+	return NewNotifyDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
 }
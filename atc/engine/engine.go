@@ -26,6 +26,7 @@ func NewEngine(
 	stepperFactory StepperFactory,
 	secrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	traceDir string,
 ) Engine {
 	return Engine{
 		stepperFactory: stepperFactory,
@@ -35,6 +36,7 @@ func NewEngine(
 
 		globalSecrets: secrets,
 		varSourcePool: varSourcePool,
+		traceDir:      traceDir,
 	}
 }
 
@@ -46,6 +48,7 @@ type Engine struct {
 
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
+	traceDir      string
 }
 
 func (engine Engine) Drain(ctx context.Context) {
@@ -67,6 +70,7 @@ func (engine Engine) NewBuild(build db.Build) builds.Runnable {
 		engine.stepperFactory,
 		engine.globalSecrets,
 		engine.varSourcePool,
+		engine.traceDir,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -78,6 +82,7 @@ func NewBuild(
 	builder StepperFactory,
 	globalSecrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	traceDir string,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -88,6 +93,7 @@ func NewBuild(
 
 		globalSecrets: globalSecrets,
 		varSourcePool: varSourcePool,
+		traceDir:      traceDir,
 
 		release:       release,
 		trackedStates: trackedStates,
@@ -101,6 +107,7 @@ type engineBuild struct {
 
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
+	traceDir      string
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -183,6 +190,21 @@ func (b *engineBuild) Run(ctx context.Context) {
 	}
 	defer b.clearRunState()
 
+	var tracker *resumeTracker
+	if b.build.JobID() != 0 {
+		tracker, err = newResumeTracker(b.build)
+		if err != nil {
+			logger.Error("failed-to-resume-build", err)
+
+			b.buildStepErrored(logger, err.Error())
+			b.finish(logger.Session("finish"), err, false)
+
+			return
+		}
+
+		ctx = withResumeTracker(ctx, tracker)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 
 	noleak := make(chan bool)
@@ -222,6 +244,12 @@ func (b *engineBuild) Run(ctx context.Context) {
 			return
 		}
 
+		b.saveTrace(logger)
+
+		if tracker != nil && !succeeded && !errors.Is(runErr, context.Canceled) {
+			tracker.keepArtifacts(logger, b.build)
+		}
+
 		b.finish(logger.Session("finish"), runErr, succeeded)
 	}
 }
@@ -244,10 +272,14 @@ func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
 		b.saveStatus(logger, atc.StatusAborted)
 		logger.Info("aborted")
 
+		b.requeuePreempted(logger)
+
 	} else if err != nil {
 		b.saveStatus(logger, atc.StatusErrored)
 		logger.Info("errored", lager.Data{"error": err.Error()})
 
+		b.autoRerun(logger, err)
+
 	} else if succeeded {
 		b.saveStatus(logger, atc.StatusSucceeded)
 		logger.Info("succeeded")
@@ -258,6 +290,110 @@ func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
 	}
 }
 
+// autoRerun creates a rerun of an errored build if its job is configured to
+// automatically rerun builds which errored for the same reason.
+func (b *engineBuild) autoRerun(logger lager.Logger, err error) {
+	triggers := exec.AutoRerunTriggers(err)
+	if len(triggers) == 0 || b.build.JobID() == 0 {
+		return
+	}
+
+	job, found, err := b.build.Job()
+	if err != nil {
+		logger.Error("failed-to-find-job", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	config, err := job.Config()
+	if err != nil {
+		logger.Error("failed-to-get-job-config", err)
+		return
+	}
+
+	if config.AutoRerun == nil {
+		return
+	}
+
+	for _, trigger := range triggers {
+		if !config.AutoRerun.RerunsOn(trigger) {
+			continue
+		}
+
+		var delay time.Duration
+		if config.AutoRerun.Delay != "" {
+			delay, err = time.ParseDuration(config.AutoRerun.Delay)
+			if err != nil {
+				logger.Error("failed-to-parse-auto-rerun-delay", err)
+				return
+			}
+		}
+
+		rerun, created, err := job.AutoRerunBuild(b.build, string(trigger), delay, config.AutoRerun.Attempts)
+		if err != nil {
+			logger.Error("failed-to-auto-rerun-build", err)
+			return
+		}
+
+		if !created {
+			logger.Info("auto-rerun-attempts-exhausted", lager.Data{"reason": trigger})
+			return
+		}
+
+		logger.Info("auto-rerun", lager.Data{
+			"reason":         trigger,
+			"rerun-build-id": rerun.ID(),
+			"delay":          delay.String(),
+		})
+
+		return
+	}
+}
+
+// requeuePreempted reruns a build which was aborted to make room for a build
+// of a higher-priority job, so that it runs again once there is room for it.
+func (b *engineBuild) requeuePreempted(logger lager.Logger) {
+	if b.build.JobID() == 0 {
+		return
+	}
+
+	// the build is preempted while it is running, so it must be reloaded to
+	// know whether it was aborted by a user or preempted
+	found, err := b.build.Reload()
+	if err != nil {
+		logger.Error("failed-to-reload-build", err)
+		return
+	}
+
+	if !found || b.build.PreemptedBy() == 0 {
+		return
+	}
+
+	job, found, err := b.build.Job()
+	if err != nil {
+		logger.Error("failed-to-find-job", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	rerun, _, err := job.AutoRerunBuild(b.build, db.PreemptedRerunReason, 0, 0)
+	if err != nil {
+		logger.Error("failed-to-requeue-preempted-build", err)
+		return
+	}
+
+	logger.Info("requeued-preempted-build", lager.Data{
+		"preempted-by":   b.build.PreemptedBy(),
+		"rerun-build-id": rerun.ID(),
+	})
+}
+
 func (b *engineBuild) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	if err := b.build.Finish(db.BuildStatus(status)); err != nil {
 		logger.Error("failed-to-finish-build", err)
@@ -311,6 +447,13 @@ func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.
 	if err != nil {
 		return nil, err
 	}
+
+	if b.traceDir != "" {
+		trace, _ := b.trackedStates.LoadOrStore(b.traceID(), exec.NewRunStateTrace())
+		state, _ := b.trackedStates.LoadOrStore(id, exec.NewTracingRunState(stepper, credVars, atc.EnableRedactSecrets, trace.(*exec.RunStateTrace)))
+		return state.(exec.RunState), nil
+	}
+
 	state, _ := b.trackedStates.LoadOrStore(id, exec.NewRunState(stepper, credVars, atc.EnableRedactSecrets))
 	return state.(exec.RunState), nil
 }
@@ -318,4 +461,24 @@ func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.
 func (b *engineBuild) clearRunState() {
 	id := fmt.Sprintf("build:%v", b.build.ID())
 	b.trackedStates.Delete(id)
+	b.trackedStates.Delete(b.traceID())
+}
+
+func (b *engineBuild) traceID() string {
+	return fmt.Sprintf("build-trace:%v", b.build.ID())
+}
+
+func (b *engineBuild) saveTrace(logger lager.Logger) {
+	trace, ok := b.trackedStates.Load(b.traceID())
+	if !ok {
+		return
+	}
+
+	err := writeBuildTrace(b.traceDir, b.build, trace.(*exec.RunStateTrace))
+	if err != nil {
+		logger.Error("failed-to-save-build-trace", err)
+		return
+	}
+
+	logger.Info("saved-build-trace", lager.Data{"path": BuildTracePath(b.traceDir, b.build.ID())})
 }
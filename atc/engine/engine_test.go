@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"sync"
 	"time"
 
@@ -53,7 +55,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool)
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool, "")
 		})
 
 		JustBeforeEach(func() {
@@ -67,22 +69,28 @@ var _ = Describe("Engine", func() {
 
 	Describe("Build", func() {
 		var (
-			build     builds.Runnable
-			release   chan bool
-			waitGroup *sync.WaitGroup
+			build         builds.Runnable
+			release       chan bool
+			trackedStates *sync.Map
+			waitGroup     *sync.WaitGroup
+			traceDir      string
 		)
 
 		BeforeEach(func() {
 
 			release = make(chan bool)
-			trackedStates := new(sync.Map)
+			trackedStates = new(sync.Map)
 			waitGroup = new(sync.WaitGroup)
+			traceDir = ""
+		})
 
+		JustBeforeEach(func() {
 			build = NewBuild(
 				fakeBuild,
 				fakeStepperFactory,
 				fakeGlobalCreds,
 				fakeVarSourcePool,
+				traceDir,
 				release,
 				trackedStates,
 				waitGroup,
@@ -248,6 +256,54 @@ var _ = Describe("Engine", func() {
 									})
 								})
 
+								Context("when a trace dir is configured", func() {
+									BeforeEach(func() {
+										var err error
+										traceDir, err = ioutil.TempDir("", "build-traces")
+										Expect(err).ToNot(HaveOccurred())
+
+										fakeBuild.IDReturns(128)
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											_, _, err := state.Get(vars.Reference{Path: "foo"})
+											return true, err
+										}
+									})
+
+									AfterEach(func() {
+										os.RemoveAll(traceDir)
+									})
+
+									It("saves a trace of the build's var lookups", func() {
+										waitGroup.Wait()
+
+										trace, err := LoadBuildTrace(BuildTracePath(traceDir, 128))
+										Expect(err).ToNot(HaveOccurred())
+										Expect(trace.Build.ID).To(Equal(128))
+										Expect(trace.Plan).To(Equal(fakeBuild.PrivatePlan()))
+										Expect(trace.State.Events).To(ConsistOf(exec.RunStateTraceEvent{
+											Type:  exec.RunStateTraceGetVar,
+											Var:   &vars.Reference{Path: "foo"},
+											Value: "bar",
+											Found: true,
+										}))
+									})
+								})
+
+								Context("when the build resumes from a build which no longer exists", func() {
+									BeforeEach(func() {
+										fakeBuild.JobIDReturns(1)
+										fakeBuild.ResumedFromReturns(1)
+										fakeBuild.ResumedFromBuildReturns(nil, false, nil)
+									})
+
+									It("errors the build without running it", func() {
+										waitGroup.Wait()
+										Expect(fakeStep.RunCallCount()).To(BeZero())
+										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+									})
+								})
+
 								Context("when the build finishes woefully", func() {
 									BeforeEach(func() {
 										fakeStep.RunReturns(false, nil)
@@ -272,6 +328,72 @@ var _ = Describe("Engine", func() {
 									})
 								})
 
+								Context("when the build errors because its image could not be fetched", func() {
+									var fakeJob *dbfakes.FakeJob
+
+									BeforeEach(func() {
+										fakeStep.RunReturns(false, exec.ImageFetchError{Cause: errors.New("nope")})
+
+										fakeJob = new(dbfakes.FakeJob)
+										fakeJob.AutoRerunBuildReturns(new(dbfakes.FakeBuild), true, nil)
+
+										fakeBuild.JobIDReturns(1)
+										fakeBuild.JobReturns(fakeJob, true, nil)
+									})
+
+									Context("when the job reruns builds which failed to fetch images", func() {
+										BeforeEach(func() {
+											fakeJob.ConfigReturns(atc.JobConfig{
+												AutoRerun: &atc.AutoRerunConfig{
+													Attempts: 3,
+													Delay:    "1m",
+													On:       []atc.AutoRerunTrigger{atc.AutoRerunOnImageFetchFailed},
+												},
+											}, nil)
+										})
+
+										It("errors the build and reruns it", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+											Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+
+											Expect(fakeJob.AutoRerunBuildCallCount()).To(Equal(1))
+											build, reason, delay, attempts := fakeJob.AutoRerunBuildArgsForCall(0)
+											Expect(build).To(Equal(fakeBuild))
+											Expect(reason).To(Equal("image_fetch_failed"))
+											Expect(delay).To(Equal(time.Minute))
+											Expect(attempts).To(Equal(3))
+										})
+									})
+
+									Context("when the job only reruns builds whose worker was lost", func() {
+										BeforeEach(func() {
+											fakeJob.ConfigReturns(atc.JobConfig{
+												AutoRerun: &atc.AutoRerunConfig{
+													Attempts: 3,
+													On:       []atc.AutoRerunTrigger{atc.AutoRerunOnWorkerLost},
+												},
+											}, nil)
+										})
+
+										It("does not rerun the build", func() {
+											waitGroup.Wait()
+											Expect(fakeJob.AutoRerunBuildCallCount()).To(BeZero())
+										})
+									})
+
+									Context("when the job does not rerun builds", func() {
+										BeforeEach(func() {
+											fakeJob.ConfigReturns(atc.JobConfig{}, nil)
+										})
+
+										It("does not rerun the build", func() {
+											waitGroup.Wait()
+											Expect(fakeJob.AutoRerunBuildCallCount()).To(BeZero())
+										})
+									})
+								})
+
 								Context("when the build finishes with cancelled error", func() {
 									BeforeEach(func() {
 										fakeStep.RunReturns(false, context.Canceled)
@@ -284,6 +406,45 @@ var _ = Describe("Engine", func() {
 									})
 								})
 
+								Context("when the build was preempted", func() {
+									var fakeJob *dbfakes.FakeJob
+
+									BeforeEach(func() {
+										fakeStep.RunReturns(false, context.Canceled)
+
+										fakeJob = new(dbfakes.FakeJob)
+										fakeJob.AutoRerunBuildReturns(new(dbfakes.FakeBuild), true, nil)
+
+										fakeBuild.JobIDReturns(1)
+										fakeBuild.JobReturns(fakeJob, true, nil)
+										fakeBuild.PreemptedByReturns(42)
+									})
+
+									It("aborts the build and re-queues it", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
+
+										Expect(fakeJob.AutoRerunBuildCallCount()).To(Equal(1))
+										build, reason, delay, attempts := fakeJob.AutoRerunBuildArgsForCall(0)
+										Expect(build).To(Equal(fakeBuild))
+										Expect(reason).To(Equal(db.PreemptedRerunReason))
+										Expect(delay).To(BeZero())
+										Expect(attempts).To(BeZero())
+									})
+
+									Context("when the build was aborted by a user instead", func() {
+										BeforeEach(func() {
+											fakeBuild.PreemptedByReturns(0)
+										})
+
+										It("does not re-queue it", func() {
+											waitGroup.Wait()
+											Expect(fakeJob.AutoRerunBuildCallCount()).To(BeZero())
+										})
+									})
+								})
+
 								Context("when the build finishes with a wrapped cancelled error", func() {
 									BeforeEach(func() {
 										fakeStep.RunReturns(false, fmt.Errorf("but im not a wrapper: %w", context.Canceled))
@@ -76,6 +76,19 @@ type FakeCoreStepFactory struct {
 	loadVarStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	NotifyStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	notifyStepMutex       sync.RWMutex
+	notifyStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	notifyStepReturns struct {
+		result1 exec.Step
+	}
+	notifyStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	PutStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
 	putStepMutex       sync.RWMutex
 	putStepArgsForCall []struct {
@@ -117,6 +130,18 @@ type FakeCoreStepFactory struct {
 	setPipelineStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	TaskOutputVarsStepStub        func(atc.Plan, []atc.TaskOutputVarConfig) exec.Step
+	taskOutputVarsStepMutex       sync.RWMutex
+	taskOutputVarsStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 []atc.TaskOutputVarConfig
+	}
+	taskOutputVarsStepReturns struct {
+		result1 exec.Step
+	}
+	taskOutputVarsStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	TaskStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
 	taskStepMutex       sync.RWMutex
 	taskStepArgsForCall []struct {
@@ -411,6 +436,8 @@ func (fake *FakeCoreStepFactory) LoadVarStep(arg1 atc.Plan, arg2 exec.StepMetada
 func (fake *FakeCoreStepFactory) LoadVarStepCallCount() int {
 	fake.loadVarStepMutex.RLock()
 	defer fake.loadVarStepMutex.RUnlock()
+	fake.notifyStepMutex.RLock()
+	defer fake.notifyStepMutex.RUnlock()
 	return len(fake.loadVarStepArgsForCall)
 }
 
@@ -423,6 +450,8 @@ func (fake *FakeCoreStepFactory) LoadVarStepCalls(stub func(atc.Plan, exec.StepM
 func (fake *FakeCoreStepFactory) LoadVarStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
 	fake.loadVarStepMutex.RLock()
 	defer fake.loadVarStepMutex.RUnlock()
+	fake.notifyStepMutex.RLock()
+	defer fake.notifyStepMutex.RUnlock()
 	argsForCall := fake.loadVarStepArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
@@ -450,6 +479,69 @@ func (fake *FakeCoreStepFactory) LoadVarStepReturnsOnCall(i int, result1 exec.St
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) NotifyStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.notifyStepMutex.Lock()
+	ret, specificReturn := fake.notifyStepReturnsOnCall[len(fake.notifyStepArgsForCall)]
+	fake.notifyStepArgsForCall = append(fake.notifyStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.NotifyStepStub
+	fakeReturns := fake.notifyStepReturns
+	fake.recordInvocation("NotifyStep", []interface{}{arg1, arg2, arg3})
+	fake.notifyStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) NotifyStepCallCount() int {
+	fake.notifyStepMutex.RLock()
+	defer fake.notifyStepMutex.RUnlock()
+	return len(fake.notifyStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) NotifyStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.notifyStepMutex.Lock()
+	defer fake.notifyStepMutex.Unlock()
+	fake.NotifyStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) NotifyStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.notifyStepMutex.RLock()
+	defer fake.notifyStepMutex.RUnlock()
+	argsForCall := fake.notifyStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) NotifyStepReturns(result1 exec.Step) {
+	fake.notifyStepMutex.Lock()
+	defer fake.notifyStepMutex.Unlock()
+	fake.NotifyStepStub = nil
+	fake.notifyStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) NotifyStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.notifyStepMutex.Lock()
+	defer fake.notifyStepMutex.Unlock()
+	fake.NotifyStepStub = nil
+	if fake.notifyStepReturnsOnCall == nil {
+		fake.notifyStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.notifyStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) PutStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
 	fake.putStepMutex.Lock()
 	ret, specificReturn := fake.putStepReturnsOnCall[len(fake.putStepArgsForCall)]
@@ -641,6 +733,73 @@ func (fake *FakeCoreStepFactory) SetPipelineStepReturnsOnCall(i int, result1 exe
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) TaskOutputVarsStep(arg1 atc.Plan, arg2 []atc.TaskOutputVarConfig) exec.Step {
+	var arg2Copy []atc.TaskOutputVarConfig
+	if arg2 != nil {
+		arg2Copy = make([]atc.TaskOutputVarConfig, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.taskOutputVarsStepMutex.Lock()
+	ret, specificReturn := fake.taskOutputVarsStepReturnsOnCall[len(fake.taskOutputVarsStepArgsForCall)]
+	fake.taskOutputVarsStepArgsForCall = append(fake.taskOutputVarsStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 []atc.TaskOutputVarConfig
+	}{arg1, arg2Copy})
+	stub := fake.TaskOutputVarsStepStub
+	fakeReturns := fake.taskOutputVarsStepReturns
+	fake.recordInvocation("TaskOutputVarsStep", []interface{}{arg1, arg2Copy})
+	fake.taskOutputVarsStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) TaskOutputVarsStepCallCount() int {
+	fake.taskOutputVarsStepMutex.RLock()
+	defer fake.taskOutputVarsStepMutex.RUnlock()
+	return len(fake.taskOutputVarsStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) TaskOutputVarsStepCalls(stub func(atc.Plan, []atc.TaskOutputVarConfig) exec.Step) {
+	fake.taskOutputVarsStepMutex.Lock()
+	defer fake.taskOutputVarsStepMutex.Unlock()
+	fake.TaskOutputVarsStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) TaskOutputVarsStepArgsForCall(i int) (atc.Plan, []atc.TaskOutputVarConfig) {
+	fake.taskOutputVarsStepMutex.RLock()
+	defer fake.taskOutputVarsStepMutex.RUnlock()
+	argsForCall := fake.taskOutputVarsStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCoreStepFactory) TaskOutputVarsStepReturns(result1 exec.Step) {
+	fake.taskOutputVarsStepMutex.Lock()
+	defer fake.taskOutputVarsStepMutex.Unlock()
+	fake.TaskOutputVarsStepStub = nil
+	fake.taskOutputVarsStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) TaskOutputVarsStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.taskOutputVarsStepMutex.Lock()
+	defer fake.taskOutputVarsStepMutex.Unlock()
+	fake.TaskOutputVarsStepStub = nil
+	if fake.taskOutputVarsStepReturnsOnCall == nil {
+		fake.taskOutputVarsStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.taskOutputVarsStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) TaskStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
 	fake.taskStepMutex.Lock()
 	ret, specificReturn := fake.taskStepReturnsOnCall[len(fake.taskStepArgsForCall)]
@@ -718,12 +877,16 @@ func (fake *FakeCoreStepFactory) Invocations() map[string][][]interface{} {
 	defer fake.getStepMutex.RUnlock()
 	fake.loadVarStepMutex.RLock()
 	defer fake.loadVarStepMutex.RUnlock()
+	fake.notifyStepMutex.RLock()
+	defer fake.notifyStepMutex.RUnlock()
 	fake.putStepMutex.RLock()
 	defer fake.putStepMutex.RUnlock()
 	fake.runStepMutex.RLock()
 	defer fake.runStepMutex.RUnlock()
 	fake.setPipelineStepMutex.RLock()
 	defer fake.setPipelineStepMutex.RUnlock()
+	fake.taskOutputVarsStepMutex.RLock()
+	defer fake.taskOutputVarsStepMutex.RUnlock()
 	fake.taskStepMutex.RLock()
 	defer fake.taskStepMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
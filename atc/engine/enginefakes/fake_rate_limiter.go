@@ -20,6 +20,17 @@ type FakeRateLimiter struct {
 	waitReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WaitPriorityStub        func(context.Context) error
+	waitPriorityMutex       sync.RWMutex
+	waitPriorityArgsForCall []struct {
+		arg1 context.Context
+	}
+	waitPriorityReturns struct {
+		result1 error
+	}
+	waitPriorityReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -85,11 +96,74 @@ func (fake *FakeRateLimiter) WaitReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRateLimiter) WaitPriority(arg1 context.Context) error {
+	fake.waitPriorityMutex.Lock()
+	ret, specificReturn := fake.waitPriorityReturnsOnCall[len(fake.waitPriorityArgsForCall)]
+	fake.waitPriorityArgsForCall = append(fake.waitPriorityArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.WaitPriorityStub
+	fakeReturns := fake.waitPriorityReturns
+	fake.recordInvocation("WaitPriority", []interface{}{arg1})
+	fake.waitPriorityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRateLimiter) WaitPriorityCallCount() int {
+	fake.waitPriorityMutex.RLock()
+	defer fake.waitPriorityMutex.RUnlock()
+	return len(fake.waitPriorityArgsForCall)
+}
+
+func (fake *FakeRateLimiter) WaitPriorityCalls(stub func(context.Context) error) {
+	fake.waitPriorityMutex.Lock()
+	defer fake.waitPriorityMutex.Unlock()
+	fake.WaitPriorityStub = stub
+}
+
+func (fake *FakeRateLimiter) WaitPriorityArgsForCall(i int) context.Context {
+	fake.waitPriorityMutex.RLock()
+	defer fake.waitPriorityMutex.RUnlock()
+	argsForCall := fake.waitPriorityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRateLimiter) WaitPriorityReturns(result1 error) {
+	fake.waitPriorityMutex.Lock()
+	defer fake.waitPriorityMutex.Unlock()
+	fake.WaitPriorityStub = nil
+	fake.waitPriorityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRateLimiter) WaitPriorityReturnsOnCall(i int, result1 error) {
+	fake.waitPriorityMutex.Lock()
+	defer fake.waitPriorityMutex.Unlock()
+	fake.WaitPriorityStub = nil
+	if fake.waitPriorityReturnsOnCall == nil {
+		fake.waitPriorityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitPriorityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRateLimiter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.waitMutex.RLock()
 	defer fake.waitMutex.RUnlock()
+	fake.waitPriorityMutex.RLock()
+	defer fake.waitPriorityMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -87,6 +87,61 @@ func (d *getDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus,
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
 
+// VersionHistory returns up to depth enabled versions of the pipeline
+// resource, newest first, starting with the given version.
+func (d *getDelegate) VersionHistory(logger lager.Logger, resourceName string, version atc.Version, depth int) ([]atc.Version, error) {
+	pipeline, found, err := d.build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return nil, err
+	}
+
+	if !found {
+		return nil, exec.ErrPipelineNotFound{PipelineName: d.build.PipelineName()}
+	}
+
+	resource, found, err := pipeline.Resource(resourceName)
+	if err != nil {
+		logger.Error("failed-to-find-resource", err)
+		return nil, err
+	}
+
+	if !found {
+		return nil, exec.ErrResourceNotFound{ResourceName: resourceName}
+	}
+
+	current, _, _, err := resource.Versions(db.Page{Limit: 1}, version)
+	if err != nil {
+		logger.Error("failed-to-find-version", err)
+		return nil, err
+	}
+
+	versions := []atc.Version{version}
+	if len(current) == 0 {
+		return versions, nil
+	}
+
+	history, _, _, err := resource.Versions(db.Page{To: &current[0].ID, Limit: depth}, nil)
+	if err != nil {
+		logger.Error("failed-to-find-versions", err)
+		return nil, err
+	}
+
+	for _, rv := range history {
+		if len(versions) == depth {
+			break
+		}
+
+		if rv.ID == current[0].ID || !rv.Enabled {
+			continue
+		}
+
+		versions = append(versions, rv.Version)
+	}
+
+	return versions, nil
+}
+
 func (d *getDelegate) UpdateMetadata(log lager.Logger, resourceName string, resourceCache db.ResourceCache, info resource.VersionResult) {
 	logger := log.WithData(lager.Data{
 		"pipeline-name": d.build.PipelineName(),
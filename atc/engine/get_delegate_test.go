@@ -79,6 +79,88 @@ var _ = Describe("GetDelegate", func() {
 		})
 	})
 
+	Describe("VersionHistory", func() {
+		var (
+			versions []atc.Version
+			err      error
+		)
+
+		JustBeforeEach(func() {
+			versions, err = delegate.VersionHistory(logger, "some-resource", atc.Version{"v": "3"}, 3)
+		})
+
+		Context("when the pipeline is not found", func() {
+			BeforeEach(func() {
+				fakeBuild.PipelineReturns(nil, false, nil)
+				fakeBuild.PipelineNameReturns("some-pipeline")
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(Equal(exec.ErrPipelineNotFound{PipelineName: "some-pipeline"}))
+			})
+		})
+
+		Context("when the resource is not found", func() {
+			BeforeEach(func() {
+				fakeBuild.PipelineReturns(fakePipeline, true, nil)
+				fakePipeline.ResourceReturns(nil, false, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(Equal(exec.ErrResourceNotFound{ResourceName: "some-resource"}))
+			})
+		})
+
+		Context("when the resource is found", func() {
+			BeforeEach(func() {
+				fakeBuild.PipelineReturns(fakePipeline, true, nil)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			Context("when the version is not found", func() {
+				BeforeEach(func() {
+					fakeResource.VersionsReturns(nil, db.Pagination{}, true, nil)
+				})
+
+				It("returns only the version", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versions).To(Equal([]atc.Version{{"v": "3"}}))
+				})
+			})
+
+			Context("when the version is found", func() {
+				BeforeEach(func() {
+					fakeResource.VersionsReturnsOnCall(0, []atc.ResourceVersion{
+						{ID: 3, Version: atc.Version{"v": "3"}, Enabled: true},
+					}, db.Pagination{}, true, nil)
+					fakeResource.VersionsReturnsOnCall(1, []atc.ResourceVersion{
+						{ID: 3, Version: atc.Version{"v": "3"}, Enabled: true},
+						{ID: 2, Version: atc.Version{"v": "2"}, Enabled: false},
+						{ID: 1, Version: atc.Version{"v": "1"}, Enabled: true},
+					}, db.Pagination{}, true, nil)
+				})
+
+				It("pages back from the version", func() {
+					Expect(fakeResource.VersionsCallCount()).To(Equal(2))
+
+					page, filter := fakeResource.VersionsArgsForCall(0)
+					Expect(page).To(Equal(db.Page{Limit: 1}))
+					Expect(filter).To(Equal(atc.Version{"v": "3"}))
+
+					page, filter = fakeResource.VersionsArgsForCall(1)
+					Expect(*page.To).To(Equal(3))
+					Expect(page.Limit).To(Equal(3))
+					Expect(filter).To(BeNil())
+				})
+
+				It("returns the enabled versions, newest first", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versions).To(Equal([]atc.Version{{"v": "3"}, {"v": "1"}}))
+				})
+			})
+		})
+	})
+
 	Describe("UpdateMetadata", func() {
 		var dummyResourceCache db.ResourceCache
 		var resourceName string
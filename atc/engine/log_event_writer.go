@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"fmt"
 	"io"
 	"strings"
 	"unicode/utf8"
@@ -124,3 +125,67 @@ func (writer *dbEventWriterWithSecretRedaction) Close() error {
 	writer.Write(nil)
 	return nil
 }
+
+// newTruncatingWriter passes at most limit bytes through to writer, followed
+// by a marker noting that the rest of the output was dropped.
+func newTruncatingWriter(writer io.Writer, limit uint64) io.WriteCloser {
+	return &truncatingWriter{
+		writer: writer,
+		limit:  limit,
+	}
+}
+
+type truncatingWriter struct {
+	writer    io.Writer
+	limit     uint64
+	written   uint64
+	truncated bool
+}
+
+func (writer *truncatingWriter) Write(data []byte) (int, error) {
+	if writer.truncated {
+		return len(data), nil
+	}
+
+	remaining := writer.limit - writer.written
+	if uint64(len(data)) <= remaining {
+		n, err := writer.writer.Write(data)
+		writer.written += uint64(n)
+		return n, err
+	}
+
+	_, err := writer.writer.Write(data[:remaining])
+	if err != nil {
+		return 0, err
+	}
+
+	writer.written = writer.limit
+	writer.truncated = true
+
+	_, err = fmt.Fprintf(writer.writer, "\n[output truncated after %d bytes]\n", writer.limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+func (writer *truncatingWriter) Close() error {
+	if closer, ok := writer.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// discardWriter drops everything written to it. It implements io.Closer so
+// that it can stand in for the writers above.
+type discardWriter struct{}
+
+func (discardWriter) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (discardWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+func NewNotifyDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	state exec.RunState,
+	clock clock.Clock,
+	policyChecker policy.Checker,
+) *notifyDelegate {
+	return &notifyDelegate{
+		buildStepDelegate{
+			build:         build,
+			planID:        planID,
+			clock:         clock,
+			state:         state,
+			stdout:        nil,
+			stderr:        nil,
+			policyChecker: policyChecker,
+		},
+	}
+}
+
+type notifyDelegate struct {
+	buildStepDelegate
+}
+
+func (delegate *notifyDelegate) NotificationSent(logger lager.Logger, target string) {
+	err := delegate.build.SaveEvent(event.NotificationSent{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:   delegate.clock.Now().Unix(),
+		Target: target,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-notification-sent-event", err)
+		return
+	}
+
+	logger.Debug("notification sent", lager.Data{"target": target})
+}
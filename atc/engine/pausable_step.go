@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+// pausableStep holds off on running its step for as long as the build is
+// paused, so that a paused build does not start any more steps until it is
+// resumed. Steps which are already running are not affected.
+//
+// It is also where an aborted build, e.g. one which was preempted, stops
+// starting any more steps, even if its steps would not notice the abort
+// themselves.
+type pausableStep struct {
+	step  exec.Step
+	build db.Build
+}
+
+func pausable(build db.Build, step exec.Step) exec.Step {
+	return pausableStep{
+		step:  step,
+		build: build,
+	}
+}
+
+func (step pausableStep) Run(ctx context.Context, state exec.RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	notifier, err := step.build.ResumeNotifier()
+	if err != nil {
+		return false, fmt.Errorf("listen for resume: %w", err)
+	}
+
+	select {
+	case <-notifier.Notify():
+	case <-ctx.Done():
+		notifier.Close()
+		logger.Info("aborted-while-paused")
+		return false, ctx.Err()
+	}
+
+	notifier.Close()
+
+	// the build may have been aborted as it was resumed
+	if ctx.Err() != nil {
+		logger.Info("aborted-before-running-step")
+		return false, ctx.Err()
+	}
+
+	return step.step.Run(ctx, state)
+}
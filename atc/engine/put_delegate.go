@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/scanner"
 )
 
 func NewPutDelegate(
@@ -84,6 +85,28 @@ func (d *putDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus,
 	logger.Info("finished", lager.Data{"exit-status": exitStatus, "version-info": info})
 }
 
+func (d *putDelegate) ArtifactScanned(logger lager.Logger, report scanner.Report) {
+	ev := event.ArtifactScanned{
+		Origin:   d.eventOrigin,
+		Time:     d.clock.Now().Unix(),
+		Artifact: report.Artifact,
+		Clean:    report.Result.Clean,
+		Findings: report.Result.Findings,
+		Blocked:  report.Blocked,
+	}
+	if report.Error != nil {
+		ev.Error = report.Error.Error()
+	}
+
+	err := d.build.SaveEvent(ev)
+	if err != nil {
+		logger.Error("failed-to-save-artifact-scanned-event", err)
+		return
+	}
+
+	logger.Info("artifact-scanned", lager.Data{"artifact": report.Artifact, "clean": report.Result.Clean, "blocked": report.Blocked})
+}
+
 func (d *putDelegate) SaveOutput(log lager.Logger, plan atc.PutPlan, source atc.Source, imageResourceCache db.ResourceCache, info resource.VersionResult) {
 	logger := log.WithData(lager.Data{
 		"step":          plan.Name,
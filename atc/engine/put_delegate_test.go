@@ -1,6 +1,7 @@
 package engine_test
 
 import (
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -16,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/scanner"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -73,6 +75,56 @@ var _ = Describe("PutDelegate", func() {
 		})
 	})
 
+	Describe("ArtifactScanned", func() {
+		var report scanner.Report
+
+		BeforeEach(func() {
+			report = scanner.Report{
+				Artifact: "some-input",
+				Result: scanner.Result{
+					Clean:    false,
+					Findings: []string{"Eicar-Test-Signature"},
+				},
+				Blocked: true,
+			}
+		})
+
+		JustBeforeEach(func() {
+			delegate.ArtifactScanned(logger, report)
+		})
+
+		It("saves an event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ArtifactScanned{
+				Origin:   event.Origin{ID: event.OriginID("some-plan-id")},
+				Time:     now.Unix(),
+				Artifact: "some-input",
+				Clean:    false,
+				Findings: []string{"Eicar-Test-Signature"},
+				Blocked:  true,
+			}))
+		})
+
+		Context("when scanning errored", func() {
+			BeforeEach(func() {
+				report = scanner.Report{
+					Artifact: "some-input",
+					Error:    errors.New("clamd unavailable"),
+				}
+			})
+
+			It("saves the error in the event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ArtifactScanned{
+					Origin:   event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:     now.Unix(),
+					Artifact: "some-input",
+					Error:    "clamd unavailable",
+				}))
+			})
+		})
+	})
+
 	Describe("SaveOutput", func() {
 		var plan atc.PutPlan
 		var source atc.Source
@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+// replayStepFactory builds steps which, rather than running anything on a
+// worker, only resolve the vars their plan refers to against a replayed
+// RunState.
+type replayStepFactory struct {
+	out   io.Writer
+	trace *exec.RunStateTrace
+}
+
+func NewReplayStepFactory(out io.Writer, trace *exec.RunStateTrace) CoreStepFactory {
+	return &replayStepFactory{
+		out:   out,
+		trace: trace,
+	}
+}
+
+func (factory *replayStepFactory) GetStep(plan atc.Plan, _ exec.StepMetadata, _ db.ContainerMetadata, _ DelegateFactory) exec.Step {
+	return factory.step(plan, "get", plan.Get.Name, func(state exec.RunState) error {
+		_, err := creds.NewSource(state, plan.Get.Source).Evaluate()
+		if err != nil {
+			return err
+		}
+
+		_, err = creds.NewParams(state, plan.Get.Params).Evaluate()
+		return err
+	})
+}
+
+func (factory *replayStepFactory) PutStep(plan atc.Plan, _ exec.StepMetadata, _ db.ContainerMetadata, _ DelegateFactory) exec.Step {
+	return factory.step(plan, "put", plan.Put.Name, func(state exec.RunState) error {
+		_, err := creds.NewSource(state, plan.Put.Source).Evaluate()
+		if err != nil {
+			return err
+		}
+
+		_, err = creds.NewParams(state, plan.Put.Params).Evaluate()
+		return err
+	})
+}
+
+func (factory *replayStepFactory) TaskStep(plan atc.Plan, _ exec.StepMetadata, _ db.ContainerMetadata, _ DelegateFactory) exec.Step {
+	return factory.step(plan, "task", plan.Task.Name, func(state exec.RunState) error {
+		err := creds.NewTaskEnvValidator(state, plan.Task.Params).Validate()
+		if err != nil {
+			return err
+		}
+
+		return creds.NewTaskVarsValidator(state, plan.Task.Vars).Validate()
+	})
+}
+
+func (factory *replayStepFactory) RunStep(plan atc.Plan, _ exec.StepMetadata, _ db.ContainerMetadata, _ DelegateFactory) exec.Step {
+	return factory.step(plan, "run", plan.Run.Message, func(state exec.RunState) error {
+		_, err := creds.NewParams(state, plan.Run.Object).Evaluate()
+		return err
+	})
+}
+
+func (factory *replayStepFactory) CheckStep(plan atc.Plan, _ exec.StepMetadata, _ db.ContainerMetadata, _ DelegateFactory) exec.Step {
+	return factory.step(plan, "check", plan.Check.Name, func(state exec.RunState) error {
+		_, err := creds.NewSource(state, plan.Check.Source).Evaluate()
+		return err
+	})
+}
+
+func (factory *replayStepFactory) SetPipelineStep(plan atc.Plan, _ exec.StepMetadata, _ DelegateFactory) exec.Step {
+	return factory.step(plan, "set_pipeline", plan.SetPipeline.Name, func(state exec.RunState) error {
+		_, err := creds.NewSetPipelinePlan(state, *plan.SetPipeline).Evaluate()
+		return err
+	})
+}
+
+func (factory *replayStepFactory) LoadVarStep(plan atc.Plan, _ exec.StepMetadata, _ DelegateFactory) exec.Step {
+	return factory.step(plan, "load_var", plan.LoadVar.Name, func(state exec.RunState) error {
+		val, redact, found := factory.trace.NextLocalVar(plan.LoadVar.Name)
+		if !found {
+			return fmt.Errorf("load_var %s was not recorded in the trace", plan.LoadVar.Name)
+		}
+
+		state.AddLocalVar(plan.LoadVar.Name, val, redact)
+		return nil
+	})
+}
+
+func (factory *replayStepFactory) NotifyStep(plan atc.Plan, _ exec.StepMetadata, _ DelegateFactory) exec.Step {
+	return factory.step(plan, "notify", plan.Notify.Name, func(state exec.RunState) error {
+		_, err := creds.NewString(state, plan.Notify.Message).Evaluate()
+		return err
+	})
+}
+
+func (factory *replayStepFactory) TaskOutputVarsStep(plan atc.Plan, outputVars []atc.TaskOutputVarConfig) exec.Step {
+	return factory.step(plan, "task_output_vars", plan.Task.Name, func(state exec.RunState) error {
+		for _, outputVar := range outputVars {
+			val, redact, found := factory.trace.NextLocalVar(outputVar.Name)
+			if !found {
+				return fmt.Errorf("output var %s was not recorded in the trace", outputVar.Name)
+			}
+
+			state.AddLocalVar(outputVar.Name, val, redact)
+		}
+
+		return nil
+	})
+}
+
+func (factory *replayStepFactory) ArtifactInputStep(plan atc.Plan, _ db.Build) exec.Step {
+	return factory.step(plan, "artifact_input", plan.ArtifactInput.Name, nil)
+}
+
+func (factory *replayStepFactory) ArtifactOutputStep(plan atc.Plan, _ db.Build) exec.Step {
+	return factory.step(plan, "artifact_output", plan.ArtifactOutput.Name, nil)
+}
+
+func (factory *replayStepFactory) step(plan atc.Plan, kind string, name string, replay func(exec.RunState) error) exec.Step {
+	return replayStep{
+		planID: plan.ID,
+		kind:   kind,
+		name:   name,
+		replay: replay,
+		out:    factory.out,
+	}
+}
+
+type replayStep struct {
+	planID atc.PlanID
+	kind   string
+	name   string
+	replay func(exec.RunState) error
+	out    io.Writer
+}
+
+func (step replayStep) Run(ctx context.Context, state exec.RunState) (bool, error) {
+	fmt.Fprintf(step.out, "%s %s (%s)\n", step.kind, step.name, step.planID)
+
+	if step.replay == nil {
+		return true, nil
+	}
+
+	err := step.replay(state)
+	if err != nil {
+		fmt.Fprintf(step.out, "  error: %s\n", err)
+		return false, err
+	}
+
+	return true, nil
+}
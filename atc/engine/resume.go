@@ -0,0 +1,335 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+// resumeTracker keeps track of the steps of a build so that the build can be
+// rerun from the failed step.
+//
+// It records the artifacts produced by each step which succeeds, which are
+// kept around if the build does not succeed. If the build is itself resumed
+// from the failed step of another build, it also knows which of that build's
+// steps succeeded, so that they can be replayed rather than run again.
+type resumeTracker struct {
+	lock     sync.Mutex
+	produced map[atc.PlanID]map[build.ArtifactName]runtime.Artifact
+
+	resumed map[atc.PlanID]*resumedStep
+}
+
+// resumedStep is a step of the build being resumed from.
+type resumedStep struct {
+	succeeded  bool
+	events     []atc.Event
+	version    atc.Version
+	outputVars []atc.TaskOutputVarConfig
+	artifacts  map[build.ArtifactName]int
+}
+
+type resumeTrackerKey struct{}
+
+func withResumeTracker(ctx context.Context, tracker *resumeTracker) context.Context {
+	return context.WithValue(ctx, resumeTrackerKey{}, tracker)
+}
+
+func resumeTrackerFromContext(ctx context.Context) *resumeTracker {
+	tracker, _ := ctx.Value(resumeTrackerKey{}).(*resumeTracker)
+	return tracker
+}
+
+func newResumeTracker(dbBuild db.Build) (*resumeTracker, error) {
+	tracker := &resumeTracker{
+		produced: map[atc.PlanID]map[build.ArtifactName]runtime.Artifact{},
+	}
+
+	if dbBuild.ResumedFrom() == 0 {
+		return tracker, nil
+	}
+
+	resumedFrom, found, err := dbBuild.ResumedFromBuild()
+	if err != nil {
+		return nil, fmt.Errorf("find resumed build: %w", err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("resumed build %d not found", dbBuild.ResumedFrom())
+	}
+
+	tracker.resumed, err = resumedSteps(resumedFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	return tracker, nil
+}
+
+// resumedSteps reconstructs the outcome of each step of a finished build from
+// its events and the artifacts which were kept around when it finished.
+func resumedSteps(dbBuild db.Build) (map[atc.PlanID]*resumedStep, error) {
+	steps := map[atc.PlanID]*resumedStep{}
+
+	step := func(id atc.PlanID) *resumedStep {
+		if steps[id] == nil {
+			steps[id] = &resumedStep{
+				artifacts: map[build.ArtifactName]int{},
+			}
+		}
+
+		return steps[id]
+	}
+
+	events, err := dbBuild.Events(0)
+	if err != nil {
+		return nil, fmt.Errorf("get resumed build events: %w", err)
+	}
+
+	defer events.Close()
+
+	for {
+		envelope, err := events.Next()
+		if err != nil {
+			if errors.Is(err, db.ErrEndOfBuildEventStream) {
+				break
+			}
+
+			return nil, fmt.Errorf("read resumed build events: %w", err)
+		}
+
+		if envelope.Data == nil {
+			continue
+		}
+
+		var origin struct {
+			Origin event.Origin `json:"origin"`
+		}
+
+		err = json.Unmarshal(*envelope.Data, &origin)
+		if err != nil || origin.Origin.ID == "" {
+			continue
+		}
+
+		ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			return nil, fmt.Errorf("parse resumed build event: %w", err)
+		}
+
+		s := step(atc.PlanID(origin.Origin.ID))
+		s.events = append(s.events, ev)
+
+		switch e := ev.(type) {
+		case event.StartTask:
+			s.outputVars = e.TaskConfig.OutputVars
+		case event.FinishTask:
+			s.succeeded = e.ExitStatus == 0
+		case event.FinishPut:
+			s.succeeded = e.ExitStatus == 0
+			s.version = e.CreatedVersion
+		case event.Finish:
+			s.succeeded = e.Succeeded
+		}
+	}
+
+	artifacts, err := dbBuild.Artifacts()
+	if err != nil {
+		return nil, fmt.Errorf("get resumed build artifacts: %w", err)
+	}
+
+	for _, artifact := range artifacts {
+		parts := strings.SplitN(artifact.Name(), "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		step(atc.PlanID(parts[0])).artifacts[build.ArtifactName(parts[1])] = artifact.ID()
+	}
+
+	return steps, nil
+}
+
+func (tracker *resumeTracker) resumedStep(id atc.PlanID) (*resumedStep, bool) {
+	step, found := tracker.resumed[id]
+	if !found || !step.succeeded {
+		return nil, false
+	}
+
+	return step, true
+}
+
+func (tracker *resumeTracker) recordProduced(id atc.PlanID, artifacts map[build.ArtifactName]runtime.Artifact) {
+	if len(artifacts) == 0 {
+		return
+	}
+
+	tracker.lock.Lock()
+	tracker.produced[id] = artifacts
+	tracker.lock.Unlock()
+}
+
+// keepArtifacts keeps the artifacts produced by the steps which succeeded
+// around as worker artifacts of the build, so that they can be replayed when
+// the build is rerun from the failed step. Like any other worker artifact,
+// they expire after a while.
+func (tracker *resumeTracker) keepArtifacts(logger lager.Logger, dbBuild db.Build) {
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	for id, artifacts := range tracker.produced {
+		for name, artifact := range artifacts {
+			volume, ok := artifact.(runtime.Volume)
+			if !ok {
+				continue
+			}
+
+			_, err := volume.DBVolume().InitializeArtifact(resumeArtifactName(id, name), dbBuild.ID())
+			if err != nil {
+				logger.Error("failed-to-keep-artifact", err, lager.Data{
+					"plan-id":  id,
+					"artifact": name,
+				})
+			}
+		}
+	}
+}
+
+func resumeArtifactName(id atc.PlanID, name build.ArtifactName) string {
+	return fmt.Sprintf("%s/%s", id, name)
+}
+
+// resumableStep replays its step from the build being resumed from if the
+// step succeeded there, and otherwise runs it, recording the artifacts it
+// produces.
+type resumableStep struct {
+	step  exec.Step
+	plan  atc.Plan
+	build db.Build
+
+	artifactInputStep  func(atc.Plan, db.Build) exec.Step
+	taskOutputVarsStep func(atc.Plan, []atc.TaskOutputVarConfig) exec.Step
+}
+
+func (factory *stepperFactory) resumable(dbBuild db.Build, plan atc.Plan, step exec.Step) exec.Step {
+	return resumableStep{
+		step:  step,
+		plan:  plan,
+		build: dbBuild,
+
+		artifactInputStep:  factory.coreFactory.ArtifactInputStep,
+		taskOutputVarsStep: factory.coreFactory.TaskOutputVarsStep,
+	}
+}
+
+func (step resumableStep) Run(ctx context.Context, state exec.RunState) (bool, error) {
+	tracker := resumeTrackerFromContext(ctx)
+	if tracker == nil {
+		return step.step.Run(ctx, state)
+	}
+
+	// register the artifacts produced by the step in a scope of their own so
+	// that they can be told apart from those produced by steps running in
+	// parallel, and only then make them available to the rest of the build
+	artifacts := state.ArtifactRepository()
+	scope := artifacts.NewLocalScope()
+	scope.OnRegister(nil)
+
+	scopedState := artifactScopedState{
+		RunState:  state,
+		artifacts: scope,
+	}
+
+	var ok bool
+	var err error
+	if resumed, found := tracker.resumedStep(step.plan.ID); found {
+		ok, err = step.replay(ctx, scopedState, resumed)
+	} else {
+		ok, err = step.step.Run(ctx, scopedState)
+	}
+
+	produced := scope.LocalAsMap()
+	for name, artifact := range produced {
+		artifacts.RegisterArtifact(name, artifact)
+	}
+
+	if ok && err == nil {
+		tracker.recordProduced(step.plan.ID, produced)
+	}
+
+	return ok, err
+}
+
+func (step resumableStep) replay(ctx context.Context, state exec.RunState, resumed *resumedStep) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("replay", lager.Data{
+		"plan-id": step.plan.ID,
+	})
+
+	logger.Info("replaying-succeeded-step")
+
+	for _, ev := range resumed.events {
+		err := step.build.SaveEvent(ev)
+		if err != nil {
+			return false, fmt.Errorf("replay event: %w", err)
+		}
+	}
+
+	for name, artifactID := range resumed.artifacts {
+		ok, err := step.artifactInputStep(atc.Plan{
+			ID: step.plan.ID,
+			ArtifactInput: &atc.ArtifactInputPlan{
+				ArtifactID: artifactID,
+				Name:       string(name),
+			},
+		}, step.build).Run(ctx, state)
+		if err != nil {
+			return false, fmt.Errorf("replay artifact %s: %w", name, err)
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	// the output vars of a task are loaded again from its replayed outputs,
+	// as their values are not kept around
+	if step.plan.Task != nil && len(resumed.outputVars) > 0 {
+		ok, err := step.taskOutputVarsStep(step.plan, resumed.outputVars).Run(ctx, state)
+		if err != nil {
+			return false, fmt.Errorf("replay output vars: %w", err)
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	if resumed.version != nil {
+		state.StoreResult(step.plan.ID, resumed.version)
+	}
+
+	return true, nil
+}
+
+// artifactScopedState is a RunState whose artifacts are registered in a scope
+// of their own.
+type artifactScopedState struct {
+	exec.RunState
+
+	artifacts *build.Repository
+}
+
+func (state artifactScopedState) ArtifactRepository() *build.Repository {
+	return state.artifacts
+}
@@ -0,0 +1,316 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/vars"
+)
+
+func eventEnvelope(ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Data:    &data,
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+	}
+}
+
+var _ = Describe("Resuming builds", func() {
+	var (
+		fakeBuild        *dbfakes.FakeBuild
+		fakeResumedBuild *dbfakes.FakeBuild
+		fakeEventSource  *dbfakes.FakeEventSource
+
+		resumedEvents []atc.Event
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(2)
+		fakeBuild.ResumedFromReturns(1)
+
+		fakeResumedBuild = new(dbfakes.FakeBuild)
+		fakeResumedBuild.IDReturns(1)
+		fakeBuild.ResumedFromBuildReturns(fakeResumedBuild, true, nil)
+
+		resumedEvents = []atc.Event{
+			event.StartTask{
+				Origin: event.Origin{ID: "task-plan"},
+				TaskConfig: event.TaskConfig{
+					OutputVars: []atc.TaskOutputVarConfig{
+						{Name: "some-var", File: "some-output/some-file", Format: "trim"},
+					},
+				},
+			},
+			event.Log{Origin: event.Origin{ID: "task-plan"}, Payload: "hello"},
+			event.FinishTask{Origin: event.Origin{ID: "task-plan"}, ExitStatus: 0},
+			event.FinishPut{Origin: event.Origin{ID: "put-plan"}, ExitStatus: 0, CreatedVersion: atc.Version{"ref": "v1"}},
+			event.FinishTask{Origin: event.Origin{ID: "failed-plan"}, ExitStatus: 1},
+		}
+
+		fakeEventSource = new(dbfakes.FakeEventSource)
+		for i, ev := range resumedEvents {
+			fakeEventSource.NextReturnsOnCall(i, eventEnvelope(ev), nil)
+		}
+		fakeEventSource.NextReturnsOnCall(len(resumedEvents), event.Envelope{}, db.ErrEndOfBuildEventStream)
+		fakeResumedBuild.EventsReturns(fakeEventSource, nil)
+
+		fakeArtifact := new(dbfakes.FakeWorkerArtifact)
+		fakeArtifact.IDReturns(42)
+		fakeArtifact.NameReturns("task-plan/some-output")
+		fakeResumedBuild.ArtifactsReturns([]db.WorkerArtifact{fakeArtifact}, nil)
+	})
+
+	Describe("newResumeTracker", func() {
+		var tracker *resumeTracker
+		var trackerErr error
+
+		JustBeforeEach(func() {
+			tracker, trackerErr = newResumeTracker(fakeBuild)
+		})
+
+		It("knows which steps of the resumed build succeeded", func() {
+			Expect(trackerErr).ToNot(HaveOccurred())
+
+			task, found := tracker.resumedStep("task-plan")
+			Expect(found).To(BeTrue())
+			Expect(task.events).To(Equal(resumedEvents[0:3]))
+			Expect(task.outputVars).To(Equal([]atc.TaskOutputVarConfig{
+				{Name: "some-var", File: "some-output/some-file", Format: "trim"},
+			}))
+			Expect(task.artifacts).To(HaveKeyWithValue(BeEquivalentTo("some-output"), 42))
+
+			put, found := tracker.resumedStep("put-plan")
+			Expect(found).To(BeTrue())
+			Expect(put.version).To(Equal(atc.Version{"ref": "v1"}))
+
+			_, found = tracker.resumedStep("failed-plan")
+			Expect(found).To(BeFalse())
+		})
+
+		It("closes the event source", func() {
+			Expect(fakeEventSource.CloseCallCount()).To(Equal(1))
+		})
+
+		Context("when the build is not resumed", func() {
+			BeforeEach(func() {
+				fakeBuild.ResumedFromReturns(0)
+			})
+
+			It("does not look for a build to resume from", func() {
+				Expect(trackerErr).ToNot(HaveOccurred())
+				Expect(fakeBuild.ResumedFromBuildCallCount()).To(BeZero())
+
+				_, found := tracker.resumedStep("task-plan")
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the resumed build is not found", func() {
+			BeforeEach(func() {
+				fakeBuild.ResumedFromBuildReturns(nil, false, nil)
+			})
+
+			It("errors", func() {
+				Expect(trackerErr).To(MatchError("resumed build 1 not found"))
+			})
+		})
+	})
+
+	Describe("resumableStep", func() {
+		var (
+			tracker       *resumeTracker
+			fakeStep      *execfakes.FakeStep
+			fakeInputStep *execfakes.FakeStep
+			inputPlans    []atc.Plan
+			fakeStreamer  *execfakes.FakeStreamer
+			state         exec.RunState
+			plan          atc.Plan
+
+			ok     bool
+			runErr error
+		)
+
+		BeforeEach(func() {
+			var err error
+			tracker, err = newResumeTracker(fakeBuild)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeStep = new(execfakes.FakeStep)
+			fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+				state.ArtifactRepository().RegisterArtifact("produced", runtimetest.NewVolume("produced-volume"))
+				return true, nil
+			}
+
+			fakeInputStep = new(execfakes.FakeStep)
+			fakeInputStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+				state.ArtifactRepository().RegisterArtifact("some-output", runtimetest.NewVolume("replayed-volume"))
+				return true, nil
+			}
+
+			inputPlans = nil
+
+			fakeStreamer = new(execfakes.FakeStreamer)
+			fakeStreamer.StreamFileReturns(ioutil.NopCloser(strings.NewReader("some-value\n")), nil)
+
+			state = exec.NewRunState(noopStepper, nil, false)
+		})
+
+		JustBeforeEach(func() {
+			step := resumableStep{
+				step:  fakeStep,
+				plan:  plan,
+				build: fakeBuild,
+
+				artifactInputStep: func(plan atc.Plan, build db.Build) exec.Step {
+					Expect(build).To(Equal(fakeBuild))
+					inputPlans = append(inputPlans, plan)
+					return fakeInputStep
+				},
+				taskOutputVarsStep: func(plan atc.Plan, outputVars []atc.TaskOutputVarConfig) exec.Step {
+					return exec.NewTaskOutputVarsStep(plan.ID, *plan.Task, outputVars, fakeStreamer)
+				},
+			}
+
+			ctx := withResumeTracker(context.Background(), tracker)
+			ok, runErr = step.Run(ctx, state)
+		})
+
+		Context("when the step succeeded in the resumed build", func() {
+			BeforeEach(func() {
+				plan = atc.Plan{ID: "task-plan", Task: &atc.TaskPlan{Name: "some-task"}}
+			})
+
+			It("replays it rather than running it", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				Expect(fakeStep.RunCallCount()).To(BeZero())
+			})
+
+			It("saves the events of the resumed step", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
+				Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(resumedEvents[1]))
+			})
+
+			It("registers the artifacts of the resumed step", func() {
+				Expect(inputPlans).To(Equal([]atc.Plan{
+					{
+						ID: "task-plan",
+						ArtifactInput: &atc.ArtifactInputPlan{
+							ArtifactID: 42,
+							Name:       "some-output",
+						},
+					},
+				}))
+
+				_, found := state.ArtifactRepository().ArtifactFor("some-output")
+				Expect(found).To(BeTrue())
+			})
+
+			It("loads the output vars of the resumed step from its replayed artifacts", func() {
+				Expect(fakeStreamer.StreamFileCallCount()).To(Equal(1))
+				_, artifact, path := fakeStreamer.StreamFileArgsForCall(0)
+				Expect(artifact).To(Equal(runtimetest.NewVolume("replayed-volume")))
+				Expect(path).To(Equal("some-file"))
+
+				val, found, err := state.Get(vars.Reference{Source: ".", Path: "some-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("some-value"))
+			})
+
+			Context("when the output vars cannot be loaded", func() {
+				BeforeEach(func() {
+					fakeStreamer.StreamFileReturns(nil, errors.New("nope"))
+				})
+
+				It("errors", func() {
+					Expect(runErr).To(MatchError("replay output vars: nope"))
+				})
+			})
+		})
+
+		Context("when the step is a put which succeeded in the resumed build", func() {
+			BeforeEach(func() {
+				plan = atc.Plan{ID: "put-plan", Put: &atc.PutPlan{Name: "some-put"}}
+			})
+
+			It("stores the version it created", func() {
+				Expect(fakeStep.RunCallCount()).To(BeZero())
+
+				var version atc.Version
+				Expect(state.Result("put-plan", &version)).To(BeTrue())
+				Expect(version).To(Equal(atc.Version{"ref": "v1"}))
+			})
+		})
+
+		Context("when the step failed in the resumed build", func() {
+			BeforeEach(func() {
+				plan = atc.Plan{ID: "failed-plan", Task: &atc.TaskPlan{Name: "some-task"}}
+			})
+
+			It("runs it", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeStep.RunCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+			})
+
+			It("makes the artifacts it produced available to the rest of the build", func() {
+				_, found := state.ArtifactRepository().ArtifactFor("produced")
+				Expect(found).To(BeTrue())
+			})
+
+			It("records the artifacts it produced", func() {
+				Expect(tracker.produced).To(HaveKey(atc.PlanID("failed-plan")))
+			})
+
+			Context("when the build does not succeed", func() {
+				It("keeps the artifacts around for the build", func() {
+					tracker.keepArtifacts(lagertest.NewTestLogger("test"), fakeBuild)
+
+					artifact, _ := state.ArtifactRepository().ArtifactFor("produced")
+					dbVolume := artifact.(runtime.Volume).DBVolume().(*dbfakes.FakeCreatedVolume)
+					Expect(dbVolume.InitializeArtifactCallCount()).To(Equal(1))
+
+					name, buildID := dbVolume.InitializeArtifactArgsForCall(0)
+					Expect(name).To(Equal("failed-plan/produced"))
+					Expect(buildID).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when the step fails", func() {
+			BeforeEach(func() {
+				plan = atc.Plan{ID: "failed-plan", Task: &atc.TaskPlan{Name: "some-task"}}
+
+				fakeStep.RunReturns(false, nil)
+				fakeStep.RunStub = nil
+			})
+
+			It("does not record it", func() {
+				Expect(ok).To(BeFalse())
+				Expect(tracker.produced).To(BeEmpty())
+			})
+		})
+	})
+})
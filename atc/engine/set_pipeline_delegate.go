@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"fmt"
+
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -16,9 +18,10 @@ func NewSetPipelineStepDelegate(
 	state exec.RunState,
 	clock clock.Clock,
 	policyChecker    policy.Checker,
+	acrossTeams []string,
 ) *setPipelineStepDelegate {
 	return &setPipelineStepDelegate{
-		buildStepDelegate{
+		buildStepDelegate: buildStepDelegate{
 			build:  build,
 			planID: planID,
 			clock:  clock,
@@ -27,11 +30,16 @@ func NewSetPipelineStepDelegate(
 			stderr: nil,
 			policyChecker: policyChecker,
 		},
+		acrossTeams: acrossTeams,
 	}
 }
 
 type setPipelineStepDelegate struct {
 	buildStepDelegate
+
+	// acrossTeams lists the SOURCE:TARGET team pairs the operator has
+	// allowed to set pipelines across teams.
+	acrossTeams []string
 }
 
 func (delegate *setPipelineStepDelegate) SetPipelineChanged(logger lager.Logger, changed bool) {
@@ -61,3 +69,49 @@ func (delegate *setPipelineStepDelegate) CheckRunSetPipelinePolicy(atcConfig *at
 		Data:     atcConfig,
 	})
 }
+
+// CheckSetPipelineAcrossTeamsPolicy returns whether the build's team is
+// granted permission to set the named pipeline in the target team. The grant
+// must come from the operator's allowlist of team pairs; if the action is
+// configured to be checked, the policy agent may still deny it.
+func (delegate *setPipelineStepDelegate) CheckSetPipelineAcrossTeamsPolicy(targetTeam string, pipelineName string) (bool, error) {
+	if !delegate.allowedAcrossTeams(targetTeam) {
+		return false, nil
+	}
+
+	if !delegate.policyChecker.ShouldCheckAction(policy.ActionSetPipelineAcrossTeams) {
+		return true, nil
+	}
+
+	result, err := delegate.policyChecker.Check(policy.PolicyCheckInput{
+		Action:   policy.ActionSetPipelineAcrossTeams,
+		Team:     delegate.build.TeamName(),
+		Pipeline: delegate.build.PipelineName(),
+		Data: map[string]string{
+			"target_team":     targetTeam,
+			"target_pipeline": pipelineName,
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("policy check: %w", err)
+	}
+
+	if !result.Allowed() {
+		return false, policy.PolicyCheckNotPass{
+			Messages: result.Messages(),
+		}
+	}
+
+	return true, nil
+}
+
+func (delegate *setPipelineStepDelegate) allowedAcrossTeams(targetTeam string) bool {
+	pair := delegate.build.TeamName() + ":" + targetTeam
+	for _, allowed := range delegate.acrossTeams {
+		if allowed == pair {
+			return true
+		}
+	}
+
+	return false
+}
@@ -51,7 +51,7 @@ var _ = Describe("SetPipelineStepDelegate", func() {
 		fakePolicyChecker = new(policyfakes.FakeChecker)
 		fakePolicyChecker.CheckReturns(fakePolicyCheckResult, nil)
 
-		delegate = engine.NewSetPipelineStepDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker)
+		delegate = engine.NewSetPipelineStepDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, nil)
 	})
 
 	Describe("SetPipelineChanged", func() {
@@ -193,4 +193,115 @@ var _ = Describe("SetPipelineStepDelegate", func() {
 			})
 		})
 	})
+
+	Describe("CheckSetPipelineAcrossTeamsPolicy", func() {
+		var acrossTeams []string
+		var granted bool
+		var checkErr error
+
+		BeforeEach(func() {
+			acrossTeams = nil
+		})
+
+		JustBeforeEach(func() {
+			delegate = engine.NewSetPipelineStepDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, acrossTeams)
+			granted, checkErr = delegate.CheckSetPipelineAcrossTeamsPolicy("other-team", "other-pipeline")
+		})
+
+		Context("when the team pair is not allowed", func() {
+			BeforeEach(func() {
+				acrossTeams = []string{"some-team:another-team", "other-team:some-team"}
+				fakePolicyChecker.ShouldCheckActionReturns(true)
+				fakePolicyCheckResult.AllowedReturns(true)
+			})
+
+			It("should not grant", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(granted).To(BeFalse())
+			})
+
+			It("should not check policy", func() {
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the team pair is allowed", func() {
+			BeforeEach(func() {
+				acrossTeams = []string{"some-team:other-team"}
+			})
+
+			Context("when the action does not need to be checked", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.ShouldCheckActionReturns(false)
+				})
+
+				It("should grant", func() {
+					Expect(checkErr).ToNot(HaveOccurred())
+					Expect(granted).To(BeTrue())
+				})
+
+				It("should not check policy", func() {
+					Expect(fakePolicyChecker.CheckCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the action needs to be checked", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.ShouldCheckActionReturns(true)
+				})
+
+				It("should check policy", func() {
+					Expect(fakePolicyChecker.ShouldCheckActionArgsForCall(0)).To(Equal(policy.ActionSetPipelineAcrossTeams))
+					Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+
+					input := fakePolicyChecker.CheckArgsForCall(0)
+					Expect(input).To(Equal(policy.PolicyCheckInput{
+						Action:   policy.ActionSetPipelineAcrossTeams,
+						Team:     "some-team",
+						Pipeline: "some-pipeline",
+						Data: map[string]string{
+							"target_team":     "other-team",
+							"target_pipeline": "other-pipeline",
+						},
+					}))
+				})
+
+				Context("when policy check fails", func() {
+					BeforeEach(func() {
+						fakePolicyChecker.CheckReturns(nil, errors.New("some-error"))
+					})
+
+					It("should fail", func() {
+						Expect(checkErr).To(MatchError("policy check: some-error"))
+						Expect(granted).To(BeFalse())
+					})
+				})
+
+				Context("when policy check not pass", func() {
+					BeforeEach(func() {
+						fakePolicyCheckResult.AllowedReturns(false)
+						fakePolicyCheckResult.ShouldBlockReturns(false)
+						fakePolicyCheckResult.MessagesReturns([]string{"reasonA"})
+					})
+
+					It("should fail even for soft enforcement", func() {
+						Expect(checkErr).To(HaveOccurred())
+						Expect(checkErr.Error()).To(ContainSubstring("reasonA"))
+						Expect(granted).To(BeFalse())
+					})
+				})
+
+				Context("policy check passes", func() {
+					BeforeEach(func() {
+						fakePolicyCheckResult.AllowedReturns(true)
+					})
+
+					It("should grant", func() {
+						Expect(checkErr).ToNot(HaveOccurred())
+						Expect(granted).To(BeTrue())
+					})
+				})
+			})
+		})
+	})
 })
@@ -3,14 +3,18 @@ package engine
 import (
 	"crypto/sha1"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/archiver"
+	"github.com/concourse/concourse/atc/artifactstore"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/scanner"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -25,8 +29,16 @@ type coreStepFactory struct {
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.PlacementStrategy
 	defaultCheckTimeout   time.Duration
+	artifactScanner       scanner.Scanner
+	scanAction            scanner.Action
+	artifactArchiver      archiver.Archiver
+	artifactStore         artifactstore.Store
+	notifyClient          *http.Client
 }
 
+// notifyTimeout bounds each post a notify step makes to one of its targets.
+const notifyTimeout = time.Minute
+
 func NewCoreStepFactory(
 	pool worker.Pool,
 	streamer worker.Streamer,
@@ -38,6 +50,11 @@ func NewCoreStepFactory(
 	defaultLimits atc.ContainerLimits,
 	strategy worker.PlacementStrategy,
 	defaultCheckTimeout time.Duration,
+	artifactScanner scanner.Scanner,
+	scanAction scanner.Action,
+	artifactArchiver archiver.Archiver,
+	artifactStore artifactstore.Store,
+	notifyAllowedHosts []string,
 ) CoreStepFactory {
 	return &coreStepFactory{
 		pool:                  pool,
@@ -50,6 +67,11 @@ func NewCoreStepFactory(
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		defaultCheckTimeout:   defaultCheckTimeout,
+		artifactScanner:       artifactScanner,
+		scanAction:            scanAction,
+		artifactArchiver:      artifactArchiver,
+		artifactStore:         artifactStore,
+		notifyClient:          exec.NewNotifyClient(notifyAllowedHosts, notifyTimeout),
 	}
 }
 
@@ -71,8 +93,13 @@ func (factory *coreStepFactory) GetStep(
 		factory.strategy,
 		delegateFactory,
 		factory.pool,
+		factory.streamer,
 	)
 
+	if atc.MaxStepReschedules > 0 {
+		getStep = exec.Reschedule(getStep, delegateFactory)
+	}
+
 	getStep = exec.LogError(getStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		getStep = exec.RetryError(getStep, delegateFactory)
@@ -96,6 +123,8 @@ func (factory *coreStepFactory) PutStep(
 		factory.strategy,
 		factory.pool,
 		delegateFactory,
+		factory.artifactScanner,
+		factory.scanAction,
 	)
 
 	putStep = exec.LogError(putStep, delegateFactory)
@@ -125,6 +154,10 @@ func (factory *coreStepFactory) CheckStep(
 		factory.defaultCheckTimeout,
 	)
 
+	if atc.MaxStepReschedules > 0 {
+		checkStep = exec.Reschedule(checkStep, delegateFactory)
+	}
+
 	checkStep = exec.LogError(checkStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		checkStep = exec.RetryError(checkStep, delegateFactory)
@@ -143,6 +176,10 @@ func (factory *coreStepFactory) RunStep(
 	runStep := exec.NewRunStep(
 		plan.ID,
 		*plan.Run,
+		stepMetadata,
+		containerMetadata,
+		factory.strategy,
+		factory.pool,
 		delegateFactory,
 	)
 
@@ -172,8 +209,15 @@ func (factory *coreStepFactory) TaskStep(
 		factory.pool,
 		factory.streamer,
 		delegateFactory,
+		factory.artifactArchiver,
+		factory.artifactScanner,
+		factory.scanAction,
 	)
 
+	if atc.MaxStepReschedules > 0 {
+		taskStep = exec.Reschedule(taskStep, delegateFactory)
+	}
+
 	taskStep = exec.LogError(taskStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		taskStep = exec.RetryError(taskStep, delegateFactory)
@@ -223,16 +267,39 @@ func (factory *coreStepFactory) LoadVarStep(
 	return loadVarStep
 }
 
+func (factory *coreStepFactory) NotifyStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	notifyStep := exec.NewNotifyStep(
+		plan.ID,
+		*plan.Notify,
+		stepMetadata,
+		delegateFactory,
+		factory.notifyClient,
+	)
+
+	return exec.LogError(notifyStep, delegateFactory)
+}
+
+func (factory *coreStepFactory) TaskOutputVarsStep(
+	plan atc.Plan,
+	outputVars []atc.TaskOutputVarConfig,
+) exec.Step {
+	return exec.NewTaskOutputVarsStep(plan.ID, *plan.Task, outputVars, factory.streamer)
+}
+
 func (factory *coreStepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
 ) exec.Step {
-	return exec.NewArtifactInputStep(plan, build, factory.pool)
+	return exec.NewArtifactInputStep(plan, build, factory.pool, factory.artifactStore)
 }
 
 func (factory *coreStepFactory) ArtifactOutputStep(
 	plan atc.Plan,
 	build db.Build,
 ) exec.Step {
-	return exec.NewArtifactOutputStep(plan, build, factory.pool)
+	return exec.NewArtifactOutputStep(plan, build, factory.pool, factory.artifactStore)
 }
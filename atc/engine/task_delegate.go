@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"code.cloudfoundry.org/clock"
@@ -13,6 +14,8 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/scanner"
+	"github.com/concourse/concourse/atc/testreport"
 )
 
 func NewTaskDelegate(
@@ -145,3 +148,85 @@ func (d *taskDelegate) FetchImage(
 
 	return imageSpec, nil
 }
+
+func (d *taskDelegate) ArtifactArchived(logger lager.Logger, artifact string, location string) {
+	err := d.build.SaveEvent(event.ArtifactArchived{
+		Origin:   d.eventOrigin,
+		Time:     d.clock.Now().Unix(),
+		Artifact: artifact,
+		Location: location,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-artifact-archived-event", err)
+		return
+	}
+
+	logger.Info("artifact-archived", lager.Data{"artifact": artifact, "location": location})
+}
+
+func (d *taskDelegate) ArtifactScanned(logger lager.Logger, report scanner.Report) {
+	ev := event.ArtifactScanned{
+		Origin:   d.eventOrigin,
+		Time:     d.clock.Now().Unix(),
+		Artifact: report.Artifact,
+		Clean:    report.Result.Clean,
+		Findings: report.Result.Findings,
+		Blocked:  report.Blocked,
+	}
+	if report.Error != nil {
+		ev.Error = report.Error.Error()
+	}
+
+	err := d.build.SaveEvent(ev)
+	if err != nil {
+		logger.Error("failed-to-save-artifact-scanned-event", err)
+		return
+	}
+
+	logger.Info("artifact-scanned", lager.Data{"artifact": report.Artifact, "clean": report.Result.Clean, "blocked": report.Blocked})
+}
+
+func (d *taskDelegate) SaveTestResults(ctx context.Context, logger lager.Logger, stepName string, results []atc.TestResult) error {
+	err := d.build.SaveTestResults(results)
+	if err != nil {
+		return fmt.Errorf("save test results: %w", err)
+	}
+
+	summary := atc.SummarizeTestResults(results)
+	fmt.Fprintf(d.Stdout(), "\x1b[1mtest results:\x1b[0m %d passed, %d failed, %d errored, %d skipped\n", summary.Passed, summary.Failed, summary.Errored, summary.Skipped)
+
+	job, found, err := d.build.Job()
+	if err != nil {
+		return fmt.Errorf("find job: %w", err)
+	}
+
+	if !found {
+		return nil
+	}
+
+	config, err := job.Config()
+	if err != nil {
+		return fmt.Errorf("job config: %w", err)
+	}
+
+	if config.TestReportWebhook == "" {
+		return nil
+	}
+
+	err = testreport.Forward(ctx, config.TestReportWebhook, atc.TestReportNotification{
+		BuildID:              d.build.ID(),
+		BuildName:            d.build.Name(),
+		TeamName:             d.build.TeamName(),
+		PipelineName:         d.build.PipelineName(),
+		PipelineInstanceVars: d.build.PipelineInstanceVars(),
+		JobName:              d.build.JobName(),
+		StepName:             stepName,
+		Summary:              summary,
+	})
+	if err != nil {
+		logger.Error("failed-to-forward-test-report", err)
+		fmt.Fprintf(d.Stderr(), "\x1b[1;33mWARNING: failed to forward test results to webhook: %s\x1b[0m\n", err)
+	}
+
+	return nil
+}
@@ -3,10 +3,13 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 
 	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -19,6 +22,7 @@ import (
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/scanner"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -146,6 +150,120 @@ var _ = Describe("TaskDelegate", func() {
 		})
 	})
 
+	Describe("ArtifactArchived", func() {
+		JustBeforeEach(func() {
+			delegate.ArtifactArchived(logger, "some-output", "s3://some-bucket/some-output.tgz")
+		})
+
+		It("saves an event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ArtifactArchived{
+				Time:     now.Unix(),
+				Origin:   event.Origin{ID: event.OriginID(planID)},
+				Artifact: "some-output",
+				Location: "s3://some-bucket/some-output.tgz",
+			}))
+		})
+	})
+
+	Describe("ArtifactScanned", func() {
+		JustBeforeEach(func() {
+			delegate.ArtifactScanned(logger, scanner.Report{
+				Artifact: "some-output",
+				Result: scanner.Result{
+					Clean:    false,
+					Findings: []string{"Eicar-Test-Signature"},
+				},
+				Blocked: true,
+			})
+		})
+
+		It("saves an event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ArtifactScanned{
+				Time:     now.Unix(),
+				Origin:   event.Origin{ID: event.OriginID(planID)},
+				Artifact: "some-output",
+				Clean:    false,
+				Findings: []string{"Eicar-Test-Signature"},
+				Blocked:  true,
+			}))
+		})
+	})
+
+	Describe("SaveTestResults", func() {
+		var (
+			results []atc.TestResult
+			saveErr error
+		)
+
+		BeforeEach(func() {
+			results = []atc.TestResult{
+				{PlanID: planID, Name: "passes", Status: atc.TestStatusPassed},
+				{PlanID: planID, Name: "fails", Status: atc.TestStatusFailed},
+			}
+		})
+
+		JustBeforeEach(func() {
+			saveErr = delegate.SaveTestResults(context.Background(), logger, "some-task", results)
+		})
+
+		It("saves the results to the build", func() {
+			Expect(saveErr).ToNot(HaveOccurred())
+			Expect(fakeBuild.SaveTestResultsCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveTestResultsArgsForCall(0)).To(Equal(results))
+		})
+
+		Context("when saving the results fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveTestResultsReturns(errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				Expect(saveErr).To(MatchError(ContainSubstring("nope")))
+			})
+		})
+
+		Context("when the job has a test_report_webhook", func() {
+			var webhook *ghttp.Server
+
+			BeforeEach(func() {
+				webhook = ghttp.NewServer()
+				webhook.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/hook"),
+					ghttp.VerifyJSONRepresenting(atc.TestReportNotification{
+						BuildID:      42,
+						BuildName:    "7",
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						StepName:     "some-task",
+						Summary:      atc.TestResultsSummary{Total: 2, Passed: 1, Failed: 1},
+					}),
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+
+				fakeJob := new(dbfakes.FakeJob)
+				fakeJob.ConfigReturns(atc.JobConfig{TestReportWebhook: webhook.URL() + "/hook"}, nil)
+				fakeBuild.JobReturns(fakeJob, true, nil)
+				fakeBuild.IDReturns(42)
+				fakeBuild.NameReturns("7")
+				fakeBuild.TeamNameReturns("some-team")
+				fakeBuild.PipelineNameReturns("some-pipeline")
+				fakeBuild.JobNameReturns("some-job")
+			})
+
+			AfterEach(func() {
+				webhook.Close()
+			})
+
+			It("forwards a summary to the webhook", func() {
+				Expect(saveErr).ToNot(HaveOccurred())
+				Expect(webhook.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+
 	Describe("FetchImage", func() {
 		var delegate exec.TaskDelegate
 
@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"golang.org/x/time/rate"
+)
+
+//counterfeiter:generate . TeamRateLimiter
+type TeamRateLimiter interface {
+	Wait(ctx context.Context, teamName string) error
+}
+
+// TeamRateLimits are the number of periodic checks per second each team's
+// resources may run. Teams without a limit are only subject to the
+// cluster-wide RateLimiter.
+type TeamRateLimits struct {
+	Default rate.Limit
+	Teams   map[string]rate.Limit
+}
+
+func (limits TeamRateLimits) For(teamName string) rate.Limit {
+	if limit, found := limits.Teams[teamName]; found {
+		return limit
+	}
+
+	return limits.Default
+}
+
+type teamRateLimiter struct {
+	limits TeamRateLimits
+	clock  clock.Clock
+
+	mut      sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewTeamRateLimiter constructs a TeamRateLimiter which keeps one limiter per
+// team, so that one team's resources can't use up the whole cluster's check
+// budget.
+func NewTeamRateLimiter(limits TeamRateLimits, clock clock.Clock) TeamRateLimiter {
+	return &teamRateLimiter{
+		limits:   limits,
+		clock:    clock,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+func (limiter *teamRateLimiter) Wait(ctx context.Context, teamName string) error {
+	logger := lagerctx.FromContext(ctx)
+
+	teamLimiter := limiter.teamLimiter(teamName)
+	if teamLimiter == nil {
+		return nil
+	}
+
+	reservation := teamLimiter.ReserveN(limiter.clock.Now(), 1)
+
+	delay := reservation.DelayFrom(limiter.clock.Now())
+	if delay == 0 {
+		return nil
+	}
+	logger.Debug("team-rate-limit-exceeded", lager.Data{"team": teamName, "waiting-for": delay.String()})
+
+	timer := limiter.clock.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+func (limiter *teamRateLimiter) teamLimiter(teamName string) *rate.Limiter {
+	limit := limiter.limits.For(teamName)
+	if limit <= 0 {
+		return nil
+	}
+
+	limiter.mut.Lock()
+	defer limiter.mut.Unlock()
+
+	teamLimiter, found := limiter.limiters[teamName]
+	if !found {
+		teamLimiter = rate.NewLimiter(limit, 1)
+		limiter.limiters[teamName] = teamLimiter
+	}
+
+	return teamLimiter
+}
@@ -0,0 +1,71 @@
+package engine_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc/engine"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/time/rate"
+)
+
+var _ = Describe("TeamRateLimiter", func() {
+	var (
+		fakeClock *fakeclock.FakeClock
+		limiter   engine.TeamRateLimiter
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		limiter = engine.NewTeamRateLimiter(engine.TeamRateLimits{
+			Default: 1,
+			Teams: map[string]rate.Limit{
+				"busy-team": 0.5,
+				"free-team": 0,
+			},
+		}, fakeClock)
+	})
+
+	It("does not wait for the first check of a team", func() {
+		Expect(limiter.Wait(context.Background(), "some-team")).To(Succeed())
+	})
+
+	It("waits once a team has used up its limit", func() {
+		Expect(limiter.Wait(context.Background(), "busy-team")).To(Succeed())
+
+		waited := make(chan error)
+		go func() {
+			waited <- limiter.Wait(context.Background(), "busy-team")
+		}()
+
+		fakeClock.WaitForWatcherAndIncrement(time.Second)
+		Consistently(waited).ShouldNot(Receive())
+
+		fakeClock.Increment(time.Second)
+		Eventually(waited).Should(Receive(BeNil()))
+	})
+
+	It("limits each team separately", func() {
+		Expect(limiter.Wait(context.Background(), "some-team")).To(Succeed())
+		Expect(limiter.Wait(context.Background(), "some-other-team")).To(Succeed())
+	})
+
+	It("does not limit teams whose limit is 0", func() {
+		for i := 0; i < 10; i++ {
+			Expect(limiter.Wait(context.Background(), "free-team")).To(Succeed())
+		}
+	})
+
+	Context("when the context is canceled while waiting", func() {
+		It("returns the error", func() {
+			Expect(limiter.Wait(context.Background(), "some-team")).To(Succeed())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			Expect(limiter.Wait(ctx, "some-team")).To(Equal(context.Canceled))
+		})
+	})
+})
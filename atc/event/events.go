@@ -40,6 +40,10 @@ type TaskConfig struct {
 
 	Run    TaskRunConfig     `json:"run"`
 	Inputs []TaskInputConfig `json:"inputs"`
+
+	// OutputVars are kept so that they can be loaded again when the task is
+	// replayed by a build rerun from a later step.
+	OutputVars []atc.TaskOutputVarConfig `json:"output_vars,omitempty"`
 }
 
 type TaskRunConfig struct {
@@ -71,7 +75,8 @@ func ShadowTaskConfig(config atc.TaskConfig) TaskConfig {
 			Args: config.Run.Args,
 			Dir:  config.Run.Dir,
 		},
-		Inputs: inputConfigs,
+		Inputs:     inputConfigs,
+		OutputVars: config.OutputVars,
 	}
 }
 
@@ -258,3 +263,93 @@ type AcrossSubsteps struct {
 
 func (AcrossSubsteps) EventType() atc.EventType  { return EventTypeAcrossSubsteps }
 func (AcrossSubsteps) Version() atc.EventVersion { return "1.0" }
+
+type HookTimeout struct {
+	Time     int64  `json:"time"`
+	Origin   Origin `json:"origin"`
+	Duration string `json:"duration"`
+}
+
+func (HookTimeout) EventType() atc.EventType  { return EventTypeHookTimeout }
+func (HookTimeout) Version() atc.EventVersion { return "1.0" }
+
+type CheckTimeout struct {
+	Time     int64  `json:"time"`
+	Origin   Origin `json:"origin"`
+	Duration string `json:"duration"`
+}
+
+func (CheckTimeout) EventType() atc.EventType  { return EventTypeCheckTimeout }
+func (CheckTimeout) Version() atc.EventVersion { return "1.0" }
+
+type BuildTimeout struct {
+	Time     int64  `json:"time"`
+	Origin   Origin `json:"origin"`
+	Duration string `json:"duration"`
+}
+
+func (BuildTimeout) EventType() atc.EventType  { return EventTypeBuildTimeout }
+func (BuildTimeout) Version() atc.EventVersion { return "1.0" }
+
+type TimeoutWarning struct {
+	Time     int64  `json:"time"`
+	Origin   Origin `json:"origin"`
+	Duration string `json:"duration"`
+}
+
+func (TimeoutWarning) EventType() atc.EventType  { return EventTypeTimeoutWarning }
+func (TimeoutWarning) Version() atc.EventVersion { return "1.0" }
+
+type NotificationSent struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Target string `json:"target"`
+}
+
+func (NotificationSent) EventType() atc.EventType  { return EventTypeNotificationSent }
+func (NotificationSent) Version() atc.EventVersion { return "1.0" }
+
+type ArtifactScanned struct {
+	Time     int64    `json:"time"`
+	Origin   Origin   `json:"origin"`
+	Artifact string   `json:"artifact"`
+	Clean    bool     `json:"clean"`
+	Findings []string `json:"findings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Blocked  bool     `json:"blocked"`
+}
+
+func (ArtifactScanned) EventType() atc.EventType  { return EventTypeArtifactScanned }
+func (ArtifactScanned) Version() atc.EventVersion { return "1.0" }
+
+type ArtifactArchived struct {
+	Time     int64  `json:"time"`
+	Origin   Origin `json:"origin"`
+	Artifact string `json:"artifact"`
+	Location string `json:"location"`
+}
+
+func (ArtifactArchived) EventType() atc.EventType  { return EventTypeArtifactArchived }
+func (ArtifactArchived) Version() atc.EventVersion { return "1.0" }
+
+// StepPhase records how long a step spent in one of its phases, in seconds.
+type StepPhase struct {
+	Time     int64   `json:"time"`
+	Origin   Origin  `json:"origin"`
+	Phase    string  `json:"phase"`
+	Duration float64 `json:"duration"`
+}
+
+func (StepPhase) EventType() atc.EventType  { return EventTypeStepPhase }
+func (StepPhase) Version() atc.EventVersion { return "1.0" }
+
+// Rescheduled records that a step is being re-run from the start on another
+// worker, as the worker running it went away.
+type Rescheduled struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Reason string `json:"reason"`
+}
+
+func (Rescheduled) EventType() atc.EventType  { return EventTypeRescheduled }
+func (Rescheduled) Version() atc.EventVersion { return "1.0" }
@@ -53,6 +53,15 @@ func init() {
 	RegisterEvent(ImageCheck{})
 	RegisterEvent(ImageGet{})
 	RegisterEvent(AcrossSubsteps{})
+	RegisterEvent(HookTimeout{})
+	RegisterEvent(CheckTimeout{})
+	RegisterEvent(BuildTimeout{})
+	RegisterEvent(TimeoutWarning{})
+	RegisterEvent(NotificationSent{})
+	RegisterEvent(ArtifactScanned{})
+	RegisterEvent(ArtifactArchived{})
+	RegisterEvent(StepPhase{})
+	RegisterEvent(Rescheduled{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
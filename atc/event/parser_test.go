@@ -75,5 +75,6 @@ var _ = Describe("ParseEvent", func() {
 		Entry("ImageCheck", event.ImageCheck{}),
 		Entry("ImageGet", event.ImageGet{}),
 		Entry("AcrossSubsteps", event.AcrossSubsteps{}),
+		Entry("Rescheduled", event.Rescheduled{}),
 	)
 })
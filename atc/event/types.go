@@ -67,4 +67,31 @@ const (
 
 	// across step substeps (sent dynamically as of Concourse 7.4)
 	EventTypeAcrossSubsteps atc.EventType = "across-substeps"
+
+	// a step hook was interrupted for exceeding its timeout
+	EventTypeHookTimeout atc.EventType = "hook-timeout"
+
+	// a check was interrupted for exceeding its timeout
+	EventTypeCheckTimeout atc.EventType = "check-timeout"
+
+	// a build was interrupted for exceeding its job's build_timeout
+	EventTypeBuildTimeout atc.EventType = "build-timeout"
+
+	// a step with a timeout has been running for longer than its warn_after
+	EventTypeTimeoutWarning atc.EventType = "timeout-warning"
+
+	// a notify step posted to one of its targets
+	EventTypeNotificationSent atc.EventType = "notification-sent"
+
+	// an artifact was scanned before being used by a step
+	EventTypeArtifactScanned atc.EventType = "artifact-scanned"
+
+	// an output was uploaded to the artifact archive
+	EventTypeArtifactArchived atc.EventType = "artifact-archived"
+
+	// a step finished waiting for a worker, creating its container, or running
+	EventTypeStepPhase atc.EventType = "step-phase"
+
+	// a step is being re-run on another worker as its worker went away
+	EventTypeRescheduled atc.EventType = "rescheduled"
 )
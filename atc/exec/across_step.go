@@ -2,6 +2,7 @@ package exec
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"code.cloudfoundry.org/lager"
@@ -63,17 +64,22 @@ func (step AcrossStep) Run(ctx context.Context, state RunState) (bool, error) {
 			fmt.Fprintf(stderr, "\x1b[1;33mWARNING: across step shadows local var '%s'\x1b[0m\n", v.Var)
 		}
 		var err error
-		varValues[i], err = creds.NewList(state, v.Values).Evaluate()
+		if v.ValuesFrom != nil {
+			varValues[i], err = step.lookupValues(state, delegate, *v.ValuesFrom)
+		} else {
+			varValues[i], err = creds.NewList(state, v.Values).Evaluate()
+		}
 		if err != nil {
 			return false, err
 		}
 	}
-	substeps, err := delegate.ConstructAcrossSubsteps([]byte(step.plan.SubStepTemplate), step.plan.Vars, cartesianProduct(varValues))
+	combinations := step.filterCombinations(cartesianProduct(varValues))
+	substeps, err := delegate.ConstructAcrossSubsteps([]byte(step.plan.SubStepTemplate), step.plan.Vars, combinations)
 	if err != nil {
 		return false, err
 	}
 
-	exec := step.acrossStepExecutor(state, varValues, 0, substeps)
+	exec := step.acrossStepExecutor(state, 0, substeps)
 	succeeded, err := exec.run(ctx)
 	if err != nil {
 		return false, err
@@ -84,27 +90,158 @@ func (step AcrossStep) Run(ctx context.Context, state RunState) (bool, error) {
 	return succeeded, nil
 }
 
-func (step AcrossStep) acrossStepExecutor(state RunState, varValues [][]interface{}, varIndex int, steps []atc.VarScopedPlan) parallelExecutor {
+// lookupValues fetches the values of a var configured with values_from.
+func (step AcrossStep) lookupValues(state RunState, delegate BuildStepDelegate, from atc.AcrossValuesFrom) ([]interface{}, error) {
+	limit := from.Limit
+	if limit == 0 {
+		limit = atc.DefaultAcrossValuesLimit
+	}
+
+	var values []interface{}
+
+	if from.Resource != "" {
+		versions, err := delegate.ListResourceVersions(from.Resource, limit)
+		if err != nil {
+			return nil, fmt.Errorf("list versions of resource '%s': %w", from.Resource, err)
+		}
+
+		for _, version := range versions {
+			value := map[string]interface{}{}
+			for k, v := range version {
+				value[k] = v
+			}
+
+			values = append(values, value)
+		}
+
+		return values, nil
+	}
+
+	ref := vars.Reference{Source: from.VarSource, Path: from.Var}
+	val, found, err := state.Get(ref)
+	if err != nil {
+		return nil, fmt.Errorf("get var '%s': %w", ref, err)
+	}
+
+	if !found {
+		return nil, vars.UndefinedVarsError{Vars: []string{ref.String()}}
+	}
+
+	list, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("var '%s' must be a list, got %T", ref, val)
+	}
+
+	for _, value := range list {
+		if len(values) == limit {
+			break
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// filterCombinations drops the combinations matched by the plan's exclude
+// rules and appends the plan's include rules that aren't already present.
+func (step AcrossStep) filterCombinations(combinations [][]interface{}) [][]interface{} {
+	var filtered [][]interface{}
+	for _, values := range combinations {
+		excluded := false
+		for _, exclude := range step.plan.Exclude {
+			if step.combinationMatches(exclude, values) {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			filtered = append(filtered, values)
+		}
+	}
+
+	for _, include := range step.plan.Include {
+		values := make([]interface{}, len(step.plan.Vars))
+		for i, v := range step.plan.Vars {
+			values[i] = include[v.Var]
+		}
+
+		present := false
+		for _, existing := range filtered {
+			if step.combinationMatches(include, existing) {
+				present = true
+				break
+			}
+		}
+
+		if !present {
+			filtered = append(filtered, values)
+		}
+	}
+
+	return filtered
+}
+
+// combinationMatches returns whether every var set in the combination has
+// the same value in values. Values are compared by their JSON encoding, as
+// they may have been decoded differently (e.g. numbers).
+func (step AcrossStep) combinationMatches(combination atc.AcrossCombination, values []interface{}) bool {
+	for i, v := range step.plan.Vars {
+		expected, found := combination[v.Var]
+		if !found {
+			continue
+		}
+
+		if valueKey(expected) != valueKey(values[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func valueKey(val interface{}) string {
+	payload, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%#v", val)
+	}
+
+	return string(payload)
+}
+
+// acrossStepExecutor runs the substeps grouped by the value of the var at
+// varIndex, so that each var's max_in_flight limits how many of its values
+// are in flight at once.
+func (step AcrossStep) acrossStepExecutor(state RunState, varIndex int, steps []atc.VarScopedPlan) parallelExecutor {
 	if varIndex == len(step.plan.Vars)-1 {
 		return step.acrossStepLeafExecutor(state, steps)
 	}
-	stepsPerValue := 1
-	for i := varIndex + 1; i < len(step.plan.Vars); i++ {
-		stepsPerValue *= len(varValues[i])
+
+	var groups [][]atc.VarScopedPlan
+	groupIndex := map[string]int{}
+	for _, substep := range steps {
+		key := valueKey(substep.Values[varIndex])
+
+		i, found := groupIndex[key]
+		if !found {
+			i = len(groups)
+			groupIndex[key] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], substep)
 	}
-	numValues := len(varValues[varIndex])
+
 	return parallelExecutor{
 		stepName: "across",
 
 		maxInFlight: step.plan.Vars[varIndex].MaxInFlight,
 		failFast:    step.plan.FailFast,
-		count:       numValues,
+		count:       len(groups),
 
 		runFunc: func(ctx context.Context, i int) (bool, error) {
-			startIndex := i * stepsPerValue
-			endIndex := (i + 1) * stepsPerValue
-			substeps := steps[startIndex:endIndex]
-			return step.acrossStepExecutor(state, varValues, varIndex+1, substeps).run(ctx)
+			return step.acrossStepExecutor(state, varIndex+1, groups[i]).run(ctx)
 		},
 	}
 }
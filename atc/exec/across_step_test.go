@@ -2,10 +2,13 @@ package exec_test
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dummy"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/vars"
@@ -165,6 +168,8 @@ var _ = Describe("AcrossStep", func() {
 		fakeDelegate.ConstructAcrossSubstepsReturns(plans, nil)
 
 		plan.FailFast = false
+		plan.Exclude = nil
+		plan.Include = nil
 	})
 
 	AfterEach(func() {
@@ -242,6 +247,136 @@ var _ = Describe("AcrossStep", func() {
 		}))
 	})
 
+	Context("when combinations are excluded and included", func() {
+		BeforeEach(func() {
+			plan.Exclude = []atc.AcrossCombination{
+				{"var1": "a2"},
+				{"var2": "b2", "var3": "c3"},
+			}
+			plan.Include = []atc.AcrossCombination{
+				{"var1": "a3", "var2": "b1", "var3": "c1", "var4": "d1"},
+				{"var1": "a1", "var2": "b1", "var3": "c1", "var4": "d1"},
+			}
+		})
+
+		It("filters the combinations of var values", func() {
+			step.Run(ctx, state)
+
+			_, _, valueCombinations := fakeDelegate.ConstructAcrossSubstepsArgsForCall(0)
+			Expect(valueCombinations).To(Equal([][]interface{}{
+				{"a1", "b1", "c1", "d1"},
+				{"a1", "b1", "c2", "d1"},
+				{"a1", "b1", "c3", "d1"},
+
+				{"a1", "b2", "c1", "d1"},
+				{"a1", "b2", "c2", "d1"},
+
+				{"a3", "b1", "c1", "d1"},
+			}))
+		})
+	})
+
+	Context("when var values are looked up at runtime", func() {
+		BeforeEach(func() {
+			// var sources are backed by secrets which can only be fetched by
+			// name, and never listed
+			state = exec.NewRunState(stepper, vars.NamedVariables{
+				"some-source": creds.NewVariables(&dummy.Secrets{
+					StaticVariables: vars.StaticVariables{"branches": []interface{}{"b1", "b2"}},
+				}, "some-team", "some-pipeline", false),
+				"other-source": creds.NewVariables(&dummy.Secrets{
+					StaticVariables: vars.StaticVariables{"branches": []interface{}{"b3"}},
+				}, "some-team", "some-pipeline", false),
+			}, false)
+
+			plan.Vars[0].Values = nil
+			plan.Vars[0].ValuesFrom = &atc.AcrossValuesFrom{Resource: "some-resource", Limit: 5}
+
+			plan.Vars[1].Values = nil
+			plan.Vars[1].ValuesFrom = &atc.AcrossValuesFrom{VarSource: "some-source", Var: "branches"}
+
+			fakeDelegate.ListResourceVersionsReturns([]atc.Version{
+				{"ref": "a1"},
+				{"ref": "a2"},
+			}, nil)
+		})
+
+		It("lists the versions of the resource", func() {
+			step.Run(ctx, state)
+
+			Expect(fakeDelegate.ListResourceVersionsCallCount()).To(Equal(1))
+			resource, limit := fakeDelegate.ListResourceVersionsArgsForCall(0)
+			Expect(resource).To(Equal("some-resource"))
+			Expect(limit).To(Equal(5))
+		})
+
+		It("uses the versions and the elements of the var as values", func() {
+			step.Run(ctx, state)
+
+			_, _, valueCombinations := fakeDelegate.ConstructAcrossSubstepsArgsForCall(0)
+			Expect(valueCombinations).To(HaveLen(12))
+			Expect(valueCombinations[0]).To(Equal([]interface{}{
+				map[string]interface{}{"ref": "a1"}, "b1", "c1", "d1",
+			}))
+			Expect(valueCombinations[11]).To(Equal([]interface{}{
+				map[string]interface{}{"ref": "a2"}, "b2", "c3", "d1",
+			}))
+		})
+
+		Context("when listing the versions fails", func() {
+			BeforeEach(func() {
+				fakeDelegate.ListResourceVersionsReturns(nil, errors.New("nope"))
+			})
+
+			It("errors", func() {
+				_, err := step.Run(ctx, state)
+				Expect(err).To(MatchError("list versions of resource 'some-resource': nope"))
+			})
+		})
+
+		Context("when the var has more elements than the limit", func() {
+			BeforeEach(func() {
+				plan.Vars[1].ValuesFrom.Limit = 1
+			})
+
+			It("only uses the first elements", func() {
+				step.Run(ctx, state)
+
+				_, _, valueCombinations := fakeDelegate.ConstructAcrossSubstepsArgsForCall(0)
+				Expect(valueCombinations).To(HaveLen(6))
+				for _, values := range valueCombinations {
+					Expect(values[1]).To(Equal("b1"))
+				}
+			})
+		})
+
+		Context("when the var is not found", func() {
+			BeforeEach(func() {
+				plan.Vars[1].ValuesFrom.Var = "bogus"
+			})
+
+			It("errors", func() {
+				_, err := step.Run(ctx, state)
+				Expect(err).To(MatchError(vars.UndefinedVarsError{Vars: []string{"some-source:bogus"}}))
+			})
+		})
+
+		Context("when the var is not a list", func() {
+			BeforeEach(func() {
+				state = exec.NewRunState(stepper, vars.NamedVariables{
+					"some-source": creds.NewVariables(&dummy.Secrets{
+						StaticVariables: vars.StaticVariables{"branches": "b1"},
+					}, "some-team", "some-pipeline", false),
+				}, false)
+			})
+
+			It("errors", func() {
+				_, err := step.Run(ctx, state)
+				Expect(err).To(MatchError("var 'some-source:branches' must be a list, got string"))
+			})
+		})
+	})
+
 	Describe("parallel execution", func() {
 		BeforeEach(func() {
 			for _, v := range allVals {
@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/artifactstore"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
 )
@@ -19,17 +20,27 @@ func (e ArtifactVolumeNotFoundError) Error() string {
 	return fmt.Sprintf("volume for worker artifact '%s' not found", e.ArtifactName)
 }
 
+type ArtifactNotStoredError struct {
+	ArtifactName string
+}
+
+func (e ArtifactNotStoredError) Error() string {
+	return fmt.Sprintf("worker artifact '%s' is kept in an artifact store, but no artifact store is configured", e.ArtifactName)
+}
+
 type ArtifactInputStep struct {
 	plan       atc.Plan
 	build      db.Build
 	workerPool Pool
+	store      artifactstore.Store
 }
 
-func NewArtifactInputStep(plan atc.Plan, build db.Build, workerPool Pool) Step {
+func NewArtifactInputStep(plan atc.Plan, build db.Build, workerPool Pool, store artifactstore.Store) Step {
 	return &ArtifactInputStep{
 		plan:       plan,
 		build:      build,
 		workerPool: workerPool,
+		store:      store,
 	}
 }
 
@@ -43,6 +54,10 @@ func (step *ArtifactInputStep) Run(ctx context.Context, state RunState) (bool, e
 		return false, err
 	}
 
+	if buildArtifact.StoreKey() != "" {
+		return step.registerStoredArtifact(logger, state, buildArtifact)
+	}
+
 	// TODO (runtime/#3607): artifact_input_step shouldn't know about db Volumem
 	//		has a runState with artifact repo. We could use that.
 	createdVolume, found, err := buildArtifact.Volume(step.build.TeamID())
@@ -73,3 +88,27 @@ func (step *ArtifactInputStep) Run(ctx context.Context, state RunState) (bool, e
 
 	return true, nil
 }
+
+// registerStoredArtifact registers an artifact kept in the artifact store,
+// which is streamed from the store to the steps using it.
+func (step *ArtifactInputStep) registerStoredArtifact(logger lager.Logger, state RunState, buildArtifact db.WorkerArtifact) (bool, error) {
+	if buildArtifact.TeamID() != step.build.TeamID() {
+		return false, ArtifactVolumeNotFoundError{buildArtifact.Name()}
+	}
+
+	if step.store == nil {
+		return false, ArtifactNotStoredError{buildArtifact.Name()}
+	}
+
+	logger.Info("register-stored-artifact-source", lager.Data{
+		"artifact_id": buildArtifact.ID(),
+		"store_key":   buildArtifact.StoreKey(),
+	})
+
+	state.ArtifactRepository().RegisterArtifact(build.ArtifactName(step.plan.ArtifactInput.Name), artifactstore.Artifact{
+		Store: step.store,
+		Key:   buildArtifact.StoreKey(),
+	})
+
+	return true, nil
+}
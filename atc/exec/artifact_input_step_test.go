@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/artifactstore"
+	"github.com/concourse/concourse/atc/artifactstore/artifactstorefakes"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
@@ -28,6 +30,7 @@ var _ = Describe("ArtifactInputStep", func() {
 		plan           atc.Plan
 		fakeBuild      *dbfakes.FakeBuild
 		fakeWorkerPool *execfakes.FakePool
+		store          artifactstore.Store
 	)
 
 	BeforeEach(func() {
@@ -37,9 +40,9 @@ var _ = Describe("ArtifactInputStep", func() {
 
 		fakeBuild = new(dbfakes.FakeBuild)
 		fakeWorkerPool = new(execfakes.FakePool)
+		store = nil
 
 		plan = atc.Plan{ArtifactInput: &atc.ArtifactInputPlan{34, "some-input-artifact-name"}}
-		step = exec.NewArtifactInputStep(plan, fakeBuild, fakeWorkerPool)
 	})
 
 	AfterEach(func() {
@@ -47,6 +50,7 @@ var _ = Describe("ArtifactInputStep", func() {
 	})
 
 	JustBeforeEach(func() {
+		step = exec.NewArtifactInputStep(plan, fakeBuild, fakeWorkerPool, store)
 		stepOk, stepErr = step.Run(ctx, state)
 	})
 
@@ -138,5 +142,59 @@ var _ = Describe("ArtifactInputStep", func() {
 				})
 			})
 		})
+
+		Context("when the artifact is kept in the artifact store", func() {
+			BeforeEach(func() {
+				fakeBuild.TeamIDReturns(4)
+
+				fakeWorkerArtifact.NameReturns("some-input-artifact-name")
+				fakeWorkerArtifact.StoreKeyReturns("artifacts/some-key.tgz")
+				fakeWorkerArtifact.TeamIDReturns(4)
+			})
+
+			Context("when an artifact store is configured", func() {
+				var fakeStore *artifactstorefakes.FakeStore
+
+				BeforeEach(func() {
+					fakeStore = new(artifactstorefakes.FakeStore)
+					store = fakeStore
+				})
+
+				It("registers the stored artifact", func() {
+					artifact, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName("some-input-artifact-name"))
+					Expect(found).To(BeTrue())
+					Expect(artifact).To(Equal(artifactstore.Artifact{
+						Store: fakeStore,
+						Key:   "artifacts/some-key.tgz",
+					}))
+				})
+
+				It("does not look up a volume", func() {
+					Expect(fakeWorkerArtifact.VolumeCallCount()).To(BeZero())
+					Expect(fakeWorkerPool.LocateVolumeCallCount()).To(BeZero())
+				})
+
+				It("succeeds", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+
+				Context("when the artifact belongs to another team", func() {
+					BeforeEach(func() {
+						fakeWorkerArtifact.TeamIDReturns(5)
+					})
+
+					It("returns an error", func() {
+						Expect(stepErr).To(Equal(exec.ArtifactVolumeNotFoundError{ArtifactName: "some-input-artifact-name"}))
+					})
+				})
+			})
+
+			Context("when no artifact store is configured", func() {
+				It("returns an error", func() {
+					Expect(stepErr).To(Equal(exec.ArtifactNotStoredError{ArtifactName: "some-input-artifact-name"}))
+				})
+			})
+		})
 	})
 })
@@ -7,6 +7,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/artifactstore"
+	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/runtime"
@@ -32,13 +34,15 @@ type ArtifactOutputStep struct {
 	plan       atc.Plan
 	build      db.Build
 	workerPool Pool
+	store      artifactstore.Store
 }
 
-func NewArtifactOutputStep(plan atc.Plan, build db.Build, workerPool Pool) Step {
+func NewArtifactOutputStep(plan atc.Plan, build db.Build, workerPool Pool, store artifactstore.Store) Step {
 	return &ArtifactOutputStep{
 		plan:       plan,
 		build:      build,
 		workerPool: workerPool,
+		store:      store,
 	}
 }
 
@@ -59,6 +63,10 @@ func (step *ArtifactOutputStep) Run(ctx context.Context, state RunState) (bool,
 		return false, ArtifactNotVolumeError{outputName}
 	}
 
+	if step.store != nil {
+		return step.storeArtifact(ctx, logger, outputName, volume)
+	}
+
 	dbWorkerArtifact, err := volume.DBVolume().InitializeArtifact(outputName, step.build.ID())
 	if err != nil {
 		return false, err
@@ -71,3 +79,37 @@ func (step *ArtifactOutputStep) Run(ctx context.Context, state RunState) (bool,
 
 	return true, nil
 }
+
+// storeArtifact uploads the contents of the volume to the artifact store, so
+// that the artifact outlives the volume.
+func (step *ArtifactOutputStep) storeArtifact(ctx context.Context, logger lager.Logger, outputName string, volume runtime.Volume) (bool, error) {
+	key, err := artifactstore.NewKey()
+	if err != nil {
+		return false, err
+	}
+
+	out, err := volume.StreamOut(ctx, ".", compression.NewGzipCompression())
+	if err != nil {
+		return false, err
+	}
+
+	defer out.Close()
+
+	err = step.store.Put(ctx, key, out)
+	if err != nil {
+		return false, fmt.Errorf("store artifact: %w", err)
+	}
+
+	dbWorkerArtifact, err := step.build.SaveStoredArtifact(outputName, key)
+	if err != nil {
+		return false, err
+	}
+
+	logger.Info("store-artifact-from-source", lager.Data{
+		"handle":      volume.Handle(),
+		"artifact_id": dbWorkerArtifact.ID(),
+		"store_key":   key,
+	})
+
+	return true, nil
+}
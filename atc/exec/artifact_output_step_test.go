@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/artifactstore"
+	"github.com/concourse/concourse/atc/artifactstore/artifactstorefakes"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
@@ -28,6 +30,7 @@ var _ = Describe("ArtifactOutputStep", func() {
 		plan           atc.Plan
 		fakeBuild      *dbfakes.FakeBuild
 		fakeWorkerPool *execfakes.FakePool
+		store          artifactstore.Store
 
 		artifactName string
 	)
@@ -41,6 +44,7 @@ var _ = Describe("ArtifactOutputStep", func() {
 		fakeBuild.TeamIDReturns(4)
 
 		fakeWorkerPool = new(execfakes.FakePool)
+		store = nil
 
 		artifactName = "some-artifact-name"
 	})
@@ -52,7 +56,7 @@ var _ = Describe("ArtifactOutputStep", func() {
 	JustBeforeEach(func() {
 		plan = atc.Plan{ArtifactOutput: &atc.ArtifactOutputPlan{Name: artifactName}}
 
-		step = exec.NewArtifactOutputStep(plan, fakeBuild, fakeWorkerPool)
+		step = exec.NewArtifactOutputStep(plan, fakeBuild, fakeWorkerPool, store)
 		stepOk, stepErr = step.Run(ctx, state)
 	})
 
@@ -95,5 +99,50 @@ var _ = Describe("ArtifactOutputStep", func() {
 				Expect(stepOk).To(BeTrue())
 			})
 		})
+
+		Context("when an artifact store is configured", func() {
+			var fakeStore *artifactstorefakes.FakeStore
+
+			BeforeEach(func() {
+				fakeStore = new(artifactstorefakes.FakeStore)
+				store = fakeStore
+
+				fakeBuild.SaveStoredArtifactReturns(new(dbfakes.FakeWorkerArtifact), nil)
+			})
+
+			It("uploads the contents of the volume to the store", func() {
+				Expect(fakeStore.PutCallCount()).To(Equal(1))
+				_, key, _ := fakeStore.PutArgsForCall(0)
+				Expect(key).To(HavePrefix("artifacts/"))
+			})
+
+			It("saves the stored artifact for the build", func() {
+				Expect(fakeBuild.SaveStoredArtifactCallCount()).To(Equal(1))
+				name, key := fakeBuild.SaveStoredArtifactArgsForCall(0)
+				Expect(name).To(Equal(artifactName))
+
+				_, putKey, _ := fakeStore.PutArgsForCall(0)
+				Expect(key).To(Equal(putKey))
+			})
+
+			It("does not initialize an artifact on the volume", func() {
+				Expect(volume.DBVolume_.InitializeArtifactCallCount()).To(BeZero())
+			})
+
+			It("succeeds", func() {
+				Expect(stepOk).To(BeTrue())
+			})
+
+			Context("when uploading fails", func() {
+				BeforeEach(func() {
+					fakeStore.PutReturns(errors.New("nope"))
+				})
+
+				It("returns the error without saving the artifact", func() {
+					Expect(stepErr).To(MatchError(ContainSubstring("nope")))
+					Expect(fakeBuild.SaveStoredArtifactCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
@@ -0,0 +1,80 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/scanner"
+)
+
+// artifactScanReporter records the outcome of scanning a step's artifacts.
+type artifactScanReporter interface {
+	ArtifactScanned(lager.Logger, scanner.Report)
+}
+
+type scannableArtifact struct {
+	name     string
+	artifact runtime.Artifact
+}
+
+// scanArtifacts streams each artifact through the scanner. Flagged artifacts,
+// and artifacts which could not be scanned, fail the step unless the scan
+// action is set to warn.
+func scanArtifacts(ctx context.Context, logger lager.Logger, artifactScanner scanner.Scanner, action scanner.Action, reporter artifactScanReporter, artifacts []scannableArtifact) error {
+	block := action != scanner.ActionWarn
+
+	for _, a := range artifacts {
+		result, err := scanArtifact(ctx, artifactScanner, a.name, a.artifact)
+		if err != nil {
+			reporter.ArtifactScanned(logger, scanner.Report{
+				Artifact: a.name,
+				Error:    err,
+				Blocked:  block,
+			})
+
+			if block {
+				return fmt.Errorf("scan artifact %s: %w", a.name, err)
+			}
+
+			logger.Error("failed-to-scan-artifact", err, lager.Data{"artifact": a.name})
+			continue
+		}
+
+		blocked := block && !result.Clean
+		reporter.ArtifactScanned(logger, scanner.Report{
+			Artifact: a.name,
+			Result:   result,
+			Blocked:  blocked,
+		})
+
+		if blocked {
+			return scanner.BlockedError{
+				Artifact: a.name,
+				Findings: result.Findings,
+			}
+		}
+	}
+
+	return nil
+}
+
+func scanArtifact(ctx context.Context, artifactScanner scanner.Scanner, name string, artifact runtime.Artifact) (scanner.Result, error) {
+	gzipCompression := compression.NewGzipCompression()
+
+	stream, err := artifact.StreamOut(ctx, ".", gzipCompression)
+	if err != nil {
+		return scanner.Result{}, err
+	}
+	defer stream.Close()
+
+	tarStream, err := gzipCompression.NewReader(stream)
+	if err != nil {
+		return scanner.Result{}, err
+	}
+	defer tarStream.Close()
+
+	return artifactScanner.Scan(ctx, name, tarStream)
+}
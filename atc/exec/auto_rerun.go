@@ -0,0 +1,38 @@
+package exec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ImageFetchError is returned when a step is unable to fetch the image it is
+// configured to run with.
+type ImageFetchError struct {
+	Cause error
+}
+
+func (e ImageFetchError) Error() string {
+	return fmt.Sprintf("fetch image: %s", e.Cause)
+}
+
+func (e ImageFetchError) Unwrap() error {
+	return e.Cause
+}
+
+// AutoRerunTriggers classifies the error a build errored with into the
+// triggers which can be configured to automatically rerun the build.
+func AutoRerunTriggers(err error) []atc.AutoRerunTrigger {
+	var triggers []atc.AutoRerunTrigger
+
+	if errors.As(err, &ImageFetchError{}) {
+		triggers = append(triggers, atc.AutoRerunOnImageFetchFailed)
+	}
+
+	if IsWorkerLostError(err) {
+		triggers = append(triggers, atc.AutoRerunOnWorkerLost)
+	}
+
+	return triggers
+}
@@ -0,0 +1,39 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AutoRerunTriggers", func() {
+	It("classifies image fetch failures", func() {
+		err := fmt.Errorf("run step: %w", ImageFetchError{Cause: errors.New("nope")})
+		Expect(AutoRerunTriggers(err)).To(ConsistOf(atc.AutoRerunOnImageFetchFailed))
+	})
+
+	It("classifies lost workers", func() {
+		err := fmt.Errorf("run step: %w", transport.WorkerMissingError{WorkerName: "some-worker"})
+		Expect(AutoRerunTriggers(err)).To(ConsistOf(atc.AutoRerunOnWorkerLost))
+	})
+
+	It("classifies image fetch failures caused by lost workers", func() {
+		err := ImageFetchError{Cause: transport.WorkerMissingError{WorkerName: "some-worker"}}
+		Expect(AutoRerunTriggers(err)).To(ConsistOf(atc.AutoRerunOnImageFetchFailed, atc.AutoRerunOnWorkerLost))
+	})
+
+	It("does not classify other errors", func() {
+		Expect(AutoRerunTriggers(errors.New("nope"))).To(BeEmpty())
+	})
+
+	It("preserves cancellation of image fetches", func() {
+		err := ImageFetchError{Cause: context.Canceled}
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+	})
+})
@@ -25,6 +25,8 @@ type Repository struct {
 	repoL sync.RWMutex
 
 	parent *Repository
+
+	onRegister func(ArtifactName)
 }
 
 // NewRepository constructs a new repository.
@@ -41,6 +43,16 @@ func (repo *Repository) RegisterArtifact(name ArtifactName, artifact runtime.Art
 	repo.repoL.Lock()
 	repo.repo[name] = artifact
 	repo.repoL.Unlock()
+
+	if repo.onRegister != nil {
+		repo.onRegister(name)
+	}
+}
+
+// OnRegister sets a function to be called whenever an artifact is registered
+// in the repository or any of its local scopes created afterwards.
+func (repo *Repository) OnRegister(fn func(ArtifactName)) {
+	repo.onRegister = fn
 }
 
 // ArtifactFor looks up the Artifact for a given ArtifactName. Consumers of
@@ -76,9 +88,24 @@ func (repo *Repository) AsMap() map[ArtifactName]runtime.Artifact {
 	return result
 }
 
+// LocalAsMap extracts the artifacts registered in this scope of the
+// ArtifactRepository into a new map, leaving out those of its parent.
+func (repo *Repository) LocalAsMap() map[ArtifactName]runtime.Artifact {
+	result := make(map[ArtifactName]runtime.Artifact)
+
+	repo.repoL.RLock()
+	for name, artifact := range repo.repo {
+		result[name] = artifact
+	}
+	repo.repoL.RUnlock()
+
+	return result
+}
+
 func (repo *Repository) NewLocalScope() *Repository {
 	child := NewRepository()
 	child.parent = repo
+	child.onRegister = repo.onRegister
 	return child
 }
 
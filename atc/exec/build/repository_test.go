@@ -80,6 +80,12 @@ var _ = Describe("ArtifactRepository", func() {
 						"first-artifact": Artifact("first"),
 					}))
 				})
+
+				It("is the only artifact local to the child", func() {
+					Expect(child.LocalAsMap()).To(Equal(map[ArtifactName]runtime.Artifact{
+						"second-artifact": Artifact("second"),
+					}))
+				})
 			})
 
 			Context("when an artifact is overridden", func() {
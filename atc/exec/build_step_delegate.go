@@ -3,6 +3,7 @@ package exec
 import (
 	"context"
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"go.opentelemetry.io/otel/trace"
@@ -13,6 +14,30 @@ import (
 	"github.com/concourse/concourse/tracing"
 )
 
+// StepPhase is a phase of a step which runs on a worker. The delegate is told
+// how long each phase took once it is over, so that build time can be broken
+// down into time spent queued, setting up, and actually running.
+type StepPhase string
+
+const (
+	StepPhaseWaitingForWorker  StepPhase = "waiting-for-worker"
+	StepPhaseCreatingContainer StepPhase = "creating-container"
+	StepPhaseRunning           StepPhase = "running"
+)
+
+type phaseDelegate interface {
+	PhaseFinished(lager.Logger, StepPhase, time.Duration)
+}
+
+// startPhase returns a func which reports the phase to the delegate as
+// finished, along with the time since startPhase was called.
+func startPhase(logger lager.Logger, delegate phaseDelegate, phase StepPhase) func() {
+	start := time.Now()
+	return func() {
+		delegate.PhaseFinished(logger, phase, time.Since(start))
+	}
+}
+
 //counterfeiter:generate . BuildStepDelegateFactory
 type BuildStepDelegateFactory interface {
 	BuildStepDelegate(state RunState) BuildStepDelegate
@@ -31,11 +56,17 @@ type BuildStepDelegate interface {
 	Starting(lager.Logger)
 	Finished(lager.Logger, bool)
 	Errored(lager.Logger, string)
+	HookTimedOut(lager.Logger, time.Duration)
+	BuildTimedOut(lager.Logger, time.Duration)
+	TimeoutWarning(lager.Logger, time.Duration)
+	Rescheduled(lager.Logger, string)
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+	PhaseFinished(lager.Logger, StepPhase, time.Duration)
 
 	ConstructAcrossSubsteps([]byte, []atc.AcrossVar, [][]interface{}) ([]atc.VarScopedPlan, error)
+	ListResourceVersions(string, int) ([]atc.Version, error)
 }
 
 //counterfeiter:generate . SetPipelineStepDelegateFactory
@@ -48,4 +79,16 @@ type SetPipelineStepDelegate interface {
 	BuildStepDelegate
 	SetPipelineChanged(lager.Logger, bool)
 	CheckRunSetPipelinePolicy(*atc.Config) error
+	CheckSetPipelineAcrossTeamsPolicy(string, string) (bool, error)
+}
+
+//counterfeiter:generate . NotifyDelegateFactory
+type NotifyDelegateFactory interface {
+	NotifyDelegate(state RunState) NotifyDelegate
+}
+
+//counterfeiter:generate . NotifyDelegate
+type NotifyDelegate interface {
+	BuildStepDelegate
+	NotificationSent(lager.Logger, string)
 }
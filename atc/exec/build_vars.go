@@ -77,6 +77,30 @@ func (b *buildVariables) AddLocalVar(name string, val interface{}, redact bool)
 	}
 }
 
+// AddLocalVarField sets a field of a local var holding a map, keeping the
+// fields already set on it in this or any parent scope.
+func (b *buildVariables) AddLocalVarField(name string, field string, val interface{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	existing, found := b.localVars[name]
+	if !found {
+		if parent, ok := b.parentScope.(*buildVariables); ok {
+			existing, _, _ = parent.Get(vars.Reference{Source: ".", Path: name})
+		}
+	}
+
+	fields := map[string]interface{}{}
+	if existingFields, ok := existing.(map[string]interface{}); ok {
+		for k, v := range existingFields {
+			fields[k] = v
+		}
+	}
+
+	fields[field] = val
+	b.localVars[name] = fields
+}
+
 func (b *buildVariables) RedactionEnabled() bool {
 	return b.tracker.Enabled
 }
@@ -43,6 +43,7 @@ type CheckDelegate interface {
 	FindOrCreateScope(db.ResourceConfig) (db.ResourceConfigScope, error)
 	WaitToRun(context.Context, db.ResourceConfigScope) (lock.Lock, bool, error)
 	PointToCheckedConfig(db.ResourceConfigScope) error
+	CheckTimedOut(lager.Logger, time.Duration)
 }
 
 func NewCheckStep(
@@ -164,9 +165,21 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 			}
 		}
 
+		// resources with an identical config in other pipelines or teams have
+		// their own scopes, but there's no need for them to run the same check
+		siblings, err := scope.SiblingScopes()
+		if err != nil {
+			return false, fmt.Errorf("get sibling scopes: %w", err)
+		}
+
+		scopes := append([]db.ResourceConfigScope{scope}, siblings...)
+
 		metric.Metrics.ChecksStarted.Inc()
 
-		_, err = scope.UpdateLastCheckStartTime()
+		err = forEachScope(scopes, func(scope db.ResourceConfigScope) error {
+			_, err := scope.UpdateLastCheckStartTime()
+			return err
+		})
 		if err != nil {
 			return false, fmt.Errorf("update check start time: %w", err)
 		}
@@ -175,16 +188,29 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 		if runErr != nil || processResult.ExitStatus != 0 {
 			metric.Metrics.ChecksFinishedWithError.Inc()
 
-			if _, err := scope.UpdateLastCheckEndTime(false); err != nil {
+			err := forEachScope(scopes, func(scope db.ResourceConfigScope) error {
+				_, err := scope.UpdateLastCheckEndTime(false)
+				return err
+			})
+			if err != nil {
 				return false, fmt.Errorf("update check end time: %w", err)
 			}
 
+			checkErr := checkErrorMessage(runErr, processResult, timeout)
+			err = forEachScope(scopes, func(scope db.ResourceConfigScope) error {
+				_, err := scope.UpdateLastCheckError(checkErr)
+				return err
+			})
+			if err != nil {
+				return false, fmt.Errorf("update check error: %w", err)
+			}
+
 			if err := delegate.PointToCheckedConfig(scope); err != nil {
 				return false, fmt.Errorf("update resource config scope: %w", err)
 			}
 
 			if errors.Is(runErr, context.DeadlineExceeded) {
-				delegate.Errored(logger, TimeoutLogMessage)
+				delegate.CheckTimedOut(logger, timeout)
 				return false, nil
 			}
 
@@ -198,7 +224,9 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 
 		metric.Metrics.ChecksFinishedWithSuccess.Inc()
 
-		err = scope.SaveVersions(db.NewSpanContext(ctx), versions)
+		err = forEachScope(scopes, func(scope db.ResourceConfigScope) error {
+			return scope.SaveVersions(db.NewSpanContext(ctx), versions)
+		})
 		if err != nil {
 			return false, fmt.Errorf("save versions: %w", err)
 		}
@@ -207,7 +235,10 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 			state.StoreResult(step.planID, versions[len(versions)-1])
 		}
 
-		_, err = scope.UpdateLastCheckEndTime(true)
+		err = forEachScope(scopes, func(scope db.ResourceConfigScope) error {
+			_, err := scope.UpdateLastCheckEndTime(true)
+			return err
+		})
 		if err != nil {
 			return false, fmt.Errorf("update check end time: %w", err)
 		}
@@ -252,9 +283,11 @@ func (step *CheckStep) runCheck(
 	}
 
 	containerSpec := runtime.ContainerSpec{
-		TeamID:   step.metadata.TeamID,
-		TeamName: step.metadata.TeamName,
-		JobID:    step.metadata.JobID,
+		TeamID:       step.metadata.TeamID,
+		TeamName:     step.metadata.TeamName,
+		PipelineID:   step.metadata.PipelineID,
+		PipelineName: step.metadata.PipelineName,
+		JobID:        step.metadata.JobID,
 
 		ImageSpec: imageSpec,
 		Env:       step.metadata.Env(),
@@ -264,13 +297,19 @@ func (step *CheckStep) runCheck(
 
 		CertsBindMount: true,
 	}
+	if step.plan.Limits != nil {
+		containerSpec.Limits.CPU = (*uint64)(step.plan.Limits.CPU)
+		containerSpec.Limits.Memory = (*uint64)(step.plan.Limits.Memory)
+	}
 	tracing.Inject(ctx, &containerSpec)
 
 	containerOwner := step.containerOwner(resourceConfig)
-	worker, err := step.workerPool.FindOrSelectWorker(ctx, containerOwner, containerSpec, workerSpec, step.strategy, delegate)
+	finishWaiting := startPhase(logger, delegate, StepPhaseWaitingForWorker)
+	worker, err := step.selectWorker(ctx, logger, containerOwner, containerSpec, workerSpec, delegate)
 	if err != nil {
 		return nil, runtime.ProcessResult{}, err
 	}
+	finishWaiting()
 
 	delegate.SelectedWorker(logger, worker.Name())
 
@@ -288,18 +327,113 @@ func (step *CheckStep) runCheck(
 
 	defer cancel()
 
+	finishCreating := startPhase(logger, delegate, StepPhaseCreatingContainer)
 	container, _, err := worker.FindOrCreateContainer(ctx, containerOwner, step.containerMetadata, containerSpec)
 	if err != nil {
 		return nil, runtime.ProcessResult{}, err
 	}
+	finishCreating()
 
 	delegate.Starting(logger)
+	defer startPhase(logger, delegate, StepPhaseRunning)()
+
 	return resource.Resource{
 		Source:  source,
 		Version: fromVersion,
 	}.Check(ctx, container, delegate.Stderr())
 }
 
+// selectWorker waits for a worker to run the check on. If the plan has a tag
+// fallback, it only waits so long for a worker with the plan's tags before
+// failing or falling back to an untagged worker. Having no worker with the
+// plan's tags at all counts as waiting for one, as one may yet register.
+func (step *CheckStep) selectWorker(
+	ctx context.Context,
+	logger lager.Logger,
+	owner db.ContainerOwner,
+	containerSpec runtime.ContainerSpec,
+	workerSpec worker.Spec,
+	delegate CheckDelegate,
+) (runtime.Worker, error) {
+	fallback := step.plan.TagFallback
+	if fallback == nil || len(workerSpec.Tags) == 0 {
+		return step.workerPool.FindOrSelectWorker(ctx, owner, containerSpec, workerSpec, step.strategy, delegate)
+	}
+
+	after, err := time.ParseDuration(fallback.After)
+	if err != nil {
+		return nil, fmt.Errorf("parse tag fallback: %w", err)
+	}
+
+	taggedCtx, cancel := context.WithTimeout(ctx, after)
+	defer cancel()
+
+	chosen, err := step.waitForTaggedWorker(taggedCtx, owner, containerSpec, workerSpec, delegate)
+	if err == nil || ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded) {
+		return chosen, err
+	}
+
+	if fallback.Policy != atc.TagFallbackUntagged {
+		return nil, fmt.Errorf("no worker with %s became available within %s", workerSpec.Description(), after)
+	}
+
+	logger.Info("falling-back-to-untagged-worker", lager.Data{"tags": workerSpec.Tags})
+
+	workerSpec.Tags = nil
+
+	return step.workerPool.FindOrSelectWorker(ctx, owner, containerSpec, workerSpec, step.strategy, delegate)
+}
+
+// waitForTaggedWorker selects a worker with the spec's tags, retrying until
+// the context is done while there are no compatible workers.
+func (step *CheckStep) waitForTaggedWorker(
+	ctx context.Context,
+	owner db.ContainerOwner,
+	containerSpec runtime.ContainerSpec,
+	workerSpec worker.Spec,
+	delegate CheckDelegate,
+) (runtime.Worker, error) {
+	for {
+		chosen, err := step.workerPool.FindOrSelectWorker(ctx, owner, containerSpec, workerSpec, step.strategy, delegate)
+
+		var noCompatibleWorkers worker.NoCompatibleWorkersError
+		if !errors.As(err, &noCompatibleWorkers) {
+			return chosen, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(worker.PollingInterval):
+		}
+	}
+}
+
+// checkErrorMessage summarizes why a check failed, to be shown alongside the
+// resource. The full output is left to the check build's logs.
+func checkErrorMessage(runErr error, processResult runtime.ProcessResult, timeout time.Duration) string {
+	if errors.Is(runErr, context.DeadlineExceeded) {
+		return fmt.Sprintf("timeout exceeded after %s", timeout)
+	}
+
+	if runErr != nil {
+		return runErr.Error()
+	}
+
+	return fmt.Sprintf("check script exited with status %d", processResult.ExitStatus)
+}
+
+func forEachScope(scopes []db.ResourceConfigScope, f func(db.ResourceConfigScope) error) error {
+	for _, scope := range scopes {
+		err := f(scope)
+		if err != nil {
+			return fmt.Errorf("scope %d: %w", scope.ID(), err)
+		}
+	}
+
+	return nil
+}
+
 func (step *CheckStep) containerOwner(resourceConfig db.ResourceConfig) db.ContainerOwner {
 	if step.plan.Resource == "" {
 		return db.NewBuildStepContainerOwner(
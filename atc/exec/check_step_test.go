@@ -241,6 +241,113 @@ var _ = Describe("CheckStep", func() {
 				})
 			})
 
+			Describe("tag fallback", func() {
+				BeforeEach(func() {
+					checkPlan.Tags = atc.Tags{"some-tag"}
+					checkPlan.TagFallback = &atc.TagFallback{
+						Policy: atc.TagFallbackUntagged,
+						After:  "1m",
+					}
+				})
+
+				It("waits for a tagged worker until the fallback", func() {
+					Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(1))
+					ctx, _, _, workerSpec, _, _ := fakePool.FindOrSelectWorkerArgsForCall(0)
+					Expect(workerSpec.Tags).To(Equal([]string{"some-tag"}))
+
+					deadline, ok := ctx.Deadline()
+					Expect(ok).To(BeTrue())
+					Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), 10*time.Second))
+				})
+
+				Context("when no tagged worker becomes available in time", func() {
+					BeforeEach(func() {
+						fakePool.FindOrSelectWorkerStub = func(ctx context.Context, _ db.ContainerOwner, _ runtime.ContainerSpec, workerSpec worker.Spec, _ worker.PlacementStrategy, _ worker.PoolCallback) (runtime.Worker, error) {
+							if len(workerSpec.Tags) > 0 {
+								return nil, context.DeadlineExceeded
+							}
+
+							return chosenWorker, nil
+						}
+					})
+
+					Context("when falling back to untagged workers", func() {
+						It("runs the check on an untagged worker", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(2))
+
+							_, _, _, workerSpec, _, _ := fakePool.FindOrSelectWorkerArgsForCall(1)
+							Expect(workerSpec.Tags).To(BeEmpty())
+							Expect(chosenContainer.RunningProcesses()).ToNot(BeEmpty())
+						})
+					})
+
+					Context("when the fallback policy is to fail", func() {
+						BeforeEach(func() {
+							checkPlan.TagFallback.Policy = atc.TagFallbackFail
+						})
+
+						It("errors without trying an untagged worker", func() {
+							Expect(stepErr).To(MatchError(ContainSubstring("no worker with resource type 'some-base-type', tag 'some-tag' became available within 1m0s")))
+							Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when there are no workers with the tag", func() {
+					var originalPollingInterval time.Duration
+
+					BeforeEach(func() {
+						originalPollingInterval = worker.PollingInterval
+						worker.PollingInterval = 10 * time.Millisecond
+
+						checkPlan.TagFallback.After = "100ms"
+
+						fakePool.FindOrSelectWorkerStub = func(ctx context.Context, _ db.ContainerOwner, _ runtime.ContainerSpec, workerSpec worker.Spec, _ worker.PlacementStrategy, _ worker.PoolCallback) (runtime.Worker, error) {
+							if len(workerSpec.Tags) > 0 {
+								return nil, worker.NoCompatibleWorkersError{Spec: workerSpec}
+							}
+
+							return chosenWorker, nil
+						}
+					})
+
+					AfterEach(func() {
+						worker.PollingInterval = originalPollingInterval
+					})
+
+					It("keeps looking for a tagged worker until the fallback", func() {
+						var taggedCalls int
+						for i := 0; i < fakePool.FindOrSelectWorkerCallCount(); i++ {
+							_, _, _, workerSpec, _, _ := fakePool.FindOrSelectWorkerArgsForCall(i)
+							if len(workerSpec.Tags) > 0 {
+								taggedCalls++
+							}
+						}
+
+						Expect(taggedCalls).To(BeNumerically(">", 1))
+					})
+
+					It("runs the check on an untagged worker", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+
+						_, _, _, workerSpec, _, _ := fakePool.FindOrSelectWorkerArgsForCall(fakePool.FindOrSelectWorkerCallCount() - 1)
+						Expect(workerSpec.Tags).To(BeEmpty())
+						Expect(chosenContainer.RunningProcesses()).ToNot(BeEmpty())
+					})
+
+					Context("when the fallback policy is to fail", func() {
+						BeforeEach(func() {
+							checkPlan.TagFallback.Policy = atc.TagFallbackFail
+						})
+
+						It("errors once the fallback is reached", func() {
+							Expect(stepErr).To(MatchError(ContainSubstring("no worker with resource type 'some-base-type', tag 'some-tag' became available within 100ms")))
+						})
+					})
+				})
+			})
+
 			Describe("worker selection", func() {
 				var ctx context.Context
 				var workerSpec worker.Spec
@@ -275,6 +382,22 @@ var _ = Describe("CheckStep", func() {
 					})
 				})
 
+				Context("when the plan specifies container limits", func() {
+					BeforeEach(func() {
+						cpu := atc.CPULimit(512)
+						memory := atc.MemoryLimit(1024)
+						checkPlan.Limits = &atc.ContainerLimits{
+							CPU:    &cpu,
+							Memory: &memory,
+						}
+					})
+
+					It("sets them in the ContainerSpec", func() {
+						Expect(*chosenContainer.Spec.Limits.CPU).To(Equal(uint64(512)))
+						Expect(*chosenContainer.Spec.Limits.Memory).To(Equal(uint64(1024)))
+					})
+				})
+
 				It("emits a SelectedWorker event", func() {
 					Expect(fakeDelegate.SelectedWorkerCallCount()).To(Equal(1))
 					_, workerName := fakeDelegate.SelectedWorkerArgsForCall(0)
@@ -413,10 +536,14 @@ var _ = Describe("CheckStep", func() {
 						Expect(stepErr).To(BeNil())
 					})
 
-					It("emits an Errored event", func() {
-						Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-						_, status := fakeDelegate.ErroredArgsForCall(0)
-						Expect(status).To(Equal(exec.TimeoutLogMessage))
+					It("emits a CheckTimedOut event", func() {
+						Expect(fakeDelegate.CheckTimedOutCallCount()).To(Equal(1))
+						_, timeout := fakeDelegate.CheckTimedOutArgsForCall(0)
+						Expect(timeout).To(Equal(time.Millisecond))
+					})
+
+					It("does not emit an Errored event", func() {
+						Expect(fakeDelegate.ErroredCallCount()).To(BeZero())
 					})
 				})
 
@@ -574,6 +701,58 @@ var _ = Describe("CheckStep", func() {
 					})
 				})
 
+				Context("when other resources share the resource config", func() {
+					var siblingScope *dbfakes.FakeResourceConfigScope
+
+					BeforeEach(func() {
+						siblingScope = new(dbfakes.FakeResourceConfigScope)
+						fakeResourceConfigScope.SiblingScopesReturns([]db.ResourceConfigScope{siblingScope}, nil)
+					})
+
+					It("saves the versions to their scopes too", func() {
+						Expect(siblingScope.SaveVersionsCallCount()).To(Equal(1))
+						_, versions := siblingScope.SaveVersionsArgsForCall(0)
+						Expect(versions).To(Equal([]atc.Version{
+							{"version": "1"},
+							{"version": "2"},
+						}))
+					})
+
+					It("updates their last check times", func() {
+						Expect(siblingScope.UpdateLastCheckStartTimeCallCount()).To(Equal(1))
+						Expect(siblingScope.UpdateLastCheckEndTimeCallCount()).To(Equal(1))
+						Expect(siblingScope.UpdateLastCheckEndTimeArgsForCall(0)).To(BeTrue())
+					})
+
+					It("only points the checked resource to its scope", func() {
+						Expect(fakeDelegate.PointToCheckedConfigCallCount()).To(Equal(1))
+						Expect(fakeDelegate.PointToCheckedConfigArgsForCall(0)).To(Equal(fakeResourceConfigScope))
+					})
+
+					Context("when the check fails", func() {
+						BeforeEach(func() {
+							chosenContainer.ProcessDefs[0].Stub.ExitStatus = 42
+						})
+
+						It("updates their last check end times as failed", func() {
+							Expect(siblingScope.SaveVersionsCallCount()).To(BeZero())
+							Expect(siblingScope.UpdateLastCheckEndTimeCallCount()).To(Equal(1))
+							Expect(siblingScope.UpdateLastCheckEndTimeArgsForCall(0)).To(BeFalse())
+						})
+					})
+				})
+
+				Context("when finding the other scopes of the resource config fails", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.SiblingScopesReturns(nil, errors.New("nope"))
+					})
+
+					It("errors without running the check", func() {
+						Expect(stepErr).To(MatchError(ContainSubstring("nope")))
+						Expect(chosenContainer.RunningProcesses()).To(BeEmpty())
+					})
+				})
+
 				Context("after pointing the resource type to the scope", func() {
 					BeforeEach(func() {
 						fakeDelegate.PointToCheckedConfigStub = func(db.ResourceConfigScope) error {
@@ -612,6 +791,11 @@ var _ = Describe("CheckStep", func() {
 					Expect(fakeResourceConfigScope.UpdateLastCheckEndTimeCallCount()).To(Equal(1))
 				})
 
+				It("records the error on the scope", func() {
+					Expect(fakeResourceConfigScope.UpdateLastCheckErrorCallCount()).To(Equal(1))
+					Expect(fakeResourceConfigScope.UpdateLastCheckErrorArgsForCall(0)).To(ContainSubstring("run-check-step-err"))
+				})
+
 				// Finished is for script success/failure, whereas this is an error
 				It("does not emit a Finished event", func() {
 					Expect(fakeDelegate.FinishedCallCount()).To(Equal(0))
@@ -633,6 +817,11 @@ var _ = Describe("CheckStep", func() {
 					Expect(fakeResourceConfigScope.UpdateLastCheckEndTimeCallCount()).To(Equal(1))
 				})
 
+				It("records the exit status as the scope's last check error", func() {
+					Expect(fakeResourceConfigScope.UpdateLastCheckErrorCallCount()).To(Equal(1))
+					Expect(fakeResourceConfigScope.UpdateLastCheckErrorArgsForCall(0)).To(Equal("check script exited with status 42"))
+				})
+
 				It("emits a failed Finished event", func() {
 					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
 					_, succeeded := fakeDelegate.FinishedArgsForCall(0)
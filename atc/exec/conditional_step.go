@@ -0,0 +1,57 @@
+package exec
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// ConditionalStep runs a step only if a condition holds.
+type ConditionalStep struct {
+	step      Step
+	condition string
+}
+
+// Conditional constructs a ConditionalStep.
+func Conditional(step Step, condition string) ConditionalStep {
+	return ConditionalStep{
+		step:      step,
+		condition: condition,
+	}
+}
+
+// Run parses the condition, interpolates vars into each of its operands and
+// evaluates it. Operands are interpolated after parsing so that var values
+// can't change the shape of the condition. If it holds, the nested step is
+// run and its result returned. Otherwise the nested step is skipped and the
+// ConditionalStep succeeds.
+func (step ConditionalStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	condition, err := atc.ParseCondition(step.condition)
+	if err != nil {
+		return false, err
+	}
+
+	for _, operand := range []*string{&condition.Left, &condition.Right} {
+		*operand, err = creds.NewString(state, *operand).Evaluate()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	holds, err := condition.Evaluate()
+	if err != nil {
+		return false, err
+	}
+
+	if !holds {
+		logger.Debug("skipping-step", lager.Data{"condition": step.condition})
+		return true, nil
+	}
+
+	return step.step.Run(ctx, state)
+}
@@ -0,0 +1,146 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conditional Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		step *execfakes.FakeStep
+
+		repo  *build.Repository
+		state *execfakes.FakeRunState
+
+		condition string
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		step = &execfakes.FakeStep{}
+		step.RunReturns(true, nil)
+
+		repo = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(repo)
+		state.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+			if ref.Path == "branch" {
+				return "main", true, nil
+			}
+
+			return nil, false, nil
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		stepOk, stepErr = exec.Conditional(step, condition).Run(ctx, state)
+	})
+
+	Context("when the condition holds", func() {
+		BeforeEach(func() {
+			condition = `((branch)) == "main"`
+		})
+
+		It("runs the step", func() {
+			Expect(step.RunCallCount()).To(Equal(1))
+		})
+
+		It("returns the step's result", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+
+		Context("when the step fails", func() {
+			BeforeEach(func() {
+				step.RunReturns(false, nil)
+			})
+
+			It("fails", func() {
+				Expect(stepOk).To(BeFalse())
+			})
+		})
+
+		Context("when the step errors", func() {
+			disaster := errors.New("disaster")
+
+			BeforeEach(func() {
+				step.RunReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the condition does not hold", func() {
+		BeforeEach(func() {
+			condition = `((branch)) != "main"`
+		})
+
+		It("skips the step", func() {
+			Expect(step.RunCallCount()).To(Equal(0))
+		})
+
+		It("succeeds", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when a var's value contains an operator", func() {
+		BeforeEach(func() {
+			state.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+				return `main" != "other`, true, nil
+			}
+
+			condition = `((branch)) == "main"`
+		})
+
+		It("compares the value as a whole", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.RunCallCount()).To(Equal(0))
+			Expect(stepOk).To(BeTrue())
+		})
+	})
+
+	Context("when a var in the condition is missing", func() {
+		BeforeEach(func() {
+			condition = `((tag)) == "v1"`
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(step.RunCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the interpolated condition is not a boolean", func() {
+		BeforeEach(func() {
+			condition = `((branch))`
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(MatchError("condition 'main' is not a boolean"))
+			Expect(step.RunCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package exec
+
+import (
+	"context"
+)
+
+// ContinueOnFailureStep will run one step, and then a second step regardless
+// of whether the first step succeeded.
+type ContinueOnFailureStep struct {
+	step Step
+	next Step
+}
+
+// ContinueOnFailure constructs a ContinueOnFailureStep factory.
+func ContinueOnFailure(firstStep Step, secondStep Step) Step {
+	return ContinueOnFailureStep{
+		step: firstStep,
+		next: secondStep,
+	}
+}
+
+// Run will call Run on the first step and wait for it to complete. If the
+// first step errors or is interrupted, Run returns the error without running
+// the second step.
+//
+// Otherwise the second step is executed, and the ContinueOnFailureStep only
+// succeeds if both steps succeeded.
+func (o ContinueOnFailureStep) Run(ctx context.Context, state RunState) (bool, error) {
+	ok, err := o.step.Run(ctx, state)
+	if err != nil {
+		return false, err
+	}
+
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	nextOk, err := o.next.Run(ctx, state)
+	if err != nil {
+		return false, err
+	}
+
+	return ok && nextOk, nil
+}
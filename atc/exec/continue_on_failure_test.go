@@ -0,0 +1,141 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Continue On Failure Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		step *execfakes.FakeStep
+		next *execfakes.FakeStep
+
+		repo  *build.Repository
+		state *execfakes.FakeRunState
+
+		continueStep exec.Step
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		step = &execfakes.FakeStep{}
+		next = &execfakes.FakeStep{}
+
+		repo = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(repo)
+
+		continueStep = exec.ContinueOnFailure(step, next)
+
+		stepOk = false
+		stepErr = nil
+	})
+
+	JustBeforeEach(func() {
+		stepOk, stepErr = continueStep.Run(ctx, state)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	Context("when the step succeeds", func() {
+		BeforeEach(func() {
+			step.RunReturns(true, nil)
+		})
+
+		It("runs the next step", func() {
+			Expect(step.RunCallCount()).To(Equal(1))
+			Expect(next.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the next step succeeds", func() {
+			BeforeEach(func() {
+				next.RunReturns(true, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+			})
+		})
+
+		Context("when the next step fails", func() {
+			BeforeEach(func() {
+				next.RunReturns(false, nil)
+			})
+
+			It("fails", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the step fails", func() {
+		BeforeEach(func() {
+			step.RunReturns(false, nil)
+			next.RunReturns(true, nil)
+		})
+
+		It("runs the next step", func() {
+			Expect(next.RunCallCount()).To(Equal(1))
+		})
+
+		It("runs the next step with the run state", func() {
+			_, argsState := next.RunArgsForCall(0)
+			Expect(argsState).To(Equal(state))
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+	})
+
+	Context("when the step errors", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			step.RunReturns(false, disaster)
+		})
+
+		It("does not run the next step", func() {
+			Expect(next.RunCallCount()).To(Equal(0))
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when the step is interrupted", func() {
+		BeforeEach(func() {
+			step.RunStub = func(context.Context, exec.RunState) (bool, error) {
+				cancel()
+				return false, nil
+			}
+		})
+
+		It("does not run the next step", func() {
+			Expect(next.RunCallCount()).To(Equal(0))
+		})
+
+		It("returns the context error", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -16,6 +17,12 @@ import (
 )
 
 type FakeBuildStepDelegate struct {
+	BuildTimedOutStub        func(lager.Logger, time.Duration)
+	buildTimedOutMutex       sync.RWMutex
+	buildTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	ConstructAcrossSubstepsStub        func([]byte, []atc.AcrossVar, [][]interface{}) ([]atc.VarScopedPlan, error)
 	constructAcrossSubstepsMutex       sync.RWMutex
 	constructAcrossSubstepsArgsForCall []struct {
@@ -61,11 +68,44 @@ type FakeBuildStepDelegate struct {
 		arg1 lager.Logger
 		arg2 bool
 	}
+	HookTimedOutStub        func(lager.Logger, time.Duration)
+	hookTimedOutMutex       sync.RWMutex
+	hookTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	InitializingStub        func(lager.Logger)
 	initializingMutex       sync.RWMutex
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	ListResourceVersionsStub        func(string, int) ([]atc.Version, error)
+	listResourceVersionsMutex       sync.RWMutex
+	listResourceVersionsArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	listResourceVersionsReturns struct {
+		result1 []atc.Version
+		result2 error
+	}
+	listResourceVersionsReturnsOnCall map[int]struct {
+		result1 []atc.Version
+		result2 error
+	}
+	PhaseFinishedStub        func(lager.Logger, exec.StepPhase, time.Duration)
+	phaseFinishedMutex       sync.RWMutex
+	phaseFinishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}
+	RescheduledStub        func(lager.Logger, string)
+	rescheduledMutex       sync.RWMutex
+	rescheduledArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -112,6 +152,12 @@ type FakeBuildStepDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	TimeoutWarningStub        func(lager.Logger, time.Duration)
+	timeoutWarningMutex       sync.RWMutex
+	timeoutWarningArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
@@ -121,6 +167,39 @@ type FakeBuildStepDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeBuildStepDelegate) BuildTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.buildTimedOutMutex.Lock()
+	fake.buildTimedOutArgsForCall = append(fake.buildTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.BuildTimedOutStub
+	fake.recordInvocation("BuildTimedOut", []interface{}{arg1, arg2})
+	fake.buildTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.BuildTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) BuildTimedOutCallCount() int {
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
+	return len(fake.buildTimedOutArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) BuildTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.buildTimedOutMutex.Lock()
+	defer fake.buildTimedOutMutex.Unlock()
+	fake.BuildTimedOutStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) BuildTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
+	argsForCall := fake.buildTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) ConstructAcrossSubsteps(arg1 []byte, arg2 []atc.AcrossVar, arg3 [][]interface{}) ([]atc.VarScopedPlan, error) {
 	var arg1Copy []byte
 	if arg1 != nil {
@@ -338,6 +417,39 @@ func (fake *FakeBuildStepDelegate) FinishedArgsForCall(i int) (lager.Logger, boo
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeBuildStepDelegate) HookTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.hookTimedOutMutex.Lock()
+	fake.hookTimedOutArgsForCall = append(fake.hookTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.HookTimedOutStub
+	fake.recordInvocation("HookTimedOut", []interface{}{arg1, arg2})
+	fake.hookTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.HookTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) HookTimedOutCallCount() int {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	return len(fake.hookTimedOutArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) HookTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.hookTimedOutMutex.Lock()
+	defer fake.hookTimedOutMutex.Unlock()
+	fake.HookTimedOutStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) HookTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	argsForCall := fake.hookTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) Initializing(arg1 lager.Logger) {
 	fake.initializingMutex.Lock()
 	fake.initializingArgsForCall = append(fake.initializingArgsForCall, struct {
@@ -370,6 +482,138 @@ func (fake *FakeBuildStepDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeBuildStepDelegate) ListResourceVersions(arg1 string, arg2 int) ([]atc.Version, error) {
+	fake.listResourceVersionsMutex.Lock()
+	ret, specificReturn := fake.listResourceVersionsReturnsOnCall[len(fake.listResourceVersionsArgsForCall)]
+	fake.listResourceVersionsArgsForCall = append(fake.listResourceVersionsArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ListResourceVersionsStub
+	fakeReturns := fake.listResourceVersionsReturns
+	fake.recordInvocation("ListResourceVersions", []interface{}{arg1, arg2})
+	fake.listResourceVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildStepDelegate) ListResourceVersionsCallCount() int {
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	return len(fake.listResourceVersionsArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ListResourceVersionsCalls(stub func(string, int) ([]atc.Version, error)) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ListResourceVersionsArgsForCall(i int) (string, int) {
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	argsForCall := fake.listResourceVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildStepDelegate) ListResourceVersionsReturns(result1 []atc.Version, result2 error) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = nil
+	fake.listResourceVersionsReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildStepDelegate) ListResourceVersionsReturnsOnCall(i int, result1 []atc.Version, result2 error) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = nil
+	if fake.listResourceVersionsReturnsOnCall == nil {
+		fake.listResourceVersionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.Version
+			result2 error
+		})
+	}
+	fake.listResourceVersionsReturnsOnCall[i] = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildStepDelegate) PhaseFinished(arg1 lager.Logger, arg2 exec.StepPhase, arg3 time.Duration) {
+	fake.phaseFinishedMutex.Lock()
+	fake.phaseFinishedArgsForCall = append(fake.phaseFinishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.PhaseFinishedStub
+	fake.recordInvocation("PhaseFinished", []interface{}{arg1, arg2, arg3})
+	fake.phaseFinishedMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) PhaseFinishedCallCount() int {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	return len(fake.phaseFinishedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) PhaseFinishedCalls(stub func(lager.Logger, exec.StepPhase, time.Duration)) {
+	fake.phaseFinishedMutex.Lock()
+	defer fake.phaseFinishedMutex.Unlock()
+	fake.PhaseFinishedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) PhaseFinishedArgsForCall(i int) (lager.Logger, exec.StepPhase, time.Duration) {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	argsForCall := fake.phaseFinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuildStepDelegate) Rescheduled(arg1 lager.Logger, arg2 string) {
+	fake.rescheduledMutex.Lock()
+	fake.rescheduledArgsForCall = append(fake.rescheduledArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RescheduledStub
+	fake.recordInvocation("Rescheduled", []interface{}{arg1, arg2})
+	fake.rescheduledMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) RescheduledCallCount() int {
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
+	return len(fake.rescheduledArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) RescheduledCalls(stub func(lager.Logger, string)) {
+	fake.rescheduledMutex.Lock()
+	defer fake.rescheduledMutex.Unlock()
+	fake.RescheduledStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) RescheduledArgsForCall(i int) (lager.Logger, string) {
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
+	argsForCall := fake.rescheduledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -607,6 +851,39 @@ func (fake *FakeBuildStepDelegate) StdoutReturnsOnCall(i int, result1 io.Writer)
 	}{result1}
 }
 
+func (fake *FakeBuildStepDelegate) TimeoutWarning(arg1 lager.Logger, arg2 time.Duration) {
+	fake.timeoutWarningMutex.Lock()
+	fake.timeoutWarningArgsForCall = append(fake.timeoutWarningArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.TimeoutWarningStub
+	fake.recordInvocation("TimeoutWarning", []interface{}{arg1, arg2})
+	fake.timeoutWarningMutex.Unlock()
+	if stub != nil {
+		fake.TimeoutWarningStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) TimeoutWarningCallCount() int {
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
+	return len(fake.timeoutWarningArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) TimeoutWarningCalls(stub func(lager.Logger, time.Duration)) {
+	fake.timeoutWarningMutex.Lock()
+	defer fake.timeoutWarningMutex.Unlock()
+	fake.TimeoutWarningStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) TimeoutWarningArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
+	argsForCall := fake.timeoutWarningArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -642,6 +919,8 @@ func (fake *FakeBuildStepDelegate) WaitingForWorkerArgsForCall(i int) lager.Logg
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
 	fake.constructAcrossSubstepsMutex.RLock()
 	defer fake.constructAcrossSubstepsMutex.RUnlock()
 	fake.erroredMutex.RLock()
@@ -650,8 +929,16 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.fetchImageMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startSpanMutex.RLock()
@@ -662,6 +949,8 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -17,6 +18,18 @@ import (
 )
 
 type FakeCheckDelegate struct {
+	BuildTimedOutStub        func(lager.Logger, time.Duration)
+	buildTimedOutMutex       sync.RWMutex
+	buildTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
+	CheckTimedOutStub        func(lager.Logger, time.Duration)
+	checkTimedOutMutex       sync.RWMutex
+	checkTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	ConstructAcrossSubstepsStub        func([]byte, []atc.AcrossVar, [][]interface{}) ([]atc.VarScopedPlan, error)
 	constructAcrossSubstepsMutex       sync.RWMutex
 	constructAcrossSubstepsArgsForCall []struct {
@@ -75,11 +88,38 @@ type FakeCheckDelegate struct {
 		arg1 lager.Logger
 		arg2 bool
 	}
+	HookTimedOutStub        func(lager.Logger, time.Duration)
+	hookTimedOutMutex       sync.RWMutex
+	hookTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	InitializingStub        func(lager.Logger)
 	initializingMutex       sync.RWMutex
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	ListResourceVersionsStub        func(string, int) ([]atc.Version, error)
+	listResourceVersionsMutex       sync.RWMutex
+	listResourceVersionsArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	listResourceVersionsReturns struct {
+		result1 []atc.Version
+		result2 error
+	}
+	listResourceVersionsReturnsOnCall map[int]struct {
+		result1 []atc.Version
+		result2 error
+	}
+	PhaseFinishedStub        func(lager.Logger, exec.StepPhase, time.Duration)
+	phaseFinishedMutex       sync.RWMutex
+	phaseFinishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}
 	PointToCheckedConfigStub        func(db.ResourceConfigScope) error
 	pointToCheckedConfigMutex       sync.RWMutex
 	pointToCheckedConfigArgsForCall []struct {
@@ -91,6 +131,12 @@ type FakeCheckDelegate struct {
 	pointToCheckedConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RescheduledStub        func(lager.Logger, string)
+	rescheduledMutex       sync.RWMutex
+	rescheduledArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -137,6 +183,12 @@ type FakeCheckDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	TimeoutWarningStub        func(lager.Logger, time.Duration)
+	timeoutWarningMutex       sync.RWMutex
+	timeoutWarningArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	WaitToRunStub        func(context.Context, db.ResourceConfigScope) (lock.Lock, bool, error)
 	waitToRunMutex       sync.RWMutex
 	waitToRunArgsForCall []struct {
@@ -162,6 +214,72 @@ type FakeCheckDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCheckDelegate) BuildTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.buildTimedOutMutex.Lock()
+	fake.buildTimedOutArgsForCall = append(fake.buildTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.BuildTimedOutStub
+	fake.recordInvocation("BuildTimedOut", []interface{}{arg1, arg2})
+	fake.buildTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.BuildTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) BuildTimedOutCallCount() int {
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
+	return len(fake.buildTimedOutArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) BuildTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.buildTimedOutMutex.Lock()
+	defer fake.buildTimedOutMutex.Unlock()
+	fake.BuildTimedOutStub = stub
+}
+
+func (fake *FakeCheckDelegate) BuildTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
+	argsForCall := fake.buildTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheckDelegate) CheckTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.checkTimedOutMutex.Lock()
+	fake.checkTimedOutArgsForCall = append(fake.checkTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.CheckTimedOutStub
+	fake.recordInvocation("CheckTimedOut", []interface{}{arg1, arg2})
+	fake.checkTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.CheckTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) CheckTimedOutCallCount() int {
+	fake.checkTimedOutMutex.RLock()
+	defer fake.checkTimedOutMutex.RUnlock()
+	return len(fake.checkTimedOutArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) CheckTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.checkTimedOutMutex.Lock()
+	defer fake.checkTimedOutMutex.Unlock()
+	fake.CheckTimedOutStub = stub
+}
+
+func (fake *FakeCheckDelegate) CheckTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.checkTimedOutMutex.RLock()
+	defer fake.checkTimedOutMutex.RUnlock()
+	argsForCall := fake.checkTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) ConstructAcrossSubsteps(arg1 []byte, arg2 []atc.AcrossVar, arg3 [][]interface{}) ([]atc.VarScopedPlan, error) {
 	var arg1Copy []byte
 	if arg1 != nil {
@@ -443,6 +561,39 @@ func (fake *FakeCheckDelegate) FinishedArgsForCall(i int) (lager.Logger, bool) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeCheckDelegate) HookTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.hookTimedOutMutex.Lock()
+	fake.hookTimedOutArgsForCall = append(fake.hookTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.HookTimedOutStub
+	fake.recordInvocation("HookTimedOut", []interface{}{arg1, arg2})
+	fake.hookTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.HookTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) HookTimedOutCallCount() int {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	return len(fake.hookTimedOutArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) HookTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.hookTimedOutMutex.Lock()
+	defer fake.hookTimedOutMutex.Unlock()
+	fake.HookTimedOutStub = stub
+}
+
+func (fake *FakeCheckDelegate) HookTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	argsForCall := fake.hookTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) Initializing(arg1 lager.Logger) {
 	fake.initializingMutex.Lock()
 	fake.initializingArgsForCall = append(fake.initializingArgsForCall, struct {
@@ -475,6 +626,105 @@ func (fake *FakeCheckDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeCheckDelegate) ListResourceVersions(arg1 string, arg2 int) ([]atc.Version, error) {
+	fake.listResourceVersionsMutex.Lock()
+	ret, specificReturn := fake.listResourceVersionsReturnsOnCall[len(fake.listResourceVersionsArgsForCall)]
+	fake.listResourceVersionsArgsForCall = append(fake.listResourceVersionsArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ListResourceVersionsStub
+	fakeReturns := fake.listResourceVersionsReturns
+	fake.recordInvocation("ListResourceVersions", []interface{}{arg1, arg2})
+	fake.listResourceVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckDelegate) ListResourceVersionsCallCount() int {
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	return len(fake.listResourceVersionsArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ListResourceVersionsCalls(stub func(string, int) ([]atc.Version, error)) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = stub
+}
+
+func (fake *FakeCheckDelegate) ListResourceVersionsArgsForCall(i int) (string, int) {
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	argsForCall := fake.listResourceVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheckDelegate) ListResourceVersionsReturns(result1 []atc.Version, result2 error) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = nil
+	fake.listResourceVersionsReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckDelegate) ListResourceVersionsReturnsOnCall(i int, result1 []atc.Version, result2 error) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = nil
+	if fake.listResourceVersionsReturnsOnCall == nil {
+		fake.listResourceVersionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.Version
+			result2 error
+		})
+	}
+	fake.listResourceVersionsReturnsOnCall[i] = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckDelegate) PhaseFinished(arg1 lager.Logger, arg2 exec.StepPhase, arg3 time.Duration) {
+	fake.phaseFinishedMutex.Lock()
+	fake.phaseFinishedArgsForCall = append(fake.phaseFinishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.PhaseFinishedStub
+	fake.recordInvocation("PhaseFinished", []interface{}{arg1, arg2, arg3})
+	fake.phaseFinishedMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeCheckDelegate) PhaseFinishedCallCount() int {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	return len(fake.phaseFinishedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) PhaseFinishedCalls(stub func(lager.Logger, exec.StepPhase, time.Duration)) {
+	fake.phaseFinishedMutex.Lock()
+	defer fake.phaseFinishedMutex.Unlock()
+	fake.PhaseFinishedStub = stub
+}
+
+func (fake *FakeCheckDelegate) PhaseFinishedArgsForCall(i int) (lager.Logger, exec.StepPhase, time.Duration) {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	argsForCall := fake.phaseFinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeCheckDelegate) PointToCheckedConfig(arg1 db.ResourceConfigScope) error {
 	fake.pointToCheckedConfigMutex.Lock()
 	ret, specificReturn := fake.pointToCheckedConfigReturnsOnCall[len(fake.pointToCheckedConfigArgsForCall)]
@@ -536,6 +786,39 @@ func (fake *FakeCheckDelegate) PointToCheckedConfigReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCheckDelegate) Rescheduled(arg1 lager.Logger, arg2 string) {
+	fake.rescheduledMutex.Lock()
+	fake.rescheduledArgsForCall = append(fake.rescheduledArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RescheduledStub
+	fake.recordInvocation("Rescheduled", []interface{}{arg1, arg2})
+	fake.rescheduledMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) RescheduledCallCount() int {
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
+	return len(fake.rescheduledArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) RescheduledCalls(stub func(lager.Logger, string)) {
+	fake.rescheduledMutex.Lock()
+	defer fake.rescheduledMutex.Unlock()
+	fake.RescheduledStub = stub
+}
+
+func (fake *FakeCheckDelegate) RescheduledArgsForCall(i int) (lager.Logger, string) {
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
+	argsForCall := fake.rescheduledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -773,6 +1056,39 @@ func (fake *FakeCheckDelegate) StdoutReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeCheckDelegate) TimeoutWarning(arg1 lager.Logger, arg2 time.Duration) {
+	fake.timeoutWarningMutex.Lock()
+	fake.timeoutWarningArgsForCall = append(fake.timeoutWarningArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.TimeoutWarningStub
+	fake.recordInvocation("TimeoutWarning", []interface{}{arg1, arg2})
+	fake.timeoutWarningMutex.Unlock()
+	if stub != nil {
+		fake.TimeoutWarningStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) TimeoutWarningCallCount() int {
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
+	return len(fake.timeoutWarningArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) TimeoutWarningCalls(stub func(lager.Logger, time.Duration)) {
+	fake.timeoutWarningMutex.Lock()
+	defer fake.timeoutWarningMutex.Unlock()
+	fake.TimeoutWarningStub = stub
+}
+
+func (fake *FakeCheckDelegate) TimeoutWarningArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
+	argsForCall := fake.timeoutWarningArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) WaitToRun(arg1 context.Context, arg2 db.ResourceConfigScope) (lock.Lock, bool, error) {
 	fake.waitToRunMutex.Lock()
 	ret, specificReturn := fake.waitToRunReturnsOnCall[len(fake.waitToRunArgsForCall)]
@@ -876,6 +1192,10 @@ func (fake *FakeCheckDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
 func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
+	fake.checkTimedOutMutex.RLock()
+	defer fake.checkTimedOutMutex.RUnlock()
 	fake.constructAcrossSubstepsMutex.RLock()
 	defer fake.constructAcrossSubstepsMutex.RUnlock()
 	fake.erroredMutex.RLock()
@@ -886,10 +1206,18 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateScopeMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
 	fake.pointToCheckedConfigMutex.RLock()
 	defer fake.pointToCheckedConfigMutex.RUnlock()
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startSpanMutex.RLock()
@@ -900,6 +1228,8 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
 	fake.waitToRunMutex.RLock()
 	defer fake.waitToRunMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -53,6 +54,13 @@ type FakeGetDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	PhaseFinishedStub        func(lager.Logger, exec.StepPhase, time.Duration)
+	phaseFinishedMutex       sync.RWMutex
+	phaseFinishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -107,6 +115,22 @@ type FakeGetDelegate struct {
 		arg3 db.ResourceCache
 		arg4 resource.VersionResult
 	}
+	VersionHistoryStub        func(lager.Logger, string, atc.Version, int) ([]atc.Version, error)
+	versionHistoryMutex       sync.RWMutex
+	versionHistoryArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+		arg4 int
+	}
+	versionHistoryReturns struct {
+		result1 []atc.Version
+		result2 error
+	}
+	versionHistoryReturnsOnCall map[int]struct {
+		result1 []atc.Version
+		result2 error
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
@@ -285,6 +309,40 @@ func (fake *FakeGetDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeGetDelegate) PhaseFinished(arg1 lager.Logger, arg2 exec.StepPhase, arg3 time.Duration) {
+	fake.phaseFinishedMutex.Lock()
+	fake.phaseFinishedArgsForCall = append(fake.phaseFinishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.PhaseFinishedStub
+	fake.recordInvocation("PhaseFinished", []interface{}{arg1, arg2, arg3})
+	fake.phaseFinishedMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeGetDelegate) PhaseFinishedCallCount() int {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	return len(fake.phaseFinishedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) PhaseFinishedCalls(stub func(lager.Logger, exec.StepPhase, time.Duration)) {
+	fake.phaseFinishedMutex.Lock()
+	defer fake.phaseFinishedMutex.Unlock()
+	fake.PhaseFinishedStub = stub
+}
+
+func (fake *FakeGetDelegate) PhaseFinishedArgsForCall(i int) (lager.Logger, exec.StepPhase, time.Duration) {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	argsForCall := fake.phaseFinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeGetDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -557,6 +615,73 @@ func (fake *FakeGetDelegate) UpdateMetadataArgsForCall(i int) (lager.Logger, str
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
+func (fake *FakeGetDelegate) VersionHistory(arg1 lager.Logger, arg2 string, arg3 atc.Version, arg4 int) ([]atc.Version, error) {
+	fake.versionHistoryMutex.Lock()
+	ret, specificReturn := fake.versionHistoryReturnsOnCall[len(fake.versionHistoryArgsForCall)]
+	fake.versionHistoryArgsForCall = append(fake.versionHistoryArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.VersionHistoryStub
+	fakeReturns := fake.versionHistoryReturns
+	fake.recordInvocation("VersionHistory", []interface{}{arg1, arg2, arg3, arg4})
+	fake.versionHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGetDelegate) VersionHistoryCallCount() int {
+	fake.versionHistoryMutex.RLock()
+	defer fake.versionHistoryMutex.RUnlock()
+	return len(fake.versionHistoryArgsForCall)
+}
+
+func (fake *FakeGetDelegate) VersionHistoryCalls(stub func(lager.Logger, string, atc.Version, int) ([]atc.Version, error)) {
+	fake.versionHistoryMutex.Lock()
+	defer fake.versionHistoryMutex.Unlock()
+	fake.VersionHistoryStub = stub
+}
+
+func (fake *FakeGetDelegate) VersionHistoryArgsForCall(i int) (lager.Logger, string, atc.Version, int) {
+	fake.versionHistoryMutex.RLock()
+	defer fake.versionHistoryMutex.RUnlock()
+	argsForCall := fake.versionHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeGetDelegate) VersionHistoryReturns(result1 []atc.Version, result2 error) {
+	fake.versionHistoryMutex.Lock()
+	defer fake.versionHistoryMutex.Unlock()
+	fake.VersionHistoryStub = nil
+	fake.versionHistoryReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGetDelegate) VersionHistoryReturnsOnCall(i int, result1 []atc.Version, result2 error) {
+	fake.versionHistoryMutex.Lock()
+	defer fake.versionHistoryMutex.Unlock()
+	fake.VersionHistoryStub = nil
+	if fake.versionHistoryReturnsOnCall == nil {
+		fake.versionHistoryReturnsOnCall = make(map[int]struct {
+			result1 []atc.Version
+			result2 error
+		})
+	}
+	fake.versionHistoryReturnsOnCall[i] = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeGetDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -600,6 +725,8 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startSpanMutex.RLock()
@@ -612,6 +739,8 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
 	defer fake.updateMetadataMutex.RUnlock()
+	fake.versionHistoryMutex.RLock()
+	defer fake.versionHistoryMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
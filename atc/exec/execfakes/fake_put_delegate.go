@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -12,11 +13,18 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/scanner"
 	"github.com/concourse/concourse/tracing"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type FakePutDelegate struct {
+	ArtifactScannedStub        func(lager.Logger, scanner.Report)
+	artifactScannedMutex       sync.RWMutex
+	artifactScannedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 scanner.Report
+	}
 	ErroredStub        func(lager.Logger, string)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
@@ -53,6 +61,13 @@ type FakePutDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	PhaseFinishedStub        func(lager.Logger, exec.StepPhase, time.Duration)
+	phaseFinishedMutex       sync.RWMutex
+	phaseFinishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}
 	SaveOutputStub        func(lager.Logger, atc.PutPlan, atc.Source, db.ResourceCache, resource.VersionResult)
 	saveOutputMutex       sync.RWMutex
 	saveOutputArgsForCall []struct {
@@ -117,6 +132,39 @@ type FakePutDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakePutDelegate) ArtifactScanned(arg1 lager.Logger, arg2 scanner.Report) {
+	fake.artifactScannedMutex.Lock()
+	fake.artifactScannedArgsForCall = append(fake.artifactScannedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 scanner.Report
+	}{arg1, arg2})
+	stub := fake.ArtifactScannedStub
+	fake.recordInvocation("ArtifactScanned", []interface{}{arg1, arg2})
+	fake.artifactScannedMutex.Unlock()
+	if stub != nil {
+		fake.ArtifactScannedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakePutDelegate) ArtifactScannedCallCount() int {
+	fake.artifactScannedMutex.RLock()
+	defer fake.artifactScannedMutex.RUnlock()
+	return len(fake.artifactScannedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ArtifactScannedCalls(stub func(lager.Logger, scanner.Report)) {
+	fake.artifactScannedMutex.Lock()
+	defer fake.artifactScannedMutex.Unlock()
+	fake.ArtifactScannedStub = stub
+}
+
+func (fake *FakePutDelegate) ArtifactScannedArgsForCall(i int) (lager.Logger, scanner.Report) {
+	fake.artifactScannedMutex.RLock()
+	defer fake.artifactScannedMutex.RUnlock()
+	argsForCall := fake.artifactScannedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakePutDelegate) Errored(arg1 lager.Logger, arg2 string) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
@@ -286,6 +334,40 @@ func (fake *FakePutDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakePutDelegate) PhaseFinished(arg1 lager.Logger, arg2 exec.StepPhase, arg3 time.Duration) {
+	fake.phaseFinishedMutex.Lock()
+	fake.phaseFinishedArgsForCall = append(fake.phaseFinishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.PhaseFinishedStub
+	fake.recordInvocation("PhaseFinished", []interface{}{arg1, arg2, arg3})
+	fake.phaseFinishedMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakePutDelegate) PhaseFinishedCallCount() int {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	return len(fake.phaseFinishedArgsForCall)
+}
+
+func (fake *FakePutDelegate) PhaseFinishedCalls(stub func(lager.Logger, exec.StepPhase, time.Duration)) {
+	fake.phaseFinishedMutex.Lock()
+	defer fake.phaseFinishedMutex.Unlock()
+	fake.PhaseFinishedStub = stub
+}
+
+func (fake *FakePutDelegate) PhaseFinishedArgsForCall(i int) (lager.Logger, exec.StepPhase, time.Duration) {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	argsForCall := fake.phaseFinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakePutDelegate) SaveOutput(arg1 lager.Logger, arg2 atc.PutPlan, arg3 atc.Source, arg4 db.ResourceCache, arg5 resource.VersionResult) {
 	fake.saveOutputMutex.Lock()
 	fake.saveOutputArgsForCall = append(fake.saveOutputArgsForCall, struct {
@@ -594,6 +676,8 @@ func (fake *FakePutDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
 func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.artifactScannedMutex.RLock()
+	defer fake.artifactScannedMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	fake.fetchImageMutex.RLock()
@@ -602,6 +686,8 @@ func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
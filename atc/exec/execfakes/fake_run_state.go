@@ -19,6 +19,13 @@ type FakeRunState struct {
 		arg2 interface{}
 		arg3 bool
 	}
+	AddLocalVarFieldStub        func(string, string, interface{})
+	addLocalVarFieldMutex       sync.RWMutex
+	addLocalVarFieldArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 interface{}
+	}
 	ArtifactRepositoryStub        func() *build.Repository
 	artifactRepositoryMutex       sync.RWMutex
 	artifactRepositoryArgsForCall []struct {
@@ -71,6 +78,16 @@ type FakeRunState struct {
 	newLocalScopeReturnsOnCall map[int]struct {
 		result1 exec.RunState
 	}
+	OutputPolicyStub        func() exec.OutputPolicy
+	outputPolicyMutex       sync.RWMutex
+	outputPolicyArgsForCall []struct {
+	}
+	outputPolicyReturns struct {
+		result1 exec.OutputPolicy
+	}
+	outputPolicyReturnsOnCall map[int]struct {
+		result1 exec.OutputPolicy
+	}
 	ParentStub        func() exec.RunState
 	parentMutex       sync.RWMutex
 	parentArgsForCall []struct {
@@ -123,6 +140,17 @@ type FakeRunState struct {
 		arg1 atc.PlanID
 		arg2 interface{}
 	}
+	WithOutputPolicyStub        func(exec.OutputPolicy) exec.RunState
+	withOutputPolicyMutex       sync.RWMutex
+	withOutputPolicyArgsForCall []struct {
+		arg1 exec.OutputPolicy
+	}
+	withOutputPolicyReturns struct {
+		result1 exec.RunState
+	}
+	withOutputPolicyReturnsOnCall map[int]struct {
+		result1 exec.RunState
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -161,6 +189,40 @@ func (fake *FakeRunState) AddLocalVarArgsForCall(i int) (string, interface{}, bo
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeRunState) AddLocalVarField(arg1 string, arg2 string, arg3 interface{}) {
+	fake.addLocalVarFieldMutex.Lock()
+	fake.addLocalVarFieldArgsForCall = append(fake.addLocalVarFieldArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 interface{}
+	}{arg1, arg2, arg3})
+	stub := fake.AddLocalVarFieldStub
+	fake.recordInvocation("AddLocalVarField", []interface{}{arg1, arg2, arg3})
+	fake.addLocalVarFieldMutex.Unlock()
+	if stub != nil {
+		fake.AddLocalVarFieldStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeRunState) AddLocalVarFieldCallCount() int {
+	fake.addLocalVarFieldMutex.RLock()
+	defer fake.addLocalVarFieldMutex.RUnlock()
+	return len(fake.addLocalVarFieldArgsForCall)
+}
+
+func (fake *FakeRunState) AddLocalVarFieldCalls(stub func(string, string, interface{})) {
+	fake.addLocalVarFieldMutex.Lock()
+	defer fake.addLocalVarFieldMutex.Unlock()
+	fake.AddLocalVarFieldStub = stub
+}
+
+func (fake *FakeRunState) AddLocalVarFieldArgsForCall(i int) (string, string, interface{}) {
+	fake.addLocalVarFieldMutex.RLock()
+	defer fake.addLocalVarFieldMutex.RUnlock()
+	argsForCall := fake.addLocalVarFieldArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeRunState) ArtifactRepository() *build.Repository {
 	fake.artifactRepositoryMutex.Lock()
 	ret, specificReturn := fake.artifactRepositoryReturnsOnCall[len(fake.artifactRepositoryArgsForCall)]
@@ -422,6 +484,59 @@ func (fake *FakeRunState) NewLocalScopeReturnsOnCall(i int, result1 exec.RunStat
 	}{result1}
 }
 
+func (fake *FakeRunState) OutputPolicy() exec.OutputPolicy {
+	fake.outputPolicyMutex.Lock()
+	ret, specificReturn := fake.outputPolicyReturnsOnCall[len(fake.outputPolicyArgsForCall)]
+	fake.outputPolicyArgsForCall = append(fake.outputPolicyArgsForCall, struct {
+	}{})
+	stub := fake.OutputPolicyStub
+	fakeReturns := fake.outputPolicyReturns
+	fake.recordInvocation("OutputPolicy", []interface{}{})
+	fake.outputPolicyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) OutputPolicyCallCount() int {
+	fake.outputPolicyMutex.RLock()
+	defer fake.outputPolicyMutex.RUnlock()
+	return len(fake.outputPolicyArgsForCall)
+}
+
+func (fake *FakeRunState) OutputPolicyCalls(stub func() exec.OutputPolicy) {
+	fake.outputPolicyMutex.Lock()
+	defer fake.outputPolicyMutex.Unlock()
+	fake.OutputPolicyStub = stub
+}
+
+func (fake *FakeRunState) OutputPolicyReturns(result1 exec.OutputPolicy) {
+	fake.outputPolicyMutex.Lock()
+	defer fake.outputPolicyMutex.Unlock()
+	fake.OutputPolicyStub = nil
+	fake.outputPolicyReturns = struct {
+		result1 exec.OutputPolicy
+	}{result1}
+}
+
+func (fake *FakeRunState) OutputPolicyReturnsOnCall(i int, result1 exec.OutputPolicy) {
+	fake.outputPolicyMutex.Lock()
+	defer fake.outputPolicyMutex.Unlock()
+	fake.OutputPolicyStub = nil
+	if fake.outputPolicyReturnsOnCall == nil {
+		fake.outputPolicyReturnsOnCall = make(map[int]struct {
+			result1 exec.OutputPolicy
+		})
+	}
+	fake.outputPolicyReturnsOnCall[i] = struct {
+		result1 exec.OutputPolicy
+	}{result1}
+}
+
 func (fake *FakeRunState) Parent() exec.RunState {
 	fake.parentMutex.Lock()
 	ret, specificReturn := fake.parentReturnsOnCall[len(fake.parentArgsForCall)]
@@ -688,11 +803,74 @@ func (fake *FakeRunState) StoreResultArgsForCall(i int) (atc.PlanID, interface{}
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeRunState) WithOutputPolicy(arg1 exec.OutputPolicy) exec.RunState {
+	fake.withOutputPolicyMutex.Lock()
+	ret, specificReturn := fake.withOutputPolicyReturnsOnCall[len(fake.withOutputPolicyArgsForCall)]
+	fake.withOutputPolicyArgsForCall = append(fake.withOutputPolicyArgsForCall, struct {
+		arg1 exec.OutputPolicy
+	}{arg1})
+	stub := fake.WithOutputPolicyStub
+	fakeReturns := fake.withOutputPolicyReturns
+	fake.recordInvocation("WithOutputPolicy", []interface{}{arg1})
+	fake.withOutputPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) WithOutputPolicyCallCount() int {
+	fake.withOutputPolicyMutex.RLock()
+	defer fake.withOutputPolicyMutex.RUnlock()
+	return len(fake.withOutputPolicyArgsForCall)
+}
+
+func (fake *FakeRunState) WithOutputPolicyCalls(stub func(exec.OutputPolicy) exec.RunState) {
+	fake.withOutputPolicyMutex.Lock()
+	defer fake.withOutputPolicyMutex.Unlock()
+	fake.WithOutputPolicyStub = stub
+}
+
+func (fake *FakeRunState) WithOutputPolicyArgsForCall(i int) exec.OutputPolicy {
+	fake.withOutputPolicyMutex.RLock()
+	defer fake.withOutputPolicyMutex.RUnlock()
+	argsForCall := fake.withOutputPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRunState) WithOutputPolicyReturns(result1 exec.RunState) {
+	fake.withOutputPolicyMutex.Lock()
+	defer fake.withOutputPolicyMutex.Unlock()
+	fake.WithOutputPolicyStub = nil
+	fake.withOutputPolicyReturns = struct {
+		result1 exec.RunState
+	}{result1}
+}
+
+func (fake *FakeRunState) WithOutputPolicyReturnsOnCall(i int, result1 exec.RunState) {
+	fake.withOutputPolicyMutex.Lock()
+	defer fake.withOutputPolicyMutex.Unlock()
+	fake.WithOutputPolicyStub = nil
+	if fake.withOutputPolicyReturnsOnCall == nil {
+		fake.withOutputPolicyReturnsOnCall = make(map[int]struct {
+			result1 exec.RunState
+		})
+	}
+	fake.withOutputPolicyReturnsOnCall[i] = struct {
+		result1 exec.RunState
+	}{result1}
+}
+
 func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.addLocalVarMutex.RLock()
 	defer fake.addLocalVarMutex.RUnlock()
+	fake.addLocalVarFieldMutex.RLock()
+	defer fake.addLocalVarFieldMutex.RUnlock()
 	fake.artifactRepositoryMutex.RLock()
 	defer fake.artifactRepositoryMutex.RUnlock()
 	fake.getMutex.RLock()
@@ -703,6 +881,8 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.listMutex.RUnlock()
 	fake.newLocalScopeMutex.RLock()
 	defer fake.newLocalScopeMutex.RUnlock()
+	fake.outputPolicyMutex.RLock()
+	defer fake.outputPolicyMutex.RUnlock()
 	fake.parentMutex.RLock()
 	defer fake.parentMutex.RUnlock()
 	fake.redactionEnabledMutex.RLock()
@@ -713,6 +893,8 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.runMutex.RUnlock()
 	fake.storeResultMutex.RLock()
 	defer fake.storeResultMutex.RUnlock()
+	fake.withOutputPolicyMutex.RLock()
+	defer fake.withOutputPolicyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -16,6 +17,12 @@ import (
 )
 
 type FakeSetPipelineStepDelegate struct {
+	BuildTimedOutStub        func(lager.Logger, time.Duration)
+	buildTimedOutMutex       sync.RWMutex
+	buildTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	CheckRunSetPipelinePolicyStub        func(*atc.Config) error
 	checkRunSetPipelinePolicyMutex       sync.RWMutex
 	checkRunSetPipelinePolicyArgsForCall []struct {
@@ -27,6 +34,20 @@ type FakeSetPipelineStepDelegate struct {
 	checkRunSetPipelinePolicyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckSetPipelineAcrossTeamsPolicyStub        func(string, string) (bool, error)
+	checkSetPipelineAcrossTeamsPolicyMutex       sync.RWMutex
+	checkSetPipelineAcrossTeamsPolicyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	checkSetPipelineAcrossTeamsPolicyReturns struct {
+		result1 bool
+		result2 error
+	}
+	checkSetPipelineAcrossTeamsPolicyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ConstructAcrossSubstepsStub        func([]byte, []atc.AcrossVar, [][]interface{}) ([]atc.VarScopedPlan, error)
 	constructAcrossSubstepsMutex       sync.RWMutex
 	constructAcrossSubstepsArgsForCall []struct {
@@ -72,11 +93,44 @@ type FakeSetPipelineStepDelegate struct {
 		arg1 lager.Logger
 		arg2 bool
 	}
+	HookTimedOutStub        func(lager.Logger, time.Duration)
+	hookTimedOutMutex       sync.RWMutex
+	hookTimedOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	InitializingStub        func(lager.Logger)
 	initializingMutex       sync.RWMutex
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	ListResourceVersionsStub        func(string, int) ([]atc.Version, error)
+	listResourceVersionsMutex       sync.RWMutex
+	listResourceVersionsArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	listResourceVersionsReturns struct {
+		result1 []atc.Version
+		result2 error
+	}
+	listResourceVersionsReturnsOnCall map[int]struct {
+		result1 []atc.Version
+		result2 error
+	}
+	PhaseFinishedStub        func(lager.Logger, exec.StepPhase, time.Duration)
+	phaseFinishedMutex       sync.RWMutex
+	phaseFinishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}
+	RescheduledStub        func(lager.Logger, string)
+	rescheduledMutex       sync.RWMutex
+	rescheduledArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -129,6 +183,12 @@ type FakeSetPipelineStepDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	TimeoutWarningStub        func(lager.Logger, time.Duration)
+	timeoutWarningMutex       sync.RWMutex
+	timeoutWarningArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
@@ -138,6 +198,39 @@ type FakeSetPipelineStepDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeSetPipelineStepDelegate) BuildTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.buildTimedOutMutex.Lock()
+	fake.buildTimedOutArgsForCall = append(fake.buildTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.BuildTimedOutStub
+	fake.recordInvocation("BuildTimedOut", []interface{}{arg1, arg2})
+	fake.buildTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.BuildTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) BuildTimedOutCallCount() int {
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
+	return len(fake.buildTimedOutArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) BuildTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.buildTimedOutMutex.Lock()
+	defer fake.buildTimedOutMutex.Unlock()
+	fake.BuildTimedOutStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) BuildTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
+	argsForCall := fake.buildTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeSetPipelineStepDelegate) CheckRunSetPipelinePolicy(arg1 *atc.Config) error {
 	fake.checkRunSetPipelinePolicyMutex.Lock()
 	ret, specificReturn := fake.checkRunSetPipelinePolicyReturnsOnCall[len(fake.checkRunSetPipelinePolicyArgsForCall)]
@@ -199,6 +292,71 @@ func (fake *FakeSetPipelineStepDelegate) CheckRunSetPipelinePolicyReturnsOnCall(
 	}{result1}
 }
 
+func (fake *FakeSetPipelineStepDelegate) CheckSetPipelineAcrossTeamsPolicy(arg1 string, arg2 string) (bool, error) {
+	fake.checkSetPipelineAcrossTeamsPolicyMutex.Lock()
+	ret, specificReturn := fake.checkSetPipelineAcrossTeamsPolicyReturnsOnCall[len(fake.checkSetPipelineAcrossTeamsPolicyArgsForCall)]
+	fake.checkSetPipelineAcrossTeamsPolicyArgsForCall = append(fake.checkSetPipelineAcrossTeamsPolicyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CheckSetPipelineAcrossTeamsPolicyStub
+	fakeReturns := fake.checkSetPipelineAcrossTeamsPolicyReturns
+	fake.recordInvocation("CheckSetPipelineAcrossTeamsPolicy", []interface{}{arg1, arg2})
+	fake.checkSetPipelineAcrossTeamsPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSetPipelineStepDelegate) CheckSetPipelineAcrossTeamsPolicyCallCount() int {
+	fake.checkSetPipelineAcrossTeamsPolicyMutex.RLock()
+	defer fake.checkSetPipelineAcrossTeamsPolicyMutex.RUnlock()
+	return len(fake.checkSetPipelineAcrossTeamsPolicyArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) CheckSetPipelineAcrossTeamsPolicyCalls(stub func(string, string) (bool, error)) {
+	fake.checkSetPipelineAcrossTeamsPolicyMutex.Lock()
+	defer fake.checkSetPipelineAcrossTeamsPolicyMutex.Unlock()
+	fake.CheckSetPipelineAcrossTeamsPolicyStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) CheckSetPipelineAcrossTeamsPolicyArgsForCall(i int) (string, string) {
+	fake.checkSetPipelineAcrossTeamsPolicyMutex.RLock()
+	defer fake.checkSetPipelineAcrossTeamsPolicyMutex.RUnlock()
+	argsForCall := fake.checkSetPipelineAcrossTeamsPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSetPipelineStepDelegate) CheckSetPipelineAcrossTeamsPolicyReturns(result1 bool, result2 error) {
+	fake.checkSetPipelineAcrossTeamsPolicyMutex.Lock()
+	defer fake.checkSetPipelineAcrossTeamsPolicyMutex.Unlock()
+	fake.CheckSetPipelineAcrossTeamsPolicyStub = nil
+	fake.checkSetPipelineAcrossTeamsPolicyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSetPipelineStepDelegate) CheckSetPipelineAcrossTeamsPolicyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.checkSetPipelineAcrossTeamsPolicyMutex.Lock()
+	defer fake.checkSetPipelineAcrossTeamsPolicyMutex.Unlock()
+	fake.CheckSetPipelineAcrossTeamsPolicyStub = nil
+	if fake.checkSetPipelineAcrossTeamsPolicyReturnsOnCall == nil {
+		fake.checkSetPipelineAcrossTeamsPolicyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.checkSetPipelineAcrossTeamsPolicyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSetPipelineStepDelegate) ConstructAcrossSubsteps(arg1 []byte, arg2 []atc.AcrossVar, arg3 [][]interface{}) ([]atc.VarScopedPlan, error) {
 	var arg1Copy []byte
 	if arg1 != nil {
@@ -416,6 +574,39 @@ func (fake *FakeSetPipelineStepDelegate) FinishedArgsForCall(i int) (lager.Logge
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeSetPipelineStepDelegate) HookTimedOut(arg1 lager.Logger, arg2 time.Duration) {
+	fake.hookTimedOutMutex.Lock()
+	fake.hookTimedOutArgsForCall = append(fake.hookTimedOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.HookTimedOutStub
+	fake.recordInvocation("HookTimedOut", []interface{}{arg1, arg2})
+	fake.hookTimedOutMutex.Unlock()
+	if stub != nil {
+		fake.HookTimedOutStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) HookTimedOutCallCount() int {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	return len(fake.hookTimedOutArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) HookTimedOutCalls(stub func(lager.Logger, time.Duration)) {
+	fake.hookTimedOutMutex.Lock()
+	defer fake.hookTimedOutMutex.Unlock()
+	fake.HookTimedOutStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) HookTimedOutArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
+	argsForCall := fake.hookTimedOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeSetPipelineStepDelegate) Initializing(arg1 lager.Logger) {
 	fake.initializingMutex.Lock()
 	fake.initializingArgsForCall = append(fake.initializingArgsForCall, struct {
@@ -448,6 +639,138 @@ func (fake *FakeSetPipelineStepDelegate) InitializingArgsForCall(i int) lager.Lo
 	return argsForCall.arg1
 }
 
+func (fake *FakeSetPipelineStepDelegate) ListResourceVersions(arg1 string, arg2 int) ([]atc.Version, error) {
+	fake.listResourceVersionsMutex.Lock()
+	ret, specificReturn := fake.listResourceVersionsReturnsOnCall[len(fake.listResourceVersionsArgsForCall)]
+	fake.listResourceVersionsArgsForCall = append(fake.listResourceVersionsArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ListResourceVersionsStub
+	fakeReturns := fake.listResourceVersionsReturns
+	fake.recordInvocation("ListResourceVersions", []interface{}{arg1, arg2})
+	fake.listResourceVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSetPipelineStepDelegate) ListResourceVersionsCallCount() int {
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	return len(fake.listResourceVersionsArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) ListResourceVersionsCalls(stub func(string, int) ([]atc.Version, error)) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) ListResourceVersionsArgsForCall(i int) (string, int) {
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	argsForCall := fake.listResourceVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSetPipelineStepDelegate) ListResourceVersionsReturns(result1 []atc.Version, result2 error) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = nil
+	fake.listResourceVersionsReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSetPipelineStepDelegate) ListResourceVersionsReturnsOnCall(i int, result1 []atc.Version, result2 error) {
+	fake.listResourceVersionsMutex.Lock()
+	defer fake.listResourceVersionsMutex.Unlock()
+	fake.ListResourceVersionsStub = nil
+	if fake.listResourceVersionsReturnsOnCall == nil {
+		fake.listResourceVersionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.Version
+			result2 error
+		})
+	}
+	fake.listResourceVersionsReturnsOnCall[i] = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSetPipelineStepDelegate) PhaseFinished(arg1 lager.Logger, arg2 exec.StepPhase, arg3 time.Duration) {
+	fake.phaseFinishedMutex.Lock()
+	fake.phaseFinishedArgsForCall = append(fake.phaseFinishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.PhaseFinishedStub
+	fake.recordInvocation("PhaseFinished", []interface{}{arg1, arg2, arg3})
+	fake.phaseFinishedMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) PhaseFinishedCallCount() int {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	return len(fake.phaseFinishedArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) PhaseFinishedCalls(stub func(lager.Logger, exec.StepPhase, time.Duration)) {
+	fake.phaseFinishedMutex.Lock()
+	defer fake.phaseFinishedMutex.Unlock()
+	fake.PhaseFinishedStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) PhaseFinishedArgsForCall(i int) (lager.Logger, exec.StepPhase, time.Duration) {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	argsForCall := fake.phaseFinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSetPipelineStepDelegate) Rescheduled(arg1 lager.Logger, arg2 string) {
+	fake.rescheduledMutex.Lock()
+	fake.rescheduledArgsForCall = append(fake.rescheduledArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RescheduledStub
+	fake.recordInvocation("Rescheduled", []interface{}{arg1, arg2})
+	fake.rescheduledMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) RescheduledCallCount() int {
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
+	return len(fake.rescheduledArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) RescheduledCalls(stub func(lager.Logger, string)) {
+	fake.rescheduledMutex.Lock()
+	defer fake.rescheduledMutex.Unlock()
+	fake.RescheduledStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) RescheduledArgsForCall(i int) (lager.Logger, string) {
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
+	argsForCall := fake.rescheduledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeSetPipelineStepDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -718,6 +1041,39 @@ func (fake *FakeSetPipelineStepDelegate) StdoutReturnsOnCall(i int, result1 io.W
 	}{result1}
 }
 
+func (fake *FakeSetPipelineStepDelegate) TimeoutWarning(arg1 lager.Logger, arg2 time.Duration) {
+	fake.timeoutWarningMutex.Lock()
+	fake.timeoutWarningArgsForCall = append(fake.timeoutWarningArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.TimeoutWarningStub
+	fake.recordInvocation("TimeoutWarning", []interface{}{arg1, arg2})
+	fake.timeoutWarningMutex.Unlock()
+	if stub != nil {
+		fake.TimeoutWarningStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) TimeoutWarningCallCount() int {
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
+	return len(fake.timeoutWarningArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) TimeoutWarningCalls(stub func(lager.Logger, time.Duration)) {
+	fake.timeoutWarningMutex.Lock()
+	defer fake.timeoutWarningMutex.Unlock()
+	fake.TimeoutWarningStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) TimeoutWarningArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
+	argsForCall := fake.timeoutWarningArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeSetPipelineStepDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -753,8 +1109,12 @@ func (fake *FakeSetPipelineStepDelegate) WaitingForWorkerArgsForCall(i int) lage
 func (fake *FakeSetPipelineStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.buildTimedOutMutex.RLock()
+	defer fake.buildTimedOutMutex.RUnlock()
 	fake.checkRunSetPipelinePolicyMutex.RLock()
 	defer fake.checkRunSetPipelinePolicyMutex.RUnlock()
+	fake.checkSetPipelineAcrossTeamsPolicyMutex.RLock()
+	defer fake.checkSetPipelineAcrossTeamsPolicyMutex.RUnlock()
 	fake.constructAcrossSubstepsMutex.RLock()
 	defer fake.constructAcrossSubstepsMutex.RUnlock()
 	fake.erroredMutex.RLock()
@@ -763,8 +1123,16 @@ func (fake *FakeSetPipelineStepDelegate) Invocations() map[string][][]interface{
 	defer fake.fetchImageMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.hookTimedOutMutex.RLock()
+	defer fake.hookTimedOutMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.listResourceVersionsMutex.RLock()
+	defer fake.listResourceVersionsMutex.RUnlock()
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	fake.rescheduledMutex.RLock()
+	defer fake.rescheduledMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.setPipelineChangedMutex.RLock()
@@ -777,6 +1145,8 @@ func (fake *FakeSetPipelineStepDelegate) Invocations() map[string][][]interface{
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.timeoutWarningMutex.RLock()
+	defer fake.timeoutWarningMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
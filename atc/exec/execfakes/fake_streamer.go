@@ -11,6 +11,21 @@ import (
 )
 
 type FakeStreamer struct {
+	StreamDirStub        func(context.Context, runtime.Artifact, string) (map[string][]byte, error)
+	streamDirMutex       sync.RWMutex
+	streamDirArgsForCall []struct {
+		arg1 context.Context
+		arg2 runtime.Artifact
+		arg3 string
+	}
+	streamDirReturns struct {
+		result1 map[string][]byte
+		result2 error
+	}
+	streamDirReturnsOnCall map[int]struct {
+		result1 map[string][]byte
+		result2 error
+	}
 	StreamFileStub        func(context.Context, runtime.Artifact, string) (io.ReadCloser, error)
 	streamFileMutex       sync.RWMutex
 	streamFileArgsForCall []struct {
@@ -30,6 +45,72 @@ type FakeStreamer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeStreamer) StreamDir(arg1 context.Context, arg2 runtime.Artifact, arg3 string) (map[string][]byte, error) {
+	fake.streamDirMutex.Lock()
+	ret, specificReturn := fake.streamDirReturnsOnCall[len(fake.streamDirArgsForCall)]
+	fake.streamDirArgsForCall = append(fake.streamDirArgsForCall, struct {
+		arg1 context.Context
+		arg2 runtime.Artifact
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.StreamDirStub
+	fakeReturns := fake.streamDirReturns
+	fake.recordInvocation("StreamDir", []interface{}{arg1, arg2, arg3})
+	fake.streamDirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStreamer) StreamDirCallCount() int {
+	fake.streamDirMutex.RLock()
+	defer fake.streamDirMutex.RUnlock()
+	return len(fake.streamDirArgsForCall)
+}
+
+func (fake *FakeStreamer) StreamDirCalls(stub func(context.Context, runtime.Artifact, string) (map[string][]byte, error)) {
+	fake.streamDirMutex.Lock()
+	defer fake.streamDirMutex.Unlock()
+	fake.StreamDirStub = stub
+}
+
+func (fake *FakeStreamer) StreamDirArgsForCall(i int) (context.Context, runtime.Artifact, string) {
+	fake.streamDirMutex.RLock()
+	defer fake.streamDirMutex.RUnlock()
+	argsForCall := fake.streamDirArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStreamer) StreamDirReturns(result1 map[string][]byte, result2 error) {
+	fake.streamDirMutex.Lock()
+	defer fake.streamDirMutex.Unlock()
+	fake.StreamDirStub = nil
+	fake.streamDirReturns = struct {
+		result1 map[string][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStreamer) StreamDirReturnsOnCall(i int, result1 map[string][]byte, result2 error) {
+	fake.streamDirMutex.Lock()
+	defer fake.streamDirMutex.Unlock()
+	fake.StreamDirStub = nil
+	if fake.streamDirReturnsOnCall == nil {
+		fake.streamDirReturnsOnCall = make(map[int]struct {
+			result1 map[string][]byte
+			result2 error
+		})
+	}
+	fake.streamDirReturnsOnCall[i] = struct {
+		result1 map[string][]byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStreamer) StreamFile(arg1 context.Context, arg2 runtime.Artifact, arg3 string) (io.ReadCloser, error) {
 	fake.streamFileMutex.Lock()
 	ret, specificReturn := fake.streamFileReturnsOnCall[len(fake.streamFileArgsForCall)]
@@ -99,6 +180,8 @@ func (fake *FakeStreamer) StreamFileReturnsOnCall(i int, result1 io.ReadCloser,
 func (fake *FakeStreamer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.streamDirMutex.RLock()
+	defer fake.streamDirMutex.RUnlock()
 	fake.streamFileMutex.RLock()
 	defer fake.streamFileMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -5,16 +5,31 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/scanner"
 	"github.com/concourse/concourse/tracing"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type FakeTaskDelegate struct {
+	ArtifactArchivedStub        func(lager.Logger, string, string)
+	artifactArchivedMutex       sync.RWMutex
+	artifactArchivedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+	}
+	ArtifactScannedStub        func(lager.Logger, scanner.Report)
+	artifactScannedMutex       sync.RWMutex
+	artifactScannedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 scanner.Report
+	}
 	ErroredStub        func(lager.Logger, string)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
@@ -49,6 +64,27 @@ type FakeTaskDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	PhaseFinishedStub        func(lager.Logger, exec.StepPhase, time.Duration)
+	phaseFinishedMutex       sync.RWMutex
+	phaseFinishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}
+	SaveTestResultsStub        func(context.Context, lager.Logger, string, []atc.TestResult) error
+	saveTestResultsMutex       sync.RWMutex
+	saveTestResultsArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 []atc.TestResult
+	}
+	saveTestResultsReturns struct {
+		result1 error
+	}
+	saveTestResultsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -109,6 +145,73 @@ type FakeTaskDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTaskDelegate) ArtifactArchived(arg1 lager.Logger, arg2 string, arg3 string) {
+	fake.artifactArchivedMutex.Lock()
+	fake.artifactArchivedArgsForCall = append(fake.artifactArchivedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ArtifactArchivedStub
+	fake.recordInvocation("ArtifactArchived", []interface{}{arg1, arg2, arg3})
+	fake.artifactArchivedMutex.Unlock()
+	if stub != nil {
+		fake.ArtifactArchivedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeTaskDelegate) ArtifactArchivedCallCount() int {
+	fake.artifactArchivedMutex.RLock()
+	defer fake.artifactArchivedMutex.RUnlock()
+	return len(fake.artifactArchivedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ArtifactArchivedCalls(stub func(lager.Logger, string, string)) {
+	fake.artifactArchivedMutex.Lock()
+	defer fake.artifactArchivedMutex.Unlock()
+	fake.ArtifactArchivedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ArtifactArchivedArgsForCall(i int) (lager.Logger, string, string) {
+	fake.artifactArchivedMutex.RLock()
+	defer fake.artifactArchivedMutex.RUnlock()
+	argsForCall := fake.artifactArchivedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDelegate) ArtifactScanned(arg1 lager.Logger, arg2 scanner.Report) {
+	fake.artifactScannedMutex.Lock()
+	fake.artifactScannedArgsForCall = append(fake.artifactScannedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 scanner.Report
+	}{arg1, arg2})
+	stub := fake.ArtifactScannedStub
+	fake.recordInvocation("ArtifactScanned", []interface{}{arg1, arg2})
+	fake.artifactScannedMutex.Unlock()
+	if stub != nil {
+		fake.ArtifactScannedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) ArtifactScannedCallCount() int {
+	fake.artifactScannedMutex.RLock()
+	defer fake.artifactScannedMutex.RUnlock()
+	return len(fake.artifactScannedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ArtifactScannedCalls(stub func(lager.Logger, scanner.Report)) {
+	fake.artifactScannedMutex.Lock()
+	defer fake.artifactScannedMutex.Unlock()
+	fake.ArtifactScannedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ArtifactScannedArgsForCall(i int) (lager.Logger, scanner.Report) {
+	fake.artifactScannedMutex.RLock()
+	defer fake.artifactScannedMutex.RUnlock()
+	argsForCall := fake.artifactScannedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 string) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
@@ -275,6 +378,104 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeTaskDelegate) PhaseFinished(arg1 lager.Logger, arg2 exec.StepPhase, arg3 time.Duration) {
+	fake.phaseFinishedMutex.Lock()
+	fake.phaseFinishedArgsForCall = append(fake.phaseFinishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.StepPhase
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.PhaseFinishedStub
+	fake.recordInvocation("PhaseFinished", []interface{}{arg1, arg2, arg3})
+	fake.phaseFinishedMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeTaskDelegate) PhaseFinishedCallCount() int {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	return len(fake.phaseFinishedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) PhaseFinishedCalls(stub func(lager.Logger, exec.StepPhase, time.Duration)) {
+	fake.phaseFinishedMutex.Lock()
+	defer fake.phaseFinishedMutex.Unlock()
+	fake.PhaseFinishedStub = stub
+}
+
+func (fake *FakeTaskDelegate) PhaseFinishedArgsForCall(i int) (lager.Logger, exec.StepPhase, time.Duration) {
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	argsForCall := fake.phaseFinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDelegate) SaveTestResults(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 []atc.TestResult) error {
+	fake.saveTestResultsMutex.Lock()
+	ret, specificReturn := fake.saveTestResultsReturnsOnCall[len(fake.saveTestResultsArgsForCall)]
+	fake.saveTestResultsArgsForCall = append(fake.saveTestResultsArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 []atc.TestResult
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SaveTestResultsStub
+	fakeReturns := fake.saveTestResultsReturns
+	fake.recordInvocation("SaveTestResults", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveTestResultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsCallCount() int {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return len(fake.saveTestResultsArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsCalls(stub func(context.Context, lager.Logger, string, []atc.TestResult) error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = stub
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsArgsForCall(i int) (context.Context, lager.Logger, string, []atc.TestResult) {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	argsForCall := fake.saveTestResultsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsReturns(result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	fake.saveTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsReturnsOnCall(i int, result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	if fake.saveTestResultsReturnsOnCall == nil {
+		fake.saveTestResultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTestResultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTaskDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -579,6 +780,10 @@ func (fake *FakeTaskDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.artifactArchivedMutex.RLock()
+	defer fake.artifactArchivedMutex.RUnlock()
+	fake.artifactScannedMutex.RLock()
+	defer fake.artifactScannedMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	fake.fetchImageMutex.RLock()
@@ -587,6 +792,10 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.phaseFinishedMutex.RLock()
+	defer fake.phaseFinishedMutex.RUnlock()
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.setTaskConfigMutex.RLock()
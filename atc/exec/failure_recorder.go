@@ -0,0 +1,48 @@
+package exec
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type failureRecorderKey struct{}
+
+// failureRecorder is carried through the context of each attempt of a retried
+// step with retry_on conditions, so that the kinds of failure which steps
+// otherwise report as a plain failure can be told apart.
+type failureRecorder struct {
+	timeouts       *timeoutRecorder
+	scriptFailures int32
+	parent         *failureRecorder
+}
+
+func withFailureRecorder(ctx context.Context) (context.Context, *failureRecorder) {
+	recorder := &failureRecorder{
+		timeouts: &timeoutRecorder{
+			parent: timeoutRecorderFromContext(ctx),
+		},
+		parent: failureRecorderFromContext(ctx),
+	}
+
+	ctx = context.WithValue(ctx, timeoutRecorderKey{}, recorder.timeouts)
+	ctx = context.WithValue(ctx, failureRecorderKey{}, recorder)
+
+	return ctx, recorder
+}
+
+func failureRecorderFromContext(ctx context.Context) *failureRecorder {
+	recorder, _ := ctx.Value(failureRecorderKey{}).(*failureRecorder)
+	return recorder
+}
+
+func (recorder *failureRecorder) scriptFailed() bool {
+	return atomic.LoadInt32(&recorder.scriptFailures) == 1
+}
+
+// recordScriptFailure notes that a resource script exited nonzero for every
+// retried step enclosing the step running with ctx.
+func recordScriptFailure(ctx context.Context) {
+	for recorder := failureRecorderFromContext(ctx); recorder != nil; recorder = recorder.parent {
+		atomic.StoreInt32(&recorder.scriptFailures, 1)
+	}
+}
@@ -2,10 +2,16 @@ package exec
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -20,11 +26,16 @@ import (
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/worker/baggageclaim"
 	"go.opentelemetry.io/otel/trace"
 )
 
 var GetResourceLockInterval = 5 * time.Second
 
+// getVarName is the local var under which each successful get step exposes
+// its fetched version and metadata, keyed by the step name.
+const getVarName = "get"
+
 type ErrPipelineNotFound struct {
 	PipelineName string
 }
@@ -67,8 +78,11 @@ type GetDelegate interface {
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+	PhaseFinished(lager.Logger, StepPhase, time.Duration)
 
 	UpdateMetadata(lager.Logger, string, db.ResourceCache, resource.VersionResult)
+
+	VersionHistory(lager.Logger, string, atc.Version, int) ([]atc.Version, error)
 }
 
 // GetStep will fetch a version of a resource on a worker that supports the
@@ -83,6 +97,7 @@ type GetStep struct {
 	workerPool           Pool
 	lockFactory          lock.LockFactory
 	delegateFactory      GetDelegateFactory
+	streamer             Streamer
 }
 
 func NewGetStep(
@@ -95,6 +110,7 @@ func NewGetStep(
 	strategy worker.PlacementStrategy,
 	delegateFactory GetDelegateFactory,
 	pool Pool,
+	streamer Streamer,
 ) Step {
 	return &GetStep{
 		planID:               planID,
@@ -106,6 +122,7 @@ func NewGetStep(
 		lockFactory:          lockFactory,
 		delegateFactory:      delegateFactory,
 		workerPool:           pool,
+		streamer:             streamer,
 	}
 }
 
@@ -169,9 +186,11 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	}
 
 	containerSpec := runtime.ContainerSpec{
-		TeamID:   step.metadata.TeamID,
-		TeamName: step.metadata.TeamName,
-		JobID:    step.metadata.JobID,
+		TeamID:       step.metadata.TeamID,
+		TeamName:     step.metadata.TeamName,
+		PipelineID:   step.metadata.PipelineID,
+		PipelineName: step.metadata.PipelineName,
+		JobID:        step.metadata.JobID,
 
 		ImageSpec: imageSpec,
 
@@ -181,9 +200,18 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		Dir: step.containerMetadata.WorkingDirectory,
 
 		CertsBindMount: true,
+		Priority:       step.plan.Priority,
+	}
+	if step.plan.Limits != nil {
+		containerSpec.Limits.CPU = (*uint64)(step.plan.Limits.CPU)
+		containerSpec.Limits.Memory = (*uint64)(step.plan.Limits.Memory)
 	}
 	tracing.Inject(ctx, &containerSpec)
 
+	if step.plan.Depth > 1 {
+		return step.runWithDepth(ctx, logger, state, delegate, source, params, version, workerSpec, containerSpec)
+	}
+
 	resourceCache, err := step.resourceCacheFactory.FindOrCreateResourceCache(
 		db.ForBuild(step.metadata.BuildID),
 		step.plan.Type,
@@ -217,6 +245,7 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			delegate.Errored(logger, TimeoutLogMessage)
+			recordTimeout(ctx)
 			return false, nil
 		}
 
@@ -225,6 +254,11 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 
 	var succeeded bool
 	if processResult.ExitStatus == 0 {
+		err := step.verify(ctx, logger, state, delegate, volume)
+		if err != nil {
+			return false, err
+		}
+
 		state.StoreResult(step.planID, GetResult{
 			Name:          step.plan.Name,
 			ResourceCache: resourceCache,
@@ -235,10 +269,16 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 			volume,
 		)
 
+		step.addVersionVar(state, versionResult)
+
 		// step.plan.Resource can be empty if running for a non-named resource.
 		delegate.UpdateMetadata(logger, step.plan.Resource, resourceCache, versionResult)
 
+		step.requestReplication(logger, resourceCache)
+
 		succeeded = true
+	} else {
+		recordScriptFailure(ctx)
 	}
 
 	delegate.Finished(
@@ -250,6 +290,171 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	return succeeded, nil
 }
 
+// runWithDepth fetches the latest versions of the resource up to and including
+// the version being fetched, each into a subdirectory named after its index
+// with the newest at 0. The fetched volume holds several versions and so is
+// not initialized as a resource cache.
+func (step *GetStep) runWithDepth(
+	ctx context.Context,
+	logger lager.Logger,
+	state RunState,
+	delegate GetDelegate,
+	source atc.Source,
+	params atc.Params,
+	version atc.Version,
+	workerSpec worker.Spec,
+	containerSpec runtime.ContainerSpec,
+) (bool, error) {
+	versions := []atc.Version{version}
+
+	// step.plan.Resource can be empty if running for a non-named resource, in
+	// which case there is no version history to fetch.
+	if step.plan.Resource != "" {
+		var err error
+		versions, err = delegate.VersionHistory(logger, step.plan.Resource, version, step.plan.Depth)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	containerOwner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
+
+	delegate.Starting(logger)
+	volume, versionResult, processResult, err := step.performGetVersions(
+		ctx,
+		logger,
+		delegate,
+		source,
+		params,
+		versions,
+		workerSpec,
+		containerSpec,
+		containerOwner,
+	)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			delegate.Errored(logger, TimeoutLogMessage)
+			recordTimeout(ctx)
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	var succeeded bool
+	if processResult.ExitStatus == 0 {
+		err := step.verify(ctx, logger, state, delegate, volume)
+		if err != nil {
+			return false, err
+		}
+
+		state.ArtifactRepository().RegisterArtifact(
+			build.ArtifactName(step.plan.Name),
+			volume,
+		)
+
+		step.addVersionVar(state, versionResult)
+
+		succeeded = true
+	} else {
+		recordScriptFailure(ctx)
+	}
+
+	delegate.Finished(
+		logger,
+		ExitStatus(processResult.ExitStatus),
+		versionResult,
+	)
+
+	return succeeded, nil
+}
+
+// performGetVersions runs the get script once per version within a single
+// container, stopping at the first failure. The result of the newest version
+// is returned.
+func (step *GetStep) performGetVersions(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate GetDelegate,
+	source atc.Source,
+	params atc.Params,
+	versions []atc.Version,
+	workerSpec worker.Spec,
+	containerSpec runtime.ContainerSpec,
+	containerOwner db.ContainerOwner,
+) (runtime.Volume, resource.VersionResult, runtime.ProcessResult, error) {
+	logger = logger.Session("perform-get-versions")
+	ctx = lagerctx.NewContext(ctx, logger)
+
+	finishWaiting := startPhase(logger, delegate, StepPhaseWaitingForWorker)
+	worker, err := step.workerPool.FindOrSelectWorker(ctx, containerOwner, containerSpec, workerSpec, step.strategy, delegate)
+	if err != nil {
+		logger.Error("failed-to-select-worker", err)
+		return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
+	}
+	finishWaiting()
+
+	delegate.SelectedWorker(logger, worker.Name())
+
+	defer func() {
+		step.workerPool.ReleaseWorker(
+			logger,
+			containerSpec,
+			worker,
+			step.strategy,
+		)
+	}()
+
+	ctx, cancel, err := MaybeTimeout(ctx, step.plan.Timeout)
+	if err != nil {
+		return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
+	}
+	ctx = lagerctx.NewContext(ctx, logger)
+
+	defer cancel()
+
+	finishCreating := startPhase(logger, delegate, StepPhaseCreatingContainer)
+	container, mounts, err := worker.FindOrCreateContainer(ctx, containerOwner, step.containerMetadata, containerSpec)
+	if err != nil {
+		logger.Error("failed-to-create-container", err)
+		return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
+	}
+	finishCreating()
+
+	defer startPhase(logger, delegate, StepPhaseRunning)()
+
+	var newestResult resource.VersionResult
+	for i, version := range versions {
+		getResource := resource.Resource{
+			Source:  source,
+			Params:  params,
+			Version: version,
+		}
+
+		versionResult, processResult, err := getResource.GetInto(
+			ctx,
+			container,
+			delegate.Stderr(),
+			fmt.Sprintf("resource-%d", i),
+			filepath.Join(resource.ResourcesDir("get"), strconv.Itoa(i)),
+		)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err, lager.Data{"version": version})
+			return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
+		}
+
+		if processResult.ExitStatus != 0 {
+			return nil, versionResult, processResult, nil
+		}
+
+		if i == 0 {
+			newestResult = versionResult
+		}
+	}
+
+	return resourceMountVolume(mounts), newestResult, runtime.ProcessResult{ExitStatus: 0}, nil
+}
+
 func (step *GetStep) retrieveFromCacheOrPerformGet(
 	ctx context.Context,
 	logger lager.Logger,
@@ -272,11 +477,13 @@ func (step *GetStep) retrieveFromCacheOrPerformGet(
 	// ton of streaming out.
 	if !atc.EnableCacheStreamedVolumes {
 		var err error
+		finishWaiting := startPhase(logger, delegate, StepPhaseWaitingForWorker)
 		worker, err = step.workerPool.FindOrSelectWorker(ctx, containerOwner, containerSpec, workerSpec, step.strategy, delegate)
 		if err != nil {
 			logger.Error("failed-to-select-worker", err)
 			return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
 		}
+		finishWaiting()
 
 		// The lock is unique only to the current worker when not caching
 		// streamed volumes since we only consider the current worker's local
@@ -399,15 +606,22 @@ func (step *GetStep) retrieveFromCache(
 		return nil, resource.VersionResult{}, false, nil
 	}
 
-	metadata, err := step.resourceCacheFactory.ResourceCacheMetadata(resourceCache)
+	result, err := step.cachedVersionResult(resourceCache)
 	if err != nil {
 		return nil, resource.VersionResult{}, false, err
 	}
-	result := resource.VersionResult{
+	return volume, result, true, nil
+}
+
+func (step *GetStep) cachedVersionResult(resourceCache db.ResourceCache) (resource.VersionResult, error) {
+	metadata, err := step.resourceCacheFactory.ResourceCacheMetadata(resourceCache)
+	if err != nil {
+		return resource.VersionResult{}, err
+	}
+	return resource.VersionResult{
 		Version:  resourceCache.Version(),
 		Metadata: metadata.ToATCMetadata(),
-	}
-	return volume, result, true, nil
+	}, nil
 }
 
 // Must be called under a global database lock unique to the resource cache
@@ -432,11 +646,13 @@ func (step *GetStep) performGetAndInitCache(
 	// front.
 	if worker == nil {
 		var err error
+		finishWaiting := startPhase(logger, delegate, StepPhaseWaitingForWorker)
 		worker, err = step.workerPool.FindOrSelectWorker(ctx, containerOwner, containerSpec, workerSpec, step.strategy, delegate)
 		if err != nil {
 			logger.Error("failed-to-select-worker", err)
 			return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
 		}
+		finishWaiting()
 
 		delegate.SelectedWorker(logger, worker.Name())
 
@@ -450,6 +666,25 @@ func (step *GetStep) performGetAndInitCache(
 		}()
 	}
 
+	// The resource cache may have been persisted by a worker that fetched it
+	// before, in which case it needn't be fetched again.
+	if atc.EnablePersistentResourceCaches {
+		volume, hydrated, err := worker.HydrateResourceCache(logger, step.metadata.TeamID, resourceCache)
+		if err != nil {
+			logger.Error("failed-to-hydrate-resource-cache", err)
+			return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
+		}
+		if hydrated {
+			versionResult, err := step.cachedVersionResult(resourceCache)
+			if err != nil {
+				return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
+			}
+			fmt.Fprintln(delegate.Stderr(), "\x1b[1;36mINFO: hydrated persisted resource cache\x1b[0m")
+			fmt.Fprintln(delegate.Stderr(), "")
+			return volume, versionResult, runtime.ProcessResult{ExitStatus: 0}, nil
+		}
+	}
+
 	ctx, cancel, err := MaybeTimeout(ctx, step.plan.Timeout)
 	if err != nil {
 		return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
@@ -458,13 +693,17 @@ func (step *GetStep) performGetAndInitCache(
 
 	defer cancel()
 
+	finishCreating := startPhase(logger, delegate, StepPhaseCreatingContainer)
 	container, mounts, err := worker.FindOrCreateContainer(ctx, containerOwner, step.containerMetadata, containerSpec)
 	if err != nil {
 		logger.Error("failed-to-create-container", err)
 		return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
 	}
+	finishCreating()
 
+	finishRunning := startPhase(logger, delegate, StepPhaseRunning)
 	versionResult, processResult, err := getResource.Get(ctx, container, delegate.Stderr())
+	finishRunning()
 	if err != nil {
 		logger.Error("failed-to-get-resource", err)
 		return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
@@ -484,6 +723,124 @@ func (step *GetStep) performGetAndInitCache(
 	return volume, versionResult, processResult, nil
 }
 
+// requestReplication records that the cache was fetched, so that it can be
+// replicated to the other workers the team's builds may run on.
+func (step *GetStep) requestReplication(logger lager.Logger, resourceCache db.ResourceCache) {
+	if atc.ResourceCacheReplicationFactor == 0 {
+		return
+	}
+
+	err := step.resourceCacheFactory.RequestReplication(resourceCache, step.metadata.TeamID, step.plan.Tags)
+	if err != nil {
+		// the build doesn't need the cache replicated, so it isn't failed
+		logger.Error("failed-to-request-replication", err)
+	}
+}
+
+// addVersionVar exposes the fetched version and metadata to later steps, e.g.
+// as ((.:get.some-name.metadata.commit)).
+func (step *GetStep) addVersionVar(state RunState, versionResult resource.VersionResult) {
+	version := map[string]interface{}{}
+	for k, v := range versionResult.Version {
+		version[k] = v
+	}
+
+	metadata := map[string]interface{}{}
+	for _, field := range versionResult.Metadata {
+		metadata[field.Name] = field.Value
+	}
+
+	state.AddLocalVarField(getVarName, step.plan.Name, map[string]interface{}{
+		"version":  version,
+		"metadata": metadata,
+	})
+}
+
+// verify checks the digests configured in the plan against the file within
+// the fetched volume.
+func (step *GetStep) verify(ctx context.Context, logger lager.Logger, state RunState, delegate GetDelegate, volume runtime.Volume) error {
+	if step.plan.Verify == nil {
+		return nil
+	}
+
+	digests := []struct {
+		algorithm string
+		expected  string
+		hash      hash.Hash
+	}{
+		{"sha256", step.plan.Verify.SHA256, sha256.New()},
+		{"sha512", step.plan.Verify.SHA512, sha512.New()},
+	}
+
+	var writers []io.Writer
+	for i, digest := range digests {
+		if digest.expected == "" {
+			continue
+		}
+
+		expected, err := creds.NewString(state, digest.expected).Evaluate()
+		if err != nil {
+			return err
+		}
+
+		digests[i].expected = expected
+		writers = append(writers, digest.hash)
+	}
+
+	stream, err := step.streamer.StreamFile(lagerctx.NewContext(ctx, logger), volume, step.plan.Verify.File)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return FileNotFoundError{
+				Name:     step.plan.Name,
+				FilePath: step.plan.Verify.File,
+			}
+		}
+
+		return err
+	}
+
+	defer stream.Close()
+
+	_, err = io.Copy(io.MultiWriter(writers...), stream)
+	if err != nil {
+		return err
+	}
+
+	for _, digest := range digests {
+		if digest.expected == "" {
+			continue
+		}
+
+		actual := hex.EncodeToString(digest.hash.Sum(nil))
+		if !strings.EqualFold(actual, strings.TrimSpace(digest.expected)) {
+			return ChecksumMismatchError{
+				File:      step.plan.Verify.File,
+				Algorithm: digest.algorithm,
+				Expected:  digest.expected,
+				Actual:    actual,
+			}
+		}
+
+		fmt.Fprintf(delegate.Stdout(), "verified %s digest of %s\n", digest.algorithm, step.plan.Verify.File)
+	}
+
+	return nil
+}
+
+// ChecksumMismatchError is returned when the digest of a fetched file does
+// not match the one given in the plan.
+type ChecksumMismatchError struct {
+	File      string
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+// Error returns a human-friendly error message.
+func (err ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s digest of '%s' does not match: expected %s, got %s", err.Algorithm, err.File, err.Expected, err.Actual)
+}
+
 func resourceMountVolume(mounts []runtime.VolumeMount) runtime.Volume {
 	for _, mnt := range mounts {
 		if mnt.MountPath == resource.ResourcesDir("get") {
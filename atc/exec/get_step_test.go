@@ -2,8 +2,13 @@ package exec_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -21,8 +26,10 @@ import (
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
+	"github.com/concourse/concourse/worker/baggageclaim"
 	"github.com/onsi/gomega/gbytes"
 	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/trace"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -48,6 +55,8 @@ var _ = Describe("GetStep", func() {
 
 		fakeLockFactory *lockfakes.FakeLockFactory
 
+		fakeStreamer *execfakes.FakeStreamer
+
 		spanCtx context.Context
 
 		getPlan *atc.GetPlan
@@ -110,6 +119,8 @@ var _ = Describe("GetStep", func() {
 
 		fakeLockFactory = lockOnAttempt(1)
 
+		fakeStreamer = new(execfakes.FakeStreamer)
+
 		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
 		fakeResourceCache = new(dbfakes.FakeResourceCache)
 
@@ -164,6 +175,7 @@ var _ = Describe("GetStep", func() {
 			nil,
 			fakeDelegateFactory,
 			fakePool,
+			fakeStreamer,
 		)
 
 		stepOk, stepErr = getStep.Run(ctx, runState)
@@ -240,6 +252,8 @@ var _ = Describe("GetStep", func() {
 				},
 				TeamID:         stepMetadata.TeamID,
 				TeamName:       stepMetadata.TeamName,
+				PipelineID:     stepMetadata.PipelineID,
+				PipelineName:   stepMetadata.PipelineName,
 				Type:           containerMetadata.Type,
 				Env:            stepMetadata.Env(),
 				Dir:            resource.ResourcesDir("get"),
@@ -248,6 +262,22 @@ var _ = Describe("GetStep", func() {
 		))
 	})
 
+	Context("when the plan specifies container limits", func() {
+		BeforeEach(func() {
+			cpu := atc.CPULimit(512)
+			memory := atc.MemoryLimit(1024)
+			getPlan.Limits = &atc.ContainerLimits{
+				CPU:    &cpu,
+				Memory: &memory,
+			}
+		})
+
+		It("sets them in the ContainerSpec", func() {
+			Expect(*chosenContainer.Spec.Limits.CPU).To(Equal(uint64(512)))
+			Expect(*chosenContainer.Spec.Limits.Memory).To(Equal(uint64(1024)))
+		})
+	})
+
 	Describe("retrieve from cache or run get step", func() {
 		BeforeEach(func() {
 			exec.GetResourceLockInterval = 10 * time.Millisecond
@@ -349,6 +379,69 @@ var _ = Describe("GetStep", func() {
 					Expect(stderrBuf).ToNot(gbytes.Say("INFO"))
 				})
 
+				Context("when the resource cache was persisted", func() {
+					BeforeEach(func() {
+						atc.EnablePersistentResourceCaches = true
+
+						chosenContainer.ProcessDefs[0].Stub.Err = "should not run"
+
+						fakeResourceCache.IDReturns(42)
+						chosenWorker.PersistedResourceCaches = map[int]runtimetest.VolumeContent{
+							42: {"file": {Data: []byte("content")}},
+						}
+						fakeResourceCacheFactory.ResourceCacheMetadataReturns(db.ResourceConfigMetadataFields{
+							{Name: "some", Value: "metadata"},
+						}, nil)
+					})
+
+					AfterEach(func() {
+						atc.EnablePersistentResourceCaches = false
+					})
+
+					It("succeeds", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+					})
+
+					It("registers the hydrated volume as an artifact", func() {
+						artifact, found := artifactRepository.ArtifactFor(build.ArtifactName(getPlan.Name))
+						Expect(found).To(BeTrue())
+						Expect(artifact.(*runtimetest.Volume).Content).To(HaveKey("file"))
+						Expect(artifact.(*runtimetest.Volume).ResourceCacheInitialized).To(BeTrue())
+					})
+
+					It("doesn't initialize the get volume", func() {
+						Expect(getVolume.ResourceCacheInitialized).To(BeFalse())
+					})
+
+					It("finishes with the cached version result", func() {
+						Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+						_, exitStatus, versionResult := fakeDelegate.FinishedArgsForCall(0)
+						Expect(exitStatus).To(Equal(exec.ExitStatus(0)))
+						Expect(versionResult.Metadata).To(Equal([]atc.MetadataField{
+							{Name: "some", Value: "metadata"},
+						}))
+					})
+
+					It("logs a message to stderr", func() {
+						Expect(stderrBuf).To(gbytes.Say(`INFO.*hydrated.*cache`))
+					})
+				})
+
+				Context("when persistent resource caches are enabled but the cache wasn't persisted", func() {
+					BeforeEach(func() {
+						atc.EnablePersistentResourceCaches = true
+					})
+
+					AfterEach(func() {
+						atc.EnablePersistentResourceCaches = false
+					})
+
+					It("fetches and initializes the get volume", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(getVolume.ResourceCacheInitialized).To(BeTrue())
+					})
+				})
+
 				Context("when the lock isn't initially acquired", func() {
 					BeforeEach(func() {
 						fakeLockFactory = lockOnAttempt(3)
@@ -669,9 +762,55 @@ var _ = Describe("GetStep", func() {
 			Expect(fakeDelegate.UpdateMetadataCallCount()).To(Equal(1))
 		})
 
+		It("exposes the version and metadata as local vars", func() {
+			val, found, err := runState.Get(vars.Reference{Source: ".", Path: "get", Fields: []string{"some-name", "metadata", "some"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("metadata"))
+
+			val, found, err = runState.Get(vars.Reference{Source: ".", Path: "get", Fields: []string{"some-name", "version", "some"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("version"))
+		})
+
 		It("does not return an err", func() {
 			Expect(stepErr).ToNot(HaveOccurred())
 		})
+
+		It("does not request the cache be replicated", func() {
+			Expect(fakeResourceCacheFactory.RequestReplicationCallCount()).To(Equal(0))
+		})
+
+		Context("when resource caches are replicated", func() {
+			BeforeEach(func() {
+				atc.ResourceCacheReplicationFactor = 2
+				getPlan.Tags = atc.Tags{"some", "tags"}
+			})
+
+			AfterEach(func() {
+				atc.ResourceCacheReplicationFactor = 0
+			})
+
+			It("requests the cache be replicated to the workers of the team with its tags", func() {
+				Expect(fakeResourceCacheFactory.RequestReplicationCallCount()).To(Equal(1))
+				cache, teamID, tags := fakeResourceCacheFactory.RequestReplicationArgsForCall(0)
+				Expect(cache).To(Equal(fakeResourceCache))
+				Expect(teamID).To(Equal(stepMetadata.TeamID))
+				Expect(tags).To(Equal([]string{"some", "tags"}))
+			})
+
+			Context("when requesting replication fails", func() {
+				BeforeEach(func() {
+					fakeResourceCacheFactory.RequestReplicationReturns(errors.New("nope"))
+				})
+
+				It("still succeeds", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+			})
+		})
 	})
 
 	Context("when get script fails", func() {
@@ -693,6 +832,202 @@ var _ = Describe("GetStep", func() {
 		It("does not return an err", func() {
 			Expect(stepErr).ToNot(HaveOccurred())
 		})
+
+		It("is retried by a retry on resource script failures", func() {
+			nextAttempt := new(execfakes.FakeStep)
+			nextAttempt.RunReturns(true, nil)
+
+			fakeDelegate.StartSpanStub = func(ctx context.Context, _ string, _ tracing.Attrs) (context.Context, trace.Span) {
+				return ctx, tracing.NoopSpan
+			}
+
+			// start from a fresh container so the failed process is run again
+			// rather than attached to
+			chosenContainer.Container = runtimetest.NewContainer().WithProcess(
+				runtime.ProcessSpec{
+					ID:   "resource",
+					Path: "/opt/resource/in",
+					Args: []string{resource.ResourcesDir("get")},
+				},
+				runtimetest.ProcessStub{ExitStatus: 1},
+			)
+
+			retry := exec.Retry(nil, []atc.RetryCondition{{Class: atc.RetryOnScriptFailure}}, getStep, nextAttempt)
+
+			ok, err := retry.Run(ctx, runState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(nextAttempt.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the plan specifies a digest to verify", func() {
+		const content = "some-content"
+
+		var sha256Digest, sha512Digest string
+
+		BeforeEach(func() {
+			sha256Sum := sha256.Sum256([]byte(content))
+			sha256Digest = hex.EncodeToString(sha256Sum[:])
+
+			sha512Sum := sha512.Sum512([]byte(content))
+			sha512Digest = hex.EncodeToString(sha512Sum[:])
+
+			getPlan.Verify = &atc.VerifyConfig{
+				File:   "some/file.tgz",
+				SHA256: "((.:sha256-var))",
+				SHA512: strings.ToUpper(sha512Digest),
+			}
+
+			runState.AddLocalVar("sha256-var", sha256Digest, false)
+
+			fakeStreamer.StreamFileReturns(&fakeReadCloser{str: content}, nil)
+		})
+
+		It("hashes the file within the fetched volume", func() {
+			Expect(fakeStreamer.StreamFileCallCount()).To(Equal(1))
+			_, artifact, path := fakeStreamer.StreamFileArgsForCall(0)
+			Expect(artifact).To(Equal(getVolume))
+			Expect(path).To(Equal("some/file.tgz"))
+		})
+
+		It("succeeds when the digests match", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+			Expect(stdoutBuf).To(gbytes.Say("verified sha256 digest of some/file.tgz"))
+			Expect(stdoutBuf).To(gbytes.Say("verified sha512 digest of some/file.tgz"))
+		})
+
+		Context("when a digest does not match", func() {
+			BeforeEach(func() {
+				getPlan.Verify.SHA512 = "bogus"
+			})
+
+			It("returns a ChecksumMismatchError", func() {
+				Expect(stepErr).To(Equal(exec.ChecksumMismatchError{
+					File:      "some/file.tgz",
+					Algorithm: "sha512",
+					Expected:  "bogus",
+					Actual:    sha512Digest,
+				}))
+				Expect(stepOk).To(BeFalse())
+			})
+
+			It("does not register the artifact", func() {
+				_, found := artifactRepository.ArtifactFor(build.ArtifactName(getPlan.Name))
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the file does not exist", func() {
+			BeforeEach(func() {
+				fakeStreamer.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+			})
+
+			It("returns a FileNotFoundError", func() {
+				Expect(stepErr).To(Equal(exec.FileNotFoundError{
+					Name:     "some-name",
+					FilePath: "some/file.tgz",
+				}))
+			})
+		})
+	})
+
+	Context("when the plan specifies a depth", func() {
+		var versions []atc.Version
+
+		BeforeEach(func() {
+			getPlan.Resource = "some-resource"
+			getPlan.Depth = 2
+
+			versions = []atc.Version{
+				{"some": "version"},
+				{"some": "older-version"},
+			}
+			fakeDelegate.VersionHistoryReturns(versions, nil)
+
+			chosenContainer.ProcessDefs = nil
+			for i, version := range versions {
+				chosenContainer.ProcessDefs = append(chosenContainer.ProcessDefs, runtimetest.ProcessDefinition{
+					Spec: runtime.ProcessSpec{
+						ID:   fmt.Sprintf("resource-%d", i),
+						Path: "/opt/resource/in",
+						Args: []string{fmt.Sprintf("%s/%d", resource.ResourcesDir("get"), i)},
+					},
+					Stub: runtimetest.ProcessStub{
+						Output: resource.VersionResult{Version: version},
+					},
+				})
+			}
+		})
+
+		It("looks up the version history of the resource", func() {
+			Expect(fakeDelegate.VersionHistoryCallCount()).To(Equal(1))
+			_, resourceName, version, depth := fakeDelegate.VersionHistoryArgsForCall(0)
+			Expect(resourceName).To(Equal("some-resource"))
+			Expect(version).To(Equal(atc.Version{"some": "version"}))
+			Expect(depth).To(Equal(2))
+		})
+
+		It("fetches each version into a subdirectory", func() {
+			Expect(chosenContainer.RunningProcesses()).To(HaveLen(2))
+
+			for i, process := range chosenContainer.RunningProcesses() {
+				Expect(process.Spec.Args).To(Equal([]string{fmt.Sprintf("%s/%d", resource.ResourcesDir("get"), i)}))
+
+				var request resource.Resource
+				Expect(json.NewDecoder(process.Stdin()).Decode(&request)).To(Succeed())
+				Expect(request.Version).To(Equal(versions[i]))
+			}
+		})
+
+		It("does not use the resource cache", func() {
+			Expect(fakeResourceCacheFactory.FindOrCreateResourceCacheCallCount()).To(BeZero())
+			Expect(getVolume.ResourceCacheInitialized).To(BeFalse())
+		})
+
+		It("registers the volume as an artifact", func() {
+			artifact, found := artifactRepository.ArtifactFor(build.ArtifactName(getPlan.Name))
+			Expect(found).To(BeTrue())
+			Expect(artifact).To(Equal(getVolume))
+		})
+
+		It("finishes with the newest version", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, status, versionResult := fakeDelegate.FinishedArgsForCall(0)
+			Expect(status).To(Equal(exec.ExitStatus(0)))
+			Expect(versionResult.Version).To(Equal(atc.Version{"some": "version"}))
+		})
+
+		Context("when fetching an older version fails", func() {
+			BeforeEach(func() {
+				chosenContainer.ProcessDefs[1].Stub.ExitStatus = 1
+			})
+
+			It("fails without registering the artifact", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+
+				_, found := artifactRepository.ArtifactFor(build.ArtifactName(getPlan.Name))
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when looking up the version history fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDelegate.VersionHistoryReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+				Expect(fakePool.FindOrSelectWorkerCallCount()).To(BeZero())
+			})
+		})
 	})
 })
 
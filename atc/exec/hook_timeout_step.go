@@ -0,0 +1,67 @@
+package exec
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+)
+
+// HookTimeoutStep bounds the duration of a hook (ensure, on_failure, etc.) so
+// that it cannot hang a build forever, e.g. after the main step itself has
+// already timed out.
+type HookTimeoutStep struct {
+	hook     Step
+	duration string
+
+	delegateFactory BuildStepDelegateFactory
+}
+
+// HookTimeout constructs a HookTimeoutStep. If duration is empty,
+// atc.DefaultHookTimeout is used.
+func HookTimeout(hook Step, duration string, delegateFactory BuildStepDelegateFactory) HookTimeoutStep {
+	return HookTimeoutStep{
+		hook:     hook,
+		duration: duration,
+
+		delegateFactory: delegateFactory,
+	}
+}
+
+// Run invokes the hook with a deadline applied.
+//
+// If the hook exceeds the deadline it is interrupted, a hook-timeout event is
+// emitted, and the step fails without returning an error.
+func (step HookTimeoutStep) Run(ctx context.Context, state RunState) (bool, error) {
+	timeout := atc.DefaultHookTimeout
+	if step.duration != "" {
+		parsedDuration, err := time.ParseDuration(step.duration)
+		if err != nil {
+			return false, err
+		}
+
+		timeout = parsedDuration
+	}
+
+	if timeout <= 0 {
+		return step.hook.Run(ctx, state)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ok, err := step.hook.Run(timeoutCtx, state)
+	if ctx.Err() == nil && timeoutCtx.Err() == context.DeadlineExceeded {
+		logger := lagerctx.FromContext(ctx)
+		logger.Info("hook-timed-out", lager.Data{"timeout": timeout.String()})
+
+		delegate := step.delegateFactory.BuildStepDelegate(state)
+		delegate.HookTimedOut(logger, timeout)
+
+		return false, nil
+	}
+
+	return ok, err
+}
@@ -0,0 +1,171 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hook Timeout Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeHook            *execfakes.FakeStep
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		repo  *build.Repository
+		state *execfakes.FakeRunState
+
+		timeoutDuration string
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeHook = new(execfakes.FakeStep)
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		repo = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(repo)
+
+		timeoutDuration = "1h"
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		stepOk, stepErr = HookTimeout(fakeHook, timeoutDuration, fakeDelegateFactory).Run(ctx, state)
+	})
+
+	It("runs the hook with a deadline", func() {
+		runCtx, _ := fakeHook.RunArgsForCall(0)
+		deadline, ok := runCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Hour), 10*time.Second))
+	})
+
+	Context("when the hook succeeds", func() {
+		BeforeEach(func() {
+			fakeHook.RunReturns(true, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the hook returns an error", func() {
+		var someError = errors.New("some error")
+
+		BeforeEach(func() {
+			fakeHook.RunReturns(false, someError)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(someError))
+		})
+	})
+
+	Context("when the hook exceeds the timeout", func() {
+		BeforeEach(func() {
+			timeoutDuration = "10ms"
+			fakeHook.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+				<-ctx.Done()
+				return false, ctx.Err()
+			}
+		})
+
+		It("fails without an error", func() {
+			Expect(stepOk).To(BeFalse())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+
+		It("emits a hook timeout event", func() {
+			Expect(fakeDelegate.HookTimedOutCallCount()).To(Equal(1))
+			_, duration := fakeDelegate.HookTimedOutArgsForCall(0)
+			Expect(duration).To(Equal(10 * time.Millisecond))
+		})
+	})
+
+	Context("when the parent context is canceled", func() {
+		BeforeEach(func() {
+			fakeHook.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+				cancel()
+				return false, ctx.Err()
+			}
+		})
+
+		It("returns the error without emitting a hook timeout event", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+			Expect(fakeDelegate.HookTimedOutCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when no duration is configured", func() {
+		var oldDefault time.Duration
+
+		BeforeEach(func() {
+			timeoutDuration = ""
+			oldDefault = atc.DefaultHookTimeout
+		})
+
+		AfterEach(func() {
+			atc.DefaultHookTimeout = oldDefault
+		})
+
+		Context("and there is a default", func() {
+			BeforeEach(func() {
+				atc.DefaultHookTimeout = 5 * time.Minute
+			})
+
+			It("uses the default", func() {
+				runCtx, _ := fakeHook.RunArgsForCall(0)
+				deadline, ok := runCtx.Deadline()
+				Expect(ok).To(BeTrue())
+				Expect(deadline).To(BeTemporally("~", time.Now().Add(5*time.Minute), 10*time.Second))
+			})
+		})
+
+		Context("and the default is zero", func() {
+			BeforeEach(func() {
+				atc.DefaultHookTimeout = 0
+			})
+
+			It("runs the hook without a deadline", func() {
+				runCtx, _ := fakeHook.RunArgsForCall(0)
+				_, ok := runCtx.Deadline()
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the duration is invalid", func() {
+		BeforeEach(func() {
+			timeoutDuration = "nope"
+		})
+
+		It("errors without running the hook", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(fakeHook.RunCallCount()).To(BeZero())
+		})
+	})
+})
@@ -3,30 +3,29 @@ package exec
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync/atomic"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/hashicorp/go-multierror"
 )
 
 // InParallelStep is a step of steps to run in parallel.
 type InParallelStep struct {
-	steps       []Step
-	maxInFlight atc.MaxInFlightConfig
-	failFast    bool
+	steps    []Step
+	limit    *atc.InParallelLimit
+	failFast bool
 }
 
-// InParallel constructs an InParallelStep.
-func InParallel(steps []Step, limit int, failFast bool) InParallelStep {
-	maxInFlight := atc.MaxInFlightConfig{Limit: limit}
-	if limit < 1 {
-		maxInFlight.All = true
-	}
+// InParallel constructs an InParallelStep. If the limit is a var, it is
+// resolved when the step runs.
+func InParallel(steps []Step, limit *atc.InParallelLimit, failFast bool) InParallelStep {
 	return InParallelStep{
-		steps:       steps,
-		maxInFlight: maxInFlight,
-		failFast:    failFast,
+		steps:    steps,
+		limit:    limit,
+		failFast: failFast,
 	}
 }
 
@@ -41,10 +40,15 @@ func InParallel(steps []Step, limit int, failFast bool) InParallelStep {
 // After all steps finish, their errors (if any) will be collected and returned as a
 // single error.
 func (step InParallelStep) Run(ctx context.Context, state RunState) (bool, error) {
+	maxInFlight, err := step.maxInFlight(state)
+	if err != nil {
+		return false, err
+	}
+
 	return parallelExecutor{
 		stepName: "in_parallel",
 
-		maxInFlight: &step.maxInFlight,
+		maxInFlight: &maxInFlight,
 		failFast:    step.failFast,
 		count:       len(step.steps),
 
@@ -54,6 +58,27 @@ func (step InParallelStep) Run(ctx context.Context, state RunState) (bool, error
 	}.run(ctx)
 }
 
+func (step InParallelStep) maxInFlight(state RunState) (atc.MaxInFlightConfig, error) {
+	var limit int
+	if step.limit != nil {
+		limit = step.limit.Limit
+
+		if step.limit.Var != "" {
+			var err error
+			limit, err = creds.NewInt(state, step.limit.Var).Evaluate()
+			if err != nil {
+				return atc.MaxInFlightConfig{}, fmt.Errorf("evaluate limit: %w", err)
+			}
+		}
+	}
+
+	if limit < 1 {
+		return atc.MaxInFlightConfig{All: true}, nil
+	}
+
+	return atc.MaxInFlightConfig{Limit: limit}, nil
+}
+
 type parallelExecutor struct {
 	stepName string
 
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
@@ -38,7 +39,7 @@ var _ = Describe("Parallel", func() {
 		fakeStepB = new(execfakes.FakeStep)
 		fakeSteps = []Step{fakeStepA, fakeStepB}
 
-		step = InParallel(fakeSteps, len(fakeSteps), false)
+		step = InParallel(fakeSteps, &atc.InParallelLimit{Limit: len(fakeSteps)}, false)
 
 		repo = build.NewRepository()
 		state = new(execfakes.FakeRunState)
@@ -94,7 +95,7 @@ var _ = Describe("Parallel", func() {
 
 		Context("when parallel limit is 1", func() {
 			BeforeEach(func() {
-				step = InParallel(fakeSteps, 1, false)
+				step = InParallel(fakeSteps, &atc.InParallelLimit{Limit: 1}, false)
 				ch := make(chan struct{}, 1)
 
 				fakeStepA.RunStub = func(context.Context, RunState) (bool, error) {
@@ -120,6 +121,55 @@ var _ = Describe("Parallel", func() {
 				Expect(fakeStepB.RunCallCount()).To(Equal(1))
 			})
 		})
+
+		Context("when the parallel limit is a var", func() {
+			BeforeEach(func() {
+				step = InParallel(fakeSteps, &atc.InParallelLimit{Var: "((limit))"}, false)
+			})
+
+			Context("when the var resolves to a number", func() {
+				BeforeEach(func() {
+					state.GetReturns("1", true, nil)
+
+					ch := make(chan struct{}, 1)
+
+					fakeStepA.RunStub = func(context.Context, RunState) (bool, error) {
+						time.Sleep(10 * time.Millisecond)
+						ch <- struct{}{}
+						return true, nil
+					}
+
+					fakeStepB.RunStub = func(context.Context, RunState) (bool, error) {
+						defer GinkgoRecover()
+
+						select {
+						case <-ch:
+						default:
+							Fail("step B started before step A could complete")
+						}
+						return true, nil
+					}
+				})
+
+				It("applies the resolved limit", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeStepA.RunCallCount()).To(Equal(1))
+					Expect(fakeStepB.RunCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the var does not resolve to a number", func() {
+				BeforeEach(func() {
+					state.GetReturns("lots", true, nil)
+				})
+
+				It("errors without running any steps", func() {
+					Expect(stepErr).To(HaveOccurred())
+					Expect(fakeStepA.RunCallCount()).To(BeZero())
+					Expect(fakeStepB.RunCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("canceling", func() {
@@ -153,7 +203,7 @@ var _ = Describe("Parallel", func() {
 
 		Context("when there are steps pending execution", func() {
 			BeforeEach(func() {
-				step = InParallel(fakeSteps, 1, false)
+				step = InParallel(fakeSteps, &atc.InParallelLimit{Limit: 1}, false)
 
 				fakeStepA.RunStub = func(context.Context, RunState) (bool, error) {
 					cancel()
@@ -190,7 +240,7 @@ var _ = Describe("Parallel", func() {
 
 			Context("and fail fast is false", func() {
 				BeforeEach(func() {
-					step = InParallel(fakeSteps, 1, false)
+					step = InParallel(fakeSteps, &atc.InParallelLimit{Limit: 1}, false)
 				})
 				It("lets all steps finish before exiting", func() {
 					Expect(fakeStepA.RunCallCount()).To(Equal(1))
@@ -204,7 +254,7 @@ var _ = Describe("Parallel", func() {
 
 			Context("and fail fast is true", func() {
 				BeforeEach(func() {
-					step = InParallel(fakeSteps, 1, true)
+					step = InParallel(fakeSteps, &atc.InParallelLimit{Limit: 1}, true)
 				})
 				It("it cancels remaining steps", func() {
 					Expect(fakeStepA.RunCallCount()).To(Equal(1))
@@ -7,7 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/lager"
@@ -16,11 +19,15 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/worker/baggageclaim"
 )
 
-// LoadVarStep loads a value from a file and sets it as a build-local var.
+// LoadVarStep loads a value from a file and sets it as a build-local var. If
+// the file is a directory, each file within it is loaded as a field of the var.
+// If the plan is multi-document, each YAML document in the file is loaded as a
+// field of the var.
 type LoadVarStep struct {
 	planID          atc.PlanID
 	plan            atc.LoadVarPlan
@@ -54,6 +61,31 @@ func (err UnspecifiedLoadVarStepFileError) Error() string {
 	return fmt.Sprintf("file '%s' does not specify where the file lives", err.File)
 }
 
+// DuplicateLoadVarFieldError is returned when loading a directory containing
+// several files which differ only by extension.
+type DuplicateLoadVarFieldError struct {
+	Dir   string
+	Field string
+}
+
+// Error returns a human-friendly error message.
+func (err DuplicateLoadVarFieldError) Error() string {
+	return fmt.Sprintf("directory '%s' contains more than one file named '%s'", err.Dir, err.Field)
+}
+
+// DuplicateLoadVarDocumentError is returned when loading a multi-document
+// file containing several documents with the same kind and name.
+type DuplicateLoadVarDocumentError struct {
+	File string
+	Kind string
+	Name string
+}
+
+// Error returns a human-friendly error message.
+func (err DuplicateLoadVarDocumentError) Error() string {
+	return fmt.Sprintf("file '%s' contains more than one document of kind '%s' named '%s'", err.File, err.Kind, err.Name)
+}
+
 type InvalidLocalVarFile struct {
 	File   string
 	Format string
@@ -117,18 +149,22 @@ func (step *LoadVarStep) fetchVars(
 	artifactName := segs[0]
 	filePath := segs[1]
 
-	format, err := step.fileFormat(file)
-	if err != nil {
-		return nil, err
+	if step.plan.Format != "" && !step.isValidFormat(step.plan.Format) {
+		return nil, fmt.Errorf("invalid format %s", step.plan.Format)
+	}
+
+	if step.plan.MultiDocument && step.plan.Format != "" && step.plan.Format != "yml" && step.plan.Format != "yaml" {
+		return nil, fmt.Errorf("multi_document cannot be used with format %s", step.plan.Format)
 	}
-	logger.Debug("figure-out-format", lager.Data{"format": format})
 
 	art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(artifactName))
 	if !found {
 		return nil, UnknownArtifactSourceError{build.ArtifactName(artifactName), filePath}
 	}
 
-	stream, err := step.streamer.StreamFile(lagerctx.NewContext(ctx, logger), art, filePath)
+	ctx = lagerctx.NewContext(ctx, logger)
+
+	stream, err := step.streamer.StreamFile(ctx, art, filePath)
 	if err != nil {
 		if err == baggageclaim.ErrFileNotFound {
 			return nil, FileNotFoundError{
@@ -137,14 +173,68 @@ func (step *LoadVarStep) fetchVars(
 			}
 		}
 
+		if err == runtime.ErrIsDirectory {
+			return step.fetchDirVars(ctx, logger, file, art, filePath)
+		}
+
 		return nil, err
 	}
 
+	defer stream.Close()
+
 	fileContent, err := ioutil.ReadAll(stream)
 	if err != nil {
 		return nil, err
 	}
 
+	return step.parseVars(logger, file, fileContent)
+}
+
+// fetchDirVars loads each file directly within the directory as a field of
+// the var, named after the file without its extension.
+func (step *LoadVarStep) fetchDirVars(
+	ctx context.Context,
+	logger lager.Logger,
+	dir string,
+	art runtime.Artifact,
+	dirPath string,
+) (interface{}, error) {
+	files, err := step.streamer.StreamDir(ctx, art, dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	value := map[string]interface{}{}
+	for _, fileName := range fileNames {
+		field := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		if _, exists := value[field]; exists {
+			return nil, DuplicateLoadVarFieldError{dir, field}
+		}
+
+		fieldValue, err := step.parseVars(logger, path.Join(dir, fileName), files[fileName])
+		if err != nil {
+			return nil, err
+		}
+
+		value[field] = fieldValue
+	}
+
+	return value, nil
+}
+
+func (step *LoadVarStep) parseVars(logger lager.Logger, file string, fileContent []byte) (interface{}, error) {
+	format, err := step.fileFormat(file)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("figure-out-format", lager.Data{"file": file, "format": format})
+
 	var value interface{}
 	switch format {
 	case "json":
@@ -158,6 +248,10 @@ func (step *LoadVarStep) fetchVars(
 			return nil, InvalidLocalVarFile{file, "json", errors.New("invalid json: characters found after top-level value")}
 		}
 	case "yml", "yaml":
+		if step.plan.MultiDocument {
+			return step.parseYAMLDocuments(file, fileContent)
+		}
+
 		err = yaml.Unmarshal(fileContent, &value, useJSONNumber)
 		if err != nil {
 			return nil, InvalidLocalVarFile{file, "yaml", err}
@@ -173,6 +267,86 @@ func (step *LoadVarStep) fetchVars(
 	return value, nil
 }
 
+// parseYAMLDocuments loads each non-empty document of a multi-document YAML
+// file as a field named after its index. Documents with a kind and a
+// metadata.name, such as Kubernetes manifests, are also available under
+// <kind>.<name>.
+func (step *LoadVarStep) parseYAMLDocuments(file string, fileContent []byte) (interface{}, error) {
+	value := map[string]interface{}{}
+
+	index := 0
+	for _, document := range splitYAMLDocuments(fileContent) {
+		var docValue interface{}
+		err := yaml.Unmarshal(document, &docValue, useJSONNumber)
+		if err != nil {
+			return nil, InvalidLocalVarFile{file, "yaml", err}
+		}
+
+		if docValue == nil {
+			continue
+		}
+
+		value[strconv.Itoa(index)] = docValue
+		index++
+
+		kind, name, ok := documentKindAndName(docValue)
+		if !ok {
+			continue
+		}
+
+		named, _ := value[kind].(map[string]interface{})
+		if named == nil {
+			named = map[string]interface{}{}
+			value[kind] = named
+		}
+
+		if _, exists := named[name]; exists {
+			return nil, DuplicateLoadVarDocumentError{file, kind, name}
+		}
+
+		named[name] = docValue
+	}
+
+	return value, nil
+}
+
+// splitYAMLDocuments splits the content on lines consisting of the "---"
+// document separator.
+func splitYAMLDocuments(content []byte) [][]byte {
+	var documents [][]byte
+
+	var document []byte
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if bytes.Equal(bytes.TrimRight(line, " \t\r\n"), []byte("---")) {
+			documents = append(documents, document)
+			document = nil
+			continue
+		}
+
+		document = append(document, line...)
+	}
+
+	return append(documents, document)
+}
+
+func documentKindAndName(document interface{}) (string, string, bool) {
+	fields, ok := document.(map[string]interface{})
+	if !ok {
+		return "", "", false
+	}
+
+	kind, _ := fields["kind"].(string)
+
+	metadata, _ := fields["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+
+	if kind == "" || name == "" {
+		return "", "", false
+	}
+
+	return kind, name, true
+}
+
 func useJSONNumber(decoder *json.Decoder) *json.Decoder {
 	decoder.UseNumber()
 	return decoder
@@ -185,6 +359,10 @@ func (step *LoadVarStep) fileFormat(file string) (string, error) {
 		return "", fmt.Errorf("invalid format %s", step.plan.Format)
 	}
 
+	if step.plan.MultiDocument {
+		return "yaml", nil
+	}
+
 	fileExt := filepath.Ext(file)
 	format := strings.TrimPrefix(fileExt, ".")
 	if step.isValidFormat(format) {
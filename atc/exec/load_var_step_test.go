@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
 	"github.com/concourse/concourse/tracing"
 )
@@ -378,6 +379,169 @@ var _ = Describe("LoadVarStep", func() {
 		})
 	})
 
+	Context("when file is a directory", func() {
+		BeforeEach(func() {
+			loadVarPlan = &atc.LoadVarPlan{
+				Name: "some-var",
+				File: "some-resource/metadata",
+			}
+
+			fakeStreamer.StreamFileReturns(nil, runtime.ErrIsDirectory)
+			fakeStreamer.StreamDirReturns(map[string][]byte{
+				"version":   []byte(plainString),
+				"info.json": []byte(jsonString),
+				"tags.yml":  []byte("- a\n- b\n"),
+			}, nil)
+		})
+
+		It("streams the directory", func() {
+			Expect(fakeStreamer.StreamDirCallCount()).To(Equal(1))
+			_, _, path := fakeStreamer.StreamDirArgsForCall(0)
+			Expect(path).To(Equal("metadata"))
+		})
+
+		It("loads each file as a field parsed by its extension", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+			expectLocalVarAdded("some-var", map[string]interface{}{
+				"version": "pv",
+				"info": map[string]interface{}{
+					"k1": "jv1",
+					"k2": "jv2",
+					"k3": json.Number("123"),
+				},
+				"tags": []interface{}{"a", "b"},
+			}, true)
+		})
+
+		Context("when format is specified", func() {
+			BeforeEach(func() {
+				loadVarPlan.Format = "raw"
+			})
+
+			It("uses the format for every file", func() {
+				_, value, _ := state.AddLocalVarArgsForCall(0)
+				Expect(value).To(HaveKeyWithValue("version", plainString))
+				Expect(value).To(HaveKeyWithValue("info", jsonString))
+			})
+		})
+
+		Context("when a file in the directory is bad", func() {
+			BeforeEach(func() {
+				fakeStreamer.StreamDirReturns(map[string][]byte{
+					"info.json": []byte(jsonString + "{}"),
+				}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(MatchError(ContainSubstring("failed to parse some-resource/metadata/info.json in format json")))
+			})
+		})
+
+		Context("when files differ only by extension", func() {
+			BeforeEach(func() {
+				fakeStreamer.StreamDirReturns(map[string][]byte{
+					"info.json": []byte(jsonString),
+					"info.yml":  []byte(jsonString),
+				}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(Equal(exec.DuplicateLoadVarFieldError{
+					Dir:   "some-resource/metadata",
+					Field: "info",
+				}))
+			})
+		})
+	})
+
+	Context("when multi_document is set", func() {
+		BeforeEach(func() {
+			loadVarPlan = &atc.LoadVarPlan{
+				Name:          "some-var",
+				File:          "some-resource/manifests",
+				MultiDocument: true,
+			}
+
+			fakeStreamer.StreamFileReturns(&fakeReadCloser{str: `---
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+---
+# only a comment
+---
+kind: Service
+metadata:
+  name: web
+---
+- a
+- b
+`}, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("loads each document by index and by kind and name", func() {
+			deployment := map[string]interface{}{
+				"kind":     "Deployment",
+				"metadata": map[string]interface{}{"name": "web"},
+				"spec":     map[string]interface{}{"replicas": json.Number("3")},
+			}
+			service := map[string]interface{}{
+				"kind":     "Service",
+				"metadata": map[string]interface{}{"name": "web"},
+			}
+
+			expectLocalVarAdded("some-var", map[string]interface{}{
+				"0":          deployment,
+				"1":          service,
+				"2":          []interface{}{"a", "b"},
+				"Deployment": map[string]interface{}{"web": deployment},
+				"Service":    map[string]interface{}{"web": service},
+			}, true)
+		})
+
+		Context("when two documents have the same kind and name", func() {
+			BeforeEach(func() {
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: "kind: Service\nmetadata: {name: web}\n---\nkind: Service\nmetadata: {name: web}\n"}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(Equal(exec.DuplicateLoadVarDocumentError{
+					File: "some-resource/manifests",
+					Kind: "Service",
+					Name: "web",
+				}))
+			})
+		})
+
+		Context("when a document is bad", func() {
+			BeforeEach(func() {
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: "a: b\n---\na:\nb\n"}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(MatchError(ContainSubstring("failed to parse some-resource/manifests in format yaml")))
+			})
+		})
+
+		Context("when format is not yaml", func() {
+			BeforeEach(func() {
+				loadVarPlan.Format = "json"
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(MatchError("multi_document cannot be used with format json"))
+				Expect(fakeStreamer.StreamFileCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Context("reveal", func() {
 		Context("when reveal is not specified", func() {
 			BeforeEach(func() {
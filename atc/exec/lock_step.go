@@ -0,0 +1,118 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+var StepLockInterval = 5 * time.Second
+
+// LockStep runs a step while holding a named lock, so that no more than the
+// lock's count of steps holding the same lock run at once across the team's
+// builds.
+type LockStep struct {
+	step   Step
+	plan   atc.LockPlan
+	teamID int
+
+	lockFactory     lock.LockFactory
+	delegateFactory BuildStepDelegateFactory
+}
+
+// Lock constructs a LockStep.
+func Lock(
+	step Step,
+	plan atc.LockPlan,
+	teamID int,
+	lockFactory lock.LockFactory,
+	delegateFactory BuildStepDelegateFactory,
+) LockStep {
+	return LockStep{
+		step:   step,
+		plan:   plan,
+		teamID: teamID,
+
+		lockFactory:     lockFactory,
+		delegateFactory: delegateFactory,
+	}
+}
+
+// Run interpolates vars into the lock name and waits until one of the lock's
+// slots can be acquired, checking again every StepLockInterval. The nested
+// step is then run and its result returned.
+//
+// The lock is released once the nested step returns, including when the
+// build is aborted. If the build is aborted while waiting for the lock, the
+// nested step is not run at all.
+func (step LockStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	name, err := creds.NewString(state, step.plan.Name).Evaluate()
+	if err != nil {
+		return false, err
+	}
+
+	logger = logger.Session("lock", lager.Data{"lock-name": name})
+
+	held, err := step.acquire(ctx, logger, state, name)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		err := held.Release()
+		if err != nil {
+			logger.Error("failed-to-release-lock", err)
+		}
+	}()
+
+	return step.step.Run(ctx, state)
+}
+
+func (step LockStep) acquire(ctx context.Context, logger lager.Logger, state RunState, name string) (lock.Lock, error) {
+	count := step.plan.Count
+	if count == 0 {
+		count = 1
+	}
+
+	ticker := time.NewTicker(StepLockInterval)
+	defer ticker.Stop()
+
+	waiting := false
+	for {
+		for slot := 0; slot < count; slot++ {
+			held, acquired, err := step.lockFactory.Acquire(logger, lock.NewStepLockID(step.teamID, name, slot))
+			if err != nil {
+				// try again after StepLockInterval, like any other lock which is
+				// not acquired
+				logger.Error("failed-to-get-lock", err)
+				break
+			}
+
+			if acquired {
+				return held, nil
+			}
+		}
+
+		if !waiting {
+			delegate := step.delegateFactory.BuildStepDelegate(state)
+			fmt.Fprintf(delegate.Stderr(), "\x1b[1;36mINFO: waiting to acquire lock %s\x1b[0m\n", name)
+			fmt.Fprintln(delegate.Stderr(), "")
+
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
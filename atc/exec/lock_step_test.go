@@ -0,0 +1,171 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Lock Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		step *execfakes.FakeStep
+
+		fakeLockFactory     *lockfakes.FakeLockFactory
+		fakeLock            *lockfakes.FakeLock
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		stderr              *gbytes.Buffer
+
+		state *execfakes.FakeRunState
+
+		plan atc.LockPlan
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		step = &execfakes.FakeStep{}
+		step.RunReturns(true, nil)
+
+		fakeLock = new(lockfakes.FakeLock)
+		fakeLockFactory = new(lockfakes.FakeLockFactory)
+		fakeLockFactory.AcquireReturns(fakeLock, true, nil)
+
+		stderr = gbytes.NewBuffer()
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StderrReturns(stderr)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = new(execfakes.FakeRunState)
+		state.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+			if ref.Path == "env" {
+				return "staging", true, nil
+			}
+
+			return nil, false, nil
+		}
+
+		plan = atc.LockPlan{Name: "((env))"}
+
+		exec.StepLockInterval = 10 * time.Millisecond
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		stepOk, stepErr = exec.Lock(step, plan, 42, fakeLockFactory, fakeDelegateFactory).Run(ctx, state)
+	})
+
+	It("acquires the interpolated lock for the team", func() {
+		Expect(fakeLockFactory.AcquireCallCount()).To(Equal(1))
+		_, id := fakeLockFactory.AcquireArgsForCall(0)
+		Expect(id).To(Equal(lock.NewStepLockID(42, "staging", 0)))
+	})
+
+	It("runs the step while holding the lock", func() {
+		Expect(step.RunCallCount()).To(Equal(1))
+		Expect(stepOk).To(BeTrue())
+		Expect(stepErr).ToNot(HaveOccurred())
+	})
+
+	It("releases the lock once the step is done", func() {
+		Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+	})
+
+	Context("when the step fails", func() {
+		BeforeEach(func() {
+			step.RunReturns(false, nil)
+		})
+
+		It("fails and releases the lock", func() {
+			Expect(stepOk).To(BeFalse())
+			Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the step errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			step.RunReturns(false, disaster)
+		})
+
+		It("errors and releases the lock", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the lock has a count", func() {
+		BeforeEach(func() {
+			plan.Count = 3
+
+			fakeLockFactory.AcquireStub = func(logger lager.Logger, id lock.LockID) (lock.Lock, bool, error) {
+				if reflect.DeepEqual(id, lock.NewStepLockID(42, "staging", 2)) {
+					return fakeLock, true, nil
+				}
+
+				return nil, false, nil
+			}
+		})
+
+		It("acquires any free slot", func() {
+			Expect(fakeLockFactory.AcquireCallCount()).To(Equal(3))
+			Expect(step.RunCallCount()).To(Equal(1))
+			Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the lock is held elsewhere", func() {
+		BeforeEach(func() {
+			fakeLockFactory.AcquireReturnsOnCall(0, nil, false, nil)
+			fakeLockFactory.AcquireReturnsOnCall(1, nil, false, errors.New("db is down"))
+			fakeLockFactory.AcquireReturnsOnCall(2, fakeLock, true, nil)
+		})
+
+		It("waits until it is released", func() {
+			Expect(fakeLockFactory.AcquireCallCount()).To(Equal(3))
+			Expect(step.RunCallCount()).To(Equal(1))
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("says that it is waiting", func() {
+			Expect(stderr).To(gbytes.Say("waiting to acquire lock staging"))
+		})
+
+		Context("when the build is aborted while waiting", func() {
+			BeforeEach(func() {
+				fakeLockFactory.AcquireStub = func(lager.Logger, lock.LockID) (lock.Lock, bool, error) {
+					cancel()
+					return nil, false, nil
+				}
+			})
+
+			It("does not run the step", func() {
+				Expect(stepErr).To(Equal(context.Canceled))
+				Expect(step.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// OutputPolicy controls how much of a step's stdout and stderr is saved to
+// the build log.
+type OutputPolicy struct {
+	// DiscardStdout drops everything written to stdout.
+	DiscardStdout bool
+
+	// LogLimit is the number of bytes saved for each stream before the rest
+	// is truncated. Zero means no limit.
+	LogLimit uint64
+}
+
+// Merge combines two policies, keeping the most restrictive of each setting.
+func (policy OutputPolicy) Merge(other OutputPolicy) OutputPolicy {
+	merged := OutputPolicy{
+		DiscardStdout: policy.DiscardStdout || other.DiscardStdout,
+		LogLimit:      policy.LogLimit,
+	}
+
+	if merged.LogLimit == 0 || (other.LogLimit != 0 && other.LogLimit < merged.LogLimit) {
+		merged.LogLimit = other.LogLimit
+	}
+
+	return merged
+}
+
+// MuteStep runs the nested step with an OutputPolicy limiting the output
+// saved for it and any steps nested within it.
+type MuteStep struct {
+	step Step
+	plan atc.MutePlan
+}
+
+// Mute constructs a MuteStep.
+func Mute(step Step, plan atc.MutePlan) MuteStep {
+	return MuteStep{
+		step: step,
+		plan: plan,
+	}
+}
+
+// Run parses the log limit and invokes the nested step with the resulting
+// OutputPolicy merged into the current one.
+func (step MuteStep) Run(ctx context.Context, state RunState) (bool, error) {
+	policy := OutputPolicy{
+		DiscardStdout: step.plan.Stdout,
+	}
+
+	if step.plan.LogLimit != "" {
+		limit, err := atc.ParseMemoryLimit(step.plan.LogLimit)
+		if err != nil {
+			return false, fmt.Errorf("invalid log_limit '%s': %w", step.plan.LogLimit, err)
+		}
+
+		policy.LogLimit = uint64(limit)
+	}
+
+	return step.step.Run(ctx, state.WithOutputPolicy(state.OutputPolicy().Merge(policy)))
+}
@@ -0,0 +1,103 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Mute Step", func() {
+	var (
+		ctx context.Context
+
+		fakeStep   *execfakes.FakeStep
+		state      *execfakes.FakeRunState
+		mutedState *execfakes.FakeRunState
+		plan       atc.MutePlan
+		step       Step
+		stepOk     bool
+		stepErr    error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunReturns(true, nil)
+
+		state = new(execfakes.FakeRunState)
+		mutedState = new(execfakes.FakeRunState)
+		state.WithOutputPolicyReturns(mutedState)
+
+		plan = atc.MutePlan{
+			Stdout:   true,
+			LogLimit: "1KB",
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = Mute(fakeStep, plan)
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("runs the step with the output policy", func() {
+		Expect(state.WithOutputPolicyCallCount()).To(Equal(1))
+		Expect(state.WithOutputPolicyArgsForCall(0)).To(Equal(OutputPolicy{
+			DiscardStdout: true,
+			LogLimit:      1024,
+		}))
+
+		Expect(fakeStep.RunCallCount()).To(Equal(1))
+		_, runState := fakeStep.RunArgsForCall(0)
+		Expect(runState).To(Equal(mutedState))
+
+		Expect(stepOk).To(BeTrue())
+		Expect(stepErr).ToNot(HaveOccurred())
+	})
+
+	Context("when an output policy is already in effect", func() {
+		BeforeEach(func() {
+			plan.Stdout = false
+			state.OutputPolicyReturns(OutputPolicy{
+				DiscardStdout: true,
+				LogLimit:      512,
+			})
+		})
+
+		It("keeps the most restrictive settings", func() {
+			Expect(state.WithOutputPolicyArgsForCall(0)).To(Equal(OutputPolicy{
+				DiscardStdout: true,
+				LogLimit:      512,
+			}))
+		})
+	})
+
+	Context("when the step fails", func() {
+		var disaster = errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStep.RunReturns(false, disaster)
+		})
+
+		It("returns the result of the step", func() {
+			Expect(stepOk).To(BeFalse())
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when the log limit is invalid", func() {
+		BeforeEach(func() {
+			plan.LogLimit = "lots"
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrNotifyAddressBlocked is returned when a notify target resolves to a
+// private, loopback or link-local address and its host is not allowed.
+var ErrNotifyAddressBlocked = errors.New("notify target address is not allowed")
+
+var privateNetworks = mustParseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+)
+
+// NewNotifyClient returns the client notify steps post with. If allowedHosts
+// is empty, targets may be any host which does not resolve to a private,
+// loopback or link-local address. Otherwise only the listed hosts may be
+// posted to, wherever they resolve to.
+func NewNotifyClient(allowedHosts []string, timeout time.Duration) *http.Client {
+	allowed := map[string]bool{}
+	for _, host := range allowedHosts {
+		allowed[host] = true
+	}
+
+	publicDialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if isPublicIP(net.ParseIP(host)) {
+				return nil
+			}
+
+			return ErrNotifyAddressBlocked
+		},
+	}
+
+	allowedDialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return nil, err
+				}
+
+				if allowed[host] {
+					return allowedDialer.DialContext(ctx, network, address)
+				}
+
+				if len(allowed) > 0 {
+					return nil, fmt.Errorf("notify target host '%s' is not allowed", host)
+				}
+
+				return publicDialer.DialContext(ctx, network, address)
+			},
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+	}
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+		return false
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
@@ -0,0 +1,75 @@
+package exec_test
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+var _ = Describe("NewNotifyClient", func() {
+	var (
+		server       *ghttp.Server
+		allowedHosts []string
+
+		postErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		server.AllowUnhandledRequests = true
+
+		allowedHosts = nil
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		client := exec.NewNotifyClient(allowedHosts, time.Minute)
+
+		var resp *http.Response
+		resp, postErr = client.Post(server.URL(), "application/json", nil)
+		if postErr == nil {
+			resp.Body.Close()
+		}
+	})
+
+	Context("when no hosts are allowed", func() {
+		It("does not post to a loopback address", func() {
+			Expect(postErr).To(MatchError(ContainSubstring(exec.ErrNotifyAddressBlocked.Error())))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Context("when the target host is allowed", func() {
+		BeforeEach(func() {
+			serverURL, err := url.Parse(server.URL())
+			Expect(err).ToNot(HaveOccurred())
+
+			allowedHosts = []string{serverURL.Hostname()}
+		})
+
+		It("posts to it", func() {
+			Expect(postErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when other hosts are allowed", func() {
+		BeforeEach(func() {
+			allowedHosts = []string{"hooks.example.com"}
+		})
+
+		It("does not post to the target", func() {
+			Expect(postErr).To(MatchError(ContainSubstring("is not allowed")))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,212 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/tracing"
+)
+
+// NotifyStep posts a message about the build to each of its targets. Unlike a
+// put to a notification resource, it runs entirely in the ATC.
+type NotifyStep struct {
+	planID          atc.PlanID
+	plan            atc.NotifyPlan
+	metadata        StepMetadata
+	delegateFactory NotifyDelegateFactory
+	client          *http.Client
+}
+
+func NewNotifyStep(
+	planID atc.PlanID,
+	plan atc.NotifyPlan,
+	metadata StepMetadata,
+	delegateFactory NotifyDelegateFactory,
+	client *http.Client,
+) Step {
+	return &NotifyStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+		client:          client,
+	}
+}
+
+// NotifyTargetError is returned when a notify target responds with a
+// non-2xx status code.
+type NotifyTargetError struct {
+	Type       string
+	StatusCode int
+}
+
+// Error returns a human-friendly error message.
+func (err NotifyTargetError) Error() string {
+	return fmt.Sprintf("%s notify target responded with status %d", err.Type, err.StatusCode)
+}
+
+// UnknownNotifyTargetError is returned when a notify target has a type which
+// the NotifyStep does not know how to post to.
+type UnknownNotifyTargetError struct {
+	Type string
+}
+
+// Error returns a human-friendly error message.
+func (err UnknownNotifyTargetError) Error() string {
+	return fmt.Sprintf("unknown notify target type '%s'", err.Type)
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+type httpNotifyPayload struct {
+	Message   string `json:"message"`
+	Team      string `json:"team"`
+	Pipeline  string `json:"pipeline,omitempty"`
+	Job       string `json:"job,omitempty"`
+	BuildID   int    `json:"build_id"`
+	BuildName string `json:"build_name,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+func (step *NotifyStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.NotifyDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "notify", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *NotifyStep) run(ctx context.Context, state RunState, delegate NotifyDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("notify-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	stdout := delegate.Stdout()
+
+	message := step.defaultMessage()
+	if step.plan.Message != "" {
+		var err error
+		message, err = creds.NewString(state, step.plan.Message).Evaluate()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	delegate.Starting(logger)
+
+	for _, target := range step.plan.Targets {
+		targetURL, err := creds.NewString(state, target.URL).Evaluate()
+		if err != nil {
+			return false, err
+		}
+
+		err = step.post(ctx, target.Type, targetURL, message)
+		if err != nil {
+			return false, err
+		}
+
+		fmt.Fprintf(stdout, "notified %s target.\n", target.Type)
+
+		delegate.NotificationSent(logger, target.Type)
+	}
+
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
+
+func (step *NotifyStep) post(ctx context.Context, targetType string, targetURL string, message string) error {
+	var payload interface{}
+	switch targetType {
+	case atc.NotifyTargetSlack:
+		payload = slackPayload{Text: message}
+	case atc.NotifyTargetHTTP:
+		payload = httpNotifyPayload{
+			Message:   message,
+			Team:      step.metadata.TeamName,
+			Pipeline:  step.metadata.PipelineName,
+			Job:       step.metadata.JobName,
+			BuildID:   step.metadata.BuildID,
+			BuildName: step.metadata.BuildName,
+			URL:       step.buildURL(),
+		}
+	default:
+		return UnknownNotifyTargetError{Type: targetType}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(body))
+	if err != nil {
+		return redactURL(targetType, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := step.client.Do(req)
+	if err != nil {
+		return redactURL(targetType, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return NotifyTargetError{
+			Type:       targetType,
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	return nil
+}
+
+// redactURL strips the target's URL, which may embed a secret such as a
+// webhook token, from an error so that it is not written to the build log.
+func redactURL(targetType string, err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s %s notify target: %w", urlErr.Op, targetType, urlErr.Err)
+	}
+
+	return err
+}
+
+func (step *NotifyStep) defaultMessage() string {
+	if step.metadata.JobName == "" {
+		return fmt.Sprintf("build %d: %s", step.metadata.BuildID, step.buildURL())
+	}
+
+	return fmt.Sprintf(
+		"%s/%s build #%s: %s",
+		step.metadata.PipelineName,
+		step.metadata.JobName,
+		step.metadata.BuildName,
+		step.buildURL(),
+	)
+}
+
+func (step *NotifyStep) buildURL() string {
+	return fmt.Sprintf("%s/builds/%d", step.metadata.ExternalURL, step.metadata.BuildID)
+}
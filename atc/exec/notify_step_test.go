@@ -0,0 +1,226 @@
+package exec_test
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("NotifyStep", func() {
+	var (
+		ctx        context.Context
+		cancel     func()
+		testLogger *lagertest.TestLogger
+
+		fakeDelegate        *execfakes.FakeNotifyDelegate
+		fakeDelegateFactory *execfakes.FakeNotifyDelegateFactory
+
+		server *ghttp.Server
+
+		notifyPlan atc.NotifyPlan
+		state      *execfakes.FakeRunState
+
+		stepOk  bool
+		stepErr error
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:       123,
+			TeamName:     "some-team",
+			BuildID:      42,
+			BuildName:    "7",
+			JobName:      "some-job",
+			PipelineID:   4567,
+			PipelineName: "some-pipeline",
+			ExternalURL:  "https://concourse.example.com",
+		}
+
+		stdout *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		testLogger = lagertest.NewTestLogger("notify-step-test")
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, testLogger)
+
+		state = new(execfakes.FakeRunState)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeNotifyDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanReturns(context.Background(), tracing.NoopSpan)
+
+		fakeDelegateFactory = new(execfakes.FakeNotifyDelegateFactory)
+		fakeDelegateFactory.NotifyDelegateReturns(fakeDelegate)
+
+		server = ghttp.NewServer()
+
+		notifyPlan = atc.NotifyPlan{
+			Name: "some-notification",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewNotifyStep(
+			"some-plan-id",
+			notifyPlan,
+			stepMetadata,
+			fakeDelegateFactory,
+			http.DefaultClient,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	Context("with a slack target", func() {
+		BeforeEach(func() {
+			notifyPlan.Message = "hello ((name))"
+			notifyPlan.Targets = []atc.NotifyTarget{
+				{Type: "slack", URL: server.URL() + "/slack"},
+			}
+
+			state.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+				if ref.Path == "name" {
+					return "world", true, nil
+				}
+
+				return nil, false, nil
+			}
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/slack"),
+				ghttp.VerifyJSON(`{"text":"hello world"}`),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+		})
+
+		It("posts the interpolated message", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("succeeds", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+
+		It("records the notification via the delegate", func() {
+			Expect(fakeDelegate.NotificationSentCallCount()).To(Equal(1))
+			_, target := fakeDelegate.NotificationSentArgsForCall(0)
+			Expect(target).To(Equal("slack"))
+			Expect(stdout).To(gbytes.Say("notified slack target."))
+		})
+	})
+
+	Context("with an http target and no message", func() {
+		BeforeEach(func() {
+			notifyPlan.Targets = []atc.NotifyTarget{
+				{Type: "http", URL: server.URL() + "/hook"},
+			}
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyJSON(`{
+					"message": "some-pipeline/some-job build #7: https://concourse.example.com/builds/42",
+					"team": "some-team",
+					"pipeline": "some-pipeline",
+					"job": "some-job",
+					"build_id": 42,
+					"build_name": "7",
+					"url": "https://concourse.example.com/builds/42"
+				}`),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+		})
+
+		It("posts a message describing the build along with its metadata", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when a target responds with an error", func() {
+		BeforeEach(func() {
+			notifyPlan.Targets = []atc.NotifyTarget{
+				{Type: "slack", URL: server.URL() + "/slack"},
+				{Type: "http", URL: server.URL() + "/hook"},
+			}
+
+			server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, nil))
+		})
+
+		It("returns a NotifyTargetError", func() {
+			Expect(stepErr).To(Equal(exec.NotifyTargetError{
+				Type:       "slack",
+				StatusCode: http.StatusForbidden,
+			}))
+		})
+
+		It("does not post to the remaining targets", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+			Expect(fakeDelegate.NotificationSentCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a target has an unknown type", func() {
+		BeforeEach(func() {
+			notifyPlan.Targets = []atc.NotifyTarget{
+				{Type: "carrier-pigeon", URL: server.URL()},
+			}
+		})
+
+		It("returns an UnknownNotifyTargetError", func() {
+			Expect(stepErr).To(Equal(exec.UnknownNotifyTargetError{Type: "carrier-pigeon"}))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Context("when posting to a target fails", func() {
+		BeforeEach(func() {
+			notifyPlan.Targets = []atc.NotifyTarget{
+				{Type: "slack", URL: server.URL() + "/services/some-secret-token"},
+			}
+
+			server.Close()
+		})
+
+		It("does not include the target url in the error", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).To(ContainSubstring("Post slack notify target"))
+			Expect(stepErr.Error()).ToNot(ContainSubstring("some-secret-token"))
+		})
+	})
+
+	Context("when a target url cannot be parsed", func() {
+		BeforeEach(func() {
+			notifyPlan.Targets = []atc.NotifyTarget{
+				{Type: "http", URL: "http://some-secret-token\x7f/hook"},
+			}
+		})
+
+		It("does not include the target url in the error", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).ToNot(ContainSubstring("some-secret-token"))
+		})
+	})
+})
@@ -0,0 +1,74 @@
+package exec
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// OnTimeoutStep will run one step, and then a second step if a timeout
+// expired while running the first step.
+type OnTimeoutStep struct {
+	step Step
+	hook Step
+}
+
+// OnTimeout constructs an OnTimeoutStep factory.
+func OnTimeout(step Step, hook Step) OnTimeoutStep {
+	return OnTimeoutStep{
+		step: step,
+		hook: hook,
+	}
+}
+
+// Run will call Run on the first step and wait for it to complete. If the
+// first step errors, Run returns the error.
+//
+// If a `timeout` on the first step or on any step nested within it expired,
+// the second step is executed. If the second step errors, its error is
+// returned.
+func (o OnTimeoutStep) Run(ctx context.Context, state RunState) (bool, error) {
+	recorder := &timeoutRecorder{
+		parent: timeoutRecorderFromContext(ctx),
+	}
+
+	ok, err := o.step.Run(context.WithValue(ctx, timeoutRecorderKey{}, recorder), state)
+	if err != nil {
+		return false, err
+	}
+
+	if recorder.expired() {
+		_, err := o.hook.Run(ctx, state)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return ok, nil
+}
+
+type timeoutRecorderKey struct{}
+
+// timeoutRecorder is carried through the context of a step wrapped by
+// on_timeout so that timeouts, which steps otherwise report as a plain
+// failure, can be told apart from other failures.
+type timeoutRecorder struct {
+	timedOut int32
+	parent   *timeoutRecorder
+}
+
+func timeoutRecorderFromContext(ctx context.Context) *timeoutRecorder {
+	recorder, _ := ctx.Value(timeoutRecorderKey{}).(*timeoutRecorder)
+	return recorder
+}
+
+func (recorder *timeoutRecorder) expired() bool {
+	return atomic.LoadInt32(&recorder.timedOut) == 1
+}
+
+// recordTimeout notes that a timeout expired for every on_timeout hook
+// enclosing the step running with ctx.
+func recordTimeout(ctx context.Context) {
+	for recorder := timeoutRecorderFromContext(ctx); recorder != nil; recorder = recorder.parent {
+		atomic.StoreInt32(&recorder.timedOut, 1)
+	}
+}
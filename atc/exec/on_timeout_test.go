@@ -0,0 +1,158 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("On Timeout Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		step *execfakes.FakeStep
+		hook *execfakes.FakeStep
+
+		repo  *build.Repository
+		state *execfakes.FakeRunState
+
+		onTimeoutStep exec.Step
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		step = &execfakes.FakeStep{}
+		hook = &execfakes.FakeStep{}
+
+		repo = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(repo)
+
+		onTimeoutStep = exec.OnTimeout(step, hook)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		stepOk, stepErr = onTimeoutStep.Run(ctx, state)
+	})
+
+	Context("when a timeout within the step expires", func() {
+		BeforeEach(func() {
+			timedOut := new(execfakes.FakeStep)
+			timedOut.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+				<-ctx.Done()
+				return false, ctx.Err()
+			}
+
+			onTimeoutStep = exec.OnTimeout(exec.Timeout(timedOut, "10ms"), hook)
+		})
+
+		It("runs the timeout hook", func() {
+			Expect(hook.RunCallCount()).To(Equal(1))
+		})
+
+		It("runs the hook with the run state", func() {
+			_, argsState := hook.RunArgsForCall(0)
+			Expect(argsState).To(Equal(state))
+		})
+
+		It("propagates the context to the hook", func() {
+			runCtx, _ := hook.RunArgsForCall(0)
+			Expect(runCtx).To(Equal(ctx))
+		})
+
+		It("fails without erroring", func() {
+			Expect(stepOk).To(BeFalse())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+
+		Context("when the hook errors", func() {
+			disaster := errors.New("disaster")
+
+			BeforeEach(func() {
+				hook.RunReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when the timeout is nested within another on_timeout", func() {
+			var outerHook *execfakes.FakeStep
+
+			BeforeEach(func() {
+				outerHook = new(execfakes.FakeStep)
+				onTimeoutStep = exec.OnTimeout(onTimeoutStep, outerHook)
+			})
+
+			It("runs both hooks", func() {
+				Expect(hook.RunCallCount()).To(Equal(1))
+				Expect(outerHook.RunCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when the step fails without a timeout", func() {
+		BeforeEach(func() {
+			onTimeoutStep = exec.OnTimeout(exec.Timeout(step, time.Hour.String()), hook)
+			step.RunReturns(false, nil)
+		})
+
+		It("does not run the timeout hook", func() {
+			Expect(step.RunCallCount()).To(Equal(1))
+			Expect(hook.RunCallCount()).To(Equal(0))
+		})
+
+		It("fails", func() {
+			Expect(stepOk).To(BeFalse())
+		})
+	})
+
+	Context("when the step errors", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			step.RunReturns(false, disaster)
+		})
+
+		It("does not run the timeout hook", func() {
+			Expect(step.RunCallCount()).To(Equal(1))
+			Expect(hook.RunCallCount()).To(Equal(0))
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when the step succeeds", func() {
+		BeforeEach(func() {
+			step.RunReturns(true, nil)
+		})
+
+		It("does not run the timeout hook", func() {
+			Expect(step.RunCallCount()).To(Equal(1))
+			Expect(hook.RunCallCount()).To(Equal(0))
+		})
+
+		It("succeeds", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+	})
+})
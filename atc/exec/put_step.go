@@ -1,9 +1,15 @@
 package exec
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
@@ -12,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/scanner"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 	"go.opentelemetry.io/otel/trace"
@@ -38,8 +45,11 @@ type PutDelegate interface {
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+	PhaseFinished(lager.Logger, StepPhase, time.Duration)
 
 	SaveOutput(lager.Logger, atc.PutPlan, atc.Source, db.ResourceCache, resource.VersionResult)
+
+	ArtifactScanned(lager.Logger, scanner.Report)
 }
 
 // PutStep produces a resource version using preconfigured params and any data
@@ -52,6 +62,8 @@ type PutStep struct {
 	strategy          worker.PlacementStrategy
 	workerPool        Pool
 	delegateFactory   PutDelegateFactory
+	scanner           scanner.Scanner
+	scanAction        scanner.Action
 }
 
 func NewPutStep(
@@ -62,6 +74,8 @@ func NewPutStep(
 	strategy worker.PlacementStrategy,
 	workerPool Pool,
 	delegateFactory PutDelegateFactory,
+	artifactScanner scanner.Scanner,
+	scanAction scanner.Action,
 ) Step {
 	return &PutStep{
 		planID:            planID,
@@ -71,6 +85,8 @@ func NewPutStep(
 		workerPool:        workerPool,
 		strategy:          strategy,
 		delegateFactory:   delegateFactory,
+		scanner:           artifactScanner,
+		scanAction:        scanAction,
 	}
 }
 
@@ -134,6 +150,13 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		return false, err
 	}
 
+	if step.scanner != nil {
+		err = step.scanInputs(ctx, logger, delegate, containerInputs)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	workerSpec := worker.Spec{
 		Tags:   step.plan.Tags,
 		TeamID: step.metadata.TeamID,
@@ -155,9 +178,11 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	}
 
 	containerSpec := runtime.ContainerSpec{
-		TeamID:   step.metadata.TeamID,
-		TeamName: step.metadata.TeamName,
-		JobID:    step.metadata.JobID,
+		TeamID:       step.metadata.TeamID,
+		TeamName:     step.metadata.TeamName,
+		PipelineID:   step.metadata.PipelineID,
+		PipelineName: step.metadata.PipelineName,
+		JobID:        step.metadata.JobID,
 
 		ImageSpec: imageSpec,
 
@@ -169,15 +194,22 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		Inputs: containerInputs,
 
 		CertsBindMount: true,
+		Priority:       step.plan.Priority,
+	}
+	if step.plan.Limits != nil {
+		containerSpec.Limits.CPU = (*uint64)(step.plan.Limits.CPU)
+		containerSpec.Limits.Memory = (*uint64)(step.plan.Limits.Memory)
 	}
 	tracing.Inject(ctx, &containerSpec)
 
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
 
+	finishWaiting := startPhase(logger, delegate, StepPhaseWaitingForWorker)
 	worker, err := step.workerPool.FindOrSelectWorker(ctx, owner, containerSpec, workerSpec, step.strategy, delegate)
 	if err != nil {
 		return false, err
 	}
+	finishWaiting()
 
 	delegate.SelectedWorker(logger, worker.Name())
 
@@ -198,19 +230,33 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 	defer cancel()
 
+	finishCreating := startPhase(logger, delegate, StepPhaseCreatingContainer)
 	container, _, err := worker.FindOrCreateContainer(ctx, owner, step.containerMetadata, containerSpec)
 	if err != nil {
 		return false, err
 	}
+	finishCreating()
 
 	delegate.Starting(logger)
-	versionResult, processResult, err := resource.Resource{
-		Source: source,
-		Params: params,
-	}.Put(ctx, container, delegate.Stderr())
+	finishRunning := startPhase(logger, delegate, StepPhaseRunning)
+
+	var (
+		versionResult resource.VersionResult
+		processResult runtime.ProcessResult
+	)
+	if len(step.plan.Targets) > 0 {
+		versionResult, processResult, err = step.putTargets(ctx, state, delegate, container, source, params)
+	} else {
+		versionResult, processResult, err = resource.Resource{
+			Source: source,
+			Params: params,
+		}.Put(ctx, container, delegate.Stderr())
+	}
+	finishRunning()
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			delegate.Errored(logger, TimeoutLogMessage)
+			recordTimeout(ctx)
 			return false, nil
 		}
 
@@ -218,10 +264,18 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	}
 
 	if processResult.ExitStatus != 0 {
+		recordScriptFailure(ctx)
 		delegate.Finished(logger, ExitStatus(processResult.ExitStatus), resource.VersionResult{})
 		return false, nil
 	}
 
+	if len(step.plan.Targets) > 0 {
+		// The versions belong to each target's resource config rather than the
+		// resource's, so there is no output to save.
+		delegate.Finished(logger, 0, versionResult)
+		return true, nil
+	}
+
 	// step.plan.Resource maps to an actual resource that may have been used outside of a pipeline context.
 	// Hence, if it was used outside the pipeline context, we don't want to save the output.
 	if step.plan.Resource != "" {
@@ -234,3 +288,96 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 	return true, nil
 }
+
+// putTargets runs the put script for each target in parallel within the
+// container. The output of each target is buffered and written out in order
+// once all of them are done.
+//
+// The aggregated result has the version of the first target, along with a
+// metadata field holding the version of each target.
+func (step *PutStep) putTargets(
+	ctx context.Context,
+	state RunState,
+	delegate PutDelegate,
+	container runtime.Container,
+	source atc.Source,
+	params atc.Params,
+) (resource.VersionResult, runtime.ProcessResult, error) {
+	sources := make([]atc.Source, len(step.plan.Targets))
+	for i, target := range step.plan.Targets {
+		targetSource, err := creds.NewSource(state, target).Evaluate()
+		if err != nil {
+			return resource.VersionResult{}, runtime.ProcessResult{}, err
+		}
+
+		sources[i] = source.Merge(targetSource)
+	}
+
+	var (
+		results        = make([]resource.VersionResult, len(sources))
+		processResults = make([]runtime.ProcessResult, len(sources))
+		errs           = make([]error, len(sources))
+		outputs        = make([]bytes.Buffer, len(sources))
+	)
+
+	var wg sync.WaitGroup
+	for i := range sources {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			results[i], processResults[i], errs[i] = resource.Resource{
+				Source: sources[i],
+				Params: params,
+			}.PutAs(ctx, container, &outputs[i], fmt.Sprintf("resource-%d", i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i := range outputs {
+		fmt.Fprintf(delegate.Stderr(), "\x1b[1mtarget %d:\x1b[0m\n", i)
+		outputs[i].WriteTo(delegate.Stderr())
+	}
+
+	aggregated := resource.VersionResult{
+		Version: results[0].Version,
+	}
+
+	for i := range sources {
+		if errs[i] != nil {
+			return resource.VersionResult{}, runtime.ProcessResult{}, errs[i]
+		}
+
+		if processResults[i].ExitStatus != 0 {
+			return resource.VersionResult{}, processResults[i], nil
+		}
+
+		version, err := json.Marshal(results[i].Version)
+		if err != nil {
+			return resource.VersionResult{}, runtime.ProcessResult{}, err
+		}
+
+		aggregated.Metadata = append(aggregated.Metadata, atc.MetadataField{
+			Name:  fmt.Sprintf("target-%d", i),
+			Value: string(version),
+		})
+	}
+
+	return aggregated, runtime.ProcessResult{}, nil
+}
+
+// scanInputs streams each input through the configured scanner before it is
+// handed to the resource.
+func (step *PutStep) scanInputs(ctx context.Context, logger lager.Logger, delegate PutDelegate, inputs []runtime.Input) error {
+	artifacts := make([]scannableArtifact, len(inputs))
+	for i, input := range inputs {
+		artifacts[i] = scannableArtifact{
+			name:     filepath.Base(input.DestinationPath),
+			artifact: input.Artifact,
+		}
+	}
+
+	return scanArtifacts(ctx, logger, step.scanner, step.scanAction, delegate, artifacts)
+}
@@ -1,10 +1,12 @@
 package exec_test
 
 import (
+	"archive/tar"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/concourse/concourse/tracing"
@@ -22,6 +24,8 @@ import (
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/scanner"
+	"github.com/concourse/concourse/atc/scanner/scannerfakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/vars"
 )
@@ -74,6 +78,9 @@ var _ = Describe("PutStep", func() {
 		stderrBuf *gbytes.Buffer
 
 		versionResult resource.VersionResult
+
+		artifactScanner scanner.Scanner
+		scanAction      scanner.Action
 	)
 
 	BeforeEach(func() {
@@ -140,6 +147,9 @@ var _ = Describe("PutStep", func() {
 		repo.RegisterArtifact("input1", volume1)
 		repo.RegisterArtifact("input2", volume2)
 		repo.RegisterArtifact("input3", volume3)
+
+		artifactScanner = nil
+		scanAction = scanner.ActionBlock
 	})
 
 	AfterEach(func() {
@@ -160,6 +170,8 @@ var _ = Describe("PutStep", func() {
 			nil,
 			fakePool,
 			fakeDelegateFactory,
+			artifactScanner,
+			scanAction,
 		)
 
 		stepOk, stepErr = putStep.Run(ctx, state)
@@ -207,6 +219,22 @@ var _ = Describe("PutStep", func() {
 			})
 		})
 
+		Context("when the plan specifies container limits", func() {
+			BeforeEach(func() {
+				cpu := atc.CPULimit(512)
+				memory := atc.MemoryLimit(1024)
+				putPlan.Limits = &atc.ContainerLimits{
+					CPU:    &cpu,
+					Memory: &memory,
+				}
+			})
+
+			It("sets them in the ContainerSpec", func() {
+				Expect(*chosenContainer.Spec.Limits.CPU).To(Equal(uint64(512)))
+				Expect(*chosenContainer.Spec.Limits.Memory).To(Equal(uint64(1024)))
+			})
+		})
+
 		Context("when selecting a worker fails", func() {
 			BeforeEach(func() {
 				fakePool.FindOrSelectWorkerReturns(nil, errors.New("nope"))
@@ -375,6 +403,135 @@ var _ = Describe("PutStep", func() {
 		})
 	})
 
+	Context("when an artifact scanner is configured", func() {
+		var fakeScanner *scannerfakes.FakeScanner
+		var scannedFiles []string
+
+		BeforeEach(func() {
+			putPlan.Inputs = &atc.InputsConfig{
+				Specified: []string{"input1"},
+			}
+
+			volume1 = runtimetest.NewVolume("volume1").WithContent(runtimetest.VolumeContent{
+				"some-file": {Data: []byte("some-content")},
+			})
+			repo.RegisterArtifact("input1", volume1)
+
+			scannedFiles = nil
+			fakeScanner = new(scannerfakes.FakeScanner)
+			fakeScanner.ScanStub = func(_ context.Context, _ string, tarStream io.Reader) (scanner.Result, error) {
+				tarReader := tar.NewReader(tarStream)
+				for {
+					header, err := tarReader.Next()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						return scanner.Result{}, err
+					}
+					scannedFiles = append(scannedFiles, header.Name)
+				}
+				return scanner.Result{Clean: true}, nil
+			}
+			artifactScanner = fakeScanner
+		})
+
+		It("streams each input through the scanner", func() {
+			Expect(fakeScanner.ScanCallCount()).To(Equal(1))
+			_, name, _ := fakeScanner.ScanArgsForCall(0)
+			Expect(name).To(Equal("input1"))
+			Expect(scannedFiles).To(ConsistOf("some-file"))
+		})
+
+		It("records the result via the delegate", func() {
+			Expect(fakeDelegate.ArtifactScannedCallCount()).To(Equal(1))
+			_, report := fakeDelegate.ArtifactScannedArgsForCall(0)
+			Expect(report).To(Equal(scanner.Report{
+				Artifact: "input1",
+				Result:   scanner.Result{Clean: true},
+			}))
+		})
+
+		It("runs the put", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		Context("when an input is flagged", func() {
+			BeforeEach(func() {
+				fakeScanner.ScanStub = nil
+				fakeScanner.ScanReturns(scanner.Result{
+					Clean:    false,
+					Findings: []string{"Eicar-Test-Signature"},
+				}, nil)
+			})
+
+			It("blocks the step before selecting a worker", func() {
+				Expect(stepErr).To(Equal(scanner.BlockedError{
+					Artifact: "input1",
+					Findings: []string{"Eicar-Test-Signature"},
+				}))
+				Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(0))
+			})
+
+			It("records the blocked result via the delegate", func() {
+				Expect(fakeDelegate.ArtifactScannedCallCount()).To(Equal(1))
+				_, report := fakeDelegate.ArtifactScannedArgsForCall(0)
+				Expect(report.Blocked).To(BeTrue())
+				Expect(report.Result.Findings).To(ConsistOf("Eicar-Test-Signature"))
+			})
+
+			Context("when the scan action is warn", func() {
+				BeforeEach(func() {
+					scanAction = scanner.ActionWarn
+				})
+
+				It("runs the put", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+
+				It("records the unblocked result via the delegate", func() {
+					Expect(fakeDelegate.ArtifactScannedCallCount()).To(Equal(1))
+					_, report := fakeDelegate.ArtifactScannedArgsForCall(0)
+					Expect(report.Blocked).To(BeFalse())
+					Expect(report.Result.Clean).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when scanning fails", func() {
+			disaster := errors.New("clamd unavailable")
+
+			BeforeEach(func() {
+				fakeScanner.ScanStub = nil
+				fakeScanner.ScanReturns(scanner.Result{}, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(MatchError(disaster))
+			})
+
+			It("records the error via the delegate", func() {
+				Expect(fakeDelegate.ArtifactScannedCallCount()).To(Equal(1))
+				_, report := fakeDelegate.ArtifactScannedArgsForCall(0)
+				Expect(report.Error).To(Equal(disaster))
+				Expect(report.Blocked).To(BeTrue())
+			})
+
+			Context("when the scan action is warn", func() {
+				BeforeEach(func() {
+					scanAction = scanner.ActionWarn
+				})
+
+				It("runs the put", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+			})
+		})
+	})
+
 	It("saves the build output", func() {
 		Expect(fakeDelegate.SaveOutputCallCount()).To(Equal(1))
 
@@ -541,6 +698,101 @@ var _ = Describe("PutStep", func() {
 		})
 	})
 
+	Context("when the plan has targets", func() {
+		BeforeEach(func() {
+			putPlan.Targets = []atc.Source{
+				{"space": "some-space"},
+				{"space": "((source-var))"},
+			}
+
+			chosenContainer.Container = runtimetest.NewContainer().
+				WithProcess(
+					runtime.ProcessSpec{
+						ID:   "resource-0",
+						Path: "/opt/resource/out",
+						Args: []string{resource.ResourcesDir("put")},
+					},
+					runtimetest.ProcessStub{
+						Output: resource.VersionResult{Version: atc.Version{"some": "version-0"}},
+						Stderr: "pushing to some-space\n",
+					},
+				).
+				WithProcess(
+					runtime.ProcessSpec{
+						ID:   "resource-1",
+						Path: "/opt/resource/out",
+						Args: []string{resource.ResourcesDir("put")},
+					},
+					runtimetest.ProcessStub{
+						Output: resource.VersionResult{Version: atc.Version{"some": "version-1"}},
+						Stderr: "pushing to super-secret-source\n",
+					},
+				)
+		})
+
+		It("runs the put script for each target with the merged source", func() {
+			Expect(chosenContainer.RunningProcesses()).To(HaveLen(2))
+
+			sources := map[string]atc.Source{}
+			for _, process := range chosenContainer.RunningProcesses() {
+				var request resource.Resource
+				Expect(json.NewDecoder(process.Stdin()).Decode(&request)).To(Succeed())
+				Expect(request.Params).To(Equal(atc.Params{"some": "super-secret-params"}))
+				sources[process.Spec.ID] = request.Source
+			}
+
+			Expect(sources).To(Equal(map[string]atc.Source{
+				"resource-0": {"some": "super-secret-source", "space": "some-space"},
+				"resource-1": {"some": "super-secret-source", "space": "super-secret-source"},
+			}))
+		})
+
+		It("writes the output of each target in order", func() {
+			Expect(stderrBuf).To(gbytes.Say("target 0:"))
+			Expect(stderrBuf).To(gbytes.Say("pushing to some-space"))
+			Expect(stderrBuf).To(gbytes.Say("target 1:"))
+			Expect(stderrBuf).To(gbytes.Say("pushing to super-secret-source"))
+		})
+
+		It("finishes with the aggregated versions", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, status, info := fakeDelegate.FinishedArgsForCall(0)
+			Expect(status).To(Equal(exec.ExitStatus(0)))
+			Expect(info.Version).To(Equal(atc.Version{"some": "version-0"}))
+			Expect(info.Metadata).To(Equal([]atc.MetadataField{
+				{Name: "target-0", Value: `{"some":"version-0"}`},
+				{Name: "target-1", Value: `{"some":"version-1"}`},
+			}))
+		})
+
+		It("does not save the build output", func() {
+			Expect(fakeDelegate.SaveOutputCallCount()).To(Equal(0))
+		})
+
+		It("is successful", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		Context("when a target exits unsuccessfully", func() {
+			BeforeEach(func() {
+				chosenContainer.ProcessDefs[1].Stub.ExitStatus = 42
+			})
+
+			It("finishes the step via the delegate", func() {
+				Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+				_, status, info := fakeDelegate.FinishedArgsForCall(0)
+				Expect(status).To(Equal(exec.ExitStatus(42)))
+				Expect(info).To(BeZero())
+			})
+
+			It("is not successful", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+			})
+		})
+	})
+
 	Context("when the step.Plan.Resource is blank", func() {
 		BeforeEach(func() {
 			putPlan.Resource = ""
@@ -0,0 +1,54 @@
+package exec
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+)
+
+// RescheduleStep re-runs a step from the start when the worker running it
+// goes away, e.g. because it was retired or landed mid-step, instead of
+// failing the build. Only running workers are selected when placing the step
+// again, so it ends up on another worker.
+//
+// Only steps which are safe to run more than once, i.e. gets, checks and
+// tasks, should be rescheduled.
+type RescheduleStep struct {
+	Step
+
+	delegateFactory BuildStepDelegateFactory
+}
+
+// Reschedule constructs a RescheduleStep which re-runs the step up to
+// atc.MaxStepReschedules times.
+func Reschedule(step Step, delegateFactory BuildStepDelegateFactory) Step {
+	return RescheduleStep{
+		Step:            step,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step RescheduleStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	for reschedules := 0; ; reschedules++ {
+		runOk, runErr := step.Step.Run(ctx, state)
+		if runErr == nil || ctx.Err() != nil || !IsWorkerLostError(runErr) {
+			return runOk, runErr
+		}
+
+		if reschedules >= atc.MaxStepReschedules {
+			return runOk, runErr
+		}
+
+		logger.Info("rescheduling", lager.Data{
+			"error":       runErr.Error(),
+			"reschedules": reschedules,
+		})
+
+		delegate := step.delegateFactory.BuildStepDelegate(state)
+		delegate.Rescheduled(logger, runErr.Error())
+	}
+}
@@ -0,0 +1,127 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RescheduleStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep *execfakes.FakeStep
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		state *execfakes.FakeRunState
+
+		step Step
+
+		runOk  bool
+		runErr error
+	)
+
+	workerLost := transport.WorkerMissingError{WorkerName: "some-worker"}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = new(execfakes.FakeRunState)
+
+		atc.MaxStepReschedules = 2
+
+		step = Reschedule(fakeStep, fakeDelegateFactory)
+	})
+
+	AfterEach(func() {
+		atc.MaxStepReschedules = 0
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		runOk, runErr = step.Run(ctx, state)
+	})
+
+	Context("when the step succeeds", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(true, nil)
+		})
+
+		It("runs it once", func() {
+			Expect(runOk).To(BeTrue())
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(fakeDelegate.RescheduledCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the worker goes away and the step succeeds when re-run", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturnsOnCall(0, false, workerLost)
+			fakeStep.RunReturnsOnCall(1, true, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(runOk).To(BeTrue())
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(Equal(2))
+		})
+
+		It("emits a rescheduled event with the reason", func() {
+			Expect(fakeDelegate.RescheduledCallCount()).To(Equal(1))
+			_, reason := fakeDelegate.RescheduledArgsForCall(0)
+			Expect(reason).To(Equal(workerLost.Error()))
+		})
+	})
+
+	Context("when the worker keeps going away", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(false, workerLost)
+		})
+
+		It("gives up after the maximum number of reschedules", func() {
+			Expect(runErr).To(Equal(workerLost))
+			Expect(fakeStep.RunCallCount()).To(Equal(3))
+			Expect(fakeDelegate.RescheduledCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the step errors for another reason", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeStep.RunReturns(false, disaster)
+		})
+
+		It("does not re-run it", func() {
+			Expect(runErr).To(Equal(disaster))
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the build is aborted", func() {
+		BeforeEach(func() {
+			cancel()
+			fakeStep.RunReturns(false, workerLost)
+		})
+
+		It("does not re-run the step", func() {
+			Expect(runErr).To(Equal(workerLost))
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+	})
+})
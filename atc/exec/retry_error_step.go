@@ -56,16 +56,22 @@ func (step RetryErrorStep) Run(ctx context.Context, state RunState) (bool, error
 }
 
 func (step RetryErrorStep) toRetry(logger lager.Logger, err error) bool {
-	var urlError *url.Error
-	var netError net.Error
-	if errors.As(err, &transport.WorkerMissingError{}) || errors.As(err, &transport.WorkerUnreachableError{}) || errors.As(err, &urlError) {
+	if IsWorkerLostError(err) {
 		logger.Debug("retry-error",
 			lager.Data{"err_type": reflect.TypeOf(err).String(), "err": err.Error()})
 		return true
-	} else if errors.As(err, &netError) || regexp.MustCompile(`worker .+ disappeared`).MatchString(err.Error()) {
-		logger.Debug("retry-error",
-			lager.Data{"err_type": reflect.TypeOf(err).String(), "err": err})
-		return true
 	}
 	return false
 }
+
+// IsWorkerLostError returns true if the error was caused by the worker
+// running a step going away or becoming unreachable.
+func IsWorkerLostError(err error) bool {
+	var urlError *url.Error
+	var netError net.Error
+	return errors.As(err, &transport.WorkerMissingError{}) ||
+		errors.As(err, &transport.WorkerUnreachableError{}) ||
+		errors.As(err, &urlError) ||
+		errors.As(err, &netError) ||
+		regexp.MustCompile(`worker .+ disappeared`).MatchString(err.Error())
+}
@@ -2,43 +2,194 @@ package exec
 
 import (
 	"context"
+	"errors"
+	"math/rand"
+	"regexp"
+	"time"
+
+	"github.com/concourse/concourse/atc"
 )
 
+const defaultBackoffMultiplier = 2
+
 // RetryStep is a step that will run the steps in order until one of them
 // succeeds.
 type RetryStep struct {
 	Attempts    []Step
+	Backoff     *atc.RetryBackoff
+	RetryOn     []atc.RetryCondition
 	LastAttempt Step
 }
 
-func Retry(attempts ...Step) Step {
+// Retry constructs a RetryStep. If backoff is non-nil, each attempt after the
+// first waits for an increasing delay before running. If retryOn is non-empty,
+// a failed attempt is only retried if its failure matches one of the
+// conditions.
+func Retry(backoff *atc.RetryBackoff, retryOn []atc.RetryCondition, attempts ...Step) Step {
 	return &RetryStep{
 		Attempts: attempts,
+		Backoff:  backoff,
+		RetryOn:  retryOn,
 	}
 }
 
-// Run iterates through each step, stopping once a step succeeds. If all steps
+// Run iterates through each step, stopping once a step succeeds, or once a
+// step fails in a way that does not match the retry conditions. If all steps
 // fail, the RetryStep will fail.
 func (step *RetryStep) Run(ctx context.Context, state RunState) (bool, error) {
 	var attemptOk bool
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
+	delays, err := newBackoffDelays(step.Backoff)
+	if err != nil {
+		return false, err
+	}
+
+	messagePatterns, err := retryMessagePatterns(step.RetryOn)
+	if err != nil {
+		return false, err
+	}
+
+	for i, attempt := range step.Attempts {
+		if i > 0 && delays != nil {
+			timer := time.NewTimer(delays.next())
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return false, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
 		step.LastAttempt = attempt
 
-		attemptOk, attemptErr = attempt.Run(ctx, state)
+		attemptCtx := ctx
+		var failures *failureRecorder
+		if len(step.RetryOn) > 0 {
+			attemptCtx, failures = withFailureRecorder(ctx)
+		}
+
+		attemptOk, attemptErr = attempt.Run(attemptCtx, state)
 		if ctx.Err() != nil {
 			return false, ctx.Err()
 		}
 
-		if attemptErr != nil {
-			continue
+		if attemptErr == nil && attemptOk {
+			break
 		}
 
-		if attemptOk {
+		if failures != nil && !step.matchesRetryOn(failures, messagePatterns, attemptErr) {
 			break
 		}
 	}
 
 	return attemptOk, attemptErr
 }
+
+func (step *RetryStep) matchesRetryOn(failures *failureRecorder, messagePatterns []*regexp.Regexp, attemptErr error) bool {
+	for _, condition := range step.RetryOn {
+		switch condition.Class {
+		case atc.RetryOnTimeout:
+			if failures.timeouts.expired() {
+				return true
+			}
+		case atc.RetryOnWorkerDisappeared:
+			if attemptErr != nil && (errors.As(attemptErr, &Retriable{}) || IsWorkerLostError(attemptErr)) {
+				return true
+			}
+		case atc.RetryOnScriptFailure:
+			if failures.scriptFailed() {
+				return true
+			}
+		}
+	}
+
+	if attemptErr != nil {
+		for _, pattern := range messagePatterns {
+			if pattern.MatchString(attemptErr.Error()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func retryMessagePatterns(conditions []atc.RetryCondition) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, condition := range conditions {
+		if condition.Message == "" {
+			continue
+		}
+
+		pattern, err := regexp.Compile(condition.Message)
+		if err != nil {
+			return nil, err
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+type backoffDelays struct {
+	current    time.Duration
+	max        time.Duration
+	multiplier float64
+	jitter     bool
+}
+
+func newBackoffDelays(config *atc.RetryBackoff) (*backoffDelays, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	initial, err := time.ParseDuration(config.Initial)
+	if err != nil {
+		return nil, err
+	}
+
+	var max time.Duration
+	if config.Max != "" {
+		max, err = time.ParseDuration(config.Max)
+		if err != nil {
+			return nil, err
+		}
+
+		if initial > max {
+			initial = max
+		}
+	}
+
+	multiplier := config.Multiplier
+	if multiplier == 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	return &backoffDelays{
+		current:    initial,
+		max:        max,
+		multiplier: multiplier,
+		jitter:     config.Jitter,
+	}, nil
+}
+
+// next returns the delay to wait before the upcoming attempt and grows the
+// delay for the one after it.
+func (delays *backoffDelays) next() time.Duration {
+	delay := delays.current
+
+	delays.current = time.Duration(float64(delays.current) * delays.multiplier)
+	if delays.max > 0 && delays.current > delays.max {
+		delays.current = delays.max
+	}
+
+	if delays.jitter && delay > 1 {
+		half := delay / 2
+		delay = half + time.Duration(rand.Int63n(int64(delay-half)))
+	}
+
+	return delay
+}
@@ -3,10 +3,13 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -37,7 +40,7 @@ var _ = Describe("Retry Step", func() {
 		state = new(execfakes.FakeRunState)
 		state.ArtifactRepositoryReturns(repo)
 
-		step = Retry(attempt1, attempt2, attempt3)
+		step = Retry(nil, nil, attempt1, attempt2, attempt3)
 	})
 
 	Describe("Run", func() {
@@ -212,5 +215,172 @@ var _ = Describe("Retry Step", func() {
 				Expect(stepOk).To(BeFalse())
 			})
 		})
+
+		Context("with retry conditions", func() {
+			BeforeEach(func() {
+				retryOn := []atc.RetryCondition{
+					{Class: atc.RetryOnTimeout},
+					{Class: atc.RetryOnWorkerDisappeared},
+					{Message: "connection reset"},
+				}
+
+				attempt2.RunReturns(true, nil)
+
+				step = Retry(nil, retryOn, attempt1, attempt2, attempt3)
+			})
+
+			Context("when attempt 1 times out", func() {
+				BeforeEach(func() {
+					timedOut := new(execfakes.FakeStep)
+					timedOut.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+						<-ctx.Done()
+						return false, ctx.Err()
+					}
+
+					attempt1.RunStub = Timeout(timedOut, "1ms").Run
+				})
+
+				It("retries", func() {
+					Expect(attempt2.RunCallCount()).To(Equal(1))
+					Expect(stepOk).To(BeTrue())
+				})
+			})
+
+			Context("when attempt 1 loses its worker", func() {
+				BeforeEach(func() {
+					attempt1.RunReturns(false, transport.WorkerMissingError{WorkerName: "some-worker"})
+				})
+
+				It("retries", func() {
+					Expect(attempt2.RunCallCount()).To(Equal(1))
+					Expect(stepOk).To(BeTrue())
+				})
+			})
+
+			Context("when attempt 1 errors with a matching message", func() {
+				BeforeEach(func() {
+					attempt1.RunReturns(false, errors.New("read: connection reset by peer"))
+				})
+
+				It("retries", func() {
+					Expect(attempt2.RunCallCount()).To(Equal(1))
+					Expect(stepOk).To(BeTrue())
+				})
+			})
+
+			Context("when attempt 1 errors with some other message", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					attempt1.RunReturns(false, disaster)
+				})
+
+				It("returns the error without retrying", func() {
+					Expect(stepErr).To(Equal(disaster))
+					Expect(attempt2.RunCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when attempt 1 fails", func() {
+				BeforeEach(func() {
+					attempt1.RunReturns(false, nil)
+				})
+
+				It("fails without retrying", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeFalse())
+					Expect(attempt2.RunCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a message pattern is invalid", func() {
+				BeforeEach(func() {
+					step = Retry(nil, []atc.RetryCondition{{Message: "("}}, attempt1, attempt2, attempt3)
+				})
+
+				It("errors without running any attempts", func() {
+					Expect(stepErr).To(HaveOccurred())
+					Expect(attempt1.RunCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("with a backoff", func() {
+			var backoff *atc.RetryBackoff
+			var attemptTimes []time.Time
+
+			recordAttempt := func(ok bool) func(context.Context, RunState) (bool, error) {
+				return func(context.Context, RunState) (bool, error) {
+					attemptTimes = append(attemptTimes, time.Now())
+					return ok, nil
+				}
+			}
+
+			BeforeEach(func() {
+				attemptTimes = nil
+
+				backoff = &atc.RetryBackoff{
+					Initial:    "50ms",
+					Multiplier: 3,
+					Max:        "100ms",
+				}
+
+				attempt1.RunStub = recordAttempt(false)
+				attempt2.RunStub = recordAttempt(false)
+				attempt3.RunStub = recordAttempt(true)
+
+				step = Retry(backoff, nil, attempt1, attempt2, attempt3)
+			})
+
+			It("waits between attempts, growing the delay up to the max", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+
+				Expect(attemptTimes).To(HaveLen(3))
+				Expect(attemptTimes[1].Sub(attemptTimes[0])).To(BeNumerically(">=", 50*time.Millisecond))
+				Expect(attemptTimes[2].Sub(attemptTimes[1])).To(BeNumerically(">=", 100*time.Millisecond))
+				Expect(attemptTimes[2].Sub(attemptTimes[1])).To(BeNumerically("<", 150*time.Millisecond))
+			})
+
+			Context("when the first attempt succeeds", func() {
+				BeforeEach(func() {
+					attempt1.RunStub = recordAttempt(true)
+					backoff.Initial = "1h"
+				})
+
+				It("does not wait", func() {
+					Expect(stepOk).To(BeTrue())
+					Expect(attemptTimes).To(HaveLen(1))
+				})
+			})
+
+			Context("when interrupted while waiting", func() {
+				BeforeEach(func() {
+					backoff.Initial = "1h"
+					backoff.Max = ""
+
+					attempt1.RunStub = func(context.Context, RunState) (bool, error) {
+						cancel()
+						return false, nil
+					}
+				})
+
+				It("returns the context error without running another attempt", func() {
+					Expect(stepErr).To(Equal(context.Canceled))
+					Expect(attempt2.RunCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the delay is invalid", func() {
+				BeforeEach(func() {
+					backoff.Initial = "nope"
+				})
+
+				It("errors without running any attempts", func() {
+					Expect(stepErr).To(HaveOccurred())
+					Expect(attempt1.RunCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
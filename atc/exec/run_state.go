@@ -19,6 +19,10 @@ type runState struct {
 	results   *sync.Map
 
 	parent RunState
+
+	output OutputPolicy
+
+	trace *RunStateTrace
 }
 
 type Stepper func(atc.Plan) Step
@@ -38,6 +42,20 @@ func NewRunState(
 	}
 }
 
+// NewTracingRunState constructs a RunState which records its var lookups,
+// local vars and artifact registrations into the given trace.
+func NewTracingRunState(
+	stepper Stepper,
+	credVars vars.Variables,
+	enableRedaction bool,
+	trace *RunStateTrace,
+) RunState {
+	state := NewRunState(stepper, credVars, enableRedaction).(*runState)
+	state.trace = trace
+	state.artifacts.OnRegister(trace.recordRegisterArtifact)
+	return state
+}
+
 func (state *runState) ArtifactRepository() *build.Repository {
 	return state.artifacts
 }
@@ -61,7 +79,11 @@ func (state *runState) StoreResult(id atc.PlanID, val interface{}) {
 }
 
 func (state *runState) Get(ref vars.Reference) (interface{}, bool, error) {
-	return state.vars.Get(ref)
+	val, found, err := state.vars.Get(ref)
+	if state.trace != nil {
+		state.trace.recordGetVar(ref, val, found, err, state.vars.RedactionEnabled())
+	}
+	return val, found, err
 }
 
 func (state *runState) List() ([]vars.Reference, error) {
@@ -86,12 +108,34 @@ func (state *runState) Parent() RunState {
 
 func (state *runState) AddLocalVar(name string, val interface{}, redact bool) {
 	state.vars.AddLocalVar(name, val, redact)
+	if state.trace != nil {
+		state.trace.recordAddLocalVar(name, val, redact && state.vars.RedactionEnabled())
+	}
+}
+
+func (state *runState) AddLocalVarField(name string, field string, val interface{}) {
+	state.vars.AddLocalVarField(name, field, val)
+	if state.trace != nil {
+		state.trace.recordAddLocalVar(name+"."+field, val, false)
+	}
 }
 
 func (state *runState) RedactionEnabled() bool {
 	return state.vars.RedactionEnabled()
 }
 
+func (state *runState) OutputPolicy() OutputPolicy {
+	return state.output
+}
+
+// WithOutputPolicy returns a RunState sharing this state's vars, artifacts
+// and results whose steps save their output according to the given policy.
+func (state *runState) WithOutputPolicy(policy OutputPolicy) RunState {
+	clone := *state
+	clone.output = policy
+	return &clone
+}
+
 func (state *runState) Run(ctx context.Context, plan atc.Plan) (bool, error) {
 	return state.stepper(plan).Run(ctx, state)
 }
@@ -288,6 +288,31 @@ var _ = Describe("RunState", func() {
 		})
 	})
 
+	Describe("AddLocalVarField", func() {
+		BeforeEach(func() {
+			state.AddLocalVarField("foo", "a", "1")
+			state.AddLocalVarField("foo", "b", "2")
+		})
+
+		It("keeps the fields already set", func() {
+			val, found, err := state.Get(vars.Reference{Source: ".", Path: "foo"})
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[string]interface{}{"a": "1", "b": "2"}))
+		})
+
+		It("keeps the fields set in a parent scope", func() {
+			scope := state.NewLocalScope()
+			scope.AddLocalVarField("foo", "c", "3")
+
+			val, _, _ := scope.Get(vars.Reference{Source: ".", Path: "foo"})
+			Expect(val).To(Equal(map[string]interface{}{"a": "1", "b": "2", "c": "3"}))
+
+			val, _, _ = state.Get(vars.Reference{Source: ".", Path: "foo"})
+			Expect(val).To(Equal(map[string]interface{}{"a": "1", "b": "2"}))
+		})
+	})
+
 	Describe("NewLocalScope", func() {
 		It("maintains a reference to the parent", func() {
 			Expect(state.NewLocalScope().Parent()).To(Equal(state))
@@ -0,0 +1,222 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/vars"
+)
+
+type RunStateTraceEventType string
+
+const (
+	RunStateTraceGetVar           RunStateTraceEventType = "get-var"
+	RunStateTraceAddLocalVar      RunStateTraceEventType = "add-local-var"
+	RunStateTraceRegisterArtifact RunStateTraceEventType = "register-artifact"
+)
+
+// RedactedTraceValue is recorded in place of credential values when secret
+// redaction is enabled, and is what replayed lookups of them resolve to.
+const RedactedTraceValue = "((redacted))"
+
+type RunStateTraceEvent struct {
+	Type RunStateTraceEventType `json:"type"`
+
+	Var      *vars.Reference `json:"var,omitempty"`
+	Name     string          `json:"name,omitempty"`
+	Value    interface{}     `json:"value,omitempty"`
+	Found    bool            `json:"found,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Redacted bool            `json:"redacted,omitempty"`
+}
+
+// RunStateTrace records every var lookup, local var and artifact registration
+// made against a RunState, in order, so that the way a build resolved its
+// state can be replayed offline.
+type RunStateTrace struct {
+	Events []RunStateTraceEvent `json:"events"`
+
+	lock           sync.Mutex
+	localVarCursor map[string]int
+}
+
+func NewRunStateTrace() *RunStateTrace {
+	return &RunStateTrace{}
+}
+
+func (trace *RunStateTrace) record(event RunStateTraceEvent) {
+	trace.lock.Lock()
+	trace.Events = append(trace.Events, event)
+	trace.lock.Unlock()
+}
+
+func (trace *RunStateTrace) recordGetVar(ref vars.Reference, val interface{}, found bool, err error, redact bool) {
+	event := RunStateTraceEvent{
+		Type:  RunStateTraceGetVar,
+		Var:   &ref,
+		Found: found,
+	}
+
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	// local vars are recorded when they are added, and will be added again by
+	// the replayed steps
+	if found && ref.Source != "." {
+		if redact {
+			event.Redacted = true
+		} else {
+			event.Value = val
+		}
+	}
+
+	trace.record(event)
+}
+
+func (trace *RunStateTrace) recordAddLocalVar(name string, val interface{}, redact bool) {
+	event := RunStateTraceEvent{
+		Type: RunStateTraceAddLocalVar,
+		Name: name,
+	}
+
+	if redact {
+		event.Redacted = true
+	} else {
+		event.Value = val
+	}
+
+	trace.record(event)
+}
+
+func (trace *RunStateTrace) recordRegisterArtifact(name build.ArtifactName) {
+	trace.record(RunStateTraceEvent{
+		Type: RunStateTraceRegisterArtifact,
+		Name: string(name),
+	})
+}
+
+// NextLocalVar returns the value of the next recorded local var with the given
+// name which has not yet been replayed, and whether it should be redacted.
+func (trace *RunStateTrace) NextLocalVar(name string) (interface{}, bool, bool) {
+	trace.lock.Lock()
+	defer trace.lock.Unlock()
+
+	if trace.localVarCursor == nil {
+		trace.localVarCursor = map[string]int{}
+	}
+
+	seen := 0
+	for _, event := range trace.Events {
+		if event.Type != RunStateTraceAddLocalVar || event.Name != name {
+			continue
+		}
+
+		if seen == trace.localVarCursor[name] {
+			trace.localVarCursor[name]++
+
+			if event.Redacted {
+				return RedactedTraceValue, true, true
+			}
+
+			return event.Value, false, true
+		}
+
+		seen++
+	}
+
+	return nil, false, false
+}
+
+// Artifacts returns the names of all recorded artifacts.
+func (trace *RunStateTrace) Artifacts() []build.ArtifactName {
+	trace.lock.Lock()
+	defer trace.lock.Unlock()
+
+	var names []build.ArtifactName
+	seen := map[string]bool{}
+	for _, event := range trace.Events {
+		if event.Type != RunStateTraceRegisterArtifact || seen[event.Name] {
+			continue
+		}
+
+		seen[event.Name] = true
+		names = append(names, build.ArtifactName(event.Name))
+	}
+
+	return names
+}
+
+// Variables returns the recorded outcome of every var lookup that was not
+// satisfied by a local var.
+func (trace *RunStateTrace) Variables() vars.Variables {
+	trace.lock.Lock()
+	defer trace.lock.Unlock()
+
+	replayed := replayVariables{}
+	for _, event := range trace.Events {
+		if event.Type != RunStateTraceGetVar || event.Var == nil || event.Var.Source == "." {
+			continue
+		}
+
+		replayed[event.Var.String()] = event
+	}
+
+	return replayed
+}
+
+type replayVariables map[string]RunStateTraceEvent
+
+func (replayed replayVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	event, recorded := replayed[ref.String()]
+	if !recorded {
+		return nil, false, fmt.Errorf("var %s was not recorded in the trace", ref)
+	}
+
+	if event.Error != "" {
+		return nil, false, errors.New(event.Error)
+	}
+
+	if event.Redacted {
+		return RedactedTraceValue, event.Found, nil
+	}
+
+	return event.Value, event.Found, nil
+}
+
+func (replayed replayVariables) List() ([]vars.Reference, error) {
+	var refs []vars.Reference
+	for _, event := range replayed {
+		refs = append(refs, *event.Var)
+	}
+
+	return refs, nil
+}
+
+// ReplayArtifact stands in for an artifact recorded in a RunStateTrace. Its
+// contents were not recorded, so it cannot be streamed.
+type ReplayArtifact struct {
+	Name build.ArtifactName
+}
+
+func (artifact ReplayArtifact) StreamOut(context.Context, string, compression.Compression) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("cannot stream replayed artifact %s", artifact.Name)
+}
+
+// NewReplayRunState constructs a RunState which resolves vars from the given
+// trace rather than from credential managers, and in which every recorded
+// artifact is registered up front.
+func NewReplayRunState(stepper Stepper, trace *RunStateTrace) RunState {
+	state := NewRunState(stepper, trace.Variables(), false)
+
+	for _, name := range trace.Artifacts() {
+		state.ArtifactRepository().RegisterArtifact(name, ReplayArtifact{Name: name})
+	}
+
+	return state
+}
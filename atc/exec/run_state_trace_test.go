@@ -0,0 +1,138 @@
+package exec_test
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunStateTrace", func() {
+	var (
+		trace *exec.RunStateTrace
+		state exec.RunState
+
+		credVars        vars.Variables
+		enableRedaction bool
+	)
+
+	BeforeEach(func() {
+		trace = exec.NewRunStateTrace()
+		credVars = vars.StaticVariables{"k1": "v1"}
+		enableRedaction = false
+	})
+
+	JustBeforeEach(func() {
+		state = exec.NewTracingRunState(noopStepper, credVars, enableRedaction, trace)
+	})
+
+	Describe("recording", func() {
+		JustBeforeEach(func() {
+			state.Get(vars.Reference{Path: "k1"})
+			state.Get(vars.Reference{Path: "missing"})
+
+			scope := state.NewLocalScope()
+			scope.AddLocalVar("local", "some-value", false)
+			scope.Get(vars.Reference{Source: ".", Path: "local"})
+			scope.ArtifactRepository().RegisterArtifact("some-artifact", runtimetest.NewVolume("some-volume"))
+		})
+
+		It("records var lookups, local vars and artifacts in order", func() {
+			Expect(trace.Events).To(Equal([]exec.RunStateTraceEvent{
+				{Type: exec.RunStateTraceGetVar, Var: &vars.Reference{Path: "k1"}, Value: "v1", Found: true},
+				{Type: exec.RunStateTraceGetVar, Var: &vars.Reference{Path: "missing"}},
+				{Type: exec.RunStateTraceAddLocalVar, Name: "local", Value: "some-value"},
+				{Type: exec.RunStateTraceGetVar, Var: &vars.Reference{Source: ".", Path: "local"}, Found: true},
+				{Type: exec.RunStateTraceRegisterArtifact, Name: "some-artifact"},
+			}))
+		})
+
+		Context("when redaction is enabled", func() {
+			BeforeEach(func() {
+				enableRedaction = true
+			})
+
+			It("does not record credential values", func() {
+				Expect(trace.Events[0]).To(Equal(exec.RunStateTraceEvent{
+					Type:     exec.RunStateTraceGetVar,
+					Var:      &vars.Reference{Path: "k1"},
+					Found:    true,
+					Redacted: true,
+				}))
+			})
+		})
+	})
+
+	Describe("NewReplayRunState", func() {
+		var replayed exec.RunState
+
+		JustBeforeEach(func() {
+			state.Get(vars.Reference{Path: "k1"})
+			state.AddLocalVar("local", "some-value", false)
+			state.ArtifactRepository().RegisterArtifact("some-artifact", runtimetest.NewVolume("some-volume"))
+
+			replayed = exec.NewReplayRunState(noopStepper, trace)
+		})
+
+		It("resolves recorded vars", func() {
+			val, found, err := replayed.Get(vars.Reference{Path: "k1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("v1"))
+		})
+
+		It("errors for vars which were not recorded", func() {
+			_, _, err := replayed.Get(vars.Reference{Path: "k2"})
+			Expect(err).To(MatchError("var k2 was not recorded in the trace"))
+		})
+
+		It("registers placeholders for recorded artifacts", func() {
+			artifact, found := replayed.ArtifactRepository().ArtifactFor("some-artifact")
+			Expect(found).To(BeTrue())
+			Expect(artifact).To(Equal(exec.ReplayArtifact{Name: build.ArtifactName("some-artifact")}))
+
+			_, err := artifact.StreamOut(context.Background(), ".", nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("replays recorded local vars in order", func() {
+			val, redact, found := trace.NextLocalVar("local")
+			Expect(found).To(BeTrue())
+			Expect(redact).To(BeFalse())
+			Expect(val).To(Equal("some-value"))
+
+			_, _, found = trace.NextLocalVar("local")
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when redaction is enabled", func() {
+			BeforeEach(func() {
+				enableRedaction = true
+			})
+
+			It("resolves credentials to a placeholder", func() {
+				val, _, err := replayed.Get(vars.Reference{Path: "k1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(val).To(Equal(exec.RedactedTraceValue))
+			})
+		})
+	})
+
+	It("runs plans with the stepper", func() {
+		var stepped atc.Plan
+		replayed := exec.NewReplayRunState(func(plan atc.Plan) exec.Step {
+			stepped = plan
+			return exec.IdentityStep{}
+		}, trace)
+
+		ok, err := replayed.Run(context.Background(), atc.Plan{ID: "some-plan"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(stepped.ID).To(Equal(atc.PlanID("some-plan")))
+	})
+})
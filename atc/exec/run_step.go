@@ -1,61 +1,300 @@
 package exec
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"path"
+	"path/filepath"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
 )
 
-// RunStep will run a message against a prototype.
-type RunStep struct {
-	planID          atc.PlanID
-	plan            atc.RunPlan
-	delegateFactory RunDelegateFactory
-}
+const runProcessID = "run"
 
+//counterfeiter:generate . RunDelegateFactory
 type RunDelegateFactory interface {
 	RunDelegate(state RunState) RunDelegate
 }
 
+//counterfeiter:generate . RunDelegate
 type RunDelegate interface {
-	Stdout() io.Writer
-	Stderr() io.Writer
+	BuildStepDelegate
+}
 
-	Initializing(lager.Logger)
-	Starting(lager.Logger)
-	Finished(lager.Logger, bool)
+// RunRequest is written to the stdin of the prototype's executable for the
+// message being run.
+type RunRequest struct {
+	Object atc.Params `json:"object"`
+}
+
+// RunStep will run a message against a prototype.
+//
+// The prototype's image is fetched and a container is created from it with
+// the step's inputs mounted into its working directory. The message is run
+// by executing /usr/bin/<message> within the container, with a RunRequest
+// written to its stdin.
+type RunStep struct {
+	planID            atc.PlanID
+	plan              atc.RunPlan
+	metadata          StepMetadata
+	containerMetadata db.ContainerMetadata
+	strategy          worker.PlacementStrategy
+	workerPool        Pool
+	delegateFactory   RunDelegateFactory
 }
 
 func NewRunStep(
 	planID atc.PlanID,
 	plan atc.RunPlan,
+	metadata StepMetadata,
+	containerMetadata db.ContainerMetadata,
+	strategy worker.PlacementStrategy,
+	workerPool Pool,
 	delegateFactory RunDelegateFactory,
 ) Step {
 	return &RunStep{
-		planID:          planID,
-		plan:            plan,
-		delegateFactory: delegateFactory,
+		planID:            planID,
+		plan:              plan,
+		metadata:          metadata,
+		containerMetadata: containerMetadata,
+		strategy:          strategy,
+		workerPool:        workerPool,
+		delegateFactory:   delegateFactory,
 	}
 }
 
 func (step *RunStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.RunDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "run", tracing.Attrs{
+		"message":   step.plan.Message,
+		"prototype": step.plan.Type,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *RunStep) run(ctx context.Context, state RunState, delegate RunDelegate) (bool, error) {
 	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("run-step", lager.Data{
+		"message":   step.plan.Message,
+		"prototype": step.plan.Type,
+	})
 
-	delegate := step.delegateFactory.RunDelegate(state)
 	delegate.Initializing(logger)
 
-	stderr := delegate.Stderr()
-	fmt.Fprint(stderr, "\x1b[1;33mthe run step is not yet implemented\x1b[0m\n\n")
+	object, err := creds.NewParams(state, step.plan.Object).Evaluate()
+	if err != nil {
+		return false, err
+	}
+
+	request, err := json.Marshal(RunRequest{Object: object})
+	if err != nil {
+		return false, err
+	}
+
+	var imageSpec runtime.ImageSpec
+	if step.plan.TypeImage.GetPlan != nil {
+		imageSpec, _, err = delegate.FetchImage(ctx, *step.plan.TypeImage.GetPlan, step.plan.TypeImage.CheckPlan, step.plan.TypeImage.Privileged)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		imageSpec.ResourceType = step.plan.TypeImage.BaseType
+	}
+
+	imageSpec.Privileged = imageSpec.Privileged || step.plan.Privileged
+
+	repository := state.ArtifactRepository()
+
+	containerSpec := runtime.ContainerSpec{
+		TeamID:       step.metadata.TeamID,
+		TeamName:     step.metadata.TeamName,
+		PipelineID:   step.metadata.PipelineID,
+		PipelineName: step.metadata.PipelineName,
+		JobID:        step.metadata.JobID,
+		StepName:     step.plan.Message,
+
+		ImageSpec: imageSpec,
+
+		Env:  step.metadata.Env(),
+		Type: step.containerMetadata.Type,
+
+		Dir: step.containerMetadata.WorkingDirectory,
+
+		Priority: step.plan.Priority,
+	}
+
+	containerSpec.Inputs, err = step.containerInputs(repository)
+	if err != nil {
+		return false, err
+	}
+
+	containerSpec.Outputs = make(runtime.OutputPaths, len(step.plan.Outputs))
+	for _, output := range step.plan.Outputs {
+		containerSpec.Outputs[output] = ensureTrailingSlash(artifactPath(step.containerMetadata.WorkingDirectory, output, ""))
+	}
+
+	if step.plan.Limits != nil {
+		containerSpec.Limits.CPU = (*uint64)(step.plan.Limits.CPU)
+		containerSpec.Limits.Memory = (*uint64)(step.plan.Limits.Memory)
+	}
+	tracing.Inject(ctx, &containerSpec)
+
+	workerSpec := worker.Spec{
+		Tags:   step.plan.Tags,
+		TeamID: step.metadata.TeamID,
+
+		// Used to filter out non-Linux workers, simply because they don't support
+		// base resource types
+		ResourceType: step.plan.TypeImage.BaseType,
+	}
+
+	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
+
+	finishWaiting := startPhase(logger, delegate, StepPhaseWaitingForWorker)
+	worker, err := step.workerPool.FindOrSelectWorker(
+		ctx,
+		owner,
+		containerSpec,
+		workerSpec,
+		step.strategy,
+		delegate,
+	)
+	if err != nil {
+		return false, err
+	}
+	finishWaiting()
+
+	defer func() {
+		step.workerPool.ReleaseWorker(
+			logger,
+			containerSpec,
+			worker,
+			step.strategy,
+		)
+	}()
+
+	if step.plan.Timeout != "" {
+		timeout, err := time.ParseDuration(step.plan.Timeout)
+		if err != nil {
+			return false, fmt.Errorf("parse timeout: %w", err)
+		}
+
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	ctx = lagerctx.NewContext(ctx, logger)
+
+	delegate.SelectedWorker(logger, worker.Name())
+
+	finishCreating := startPhase(logger, delegate, StepPhaseCreatingContainer)
+	container, volumeMounts, err := worker.FindOrCreateContainer(ctx, owner, step.containerMetadata, containerSpec)
+	if err != nil {
+		return false, err
+	}
+	finishCreating()
 
 	delegate.Starting(logger)
+	finishRunning := startPhase(logger, delegate, StepPhaseRunning)
+	process, err := attachOrRun(
+		ctx,
+		container,
+		runtime.ProcessSpec{
+			ID:   runProcessID,
+			Path: path.Join("/usr/bin", step.plan.Message),
+			Dir:  step.containerMetadata.WorkingDirectory,
+		},
+		runtime.ProcessIO{
+			Stdin:  bytes.NewBuffer(request),
+			Stdout: delegate.Stdout(),
+			Stderr: delegate.Stderr(),
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	result, runErr := process.Wait(ctx)
+	finishRunning()
 
-	fmt.Fprintf(stderr, "\x1b[1;34mpretending to run %s on prototype %s...\x1b[0m\n", step.plan.Message, step.plan.Type)
+	step.registerOutputs(logger, repository, volumeMounts)
 
-	delegate.Finished(logger, true)
+	if runErr != nil {
+		if errors.Is(runErr, context.DeadlineExceeded) {
+			delegate.Errored(logger, TimeoutLogMessage)
+			recordTimeout(ctx)
+			return false, nil
+		}
+
+		return false, runErr
+	}
 
-	return true, nil
+	succeeded := result.ExitStatus == 0
+	delegate.Finished(logger, succeeded)
+
+	return succeeded, nil
+}
+
+func (step *RunStep) containerInputs(repository *build.Repository) ([]runtime.Input, error) {
+	var inputs []runtime.Input
+	var missingInputs []string
+
+	for _, input := range step.plan.Inputs {
+		inputName := input
+		if sourceName, ok := step.plan.InputMapping[input]; ok {
+			inputName = sourceName
+		}
+
+		artifact, found := repository.ArtifactFor(build.ArtifactName(inputName))
+		if !found {
+			missingInputs = append(missingInputs, inputName)
+			continue
+		}
+
+		inputs = append(inputs, runtime.Input{
+			Artifact:        artifact,
+			DestinationPath: artifactPath(step.containerMetadata.WorkingDirectory, input, ""),
+		})
+	}
+
+	if len(missingInputs) > 0 {
+		return nil, MissingInputsError{missingInputs}
+	}
+
+	return inputs, nil
+}
+
+func (step *RunStep) registerOutputs(logger lager.Logger, repository *build.Repository, volumeMounts []runtime.VolumeMount) {
+	logger.Debug("registering-outputs", lager.Data{"outputs": step.plan.Outputs})
+
+	for _, output := range step.plan.Outputs {
+		outputName := output
+		if destinationName, ok := step.plan.OutputMapping[output]; ok {
+			outputName = destinationName
+		}
+
+		outputPath := artifactPath(step.containerMetadata.WorkingDirectory, output, "")
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				repository.RegisterArtifact(build.ArtifactName(outputName), mount.Volume)
+			}
+		}
+	}
 }
@@ -0,0 +1,310 @@
+package exec_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		stdoutBuf *gbytes.Buffer
+		stderrBuf *gbytes.Buffer
+
+		fakePool            *execfakes.FakePool
+		fakeDelegate        *execfakes.FakeRunDelegate
+		fakeDelegateFactory *execfakes.FakeRunDelegateFactory
+
+		chosenWorker    *runtimetest.Worker
+		chosenContainer *runtimetest.WorkerContainer
+
+		runPlan *atc.RunPlan
+
+		state exec.RunState
+		repo  *build.Repository
+
+		stepOk  bool
+		stepErr error
+
+		containerMetadata = db.ContainerMetadata{
+			WorkingDirectory: "/tmp/build/run",
+			Type:             db.ContainerTypeRun,
+		}
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:  123,
+			BuildID: 1234,
+			JobID:   12345,
+		}
+
+		planID = atc.PlanID("42")
+
+		expectedOwner = db.NewBuildStepContainerOwner(stepMetadata.BuildID, planID, stepMetadata.TeamID)
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		stdoutBuf = gbytes.NewBuffer()
+		stderrBuf = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeRunDelegate)
+		fakeDelegate.StdoutReturns(stdoutBuf)
+		fakeDelegate.StderrReturns(stderrBuf)
+		fakeDelegate.StartSpanReturns(ctx, tracing.NoopSpan)
+		fakeDelegate.FetchImageReturns(runtime.ImageSpec{ImageURL: "some-image"}, nil, nil)
+
+		fakeDelegateFactory = new(execfakes.FakeRunDelegateFactory)
+		fakeDelegateFactory.RunDelegateReturns(fakeDelegate)
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{"secret": "super-secret"}, false)
+		repo = state.ArtifactRepository()
+
+		runPlan = &atc.RunPlan{
+			Message: "some-message",
+			Type:    "some-prototype",
+			Object:  atc.Params{"some": "((secret))"},
+			TypeImage: atc.TypeImage{
+				BaseType: "registry-image",
+				GetPlan: &atc.Plan{
+					ID:  "42/image-get",
+					Get: &atc.GetPlan{Name: "some-prototype"},
+				},
+				CheckPlan: &atc.Plan{
+					ID:    "42/image-check",
+					Check: &atc.CheckPlan{Name: "some-prototype"},
+				},
+			},
+			Tags: atc.Tags{"some", "tags"},
+		}
+
+		chosenWorker = runtimetest.NewWorker("worker").
+			WithContainer(
+				expectedOwner,
+				runtimetest.NewContainer().WithProcess(
+					runtime.ProcessSpec{
+						ID:   "run",
+						Path: "/usr/bin/some-message",
+						Dir:  "/tmp/build/run",
+					},
+					runtimetest.ProcessStub{},
+				),
+				nil,
+			)
+		chosenContainer = chosenWorker.Containers[0]
+
+		fakePool = new(execfakes.FakePool)
+		fakePool.FindOrSelectWorkerReturns(chosenWorker, nil)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewRunStep(
+			planID,
+			*runPlan,
+			stepMetadata,
+			containerMetadata,
+			nil,
+			fakePool,
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("fetches the prototype's image", func() {
+		Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+		_, getPlan, checkPlan, privileged := fakeDelegate.FetchImageArgsForCall(0)
+		Expect(getPlan).To(Equal(*runPlan.TypeImage.GetPlan))
+		Expect(checkPlan).To(Equal(runPlan.TypeImage.CheckPlan))
+		Expect(privileged).To(BeFalse())
+
+		Expect(chosenContainer.Spec.ImageSpec).To(Equal(runtime.ImageSpec{ImageURL: "some-image"}))
+	})
+
+	It("selects a worker supporting the prototype's base type", func() {
+		Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(1))
+		_, owner, _, workerSpec, _, _ := fakePool.FindOrSelectWorkerArgsForCall(0)
+		Expect(owner).To(Equal(expectedOwner))
+		Expect(workerSpec.ResourceType).To(Equal("registry-image"))
+		Expect(workerSpec.Tags).To(Equal([]string{"some", "tags"}))
+		Expect(workerSpec.TeamID).To(Equal(stepMetadata.TeamID))
+	})
+
+	It("runs the message with the interpolated object on stdin", func() {
+		Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+
+		var request exec.RunRequest
+		err := json.NewDecoder(chosenContainer.RunningProcesses()[0].Stdin()).Decode(&request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(request).To(Equal(exec.RunRequest{
+			Object: atc.Params{"some": "super-secret"},
+		}))
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+
+	Context("when the plan is privileged", func() {
+		BeforeEach(func() {
+			runPlan.Privileged = true
+		})
+
+		It("runs a privileged container", func() {
+			Expect(chosenContainer.Spec.ImageSpec.Privileged).To(BeTrue())
+		})
+	})
+
+	Context("when the plan specifies container limits", func() {
+		BeforeEach(func() {
+			cpu := atc.CPULimit(1024)
+			memory := atc.MemoryLimit(2048)
+			runPlan.Limits = &atc.ContainerLimits{CPU: &cpu, Memory: &memory}
+		})
+
+		It("sets the limits on the container", func() {
+			Expect(*chosenContainer.Spec.Limits.CPU).To(Equal(uint64(1024)))
+			Expect(*chosenContainer.Spec.Limits.Memory).To(Equal(uint64(2048)))
+		})
+	})
+
+	Context("with inputs", func() {
+		var input, mappedInput *runtimetest.Volume
+
+		BeforeEach(func() {
+			runPlan.Inputs = []string{"some-input", "some-mapped-input"}
+			runPlan.InputMapping = map[string]string{"some-mapped-input": "some-source"}
+
+			input = runtimetest.NewVolume("input")
+			mappedInput = runtimetest.NewVolume("mapped-input")
+		})
+
+		Context("when the inputs are present", func() {
+			BeforeEach(func() {
+				repo.RegisterArtifact("some-input", input)
+				repo.RegisterArtifact("some-source", mappedInput)
+			})
+
+			It("mounts them into the working directory", func() {
+				Expect(chosenContainer.Spec.Inputs).To(ConsistOf([]runtime.Input{
+					{
+						Artifact:        input,
+						DestinationPath: "/tmp/build/run/some-input",
+					},
+					{
+						Artifact:        mappedInput,
+						DestinationPath: "/tmp/build/run/some-mapped-input",
+					},
+				}))
+			})
+		})
+
+		Context("when an input is missing", func() {
+			BeforeEach(func() {
+				repo.RegisterArtifact("some-input", input)
+			})
+
+			It("returns a MissingInputsError", func() {
+				Expect(stepErr).To(Equal(exec.MissingInputsError{Inputs: []string{"some-source"}}))
+				Expect(fakePool.FindOrSelectWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("with outputs", func() {
+		var output, mappedOutput *runtimetest.Volume
+
+		BeforeEach(func() {
+			runPlan.Outputs = []string{"some-output", "some-mapped-output"}
+			runPlan.OutputMapping = map[string]string{"some-mapped-output": "some-destination"}
+
+			output = runtimetest.NewVolume("output")
+			mappedOutput = runtimetest.NewVolume("mapped-output")
+
+			chosenContainer.Mounts = []runtime.VolumeMount{
+				{Volume: output, MountPath: "/tmp/build/run/some-output"},
+				{Volume: mappedOutput, MountPath: "/tmp/build/run/some-mapped-output"},
+			}
+		})
+
+		It("creates the container with the outputs", func() {
+			Expect(chosenContainer.Spec.Outputs).To(Equal(runtime.OutputPaths{
+				"some-output":        "/tmp/build/run/some-output/",
+				"some-mapped-output": "/tmp/build/run/some-mapped-output/",
+			}))
+		})
+
+		It("registers the outputs as artifacts", func() {
+			artifact, found := repo.ArtifactFor("some-output")
+			Expect(found).To(BeTrue())
+			Expect(artifact).To(Equal(output))
+
+			artifact, found = repo.ArtifactFor("some-destination")
+			Expect(found).To(BeTrue())
+			Expect(artifact).To(Equal(mappedOutput))
+		})
+	})
+
+	Context("when the message exits nonzero", func() {
+		BeforeEach(func() {
+			chosenContainer.ProcessDefs[0].Stub.ExitStatus = 1
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when the message times out", func() {
+		BeforeEach(func() {
+			runPlan.Timeout = "1ms"
+
+			chosenContainer.ProcessDefs[0].Stub.Do = func(ctx context.Context, _ *runtimetest.Process) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+		})
+
+		It("fails without erroring", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+
+		It("logs that the step timed out", func() {
+			Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+			_, message := fakeDelegate.ErroredArgsForCall(0)
+			Expect(message).To(Equal(exec.TimeoutLogMessage))
+		})
+	})
+})
@@ -81,7 +81,6 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 	}
 	step.plan = interpolatedPlan
 
-	stdout := delegate.Stdout()
 	stderr := delegate.Stderr()
 
 	if step.plan.Name == "self" {
@@ -109,27 +108,43 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, err
 	}
 
-	atcConfig, err := source.FetchPipelineConfig()
+	instanceVarsList, err := source.FetchInstanceVarsList()
 	if err != nil {
 		return false, err
 	}
 
-	delegate.Starting(logger)
+	var atcConfigs []atc.Config
+	for _, instanceVars := range instanceVarsList {
+		atcConfig, err := source.FetchPipelineConfig(instanceVars)
+		if err != nil {
+			return false, err
+		}
 
-	warnings, errors := configvalidate.Validate(atcConfig)
-	for _, warning := range warnings {
-		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+		atcConfigs = append(atcConfigs, atcConfig)
 	}
 
-	if len(errors) > 0 {
-		fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
+	delegate.Starting(logger)
+
+	for i, atcConfig := range atcConfigs {
+		if step.fanOut() {
+			fmt.Fprintf(stderr, "%s:\n", atc.PipelineRef{Name: step.plan.Name, InstanceVars: instanceVarsList[i]}.String())
+		}
 
-		for _, e := range errors {
-			fmt.Fprintf(stderr, "- %s", e)
+		warnings, errors := configvalidate.Validate(atcConfig)
+		for _, warning := range warnings {
+			fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
 		}
 
-		delegate.Finished(logger, false)
-		return false, nil
+		if len(errors) > 0 {
+			fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
+
+			for _, e := range errors {
+				fmt.Fprintf(stderr, "- %s", e)
+			}
+
+			delegate.Finished(logger, false)
+			return false, nil
+		}
 	}
 
 	var team db.Team
@@ -164,9 +179,17 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		if currentTeam.Admin() {
 			permitted = true
 		}
+		if !permitted {
+			granted, err := delegate.CheckSetPipelineAcrossTeamsPolicy(targetTeam.Name(), step.plan.Name)
+			if err != nil {
+				return false, err
+			}
+
+			permitted = granted
+		}
 		if !permitted {
 			return false, fmt.Errorf(
-				"only %s team can set another team's pipeline",
+				"only %s team or teams allowed by the operator can set another team's pipeline",
 				atc.DefaultTeamName,
 			)
 		}
@@ -174,10 +197,59 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		team = targetTeam
 	}
 
-	pipelineRef := atc.PipelineRef{
-		Name:         step.plan.Name,
-		InstanceVars: step.plan.InstanceVars,
+	changed := false
+	setByNewerBuild := false
+	for i, atcConfig := range atcConfigs {
+		pipelineRef := atc.PipelineRef{
+			Name:         step.plan.Name,
+			InstanceVars: instanceVarsList[i],
+		}
+
+		pipelineChanged, err := step.setPipeline(logger, delegate, team, pipelineRef, atcConfig)
+		if err == db.ErrSetByNewerBuild {
+			fmt.Fprintln(stderr, "\x1b[1;33mWARNING: the pipeline was not saved because it was already saved by a newer build\x1b[0m")
+			setByNewerBuild = true
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if pipelineChanged {
+			changed = true
+		}
+	}
+
+	if step.plan.PruneInstances {
+		// the instances listed by an older build may well be stale, so leave
+		// pruning to the newer build
+		if setByNewerBuild {
+			fmt.Fprintln(stderr, "\x1b[1;33mWARNING: not pruning instances because a newer build has set the pipeline\x1b[0m")
+		} else {
+			err = step.pruneInstances(logger, delegate, team, instanceVarsList)
+			if err != nil {
+				return false, err
+			}
+		}
 	}
+
+	delegate.SetPipelineChanged(logger, changed)
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
+
+func (step *SetPipelineStep) fanOut() bool {
+	return len(step.plan.InstanceVarsList) > 0 || step.plan.InstanceVarsFile != ""
+}
+
+// setPipeline saves the config to the pipeline instance if it differs from
+// the existing one, returning whether anything changed. In a dry run the diff
+// is shown but nothing is saved. db.ErrSetByNewerBuild is returned if a newer
+// build has already saved the pipeline.
+func (step *SetPipelineStep) setPipeline(logger lager.Logger, delegate SetPipelineStepDelegate, team db.Team, pipelineRef atc.PipelineRef, atcConfig atc.Config) (bool, error) {
+	stdout := delegate.Stdout()
+
 	pipeline, found, err := team.Pipeline(pipelineRef)
 	if err != nil {
 		return false, err
@@ -197,20 +269,22 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 
 	diffExists := existingConfig.Diff(stdout, atcConfig)
 	if !diffExists {
-		logger.Debug("no-diff")
+		logger.Debug("no-diff", lager.Data{"pipeline": pipelineRef.String()})
 
-		fmt.Fprintf(stdout, "no changes to apply.\n")
+		if step.fanOut() {
+			fmt.Fprintf(stdout, "no changes to apply to %s.\n", pipelineRef.String())
+		} else {
+			fmt.Fprintf(stdout, "no changes to apply.\n")
+		}
 
-		if found {
+		if found && !step.plan.DryRun {
 			err := pipeline.SetParentIDs(step.metadata.JobID, step.metadata.BuildID)
 			if err != nil {
 				return false, err
 			}
 		}
 
-		delegate.SetPipelineChanged(logger, false)
-		delegate.Finished(logger, true)
-		return true, nil
+		return false, nil
 	}
 
 	err = delegate.CheckRunSetPipelinePolicy(&atcConfig)
@@ -218,8 +292,12 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, err
 	}
 
+	if step.plan.DryRun {
+		fmt.Fprintf(stdout, "dry run: not setting pipeline: %s\n", pipelineRef.String())
+		return false, nil
+	}
+
 	fmt.Fprintf(stdout, "setting pipeline: %s\n", pipelineRef.String())
-	delegate.SetPipelineChanged(logger, true)
 
 	parentBuild, found, err := step.buildFactory.Build(step.metadata.BuildID)
 	if err != nil {
@@ -232,21 +310,64 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 
 	pipeline, _, err = parentBuild.SavePipeline(pipelineRef, team.ID(), atcConfig, fromVersion, false)
 	if err != nil {
-		if err == db.ErrSetByNewerBuild {
-			fmt.Fprintln(stderr, "\x1b[1;33mWARNING: the pipeline was not saved because it was already saved by a newer build\x1b[0m")
-			delegate.Finished(logger, true)
-			return true, nil
-		}
 		return false, err
 	}
 
 	fmt.Fprintf(stdout, "done\n")
 	logger.Info("saved-pipeline", lager.Data{"team": team.Name(), "pipeline": pipeline.Name()})
-	delegate.Finished(logger, true)
 
 	return true, nil
 }
 
+// pruneInstances archives instances of the pipeline that were set by this
+// job but are no longer listed.
+func (step *SetPipelineStep) pruneInstances(logger lager.Logger, delegate SetPipelineStepDelegate, team db.Team, instanceVarsList []atc.InstanceVars) error {
+	listed := map[string]bool{}
+	for _, instanceVars := range instanceVarsList {
+		listed[instanceVars.String()] = true
+	}
+
+	pipelines, err := team.Pipelines()
+	if err != nil {
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		if pipeline.Name() != step.plan.Name || pipeline.Archived() {
+			continue
+		}
+
+		if pipeline.ParentJobID() != step.metadata.JobID {
+			continue
+		}
+
+		if listed[pipeline.InstanceVars().String()] {
+			continue
+		}
+
+		pipelineRef := atc.PipelineRef{
+			Name:         pipeline.Name(),
+			InstanceVars: pipeline.InstanceVars(),
+		}
+
+		if step.plan.DryRun {
+			fmt.Fprintf(delegate.Stdout(), "dry run: not archiving pipeline: %s\n", pipelineRef.String())
+			continue
+		}
+
+		fmt.Fprintf(delegate.Stdout(), "archiving pipeline: %s\n", pipelineRef.String())
+
+		err = pipeline.Archive()
+		if err != nil {
+			return err
+		}
+
+		logger.Info("archived-pipeline", lager.Data{"team": team.Name(), "pipeline": pipelineRef.String()})
+	}
+
+	return nil
+}
+
 type setPipelineSource struct {
 	ctx      context.Context
 	logger   lager.Logger
@@ -260,16 +381,51 @@ func (s setPipelineSource) Validate() error {
 		return errors.New("file is not specified")
 	}
 
-	if !atc.EnablePipelineInstances && s.step.plan.InstanceVars != nil {
+	if !atc.EnablePipelineInstances && (s.step.plan.InstanceVars != nil || s.step.fanOut()) {
 		return errors.New("support for `instance_vars` is disabled")
 	}
 
 	return nil
 }
 
+// FetchInstanceVarsList returns the sets of instance vars to set the pipeline
+// with: one per entry when the step fans out, or just the step's own
+// instance_vars otherwise.
+func (s setPipelineSource) FetchInstanceVarsList() ([]atc.InstanceVars, error) {
+	if s.step.plan.InstanceVarsFile != "" {
+		bytes, err := s.fetchPipelineBits(s.step.plan.InstanceVarsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var instanceVarsList []atc.InstanceVars
+		err = yaml.Unmarshal(bytes, &instanceVarsList)
+		if err != nil {
+			return nil, fmt.Errorf("malformed instance vars file: %w", err)
+		}
+
+		if len(instanceVarsList) == 0 {
+			return nil, errors.New("instance vars file does not list any instances")
+		}
+
+		return instanceVarsList, nil
+	}
+
+	if len(s.step.plan.InstanceVarsList) > 0 {
+		var instanceVarsList []atc.InstanceVars
+		for _, instanceVars := range s.step.plan.InstanceVarsList {
+			instanceVarsList = append(instanceVarsList, instanceVars)
+		}
+
+		return instanceVarsList, nil
+	}
+
+	return []atc.InstanceVars{s.step.plan.InstanceVars}, nil
+}
+
 // FetchConfig streams pipeline config file and var files from other resources
 // and construct an atc.Config object
-func (s setPipelineSource) FetchPipelineConfig() (atc.Config, error) {
+func (s setPipelineSource) FetchPipelineConfig(instanceVars atc.InstanceVars) (atc.Config, error) {
 	config, err := s.fetchPipelineBits(s.step.plan.File)
 	if err != nil {
 		return atc.Config{}, err
@@ -294,9 +450,9 @@ func (s setPipelineSource) FetchPipelineConfig() (atc.Config, error) {
 		staticVars = append(staticVars, sv)
 	}
 
-	if len(s.step.plan.InstanceVars) > 0 {
+	if len(instanceVars) > 0 {
 		iv := vars.StaticVariables{}
-		for k, v := range s.step.plan.InstanceVars {
+		for k, v := range instanceVars {
 			iv[k] = v
 		}
 		staticVars = append(staticVars, iv)
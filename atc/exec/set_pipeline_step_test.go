@@ -18,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
@@ -337,6 +338,16 @@ jobs:
 						Expect(jobID).To(Equal(stepMetadata.JobID))
 						Expect(buildID).To(Equal(stepMetadata.BuildID))
 					})
+
+					Context("when doing a dry run", func() {
+						BeforeEach(func() {
+							spPlan.DryRun = true
+						})
+
+						It("should not update the job and build id", func() {
+							Expect(fakePipeline.SetParentIDsCallCount()).To(BeZero())
+						})
+					})
 				})
 
 				Context("when there are some diff", func() {
@@ -354,6 +365,35 @@ jobs:
 						_, changed := fakeDelegate.SetPipelineChangedArgsForCall(0)
 						Expect(changed).To(BeTrue())
 					})
+
+					Context("when doing a dry run", func() {
+						BeforeEach(func() {
+							spPlan.DryRun = true
+						})
+
+						It("should log diff", func() {
+							Expect(stdout).To(gbytes.Say("job some-job has changed:"))
+							Expect(stdout).To(gbytes.Say(`dry run: not setting pipeline: some-pipeline/branch:"feature/foo"`))
+						})
+
+						It("should still check the policy", func() {
+							Expect(fakeDelegate.CheckRunSetPipelinePolicyCallCount()).To(Equal(1))
+						})
+
+						It("should not save the pipeline", func() {
+							Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+						})
+
+						It("should send an unchanged set pipeline changed event", func() {
+							Expect(fakeDelegate.SetPipelineChangedCallCount()).To(Equal(1))
+							_, changed := fakeDelegate.SetPipelineChangedArgsForCall(0)
+							Expect(changed).To(BeFalse())
+						})
+
+						It("should finish successfully", func() {
+							Expect(stepOk).To(BeTrue())
+						})
+					})
 				})
 
 				Context("when policy check fails", func() {
@@ -388,6 +428,11 @@ jobs:
 							Expect(stepErr).ToNot(HaveOccurred())
 							Expect(stepOk).To(BeTrue())
 						})
+						It("does not report a change", func() {
+							Expect(fakeDelegate.SetPipelineChangedCallCount()).To(Equal(1))
+							_, changed := fakeDelegate.SetPipelineChangedArgsForCall(0)
+							Expect(changed).To(BeFalse())
+						})
 					})
 				})
 
@@ -413,6 +458,182 @@ jobs:
 				})
 			})
 
+			Context("when fanning out over a list of instance vars", func() {
+				BeforeEach(func() {
+					spPlan = &atc.SetPipelinePlan{
+						Name: "some-pipeline",
+						File: "some-resource/pipeline.yml",
+						InstanceVarsList: []map[string]interface{}{
+							{"branch": "feature/foo"},
+							{"branch": "feature/bar"},
+						},
+					}
+
+					fakeStreamer.StreamFileStub = func(context.Context, runtime.Artifact, string) (io.ReadCloser, error) {
+						return &fakeReadCloser{str: pipelineContent}, nil
+					}
+
+					fakeTeam.PipelineReturns(nil, false, nil)
+					fakeBuild.SavePipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("saves a pipeline instance for each set of instance vars", func() {
+					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(2))
+
+					ref, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+					Expect(ref).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+					}))
+
+					ref, _, _, _, _ = fakeBuild.SavePipelineArgsForCall(1)
+					Expect(ref).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "feature/bar"},
+					}))
+				})
+
+				It("sends a single set pipeline changed event", func() {
+					Expect(fakeDelegate.SetPipelineChangedCallCount()).To(Equal(1))
+					_, changed := fakeDelegate.SetPipelineChangedArgsForCall(0)
+					Expect(changed).To(BeTrue())
+				})
+
+				It("does not prune other instances", func() {
+					Expect(fakeTeam.PipelinesCallCount()).To(BeZero())
+				})
+
+				Context("when pruning instances", func() {
+					var listedPipeline, unlistedPipeline, otherJobPipeline *dbfakes.FakePipeline
+
+					BeforeEach(func() {
+						spPlan.PruneInstances = true
+						stepMetadata.JobID = 87
+
+						listedPipeline = new(dbfakes.FakePipeline)
+						listedPipeline.NameReturns("some-pipeline")
+						listedPipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "feature/foo"})
+						listedPipeline.ParentJobIDReturns(87)
+
+						unlistedPipeline = new(dbfakes.FakePipeline)
+						unlistedPipeline.NameReturns("some-pipeline")
+						unlistedPipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "feature/gone"})
+						unlistedPipeline.ParentJobIDReturns(87)
+
+						otherJobPipeline = new(dbfakes.FakePipeline)
+						otherJobPipeline.NameReturns("some-pipeline")
+						otherJobPipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "manual"})
+						otherJobPipeline.ParentJobIDReturns(0)
+
+						fakeTeam.PipelinesReturns([]db.Pipeline{listedPipeline, unlistedPipeline, otherJobPipeline}, nil)
+					})
+
+					It("archives only the unlisted instances set by this job", func() {
+						Expect(listedPipeline.ArchiveCallCount()).To(BeZero())
+						Expect(unlistedPipeline.ArchiveCallCount()).To(Equal(1))
+						Expect(otherJobPipeline.ArchiveCallCount()).To(BeZero())
+					})
+
+					It("logs the archived instance", func() {
+						Expect(stdout).To(gbytes.Say(`archiving pipeline: some-pipeline/branch:"feature/gone"`))
+					})
+
+					Context("when doing a dry run", func() {
+						BeforeEach(func() {
+							spPlan.DryRun = true
+						})
+
+						It("does not archive anything", func() {
+							Expect(unlistedPipeline.ArchiveCallCount()).To(BeZero())
+							Expect(stdout).To(gbytes.Say(`dry run: not archiving pipeline: some-pipeline/branch:"feature/gone"`))
+						})
+					})
+
+					Context("when a newer build has set the pipeline", func() {
+						BeforeEach(func() {
+							fakeBuild.SavePipelineReturns(nil, false, db.ErrSetByNewerBuild)
+						})
+
+						It("does not archive anything", func() {
+							Expect(fakeTeam.PipelinesCallCount()).To(BeZero())
+							Expect(unlistedPipeline.ArchiveCallCount()).To(BeZero())
+						})
+
+						It("logs a warning", func() {
+							Expect(stderr).To(gbytes.Say("WARNING: not pruning instances because a newer build has set the pipeline"))
+						})
+
+						It("does not report a change", func() {
+							Expect(fakeDelegate.SetPipelineChangedCallCount()).To(Equal(1))
+							_, changed := fakeDelegate.SetPipelineChangedArgsForCall(0)
+							Expect(changed).To(BeFalse())
+						})
+
+						It("does not fail the step", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(stepOk).To(BeTrue())
+						})
+					})
+
+					Context("when archiving fails", func() {
+						BeforeEach(func() {
+							unlistedPipeline.ArchiveReturns(errors.New("nope"))
+						})
+
+						It("returns the error", func() {
+							Expect(stepErr).To(MatchError("nope"))
+						})
+					})
+				})
+			})
+
+			Context("when fanning out over an instance vars file", func() {
+				BeforeEach(func() {
+					spPlan = &atc.SetPipelinePlan{
+						Name:             "some-pipeline",
+						File:             "some-resource/pipeline.yml",
+						InstanceVarsFile: "some-resource/instances.yml",
+					}
+
+					fakeStreamer.StreamFileStub = func(_ context.Context, _ runtime.Artifact, path string) (io.ReadCloser, error) {
+						if path == "instances.yml" {
+							return &fakeReadCloser{str: "- branch: feature/foo\n- branch: feature/bar\n"}, nil
+						}
+
+						return &fakeReadCloser{str: pipelineContent}, nil
+					}
+
+					fakeTeam.PipelineReturns(nil, false, nil)
+					fakeBuild.SavePipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("saves a pipeline instance for each entry in the file", func() {
+					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(2))
+
+					ref, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(1)
+					Expect(ref).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "feature/bar"},
+					}))
+				})
+
+				Context("when the file does not list any instances", func() {
+					BeforeEach(func() {
+						fakeStreamer.StreamFileStub = func(_ context.Context, _ runtime.Artifact, path string) (io.ReadCloser, error) {
+							if path == "instances.yml" {
+								return &fakeReadCloser{str: "[]"}, nil
+							}
+
+							return &fakeReadCloser{str: pipelineContent}, nil
+						}
+					})
+
+					It("returns an error", func() {
+						Expect(stepErr).To(MatchError("instance vars file does not list any instances"))
+					})
+				})
+			})
+
 			Context("when set-pipeline self", func() {
 				BeforeEach(func() {
 					spPlan = &atc.SetPipelinePlan{
@@ -549,13 +770,46 @@ jobs:
 						})
 
 						Context("when the current team is not an admin team", func() {
-							It("should return error", func() {
+							It("should check for a policy grant", func() {
+								Expect(fakeDelegate.CheckSetPipelineAcrossTeamsPolicyCallCount()).To(Equal(1))
+								targetTeam, pipelineName := fakeDelegate.CheckSetPipelineAcrossTeamsPolicyArgsForCall(0)
+								Expect(targetTeam).To(Equal(fakeTeam.Name()))
+								Expect(pipelineName).To(Equal("some-pipeline"))
+							})
 
+							It("should return error", func() {
 								Expect(stepErr).To(HaveOccurred())
 								Expect(stepErr.Error()).To(Equal(
-									"only main team can set another team's pipeline",
+									"only main team or teams allowed by the operator can set another team's pipeline",
 								))
 							})
+
+							Context("when the policy grants the current team", func() {
+								BeforeEach(func() {
+									fakeDelegate.CheckSetPipelineAcrossTeamsPolicyReturns(true, nil)
+
+									fakeBuild.PipelineReturns(fakePipeline, true, nil)
+									fakeBuild.SavePipelineReturns(fakePipeline, false, nil)
+								})
+
+								It("should save the pipeline to the target team", func() {
+									_, teamID, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+									Expect(teamID).To(Equal(fakeTeam.ID()))
+									_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+									Expect(succeeded).To(BeTrue())
+								})
+							})
+
+							Context("when the policy check fails", func() {
+								BeforeEach(func() {
+									fakeDelegate.CheckSetPipelineAcrossTeamsPolicyReturns(false, errors.New("policy-check-error"))
+								})
+
+								It("should return the error", func() {
+									Expect(stepErr).To(MatchError("policy-check-error"))
+									Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+								})
+							})
 						})
 					})
 				})
@@ -44,10 +44,14 @@ type RunState interface {
 
 	NewLocalScope() RunState
 	AddLocalVar(name string, val interface{}, redact bool)
+	AddLocalVarField(name string, field string, val interface{})
 
 	IterateInterpolatedCreds(vars.TrackedVarsIterator)
 	RedactionEnabled() bool
 
+	OutputPolicy() OutputPolicy
+	WithOutputPolicy(OutputPolicy) RunState
+
 	ArtifactRepository() *build.Repository
 
 	Result(atc.PlanID, interface{}) bool
@@ -83,4 +87,5 @@ type Pool interface {
 
 type Streamer interface {
 	StreamFile(ctx context.Context, artifact runtime.Artifact, path string) (io.ReadCloser, error)
+	StreamDir(ctx context.Context, artifact runtime.Artifact, path string) (map[string][]byte, error)
 }
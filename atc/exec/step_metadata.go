@@ -3,8 +3,27 @@ package exec
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
+// StepMetadataEnv are the names of the environment variables through which
+// the metadata of a build is exposed to the containers of its get, put,
+// check, run and task steps. Variables for metadata which does not apply,
+// e.g. the job of a one-off build, are omitted.
+var StepMetadataEnv = []string{
+	"BUILD_ID",
+	"BUILD_NAME",
+	"BUILD_TEAM_ID",
+	"BUILD_TEAM_NAME",
+	"BUILD_JOB_ID",
+	"BUILD_JOB_NAME",
+	"BUILD_PIPELINE_ID",
+	"BUILD_PIPELINE_NAME",
+	"BUILD_PIPELINE_INSTANCE_VARS",
+	"ATC_EXTERNAL_URL",
+	"BUILD_CREATED_BY",
+}
+
 type StepMetadata struct {
 	BuildID              int
 	BuildName            string
@@ -17,8 +36,14 @@ type StepMetadata struct {
 	PipelineInstanceVars map[string]interface{}
 	ExternalURL          string
 	CreatedBy            string
+
+	// EnvAllowlist restricts the variables returned by Env to the given
+	// names. All of them are returned if it is empty.
+	EnvAllowlist []string
 }
 
+// Env returns the metadata as the environment variables listed in
+// StepMetadataEnv.
 func (metadata StepMetadata) Env() []string {
 	env := []string{}
 
@@ -67,5 +92,25 @@ func (metadata StepMetadata) Env() []string {
 		env = append(env, "BUILD_CREATED_BY="+metadata.CreatedBy)
 	}
 
-	return env
+	if len(metadata.EnvAllowlist) == 0 {
+		return env
+	}
+
+	allowed := map[string]bool{}
+	for _, name := range metadata.EnvAllowlist {
+		allowed[name] = true
+	}
+
+	filtered := []string{}
+	for _, v := range env {
+		if allowed[envName(v)] {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered
+}
+
+func envName(v string) string {
+	return strings.SplitN(v, "=", 2)[0]
 }
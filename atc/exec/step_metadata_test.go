@@ -55,5 +55,24 @@ var _ = Describe("StepMetadata", func() {
 				}))
 			})
 		})
+
+		Context("when an allowlist is set", func() {
+			BeforeEach(func() {
+				stepMetadata = exec.StepMetadata{
+					BuildID:              1,
+					TeamName:             "some-team",
+					PipelineName:         "some-pipeline-name",
+					PipelineInstanceVars: map[string]interface{}{"branch": "main"},
+					EnvAllowlist:         []string{"BUILD_TEAM_NAME", "BUILD_PIPELINE_INSTANCE_VARS", "BUILD_JOB_NAME"},
+				}
+			})
+
+			It("only includes the allowed fields", func() {
+				Expect(stepMetadata.Env()).To(Equal([]string{
+					"BUILD_TEAM_NAME=some-team",
+					`BUILD_PIPELINE_INSTANCE_VARS={"branch":"main"}`,
+				}))
+			})
+		})
 	})
 })
@@ -164,6 +164,33 @@ func (configSource *OverrideContainerLimitsSource) Warnings() []string {
 
 var _ TaskConfigSource = &OverrideContainerLimitsSource{}
 
+// OverrideUserConfigSource is used to override the user to run a task as.
+type OverrideUserConfigSource struct {
+	ConfigSource TaskConfigSource
+	User         string
+}
+
+// FetchConfig overrides the user, allowing the user of an image to be replaced
+// without having to rebuild it or change the task config.
+func (configSource *OverrideUserConfigSource) FetchConfig(ctx context.Context, logger lager.Logger, source *build.Repository) (atc.TaskConfig, error) {
+	taskConfig, err := configSource.ConfigSource.FetchConfig(ctx, logger, source)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if configSource.User != "" {
+		taskConfig.Run.User = configSource.User
+	}
+
+	return taskConfig, nil
+}
+
+func (configSource *OverrideUserConfigSource) Warnings() []string {
+	return configSource.ConfigSource.Warnings()
+}
+
+var _ TaskConfigSource = &OverrideUserConfigSource{}
+
 // OverrideParamsConfigSource is used to override params in a config source
 type OverrideParamsConfigSource struct {
 	ConfigSource TaskConfigSource
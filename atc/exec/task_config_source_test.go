@@ -424,6 +424,59 @@ run: {path: a/file}
 		})
 	})
 
+	Describe("OverrideUserConfigSource", func() {
+		var (
+			config       atc.TaskConfig
+			configSource TaskConfigSource
+
+			overrideUser string
+
+			fetchedConfig atc.TaskConfig
+			fetchErr      error
+		)
+
+		BeforeEach(func() {
+			config = atc.TaskConfig{
+				Platform:  "some-platform",
+				RootfsURI: "some-image",
+				Run: atc.TaskRunConfig{
+					Path: "echo",
+					Args: []string{"bananapants"},
+					User: "root",
+				},
+			}
+
+			overrideUser = ""
+		})
+
+		JustBeforeEach(func() {
+			configSource = &OverrideUserConfigSource{
+				ConfigSource: StaticConfigSource{Config: &config},
+				User:         overrideUser,
+			}
+
+			fetchedConfig, fetchErr = configSource.FetchConfig(context.TODO(), logger, repo)
+		})
+
+		Context("when there is no user to override", func() {
+			It("returns the same config", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchedConfig).To(Equal(config))
+			})
+		})
+
+		Context("when a user is specified", func() {
+			BeforeEach(func() {
+				overrideUser = "nobody"
+			})
+
+			It("returns the config with the overridden user", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchedConfig.Run.User).To(Equal("nobody"))
+			})
+		})
+	})
+
 	Describe("ValidatingConfigSource", func() {
 		var (
 			fakeConfigSource *execfakes.FakeTaskConfigSource
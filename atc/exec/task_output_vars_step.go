@@ -0,0 +1,49 @@
+package exec
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// TaskOutputVarsStep loads the output vars of a task from the outputs it
+// registered and sets them as build-local vars. It restores the output vars of
+// a task which is replayed rather than run again.
+type TaskOutputVarsStep struct {
+	planID     atc.PlanID
+	plan       atc.TaskPlan
+	outputVars []atc.TaskOutputVarConfig
+	streamer   Streamer
+}
+
+func NewTaskOutputVarsStep(
+	planID atc.PlanID,
+	plan atc.TaskPlan,
+	outputVars []atc.TaskOutputVarConfig,
+	streamer Streamer,
+) Step {
+	return &TaskOutputVarsStep{
+		planID:     planID,
+		plan:       plan,
+		outputVars: outputVars,
+		streamer:   streamer,
+	}
+}
+
+func (step *TaskOutputVarsStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("task-output-vars-step", lager.Data{
+		"plan-id": step.planID,
+	})
+
+	for _, outputVar := range step.outputVars {
+		err := loadTaskOutputVar(ctx, logger, step.streamer, state, outputVar, step.plan.OutputMapping)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,87 @@
+package exec_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+)
+
+var _ = Describe("TaskOutputVarsStep", func() {
+	var (
+		ctx context.Context
+
+		fakeStreamer       *execfakes.FakeStreamer
+		artifactRepository *build.Repository
+		state              *execfakes.FakeRunState
+
+		taskPlan   atc.TaskPlan
+		outputVars []atc.TaskOutputVarConfig
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("task-output-vars-step-test"))
+
+		fakeStreamer = new(execfakes.FakeStreamer)
+		fakeStreamer.StreamFileReturns(&fakeReadCloser{str: plainString}, nil)
+
+		artifactRepository = build.NewRepository()
+		artifactRepository.RegisterArtifact("mapped-output", runtimetest.NewVolume("some-volume"))
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(artifactRepository)
+
+		taskPlan = atc.TaskPlan{
+			Name:          "some-task",
+			OutputMapping: map[string]string{"some-output": "mapped-output"},
+		}
+
+		outputVars = []atc.TaskOutputVarConfig{
+			{Name: "some-var", File: "some-output/some-file", Format: "trim", Reveal: true},
+		}
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewTaskOutputVarsStep("some-plan-id", taskPlan, outputVars, fakeStreamer)
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("loads each output var from the mapped output", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		Expect(fakeStreamer.StreamFileCallCount()).To(Equal(1))
+		_, artifact, path := fakeStreamer.StreamFileArgsForCall(0)
+		Expect(artifact).To(Equal(runtimetest.NewVolume("some-volume")))
+		Expect(path).To(Equal("some-file"))
+
+		Expect(state.AddLocalVarCallCount()).To(Equal(1))
+		name, value, redact := state.AddLocalVarArgsForCall(0)
+		Expect(name).To(Equal("some-var"))
+		Expect(value).To(Equal("pv"))
+		Expect(redact).To(BeFalse())
+	})
+
+	Context("when the output is not registered", func() {
+		BeforeEach(func() {
+			taskPlan.OutputMapping = nil
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+			Expect(state.AddLocalVarCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,136 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+const sidecarProcessIDPrefix = "sidecar-"
+
+// SidecarOutputLimit is how much of each sidecar's output is kept to be
+// written once the task is done. Anything beyond it is dropped.
+var SidecarOutputLimit = 1024 * 1024
+
+// sidecarOutput collects the stdout and stderr of a sidecar, which may be
+// written to concurrently, keeping at most SidecarOutputLimit bytes.
+type sidecarOutput struct {
+	lock      sync.Mutex
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (output *sidecarOutput) Write(p []byte) (int, error) {
+	output.lock.Lock()
+	defer output.lock.Unlock()
+
+	remaining := SidecarOutputLimit - output.buf.Len()
+	if len(p) > remaining {
+		output.truncated = true
+		if remaining > 0 {
+			output.buf.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+
+	return output.buf.Write(p)
+}
+
+type sidecarResult struct {
+	exited     bool
+	exitStatus int
+}
+
+// startSidecars runs each of the task's sidecars in the container. Their
+// output is collected rather than streamed, so that it does not interleave
+// with the output of the script, and is capped at SidecarOutputLimit.
+//
+// The returned function stops the sidecars and writes their output to the
+// given writer. Stopping the sidecars stops the container, so it must only be
+// called once the script is done.
+func (step *TaskStep) startSidecars(
+	ctx context.Context,
+	logger lager.Logger,
+	container runtime.Container,
+	config atc.TaskConfig,
+) (func(io.Writer), error) {
+	if len(config.Sidecars) == 0 {
+		return func(io.Writer) {}, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	outputs := make([]*sidecarOutput, len(config.Sidecars))
+	results := make([]sidecarResult, len(config.Sidecars))
+
+	stop := func(w io.Writer) {
+		cancel()
+		wg.Wait()
+
+		for i, sidecar := range config.Sidecars {
+			if outputs[i] == nil {
+				continue
+			}
+
+			fmt.Fprintf(w, "\x1b[1msidecar %s:\x1b[0m\n", sidecar.Name)
+			outputs[i].buf.WriteTo(w)
+
+			if outputs[i].truncated {
+				fmt.Fprintf(w, "\n\x1b[1msidecar %s output truncated to %d bytes\x1b[0m\n", sidecar.Name, SidecarOutputLimit)
+			}
+
+			if results[i].exited {
+				fmt.Fprintf(w, "sidecar %s exited with status %d\n", sidecar.Name, results[i].exitStatus)
+			}
+		}
+	}
+
+	for i, sidecar := range config.Sidecars {
+		outputs[i] = new(sidecarOutput)
+
+		process, err := attachOrRun(
+			ctx,
+			container,
+			runtime.ProcessSpec{
+				ID:   sidecarProcessIDPrefix + sidecar.Name,
+				Path: sidecar.Run.Path,
+				Args: sidecar.Run.Args,
+				Dir:  resolvePath(step.containerMetadata.WorkingDirectory, sidecar.Run.Dir),
+				User: sidecar.Run.User,
+			},
+			runtime.ProcessIO{
+				Stdout: outputs[i],
+				Stderr: outputs[i],
+			},
+		)
+		if err != nil {
+			stop(io.Discard)
+			return nil, fmt.Errorf("start sidecar %s: %w", sidecar.Name, err)
+		}
+
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			result, err := process.Wait(ctx)
+			if err != nil {
+				// stopping the sidecar once the script is done cancels the wait
+				if ctx.Err() == nil {
+					logger.Error("failed-to-wait-for-sidecar", err, lager.Data{"sidecar": name})
+				}
+				return
+			}
+
+			results[i] = sidecarResult{exited: true, exitStatus: result.ExitStatus}
+		}(i, sidecar.Name)
+	}
+
+	return stop, nil
+}
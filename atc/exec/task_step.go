@@ -5,16 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/archiver"
+	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/scanner"
+	"github.com/concourse/concourse/atc/testreport"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
@@ -23,6 +29,10 @@ import (
 
 const taskProcessID = "task"
 
+// ErrArchiveNotConfigured is returned when a task step archives its outputs
+// but no artifact archive has been configured.
+var ErrArchiveNotConfigured = errors.New("archive_outputs is set but no artifact archive is configured")
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -52,6 +62,24 @@ func (err TaskImageSourceParametersError) Error() string {
 	return fmt.Sprintf("failed to evaluate image resource parameters: %s", err.Err)
 }
 
+// VolumeQuotaExceededError is returned when a task fails having filled up
+// the disk quota of one of its outputs or caches, which is most likely why it
+// failed.
+type VolumeQuotaExceededError struct {
+	Kind  string
+	Name  string
+	Quota uint64
+}
+
+func (err VolumeQuotaExceededError) Error() string {
+	return fmt.Sprintf("%s '%s' exceeded its disk quota of %d bytes", err.Kind, err.Name, err.Quota)
+}
+
+// quotaHeadroom is how close to its quota a volume has to be to count as
+// full. writes fail when they would take a volume over its quota, so a
+// volume which ran out of space is usually still a little under it.
+const quotaHeadroom = 1024 * 1024
+
 //counterfeiter:generate . TaskDelegateFactory
 type TaskDelegateFactory interface {
 	TaskDelegate(state RunState) TaskDelegate
@@ -68,6 +96,10 @@ type TaskDelegate interface {
 
 	SetTaskConfig(config atc.TaskConfig)
 
+	SaveTestResults(context.Context, lager.Logger, string, []atc.TestResult) error
+	ArtifactArchived(lager.Logger, string, string)
+	ArtifactScanned(lager.Logger, scanner.Report)
+
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
 	Finished(lager.Logger, ExitStatus)
@@ -75,6 +107,7 @@ type TaskDelegate interface {
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+	PhaseFinished(lager.Logger, StepPhase, time.Duration)
 }
 
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
@@ -89,6 +122,9 @@ type TaskStep struct {
 	workerPool        Pool
 	streamer          Streamer
 	delegateFactory   TaskDelegateFactory
+	archiver          archiver.Archiver
+	scanner           scanner.Scanner
+	scanAction        scanner.Action
 }
 
 func NewTaskStep(
@@ -101,6 +137,9 @@ func NewTaskStep(
 	workerPool Pool,
 	streamer Streamer,
 	delegateFactory TaskDelegateFactory,
+	artifactArchiver archiver.Archiver,
+	artifactScanner scanner.Scanner,
+	scanAction scanner.Action,
 ) Step {
 	return &TaskStep{
 		planID:            planID,
@@ -112,6 +151,9 @@ func NewTaskStep(
 		workerPool:        workerPool,
 		streamer:          streamer,
 		delegateFactory:   delegateFactory,
+		archiver:          artifactArchiver,
+		scanner:           artifactScanner,
+		scanAction:        scanAction,
 	}
 }
 
@@ -186,6 +228,9 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	// override params
 	taskConfigSource = &OverrideParamsConfigSource{ConfigSource: taskConfigSource, Params: step.plan.Params}
 
+	// override user
+	taskConfigSource = &OverrideUserConfigSource{ConfigSource: taskConfigSource, User: step.plan.User}
+
 	// interpolate template vars
 	taskConfigSource = InterpolateTemplateConfigSource{
 		ConfigSource:  taskConfigSource,
@@ -235,6 +280,7 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
 
+	finishWaiting := startPhase(logger, delegate, StepPhaseWaitingForWorker)
 	worker, err := step.workerPool.FindOrSelectWorker(
 		ctx,
 		owner,
@@ -246,6 +292,7 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	if err != nil {
 		return false, err
 	}
+	finishWaiting()
 
 	defer func() {
 		step.workerPool.ReleaseWorker(
@@ -270,12 +317,21 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	delegate.SelectedWorker(logger, worker.Name())
 
+	finishCreating := startPhase(logger, delegate, StepPhaseCreatingContainer)
 	container, volumeMounts, err := worker.FindOrCreateContainer(ctx, owner, step.containerMetadata, containerSpec)
 	if err != nil {
 		return false, err
 	}
+	finishCreating()
 
 	delegate.Starting(logger)
+	finishRunning := startPhase(logger, delegate, StepPhaseRunning)
+
+	stopSidecars, err := step.startSidecars(ctx, logger, container, config)
+	if err != nil {
+		return false, err
+	}
+
 	process, err := attachOrRun(
 		ctx,
 		container,
@@ -300,13 +356,33 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		},
 	)
 	if err != nil {
+		stopSidecars(delegate.Stderr())
 		return false, err
 	}
 
 	result, runErr := process.Wait(ctx)
+	finishRunning()
+
+	stopSidecars(delegate.Stderr())
+
+	if runErr == nil && step.scanner != nil {
+		if err := step.scanOutputs(ctx, logger, config, volumeMounts, step.containerMetadata, delegate); err != nil {
+			return false, err
+		}
+	}
 
 	step.registerOutputs(logger, repository, config, volumeMounts, step.containerMetadata)
 
+	if runErr == nil && len(step.plan.TestReports) > 0 {
+		step.reportTestResults(ctx, logger, repository, delegate)
+	}
+
+	if runErr == nil && step.plan.ArchiveOutputs {
+		if err := step.archiveOutputs(ctx, logger, repository, config, delegate); err != nil {
+			return false, err
+		}
+	}
+
 	// Do not initialize caches for one-off builds
 	if step.metadata.JobID != 0 {
 		if err := step.registerCaches(logger, repository, config, volumeMounts, step.containerMetadata); err != nil {
@@ -317,12 +393,25 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	if runErr != nil {
 		if errors.Is(runErr, context.DeadlineExceeded) {
 			delegate.Errored(logger, TimeoutLogMessage)
+			recordTimeout(ctx)
 			return false, nil
 		}
 
 		return false, runErr
 	}
 
+	if result.ExitStatus != 0 {
+		if err := step.checkVolumeQuotas(ctx, logger, config, volumeMounts, step.containerMetadata); err != nil {
+			return false, err
+		}
+	}
+
+	if result.ExitStatus == 0 {
+		if err := step.registerOutputVars(ctx, logger, state, config, delegate); err != nil {
+			return false, err
+		}
+	}
+
 	delegate.Finished(logger, ExitStatus(result.ExitStatus))
 	return result.ExitStatus == 0, nil
 }
@@ -360,6 +449,10 @@ func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state
 		)
 		return imageSpec, err
 
+		// an image pulled from a registry by digest
+	} else if config.Image != nil {
+		imageSpec.RegistryImage = config.Image
+
 		// a rootfs_uri
 	} else if config.RootfsURI != "" {
 		imageSpec.ImageURL = config.RootfsURI
@@ -399,18 +492,36 @@ func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Rep
 	return inputs, nil
 }
 
+// taskEnv exposes the step metadata alongside the task's params, which take
+// precedence over metadata of the same name.
+func taskEnv(metadata StepMetadata, params atc.TaskEnv) []string {
+	env := []string{}
+	for _, v := range metadata.Env() {
+		if _, found := params[envName(v)]; !found {
+			env = append(env, v)
+		}
+	}
+
+	return append(env, params.Env()...)
+}
+
 func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSpec runtime.ImageSpec, config atc.TaskConfig, metadata db.ContainerMetadata) (runtime.ContainerSpec, error) {
 	containerSpec := runtime.ContainerSpec{
-		TeamID:   step.metadata.TeamID,
-		TeamName: step.metadata.TeamName,
-		JobID:    step.metadata.JobID,
-		StepName: step.plan.Name,
+		TeamID:       step.metadata.TeamID,
+		TeamName:     step.metadata.TeamName,
+		PipelineID:   step.metadata.PipelineID,
+		PipelineName: step.metadata.PipelineName,
+		JobID:        step.metadata.JobID,
+		StepName:     step.plan.Name,
 
 		ImageSpec: imageSpec,
-		Env:       config.Params.Env(),
+		Env:       taskEnv(step.metadata, config.Params),
 		Type:      metadata.Type,
 
 		Dir: metadata.WorkingDirectory,
+
+		Hermetic: step.plan.Hermetic,
+		Priority: step.plan.Priority,
 	}
 
 	var err error
@@ -424,6 +535,21 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 		containerSpec.Caches[i] = cache.Path
 	}
 
+	for _, tmpfs := range config.Tmpfs {
+		mount := runtime.TmpfsMount{Path: tmpfs.Path}
+		if tmpfs.Size != nil {
+			mount.Size = uint64(*tmpfs.Size)
+		}
+
+		containerSpec.Tmpfs = append(containerSpec.Tmpfs, mount)
+	}
+
+	if config.ShmSize != nil {
+		containerSpec.ShmSize = uint64(*config.ShmSize)
+	}
+
+	containerSpec.GPUs = config.GPUs
+
 	containerSpec.Outputs = make(runtime.OutputPaths, len(config.Outputs))
 	for _, output := range config.Outputs {
 		containerSpec.Outputs[output.Name] = ensureTrailingSlash(artifactPath(metadata.WorkingDirectory, output.Name, output.Path))
@@ -442,6 +568,7 @@ func (step *TaskStep) workerSpec(config atc.TaskConfig) worker.Spec {
 		Platform: config.Platform,
 		Tags:     step.plan.Tags,
 		TeamID:   step.metadata.TeamID,
+		GPUs:     config.GPUs,
 	}
 }
 
@@ -464,6 +591,172 @@ func (step *TaskStep) registerOutputs(logger lager.Logger, repository *build.Rep
 	}
 }
 
+// scanOutputs streams each of the task's outputs through the configured
+// scanner before they are registered, so that flagged outputs never reach
+// later steps.
+func (step *TaskStep) scanOutputs(ctx context.Context, logger lager.Logger, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata, delegate TaskDelegate) error {
+	var artifacts []scannableArtifact
+	for _, output := range config.Outputs {
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		outputPath := artifactPath(metadata.WorkingDirectory, output.Name, output.Path)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				artifacts = append(artifacts, scannableArtifact{
+					name:     outputName,
+					artifact: mount.Volume,
+				})
+			}
+		}
+	}
+
+	return scanArtifacts(ctx, logger, step.scanner, step.scanAction, delegate, artifacts)
+}
+
+// registerOutputVars loads the output vars from the registered outputs and
+// sets them as build-local vars.
+func (step *TaskStep) registerOutputVars(ctx context.Context, logger lager.Logger, state RunState, config atc.TaskConfig, delegate TaskDelegate) error {
+	for _, outputVar := range config.OutputVars {
+		err := loadTaskOutputVar(ctx, logger, step.streamer, state, outputVar, step.plan.OutputMapping)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(delegate.Stdout(), "added var %s to build.\n", outputVar.Name)
+	}
+
+	return nil
+}
+
+func loadTaskOutputVar(ctx context.Context, logger lager.Logger, streamer Streamer, state RunState, outputVar atc.TaskOutputVarConfig, outputMapping map[string]string) error {
+	file := outputVar.File
+
+	segs := strings.SplitN(file, "/", 2)
+	if destinationName, ok := outputMapping[segs[0]]; ok && len(segs) == 2 {
+		file = destinationName + "/" + segs[1]
+	}
+
+	loader := &LoadVarStep{
+		plan: atc.LoadVarPlan{
+			Name:   outputVar.Name,
+			File:   file,
+			Format: outputVar.Format,
+			Reveal: outputVar.Reveal,
+		},
+		streamer: streamer,
+	}
+
+	value, err := loader.fetchVars(ctx, logger, file, state)
+	if err != nil {
+		return err
+	}
+
+	state.AddLocalVar(outputVar.Name, value, !outputVar.Reveal)
+
+	return nil
+}
+
+func (step *TaskStep) reportTestResults(ctx context.Context, logger lager.Logger, repository *build.Repository, delegate TaskDelegate) {
+	var results []atc.TestResult
+	for _, report := range step.plan.TestReports {
+		reportResults, err := step.parseTestReport(ctx, logger, repository, report)
+		if err != nil {
+			logger.Info("failed-to-parse-test-report", lager.Data{"report": report, "error": err.Error()})
+			fmt.Fprintf(delegate.Stderr(), "\x1b[1;33mWARNING: failed to read test report %s: %s\x1b[0m\n", report, err)
+			continue
+		}
+
+		for i := range reportResults {
+			reportResults[i].PlanID = step.planID
+		}
+
+		results = append(results, reportResults...)
+	}
+
+	if len(results) == 0 {
+		return
+	}
+
+	err := delegate.SaveTestResults(ctx, logger, step.plan.Name, results)
+	if err != nil {
+		logger.Error("failed-to-save-test-results", err)
+		fmt.Fprintf(delegate.Stderr(), "\x1b[1;33mWARNING: failed to save test results: %s\x1b[0m\n", err)
+	}
+}
+
+func (step *TaskStep) parseTestReport(ctx context.Context, logger lager.Logger, repository *build.Repository, report string) ([]atc.TestResult, error) {
+	segs := strings.SplitN(report, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{report}
+	}
+
+	artifactName, filePath := segs[0], segs[1]
+
+	artifact, found := repository.ArtifactFor(build.ArtifactName(artifactName))
+	if !found {
+		return nil, UnknownArtifactSourceError{build.ArtifactName(artifactName), filePath}
+	}
+
+	stream, err := step.streamer.StreamFile(lagerctx.NewContext(ctx, logger), artifact, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return testreport.ParseJUnit(stream)
+}
+
+// archiveOutputs uploads each of the task's outputs to the artifact archive as
+// a gzipped tar archive, recording where it was uploaded to on the build.
+func (step *TaskStep) archiveOutputs(ctx context.Context, logger lager.Logger, repository *build.Repository, config atc.TaskConfig, delegate TaskDelegate) error {
+	if step.archiver == nil {
+		return ErrArchiveNotConfigured
+	}
+
+	for _, output := range config.Outputs {
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		artifact, found := repository.ArtifactFor(build.ArtifactName(outputName))
+		if !found {
+			continue
+		}
+
+		key := path.Join(
+			step.metadata.TeamName,
+			strconv.Itoa(step.metadata.BuildID),
+			step.plan.Name,
+			outputName+".tgz",
+		)
+
+		location, err := step.archiveArtifact(ctx, key, artifact)
+		if err != nil {
+			return fmt.Errorf("archive output %s: %w", outputName, err)
+		}
+
+		delegate.ArtifactArchived(logger, outputName, location)
+	}
+
+	return nil
+}
+
+func (step *TaskStep) archiveArtifact(ctx context.Context, key string, artifact runtime.Artifact) (string, error) {
+	stream, err := artifact.StreamOut(ctx, ".", compression.NewGzipCompression())
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	return step.archiver.Archive(ctx, key, stream)
+}
+
 func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata) error {
 	for _, cacheConfig := range config.Caches {
 		for _, volumeMount := range volumeMounts {
@@ -491,6 +784,56 @@ func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repo
 	return nil
 }
 
+// checkVolumeQuotas returns a VolumeQuotaExceededError for the first of the
+// task's outputs and caches which used up its quota.
+func (step *TaskStep) checkVolumeQuotas(ctx context.Context, logger lager.Logger, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata) error {
+	type limitedPath struct {
+		kind string
+		name string
+		path string
+	}
+
+	var paths []limitedPath
+	for _, output := range config.Outputs {
+		paths = append(paths, limitedPath{"output", output.Name, artifactPath(metadata.WorkingDirectory, output.Name, output.Path)})
+	}
+
+	for _, cacheConfig := range config.Caches {
+		paths = append(paths, limitedPath{"cache", cacheConfig.Path, resolvePath(metadata.WorkingDirectory, cacheConfig.Path)})
+	}
+
+	for _, limited := range paths {
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) != filepath.Clean(limited.path) {
+				continue
+			}
+
+			quotaVolume, ok := mount.Volume.(runtime.QuotaVolume)
+			if !ok {
+				break
+			}
+
+			limit, used, err := quotaVolume.Quota(ctx)
+			if err != nil {
+				logger.Error("failed-to-get-volume-quota", err, lager.Data{limited.kind: limited.name})
+				break
+			}
+
+			if limit > 0 && used+quotaHeadroom >= limit {
+				return VolumeQuotaExceededError{
+					Kind:  limited.kind,
+					Name:  limited.name,
+					Quota: limit,
+				}
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
 func artifactPath(workingDir string, name string, path string) string {
 	subdir := path
 	if path == "" {
@@ -1,18 +1,26 @@
 package exec_test
 
 import (
+	"archive/tar"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/archiver"
+	"github.com/concourse/concourse/atc/archiver/archiverfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/scanner"
+	"github.com/concourse/concourse/atc/scanner/scannerfakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
@@ -33,6 +41,12 @@ var _ = Describe("TaskStep", func() {
 
 		fakePool     *execfakes.FakePool
 		fakeStreamer *execfakes.FakeStreamer
+		fakeArchiver *archiverfakes.FakeArchiver
+
+		artifactArchiver archiver.Archiver
+
+		artifactScanner scanner.Scanner
+		scanAction      scanner.Action
 
 		fakeDelegate *execfakes.FakeTaskDelegate
 
@@ -73,6 +87,12 @@ var _ = Describe("TaskStep", func() {
 		stderrBuf = gbytes.NewBuffer()
 
 		fakeStreamer = new(execfakes.FakeStreamer)
+		fakeArchiver = new(archiverfakes.FakeArchiver)
+
+		artifactArchiver = nil
+
+		artifactScanner = nil
+		scanAction = scanner.ActionBlock
 
 		fakeDelegate = new(execfakes.FakeTaskDelegate)
 		fakeDelegate.StdoutReturns(stdoutBuf)
@@ -117,6 +137,9 @@ var _ = Describe("TaskStep", func() {
 			fakePool,
 			fakeStreamer,
 			fakeDelegateFactory,
+			artifactArchiver,
+			artifactScanner,
+			scanAction,
 		)
 
 		stepOk, stepErr = taskStep.Run(ctx, state)
@@ -209,6 +232,22 @@ var _ = Describe("TaskStep", func() {
 				Expect(workerName).To(Equal("worker"))
 			})
 
+			It("reports the duration of each phase in order", func() {
+				Expect(fakeDelegate.PhaseFinishedCallCount()).To(Equal(3))
+
+				var phases []exec.StepPhase
+				for i := 0; i < fakeDelegate.PhaseFinishedCallCount(); i++ {
+					_, phase, _ := fakeDelegate.PhaseFinishedArgsForCall(i)
+					phases = append(phases, phase)
+				}
+
+				Expect(phases).To(Equal([]exec.StepPhase{
+					exec.StepPhaseWaitingForWorker,
+					exec.StepPhaseCreatingContainer,
+					exec.StepPhaseRunning,
+				}))
+			})
+
 			Context("when tags are configured", func() {
 				BeforeEach(func() {
 					taskPlan.Tags = atc.Tags{"plan", "tags"}
@@ -227,6 +266,10 @@ var _ = Describe("TaskStep", func() {
 				It("returns an err", func() {
 					Expect(stepErr).To(MatchError(ContainSubstring("nope")))
 				})
+
+				It("does not report the phase as finished", func() {
+					Expect(fakeDelegate.PhaseFinishedCallCount()).To(BeZero())
+				})
 			})
 		})
 
@@ -246,6 +289,61 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when the config has tmpfs mounts and a shm size", func() {
+			BeforeEach(func() {
+				size := atc.MemoryLimit(1024)
+				shmSize := atc.MemoryLimit(2048)
+
+				taskPlan.Config.Tmpfs = []atc.TaskTmpfsConfig{
+					{Path: "/tmp"},
+					{Path: "scratch", Size: &size},
+				}
+				taskPlan.Config.ShmSize = &shmSize
+			})
+
+			It("creates the container with them", func() {
+				Expect(chosenContainer.Spec.Tmpfs).To(Equal([]runtime.TmpfsMount{
+					{Path: "/tmp"},
+					{Path: "scratch", Size: 1024},
+				}))
+				Expect(chosenContainer.Spec.ShmSize).To(Equal(uint64(2048)))
+			})
+		})
+
+		Context("when the config requests gpus", func() {
+			BeforeEach(func() {
+				taskPlan.Config.GPUs = 2
+			})
+
+			It("creates the container with them on a worker which has them", func() {
+				Expect(chosenContainer.Spec.GPUs).To(Equal(2))
+
+				_, _, _, workerSpec, _, _ := fakePool.FindOrSelectWorkerArgsForCall(0)
+				Expect(workerSpec.GPUs).To(Equal(2))
+			})
+		})
+
+		Context("when hermetic", func() {
+			BeforeEach(func() {
+				taskPlan.Hermetic = true
+			})
+
+			It("creates the container without networking", func() {
+				Expect(chosenContainer.Spec.Hermetic).To(BeTrue())
+			})
+		})
+
+		Context("with a priority", func() {
+			BeforeEach(func() {
+				taskPlan.Priority = 10
+			})
+
+			It("places the container with the priority", func() {
+				_, _, containerSpec, _, _, _ := fakePool.FindOrSelectWorkerArgsForCall(0)
+				Expect(containerSpec.Priority).To(Equal(10))
+			})
+		})
+
 		It("uses the correct container limits", func() {
 			Expect(atc.CPULimit(*chosenContainer.Spec.Limits.CPU)).To(Equal(atc.CPULimit(1024)))
 			Expect(atc.MemoryLimit(*chosenContainer.Spec.Limits.Memory)).To(Equal(atc.MemoryLimit(1024)))
@@ -316,6 +414,26 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		It("exposes the step metadata and params as env", func() {
+			Expect(chosenContainer.Spec.Env).To(ConsistOf(
+				"BUILD_ID=1234",
+				"BUILD_TEAM_ID=123",
+				"BUILD_JOB_ID=12345",
+				"SECURE=secret-task-param",
+			))
+		})
+
+		Context("when a param has the same name as a metadata var", func() {
+			BeforeEach(func() {
+				taskPlan.Config.Params["BUILD_ID"] = "overridden"
+			})
+
+			It("takes precedence", func() {
+				Expect(chosenContainer.Spec.Env).To(ContainElement("BUILD_ID=overridden"))
+				Expect(chosenContainer.Spec.Env).ToNot(ContainElement("BUILD_ID=1234"))
+			})
+		})
+
 		Context("when tracing is enabled", func() {
 			BeforeEach(func() {
 				tracing.ConfigureTraceProvider(oteltest.NewTracerProvider())
@@ -518,6 +636,17 @@ var _ = Describe("TaskStep", func() {
 					})
 
 					itRegistersCaches(true)
+
+					Context("having filled up the quota of a cache", func() {
+						BeforeEach(func() {
+							volume1.QuotaLimit = 64 * 1024 * 1024
+							volume1.QuotaUsed = 64 * 1024 * 1024
+						})
+
+						It("returns an error naming the cache", func() {
+							Expect(stepErr).To(MatchError("cache 'some-path-1' exceeded its disk quota of 67108864 bytes"))
+						})
+					})
 				})
 
 				Context("when the task errors", func() {
@@ -590,6 +719,345 @@ var _ = Describe("TaskStep", func() {
 					"some-trailing-slash-output": outputVolume3,
 				}))
 			})
+
+			Context("when the task fails", func() {
+				BeforeEach(func() {
+					chosenContainer.ProcessDefs[0].Stub.ExitStatus = 1
+				})
+
+				Context("having filled up the quota of an output", func() {
+					BeforeEach(func() {
+						outputVolume2.QuotaLimit = 64 * 1024 * 1024
+						outputVolume2.QuotaUsed = 64*1024*1024 - 4096
+					})
+
+					It("returns an error naming the output", func() {
+						Expect(stepErr).To(Equal(exec.VolumeQuotaExceededError{
+							Kind:  "output",
+							Name:  "some-other-output",
+							Quota: 64 * 1024 * 1024,
+						}))
+						Expect(stepErr).To(MatchError("output 'some-other-output' exceeded its disk quota of 67108864 bytes"))
+					})
+
+					It("still registers the outputs", func() {
+						Expect(repo.AsMap()).To(HaveKey(build.ArtifactName("some-remapped-output")))
+					})
+				})
+
+				Context("with room left in the quotas of its outputs", func() {
+					BeforeEach(func() {
+						outputVolume2.QuotaLimit = 64 * 1024 * 1024
+						outputVolume2.QuotaUsed = 1024
+					})
+
+					It("fails as usual", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(stepOk).To(BeFalse())
+					})
+				})
+			})
+
+			Context("when an artifact scanner is configured", func() {
+				var fakeScanner *scannerfakes.FakeScanner
+				var scannedFiles map[string][]string
+
+				BeforeEach(func() {
+					outputVolume1.Content = runtimetest.VolumeContent{
+						"some-file": {Data: []byte("some-content")},
+					}
+					outputVolume2.Content = runtimetest.VolumeContent{
+						"some-other-file": {Data: []byte("some-other-content")},
+					}
+					outputVolume3.Content = runtimetest.VolumeContent{
+						"some-file": {Data: []byte("some-content")},
+					}
+
+					scannedFiles = map[string][]string{}
+					fakeScanner = new(scannerfakes.FakeScanner)
+					fakeScanner.ScanStub = func(_ context.Context, name string, tarStream io.Reader) (scanner.Result, error) {
+						tarReader := tar.NewReader(tarStream)
+						for {
+							header, err := tarReader.Next()
+							if err == io.EOF {
+								break
+							}
+							if err != nil {
+								return scanner.Result{}, err
+							}
+							scannedFiles[name] = append(scannedFiles[name], header.Name)
+						}
+						return scanner.Result{Clean: true}, nil
+					}
+					artifactScanner = fakeScanner
+				})
+
+				It("streams each output through the scanner under its registered name", func() {
+					Expect(fakeScanner.ScanCallCount()).To(Equal(3))
+
+					var names []string
+					for i := 0; i < fakeScanner.ScanCallCount(); i++ {
+						_, name, _ := fakeScanner.ScanArgsForCall(i)
+						names = append(names, name)
+					}
+
+					Expect(names).To(Equal([]string{
+						"some-output",
+						"some-remapped-output",
+						"some-trailing-slash-output",
+					}))
+					Expect(scannedFiles["some-remapped-output"]).To(ConsistOf("some-other-file"))
+				})
+
+				It("records the results via the delegate", func() {
+					Expect(fakeDelegate.ArtifactScannedCallCount()).To(Equal(3))
+					_, report := fakeDelegate.ArtifactScannedArgsForCall(1)
+					Expect(report).To(Equal(scanner.Report{
+						Artifact: "some-remapped-output",
+						Result:   scanner.Result{Clean: true},
+					}))
+				})
+
+				It("registers the outputs", func() {
+					Expect(repo.AsMap()).To(HaveLen(3))
+				})
+
+				It("succeeds", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+
+				Context("when an output is flagged", func() {
+					BeforeEach(func() {
+						fakeScanner.ScanStub = nil
+						fakeScanner.ScanReturns(scanner.Result{
+							Clean:    false,
+							Findings: []string{"Eicar-Test-Signature"},
+						}, nil)
+					})
+
+					It("blocks the step", func() {
+						Expect(stepErr).To(Equal(scanner.BlockedError{
+							Artifact: "some-output",
+							Findings: []string{"Eicar-Test-Signature"},
+						}))
+					})
+
+					It("does not register the outputs", func() {
+						Expect(repo.AsMap()).To(BeEmpty())
+					})
+
+					It("records the blocked result via the delegate", func() {
+						Expect(fakeDelegate.ArtifactScannedCallCount()).To(Equal(1))
+						_, report := fakeDelegate.ArtifactScannedArgsForCall(0)
+						Expect(report.Blocked).To(BeTrue())
+						Expect(report.Result.Findings).To(ConsistOf("Eicar-Test-Signature"))
+					})
+
+					Context("when the scan action is warn", func() {
+						BeforeEach(func() {
+							scanAction = scanner.ActionWarn
+						})
+
+						It("registers the outputs", func() {
+							Expect(repo.AsMap()).To(HaveLen(3))
+						})
+
+						It("succeeds", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(stepOk).To(BeTrue())
+						})
+					})
+				})
+
+				Context("when scanning fails", func() {
+					disaster := errors.New("clamd unavailable")
+
+					BeforeEach(func() {
+						fakeScanner.ScanStub = nil
+						fakeScanner.ScanReturns(scanner.Result{}, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(stepErr).To(MatchError(disaster))
+					})
+
+					It("does not register the outputs", func() {
+						Expect(repo.AsMap()).To(BeEmpty())
+					})
+				})
+			})
+
+			Context("when the plan archives outputs", func() {
+				BeforeEach(func() {
+					taskPlan.ArchiveOutputs = true
+				})
+
+				Context("when an archive is configured", func() {
+					BeforeEach(func() {
+						artifactArchiver = fakeArchiver
+						fakeArchiver.ArchiveStub = func(_ context.Context, key string, _ io.Reader) (string, error) {
+							return "s3://some-bucket/" + key, nil
+						}
+					})
+
+					It("uploads each output under its build-scoped key", func() {
+						Expect(fakeArchiver.ArchiveCallCount()).To(Equal(3))
+
+						var keys []string
+						for i := 0; i < fakeArchiver.ArchiveCallCount(); i++ {
+							_, key, _ := fakeArchiver.ArchiveArgsForCall(i)
+							keys = append(keys, key)
+						}
+
+						Expect(keys).To(Equal([]string{
+							"1234/some-task/some-output.tgz",
+							"1234/some-task/some-remapped-output.tgz",
+							"1234/some-task/some-trailing-slash-output.tgz",
+						}))
+					})
+
+					It("records where each output was archived", func() {
+						Expect(fakeDelegate.ArtifactArchivedCallCount()).To(Equal(3))
+						_, artifact, location := fakeDelegate.ArtifactArchivedArgsForCall(1)
+						Expect(artifact).To(Equal("some-remapped-output"))
+						Expect(location).To(Equal("s3://some-bucket/1234/some-task/some-remapped-output.tgz"))
+					})
+
+					It("succeeds", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(stepOk).To(BeTrue())
+					})
+
+					Context("when uploading fails", func() {
+						BeforeEach(func() {
+							fakeArchiver.ArchiveStub = nil
+							fakeArchiver.ArchiveReturns("", errors.New("nope"))
+						})
+
+						It("returns the error", func() {
+							Expect(stepErr).To(MatchError(ContainSubstring("archive output some-output: nope")))
+						})
+					})
+				})
+
+				Context("when no archive is configured", func() {
+					It("returns ErrArchiveNotConfigured", func() {
+						Expect(stepErr).To(Equal(exec.ErrArchiveNotConfigured))
+					})
+				})
+			})
+		})
+
+		Context("when the plan specifies test reports", func() {
+			BeforeEach(func() {
+				taskPlan.Config.Outputs = []atc.TaskOutputConfig{
+					{Name: "reports"},
+				}
+				taskPlan.TestReports = []string{"reports/junit.xml"}
+
+				chosenContainer.Mounts = []runtime.VolumeMount{
+					{
+						Volume:    runtimetest.NewVolume("reports"),
+						MountPath: "some-artifact-root/reports/",
+					},
+				}
+
+				fakeStreamer.StreamFileReturns(ioutil.NopCloser(strings.NewReader(`
+					<testsuite name="suite">
+						<testcase name="passes" time="1.5"/>
+						<testcase name="fails"><failure message="boom"/></testcase>
+					</testsuite>`)), nil)
+			})
+
+			It("streams the report from the output", func() {
+				Expect(fakeStreamer.StreamFileCallCount()).To(Equal(1))
+				_, _, path := fakeStreamer.StreamFileArgsForCall(0)
+				Expect(path).To(Equal("junit.xml"))
+			})
+
+			It("saves the parsed results via the delegate", func() {
+				Expect(fakeDelegate.SaveTestResultsCallCount()).To(Equal(1))
+				_, _, stepName, results := fakeDelegate.SaveTestResultsArgsForCall(0)
+				Expect(stepName).To(Equal("some-task"))
+				Expect(results).To(Equal([]atc.TestResult{
+					{PlanID: planID, Suite: "suite", Name: "passes", Status: atc.TestStatusPassed, Duration: 1.5},
+					{PlanID: planID, Suite: "suite", Name: "fails", Status: atc.TestStatusFailed, Message: "boom"},
+				}))
+			})
+
+			Context("when the report cannot be read", func() {
+				BeforeEach(func() {
+					fakeStreamer.StreamFileReturns(nil, errors.New("nope"))
+				})
+
+				It("warns without failing the step", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stderrBuf).To(gbytes.Say("failed to read test report reports/junit.xml: nope"))
+					Expect(fakeDelegate.SaveTestResultsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the config declares output vars", func() {
+			BeforeEach(func() {
+				taskPlan.Config.Outputs = []atc.TaskOutputConfig{
+					{Name: "out"},
+				}
+				taskPlan.Config.OutputVars = []atc.TaskOutputVarConfig{
+					{Name: "version", File: "out/version", Format: "trim"},
+				}
+				taskPlan.OutputMapping = map[string]string{
+					"out": "remapped-out",
+				}
+
+				chosenContainer.Mounts = []runtime.VolumeMount{
+					{
+						Volume:    runtimetest.NewVolume("out"),
+						MountPath: "some-artifact-root/out/",
+					},
+				}
+
+				fakeStreamer.StreamFileReturns(ioutil.NopCloser(strings.NewReader("1.2.3\n")), nil)
+			})
+
+			It("reads the file from the remapped output", func() {
+				Expect(fakeStreamer.StreamFileCallCount()).To(Equal(1))
+				_, artifact, path := fakeStreamer.StreamFileArgsForCall(0)
+				Expect(artifact).To(Equal(runtimetest.NewVolume("out")))
+				Expect(path).To(Equal("version"))
+			})
+
+			It("adds the var to the build", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+
+				val, found, err := state.Get(vars.Reference{Source: ".", Path: "version"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("1.2.3"))
+			})
+
+			Context("when the file cannot be read", func() {
+				BeforeEach(func() {
+					fakeStreamer.StreamFileReturns(nil, errors.New("nope"))
+				})
+
+				It("errors", func() {
+					Expect(stepErr).To(MatchError("nope"))
+				})
+			})
+
+			Context("when the task fails", func() {
+				BeforeEach(func() {
+					chosenContainer.ProcessDefs[0].Stub.ExitStatus = 1
+				})
+
+				It("does not load the vars", func() {
+					Expect(fakeStreamer.StreamFileCallCount()).To(BeZero())
+					Expect(stepOk).To(BeFalse())
+				})
+			})
 		})
 
 		Context("when missing the platform", func() {
@@ -746,6 +1214,36 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when an image to pull by digest is specified", func() {
+			var registryImage *atc.RegistryImage
+
+			BeforeEach(func() {
+				registryImage = &atc.RegistryImage{
+					Repository: "some/image",
+					Digest:     "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				}
+
+				taskPlan.Config.Image = registryImage
+				taskPlan.Privileged = true
+			})
+
+			It("succeeds", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+			})
+
+			It("does not fetch an image resource", func() {
+				Expect(fakeDelegate.FetchImageCallCount()).To(BeZero())
+			})
+
+			It("leaves pulling the image to the worker", func() {
+				Expect(chosenContainer.Spec.ImageSpec).To(Equal(runtime.ImageSpec{
+					RegistryImage: registryImage,
+					Privileged:    true,
+				}))
+			})
+		})
+
 		Context("when a run dir and user are specified", func() {
 			BeforeEach(func() {
 				taskPlan.Config.Run.Dir = "/some/dir"
@@ -759,6 +1257,107 @@ var _ = Describe("TaskStep", func() {
 				Expect(stepErr).ToNot(HaveOccurred())
 				Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
 			})
+
+			Context("when the step overrides the user", func() {
+				BeforeEach(func() {
+					taskPlan.User = "some-other-user"
+
+					chosenContainer.ProcessDefs[0].Spec.User = "some-other-user"
+				})
+
+				It("runs as the step's user", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+				})
+			})
+		})
+
+		Context("when the task has sidecars", func() {
+			var sidecarStopped bool
+
+			BeforeEach(func() {
+				sidecarStopped = false
+
+				taskPlan.Config.Sidecars = []atc.TaskSidecarConfig{
+					{
+						Name: "postgres",
+						Run: atc.TaskRunConfig{
+							Path: "postgres",
+							Args: []string{"-D", "/data"},
+						},
+					},
+				}
+
+				chosenContainer.Container = chosenContainer.WithProcess(
+					runtime.ProcessSpec{
+						ID:   "sidecar-postgres",
+						Path: "postgres",
+						Args: []string{"-D", "/data"},
+						Dir:  "some-artifact-root",
+					},
+					runtimetest.ProcessStub{
+						Do: func(ctx context.Context, p *runtimetest.Process) error {
+							fmt.Fprintln(p.Stderr(), "ready to accept connections")
+							<-ctx.Done()
+							sidecarStopped = true
+							return ctx.Err()
+						},
+					},
+				)
+			})
+
+			It("runs the sidecars alongside the task", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(chosenContainer.RunningProcesses()).To(HaveLen(2))
+				Expect(chosenContainer.RunningProcesses()[0].Spec.ID).To(Equal("sidecar-postgres"))
+				Expect(chosenContainer.RunningProcesses()[1].Spec.ID).To(Equal("task"))
+			})
+
+			It("stops the sidecars once the task is done", func() {
+				Expect(sidecarStopped).To(BeTrue())
+			})
+
+			It("writes the output of the sidecars", func() {
+				Expect(stderrBuf).To(gbytes.Say("sidecar postgres:"))
+				Expect(stderrBuf).To(gbytes.Say("ready to accept connections"))
+			})
+
+			It("is successful", func() {
+				Expect(stepOk).To(BeTrue())
+			})
+
+			Context("when a sidecar writes more than the output limit", func() {
+				var originalLimit int
+
+				BeforeEach(func() {
+					originalLimit = exec.SidecarOutputLimit
+					exec.SidecarOutputLimit = 10
+				})
+
+				AfterEach(func() {
+					exec.SidecarOutputLimit = originalLimit
+				})
+
+				It("truncates the output of the sidecar", func() {
+					Expect(stderrBuf).To(gbytes.Say("sidecar postgres:"))
+					Expect(stderrBuf).To(gbytes.Say("ready to a\n"))
+					Expect(stderrBuf).To(gbytes.Say("sidecar postgres output truncated to 10 bytes"))
+				})
+			})
+
+			Context("when a sidecar exits with a non-zero status", func() {
+				BeforeEach(func() {
+					chosenContainer.ProcessDefs[len(chosenContainer.ProcessDefs)-1].Stub = runtimetest.ProcessStub{
+						Stderr:     "sidecar crashed\n",
+						ExitStatus: 3,
+					}
+				})
+
+				It("reports the exit status of the task, not the sidecar", func() {
+					Expect(stepOk).To(BeTrue())
+					Expect(stderrBuf).To(gbytes.Say("sidecar postgres exited with status 3"))
+				})
+			})
 		})
 
 		Context("when running the task exits with a non-zero status", func() {
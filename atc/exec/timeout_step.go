@@ -3,14 +3,26 @@ package exec
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 )
 
 // TimeoutStep applies a fixed timeout to a step's Run.
 type TimeoutStep struct {
-	step     Step
-	duration string
-	timedOut bool
+	step      Step
+	duration  string
+	warnAfter string
+	timedOut  bool
+
+	// runningDuration is set for a build_timeout, which counts the time the
+	// build has been running rather than the time the step has been running.
+	runningDuration func() (time.Duration, error)
+
+	delegateFactory BuildStepDelegateFactory
 }
 
 // Timeout constructs a TimeoutStep factory.
@@ -22,12 +34,48 @@ func Timeout(step Step, duration string) *TimeoutStep {
 	}
 }
 
+// TimeoutWithWarning constructs a TimeoutStep which additionally emits a
+// timeout-warning event once the nested step has been running for warnAfter.
+// If warnAfter is empty, no warning is emitted.
+func TimeoutWithWarning(step Step, duration string, warnAfter string, delegateFactory BuildStepDelegateFactory) *TimeoutStep {
+	return &TimeoutStep{
+		step:      step,
+		duration:  duration,
+		warnAfter: warnAfter,
+		timedOut:  false,
+
+		delegateFactory: delegateFactory,
+	}
+}
+
+// BuildTimeout constructs a TimeoutStep bounding a whole build. The time
+// counted towards the timeout is reported by runningDuration, which leaves out
+// the time the build spent queued or paused. It is kept in the database, so
+// that it is not reset when the build is picked up again by another ATC.
+func BuildTimeout(step Step, duration string, runningDuration func() (time.Duration, error), delegateFactory BuildStepDelegateFactory) *TimeoutStep {
+	return &TimeoutStep{
+		step:            step,
+		duration:        duration,
+		timedOut:        false,
+		runningDuration: runningDuration,
+
+		delegateFactory: delegateFactory,
+	}
+}
+
 // Run parses the timeout duration and invokes the nested step.
 //
 // If the nested step takes longer than the duration, it is sent the Interrupt
 // signal, and the TimeoutStep returns nil once the nested step exits (ignoring
 // the nested step's error).
 //
+// If a warning threshold is configured and the nested step is still running
+// once it is crossed, a timeout-warning event is emitted. The nested step is
+// left running until the timeout itself expires.
+//
+// If it bounds a whole build, a build-timeout event is emitted once it has
+// expired.
+//
 // The result of the nested step's Run is returned.
 func (ts *TimeoutStep) Run(ctx context.Context, state RunState) (bool, error) {
 	parsedDuration, err := time.ParseDuration(ts.duration)
@@ -35,13 +83,148 @@ func (ts *TimeoutStep) Run(ctx context.Context, state RunState) (bool, error) {
 		return false, err
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, parsedDuration)
+	if ts.warnAfter != "" {
+		warnAfter, err := time.ParseDuration(ts.warnAfter)
+		if err != nil {
+			return false, err
+		}
+
+		logger := lagerctx.FromContext(ctx)
+		delegate := ts.delegateFactory.BuildStepDelegate(state)
+
+		warning := time.AfterFunc(warnAfter, func() {
+			logger.Info("timeout-warning", lager.Data{"warn-after": warnAfter.String()})
+			delegate.TimeoutWarning(logger, warnAfter)
+		})
+		defer warning.Stop()
+	}
+
+	var timeoutCtx context.Context
+	var cancel context.CancelFunc
+	if ts.runningDuration == nil {
+		timeoutCtx, cancel = context.WithTimeout(ctx, parsedDuration)
+	} else {
+		remaining := func() (time.Duration, error) {
+			running, err := ts.runningDuration()
+			if err != nil {
+				return 0, fmt.Errorf("get build running duration: %w", err)
+			}
+
+			return parsedDuration - running, nil
+		}
+
+		left, err := remaining()
+		if err != nil {
+			return false, err
+		}
+
+		timeoutCtx, cancel = withBuildDeadline(ctx, left, remaining)
+	}
 	defer cancel()
 
 	ok, err := ts.step.Run(timeoutCtx, state)
+	if timeoutCtx.Err() == context.DeadlineExceeded {
+		ts.timedOut = ctx.Err() == nil
+		recordTimeout(ctx)
+	}
+
+	if ts.timedOut && ts.runningDuration != nil {
+		logger := lagerctx.FromContext(ctx)
+		logger.Info("build-timed-out", lager.Data{"timeout": parsedDuration.String()})
+
+		delegate := ts.delegateFactory.BuildStepDelegate(state)
+		delegate.BuildTimedOut(logger, parsedDuration)
+	}
+
 	if errors.Is(err, context.DeadlineExceeded) {
 		return false, nil
 	}
 
 	return ok, err
 }
+
+// TimedOut returns whether the nested step was interrupted because the
+// timeout expired.
+func (ts *TimeoutStep) TimedOut() bool {
+	return ts.timedOut
+}
+
+// buildTimeoutRetryInterval is how long to wait before checking again how
+// long a build has left to run if it could not be determined.
+const buildTimeoutRetryInterval = time.Minute
+
+// buildDeadlineCtx is done once a build has run for as long as its
+// build_timeout allows. Rather than having a fixed deadline, it checks how
+// long the build has left each time its timer fires, so that any time the
+// build spent paused in the meantime is not counted.
+type buildDeadlineCtx struct {
+	context.Context
+
+	done chan struct{}
+
+	errLock sync.Mutex
+	err     error
+}
+
+func withBuildDeadline(parent context.Context, left time.Duration, remaining func() (time.Duration, error)) (context.Context, context.CancelFunc) {
+	ctx := &buildDeadlineCtx{
+		Context: parent,
+		done:    make(chan struct{}),
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		timer := time.NewTimer(left)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-parent.Done():
+				ctx.finish(parent.Err())
+				return
+
+			case <-stop:
+				ctx.finish(context.Canceled)
+				return
+
+			case <-timer.C:
+				var err error
+				left, err = remaining()
+				if err != nil {
+					lagerctx.FromContext(parent).Error("failed-to-check-build-timeout", err)
+					left = buildTimeoutRetryInterval
+				} else if left <= 0 {
+					ctx.finish(context.DeadlineExceeded)
+					return
+				}
+
+				timer.Reset(left)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() { close(stop) })
+	}
+}
+
+func (ctx *buildDeadlineCtx) Done() <-chan struct{} {
+	return ctx.done
+}
+
+func (ctx *buildDeadlineCtx) Err() error {
+	ctx.errLock.Lock()
+	defer ctx.errLock.Unlock()
+	return ctx.err
+}
+
+func (ctx *buildDeadlineCtx) finish(err error) {
+	ctx.errLock.Lock()
+	defer ctx.errLock.Unlock()
+
+	if ctx.err == nil {
+		ctx.err = err
+		close(ctx.done)
+	}
+}
@@ -22,9 +22,14 @@ var _ = Describe("Timeout Step", func() {
 		repo  *build.Repository
 		state *execfakes.FakeRunState
 
-		step Step
+		step *TimeoutStep
 
 		timeoutDuration string
+		warnAfter       string
+		runningDuration func() (time.Duration, error)
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
 
 		stepOk  bool
 		stepErr error
@@ -40,10 +45,21 @@ var _ = Describe("Timeout Step", func() {
 		state.ArtifactRepositoryReturns(repo)
 
 		timeoutDuration = "1h"
+		warnAfter = ""
+		runningDuration = nil
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
 	})
 
 	JustBeforeEach(func() {
-		step = Timeout(fakeStep, timeoutDuration)
+		if runningDuration == nil {
+			step = TimeoutWithWarning(fakeStep, timeoutDuration, warnAfter, fakeDelegateFactory)
+		} else {
+			step = BuildTimeout(fakeStep, timeoutDuration, runningDuration, fakeDelegateFactory)
+		}
+
 		stepOk, stepErr = step.Run(ctx, state)
 	})
 
@@ -83,6 +99,69 @@ var _ = Describe("Timeout Step", func() {
 			})
 		})
 
+		Context("when the timeout expires", func() {
+			BeforeEach(func() {
+				timeoutDuration = "1ms"
+
+				fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+					<-ctx.Done()
+					return false, ctx.Err()
+				}
+			})
+
+			It("reports that it timed out", func() {
+				Expect(step.TimedOut()).To(BeTrue())
+			})
+		})
+
+		Context("when a warning threshold is configured", func() {
+			BeforeEach(func() {
+				warnAfter = "1ms"
+			})
+
+			Context("when the step runs past the threshold", func() {
+				BeforeEach(func() {
+					fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+						Eventually(fakeDelegate.TimeoutWarningCallCount).Should(Equal(1))
+						return true, nil
+					}
+				})
+
+				It("emits a timeout warning", func() {
+					_, duration := fakeDelegate.TimeoutWarningArgsForCall(0)
+					Expect(duration).To(Equal(time.Millisecond))
+				})
+
+				It("lets the step continue", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+					Expect(step.TimedOut()).To(BeFalse())
+				})
+			})
+
+			Context("when the step finishes before the threshold", func() {
+				BeforeEach(func() {
+					warnAfter = "1h"
+					fakeStep.RunReturns(true, nil)
+				})
+
+				It("does not emit a timeout warning", func() {
+					Consistently(fakeDelegate.TimeoutWarningCallCount, 50*time.Millisecond).Should(BeZero())
+				})
+			})
+
+			Context("when the threshold is invalid", func() {
+				BeforeEach(func() {
+					warnAfter = "nope"
+				})
+
+				It("errors immediately without running the step", func() {
+					Expect(stepErr).To(HaveOccurred())
+					Expect(fakeStep.RunCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Describe("canceling", func() {
 			BeforeEach(func() {
 				cancel()
@@ -93,6 +172,10 @@ var _ = Describe("Timeout Step", func() {
 				Expect(runCtx.Err()).To(Equal(context.Canceled))
 			})
 
+			It("does not report that it timed out", func() {
+				Expect(step.TimedOut()).To(BeFalse())
+			})
+
 			It("is not successful", func() {
 				Expect(stepOk).To(BeFalse())
 			})
@@ -119,6 +202,97 @@ var _ = Describe("Timeout Step", func() {
 		})
 	})
 
+	Context("when bounding a whole build", func() {
+		var runningDurations chan time.Duration
+
+		BeforeEach(func() {
+			runningDurations = make(chan time.Duration, 10)
+			runningDurations <- 30 * time.Minute
+
+			runningDuration = func() (time.Duration, error) {
+				select {
+				case duration := <-runningDurations:
+					return duration, nil
+				default:
+					return 0, errors.New("unexpected check of the running duration")
+				}
+			}
+
+			fakeStep.RunReturns(true, nil)
+		})
+
+		It("runs the step", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+
+		It("does not emit a build-timeout event", func() {
+			Expect(fakeDelegate.BuildTimedOutCallCount()).To(BeZero())
+		})
+
+		Context("when the build has already run for longer than the timeout", func() {
+			BeforeEach(func() {
+				runningDurations = make(chan time.Duration, 10)
+				runningDurations <- 2 * time.Hour
+				runningDurations <- 2 * time.Hour
+
+				fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+					<-ctx.Done()
+					return false, ctx.Err()
+				}
+			})
+
+			It("emits a build-timeout event", func() {
+				Expect(fakeDelegate.BuildTimedOutCallCount()).To(Equal(1))
+				_, duration := fakeDelegate.BuildTimedOutArgsForCall(0)
+				Expect(duration).To(Equal(time.Hour))
+			})
+
+			It("fails without an error", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+				Expect(step.TimedOut()).To(BeTrue())
+			})
+		})
+
+		Context("when the build is paused before the timeout expires", func() {
+			BeforeEach(func() {
+				runningDurations = make(chan time.Duration, 10)
+
+				// the build is paused as the timer first fires, so it has as
+				// long left as when the step started
+				runningDurations <- time.Hour - 10*time.Millisecond
+				runningDurations <- time.Hour - 10*time.Millisecond
+				runningDurations <- time.Hour
+
+				fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+					<-ctx.Done()
+					return false, ctx.Err()
+				}
+			})
+
+			It("does not count the time paused towards the timeout", func() {
+				Expect(runningDurations).To(BeEmpty())
+				Expect(step.TimedOut()).To(BeTrue())
+				Expect(fakeDelegate.BuildTimedOutCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the running duration cannot be determined", func() {
+			BeforeEach(func() {
+				runningDuration = func() (time.Duration, error) {
+					return 0, errors.New("nope")
+				}
+			})
+
+			It("errors without running the step", func() {
+				Expect(stepErr).To(MatchError("get build running duration: nope"))
+				Expect(fakeStep.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Context("when the duration is invalid", func() {
 		BeforeEach(func() {
 			timeoutDuration = "nope"
@@ -0,0 +1,118 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/vars"
+)
+
+// MaxIterationsExceededError is returned when a WhileStep's condition still
+// holds after its final iteration.
+type MaxIterationsExceededError struct {
+	Iterations int
+}
+
+func (err MaxIterationsExceededError) Error() string {
+	return fmt.Sprintf("while condition still true after %d iterations", err.Iterations)
+}
+
+// WhileStepConditionError is returned when the var a WhileStep checks is
+// missing or can not be interpreted as a boolean.
+type WhileStepConditionError struct {
+	Var   string
+	Value interface{}
+}
+
+func (err WhileStepConditionError) Error() string {
+	if err.Value == nil {
+		return fmt.Sprintf("while condition var '%s' is not set", err.Var)
+	}
+
+	return fmt.Sprintf("while condition var '%s' is not a boolean: %v", err.Var, err.Value)
+}
+
+// WhileStep runs its iterations in order for as long as its condition holds.
+type WhileStep struct {
+	Iterations    []Step
+	Var           string
+	LastIteration Step
+}
+
+// While constructs a WhileStep. If condVar is empty, the loop continues while
+// each iteration succeeds; otherwise it continues while the local var named
+// condVar is true after each iteration.
+func While(condVar string, iterations ...Step) Step {
+	return &WhileStep{
+		Iterations: iterations,
+		Var:        condVar,
+	}
+}
+
+// Run runs each iteration until the condition no longer holds.
+//
+// When looping on a var, a failing iteration fails the WhileStep. When
+// looping on the iterations' status, a failing iteration ends the loop
+// successfully, like a shell `while` loop.
+//
+// If the condition still holds once every iteration has run, a
+// MaxIterationsExceededError is returned.
+func (step *WhileStep) Run(ctx context.Context, state RunState) (bool, error) {
+	for _, iteration := range step.Iterations {
+		step.LastIteration = iteration
+
+		ok, err := iteration.Run(ctx, state)
+		if err != nil {
+			return false, err
+		}
+
+		if step.Var == "" {
+			if !ok {
+				return true, nil
+			}
+
+			continue
+		}
+
+		if !ok {
+			return false, nil
+		}
+
+		again, err := step.condition(state)
+		if err != nil {
+			return false, err
+		}
+
+		if !again {
+			return true, nil
+		}
+	}
+
+	return false, MaxIterationsExceededError{
+		Iterations: len(step.Iterations),
+	}
+}
+
+func (step *WhileStep) condition(state RunState) (bool, error) {
+	val, found, err := state.Get(vars.Reference{Source: ".", Path: step.Var})
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, WhileStepConditionError{Var: step.Var}
+	}
+
+	switch v := val.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err == nil {
+			return b, nil
+		}
+	}
+
+	return false, WhileStepConditionError{Var: step.Var, Value: val}
+}
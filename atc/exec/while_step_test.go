@@ -0,0 +1,177 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("While Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		iteration1 *execfakes.FakeStep
+		iteration2 *execfakes.FakeStep
+		iteration3 *execfakes.FakeStep
+
+		repo  *build.Repository
+		state *execfakes.FakeRunState
+
+		condVar string
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		iteration1 = new(execfakes.FakeStep)
+		iteration2 = new(execfakes.FakeStep)
+		iteration3 = new(execfakes.FakeStep)
+
+		repo = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(repo)
+
+		condVar = ""
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := While(condVar, iteration1, iteration2, iteration3)
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	Context("without a var", func() {
+		Context("when iteration 2 fails", func() {
+			BeforeEach(func() {
+				iteration1.RunReturns(true, nil)
+				iteration2.RunReturns(false, nil)
+			})
+
+			It("stops looping", func() {
+				Expect(iteration1.RunCallCount()).To(Equal(1))
+				Expect(iteration2.RunCallCount()).To(Equal(1))
+				Expect(iteration3.RunCallCount()).To(Equal(0))
+			})
+
+			It("succeeds", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+			})
+		})
+
+		Context("when an iteration errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				iteration1.RunReturns(false, disaster)
+			})
+
+			It("returns the error without running any more iterations", func() {
+				Expect(stepErr).To(Equal(disaster))
+				Expect(iteration2.RunCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when every iteration succeeds", func() {
+			BeforeEach(func() {
+				iteration1.RunReturns(true, nil)
+				iteration2.RunReturns(true, nil)
+				iteration3.RunReturns(true, nil)
+			})
+
+			It("returns a MaxIterationsExceededError", func() {
+				Expect(stepErr).To(Equal(MaxIterationsExceededError{Iterations: 3}))
+				Expect(stepOk).To(BeFalse())
+			})
+		})
+	})
+
+	Context("with a var", func() {
+		var values []interface{}
+
+		BeforeEach(func() {
+			condVar = "keep-going"
+
+			values = []interface{}{true, "true", false}
+			state.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+				Expect(ref).To(Equal(vars.Reference{Source: ".", Path: "keep-going"}))
+
+				val := values[0]
+				values = values[1:]
+				return val, true, nil
+			}
+
+			iteration1.RunReturns(true, nil)
+			iteration2.RunReturns(true, nil)
+			iteration3.RunReturns(true, nil)
+		})
+
+		It("loops until the var is false", func() {
+			Expect(iteration1.RunCallCount()).To(Equal(1))
+			Expect(iteration2.RunCallCount()).To(Equal(1))
+			Expect(iteration3.RunCallCount()).To(Equal(1))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		Context("when an iteration fails", func() {
+			BeforeEach(func() {
+				iteration2.RunReturns(false, nil)
+			})
+
+			It("fails without running any more iterations", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+				Expect(iteration3.RunCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the var is still true after the last iteration", func() {
+			BeforeEach(func() {
+				values = []interface{}{true, true, true}
+			})
+
+			It("returns a MaxIterationsExceededError", func() {
+				Expect(stepErr).To(Equal(MaxIterationsExceededError{Iterations: 3}))
+			})
+		})
+
+		Context("when the var is not set", func() {
+			BeforeEach(func() {
+				state.GetReturns(nil, false, nil)
+				state.GetStub = nil
+			})
+
+			It("returns a WhileStepConditionError", func() {
+				Expect(stepErr).To(Equal(WhileStepConditionError{Var: "keep-going"}))
+				Expect(iteration2.RunCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the var is not a boolean", func() {
+			BeforeEach(func() {
+				values = []interface{}{"maybe"}
+			})
+
+			It("returns a WhileStepConditionError", func() {
+				Expect(stepErr).To(Equal(WhileStepConditionError{Var: "keep-going", Value: "maybe"}))
+			})
+		})
+	})
+})
@@ -8,6 +8,7 @@ var (
 	EnablePipelineInstances              bool
 	EnableCacheStreamedVolumes           bool
 	EnableResourceCausality              bool
+	EnablePersistentResourceCaches       bool
 )
 
 func FeatureFlags() map[string]bool {
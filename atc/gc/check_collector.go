@@ -9,11 +9,13 @@ import (
 
 type checksCollector struct {
 	lifecycle db.CheckLifecycle
+	retention db.CheckBuildRetention
 }
 
-func NewChecksCollector(lifecycle db.CheckLifecycle) *checksCollector {
+func NewChecksCollector(lifecycle db.CheckLifecycle, retention db.CheckBuildRetention) *checksCollector {
 	return &checksCollector{
 		lifecycle: lifecycle,
+		retention: retention,
 	}
 }
 
@@ -23,7 +25,7 @@ func (c *checksCollector) Run(ctx context.Context) error {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
-	err := c.lifecycle.DeleteCompletedChecks(logger)
+	err := c.lifecycle.DeleteCompletedChecks(logger, c.retention)
 	if err != nil {
 		logger.Error("failed-to-delete-completed-checks", err)
 		return err
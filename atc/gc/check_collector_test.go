@@ -2,7 +2,9 @@ package gc_test
 
 import (
 	"context"
+	"time"
 
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/gc"
 	. "github.com/onsi/ginkgo"
@@ -16,7 +18,7 @@ var _ = Describe("CheckCollector", func() {
 	BeforeEach(func() {
 		fakeLifecycle = new(dbfakes.FakeCheckLifecycle)
 
-		collector = gc.NewChecksCollector(fakeLifecycle)
+		collector = gc.NewChecksCollector(fakeLifecycle, db.CheckBuildRetention{Count: 5, MaxAge: time.Hour})
 	})
 
 	Describe("Run", func() {
@@ -25,6 +27,9 @@ var _ = Describe("CheckCollector", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeLifecycle.DeleteCompletedChecksCallCount()).To(Equal(1))
+
+			_, retention := fakeLifecycle.DeleteCompletedChecksArgsForCall(0)
+			Expect(retention).To(Equal(db.CheckBuildRetention{Count: 5, MaxAge: time.Hour}))
 		})
 	})
 })
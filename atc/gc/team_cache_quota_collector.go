@@ -0,0 +1,62 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type teamCacheQuotaCollector struct {
+	cacheLifecycle db.ResourceCacheLifecycle
+	defaultQuota   int
+	quotas         map[string]int
+}
+
+// NewTeamCacheQuotaCollector constructs a collector which evicts the least
+// recently used resource caches of any team holding more caches on a worker
+// than its quota allows. quotas maps team names to the number of caches each
+// team may keep per worker; teams not listed get defaultQuota. A quota of 0
+// is unlimited.
+func NewTeamCacheQuotaCollector(cacheLifecycle db.ResourceCacheLifecycle, defaultQuota int, quotas map[string]int) *teamCacheQuotaCollector {
+	return &teamCacheQuotaCollector{
+		cacheLifecycle: cacheLifecycle,
+		defaultQuota:   defaultQuota,
+		quotas:         quotas,
+	}
+}
+
+func (tcq *teamCacheQuotaCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("team-cache-quota-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	if tcq.defaultQuota <= 0 && len(tcq.quotas) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		metric.TeamCacheQuotaCollectorDuration{
+			Duration: time.Since(start),
+		}.Emit(logger)
+	}()
+
+	evictions, err := tcq.cacheLifecycle.EvictCachesOverTeamQuota(logger, tcq.defaultQuota, tcq.quotas)
+	if err != nil {
+		return err
+	}
+
+	for _, eviction := range evictions {
+		metric.TeamCachesEvicted{
+			TeamName:   eviction.TeamName,
+			WorkerName: eviction.WorkerName,
+			Caches:     eviction.Count,
+		}.Emit(logger)
+	}
+
+	return nil
+}
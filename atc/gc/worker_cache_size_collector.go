@@ -0,0 +1,58 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type workerCacheSizeCollector struct {
+	cacheLifecycle db.ResourceCacheLifecycle
+	limit          uint64
+}
+
+// NewWorkerCacheSizeCollector constructs a collector which evicts the least
+// recently used resource caches of any worker whose caches take up more than
+// limit bytes. A limit of 0 is unlimited.
+func NewWorkerCacheSizeCollector(cacheLifecycle db.ResourceCacheLifecycle, limit uint64) *workerCacheSizeCollector {
+	return &workerCacheSizeCollector{
+		cacheLifecycle: cacheLifecycle,
+		limit:          limit,
+	}
+}
+
+func (wcs *workerCacheSizeCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("worker-cache-size-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	if wcs.limit == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		metric.WorkerCacheSizeCollectorDuration{
+			Duration: time.Since(start),
+		}.Emit(logger)
+	}()
+
+	evictions, err := wcs.cacheLifecycle.EvictCachesOverWorkerSizeLimit(logger, wcs.limit)
+	if err != nil {
+		return err
+	}
+
+	for _, eviction := range evictions {
+		metric.WorkerCachesEvicted{
+			WorkerName: eviction.WorkerName,
+			Caches:     eviction.Count,
+			Bytes:      eviction.Bytes,
+		}.Emit(logger)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package atc
+
+// InstanceGroupMaxInFlight limits the number of builds which may run at once
+// across all instances of an instance group, keyed by "TEAM/GROUP". Builds are
+// admitted round-robin across the instances of a group which is at its limit,
+// so that no single instance can monopolize it.
+var InstanceGroupMaxInFlight map[string]int
+
+// MaxInFlightForInstanceGroup returns the number of builds which may run at
+// once across the instances of the given group, or 0 if it is unlimited.
+func MaxInFlightForInstanceGroup(teamName string, groupName string) int {
+	return InstanceGroupMaxInFlight[teamName+"/"+groupName]
+}
@@ -14,6 +14,19 @@ type JobConfig struct {
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	TestReportWebhook string `json:"test_report_webhook,omitempty"`
+
+	AutoRerun *AutoRerunConfig `json:"auto_rerun,omitempty"`
+
+	// BuildTimeout bounds the duration of an entire build of the job,
+	// including its hooks.
+	BuildTimeout string `json:"build_timeout,omitempty"`
+
+	// Priority ranks the job's builds against those of the team's other jobs.
+	// A pending build which has been waiting for long enough may preempt a
+	// running build of a lower-priority job.
+	Priority int `json:"priority,omitempty"`
+
 	OnSuccess *Step `json:"on_success,omitempty"`
 	OnFailure *Step `json:"on_failure,omitempty"`
 	OnAbort   *Step `json:"on_abort,omitempty"`
@@ -21,6 +34,22 @@ type JobConfig struct {
 	Ensure    *Step `json:"ensure,omitempty"`
 
 	PlanSequence []Step `json:"plan"`
+
+	// PipelineHooks are the hooks of the pipeline the job belongs to. They
+	// are not part of the job's own config, and so are not marshalled with it.
+	PipelineHooks PipelineHooks `json:"-"`
+}
+
+// PipelineHooks are configured at the top level of a pipeline, and run after
+// the plan of every job in the pipeline, including the job's own hooks.
+type PipelineHooks struct {
+	OnSuccess *Step `json:"on_success,omitempty"`
+	OnFailure *Step `json:"on_failure,omitempty"`
+	OnError   *Step `json:"on_error,omitempty"`
+}
+
+func (hooks PipelineHooks) IsZero() bool {
+	return hooks.OnSuccess == nil && hooks.OnFailure == nil && hooks.OnError == nil
 }
 
 type BuildLogRetention struct {
@@ -29,6 +58,47 @@ type BuildLogRetention struct {
 	Days                   int `json:"days,omitempty"`
 }
 
+// AutoRerunConfig configures a job to automatically rerun builds which
+// errored for reasons which are likely to be transient, e.g. the worker
+// running the build going away.
+type AutoRerunConfig struct {
+	Attempts int                `json:"attempts"`
+	Delay    string             `json:"delay,omitempty"`
+	On       []AutoRerunTrigger `json:"on,omitempty"`
+}
+
+type AutoRerunTrigger string
+
+const (
+	AutoRerunOnWorkerLost       AutoRerunTrigger = "worker_lost"
+	AutoRerunOnImageFetchFailed AutoRerunTrigger = "image_fetch_failed"
+)
+
+var AutoRerunTriggers = []AutoRerunTrigger{
+	AutoRerunOnWorkerLost,
+	AutoRerunOnImageFetchFailed,
+}
+
+// Triggers returns the error classes which cause a rerun, defaulting to all
+// of them when none are configured.
+func (config AutoRerunConfig) Triggers() []AutoRerunTrigger {
+	if len(config.On) == 0 {
+		return AutoRerunTriggers
+	}
+
+	return config.On
+}
+
+func (config AutoRerunConfig) RerunsOn(trigger AutoRerunTrigger) bool {
+	for _, t := range config.Triggers() {
+		if t == trigger {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (config JobConfig) Step() Step {
 	return Step{Config: config.StepConfig()}
 }
@@ -73,9 +143,45 @@ func (config JobConfig) StepConfig() StepConfig {
 		}
 	}
 
+	if config.PipelineHooks.OnSuccess != nil {
+		step = &OnSuccessStep{
+			Step: step,
+			Hook: *config.PipelineHooks.OnSuccess,
+		}
+	}
+
+	if config.PipelineHooks.OnFailure != nil {
+		step = &OnFailureStep{
+			Step: step,
+			Hook: *config.PipelineHooks.OnFailure,
+		}
+	}
+
+	if config.PipelineHooks.OnError != nil {
+		step = &OnErrorStep{
+			Step: step,
+			Hook: *config.PipelineHooks.OnError,
+		}
+	}
+
 	return step
 }
 
+// BuildStepConfig returns the step config run by the job's builds: its
+// StepConfig, bounded by its build_timeout if one is configured.
+func (config JobConfig) BuildStepConfig() StepConfig {
+	step := config.StepConfig()
+	if config.BuildTimeout == "" {
+		return step
+	}
+
+	return &TimeoutStep{
+		Step:     step,
+		Duration: config.BuildTimeout,
+		Build:    true,
+	}
+}
+
 func (config JobConfig) MaxInFlight() int {
 	if config.Serial || len(config.SerialGroups) > 0 {
 		return 1
@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"encoding/json"
+
 	"github.com/concourse/concourse/atc"
 
 	. "github.com/onsi/ginkgo"
@@ -655,6 +657,39 @@ var _ = Describe("JobConfig", func() {
 			})
 		})
 
+		Context("when the pipeline has hooks", func() {
+			BeforeEach(func() {
+				jobConfig.PlanSequence = []atc.Step{
+					{
+						Config: &atc.PutStep{
+							Name: "a",
+						},
+					},
+				}
+
+				jobConfig.PipelineHooks = atc.PipelineHooks{
+					OnFailure: &atc.Step{
+						Config: &atc.PutStep{
+							Name: "notify",
+						},
+					},
+				}
+			})
+
+			It("returns an output for the puts in the pipeline hooks", func() {
+				Expect(outputs).To(ConsistOf(
+					atc.JobOutput{
+						Name:     "a",
+						Resource: "a",
+					},
+					atc.JobOutput{
+						Name:     "notify",
+						Resource: "notify",
+					},
+				))
+			})
+		})
+
 		Context("when the plan contains no puts steps", func() {
 			BeforeEach(func() {
 				jobConfig.PlanSequence = []atc.Step{
@@ -671,4 +706,88 @@ var _ = Describe("JobConfig", func() {
 			})
 		})
 	})
+
+	Describe("StepConfig", func() {
+		var jobConfig atc.JobConfig
+
+		BeforeEach(func() {
+			jobConfig = atc.JobConfig{
+				PlanSequence: []atc.Step{
+					{Config: &atc.TaskStep{Name: "some-task"}},
+				},
+				OnSuccess: &atc.Step{
+					Config: &atc.TaskStep{Name: "job-success"},
+				},
+			}
+		})
+
+		It("does not wrap the plan when the pipeline has no hooks", func() {
+			Expect(jobConfig.StepConfig()).To(Equal(&atc.OnSuccessStep{
+				Step: &atc.DoStep{Steps: jobConfig.PlanSequence},
+				Hook: atc.Step{Config: &atc.TaskStep{Name: "job-success"}},
+			}))
+		})
+
+		Context("when the pipeline has hooks", func() {
+			BeforeEach(func() {
+				jobConfig.PipelineHooks = atc.PipelineHooks{
+					OnSuccess: &atc.Step{Config: &atc.TaskStep{Name: "pipeline-success"}},
+					OnFailure: &atc.Step{Config: &atc.TaskStep{Name: "pipeline-failure"}},
+					OnError:   &atc.Step{Config: &atc.TaskStep{Name: "pipeline-error"}},
+				}
+			})
+
+			It("wraps the job's plan and hooks with the pipeline's hooks", func() {
+				Expect(jobConfig.StepConfig()).To(Equal(&atc.OnErrorStep{
+					Step: &atc.OnFailureStep{
+						Step: &atc.OnSuccessStep{
+							Step: &atc.OnSuccessStep{
+								Step: &atc.DoStep{Steps: jobConfig.PlanSequence},
+								Hook: atc.Step{Config: &atc.TaskStep{Name: "job-success"}},
+							},
+							Hook: atc.Step{Config: &atc.TaskStep{Name: "pipeline-success"}},
+						},
+						Hook: atc.Step{Config: &atc.TaskStep{Name: "pipeline-failure"}},
+					},
+					Hook: atc.Step{Config: &atc.TaskStep{Name: "pipeline-error"}},
+				}))
+			})
+
+			It("does not marshal them with the job", func() {
+				payload, err := json.Marshal(jobConfig)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(payload)).ToNot(ContainSubstring("pipeline-"))
+			})
+		})
+	})
+
+	Describe("BuildStepConfig", func() {
+		var jobConfig atc.JobConfig
+
+		BeforeEach(func() {
+			jobConfig = atc.JobConfig{
+				PlanSequence: []atc.Step{
+					{Config: &atc.TaskStep{Name: "some-task"}},
+				},
+			}
+		})
+
+		It("returns the step config when there is no build_timeout", func() {
+			Expect(jobConfig.BuildStepConfig()).To(Equal(jobConfig.StepConfig()))
+		})
+
+		Context("when the job has a build_timeout", func() {
+			BeforeEach(func() {
+				jobConfig.BuildTimeout = "1h"
+			})
+
+			It("wraps the step config with a build timeout", func() {
+				Expect(jobConfig.BuildStepConfig()).To(Equal(&atc.TimeoutStep{
+					Step:     jobConfig.StepConfig(),
+					Duration: "1h",
+					Build:    true,
+				}))
+			})
+		})
+	})
 })
@@ -2,9 +2,15 @@ package lidar
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -13,16 +19,45 @@ import (
 	"github.com/concourse/concourse/tracing"
 )
 
-func NewScanner(checkFactory db.CheckFactory, planFactory atc.PlanFactory) *scanner {
+// NewScanner constructs a scanner which creates the periodic checks of all
+// resources. At most maxChecksPerPipeline checks of any one pipeline are
+// running at once; 0 means unlimited.
+//
+// Rather than loading every resource on each run, the scanner keeps track of
+// when each resource is next due to be checked, and only loads the resources
+// which are due or which it has been notified of a change to (see the
+// resources_upsert_trigger). All of the resources are re-scanned every
+// fullScanInterval in case a notification was missed; 0 means every run is a
+// full scan.
+func NewScanner(
+	checkFactory db.CheckFactory,
+	planFactory atc.PlanFactory,
+	maxChecksPerPipeline int,
+	notifications <-chan db.Notification,
+	fullScanInterval time.Duration,
+) *scanner {
 	return &scanner{
-		checkFactory: checkFactory,
-		planFactory:  planFactory,
+		checkFactory:         checkFactory,
+		planFactory:          planFactory,
+		maxChecksPerPipeline: maxChecksPerPipeline,
+		notifications:        notifications,
+		fullScanInterval:     fullScanInterval,
+		schedule:             map[int]time.Time{},
 	}
 }
 
 type scanner struct {
-	checkFactory db.CheckFactory
-	planFactory  atc.PlanFactory
+	checkFactory         db.CheckFactory
+	planFactory          atc.PlanFactory
+	maxChecksPerPipeline int
+
+	notifications    <-chan db.Notification
+	fullScanInterval time.Duration
+
+	// schedule is when each resource is next due to be checked, keyed by
+	// resource ID. A zero time means as soon as possible.
+	schedule     map[int]time.Time
+	lastFullScan time.Time
 }
 
 func (s *scanner) Run(ctx context.Context) error {
@@ -34,7 +69,26 @@ func (s *scanner) Run(ctx context.Context) error {
 	logger.Info("start")
 	defer logger.Info("end")
 
-	resources, err := s.checkFactory.Resources()
+	now := time.Now()
+
+	fullScan := s.receiveNotifications(logger)
+	if s.fullScanInterval <= 0 || now.Sub(s.lastFullScan) >= s.fullScanInterval {
+		fullScan = true
+	}
+
+	var resources []db.Resource
+	var err error
+	if fullScan {
+		resources, err = s.checkFactory.Resources()
+	} else {
+		due := s.dueResources(now)
+		if len(due) == 0 {
+			logger.Debug("no-resources-due")
+			return nil
+		}
+
+		resources, err = s.checkFactory.ResourcesByIDs(due)
+	}
 	if err != nil {
 		logger.Error("failed-to-get-resources", err)
 		return err
@@ -46,34 +100,235 @@ func (s *scanner) Run(ctx context.Context) error {
 		return err
 	}
 
-	s.scanResources(spanCtx, resources, resourceTypes)
+	slots := &pipelineCheckSlots{
+		limit:   s.maxChecksPerPipeline,
+		running: map[int]int{},
+	}
+
+	if slots.limit > 0 {
+		running, err := s.checkFactory.RunningChecksByPipeline()
+		if err != nil {
+			logger.Error("failed-to-get-running-checks", err)
+			return err
+		}
+
+		for pipelineID, count := range running {
+			slots.running[pipelineID] = count
+		}
+	}
+
+	s.scanResources(spanCtx, resources, resourceTypes, slots)
+
+	if fullScan {
+		s.schedule = map[int]time.Time{}
+		s.lastFullScan = now
+	} else {
+		// resources which weren't found have been removed, or are no longer
+		// checked periodically
+		for _, id := range s.dueResources(now) {
+			delete(s.schedule, id)
+		}
+	}
+
+	for _, resource := range resources {
+		s.reschedule(resource)
+	}
 
 	return nil
 }
 
-func (s *scanner) scanResources(ctx context.Context, resources []db.Resource, resourceTypesMap map[int]db.ResourceTypes) {
+// receiveNotifications marks the resources which have changed since the last
+// run as due. It returns true if all of the resources need to be scanned,
+// either because the notification was not about a specific resource or because
+// notifications may have been missed.
+func (s *scanner) receiveNotifications(logger lager.Logger) bool {
+	var fullScan bool
+	for {
+		select {
+		case notification := <-s.notifications:
+			if !notification.Healthy || notification.Payload == "" {
+				fullScan = true
+				continue
+			}
+
+			var event db.TriggerEvent
+			if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+				logger.Error("invalid-payload", err)
+				fullScan = true
+				continue
+			}
+
+			idStr := event.Data["id"]
+			if idStr == nil {
+				logger.Info("missing-id")
+				fullScan = true
+				continue
+			}
+
+			id, err := strconv.Atoi(*idStr)
+			if err != nil {
+				logger.Error("invalid-id", err)
+				fullScan = true
+				continue
+			}
+
+			s.schedule[id] = time.Time{}
+		default:
+			return fullScan
+		}
+	}
+}
+
+func (s *scanner) dueResources(now time.Time) []int {
+	var due []int
+	for id, next := range s.schedule {
+		if !next.After(now) {
+			due = append(due, id)
+		}
+	}
+
+	sort.Ints(due)
+
+	return due
+}
+
+// reschedule records when the resource is next due to be checked. Resources
+// whose periodic checks are paused or disabled are left out until a
+// notification says they've changed.
+func (s *scanner) reschedule(resource db.Resource) {
+	if resource.ChecksPaused() || (resource.CheckEvery() != nil && resource.CheckEvery().Never) {
+		return
+	}
+
+	// a resource which is due but still being checked has a next check time in
+	// the past, so it is looked at again on the next run
+	s.schedule[resource.ID()] = resource.NextCheckTime()
+}
+
+func (s *scanner) scanResources(ctx context.Context, resources []db.Resource, resourceTypesMap map[int]db.ResourceTypes, slots *pipelineCheckSlots) {
 	logger := lagerctx.FromContext(ctx)
 	waitGroup := new(sync.WaitGroup)
-	for _, resource := range resources {
+	for _, group := range groupByConfig(withoutPausedChecks(resources)) {
 		waitGroup.Add(1)
 
-		resourceTypes := resourceTypesMap[resource.PipelineID()]
-		go func(r db.Resource, rts db.ResourceTypes) {
+		go func(group []db.Resource) {
 			defer func() {
-				err := util.DumpPanic(recover(), "scanning resource %d", r.ID())
+				err := util.DumpPanic(recover(), "scanning resource %d", group[0].ID())
 				if err != nil {
 					logger.Error("panic-in-scanner-run", err)
 				}
 			}()
 			defer waitGroup.Done()
 
-			s.check(ctx, r, rts)
-		}(resource, resourceTypes)
+			// the result of a check is fanned out to every resource in the
+			// group, so there's no need to create more than one
+			for _, r := range group {
+				if !slots.acquire(r.PipelineID()) {
+					logger.Debug("pipeline-check-limit-reached", lager.Data{"pipeline": r.PipelineName()})
+					continue
+				}
+
+				if s.check(ctx, r, resourceTypesMap[r.PipelineID()]) {
+					break
+				}
+
+				slots.release(r.PipelineID())
+			}
+		}(group)
 	}
 	waitGroup.Wait()
 }
 
-func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTypes db.ResourceTypes) {
+// pipelineCheckSlots keeps track of how many checks of each pipeline are
+// running, so that a pipeline with lots of resources can't take up all of the
+// check containers.
+type pipelineCheckSlots struct {
+	limit int
+
+	mut     sync.Mutex
+	running map[int]int
+}
+
+func (slots *pipelineCheckSlots) acquire(pipelineID int) bool {
+	if slots.limit <= 0 {
+		return true
+	}
+
+	slots.mut.Lock()
+	defer slots.mut.Unlock()
+
+	if slots.running[pipelineID] >= slots.limit {
+		return false
+	}
+
+	slots.running[pipelineID]++
+
+	return true
+}
+
+func (slots *pipelineCheckSlots) release(pipelineID int) {
+	if slots.limit <= 0 {
+		return
+	}
+
+	slots.mut.Lock()
+	defer slots.mut.Unlock()
+
+	slots.running[pipelineID]--
+}
+
+// withoutPausedChecks filters out the resources whose periodic checks are
+// paused.
+func withoutPausedChecks(resources []db.Resource) []db.Resource {
+	var checkable []db.Resource
+	for _, resource := range resources {
+		if !resource.ChecksPaused() {
+			checkable = append(checkable, resource)
+		}
+	}
+
+	return checkable
+}
+
+// groupByConfig groups together resources which share a resource config, and
+// so would run identical checks. Resources are only grouped when global
+// resources are enabled, as otherwise resources in different teams must not
+// share check results. Resources which haven't been checked yet, are pinned,
+// or require a unique version history are each in a group of their own.
+func groupByConfig(resources []db.Resource) [][]db.Resource {
+	var groups [][]db.Resource
+
+	groupIndexes := map[string]int{}
+	for _, resource := range resources {
+		if !atc.EnableGlobalResources ||
+			resource.ResourceConfigID() == 0 ||
+			resource.CurrentPinnedVersion() != nil ||
+			atc.RequiresUniqueVersionHistory(resource.TeamName(), resource.Config()) {
+			groups = append(groups, []db.Resource{resource})
+			continue
+		}
+
+		// tags decide which workers the check can run on, which may matter for
+		// what the check finds
+		tags := append([]string{}, resource.Tags()...)
+		sort.Strings(tags)
+
+		key := fmt.Sprintf("%d:%s", resource.ResourceConfigID(), strings.Join(tags, ","))
+
+		index, found := groupIndexes[key]
+		if !found {
+			groupIndexes[key] = len(groups)
+			groups = append(groups, []db.Resource{resource})
+			continue
+		}
+
+		groups[index] = append(groups[index], resource)
+	}
+
+	return groups
+}
+
+func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTypes db.ResourceTypes) bool {
 	logger := lagerctx.FromContext(ctx)
 
 	spanCtx, span := tracing.StartSpan(ctx, "scanner.check", tracing.Attrs{
@@ -88,13 +343,13 @@ func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTyp
 	version := checkable.CurrentPinnedVersion()
 
 	if checkable.CheckEvery() != nil && checkable.CheckEvery().Never {
-		return
+		return false
 	}
 
 	_, created, err := s.checkFactory.TryCreateCheck(lagerctx.NewContext(spanCtx, logger), checkable, resourceTypes, version, false, false)
 	if err != nil {
 		logger.Error("failed-to-create-check", err)
-		return
+		return false
 	}
 
 	if !created {
@@ -102,4 +357,6 @@ func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTyp
 	} else {
 		metric.Metrics.ChecksEnqueued.Inc()
 	}
+
+	return created
 }
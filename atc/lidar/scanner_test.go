@@ -3,6 +3,7 @@ package lidar_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -23,17 +24,23 @@ var _ = Describe("Scanner", func() {
 		fakeCheckFactory *dbfakes.FakeCheckFactory
 		planFactory      atc.PlanFactory
 
+		maxChecksPerPipeline int
+		notifications        chan db.Notification
+		fullScanInterval     time.Duration
+
 		scanner Scanner
 	)
 
 	BeforeEach(func() {
 		planFactory = atc.NewPlanFactory(0)
 		fakeCheckFactory = new(dbfakes.FakeCheckFactory)
-
-		scanner = lidar.NewScanner(fakeCheckFactory, planFactory)
+		maxChecksPerPipeline = 0
+		notifications = make(chan db.Notification, 10)
+		fullScanInterval = 0
 	})
 
 	JustBeforeEach(func() {
+		scanner = lidar.NewScanner(fakeCheckFactory, planFactory, maxChecksPerPipeline, notifications, fullScanInterval)
 		err = scanner.Run(context.TODO())
 	})
 
@@ -88,6 +95,16 @@ var _ = Describe("Scanner", func() {
 				})
 			})
 
+			Context("when the resource's checks are paused", func() {
+				BeforeEach(func() {
+					fakeResource.ChecksPausedReturns(true)
+				})
+
+				It("does not check the resource", func() {
+					Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when fetching resources types succeeds", func() {
 				var fakeResourceType *dbfakes.FakeResourceType
 
@@ -156,6 +173,251 @@ var _ = Describe("Scanner", func() {
 					})
 				})
 
+				Context("when other resources share the resource's config", func() {
+					var otherResource, untaggedResource *dbfakes.FakeResource
+
+					BeforeEach(func() {
+						atc.EnableGlobalResources = true
+
+						fakeResource.ResourceConfigIDReturns(5)
+
+						otherResource = new(dbfakes.FakeResource)
+						otherResource.NameReturns("other-name")
+						otherResource.TagsReturns([]string{"tag-b", "tag-a"})
+						otherResource.ResourceConfigIDReturns(5)
+
+						untaggedResource = new(dbfakes.FakeResource)
+						untaggedResource.NameReturns("untagged-name")
+						untaggedResource.ResourceConfigIDReturns(5)
+
+						fakeCheckFactory.ResourcesReturns([]db.Resource{fakeResource, otherResource, untaggedResource}, nil)
+					})
+
+					AfterEach(func() {
+						atc.EnableGlobalResources = false
+					})
+
+					checkedResources := func() []string {
+						var names []string
+						for i := 0; i < fakeCheckFactory.TryCreateCheckCallCount(); i++ {
+							_, checkable, _, _, _, _ := fakeCheckFactory.TryCreateCheckArgsForCall(i)
+							names = append(names, checkable.Name())
+						}
+
+						return names
+					}
+
+					Context("when a check is created for one of them", func() {
+						BeforeEach(func() {
+							fakeCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+						})
+
+						It("only creates one check for the resources with the same tags", func() {
+							Expect(checkedResources()).To(ConsistOf("untagged-name", Or(Equal("some-name"), Equal("other-name"))))
+						})
+					})
+
+					Context("when no check is created", func() {
+						BeforeEach(func() {
+							fakeCheckFactory.TryCreateCheckReturns(nil, false, nil)
+						})
+
+						It("tries each of the resources", func() {
+							Expect(checkedResources()).To(ConsistOf("some-name", "other-name", "untagged-name"))
+						})
+					})
+
+					Context("when global resources are disabled", func() {
+						BeforeEach(func() {
+							atc.EnableGlobalResources = false
+							fakeCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+						})
+
+						It("checks each of the resources", func() {
+							Expect(checkedResources()).To(ConsistOf("some-name", "other-name", "untagged-name"))
+						})
+					})
+
+					Context("when one of them is pinned", func() {
+						BeforeEach(func() {
+							otherResource.CurrentPinnedVersionReturns(atc.Version{"some": "version"})
+							fakeCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+						})
+
+						It("checks the pinned resource on its own", func() {
+							Expect(checkedResources()).To(ConsistOf("some-name", "other-name", "untagged-name"))
+						})
+					})
+
+					Context("when one of them requires a unique version history", func() {
+						BeforeEach(func() {
+							otherResource.ConfigReturns(atc.ResourceConfig{UniqueVersionHistory: true})
+							fakeCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+						})
+
+						It("checks that resource on its own", func() {
+							Expect(checkedResources()).To(ConsistOf("some-name", "other-name", "untagged-name"))
+						})
+					})
+				})
+
+				Context("when the number of checks per pipeline is limited", func() {
+					var otherResource, otherPipelineResource *dbfakes.FakeResource
+
+					BeforeEach(func() {
+						maxChecksPerPipeline = 2
+
+						fakeResource.PipelineIDReturns(1)
+
+						otherResource = new(dbfakes.FakeResource)
+						otherResource.NameReturns("other-name")
+						otherResource.PipelineIDReturns(1)
+
+						otherPipelineResource = new(dbfakes.FakeResource)
+						otherPipelineResource.NameReturns("other-pipeline-name")
+						otherPipelineResource.PipelineIDReturns(2)
+
+						fakeCheckFactory.ResourcesReturns([]db.Resource{fakeResource, otherResource, otherPipelineResource}, nil)
+						fakeCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+					})
+
+					Context("when the pipeline has room for its checks", func() {
+						It("checks all of the resources", func() {
+							Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(3))
+						})
+					})
+
+					Context("when the pipeline is already running checks", func() {
+						BeforeEach(func() {
+							fakeCheckFactory.RunningChecksByPipelineReturns(map[int]int{1: 1}, nil)
+						})
+
+						It("only checks as many of its resources as there is room for", func() {
+							var pipelineIDs []int
+							for i := 0; i < fakeCheckFactory.TryCreateCheckCallCount(); i++ {
+								_, checkable, _, _, _, _ := fakeCheckFactory.TryCreateCheckArgsForCall(i)
+								pipelineIDs = append(pipelineIDs, checkable.PipelineID())
+							}
+
+							Expect(pipelineIDs).To(ConsistOf(1, 2))
+						})
+					})
+
+					Context("when a check is not created", func() {
+						BeforeEach(func() {
+							fakeCheckFactory.RunningChecksByPipelineReturns(map[int]int{1: 1}, nil)
+							fakeCheckFactory.TryCreateCheckReturns(nil, false, nil)
+						})
+
+						It("gives up the pipeline's slot to another resource", func() {
+							Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(3))
+						})
+					})
+
+					Context("when fetching the running checks fails", func() {
+						BeforeEach(func() {
+							fakeCheckFactory.RunningChecksByPipelineReturns(nil, errors.New("nope"))
+						})
+
+						It("errors", func() {
+							Expect(err).To(HaveOccurred())
+						})
+					})
+				})
+
+				Context("when only scanning the resources which are due", func() {
+					var otherResource *dbfakes.FakeResource
+
+					BeforeEach(func() {
+						fullScanInterval = time.Hour
+
+						fakeResource.IDReturns(1)
+						fakeResource.LastCheckEndTimeReturns(time.Now())
+						fakeResource.NextCheckTimeReturns(time.Now().Add(time.Hour))
+
+						otherResource = new(dbfakes.FakeResource)
+						otherResource.IDReturns(2)
+						otherResource.NameReturns("other-name")
+						otherResource.LastCheckEndTimeReturns(time.Now().Add(-time.Hour))
+						otherResource.NextCheckTimeReturns(time.Now().Add(-time.Minute))
+
+						fakeCheckFactory.ResourcesReturns([]db.Resource{fakeResource, otherResource}, nil)
+						fakeCheckFactory.ResourcesByIDsReturns([]db.Resource{otherResource}, nil)
+					})
+
+					It("scans every resource on the first run", func() {
+						Expect(fakeCheckFactory.ResourcesCallCount()).To(Equal(1))
+						Expect(fakeCheckFactory.ResourcesByIDsCallCount()).To(Equal(0))
+					})
+
+					It("only loads the resources which are due on later runs", func() {
+						Expect(scanner.Run(context.TODO())).To(Succeed())
+
+						Expect(fakeCheckFactory.ResourcesCallCount()).To(Equal(1))
+						Expect(fakeCheckFactory.ResourcesByIDsCallCount()).To(Equal(1))
+						Expect(fakeCheckFactory.ResourcesByIDsArgsForCall(0)).To(Equal([]int{2}))
+					})
+
+					Context("when a resource that is not due is changed", func() {
+						It("loads it on the next run", func() {
+							notifications <- db.Notification{
+								Healthy: true,
+								Payload: `{"operation":"UPDATE","data":{"id":"1"}}`,
+							}
+
+							Expect(scanner.Run(context.TODO())).To(Succeed())
+
+							Expect(fakeCheckFactory.ResourcesByIDsCallCount()).To(Equal(1))
+							Expect(fakeCheckFactory.ResourcesByIDsArgsForCall(0)).To(Equal([]int{1, 2}))
+						})
+					})
+
+					Context("when no resources are due", func() {
+						BeforeEach(func() {
+							otherResource.NextCheckTimeReturns(time.Now().Add(time.Hour))
+						})
+
+						It("does not load any resources", func() {
+							Expect(scanner.Run(context.TODO())).To(Succeed())
+
+							Expect(fakeCheckFactory.ResourcesCallCount()).To(Equal(1))
+							Expect(fakeCheckFactory.ResourcesByIDsCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the scanner is notified without a resource", func() {
+						It("scans every resource", func() {
+							notifications <- db.Notification{Healthy: true}
+
+							Expect(scanner.Run(context.TODO())).To(Succeed())
+
+							Expect(fakeCheckFactory.ResourcesCallCount()).To(Equal(2))
+							Expect(fakeCheckFactory.ResourcesByIDsCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the notifications are unhealthy", func() {
+						It("scans every resource", func() {
+							notifications <- db.Notification{Healthy: false}
+
+							Expect(scanner.Run(context.TODO())).To(Succeed())
+
+							Expect(fakeCheckFactory.ResourcesCallCount()).To(Equal(2))
+						})
+					})
+
+					Context("when a resource's checks are paused", func() {
+						BeforeEach(func() {
+							otherResource.ChecksPausedReturns(true)
+						})
+
+						It("is not loaded again until it changes", func() {
+							Expect(scanner.Run(context.TODO())).To(Succeed())
+							Expect(fakeCheckFactory.ResourcesByIDsCallCount()).To(Equal(0))
+						})
+					})
+				})
+
 				Context("when there's a put-only resource", func() {
 					BeforeEach(func() {
 						By("checkFactory.Resources should not return any put-only resources")
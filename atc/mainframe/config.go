@@ -0,0 +1,229 @@
+package mainframe
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/skymarshal/skycmd"
+)
+
+const (
+	// TeamsDir holds one file per team, named after the team, in the same
+	// format as `fly set-team --config`.
+	TeamsDir = "teams"
+
+	// PipelinesDir holds one directory per team, each containing one file per
+	// pipeline, named after the pipeline.
+	PipelinesDir = "pipelines"
+
+	// SignatureFile holds the base64-encoded ed25519 signature of the
+	// configuration's manifest.
+	SignatureFile = "mainframe.sig"
+)
+
+var ErrSignatureMissing = errors.New("configuration is not signed")
+var ErrSignatureInvalid = errors.New("configuration signature is invalid")
+
+// Config is the desired state of the teams and seed pipelines of an install.
+type Config struct {
+	Teams     []Team
+	Pipelines []Pipeline
+
+	// Digest identifies the contents of the configuration, so that it is only
+	// reconciled when it changes.
+	Digest string
+}
+
+type Team struct {
+	Name string
+	Auth atc.TeamAuth
+}
+
+type Pipeline struct {
+	TeamName string
+	Name     string
+	Config   atc.Config
+}
+
+// Load reads the configuration from dir. If publicKey is given, the
+// configuration must carry a valid signature made with the matching private
+// key.
+//
+// The signature covers the manifest of the configuration: a line of the form
+// "<sha256>  <path>" for every file under the teams and pipelines
+// directories, sorted by path. This is the output of:
+//
+//	find teams pipelines -type f | LC_ALL=C sort | xargs sha256sum
+func Load(dir string, publicKey ed25519.PublicKey) (Config, error) {
+	paths, err := configFiles(dir)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// the files are read once, so that the configuration is parsed from
+	// exactly the contents covered by the signature
+	payloads, manifest, err := buildManifest(dir, paths)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if publicKey != nil {
+		err = verify(dir, manifest, publicKey)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	digest := sha256.Sum256(manifest)
+	config := Config{
+		Digest: hex.EncodeToString(digest[:]),
+	}
+
+	for _, path := range paths {
+		segments := strings.Split(path, "/")
+		ext := filepath.Ext(path)
+		name := strings.TrimSuffix(segments[len(segments)-1], ext)
+
+		if ext != ".yml" && ext != ".yaml" {
+			return Config{}, fmt.Errorf("%s: unexpected file", path)
+		}
+
+		switch {
+		case segments[0] == TeamsDir && len(segments) == 2:
+			auth, err := skycmd.FormatTeamConfig(payloads[path])
+			if err != nil {
+				return Config{}, fmt.Errorf("%s: %w", path, err)
+			}
+
+			config.Teams = append(config.Teams, Team{
+				Name: name,
+				Auth: auth,
+			})
+
+		case segments[0] == PipelinesDir && len(segments) == 3:
+			var pipelineConfig atc.Config
+			err := atc.UnmarshalConfig(payloads[path], &pipelineConfig)
+			if err != nil {
+				return Config{}, fmt.Errorf("%s: %w", path, err)
+			}
+
+			config.Pipelines = append(config.Pipelines, Pipeline{
+				TeamName: segments[1],
+				Name:     name,
+				Config:   pipelineConfig,
+			})
+
+		default:
+			return Config{}, fmt.Errorf("%s: unexpected file", path)
+		}
+	}
+
+	return config, nil
+}
+
+// ParsePublicKey parses a PEM-encoded ed25519 public key.
+func ParsePublicKey(payload []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(payload)
+	if block == nil {
+		return nil, errors.New("public key is not PEM-encoded")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key must be ed25519, got %T", key)
+	}
+
+	return publicKey, nil
+}
+
+func configFiles(dir string) ([]string, error) {
+	var paths []string
+	for _, sub := range []string{TeamsDir, PipelinesDir} {
+		err := filepath.Walk(filepath.Join(dir, sub), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			if info.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+
+			paths = append(paths, filepath.ToSlash(rel))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func buildManifest(dir string, paths []string) (map[string][]byte, []byte, error) {
+	payloads := map[string][]byte{}
+
+	var manifest strings.Builder
+	for _, path := range paths {
+		payload, err := ioutil.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		payloads[path] = payload
+
+		sum := sha256.Sum256(payload)
+		fmt.Fprintf(&manifest, "%s  %s\n", hex.EncodeToString(sum[:]), path)
+	}
+
+	return payloads, []byte(manifest.String()), nil
+}
+
+func verify(dir string, manifest []byte, publicKey ed25519.PublicKey) error {
+	encoded, err := ioutil.ReadFile(filepath.Join(dir, SignatureFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrSignatureMissing
+		}
+
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	if !ed25519.Verify(publicKey, manifest, signature) {
+		return ErrSignatureInvalid
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package mainframe_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/mainframe"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Load", func() {
+	var (
+		dir       string
+		publicKey ed25519.PublicKey
+
+		config  mainframe.Config
+		loadErr error
+	)
+
+	writeFile := func(path string, content string) {
+		path = filepath.Join(dir, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	}
+
+	manifest := func(paths ...string) []byte {
+		var manifest string
+		for _, path := range paths {
+			content, err := ioutil.ReadFile(filepath.Join(dir, path))
+			Expect(err).ToNot(HaveOccurred())
+
+			sum := sha256.Sum256(content)
+			manifest += fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), path)
+		}
+
+		return []byte(manifest)
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "mainframe-config")
+		Expect(err).ToNot(HaveOccurred())
+
+		publicKey = nil
+
+		writeFile("teams/some-team.yml", `
+roles:
+- name: owner
+  local:
+    users: ["some-user"]
+`)
+
+		writeFile("pipelines/some-team/some-pipeline.yml", `
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+    config:
+      platform: linux
+      run: {path: "true"}
+`)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		config, loadErr = mainframe.Load(dir, publicKey)
+	})
+
+	It("loads the teams", func() {
+		Expect(loadErr).ToNot(HaveOccurred())
+		Expect(config.Teams).To(Equal([]mainframe.Team{
+			{
+				Name: "some-team",
+				Auth: atc.TeamAuth{
+					"owner": map[string][]string{
+						"users":  {"local:some-user"},
+						"groups": {},
+					},
+				},
+			},
+		}))
+	})
+
+	It("loads the pipelines", func() {
+		Expect(loadErr).ToNot(HaveOccurred())
+		Expect(config.Pipelines).To(HaveLen(1))
+		Expect(config.Pipelines[0].TeamName).To(Equal("some-team"))
+		Expect(config.Pipelines[0].Name).To(Equal("some-pipeline"))
+		Expect(config.Pipelines[0].Config.Jobs).To(HaveLen(1))
+		Expect(config.Pipelines[0].Config.Jobs[0].Name).To(Equal("some-job"))
+	})
+
+	It("digests the contents of the configuration", func() {
+		digest := sha256.Sum256(manifest(
+			"pipelines/some-team/some-pipeline.yml",
+			"teams/some-team.yml",
+		))
+
+		Expect(config.Digest).To(Equal(hex.EncodeToString(digest[:])))
+	})
+
+	Context("when the configuration contains an unexpected file", func() {
+		BeforeEach(func() {
+			writeFile("pipelines/some-pipeline.yml", "{}")
+		})
+
+		It("errors", func() {
+			Expect(loadErr).To(MatchError("pipelines/some-pipeline.yml: unexpected file"))
+		})
+	})
+
+	Context("when a pipeline is malformed", func() {
+		BeforeEach(func() {
+			writeFile("pipelines/some-team/some-pipeline.yml", "jobs: 42")
+		})
+
+		It("errors", func() {
+			Expect(loadErr).To(HaveOccurred())
+			Expect(loadErr.Error()).To(HavePrefix("pipelines/some-team/some-pipeline.yml: "))
+		})
+	})
+
+	Context("when a public key is configured", func() {
+		var privateKey ed25519.PrivateKey
+
+		BeforeEach(func() {
+			var err error
+			publicKey, privateKey, err = ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the configuration is signed", func() {
+			BeforeEach(func() {
+				signature := ed25519.Sign(privateKey, manifest(
+					"pipelines/some-team/some-pipeline.yml",
+					"teams/some-team.yml",
+				))
+
+				writeFile(mainframe.SignatureFile, base64.StdEncoding.EncodeToString(signature)+"\n")
+			})
+
+			It("loads the configuration", func() {
+				Expect(loadErr).ToNot(HaveOccurred())
+				Expect(config.Teams).To(HaveLen(1))
+				Expect(config.Pipelines).To(HaveLen(1))
+			})
+
+			Context("when a file is changed after signing", func() {
+				BeforeEach(func() {
+					writeFile("teams/some-team.yml", `
+roles:
+- name: owner
+  local:
+    users: ["some-intruder"]
+`)
+				})
+
+				It("errors", func() {
+					Expect(loadErr).To(Equal(mainframe.ErrSignatureInvalid))
+				})
+			})
+		})
+
+		Context("when the configuration is not signed", func() {
+			It("errors", func() {
+				Expect(loadErr).To(Equal(mainframe.ErrSignatureMissing))
+			})
+		})
+	})
+})
+
+var _ = Describe("ParsePublicKey", func() {
+	It("parses a PEM-encoded ed25519 public key", func() {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		parsed, err := mainframe.ParsePublicKey(pem.EncodeToMemory(&pem.Block{
+			Type:  "PUBLIC KEY",
+			Bytes: der,
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed).To(Equal(publicKey))
+	})
+
+	It("errors when the key is not PEM-encoded", func() {
+		_, err := mainframe.ParsePublicKey([]byte("nope"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,13 @@
+package mainframe_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMainframe(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mainframe Suite")
+}
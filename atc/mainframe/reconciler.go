@@ -0,0 +1,219 @@
+package mainframe
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Reconciler makes the teams and seed pipelines of the install match the
+// configuration provided by its Source. It runs on an interval, but only
+// applies the configuration when it has changed since the last run.
+//
+// Teams and pipelines which are not part of the configuration are left alone.
+type Reconciler struct {
+	source      Source
+	publicKey   ed25519.PublicKey
+	teamFactory db.TeamFactory
+
+	lastDigest string
+}
+
+func NewReconciler(source Source, publicKey ed25519.PublicKey, teamFactory db.TeamFactory) *Reconciler {
+	return &Reconciler{
+		source:      source,
+		publicKey:   publicKey,
+		teamFactory: teamFactory,
+	}
+}
+
+func (r *Reconciler) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	dir, err := r.source.Fetch(ctx)
+	if err != nil {
+		logger.Error("failed-to-fetch-config", err)
+		return err
+	}
+
+	config, err := Load(dir, r.publicKey)
+	if err != nil {
+		logger.Error("failed-to-load-config", err)
+		return err
+	}
+
+	if config.Digest == r.lastDigest {
+		return nil
+	}
+
+	// validate everything up front so that a mistake in one file doesn't
+	// leave the install half reconciled
+	err = r.validate(logger, config)
+	if err != nil {
+		logger.Error("invalid-config", err)
+		return err
+	}
+
+	for _, team := range config.Teams {
+		err = r.reconcileTeam(logger, team)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(config.Teams) > 0 {
+		err = r.teamFactory.NotifyCacher()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, pipeline := range config.Pipelines {
+		err = r.reconcilePipeline(logger, pipeline)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.lastDigest = config.Digest
+
+	logger.Info("reconciled", lager.Data{
+		"digest":    config.Digest,
+		"teams":     len(config.Teams),
+		"pipelines": len(config.Pipelines),
+	})
+
+	return nil
+}
+
+func (r *Reconciler) validate(logger lager.Logger, config Config) error {
+	declaredTeams := map[string]bool{}
+	for _, team := range config.Teams {
+		_, err := atc.ValidateIdentifier(team.Name, "team")
+		if err != nil {
+			return fmt.Errorf("team %s: %w", team.Name, err)
+		}
+
+		err = atc.Team{Name: team.Name, Auth: team.Auth}.Validate()
+		if err != nil {
+			return fmt.Errorf("team %s: %w", team.Name, err)
+		}
+
+		declaredTeams[team.Name] = true
+	}
+
+	for _, pipeline := range config.Pipelines {
+		ref := pipeline.TeamName + "/" + pipeline.Name
+
+		if !declaredTeams[pipeline.TeamName] {
+			_, found, err := r.teamFactory.FindTeam(pipeline.TeamName)
+			if err != nil {
+				return err
+			}
+
+			if !found {
+				return fmt.Errorf("pipeline %s: team %s not found", ref, pipeline.TeamName)
+			}
+		}
+
+		_, err := atc.ValidateIdentifier(pipeline.Name, "pipeline")
+		if err != nil {
+			return fmt.Errorf("pipeline %s: %w", ref, err)
+		}
+
+		warnings, errorMessages := configvalidate.Validate(pipeline.Config)
+		for _, warning := range warnings {
+			logger.Info("pipeline-config-warning", lager.Data{
+				"pipeline": ref,
+				"warning":  warning.Message,
+			})
+		}
+
+		if len(errorMessages) > 0 {
+			return fmt.Errorf("pipeline %s: %s", ref, strings.Join(errorMessages, "; "))
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileTeam(logger lager.Logger, config Team) error {
+	team, found, err := r.teamFactory.FindTeam(config.Name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		_, err = r.teamFactory.CreateTeam(atc.Team{
+			Name: config.Name,
+			Auth: config.Auth,
+		})
+		if err != nil {
+			return err
+		}
+
+		logger.Info("created-team", lager.Data{"team": config.Name})
+		return nil
+	}
+
+	err = team.UpdateProviderAuth(config.Auth)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("updated-team", lager.Data{"team": config.Name})
+
+	return nil
+}
+
+func (r *Reconciler) reconcilePipeline(logger lager.Logger, config Pipeline) error {
+	team, found, err := r.teamFactory.FindTeam(config.TeamName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("team %s not found", config.TeamName)
+	}
+
+	pipelineRef := atc.PipelineRef{Name: config.Name}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	fromVersion := db.ConfigVersion(0)
+	if found {
+		fromVersion = pipeline.ConfigVersion()
+
+		existingConfig, err := pipeline.Config()
+		if err != nil {
+			return err
+		}
+
+		if !existingConfig.Diff(ioutil.Discard, config.Config) {
+			return nil
+		}
+	}
+
+	_, _, err = team.SavePipeline(pipelineRef, config.Config, fromVersion, false)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("saved-pipeline", lager.Data{"team": config.TeamName, "pipeline": config.Name})
+
+	return nil
+}
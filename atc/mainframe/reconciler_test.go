@@ -0,0 +1,233 @@
+package mainframe_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/mainframe"
+	"github.com/concourse/concourse/atc/mainframe/mainframefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		dir             string
+		fakeSource      *mainframefakes.FakeSource
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+
+		reconciler *mainframe.Reconciler
+		runErr     error
+	)
+
+	writeFile := func(path string, content string) {
+		path = filepath.Join(dir, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	}
+
+	run := func() error {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		return reconciler.Run(ctx)
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "mainframe-config")
+		Expect(err).ToNot(HaveOccurred())
+
+		writeFile("teams/some-team.yml", `
+roles:
+- name: owner
+  local:
+    users: ["some-user"]
+`)
+
+		writeFile("pipelines/some-team/some-pipeline.yml", `
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+    config:
+      platform: linux
+      image_resource: {type: registry-image, source: {repository: busybox}}
+      run: {path: "true"}
+`)
+
+		fakeSource = new(mainframefakes.FakeSource)
+		fakeSource.FetchReturns(dir, nil)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		reconciler = mainframe.NewReconciler(fakeSource, nil, fakeTeamFactory)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		runErr = run()
+	})
+
+	Context("when the team exists", func() {
+		It("updates its auth", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+			Expect(fakeTeam.UpdateProviderAuthArgsForCall(0)).To(Equal(atc.TeamAuth{
+				"owner": map[string][]string{
+					"users":  {"local:some-user"},
+					"groups": {},
+				},
+			}))
+			Expect(fakeTeamFactory.CreateTeamCallCount()).To(BeZero())
+			Expect(fakeTeamFactory.NotifyCacherCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the team does not exist", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.FindTeamReturnsOnCall(0, nil, false, nil)
+			fakeTeamFactory.CreateTeamReturns(fakeTeam, nil)
+		})
+
+		It("creates it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeTeamFactory.CreateTeamCallCount()).To(Equal(1))
+			Expect(fakeTeamFactory.CreateTeamArgsForCall(0).Name).To(Equal("some-team"))
+		})
+	})
+
+	Context("when the pipeline does not exist", func() {
+		It("saves it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+
+			ref, config, from, paused := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(ref).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(config.Jobs[0].Name).To(Equal("some-job"))
+			Expect(from).To(Equal(db.ConfigVersion(0)))
+			Expect(paused).To(BeFalse())
+		})
+	})
+
+	Context("when the pipeline exists", func() {
+		var fakePipeline *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakePipeline = new(dbfakes.FakePipeline)
+			fakePipeline.ConfigVersionReturns(42)
+			fakeTeam.PipelineReturns(fakePipeline, true, nil)
+		})
+
+		Context("when its config differs", func() {
+			It("saves it from the current version", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+
+				_, _, from, _ := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(from).To(Equal(db.ConfigVersion(42)))
+			})
+		})
+
+		Context("when its config is unchanged", func() {
+			BeforeEach(func() {
+				config, err := mainframe.Load(dir, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				fakePipeline.ConfigReturns(config.Pipelines[0].Config, nil)
+			})
+
+			It("does not save it", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when a pipeline is invalid", func() {
+		BeforeEach(func() {
+			writeFile("pipelines/some-team/some-pipeline.yml", `
+jobs:
+- name: some-job
+  plan:
+  - get: some-missing-resource
+`)
+		})
+
+		It("errors without applying anything", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(runErr.Error()).To(ContainSubstring("pipeline some-team/some-pipeline"))
+			Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a pipeline belongs to an unknown team", func() {
+		BeforeEach(func() {
+			writeFile("pipelines/other-team/some-pipeline.yml", "{}")
+			fakeTeamFactory.FindTeamStub = func(name string) (db.Team, bool, error) {
+				return fakeTeam, name == "some-team", nil
+			}
+		})
+
+		It("errors without applying anything", func() {
+			Expect(runErr).To(MatchError("pipeline other-team/some-pipeline: team other-team not found"))
+			Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when fetching the configuration fails", func() {
+		var disaster = errors.New("nope")
+
+		BeforeEach(func() {
+			fakeSource.FetchReturns("", disaster)
+		})
+
+		It("errors", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when run again", func() {
+		Context("when the configuration is unchanged", func() {
+			It("does not reconcile again", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(run()).To(Succeed())
+
+				Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+				Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the configuration has changed", func() {
+			It("reconciles again", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+
+				writeFile("teams/some-team.yml", `
+roles:
+- name: owner
+  local:
+    users: ["some-other-user"]
+`)
+				Expect(run()).To(Succeed())
+
+				Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(2))
+				Expect(fakeTeam.UpdateProviderAuthArgsForCall(1)["owner"]["users"]).To(Equal([]string{"local:some-other-user"}))
+			})
+		})
+	})
+})
@@ -0,0 +1,93 @@
+package mainframe
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Source provides the directory the configuration is loaded from.
+//
+//counterfeiter:generate . Source
+type Source interface {
+	Fetch(context.Context) (string, error)
+}
+
+type dirSource struct {
+	dir string
+}
+
+// NewDirSource returns a Source for a local directory.
+func NewDirSource(dir string) Source {
+	return dirSource{dir: dir}
+}
+
+func (source dirSource) Fetch(context.Context) (string, error) {
+	return source.dir, nil
+}
+
+type gitSource struct {
+	uri    string
+	branch string
+	path   string
+
+	workDir string
+}
+
+// NewGitSource returns a Source which clones the given branch of a git
+// repository, pulling it again on every Fetch. path is the directory within
+// the repository containing the configuration.
+func NewGitSource(uri string, branch string, path string) Source {
+	return &gitSource{
+		uri:    uri,
+		branch: branch,
+		path:   path,
+	}
+}
+
+func (source *gitSource) Fetch(ctx context.Context) (string, error) {
+	if source.workDir == "" {
+		workDir, err := ioutil.TempDir("", "mainframe")
+		if err != nil {
+			return "", err
+		}
+
+		err = source.git(ctx, workDir, "clone", "--depth", "1", "--branch", source.branch, source.uri, ".")
+		if err != nil {
+			os.RemoveAll(workDir)
+			return "", err
+		}
+
+		source.workDir = workDir
+	} else {
+		err := source.git(ctx, source.workDir, "fetch", "--depth", "1", "origin", source.branch)
+		if err != nil {
+			return "", err
+		}
+
+		err = source.git(ctx, source.workDir, "reset", "--hard", "FETCH_HEAD")
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return filepath.Join(source.workDir, source.path), nil
+}
+
+func (source *gitSource) git(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
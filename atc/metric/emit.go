@@ -81,7 +81,8 @@ type Monitor struct {
 	ConcurrentRequests         map[string]*Gauge
 	ConcurrentRequestsLimitHit map[string]*Counter
 
-	VolumesStreamed Counter
+	VolumesStreamed      Counter
+	VolumesDeltaStreamed Counter
 
 	GetStepCacheHits       Counter
 	StreamedResourceCaches Counter
@@ -29,6 +29,36 @@ type FakePrometheusGarbageCollectable struct {
 	workerContainersLabelsReturnsOnCall map[int]struct {
 		result1 map[string]map[string]prometheus.Labels
 	}
+	WorkerStatsStub        func() map[string]*prometheus.GaugeVec
+	workerStatsMutex       sync.RWMutex
+	workerStatsArgsForCall []struct {
+	}
+	workerStatsReturns struct {
+		result1 map[string]*prometheus.GaugeVec
+	}
+	workerStatsReturnsOnCall map[int]struct {
+		result1 map[string]*prometheus.GaugeVec
+	}
+	WorkerStatsLabelsStub        func() map[string]prometheus.Labels
+	workerStatsLabelsMutex       sync.RWMutex
+	workerStatsLabelsArgsForCall []struct {
+	}
+	workerStatsLabelsReturns struct {
+		result1 map[string]prometheus.Labels
+	}
+	workerStatsLabelsReturnsOnCall map[int]struct {
+		result1 map[string]prometheus.Labels
+	}
+	WorkerStreamStatsStub        func() map[string]*prometheus.CounterVec
+	workerStreamStatsMutex       sync.RWMutex
+	workerStreamStatsArgsForCall []struct {
+	}
+	workerStreamStatsReturns struct {
+		result1 map[string]*prometheus.CounterVec
+	}
+	workerStreamStatsReturnsOnCall map[int]struct {
+		result1 map[string]*prometheus.CounterVec
+	}
 	WorkerTasksStub        func() *prometheus.GaugeVec
 	workerTasksMutex       sync.RWMutex
 	workerTasksArgsForCall []struct {
@@ -179,6 +209,165 @@ func (fake *FakePrometheusGarbageCollectable) WorkerContainersLabelsReturnsOnCal
 	}{result1}
 }
 
+func (fake *FakePrometheusGarbageCollectable) WorkerStats() map[string]*prometheus.GaugeVec {
+	fake.workerStatsMutex.Lock()
+	ret, specificReturn := fake.workerStatsReturnsOnCall[len(fake.workerStatsArgsForCall)]
+	fake.workerStatsArgsForCall = append(fake.workerStatsArgsForCall, struct {
+	}{})
+	stub := fake.WorkerStatsStub
+	fakeReturns := fake.workerStatsReturns
+	fake.recordInvocation("WorkerStats", []interface{}{})
+	fake.workerStatsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsCallCount() int {
+	fake.workerStatsMutex.RLock()
+	defer fake.workerStatsMutex.RUnlock()
+	return len(fake.workerStatsArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsCalls(stub func() map[string]*prometheus.GaugeVec) {
+	fake.workerStatsMutex.Lock()
+	defer fake.workerStatsMutex.Unlock()
+	fake.WorkerStatsStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsReturns(result1 map[string]*prometheus.GaugeVec) {
+	fake.workerStatsMutex.Lock()
+	defer fake.workerStatsMutex.Unlock()
+	fake.WorkerStatsStub = nil
+	fake.workerStatsReturns = struct {
+		result1 map[string]*prometheus.GaugeVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsReturnsOnCall(i int, result1 map[string]*prometheus.GaugeVec) {
+	fake.workerStatsMutex.Lock()
+	defer fake.workerStatsMutex.Unlock()
+	fake.WorkerStatsStub = nil
+	if fake.workerStatsReturnsOnCall == nil {
+		fake.workerStatsReturnsOnCall = make(map[int]struct {
+			result1 map[string]*prometheus.GaugeVec
+		})
+	}
+	fake.workerStatsReturnsOnCall[i] = struct {
+		result1 map[string]*prometheus.GaugeVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsLabels() map[string]prometheus.Labels {
+	fake.workerStatsLabelsMutex.Lock()
+	ret, specificReturn := fake.workerStatsLabelsReturnsOnCall[len(fake.workerStatsLabelsArgsForCall)]
+	fake.workerStatsLabelsArgsForCall = append(fake.workerStatsLabelsArgsForCall, struct {
+	}{})
+	stub := fake.WorkerStatsLabelsStub
+	fakeReturns := fake.workerStatsLabelsReturns
+	fake.recordInvocation("WorkerStatsLabels", []interface{}{})
+	fake.workerStatsLabelsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsLabelsCallCount() int {
+	fake.workerStatsLabelsMutex.RLock()
+	defer fake.workerStatsLabelsMutex.RUnlock()
+	return len(fake.workerStatsLabelsArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsLabelsCalls(stub func() map[string]prometheus.Labels) {
+	fake.workerStatsLabelsMutex.Lock()
+	defer fake.workerStatsLabelsMutex.Unlock()
+	fake.WorkerStatsLabelsStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsLabelsReturns(result1 map[string]prometheus.Labels) {
+	fake.workerStatsLabelsMutex.Lock()
+	defer fake.workerStatsLabelsMutex.Unlock()
+	fake.WorkerStatsLabelsStub = nil
+	fake.workerStatsLabelsReturns = struct {
+		result1 map[string]prometheus.Labels
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStatsLabelsReturnsOnCall(i int, result1 map[string]prometheus.Labels) {
+	fake.workerStatsLabelsMutex.Lock()
+	defer fake.workerStatsLabelsMutex.Unlock()
+	fake.WorkerStatsLabelsStub = nil
+	if fake.workerStatsLabelsReturnsOnCall == nil {
+		fake.workerStatsLabelsReturnsOnCall = make(map[int]struct {
+			result1 map[string]prometheus.Labels
+		})
+	}
+	fake.workerStatsLabelsReturnsOnCall[i] = struct {
+		result1 map[string]prometheus.Labels
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStreamStats() map[string]*prometheus.CounterVec {
+	fake.workerStreamStatsMutex.Lock()
+	ret, specificReturn := fake.workerStreamStatsReturnsOnCall[len(fake.workerStreamStatsArgsForCall)]
+	fake.workerStreamStatsArgsForCall = append(fake.workerStreamStatsArgsForCall, struct {
+	}{})
+	stub := fake.WorkerStreamStatsStub
+	fakeReturns := fake.workerStreamStatsReturns
+	fake.recordInvocation("WorkerStreamStats", []interface{}{})
+	fake.workerStreamStatsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStreamStatsCallCount() int {
+	fake.workerStreamStatsMutex.RLock()
+	defer fake.workerStreamStatsMutex.RUnlock()
+	return len(fake.workerStreamStatsArgsForCall)
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStreamStatsCalls(stub func() map[string]*prometheus.CounterVec) {
+	fake.workerStreamStatsMutex.Lock()
+	defer fake.workerStreamStatsMutex.Unlock()
+	fake.WorkerStreamStatsStub = stub
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStreamStatsReturns(result1 map[string]*prometheus.CounterVec) {
+	fake.workerStreamStatsMutex.Lock()
+	defer fake.workerStreamStatsMutex.Unlock()
+	fake.WorkerStreamStatsStub = nil
+	fake.workerStreamStatsReturns = struct {
+		result1 map[string]*prometheus.CounterVec
+	}{result1}
+}
+
+func (fake *FakePrometheusGarbageCollectable) WorkerStreamStatsReturnsOnCall(i int, result1 map[string]*prometheus.CounterVec) {
+	fake.workerStreamStatsMutex.Lock()
+	defer fake.workerStreamStatsMutex.Unlock()
+	fake.WorkerStreamStatsStub = nil
+	if fake.workerStreamStatsReturnsOnCall == nil {
+		fake.workerStreamStatsReturnsOnCall = make(map[int]struct {
+			result1 map[string]*prometheus.CounterVec
+		})
+	}
+	fake.workerStreamStatsReturnsOnCall[i] = struct {
+		result1 map[string]*prometheus.CounterVec
+	}{result1}
+}
+
 func (fake *FakePrometheusGarbageCollectable) WorkerTasks() *prometheus.GaugeVec {
 	fake.workerTasksMutex.Lock()
 	ret, specificReturn := fake.workerTasksReturnsOnCall[len(fake.workerTasksArgsForCall)]
@@ -398,6 +587,12 @@ func (fake *FakePrometheusGarbageCollectable) Invocations() map[string][][]inter
 	defer fake.workerContainersMutex.RUnlock()
 	fake.workerContainersLabelsMutex.RLock()
 	defer fake.workerContainersLabelsMutex.RUnlock()
+	fake.workerStatsMutex.RLock()
+	defer fake.workerStatsMutex.RUnlock()
+	fake.workerStatsLabelsMutex.RLock()
+	defer fake.workerStatsLabelsMutex.RUnlock()
+	fake.workerStreamStatsMutex.RLock()
+	defer fake.workerStreamStatsMutex.RUnlock()
 	fake.workerTasksMutex.RLock()
 	defer fake.workerTasksMutex.RUnlock()
 	fake.workerTasksLabelsMutex.RLock()
@@ -105,6 +105,7 @@ func (emitter *NewRelicEmitter) Emit(logger lager.Logger, event metric.Event) {
 		"worker unknown containers",
 		"worker unknown volumes",
 		"volumes streamed",
+		"volumes delta streamed",
 		"get step cache hits",
 		"streamed resource caches":
 		emitter.NewRelicBatch = append(emitter.NewRelicBatch, emitter.transformToNewRelicEvent(event, ""))
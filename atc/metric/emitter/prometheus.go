@@ -35,6 +35,7 @@ type PrometheusEmitter struct {
 
 	stepsWaiting         *prometheus.GaugeVec
 	stepsWaitingDuration *prometheus.HistogramVec
+	stepsPhaseDuration   *prometheus.HistogramVec
 
 	buildDurationsVec *prometheus.HistogramVec
 	buildsAborted     prometheus.Counter
@@ -65,10 +66,12 @@ type PrometheusEmitter struct {
 
 	checksEnqueued prometheus.Counter
 
-	volumesStreamed prometheus.Counter
+	volumesStreamed      prometheus.Counter
+	volumesDeltaStreamed prometheus.Counter
 
 	getStepCacheHits       prometheus.Counter
 	streamedResourceCaches prometheus.Counter
+	teamCachesEvicted      *prometheus.CounterVec
 
 	workerContainers        *prometheus.GaugeVec
 	workerUnknownContainers *prometheus.GaugeVec
@@ -76,10 +79,14 @@ type PrometheusEmitter struct {
 	workerUnknownVolumes    *prometheus.GaugeVec
 	workerTasks             *prometheus.GaugeVec
 	workersRegistered       *prometheus.GaugeVec
+	workerStats             map[string]*prometheus.GaugeVec
+	workerStreamStats       map[string]*prometheus.CounterVec
 
 	workerContainersLabels map[string]map[string]prometheus.Labels
 	workerVolumesLabels    map[string]map[string]prometheus.Labels
 	workerTasksLabels      map[string]map[string]prometheus.Labels
+	workerStatsLabels      map[string]prometheus.Labels
+	workerStreamTotals     map[string]map[string]float64
 	workerLastSeen         map[string]time.Time
 	mu                     sync.Mutex
 }
@@ -266,6 +273,16 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	}, []string{"platform", "teamId", "teamName", "type", "workerTags"})
 	prometheus.MustRegister(stepsWaitingDuration)
 
+	stepsPhaseDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   "concourse",
+		Subsystem:   "steps",
+		Name:        "phase_duration_seconds",
+		Help:        "Time steps spent waiting for a worker, creating their container, and running.",
+		ConstLabels: attributes,
+		Buckets:     []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"team", "pipeline", "phase"})
+	prometheus.MustRegister(stepsPhaseDuration)
+
 	buildsFinished := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace:   "concourse",
 		Subsystem:   "builds",
@@ -442,6 +459,36 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(workerTasks)
 
+	// disk and volume streaming stats reported by each worker's baggageclaim,
+	// keyed by the name of the event they are emitted as
+	workerStats := map[string]*prometheus.GaugeVec{}
+	for eventName, opts := range map[string]prometheus.GaugeOpts{
+		"worker disk total bytes": {Name: "disk_total_bytes", Help: "Size of the volumes disk per worker"},
+		"worker disk free bytes":  {Name: "disk_free_bytes", Help: "Free space on the volumes disk per worker"},
+	} {
+		opts.Namespace = "concourse"
+		opts.Subsystem = "workers"
+		opts.ConstLabels = attributes
+
+		workerStats[eventName] = prometheus.NewGaugeVec(opts, []string{"worker", "platform"})
+		prometheus.MustRegister(workerStats[eventName])
+	}
+
+	workerStreamStats := map[string]*prometheus.CounterVec{}
+	for eventName, opts := range map[string]prometheus.CounterOpts{
+		"worker volume streams in":         {Name: "volume_streams_in_total", Help: "Total number of volume streams into each worker"},
+		"worker volume streams out":        {Name: "volume_streams_out_total", Help: "Total number of volume streams out of each worker"},
+		"worker volume streamed in bytes":  {Name: "volume_streamed_in_bytes_total", Help: "Total bytes streamed into volumes on each worker"},
+		"worker volume streamed out bytes": {Name: "volume_streamed_out_bytes_total", Help: "Total bytes streamed out of volumes on each worker"},
+	} {
+		opts.Namespace = "concourse"
+		opts.Subsystem = "workers"
+		opts.ConstLabels = attributes
+
+		workerStreamStats[eventName] = prometheus.NewCounterVec(opts, []string{"worker", "platform"})
+		prometheus.MustRegister(workerStreamStats[eventName])
+	}
+
 	workersRegistered := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace:   "concourse",
@@ -533,6 +580,17 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(volumesStreamed)
 
+	volumesDeltaStreamed := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   "concourse",
+			Subsystem:   "volumes",
+			Name:        "volumes_delta_streamed",
+			Help:        "Total number of volumes streamed from one worker to the other as the difference from a volume on the other",
+			ConstLabels: attributes,
+		},
+	)
+	prometheus.MustRegister(volumesDeltaStreamed)
+
 	getStepCacheHits := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   "concourse",
@@ -555,6 +613,18 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(streamedResourceCaches)
 
+	teamCachesEvicted := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "concourse",
+			Subsystem:   "caches",
+			Name:        "team_caches_evicted_total",
+			Help:        "Total number of resource caches evicted from workers for exceeding a team's cache quota",
+			ConstLabels: attributes,
+		},
+		[]string{"team_name", "worker"},
+	)
+	prometheus.MustRegister(teamCachesEvicted)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -577,6 +647,7 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 
 		stepsWaiting:         stepsWaiting,
 		stepsWaitingDuration: stepsWaitingDuration,
+		stepsPhaseDuration:   stepsPhaseDuration,
 
 		buildDurationsVec: buildDurationsVec,
 		buildsAborted:     buildsAborted,
@@ -609,6 +680,10 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 
 		workerContainers:        workerContainers,
 		workersRegistered:       workersRegistered,
+		workerStats:             workerStats,
+		workerStatsLabels:       map[string]prometheus.Labels{},
+		workerStreamStats:       workerStreamStats,
+		workerStreamTotals:      map[string]map[string]float64{},
 		workerContainersLabels:  map[string]map[string]prometheus.Labels{},
 		workerVolumesLabels:     map[string]map[string]prometheus.Labels{},
 		workerTasksLabels:       map[string]map[string]prometheus.Labels{},
@@ -618,10 +693,12 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 		workerUnknownContainers: workerUnknownContainers,
 		workerUnknownVolumes:    workerUnknownVolumes,
 
-		volumesStreamed: volumesStreamed,
+		volumesStreamed:      volumesStreamed,
+		volumesDeltaStreamed: volumesDeltaStreamed,
 
 		getStepCacheHits:       getStepCacheHits,
 		streamedResourceCaches: streamedResourceCaches,
+		teamCachesEvicted:      teamCachesEvicted,
 	}
 	go emitter.periodicMetricGC()
 
@@ -679,6 +756,13 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 				event.Attributes["type"],
 				event.Attributes["workerTags"],
 			).Observe(event.Value)
+	case "step phase duration":
+		emitter.stepsPhaseDuration.
+			WithLabelValues(
+				event.Attributes["team_name"],
+				event.Attributes["pipeline"],
+				event.Attributes["phase"],
+			).Observe(event.Value)
 	case "build finished":
 		emitter.buildFinishedMetrics(logger, event)
 	case "check build finished":
@@ -693,6 +777,14 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.workerUnknownVolumesMetric(logger, event)
 	case "worker tasks":
 		emitter.workerTasksMetric(logger, event)
+	case "worker disk total bytes",
+		"worker disk free bytes":
+		emitter.workerStatsMetric(logger, event)
+	case "worker volume streams in",
+		"worker volume streams out",
+		"worker volume streamed in bytes",
+		"worker volume streamed out bytes":
+		emitter.workerStreamStatsMetric(logger, event)
 	case "worker state":
 		emitter.workersRegisteredMetric(logger, event)
 	case "http response time":
@@ -711,10 +803,16 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.checksEnqueued.Add(event.Value)
 	case "volumes streamed":
 		emitter.volumesStreamed.Add(event.Value)
+	case "volumes delta streamed":
+		emitter.volumesDeltaStreamed.Add(event.Value)
 	case "get step cache hits":
 		emitter.getStepCacheHits.Add(event.Value)
 	case "streamed resource caches":
 		emitter.streamedResourceCaches.Add(event.Value)
+	case "team caches evicted":
+		emitter.teamCachesEvicted.
+			WithLabelValues(event.Attributes["team_name"], event.Attributes["worker"]).
+			Add(event.Value)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -959,6 +1057,58 @@ func (emitter *PrometheusEmitter) workerTasksMetric(logger lager.Logger, event m
 	emitter.workerTasks.With(emitter.workerTasksLabels[worker][key]).Set(event.Value)
 }
 
+func (emitter *PrometheusEmitter) workerStatsMetric(logger lager.Logger, event metric.Event) {
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+		return
+	}
+
+	labels := prometheus.Labels{
+		"worker":   worker,
+		"platform": event.Attributes["platform"],
+	}
+
+	emitter.mu.Lock()
+	emitter.workerStatsLabels[worker] = labels
+	emitter.mu.Unlock()
+
+	emitter.workerStats[event.Name].With(labels).Set(event.Value)
+}
+
+// workerStreamStatsMetric adds to the counters what has been streamed since
+// the worker last reported. Workers report their totals since they started,
+// so a total lower than the last one means the worker has restarted.
+func (emitter *PrometheusEmitter) workerStreamStatsMetric(logger lager.Logger, event metric.Event) {
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+		return
+	}
+
+	labels := prometheus.Labels{
+		"worker":   worker,
+		"platform": event.Attributes["platform"],
+	}
+
+	emitter.mu.Lock()
+	emitter.workerStatsLabels[worker] = labels
+
+	if _, found := emitter.workerStreamTotals[worker]; !found {
+		emitter.workerStreamTotals[worker] = map[string]float64{}
+	}
+
+	increase := event.Value
+	if last := emitter.workerStreamTotals[worker][event.Name]; event.Value >= last {
+		increase = event.Value - last
+	}
+
+	emitter.workerStreamTotals[worker][event.Name] = event.Value
+	emitter.mu.Unlock()
+
+	emitter.workerStreamStats[event.Name].With(labels).Add(increase)
+}
+
 func (emitter *PrometheusEmitter) httpResponseTimeMetrics(logger lager.Logger, event metric.Event) {
 	route, exists := event.Attributes["route"]
 	if !exists {
@@ -1015,6 +1165,7 @@ func (emitter *PrometheusEmitter) periodicMetricGC() {
 			if now.Sub(lastSeen) > 5*time.Minute {
 				DoGarbageCollection(emitter, worker)
 				delete(emitter.workerLastSeen, worker)
+				delete(emitter.workerStreamTotals, worker)
 			}
 		}
 		emitter.mu.Unlock()
@@ -1036,9 +1187,20 @@ func DoGarbageCollection(emitter PrometheusGarbageCollectable, worker string) {
 		emitter.WorkerTasks().Delete(labels)
 	}
 
+	if labels, found := emitter.WorkerStatsLabels()[worker]; found {
+		for _, gauge := range emitter.WorkerStats() {
+			gauge.Delete(labels)
+		}
+
+		for _, counter := range emitter.WorkerStreamStats() {
+			counter.Delete(labels)
+		}
+	}
+
 	delete(emitter.WorkerContainersLabels(), worker)
 	delete(emitter.WorkerVolumesLabels(), worker)
 	delete(emitter.WorkerTasksLabels(), worker)
+	delete(emitter.WorkerStatsLabels(), worker)
 }
 
 //counterfeiter:generate . PrometheusGarbageCollectable
@@ -1046,10 +1208,13 @@ type PrometheusGarbageCollectable interface {
 	WorkerContainers() *prometheus.GaugeVec
 	WorkerVolumes() *prometheus.GaugeVec
 	WorkerTasks() *prometheus.GaugeVec
+	WorkerStats() map[string]*prometheus.GaugeVec
+	WorkerStreamStats() map[string]*prometheus.CounterVec
 
 	WorkerContainersLabels() map[string]map[string]prometheus.Labels
 	WorkerVolumesLabels() map[string]map[string]prometheus.Labels
 	WorkerTasksLabels() map[string]map[string]prometheus.Labels
+	WorkerStatsLabels() map[string]prometheus.Labels
 }
 
 func (emitter *PrometheusEmitter) WorkerContainers() *prometheus.GaugeVec {
@@ -1075,3 +1240,15 @@ func (emitter *PrometheusEmitter) WorkerVolumesLabels() map[string]map[string]pr
 func (emitter *PrometheusEmitter) WorkerTasksLabels() map[string]map[string]prometheus.Labels {
 	return emitter.workerTasksLabels
 }
+
+func (emitter *PrometheusEmitter) WorkerStats() map[string]*prometheus.GaugeVec {
+	return emitter.workerStats
+}
+
+func (emitter *PrometheusEmitter) WorkerStreamStats() map[string]*prometheus.CounterVec {
+	return emitter.workerStreamStats
+}
+
+func (emitter *PrometheusEmitter) WorkerStatsLabels() map[string]prometheus.Labels {
+	return emitter.workerStatsLabels
+}
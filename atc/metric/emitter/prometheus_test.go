@@ -26,10 +26,13 @@ var _ = Describe("PrometheusEmitter garbage collector", func() {
 		workerContainers *prometheus.GaugeVec
 		workerVolumes    *prometheus.GaugeVec
 		workerTasks      *prometheus.GaugeVec
+		workerDiskFree   *prometheus.GaugeVec
+		workerStreamsIn  *prometheus.CounterVec
 
 		workerContainersLabels map[string]map[string]prometheus.Labels
 		workerVolumesLabels    map[string]map[string]prometheus.Labels
 		workerTasksLabels      map[string]map[string]prometheus.Labels
+		workerStatsLabels      map[string]prometheus.Labels
 	)
 
 	BeforeEach(func() {
@@ -66,9 +69,32 @@ var _ = Describe("PrometheusEmitter garbage collector", func() {
 		)
 		prometheus.Register(workerTasks)
 
+		workerDiskFree = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "concoursedev",
+				Subsystem: "workers",
+				Name:      "disk_free_bytes",
+				Help:      "Free space on the volumes disk per worker",
+			},
+			[]string{"worker", "platform"},
+		)
+		prometheus.Register(workerDiskFree)
+
+		workerStreamsIn = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "concoursedev",
+				Subsystem: "workers",
+				Name:      "volume_streams_in_total",
+				Help:      "Total number of volume streams into each worker",
+			},
+			[]string{"worker", "platform"},
+		)
+		prometheus.Register(workerStreamsIn)
+
 		workerContainersLabels = map[string]map[string]prometheus.Labels{}
 		workerVolumesLabels = map[string]map[string]prometheus.Labels{}
 		workerTasksLabels = map[string]map[string]prometheus.Labels{}
+		workerStatsLabels = map[string]prometheus.Labels{}
 
 		labelsLong = prometheus.Labels{
 			"worker":   "foo",
@@ -87,6 +113,12 @@ var _ = Describe("PrometheusEmitter garbage collector", func() {
 			WorkerContainersStub: func() *prometheus.GaugeVec { return workerContainers },
 			WorkerVolumesStub:    func() *prometheus.GaugeVec { return workerVolumes },
 			WorkerTasksStub:      func() *prometheus.GaugeVec { return workerTasks },
+			WorkerStatsStub: func() map[string]*prometheus.GaugeVec {
+				return map[string]*prometheus.GaugeVec{"worker disk free bytes": workerDiskFree}
+			},
+			WorkerStreamStatsStub: func() map[string]*prometheus.CounterVec {
+				return map[string]*prometheus.CounterVec{"worker volume streams in": workerStreamsIn}
+			},
 
 			WorkerContainersLabelsStub: func() map[string]map[string]prometheus.Labels {
 				return workerContainersLabels
@@ -97,6 +129,9 @@ var _ = Describe("PrometheusEmitter garbage collector", func() {
 			WorkerTasksLabelsStub: func() map[string]map[string]prometheus.Labels {
 				return workerTasksLabels
 			},
+			WorkerStatsLabelsStub: func() map[string]prometheus.Labels {
+				return workerStatsLabels
+			},
 		}
 
 		// Deep copy the labels so we can use them to verify the test results later
@@ -119,23 +154,31 @@ var _ = Describe("PrometheusEmitter garbage collector", func() {
 		fake.WorkerTasks().With(labels).Set(42.0)
 		fake.WorkerTasksLabels()["foo"] = make(map[string]prometheus.Labels)
 		fake.WorkerTasksLabels()["foo"]["foo_linux"] = labels
+
+		fake.WorkerStats()["worker disk free bytes"].With(labels).Set(42.0)
+		fake.WorkerStreamStats()["worker volume streams in"].With(labels).Add(42.0)
+		fake.WorkerStatsLabels()["foo"] = labels
 	})
 
 	It("should remove all metrics from the emitter", func() {
 		Expect(fake.WorkerContainersLabels()).To(HaveLen(1))
 		Expect(fake.WorkerVolumesLabels()).To(HaveLen(1))
 		Expect(fake.WorkerTasksLabels()).To(HaveLen(1))
+		Expect(fake.WorkerStatsLabels()).To(HaveLen(1))
 
 		emitter.DoGarbageCollection(&fake, "foo")
 
 		Expect(fake.WorkerContainersLabels()).To(HaveLen(0))
 		Expect(fake.WorkerVolumesLabels()).To(HaveLen(0))
 		Expect(fake.WorkerTasksLabels()).To(HaveLen(0))
+		Expect(fake.WorkerStatsLabels()).To(HaveLen(0))
 
 		// Delete should return false if the metrics no longer exist
 		Expect(fake.WorkerContainers().Delete(labelsLong)).To(Equal(false))
 		Expect(fake.WorkerVolumes().Delete(labelsLong)).To(Equal(false))
 		Expect(fake.WorkerTasks().Delete(labelsShort)).To(Equal(false))
+		Expect(workerDiskFree.Delete(labelsShort)).To(Equal(false))
+		Expect(workerStreamsIn.Delete(labelsShort)).To(Equal(false))
 	})
 
 	// There is no easy way to detect whether metrics are REALLY garbage collected due to the
@@ -146,6 +189,8 @@ var _ = Describe("PrometheusEmitter garbage collector", func() {
 		Expect(fake.WorkerContainers().Delete(labelsLong)).To(Equal(true))
 		Expect(fake.WorkerVolumes().Delete(labelsLong)).To(Equal(true))
 		Expect(fake.WorkerTasks().Delete(labelsShort)).To(Equal(true))
+		Expect(workerDiskFree.Delete(labelsShort)).To(Equal(true))
+		Expect(workerStreamsIn.Delete(labelsShort)).To(Equal(true))
 
 		emitter.DoGarbageCollection(&fake, "foo")
 
@@ -160,14 +205,19 @@ var _ = Describe("PrometheusEmitter garbage collector", func() {
 		workerContainers.Reset()
 		workerVolumes.Reset()
 		workerTasks.Reset()
+		workerDiskFree.Reset()
+		workerStreamsIn.Reset()
 
 		workerContainersLabels = map[string]map[string]prometheus.Labels{}
 		workerVolumesLabels = map[string]map[string]prometheus.Labels{}
 		workerTasksLabels = map[string]map[string]prometheus.Labels{}
+		workerStatsLabels = map[string]prometheus.Labels{}
 
 		prometheus.Unregister(workerContainers)
 		prometheus.Unregister(workerVolumes)
 		prometheus.Unregister(workerTasks)
+		prometheus.Unregister(workerDiskFree)
+		prometheus.Unregister(workerStreamsIn)
 	})
 })
 
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -111,6 +112,84 @@ func (event ResourceCacheCollectorDuration) Emit(logger lager.Logger) {
 	)
 }
 
+type TeamCacheQuotaCollectorDuration struct {
+	Duration time.Duration
+}
+
+func (event TeamCacheQuotaCollectorDuration) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-team-cache-quota-collector-duration"),
+		Event{
+			Name:  "gc: team cache quota collector duration (ms)",
+			Value: ms(event.Duration),
+		},
+	)
+}
+
+type TeamCachesEvicted struct {
+	TeamName   string
+	WorkerName string
+	Caches     int
+}
+
+func (event TeamCachesEvicted) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("team-caches-evicted"),
+		Event{
+			Name:  "team caches evicted",
+			Value: float64(event.Caches),
+			Attributes: map[string]string{
+				"team_name": event.TeamName,
+				"worker":    event.WorkerName,
+			},
+		},
+	)
+}
+
+type WorkerCacheSizeCollectorDuration struct {
+	Duration time.Duration
+}
+
+func (event WorkerCacheSizeCollectorDuration) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-worker-cache-size-collector-duration"),
+		Event{
+			Name:  "gc: worker cache size collector duration (ms)",
+			Value: ms(event.Duration),
+		},
+	)
+}
+
+type WorkerCachesEvicted struct {
+	WorkerName string
+	Caches     int
+	Bytes      uint64
+}
+
+func (event WorkerCachesEvicted) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("worker-caches-evicted"),
+		Event{
+			Name:  "worker caches evicted",
+			Value: float64(event.Caches),
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("worker-cache-bytes-evicted"),
+		Event{
+			Name:  "worker cache bytes evicted",
+			Value: float64(event.Bytes),
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
 type TaskCacheCollectorDuration struct {
 	Duration time.Duration
 }
@@ -307,6 +386,37 @@ func (event WorkerTasks) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerStats struct {
+	WorkerName string
+	Platform   string
+	Stats      atc.WorkerStats
+}
+
+func (event WorkerStats) Emit(logger lager.Logger) {
+	values := map[string]uint64{
+		"worker disk total bytes":          event.Stats.DiskTotalBytes,
+		"worker disk free bytes":           event.Stats.DiskFreeBytes,
+		"worker volume streams in":         event.Stats.VolumeStreamsIn,
+		"worker volume streams out":        event.Stats.VolumeStreamsOut,
+		"worker volume streamed in bytes":  event.Stats.VolumeStreamedInBytes,
+		"worker volume streamed out bytes": event.Stats.VolumeStreamedOutBytes,
+	}
+
+	for name, value := range values {
+		Metrics.emit(
+			logger.Session("worker-stats"),
+			Event{
+				Name:  name,
+				Value: float64(value),
+				Attributes: map[string]string{
+					"worker":   event.WorkerName,
+					"platform": event.Platform,
+				},
+			},
+		)
+	}
+}
+
 type VolumesToBeGarbageCollected struct {
 	Volumes int
 }
@@ -477,6 +587,42 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type StepTimeoutWarning struct {
+	Build     db.Build
+	WarnAfter time.Duration
+}
+
+func (event StepTimeoutWarning) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("step-timeout-warning"),
+		Event{
+			Name:       "step timeout warning",
+			Value:      event.WarnAfter.Seconds(),
+			Attributes: event.Build.TracingAttrs(),
+		},
+	)
+}
+
+type StepPhaseDuration struct {
+	Build    db.Build
+	Phase    string
+	Duration time.Duration
+}
+
+func (event StepPhaseDuration) Emit(logger lager.Logger) {
+	attrs := event.Build.TracingAttrs()
+	attrs["phase"] = event.Phase
+
+	Metrics.emit(
+		logger.Session("step-phase-duration"),
+		Event{
+			Name:       "step phase duration",
+			Value:      event.Duration.Seconds(),
+			Attributes: attrs,
+		},
+	)
+}
+
 type CheckBuildStarted struct {
 	Build db.Build
 }
@@ -75,6 +75,14 @@ func tick(logger lager.Logger, m *Monitor) {
 		},
 	)
 
+	m.emit(
+		logger.Session("volumes-delta-streamed"),
+		Event{
+			Name:  "volumes delta streamed",
+			Value: m.VolumesDeltaStreamed.Delta(),
+		},
+	)
+
 	m.emit(
 		logger.Session("get-step-cache-hits"),
 		Event{
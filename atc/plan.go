@@ -1,5 +1,7 @@
 package atc
 
+import "encoding/json"
+
 type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
@@ -11,20 +13,30 @@ type Plan struct {
 	Run         *RunPlan         `json:"run,omitempty"`
 	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
 	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	Notify      *NotifyPlan      `json:"notify,omitempty"`
 
 	Do         *DoPlan         `json:"do,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
 	Across     *AcrossPlan     `json:"across,omitempty"`
 
+	// ContinueOnFailure applies to Do. DoPlan is a plain list of plans, so
+	// the flag is carried alongside it.
+	ContinueOnFailure bool `json:"continue_on_failure,omitempty"`
+
 	OnSuccess *OnSuccessPlan `json:"on_success,omitempty"`
 	OnFailure *OnFailurePlan `json:"on_failure,omitempty"`
 	OnAbort   *OnAbortPlan   `json:"on_abort,omitempty"`
 	OnError   *OnErrorPlan   `json:"on_error,omitempty"`
+	OnTimeout *OnTimeoutPlan `json:"on_timeout,omitempty"`
 	Ensure    *EnsurePlan    `json:"ensure,omitempty"`
 
 	Try     *TryPlan     `json:"try,omitempty"`
 	Timeout *TimeoutPlan `json:"timeout,omitempty"`
+	Mute    *MutePlan    `json:"mute,omitempty"`
 	Retry   *RetryPlan   `json:"retry,omitempty"`
+	While   *WhilePlan   `json:"while,omitempty"`
+	When    *WhenPlan    `json:"when,omitempty"`
+	Lock    *LockPlan    `json:"lock,omitempty"`
 
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
@@ -71,6 +83,11 @@ func (plan *Plan) Each(f func(*Plan)) {
 		plan.OnError.Next.Each(f)
 	}
 
+	if plan.OnTimeout != nil {
+		plan.OnTimeout.Step.Each(f)
+		plan.OnTimeout.Next.Each(f)
+	}
+
 	if plan.Ensure != nil {
 		plan.Ensure.Step.Each(f)
 		plan.Ensure.Next.Each(f)
@@ -84,10 +101,29 @@ func (plan *Plan) Each(f func(*Plan)) {
 		plan.Timeout.Step.Each(f)
 	}
 
+	if plan.Mute != nil {
+		plan.Mute.Step.Each(f)
+	}
+
 	if plan.Retry != nil {
-		for i, p := range *plan.Retry {
+		for i, p := range plan.Retry.Steps {
+			p.Each(f)
+			plan.Retry.Steps[i] = p
+		}
+	}
+
+	if plan.When != nil {
+		plan.When.Step.Each(f)
+	}
+
+	if plan.Lock != nil {
+		plan.Lock.Step.Each(f)
+	}
+
+	if plan.While != nil {
+		for i, p := range plan.While.Steps {
 			p.Each(f)
-			(*plan.Retry)[i] = p
+			plan.While.Steps[i] = p
 		}
 	}
 
@@ -102,6 +138,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 	if plan.Check != nil {
 		plan.Check.TypeImage.EachPlan(f)
 	}
+
+	if plan.Run != nil {
+		plan.Run.TypeImage.EachPlan(f)
+	}
 }
 
 type PlanID string
@@ -141,33 +181,66 @@ type ArtifactOutputPlan struct {
 }
 
 type OnAbortPlan struct {
-	Step Plan `json:"step"`
-	Next Plan `json:"on_abort"`
+	Step    Plan   `json:"step"`
+	Next    Plan   `json:"on_abort"`
+	Timeout string `json:"timeout,omitempty"`
 }
 
 type OnErrorPlan struct {
-	Step Plan `json:"step"`
-	Next Plan `json:"on_error"`
+	Step    Plan   `json:"step"`
+	Next    Plan   `json:"on_error"`
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type OnTimeoutPlan struct {
+	Step    Plan   `json:"step"`
+	Next    Plan   `json:"on_timeout"`
+	Timeout string `json:"timeout,omitempty"`
 }
 
 type OnFailurePlan struct {
-	Step Plan `json:"step"`
-	Next Plan `json:"on_failure"`
+	Step    Plan   `json:"step"`
+	Next    Plan   `json:"on_failure"`
+	Timeout string `json:"timeout,omitempty"`
 }
 
 type EnsurePlan struct {
-	Step Plan `json:"step"`
-	Next Plan `json:"ensure"`
+	Step    Plan   `json:"step"`
+	Next    Plan   `json:"ensure"`
+	Timeout string `json:"timeout,omitempty"`
 }
 
 type OnSuccessPlan struct {
-	Step Plan `json:"step"`
-	Next Plan `json:"on_success"`
+	Step    Plan   `json:"step"`
+	Next    Plan   `json:"on_success"`
+	Timeout string `json:"timeout,omitempty"`
 }
 
 type TimeoutPlan struct {
+	Step      Plan   `json:"step"`
+	Duration  string `json:"duration"`
+	WarnAfter string `json:"warn_after,omitempty"`
+
+	// Build is set on the timeout wrapped around a job's plan for its
+	// build_timeout, which counts from the build's start time.
+	Build bool `json:"build,omitempty"`
+}
+
+type MutePlan struct {
 	Step     Plan   `json:"step"`
-	Duration string `json:"duration"`
+	Stdout   bool   `json:"stdout,omitempty"`
+	LogLimit string `json:"log_limit,omitempty"`
+}
+
+type WhenPlan struct {
+	Step      Plan   `json:"step"`
+	Condition string `json:"condition"`
+}
+
+type LockPlan struct {
+	Step  Plan   `json:"step"`
+	Name  string `json:"name"`
+	Count int    `json:"count,omitempty"`
 }
 
 type TryPlan struct {
@@ -175,9 +248,9 @@ type TryPlan struct {
 }
 
 type InParallelPlan struct {
-	Steps    []Plan `json:"steps"`
-	Limit    int    `json:"limit,omitempty"`
-	FailFast bool   `json:"fail_fast,omitempty"`
+	Steps    []Plan           `json:"steps"`
+	Limit    *InParallelLimit `json:"limit,omitempty"`
+	FailFast bool             `json:"fail_fast,omitempty"`
 }
 
 type AcrossPlan struct {
@@ -185,13 +258,16 @@ type AcrossPlan struct {
 	// SubStepTemplate contains the uninterpolated JSON encoded plan for the
 	// substep. This template must be interpolated for each substep using the
 	// across vars, and the plan IDs must be updated.
-	SubStepTemplate string `json:"substep_template"`
-	FailFast        bool   `json:"fail_fast,omitempty"`
+	SubStepTemplate string              `json:"substep_template"`
+	Exclude         []AcrossCombination `json:"exclude,omitempty"`
+	Include         []AcrossCombination `json:"include,omitempty"`
+	FailFast        bool                `json:"fail_fast,omitempty"`
 }
 
 type AcrossVar struct {
 	Var         string             `json:"name"`
 	Values      interface{}        `json:"values,omitempty"`
+	ValuesFrom  *AcrossValuesFrom  `json:"values_from,omitempty"`
 	MaxInFlight *MaxInFlightConfig `json:"max_in_flight,omitempty"`
 }
 
@@ -229,6 +305,19 @@ type GetPlan struct {
 	// A timeout to enforce on the resource `get` process. Note that fetching the
 	// resource's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
+
+	// Resource limits to enforce on the resource `get` container.
+	Limits *ContainerLimits `json:"container_limits,omitempty"`
+
+	// Placement priority of the resource `get` container.
+	Priority int `json:"priority,omitempty"`
+
+	// The number of versions to fetch, newest first, each into a subdirectory
+	// named after its index. Only applies to pipeline resources.
+	Depth int `json:"depth,omitempty"`
+
+	// The expected digest of a file within the fetched artifact.
+	Verify *VerifyConfig `json:"verify,omitempty"`
 }
 
 type PutPlan struct {
@@ -258,8 +347,21 @@ type PutPlan struct {
 	// resource's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
+	// Resource limits to enforce on the resource `put` container.
+	Limits *ContainerLimits `json:"container_limits,omitempty"`
+
+	// Placement priority of the resource `put` container.
+	Priority int `json:"priority,omitempty"`
+
 	// If or not expose BUILD_CREATED_BY to build metadata
 	ExposeBuildCreatedBy bool `json:"expose_build_created_by,omitempty"`
+
+	// Whether the put is run without fetching the created version afterwards.
+	NoGet bool `json:"no_get,omitempty"`
+
+	// Sources to merge over Source, each pushed to in parallel within the same
+	// container.
+	Targets []Source `json:"targets,omitempty"`
 }
 
 type CheckPlan struct {
@@ -298,6 +400,13 @@ type CheckPlan struct {
 
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
+
+	// What to do if no worker with the tags becomes available in time. If not
+	// specified, the check waits for a tagged worker indefinitely.
+	TagFallback *TagFallback `json:"tag_fallback,omitempty"`
+
+	// Resource limits to enforce on the resource `check` container.
+	Limits *ContainerLimits `json:"container_limits,omitempty"`
 }
 
 func (plan CheckPlan) IsPeriodic() bool {
@@ -324,6 +433,9 @@ type TaskPlan struct {
 	// Limits to set on the Task Container
 	Limits *ContainerLimits `json:"container_limits,omitempty"`
 
+	// Placement priority of the Task Container
+	Priority int `json:"priority,omitempty"`
+
 	// An artifact in the build plan to use as the task's image. Overrides any
 	// image set in the task's config.
 	ImageArtifactName string `json:"image,omitempty"`
@@ -345,6 +457,21 @@ type TaskPlan struct {
 
 	// Resource types to have available for use when fetching the task's image.
 	ResourceTypes ResourceTypes `json:"resource_types,omitempty"`
+
+	// JUnit/XUnit XML reports, relative to the task's artifacts (e.g.
+	// 'output/junit.xml'), to parse into test results once the task exits.
+	TestReports []string `json:"test_reports,omitempty"`
+
+	// Run the task without networking. This is only enforced by workers using
+	// the containerd runtime.
+	Hermetic bool `json:"hermetic,omitempty"`
+
+	// The user to run the task as, overriding the one from the task's config
+	// and image.
+	User string `json:"user,omitempty"`
+
+	// Upload the task's outputs to the artifact archive once it exits.
+	ArchiveOutputs bool `json:"archive_outputs,omitempty"`
 }
 
 type RunPlan struct {
@@ -354,6 +481,9 @@ type RunPlan struct {
 	// The prototype name.
 	Type string `json:"type"`
 
+	// Image of the prototype, fetched before running the message.
+	TypeImage TypeImage `json:"image"`
+
 	// Object to provide to the prototype. Result of merging run.params with
 	// prototype.defaults.
 	Object Params `json:"object,omitempty"`
@@ -368,9 +498,22 @@ type RunPlan struct {
 	// Limits to set on the Container
 	Limits *ContainerLimits `json:"container_limits,omitempty"`
 
+	// Placement priority of the Container
+	Priority int `json:"priority,omitempty"`
+
 	// A timeout to enforce on the run step's process. Note that fetching the
 	// prototype's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
+
+	// Artifacts to mount into the container, and artifacts to register from
+	// the container once the message has run.
+	Inputs  []string `json:"inputs,omitempty"`
+	Outputs []string `json:"outputs,omitempty"`
+
+	// Mappings from the names the prototype sees to artifact names in the
+	// build.
+	InputMapping  map[string]string `json:"input_mapping,omitempty"`
+	OutputMapping map[string]string `json:"output_mapping,omitempty"`
 }
 
 type SetPipelinePlan struct {
@@ -380,16 +523,64 @@ type SetPipelinePlan struct {
 	Vars         map[string]interface{} `json:"vars,omitempty"`
 	VarFiles     []string               `json:"var_files,omitempty"`
 	InstanceVars map[string]interface{} `json:"instance_vars,omitempty"`
+
+	InstanceVarsList []map[string]interface{} `json:"instance_vars_list,omitempty"`
+	InstanceVarsFile string                   `json:"instance_vars_file,omitempty"`
+	PruneInstances   bool                     `json:"prune_instances,omitempty"`
+	DryRun           bool                     `json:"dry_run,omitempty"`
 }
 
 type LoadVarPlan struct {
-	Name   string `json:"name"`
-	File   string `json:"file"`
-	Format string `json:"format,omitempty"`
-	Reveal bool   `json:"reveal,omitempty"`
+	Name          string `json:"name"`
+	File          string `json:"file"`
+	Format        string `json:"format,omitempty"`
+	Reveal        bool   `json:"reveal,omitempty"`
+	MultiDocument bool   `json:"multi_document,omitempty"`
+}
+
+type NotifyPlan struct {
+	// The name of the step.
+	Name string `json:"name"`
+
+	// The message to send. If empty, a message describing the build is sent.
+	Message string `json:"message,omitempty"`
+
+	// The endpoints to post the message to.
+	Targets []NotifyTarget `json:"targets"`
+}
+
+type RetryPlan struct {
+	Steps   []Plan           `json:"steps"`
+	Backoff *RetryBackoff    `json:"backoff,omitempty"`
+	RetryOn []RetryCondition `json:"retry_on,omitempty"`
 }
 
-type RetryPlan []Plan
+type WhilePlan struct {
+	// Steps contains one plan per iteration, up to the configured max.
+	Steps []Plan `json:"steps"`
+	Var   string `json:"var,omitempty"`
+}
+
+// UnmarshalJSON also accepts the list of attempts that retry plans used to be
+// stored as, so that builds planned before backoff was supported still run.
+func (plan *RetryPlan) UnmarshalJSON(data []byte) error {
+	var steps []Plan
+	if err := json.Unmarshal(data, &steps); err == nil {
+		*plan = RetryPlan{Steps: steps}
+		return nil
+	}
+
+	type target RetryPlan
+
+	var t target
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+
+	*plan = RetryPlan(t)
+
+	return nil
+}
 
 type DependentGetPlan struct {
 	Type     string `json:"type"`
@@ -43,12 +43,16 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.SetPipeline = &t
 	case LoadVarPlan:
 		plan.LoadVar = &t
+	case NotifyPlan:
+		plan.Notify = &t
 	case CheckPlan:
 		plan.Check = &t
 	case OnAbortPlan:
 		plan.OnAbort = &t
 	case OnErrorPlan:
 		plan.OnError = &t
+	case OnTimeoutPlan:
+		plan.OnTimeout = &t
 	case EnsurePlan:
 		plan.Ensure = &t
 	case OnSuccessPlan:
@@ -59,8 +63,16 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.Try = &t
 	case TimeoutPlan:
 		plan.Timeout = &t
+	case MutePlan:
+		plan.Mute = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case WhilePlan:
+		plan.While = &t
+	case WhenPlan:
+		plan.When = &t
+	case LockPlan:
+		plan.Lock = &t
 	case ArtifactInputPlan:
 		plan.ArtifactInput = &t
 	case ArtifactOutputPlan:
@@ -0,0 +1,24 @@
+package atc
+
+// PlanPreviewRequest is the payload for previewing a pipeline config: given
+// the config and a set of hypothetical new resource versions, which jobs
+// would trigger and what would they run?
+type PlanPreviewRequest struct {
+	Config   Config             `json:"config"`
+	Versions map[string]Version `json:"versions"`
+}
+
+type PlanPreview struct {
+	Jobs []JobPlanPreview `json:"jobs"`
+}
+
+type JobPlanPreview struct {
+	Name string `json:"name"`
+
+	// TriggeredBy lists the names of the inputs whose new versions would
+	// cause the job to trigger.
+	TriggeredBy []string `json:"triggered_by"`
+
+	Plan  *Plan  `json:"plan,omitempty"`
+	Error string `json:"error,omitempty"`
+}
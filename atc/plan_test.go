@@ -0,0 +1,40 @@
+package atc_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryPlan", func() {
+	Describe("UnmarshalJSON", func() {
+		It("unmarshals steps and backoff", func() {
+			var plan atc.RetryPlan
+			err := json.Unmarshal([]byte(`{
+				"steps": [{"id": "1"}, {"id": "2"}],
+				"backoff": {"initial": "1s", "max": "10s"}
+			}`), &plan)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(plan).To(Equal(atc.RetryPlan{
+				Steps: []atc.Plan{{ID: "1"}, {ID: "2"}},
+				Backoff: &atc.RetryBackoff{
+					Initial: "1s",
+					Max:     "10s",
+				},
+			}))
+		})
+
+		It("unmarshals plans stored as a list of attempts", func() {
+			var plan atc.RetryPlan
+			err := json.Unmarshal([]byte(`[{"id": "1"}, {"id": "2"}]`), &plan)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(plan).To(Equal(atc.RetryPlan{
+				Steps: []atc.Plan{{ID: "1"}, {ID: "2"}},
+			}))
+		})
+	})
+})
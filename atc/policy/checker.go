@@ -13,6 +13,11 @@ import (
 const ActionUseImage = "UseImage"
 const ActionRunSetPipeline = "SetPipeline"
 
+// ActionSetPipelineAcrossTeams is checked when a set_pipeline step targets a
+// team other than the build's own and the operator has allowed the pair of
+// teams. The policy agent can only deny it; it never grants on its own.
+const ActionSetPipelineAcrossTeams = "SetPipelineAcrossTeams"
+
 type PolicyCheckNotPass struct {
 	Messages []string
 }
@@ -0,0 +1,13 @@
+package preemption_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPreemption(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Preemption Suite")
+}
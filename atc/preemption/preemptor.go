@@ -0,0 +1,97 @@
+package preemption
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// Preemptor makes room for the builds of high-priority jobs which have been
+// queued or waiting for a worker for too long by preempting running builds of
+// lower-priority jobs in the same team. Preempted builds are aborted, and are
+// re-queued by the engine once they have finished aborting.
+type Preemptor struct {
+	preemptionFactory db.PreemptionFactory
+	threshold         time.Duration
+}
+
+func NewPreemptor(preemptionFactory db.PreemptionFactory, threshold time.Duration) *Preemptor {
+	return &Preemptor{
+		preemptionFactory: preemptionFactory,
+		threshold:         threshold,
+	}
+}
+
+func (p *Preemptor) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	waitingBuilds, err := p.preemptionFactory.WaitingBuilds(time.Now().Add(-p.threshold))
+	if err != nil {
+		logger.Error("failed-to-find-waiting-builds", err)
+		return err
+	}
+
+	for _, build := range waitingBuilds {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		err := p.preemptFor(logger.Session("preempt", build.LagerData()), build)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// preemptFor preempts a single running build, so that each waiting build only
+// ever preempts one build.
+func (p *Preemptor) preemptFor(logger lager.Logger, build db.Build) error {
+	candidates, err := p.preemptionFactory.PreemptibleBuilds(build.TeamID(), build.Priority())
+	if err != nil {
+		logger.Error("failed-to-find-preemptible-builds", err)
+		return err
+	}
+
+	for _, candidate := range candidates {
+		preempted, err := candidate.Preempt(build.ID())
+		if err != nil {
+			logger.Error("failed-to-preempt-build", err)
+			return err
+		}
+
+		// the build finished or was aborted in the meantime
+		if !preempted {
+			continue
+		}
+
+		logger.Info("preempted-build", lager.Data{
+			"preempted-build-id": candidate.ID(),
+			"priority":           build.Priority(),
+		})
+
+		err = candidate.SaveEvent(event.Error{
+			Message: fmt.Sprintf("preempted by build %s/%s #%s of a higher-priority job", build.PipelineName(), build.JobName(), build.Name()),
+			Origin: event.Origin{
+				ID: event.OriginID(candidate.PrivatePlan().ID),
+			},
+			Time: time.Now().Unix(),
+		})
+		if err != nil {
+			logger.Error("failed-to-save-preempted-event", err)
+		}
+
+		return nil
+	}
+
+	return nil
+}
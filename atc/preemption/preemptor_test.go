@@ -0,0 +1,129 @@
+package preemption_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/preemption"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Preemptor", func() {
+	var (
+		fakePreemptionFactory *dbfakes.FakePreemptionFactory
+
+		waitingBuild *dbfakes.FakeBuild
+		runningBuild *dbfakes.FakeBuild
+		otherBuild   *dbfakes.FakeBuild
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakePreemptionFactory = new(dbfakes.FakePreemptionFactory)
+
+		waitingBuild = new(dbfakes.FakeBuild)
+		waitingBuild.IDReturns(42)
+		waitingBuild.TeamIDReturns(1)
+		waitingBuild.PriorityReturns(10)
+		waitingBuild.PipelineNameReturns("some-pipeline")
+		waitingBuild.JobNameReturns("urgent-job")
+		waitingBuild.NameReturns("7")
+
+		runningBuild = new(dbfakes.FakeBuild)
+		runningBuild.IDReturns(12)
+		runningBuild.PreemptReturns(true, nil)
+
+		otherBuild = new(dbfakes.FakeBuild)
+		otherBuild.IDReturns(13)
+		otherBuild.PreemptReturns(true, nil)
+
+		fakePreemptionFactory.WaitingBuildsReturns([]db.Build{waitingBuild}, nil)
+		fakePreemptionFactory.PreemptibleBuildsReturns([]db.Build{runningBuild, otherBuild}, nil)
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = preemption.NewPreemptor(fakePreemptionFactory, 10*time.Minute).Run(ctx)
+	})
+
+	It("looks for builds which have been waiting beyond the threshold", func() {
+		Expect(fakePreemptionFactory.WaitingBuildsCallCount()).To(Equal(1))
+		Expect(fakePreemptionFactory.WaitingBuildsArgsForCall(0)).To(BeTemporally("~", time.Now().Add(-10*time.Minute), time.Minute))
+	})
+
+	It("looks for lower-priority builds of the same team", func() {
+		Expect(fakePreemptionFactory.PreemptibleBuildsCallCount()).To(Equal(1))
+		teamID, priority := fakePreemptionFactory.PreemptibleBuildsArgsForCall(0)
+		Expect(teamID).To(Equal(1))
+		Expect(priority).To(Equal(10))
+	})
+
+	It("preempts a single build for the waiting build", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+
+		Expect(runningBuild.PreemptCallCount()).To(Equal(1))
+		Expect(runningBuild.PreemptArgsForCall(0)).To(Equal(42))
+
+		Expect(otherBuild.PreemptCallCount()).To(BeZero())
+	})
+
+	It("tells the preempted build why it was aborted", func() {
+		Expect(runningBuild.SaveEventCallCount()).To(Equal(1))
+		Expect(runningBuild.SaveEventArgsForCall(0)).To(BeAssignableToTypeOf(event.Error{}))
+		Expect(runningBuild.SaveEventArgsForCall(0).(event.Error).Message).To(Equal("preempted by build some-pipeline/urgent-job #7 of a higher-priority job"))
+	})
+
+	Context("when the first build has already finished", func() {
+		BeforeEach(func() {
+			runningBuild.PreemptReturns(false, nil)
+		})
+
+		It("preempts the next one", func() {
+			Expect(runningBuild.SaveEventCallCount()).To(BeZero())
+			Expect(otherBuild.PreemptCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when there are no lower-priority builds", func() {
+		BeforeEach(func() {
+			fakePreemptionFactory.PreemptibleBuildsReturns(nil, nil)
+		})
+
+		It("does nothing", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when preempting fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			runningBuild.PreemptReturns(false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when finding waiting builds fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakePreemptionFactory.WaitingBuildsReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+			Expect(fakePreemptionFactory.PreemptibleBuildsCallCount()).To(BeZero())
+		})
+	})
+})
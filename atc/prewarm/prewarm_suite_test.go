@@ -0,0 +1,13 @@
+package prewarm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPrewarm(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Prewarm Suite")
+}
@@ -0,0 +1,229 @@
+package prewarm
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . Pool
+type Pool interface {
+	FindWorker(lager.Logger, string) (runtime.Worker, bool, error)
+	FindResourceCacheVolume(lager.Logger, int, db.ResourceCache, worker.Spec) (runtime.Volume, bool, error)
+	FindResourceCacheVolumeOnWorker(lager.Logger, db.ResourceCache, worker.Spec, string) (runtime.Volume, bool, error)
+}
+
+//counterfeiter:generate . Streamer
+type Streamer interface {
+	Stream(context.Context, runtime.Artifact, runtime.Volume) error
+}
+
+type Config struct {
+	// Images is the number of most used task images to prewarm.
+	Images int
+	// BaseResourceTypes is the number of most used base resource types to
+	// prewarm.
+	BaseResourceTypes int
+	// UsageWindow is how far back to look at builds when ranking images.
+	UsageWindow time.Duration
+	// MaxActiveContainers is the number of containers above which a worker is
+	// considered busy, and is left alone.
+	MaxActiveContainers int
+}
+
+// Prewarmer proactively imports frequently used base resource types and task
+// images onto idle workers, so that builds scheduled there later don't have
+// to wait for them to be imported or streamed.
+type Prewarmer struct {
+	prewarmFactory db.PrewarmFactory
+	workerFactory  db.WorkerFactory
+	pool           Pool
+	streamer       Streamer
+	config         Config
+}
+
+func NewPrewarmer(
+	prewarmFactory db.PrewarmFactory,
+	workerFactory db.WorkerFactory,
+	pool Pool,
+	streamer Streamer,
+	config Config,
+) *Prewarmer {
+	return &Prewarmer{
+		prewarmFactory: prewarmFactory,
+		workerFactory:  workerFactory,
+		pool:           pool,
+		streamer:       streamer,
+		config:         config,
+	}
+}
+
+func (p *Prewarmer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	workers, err := p.idleWorkers()
+	if err != nil {
+		logger.Error("failed-to-find-idle-workers", err)
+		return err
+	}
+
+	if len(workers) == 0 {
+		return nil
+	}
+
+	var resourceTypes []string
+	if p.config.BaseResourceTypes > 0 {
+		resourceTypes, err = p.prewarmFactory.MostUsedBaseResourceTypes(p.config.BaseResourceTypes)
+		if err != nil {
+			logger.Error("failed-to-rank-base-resource-types", err)
+			return err
+		}
+	}
+
+	var caches []db.ResourceCache
+	if p.config.Images > 0 {
+		caches, err = p.prewarmFactory.MostUsedImageResourceCaches(time.Now().Add(-p.config.UsageWindow), p.config.Images)
+		if err != nil {
+			logger.Error("failed-to-rank-images", err)
+			return err
+		}
+	}
+
+	for _, dbWorker := range workers {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		p.prewarmWorker(ctx, logger.Session("prewarm-worker", lager.Data{"worker": dbWorker.Name()}), dbWorker, resourceTypes, caches)
+	}
+
+	return nil
+}
+
+// idleWorkers only returns workers that are shared across teams and untagged,
+// as the images of team and tagged builds could otherwise end up on workers
+// those builds will never run on.
+func (p *Prewarmer) idleWorkers() ([]db.Worker, error) {
+	workers, err := p.workerFactory.Workers()
+	if err != nil {
+		return nil, err
+	}
+
+	var idle []db.Worker
+	for _, w := range workers {
+		if w.State() != db.WorkerStateRunning || w.TeamID() != 0 || len(w.Tags()) != 0 {
+			continue
+		}
+
+		if w.ActiveContainers() > p.config.MaxActiveContainers {
+			continue
+		}
+
+		idle = append(idle, w)
+	}
+
+	return idle, nil
+}
+
+func (p *Prewarmer) prewarmWorker(ctx context.Context, logger lager.Logger, dbWorker db.Worker, resourceTypes []string, caches []db.ResourceCache) {
+	runtimeWorker, found, err := p.pool.FindWorker(logger, dbWorker.Name())
+	if err != nil {
+		logger.Error("failed-to-find-worker", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	for _, resourceType := range resourceTypes {
+		if !supportsResourceType(dbWorker, resourceType) {
+			continue
+		}
+
+		err := runtimeWorker.PrewarmBaseResourceType(logger, resourceType)
+		if err != nil {
+			logger.Error("failed-to-prewarm-base-resource-type", err, lager.Data{"resource-type": resourceType})
+		}
+	}
+
+	spec := worker.Spec{Platform: dbWorker.Platform()}
+	for _, cache := range caches {
+		if ctx.Err() != nil {
+			return
+		}
+
+		cacheLogger := logger.WithData(lager.Data{"resource-cache-id": cache.ID()})
+
+		err := p.prewarmResourceCache(ctx, cacheLogger, runtimeWorker, spec, cache)
+		if err != nil {
+			cacheLogger.Error("failed-to-prewarm-resource-cache", err)
+		}
+	}
+}
+
+func (p *Prewarmer) prewarmResourceCache(ctx context.Context, logger lager.Logger, runtimeWorker runtime.Worker, spec worker.Spec, cache db.ResourceCache) error {
+	_, found, err := p.pool.FindResourceCacheVolumeOnWorker(logger, cache, spec, runtimeWorker.Name())
+	if err != nil {
+		return err
+	}
+
+	if found {
+		return nil
+	}
+
+	src, found, err := p.pool.FindResourceCacheVolume(logger, 0, cache, spec)
+	if err != nil {
+		return err
+	}
+
+	// the cache isn't on any compatible worker, so there's nothing to stream
+	// from. it will be fetched again the next time a build needs it.
+	if !found {
+		return nil
+	}
+
+	dst, _, err := runtimeWorker.CreateVolumeForArtifact(logger, 0)
+	if err != nil {
+		return err
+	}
+
+	err = p.streamer.Stream(lagerctx.NewContext(ctx, logger), src, dst)
+	if err != nil {
+		return err
+	}
+
+	// the streamer already initializes streamed resource caches when caching
+	// of streamed volumes is enabled
+	if !atc.EnableCacheStreamedVolumes {
+		err = dst.InitializeStreamedResourceCache(logger, cache, src.DBVolume().WorkerName())
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Info("prewarmed-resource-cache", lager.Data{"from": src.DBVolume().WorkerName()})
+
+	return nil
+}
+
+func supportsResourceType(dbWorker db.Worker, resourceType string) bool {
+	for _, t := range dbWorker.ResourceTypes() {
+		if t.Type == resourceType {
+			return true
+		}
+	}
+
+	return false
+}
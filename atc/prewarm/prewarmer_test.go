@@ -0,0 +1,217 @@
+package prewarm_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/prewarm"
+	"github.com/concourse/concourse/atc/prewarm/prewarmfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prewarmer", func() {
+	var (
+		fakePrewarmFactory *dbfakes.FakePrewarmFactory
+		fakeWorkerFactory  *dbfakes.FakeWorkerFactory
+		fakePool           *prewarmfakes.FakePool
+		fakeStreamer       *prewarmfakes.FakeStreamer
+		config             prewarm.Config
+
+		idleWorker    *runtimetest.Worker
+		idleDBWorker  *dbfakes.FakeWorker
+		busyDBWorker  *dbfakes.FakeWorker
+		teamDBWorker  *dbfakes.FakeWorker
+		otherDBWorker *dbfakes.FakeWorker
+
+		fakeCache *dbfakes.FakeResourceCache
+		srcVolume *runtimetest.Volume
+
+		runErr error
+	)
+
+	newDBWorker := func(name string, activeContainers int) *dbfakes.FakeWorker {
+		dbWorker := new(dbfakes.FakeWorker)
+		dbWorker.NameReturns(name)
+		dbWorker.StateReturns(db.WorkerStateRunning)
+		dbWorker.PlatformReturns("linux")
+		dbWorker.ActiveContainersReturns(activeContainers)
+		dbWorker.ResourceTypesReturns([]atc.WorkerResourceType{{Type: "registry-image"}})
+		return dbWorker
+	}
+
+	BeforeEach(func() {
+		fakePrewarmFactory = new(dbfakes.FakePrewarmFactory)
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakePool = new(prewarmfakes.FakePool)
+		fakeStreamer = new(prewarmfakes.FakeStreamer)
+		config = prewarm.Config{
+			Images:              10,
+			BaseResourceTypes:   5,
+			UsageWindow:         24 * time.Hour,
+			MaxActiveContainers: 2,
+		}
+
+		idleWorker = runtimetest.NewWorker("idle-worker")
+		idleDBWorker = newDBWorker("idle-worker", 1)
+		busyDBWorker = newDBWorker("busy-worker", 10)
+
+		teamDBWorker = newDBWorker("team-worker", 0)
+		teamDBWorker.TeamIDReturns(1)
+
+		otherDBWorker = newDBWorker("stalled-worker", 0)
+		otherDBWorker.StateReturns(db.WorkerStateStalled)
+
+		fakeWorkerFactory.WorkersReturns([]db.Worker{idleDBWorker, busyDBWorker, teamDBWorker, otherDBWorker}, nil)
+
+		fakePool.FindWorkerStub = func(_ lager.Logger, name string) (runtime.Worker, bool, error) {
+			if name == "idle-worker" {
+				return idleWorker, true, nil
+			}
+			return nil, false, nil
+		}
+
+		fakeCache = new(dbfakes.FakeResourceCache)
+		fakeCache.IDReturns(42)
+
+		srcVolume = runtimetest.NewVolume("src-volume")
+		srcVolume.DBVolume_.WorkerNameReturns("busy-worker")
+
+		fakePrewarmFactory.MostUsedBaseResourceTypesReturns([]string{"registry-image", "unsupported-type"}, nil)
+		fakePrewarmFactory.MostUsedImageResourceCachesReturns([]db.ResourceCache{fakeCache}, nil)
+		fakePool.FindResourceCacheVolumeReturns(srcVolume, true, nil)
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = prewarm.NewPrewarmer(
+			fakePrewarmFactory,
+			fakeWorkerFactory,
+			fakePool,
+			fakeStreamer,
+			config,
+		).Run(ctx)
+	})
+
+	It("succeeds", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+	})
+
+	It("ranks the images used within the usage window", func() {
+		Expect(fakePrewarmFactory.MostUsedImageResourceCachesCallCount()).To(Equal(1))
+		since, limit := fakePrewarmFactory.MostUsedImageResourceCachesArgsForCall(0)
+		Expect(since).To(BeTemporally("~", time.Now().Add(-24*time.Hour), time.Minute))
+		Expect(limit).To(Equal(10))
+
+		Expect(fakePrewarmFactory.MostUsedBaseResourceTypesCallCount()).To(Equal(1))
+		Expect(fakePrewarmFactory.MostUsedBaseResourceTypesArgsForCall(0)).To(Equal(5))
+	})
+
+	It("only prewarms idle, shared, running workers", func() {
+		Expect(fakePool.FindWorkerCallCount()).To(Equal(1))
+		_, name := fakePool.FindWorkerArgsForCall(0)
+		Expect(name).To(Equal("idle-worker"))
+	})
+
+	It("prewarms the base resource types the worker supports", func() {
+		Expect(idleWorker.PrewarmedBaseResourceTypes).To(Equal([]string{"registry-image"}))
+	})
+
+	It("streams the image from a worker which has it", func() {
+		Expect(fakePool.FindResourceCacheVolumeCallCount()).To(Equal(1))
+		_, teamID, cache, spec := fakePool.FindResourceCacheVolumeArgsForCall(0)
+		Expect(teamID).To(Equal(0))
+		Expect(cache).To(Equal(fakeCache))
+		Expect(spec).To(Equal(worker.Spec{Platform: "linux"}))
+
+		Expect(fakeStreamer.StreamCallCount()).To(Equal(1))
+		_, src, dst := fakeStreamer.StreamArgsForCall(0)
+		Expect(src).To(Equal(srcVolume))
+		Expect(idleWorker.Volumes).To(ConsistOf(dst))
+	})
+
+	It("initializes the streamed volume as the resource cache", func() {
+		Expect(idleWorker.Volumes).To(HaveLen(1))
+		Expect(idleWorker.Volumes[0].ResourceCacheInitialized).To(BeTrue())
+		Expect(idleWorker.Volumes[0].ResourceCacheStreamedFrom).To(Equal("busy-worker"))
+	})
+
+	Context("when the worker already has the image", func() {
+		BeforeEach(func() {
+			fakePool.FindResourceCacheVolumeOnWorkerReturns(runtimetest.NewVolume("existing"), true, nil)
+		})
+
+		It("does not stream it", func() {
+			Expect(fakePool.FindResourceCacheVolumeCallCount()).To(Equal(0))
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(0))
+			Expect(idleWorker.Volumes).To(BeEmpty())
+		})
+	})
+
+	Context("when no worker has the image", func() {
+		BeforeEach(func() {
+			fakePool.FindResourceCacheVolumeReturns(nil, false, nil)
+		})
+
+		It("does not stream it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(0))
+			Expect(idleWorker.Volumes).To(BeEmpty())
+		})
+	})
+
+	Context("when streaming fails", func() {
+		BeforeEach(func() {
+			fakeStreamer.StreamReturns(errors.New("nope"))
+		})
+
+		It("carries on without initializing the cache", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(idleWorker.Volumes).To(HaveLen(1))
+			Expect(idleWorker.Volumes[0].ResourceCacheInitialized).To(BeFalse())
+		})
+	})
+
+	Context("when ranking the images fails", func() {
+		BeforeEach(func() {
+			fakePrewarmFactory.MostUsedImageResourceCachesReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+
+	Context("when no workers are idle", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns([]db.Worker{busyDBWorker}, nil)
+		})
+
+		It("does not rank anything", func() {
+			Expect(fakePrewarmFactory.MostUsedImageResourceCachesCallCount()).To(Equal(0))
+			Expect(fakePrewarmFactory.MostUsedBaseResourceTypesCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when image prewarming is disabled", func() {
+		BeforeEach(func() {
+			config.Images = 0
+		})
+
+		It("only prewarms base resource types", func() {
+			Expect(fakePrewarmFactory.MostUsedImageResourceCachesCallCount()).To(Equal(0))
+			Expect(idleWorker.PrewarmedBaseResourceTypes).To(Equal([]string{"registry-image"}))
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -7,6 +7,24 @@ func (plan *Plan) Public() *json.RawMessage {
 		return nil
 	}
 
+	// muting only affects how much output is saved, so it is rendered as the
+	// step it wraps
+	if plan.Mute != nil {
+		return plan.Mute.Public()
+	}
+
+	// conditions only decide whether the step runs at all, so they are
+	// rendered as the step they wrap
+	if plan.When != nil {
+		return plan.When.Public()
+	}
+
+	// locks only decide when the step runs, so they are rendered as the step
+	// they wrap
+	if plan.Lock != nil {
+		return plan.Lock.Public()
+	}
+
 	var public struct {
 		ID PlanID `json:"id,omitempty"`
 
@@ -20,8 +38,10 @@ func (plan *Plan) Public() *json.RawMessage {
 		Run            *json.RawMessage `json:"run,omitempty"`
 		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
 		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
+		Notify         *json.RawMessage `json:"notify,omitempty"`
 		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
 		OnError        *json.RawMessage `json:"on_error,omitempty"`
+		OnTimeout      *json.RawMessage `json:"on_timeout,omitempty"`
 		Ensure         *json.RawMessage `json:"ensure,omitempty"`
 		OnSuccess      *json.RawMessage `json:"on_success,omitempty"`
 		OnFailure      *json.RawMessage `json:"on_failure,omitempty"`
@@ -29,6 +49,7 @@ func (plan *Plan) Public() *json.RawMessage {
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
 		Retry          *json.RawMessage `json:"retry,omitempty"`
+		While          *json.RawMessage `json:"while,omitempty"`
 		ArtifactInput  *json.RawMessage `json:"artifact_input,omitempty"`
 		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
 	}
@@ -75,6 +96,10 @@ func (plan *Plan) Public() *json.RawMessage {
 		public.LoadVar = plan.LoadVar.Public()
 	}
 
+	if plan.Notify != nil {
+		public.Notify = plan.Notify.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -83,6 +108,10 @@ func (plan *Plan) Public() *json.RawMessage {
 		public.OnError = plan.OnError.Public()
 	}
 
+	if plan.OnTimeout != nil {
+		public.OnTimeout = plan.OnTimeout.Public()
+	}
+
 	if plan.Ensure != nil {
 		public.Ensure = plan.Ensure.Public()
 	}
@@ -107,6 +136,10 @@ func (plan *Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.While != nil {
+		public.While = plan.While.Public()
+	}
+
 	if plan.ArtifactInput != nil {
 		public.ArtifactInput = plan.ArtifactInput.Public()
 	}
@@ -129,13 +162,19 @@ func (plan InParallelPlan) Public() *json.RawMessage {
 		steps[i] = plan.Steps[i].Public()
 	}
 
+	// a limit from a var is only known once the step runs
+	var limit int
+	if plan.Limit != nil {
+		limit = plan.Limit.Limit
+	}
+
 	return enc(struct {
 		Steps    []*json.RawMessage `json:"steps"`
 		Limit    int                `json:"limit,omitempty"`
 		FailFast bool               `json:"fail_fast,omitempty"`
 	}{
 		Steps:    steps,
-		Limit:    plan.Limit,
+		Limit:    limit,
 		FailFast: plan.FailFast,
 	})
 }
@@ -231,6 +270,16 @@ func (plan OnErrorPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan OnTimeoutPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step *json.RawMessage `json:"step"`
+		Next *json.RawMessage `json:"on_timeout"`
+	}{
+		Step: plan.Step.Public(),
+		Next: plan.Next.Public(),
+	})
+}
+
 func (plan OnFailurePlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
@@ -308,10 +357,12 @@ func (plan SetPipelinePlan) Public() *json.RawMessage {
 		Name         string       `json:"name"`
 		Team         string       `json:"team"`
 		InstanceVars InstanceVars `json:"instance_vars"`
+		DryRun       bool         `json:"dry_run,omitempty"`
 	}{
 		Name:         plan.Name,
 		Team:         plan.Team,
 		InstanceVars: plan.InstanceVars,
+		DryRun:       plan.DryRun,
 	})
 }
 
@@ -323,6 +374,22 @@ func (plan LoadVarPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan NotifyPlan) Public() *json.RawMessage {
+	// target URLs are left out, since webhook URLs are usually credentials
+	targets := make([]string, len(plan.Targets))
+	for i, target := range plan.Targets {
+		targets[i] = target.Type
+	}
+
+	return enc(struct {
+		Name    string   `json:"name"`
+		Targets []string `json:"targets"`
+	}{
+		Name:    plan.Name,
+		Targets: targets,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -333,6 +400,18 @@ func (plan TimeoutPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan MutePlan) Public() *json.RawMessage {
+	return plan.Step.Public()
+}
+
+func (plan WhenPlan) Public() *json.RawMessage {
+	return plan.Step.Public()
+}
+
+func (plan LockPlan) Public() *json.RawMessage {
+	return plan.Step.Public()
+}
+
 func (plan TryPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
@@ -342,15 +421,29 @@ func (plan TryPlan) Public() *json.RawMessage {
 }
 
 func (plan RetryPlan) Public() *json.RawMessage {
-	public := make([]*json.RawMessage, len(plan))
+	public := make([]*json.RawMessage, len(plan.Steps))
 
-	for i := 0; i < len(plan); i++ {
-		public[i] = plan[i].Public()
+	for i := 0; i < len(plan.Steps); i++ {
+		public[i] = plan.Steps[i].Public()
 	}
 
 	return enc(public)
 }
 
+func (plan WhilePlan) Public() *json.RawMessage {
+	steps := make([]*json.RawMessage, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = plan.Steps[i].Public()
+	}
+
+	return enc(struct {
+		Steps []*json.RawMessage `json:"steps"`
+	}{
+		Steps: steps,
+	})
+}
+
 func (plan ArtifactInputPlan) Public() *json.RawMessage {
 	return enc(plan)
 }
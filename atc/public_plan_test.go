@@ -390,36 +390,67 @@ var _ = Describe("Plan", func() {
 						{
 							ID: "24",
 							Retry: &atc.RetryPlan{
-								atc.Plan{
-									ID: "25",
-									Task: &atc.TaskPlan{
-										Name:       "name",
-										ConfigPath: "some/config/path.yml",
-										Config: &atc.TaskConfig{
-											Params: atc.TaskEnv{"some": "secret"},
+								Steps: []atc.Plan{
+									atc.Plan{
+										ID: "25",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: atc.TaskEnv{"some": "secret"},
+											},
 										},
 									},
-								},
-								atc.Plan{
-									ID: "26",
-									Task: &atc.TaskPlan{
-										Name:       "name",
-										ConfigPath: "some/config/path.yml",
-										Config: &atc.TaskConfig{
-											Params: atc.TaskEnv{"some": "secret"},
+									atc.Plan{
+										ID: "26",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: atc.TaskEnv{"some": "secret"},
+											},
+										},
+									},
+									atc.Plan{
+										ID: "27",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: atc.TaskEnv{"some": "secret"},
+											},
 										},
 									},
 								},
-								atc.Plan{
-									ID: "27",
-									Task: &atc.TaskPlan{
-										Name:       "name",
-										ConfigPath: "some/config/path.yml",
-										Config: &atc.TaskConfig{
-											Params: atc.TaskEnv{"some": "secret"},
+							},
+						},
+
+						{
+							ID: "46",
+							While: &atc.WhilePlan{
+								Steps: []atc.Plan{
+									atc.Plan{
+										ID: "47",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: atc.TaskEnv{"some": "secret"},
+											},
+										},
+									},
+									atc.Plan{
+										ID: "48",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: atc.TaskEnv{"some": "secret"},
+											},
 										},
 									},
 								},
+								Var: "some-var",
 							},
 						},
 
@@ -489,10 +520,35 @@ var _ = Describe("Plan", func() {
 								},
 							},
 						},
+						{
+							ID: "43",
+							OnTimeout: &atc.OnTimeoutPlan{
+								Step: atc.Plan{
+									ID: "44",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+										Config: &atc.TaskConfig{
+											Params: atc.TaskEnv{"some": "secret"},
+										},
+									},
+								},
+								Next: atc.Plan{
+									ID: "45",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+										Config: &atc.TaskConfig{
+											Params: atc.TaskEnv{"some": "secret"},
+										},
+									},
+								},
+							},
+						},
 						{
 							ID: "36",
 							InParallel: &atc.InParallelPlan{
-								Limit:    1,
+								Limit:    &atc.InParallelLimit{Limit: 1},
 								FailFast: true,
 								Steps: []atc.Plan{
 									{
@@ -517,6 +573,7 @@ var _ = Describe("Plan", func() {
 								VarFiles:     []string{"vf"},
 								Vars:         map[string]interface{}{"k1": "v1"},
 								InstanceVars: map[string]interface{}{"branch": "feature/foo"},
+								DryRun:       true,
 							},
 						},
 						{
@@ -547,6 +604,17 @@ var _ = Describe("Plan", func() {
 								Reveal: true,
 							},
 						},
+						{
+							ID: "49",
+							Notify: &atc.NotifyPlan{
+								Name:    "some-name",
+								Message: "some-message",
+								Targets: []atc.NotifyTarget{
+									{Type: "slack", URL: "https://slack.example.com/some-secret"},
+									{Type: "http", URL: "https://example.com/some-secret"},
+								},
+							},
+						},
 					},
 				},
 			}
@@ -839,6 +907,27 @@ var _ = Describe("Plan", func() {
 					}
 				]
 			},
+			{
+				"id": "46",
+				"while": {
+					"steps": [
+						{
+							"id": "47",
+							"task": {
+								"name": "name",
+								"privileged": false
+							}
+						},
+						{
+							"id": "48",
+							"task": {
+								"name": "name",
+								"privileged": false
+							}
+						}
+					]
+				}
+			},
 			{
 				"id": "28",
 				"on_abort": {
@@ -890,6 +979,25 @@ var _ = Describe("Plan", func() {
 					}
 				}
 			},
+			{
+				"id": "43",
+				"on_timeout": {
+					"step": {
+						"id": "44",
+						"task": {
+							"name": "name",
+							"privileged": false
+						}
+					},
+					"on_timeout": {
+						"id": "45",
+						"task": {
+							"name": "name",
+							"privileged": false
+						}
+					}
+				}
+			},
 			{
 				"id": "36",
 				"in_parallel": {
@@ -913,7 +1021,8 @@ var _ = Describe("Plan", func() {
 					"team": "some-team",
 					"instance_vars": {
 						"branch": "feature/foo"
-					}
+					},
+					"dry_run": true
 				}
 			},
       {
@@ -935,6 +1044,13 @@ var _ = Describe("Plan", func() {
 				"load_var": {
 					"name": "some-name"
 				}
+			},
+			{
+				"id": "49",
+				"notify": {
+					"name": "some-name",
+					"targets": ["slack", "http"]
+				}
 			}
 		]
 	}
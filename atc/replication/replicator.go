@@ -0,0 +1,209 @@
+package replication
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . Pool
+type Pool interface {
+	CompatibleWorkers(lager.Logger, worker.Spec) ([]db.Worker, error)
+	FindWorker(lager.Logger, string) (runtime.Worker, bool, error)
+	FindResourceCacheVolumeOnWorker(lager.Logger, db.ResourceCache, worker.Spec, string) (runtime.Volume, bool, error)
+}
+
+//counterfeiter:generate . Streamer
+type Streamer interface {
+	Stream(context.Context, runtime.Artifact, runtime.Volume) error
+}
+
+type Config struct {
+	// Factor is the number of workers each recently fetched resource cache
+	// should be on.
+	Factor int
+	// Window is how long a resource cache is replicated for after it was
+	// last fetched.
+	Window time.Duration
+}
+
+// Replicator streams the resource caches recently fetched by get steps to
+// other workers the builds of the same team and tags may run on, until each
+// is on as many workers as the replication factor, so that builds scheduled
+// there later don't have to wait for them to be streamed.
+type Replicator struct {
+	replicationFactory db.ReplicationFactory
+	pool               Pool
+	streamer           Streamer
+	config             Config
+}
+
+func NewReplicator(
+	replicationFactory db.ReplicationFactory,
+	pool Pool,
+	streamer Streamer,
+	config Config,
+) *Replicator {
+	return &Replicator{
+		replicationFactory: replicationFactory,
+		pool:               pool,
+		streamer:           streamer,
+		config:             config,
+	}
+}
+
+func (r *Replicator) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	since := time.Now().Add(-r.config.Window)
+
+	err := r.replicationFactory.PruneResourceCacheReplications(since)
+	if err != nil {
+		logger.Error("failed-to-prune-replications", err)
+		return err
+	}
+
+	replications, err := r.replicationFactory.RecentlyFetchedResourceCaches(since)
+	if err != nil {
+		logger.Error("failed-to-find-recently-fetched-resource-caches", err)
+		return err
+	}
+
+	for _, replication := range replications {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		cacheLogger := logger.Session("replicate", lager.Data{
+			"resource-cache-id": replication.ResourceCache.ID(),
+			"team-id":           replication.TeamID,
+			"tags":              replication.Tags,
+		})
+
+		err := r.replicate(ctx, cacheLogger, replication)
+		if err != nil {
+			cacheLogger.Error("failed-to-replicate-resource-cache", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Replicator) replicate(ctx context.Context, logger lager.Logger, replication db.ResourceCacheReplication) error {
+	spec := worker.Spec{
+		TeamID: replication.TeamID,
+		Tags:   replication.Tags,
+	}
+
+	workers, err := r.pool.CompatibleWorkers(logger, spec)
+	if err != nil {
+		return err
+	}
+
+	var src runtime.Volume
+	var srcPlatform string
+	var holders int
+	var missing []db.Worker
+	for _, dbWorker := range workers {
+		volume, found, err := r.pool.FindResourceCacheVolumeOnWorker(logger, replication.ResourceCache, spec, dbWorker.Name())
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			missing = append(missing, dbWorker)
+			continue
+		}
+
+		holders++
+
+		if src == nil {
+			src = volume
+			srcPlatform = dbWorker.Platform()
+		}
+	}
+
+	// the cache isn't on any compatible worker, so there's nothing to stream
+	// from. it will be fetched again the next time a build needs it.
+	if src == nil || holders >= r.config.Factor {
+		return nil
+	}
+
+	// volumes can only be streamed between workers of the same platform, and
+	// the least busy workers are replicated to first
+	var targets []db.Worker
+	for _, dbWorker := range missing {
+		if dbWorker.Platform() == srcPlatform {
+			targets = append(targets, dbWorker)
+		}
+	}
+
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targets[i].ActiveContainers() < targets[j].ActiveContainers()
+	})
+
+	if len(targets) > r.config.Factor-holders {
+		targets = targets[:r.config.Factor-holders]
+	}
+
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		targetLogger := logger.WithData(lager.Data{"worker": target.Name()})
+
+		err := r.replicateTo(ctx, targetLogger, replication, src, target.Name())
+		if err != nil {
+			targetLogger.Error("failed-to-replicate-to-worker", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Replicator) replicateTo(ctx context.Context, logger lager.Logger, replication db.ResourceCacheReplication, src runtime.Volume, workerName string) error {
+	runtimeWorker, found, err := r.pool.FindWorker(logger, workerName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	dst, _, err := runtimeWorker.CreateVolumeForArtifact(logger, replication.TeamID)
+	if err != nil {
+		return err
+	}
+
+	err = r.streamer.Stream(lagerctx.NewContext(ctx, logger), src, dst)
+	if err != nil {
+		return err
+	}
+
+	// the streamer already initializes streamed resource caches when caching
+	// of streamed volumes is enabled
+	if !atc.EnableCacheStreamedVolumes {
+		err = dst.InitializeStreamedResourceCache(logger, replication.ResourceCache, src.DBVolume().WorkerName())
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Info("replicated-resource-cache", lager.Data{"from": src.DBVolume().WorkerName()})
+
+	return nil
+}
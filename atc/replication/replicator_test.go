@@ -0,0 +1,197 @@
+package replication_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/replication"
+	"github.com/concourse/concourse/atc/replication/replicationfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Replicator", func() {
+	var (
+		fakeReplicationFactory *dbfakes.FakeReplicationFactory
+		fakePool               *replicationfakes.FakePool
+		fakeStreamer           *replicationfakes.FakeStreamer
+		config                 replication.Config
+
+		fakeCache *dbfakes.FakeResourceCache
+		srcVolume *runtimetest.Volume
+
+		runtimeWorkers map[string]*runtimetest.Worker
+		holders        map[string]bool
+
+		runErr error
+	)
+
+	newDBWorker := func(name string, platform string, activeContainers int) *dbfakes.FakeWorker {
+		dbWorker := new(dbfakes.FakeWorker)
+		dbWorker.NameReturns(name)
+		dbWorker.PlatformReturns(platform)
+		dbWorker.ActiveContainersReturns(activeContainers)
+		runtimeWorkers[name] = runtimetest.NewWorker(name)
+		return dbWorker
+	}
+
+	BeforeEach(func() {
+		fakeReplicationFactory = new(dbfakes.FakeReplicationFactory)
+		fakePool = new(replicationfakes.FakePool)
+		fakeStreamer = new(replicationfakes.FakeStreamer)
+		config = replication.Config{
+			Factor: 2,
+			Window: time.Hour,
+		}
+
+		runtimeWorkers = map[string]*runtimetest.Worker{}
+		holders = map[string]bool{"src-worker": true}
+
+		fakeCache = new(dbfakes.FakeResourceCache)
+		fakeCache.IDReturns(42)
+
+		fakeReplicationFactory.RecentlyFetchedResourceCachesReturns([]db.ResourceCacheReplication{
+			{ResourceCache: fakeCache, TeamID: 1, Tags: []string{"some-tag"}},
+		}, nil)
+
+		fakePool.CompatibleWorkersReturns([]db.Worker{
+			newDBWorker("src-worker", "linux", 0),
+			newDBWorker("busy-worker", "linux", 10),
+			newDBWorker("idle-worker", "linux", 1),
+			newDBWorker("windows-worker", "windows", 0),
+		}, nil)
+
+		srcVolume = runtimetest.NewVolume("src-volume")
+		srcVolume.DBVolume_.WorkerNameReturns("src-worker")
+
+		fakePool.FindResourceCacheVolumeOnWorkerStub = func(_ lager.Logger, _ db.ResourceCache, _ worker.Spec, name string) (runtime.Volume, bool, error) {
+			if holders[name] {
+				return srcVolume, true, nil
+			}
+			return nil, false, nil
+		}
+
+		fakePool.FindWorkerStub = func(_ lager.Logger, name string) (runtime.Worker, bool, error) {
+			w, found := runtimeWorkers[name]
+			return w, found, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = replication.NewReplicator(
+			fakeReplicationFactory,
+			fakePool,
+			fakeStreamer,
+			config,
+		).Run(ctx)
+	})
+
+	It("succeeds", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+	})
+
+	It("prunes and finds the caches fetched within the window", func() {
+		Expect(fakeReplicationFactory.PruneResourceCacheReplicationsCallCount()).To(Equal(1))
+		Expect(fakeReplicationFactory.PruneResourceCacheReplicationsArgsForCall(0)).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+
+		Expect(fakeReplicationFactory.RecentlyFetchedResourceCachesCallCount()).To(Equal(1))
+		Expect(fakeReplicationFactory.RecentlyFetchedResourceCachesArgsForCall(0)).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+	})
+
+	It("looks for workers compatible with the team and tags", func() {
+		Expect(fakePool.CompatibleWorkersCallCount()).To(Equal(1))
+		_, spec := fakePool.CompatibleWorkersArgsForCall(0)
+		Expect(spec).To(Equal(worker.Spec{TeamID: 1, Tags: []string{"some-tag"}}))
+	})
+
+	It("streams the cache to the least busy worker of the same platform", func() {
+		Expect(fakeStreamer.StreamCallCount()).To(Equal(1))
+		_, src, dst := fakeStreamer.StreamArgsForCall(0)
+		Expect(src).To(Equal(srcVolume))
+		Expect(runtimeWorkers["idle-worker"].Volumes).To(ConsistOf(dst))
+	})
+
+	It("initializes the streamed volume as the resource cache", func() {
+		Expect(runtimeWorkers["idle-worker"].Volumes).To(HaveLen(1))
+		Expect(runtimeWorkers["idle-worker"].Volumes[0].ResourceCacheInitialized).To(BeTrue())
+		Expect(runtimeWorkers["idle-worker"].Volumes[0].ResourceCacheStreamedFrom).To(Equal("src-worker"))
+	})
+
+	Context("when the factor is higher", func() {
+		BeforeEach(func() {
+			config.Factor = 10
+		})
+
+		It("streams the cache to every worker of the same platform", func() {
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(2))
+			Expect(runtimeWorkers["idle-worker"].Volumes).To(HaveLen(1))
+			Expect(runtimeWorkers["busy-worker"].Volumes).To(HaveLen(1))
+			Expect(runtimeWorkers["windows-worker"].Volumes).To(BeEmpty())
+		})
+	})
+
+	Context("when the cache is already on enough workers", func() {
+		BeforeEach(func() {
+			holders["busy-worker"] = true
+		})
+
+		It("does not stream it", func() {
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when no worker has the cache", func() {
+		BeforeEach(func() {
+			holders = map[string]bool{}
+		})
+
+		It("does not stream it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when streaming fails", func() {
+		BeforeEach(func() {
+			config.Factor = 3
+			fakeStreamer.StreamReturns(errors.New("nope"))
+		})
+
+		It("carries on to the next worker without initializing the cache", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(2))
+			Expect(runtimeWorkers["idle-worker"].Volumes[0].ResourceCacheInitialized).To(BeFalse())
+		})
+	})
+
+	Context("when finding compatible workers fails", func() {
+		BeforeEach(func() {
+			fakePool.CompatibleWorkersReturns(nil, errors.New("nope"))
+		})
+
+		It("carries on", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStreamer.StreamCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when finding the recently fetched caches fails", func() {
+		BeforeEach(func() {
+			fakeReplicationFactory.RecentlyFetchedResourceCachesReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
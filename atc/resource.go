@@ -10,9 +10,17 @@ type Resource struct {
 	LastChecked          int64        `json:"last_checked,omitempty"`
 	Icon                 string       `json:"icon,omitempty"`
 
+	LastCheckStartTime int64   `json:"last_check_start_time,omitempty"`
+	LastCheckEndTime   int64   `json:"last_check_end_time,omitempty"`
+	LastCheckDuration  float64 `json:"last_check_duration,omitempty"`
+	LastCheckError     string  `json:"last_check_error,omitempty"`
+	NextCheckTime      int64   `json:"next_check_time,omitempty"`
+
 	PinnedVersion  Version `json:"pinned_version,omitempty"`
 	PinnedInConfig bool    `json:"pinned_in_config,omitempty"`
 	PinComment     string  `json:"pin_comment,omitempty"`
 
+	ChecksPaused bool `json:"checks_paused,omitempty"`
+
 	Build *BuildSummary `json:"build,omitempty"`
 }
@@ -59,21 +59,34 @@ func (resource Resource) Get(ctx context.Context, container runtime.Container, s
 		return versionResult, runtime.ProcessResult{}, nil
 	}
 
+	versionResult, processResult, err := resource.GetInto(ctx, container, stderr, resourceProcessID, ResourcesDir("get"))
+	if err != nil {
+		return VersionResult{}, runtime.ProcessResult{}, err
+	}
+
+	if err := resource.cacheResult(container, versionResult); err != nil {
+		return VersionResult{}, runtime.ProcessResult{}, err
+	}
+
+	return versionResult, processResult, nil
+}
+
+// GetInto fetches the resource into the given directory by running a process
+// with the given ID, attaching to it if it is already running. Unlike Get, the
+// result is not cached on the container.
+func (resource Resource) GetInto(ctx context.Context, container runtime.Container, stderr io.Writer, processID string, dir string) (VersionResult, runtime.ProcessResult, error) {
 	spec := runtime.ProcessSpec{
-		ID:   resourceProcessID,
+		ID:   processID,
 		Path: "/opt/resource/in",
-		Args: []string{ResourcesDir("get")},
+		Args: []string{dir},
 	}
 
+	var versionResult VersionResult
 	processResult, err := resource.run(ctx, container, spec, stderr, true, &versionResult)
 	if err != nil {
 		return VersionResult{}, runtime.ProcessResult{}, err
 	}
 
-	if err := resource.cacheResult(container, versionResult); err != nil {
-		return VersionResult{}, runtime.ProcessResult{}, err
-	}
-
 	return versionResult, processResult, nil
 }
 
@@ -92,12 +105,29 @@ func (resource Resource) Put(ctx context.Context, container runtime.Container, s
 		return versionResult, runtime.ProcessResult{}, nil
 	}
 
+	versionResult, processResult, err := resource.PutAs(ctx, container, stderr, resourceProcessID)
+	if err != nil {
+		return VersionResult{}, runtime.ProcessResult{}, err
+	}
+
+	if err := resource.cacheResult(container, versionResult); err != nil {
+		return VersionResult{}, runtime.ProcessResult{}, err
+	}
+
+	return versionResult, processResult, nil
+}
+
+// PutAs pushes the resource by running a process with the given ID, attaching
+// to it if it is already running. Unlike Put, the result is not cached on the
+// container, so several puts may run within the same container.
+func (resource Resource) PutAs(ctx context.Context, container runtime.Container, stderr io.Writer, processID string) (VersionResult, runtime.ProcessResult, error) {
 	spec := runtime.ProcessSpec{
-		ID:   resourceProcessID,
+		ID:   processID,
 		Path: "/opt/resource/out",
 		Args: []string{ResourcesDir("put")},
 	}
 
+	var versionResult VersionResult
 	processResult, err := resource.run(ctx, container, spec, stderr, true, &versionResult)
 	if err != nil {
 		return VersionResult{}, runtime.ProcessResult{}, err
@@ -106,10 +136,6 @@ func (resource Resource) Put(ctx context.Context, container runtime.Container, s
 		return VersionResult{}, runtime.ProcessResult{}, fmt.Errorf("resource script (%s %s) output a null version", spec.Path, strings.Join(spec.Args, " "))
 	}
 
-	if err := resource.cacheResult(container, versionResult); err != nil {
-		return VersionResult{}, runtime.ProcessResult{}, err
-	}
-
 	return versionResult, processResult, nil
 }
 
@@ -198,6 +198,52 @@ func TestResourceGet(t *testing.T) {
 	})
 }
 
+func TestResourceGetInto(t *testing.T) {
+	resource := Resource{
+		Source:  atc.Source{"some": "source"},
+		Params:  atc.Params{"some": "params"},
+		Version: atc.Version{"some": "version"},
+	}
+	ctx := context.Background()
+	expectedSpec := runtime.ProcessSpec{
+		ID:   "resource-1",
+		Path: "/opt/resource/in",
+		Args: []string{"/tmp/build/get/1"},
+	}
+
+	t.Run("successful run", func(t *testing.T) {
+		expectedResult := VersionResult{
+			Version: atc.Version{"version": "v1"},
+		}
+		container := runtimetest.NewContainer().
+			WithProcess(
+				expectedSpec,
+				runtimetest.ProcessStub{
+					Output: expectedResult,
+				},
+			)
+		result, processResult, err := resource.GetInto(ctx, container, new(bytes.Buffer), "resource-1", "/tmp/build/get/1")
+		require.NoError(t, err)
+		require.Equal(t, expectedResult, result)
+		require.Equal(t, 0, processResult.ExitStatus)
+	})
+
+	t.Run("does not cache the result", func(t *testing.T) {
+		container := runtimetest.NewContainer().
+			WithProcess(
+				expectedSpec,
+				runtimetest.ProcessStub{
+					Output: VersionResult{
+						Version: atc.Version{"version": "v1"},
+					},
+				},
+			)
+		_, _, err := resource.GetInto(ctx, container, new(bytes.Buffer), "resource-1", "/tmp/build/get/1")
+		require.NoError(t, err)
+		require.Empty(t, container.Props)
+	})
+}
+
 func TestResourcePut(t *testing.T) {
 	resource := Resource{
 		Source:  atc.Source{"some": "source"},
@@ -337,3 +383,46 @@ func TestResourcePut(t *testing.T) {
 		})
 	})
 }
+
+func TestResourcePutAs(t *testing.T) {
+	resource := Resource{
+		Source: atc.Source{"some": "source"},
+		Params: atc.Params{"some": "params"},
+	}
+	ctx := context.Background()
+	expectedSpec := runtime.ProcessSpec{
+		ID:   "resource-1",
+		Path: "/opt/resource/out",
+		Args: []string{"/tmp/build/put"},
+	}
+
+	t.Run("successful run", func(t *testing.T) {
+		expectedResult := VersionResult{
+			Version: atc.Version{"version": "v1"},
+		}
+		container := runtimetest.NewContainer().
+			WithProcess(
+				expectedSpec,
+				runtimetest.ProcessStub{
+					Output: expectedResult,
+				},
+			)
+		result, processResult, err := resource.PutAs(ctx, container, new(bytes.Buffer), "resource-1")
+		require.NoError(t, err)
+		require.Equal(t, expectedResult, result)
+		require.Equal(t, 0, processResult.ExitStatus)
+		require.Empty(t, container.Props)
+	})
+
+	t.Run("null version", func(t *testing.T) {
+		container := runtimetest.NewContainer().
+			WithProcess(
+				expectedSpec,
+				runtimetest.ProcessStub{
+					Output: VersionResult{},
+				},
+			)
+		_, _, err := resource.PutAs(ctx, container, new(bytes.Buffer), "resource-1")
+		require.EqualError(t, err, "resource script (/opt/resource/out /tmp/build/put) output a null version")
+	})
+}
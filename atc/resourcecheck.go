@@ -1,12 +1,93 @@
 package atc
 
-import "time"
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
 
 var (
 	DefaultCheckInterval   time.Duration
 	DefaultWebhookInterval time.Duration
+	DefaultHookTimeout     time.Duration
+
+	// MaxStepReschedules is the number of times a get, check or task step is
+	// re-run from the start when the worker running it goes away. 0 disables
+	// rescheduling.
+	MaxStepReschedules int
+
+	// ResourceCacheReplicationFactor is the number of workers the caches
+	// fetched by get steps are replicated to in the background. 0 disables
+	// replication.
+	ResourceCacheReplicationFactor int
+
+	// MinimumCheckInterval is the shortest interval any check may run on,
+	// regardless of the check_every configured by the pipeline.
+	MinimumCheckInterval time.Duration
+
+	// TeamDefaultCheckIntervals overrides DefaultCheckInterval for the
+	// resources of individual teams, keyed by team name.
+	TeamDefaultCheckIntervals map[string]time.Duration
+
+	// CheckIntervalJitter is the longest delay added to the interval of
+	// periodic checks, so that resources with the same interval don't all
+	// check at once.
+	CheckIntervalJitter time.Duration
+
+	// BaseResourceTypeCheckTimeouts overrides the global check timeout for
+	// resources of individual base resource types, keyed by type name.
+	BaseResourceTypeCheckTimeouts map[string]time.Duration
 )
 
+// DefaultCheckIntervalForTeam returns the interval on which to check the
+// team's resources which do not configure check_every.
+func DefaultCheckIntervalForTeam(teamName string) time.Duration {
+	if interval, found := TeamDefaultCheckIntervals[teamName]; found {
+		return interval
+	}
+
+	return DefaultCheckInterval
+}
+
+// CheckTimeoutForType returns the timeout of checks of the given resource
+// type. The check_timeout configured by the pipeline takes precedence over
+// the type's default. An empty timeout means the global check timeout.
+func CheckTimeoutForType(resourceType string, checkTimeout string) string {
+	if checkTimeout != "" {
+		return checkTimeout
+	}
+
+	if timeout, found := BaseResourceTypeCheckTimeouts[resourceType]; found {
+		return timeout.String()
+	}
+
+	return ""
+}
+
+// EnforceMinimumCheckInterval raises the interval to MinimumCheckInterval if
+// it is shorter.
+func EnforceMinimumCheckInterval(interval CheckEvery) CheckEvery {
+	if !interval.Never && interval.Interval < MinimumCheckInterval {
+		interval.Interval = MinimumCheckInterval
+	}
+
+	return interval
+}
+
+// JitterCheckInterval adds a delay of up to CheckIntervalJitter to the
+// interval. The delay is derived from the resource config scope and the end of
+// its last check, so that it stays the same until the scope is checked again.
+func JitterCheckInterval(interval time.Duration, scopeID int, lastCheckEnd time.Time) time.Duration {
+	if CheckIntervalJitter <= 0 {
+		return interval
+	}
+
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d/%d", scopeID, lastCheckEnd.UnixNano())
+
+	return interval + time.Duration(hash.Sum64()%uint64(CheckIntervalJitter))
+}
+
 type CheckRequestBody struct {
 	From    Version `json:"from"`
 	Shallow bool    `json:"shallow"`
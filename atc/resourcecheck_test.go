@@ -0,0 +1,85 @@
+package atc_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JitterCheckInterval", func() {
+	var lastCheckEnd time.Time
+
+	BeforeEach(func() {
+		lastCheckEnd = time.Unix(1600000000, 0)
+	})
+
+	AfterEach(func() {
+		atc.CheckIntervalJitter = 0
+	})
+
+	Context("when no jitter is configured", func() {
+		It("returns the interval", func() {
+			Expect(atc.JitterCheckInterval(time.Minute, 1, lastCheckEnd)).To(Equal(time.Minute))
+		})
+	})
+
+	Context("when jitter is configured", func() {
+		BeforeEach(func() {
+			atc.CheckIntervalJitter = 30 * time.Second
+		})
+
+		It("adds up to the jitter to the interval", func() {
+			for id := 1; id <= 100; id++ {
+				interval := atc.JitterCheckInterval(time.Minute, id, lastCheckEnd)
+				Expect(interval).To(BeNumerically(">=", time.Minute))
+				Expect(interval).To(BeNumerically("<", time.Minute+30*time.Second))
+			}
+		})
+
+		It("returns the same interval until the next check", func() {
+			Expect(atc.JitterCheckInterval(time.Minute, 1, lastCheckEnd)).To(Equal(atc.JitterCheckInterval(time.Minute, 1, lastCheckEnd)))
+		})
+
+		It("spreads scopes with the same interval apart", func() {
+			intervals := map[time.Duration]bool{}
+			for id := 1; id <= 100; id++ {
+				intervals[atc.JitterCheckInterval(time.Minute, id, lastCheckEnd)] = true
+			}
+
+			Expect(len(intervals)).To(BeNumerically(">", 90))
+		})
+	})
+})
+
+var _ = Describe("CheckTimeoutForType", func() {
+	AfterEach(func() {
+		atc.BaseResourceTypeCheckTimeouts = nil
+	})
+
+	Context("when no timeouts are configured for the type", func() {
+		It("returns the pipeline's timeout", func() {
+			Expect(atc.CheckTimeoutForType("git", "5m")).To(Equal("5m"))
+			Expect(atc.CheckTimeoutForType("git", "")).To(BeEmpty())
+		})
+	})
+
+	Context("when a timeout is configured for the type", func() {
+		BeforeEach(func() {
+			atc.BaseResourceTypeCheckTimeouts = map[string]time.Duration{
+				"git": time.Minute,
+			}
+		})
+
+		It("defaults to the type's timeout", func() {
+			Expect(atc.CheckTimeoutForType("git", "")).To(Equal("1m0s"))
+			Expect(atc.CheckTimeoutForType("registry-image", "")).To(BeEmpty())
+		})
+
+		It("is overridden by the pipeline's timeout", func() {
+			Expect(atc.CheckTimeoutForType("git", "10m")).To(Equal("10m"))
+		})
+	})
+})
@@ -3,16 +3,20 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig      = "SaveConfig"
+	GetConfig       = "GetConfig"
+	PreviewPipeline = "PreviewPipeline"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
+	GetBuildPlanDAG     = "GetBuildPlanDAG"
 	CreateBuild         = "CreateBuild"
 	ListBuilds          = "ListBuilds"
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	PauseBuild          = "PauseBuild"
+	ResumeBuild         = "ResumeBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 	SetBuildComment     = "SetBuildComment"
 
@@ -42,6 +46,10 @@ const (
 	CheckResourceType    = "CheckResourceType"
 	CheckPrototype       = "CheckPrototype"
 
+	CreateResourceWebhookToken  = "CreateResourceWebhookToken"
+	ListResourceWebhookTokens   = "ListResourceWebhookTokens"
+	RotateResourceWebhookTokens = "RotateResourceWebhookTokens"
+
 	ListResourceVersions           = "ListResourceVersions"
 	GetResourceVersion             = "GetResourceVersion"
 	EnableResourceVersion          = "EnableResourceVersion"
@@ -49,6 +57,8 @@ const (
 	PinResourceVersion             = "PinResourceVersion"
 	UnpinResource                  = "UnpinResource"
 	SetPinCommentOnResource        = "SetPinCommentOnResource"
+	PauseResourceChecks            = "PauseResourceChecks"
+	UnpauseResourceChecks          = "UnpauseResourceChecks"
 	ListBuildsWithVersionAsInput   = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput  = "ListBuildsWithVersionAsOutput"
 	ClearResourceCache             = "ClearResourceCache"
@@ -109,12 +119,20 @@ const (
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
 
+	GetBuildTestResults = "GetBuildTestResults"
+
 	GetUser              = "GetUser"
 	ListActiveUsersSince = "ListActiveUsersSince"
 
 	SetWall   = "SetWall"
 	GetWall   = "GetWall"
 	ClearWall = "ClearWall"
+
+	CreateWorkerEnrollmentToken = "CreateWorkerEnrollmentToken"
+	ListWorkerEnrollmentTokens  = "ListWorkerEnrollmentTokens"
+	DeleteWorkerEnrollmentToken = "DeleteWorkerEnrollmentToken"
+	EnrollWorker                = "EnrollWorker"
+	ListEnrolledWorkerKeys      = "ListEnrolledWorkerKeys"
 )
 
 const (
@@ -125,17 +143,22 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipeline_preview", Method: "POST", Name: PreviewPipeline},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
 	{Path: "/api/v1/builds/:build_id", Method: "GET", Name: GetBuild},
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
+	{Path: "/api/v1/builds/:build_id/plan/dag", Method: "GET", Name: GetBuildPlanDAG},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/pause", Method: "PUT", Name: PauseBuild},
+	{Path: "/api/v1/builds/:build_id/resume", Method: "PUT", Name: ResumeBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/test_results", Method: "GET", Name: GetBuildTestResults},
 	{Path: "/api/v1/builds/:build_id/comment", Method: "PUT", Name: SetBuildComment},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
@@ -177,6 +200,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook-tokens", Method: "POST", Name: CreateResourceWebhookToken},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook-tokens", Method: "GET", Name: ListResourceWebhookTokens},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook-tokens/rotate", Method: "PUT", Name: RotateResourceWebhookTokens},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/check", Method: "POST", Name: CheckResourceType},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/prototypes/:prototype_name/check", Method: "POST", Name: CheckPrototype},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/cache", Method: "DELETE", Name: ClearResourceCache},
@@ -188,6 +214,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/pin", Method: "PUT", Name: PinResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin_comment", Method: "PUT", Name: SetPinCommentOnResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause-checks", Method: "PUT", Name: PauseResourceChecks},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause-checks", Method: "PUT", Name: UnpauseResourceChecks},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/downstream", Method: "GET", Name: GetDownstreamResourceCausality},
@@ -237,4 +265,10 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/wall", Method: "GET", Name: GetWall},
 	{Path: "/api/v1/wall", Method: "PUT", Name: SetWall},
 	{Path: "/api/v1/wall", Method: "DELETE", Name: ClearWall},
+
+	{Path: "/api/v1/worker_enrollment_tokens", Method: "POST", Name: CreateWorkerEnrollmentToken},
+	{Path: "/api/v1/worker_enrollment_tokens", Method: "GET", Name: ListWorkerEnrollmentTokens},
+	{Path: "/api/v1/worker_enrollment_tokens/:token_id", Method: "DELETE", Name: DeleteWorkerEnrollmentToken},
+	{Path: "/api/v1/worker_enrollments", Method: "POST", Name: EnrollWorker},
+	{Path: "/api/v1/worker_enrollments", Method: "GET", Name: ListEnrolledWorkerKeys},
 })
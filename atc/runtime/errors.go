@@ -1,5 +1,11 @@
 package runtime
 
+import "errors"
+
+// ErrIsDirectory is returned when streaming a single file from a path that
+// refers to a directory.
+var ErrIsDirectory = errors.New("path is a directory")
+
 type ExecutableNotFoundError struct {
 	Message string
 }
@@ -46,6 +46,9 @@ func (c *Container) WithProcess(spec runtime.ProcessSpec, stub ProcessStub) *Con
 }
 
 func (c *Container) Run(ctx context.Context, spec runtime.ProcessSpec, io runtime.ProcessIO) (runtime.Process, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
 	for i, pd := range c.ProcessDefs {
 		if reflect.DeepEqual(pd.Spec, spec) {
 			// remove current ProcessDefinition
@@ -55,9 +58,7 @@ func (c *Container) Run(ctx context.Context, spec runtime.ProcessSpec, io runtim
 			p := &Process{Spec: pd.Spec, ProcessStub: pd.Stub}
 			p.addIO(io)
 
-			c.mtx.Lock()
 			c.processes = append(c.processes, p)
-			c.mtx.Unlock()
 			return p, nil
 		}
 	}
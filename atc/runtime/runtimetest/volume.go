@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +31,9 @@ type Volume struct {
 	ResourceCacheStreamedFrom string
 	TaskCacheInitialized      bool
 	DBVolume_                 *dbfakes.FakeCreatedVolume
+
+	QuotaLimit uint64
+	QuotaUsed  uint64
 }
 
 func NewVolume(handle string) *Volume {
@@ -79,6 +83,10 @@ func (v Volume) DBVolume() db.CreatedVolume {
 	return v.DBVolume_
 }
 
+func (v Volume) Quota(_ context.Context) (uint64, uint64, error) {
+	return v.QuotaLimit, v.QuotaUsed, nil
+}
+
 func (vc VolumeContent) StreamIn(ctx context.Context, path string, encoding baggageclaim.Encoding, tarStream io.Reader) error {
 	if encoding != baggageclaim.GzipEncoding {
 		return errors.New("only gzip is supported for runtimetest.VolumeContent")
@@ -166,6 +174,72 @@ func (vc VolumeContent) StreamOut(ctx context.Context, path string, encoding bag
 	return io.NopCloser(buf), nil
 }
 
+// volumeDelta is what VolumeContent streams out as the difference from
+// another VolumeContent, whose manifest is simply its content.
+type volumeDelta struct {
+	Changed map[string][]byte `json:"changed"`
+	Removed []string          `json:"removed"`
+}
+
+func (vc VolumeContent) StreamManifest(ctx context.Context) (io.ReadCloser, error) {
+	manifest := map[string][]byte{}
+	for path, file := range vc {
+		manifest[path] = file.Data
+	}
+
+	payload, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(payload)), nil
+}
+
+func (vc VolumeContent) StreamDeltaOut(ctx context.Context, manifest io.Reader, encoding baggageclaim.Encoding) (io.ReadCloser, error) {
+	var base map[string][]byte
+	if err := json.NewDecoder(manifest).Decode(&base); err != nil {
+		return nil, err
+	}
+
+	delta := volumeDelta{Changed: map[string][]byte{}}
+	for path, file := range vc {
+		if data, found := base[path]; !found || !bytes.Equal(data, file.Data) {
+			delta.Changed[path] = file.Data
+		}
+	}
+	for path := range base {
+		if _, found := vc[path]; !found {
+			delta.Removed = append(delta.Removed, path)
+		}
+	}
+
+	payload, err := json.Marshal(delta)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(payload)), nil
+}
+
+func (vc VolumeContent) StreamDeltaIn(ctx context.Context, base VolumeContent, encoding baggageclaim.Encoding, reader io.Reader) error {
+	var delta volumeDelta
+	if err := json.NewDecoder(reader).Decode(&delta); err != nil {
+		return err
+	}
+
+	for path, file := range base {
+		vc[path] = &fstest.MapFile{Data: file.Data}
+	}
+	for _, path := range delta.Removed {
+		delete(vc, path)
+	}
+	for path, data := range delta.Changed {
+		vc[path] = &fstest.MapFile{Data: data}
+	}
+
+	return nil
+}
+
 func removeLeadingSlash(path string) string {
 	path = strings.TrimPrefix(path, "/")
 	if path == "" {
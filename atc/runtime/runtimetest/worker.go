@@ -23,6 +23,12 @@ type Worker struct {
 	Containers []*WorkerContainer
 	Volumes    []*Volume
 	DBWorker_  *dbfakes.FakeWorker
+
+	PrewarmedBaseResourceTypes []string
+
+	// PersistedResourceCaches is the content of the resource caches that
+	// can be hydrated, keyed by resource cache ID.
+	PersistedResourceCaches map[int]VolumeContent
 }
 
 func NewWorker(name string) *Worker {
@@ -39,6 +45,18 @@ func (w Worker) WithVolumes(volumes ...*Volume) *Worker {
 	return &w2
 }
 
+// WithPersistedResourceCache makes the resource cache hydratable with the
+// content, as though it had been persisted by another worker.
+func (w Worker) WithPersistedResourceCache(resourceCacheID int, content VolumeContent) *Worker {
+	w2 := w
+	w2.PersistedResourceCaches = make(map[int]VolumeContent, len(w.PersistedResourceCaches)+1)
+	for id, c := range w.PersistedResourceCaches {
+		w2.PersistedResourceCaches[id] = c
+	}
+	w2.PersistedResourceCaches[resourceCacheID] = content
+	return &w2
+}
+
 func (w Worker) WithContainer(owner db.ContainerOwner, container *Container, mounts []runtime.VolumeMount) *Worker {
 	w2 := w
 	w2.Containers = make([]*WorkerContainer, len(w.Containers))
@@ -69,8 +87,28 @@ func (w Worker) Name() string {
 	return w.WorkerName
 }
 
-func (w Worker) CreateVolumeForArtifact(logger lager.Logger, teamID int) (runtime.Volume, db.WorkerArtifact, error) {
-	panic("unimplemented")
+func (w *Worker) CreateVolumeForArtifact(logger lager.Logger, teamID int) (runtime.Volume, db.WorkerArtifact, error) {
+	volume := NewVolume(fmt.Sprintf("%s-artifact-%d", w.WorkerName, len(w.Volumes)))
+	volume.DBVolume_.WorkerNameReturns(w.WorkerName)
+	w.Volumes = append(w.Volumes, volume)
+	return volume, new(dbfakes.FakeWorkerArtifact), nil
+}
+
+func (w *Worker) PrewarmBaseResourceType(logger lager.Logger, resourceTypeName string) error {
+	w.PrewarmedBaseResourceTypes = append(w.PrewarmedBaseResourceTypes, resourceTypeName)
+	return nil
+}
+
+func (w *Worker) HydrateResourceCache(logger lager.Logger, teamID int, cache db.ResourceCache) (runtime.Volume, bool, error) {
+	content, found := w.PersistedResourceCaches[cache.ID()]
+	if !found {
+		return nil, false, nil
+	}
+	volume := NewVolume(fmt.Sprintf("%s-hydrated-%d", w.WorkerName, cache.ID())).WithContent(content)
+	volume.DBVolume_.WorkerNameReturns(w.WorkerName)
+	volume.ResourceCacheInitialized = true
+	w.Volumes = append(w.Volumes, volume)
+	return volume, true, nil
 }
 
 func (w *Worker) FindOrCreateContainer(ctx context.Context, owner db.ContainerOwner, metadata db.ContainerMetadata, spec runtime.ContainerSpec) (runtime.Container, []runtime.VolumeMount, error) {
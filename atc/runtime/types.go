@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"go.opentelemetry.io/otel/propagation"
@@ -33,6 +34,15 @@ type Worker interface {
 	// WorkerArtifact. This is used for uploading local inputs to a worker via
 	// `fly execute -i ...`.
 	CreateVolumeForArtifact(logger lager.Logger, teamID int) (Volume, db.WorkerArtifact, error)
+	// PrewarmBaseResourceType imports the image of the named base resource
+	// type into a Volume, if it hasn't been imported already, so that the
+	// first container to use it does not have to wait for the import.
+	PrewarmBaseResourceType(logger lager.Logger, resourceTypeName string) error
+	// HydrateResourceCache creates a Volume from the copy of the resource
+	// cache persisted by the Worker that fetched it, and initializes it as the
+	// resource cache on this Worker. If no copy was persisted, or the Worker
+	// can't restore persisted volumes, false is returned.
+	HydrateResourceCache(logger lager.Logger, teamID int, cache db.ResourceCache) (Volume, bool, error)
 
 	// LookupContainer finds the Container on the Worker by its handle, if it
 	// exists.
@@ -80,6 +90,11 @@ type ContainerSpec struct {
 	TeamID int
 	// TeamName is the name of the team to which the Container belongs.
 	TeamName string
+	// PipelineID and PipelineName identify the pipeline in which the
+	// Container is running, used for pipeline affinity when placing it. They
+	// are left empty for Containers which are not part of a pipeline.
+	PipelineID   int
+	PipelineName string
 	// JobID identifies the job in which the Container is running, used for
 	// identifying task caches.
 	JobID int
@@ -122,6 +137,35 @@ type ContainerSpec struct {
 	// CertsBindMount indicates whether or not to mount the worker's Certs
 	// volume onto the container.
 	CertsBindMount bool
+
+	// Hermetic indicates that the container should be created without
+	// networking.
+	Hermetic bool
+
+	// Tmpfs is a list of in-memory filesystems to mount into the container.
+	//
+	// Paths may be relative (to Dir) or absolute.
+	Tmpfs []TmpfsMount
+
+	// ShmSize is the size of the container's /dev/shm in bytes. If zero, the
+	// runtime's default is used.
+	ShmSize uint64
+
+	// GPUs is the number of GPUs to mount into the container.
+	GPUs int
+
+	// Priority is used when placing the container. When workers are scarce,
+	// containers with a higher Priority are placed before those with a lower
+	// one.
+	Priority int
+}
+
+// TmpfsMount is an in-memory filesystem mounted into a container.
+type TmpfsMount struct {
+	Path string
+	// Size is the maximum size of the filesystem in bytes. If zero, the
+	// runtime's default is used.
+	Size uint64
 }
 
 // ContainerSpec must implement propagation.TextMapCarrier so that it can be
@@ -252,12 +296,18 @@ type OutputPaths map[string]string
 type ImageSpec struct {
 	// ImageArtifact is the Artifact whose contents are the container image.
 	ImageArtifact Artifact
+	// ImageArtifactSource is the registry image ImageArtifact was fetched
+	// from, if known, so that its signature can be verified.
+	ImageArtifactSource *atc.RegistryImage
 	// ImageURL points to a path on the worker to use as the rootfs. This
 	// corresponds with `task.rootfs_uri`:
 	// https://concourse-ci.org/tasks.html#schema.task.rootfs_uri
 	ImageURL string
 	// ResourceType is the name of the base resource type to use for the image.
 	ResourceType string
+	// RegistryImage is an image for the worker to pull straight from a
+	// registry by its digest. This corresponds with `task.image`.
+	RegistryImage *atc.RegistryImage
 
 	// Privileged indicates whether the container should be privileged. The
 	// precise meaning of "privileged" is runtime specific.
@@ -341,6 +391,43 @@ type P2PVolume interface {
 	StreamP2POut(ctx context.Context, path string, destURL string, compression compression.Compression) error
 }
 
+// DeltaVolume is an interface that may also be satisfied by Volume
+// implementations. When streaming a resource cache's Volume to another
+// Volume, if both implement this interface and the destination's worker
+// holds a Volume for another version of the same resource, only the
+// difference from that Volume is streamed.
+type DeltaVolume interface {
+	Volume
+
+	// FindDeltaBase finds a Volume on the same worker as this Volume which
+	// holds another cache of the same resource config as the given resource
+	// cache, to stream it in as the difference from.
+	FindDeltaBase(ctx context.Context, cache db.ResourceCache) (DeltaVolume, bool, error)
+
+	// StreamManifest streams a manifest of the contents of the Volume, which
+	// another DeltaVolume can stream out the difference from.
+	StreamManifest(ctx context.Context) (io.ReadCloser, error)
+
+	// StreamDeltaOut streams the compressed difference between the contents
+	// of the Volume and those described by the manifest of another Volume.
+	StreamDeltaOut(ctx context.Context, manifest io.Reader, compression compression.Compression) (io.ReadCloser, error)
+
+	// StreamDeltaIn populates the Volume with the contents of base, which
+	// must be on the same worker, changed by the difference streamed out of
+	// another DeltaVolume against base's manifest.
+	StreamDeltaIn(ctx context.Context, base DeltaVolume, compression compression.Compression, delta io.Reader) error
+}
+
+// QuotaVolume is an interface that may also be satisfied by Volume
+// implementations whose size can be limited by a quota.
+type QuotaVolume interface {
+	Volume
+
+	// Quota returns the number of bytes the Volume is limited to, which is 0
+	// if it isn't limited, and how many of them are used.
+	Quota(ctx context.Context) (limit uint64, used uint64, err error)
+}
+
 // VolumeMount defines a Volume mounted at a particular path in a Container.
 type VolumeMount struct {
 	// Volume is the mounted Volume.
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const clamdChunkSize = 64 * 1024
+
+type clamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamdScanner constructs a Scanner which streams artifacts to a clamd
+// daemon using the INSTREAM command. The address is either host:port or
+// unix:/path/to/socket.
+func NewClamdScanner(address string, timeout time.Duration) Scanner {
+	network := "tcp"
+	if strings.HasPrefix(address, "unix:") {
+		network = "unix"
+		address = strings.TrimPrefix(address, "unix:")
+	}
+
+	return clamdScanner{
+		network: network,
+		address: address,
+		timeout: timeout,
+	}
+}
+
+func (s clamdScanner) Scan(ctx context.Context, artifact string, tarStream io.Reader) (Result, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("dial clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	_, err = conn.Write([]byte("zINSTREAM\x00"))
+	if err != nil {
+		return Result{}, fmt.Errorf("write clamd command: %w", err)
+	}
+
+	err = writeChunks(conn, tarStream)
+	if err != nil {
+		return Result{}, err
+	}
+
+	response, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil && err != io.EOF {
+		return Result{}, fmt.Errorf("read clamd response: %w", err)
+	}
+
+	return parseClamdResponse(strings.TrimRight(response, "\x00\n"))
+}
+
+func writeChunks(w io.Writer, r io.Reader) error {
+	buf := make([]byte, clamdChunkSize)
+	size := make([]byte, 4)
+
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+
+			_, err := w.Write(size)
+			if err != nil {
+				return fmt.Errorf("write clamd chunk: %w", err)
+			}
+
+			_, err = w.Write(buf[:n])
+			if err != nil {
+				return fmt.Errorf("write clamd chunk: %w", err)
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+
+		if readErr != nil {
+			return fmt.Errorf("read artifact: %w", readErr)
+		}
+	}
+
+	binary.BigEndian.PutUint32(size, 0)
+
+	_, err := w.Write(size)
+	if err != nil {
+		return fmt.Errorf("write clamd chunk: %w", err)
+	}
+
+	return nil
+}
+
+// parseClamdResponse interprets responses of the form "stream: OK",
+// "stream: Eicar-Test-Signature FOUND" and "INSTREAM size limit exceeded.
+// ERROR".
+func parseClamdResponse(response string) (Result, error) {
+	status := strings.TrimPrefix(response, "stream: ")
+
+	switch {
+	case status == "OK":
+		return Result{Clean: true}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return Result{
+			Clean:    false,
+			Findings: []string{strings.TrimSuffix(status, " FOUND")},
+		}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd returned: %s", response)
+	}
+}
@@ -0,0 +1,120 @@
+package scanner_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc/scanner"
+)
+
+var _ = Describe("Clamd", func() {
+	var (
+		listener net.Listener
+		response string
+		command  string
+		received []byte
+
+		result  scanner.Result
+		scanErr error
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		response = "stream: OK\x00"
+		command = ""
+		received = nil
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	JustBeforeEach(func() {
+		done := make(chan struct{})
+
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+
+			conn, err := listener.Accept()
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			reader := bufio.NewReader(conn)
+			command, err = reader.ReadString('\x00')
+			Expect(err).ToNot(HaveOccurred())
+
+			size := make([]byte, 4)
+			for {
+				_, err := io.ReadFull(reader, size)
+				Expect(err).ToNot(HaveOccurred())
+
+				n := binary.BigEndian.Uint32(size)
+				if n == 0 {
+					break
+				}
+
+				chunk := make([]byte, n)
+				_, err = io.ReadFull(reader, chunk)
+				Expect(err).ToNot(HaveOccurred())
+
+				received = append(received, chunk...)
+			}
+
+			_, err = conn.Write([]byte(response))
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		result, scanErr = scanner.NewClamdScanner(listener.Addr().String(), time.Minute).
+			Scan(context.Background(), "some-artifact", strings.NewReader("some-content"))
+
+		<-done
+	})
+
+	It("streams the artifact using INSTREAM", func() {
+		Expect(scanErr).ToNot(HaveOccurred())
+		Expect(command).To(Equal("zINSTREAM\x00"))
+		Expect(string(received)).To(Equal("some-content"))
+	})
+
+	Context("when clamd reports the stream is clean", func() {
+		It("returns a clean result", func() {
+			Expect(result).To(Equal(scanner.Result{Clean: true}))
+		})
+	})
+
+	Context("when clamd finds a signature", func() {
+		BeforeEach(func() {
+			response = "stream: Eicar-Test-Signature FOUND\x00"
+		})
+
+		It("returns the finding", func() {
+			Expect(scanErr).ToNot(HaveOccurred())
+			Expect(result).To(Equal(scanner.Result{
+				Clean:    false,
+				Findings: []string{"Eicar-Test-Signature"},
+			}))
+		})
+	})
+
+	Context("when clamd returns an error", func() {
+		BeforeEach(func() {
+			response = "INSTREAM size limit exceeded. ERROR\x00"
+		})
+
+		It("errors", func() {
+			Expect(scanErr).To(MatchError("clamd returned: INSTREAM size limit exceeded. ERROR"))
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Action determines what happens to a step when one of its artifacts is
+// flagged by the scanner, or cannot be scanned at all.
+type Action string
+
+const (
+	ActionBlock Action = "block"
+	ActionWarn  Action = "warn"
+)
+
+type Config struct {
+	ClamdAddress string        `long:"artifact-scanner-clamd-address" description:"Address of a clamd daemon used to scan put inputs and task outputs. Either host:port or unix:/path/to/clamd.sock."`
+	WebhookURL   string        `long:"artifact-scanner-webhook-url" description:"URL to which put inputs and task outputs are POSTed as a tar stream for scanning. Expects a JSON response of the form {\"clean\": bool, \"findings\": [string]}."`
+	Timeout      time.Duration `long:"artifact-scanner-timeout" default:"10m" description:"Maximum duration of scanning a single artifact."`
+	Action       Action        `long:"artifact-scanner-action" default:"block" choice:"block" choice:"warn" description:"Whether to fail the step or only record a warning when an artifact is flagged or cannot be scanned."`
+}
+
+func (c Config) IsConfigured() bool {
+	return c.ClamdAddress != "" || c.WebhookURL != ""
+}
+
+// NewScanner constructs the configured Scanner, or returns nil if scanning is
+// not configured.
+func (c Config) NewScanner() (Scanner, error) {
+	if c.ClamdAddress != "" && c.WebhookURL != "" {
+		return nil, errors.New("multiple artifact scanners configured: clamd, webhook")
+	}
+
+	if c.ClamdAddress != "" {
+		return NewClamdScanner(c.ClamdAddress, c.Timeout), nil
+	}
+
+	if c.WebhookURL != "" {
+		return NewWebhookScanner(c.WebhookURL, c.Timeout), nil
+	}
+
+	return nil, nil
+}
+
+//counterfeiter:generate . Scanner
+
+// Scanner inspects the contents of an artifact, streamed as a tar archive.
+type Scanner interface {
+	Scan(ctx context.Context, artifact string, tarStream io.Reader) (Result, error)
+}
+
+type Result struct {
+	Clean    bool
+	Findings []string
+}
+
+// Report describes the outcome of scanning a single artifact, as recorded in
+// the build's events.
+type Report struct {
+	Artifact string
+	Result   Result
+	Error    error
+	Blocked  bool
+}
+
+type BlockedError struct {
+	Artifact string
+	Findings []string
+}
+
+func (e BlockedError) Error() string {
+	if len(e.Findings) == 0 {
+		return fmt.Sprintf("artifact %s was blocked by the artifact scanner", e.Artifact)
+	}
+
+	return fmt.Sprintf("artifact %s was blocked by the artifact scanner: %s", e.Artifact, strings.Join(e.Findings, ", "))
+}
@@ -0,0 +1,47 @@
+package scanner_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc/scanner"
+)
+
+var _ = Describe("Config", func() {
+	var config scanner.Config
+
+	BeforeEach(func() {
+		config = scanner.Config{Action: scanner.ActionBlock}
+	})
+
+	Context("when no scanner is configured", func() {
+		It("returns no scanner", func() {
+			s, err := config.NewScanner()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s).To(BeNil())
+		})
+	})
+
+	Context("when clamd is configured", func() {
+		BeforeEach(func() {
+			config.ClamdAddress = "127.0.0.1:3310"
+		})
+
+		It("returns a scanner", func() {
+			s, err := config.NewScanner()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s).ToNot(BeNil())
+		})
+
+		Context("when a webhook is also configured", func() {
+			BeforeEach(func() {
+				config.WebhookURL = "http://scanner.example.com"
+			})
+
+			It("errors", func() {
+				_, err := config.NewScanner()
+				Expect(err).To(MatchError("multiple artifact scanners configured: clamd, webhook"))
+			})
+		})
+	})
+})
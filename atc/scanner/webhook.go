@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type webhookScanner struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookScanner constructs a Scanner which POSTs artifacts to an HTTP
+// endpoint and reads the verdict from the JSON response.
+func NewWebhookScanner(url string, timeout time.Duration) Scanner {
+	return webhookScanner{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type webhookResponse struct {
+	Clean    bool     `json:"clean"`
+	Findings []string `json:"findings"`
+}
+
+func (s webhookScanner) Scan(ctx context.Context, artifact string, tarStream io.Reader) (Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, tarStream)
+	if err != nil {
+		return Result{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-tar")
+	req.Header.Set("X-Concourse-Artifact", artifact)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("scanner returned status: %d", resp.StatusCode)
+	}
+
+	var body webhookResponse
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return Result{}, fmt.Errorf("parsing scanner response: %w", err)
+	}
+
+	return Result{
+		Clean:    body.Clean,
+		Findings: body.Findings,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package scanner_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc/scanner"
+)
+
+var _ = Describe("Webhook", func() {
+	var (
+		server *ghttp.Server
+
+		result  scanner.Result
+		scanErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		result, scanErr = scanner.NewWebhookScanner(server.URL()+"/scan", time.Minute).
+			Scan(context.Background(), "some-artifact", strings.NewReader("some-content"))
+	})
+
+	Context("when the scanner responds", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/scan"),
+					ghttp.VerifyHeaderKV("Content-Type", "application/x-tar"),
+					ghttp.VerifyHeaderKV("X-Concourse-Artifact", "some-artifact"),
+					ghttp.VerifyBody([]byte("some-content")),
+					ghttp.RespondWith(http.StatusOK, `{"clean":false,"findings":["some-finding"]}`),
+				),
+			)
+		})
+
+		It("returns the result", func() {
+			Expect(scanErr).ToNot(HaveOccurred())
+			Expect(result).To(Equal(scanner.Result{
+				Clean:    false,
+				Findings: []string{"some-finding"},
+			}))
+		})
+	})
+
+	Context("when the scanner returns an unexpected status", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+		})
+
+		It("errors", func() {
+			Expect(scanErr).To(MatchError("scanner returned status: 500"))
+		})
+	})
+
+	Context("when the response is not valid JSON", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, "bogus"))
+		})
+
+		It("errors", func() {
+			Expect(scanErr).To(HaveOccurred())
+		})
+	})
+})
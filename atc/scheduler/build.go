@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
@@ -77,6 +78,13 @@ type rerunBuild struct {
 }
 
 func (r *rerunBuild) IsReadyToDetermineInputs(logger lager.Logger) (bool, error) {
+	// automatic reruns may be delayed to give infrastructure a chance to
+	// recover before trying again
+	if time.Now().Before(r.StartAfter()) {
+		logger.Debug("waiting-for-rerun-delay", lager.Data{"start-after": r.StartAfter()})
+		return false, nil
+	}
+
 	return true, nil
 }
 
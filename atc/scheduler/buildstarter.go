@@ -191,12 +191,18 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		}, nil
 	}
 
-	config, err := job.Config()
-	if err != nil {
-		return startResults{}, fmt.Errorf("config: %w", err)
-	}
+	var plan atc.Plan
+	if nextPendingBuild.ResumedFrom() != 0 {
+		plan, err = resumedPlan(nextPendingBuild)
+	} else {
+		var config atc.JobConfig
+		config, err = job.Config()
+		if err != nil {
+			return startResults{}, fmt.Errorf("config: %w", err)
+		}
 
-	plan, err := s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, job.Prototypes, buildInputs)
+		plan, err = s.planner.Create(config.BuildStepConfig(), job.Resources, job.ResourceTypes, job.Prototypes, buildInputs)
+	}
 	if err != nil {
 		logger.Error("failed-to-create-build-plan", err)
 
@@ -239,3 +245,19 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		finished: true,
 	}, nil
 }
+
+// resumedPlan returns the plan of the build which the given build resumes
+// from the failed step of. The resumed build runs the same plan so that its
+// steps can be matched up with those of the original build.
+func resumedPlan(build Build) (atc.Plan, error) {
+	resumedFrom, found, err := build.ResumedFromBuild()
+	if err != nil {
+		return atc.Plan{}, fmt.Errorf("find resumed build: %w", err)
+	}
+
+	if !found {
+		return atc.Plan{}, fmt.Errorf("resumed build %d not found", build.ResumedFrom())
+	}
+
+	return resumedFrom.PrivatePlan(), nil
+}
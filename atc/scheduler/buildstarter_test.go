@@ -3,6 +3,7 @@ package scheduler_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
@@ -513,6 +514,68 @@ var _ = Describe("BuildStarter", func() {
 						})
 					})
 
+					Context("when a rerun build is delayed", func() {
+						BeforeEach(func() {
+							pendingBuild1 = new(dbfakes.FakeBuild)
+							pendingBuild1.IDReturns(99)
+							pendingBuild1.RerunOfReturns(1)
+							pendingBuild1.StartAfterReturns(time.Now().Add(time.Hour))
+							job.GetPendingBuildsReturns([]db.Build{pendingBuild1}, nil)
+						})
+
+						It("does not adopt inputs and retries to schedule", func() {
+							Expect(tryStartErr).ToNot(HaveOccurred())
+							Expect(needsReschedule).To(BeTrue())
+							Expect(pendingBuild1.AdoptRerunInputsAndPipesCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when a rerun build resumes from the failed step of another build", func() {
+						var resumedBuild *dbfakes.FakeBuild
+
+						resumedPlan := atc.Plan{
+							ID: "resumed-plan",
+							Get: &atc.GetPlan{
+								Name:     "some-input",
+								Resource: "some-input",
+							},
+						}
+
+						BeforeEach(func() {
+							resumedBuild = new(dbfakes.FakeBuild)
+							resumedBuild.PrivatePlanReturns(resumedPlan)
+
+							pendingBuild1 = new(dbfakes.FakeBuild)
+							pendingBuild1.IDReturns(99)
+							pendingBuild1.RerunOfReturns(1)
+							pendingBuild1.ResumedFromReturns(2)
+							pendingBuild1.ResumedFromBuildReturns(resumedBuild, true, nil)
+							pendingBuild1.AdoptRerunInputsAndPipesReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
+							pendingBuild1.StartReturns(true, nil)
+							job.GetPendingBuildsReturns([]db.Build{pendingBuild1}, nil)
+						})
+
+						It("starts the build with the plan of the resumed build", func() {
+							Expect(tryStartErr).ToNot(HaveOccurred())
+							Expect(fakePlanner.CreateCallCount()).To(BeZero())
+							Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+							Expect(pendingBuild1.StartArgsForCall(0)).To(Equal(resumedPlan))
+						})
+
+						Context("when the resumed build is not found", func() {
+							BeforeEach(func() {
+								pendingBuild1.ResumedFromBuildReturns(nil, false, nil)
+							})
+
+							It("marks the build as errored", func() {
+								Expect(tryStartErr).ToNot(HaveOccurred())
+								Expect(pendingBuild1.StartCallCount()).To(BeZero())
+								Expect(pendingBuild1.FinishCallCount()).To(Equal(1))
+								Expect(pendingBuild1.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+							})
+						})
+					})
+
 					Context("when adopting inputs and pipes for a normal scheduler build fails", func() {
 						BeforeEach(func() {
 							pendingBuild1 = new(dbfakes.FakeBuild)
@@ -25,6 +25,9 @@ type StepRecursor struct {
 
 	// OnLoadVar will be invoked for any *LoadVarStep present in the StepConfig.
 	OnLoadVar func(*LoadVarStep) error
+
+	// OnNotify will be invoked for any *NotifyStep present in the StepConfig.
+	OnNotify func(*NotifyStep) error
 }
 
 // VisitTask calls the OnTask hook if configured.
@@ -81,6 +84,15 @@ func (recursor StepRecursor) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+// VisitNotify calls the OnNotify hook if configured.
+func (recursor StepRecursor) VisitNotify(step *NotifyStep) error {
+	if recursor.OnNotify != nil {
+		return recursor.OnNotify(step)
+	}
+
+	return nil
+}
+
 // VisitTry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitTry(step *TryStep) error {
 	return step.Step.Config.Visit(recursor)
@@ -120,11 +132,31 @@ func (recursor StepRecursor) VisitTimeout(step *TimeoutStep) error {
 	return step.Step.Visit(recursor)
 }
 
+// VisitMute recurses through to the wrapped step.
+func (recursor StepRecursor) VisitMute(step *MuteStep) error {
+	return step.Step.Visit(recursor)
+}
+
 // VisitRetry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitRetry(step *RetryStep) error {
 	return step.Step.Visit(recursor)
 }
 
+// VisitWhile recurses through to the wrapped step.
+func (recursor StepRecursor) VisitWhile(step *WhileStep) error {
+	return step.Step.Visit(recursor)
+}
+
+// VisitWhen recurses through to the wrapped step.
+func (recursor StepRecursor) VisitWhen(step *WhenStep) error {
+	return step.Step.Visit(recursor)
+}
+
+// VisitLock recurses through to the wrapped step.
+func (recursor StepRecursor) VisitLock(step *LockStep) error {
+	return step.Step.Visit(recursor)
+}
+
 // VisitOnSuccess recurses through to the wrapped step and hook.
 func (recursor StepRecursor) VisitOnSuccess(step *OnSuccessStep) error {
 	err := step.Step.Visit(recursor)
@@ -165,6 +197,16 @@ func (recursor StepRecursor) VisitOnError(step *OnErrorStep) error {
 	return step.Hook.Config.Visit(recursor)
 }
 
+// VisitOnTimeout recurses through to the wrapped step and hook.
+func (recursor StepRecursor) VisitOnTimeout(step *OnTimeoutStep) error {
+	err := step.Step.Visit(recursor)
+	if err != nil {
+		return err
+	}
+
+	return step.Hook.Config.Visit(recursor)
+}
+
 // VisitEnsure recurses through to the wrapped step and hook.
 func (recursor StepRecursor) VisitEnsure(step *EnsureStep) error {
 	err := step.Step.Visit(recursor)
@@ -2,6 +2,8 @@ package atc
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -80,7 +82,7 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		validator.recordError("must specify one of `file:` or `config:`, not both")
 	}
 
-	if plan.Config != nil && (plan.Config.RootfsURI != "" || plan.Config.ImageResource != nil) && plan.ImageArtifactName != "" {
+	if plan.Config != nil && (plan.Config.RootfsURI != "" || plan.Config.ImageResource != nil || plan.Config.Image != nil) && plan.ImageArtifactName != "" {
 		validator.recordWarning(ConfigWarning{
 			Type:    "pipeline",
 			Message: validator.annotate("specifies image: on the step but also specifies an image under config: - the image: on the step takes precedence"),
@@ -103,6 +105,12 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		validator.popContext()
 	}
 
+	for i, report := range plan.TestReports {
+		if len(strings.SplitN(report, "/", 2)) != 2 {
+			validator.recordError("test_reports[%d]: '%s' does not specify which artifact the report lives in", i, report)
+		}
+	}
+
 	return nil
 }
 
@@ -131,6 +139,24 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 		validator.recordError("unknown resource '%s'", resourceName)
 	}
 
+	if step.Depth < 0 {
+		validator.recordError("depth must not be negative")
+	}
+
+	if step.Verify != nil {
+		validator.pushContext(".verify")
+
+		if step.Verify.File == "" {
+			validator.recordError("no file specified")
+		}
+
+		if step.Verify.SHA256 == "" && step.Verify.SHA512 == "" {
+			validator.recordError("no sha256 or sha512 digest specified")
+		}
+
+		validator.popContext()
+	}
+
 	validator.pushContext(".passed")
 
 	for _, job := range step.Passed {
@@ -186,6 +212,14 @@ func (validator *StepValidator) VisitPut(step *PutStep) error {
 		validator.recordError("unknown resource '%s'", resourceName)
 	}
 
+	if step.NoGet && step.GetParams != nil {
+		validator.recordError("`get_params:` cannot be used with `no_get:`")
+	}
+
+	if len(step.Targets) > 0 && step.GetParams != nil {
+		validator.recordError("`get_params:` cannot be used with `targets:`")
+	}
+
 	return nil
 }
 
@@ -228,6 +262,23 @@ func (validator *StepValidator) VisitSetPipeline(step *SetPipelineStep) error {
 		validator.recordError("no file specified")
 	}
 
+	fanOut := len(step.InstanceVarsList) > 0 || step.InstanceVarsFile != ""
+	if len(step.InstanceVarsList) > 0 && step.InstanceVarsFile != "" {
+		validator.recordError("must specify one of `instance_vars_list:` or `instance_vars_file:`, not both")
+	}
+
+	if fanOut && step.InstanceVars != nil {
+		validator.recordError("cannot specify `instance_vars:` alongside `instance_vars_list:` or `instance_vars_file:`")
+	}
+
+	if fanOut && step.Name == "self" {
+		validator.recordError("cannot fan out `set_pipeline: self`")
+	}
+
+	if step.PruneInstances && !fanOut {
+		validator.recordError("`prune_instances:` requires `instance_vars_list:` or `instance_vars_file:`")
+	}
+
 	return nil
 }
 
@@ -249,9 +300,56 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 		validator.recordError("no file specified")
 	}
 
+	if step.MultiDocument && step.Format != "" && step.Format != "yml" && step.Format != "yaml" {
+		validator.recordError("`multi_document:` cannot be used with format '%s'", step.Format)
+	}
+
 	return nil
 }
 
+func (validator *StepValidator) VisitNotify(step *NotifyStep) error {
+	validator.pushContext(".notify(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	if len(step.Targets) == 0 {
+		validator.recordError("no targets specified")
+	}
+
+	for i, target := range step.Targets {
+		validator.pushContext(".targets[%d]", i)
+
+		if !isValidNotifyTargetType(target.Type) {
+			validator.recordError("unknown type '%s' (must be one of: %s)", target.Type, strings.Join(NotifyTargetTypes, ", "))
+		}
+
+		if target.URL == "" {
+			validator.recordError("no url specified")
+		}
+
+		validator.popContext()
+	}
+
+	return nil
+}
+
+func isValidNotifyTargetType(targetType string) bool {
+	for _, t := range NotifyTargetTypes {
+		if t == targetType {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (validator *StepValidator) VisitTry(step *TryStep) error {
 	validator.pushContext(".try")
 	defer validator.popContext()
@@ -281,6 +379,18 @@ func (validator *StepValidator) VisitInParallel(step *InParallelStep) error {
 	validator.pushContext(".in_parallel")
 	defer validator.popContext()
 
+	if step.Config.Limit != nil {
+		validator.pushContext(".limit")
+		if step.Config.Limit.Var != "" {
+			if !strings.HasPrefix(step.Config.Limit.Var, "((") || !strings.HasSuffix(step.Config.Limit.Var, "))") {
+				validator.recordError("must be a number or a var, got '%s'", step.Config.Limit.Var)
+			}
+		} else if step.Config.Limit.Limit < 0 {
+			validator.recordError("must not be negative")
+		}
+		validator.popContext()
+	}
+
 	for i, sub := range step.Config.Steps {
 		validator.pushContext(".steps[%d]", i)
 
@@ -315,6 +425,14 @@ func (validator *StepValidator) VisitAcross(step *AcrossStep) error {
 
 		validator.declareLocalVar(v.Var)
 
+		if v.ValuesFrom != nil {
+			if v.Values != nil {
+				validator.recordError("cannot specify both values and values_from")
+			}
+
+			validator.validateAcrossValuesFrom(*v.ValuesFrom)
+		}
+
 		validator.pushContext(".max_in_flight")
 		if v.MaxInFlight != nil && !v.MaxInFlight.All && v.MaxInFlight.Limit <= 0 {
 			validator.recordError("must be greater than 0")
@@ -323,9 +441,94 @@ func (validator *StepValidator) VisitAcross(step *AcrossStep) error {
 		validator.popContext()
 	}
 
+	varNames := map[string]bool{}
+	for _, v := range step.Vars {
+		varNames[v.Var] = true
+	}
+
+	for i, combination := range step.Exclude {
+		validator.pushContext(".exclude[%d]", i)
+		validator.validateAcrossCombination(combination, varNames, false)
+		validator.popContext()
+	}
+
+	for i, combination := range step.Include {
+		validator.pushContext(".include[%d]", i)
+		validator.validateAcrossCombination(combination, varNames, true)
+		validator.popContext()
+	}
+
 	return step.Step.Visit(validator)
 }
 
+func (validator *StepValidator) validateAcrossCombination(combination AcrossCombination, varNames map[string]bool, complete bool) {
+	if len(combination) == 0 {
+		validator.recordError("no vars specified")
+	}
+
+	var unknown []string
+	for name := range combination {
+		if !varNames[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	sort.Strings(unknown)
+
+	for _, name := range unknown {
+		validator.recordError("unknown var '%s'", name)
+	}
+
+	if !complete {
+		return
+	}
+
+	var missing []string
+	for name := range varNames {
+		if _, found := combination[name]; !found {
+			missing = append(missing, name)
+		}
+	}
+
+	sort.Strings(missing)
+
+	for _, name := range missing {
+		validator.recordError("missing var '%s'", name)
+	}
+}
+
+func (validator *StepValidator) validateAcrossValuesFrom(from AcrossValuesFrom) {
+	validator.pushContext(".values_from")
+	defer validator.popContext()
+
+	switch {
+	case from.Resource != "" && from.VarSource != "":
+		validator.recordError("cannot specify both resource and var_source")
+	case from.Resource != "":
+		if _, found := validator.config.Resources.Lookup(from.Resource); !found {
+			validator.recordError("unknown resource '%s'", from.Resource)
+		}
+	case from.VarSource != "":
+		if _, found := validator.config.VarSources.Lookup(from.VarSource); !found {
+			validator.recordError("unknown var source '%s'", from.VarSource)
+		}
+
+		if from.Var == "" {
+			validator.recordError("var_source requires var")
+		}
+	default:
+		validator.recordError("must specify either resource or var_source")
+	}
+
+	if from.Var != "" && from.VarSource == "" {
+		validator.recordError("var requires var_source")
+	}
+
+	if from.Limit < 0 {
+		validator.recordError("limit must not be negative")
+	}
+}
+
 func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
@@ -335,36 +538,172 @@ func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 	validator.pushContext(".timeout")
 	defer validator.popContext()
 
-	_, err = time.ParseDuration(step.Duration)
+	duration, err := time.ParseDuration(step.Duration)
 	if err != nil {
 		validator.recordError("invalid duration '%s'", step.Duration)
 	}
 
+	if step.WarnAfter != "" {
+		warnAfter, err := time.ParseDuration(step.WarnAfter)
+		if err != nil {
+			validator.recordError("invalid warn_after '%s'", step.WarnAfter)
+		} else if duration > 0 && warnAfter >= duration {
+			validator.recordError("warn_after '%s' must be shorter than the timeout '%s'", step.WarnAfter, step.Duration)
+		}
+	}
+
 	return nil
 }
 
-func (validator *StepValidator) VisitRetry(step *RetryStep) error {
+func (validator *StepValidator) VisitMute(step *MuteStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
 		return err
 	}
 
-	validator.pushContext(".attempts")
+	validator.pushContext(".mute")
 	defer validator.popContext()
 
+	if step.Config.LogLimit != "" {
+		_, err = ParseMemoryLimit(step.Config.LogLimit)
+		if err != nil {
+			validator.recordError("invalid log_limit '%s'", step.Config.LogLimit)
+		}
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitRetry(step *RetryStep) error {
+	err := step.Step.Visit(validator)
+	if err != nil {
+		return err
+	}
+
+	validator.pushContext(".attempts")
 	if step.Attempts <= 0 {
 		validator.recordError("must be greater than 0")
 	}
+	validator.popContext()
+
+	if step.Backoff != nil {
+		validator.validateRetryBackoff(*step.Backoff)
+	}
+
+	for i, condition := range step.RetryOn {
+		validator.validateRetryCondition(i, condition)
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitWhile(step *WhileStep) error {
+	err := step.Step.Visit(validator)
+	if err != nil {
+		return err
+	}
+
+	validator.pushContext(".while.max_iterations")
+	if step.Config.MaxIterations < 0 {
+		validator.recordError("must not be negative")
+	} else if step.Config.MaxIterations > MaxWhileIterations {
+		validator.recordError("must not be greater than %d", MaxWhileIterations)
+	}
+	validator.popContext()
+
+	if step.Config.Var != "" && !validator.localVarIsDeclared(step.Config.Var) {
+		validator.pushContext(".while.var")
+		validator.recordError("local var '%s' is not set by a load_var step", step.Config.Var)
+		validator.popContext()
+	}
 
 	return nil
 }
 
+func (validator *StepValidator) VisitWhen(step *WhenStep) error {
+	validator.pushContext(".when")
+	_, err := ParseCondition(step.Condition)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	validator.popContext()
+
+	return step.Step.Visit(validator)
+}
+
+func (validator *StepValidator) VisitLock(step *LockStep) error {
+	validator.pushContext(".lock")
+	if step.Config.Name == "" {
+		validator.recordError("missing name")
+	}
+
+	if step.Config.Count < 0 {
+		validator.recordError("count must be a positive number")
+	}
+	validator.popContext()
+
+	return step.Step.Visit(validator)
+}
+
+func (validator *StepValidator) validateRetryBackoff(backoff RetryBackoff) {
+	validator.pushContext(".backoff")
+	defer validator.popContext()
+
+	initial, err := time.ParseDuration(backoff.Initial)
+	if err != nil {
+		validator.recordError("invalid initial delay '%s'", backoff.Initial)
+	} else if initial < 0 {
+		validator.recordError("initial delay must not be negative")
+	}
+
+	if backoff.Multiplier != 0 && backoff.Multiplier < 1 {
+		validator.recordError("multiplier must be at least 1")
+	}
+
+	if backoff.Max != "" {
+		max, err := time.ParseDuration(backoff.Max)
+		if err != nil {
+			validator.recordError("invalid max delay '%s'", backoff.Max)
+		} else if max < initial {
+			validator.recordError("max delay must not be less than the initial delay")
+		}
+	}
+}
+
+func (validator *StepValidator) validateRetryCondition(index int, condition RetryCondition) {
+	validator.pushContext(fmt.Sprintf(".retry_on[%d]", index))
+	defer validator.popContext()
+
+	if condition.Class != "" {
+		for _, class := range RetryConditionClasses {
+			if condition.Class == class {
+				return
+			}
+		}
+
+		validator.recordError("unknown class of failure '%s' (must be one of %s)", condition.Class, strings.Join(RetryConditionClasses, ", "))
+		return
+	}
+
+	if condition.Message == "" {
+		validator.recordError("must specify either a class of failure or a message")
+		return
+	}
+
+	_, err := regexp.Compile(condition.Message)
+	if err != nil {
+		validator.recordError("invalid message pattern: %s", err)
+	}
+}
+
 func (validator *StepValidator) VisitOnSuccess(step *OnSuccessStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
 		return err
 	}
 
+	validator.validateHookTimeout("on_success", step.Timeout)
+
 	validator.pushContext(".on_success")
 	defer validator.popContext()
 
@@ -377,6 +716,8 @@ func (validator *StepValidator) VisitOnFailure(step *OnFailureStep) error {
 		return err
 	}
 
+	validator.validateHookTimeout("on_failure", step.Timeout)
+
 	validator.pushContext(".on_failure")
 	defer validator.popContext()
 
@@ -389,6 +730,8 @@ func (validator *StepValidator) VisitOnAbort(step *OnAbortStep) error {
 		return err
 	}
 
+	validator.validateHookTimeout("on_abort", step.Timeout)
+
 	validator.pushContext(".on_abort")
 	defer validator.popContext()
 
@@ -401,24 +744,56 @@ func (validator *StepValidator) VisitOnError(step *OnErrorStep) error {
 		return err
 	}
 
+	validator.validateHookTimeout("on_error", step.Timeout)
+
 	validator.pushContext(".on_error")
 	defer validator.popContext()
 
 	return validator.Validate(step.Hook)
 }
 
+func (validator *StepValidator) VisitOnTimeout(step *OnTimeoutStep) error {
+	err := step.Step.Visit(validator)
+	if err != nil {
+		return err
+	}
+
+	validator.validateHookTimeout("on_timeout", step.Timeout)
+
+	validator.pushContext(".on_timeout")
+	defer validator.popContext()
+
+	return validator.Validate(step.Hook)
+}
+
 func (validator *StepValidator) VisitEnsure(step *EnsureStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
 		return err
 	}
 
+	validator.validateHookTimeout("ensure", step.Timeout)
+
 	validator.pushContext(".ensure")
 	defer validator.popContext()
 
 	return validator.Validate(step.Hook)
 }
 
+func (validator *StepValidator) validateHookTimeout(hook string, duration string) {
+	if duration == "" {
+		return
+	}
+
+	validator.pushContext(".%s_timeout", hook)
+	defer validator.popContext()
+
+	_, err := time.ParseDuration(duration)
+	if err != nil {
+		validator.recordError("invalid duration '%s'", duration)
+	}
+}
+
 func (validator *StepValidator) recordWarning(warning ConfigWarning) {
 	validator.Warnings = append(validator.Warnings, warning)
 }
@@ -194,16 +194,22 @@ type StepVisitor interface {
 	VisitRun(*RunStep) error
 	VisitSetPipeline(*SetPipelineStep) error
 	VisitLoadVar(*LoadVarStep) error
+	VisitNotify(*NotifyStep) error
 	VisitTry(*TryStep) error
 	VisitDo(*DoStep) error
 	VisitInParallel(*InParallelStep) error
 	VisitAcross(*AcrossStep) error
 	VisitTimeout(*TimeoutStep) error
+	VisitMute(*MuteStep) error
 	VisitRetry(*RetryStep) error
+	VisitWhile(*WhileStep) error
+	VisitWhen(*WhenStep) error
+	VisitLock(*LockStep) error
 	VisitOnSuccess(*OnSuccessStep) error
 	VisitOnFailure(*OnFailureStep) error
 	VisitOnAbort(*OnAbortStep) error
 	VisitOnError(*OnErrorStep) error
+	VisitOnTimeout(*OnTimeoutStep) error
 	VisitEnsure(*EnsureStep) error
 }
 
@@ -222,6 +228,14 @@ type StepDetector struct {
 // some important inter-modifier precedence - while core step types are parsed
 // last.
 var StepPrecedence = []StepDetector{
+	{
+		Key: "when",
+		New: func() StepConfig { return &WhenStep{} },
+	},
+	{
+		Key: "lock",
+		New: func() StepConfig { return &LockStep{} },
+	},
 	{
 		Key: "ensure",
 		New: func() StepConfig { return &EnsureStep{} },
@@ -230,6 +244,10 @@ var StepPrecedence = []StepDetector{
 		Key: "on_error",
 		New: func() StepConfig { return &OnErrorStep{} },
 	},
+	{
+		Key: "on_timeout",
+		New: func() StepConfig { return &OnTimeoutStep{} },
+	},
 	{
 		Key: "on_abort",
 		New: func() StepConfig { return &OnAbortStep{} },
@@ -246,10 +264,18 @@ var StepPrecedence = []StepDetector{
 		Key: "across",
 		New: func() StepConfig { return &AcrossStep{} },
 	},
+	{
+		Key: "while",
+		New: func() StepConfig { return &WhileStep{} },
+	},
 	{
 		Key: "attempts",
 		New: func() StepConfig { return &RetryStep{} },
 	},
+	{
+		Key: "mute",
+		New: func() StepConfig { return &MuteStep{} },
+	},
 	{
 		Key: "run",
 		New: func() StepConfig { return &RunStep{} },
@@ -278,6 +304,10 @@ var StepPrecedence = []StepDetector{
 		Key: "load_var",
 		New: func() StepConfig { return &LoadVarStep{} },
 	},
+	{
+		Key: "notify",
+		New: func() StepConfig { return &NotifyStep{} },
+	},
 	{
 		Key: "try",
 		New: func() StepConfig { return &TryStep{} },
@@ -293,14 +323,31 @@ var StepPrecedence = []StepDetector{
 }
 
 type GetStep struct {
-	Name     string         `json:"get"`
-	Resource string         `json:"resource,omitempty"`
-	Version  *VersionConfig `json:"version,omitempty"`
-	Params   Params         `json:"params,omitempty"`
-	Passed   []string       `json:"passed,omitempty"`
-	Trigger  bool           `json:"trigger,omitempty"`
-	Tags     Tags           `json:"tags,omitempty"`
-	Timeout  string         `json:"timeout,omitempty"`
+	Name     string           `json:"get"`
+	Resource string           `json:"resource,omitempty"`
+	Version  *VersionConfig   `json:"version,omitempty"`
+	Params   Params           `json:"params,omitempty"`
+	Passed   []string         `json:"passed,omitempty"`
+	Trigger  bool             `json:"trigger,omitempty"`
+	Tags     Tags             `json:"tags,omitempty"`
+	Timeout  string           `json:"timeout,omitempty"`
+	Limits   *ContainerLimits `json:"container_limits,omitempty"`
+	Priority int              `json:"priority,omitempty"`
+
+	// Depth fetches the latest N versions of the resource, up to and
+	// including the version being fetched, each into its own subdirectory.
+	Depth int `json:"depth,omitempty"`
+
+	// Verify checks the digest of a file within the fetched artifact.
+	Verify *VerifyConfig `json:"verify,omitempty"`
+}
+
+// VerifyConfig is the expected digest of a file within a fetched artifact.
+// The digests may be given as vars.
+type VerifyConfig struct {
+	File   string `json:"file"`
+	SHA256 string `json:"sha256,omitempty"`
+	SHA512 string `json:"sha512,omitempty"`
 }
 
 func (step *GetStep) ResourceName() string {
@@ -316,13 +363,22 @@ func (step *GetStep) Visit(v StepVisitor) error {
 }
 
 type PutStep struct {
-	Name      string        `json:"put"`
-	Resource  string        `json:"resource,omitempty"`
-	Params    Params        `json:"params,omitempty"`
-	Inputs    *InputsConfig `json:"inputs,omitempty"`
-	Tags      Tags          `json:"tags,omitempty"`
-	GetParams Params        `json:"get_params,omitempty"`
-	Timeout   string        `json:"timeout,omitempty"`
+	Name      string           `json:"put"`
+	Resource  string           `json:"resource,omitempty"`
+	Params    Params           `json:"params,omitempty"`
+	Inputs    *InputsConfig    `json:"inputs,omitempty"`
+	Tags      Tags             `json:"tags,omitempty"`
+	GetParams Params           `json:"get_params,omitempty"`
+	Timeout   string           `json:"timeout,omitempty"`
+	Limits    *ContainerLimits `json:"container_limits,omitempty"`
+	Priority  int              `json:"priority,omitempty"`
+
+	// NoGet skips the implicit get of the version created by the put.
+	NoGet bool `json:"no_get,omitempty"`
+
+	// Targets pushes to each of the given sources, merged over the resource's
+	// source, in parallel. The implicit get is skipped.
+	Targets []Source `json:"targets,omitempty"`
 }
 
 func (step *PutStep) ResourceName() string {
@@ -350,6 +406,11 @@ type TaskStep struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 	Timeout           string            `json:"timeout,omitempty"`
+	TestReports       []string          `json:"test_reports,omitempty"`
+	Hermetic          bool              `json:"hermetic,omitempty"`
+	User              string            `json:"user,omitempty"`
+	ArchiveOutputs    bool              `json:"archive_outputs,omitempty"`
+	Priority          int               `json:"priority,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
@@ -364,9 +425,12 @@ type RunStep struct {
 	Tags       Tags             `json:"tags,omitempty"`
 	Limits     *ContainerLimits `json:"container_limits,omitempty"`
 	Timeout    string           `json:"timeout,omitempty"`
+	Priority   int              `json:"priority,omitempty"`
 
-	// XXX(prototypes): inputs, outputs, input_mapping, output_mapping?
-	// see https://github.com/concourse/rfcs/pull/103
+	Inputs        []string          `json:"inputs,omitempty"`
+	Outputs       []string          `json:"outputs,omitempty"`
+	InputMapping  map[string]string `json:"input_mapping,omitempty"`
+	OutputMapping map[string]string `json:"output_mapping,omitempty"`
 
 	// XXX(prototypes): set_vars?
 
@@ -385,6 +449,19 @@ type SetPipelineStep struct {
 	Vars         Params       `json:"vars,omitempty"`
 	VarFiles     []string     `json:"var_files,omitempty"`
 	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+
+	// InstanceVarsList and InstanceVarsFile fan the step out, setting one
+	// pipeline instance per set of instance vars.
+	InstanceVarsList []InstanceVars `json:"instance_vars_list,omitempty"`
+	InstanceVarsFile string         `json:"instance_vars_file,omitempty"`
+
+	// PruneInstances archives instances of the pipeline previously set by
+	// the same job which are no longer listed.
+	PruneInstances bool `json:"prune_instances,omitempty"`
+
+	// DryRun shows the diff against the currently stored config without
+	// saving the pipeline.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 func (step *SetPipelineStep) Visit(v StepVisitor) error {
@@ -396,12 +473,47 @@ type LoadVarStep struct {
 	File   string `json:"file,omitempty"`
 	Format string `json:"format,omitempty"`
 	Reveal bool   `json:"reveal,omitempty"`
+
+	// MultiDocument loads each document of a multi-document YAML file,
+	// indexed by position and, when it has a kind and metadata.name, by
+	// kind and name.
+	MultiDocument bool `json:"multi_document,omitempty"`
 }
 
 func (step *LoadVarStep) Visit(v StepVisitor) error {
 	return v.VisitLoadVar(step)
 }
 
+// NotifyStep posts a message about the build to one or more notification
+// targets, without running a container.
+type NotifyStep struct {
+	Name    string         `json:"notify"`
+	Message string         `json:"message,omitempty"`
+	Targets []NotifyTarget `json:"targets,omitempty"`
+}
+
+func (step *NotifyStep) Visit(v StepVisitor) error {
+	return v.VisitNotify(step)
+}
+
+const (
+	NotifyTargetSlack = "slack"
+	NotifyTargetHTTP  = "http"
+)
+
+var NotifyTargetTypes = []string{
+	NotifyTargetSlack,
+	NotifyTargetHTTP,
+}
+
+// NotifyTarget is an endpoint that a notify step posts to. Slack targets are
+// sent the message as an incoming webhook payload, while HTTP targets are
+// sent the message along with the build's metadata as JSON.
+type NotifyTarget struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
 type TryStep struct {
 	Step Step `json:"try"`
 }
@@ -412,6 +524,10 @@ func (step *TryStep) Visit(v StepVisitor) error {
 
 type DoStep struct {
 	Steps []Step `json:"do"`
+
+	// ContinueOnFailure runs every step even if an earlier one fails, failing
+	// once all of them have run.
+	ContinueOnFailure bool `json:"continue_on_failure,omitempty"`
 }
 
 func (step *DoStep) Visit(v StepVisitor) error {
@@ -427,9 +543,44 @@ func (step *InParallelStep) Visit(v StepVisitor) error {
 }
 
 type InParallelConfig struct {
-	Steps    []Step `json:"steps,omitempty"`
-	Limit    int    `json:"limit,omitempty"`
-	FailFast bool   `json:"fail_fast,omitempty"`
+	Steps    []Step           `json:"steps,omitempty"`
+	Limit    *InParallelLimit `json:"limit,omitempty"`
+	FailFast bool             `json:"fail_fast,omitempty"`
+}
+
+// An InParallelLimit is either a number or a ((var)) which is resolved to a
+// number when the in_parallel step runs.
+type InParallelLimit struct {
+	Limit int
+	Var   string
+}
+
+func (c *InParallelLimit) UnmarshalJSON(limit []byte) error {
+	var data interface{}
+
+	err := json.Unmarshal(limit, &data)
+	if err != nil {
+		return err
+	}
+
+	switch actual := data.(type) {
+	case float64:
+		c.Limit = int(actual)
+	case string:
+		c.Var = actual
+	default:
+		return errors.New("invalid limit (must be a number or a var)")
+	}
+
+	return nil
+}
+
+func (c InParallelLimit) MarshalJSON() ([]byte, error) {
+	if c.Var != "" {
+		return json.Marshal(c.Var)
+	}
+
+	return json.Marshal(c.Limit)
 }
 
 func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
@@ -464,9 +615,29 @@ func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
 type AcrossVarConfig struct {
 	Var         string             `json:"var"`
 	Values      interface{}        `json:"values,omitempty"`
+	ValuesFrom  *AcrossValuesFrom  `json:"values_from,omitempty"`
 	MaxInFlight *MaxInFlightConfig `json:"max_in_flight,omitempty"`
 }
 
+// AcrossValuesFrom looks up an across var's values when the build runs,
+// rather than taking them from the pipeline config. Exactly one of Resource
+// or VarSource must be set.
+type AcrossValuesFrom struct {
+	// Resource takes the values from the most recent enabled versions of a
+	// resource in the pipeline, newest first. Each value is a version.
+	Resource string `json:"resource,omitempty"`
+
+	// VarSource takes the values from the var named Var in the var source,
+	// which must be a list.
+	VarSource string `json:"var_source,omitempty"`
+	Var       string `json:"var,omitempty"`
+
+	// Limit caps the number of values. Defaults to DefaultAcrossValuesLimit.
+	Limit int `json:"limit,omitempty"`
+}
+
+const DefaultAcrossValuesLimit = 100
+
 func (config *AcrossVarConfig) UnmarshalJSON(data []byte) error {
 	// Used to avoid infinite recursion when unmarshalling.
 	type target AcrossVarConfig
@@ -481,11 +652,19 @@ func (config *AcrossVarConfig) UnmarshalJSON(data []byte) error {
 }
 
 type AcrossStep struct {
-	Step     StepConfig        `json:"-"`
-	Vars     []AcrossVarConfig `json:"across"`
-	FailFast bool              `json:"fail_fast,omitempty"`
+	Step     StepConfig          `json:"-"`
+	Vars     []AcrossVarConfig   `json:"across"`
+	Exclude  []AcrossCombination `json:"exclude,omitempty"`
+	Include  []AcrossCombination `json:"include,omitempty"`
+	FailFast bool                `json:"fail_fast,omitempty"`
 }
 
+// AcrossCombination maps across var names to values. Combinations in
+// exclude skip every combination of var values that they match; vars that
+// they leave out match any value. Combinations in include must set every
+// var, and are run in addition to the others.
+type AcrossCombination map[string]interface{}
+
 func (step *AcrossStep) ParseJSON(data []byte) error {
 	return json.Unmarshal(data, step)
 }
@@ -503,8 +682,84 @@ func (step *AcrossStep) Unwrap() StepConfig {
 }
 
 type RetryStep struct {
-	Step     StepConfig `json:"-"`
-	Attempts int        `json:"attempts"`
+	Step     StepConfig       `json:"-"`
+	Attempts int              `json:"attempts"`
+	Backoff  *RetryBackoff    `json:"backoff,omitempty"`
+	RetryOn  []RetryCondition `json:"retry_on,omitempty"`
+}
+
+// RetryBackoff configures how long to wait between the attempts of a retried
+// step. Durations are given as strings, like the timeout modifier, and must
+// parse as a Go duration; they are not interpolated with `((vars))`.
+type RetryBackoff struct {
+	// Initial is the delay before the second attempt.
+	Initial string `json:"initial"`
+
+	// Multiplier is applied to the delay after each attempt. Defaults to 2.
+	Multiplier float64 `json:"multiplier,omitempty"`
+
+	// Max caps the delay between any two attempts.
+	Max string `json:"max,omitempty"`
+
+	// Jitter randomizes each delay to between half and all of its value, so
+	// that builds retrying against the same service spread out.
+	Jitter bool `json:"jitter,omitempty"`
+}
+
+const (
+	RetryOnTimeout           = "timeout"
+	RetryOnWorkerDisappeared = "worker-disappeared"
+	RetryOnScriptFailure     = "resource-script-failure"
+)
+
+// RetryConditionClasses lists the classes of failure a RetryCondition may
+// name.
+var RetryConditionClasses = []string{
+	RetryOnTimeout,
+	RetryOnWorkerDisappeared,
+	RetryOnScriptFailure,
+}
+
+// A RetryCondition restricts the failures for which a retried step is
+// attempted again. It is configured either as the name of a class of failure
+// (e.g. "timeout") or as {message: <regexp>} to match the error that the
+// attempt failed with.
+type RetryCondition struct {
+	Class   string
+	Message string
+}
+
+func (c *RetryCondition) UnmarshalJSON(condition []byte) error {
+	var data interface{}
+
+	err := json.Unmarshal(condition, &data)
+	if err != nil {
+		return err
+	}
+
+	switch actual := data.(type) {
+	case string:
+		c.Class = actual
+	case map[string]interface{}:
+		message, ok := actual["message"].(string)
+		if !ok || len(actual) != 1 {
+			return errors.New("retry condition must only specify a message")
+		}
+
+		c.Message = message
+	default:
+		return errors.New("unknown type for retry condition")
+	}
+
+	return nil
+}
+
+func (c RetryCondition) MarshalJSON() ([]byte, error) {
+	if c.Class != "" {
+		return json.Marshal(c.Class)
+	}
+
+	return json.Marshal(map[string]string{"message": c.Message})
 }
 
 func (step *RetryStep) Wrap(sub StepConfig) {
@@ -519,12 +774,135 @@ func (step *RetryStep) Visit(v StepVisitor) error {
 	return v.VisitRetry(step)
 }
 
+type WhileStep struct {
+	Step StepConfig `json:"-"`
+
+	Config WhileConfig `json:"while"`
+}
+
+// WhileConfig configures when a looped step stops being run again.
+type WhileConfig struct {
+	// Var is the name of a local var, typically set by a load_var step within
+	// the loop. The step is run again for as long as the var is true. If no
+	// var is given, the step is run again for as long as it succeeds.
+	Var string `json:"var,omitempty"`
+
+	// MaxIterations caps the number of times the step is run. The loop fails
+	// if the condition still holds after the last iteration. Defaults to
+	// DefaultWhileMaxIterations.
+	MaxIterations int `json:"max_iterations,omitempty"`
+}
+
+const (
+	DefaultWhileMaxIterations = 10
+
+	// MaxWhileIterations bounds max_iterations, since the planner expands a
+	// plan for every iteration up front.
+	MaxWhileIterations = 100
+)
+
+func (step *WhileStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *WhileStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *WhileStep) Visit(v StepVisitor) error {
+	return v.VisitWhile(step)
+}
+
+// WhenStep only runs its step, along with any hooks, if Condition holds
+// once vars have been interpolated into it. See ParseCondition.
+type WhenStep struct {
+	Step      StepConfig `json:"-"`
+	Condition string     `json:"when"`
+}
+
+func (step *WhenStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *WhenStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *WhenStep) Visit(v StepVisitor) error {
+	return v.VisitWhen(step)
+}
+
+// LockStep runs its step, along with any hooks, while holding a named lock
+// shared by all of the team's builds.
+type LockStep struct {
+	Step StepConfig `json:"-"`
+
+	Config LockConfig `json:"lock"`
+}
+
+// LockConfig is either just the name of the lock, or the name along with how
+// many steps may hold the lock at once.
+type LockConfig struct {
+	Name string `json:"name"`
+
+	// Count turns the lock into a semaphore which up to Count steps may hold
+	// at once. Defaults to 1.
+	Count int `json:"count,omitempty"`
+}
+
+func (c *LockConfig) UnmarshalJSON(payload []byte) error {
+	var data interface{}
+	err := json.Unmarshal(payload, &data)
+	if err != nil {
+		return err
+	}
+
+	switch actual := data.(type) {
+	case string:
+		c.Name = actual
+	case map[string]interface{}:
+		// Used to avoid infinite recursion when unmarshalling this variant.
+		type target LockConfig
+
+		var t target
+		if err := json.Unmarshal(payload, &t); err != nil {
+			return fmt.Errorf("failed to unmarshal lock config: %s", err)
+		}
+
+		c.Name, c.Count = t.Name, t.Count
+	default:
+		return fmt.Errorf("wrong type for lock config: %v", actual)
+	}
+
+	return nil
+}
+
+func (step *LockStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *LockStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *LockStep) Visit(v StepVisitor) error {
+	return v.VisitLock(step)
+}
+
 type TimeoutStep struct {
 	Step StepConfig `json:"-"`
 
 	// it's very tempting to make this a Duration type, but that would probably
 	// prevent using `((vars))` to parameterize it
 	Duration string `json:"timeout"`
+
+	// WarnAfter, if set, emits a warning once the step has been running for
+	// the given duration, without interrupting it.
+	WarnAfter string `json:"warn_after,omitempty"`
+
+	// Build marks the timeout wrapped around a job's steps for its
+	// build_timeout. It cannot be set in a pipeline config.
+	Build bool `json:"-"`
 }
 
 func (step *TimeoutStep) Wrap(sub StepConfig) {
@@ -539,9 +917,39 @@ func (step *TimeoutStep) Visit(v StepVisitor) error {
 	return v.VisitTimeout(step)
 }
 
-type OnSuccessStep struct {
+type MuteStep struct {
 	Step StepConfig `json:"-"`
-	Hook Step       `json:"on_success"`
+
+	Config MuteConfig `json:"mute"`
+}
+
+// MuteConfig limits how much of a step's output is saved to the build log.
+// Status events (start, finish, errors) are always saved.
+type MuteConfig struct {
+	// Stdout discards everything the step writes to stdout, keeping stderr.
+	Stdout bool `json:"stdout,omitempty"`
+
+	// LogLimit caps the number of bytes saved for each output stream, e.g.
+	// "10MB". Anything past the limit is replaced by a truncation marker.
+	LogLimit string `json:"log_limit,omitempty"`
+}
+
+func (step *MuteStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *MuteStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *MuteStep) Visit(v StepVisitor) error {
+	return v.VisitMute(step)
+}
+
+type OnSuccessStep struct {
+	Step    StepConfig `json:"-"`
+	Hook    Step       `json:"on_success"`
+	Timeout string     `json:"on_success_timeout,omitempty"`
 }
 
 func (step *OnSuccessStep) Wrap(sub StepConfig) {
@@ -557,8 +965,9 @@ func (step *OnSuccessStep) Visit(v StepVisitor) error {
 }
 
 type OnFailureStep struct {
-	Step StepConfig `json:"-"`
-	Hook Step       `json:"on_failure"`
+	Step    StepConfig `json:"-"`
+	Hook    Step       `json:"on_failure"`
+	Timeout string     `json:"on_failure_timeout,omitempty"`
 }
 
 func (step *OnFailureStep) Wrap(sub StepConfig) {
@@ -574,8 +983,9 @@ func (step *OnFailureStep) Visit(v StepVisitor) error {
 }
 
 type OnErrorStep struct {
-	Step StepConfig `json:"-"`
-	Hook Step       `json:"on_error"`
+	Step    StepConfig `json:"-"`
+	Hook    Step       `json:"on_error"`
+	Timeout string     `json:"on_error_timeout,omitempty"`
 }
 
 func (step *OnErrorStep) Wrap(sub StepConfig) {
@@ -590,9 +1000,28 @@ func (step *OnErrorStep) Visit(v StepVisitor) error {
 	return v.VisitOnError(step)
 }
 
+type OnTimeoutStep struct {
+	Step    StepConfig `json:"-"`
+	Hook    Step       `json:"on_timeout"`
+	Timeout string     `json:"on_timeout_timeout,omitempty"`
+}
+
+func (step *OnTimeoutStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *OnTimeoutStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *OnTimeoutStep) Visit(v StepVisitor) error {
+	return v.VisitOnTimeout(step)
+}
+
 type OnAbortStep struct {
-	Step StepConfig `json:"-"`
-	Hook Step       `json:"on_abort"`
+	Step    StepConfig `json:"-"`
+	Hook    Step       `json:"on_abort"`
+	Timeout string     `json:"on_abort_timeout,omitempty"`
 }
 
 func (step *OnAbortStep) Wrap(sub StepConfig) {
@@ -608,8 +1037,9 @@ func (step *OnAbortStep) Visit(v StepVisitor) error {
 }
 
 type EnsureStep struct {
-	Step StepConfig `json:"-"`
-	Hook Step       `json:"ensure"`
+	Step    StepConfig `json:"-"`
+	Hook    Step       `json:"ensure"`
+	Timeout string     `json:"ensure_timeout,omitempty"`
 }
 
 func (step *EnsureStep) Wrap(sub StepConfig) {
@@ -35,6 +35,7 @@ var factoryTests = []StepTest{
 			version: {some: version}
 			tags: [tag-1, tag-2]
 			timeout: 1h
+			container_limits: {cpu: 10, memory: 1024}
 		`,
 		StepConfig: &atc.GetStep{
 			Name:     "some-name",
@@ -43,6 +44,34 @@ var factoryTests = []StepTest{
 			Version:  &atc.VersionConfig{Pinned: atc.Version{"some": "version"}},
 			Tags:     []string{"tag-1", "tag-2"},
 			Timeout:  "1h",
+			Limits:   &atc.ContainerLimits{CPU: newCPULimit(10), Memory: newMemoryLimit(1024)},
+		},
+	},
+	{
+		Title: "get step with depth",
+		ConfigYAML: `
+			get: some-name
+			depth: 5
+		`,
+		StepConfig: &atc.GetStep{
+			Name:  "some-name",
+			Depth: 5,
+		},
+	},
+	{
+		Title: "get step with verify",
+		ConfigYAML: `
+			get: some-name
+			verify:
+			  file: some-file.tgz
+			  sha256: ((some-digest))
+		`,
+		StepConfig: &atc.GetStep{
+			Name: "some-name",
+			Verify: &atc.VerifyConfig{
+				File:   "some-file.tgz",
+				SHA256: "((some-digest))",
+			},
 		},
 	},
 	{
@@ -56,6 +85,7 @@ var factoryTests = []StepTest{
 			inputs: all
 			get_params: {some: get-params}
 			timeout: 1h
+			container_limits: {cpu: 10, memory: 1024}
 		`,
 		StepConfig: &atc.PutStep{
 			Name:      "some-name",
@@ -65,6 +95,36 @@ var factoryTests = []StepTest{
 			Inputs:    &atc.InputsConfig{All: true},
 			GetParams: atc.Params{"some": "get-params"},
 			Timeout:   "1h",
+			Limits:    &atc.ContainerLimits{CPU: newCPULimit(10), Memory: newMemoryLimit(1024)},
+		},
+	},
+	{
+		Title: "put step with no_get",
+
+		ConfigYAML: `
+			put: some-name
+			no_get: true
+		`,
+		StepConfig: &atc.PutStep{
+			Name:  "some-name",
+			NoGet: true,
+		},
+	},
+	{
+		Title: "put step with targets",
+
+		ConfigYAML: `
+			put: some-name
+			targets:
+			- space: some-space
+			- space: other-space
+		`,
+		StepConfig: &atc.PutStep{
+			Name: "some-name",
+			Targets: []atc.Source{
+				{"space": "some-space"},
+				{"space": "other-space"},
+			},
 		},
 	},
 	{
@@ -84,6 +144,10 @@ var factoryTests = []StepTest{
 			output_mapping: {specific: generic}
 			image: some-image
 			timeout: 1h
+			hermetic: true
+			user: nobody
+			archive_outputs: true
+			priority: 10
 		`,
 
 		StepConfig: &atc.TaskStep{
@@ -101,6 +165,10 @@ var factoryTests = []StepTest{
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
+			Hermetic:          true,
+			User:              "nobody",
+			ArchiveOutputs:    true,
+			Priority:          10,
 		},
 	},
 	{
@@ -178,6 +246,10 @@ var factoryTests = []StepTest{
 			tags: [tag-1, tag-2]
 			container_limits: {cpu: 10, memory: 1024}
 			timeout: 1h
+			inputs: [some-input]
+			outputs: [some-output]
+			input_mapping: {some-input: some-source}
+			output_mapping: {some-output: some-destination}
 		`,
 
 		StepConfig: &atc.RunStep{
@@ -193,6 +265,11 @@ var factoryTests = []StepTest{
 			Tags:    []string{"tag-1", "tag-2"},
 			Limits:  &atc.ContainerLimits{CPU: newCPULimit(10), Memory: newMemoryLimit(1024)},
 			Timeout: "1h",
+
+			Inputs:        []string{"some-input"},
+			Outputs:       []string{"some-output"},
+			InputMapping:  map[string]string{"some-input": "some-source"},
+			OutputMapping: map[string]string{"some-output": "some-destination"},
 		},
 	},
 	{
@@ -204,6 +281,7 @@ var factoryTests = []StepTest{
 			vars: {some: vars}
 			var_files: [file-1, file-2]
 			instance_vars: {branch: feature/foo}
+			dry_run: true
 		`,
 
 		StepConfig: &atc.SetPipelineStep{
@@ -212,6 +290,7 @@ var factoryTests = []StepTest{
 			Vars:         atc.Params{"some": "vars"},
 			VarFiles:     []string{"file-1", "file-2"},
 			InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+			DryRun:       true,
 		},
 	},
 	{
@@ -231,6 +310,43 @@ var factoryTests = []StepTest{
 			Reveal: true,
 		},
 	},
+	{
+		Title: "load_var step with multi_document",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-var-file
+			multi_document: true
+		`,
+
+		StepConfig: &atc.LoadVarStep{
+			Name:          "some-var",
+			File:          "some-var-file",
+			MultiDocument: true,
+		},
+	},
+	{
+		Title: "notify step",
+
+		ConfigYAML: `
+			notify: some-notification
+			message: some-message
+			targets:
+			- type: slack
+			  url: ((slack-webhook))
+			- type: http
+			  url: https://example.com/notify
+		`,
+
+		StepConfig: &atc.NotifyStep{
+			Name:    "some-notification",
+			Message: "some-message",
+			Targets: []atc.NotifyTarget{
+				{Type: "slack", URL: "((slack-webhook))"},
+				{Type: "http", URL: "https://example.com/notify"},
+			},
+		},
+	},
 	{
 		Title: "try step",
 
@@ -277,6 +393,28 @@ var factoryTests = []StepTest{
 			},
 		},
 	},
+	{
+		Title: "do step with continue_on_failure",
+
+		ConfigYAML: `
+			do:
+			- load_var: some-var
+			  file: some-file
+			continue_on_failure: true
+		`,
+
+		StepConfig: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-var",
+						File: "some-file",
+					},
+				},
+			},
+			ContinueOnFailure: true,
+		},
+	},
 	{
 		Title: "in_parallel step with simple list",
 
@@ -337,11 +475,36 @@ var factoryTests = []StepTest{
 						},
 					},
 				},
-				Limit:    3,
+				Limit:    &atc.InParallelLimit{Limit: 3},
 				FailFast: true,
 			},
 		},
 	},
+	{
+		Title: "in_parallel step with a var limit",
+
+		ConfigYAML: `
+			in_parallel:
+			  steps:
+			  - load_var: some-var
+			    file: some-file
+			  limit: ((parallelism))
+		`,
+
+		StepConfig: &atc.InParallelStep{
+			Config: atc.InParallelConfig{
+				Steps: []atc.Step{
+					{
+						Config: &atc.LoadVarStep{
+							Name: "some-var",
+							File: "some-file",
+						},
+					},
+				},
+				Limit: &atc.InParallelLimit{Var: "((parallelism))"},
+			},
+		},
+	},
 	{
 		Title: "across step",
 
@@ -429,6 +592,220 @@ var factoryTests = []StepTest{
 			Duration: "1h",
 		},
 	},
+	{
+		Title: "timeout modifier with warn_after",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			timeout: 1h
+			warn_after: 45m
+		`,
+
+		StepConfig: &atc.TimeoutStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Duration:  "1h",
+			WarnAfter: "45m",
+		},
+	},
+	{
+		Title: "mute modifier",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			mute:
+			  stdout: true
+			  log_limit: 10MB
+		`,
+
+		StepConfig: &atc.MuteStep{
+			Step: &atc.TaskStep{
+				Name:       "some-task",
+				ConfigPath: "some-file",
+			},
+			Config: atc.MuteConfig{
+				Stdout:   true,
+				LogLimit: "10MB",
+			},
+		},
+	},
+	{
+		Title: "while modifier",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			while:
+			  var: keep-going
+			  max_iterations: 20
+		`,
+
+		StepConfig: &atc.WhileStep{
+			Step: &atc.TaskStep{
+				Name:       "some-task",
+				ConfigPath: "some-file",
+			},
+			Config: atc.WhileConfig{
+				Var:           "keep-going",
+				MaxIterations: 20,
+			},
+		},
+	},
+	{
+		Title: "when modifier",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			when: ((branch)) == "main"
+			on_success:
+			  load_var: success-var
+			  file: success-file
+		`,
+
+		StepConfig: &atc.WhenStep{
+			Step: &atc.OnSuccessStep{
+				Step: &atc.TaskStep{
+					Name:       "some-task",
+					ConfigPath: "some-file",
+				},
+				Hook: atc.Step{
+					Config: &atc.LoadVarStep{
+						Name: "success-var",
+						File: "success-file",
+					},
+				},
+			},
+			Condition: `((branch)) == "main"`,
+		},
+	},
+	{
+		Title: "across step with exclude and include",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			across:
+			- var: os
+			  values: [linux, windows]
+			- var: arch
+			  values: [amd64, arm64]
+			exclude:
+			- os: windows
+			  arch: arm64
+			include:
+			- os: darwin
+			  arch: arm64
+		`,
+
+		StepConfig: &atc.AcrossStep{
+			Step: &atc.TaskStep{
+				Name:       "some-task",
+				ConfigPath: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:    "os",
+					Values: []interface{}{"linux", "windows"},
+				},
+				{
+					Var:    "arch",
+					Values: []interface{}{"amd64", "arm64"},
+				},
+			},
+			Exclude: []atc.AcrossCombination{
+				{"os": "windows", "arch": "arm64"},
+			},
+			Include: []atc.AcrossCombination{
+				{"os": "darwin", "arch": "arm64"},
+			},
+		},
+	},
+	{
+		Title: "across step with values from a resource",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			across:
+			- var: branch
+			  values_from:
+			    resource: branches
+			    limit: 5
+		`,
+
+		StepConfig: &atc.AcrossStep{
+			Step: &atc.TaskStep{
+				Name:       "some-task",
+				ConfigPath: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var: "branch",
+					ValuesFrom: &atc.AcrossValuesFrom{
+						Resource: "branches",
+						Limit:    5,
+					},
+				},
+			},
+		},
+	},
+	{
+		Title: "lock modifier",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			lock: staging
+			ensure:
+			  load_var: ensure-var
+			  file: ensure-file
+		`,
+
+		StepConfig: &atc.LockStep{
+			Step: &atc.EnsureStep{
+				Step: &atc.TaskStep{
+					Name:       "some-task",
+					ConfigPath: "some-file",
+				},
+				Hook: atc.Step{
+					Config: &atc.LoadVarStep{
+						Name: "ensure-var",
+						File: "ensure-file",
+					},
+				},
+			},
+			Config: atc.LockConfig{
+				Name: "staging",
+			},
+		},
+	},
+	{
+		Title: "lock modifier with a count",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			lock:
+			  name: staging
+			  count: 2
+		`,
+
+		StepConfig: &atc.LockStep{
+			Step: &atc.TaskStep{
+				Name:       "some-task",
+				ConfigPath: "some-file",
+			},
+			Config: atc.LockConfig{
+				Name:  "staging",
+				Count: 2,
+			},
+		},
+	},
 	{
 		Title: "attempts modifier",
 
@@ -446,6 +823,74 @@ var factoryTests = []StepTest{
 			Attempts: 3,
 		},
 	},
+	{
+		Title: "attempts modifier with backoff",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			attempts: 3
+			backoff:
+			  initial: 5s
+			  multiplier: 1.5
+			  max: 1m
+			  jitter: true
+		`,
+
+		StepConfig: &atc.RetryStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Attempts: 3,
+			Backoff: &atc.RetryBackoff{
+				Initial:    "5s",
+				Multiplier: 1.5,
+				Max:        "1m",
+				Jitter:     true,
+			},
+		},
+	},
+	{
+		Title: "attempts modifier with retry_on",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			attempts: 3
+			retry_on:
+			- timeout
+			- worker-disappeared
+			- message: connection reset
+		`,
+
+		StepConfig: &atc.RetryStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Attempts: 3,
+			RetryOn: []atc.RetryCondition{
+				{Class: "timeout"},
+				{Class: "worker-disappeared"},
+				{Message: "connection reset"},
+			},
+		},
+	},
+	{
+		Title: "attempts modifier with a malformed retry condition",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			attempts: 3
+			retry_on:
+			- message: connection reset
+			  class: timeout
+		`,
+
+		Err: `error unmarshaling JSON: while decoding JSON: malformed attempts step: retry condition must only specify a message`,
+	},
 	{
 		Title: "precedence of all hooks and modifiers",
 
@@ -469,6 +914,9 @@ var factoryTests = []StepTest{
 			on_error:
 			  load_var: error-var
 			  file: error-file
+			on_timeout:
+			  load_var: timeout-var
+			  file: timeout-file
 			ensure:
 			  load_var: ensure-var
 			  file: ensure-file
@@ -476,45 +924,53 @@ var factoryTests = []StepTest{
 
 		StepConfig: &atc.EnsureStep{
 			Step: &atc.OnErrorStep{
-				Step: &atc.OnAbortStep{
-					Step: &atc.OnFailureStep{
-						Step: &atc.OnSuccessStep{
-							Step: &atc.AcrossStep{
-								Step: &atc.RetryStep{
-									Step: &atc.TimeoutStep{
-										Step: &atc.LoadVarStep{
-											Name: "some-var",
-											File: "some-file",
+				Step: &atc.OnTimeoutStep{
+					Step: &atc.OnAbortStep{
+						Step: &atc.OnFailureStep{
+							Step: &atc.OnSuccessStep{
+								Step: &atc.AcrossStep{
+									Step: &atc.RetryStep{
+										Step: &atc.TimeoutStep{
+											Step: &atc.LoadVarStep{
+												Name: "some-var",
+												File: "some-file",
+											},
+											Duration: "1h",
+										},
+										Attempts: 3,
+									},
+									Vars: []atc.AcrossVarConfig{
+										{
+											Var:    "version",
+											Values: []interface{}{"v1", "v2", "v3"},
 										},
-										Duration: "1h",
 									},
-									Attempts: 3,
 								},
-								Vars: []atc.AcrossVarConfig{
-									{
-										Var:    "version",
-										Values: []interface{}{"v1", "v2", "v3"},
+								Hook: atc.Step{
+									Config: &atc.LoadVarStep{
+										Name: "success-var",
+										File: "success-file",
 									},
 								},
 							},
 							Hook: atc.Step{
 								Config: &atc.LoadVarStep{
-									Name: "success-var",
-									File: "success-file",
+									Name: "failure-var",
+									File: "failure-file",
 								},
 							},
 						},
 						Hook: atc.Step{
 							Config: &atc.LoadVarStep{
-								Name: "failure-var",
-								File: "failure-file",
+								Name: "abort-var",
+								File: "abort-file",
 							},
 						},
 					},
 					Hook: atc.Step{
 						Config: &atc.LoadVarStep{
-							Name: "abort-var",
-							File: "abort-file",
+							Name: "timeout-var",
+							File: "timeout-file",
 						},
 					},
 				},
@@ -533,6 +989,45 @@ var factoryTests = []StepTest{
 			},
 		},
 	},
+	{
+		Title: "hook timeouts",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			on_failure:
+			  load_var: failure-var
+			  file: failure-file
+			on_failure_timeout: 5m
+			ensure:
+			  load_var: ensure-var
+			  file: ensure-file
+			ensure_timeout: 10m
+		`,
+
+		StepConfig: &atc.EnsureStep{
+			Step: &atc.OnFailureStep{
+				Step: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+				Hook: atc.Step{
+					Config: &atc.LoadVarStep{
+						Name: "failure-var",
+						File: "failure-file",
+					},
+				},
+				Timeout: "5m",
+			},
+			Hook: atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "ensure-var",
+					File: "ensure-file",
+				},
+			},
+			Timeout: "10m",
+		},
+	},
 	{
 		Title: "unknown field with get step",
 
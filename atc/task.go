@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"sigs.k8s.io/yaml"
@@ -19,6 +20,10 @@ type TaskConfig struct {
 
 	ImageResource *ImageResource `json:"image_resource,omitempty"`
 
+	// Image to pull straight from a registry by its digest, without checking
+	// or fetching an image resource.
+	Image *RegistryImage `json:"image,omitempty"`
+
 	// Limits to set on the Task Container
 	Limits *ContainerLimits `json:"container_limits,omitempty"`
 
@@ -34,8 +39,25 @@ type TaskConfig struct {
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty"`
 
+	// Vars loaded from files written to the outputs, which are set as
+	// build-local vars once the task succeeds.
+	OutputVars []TaskOutputVarConfig `json:"output_vars,omitempty"`
+
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []TaskCacheConfig `json:"caches,omitempty"`
+
+	// Processes to run alongside the script, e.g. a database or docker daemon.
+	Sidecars []TaskSidecarConfig `json:"sidecars,omitempty"`
+
+	// In-memory filesystems to mount into the task's container.
+	Tmpfs []TaskTmpfsConfig `json:"tmpfs,omitempty"`
+
+	// Size of the task container's /dev/shm, which defaults to 64MB.
+	ShmSize *MemoryLimit `json:"shm_size,omitempty"`
+
+	// Number of GPUs to mount into the task's container. The task only runs
+	// on workers with at least as many GPUs.
+	GPUs int `json:"gpus,omitempty"`
 }
 
 type ImageResource struct {
@@ -47,6 +69,21 @@ type ImageResource struct {
 	Tags    Tags    `json:"tags,omitempty"`
 }
 
+// RegistryImage is an image in a registry, pinned to the digest of its
+// manifest or index.
+type RegistryImage struct {
+	Repository string `json:"repository"`
+	Digest     string `json:"digest"`
+
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+
+	// Insecure registries are talked to over plain HTTP.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+var imageDigestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
 func (ir *ImageResource) ApplySourceDefaults(resourceTypes ResourceTypes) {
 	if ir == nil {
 		return
@@ -93,12 +130,16 @@ func (config TaskConfig) Validate() error {
 		errors = append(errors, "missing 'platform'")
 	}
 
-	if config.Run.Path == "" {
-		errors = append(errors, "missing path to executable to run")
-	}
-
+	errors = append(errors, config.validateImage()...)
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
+	errors = append(errors, config.validateOutputVars()...)
+	errors = append(errors, config.validateSidecars()...)
+	errors = append(errors, config.validateTmpfsContainsPaths()...)
+
+	if config.GPUs < 0 {
+		errors = append(errors, "'gpus' must not be negative")
+	}
 
 	if len(errors) > 0 {
 		return TaskValidationError{
@@ -109,6 +150,28 @@ func (config TaskConfig) Validate() error {
 	return nil
 }
 
+func (config TaskConfig) validateImage() []string {
+	if config.Image == nil {
+		return nil
+	}
+
+	var messages []string
+
+	if config.RootfsURI != "" || config.ImageResource != nil {
+		messages = append(messages, "'image' may not be specified alongside 'rootfs_uri' or 'image_resource'")
+	}
+
+	if config.Image.Repository == "" {
+		messages = append(messages, "image is missing 'repository'")
+	}
+
+	if !imageDigestRegexp.MatchString(config.Image.Digest) {
+		messages = append(messages, "image 'digest' must be of the form sha256:<64 hex characters>")
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateOutputContainsNames() []string {
 	var messages []string
 
@@ -121,6 +184,72 @@ func (config TaskConfig) validateOutputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateOutputVars() []string {
+	var messages []string
+
+	outputs := map[string]bool{}
+	for _, output := range config.Outputs {
+		outputs[output.Name] = true
+	}
+
+	names := map[string]bool{}
+	for i, outputVar := range config.OutputVars {
+		if outputVar.Name == "" {
+			messages = append(messages, fmt.Sprintf("  output var in position %d is missing a name", i))
+		} else if names[outputVar.Name] {
+			messages = append(messages, fmt.Sprintf("  output var '%s' is declared more than once", outputVar.Name))
+		}
+
+		names[outputVar.Name] = true
+
+		segs := strings.SplitN(outputVar.File, "/", 2)
+		if len(segs) != 2 || !outputs[segs[0]] {
+			messages = append(messages, fmt.Sprintf("  output var in position %d must load a file from one of the task's outputs", i))
+		}
+
+		switch outputVar.Format {
+		case "", "raw", "trim", "yml", "yaml", "json":
+		default:
+			messages = append(messages, fmt.Sprintf("  output var in position %d has invalid format '%s'", i, outputVar.Format))
+		}
+	}
+
+	return messages
+}
+
+func (config TaskConfig) validateSidecars() []string {
+	var messages []string
+
+	names := map[string]bool{}
+	for i, sidecar := range config.Sidecars {
+		if sidecar.Name == "" {
+			messages = append(messages, fmt.Sprintf("  sidecar in position %d is missing a name", i))
+		} else if names[sidecar.Name] {
+			messages = append(messages, fmt.Sprintf("  sidecar '%s' is declared more than once", sidecar.Name))
+		}
+
+		names[sidecar.Name] = true
+
+		if sidecar.Run.Path == "" {
+			messages = append(messages, fmt.Sprintf("  sidecar in position %d is missing path to executable to run", i))
+		}
+	}
+
+	return messages
+}
+
+func (config TaskConfig) validateTmpfsContainsPaths() []string {
+	var messages []string
+
+	for i, tmpfs := range config.Tmpfs {
+		if tmpfs.Path == "" {
+			messages = append(messages, fmt.Sprintf("  tmpfs in position %d is missing a path", i))
+		}
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateInputContainsNames() []string {
 	messages := []string{}
 
@@ -134,6 +263,8 @@ func (config TaskConfig) validateInputContainsNames() []string {
 }
 
 type TaskRunConfig struct {
+	// The executable to run. If empty, the image's entrypoint and cmd are run
+	// instead, with Args in place of the cmd.
 	Path string   `json:"path"`
 	Args []string `json:"args,omitempty"`
 	Dir  string   `json:"dir,omitempty"`
@@ -142,6 +273,14 @@ type TaskRunConfig struct {
 	User string `json:"user,omitempty"`
 }
 
+// TaskSidecarConfig is a process started in the task's container before the
+// script runs. Since it runs in the same container, it shares the script's
+// network namespace and is stopped once the script exits.
+type TaskSidecarConfig struct {
+	Name string        `json:"name"`
+	Run  TaskRunConfig `json:"run"`
+}
+
 type TaskInputConfig struct {
 	Name     string `json:"name"`
 	Path     string `json:"path,omitempty"`
@@ -153,10 +292,25 @@ type TaskOutputConfig struct {
 	Path string `json:"path,omitempty"`
 }
 
+// TaskOutputVarConfig is a var loaded from a file in one of the task's
+// outputs, like a load_var step would. The file is given as
+// <output-name>/<path>.
+type TaskOutputVarConfig struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Format string `json:"format,omitempty"`
+	Reveal bool   `json:"reveal,omitempty"`
+}
+
 type TaskCacheConfig struct {
 	Path string `json:"path,omitempty"`
 }
 
+type TaskTmpfsConfig struct {
+	Path string       `json:"path"`
+	Size *MemoryLimit `json:"size,omitempty"`
+}
+
 type TaskEnv map[string]string
 
 func (te *TaskEnv) UnmarshalJSON(p []byte) error {
@@ -309,6 +309,49 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has an image pulled by digest", func() {
+			const digest = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+			BeforeEach(func() {
+				validConfig.Image = &RegistryImage{Repository: "some/image", Digest: digest}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when the repository is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Image = &RegistryImage{Digest: digest}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("image is missing 'repository'")))
+				})
+			})
+
+			Context("when the digest is not a sha256 digest", func() {
+				BeforeEach(func() {
+					invalidConfig.Image = &RegistryImage{Repository: "some/image", Digest: "latest"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("image 'digest' must be of the form sha256:<64 hex characters>")))
+				})
+			})
+
+			Context("when an image resource is also specified", func() {
+				BeforeEach(func() {
+					invalidConfig.Image = &RegistryImage{Repository: "some/image", Digest: digest}
+					invalidConfig.ImageResource = &ImageResource{Type: "registry-image"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("'image' may not be specified alongside 'rootfs_uri' or 'image_resource'")))
+				})
+			})
+		})
+
 		Context("when the task has inputs", func() {
 			BeforeEach(func() {
 				validConfig.Inputs = append(validConfig.Inputs, TaskInputConfig{Name: "concourse"})
@@ -385,13 +428,131 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has sidecars", func() {
+			BeforeEach(func() {
+				validConfig.Sidecars = append(validConfig.Sidecars, TaskSidecarConfig{
+					Name: "postgres",
+					Run:  TaskRunConfig{Path: "postgres"},
+				})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when sidecar.name is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Sidecars = append(invalidConfig.Sidecars, TaskSidecarConfig{Run: TaskRunConfig{Path: "postgres"}})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("sidecar in position 0 is missing a name")))
+				})
+			})
+
+			Context("when sidecar.name is repeated", func() {
+				BeforeEach(func() {
+					invalidConfig.Sidecars = append(
+						invalidConfig.Sidecars,
+						TaskSidecarConfig{Name: "postgres", Run: TaskRunConfig{Path: "postgres"}},
+						TaskSidecarConfig{Name: "postgres", Run: TaskRunConfig{Path: "postgres"}},
+					)
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("sidecar 'postgres' is declared more than once")))
+				})
+			})
+
+			Context("when sidecar.run.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Sidecars = append(invalidConfig.Sidecars, TaskSidecarConfig{Name: "postgres"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("sidecar in position 0 is missing path to executable to run")))
+				})
+			})
+		})
+
+		Context("when the task has output vars", func() {
+			BeforeEach(func() {
+				validConfig.Outputs = []TaskOutputConfig{{Name: "out"}}
+			})
+
+			It("is valid when they load files from the outputs", func() {
+				validConfig.OutputVars = []TaskOutputVarConfig{
+					{Name: "version", File: "out/version", Format: "trim"},
+				}
+
+				Expect(validConfig.Validate()).To(Succeed())
+			})
+
+			It("requires a name", func() {
+				validConfig.OutputVars = []TaskOutputVarConfig{{File: "out/version"}}
+
+				Expect(validConfig.Validate()).To(MatchError(ContainSubstring("output var in position 0 is missing a name")))
+			})
+
+			It("requires unique names", func() {
+				validConfig.OutputVars = []TaskOutputVarConfig{
+					{Name: "version", File: "out/version"},
+					{Name: "version", File: "out/other"},
+				}
+
+				Expect(validConfig.Validate()).To(MatchError(ContainSubstring("output var 'version' is declared more than once")))
+			})
+
+			It("requires the file to be in an output", func() {
+				validConfig.OutputVars = []TaskOutputVarConfig{{Name: "version", File: "in/version"}}
+
+				Expect(validConfig.Validate()).To(MatchError(ContainSubstring("output var in position 0 must load a file from one of the task's outputs")))
+			})
+
+			It("requires a valid format", func() {
+				validConfig.OutputVars = []TaskOutputVarConfig{{Name: "version", File: "out/version", Format: "toml"}}
+
+				Expect(validConfig.Validate()).To(MatchError(ContainSubstring("output var in position 0 has invalid format 'toml'")))
+			})
+		})
+
+		Context("when the task has tmpfs mounts", func() {
+			BeforeEach(func() {
+				validConfig.Tmpfs = append(validConfig.Tmpfs, TaskTmpfsConfig{Path: "/tmp"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when tmpfs.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Tmpfs = append(invalidConfig.Tmpfs, TaskTmpfsConfig{Path: "/tmp"}, TaskTmpfsConfig{})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("tmpfs in position 1 is missing a path")))
+				})
+			})
+		})
+
+		Context("when gpus is negative", func() {
+			BeforeEach(func() {
+				invalidConfig.GPUs = -1
+			})
+
+			It("returns an error", func() {
+				Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("'gpus' must not be negative")))
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
 			})
 
-			It("returns an error", func() {
-				Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("missing path to executable to run")))
+			It("is valid, as the image's entrypoint and cmd are run instead", func() {
+				Expect(invalidConfig.Validate()).To(Succeed())
 			})
 		})
 
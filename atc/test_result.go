@@ -0,0 +1,65 @@
+package atc
+
+type TestStatus string
+
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusErrored TestStatus = "errored"
+	TestStatusSkipped TestStatus = "skipped"
+)
+
+// TestResult is a single test case parsed from a JUnit/XUnit report produced
+// by a task.
+type TestResult struct {
+	PlanID    PlanID     `json:"plan_id,omitempty"`
+	Suite     string     `json:"suite,omitempty"`
+	ClassName string     `json:"class_name,omitempty"`
+	Name      string     `json:"name"`
+	Status    TestStatus `json:"status"`
+	Duration  float64    `json:"duration,omitempty"`
+	Message   string     `json:"message,omitempty"`
+}
+
+type TestResultsSummary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Errored int `json:"errored"`
+	Skipped int `json:"skipped"`
+}
+
+func SummarizeTestResults(results []TestResult) TestResultsSummary {
+	summary := TestResultsSummary{Total: len(results)}
+	for _, result := range results {
+		switch result.Status {
+		case TestStatusPassed:
+			summary.Passed++
+		case TestStatusFailed:
+			summary.Failed++
+		case TestStatusErrored:
+			summary.Errored++
+		case TestStatusSkipped:
+			summary.Skipped++
+		}
+	}
+	return summary
+}
+
+type BuildTestResults struct {
+	Summary TestResultsSummary `json:"summary"`
+	Results []TestResult       `json:"results"`
+}
+
+// TestReportNotification is the payload sent to a job's test_report_webhook
+// whenever a task in one of its builds reports test results.
+type TestReportNotification struct {
+	BuildID              int                `json:"build_id"`
+	BuildName            string             `json:"build_name"`
+	TeamName             string             `json:"team_name"`
+	PipelineName         string             `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars       `json:"pipeline_instance_vars,omitempty"`
+	JobName              string             `json:"job_name"`
+	StepName             string             `json:"step_name"`
+	Summary              TestResultsSummary `json:"summary"`
+}
@@ -0,0 +1,60 @@
+package testreport_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/testreport"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Forward", func() {
+	var (
+		server       *ghttp.Server
+		notification atc.TestReportNotification
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		notification = atc.TestReportNotification{
+			BuildID:  42,
+			JobName:  "some-job",
+			StepName: "unit",
+			Summary:  atc.TestResultsSummary{Total: 2, Passed: 1, Failed: 1},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the webhook accepts the notification", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyJSONRepresenting(notification),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+		})
+
+		It("posts the notification as JSON", func() {
+			err := testreport.Forward(context.Background(), server.URL()+"/hook", notification)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the webhook responds with an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("returns an error", func() {
+			err := testreport.Forward(context.Background(), server.URL(), notification)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
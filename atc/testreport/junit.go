@@ -0,0 +1,104 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	XMLName xml.Name         `xml:"testsuite"`
+	Name    string           `xml:"name,attr"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+	Cases   []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	Skipped   *junitMessage `xml:"skipped"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func (m junitMessage) String() string {
+	if m.Message != "" {
+		return m.Message
+	}
+
+	return strings.TrimSpace(m.Body)
+}
+
+// ParseJUnit parses a JUnit (or XUnit-compatible) XML report into a flat list
+// of test results. Both a <testsuites> and a bare <testsuite> root element are
+// accepted.
+func ParseJUnit(r io.Reader) ([]atc.TestResult, error) {
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(payload, &suites); err != nil {
+		var suite junitTestSuite
+		if suiteErr := xml.Unmarshal(payload, &suite); suiteErr != nil {
+			return nil, fmt.Errorf("parse junit report: %w", err)
+		}
+
+		suites.Suites = []junitTestSuite{suite}
+	}
+
+	var results []atc.TestResult
+	for _, suite := range suites.Suites {
+		results = appendSuite(results, suite)
+	}
+
+	return results, nil
+}
+
+func appendSuite(results []atc.TestResult, suite junitTestSuite) []atc.TestResult {
+	for _, nested := range suite.Suites {
+		results = appendSuite(results, nested)
+	}
+
+	for _, tc := range suite.Cases {
+		result := atc.TestResult{
+			Suite:     suite.Name,
+			ClassName: tc.ClassName,
+			Name:      tc.Name,
+			Status:    atc.TestStatusPassed,
+		}
+
+		fmt.Sscanf(tc.Time, "%g", &result.Duration)
+
+		switch {
+		case tc.Error != nil:
+			result.Status = atc.TestStatusErrored
+			result.Message = tc.Error.String()
+		case tc.Failure != nil:
+			result.Status = atc.TestStatusFailed
+			result.Message = tc.Failure.String()
+		case tc.Skipped != nil:
+			result.Status = atc.TestStatusSkipped
+			result.Message = tc.Skipped.String()
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
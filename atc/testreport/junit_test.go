@@ -0,0 +1,99 @@
+package testreport_test
+
+import (
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/testreport"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseJUnit", func() {
+	var (
+		report string
+
+		results  []atc.TestResult
+		parseErr error
+	)
+
+	JustBeforeEach(func() {
+		results, parseErr = testreport.ParseJUnit(strings.NewReader(report))
+	})
+
+	Context("when the root element is <testsuites>", func() {
+		BeforeEach(func() {
+			report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="unit">
+    <testcase name="passes" classname="foo" time="0.5"/>
+    <testcase name="fails" classname="foo" time="1.25">
+      <failure message="expected 1 to equal 2">stack trace</failure>
+    </testcase>
+    <testcase name="errors" classname="bar">
+      <error>boom</error>
+    </testcase>
+    <testcase name="skips" classname="bar">
+      <skipped/>
+    </testcase>
+  </testsuite>
+</testsuites>`
+		})
+
+		It("returns every test case with its status", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(results).To(Equal([]atc.TestResult{
+				{Suite: "unit", ClassName: "foo", Name: "passes", Status: atc.TestStatusPassed, Duration: 0.5},
+				{Suite: "unit", ClassName: "foo", Name: "fails", Status: atc.TestStatusFailed, Duration: 1.25, Message: "expected 1 to equal 2"},
+				{Suite: "unit", ClassName: "bar", Name: "errors", Status: atc.TestStatusErrored, Message: "boom"},
+				{Suite: "unit", ClassName: "bar", Name: "skips", Status: atc.TestStatusSkipped},
+			}))
+		})
+
+		It("can be summarized", func() {
+			Expect(atc.SummarizeTestResults(results)).To(Equal(atc.TestResultsSummary{
+				Total:   4,
+				Passed:  1,
+				Failed:  1,
+				Errored: 1,
+				Skipped: 1,
+			}))
+		})
+	})
+
+	Context("when the root element is a single <testsuite>", func() {
+		BeforeEach(func() {
+			report = `<testsuite name="integration"><testcase name="works"/></testsuite>`
+		})
+
+		It("returns its test cases", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(results).To(Equal([]atc.TestResult{
+				{Suite: "integration", Name: "works", Status: atc.TestStatusPassed},
+			}))
+		})
+	})
+
+	Context("when suites are nested", func() {
+		BeforeEach(func() {
+			report = `<testsuites><testsuite name="outer"><testsuite name="inner"><testcase name="deep"/></testsuite></testsuite></testsuites>`
+		})
+
+		It("flattens them", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(results).To(Equal([]atc.TestResult{
+				{Suite: "inner", Name: "deep", Status: atc.TestStatusPassed},
+			}))
+		})
+	})
+
+	Context("when the report is not XML", func() {
+		BeforeEach(func() {
+			report = `{"not": "xml"}`
+		})
+
+		It("errors", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package atc
+
+// TeamsWithUniqueVersionHistory are the teams whose resources never share
+// check results or version history with identical resources elsewhere, even
+// when global resources are enabled.
+var TeamsWithUniqueVersionHistory = map[string]bool{}
+
+// RequiresUniqueVersionHistory returns whether a resource of the given team
+// must have a version history of its own, either because the resource itself
+// or its team has opted out of sharing it.
+func RequiresUniqueVersionHistory(teamName string, resource ResourceConfig) bool {
+	return resource.UniqueVersionHistory || TeamsWithUniqueVersionHistory[teamName]
+}
@@ -0,0 +1,64 @@
+package atc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const DefaultWebhookTokenGracePeriod = time.Hour
+
+// WebhookToken describes a token which can be used to trigger a check of a
+// resource via its webhook endpoint, in addition to the webhook_token in the
+// pipeline config. The token value itself is only returned once, when the
+// token is created.
+type WebhookToken struct {
+	ID         int    `json:"id"`
+	Token      string `json:"token,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	ExpiresAt  int64  `json:"expires_at,omitempty"`
+	LastUsedAt int64  `json:"last_used_at,omitempty"`
+}
+
+// WebhookTokenRotationRequest is submitted to replace a resource's webhook
+// tokens with a new one. The existing tokens remain valid for the grace
+// period so that webhooks can be updated without missing any checks. If no
+// grace period is given, DefaultWebhookTokenGracePeriod is used.
+type WebhookTokenRotationRequest struct {
+	GracePeriod *WebhookTokenGracePeriod `json:"grace_period,omitempty"`
+}
+
+// WebhookTokenGracePeriod is given either as a duration string, e.g. "30m",
+// or as a whole number of seconds.
+type WebhookTokenGracePeriod time.Duration
+
+func (period WebhookTokenGracePeriod) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(period).String())
+}
+
+func (period *WebhookTokenGracePeriod) UnmarshalJSON(payload []byte) error {
+	var str string
+	if err := json.Unmarshal(payload, &str); err == nil {
+		duration, err := time.ParseDuration(str)
+		if err != nil {
+			return fmt.Errorf("invalid grace_period '%s': %w", str, err)
+		}
+
+		*period = WebhookTokenGracePeriod(duration)
+		return nil
+	}
+
+	var seconds int64
+	if err := json.Unmarshal(payload, &seconds); err != nil {
+		return errors.New("grace_period must be a duration string or a whole number of seconds")
+	}
+
+	if seconds > math.MaxInt64/int64(time.Second) || seconds < math.MinInt64/int64(time.Second) {
+		return fmt.Errorf("grace_period of %d seconds is out of range", seconds)
+	}
+
+	*period = WebhookTokenGracePeriod(time.Duration(seconds) * time.Second)
+	return nil
+}
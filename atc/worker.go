@@ -21,8 +21,20 @@ type Worker struct {
 	ActiveVolumes    int `json:"active_volumes"`
 	ActiveTasks      int `json:"active_tasks"`
 
+	// Stats is reported on each heartbeat for metrics. Only the load it
+	// describes is persisted, for load-aware placement.
+	Stats *WorkerStats `json:"stats,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
+	// GPUs is the number of GPUs which the worker can mount into containers.
+	GPUs int `json:"gpus,omitempty"`
+
+	// CPUCapacity and MemoryCapacity are the CPU shares and bytes of memory
+	// which containers may request on the worker. Zero means unknown.
+	CPUCapacity    uint64 `json:"cpu_capacity,omitempty"`
+	MemoryCapacity uint64 `json:"memory_capacity,omitempty"`
+
 	Platform  string `json:"platform"`
 	Tags      Tags   `json:"tags"`
 	Team      string `json:"team"`
@@ -33,6 +45,22 @@ type Worker struct {
 	State     string `json:"state"`
 }
 
+type WorkerStats struct {
+	DiskTotalBytes uint64 `json:"disk_total_bytes"`
+	DiskFreeBytes  uint64 `json:"disk_free_bytes"`
+
+	// CPULoad is the worker's 1-minute load average divided by its number of
+	// CPUs.
+	CPULoad              float64 `json:"cpu_load,omitempty"`
+	MemoryTotalBytes     uint64  `json:"memory_total_bytes,omitempty"`
+	MemoryAvailableBytes uint64  `json:"memory_available_bytes,omitempty"`
+
+	VolumeStreamsIn        uint64 `json:"volume_streams_in"`
+	VolumeStreamsOut       uint64 `json:"volume_streams_out"`
+	VolumeStreamedInBytes  uint64 `json:"volume_streamed_in_bytes"`
+	VolumeStreamedOutBytes uint64 `json:"volume_streamed_out_bytes"`
+}
+
 type Tags []string
 
 // UnmarshalJSON unmarshals as a []string, removing any empty elements. Empty
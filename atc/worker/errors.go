@@ -34,3 +34,21 @@ type StreamingResourceCacheNotFoundError struct {
 func (e StreamingResourceCacheNotFoundError) Error() string {
 	return fmt.Sprintf("resource cache not found (id %d, volume handle %s)", e.ResourceCacheID, e.Handle)
 }
+
+// StreamingDigestMismatchError is returned when a volume streamed to a worker
+// doesn't match the digest of what was streamed out of its source, e.g.
+// because it was corrupted along the way.
+type StreamingDigestMismatchError struct {
+	Handle     string
+	FromWorker string
+	ToWorker   string
+}
+
+func (e StreamingDigestMismatchError) Error() string {
+	from := "the web node"
+	if e.FromWorker != "" {
+		from = "worker " + e.FromWorker
+	}
+
+	return fmt.Sprintf("volume %s streamed from %s to worker %s failed checksum validation", e.Handle, from, e.ToWorker)
+}
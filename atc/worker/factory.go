@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/cosign"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker/gardenruntime"
@@ -26,6 +27,18 @@ type DefaultFactory struct {
 	GardenRequestTimeout              time.Duration
 	BaggageclaimResponseHeaderTimeout time.Duration
 	HTTPRetryTimeout                  time.Duration
+
+	// StreamingZstdLevel is the level volumes are compressed at when streamed
+	// out with zstd, or 0 for baggageclaim's default.
+	StreamingZstdLevel int
+
+	// StreamingBandwidthLimit is the most bytes per second each volume is
+	// streamed from one worker to another at, or 0 for no limit.
+	StreamingBandwidthLimit int
+
+	// ImageVerifier verifies the signatures of the images containers are
+	// created with, or is nil if they aren't verified.
+	ImageVerifier cosign.Verifier
 }
 
 func (f DefaultFactory) NewWorker(logger lager.Logger, dbWorker db.Worker) runtime.Worker {
@@ -50,7 +63,7 @@ func (f DefaultFactory) newGardenWorker(logger lager.Logger, dbWorker db.Worker)
 			DisableKeepAlives:     true,
 			ResponseHeaderTimeout: f.BaggageclaimResponseHeaderTimeout,
 		},
-	))
+	), bclient.WithZstdLevel(f.StreamingZstdLevel), bclient.WithStreamBandwidthLimit(f.StreamingBandwidthLimit))
 
 	return gardenruntime.NewWorker(
 		dbWorker,
@@ -58,5 +71,6 @@ func (f DefaultFactory) newGardenWorker(logger lager.Logger, dbWorker db.Worker)
 		bcClient,
 		f.DB.ToGardenRuntimeDB(),
 		f.Streamer,
+		f.ImageVerifier,
 	)
 }
@@ -2,6 +2,7 @@ package gardenruntime
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -15,8 +16,53 @@ import (
 
 const userPropertyName = "user"
 
+// entrypointPropertyName, cmdPropertyName and workingDirPropertyName hold the
+// image's defaults for processes which don't specify their own path or dir.
+// exposedPortsPropertyName records the ports the image expects to listen on.
+const (
+	entrypointPropertyName   = "concourse:entrypoint"
+	cmdPropertyName          = "concourse:cmd"
+	workingDirPropertyName   = "concourse:working-dir"
+	exposedPortsPropertyName = "concourse:exposed-ports"
+)
+
 const exitStatusPropertyName = "concourse:exit-status"
 
+// exitStatusProperty names the property recording the exit status of the
+// process with the given ID, so that processes sharing a container (e.g. a
+// task and its sidecars) don't clobber each other's status.
+func exitStatusProperty(processID string) string {
+	return exitStatusPropertyName + ":" + processID
+}
+
+// hermeticPropertyName marks a container to be created without networking.
+// Only the containerd runtime honours it.
+const hermeticPropertyName = "concourse:hermetic"
+
+// teamPropertyName and pipelinePropertyName name the team and pipeline a
+// container belongs to, so that the worker can choose its network. Only the
+// containerd runtime honours them.
+const (
+	teamPropertyName     = "concourse:team"
+	pipelinePropertyName = "concourse:pipeline"
+)
+
+// tmpfsPropertyName and shmSizePropertyName request tmpfs mounts and the size
+// of /dev/shm. Only the containerd runtime honours them.
+const (
+	tmpfsPropertyName   = "concourse:tmpfs"
+	shmSizePropertyName = "concourse:shm-size"
+)
+
+// gpusPropertyName requests GPUs to be mounted into the container. Only the
+// containerd runtime honours it.
+const gpusPropertyName = "concourse:gpus"
+
+type tmpfsProperty struct {
+	Path string `json:"path"`
+	Size uint64 `json:"size,omitempty"`
+}
+
 type Container struct {
 	DBContainer_    db.CreatedContainer
 	GardenContainer gclient.Container
@@ -57,7 +103,11 @@ func (c Container) Run(_ context.Context, spec runtime.ProcessSpec, io runtime.P
 	// ctx to stream the process output. Since we send a SIGTERM on ctx
 	// cancellation, using the same ctx for streaming results in those logs not
 	// showing up.
-	process, err := c.GardenContainer.Run(context.Background(), toGardenProcessSpec(spec, properties), toGardenProcessIO(io))
+	gardenSpec, err := toGardenProcessSpec(spec, properties)
+	if err != nil {
+		return nil, err
+	}
+	process, err := c.GardenContainer.Run(context.Background(), gardenSpec, toGardenProcessIO(io))
 	if err != nil {
 		var exeNotFound garden.ExecutableNotFoundError
 		if errors.As(err, &exeNotFound) {
@@ -71,7 +121,7 @@ func (c Container) Run(_ context.Context, spec runtime.ProcessSpec, io runtime.P
 
 func (c Container) Attach(_ context.Context, id string, io runtime.ProcessIO) (runtime.Process, error) {
 	properties, _ := c.GardenContainer.Properties()
-	statusStr, ok := properties[exitStatusPropertyName]
+	statusStr, ok := properties[exitStatusProperty(id)]
 	if ok {
 		if status, err := strconv.Atoi(statusStr); err == nil {
 			return ExitedProcess{id: id, Result: runtime.ProcessResult{ExitStatus: status}}, nil
@@ -98,11 +148,23 @@ func (c Container) Properties() (map[string]string, error) {
 	return c.GardenContainer.Properties()
 }
 
-func toGardenProcessSpec(spec runtime.ProcessSpec, properties garden.Properties) garden.ProcessSpec {
+func toGardenProcessSpec(spec runtime.ProcessSpec, properties garden.Properties) (garden.ProcessSpec, error) {
 	user := spec.User
 	if user == "" {
 		user = properties[userPropertyName]
 	}
+	dir := spec.Dir
+	if dir == "" {
+		dir = properties[workingDirPropertyName]
+	}
+	path, args := spec.Path, spec.Args
+	if path == "" {
+		var err error
+		path, args, err = imageCommand(args, properties)
+		if err != nil {
+			return garden.ProcessSpec{}, err
+		}
+	}
 	var tty *garden.TTYSpec
 	if spec.TTY != nil {
 		spec := toGardenTTYSpec(*spec.TTY)
@@ -110,13 +172,43 @@ func toGardenProcessSpec(spec runtime.ProcessSpec, properties garden.Properties)
 	}
 	return garden.ProcessSpec{
 		ID:   spec.ID,
-		Path: spec.Path,
-		Args: spec.Args,
+		Path: path,
+		Args: args,
 		Env:  spec.Env,
-		Dir:  spec.Dir,
+		Dir:  dir,
 		User: user,
 		TTY:  tty,
+	}, nil
+}
+
+// imageCommand builds the command to run from the image's entrypoint and
+// cmd, for a process that doesn't specify a path. As with `docker run`, the
+// process's args replace the cmd.
+func imageCommand(args []string, properties garden.Properties) (string, []string, error) {
+	var entrypoint, cmd []string
+	if value, ok := properties[entrypointPropertyName]; ok {
+		if err := json.Unmarshal([]byte(value), &entrypoint); err != nil {
+			return "", nil, fmt.Errorf("malformed image entrypoint: %w", err)
+		}
+	}
+	if value, ok := properties[cmdPropertyName]; ok {
+		if err := json.Unmarshal([]byte(value), &cmd); err != nil {
+			return "", nil, fmt.Errorf("malformed image cmd: %w", err)
+		}
+	}
+
+	if len(args) == 0 {
+		args = cmd
 	}
+
+	command := append(append([]string{}, entrypoint...), args...)
+	if len(command) == 0 {
+		return "", nil, runtime.ExecutableNotFoundError{
+			Message: "no path to executable to run, and the image has no entrypoint or cmd",
+		}
+	}
+
+	return command[0], command[1:], nil
 }
 
 func toGardenTTYSpec(tty runtime.TTYSpec) garden.TTYSpec {
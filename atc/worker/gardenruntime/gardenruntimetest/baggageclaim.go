@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
@@ -15,6 +16,42 @@ import (
 
 type Baggageclaim struct {
 	Volumes []*Volume
+
+	// RegistryImages is the content of the images volumes can be pulled
+	// from, keyed by digest.
+	RegistryImages map[string]runtimetest.VolumeContent
+
+	// Persisted is the object store volumes are persisted to and hydrated
+	// from. If it's nil, persistence is unsupported.
+	Persisted *PersistedVolumes
+}
+
+// PersistedVolumes is an object store of volume content, which may be shared
+// by the baggageclaims of several workers.
+type PersistedVolumes struct {
+	lock    sync.Mutex
+	content map[string]runtimetest.VolumeContent
+}
+
+func NewPersistedVolumes() *PersistedVolumes {
+	return &PersistedVolumes{content: map[string]runtimetest.VolumeContent{}}
+}
+
+func (p *PersistedVolumes) Get(key string) (runtimetest.VolumeContent, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	content, found := p.content[key]
+	return content, found
+}
+
+func (p *PersistedVolumes) Put(key string, content runtimetest.VolumeContent) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	copied := make(runtimetest.VolumeContent, len(content))
+	for path, file := range content {
+		copied[path] = file
+	}
+	p.content[key] = copied
 }
 
 func (b *Baggageclaim) FindVolume(handle string) (*Volume, int, bool) {
@@ -37,6 +74,7 @@ func (b *Baggageclaim) FilteredVolumes(pred func(*Volume) bool) []*Volume {
 }
 
 func (b *Baggageclaim) AddVolume(volume *Volume) *Volume {
+	volume.baggageclaim = b
 	_, i, ok := b.FindVolume(volume.handle)
 	if ok {
 		b.Volumes[i] = volume
@@ -47,8 +85,25 @@ func (b *Baggageclaim) AddVolume(volume *Volume) *Volume {
 }
 
 func (b *Baggageclaim) CreateVolume(_ lager.Logger, handle string, spec baggageclaim.VolumeSpec) (baggageclaim.Volume, error) {
-	volume := b.AddVolume(NewVolume(handle).WithSpec(spec))
-	return volume, nil
+	volume := NewVolume(handle).WithSpec(spec)
+	if image, ok := spec.Strategy.(baggageclaim.RegistryImageStrategy); ok {
+		content, found := b.RegistryImages[image.Digest]
+		if !found {
+			return nil, fmt.Errorf("image %s@%s not found", image.Repository, image.Digest)
+		}
+		volume = volume.WithContent(content)
+	}
+	if hydrate, ok := spec.Strategy.(baggageclaim.HydrateStrategy); ok {
+		if b.Persisted == nil {
+			return nil, baggageclaim.ErrPersistenceUnsupported
+		}
+		content, found := b.Persisted.Get(hydrate.Key)
+		if !found {
+			return nil, baggageclaim.ErrVolumeNotPersisted
+		}
+		volume = volume.WithContent(content)
+	}
+	return b.AddVolume(volume), nil
 }
 
 func (b *Baggageclaim) ListVolumes(_ lager.Logger, filter baggageclaim.VolumeProperties) (baggageclaim.Volumes, error) {
@@ -81,6 +136,10 @@ func (b *Baggageclaim) DestroyVolume(_ lager.Logger, handle string) error {
 	return nil
 }
 
+func (b *Baggageclaim) Stats(_ lager.Logger) (baggageclaim.Stats, error) {
+	return baggageclaim.Stats{}, nil
+}
+
 func matchesFilter(properties map[string]string, filter map[string]string) bool {
 	for k, v := range filter {
 		if properties[k] != v {
@@ -107,6 +166,8 @@ type Volume struct {
 	path   string
 	Spec   baggageclaim.VolumeSpec
 
+	baggageclaim *Baggageclaim
+
 	Content runtimetest.VolumeContent
 }
 
@@ -171,6 +232,56 @@ func (v Volume) StreamP2pOut(ctx context.Context, path string, streamInURL strin
 	return err
 }
 
+func (v Volume) StreamManifest(ctx context.Context) (io.ReadCloser, error) {
+	return v.Content.StreamManifest(ctx)
+}
+
+func (v Volume) StreamDeltaOut(ctx context.Context, manifest io.Reader, encoding baggageclaim.Encoding) (io.ReadCloser, error) {
+	return v.Content.StreamDeltaOut(ctx, manifest, encoding)
+}
+
+func (v Volume) StreamDeltaIn(ctx context.Context, base string, encoding baggageclaim.Encoding, delta io.Reader) error {
+	if v.baggageclaim == nil {
+		return fmt.Errorf("volume %s is not on a baggageclaim", v.handle)
+	}
+
+	baseVolume, _, found := v.baggageclaim.FindVolume(base)
+	if !found {
+		return baggageclaim.ErrVolumeNotFound
+	}
+
+	return v.Content.StreamDeltaIn(ctx, baseVolume.Content, encoding, delta)
+}
+
+func (v Volume) Persist(ctx context.Context, key string) error {
+	if v.baggageclaim == nil || v.baggageclaim.Persisted == nil {
+		return baggageclaim.ErrPersistenceUnsupported
+	}
+	v.baggageclaim.Persisted.Put(key, v.Content)
+	return nil
+}
+
+func (v Volume) Quota(ctx context.Context) (baggageclaim.VolumeQuota, error) {
+	used, err := v.Size(ctx)
+	if err != nil {
+		return baggageclaim.VolumeQuota{}, err
+	}
+
+	return baggageclaim.VolumeQuota{
+		Limit: v.Spec.Quota,
+		Used:  used,
+	}, nil
+}
+
+func (v Volume) Size(ctx context.Context) (uint64, error) {
+	var size uint64
+	for _, file := range v.Content {
+		size += uint64(len(file.Data))
+	}
+
+	return size, nil
+}
+
 func (v Volume) Destroy() error {
 	return nil
 }
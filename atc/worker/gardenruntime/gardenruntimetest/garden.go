@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -296,6 +297,12 @@ func (p *Process) Run(ctx context.Context) {
 		}
 	case "noop":
 		p.exitCode = 0
+	case "exit":
+		exitCode, err := strconv.Atoi(p.Spec.Args[0])
+		if err != nil {
+			panic(fmt.Sprintf("invalid exit code: %v", err))
+		}
+		p.exitCode = exitCode
 	default:
 		panic(fmt.Sprintf("unsupported program %s", p.Spec.Path))
 	}
@@ -5,9 +5,11 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/cosign"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbtest"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/gardenruntime"
 	"github.com/concourse/concourse/atc/worker/workertest"
@@ -30,6 +32,9 @@ type Worker struct {
 	WorkerName       string
 	Containers       []*Container
 	Volumes          []*Volume
+	RegistryImages   map[string]runtimetest.VolumeContent
+	PersistedVolumes *PersistedVolumes
+	ImageVerifier    cosign.Verifier
 	SetupFuncs       []SetupFunc
 	WorkerSetupFuncs []WorkerSetupFunc
 }
@@ -59,14 +64,21 @@ func (w *Worker) Setup(s *workertest.Scenario) {
 }
 
 func (w Worker) Build(db worker.DB, dbWorker db.Worker) runtime.Worker {
+	bc := &Baggageclaim{Volumes: w.Volumes, RegistryImages: w.RegistryImages, Persisted: w.PersistedVolumes}
+	for _, v := range bc.Volumes {
+		v.baggageclaim = bc
+	}
 	return gardenruntime.NewWorker(
 		dbWorker,
 		&Garden{ContainerList: w.Containers},
-		&Baggageclaim{Volumes: w.Volumes},
+		bc,
 		db.ToGardenRuntimeDB(),
 		worker.NewStreamer(db.ResourceCacheFactory, compression.NewGzipCompression(), worker.P2PConfig{
 			Enabled: false,
-		}),
+		}, worker.DeltaConfig{
+			Enabled: false,
+		}, worker.BandwidthConfig{}),
+		w.ImageVerifier,
 	)
 }
 
@@ -86,6 +98,32 @@ func (w Worker) WithBaggageclaimVolumes(volumes ...*Volume) *Worker {
 	return &w2
 }
 
+// WithRegistryImage makes volumes pulled from registries with the digest
+// have the content, as though the image had been pulled.
+func (w Worker) WithRegistryImage(digest string, content runtimetest.VolumeContent) *Worker {
+	w2 := w
+	w2.RegistryImages = make(map[string]runtimetest.VolumeContent, len(w.RegistryImages)+1)
+	for d, c := range w.RegistryImages {
+		w2.RegistryImages[d] = c
+	}
+	w2.RegistryImages[digest] = content
+	return &w2
+}
+
+// WithPersistedVolumes makes volumes on the worker persist to and hydrate
+// from the store.
+func (w Worker) WithPersistedVolumes(store *PersistedVolumes) *Worker {
+	w2 := w
+	w2.PersistedVolumes = store
+	return &w2
+}
+
+func (w Worker) WithImageVerifier(verifier cosign.Verifier) *Worker {
+	w2 := w
+	w2.ImageVerifier = verifier
+	return &w2
+}
+
 func (w Worker) WithMutableSetup(setup ...SetupFunc) *Worker {
 	w2 := w
 	w2.SetupFuncs = make([]SetupFunc, len(w.SetupFuncs)+len(setup))
@@ -206,6 +244,33 @@ func (w Worker) WithTags(tags ...string) *Worker {
 	})
 }
 
+func (w Worker) WithGPUs(gpus int) *Worker {
+	return w.WithWorkerSetup(func(w *atc.Worker) {
+		w.GPUs = gpus
+	})
+}
+
+func (w Worker) WithCapacity(cpu, memory uint64) *Worker {
+	return w.WithWorkerSetup(func(w *atc.Worker) {
+		w.CPUCapacity = cpu
+		w.MemoryCapacity = memory
+	})
+}
+
+func (w Worker) WithStats(stats atc.WorkerStats) *Worker {
+	return w.WithWorkerSetup(func(w *atc.Worker) {
+		w.Stats = &stats
+	})
+}
+
+func (w Worker) WithReserved(cpu, memory uint64) *Worker {
+	return w.WithSetup(func(s *workertest.Scenario) {
+		reserved, err := s.DB.Worker(w.Name()).Reserve(db.WorkerResources{CPU: cpu, Memory: memory})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reserved).To(BeTrue())
+	})
+}
+
 func (w Worker) WithPlatform(platform string) *Worker {
 	return w.WithWorkerSetup(func(w *atc.Worker) {
 		w.Platform = platform
@@ -7,9 +7,11 @@ import (
 	"io"
 	"net/url"
 	"path"
+	"sort"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/cosign"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/worker/baggageclaim"
@@ -26,9 +28,37 @@ type FetchedImage struct {
 	Privileged bool
 }
 
+// ImageMetadata is the configuration of an image that isn't part of its
+// rootfs. Image resources write it to metadata.json next to the rootfs.
 type ImageMetadata struct {
 	Env  []string `json:"env"`
 	User string   `json:"user"`
+
+	// Entrypoint and Cmd are run when a process doesn't specify a path to
+	// run, with the process's args, if any, in place of Cmd.
+	Entrypoint []string `json:"entrypoint,omitempty"`
+	Cmd        []string `json:"cmd,omitempty"`
+
+	// WorkingDir is the directory processes which don't specify one run in.
+	WorkingDir string `json:"working_dir,omitempty"`
+
+	// ExposedPorts are the ports, e.g. "8080/tcp", the image expects to
+	// listen on. They're recorded on the container, but not published.
+	ExposedPorts []string `json:"exposed_ports,omitempty"`
+}
+
+// ociImageConfig is the part of an OCI image config (or a Docker image
+// config) that ImageMetadata is made up of. Image resources may write the
+// image's config to metadata.json as is, rather than converting it.
+type ociImageConfig struct {
+	Config struct {
+		Env          []string            `json:"Env"`
+		User         string              `json:"User"`
+		Entrypoint   []string            `json:"Entrypoint"`
+		Cmd          []string            `json:"Cmd"`
+		WorkingDir   string              `json:"WorkingDir"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+	} `json:"config"`
 }
 
 func (worker *Worker) fetchImageForContainer(
@@ -36,8 +66,14 @@ func (worker *Worker) fetchImageForContainer(
 	logger lager.Logger,
 	imageSpec runtime.ImageSpec,
 	teamID int,
+	teamName string,
 	container db.CreatingContainer,
 ) (FetchedImage, error) {
+	err := worker.verifyImage(ctx, logger, imageSpec, teamName)
+	if err != nil {
+		return FetchedImage{}, err
+	}
+
 	if imageSpec.ImageArtifact != nil {
 		volume, ok, err := worker.findVolumeForArtifact(logger, teamID, imageSpec.ImageArtifact)
 		if err != nil {
@@ -62,9 +98,55 @@ func (worker *Worker) fetchImageForContainer(
 		return FetchedImage{}, ErrUnsupportedResourceType
 	}
 
+	if imageSpec.RegistryImage != nil {
+		return worker.imageFromRegistry(ctx, logger, *imageSpec.RegistryImage, imageSpec.Privileged, teamID, container)
+	}
+
 	return FetchedImage{URL: imageSpec.ImageURL}, nil
 }
 
+// verifyImage checks that the image is signed, if the team's images must be,
+// before anything is done with it. Images provided by the worker itself, i.e.
+// base resource types and the default rootfs, are trusted.
+func (worker *Worker) verifyImage(
+	ctx context.Context,
+	logger lager.Logger,
+	imageSpec runtime.ImageSpec,
+	teamName string,
+) error {
+	if worker.imageVerifier == nil || !worker.imageVerifier.Required(teamName) {
+		return nil
+	}
+
+	var image *atc.RegistryImage
+	switch {
+	case imageSpec.ImageArtifact != nil:
+		image = imageSpec.ImageArtifactSource
+	case imageSpec.ResourceType != "":
+		return nil
+	case imageSpec.RegistryImage != nil:
+		image = imageSpec.RegistryImage
+	case imageSpec.ImageURL == "":
+		return nil
+	}
+
+	if image == nil {
+		logger.Info("unverifiable-image")
+		return cosign.ErrUnverifiableImage
+	}
+
+	err := worker.imageVerifier.Verify(ctx, teamName, *image)
+	if err != nil {
+		logger.Error("failed-to-verify-image-signature", err, lager.Data{
+			"repository": image.Repository,
+			"digest":     image.Digest,
+		})
+		return err
+	}
+
+	return nil
+}
+
 func (worker *Worker) imageProvidedByPreviousStepOnSameWorker(
 	ctx context.Context,
 	logger lager.Logger,
@@ -76,6 +158,7 @@ func (worker *Worker) imageProvidedByPreviousStepOnSameWorker(
 	imageVolume, err := worker.findOrCreateCOWVolumeForContainer(
 		logger,
 		privileged,
+		0,
 		container,
 		artifactVolume,
 		teamID,
@@ -117,27 +200,35 @@ func (worker *Worker) imageProvidedByPreviousStepOnDifferentWorker(
 	container db.CreatingContainer,
 	artifact runtime.Artifact,
 ) (FetchedImage, error) {
-	streamedVolume, err := worker.findOrCreateVolumeForStreaming(
-		logger,
-		privileged,
-		container,
-		teamID,
-		"/",
-	)
+	streamedVolume, hydrated, err := worker.hydrateStreamedArtifact(logger, teamID, artifact)
 	if err != nil {
-		logger.Error("failed-to-create-image-artifact-replicated-volume", err)
 		return FetchedImage{}, err
 	}
 
-	if err := worker.streamer.Stream(ctx, artifact, streamedVolume); err != nil {
-		logger.Error("failed-to-stream-image-artifact", err)
-		return FetchedImage{}, err
+	if !hydrated {
+		streamedVolume, err = worker.findOrCreateVolumeForStreaming(
+			logger,
+			privileged,
+			container,
+			teamID,
+			"/",
+		)
+		if err != nil {
+			logger.Error("failed-to-create-image-artifact-replicated-volume", err)
+			return FetchedImage{}, err
+		}
+
+		if err := worker.streamer.Stream(ctx, artifact, streamedVolume); err != nil {
+			logger.Error("failed-to-stream-image-artifact", err)
+			return FetchedImage{}, err
+		}
+		logger.Debug("streamed-non-local-image-volume")
 	}
-	logger.Debug("streamed-non-local-image-volume")
 
 	imageVolume, err := worker.findOrCreateCOWVolumeForContainer(
 		logger,
 		privileged,
+		0,
 		container,
 		streamedVolume,
 		teamID,
@@ -195,6 +286,7 @@ func (worker *Worker) imageFromBaseResourceType(
 	cowVolume, err := worker.findOrCreateCOWVolumeForContainer(
 		logger,
 		resourceType.Privileged,
+		0,
 		container,
 		importVolume,
 		teamID,
@@ -217,15 +309,119 @@ func (worker *Worker) imageFromBaseResourceType(
 	}, nil
 }
 
+// imageFromRegistry has baggageclaim pull the image straight from its
+// registry into a volume for the container, laid out like the images fetched
+// by image resources.
+func (worker *Worker) imageFromRegistry(
+	ctx context.Context,
+	logger lager.Logger,
+	image atc.RegistryImage,
+	privileged bool,
+	teamID int,
+	container db.CreatingContainer,
+) (FetchedImage, error) {
+	logger = logger.Session("image-from-registry", lager.Data{
+		"repository": image.Repository,
+		"digest":     image.Digest,
+	})
+
+	imageVolume, err := worker.findOrCreateVolumeForContainer(
+		logger,
+		baggageclaim.VolumeSpec{
+			Strategy: baggageclaim.RegistryImageStrategy{
+				Repository: image.Repository,
+				Digest:     image.Digest,
+				Username:   image.Username,
+				Password:   image.Password,
+				Insecure:   image.Insecure,
+			},
+			Privileged: privileged,
+		},
+		container,
+		teamID,
+		"/",
+	)
+	if err != nil {
+		logger.Error("failed-to-pull-image", err)
+		return FetchedImage{}, fmt.Errorf("pull image %s@%s: %w", image.Repository, image.Digest, err)
+	}
+
+	imageMetadataReader, err := worker.streamer.StreamFile(ctx, imageVolume, ImageMetadataFile)
+	if err != nil {
+		logger.Error("failed-to-stream-metadata-file", err)
+		return FetchedImage{}, fmt.Errorf("stream metadata: %w", err)
+	}
+
+	metadata, err := loadMetadata(imageMetadataReader)
+	if err != nil {
+		return FetchedImage{}, fmt.Errorf("load metadata: %w", err)
+	}
+
+	imageURL := url.URL{
+		Scheme: RawRootFSScheme,
+		Path:   path.Join(imageVolume.Path(), "rootfs"),
+	}
+
+	return FetchedImage{
+		Metadata:   metadata,
+		URL:        imageURL.String(),
+		Privileged: privileged,
+	}, nil
+}
+
 func loadMetadata(tarReader io.ReadCloser) (ImageMetadata, error) {
 	defer tarReader.Close()
 
+	var payload json.RawMessage
+	if err := json.NewDecoder(tarReader).Decode(&payload); err != nil {
+		return ImageMetadata{}, MalformedMetadataError{
+			UnmarshalError: err,
+		}
+	}
+
 	var imageMetadata ImageMetadata
-	if err := json.NewDecoder(tarReader).Decode(&imageMetadata); err != nil {
+	if err := json.Unmarshal(payload, &imageMetadata); err != nil {
+		return ImageMetadata{}, MalformedMetadataError{
+			UnmarshalError: err,
+		}
+	}
+
+	var imageConfig ociImageConfig
+	if err := json.Unmarshal(payload, &imageConfig); err != nil {
 		return ImageMetadata{}, MalformedMetadataError{
 			UnmarshalError: err,
 		}
 	}
 
-	return imageMetadata, nil
+	return mergeImageConfig(imageMetadata, imageConfig), nil
+}
+
+// mergeImageConfig fills in whatever the metadata leaves out from the OCI
+// image config, if the metadata is one.
+func mergeImageConfig(metadata ImageMetadata, imageConfig ociImageConfig) ImageMetadata {
+	config := imageConfig.Config
+
+	if metadata.Env == nil {
+		metadata.Env = config.Env
+	}
+	if metadata.User == "" {
+		metadata.User = config.User
+	}
+	if metadata.Entrypoint == nil {
+		metadata.Entrypoint = config.Entrypoint
+	}
+	if metadata.Cmd == nil {
+		metadata.Cmd = config.Cmd
+	}
+	if metadata.WorkingDir == "" {
+		metadata.WorkingDir = config.WorkingDir
+	}
+	if metadata.ExposedPorts == nil && len(config.ExposedPorts) > 0 {
+		for port := range config.ExposedPorts {
+			metadata.ExposedPorts = append(metadata.ExposedPorts, port)
+		}
+		sort.Strings(metadata.ExposedPorts)
+	}
+
+	return metadata
 }
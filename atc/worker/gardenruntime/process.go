@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/atc/runtime"
@@ -11,6 +12,10 @@ import (
 	"github.com/hashicorp/go-multierror"
 )
 
+// StopGracePeriod is how long a process is given to exit after being
+// signalled to stop before it is forcibly killed.
+var StopGracePeriod = 10 * time.Second
+
 type Process struct {
 	GardenContainer gclient.Container
 	GardenProcess   garden.Process
@@ -35,14 +40,23 @@ func (p Process) Wait(ctx context.Context) (runtime.ProcessResult, error) {
 	select {
 	case <-ctx.Done():
 		err := p.GardenContainer.Stop(false)
-		// TODO: forcibly stop after timeout?
-		<-waitResult
+
+		select {
+		case <-waitResult:
+		case <-time.After(StopGracePeriod):
+			// the process is ignoring the signal, so kill it rather than leaving
+			// it (and whatever is waiting on it) hanging around indefinitely
+			if killErr := p.GardenContainer.Stop(true); killErr != nil {
+				err = multierror.Append(err, killErr)
+			}
+		}
+
 		return runtime.ProcessResult{}, multierror.Append(ctx.Err(), err)
 	case r := <-waitResult:
 		if r.err != nil {
 			return runtime.ProcessResult{}, fmt.Errorf("wait for process completion: %w", r.err)
 		}
-		p.GardenContainer.SetProperty(exitStatusPropertyName, strconv.Itoa(r.exitStatus))
+		p.GardenContainer.SetProperty(exitStatusProperty(p.ID()), strconv.Itoa(r.exitStatus))
 		return runtime.ProcessResult{ExitStatus: r.exitStatus}, nil
 	}
 }
@@ -2,11 +2,15 @@ package gardenruntime
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"path/filepath"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
@@ -49,6 +53,8 @@ func (v Volume) InitializeResourceCache(logger lager.Logger, cache db.ResourceCa
 		logger.Error("failed-to-initialize-resource-cache", err)
 		return err
 	}
+	v.recordResourceCacheSize(logger)
+	v.persistResourceCache(logger, cache)
 	return nil
 }
 
@@ -61,9 +67,66 @@ func (v Volume) InitializeStreamedResourceCache(logger lager.Logger, cache db.Re
 		logger.Error("failed-to-initialize-resource-cache", err)
 		return err
 	}
+	v.recordResourceCacheSize(logger)
 	return nil
 }
 
+// persistResourceCache stores the resource cache the volume was initialized
+// as in the object store of the worker's baggageclaim, so that other workers
+// can hydrate it rather than fetching or streaming it. The cache is usable
+// without it, so it's done in the background and failing isn't fatal.
+func (v Volume) persistResourceCache(logger lager.Logger, cache db.ResourceCache) {
+	if !atc.EnablePersistentResourceCaches {
+		return
+	}
+
+	logger = logger.Session("persist-resource-cache", lager.Data{
+		"volume":            v.Handle(),
+		"resource-cache-id": cache.ID(),
+	})
+
+	go func() {
+		err := v.bcVolume.Persist(lagerctx.NewContext(context.Background(), logger), resourceCacheKey(cache.ID()))
+		if err != nil {
+			if errors.Is(err, baggageclaim.ErrPersistenceUnsupported) {
+				logger.Debug("persistence-unsupported")
+				return
+			}
+			logger.Error("failed-to-persist-resource-cache", err)
+			return
+		}
+		logger.Debug("persisted")
+	}()
+}
+
+// resourceCacheKey is the key a resource cache is persisted under. A
+// resource cache is the same wherever it was fetched, so it's keyed only by
+// its ID.
+func resourceCacheKey(resourceCacheID int) string {
+	return fmt.Sprintf("resource-caches/%d", resourceCacheID)
+}
+
+// recordResourceCacheSize records the size of the resource cache the volume
+// was initialized as, which is what the caches on the worker are limited by.
+// The cache isn't written to after it's initialized, so it only has to be
+// measured once. Failing to measure it isn't fatal: the cache just counts as
+// empty.
+func (v Volume) recordResourceCacheSize(logger lager.Logger) {
+	if atc.WorkerResourceCacheSizeLimit == 0 {
+		return
+	}
+
+	size, err := v.bcVolume.Size(lagerctx.NewContext(context.Background(), logger))
+	if err != nil {
+		logger.Error("failed-to-get-resource-cache-size", err)
+		return
+	}
+
+	if err := v.dbVolume.SetResourceCacheSize(size); err != nil {
+		logger.Error("failed-to-set-resource-cache-size", err)
+	}
+}
+
 func (v Volume) InitializeTaskCache(logger lager.Logger, jobID int, stepName string, path string, privileged bool) error {
 	path = filepath.Clean(path)
 
@@ -113,6 +176,78 @@ func (v Volume) StreamP2POut(ctx context.Context, path string, destURL string, c
 
 var _ runtime.P2PVolume = Volume{}
 
+func (v Volume) FindDeltaBase(ctx context.Context, cache db.ResourceCache) (runtime.DeltaVolume, bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("find-delta-base")
+
+	dbVolume, found, err := v.worker.db.VolumeRepo.FindResourceConfigVolume(v.worker.Name(), cache)
+	if err != nil {
+		logger.Error("failed-to-find-resource-config-volume", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	bcVolume, found, err := v.worker.bcClient.LookupVolume(logger, dbVolume.Handle())
+	if err != nil {
+		logger.Error("failed-to-lookup-volume-in-baggageclaim", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	// the base's contents are copied as they are, so their ownership is only
+	// right if both volumes are namespaced the same way
+	privileged, err := v.bcVolume.GetPrivileged()
+	if err != nil {
+		return nil, false, err
+	}
+
+	basePrivileged, err := bcVolume.GetPrivileged()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if privileged != basePrivileged {
+		return nil, false, nil
+	}
+
+	return v.worker.newVolume(bcVolume, dbVolume), true, nil
+}
+
+func (v Volume) StreamManifest(ctx context.Context) (io.ReadCloser, error) {
+	return v.bcVolume.StreamManifest(ctx)
+}
+
+func (v Volume) StreamDeltaOut(ctx context.Context, manifest io.Reader, compression compression.Compression) (io.ReadCloser, error) {
+	return v.bcVolume.StreamDeltaOut(ctx, manifest, compression.Encoding())
+}
+
+func (v Volume) StreamDeltaIn(ctx context.Context, base runtime.DeltaVolume, compression compression.Compression, delta io.Reader) error {
+	return v.bcVolume.StreamDeltaIn(ctx, base.Handle(), compression.Encoding(), delta)
+}
+
+var _ runtime.DeltaVolume = Volume{}
+
+func (v Volume) Quota(ctx context.Context) (uint64, uint64, error) {
+	quota, err := v.bcVolume.Quota(ctx)
+	if err != nil {
+		// volumes on workers which can't enforce quotas aren't limited
+		if errors.Is(err, baggageclaim.ErrQuotaUnsupported) {
+			return 0, 0, nil
+		}
+
+		return 0, 0, err
+	}
+
+	return quota.Limit, quota.Used, nil
+}
+
+var _ runtime.QuotaVolume = Volume{}
+
 func (worker *Worker) newVolume(bcVolume baggageclaim.Volume, dbVolume db.CreatedVolume) Volume {
 	return Volume{bcVolume: bcVolume, dbVolume: dbVolume, worker: worker}
 }
@@ -171,6 +306,118 @@ func (worker *Worker) CreateVolumeForArtifact(logger lager.Logger, teamID int) (
 	return worker.newVolume(bcVolume, createdVolume), workerArtifact, nil
 }
 
+func (worker *Worker) HydrateResourceCache(logger lager.Logger, teamID int, cache db.ResourceCache) (runtime.Volume, bool, error) {
+	volume, hydrated, err := worker.hydrateResourceCache(logger, teamID, cache)
+	if err != nil || !hydrated {
+		return nil, false, err
+	}
+	return volume, true, nil
+}
+
+// hydrateResourceCache creates a volume from the persisted copy of the
+// resource cache and initializes it as the resource cache on this worker.
+// Failing to hydrate the volume isn't fatal, since the cache can always be
+// streamed or fetched instead.
+func (worker *Worker) hydrateResourceCache(logger lager.Logger, teamID int, cache db.ResourceCache) (Volume, bool, error) {
+	if !atc.EnablePersistentResourceCaches {
+		return Volume{}, false, nil
+	}
+
+	logger = logger.Session("hydrate-resource-cache", lager.Data{"resource-cache-id": cache.ID()})
+
+	creatingVolume, err := worker.db.VolumeRepo.CreateVolume(teamID, worker.Name(), db.VolumeTypeResource)
+	if err != nil {
+		logger.Error("failed-to-create-volume-in-db", err)
+		return Volume{}, false, err
+	}
+
+	logger = logger.WithData(lager.Data{"volume": creatingVolume.Handle()})
+
+	bcVolume, err := worker.bcClient.CreateVolume(logger, creatingVolume.Handle(), baggageclaim.VolumeSpec{
+		Strategy: baggageclaim.HydrateStrategy{Key: resourceCacheKey(cache.ID())},
+	})
+	if err != nil {
+		if _, failedErr := creatingVolume.Failed(); failedErr != nil {
+			logger.Error("failed-to-mark-volume-as-failed", failedErr)
+		}
+
+		if errors.Is(err, baggageclaim.ErrVolumeNotPersisted) || errors.Is(err, baggageclaim.ErrPersistenceUnsupported) {
+			logger.Debug("resource-cache-not-persisted")
+		} else {
+			logger.Error("failed-to-hydrate-volume", err)
+		}
+		return Volume{}, false, nil
+	}
+
+	createdVolume, err := creatingVolume.Created()
+	if err != nil {
+		logger.Error("failed-to-mark-volume-as-created", err)
+		return Volume{}, false, err
+	}
+
+	if err := createdVolume.InitializeResourceCache(cache); err != nil {
+		logger.Error("failed-to-initialize-resource-cache", err)
+		return Volume{}, false, err
+	}
+
+	volume := worker.newVolume(bcVolume, createdVolume)
+	volume.recordResourceCacheSize(logger)
+
+	logger.Debug("hydrated")
+
+	return volume, true, nil
+}
+
+// hydrateStreamedArtifact hydrates the resource cache the artifact from
+// another worker was initialized as, if any, so that it needn't be streamed.
+func (worker *Worker) hydrateStreamedArtifact(logger lager.Logger, teamID int, artifact runtime.Artifact) (Volume, bool, error) {
+	if !atc.EnablePersistentResourceCaches {
+		return Volume{}, false, nil
+	}
+
+	srcVolume, ok := artifact.(runtime.Volume)
+	if !ok {
+		return Volume{}, false, nil
+	}
+
+	resourceCacheID := srcVolume.DBVolume().GetResourceCacheID()
+	if resourceCacheID == 0 {
+		return Volume{}, false, nil
+	}
+
+	cache, found, err := worker.db.ResourceCacheFactory.FindResourceCacheByID(resourceCacheID)
+	if err != nil {
+		logger.Error("failed-to-find-resource-cache", err)
+		return Volume{}, false, err
+	}
+	if !found {
+		return Volume{}, false, nil
+	}
+
+	return worker.hydrateResourceCache(logger, teamID, cache)
+}
+
+func (worker *Worker) PrewarmBaseResourceType(logger lager.Logger, resourceTypeName string) error {
+	for _, t := range worker.dbWorker.ResourceTypes() {
+		if t.Type != resourceTypeName {
+			continue
+		}
+
+		_, err := worker.findOrCreateVolumeForBaseResourceType(
+			logger,
+			baggageclaim.VolumeSpec{
+				Strategy:   baggageclaim.ImportStrategy{Path: t.Image},
+				Privileged: t.Privileged,
+			},
+			0,
+			resourceTypeName,
+		)
+		return err
+	}
+
+	return ErrUnsupportedResourceType
+}
+
 func (worker *Worker) findOrCreateVolumeForContainer(
 	logger lager.Logger,
 	volumeSpec baggageclaim.VolumeSpec,
@@ -214,6 +461,7 @@ func (worker *Worker) findOrCreateVolumeForStreaming(
 func (worker *Worker) findOrCreateCOWVolumeForContainer(
 	logger lager.Logger,
 	privileged bool,
+	quota uint64,
 	container db.CreatingContainer,
 	parent Volume,
 	teamID int,
@@ -224,6 +472,7 @@ func (worker *Worker) findOrCreateCOWVolumeForContainer(
 		baggageclaim.VolumeSpec{
 			Strategy:   parent.COWStrategy(),
 			Privileged: privileged,
+			Quota:      quota,
 		},
 		func() (db.CreatingVolume, db.CreatedVolume, error) {
 			return worker.db.VolumeRepo.FindContainerVolume(teamID, worker.Name(), container, mountPath)
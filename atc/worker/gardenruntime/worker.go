@@ -2,16 +2,20 @@ package gardenruntime
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/cosign"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/metric"
@@ -30,6 +34,10 @@ type Streamer interface {
 type Worker struct {
 	streamer Streamer
 
+	// imageVerifier verifies the signatures of container images, or is nil
+	// if they aren't verified.
+	imageVerifier cosign.Verifier
+
 	dbWorker     db.Worker
 	gardenClient gclient.Client
 	bcClient     baggageclaim.Client
@@ -46,9 +54,10 @@ type DB struct {
 	LockFactory                   lock.LockFactory
 }
 
-func NewWorker(dbWorker db.Worker, gardenClient gclient.Client, bcClient baggageclaim.Client, db DB, streamer Streamer) *Worker {
+func NewWorker(dbWorker db.Worker, gardenClient gclient.Client, bcClient baggageclaim.Client, db DB, streamer Streamer, imageVerifier cosign.Verifier) *Worker {
 	return &Worker{
-		streamer: streamer,
+		streamer:      streamer,
+		imageVerifier: imageVerifier,
 
 		dbWorker:     dbWorker,
 		gardenClient: gardenClient,
@@ -175,6 +184,7 @@ func (worker *Worker) createGardenContainer(
 		logger,
 		containerSpec.ImageSpec,
 		containerSpec.TeamID,
+		containerSpec.TeamName,
 		creatingContainer,
 	)
 	if err != nil {
@@ -199,6 +209,43 @@ func (worker *Worker) createGardenContainer(
 
 	logger.Debug("creating-garden-container")
 
+	properties := garden.Properties{
+		userPropertyName: fetchedImage.Metadata.User,
+	}
+	if err := setImageProperties(properties, fetchedImage.Metadata); err != nil {
+		logger.Error("failed-to-encode-image-metadata", err)
+		markContainerAsFailed(logger, creatingContainer)
+		return nil, err
+	}
+	if containerSpec.Hermetic {
+		properties[hermeticPropertyName] = "true"
+	}
+	if containerSpec.TeamName != "" {
+		properties[teamPropertyName] = containerSpec.TeamName
+	}
+	if pipelineName := creatingContainer.Metadata().PipelineName; pipelineName != "" {
+		properties[pipelinePropertyName] = pipelineName
+	}
+
+	if len(containerSpec.Tmpfs) > 0 {
+		tmpfs, err := tmpfsPropertyValue(containerSpec)
+		if err != nil {
+			logger.Error("failed-to-encode-tmpfs-mounts", err)
+			markContainerAsFailed(logger, creatingContainer)
+			return nil, err
+		}
+
+		properties[tmpfsPropertyName] = tmpfs
+	}
+
+	if containerSpec.ShmSize > 0 {
+		properties[shmSizePropertyName] = strconv.FormatUint(containerSpec.ShmSize, 10)
+	}
+
+	if containerSpec.GPUs > 0 {
+		properties[gpusPropertyName] = strconv.Itoa(containerSpec.GPUs)
+	}
+
 	gardenContainer, err := worker.gardenClient.Create(
 		garden.ContainerSpec{
 			Handle:     creatingContainer.Handle(),
@@ -207,9 +254,7 @@ func (worker *Worker) createGardenContainer(
 			BindMounts: bindMounts,
 			Limits:     toGardenLimits(containerSpec.Limits),
 			Env:        worker.containerEnv(containerSpec, fetchedImage),
-			Properties: garden.Properties{
-				userPropertyName: fetchedImage.Metadata.User,
-			},
+			Properties: properties,
 		})
 	if err != nil {
 		logger.Error("failed-to-create-container-in-garden", err)
@@ -220,6 +265,51 @@ func (worker *Worker) createGardenContainer(
 	return gardenContainer, nil
 }
 
+// setImageProperties records the parts of the image's config that apply to
+// the processes run in the container, rather than to the container itself.
+func setImageProperties(properties garden.Properties, metadata ImageMetadata) error {
+	if len(metadata.Entrypoint) > 0 {
+		entrypoint, err := json.Marshal(metadata.Entrypoint)
+		if err != nil {
+			return err
+		}
+		properties[entrypointPropertyName] = string(entrypoint)
+	}
+	if len(metadata.Cmd) > 0 {
+		cmd, err := json.Marshal(metadata.Cmd)
+		if err != nil {
+			return err
+		}
+		properties[cmdPropertyName] = string(cmd)
+	}
+	if metadata.WorkingDir != "" {
+		properties[workingDirPropertyName] = metadata.WorkingDir
+	}
+	if len(metadata.ExposedPorts) > 0 {
+		properties[exposedPortsPropertyName] = strings.Join(metadata.ExposedPorts, ",")
+	}
+	return nil
+}
+
+func tmpfsPropertyValue(containerSpec runtime.ContainerSpec) (string, error) {
+	mounts := make([]tmpfsProperty, len(containerSpec.Tmpfs))
+	for i, tmpfs := range containerSpec.Tmpfs {
+		mountPath := filepath.Clean(tmpfs.Path)
+		if !filepath.IsAbs(mountPath) {
+			mountPath = filepath.Join(containerSpec.Dir, mountPath)
+		}
+
+		mounts[i] = tmpfsProperty{Path: mountPath, Size: tmpfs.Size}
+	}
+
+	payload, err := json.Marshal(mounts)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
 func (worker *Worker) containerEnv(containerSpec runtime.ContainerSpec, fetchedImage FetchedImage) []string {
 	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
 
@@ -450,6 +540,7 @@ func (worker *Worker) cloneLocalInputVolumes(
 		cowVolume, err := worker.findOrCreateCOWVolumeForContainer(
 			logger,
 			privileged,
+			0,
 			container,
 			input.cowParent,
 			spec.TeamID,
@@ -489,6 +580,21 @@ func (worker *Worker) streamRemoteInputVolumes(
 		// capture loop vars so each goroutine gets its own copy
 		i, input := i, input
 
+		// if the input is a resource cache that was persisted, it can be
+		// hydrated from the persisted copy rather than streamed from the
+		// other worker.
+		hydratedVolume, hydrated, err := worker.hydrateStreamedArtifact(logger, spec.TeamID, input.volume)
+		if err != nil {
+			return nil, err
+		}
+		if hydrated {
+			mounts[i] = mountableLocalInput{
+				cowParent: hydratedVolume,
+				mountPath: input.mountPath,
+			}
+			continue
+		}
+
 		// create an empty volume to stream-in the remote volume. this volume
 		// will only be used as a parent volume (i.e. it won't be directly
 		// mounted to a container) - this is because it may be saved as a
@@ -549,6 +655,7 @@ func (worker *Worker) createOutputVolumes(
 			baggageclaim.VolumeSpec{
 				Strategy:   baggageclaim.EmptyStrategy{},
 				Privileged: privileged,
+				Quota:      atc.OutputVolumeQuota,
 			},
 			container,
 			spec.TeamID,
@@ -596,6 +703,7 @@ func (worker *Worker) cloneCacheVolumes(
 			mountedVolume, err = worker.findOrCreateCOWVolumeForContainer(
 				logger,
 				privileged,
+				atc.CacheVolumeQuota,
 				container,
 				volume,
 				spec.TeamID,
@@ -613,6 +721,7 @@ func (worker *Worker) cloneCacheVolumes(
 				baggageclaim.VolumeSpec{
 					Strategy:   baggageclaim.EmptyStrategy{},
 					Privileged: privileged,
+					Quota:      atc.CacheVolumeQuota,
 				},
 				container,
 				spec.TeamID,
@@ -5,10 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"testing/fstest"
 	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/cosign"
+	"github.com/concourse/concourse/atc/cosign/cosignfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbtest"
 	"github.com/concourse/concourse/atc/db/lock"
@@ -208,6 +212,68 @@ var _ = Describe("Garden Worker", func() {
 		})
 	})
 
+	Test("attaching to a process while another in the container has exited", func() {
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("worker"),
+			),
+		)
+		worker := scenario.Worker("worker")
+
+		container, _, err := worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("my-handle"),
+			db.ContainerMetadata{},
+			runtime.ContainerSpec{
+				Dir: "/workdir",
+				ImageSpec: runtime.ImageSpec{
+					ImageURL: "raw:///img/rootfs",
+				},
+			},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("running a sidecar which exits", func() {
+			sidecar, err := container.Run(ctx, runtime.ProcessSpec{
+				ID:   "sidecar",
+				Path: "exit",
+				Args: []string{"3"},
+			}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := sidecar.Wait(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitStatus).To(Equal(3))
+		})
+
+		_, err = container.Run(ctx, runtime.ProcessSpec{
+			ID:   "task",
+			Path: "sleep-and-echo",
+			Args: []string{"200ms", "hello world"},
+		}, runtime.ProcessIO{Stdout: new(bytes.Buffer)})
+		Expect(err).ToNot(HaveOccurred())
+
+		By("attaching to the still running process", func() {
+			attachBuf := new(bytes.Buffer)
+			process, err := container.Attach(ctx, "task", runtime.ProcessIO{Stdout: attachBuf})
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := process.Wait(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitStatus).To(Equal(0))
+			Expect(attachBuf.String()).To(Equal("hello world\n"))
+		})
+
+		By("attaching to the exited sidecar", func() {
+			process, err := container.Attach(ctx, "sidecar", runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := process.Wait(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitStatus).To(Equal(3))
+		})
+	})
+
 	Test("reports executable not found error", func() {
 		scenario := Setup(
 			workertest.WithWorkers(
@@ -387,6 +453,253 @@ var _ = Describe("Garden Worker", func() {
 		})
 	})
 
+	Test("fetch image pulled from a registry", func() {
+		registryImage := atc.RegistryImage{
+			Repository: "some/image",
+			Digest:     "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			Username:   "some-user",
+			Password:   "some-password",
+		}
+
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("worker").
+					WithRegistryImage(registryImage.Digest, runtimetest.VolumeContent{
+						"metadata.json": &fstest.MapFile{Data: []byte(`{"config":{"Env":["FOO=bar"],"User":"somebody"}}`)},
+					}),
+			),
+		)
+		worker := scenario.Worker("worker")
+
+		container, _, err := worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("my-handle"),
+			db.ContainerMetadata{},
+			runtime.ContainerSpec{
+				ImageSpec: runtime.ImageSpec{
+					RegistryImage: &registryImage,
+					Privileged:    true,
+				},
+			},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		imageVolume, ok := findVolumeBy(worker, grt.StrategyEq(baggageclaim.RegistryImageStrategy{
+			Repository: "some/image",
+			Digest:     registryImage.Digest,
+			Username:   "some-user",
+			Password:   "some-password",
+		}))
+		Expect(ok).To(BeTrue())
+		Expect(imageVolume.Spec.Privileged).To(BeTrue())
+
+		gardenContainer := gardenContainer(container)
+		Expect(gardenContainer.Spec.RootFSPath).To(Equal(fmt.Sprintf("raw://%s/rootfs", imageVolume.Path())))
+		Expect(gardenContainer.Spec.Env).To(Equal([]string{"FOO=bar"}))
+		Expect(gardenContainer.Spec.Privileged).To(BeTrue())
+	})
+
+	Describe("image signature verification", func() {
+		var fakeVerifier *cosignfakes.FakeVerifier
+
+		registryImage := atc.RegistryImage{
+			Repository: "some/image",
+			Digest:     "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		}
+
+		BeforeEach(func() {
+			fakeVerifier = new(cosignfakes.FakeVerifier)
+			fakeVerifier.RequiredReturns(true)
+		})
+
+		// createContainer creates a container with the image, replacing any
+		// image artifact with a volume on the worker
+		createContainer := func(imageSpec runtime.ImageSpec) (runtime.Worker, error) {
+			imageVolume := grt.NewVolume("local-image-volume").WithContent(runtimetest.VolumeContent{
+				"metadata.json": grt.ImageMetadataFile(gardenruntime.ImageMetadata{}),
+			})
+
+			scenario := Setup(workertest.WithWorkers(grt.NewWorker("worker").
+				WithImageVerifier(fakeVerifier).
+				WithRegistryImage(registryImage.Digest, runtimetest.VolumeContent{
+					"metadata.json": grt.ImageMetadataFile(gardenruntime.ImageMetadata{}),
+				}).
+				WithVolumesCreatedInDBAndBaggageclaim(imageVolume),
+			))
+			worker := scenario.Worker("worker")
+
+			if imageSpec.ImageArtifact != nil {
+				imageSpec.ImageArtifact = scenario.WorkerVolume("worker", imageVolume.Handle())
+			}
+
+			_, _, err := worker.FindOrCreateContainer(
+				ctx,
+				db.NewFixedHandleContainerOwner("my-handle"),
+				db.ContainerMetadata{},
+				runtime.ContainerSpec{
+					TeamName:  "some-team",
+					ImageSpec: imageSpec,
+				},
+			)
+
+			return worker, err
+		}
+
+		Test("verifies images pulled from registries", func() {
+			worker, err := createContainer(runtime.ImageSpec{RegistryImage: &registryImage})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeVerifier.VerifyCallCount()).To(Equal(1))
+			_, team, image := fakeVerifier.VerifyArgsForCall(0)
+			Expect(team).To(Equal("some-team"))
+			Expect(image).To(Equal(registryImage))
+
+			Expect(gardenServer(worker).ContainerList).To(HaveLen(1))
+		})
+
+		Test("verifies images fetched by image resources", func() {
+			_, err := createContainer(runtime.ImageSpec{
+				ImageArtifact:       runtimetest.NewVolume("placeholder"),
+				ImageArtifactSource: &registryImage,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeVerifier.VerifyCallCount()).To(Equal(1))
+			_, _, image := fakeVerifier.VerifyArgsForCall(0)
+			Expect(image).To(Equal(registryImage))
+		})
+
+		Test("does not create containers with images that fail verification", func() {
+			disaster := cosign.UnsignedImageError{Repository: "some/image", Digest: registryImage.Digest, Reason: "no signatures found"}
+			fakeVerifier.VerifyReturns(disaster)
+
+			worker, err := createContainer(runtime.ImageSpec{RegistryImage: &registryImage})
+			Expect(err).To(Equal(disaster))
+
+			Expect(gardenServer(worker).ContainerList).To(BeEmpty())
+			_, found := findVolumeBy(worker, grt.StrategyEq(baggageclaim.RegistryImageStrategy{
+				Repository: registryImage.Repository,
+				Digest:     registryImage.Digest,
+			}))
+			Expect(found).To(BeFalse())
+		})
+
+		Test("refuses rootfs_uri images, which cannot be verified", func() {
+			_, err := createContainer(runtime.ImageSpec{ImageURL: "docker:///busybox"})
+			Expect(err).To(Equal(cosign.ErrUnverifiableImage))
+		})
+
+		Test("refuses image artifacts of unknown origin, which cannot be verified", func() {
+			_, err := createContainer(runtime.ImageSpec{ImageArtifact: runtimetest.NewVolume("placeholder")})
+			Expect(err).To(Equal(cosign.ErrUnverifiableImage))
+		})
+
+		Test("trusts images provided by the worker", func() {
+			_, err := createContainer(runtime.ImageSpec{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeVerifier.VerifyCallCount()).To(BeZero())
+		})
+
+		Test("does not verify images of teams which needn't be signed", func() {
+			fakeVerifier.RequiredReturns(false)
+
+			_, err := createContainer(runtime.ImageSpec{ImageURL: "docker:///busybox"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeVerifier.VerifyCallCount()).To(BeZero())
+		})
+	})
+
+	Test("fetch image with an OCI image config", func() {
+		imageVolume := grt.NewVolume("local-image-volume").WithContent(runtimetest.VolumeContent{
+			"metadata.json": &fstest.MapFile{Data: []byte(`{
+				"architecture": "amd64",
+				"os": "linux",
+				"config": {
+					"Env": ["FOO=bar"],
+					"User": "somebody",
+					"Entrypoint": ["/bin/server"],
+					"Cmd": ["--port", "8080"],
+					"WorkingDir": "/app",
+					"ExposedPorts": {"8080/tcp": {}, "443/tcp": {}}
+				}
+			}`)},
+		})
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("worker").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						imageVolume,
+					),
+			),
+		)
+		worker := scenario.Worker("worker")
+
+		container, _, err := worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("my-handle"),
+			db.ContainerMetadata{},
+			runtime.ContainerSpec{
+				ImageSpec: runtime.ImageSpec{
+					ImageArtifact: scenario.WorkerVolume("worker", imageVolume.Handle()),
+				},
+			},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		gardenContainer := gardenContainer(container)
+
+		By("validating the container was created with the image's env and exposed ports", func() {
+			Expect(gardenContainer.Spec.Env).To(Equal([]string{"FOO=bar"}))
+			Expect(gardenContainer.Spec.Properties).To(HaveKeyWithValue("concourse:exposed-ports", "443/tcp,8080/tcp"))
+		})
+
+		By("running the image's entrypoint and cmd in its working dir when no path is given", func() {
+			_, err := container.Run(ctx, runtime.ProcessSpec{}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gardenContainer.Processes[0].Spec.Path).To(Equal("/bin/server"))
+			Expect(gardenContainer.Processes[0].Spec.Args).To(Equal([]string{"--port", "8080"}))
+			Expect(gardenContainer.Processes[0].Spec.Dir).To(Equal("/app"))
+			Expect(gardenContainer.Processes[0].Spec.User).To(Equal("somebody"))
+		})
+
+		By("replacing the image's cmd with the process's args", func() {
+			_, err := container.Run(ctx, runtime.ProcessSpec{Args: []string{"--debug"}}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gardenContainer.Processes[1].Spec.Path).To(Equal("/bin/server"))
+			Expect(gardenContainer.Processes[1].Spec.Args).To(Equal([]string{"--debug"}))
+		})
+
+		By("running the given path in the given dir instead of the image's defaults", func() {
+			_, err := container.Run(ctx, runtime.ProcessSpec{Path: "noop", Dir: "/workdir"}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gardenContainer.Processes[2].Spec.Path).To(Equal("noop"))
+			Expect(gardenContainer.Processes[2].Spec.Args).To(BeEmpty())
+			Expect(gardenContainer.Processes[2].Spec.Dir).To(Equal("/workdir"))
+		})
+	})
+
+	Test("running a process without a path in an image without an entrypoint or cmd", func() {
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("worker"),
+			),
+		)
+		worker := scenario.Worker("worker")
+
+		container, _, err := worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("my-handle"),
+			db.ContainerMetadata{},
+			runtime.ContainerSpec{},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = container.Run(ctx, runtime.ProcessSpec{}, runtime.ProcessIO{})
+		Expect(err).To(BeAssignableToTypeOf(runtime.ExecutableNotFoundError{}))
+	})
+
 	Test("fetch image from resource cache volume on same worker", func() {
 		imageContent := runtimetest.VolumeContent{
 			"metadata.json": grt.ImageMetadataFile(gardenruntime.ImageMetadata{
@@ -530,6 +843,54 @@ var _ = Describe("Garden Worker", func() {
 		})
 	})
 
+	Test("prewarming a base resource type", func() {
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("worker"),
+			),
+		)
+		worker := scenario.Worker("worker")
+
+		err := worker.PrewarmBaseResourceType(logger, dbtest.BaseResourceType)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("validating that the image was imported", func() {
+			_, ok := findVolumeBy(worker, grt.StrategyEq(baggageclaim.ImportStrategy{Path: "/path/to/global/image"}))
+			Expect(ok).To(BeTrue())
+		})
+
+		By("validating that the import volume is reused by containers", func() {
+			_, _, err := worker.FindOrCreateContainer(
+				ctx,
+				db.NewFixedHandleContainerOwner("my-handle"),
+				db.ContainerMetadata{},
+				runtime.ContainerSpec{
+					ImageSpec: runtime.ImageSpec{
+						ResourceType: dbtest.BaseResourceType,
+					},
+				},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			importVolumes := baggageclaimServer(worker).FilteredVolumes(
+				grt.StrategyEq(baggageclaim.ImportStrategy{Path: "/path/to/global/image"}),
+			)
+			Expect(importVolumes).To(HaveLen(1))
+		})
+	})
+
+	Test("prewarming an unsupported resource type", func() {
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("worker"),
+			),
+		)
+		worker := scenario.Worker("worker")
+
+		err := worker.PrewarmBaseResourceType(logger, "bogus-type")
+		Expect(err).To(Equal(gardenruntime.ErrUnsupportedResourceType))
+	})
+
 	Test("input and output volumes", func() {
 		localInputVolume1 := grt.NewVolume("local-input1")
 		localInputVolume2 := grt.NewVolume("local-input2")
@@ -656,6 +1017,147 @@ var _ = Describe("Garden Worker", func() {
 		})
 	})
 
+	Describe("persistent resource caches", func() {
+		cacheContent := runtimetest.VolumeContent{
+			"file": {Data: []byte("cached content")},
+		}
+
+		BeforeEach(func() {
+			atc.EnablePersistentResourceCaches = true
+		})
+
+		AfterEach(func() {
+			atc.EnablePersistentResourceCaches = false
+		})
+
+		Test("persisting a resource cache once it's initialized", func() {
+			store := grt.NewPersistedVolumes()
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker("worker").
+						WithPersistedVolumes(store).
+						WithVolumesCreatedInDBAndBaggageclaim(
+							grt.NewVolume("cache-volume").WithContent(cacheContent),
+						),
+				),
+			)
+
+			resourceCache := scenario.FindOrCreateResourceCache("worker")
+			err := scenario.WorkerVolume("worker", "cache-volume").InitializeResourceCache(logger, resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() runtimetest.VolumeContent {
+				content, _ := store.Get(fmt.Sprintf("resource-caches/%d", resourceCache.ID()))
+				return content
+			}).Should(Equal(cacheContent))
+		})
+
+		Test("input volume hydrated from a persisted resource cache", func() {
+			store := grt.NewPersistedVolumes()
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithPersistedVolumes(store),
+					grt.NewWorker("worker2").
+						WithVolumesCreatedInDBAndBaggageclaim(
+							grt.NewVolume("remote-volume").WithContent(cacheContent),
+						),
+				),
+			)
+
+			resourceCache := scenario.FindOrCreateResourceCache("worker2")
+			err := scenario.WorkerVolume("worker2", "remote-volume").InitializeResourceCache(logger, resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			key := fmt.Sprintf("resource-caches/%d", resourceCache.ID())
+			store.Put(key, cacheContent)
+
+			worker := scenario.Worker("worker1")
+
+			_, _, err = worker.FindOrCreateContainer(
+				ctx,
+				db.NewFixedHandleContainerOwner("my-handle"),
+				db.ContainerMetadata{},
+				runtime.ContainerSpec{
+					ImageSpec: runtime.ImageSpec{
+						ImageURL: "raw:///img/rootfs",
+					},
+					Dir: "/workdir",
+					Inputs: []runtime.Input{
+						{
+							Artifact:        scenario.WorkerVolume("worker2", "remote-volume"),
+							DestinationPath: "/input",
+						},
+					},
+				},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			hydratedVolume, ok := findVolumeBy(worker, grt.StrategyEq(baggageclaim.HydrateStrategy{Key: key}))
+			Expect(ok).To(BeTrue(), "hydrated volume not found")
+			Expect(hydratedVolume.Content).To(Equal(cacheContent))
+
+			By("validating the input was cloned from the hydrated volume rather than streamed", func() {
+				_, ok := findVolumeBy(worker, grt.StrategyEq(baggageclaim.COWStrategy{Parent: hydratedVolume}))
+				Expect(ok).To(BeTrue())
+
+				_, streamed := findVolumeBy(worker, func(v *grt.Volume) bool {
+					return grt.StrategyEq(baggageclaim.EmptyStrategy{})(v) && grt.ContentEq(cacheContent)(v)
+				})
+				Expect(streamed).To(BeFalse())
+			})
+
+			By("validating the hydrated volume is the resource cache on the worker", func() {
+				volume, found, err := worker.LookupVolume(logger, hydratedVolume.Handle())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(volume.DBVolume().GetResourceCacheID()).To(Equal(resourceCache.ID()))
+			})
+		})
+
+		Test("input volume streamed when the resource cache wasn't persisted", func() {
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithPersistedVolumes(grt.NewPersistedVolumes()),
+					grt.NewWorker("worker2").
+						WithVolumesCreatedInDBAndBaggageclaim(
+							grt.NewVolume("remote-volume").WithContent(cacheContent),
+						),
+				),
+			)
+
+			resourceCache := scenario.FindOrCreateResourceCache("worker2")
+			err := scenario.WorkerVolume("worker2", "remote-volume").InitializeResourceCache(logger, resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			worker := scenario.Worker("worker1")
+
+			_, _, err = worker.FindOrCreateContainer(
+				ctx,
+				db.NewFixedHandleContainerOwner("my-handle"),
+				db.ContainerMetadata{},
+				runtime.ContainerSpec{
+					ImageSpec: runtime.ImageSpec{
+						ImageURL: "raw:///img/rootfs",
+					},
+					Dir: "/workdir",
+					Inputs: []runtime.Input{
+						{
+							Artifact:        scenario.WorkerVolume("worker2", "remote-volume"),
+							DestinationPath: "/input",
+						},
+					},
+				},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			streamedVolume, ok := findVolumeBy(worker, grt.ContentEq(cacheContent))
+			Expect(ok).To(BeTrue(), "streamed volume not found")
+			Expect(streamedVolume).To(grt.HaveStrategy(baggageclaim.EmptyStrategy{}))
+		})
+	})
+
 	Test("input volume matching workdir/output", func() {
 		localInputVolume := grt.NewVolume("local-input")
 		scenario := Setup(
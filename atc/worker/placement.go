@@ -13,16 +13,24 @@ import (
 )
 
 type PlacementOptions struct {
-	Strategies                   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
+	Strategies                   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" choice:"bin-packing" choice:"pipeline-affinity" choice:"pipeline-anti-affinity" choice:"limit-worker-load" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
 	MaxActiveTasksPerWorker      int      `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
 	MaxActiveContainersPerWorker int      `long:"max-active-containers-per-worker" default:"0" description:"Maximum allowed number of active containers per worker. Has effect only when used with limit-active-containers placement strategy. 0 means no limit."`
 	MaxActiveVolumesPerWorker    int      `long:"max-active-volumes-per-worker" default:"0" description:"Maximum allowed number of active volumes per worker. Has effect only when used with limit-active-volumes placement strategy. 0 means no limit."`
+	MaxWorkerCPULoad             float64  `long:"max-worker-cpu-load" default:"0" description:"Maximum allowed 1-minute load average per CPU of a worker, as reported on its last heartbeat. Has effect only when used with limit-worker-load placement strategy. 0 means no limit."`
+	MaxWorkerMemoryUsage         float64  `long:"max-worker-memory-usage" default:"0" description:"Maximum allowed fraction (between 0 and 1) of a worker's memory in use, as reported on its last heartbeat. Has effect only when used with limit-worker-load placement strategy. 0 means no limit."`
+	MaxWorkerDiskUsage           float64  `long:"max-worker-disk-usage" default:"0" description:"Maximum allowed fraction (between 0 and 1) of a worker's volumes disk in use, as reported on its last heartbeat. Has effect only when used with limit-worker-load placement strategy. 0 means no limit."`
+	PipelineAffinity             []string `long:"pipeline-affinity" description:"Team (TEAM) or pipeline (TEAM/PIPELINE) whose containers are placed with the pipeline-affinity placement strategy. Can be specified multiple times. If unset, it applies to every pipeline."`
+	PipelineAntiAffinity         []string `long:"pipeline-anti-affinity" description:"Comma-separated group of teams (TEAM) or pipelines (TEAM/PIPELINE) whose builds are never placed on the same worker by the pipeline-anti-affinity placement strategy. Can be specified multiple times."`
 }
 
 var (
 	ErrTooManyActiveTasks = errors.New("worker has too many active tasks")
 	ErrTooManyContainers  = errors.New("worker has too many containers")
 	ErrTooManyVolumes     = errors.New("worker has too many volumes")
+	ErrNotEnoughCapacity  = errors.New("worker does not have enough cpu or memory capacity")
+	ErrPipelineConflict   = errors.New("worker is running builds of a conflicting pipeline")
+	ErrWorkerOverloaded   = errors.New("worker load is above the configured limits")
 )
 
 func NewPlacementStrategy(options PlacementOptions) (PlacementStrategy, error) {
@@ -52,6 +60,39 @@ func NewPlacementStrategy(options PlacementOptions) (PlacementStrategy, error) {
 				return nil, errors.New("max-active-volumes-per-worker must be greater or equal than 0")
 			}
 			strategy = append(strategy, limitActiveVolumesStrategy{MaxVolumes: options.MaxActiveVolumesPerWorker})
+		case "bin-packing":
+			strategy = append(strategy, binPackingStrategy{})
+		case "limit-worker-load":
+			if options.MaxWorkerCPULoad < 0 {
+				return nil, errors.New("max-worker-cpu-load must be greater or equal than 0")
+			}
+			if options.MaxWorkerMemoryUsage < 0 || options.MaxWorkerMemoryUsage > 1 {
+				return nil, errors.New("max-worker-memory-usage must be between 0 and 1")
+			}
+			if options.MaxWorkerDiskUsage < 0 || options.MaxWorkerDiskUsage > 1 {
+				return nil, errors.New("max-worker-disk-usage must be between 0 and 1")
+			}
+			strategy = append(strategy, limitWorkerLoadStrategy{
+				MaxCPULoad:     options.MaxWorkerCPULoad,
+				MaxMemoryUsage: options.MaxWorkerMemoryUsage,
+				MaxDiskUsage:   options.MaxWorkerDiskUsage,
+			})
+		case "pipeline-affinity":
+			scope, err := parsePipelineSelectors(options.PipelineAffinity)
+			if err != nil {
+				return nil, fmt.Errorf("pipeline-affinity: %w", err)
+			}
+			strategy = append(strategy, pipelineAffinityStrategy{Scope: scope})
+		case "pipeline-anti-affinity":
+			groups := make([][]pipelineSelector, 0, len(options.PipelineAntiAffinity))
+			for _, group := range options.PipelineAntiAffinity {
+				selectors, err := parsePipelineSelectors(strings.Split(group, ","))
+				if err != nil {
+					return nil, fmt.Errorf("pipeline-anti-affinity: %w", err)
+				}
+				groups = append(groups, selectors)
+			}
+			strategy = append(strategy, pipelineAntiAffinityStrategy{Groups: groups})
 		default:
 			return nil, fmt.Errorf("invalid container placement strategy %s", strategy)
 		}
@@ -371,6 +412,310 @@ func (strategy limitActiveVolumesStrategy) Approve(_ lager.Logger, worker db.Wor
 func (strategy limitActiveVolumesStrategy) Release(lager.Logger, db.Worker, runtime.ContainerSpec) {
 }
 
+// bin-packing
+
+// binPackingStrategy places containers on the workers with the least capacity
+// left that still fit the CPU and memory they request, so that load is packed
+// onto as few workers as possible. Requested resources are reserved on the
+// worker until the container's step releases it.
+type binPackingStrategy struct{}
+
+func (strategy binPackingStrategy) Order(logger lager.Logger, pool Pool, workers []db.Worker, spec runtime.ContainerSpec) ([]db.Worker, error) {
+	request := requestedResources(spec)
+
+	free := make(map[string]float64, len(workers))
+	for _, worker := range workers {
+		free[worker.Name()] = freeCapacityAfter(worker, request)
+	}
+
+	sorted := cloneWorkers(workers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		fi, fj := free[sorted[i].Name()], free[sorted[j].Name()]
+
+		// workers which don't fit go last
+		if (fi < 0) != (fj < 0) {
+			return fj < 0
+		}
+
+		return fi < fj
+	})
+
+	return sorted, nil
+}
+
+func (strategy binPackingStrategy) Approve(_ lager.Logger, worker db.Worker, spec runtime.ContainerSpec) error {
+	request := requestedResources(spec)
+	if request.IsZero() {
+		return nil
+	}
+
+	reserved, err := worker.Reserve(request)
+	if err != nil {
+		return err
+	}
+
+	if !reserved {
+		return ErrNotEnoughCapacity
+	}
+
+	return nil
+}
+
+func (strategy binPackingStrategy) Release(logger lager.Logger, worker db.Worker, spec runtime.ContainerSpec) {
+	request := requestedResources(spec)
+	if request.IsZero() {
+		return
+	}
+
+	err := worker.Unreserve(request)
+	if err != nil {
+		logger.Error("failed-to-unreserve-resources", err)
+	}
+}
+
+func requestedResources(spec runtime.ContainerSpec) db.WorkerResources {
+	var request db.WorkerResources
+	if spec.Limits.CPU != nil {
+		request.CPU = *spec.Limits.CPU
+	}
+	if spec.Limits.Memory != nil {
+		request.Memory = *spec.Limits.Memory
+	}
+	return request
+}
+
+// freeCapacityAfter is the fraction of the worker's capacity which would be
+// left after reserving `request`, averaged over CPU and memory. It is
+// negative if the request does not fit. Resources the worker has no capacity
+// for count as entirely free.
+func freeCapacityAfter(worker db.Worker, request db.WorkerResources) float64 {
+	capacity, reserved := worker.Capacity(), worker.Reserved()
+
+	fraction := func(capacity, reserved, requested uint64) float64 {
+		if capacity == 0 {
+			return 1
+		}
+
+		return (float64(capacity) - float64(reserved) - float64(requested)) / float64(capacity)
+	}
+
+	cpu := fraction(capacity.CPU, reserved.CPU, request.CPU)
+	memory := fraction(capacity.Memory, reserved.Memory, request.Memory)
+	if cpu < 0 || memory < 0 {
+		return -1
+	}
+
+	return (cpu + memory) / 2
+}
+
+// limit-worker-load
+
+// limitWorkerLoadStrategy avoids workers whose load, as reported on their last
+// heartbeat, is above any of the limits. Workers which don't report their load
+// are never considered overloaded.
+type limitWorkerLoadStrategy struct {
+	MaxCPULoad     float64
+	MaxMemoryUsage float64
+	MaxDiskUsage   float64
+}
+
+func (strategy limitWorkerLoadStrategy) Order(logger lager.Logger, pool Pool, workers []db.Worker, spec runtime.ContainerSpec) ([]db.Worker, error) {
+	return partitionWorkersBy(workers, strategy.workerSatisfies), nil
+}
+
+func (strategy limitWorkerLoadStrategy) workerSatisfies(worker db.Worker) bool {
+	load := worker.Load()
+
+	if strategy.MaxCPULoad > 0 && load.CPU > strategy.MaxCPULoad {
+		return false
+	}
+
+	if strategy.MaxMemoryUsage > 0 && load.Memory > strategy.MaxMemoryUsage {
+		return false
+	}
+
+	if strategy.MaxDiskUsage > 0 && load.Disk > strategy.MaxDiskUsage {
+		return false
+	}
+
+	return true
+}
+
+func (strategy limitWorkerLoadStrategy) Approve(_ lager.Logger, worker db.Worker, _ runtime.ContainerSpec) error {
+	if !strategy.workerSatisfies(worker) {
+		return ErrWorkerOverloaded
+	}
+
+	return nil
+}
+
+func (strategy limitWorkerLoadStrategy) Release(lager.Logger, db.Worker, runtime.ContainerSpec) {
+}
+
+// pipeline-affinity
+
+// pipelineAffinityStrategy prefers the workers already running the most build
+// containers of the container's pipeline, as they are the most likely to have
+// its caches.
+type pipelineAffinityStrategy struct {
+	Scope []pipelineSelector
+}
+
+func (strategy pipelineAffinityStrategy) Order(logger lager.Logger, pool Pool, workers []db.Worker, spec runtime.ContainerSpec) ([]db.Worker, error) {
+	if spec.PipelineID == 0 {
+		return workers, nil
+	}
+
+	if len(strategy.Scope) > 0 && !anyPipelineSelectorMatches(strategy.Scope, spec.TeamName, spec.PipelineName) {
+		return workers, nil
+	}
+
+	counts := make(map[string]int, len(workers))
+	for _, worker := range workers {
+		pipelines, err := worker.BuildPipelines()
+		if err != nil {
+			logger.Error("failed-to-find-build-pipelines", err, lager.Data{"worker": worker.Name()})
+			continue
+		}
+
+		for _, pipeline := range pipelines {
+			if pipeline.PipelineID == spec.PipelineID {
+				counts[worker.Name()] = pipeline.Containers
+			}
+		}
+	}
+
+	sorted := cloneWorkers(workers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return counts[sorted[i].Name()] > counts[sorted[j].Name()]
+	})
+
+	return sorted, nil
+}
+
+func (pipelineAffinityStrategy) Approve(lager.Logger, db.Worker, runtime.ContainerSpec) error {
+	return nil
+}
+
+func (pipelineAffinityStrategy) Release(lager.Logger, db.Worker, runtime.ContainerSpec) {}
+
+// pipeline-anti-affinity
+
+// pipelineAntiAffinityStrategy rejects workers running build containers of a
+// pipeline which shares an anti-affinity group with the container's pipeline.
+// Builds of the same pipeline may always share a worker.
+//
+// Conflicts are checked against the containers which exist at the time of
+// placement, so two conflicting builds placed at the same time may still end
+// up on the same worker.
+type pipelineAntiAffinityStrategy struct {
+	Groups [][]pipelineSelector
+}
+
+func (strategy pipelineAntiAffinityStrategy) Order(logger lager.Logger, pool Pool, workers []db.Worker, spec runtime.ContainerSpec) ([]db.Worker, error) {
+	if spec.PipelineID == 0 || len(strategy.Groups) == 0 {
+		return workers, nil
+	}
+
+	return partitionWorkersBy(workers, func(worker db.Worker) bool {
+		conflicts, err := strategy.conflicts(worker, spec)
+		if err != nil {
+			logger.Error("failed-to-find-build-pipelines", err, lager.Data{"worker": worker.Name()})
+			return false
+		}
+
+		return !conflicts
+	}), nil
+}
+
+func (strategy pipelineAntiAffinityStrategy) Approve(_ lager.Logger, worker db.Worker, spec runtime.ContainerSpec) error {
+	if spec.PipelineID == 0 || len(strategy.Groups) == 0 {
+		return nil
+	}
+
+	conflicts, err := strategy.conflicts(worker, spec)
+	if err != nil {
+		return err
+	}
+
+	if conflicts {
+		return ErrPipelineConflict
+	}
+
+	return nil
+}
+
+func (pipelineAntiAffinityStrategy) Release(lager.Logger, db.Worker, runtime.ContainerSpec) {}
+
+func (strategy pipelineAntiAffinityStrategy) conflicts(worker db.Worker, spec runtime.ContainerSpec) (bool, error) {
+	pipelines, err := worker.BuildPipelines()
+	if err != nil {
+		return false, err
+	}
+
+	for _, pipeline := range pipelines {
+		if pipeline.PipelineID == spec.PipelineID {
+			continue
+		}
+
+		for _, group := range strategy.Groups {
+			for i, selector := range group {
+				if !selector.Matches(spec.TeamName, spec.PipelineName) {
+					continue
+				}
+
+				for j, other := range group {
+					if i != j && other.Matches(pipeline.TeamName, pipeline.PipelineName) {
+						return true, nil
+					}
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// pipelineSelector matches every pipeline of a team, or a single pipeline
+// when Pipeline is set.
+type pipelineSelector struct {
+	Team     string
+	Pipeline string
+}
+
+func parsePipelineSelectors(values []string) ([]pipelineSelector, error) {
+	selectors := make([]pipelineSelector, 0, len(values))
+	for _, value := range values {
+		parts := strings.Split(strings.TrimSpace(value), "/")
+		if parts[0] == "" || len(parts) > 2 || (len(parts) == 2 && parts[1] == "") {
+			return nil, fmt.Errorf("invalid team or pipeline %q: must be TEAM or TEAM/PIPELINE", value)
+		}
+
+		selector := pipelineSelector{Team: parts[0]}
+		if len(parts) == 2 {
+			selector.Pipeline = parts[1]
+		}
+
+		selectors = append(selectors, selector)
+	}
+
+	return selectors, nil
+}
+
+func (selector pipelineSelector) Matches(team string, pipeline string) bool {
+	return selector.Team == team && (selector.Pipeline == "" || selector.Pipeline == pipeline)
+}
+
+func anyPipelineSelectorMatches(selectors []pipelineSelector, team string, pipeline string) bool {
+	for _, selector := range selectors {
+		if selector.Matches(team, pipeline) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // helpers
 
 func cloneWorkers(workers []db.Worker) []db.Worker {
@@ -1,6 +1,7 @@
 package worker_test
 
 import (
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
@@ -554,6 +555,301 @@ var _ = Describe("Container Placement Strategies", func() {
 			}
 		})
 	})
+
+	Describe("Bin Packing", func() {
+		binPackingStrategy := func() worker.PlacementStrategy {
+			strategy, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies: []string{"bin-packing"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return strategy
+		}
+
+		cpu := uint64(1024)
+		memory := uint64(1024 * 1024 * 1024)
+
+		Test("returns the fullest workers which fit the container first", func() {
+			scenario := Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithCapacity(4*cpu, 4*memory).
+						WithReserved(1*cpu, 1*memory),
+					grt.NewWorker("worker2").
+						WithCapacity(4*cpu, 4*memory).
+						WithReserved(2*cpu, 2*memory),
+					grt.NewWorker("worker3").
+						WithCapacity(4*cpu, 4*memory).
+						WithReserved(3*cpu, 3*memory),
+					grt.NewWorker("worker4"),
+				),
+			)
+
+			workers, err := binPackingStrategy().Order(logger, scenario.Pool, scenario.DB.Workers, runtime.ContainerSpec{
+				TeamID:   scenario.TeamID,
+				JobID:    scenario.JobID,
+				StepName: scenario.StepName,
+
+				Limits: runtime.ContainerLimits{
+					CPU:    &cpu,
+					Memory: &memory,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames(workers)).To(Equal([]string{"worker3", "worker2", "worker1", "worker4"}))
+		})
+
+		Test("reserves the requested resources until released", func() {
+			scenario := Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithCapacity(4*cpu, 4*memory).
+						WithReserved(3*cpu, 0),
+				),
+			)
+
+			strategy := binPackingStrategy()
+			spec := runtime.ContainerSpec{
+				TeamID:   scenario.TeamID,
+				JobID:    scenario.JobID,
+				StepName: scenario.StepName,
+
+				Limits: runtime.ContainerLimits{
+					CPU: &cpu,
+				},
+			}
+
+			worker1 := scenario.DB.Worker("worker1")
+
+			err := strategy.Approve(logger, worker1, spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = strategy.Approve(logger, worker1, spec)
+			Expect(err).To(MatchError(worker.ErrNotEnoughCapacity))
+
+			strategy.Release(logger, worker1, spec)
+
+			err = strategy.Approve(logger, worker1, spec)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("Limit Worker Load", func() {
+		limitWorkerLoadStrategy := func(options worker.PlacementOptions) worker.PlacementStrategy {
+			options.Strategies = []string{"limit-worker-load"}
+			strategy, err := worker.NewPlacementStrategy(options)
+			Expect(err).ToNot(HaveOccurred())
+			return strategy
+		}
+
+		var (
+			scenario *workertest.Scenario
+			spec     runtime.ContainerSpec
+		)
+
+		BeforeEach(func() {
+			scenario = Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithStats(atc.WorkerStats{CPULoad: 2}),
+					grt.NewWorker("worker2").
+						WithStats(atc.WorkerStats{MemoryTotalBytes: 100, MemoryAvailableBytes: 5}),
+					grt.NewWorker("worker3").
+						WithStats(atc.WorkerStats{DiskTotalBytes: 100, DiskFreeBytes: 1}),
+					grt.NewWorker("worker4").
+						WithStats(atc.WorkerStats{CPULoad: 0.5, MemoryTotalBytes: 100, MemoryAvailableBytes: 50, DiskTotalBytes: 100, DiskFreeBytes: 50}),
+					grt.NewWorker("worker5"),
+				),
+			)
+
+			spec = runtime.ContainerSpec{
+				TeamID:   scenario.TeamID,
+				JobID:    scenario.JobID,
+				StepName: scenario.StepName,
+			}
+		})
+
+		Test("disallows workers above any of the limits", func() {
+			strategy := limitWorkerLoadStrategy(worker.PlacementOptions{
+				MaxWorkerCPULoad:     1,
+				MaxWorkerMemoryUsage: 0.9,
+				MaxWorkerDiskUsage:   0.9,
+			})
+
+			workers, err := strategy.Order(logger, scenario.Pool, scenario.DB.Workers, spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames(workers)[:2]).To(ConsistOf("worker4", "worker5"))
+
+			for _, name := range []string{"worker1", "worker2", "worker3"} {
+				err := strategy.Approve(logger, scenario.DB.Worker(name), spec)
+				Expect(err).To(MatchError(worker.ErrWorkerOverloaded))
+			}
+
+			for _, name := range []string{"worker4", "worker5"} {
+				err := strategy.Approve(logger, scenario.DB.Worker(name), spec)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		Test("noop if limits are unset", func() {
+			strategy := limitWorkerLoadStrategy(worker.PlacementOptions{})
+
+			for _, worker := range scenario.DB.Workers {
+				err := strategy.Approve(logger, worker, spec)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		Test("rejects usage limits above 1", func() {
+			_, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies:           []string{"limit-worker-load"},
+				MaxWorkerMemoryUsage: 90,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Pipeline Affinity", func() {
+		pipelineAffinityStrategy := func(scope ...string) worker.PlacementStrategy {
+			strategy, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies:       []string{"pipeline-affinity"},
+				PipelineAffinity: scope,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return strategy
+		}
+
+		Test("returns workers with the most containers of the pipeline first", func() {
+			scenario := Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithJobBuildContainerCreatedInDBAndGarden(),
+					grt.NewWorker("worker2"),
+					grt.NewWorker("worker3").
+						WithJobBuildContainerCreatedInDBAndGarden().
+						WithJobBuildContainerCreatedInDBAndGarden(),
+				),
+			)
+
+			workers, err := pipelineAffinityStrategy("team").Order(logger, scenario.Pool, scenario.DB.Workers, runtime.ContainerSpec{
+				TeamID:       scenario.TeamID,
+				TeamName:     "team",
+				PipelineID:   scenario.DB.Pipeline.ID(),
+				PipelineName: scenario.DB.Pipeline.Name(),
+				JobID:        scenario.JobID,
+				StepName:     scenario.StepName,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames(workers)).To(Equal([]string{"worker3", "worker1", "worker2"}))
+		})
+
+		Test("does not reorder workers for pipelines out of scope", func() {
+			scenario := Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1"),
+					grt.NewWorker("worker2").
+						WithJobBuildContainerCreatedInDBAndGarden(),
+				),
+			)
+
+			workers, err := pipelineAffinityStrategy("other-team").Order(logger, scenario.Pool, scenario.DB.Workers, runtime.ContainerSpec{
+				TeamID:       scenario.TeamID,
+				TeamName:     "team",
+				PipelineID:   scenario.DB.Pipeline.ID(),
+				PipelineName: scenario.DB.Pipeline.Name(),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames(workers)).To(Equal(workerNames(scenario.DB.Workers)))
+		})
+
+		Test("rejects invalid teams or pipelines", func() {
+			_, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies:       []string{"pipeline-affinity"},
+				PipelineAffinity: []string{"team/pipeline/extra"},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Pipeline Anti-Affinity", func() {
+		pipelineAntiAffinityStrategy := func(groups ...string) worker.PlacementStrategy {
+			strategy, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies:           []string{"pipeline-anti-affinity"},
+				PipelineAntiAffinity: groups,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return strategy
+		}
+
+		withOtherTeamPipeline := func(s *workertest.Scenario) {
+			s.DB.Pipeline = nil
+			s.DB.Run(
+				s.DBBuilder.WithTeam("other-team"),
+				s.DBBuilder.WithPipeline(atc.Config{
+					Jobs: []atc.JobConfig{{Name: s.JobName}},
+				}),
+			)
+			s.TeamID = s.DB.Team.ID()
+		}
+
+		var (
+			scenario *workertest.Scenario
+			spec     runtime.ContainerSpec
+		)
+
+		BeforeEach(func() {
+			scenario = Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithJobBuildContainerCreatedInDBAndGarden(),
+				),
+				withOtherTeamPipeline,
+				workertest.WithWorkers(
+					grt.NewWorker("worker2").
+						WithJobBuildContainerCreatedInDBAndGarden(),
+					grt.NewWorker("worker3"),
+				),
+			)
+
+			spec = runtime.ContainerSpec{
+				TeamID:       scenario.TeamID,
+				TeamName:     "other-team",
+				PipelineID:   scenario.DB.Pipeline.ID(),
+				PipelineName: scenario.DB.Pipeline.Name(),
+			}
+		})
+
+		Test("returns workers running conflicting pipelines last", func() {
+			workers, err := pipelineAntiAffinityStrategy("team,other-team").Order(logger, scenario.Pool, scenario.DB.Workers, spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames(workers)[2]).To(Equal("worker1"))
+		})
+
+		Test("rejects workers running conflicting pipelines", func() {
+			strategy := pipelineAntiAffinityStrategy("team/some-pipeline,other-team")
+
+			err := strategy.Approve(logger, scenario.DB.Worker("worker1"), spec)
+			Expect(err).To(MatchError(worker.ErrPipelineConflict))
+
+			err = strategy.Approve(logger, scenario.DB.Worker("worker2"), spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = strategy.Approve(logger, scenario.DB.Worker("worker3"), spec)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Test("allows pipelines in different groups to share a worker", func() {
+			strategy := pipelineAntiAffinityStrategy("team,some-team", "other-team,some-other-team")
+
+			err := strategy.Approve(logger, scenario.DB.Worker("worker1"), spec)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
 })
 
 func BeOneOf(vals ...interface{}) types.GomegaMatcher {
@@ -24,7 +24,8 @@ type Pool struct {
 	db            DB
 	workerVersion version.Version
 
-	waker chan struct{}
+	waker   chan struct{}
+	waiting *waitingSteps
 }
 
 func NewPool(factory Factory, db DB, workerVersion version.Version) Pool {
@@ -33,7 +34,8 @@ func NewPool(factory Factory, db DB, workerVersion version.Version) Pool {
 		db:            db,
 		workerVersion: workerVersion,
 
-		waker: make(chan struct{}),
+		waker:   make(chan struct{}),
+		waiting: newWaitingSteps(),
 	}
 }
 
@@ -61,9 +63,11 @@ func (pool Pool) FindOrSelectWorker(
 	}
 	var worker db.Worker
 	var pollingTicker *time.Ticker
+	var woken bool
 	for {
+		var yielded bool
 		var err error
-		worker, err = pool.findOrSelectWorker(logger, owner, containerSpec, workerSpec, strategy)
+		worker, yielded, err = pool.findOrSelectWorker(logger, owner, containerSpec, workerSpec, strategy, labels)
 		if err != nil {
 			return nil, err
 		}
@@ -71,6 +75,12 @@ func (pool Pool) FindOrSelectWorker(
 			break
 		}
 
+		if yielded && woken {
+			// Pass on the wake-up in case it was meant for the step with the
+			// higher priority.
+			pool.wake(logger)
+		}
+
 		if pollingTicker == nil {
 			pollingTicker = time.NewTicker(PollingInterval)
 			defer pollingTicker.Stop()
@@ -85,17 +95,22 @@ func (pool Pool) FindOrSelectWorker(
 			metric.Metrics.StepsWaiting[labels].Inc()
 			defer metric.Metrics.StepsWaiting[labels].Dec()
 
+			pool.waiting.add(labels, containerSpec.Priority)
+			defer pool.waiting.remove(labels, containerSpec.Priority)
+
 			if callback != nil {
 				callback.WaitingForWorker(logger)
 			}
 		}
 
+		woken = false
 		select {
 		case <-ctx.Done():
 			logger.Info("aborted-waiting-for-worker")
 			return nil, ctx.Err()
 		case <-pollingTicker.C:
 		case <-pool.waker:
+			woken = true
 		}
 	}
 
@@ -108,17 +123,25 @@ func (pool Pool) FindOrSelectWorker(
 	return pool.factory.NewWorker(logger, worker), nil
 }
 
-func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner, containerSpec runtime.ContainerSpec, workerSpec Spec, strategy PlacementStrategy) (db.Worker, error) {
+func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner, containerSpec runtime.ContainerSpec, workerSpec Spec, strategy PlacementStrategy, labels metric.StepsWaitingLabels) (db.Worker, bool, error) {
 	worker, compatibleWorkers, found, err := pool.findWorkerForContainer(logger, owner, workerSpec)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if found {
-		return worker, nil
+		return worker, false, nil
 	}
 	orderedWorkers, err := strategy.Order(logger, pool, compatibleWorkers, containerSpec)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	// Leave the workers to steps with a higher priority, unless there are
+	// enough idle workers for all of them.
+	outranking := pool.waiting.outranking(labels, containerSpec.Priority)
+	if outranking > 0 && idleWorkers(orderedWorkers) <= outranking {
+		logger.Debug("yielding-to-higher-priority-step")
+		return nil, true, nil
 	}
 
 	var strategyError error
@@ -126,7 +149,7 @@ func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner
 		err := strategy.Approve(logger, candidate, containerSpec)
 
 		if err == nil {
-			return candidate, nil
+			return candidate, false, nil
 		}
 
 		strategyError = multierror.Append(
@@ -137,7 +160,17 @@ func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner
 
 	logger.Debug("all-candidate-workers-rejected-during-selection", lager.Data{"reason": strategyError.Error()})
 
-	return nil, nil
+	return nil, false, nil
+}
+
+func idleWorkers(workers []db.Worker) int {
+	idle := 0
+	for _, worker := range workers {
+		if worker.ActiveContainers() == 0 {
+			idle++
+		}
+	}
+	return idle
 }
 
 func (pool Pool) ReleaseWorker(logger lager.Logger, containerSpec runtime.ContainerSpec, worker runtime.Worker, strategy PlacementStrategy) {
@@ -145,6 +178,10 @@ func (pool Pool) ReleaseWorker(logger lager.Logger, containerSpec runtime.Contai
 
 	// Attempt to wake a random waiting step to see if it can be
 	// scheduled on the recently released worker.
+	pool.wake(logger)
+}
+
+func (pool Pool) wake(logger lager.Logger) {
 	select {
 	case pool.waker <- struct{}{}:
 		logger.Debug("attempted-to-wake-waiting-step")
@@ -337,6 +374,12 @@ func (pool Pool) CreateVolumeForArtifact(logger lager.Logger, spec Spec) (runtim
 	return worker.CreateVolumeForArtifact(logger, spec.TeamID)
 }
 
+// CompatibleWorkers returns the running workers a step with the given spec
+// could be placed on.
+func (pool Pool) CompatibleWorkers(logger lager.Logger, spec Spec) ([]db.Worker, error) {
+	return pool.allCompatibleAndRunningWorkers(logger, spec)
+}
+
 func (pool Pool) allCompatibleAndRunningWorkers(logger lager.Logger, spec Spec) ([]db.Worker, error) {
 	workers, err := pool.db.WorkerFactory.Workers()
 	if err != nil {
@@ -446,6 +489,10 @@ func (pool Pool) isWorkerCompatibleAndRunning(logger lager.Logger, worker db.Wor
 		return false
 	}
 
+	if spec.GPUs > worker.GPUs() {
+		return false
+	}
+
 	return true
 }
 
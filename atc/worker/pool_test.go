@@ -1,6 +1,7 @@
 package worker_test
 
 import (
+	"context"
 	"sync/atomic"
 	"time"
 
@@ -207,6 +208,30 @@ var _ = Describe("Pool", func() {
 			Expect(err).To(MatchError(ContainSubstring("no workers satisfying")))
 		})
 
+		Test("filters out workers without enough GPUs", func() {
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").WithGPUs(1),
+					grt.NewWorker("worker2").WithGPUs(4),
+					grt.NewWorker("worker3"),
+				),
+			)
+
+			worker, err := scenario.Pool.FindOrSelectWorker(
+				ctx,
+				db.NewFixedHandleContainerOwner("my-container"),
+				runtime.ContainerSpec{},
+				worker.Spec{
+					GPUs: 2,
+				},
+				nil,
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(worker.Name()).To(Equal("worker2"))
+		})
+
 		Test("only considers team workers when any team worker is compatible", func() {
 			scenario := Setup(
 				workertest.WithTeam("team"),
@@ -327,6 +352,132 @@ var _ = Describe("Pool", func() {
 				Expect(worker.Name()).To(Equal("worker1"))
 			})
 		})
+
+		Test("steps with a higher priority are given freed up workers first", func() {
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithActiveTasks(1),
+				),
+			)
+
+			strategy, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies:              []string{"limit-active-tasks"},
+				MaxActiveTasksPerWorker: 1,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			worker.PollingInterval = 10 * time.Millisecond
+
+			selectWorker := func(handle string, priority int) (<-chan runtime.Worker, *int32) {
+				workerCh := make(chan runtime.Worker, 1)
+
+				var callbackInvocations int32
+				callback := PoolCallback{
+					waitingForWorker: func() { atomic.AddInt32(&callbackInvocations, 1) },
+				}
+
+				go func() {
+					defer GinkgoRecover()
+
+					worker, err := scenario.Pool.FindOrSelectWorker(
+						ctx,
+						db.NewFixedHandleContainerOwner(handle),
+						runtime.ContainerSpec{Type: db.ContainerTypeTask, Priority: priority},
+						worker.Spec{TeamID: 123},
+						strategy,
+						callback,
+					)
+					Expect(err).ToNot(HaveOccurred())
+
+					workerCh <- worker
+				}()
+
+				return workerCh, &callbackInvocations
+			}
+
+			lowCh, lowWaiting := selectWorker("low-priority-container", 0)
+			Eventually(func() int32 { return atomic.LoadInt32(lowWaiting) }).Should(Equal(int32(1)))
+
+			highCh, highWaiting := selectWorker("high-priority-container", 10)
+			Eventually(func() int32 { return atomic.LoadInt32(highWaiting) }).Should(Equal(int32(1)))
+
+			By("giving the freed up worker to the step with the higher priority", func() {
+				strategy.Release(logger, scenario.Worker("worker1").DBWorker(), runtime.ContainerSpec{Type: db.ContainerTypeTask})
+
+				var worker runtime.Worker
+				Eventually(highCh).Should(Receive(&worker))
+				Expect(worker.Name()).To(Equal("worker1"))
+
+				Consistently(lowCh).ShouldNot(Receive())
+			})
+
+			By("giving the next freed up worker to the step with the lower priority", func() {
+				strategy.Release(logger, scenario.Worker("worker1").DBWorker(), runtime.ContainerSpec{Type: db.ContainerTypeTask})
+
+				var worker runtime.Worker
+				Eventually(lowCh).Should(Receive(&worker))
+				Expect(worker.Name()).To(Equal("worker1"))
+			})
+		})
+
+		Test("steps with a lower priority still find their existing container", func() {
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").
+						WithActiveTasks(1).
+						WithDBContainersInState(grt.Creating, "my-container"),
+				),
+			)
+
+			strategy, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies:              []string{"limit-active-tasks"},
+				MaxActiveTasksPerWorker: 1,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			worker.PollingInterval = 10 * time.Millisecond
+
+			var highWaiting int32
+			callback := PoolCallback{
+				waitingForWorker: func() { atomic.AddInt32(&highWaiting, 1) },
+			}
+
+			highCtx, cancelHigh := context.WithCancel(ctx)
+			highDone := make(chan struct{})
+			defer func() {
+				cancelHigh()
+				Eventually(highDone).Should(BeClosed())
+			}()
+
+			go func() {
+				defer GinkgoRecover()
+				defer close(highDone)
+
+				_, err := scenario.Pool.FindOrSelectWorker(
+					highCtx,
+					db.NewFixedHandleContainerOwner("high-priority-container"),
+					runtime.ContainerSpec{Type: db.ContainerTypeTask, Priority: 10},
+					worker.Spec{TeamID: 123},
+					strategy,
+					callback,
+				)
+				Expect(err).To(Equal(context.Canceled))
+			}()
+
+			Eventually(func() int32 { return atomic.LoadInt32(&highWaiting) }).Should(Equal(int32(1)))
+
+			worker, err := scenario.Pool.FindOrSelectWorker(
+				ctx,
+				db.NewFixedHandleContainerOwner("my-container"),
+				runtime.ContainerSpec{Type: db.ContainerTypeTask},
+				worker.Spec{TeamID: 123},
+				strategy,
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(worker.Name()).To(Equal("worker1"))
+		})
 	})
 
 	Describe("FindResourceCacheVolume", func() {
@@ -10,6 +10,7 @@ type Spec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+	GPUs         int
 }
 
 func (spec Spec) Description() string {
@@ -27,5 +28,9 @@ func (spec Spec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if spec.GPUs > 0 {
+		attrs = append(attrs, fmt.Sprintf("%d GPUs", spec.GPUs))
+	}
+
 	return strings.Join(attrs, ", ")
 }
@@ -3,7 +3,11 @@ package worker
 import (
 	"archive/tar"
 	"context"
+	"errors"
 	"io"
+	"io/ioutil"
+	"path"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -14,12 +18,16 @@ import (
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/concourse/concourse/worker/baggageclaim/throttle"
 	"github.com/hashicorp/go-multierror"
 )
 
 type Streamer struct {
 	compression compression.Compression
 	p2p         P2PConfig
+	delta       DeltaConfig
+	bandwidth   BandwidthConfig
 
 	resourceCacheFactory db.ResourceCacheFactory
 }
@@ -29,11 +37,28 @@ type P2PConfig struct {
 	Timeout time.Duration
 }
 
-func NewStreamer(cacheFactory db.ResourceCacheFactory, compression compression.Compression, p2p P2PConfig) Streamer {
+// DeltaConfig configures streaming resource caches to workers as the
+// difference from a volume they hold for another version of the resource.
+type DeltaConfig struct {
+	Enabled bool
+}
+
+// BandwidthConfig limits the rate volumes are streamed through the ATC at.
+// Volumes streamed P2P are limited by the worker streaming them out, which is
+// configured with the same limit by the worker factory.
+type BandwidthConfig struct {
+	// Limit is the most bytes per second each volume is streamed at, or 0
+	// for no limit.
+	Limit int
+}
+
+func NewStreamer(cacheFactory db.ResourceCacheFactory, compression compression.Compression, p2p P2PConfig, delta DeltaConfig, bandwidth BandwidthConfig) Streamer {
 	return Streamer{
 		resourceCacheFactory: cacheFactory,
 		compression:          compression,
 		p2p:                  p2p,
+		delta:                delta,
+		bandwidth:            bandwidth,
 	}
 }
 
@@ -53,6 +78,21 @@ func (s Streamer) Stream(ctx context.Context, src runtime.Artifact, dst runtime.
 
 	err := s.stream(ctx, src, dst)
 	if err != nil {
+		if errors.Is(err, baggageclaim.ErrDigestMismatch) {
+			logger.Error("digest-mismatch", err)
+
+			mismatchErr := StreamingDigestMismatchError{
+				Handle:   dst.Handle(),
+				ToWorker: dst.DBVolume().WorkerName(),
+			}
+			if isSrcVolume {
+				mismatchErr.Handle = srcVolume.Handle()
+				mismatchErr.FromWorker = srcVolume.DBVolume().WorkerName()
+			}
+
+			return mismatchErr
+		}
+
 		return err
 	}
 
@@ -93,6 +133,13 @@ func (s Streamer) Stream(ctx context.Context, src runtime.Artifact, dst runtime.
 }
 
 func (s Streamer) stream(ctx context.Context, src runtime.Artifact, dst runtime.Volume) error {
+	if s.delta.Enabled {
+		streamed, err := s.deltaStream(ctx, src, dst)
+		if streamed || err != nil {
+			return err
+		}
+	}
+
 	if !s.p2p.Enabled {
 		return s.streamThroughATC(ctx, src, dst)
 	}
@@ -127,7 +174,7 @@ func (s Streamer) streamThroughATC(ctx context.Context, src runtime.Artifact, ds
 
 	defer out.Close()
 
-	return dst.StreamIn(ctx, ".", s.compression, out)
+	return dst.StreamIn(ctx, ".", s.compression, s.throttle(ctx, out))
 }
 
 func (s Streamer) p2pStream(ctx context.Context, src runtime.P2PVolume, dst runtime.P2PVolume) error {
@@ -143,7 +190,8 @@ func (s Streamer) p2pStream(ctx context.Context, src runtime.P2PVolume, dst runt
 		"origin-volume": src.Handle(),
 		"origin-worker": src.DBVolume().WorkerName(),
 		"dest-worker":   dst.DBVolume().WorkerName(),
-		"stream-in-url": streamInUrl,
+		// the fragment holds the key the volume is encrypted with
+		"stream-in-url": strings.SplitN(streamInUrl, "#", 2)[0],
 	})
 	defer outSpan.End()
 
@@ -157,6 +205,89 @@ func (s Streamer) p2pStream(ctx context.Context, src runtime.P2PVolume, dst runt
 	return src.StreamP2POut(putCtx, ".", streamInUrl, s.compression)
 }
 
+// deltaStream streams only the difference between src and a volume for
+// another version of the same resource on dst's worker, which takes less
+// time than streaming it in full, even with P2P streaming, when it changes
+// little between versions.
+//
+// It returns false if there is no such volume, or if either worker can't
+// stream deltas, so that src is streamed in full instead.
+func (s Streamer) deltaStream(ctx context.Context, src runtime.Artifact, dst runtime.Volume) (bool, error) {
+	deltaSrc, ok := src.(runtime.DeltaVolume)
+	if !ok {
+		return false, nil
+	}
+	deltaDst, ok := dst.(runtime.DeltaVolume)
+	if !ok {
+		return false, nil
+	}
+
+	resourceCacheID := deltaSrc.DBVolume().GetResourceCacheID()
+	if resourceCacheID == 0 {
+		return false, nil
+	}
+
+	logger := lagerctx.FromContext(ctx).Session("delta-stream")
+
+	resourceCache, found, err := s.resourceCacheFactory.FindResourceCacheByID(resourceCacheID)
+	if err != nil {
+		logger.Error("failed-to-find-resource-cache", err)
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	base, found, err := deltaDst.FindDeltaBase(ctx, resourceCache)
+	if err != nil {
+		logger.Error("failed-to-find-delta-base", err)
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	_, span := tracing.StartSpan(ctx, "volume.StreamDelta", tracing.Attrs{
+		"origin-volume": deltaSrc.Handle(),
+		"origin-worker": deltaSrc.DBVolume().WorkerName(),
+		"dest-worker":   deltaDst.DBVolume().WorkerName(),
+		"base-volume":   base.Handle(),
+	})
+	defer span.End()
+
+	manifest, err := base.StreamManifest(ctx)
+	if err != nil {
+		logger.Info("failed-to-stream-manifest", lager.Data{"error": err.Error()})
+		return false, nil
+	}
+
+	defer manifest.Close()
+
+	delta, err := deltaSrc.StreamDeltaOut(ctx, manifest, s.compression)
+	if err != nil {
+		logger.Info("failed-to-stream-delta-out", lager.Data{"error": err.Error()})
+		return false, nil
+	}
+
+	defer delta.Close()
+
+	err = deltaDst.StreamDeltaIn(ctx, base, s.compression, s.throttle(ctx, delta))
+	if err != nil {
+		tracing.End(span, err)
+		return true, err
+	}
+
+	metric.Metrics.VolumesDeltaStreamed.Inc()
+
+	return true, nil
+}
+
+// throttle limits the rate a volume is streamed through the ATC at, with a
+// limiter of its own.
+func (s Streamer) throttle(ctx context.Context, stream io.Reader) io.Reader {
+	return throttle.Reader(ctx, stream, throttle.NewLimiter(s.bandwidth.Limit))
+}
+
 func (s Streamer) StreamFile(ctx context.Context, artifact runtime.Artifact, path string) (io.ReadCloser, error) {
 	out, err := artifact.StreamOut(ctx, path, s.compression)
 	if err != nil {
@@ -169,11 +300,17 @@ func (s Streamer) StreamFile(ctx context.Context, artifact runtime.Artifact, pat
 	}
 	tarReader := tar.NewReader(compressionReader)
 
-	_, err = tarReader.Next()
+	header, err := tarReader.Next()
 	if err != nil {
 		return nil, err
 	}
 
+	if header.Typeflag == tar.TypeDir {
+		compressionReader.Close()
+		out.Close()
+		return nil, runtime.ErrIsDirectory
+	}
+
 	return fileReadMultiCloser{
 		Reader: tarReader,
 		closers: []io.Closer{
@@ -183,6 +320,53 @@ func (s Streamer) StreamFile(ctx context.Context, artifact runtime.Artifact, pat
 	}, nil
 }
 
+// StreamDir returns the contents of each regular file directly within the
+// directory at path, keyed by file name. Nested directories are skipped.
+func (s Streamer) StreamDir(ctx context.Context, artifact runtime.Artifact, dir string) (map[string][]byte, error) {
+	out, err := artifact.StreamOut(ctx, dir, s.compression)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	compressionReader, err := s.compression.NewReader(out)
+	if err != nil {
+		return nil, err
+	}
+	defer compressionReader.Close()
+
+	tarReader := tar.NewReader(compressionReader)
+
+	files := map[string][]byte{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(header.Name)
+		if strings.Contains(name, "/") {
+			continue
+		}
+
+		content, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+
+		files[name] = content
+	}
+
+	return files, nil
+}
+
 type fileReadMultiCloser struct {
 	io.Reader
 	closers []io.Closer
@@ -1,10 +1,16 @@
 package worker_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
@@ -12,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/worker/gardenruntime"
 	grt "github.com/concourse/concourse/atc/worker/gardenruntime/gardenruntimetest"
 	"github.com/concourse/concourse/atc/worker/workertest"
+	"github.com/concourse/concourse/worker/baggageclaim"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -236,6 +243,36 @@ var _ = Describe("Streamer", func() {
 		Expect(fileContent).To(Equal([]byte("content 2")))
 	})
 
+	Test("stream a volume which fails checksum validation", func() {
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("src-worker").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("src"),
+					),
+				grt.NewWorker("dst-worker").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("dst"),
+					),
+			),
+		)
+
+		streamer := scenario.Streamer(worker.P2PConfig{
+			Enabled: false,
+		})
+
+		ctx := context.Background()
+		src := scenario.WorkerVolume("src-worker", "src")
+		dst := scenario.WorkerVolume("dst-worker", "dst")
+
+		err := streamer.Stream(ctx, src, corruptingVolume{dst})
+		Expect(err).To(Equal(worker.StreamingDigestMismatchError{
+			Handle:     "src",
+			FromWorker: "src-worker",
+			ToWorker:   "dst-worker",
+		}))
+	})
+
 	Test("stream file from artifact", func() {
 		artifact := runtimetest.Artifact{
 			Content: runtimetest.VolumeContent{
@@ -256,8 +293,94 @@ var _ = Describe("Streamer", func() {
 
 		Expect(fileContent).To(Equal([]byte("content 2")))
 	})
+
+	Test("stream file from a directory", func() {
+		artifact := tarArtifact{
+			{Name: "./", Dir: true},
+			{Name: "./file", Data: "content"},
+		}
+		streamer := Setup().Streamer(worker.P2PConfig{
+			Enabled: false,
+		})
+
+		ctx := context.Background()
+		_, err := streamer.StreamFile(ctx, artifact, "folder")
+		Expect(err).To(Equal(runtime.ErrIsDirectory))
+	})
+
+	Test("stream directory from artifact", func() {
+		artifact := tarArtifact{
+			{Name: "./", Dir: true},
+			{Name: "./file1", Data: "content 1"},
+			{Name: "./file2.json", Data: `{"some":"json"}`},
+			{Name: "./nested/", Dir: true},
+			{Name: "./nested/file3", Data: "content 3"},
+		}
+		streamer := Setup().Streamer(worker.P2PConfig{
+			Enabled: false,
+		})
+
+		ctx := context.Background()
+		files, err := streamer.StreamDir(ctx, artifact, "folder")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(files).To(Equal(map[string][]byte{
+			"file1":      []byte("content 1"),
+			"file2.json": []byte(`{"some":"json"}`),
+		}))
+	})
 })
 
+type tarEntry struct {
+	Name string
+	Dir  bool
+	Data string
+}
+
+// tarArtifact streams out its entries as-is, the same way baggageclaim
+// streams out a directory.
+type tarArtifact []tarEntry
+
+func (artifact tarArtifact) StreamOut(_ context.Context, _ string, compression compression.Compression) (io.ReadCloser, error) {
+	Expect(compression.Encoding()).To(Equal(baggageclaim.GzipEncoding))
+
+	buf := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, entry := range artifact {
+		header := &tar.Header{
+			Name:     entry.Name,
+			Mode:     0644,
+			Typeflag: tar.TypeReg,
+			Size:     int64(len(entry.Data)),
+		}
+		if entry.Dir {
+			header.Mode = 0755
+			header.Typeflag = tar.TypeDir
+		}
+
+		Expect(tarWriter.WriteHeader(header)).To(Succeed())
+		_, err := tarWriter.Write([]byte(entry.Data))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	Expect(tarWriter.Close()).To(Succeed())
+	Expect(gzipWriter.Close()).To(Succeed())
+
+	return ioutil.NopCloser(buf), nil
+}
+
+// corruptingVolume fails to stream in like baggageclaim does when what it
+// receives doesn't match the digest of what was streamed out.
+type corruptingVolume struct {
+	runtime.Volume
+}
+
+func (volume corruptingVolume) StreamIn(context.Context, string, compression.Compression, io.Reader) error {
+	return fmt.Errorf("put: %w", baggageclaim.ErrDigestMismatch)
+}
+
 func baggageclaimVolume(volume runtime.Volume) *grt.Volume {
 	grVolume, ok := volume.(gardenruntime.Volume)
 	Expect(ok).To(BeTrue(), "must be called on a gardenruntime.Volume")
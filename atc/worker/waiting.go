@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// waitingSteps tracks the priorities of the steps waiting for a worker, so
+// that steps can yield to waiting steps with a higher priority when workers
+// are scarce.
+//
+// Steps are grouped by their labels, so that a step only yields to steps with
+// the same requirements for a worker.
+type waitingSteps struct {
+	lock       sync.Mutex
+	priorities map[metric.StepsWaitingLabels][]int
+}
+
+func newWaitingSteps() *waitingSteps {
+	return &waitingSteps{
+		priorities: map[metric.StepsWaitingLabels][]int{},
+	}
+}
+
+func (waiting *waitingSteps) add(labels metric.StepsWaitingLabels, priority int) {
+	waiting.lock.Lock()
+	defer waiting.lock.Unlock()
+
+	waiting.priorities[labels] = append(waiting.priorities[labels], priority)
+}
+
+func (waiting *waitingSteps) remove(labels metric.StepsWaitingLabels, priority int) {
+	waiting.lock.Lock()
+	defer waiting.lock.Unlock()
+
+	priorities := waiting.priorities[labels]
+	for i, p := range priorities {
+		if p == priority {
+			priorities = append(priorities[:i], priorities[i+1:]...)
+			break
+		}
+	}
+
+	if len(priorities) == 0 {
+		delete(waiting.priorities, labels)
+	} else {
+		waiting.priorities[labels] = priorities
+	}
+}
+
+// outranking returns how many steps with a higher priority are waiting.
+func (waiting *waitingSteps) outranking(labels metric.StepsWaitingLabels, priority int) int {
+	waiting.lock.Lock()
+	defer waiting.lock.Unlock()
+
+	outranking := 0
+	for _, p := range waiting.priorities[labels] {
+		if p > priority {
+			outranking++
+		}
+	}
+
+	return outranking
+}
@@ -153,5 +153,9 @@ func (s *Scenario) ContainerVolume(workerName string, containerHandle string, mo
 }
 
 func (s *Scenario) Streamer(p2p worker.P2PConfig) worker.Streamer {
-	return worker.NewStreamer(s.Factory.DB.ResourceCacheFactory, compression.NewGzipCompression(), p2p)
+	return s.DeltaStreamer(p2p, worker.DeltaConfig{})
+}
+
+func (s *Scenario) DeltaStreamer(p2p worker.P2PConfig, delta worker.DeltaConfig) worker.Streamer {
+	return worker.NewStreamer(s.Factory.DB.ResourceCacheFactory, compression.NewGzipCompression(), p2p, delta, worker.BandwidthConfig{})
 }
@@ -0,0 +1,39 @@
+package atc
+
+import "time"
+
+const DefaultWorkerEnrollmentTokenTTL = time.Hour
+
+// WorkerEnrollmentTokenRequest is submitted by an admin to issue a new
+// enrollment token. Workers enrolled with the token are restricted to the
+// given team and may only register with a subset of the given tags.
+type WorkerEnrollmentTokenRequest struct {
+	Team string        `json:"team,omitempty"`
+	Tags []string      `json:"tags,omitempty"`
+	TTL  time.Duration `json:"ttl,omitempty"`
+}
+
+// WorkerEnrollmentToken describes an issued enrollment token. The token value
+// itself is only returned once, when the token is created.
+type WorkerEnrollmentToken struct {
+	ID         int      `json:"id"`
+	Token      string   `json:"token,omitempty"`
+	Team       string   `json:"team,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	ExpiresAt  int64    `json:"expires_at"`
+	EnrolledAt int64    `json:"enrolled_at,omitempty"`
+	PublicKey  string   `json:"public_key,omitempty"`
+}
+
+type WorkerEnrollmentRequest struct {
+	Token     string `json:"token"`
+	PublicKey string `json:"public_key"`
+}
+
+// EnrolledWorkerKey is a worker public key which has been exchanged for an
+// enrollment token, along with the restrictions carried over from the token.
+type EnrolledWorkerKey struct {
+	PublicKey string   `json:"public_key"`
+	Team      string   `json:"team,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
@@ -47,11 +47,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
-			atc.ListBuildArtifacts:
+			atc.GetBuildPlanDAG,
+			atc.ListBuildArtifacts,
+			atc.GetBuildTestResults:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
 		case atc.AbortBuild,
+			atc.PauseBuild,
+			atc.ResumeBuild,
 			atc.SetBuildComment:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
@@ -90,6 +94,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
+			atc.ListEnrolledWorkerKeys,
 			atc.ListTeamBuilds,
 			atc.GetUser:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
@@ -105,7 +110,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllResources,
 			atc.ListBuilds,
 			atc.MainJobBadge,
-			atc.GetWall:
+			atc.GetWall,
+			atc.EnrollWorker:
 			newHandler = auth.CheckAuthenticationIfProvidedHandler(handler, rejector)
 
 		// admin
@@ -115,7 +121,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
 			atc.SetWall,
-			atc.ClearWall:
+			atc.ClearWall,
+			atc.CreateWorkerEnrollmentToken,
+			atc.ListWorkerEnrollmentTokens,
+			atc.DeleteWorkerEnrollmentToken:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team and has required role, or is admin)
@@ -139,6 +148,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.PinResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.PauseResourceChecks,
+			atc.UnpauseResourceChecks,
 			atc.GetConfig,
 			atc.GetCC,
 			atc.GetVersionsDB,
@@ -153,9 +164,13 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig,
+			atc.PreviewPipeline,
 			atc.ArchivePipeline,
 			atc.ClearTaskCache,
 			atc.ClearResourceCache,
+			atc.CreateResourceWebhookToken,
+			atc.ListResourceWebhookTokens,
+			atc.RotateResourceWebhookTokens,
 			atc.CreateArtifact,
 			atc.ScheduleJob,
 			atc.GetArtifact:
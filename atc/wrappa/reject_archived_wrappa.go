@@ -36,6 +36,10 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.PinResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.PauseResourceChecks,
+			atc.UnpauseResourceChecks,
+			atc.CreateResourceWebhookToken,
+			atc.RotateResourceWebhookTokens,
 			atc.RerunJobBuild:
 
 			newHandler = rw.handlerFactory.RejectArchived(handler)
@@ -47,9 +51,13 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.BuildResources,
 			atc.BuildEvents,
 			atc.ListBuildArtifacts,
+			atc.GetBuildTestResults,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
+			atc.GetBuildPlanDAG,
 			atc.AbortBuild,
+			atc.PauseBuild,
+			atc.ResumeBuild,
 			atc.SetBuildComment,
 			atc.PruneWorker,
 			atc.LandWorker,
@@ -72,6 +80,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListResources,
 			atc.ListResourceTypes,
 			atc.ListResourceVersions,
+			atc.ListResourceWebhookTokens,
 			atc.GetDownstreamResourceCausality,
 			atc.GetUpstreamResourceCausality,
 			atc.GetResourceVersion,
@@ -107,6 +116,11 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListActiveUsersSince,
 			atc.SetWall,
 			atc.ClearWall,
+			atc.CreateWorkerEnrollmentToken,
+			atc.ListWorkerEnrollmentTokens,
+			atc.DeleteWorkerEnrollmentToken,
+			atc.EnrollWorker,
+			atc.ListEnrolledWorkerKeys,
 			atc.DeletePipeline,
 			atc.GetCC,
 			atc.GetVersionsDB,
@@ -116,6 +130,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ArchivePipeline,
 			atc.RenamePipeline,
 			atc.SaveConfig,
+			atc.PreviewPipeline,
 			atc.PauseJob,
 			atc.UnpauseJob,
 			atc.ExposePipeline,
@@ -43,6 +43,10 @@ var _ = Describe("RejectArchivedWrappa", func() {
 			atc.PinResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.PauseResourceChecks,
+			atc.UnpauseResourceChecks,
+			atc.CreateResourceWebhookToken,
+			atc.RotateResourceWebhookTokens,
 			atc.RerunJobBuild,
 		}
 
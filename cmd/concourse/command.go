@@ -21,6 +21,8 @@ type ConcourseCommand struct {
 	RetireWorker retire.RetireWorkerCommand `command:"retire-worker" description:"Safely remove a worker from the cluster permanently."`
 
 	GenerateKey GenerateKeyCommand `command:"generate-key" description:"Generate RSA key for use with Concourse components."`
+
+	ReplayBuildTrace ReplayBuildTraceCommand `command:"replay-build-trace" description:"Replay a build trace against the step builder, for debugging the engine."`
 }
 
 func (cmd ConcourseCommand) LessenRequirements(parser *flags.Parser) {
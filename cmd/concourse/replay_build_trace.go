@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/flag"
+)
+
+type ReplayBuildTraceCommand struct {
+	Trace flag.File `short:"t"  long:"trace"  required:"true"  description:"Path to a build trace recorded with --build-trace-dir."`
+}
+
+func (cmd *ReplayBuildTraceCommand) Execute(args []string) error {
+	trace, err := engine.LoadBuildTrace(cmd.Trace.Path())
+	if err != nil {
+		return fmt.Errorf("failed to load build trace: %s", err)
+	}
+
+	fmt.Printf("replaying build %d (%s)\n", trace.Build.ID, trace.Build.Name)
+
+	ok, err := engine.ReplayBuildTrace(context.Background(), trace, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("replay errored: %s", err)
+	}
+
+	if !ok {
+		fmt.Println("replay failed")
+		os.Exit(1)
+	}
+
+	fmt.Println("replay succeeded")
+
+	return nil
+}
@@ -74,6 +74,8 @@ func (command *ExecuteCommand) Execute(args []string) error {
 
 	if imageResource != nil {
 		taskConfig.ImageResource = imageResource
+		// the image from the job replaces any pulled by digest
+		taskConfig.Image = nil
 	}
 
 	outputs, err := executehelpers.DetermineOutputs(
@@ -16,6 +16,9 @@ type FlyCommand struct {
 
 	Verbose bool `long:"verbose" description:"Print API requests and responses"`
 
+	AsTeam func(string) `long:"as-team" value-name:"TEAM" description:"Act as a member of the given team (admins only). Every request is recorded in the audit log."`
+	AsRole func(string) `long:"as-role" value-name:"ROLE" description:"Role to act as when using --as-team (owner, member, operator, or viewer). Defaults to owner."`
+
 	PrintTableHeaders bool `long:"print-table-headers" description:"Print table headers even for redirected output"`
 
 	Login  LoginCommand  `command:"login" alias:"l" description:"Authenticate with the target"`
@@ -67,6 +70,8 @@ type FlyCommand struct {
 	CheckResource          CheckResourceCommand          `command:"check-resource"             alias:"cr"   description:"Check a resource"`
 	PinResource            PinResourceCommand            `command:"pin-resource"               alias:"pr"   description:"Pin a version to a resource"`
 	UnpinResource          UnpinResourceCommand          `command:"unpin-resource"             alias:"ur"   description:"Unpin a resource"`
+	PauseResourceChecks    PauseResourceChecksCommand    `command:"pause-resource-checks"      alias:"prc"  description:"Pause periodic checks of a resource"`
+	UnpauseResourceChecks  UnpauseResourceChecksCommand  `command:"unpause-resource-checks"    alias:"uprc" description:"Unpause periodic checks of a resource"`
 	EnableResourceVersion  EnableResourceVersionCommand  `command:"enable-resource-version"    alias:"erv"  description:"Enable a version of a resource"`
 	DisableResourceVersion DisableResourceVersionCommand `command:"disable-resource-version"   alias:"drv"  description:"Disable a version of a resource"`
 	ClearResourceCache     ClearResourceCacheCommand     `command:"clear-resource-cache"       alias:"crc"  description:"Clear cache of a resource"`
@@ -75,9 +80,11 @@ type FlyCommand struct {
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
-	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	Builds      BuildsCommand      `command:"builds"       alias:"bs"  description:"List builds data"`
+	AbortBuild  AbortBuildCommand  `command:"abort-build"  alias:"ab"  description:"Abort a build"`
+	PauseBuild  PauseBuildCommand  `command:"pause-build"  alias:"pb"  description:"Pause a build before it starts its next step"`
+	ResumeBuild ResumeBuildCommand `command:"resume-build" alias:"rsb" description:"Resume a paused build"`
+	RerunBuild  RerunBuildCommand  `command:"rerun-build"  alias:"rb"  description:"Rerun a build"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/url"
 	"os"
 	"sort"
@@ -24,14 +25,17 @@ import (
 )
 
 type HijackCommand struct {
-	Job            flaghelpers.JobFlag      `short:"j" long:"job"   value-name:"PIPELINE/JOB"   description:"Name of a job to hijack"`
-	Handle         string                   `          long:"handle"                            description:"Handle id of a job to hijack"`
-	Check          flaghelpers.ResourceFlag `short:"c" long:"check" value-name:"PIPELINE/CHECK" description:"Name of a resource's checking container to hijack"`
-	Url            string                   `short:"u" long:"url"                               description:"URL for the build, job, or check container to hijack"`
-	Build          string                   `short:"b" long:"build"                             description:"Build number within the job, or global build ID"`
-	StepName       string                   `short:"s" long:"step"                              description:"Name of step to hijack (e.g. build, unit, resource name)"`
-	StepType       string                   `          long:"step-type"                         description:"Type of step to hijack (e.g. get, put, task)"`
-	Attempt        string                   `short:"a" long:"attempt" value-name:"N[,N,...]"    description:"Attempt number of step to hijack."`
+	Job            flaghelpers.JobFlag           `short:"j" long:"job"   value-name:"PIPELINE/JOB"   description:"Name of a job to hijack"`
+	Handle         string                        `          long:"handle"                            description:"Handle id of a job to hijack"`
+	Check          flaghelpers.ResourceFlag      `short:"c" long:"check" value-name:"PIPELINE/CHECK" description:"Name of a resource's checking container to hijack"`
+	Url            string                        `short:"u" long:"url"                               description:"URL for the build, job, or check container to hijack"`
+	Build          string                        `short:"b" long:"build"                             description:"Build number within the job, or global build ID"`
+	StepName       string                        `short:"s" long:"step"                              description:"Name of step to hijack (e.g. build, unit, resource name)"`
+	StepType       string                        `          long:"step-type"                         description:"Type of step to hijack (e.g. get, put, task)"`
+	Attempt        string                        `short:"a" long:"attempt" value-name:"N[,N,...]"    description:"Attempt number of step to hijack."`
+	CopyTo         []flaghelpers.CopyFlag        `          long:"copy-to"   value-name:"LOCAL:REMOTE"   description:"Copy a local file into the container before running the command. Can be specified multiple times."`
+	CopyFrom       []flaghelpers.CopyFlag        `          long:"copy-from" value-name:"REMOTE:LOCAL"   description:"Copy a file out of the container after running the command. Can be specified multiple times."`
+	Forward        []flaghelpers.PortForwardFlag `          long:"forward"   value-name:"LOCAL:CONTAINER" description:"Forward a local port to a port in the container for as long as the command runs. Can be specified multiple times."`
 	PositionalArgs struct {
 		Command []string `positional-arg-name:"command" description:"The command to run in the container (default: bash)"`
 	} `positional-args:"yes"`
@@ -170,6 +174,43 @@ func (command *HijackCommand) Execute([]string) error {
 		}
 	}
 
+	ctx := context.Background()
+	h := hijacker.New(target.TLSConfig(), reqGenerator, target.Token())
+
+	for _, copyTo := range command.CopyTo {
+		err = copyToContainer(ctx, h, team.Name(), chosenContainer, copyTo)
+		if err != nil {
+			return err
+		}
+	}
+
+	// when only copying files there's no need to start a shell
+	copying := len(command.CopyTo) > 0 || len(command.CopyFrom) > 0
+	if copying && len(command.Forward) == 0 && len(command.PositionalArgs.Command) == 0 {
+		for _, copyFrom := range command.CopyFrom {
+			err = copyFromContainer(ctx, h, team.Name(), chosenContainer, copyFrom)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	forwardCtx, stopForwarding := context.WithCancel(ctx)
+	defer stopForwarding()
+
+	for _, forward := range command.Forward {
+		listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", forward.LocalPort))
+		if err != nil {
+			return fmt.Errorf("failed to listen on local port %d: %w", forward.LocalPort, err)
+		}
+
+		fmt.Fprintf(os.Stderr, "forwarding %s to port %d in the container\n", listener.Addr(), forward.ContainerPort)
+
+		go h.Forward(forwardCtx, team.Name(), chosenContainer.ID, chosenContainer.User, listener, forward.ContainerPort)
+	}
+
 	path, args := remoteCommand(command.PositionalArgs.Command)
 
 	someShell := false
@@ -219,8 +260,6 @@ func (command *HijackCommand) Execute([]string) error {
 			Err: os.Stderr,
 		}
 
-		ctx := context.Background()
-		h := hijacker.New(target.TLSConfig(), reqGenerator, target.Token())
 		result, exeNotFound, err := h.Hijack(ctx, team.Name(), chosenContainer.ID, spec, io)
 
 		if exeNotFound && someShell {
@@ -235,11 +274,52 @@ func (command *HijackCommand) Execute([]string) error {
 		return err
 	}
 
+	stopForwarding()
+
+	for _, copyFrom := range command.CopyFrom {
+		err = copyFromContainer(ctx, h, team.Name(), chosenContainer, copyFrom)
+		if err != nil {
+			return err
+		}
+	}
+
 	os.Exit(result)
 
 	return nil
 }
 
+func copyToContainer(ctx context.Context, h *hijacker.Hijacker, teamName string, container atc.Container, copyTo flaghelpers.CopyFlag) error {
+	src, err := os.Open(copyTo.Source)
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	err = h.CopyTo(ctx, teamName, container.ID, container.User, src, copyTo.Destination)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", copyTo.Source, copyTo.Destination, err)
+	}
+
+	return nil
+}
+
+func copyFromContainer(ctx context.Context, h *hijacker.Hijacker, teamName string, container atc.Container, copyFrom flaghelpers.CopyFlag) error {
+	dest, err := os.Create(copyFrom.Destination)
+	if err != nil {
+		return err
+	}
+
+	defer dest.Close()
+
+	err = h.CopyFrom(ctx, teamName, container.ID, container.User, copyFrom.Source, dest)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", copyFrom.Source, copyFrom.Destination, err)
+	}
+
+	return nil
+}
+
 func parseUrlPath(urlPath string) map[string]string {
 	pathWithoutFirstSlash := strings.Replace(urlPath, "/", "", 1)
 	urlComponents := strings.Split(pathWithoutFirstSlash, "/")
@@ -0,0 +1,13 @@
+package commands
+
+import "github.com/concourse/concourse/fly/rc"
+
+func init() {
+	Fly.AsTeam = func(team string) {
+		rc.Impersonation.Team = team
+	}
+
+	Fly.AsRole = func(role string) {
+		rc.Impersonation.Role = role
+	}
+}
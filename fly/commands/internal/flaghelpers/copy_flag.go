@@ -0,0 +1,23 @@
+package flaghelpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+type CopyFlag struct {
+	Source      string
+	Destination string
+}
+
+func (flag *CopyFlag) UnmarshalFlag(value string) error {
+	idx := strings.LastIndex(value, ":")
+	if idx <= 0 || idx == len(value)-1 {
+		return fmt.Errorf("invalid copy '%s' (must be source:destination)", value)
+	}
+
+	flag.Source = value[:idx]
+	flag.Destination = value[idx+1:]
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package flaghelpers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+var _ = Describe("CopyFlag", func() {
+	Describe("UnmarshalFlag", func() {
+		var flag *flaghelpers.CopyFlag
+
+		BeforeEach(func() {
+			flag = &flaghelpers.CopyFlag{}
+		})
+
+		for _, tt := range []struct {
+			desc        string
+			flag        string
+			source      string
+			destination string
+			err         string
+		}{
+			{
+				desc:        "basic",
+				flag:        "some/local/file:/tmp/some-file",
+				source:      "some/local/file",
+				destination: "/tmp/some-file",
+			},
+			{
+				desc:        "splits on the last colon",
+				flag:        `C:\some\file:/tmp/some-file`,
+				source:      `C:\some\file`,
+				destination: "/tmp/some-file",
+			},
+			{
+				desc: "errors without a colon",
+				flag: "some-file",
+				err:  "invalid copy 'some-file' (must be source:destination)",
+			},
+			{
+				desc: "errors without a destination",
+				flag: "some-file:",
+				err:  "invalid copy 'some-file:' (must be source:destination)",
+			},
+			{
+				desc: "errors without a source",
+				flag: ":/tmp/some-file",
+				err:  "invalid copy ':/tmp/some-file' (must be source:destination)",
+			},
+		} {
+			tt := tt
+
+			It(tt.desc, func() {
+				err := flag.UnmarshalFlag(tt.flag)
+				if tt.err == "" {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(flag.Source).To(Equal(tt.source))
+					Expect(flag.Destination).To(Equal(tt.destination))
+				} else {
+					Expect(err).To(MatchError(tt.err))
+				}
+			})
+		}
+	})
+})
@@ -0,0 +1,36 @@
+package flaghelpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type PortForwardFlag struct {
+	LocalPort     uint16
+	ContainerPort uint16
+}
+
+func (flag *PortForwardFlag) UnmarshalFlag(value string) error {
+	ports := strings.SplitN(value, ":", 2)
+	if len(ports) != 2 {
+		return fmt.Errorf("invalid port forward '%s' (must be localport:containerport)", value)
+	}
+
+	local, container := ports[0], ports[1]
+
+	localPort, err := strconv.ParseUint(local, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid local port '%s'", local)
+	}
+
+	containerPort, err := strconv.ParseUint(container, 10, 16)
+	if err != nil || containerPort == 0 {
+		return fmt.Errorf("invalid container port '%s'", container)
+	}
+
+	flag.LocalPort = uint16(localPort)
+	flag.ContainerPort = uint16(containerPort)
+
+	return nil
+}
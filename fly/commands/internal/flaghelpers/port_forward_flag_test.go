@@ -0,0 +1,67 @@
+package flaghelpers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+var _ = Describe("PortForwardFlag", func() {
+	Describe("UnmarshalFlag", func() {
+		var flag *flaghelpers.PortForwardFlag
+
+		BeforeEach(func() {
+			flag = &flaghelpers.PortForwardFlag{}
+		})
+
+		for _, tt := range []struct {
+			desc          string
+			flag          string
+			localPort     uint16
+			containerPort uint16
+			err           string
+		}{
+			{
+				desc:          "basic",
+				flag:          "8080:80",
+				localPort:     8080,
+				containerPort: 80,
+			},
+			{
+				desc:          "allows any free local port",
+				flag:          "0:5432",
+				localPort:     0,
+				containerPort: 5432,
+			},
+			{
+				desc: "errors without a container port",
+				flag: "8080",
+				err:  "invalid port forward '8080' (must be localport:containerport)",
+			},
+			{
+				desc: "errors if the local port is not a number",
+				flag: "http:80",
+				err:  "invalid local port 'http'",
+			},
+			{
+				desc: "errors if the container port is out of range",
+				flag: "8080:65536",
+				err:  "invalid container port '65536'",
+			},
+		} {
+			tt := tt
+
+			It(tt.desc, func() {
+				err := flag.UnmarshalFlag(tt.flag)
+				if tt.err == "" {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(flag.LocalPort).To(Equal(tt.localPort))
+					Expect(flag.ContainerPort).To(Equal(tt.containerPort))
+				} else {
+					Expect(err).To(MatchError(tt.err))
+				}
+			})
+		}
+	})
+})
@@ -69,7 +69,9 @@ func (h *Hijacker) Hijack(ctx context.Context, teamName, handle string, spec atc
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	go h.monitorTTYSize(ctx, pio.In)
+	if spec.TTY != nil {
+		go h.monitorTTYSize(ctx, pio.In)
+	}
 	go h.handleInput(ctx, conn, pio.In)
 
 	exitStatus, exeNotFound := h.handleOutput(conn, pio)
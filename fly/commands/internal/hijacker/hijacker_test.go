@@ -4,7 +4,10 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -71,6 +74,167 @@ var _ = Describe("Hijacker", func() {
 		server.Close()
 	})
 
+	// processHandler runs a fake process which echoes its stdin back on stdout
+	// until stdin is closed, after which it exits with the given status.
+	processHandler := func(specs chan<- atc.HijackProcessSpec, stdout []byte, stderr []byte, exitStatus int) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/containers/some-handle/hijack"),
+			func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				conn, err := upgrader.Upgrade(w, r, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer conn.Close()
+
+				var spec atc.HijackProcessSpec
+				err = conn.ReadJSON(&spec)
+				Expect(err).NotTo(HaveOccurred())
+
+				specs <- spec
+
+				if len(stdout) > 0 {
+					err = conn.WriteJSON(atc.HijackOutput{Stdout: stdout})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				for {
+					var input atc.HijackInput
+					err = conn.ReadJSON(&input)
+					Expect(err).NotTo(HaveOccurred())
+
+					if input.Closed {
+						break
+					}
+
+					err = conn.WriteJSON(atc.HijackOutput{Stdout: input.Stdin})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				if len(stderr) > 0 {
+					err = conn.WriteJSON(atc.HijackOutput{Stderr: stderr})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				err = conn.WriteJSON(atc.HijackOutput{ExitStatus: &exitStatus})
+				Expect(err).NotTo(HaveOccurred())
+
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			},
+		)
+	}
+
+	Describe("transferring data", func() {
+		var (
+			h     *hijacker.Hijacker
+			specs chan atc.HijackProcessSpec
+		)
+
+		BeforeEach(func() {
+			specs = make(chan atc.HijackProcessSpec, 10)
+			h = hijacker.New(&tls.Config{}, rata.NewRequestGenerator(server.URL(), atc.Routes), nil)
+		})
+
+		Describe("CopyTo", func() {
+			Context("when the process succeeds", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(processHandler(specs, nil, nil, 0))
+				})
+
+				It("streams the source to a file in the container", func() {
+					err := h.CopyTo(context.Background(), "some-team", "some-handle", "some-user", strings.NewReader("some contents"), "/tmp/some-file")
+					Expect(err).NotTo(HaveOccurred())
+
+					var spec atc.HijackProcessSpec
+					Eventually(specs).Should(Receive(&spec))
+					Expect(spec.Path).To(Equal("sh"))
+					Expect(spec.Args).To(Equal([]string{"-c", `cat > "$0"`, "/tmp/some-file"}))
+					Expect(spec.User).To(Equal("some-user"))
+					Expect(spec.TTY).To(BeNil())
+				})
+			})
+
+			Context("when the process fails", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(processHandler(specs, nil, []byte("permission denied\n"), 1))
+				})
+
+				It("returns an error including its stderr", func() {
+					err := h.CopyTo(context.Background(), "some-team", "some-handle", "some-user", strings.NewReader("some contents"), "/tmp/some-file")
+					Expect(err).To(MatchError("exit status 1: permission denied"))
+				})
+			})
+		})
+
+		Describe("CopyFrom", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(processHandler(specs, []byte("some contents"), nil, 0))
+			})
+
+			It("writes the file in the container to the destination", func() {
+				dest := gbytes.NewBuffer()
+
+				err := h.CopyFrom(context.Background(), "some-team", "some-handle", "some-user", "/tmp/some-file", dest)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(dest.Contents()).To(Equal([]byte("some contents")))
+
+				var spec atc.HijackProcessSpec
+				Eventually(specs).Should(Receive(&spec))
+				Expect(spec.Path).To(Equal("cat"))
+				Expect(spec.Args).To(Equal([]string{"/tmp/some-file"}))
+			})
+		})
+
+		Describe("Forward", func() {
+			var (
+				listener net.Listener
+				cancel   context.CancelFunc
+				done     chan error
+			)
+
+			BeforeEach(func() {
+				server.AppendHandlers(processHandler(specs, nil, nil, 0))
+
+				var err error
+				listener, err = net.Listen("tcp", "127.0.0.1:0")
+				Expect(err).NotTo(HaveOccurred())
+
+				var ctx context.Context
+				ctx, cancel = context.WithCancel(context.Background())
+
+				done = make(chan error, 1)
+				go func() {
+					done <- h.Forward(ctx, "some-team", "some-handle", "some-user", listener, 8080)
+				}()
+			})
+
+			AfterEach(func() {
+				cancel()
+				Eventually(done).Should(Receive(BeNil()))
+			})
+
+			It("tunnels connections to the port in the container", func() {
+				conn, err := net.Dial("tcp", listener.Addr().String())
+				Expect(err).NotTo(HaveOccurred())
+
+				defer conn.Close()
+
+				_, err = conn.Write([]byte("ping"))
+				Expect(err).NotTo(HaveOccurred())
+
+				response := make([]byte, 4)
+				_, err = io.ReadFull(conn, response)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(response)).To(Equal("ping"))
+
+				var spec atc.HijackProcessSpec
+				Eventually(specs).Should(Receive(&spec))
+				Expect(spec.Path).To(Equal("sh"))
+				Expect(spec.Args[len(spec.Args)-1]).To(Equal("8080"))
+			})
+		})
+	})
+
 	Describe("keeping the connection alive", func() {
 		BeforeEach(func() {
 			server.AppendHandlers(wasPingedHandler("hello", didHijack, didGetPing))
@@ -0,0 +1,143 @@
+package hijacker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+// forwardScript connects its stdin and stdout to a port on the container's
+// loopback interface using whichever tool the image happens to have.
+const forwardScript = `
+if command -v socat >/dev/null 2>&1; then
+  exec socat - "TCP:127.0.0.1:$0"
+elif command -v nc >/dev/null 2>&1; then
+  exec nc 127.0.0.1 "$0"
+else
+  exec bash -c 'exec 3<>"/dev/tcp/127.0.0.1/$0"; cat <&3 & exec cat >&3' "$0"
+fi
+`
+
+// CopyTo writes everything read from src to the file at dest in the
+// container, replacing it if it already exists.
+func (h *Hijacker) CopyTo(ctx context.Context, teamName, handle, user string, src io.Reader, dest string) error {
+	spec := atc.HijackProcessSpec{
+		Path: "sh",
+		Args: []string{"-c", `cat > "$0"`, dest},
+		User: user,
+	}
+
+	return h.run(ctx, teamName, handle, spec, src, ioutil.Discard)
+}
+
+// CopyFrom writes the contents of the file at src in the container to dest.
+func (h *Hijacker) CopyFrom(ctx context.Context, teamName, handle, user, src string, dest io.Writer) error {
+	spec := atc.HijackProcessSpec{
+		Path: "cat",
+		Args: []string{src},
+		User: user,
+	}
+
+	return h.run(ctx, teamName, handle, spec, bytes.NewReader(nil), dest)
+}
+
+// Forward accepts connections on the listener until the context is done,
+// tunnelling each of them over its own hijacked process to the given port in
+// the container.
+func (h *Hijacker) Forward(ctx context.Context, teamName, handle, user string, listener net.Listener, port uint16) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	spec := atc.HijackProcessSpec{
+		Path: "sh",
+		Args: []string{"-c", forwardScript, strconv.Itoa(int(port))},
+		User: user,
+	}
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		go func() {
+			defer conn.Close()
+
+			err := h.run(ctx, teamName, handle, spec, conn, conn)
+			if err != nil && ctx.Err() == nil {
+				fmt.Fprintf(ui.Stderr, "failed to forward connection to port %d: %s\n", port, err)
+			}
+		}()
+	}
+}
+
+func (h *Hijacker) run(ctx context.Context, teamName, handle string, spec atc.HijackProcessSpec, stdin io.Reader, stdout io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	inputs := make(chan atc.HijackInput)
+	go sendInput(ctx, stdin, inputs)
+
+	stderr := new(bytes.Buffer)
+
+	exitStatus, exeNotFound, err := h.Hijack(ctx, teamName, handle, spec, ProcessIO{
+		In:  inputs,
+		Out: stdout,
+		Err: stderr,
+	})
+	if err != nil {
+		return err
+	}
+
+	if exeNotFound {
+		return fmt.Errorf("executable not found in container: %s", spec.Path)
+	}
+
+	if exitStatus != 0 {
+		return fmt.Errorf("exit status %d: %s", exitStatus, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+func sendInput(ctx context.Context, r io.Reader, inputs chan<- atc.HijackInput) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			// the buffer is reused for the next read, while the input may not
+			// have been sent yet
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+
+			select {
+			case inputs <- atc.HijackInput{Stdin: chunk}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	select {
+	case inputs <- atc.HijackInput{Closed: true}:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type PauseResourceChecksCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+}
+
+func (command *PauseResourceChecksCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := target.Team()
+
+	paused, err := team.PauseResourceChecks(command.Resource.PipelineRef, command.Resource.ResourceName)
+	if err != nil {
+		return err
+	}
+
+	if paused {
+		fmt.Printf("paused checks of '%s/%s'\n", command.Resource.PipelineRef.String(), command.Resource.ResourceName)
+	} else {
+		displayhelpers.Failf("could not find resource '%s/%s'\n", command.Resource.PipelineRef.String(), command.Resource.ResourceName)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
@@ -13,9 +14,10 @@ import (
 )
 
 type RerunBuildCommand struct {
-	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of the job that you want to rerun a build for"`
-	Build string              `short:"b" long:"build" required:"true" description:"The number of the build to rerun"`
-	Watch bool                `short:"w" long:"watch" description:"Start watching the rerun build output"`
+	Job         flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of the job that you want to rerun a build for"`
+	Build       string              `short:"b" long:"build" required:"true" description:"The number of the build to rerun"`
+	Watch       bool                `short:"w" long:"watch" description:"Start watching the rerun build output"`
+	FromFailure bool                `long:"from-failure" description:"Only run the steps which did not succeed, replaying the steps which did from the original build"`
 }
 
 func (command *RerunBuildCommand) Execute(args []string) error {
@@ -32,7 +34,12 @@ func (command *RerunBuildCommand) Execute(args []string) error {
 		return err
 	}
 
-	build, err := target.Team().RerunJobBuild(pipelineRef, jobName, buildName)
+	var build atc.Build
+	if command.FromFailure {
+		build, err = target.Team().RerunJobBuildFromFailure(pipelineRef, jobName, buildName)
+	} else {
+		build, err = target.Team().RerunJobBuild(pipelineRef, jobName, buildName)
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ResumeBuildCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to resume"`
+	Build string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to resume. If job not specified: build id"`
+}
+
+func (command *ResumeBuildCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(command.Build)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, command.Build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	if err := target.Client().ResumeBuild(strconv.Itoa(build.ID)); err != nil {
+		return err
+	}
+
+	fmt.Println("build successfully resumed")
+	return nil
+}
@@ -71,6 +71,50 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			dstImpl.SetTimestamp(0)
 			fmt.Fprintf(dstImpl, "%s\n", errCol(e.Message))
 
+		case event.HookTimeout:
+			errCol := ui.ErroredColor.SprintFunc()
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "%s\n", errCol(fmt.Sprintf("hook timed out after %s", e.Duration)))
+
+		case event.TimeoutWarning:
+			warnCol := ui.StartedColor.SprintFunc()
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "%s\n", warnCol(fmt.Sprintf("step has been running for longer than %s", e.Duration)))
+
+		case event.Rescheduled:
+			warnCol := ui.StartedColor.SprintFunc()
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "%s\n", warnCol(fmt.Sprintf("worker went away (%s), rescheduling step", e.Reason)))
+
+		case event.CheckTimeout:
+			errCol := ui.ErroredColor.SprintFunc()
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "%s\n", errCol(fmt.Sprintf("check timed out after %s", e.Duration)))
+
+		case event.ArtifactScanned:
+			if e.Clean && e.Error == "" {
+				continue
+			}
+
+			printColor := ui.StartedColor
+			if e.Blocked {
+				printColor = ui.ErroredColor
+			}
+
+			var message string
+			if e.Error != "" {
+				message = fmt.Sprintf("failed to scan %s: %s", e.Artifact, e.Error)
+			} else {
+				message = fmt.Sprintf("scanner flagged %s: %s", e.Artifact, strings.Join(e.Findings, ", "))
+			}
+
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "%s\n", printColor.SprintFunc()(message))
+
+		case event.ArtifactArchived:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1marchived %s:\x1b[0m %s\n", e.Artifact, e.Location)
+
 		case event.Status:
 			dstImpl.SetTimestamp(e.Time)
 			var printColor *color.Color
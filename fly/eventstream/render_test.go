@@ -97,6 +97,123 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when a HookTimeout event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.HookTimeout{
+				Duration: "1h0m0s",
+			}
+		})
+
+		It("prints that the hook timed out in bold red", func() {
+			Expect(out.Contents()).To(ContainSubstring(ui.ErroredColor.SprintFunc()("hook timed out after 1h0m0s") + "\n"))
+		})
+	})
+
+	Context("when a TimeoutWarning event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.TimeoutWarning{
+				Duration: "45m0s",
+			}
+		})
+
+		It("prints that the step is still running in yellow", func() {
+			Expect(out.Contents()).To(ContainSubstring(ui.StartedColor.SprintFunc()("step has been running for longer than 45m0s") + "\n"))
+		})
+	})
+
+	Context("when a Rescheduled event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.Rescheduled{
+				Reason: "worker disappeared",
+			}
+		})
+
+		It("prints that the step is being rescheduled in yellow", func() {
+			Expect(out.Contents()).To(ContainSubstring(ui.StartedColor.SprintFunc()("worker went away (worker disappeared), rescheduling step") + "\n"))
+		})
+	})
+
+	Context("when a CheckTimeout event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.CheckTimeout{
+				Duration: "1h0m0s",
+			}
+		})
+
+		It("prints that the check timed out in bold red", func() {
+			Expect(out.Contents()).To(ContainSubstring(ui.ErroredColor.SprintFunc()("check timed out after 1h0m0s") + "\n"))
+		})
+	})
+
+	Context("when an ArtifactScanned event is received", func() {
+		Context("when the artifact was blocked", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.ArtifactScanned{
+					Artifact: "some-input",
+					Findings: []string{"Eicar-Test-Signature"},
+					Blocked:  true,
+				}
+			})
+
+			It("prints the findings in bold red", func() {
+				Expect(out.Contents()).To(ContainSubstring(ui.ErroredColor.SprintFunc()("scanner flagged some-input: Eicar-Test-Signature") + "\n"))
+			})
+		})
+
+		Context("when the artifact was not blocked", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.ArtifactScanned{
+					Artifact: "some-input",
+					Findings: []string{"Eicar-Test-Signature"},
+				}
+			})
+
+			It("prints the findings in yellow", func() {
+				Expect(out.Contents()).To(ContainSubstring(ui.StartedColor.SprintFunc()("scanner flagged some-input: Eicar-Test-Signature") + "\n"))
+			})
+		})
+
+		Context("when the scan errored", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.ArtifactScanned{
+					Artifact: "some-input",
+					Error:    "clamd unavailable",
+					Blocked:  true,
+				}
+			})
+
+			It("prints the error", func() {
+				Expect(out.Contents()).To(ContainSubstring(ui.ErroredColor.SprintFunc()("failed to scan some-input: clamd unavailable") + "\n"))
+			})
+		})
+
+		Context("when the artifact is clean", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.ArtifactScanned{
+					Artifact: "some-input",
+					Clean:    true,
+				}
+			})
+
+			It("prints nothing", func() {
+				Expect(out.Contents()).ToNot(ContainSubstring("some-input"))
+			})
+		})
+	})
+
+	Context("when an ArtifactArchived event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.ArtifactArchived{
+				Artifact: "some-output",
+				Location: "s3://some-bucket/some-output.tgz",
+			}
+		})
+
+		It("prints where the artifact was archived", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1marchived some-output:\x1b[0m s3://some-bucket/some-output.tgz\n"))
+		})
+	})
+
 	Context("when an InitializeTask event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.InitializeTask{
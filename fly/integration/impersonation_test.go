@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("--as-team", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "--as-team", "other-team", "pipelines")
+		})
+
+		It("acts on the impersonated team", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/other-team/pipelines"),
+					ghttp.VerifyHeaderKV(atc.ImpersonateTeamHeader, "other-team"),
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(r.Header.Get(atc.ImpersonateRoleHeader)).To(BeEmpty())
+					},
+					ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{}),
+				),
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+		})
+
+		Context("when --as-role is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "--as-team", "other-team", "--as-role", "viewer", "pipelines")
+			})
+
+			It("sends the role along with the team", func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team/pipelines"),
+						ghttp.VerifyHeaderKV(atc.ImpersonateTeamHeader, "other-team"),
+						ghttp.VerifyHeaderKV(atc.ImpersonateRoleHeader, "viewer"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{}),
+					),
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when the user is not allowed to impersonate", func() {
+			It("fails", func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team/pipelines"),
+						ghttp.RespondWith(403, nil),
+					),
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package rc
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// Impersonation is set by the global --as-team and --as-role flags. While a
+// team is set, targets act on that team and every request asks the ATC to
+// treat the (admin) user as having the given role on it.
+var Impersonation struct {
+	Team string
+	Role string
+}
+
+type impersonationTransport struct {
+	base http.RoundTripper
+}
+
+func (t impersonationTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the request it is given
+	r = r.Clone(r.Context())
+	r.Header.Set(atc.ImpersonateTeamHeader, Impersonation.Team)
+
+	if Impersonation.Role != "" {
+		r.Header.Set(atc.ImpersonateRoleHeader, Impersonation.Role)
+	}
+
+	return t.base.RoundTrip(r)
+}
@@ -155,9 +155,14 @@ func LoadTarget(selectedTarget TargetName, tracing bool) (Target, error) {
 	httpClient := defaultHttpClient(targetProps.Token, targetProps.Insecure, caCertPool, clientCertificate)
 	client := concourse.NewClient(targetProps.API, httpClient, tracing)
 
+	teamName := targetProps.TeamName
+	if Impersonation.Team != "" {
+		teamName = Impersonation.Team
+	}
+
 	return NewTarget(
 		selectedTarget,
-		targetProps.TeamName,
+		teamName,
 		targetProps.API,
 		targetProps.Token,
 		targetProps.CACert,
@@ -605,6 +610,10 @@ func transport(insecure bool, caCertPool *x509.CertPool, clientCertificate []tls
 		Proxy: http.ProxyFromEnvironment,
 	}
 
+	if Impersonation.Team != "" {
+		transport = impersonationTransport{base: transport}
+	}
+
 	return transport
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -74,6 +75,31 @@ func (team *team) RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, bui
 	return build, err
 }
 
+// RerunJobBuildFromFailure reruns a failed build, resuming it from the steps
+// which did not succeed.
+func (team *team) RerunJobBuildFromFailure(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error) {
+	params := rata.Params{
+		"build_name":    buildName,
+		"job_name":      jobName,
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	queryParams := url.Values{}
+	queryParams.Set("from_failure", "true")
+
+	var build atc.Build
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.RerunJobBuild,
+		Params:      params,
+		Query:       merge(queryParams, pipelineRef.QueryParams()),
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
 func (team *team) SetJobBuildComment(pipelineRef atc.PipelineRef, jobName string, buildName string, comment string) (bool, error) {
 	params := rata.Params{
 		"build_name":    buildName,
@@ -195,6 +221,28 @@ func (client *client) AbortBuild(buildID string) error {
 	}, nil)
 }
 
+func (client *client) PauseBuild(buildID string) error {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	return client.connection.Send(internal.Request{
+		RequestName: atc.PauseBuild,
+		Params:      params,
+	}, nil)
+}
+
+func (client *client) ResumeBuild(buildID string) error {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	return client.connection.Send(internal.Request{
+		RequestName: atc.ResumeBuild,
+		Params:      params,
+	}, nil)
+}
+
 func (team *team) Builds(page Page) ([]atc.Build, Pagination, error) {
 	var builds []atc.Build
 
@@ -242,3 +290,20 @@ func (client *client) ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact,
 
 	return artifacts, err
 }
+
+func (client *client) BuildTestResults(buildID string) (atc.BuildTestResults, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var results atc.BuildTestResults
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetBuildTestResults,
+		Params:      params,
+	}, &internal.Response{
+		Result: &results,
+	})
+
+	return results, err
+}
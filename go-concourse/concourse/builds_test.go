@@ -137,6 +137,40 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("RerunJobBuildFromFailure", func() {
+		var (
+			pipelineRef   atc.PipelineRef
+			expectedBuild atc.Build
+		)
+
+		BeforeEach(func() {
+			pipelineRef = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+			expectedBuild = atc.Build{
+				ID:          123,
+				Name:        "mybuild.1",
+				Status:      "pending",
+				JobName:     "myjob",
+				APIURL:      "api/v1/builds/123",
+				ResumedFrom: 122,
+			}
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds/mybuild"
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL, "from_failure=true&vars.branch=%22master%22"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("reruns the build from the failed step", func() {
+			build, err := team.RerunJobBuildFromFailure(pipelineRef, "myjob", "mybuild")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
 	Describe("JobBuild", func() {
 		var (
 			expectedBuild atc.Build
@@ -468,6 +502,50 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("PauseBuild", func() {
+		BeforeEach(func() {
+			expectedURL := "/api/v1/builds/123/pause"
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends a pause request to ATC", func() {
+			Expect(func() {
+				err := client.PauseBuild("123")
+				Expect(err).NotTo(HaveOccurred())
+			}).To(Change(func() int {
+				return len(atcServer.ReceivedRequests())
+			}).By(1))
+		})
+	})
+
+	Describe("ResumeBuild", func() {
+		BeforeEach(func() {
+			expectedURL := "/api/v1/builds/123/resume"
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends a resume request to ATC", func() {
+			Expect(func() {
+				err := client.ResumeBuild("123")
+				Expect(err).NotTo(HaveOccurred())
+			}).To(Change(func() int {
+				return len(atcServer.ReceivedRequests())
+			}).By(1))
+		})
+	})
+
 	Describe("team.Builds", func() {
 		expectedURL := "/api/v1/teams/some-team/builds"
 
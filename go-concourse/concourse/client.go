@@ -20,7 +20,10 @@ type Client interface {
 	BuildEvents(buildID string) (Events, error)
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
+	BuildTestResults(buildID string) (atc.BuildTestResults, error)
 	AbortBuild(buildID string) error
+	PauseBuild(buildID string) error
+	ResumeBuild(buildID string) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
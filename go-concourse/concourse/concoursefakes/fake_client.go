@@ -81,6 +81,19 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildTestResultsStub        func(string) (atc.BuildTestResults, error)
+	buildTestResultsMutex       sync.RWMutex
+	buildTestResultsArgsForCall []struct {
+		arg1 string
+	}
+	buildTestResultsReturns struct {
+		result1 atc.BuildTestResults
+		result2 error
+	}
+	buildTestResultsReturnsOnCall map[int]struct {
+		result1 atc.BuildTestResults
+		result2 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -232,6 +245,17 @@ type FakeClient struct {
 		result1 []atc.Worker
 		result2 error
 	}
+	PauseBuildStub        func(string) error
+	pauseBuildMutex       sync.RWMutex
+	pauseBuildArgsForCall []struct {
+		arg1 string
+	}
+	pauseBuildReturns struct {
+		result1 error
+	}
+	pauseBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PruneWorkerStub        func(string) error
 	pruneWorkerMutex       sync.RWMutex
 	pruneWorkerArgsForCall []struct {
@@ -243,6 +267,17 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ResumeBuildStub        func(string) error
+	resumeBuildMutex       sync.RWMutex
+	resumeBuildArgsForCall []struct {
+		arg1 string
+	}
+	resumeBuildReturns struct {
+		result1 error
+	}
+	resumeBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -620,6 +655,70 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildTestResults(arg1 string) (atc.BuildTestResults, error) {
+	fake.buildTestResultsMutex.Lock()
+	ret, specificReturn := fake.buildTestResultsReturnsOnCall[len(fake.buildTestResultsArgsForCall)]
+	fake.buildTestResultsArgsForCall = append(fake.buildTestResultsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BuildTestResultsStub
+	fakeReturns := fake.buildTestResultsReturns
+	fake.recordInvocation("BuildTestResults", []interface{}{arg1})
+	fake.buildTestResultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) BuildTestResultsCallCount() int {
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
+	return len(fake.buildTestResultsArgsForCall)
+}
+
+func (fake *FakeClient) BuildTestResultsCalls(stub func(string) (atc.BuildTestResults, error)) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = stub
+}
+
+func (fake *FakeClient) BuildTestResultsArgsForCall(i int) string {
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
+	argsForCall := fake.buildTestResultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildTestResultsReturns(result1 atc.BuildTestResults, result2 error) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = nil
+	fake.buildTestResultsReturns = struct {
+		result1 atc.BuildTestResults
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) BuildTestResultsReturnsOnCall(i int, result1 atc.BuildTestResults, result2 error) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = nil
+	if fake.buildTestResultsReturnsOnCall == nil {
+		fake.buildTestResultsReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildTestResults
+			result2 error
+		})
+	}
+	fake.buildTestResultsReturnsOnCall[i] = struct {
+		result1 atc.BuildTestResults
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -1341,6 +1440,67 @@ func (fake *FakeClient) ListWorkersReturnsOnCall(i int, result1 []atc.Worker, re
 	}{result1, result2}
 }
 
+func (fake *FakeClient) PauseBuild(arg1 string) error {
+	fake.pauseBuildMutex.Lock()
+	ret, specificReturn := fake.pauseBuildReturnsOnCall[len(fake.pauseBuildArgsForCall)]
+	fake.pauseBuildArgsForCall = append(fake.pauseBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PauseBuildStub
+	fakeReturns := fake.pauseBuildReturns
+	fake.recordInvocation("PauseBuild", []interface{}{arg1})
+	fake.pauseBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) PauseBuildCallCount() int {
+	fake.pauseBuildMutex.RLock()
+	defer fake.pauseBuildMutex.RUnlock()
+	return len(fake.pauseBuildArgsForCall)
+}
+
+func (fake *FakeClient) PauseBuildCalls(stub func(string) error) {
+	fake.pauseBuildMutex.Lock()
+	defer fake.pauseBuildMutex.Unlock()
+	fake.PauseBuildStub = stub
+}
+
+func (fake *FakeClient) PauseBuildArgsForCall(i int) string {
+	fake.pauseBuildMutex.RLock()
+	defer fake.pauseBuildMutex.RUnlock()
+	argsForCall := fake.pauseBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) PauseBuildReturns(result1 error) {
+	fake.pauseBuildMutex.Lock()
+	defer fake.pauseBuildMutex.Unlock()
+	fake.PauseBuildStub = nil
+	fake.pauseBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) PauseBuildReturnsOnCall(i int, result1 error) {
+	fake.pauseBuildMutex.Lock()
+	defer fake.pauseBuildMutex.Unlock()
+	fake.PauseBuildStub = nil
+	if fake.pauseBuildReturnsOnCall == nil {
+		fake.pauseBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) PruneWorker(arg1 string) error {
 	fake.pruneWorkerMutex.Lock()
 	ret, specificReturn := fake.pruneWorkerReturnsOnCall[len(fake.pruneWorkerArgsForCall)]
@@ -1402,6 +1562,67 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ResumeBuild(arg1 string) error {
+	fake.resumeBuildMutex.Lock()
+	ret, specificReturn := fake.resumeBuildReturnsOnCall[len(fake.resumeBuildArgsForCall)]
+	fake.resumeBuildArgsForCall = append(fake.resumeBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ResumeBuildStub
+	fakeReturns := fake.resumeBuildReturns
+	fake.recordInvocation("ResumeBuild", []interface{}{arg1})
+	fake.resumeBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ResumeBuildCallCount() int {
+	fake.resumeBuildMutex.RLock()
+	defer fake.resumeBuildMutex.RUnlock()
+	return len(fake.resumeBuildArgsForCall)
+}
+
+func (fake *FakeClient) ResumeBuildCalls(stub func(string) error) {
+	fake.resumeBuildMutex.Lock()
+	defer fake.resumeBuildMutex.Unlock()
+	fake.ResumeBuildStub = stub
+}
+
+func (fake *FakeClient) ResumeBuildArgsForCall(i int) string {
+	fake.resumeBuildMutex.RLock()
+	defer fake.resumeBuildMutex.RUnlock()
+	argsForCall := fake.resumeBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ResumeBuildReturns(result1 error) {
+	fake.resumeBuildMutex.Lock()
+	defer fake.resumeBuildMutex.Unlock()
+	fake.ResumeBuildStub = nil
+	fake.resumeBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ResumeBuildReturnsOnCall(i int, result1 error) {
+	fake.resumeBuildMutex.Lock()
+	defer fake.resumeBuildMutex.Unlock()
+	fake.ResumeBuildStub = nil
+	if fake.resumeBuildReturnsOnCall == nil {
+		fake.resumeBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1650,6 +1871,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.findTeamMutex.RLock()
@@ -1674,8 +1897,12 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listTeamsMutex.RUnlock()
 	fake.listWorkersMutex.RLock()
 	defer fake.listWorkersMutex.RUnlock()
+	fake.pauseBuildMutex.RLock()
+	defer fake.pauseBuildMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.resumeBuildMutex.RLock()
+	defer fake.resumeBuildMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.teamMutex.RLock()
@@ -579,6 +579,20 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	PauseResourceChecksStub        func(atc.PipelineRef, string) (bool, error)
+	pauseResourceChecksMutex       sync.RWMutex
+	pauseResourceChecksArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	pauseResourceChecksReturns struct {
+		result1 bool
+		result2 error
+	}
+	pauseResourceChecksReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	PinResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
 	pinResourceVersionMutex       sync.RWMutex
 	pinResourceVersionArgsForCall []struct {
@@ -691,6 +705,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	RerunJobBuildFromFailureStub        func(atc.PipelineRef, string, string) (atc.Build, error)
+	rerunJobBuildFromFailureMutex       sync.RWMutex
+	rerunJobBuildFromFailureArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}
+	rerunJobBuildFromFailureReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	rerunJobBuildFromFailureReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	ResourceStub        func(atc.PipelineRef, string) (atc.Resource, bool, error)
 	resourceMutex       sync.RWMutex
 	resourceArgsForCall []struct {
@@ -814,6 +843,20 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	UnpauseResourceChecksStub        func(atc.PipelineRef, string) (bool, error)
+	unpauseResourceChecksMutex       sync.RWMutex
+	unpauseResourceChecksArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	unpauseResourceChecksReturns struct {
+		result1 bool
+		result2 error
+	}
+	unpauseResourceChecksReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UnpinResourceStub        func(atc.PipelineRef, string) (bool, error)
 	unpinResourceMutex       sync.RWMutex
 	unpinResourceArgsForCall []struct {
@@ -3428,6 +3471,71 @@ func (fake *FakeTeam) PausePipelineReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) PauseResourceChecks(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+	fake.pauseResourceChecksMutex.Lock()
+	ret, specificReturn := fake.pauseResourceChecksReturnsOnCall[len(fake.pauseResourceChecksArgsForCall)]
+	fake.pauseResourceChecksArgsForCall = append(fake.pauseResourceChecksArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PauseResourceChecksStub
+	fakeReturns := fake.pauseResourceChecksReturns
+	fake.recordInvocation("PauseResourceChecks", []interface{}{arg1, arg2})
+	fake.pauseResourceChecksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PauseResourceChecksCallCount() int {
+	fake.pauseResourceChecksMutex.RLock()
+	defer fake.pauseResourceChecksMutex.RUnlock()
+	return len(fake.pauseResourceChecksArgsForCall)
+}
+
+func (fake *FakeTeam) PauseResourceChecksCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+	fake.pauseResourceChecksMutex.Lock()
+	defer fake.pauseResourceChecksMutex.Unlock()
+	fake.PauseResourceChecksStub = stub
+}
+
+func (fake *FakeTeam) PauseResourceChecksArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.pauseResourceChecksMutex.RLock()
+	defer fake.pauseResourceChecksMutex.RUnlock()
+	argsForCall := fake.pauseResourceChecksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PauseResourceChecksReturns(result1 bool, result2 error) {
+	fake.pauseResourceChecksMutex.Lock()
+	defer fake.pauseResourceChecksMutex.Unlock()
+	fake.PauseResourceChecksStub = nil
+	fake.pauseResourceChecksReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PauseResourceChecksReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.pauseResourceChecksMutex.Lock()
+	defer fake.pauseResourceChecksMutex.Unlock()
+	fake.PauseResourceChecksStub = nil
+	if fake.pauseResourceChecksReturnsOnCall == nil {
+		fake.pauseResourceChecksReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.pauseResourceChecksReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) PinResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
 	fake.pinResourceVersionMutex.Lock()
 	ret, specificReturn := fake.pinResourceVersionReturnsOnCall[len(fake.pinResourceVersionArgsForCall)]
@@ -3904,6 +4012,72 @@ func (fake *FakeTeam) RerunJobBuildReturnsOnCall(i int, result1 atc.Build, resul
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) RerunJobBuildFromFailure(arg1 atc.PipelineRef, arg2 string, arg3 string) (atc.Build, error) {
+	fake.rerunJobBuildFromFailureMutex.Lock()
+	ret, specificReturn := fake.rerunJobBuildFromFailureReturnsOnCall[len(fake.rerunJobBuildFromFailureArgsForCall)]
+	fake.rerunJobBuildFromFailureArgsForCall = append(fake.rerunJobBuildFromFailureArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RerunJobBuildFromFailureStub
+	fakeReturns := fake.rerunJobBuildFromFailureReturns
+	fake.recordInvocation("RerunJobBuildFromFailure", []interface{}{arg1, arg2, arg3})
+	fake.rerunJobBuildFromFailureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RerunJobBuildFromFailureCallCount() int {
+	fake.rerunJobBuildFromFailureMutex.RLock()
+	defer fake.rerunJobBuildFromFailureMutex.RUnlock()
+	return len(fake.rerunJobBuildFromFailureArgsForCall)
+}
+
+func (fake *FakeTeam) RerunJobBuildFromFailureCalls(stub func(atc.PipelineRef, string, string) (atc.Build, error)) {
+	fake.rerunJobBuildFromFailureMutex.Lock()
+	defer fake.rerunJobBuildFromFailureMutex.Unlock()
+	fake.RerunJobBuildFromFailureStub = stub
+}
+
+func (fake *FakeTeam) RerunJobBuildFromFailureArgsForCall(i int) (atc.PipelineRef, string, string) {
+	fake.rerunJobBuildFromFailureMutex.RLock()
+	defer fake.rerunJobBuildFromFailureMutex.RUnlock()
+	argsForCall := fake.rerunJobBuildFromFailureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) RerunJobBuildFromFailureReturns(result1 atc.Build, result2 error) {
+	fake.rerunJobBuildFromFailureMutex.Lock()
+	defer fake.rerunJobBuildFromFailureMutex.Unlock()
+	fake.RerunJobBuildFromFailureStub = nil
+	fake.rerunJobBuildFromFailureReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RerunJobBuildFromFailureReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.rerunJobBuildFromFailureMutex.Lock()
+	defer fake.rerunJobBuildFromFailureMutex.Unlock()
+	fake.RerunJobBuildFromFailureStub = nil
+	if fake.rerunJobBuildFromFailureReturnsOnCall == nil {
+		fake.rerunJobBuildFromFailureReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.rerunJobBuildFromFailureReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Resource(arg1 atc.PipelineRef, arg2 string) (atc.Resource, bool, error) {
 	fake.resourceMutex.Lock()
 	ret, specificReturn := fake.resourceReturnsOnCall[len(fake.resourceArgsForCall)]
@@ -4439,6 +4613,71 @@ func (fake *FakeTeam) UnpausePipelineReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) UnpauseResourceChecks(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+	fake.unpauseResourceChecksMutex.Lock()
+	ret, specificReturn := fake.unpauseResourceChecksReturnsOnCall[len(fake.unpauseResourceChecksArgsForCall)]
+	fake.unpauseResourceChecksArgsForCall = append(fake.unpauseResourceChecksArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UnpauseResourceChecksStub
+	fakeReturns := fake.unpauseResourceChecksReturns
+	fake.recordInvocation("UnpauseResourceChecks", []interface{}{arg1, arg2})
+	fake.unpauseResourceChecksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) UnpauseResourceChecksCallCount() int {
+	fake.unpauseResourceChecksMutex.RLock()
+	defer fake.unpauseResourceChecksMutex.RUnlock()
+	return len(fake.unpauseResourceChecksArgsForCall)
+}
+
+func (fake *FakeTeam) UnpauseResourceChecksCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+	fake.unpauseResourceChecksMutex.Lock()
+	defer fake.unpauseResourceChecksMutex.Unlock()
+	fake.UnpauseResourceChecksStub = stub
+}
+
+func (fake *FakeTeam) UnpauseResourceChecksArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.unpauseResourceChecksMutex.RLock()
+	defer fake.unpauseResourceChecksMutex.RUnlock()
+	argsForCall := fake.unpauseResourceChecksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) UnpauseResourceChecksReturns(result1 bool, result2 error) {
+	fake.unpauseResourceChecksMutex.Lock()
+	defer fake.unpauseResourceChecksMutex.Unlock()
+	fake.UnpauseResourceChecksStub = nil
+	fake.unpauseResourceChecksReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UnpauseResourceChecksReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unpauseResourceChecksMutex.Lock()
+	defer fake.unpauseResourceChecksMutex.Unlock()
+	fake.UnpauseResourceChecksStub = nil
+	if fake.unpauseResourceChecksReturnsOnCall == nil {
+		fake.unpauseResourceChecksReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unpauseResourceChecksReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) UnpinResource(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpinResourceMutex.Lock()
 	ret, specificReturn := fake.unpinResourceReturnsOnCall[len(fake.unpinResourceArgsForCall)]
@@ -4587,6 +4826,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pauseJobMutex.RUnlock()
 	fake.pausePipelineMutex.RLock()
 	defer fake.pausePipelineMutex.RUnlock()
+	fake.pauseResourceChecksMutex.RLock()
+	defer fake.pauseResourceChecksMutex.RUnlock()
 	fake.pinResourceVersionMutex.RLock()
 	defer fake.pinResourceVersionMutex.RUnlock()
 	fake.pipelineMutex.RLock()
@@ -4601,6 +4842,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.renameTeamMutex.RUnlock()
 	fake.rerunJobBuildMutex.RLock()
 	defer fake.rerunJobBuildMutex.RUnlock()
+	fake.rerunJobBuildFromFailureMutex.RLock()
+	defer fake.rerunJobBuildFromFailureMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
@@ -4617,6 +4860,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.unpauseJobMutex.RUnlock()
 	fake.unpausePipelineMutex.RLock()
 	defer fake.unpausePipelineMutex.RUnlock()
+	fake.unpauseResourceChecksMutex.RLock()
+	defer fake.unpauseResourceChecksMutex.RUnlock()
 	fake.unpinResourceMutex.RLock()
 	defer fake.unpinResourceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -87,4 +87,35 @@ func (team *team) ClearResourceCache(pipelineRef atc.PipelineRef, ResourceName s
 	} else {
 		return crcResponse.CachesRemoved, nil
 	}
-}
\ No newline at end of file
+}
+
+func (team *team) PauseResourceChecks(pipelineRef atc.PipelineRef, resourceName string) (bool, error) {
+	return team.sendResourceChecks(pipelineRef, resourceName, atc.PauseResourceChecks)
+}
+
+func (team *team) UnpauseResourceChecks(pipelineRef atc.PipelineRef, resourceName string) (bool, error) {
+	return team.sendResourceChecks(pipelineRef, resourceName, atc.UnpauseResourceChecks)
+}
+
+func (team *team) sendResourceChecks(pipelineRef atc.PipelineRef, resourceName string, requestName string) (bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"resource_name": resourceName,
+		"team_name":     team.Name(),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
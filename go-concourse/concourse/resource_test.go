@@ -1,6 +1,7 @@
 package concourse_test
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -159,4 +160,140 @@ var _ = Describe("ATC Handler Resource", func() {
 			})
 		})
 	})
+
+	Describe("PauseResourceChecks", func() {
+		var (
+			expectedStatus int
+			pipelineName   = "banana"
+			resourceName   = "myresource"
+			expectedURL    = fmt.Sprintf("/api/v1/teams/some-team/pipelines/%s/resources/%s/pause-checks", pipelineName, resourceName)
+			expectedQuery  = "vars.branch=%22master%22"
+			pipelineRef    = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL, expectedQuery),
+					ghttp.RespondWith(expectedStatus, nil),
+				),
+			)
+		})
+
+		Context("When the resource exists and there are no issues", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("calls the pause resource checks and returns no error", func() {
+				Expect(func() {
+					paused, err := team.PauseResourceChecks(pipelineRef, resourceName)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(paused).To(BeTrue())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("calls the pause resource checks and returns an error", func() {
+				Expect(func() {
+					paused, err := team.PauseResourceChecks(pipelineRef, resourceName)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(paused).To(BeFalse())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when the pause resource checks call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+			})
+
+			It("calls the pause resource checks and returns an error", func() {
+				Expect(func() {
+					paused, err := team.PauseResourceChecks(pipelineRef, resourceName)
+					Expect(err).To(HaveOccurred())
+					Expect(paused).To(BeFalse())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+	})
+
+	Describe("UnpauseResourceChecks", func() {
+		var (
+			expectedStatus int
+			pipelineName   = "banana"
+			resourceName   = "myresource"
+			expectedURL    = fmt.Sprintf("/api/v1/teams/some-team/pipelines/%s/resources/%s/unpause-checks", pipelineName, resourceName)
+			expectedQuery  = "vars.branch=%22master%22"
+			pipelineRef    = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL, expectedQuery),
+					ghttp.RespondWith(expectedStatus, nil),
+				),
+			)
+		})
+
+		Context("When the resource exists and there are no issues", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("calls the unpause resource checks and returns no error", func() {
+				Expect(func() {
+					unpaused, err := team.UnpauseResourceChecks(pipelineRef, resourceName)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(unpaused).To(BeTrue())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("calls the unpause resource checks and returns an error", func() {
+				Expect(func() {
+					unpaused, err := team.UnpauseResourceChecks(pipelineRef, resourceName)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(unpaused).To(BeFalse())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when the unpause resource checks call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+			})
+
+			It("calls the unpause resource checks and returns an error", func() {
+				Expect(func() {
+					unpaused, err := team.UnpauseResourceChecks(pipelineRef, resourceName)
+					Expect(err).To(HaveOccurred())
+					Expect(unpaused).To(BeFalse())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+	})
 })
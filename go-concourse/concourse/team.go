@@ -40,6 +40,7 @@ type Team interface {
 	JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Page) ([]atc.Build, Pagination, bool, error)
 	CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error)
 	RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error)
+	RerunJobBuildFromFailure(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error)
 	SetJobBuildComment(pipelineRef atc.PipelineRef, jobName string, buildName string, comment string) (bool, error)
 	ListJobs(pipelineRef atc.PipelineRef) ([]atc.Job, error)
 	ScheduleJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
@@ -59,6 +60,8 @@ type Team interface {
 	DisableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
 	EnableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
 	ClearResourceCache(pipelineRef atc.PipelineRef, ResourceName string, version atc.Version) (int64, error)
+	PauseResourceChecks(pipelineRef atc.PipelineRef, resourceName string) (bool, error)
+	UnpauseResourceChecks(pipelineRef atc.PipelineRef, resourceName string) (bool, error)
 
 	PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
 	UnpinResource(pipelineRef atc.PipelineRef, resourceName string) (bool, error)
@@ -12,6 +12,7 @@ require (
 	github.com/DataDog/datadog-go v3.7.2+incompatible
 	github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace v1.0.0-RC2
 	github.com/Masterminds/squirrel v1.5.0
+	github.com/Microsoft/hcsshim v0.8.18
 	github.com/NYTimes/gziphandler v1.1.1
 	github.com/aryann/difflib v0.0.0-20170710044230-e206f873d14a
 	github.com/aws/aws-sdk-go v1.40.30
@@ -22,3 +22,8 @@ deny["cannot use privileged resource types"] {
   input.action == "SaveConfig"
   input.data.resource_types[_].privileged
 }
+
+deny["only the pipeline-factory team can set pipelines in other teams"] {
+  input.action == "SetPipelineAcrossTeams"
+  input.team != "pipeline-factory"
+}
@@ -82,10 +82,17 @@ func (flag *AuthTeamFlags) formatFromFile() (atc.TeamAuth, error) {
 		return nil, err
 	}
 
+	return FormatTeamConfig(content)
+}
+
+// FormatTeamConfig formats the contents of a team configuration file, in the
+// same format as `fly set-team --config`.
+func FormatTeamConfig(content []byte) (atc.TeamAuth, error) {
+
 	var data struct {
 		Roles []map[string]interface{} `json:"roles"`
 	}
-	if err = yaml.Unmarshal(content, &data); err != nil {
+	if err := yaml.Unmarshal(content, &data); err != nil {
 		return nil, err
 	}
 
@@ -21,6 +21,7 @@ import (
 	"github.com/concourse/concourse/worker/baggageclaim"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
 )
 
 type registration struct {
@@ -46,11 +47,18 @@ var _ = Describe("Register", func() {
 	var heartbeated chan registration
 	var heartbeatResults chan workerState
 
+	var enrolledKeys []atc.EnrolledWorkerKey
+
 	BeforeEach(func() {
 		registered = make(chan registration, 100)
 		heartbeated = make(chan registration, 100)
 		heartbeatResults = make(chan workerState, 100)
 
+		enrolledKeys = []atc.EnrolledWorkerKey{}
+		atcServer.RouteToHandler("GET", "/api/v1/worker_enrollments", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(enrolledKeys)
+		})
+
 		atcServer.RouteToHandler("POST", "/api/v1/workers", func(w http.ResponseWriter, r *http.Request) {
 			var worker atc.Worker
 
@@ -197,6 +205,11 @@ var _ = Describe("Register", func() {
 
 			close(baggageclaimStubs)
 
+			baggageclaimServer.RouteToHandler("GET", "/stats", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(baggageclaim.Stats{})
+			})
+
 			baggageclaimServer.RouteToHandler("GET", "/volumes", func(w http.ResponseWriter, r *http.Request) {
 				stub, ok := <-baggageclaimStubs
 				if !ok {
@@ -252,6 +265,7 @@ var _ = Describe("Register", func() {
 			expectedWorkerPayload.BaggageclaimURL = registration.worker.BaggageclaimURL
 			expectedWorkerPayload.ActiveContainers = 3
 			expectedWorkerPayload.ActiveVolumes = 2
+			expectedWorkerPayload.Stats = &atc.WorkerStats{}
 
 			By("registering a forwarded garden address")
 			host, port, err := net.SplitHostPort(registration.worker.GardenAddr)
@@ -276,6 +290,7 @@ var _ = Describe("Register", func() {
 			expectedWorkerPayload.BaggageclaimURL = registration.worker.BaggageclaimURL
 			expectedWorkerPayload.ActiveContainers = 2
 			expectedWorkerPayload.ActiveVolumes = 1
+			expectedWorkerPayload.Stats = &atc.WorkerStats{}
 			Expect(registration.worker).To(Equal(expectedWorkerPayload))
 
 			By("heartbeating a forwarded garden address")
@@ -307,6 +322,7 @@ var _ = Describe("Register", func() {
 			expectedWorkerPayload.BaggageclaimURL = registration.worker.BaggageclaimURL
 			expectedWorkerPayload.ActiveContainers = 1
 			expectedWorkerPayload.ActiveVolumes = 0
+			expectedWorkerPayload.Stats = &atc.WorkerStats{}
 			Expect(registration.worker).To(Equal(expectedWorkerPayload))
 
 			By("having heartbeated after another interval passed")
@@ -541,6 +557,53 @@ var _ = Describe("Register", func() {
 				Expect(<-registerErr).To(BeAssignableToTypeOf(&tsa.HandshakeError{}))
 			})
 		})
+
+		Context("when the key has been enrolled", func() {
+			BeforeEach(func() {
+				_, _, enrolledKey, enrolledPubKey := generateSSHKeypair()
+				tsaClient.PrivateKey = enrolledKey
+
+				enrolledKeys = append(enrolledKeys, atc.EnrolledWorkerKey{
+					PublicKey: string(ssh.MarshalAuthorizedKey(enrolledPubKey)),
+				})
+			})
+
+			itSuccessfullyRegistersAndHeartbeats()
+		})
+
+		Context("when the key has been enrolled with tags the worker does not have", func() {
+			BeforeEach(func() {
+				_, _, enrolledKey, enrolledPubKey := generateSSHKeypair()
+				tsaClient.PrivateKey = enrolledKey
+
+				enrolledKeys = append(enrolledKeys, atc.EnrolledWorkerKey{
+					PublicKey: string(ssh.MarshalAuthorizedKey(enrolledPubKey)),
+					Tags:      []string{"some"},
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(<-registerErr).To(HaveOccurred())
+			})
+		})
+
+		Context("when the key has been enrolled with tags and the worker is untagged", func() {
+			BeforeEach(func() {
+				tsaClient.Worker.Tags = nil
+
+				_, _, enrolledKey, enrolledPubKey := generateSSHKeypair()
+				tsaClient.PrivateKey = enrolledKey
+
+				enrolledKeys = append(enrolledKeys, atc.EnrolledWorkerKey{
+					PublicKey: string(ssh.MarshalAuthorizedKey(enrolledPubKey)),
+					Tags:      []string{"some"},
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(<-registerErr).To(HaveOccurred())
+			})
+		})
 	})
 
 	Context("when the worker is for a given team", func() {
@@ -584,5 +647,35 @@ var _ = Describe("Register", func() {
 				Expect(<-registerErr).To(BeAssignableToTypeOf(&tsa.HandshakeError{}))
 			})
 		})
+
+		Context("when the key has been enrolled for the same team", func() {
+			BeforeEach(func() {
+				_, _, enrolledKey, enrolledPubKey := generateSSHKeypair()
+				tsaClient.PrivateKey = enrolledKey
+
+				enrolledKeys = append(enrolledKeys, atc.EnrolledWorkerKey{
+					PublicKey: string(ssh.MarshalAuthorizedKey(enrolledPubKey)),
+					Team:      "some-team",
+				})
+			})
+
+			itSuccessfullyRegistersAndHeartbeats()
+		})
+
+		Context("when the key has been enrolled for some other team", func() {
+			BeforeEach(func() {
+				_, _, enrolledKey, enrolledPubKey := generateSSHKeypair()
+				tsaClient.PrivateKey = enrolledKey
+
+				enrolledKeys = append(enrolledKeys, atc.EnrolledWorkerKey{
+					PublicKey: string(ssh.MarshalAuthorizedKey(enrolledPubKey)),
+					Team:      "some-other-team",
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(<-registerErr).To(HaveOccurred())
+			})
+		})
 	})
 })
@@ -0,0 +1,126 @@
+package tsa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"golang.org/x/crypto/ssh"
+)
+
+type EnrolledKey struct {
+	Key  ssh.PublicKey
+	Team string
+	Tags []string
+}
+
+// EnrolledKeys looks up worker keys which were enrolled through the ATC using
+// an enrollment token. The keys are cached for RefreshInterval so that a burst
+// of connections does not turn into a burst of requests to the ATC.
+type EnrolledKeys struct {
+	ATCEndpointPicker EndpointPicker
+	HTTPClient        *http.Client
+	RefreshInterval   time.Duration
+
+	lock      sync.Mutex
+	keys      []EnrolledKey
+	fetchedAt time.Time
+}
+
+func (e *EnrolledKeys) Lookup(ctx context.Context, key ssh.PublicKey) (EnrolledKey, bool, error) {
+	keys, err := e.enrolledKeys(ctx)
+	if err != nil {
+		return EnrolledKey{}, false, err
+	}
+
+	for _, k := range keys {
+		if bytes.Equal(k.Key.Marshal(), key.Marshal()) {
+			return k, true, nil
+		}
+	}
+
+	return EnrolledKey{}, false, nil
+}
+
+// enrolledKeys returns the cached keys, fetching them again once they are
+// stale. The lock is not held while fetching so that a slow ATC does not
+// hold up lookups which could be served from the cache.
+func (e *EnrolledKeys) enrolledKeys(ctx context.Context) ([]EnrolledKey, error) {
+	e.lock.Lock()
+	keys, fetchedAt := e.keys, e.fetchedAt
+	e.lock.Unlock()
+
+	if !fetchedAt.IsZero() && time.Since(fetchedAt) < e.RefreshInterval {
+		return keys, nil
+	}
+
+	keys, err := e.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	e.lock.Lock()
+	e.keys = keys
+	e.fetchedAt = time.Now()
+	e.lock.Unlock()
+
+	return keys, nil
+}
+
+func (e *EnrolledKeys) fetch(ctx context.Context) ([]EnrolledKey, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	request, err := e.ATCEndpointPicker.Pick().CreateRequest(atc.ListEnrolledWorkerKeys, nil, nil)
+	if err != nil {
+		logger.Error("failed-to-construct-request", err)
+		return nil, err
+	}
+
+	response, err := e.HTTPClient.Do(request.WithContext(ctx))
+	if err != nil {
+		logger.Error("failed-to-list-enrolled-keys", err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		logger.Error("bad-response", nil, lager.Data{
+			"status-code": response.StatusCode,
+		})
+
+		b, _ := httputil.DumpResponse(response, true)
+		return nil, fmt.Errorf("bad-response (%d): %s", response.StatusCode, string(b))
+	}
+
+	var enrolled []atc.EnrolledWorkerKey
+	err = json.NewDecoder(response.Body).Decode(&enrolled)
+	if err != nil {
+		logger.Error("failed-to-decode-response", err)
+		return nil, err
+	}
+
+	var keys []EnrolledKey
+	for _, k := range enrolled {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.PublicKey))
+		if err != nil {
+			logger.Error("failed-to-parse-enrolled-key", err)
+			continue
+		}
+
+		keys = append(keys, EnrolledKey{
+			Key:  key,
+			Team: k.Team,
+			Tags: k.Tags,
+		})
+	}
+
+	return keys, nil
+}
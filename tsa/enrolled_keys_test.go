@@ -0,0 +1,143 @@
+package tsa_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	"github.com/concourse/concourse/tsa/tsafakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("EnrolledKeys", func() {
+	var (
+		enrolledKeys *tsa.EnrolledKeys
+
+		ctx     context.Context
+		key     ssh.PublicKey
+		fakeATC *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err = ssh.NewPublicKey(pub)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeATC = ghttp.NewServer()
+
+		endpointPicker := new(tsafakes.FakeEndpointPicker)
+		endpointPicker.PickReturns(rata.NewRequestGenerator(fakeATC.URL(), atc.Routes))
+
+		token := &oauth2.Token{TokenType: "Bearer", AccessToken: "yo"}
+		httpClient := oauth2.NewClient(oauth2.NoContext, oauth2.StaticTokenSource(token))
+
+		enrolledKeys = &tsa.EnrolledKeys{
+			ATCEndpointPicker: endpointPicker,
+			HTTPClient:        httpClient,
+			RefreshInterval:   time.Hour,
+		}
+	})
+
+	AfterEach(func() {
+		fakeATC.Close()
+	})
+
+	Context("when the key has been enrolled", func() {
+		BeforeEach(func() {
+			fakeATC.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/worker_enrollments"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+				ghttp.RespondWithJSONEncoded(200, []atc.EnrolledWorkerKey{
+					{
+						PublicKey: string(ssh.MarshalAuthorizedKey(key)),
+						Team:      "some-team",
+						Tags:      []string{"some-tag"},
+					},
+				}),
+			))
+		})
+
+		It("returns the key's restrictions", func() {
+			enrolled, found, err := enrolledKeys.Lookup(ctx, key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(enrolled.Team).To(Equal("some-team"))
+			Expect(enrolled.Tags).To(Equal([]string{"some-tag"}))
+		})
+
+		It("caches the keys until the refresh interval passes", func() {
+			_, _, err := enrolledKeys.Lookup(ctx, key)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := enrolledKeys.Lookup(ctx, key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(fakeATC.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the keys are stale and the ATC is slow to respond", func() {
+			var unblock chan struct{}
+
+			BeforeEach(func() {
+				enrolledKeys.RefreshInterval = 0
+
+				unblock = make(chan struct{})
+				fakeATC.RouteToHandler("GET", "/api/v1/worker_enrollments", func(w http.ResponseWriter, r *http.Request) {
+					<-unblock
+					json.NewEncoder(w).Encode([]atc.EnrolledWorkerKey{})
+				})
+			})
+
+			AfterEach(func() {
+				close(unblock)
+			})
+
+			It("does not hold up other lookups while fetching", func() {
+				go enrolledKeys.Lookup(ctx, key)
+				go enrolledKeys.Lookup(ctx, key)
+
+				Eventually(fakeATC.ReceivedRequests).Should(HaveLen(2))
+			})
+		})
+	})
+
+	Context("when the key has not been enrolled", func() {
+		BeforeEach(func() {
+			fakeATC.AppendHandlers(ghttp.RespondWithJSONEncoded(200, []atc.EnrolledWorkerKey{}))
+		})
+
+		It("does not find the key", func() {
+			_, found, err := enrolledKeys.Lookup(ctx, key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the ATC fails to list the keys", func() {
+		BeforeEach(func() {
+			fakeATC.AppendHandlers(ghttp.RespondWith(500, nil, nil))
+		})
+
+		It("errors", func() {
+			_, _, err := enrolledKeys.Lookup(ctx, key)
+			Expect(err).To(MatchError(ContainSubstring("500")))
+		})
+	})
+})
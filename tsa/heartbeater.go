@@ -284,6 +284,25 @@ func (heartbeater *Heartbeater) pingWorker(logger lager.Logger) (atc.Worker, boo
 	registration.ActiveContainers = len(containers)
 	registration.ActiveVolumes = len(volumes)
 
+	// older workers don't serve stats, so failing to fetch them only means
+	// they are left out of the heartbeat
+	stats, err := heartbeater.baggageclaimClient.Stats(logger.Session("stats"))
+	if err != nil {
+		logger.Info("failed-to-get-volume-stats", lager.Data{"error": err.Error()})
+	} else {
+		registration.Stats = &atc.WorkerStats{
+			DiskTotalBytes:         stats.DiskTotalBytes,
+			DiskFreeBytes:          stats.DiskFreeBytes,
+			CPULoad:                stats.CPULoad,
+			MemoryTotalBytes:       stats.MemoryTotalBytes,
+			MemoryAvailableBytes:   stats.MemoryAvailableBytes,
+			VolumeStreamsIn:        stats.StreamsIn,
+			VolumeStreamsOut:       stats.StreamsOut,
+			VolumeStreamedInBytes:  stats.StreamedInBytes,
+			VolumeStreamedOutBytes: stats.StreamedOutBytes,
+		}
+	}
+
 	return registration, true
 }
 
@@ -3,6 +3,7 @@ package tsa_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -84,6 +85,15 @@ var _ = Describe("Heartbeater", func() {
 		}
 
 		expectedWorker = worker
+		expectedWorker.Stats = &atc.WorkerStats{
+			DiskTotalBytes:        100,
+			DiskFreeBytes:         40,
+			CPULoad:               0.5,
+			MemoryTotalBytes:      200,
+			MemoryAvailableBytes:  50,
+			VolumeStreamsIn:       2,
+			VolumeStreamedInBytes: 1024,
+		}
 
 		fakeATC1 = ghttp.NewServer()
 		fakeATC2 = ghttp.NewServer()
@@ -133,6 +143,15 @@ var _ = Describe("Heartbeater", func() {
 
 		fakeGardenClient = new(gclientfakes.FakeClient)
 		fakeBaggageclaimClient = new(baggageclaimfakes.FakeClient)
+		fakeBaggageclaimClient.StatsReturns(baggageclaim.Stats{
+			DiskTotalBytes:       100,
+			DiskFreeBytes:        40,
+			CPULoad:              0.5,
+			MemoryTotalBytes:     200,
+			MemoryAvailableBytes: 50,
+			StreamsIn:            2,
+			StreamedInBytes:      1024,
+		}, nil)
 
 		clientWriter = gbytes.NewBuffer()
 
@@ -270,6 +289,19 @@ var _ = Describe("Heartbeater", func() {
 					fakeClock.WaitForWatcherAndIncrement(interval)
 					Eventually(clientWriter).Should(gbytes.Say(`{"event":"heartbeated"}`))
 				})
+
+				Context("when the worker does not report stats", func() {
+					BeforeEach(func() {
+						fakeBaggageclaimClient.StatsReturns(baggageclaim.Stats{}, errors.New("not found"))
+						expectedWorker.Stats = nil
+					})
+
+					It("registers without them", func() {
+						expectedWorker.ActiveContainers = 2
+						expectedWorker.ActiveVolumes = 3
+						Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+					})
+				})
 			})
 		})
 
@@ -17,6 +17,7 @@ import (
 	"syscall"
 
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 	"github.com/concourse/flag"
@@ -51,6 +52,8 @@ type TSACommand struct {
 	TokenURL     flag.URL `long:"token-url" required:"true" description:"Token endpoint of the auth server"`
 	Scopes       []string `long:"scope" description:"Scopes to request from the auth server"`
 
+	EnrolledKeysRefreshInterval time.Duration `long:"enrolled-keys-refresh-interval" default:"10s" description:"How long to cache the worker keys enrolled through the ATC before fetching them again."`
+
 	HeartbeatInterval    time.Duration `long:"heartbeat-interval" default:"30s" description:"interval on which to heartbeat workers to the ATC"`
 	GardenRequestTimeout time.Duration `long:"garden-request-timeout" default:"5m" description:"How long to wait for requests to Garden to complete. 0 means no timeout."`
 
@@ -111,16 +114,10 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 
 	sessionAuthTeam := &sessionTeam{
 		sessionTeams: make(map[string]string),
+		sessionTags:  make(map[string][]string),
 		lock:         &sync.RWMutex{},
 	}
 
-	config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
-	if err != nil {
-		return nil, fmt.Errorf("failed to configure SSH server: %s", err)
-	}
-
-	listenAddr := fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
-
 	authConfig := clientcredentials.Config{
 		ClientID:     cmd.ClientID,
 		ClientSecret: cmd.ClientSecret,
@@ -133,6 +130,19 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 	tokenSource := authConfig.TokenSource(ctx)
 	httpClient := oauth2.NewClient(ctx, tokenSource)
 
+	enrolledKeys := &tsa.EnrolledKeys{
+		ATCEndpointPicker: atcEndpointPicker,
+		HTTPClient:        httpClient,
+		RefreshInterval:   cmd.EnrolledKeysRefreshInterval,
+	}
+
+	config, err := cmd.configureSSHServer(logger, sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, enrolledKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure SSH server: %s", err)
+	}
+
+	listenAddr := fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
+
 	server := &server{
 		logger:               logger,
 		heartbeatInterval:    cmd.HeartbeatInterval,
@@ -172,7 +182,7 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 			}
 
 			// Reconfigure the SSH server with the new keys
-			config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
+			config, err := cmd.configureSSHServer(logger, sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, enrolledKeys)
 			if err != nil {
 				logger.Error("failed to configure SSH server: %s", err)
 				continue
@@ -238,7 +248,7 @@ func (cmd *TSACommand) loadTeamAuthorizedKeys() ([]TeamAuthKeys, error) {
 	return teamKeys, nil
 }
 
-func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys) (*ssh.ServerConfig, error) {
+func (cmd *TSACommand) configureSSHServer(logger lager.Logger, sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys, enrolledKeys *tsa.EnrolledKeys) (*ssh.ServerConfig, error) {
 	certChecker := &ssh.CertChecker{
 		IsUserAuthority: func(key ssh.PublicKey) bool {
 			return false
@@ -264,6 +274,22 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 				}
 			}
 
+			// fall back to keys exchanged for an enrollment token
+			ctx := lagerctx.NewContext(context.Background(), logger.Session("enrolled-keys"))
+			enrolled, found, err := enrolledKeys.Lookup(ctx, key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up enrolled keys: %s", err)
+			}
+
+			if found {
+				if enrolled.Team != "" {
+					sessionAuthTeam.AuthorizeTeam(string(conn.SessionID()), enrolled.Team)
+				}
+
+				sessionAuthTeam.AuthorizeTags(string(conn.SessionID()), enrolled.Tags)
+				return nil, nil
+			}
+
 			return nil, fmt.Errorf("unknown public key")
 		},
 	}
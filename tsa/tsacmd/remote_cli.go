@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -41,6 +42,10 @@ func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, cha
 		return err
 	}
 
+	if err := checkTags(state, worker); err != nil {
+		return err
+	}
+
 	forwards := map[string]ForwardedTCPIP{}
 	for i := 0; i < 2; i++ {
 		select {
@@ -156,6 +161,33 @@ func checkTeam(state ConnState, worker atc.Worker) error {
 	return nil
 }
 
+func checkTags(state ConnState, worker atc.Worker) error {
+	if len(state.Tags) == 0 {
+		// keys without tag restrictions can register any tags
+		return nil
+	}
+
+	if len(worker.Tags) == 0 {
+		return fmt.Errorf("key is authorized for tags %s, but worker is untagged", strings.Join(state.Tags, ", "))
+	}
+
+	for _, tag := range worker.Tags {
+		allowed := false
+		for _, t := range state.Tags {
+			if tag == t {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return fmt.Errorf("key is not authorized for tag %s", tag)
+		}
+	}
+
+	return nil
+}
+
 func (req landWorkerRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
@@ -34,6 +34,7 @@ type server struct {
 
 type sessionTeam struct {
 	sessionTeams map[string]string
+	sessionTags  map[string][]string
 	lock         *sync.RWMutex
 }
 
@@ -44,6 +45,20 @@ func (s *sessionTeam) AuthorizeTeam(sessionID, team string) {
 	s.sessionTeams[sessionID] = team
 }
 
+func (s *sessionTeam) AuthorizeTags(sessionID string, tags []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sessionTags[sessionID] = tags
+}
+
+func (s *sessionTeam) AuthorizedTagsFor(sessionID string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.sessionTags[sessionID]
+}
+
 func (s *sessionTeam) IsNotAuthorized(sessionID, team string) bool {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -62,6 +77,7 @@ func (s *sessionTeam) AuthorizedTeamFor(sessionID string) string {
 
 type ConnState struct {
 	Team string
+	Tags []string
 
 	ForwardedTCPIPs <-chan ForwardedTCPIP
 }
@@ -121,6 +137,7 @@ func (server *server) handshake(logger lager.Logger, netConn net.Conn) {
 
 	state := ConnState{
 		Team: server.sessionTeam.AuthorizedTeamFor(sessionID),
+		Tags: server.sessionTeam.AuthorizedTagsFor(sessionID),
 
 		ForwardedTCPIPs: forwardedTCPIPs,
 	}
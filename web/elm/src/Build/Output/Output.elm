@@ -13,7 +13,8 @@ import Array
 import Build.Output.Models exposing (OutputModel, OutputState(..))
 import Build.StepTree.Models as StepTree
     exposing
-        ( BuildEvent(..)
+        ( ArtifactScan
+        , BuildEvent(..)
         , BuildEventEnvelope
         , Step
         , StepState(..)
@@ -275,6 +276,49 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        StepPhase _ _ _ _ ->
+            ( model, effects )
+
+        HookTimeout origin duration time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[1mhook timed out after " ++ duration ++ "\u{001B}[0m\n") (Just time)) model
+            , effects
+            )
+
+        CheckTimeout origin duration time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[1mcheck timed out after " ++ duration ++ "\u{001B}[0m\n") (Just time)) model
+            , effects
+            )
+
+        BuildTimeout origin duration time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[1mbuild timed out after " ++ duration ++ "\u{001B}[0m\n") (Just time)) model
+            , effects
+            )
+
+        TimeoutWarning origin duration time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[1mstep has been running for " ++ duration ++ "\u{001B}[0m\n") (Just time)) model
+            , effects
+            )
+
+        NotificationSent origin target time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[1mnotification sent to " ++ target ++ "\u{001B}[0m\n") (Just time)) model
+            , effects
+            )
+
+        Rescheduled origin reason time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[1mrescheduling step: " ++ reason ++ "\u{001B}[0m\n") (Just time)) model
+            , effects
+            )
+
+        ArtifactArchived origin artifact location time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[1marchived " ++ artifact ++ " to " ++ location ++ "\u{001B}[0m\n") (Just time)) model
+            , effects
+            )
+
+        ArtifactScanned origin scan time ->
+            ( updateStep origin.id (appendStepLog (artifactScanLog scan) (Just time)) model
+            , effects
+            )
+
         End ->
             ( { model | state = StepsComplete, eventStreamUrlPath = Nothing }
             , effects
@@ -320,6 +364,31 @@ appendStepLog output mtime step =
     { step | log = newLog, timestamps = newTimestamps }
 
 
+artifactScanLog : ArtifactScan -> String
+artifactScanLog scan =
+    let
+        summary =
+            case scan.error of
+                Just message ->
+                    "failed to scan " ++ scan.artifact ++ ": " ++ message
+
+                Nothing ->
+                    if scan.clean then
+                        "scanned " ++ scan.artifact ++ ": no findings"
+
+                    else
+                        "scanned " ++ scan.artifact ++ ": " ++ String.join ", " scan.findings
+
+        verdict =
+            if scan.blocked then
+                " (blocked)"
+
+            else
+                ""
+    in
+    "\u{001B}[1m" ++ summary ++ verdict ++ "\u{001B}[0m\n"
+
+
 setStepError : String -> Time.Posix -> Step -> Step
 setStepError message time step =
     { step
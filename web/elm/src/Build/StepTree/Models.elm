@@ -1,5 +1,6 @@
 module Build.StepTree.Models exposing
-    ( BuildEvent(..)
+    ( ArtifactScan
+    , BuildEvent(..)
     , BuildEventEnvelope
     , HookedStep
     , MetadataField
@@ -52,6 +53,7 @@ type StepTree
     | Put StepID
     | SetPipeline StepID
     | LoadVar StepID
+    | Notify StepID
     | ArtifactInput StepID
     | ArtifactOutput StepID
     | InParallel (Array StepTree)
@@ -62,6 +64,7 @@ type StepTree
     | OnFailure HookedStep
     | OnAbort HookedStep
     | OnError HookedStep
+    | OnTimeout HookedStep
     | Ensure HookedStep
     | Try StepTree
     | Timeout StepTree
@@ -210,6 +213,15 @@ type BuildEvent
     | ImageCheck Origin Concourse.BuildPlan
     | ImageGet Origin Concourse.BuildPlan
     | AcrossSubsteps Origin (List Concourse.AcrossSubstep)
+    | StepPhase Origin String Float Time.Posix
+    | HookTimeout Origin String Time.Posix
+    | CheckTimeout Origin String Time.Posix
+    | BuildTimeout Origin String Time.Posix
+    | TimeoutWarning Origin String Time.Posix
+    | NotificationSent Origin String Time.Posix
+    | Rescheduled Origin String Time.Posix
+    | ArtifactArchived Origin String String Time.Posix
+    | ArtifactScanned Origin ArtifactScan Time.Posix
     | End
     | Opened
     | NetworkError
@@ -221,6 +233,15 @@ type alias Origin =
     }
 
 
+type alias ArtifactScan =
+    { artifact : String
+    , clean : Bool
+    , findings : List String
+    , error : Maybe String
+    , blocked : Bool
+    }
+
+
 
 -- model manipulation functions
 
@@ -280,6 +301,9 @@ activeStepIds model tree =
         LoadVar stepId ->
             [ stepId ]
 
+        Notify stepId ->
+            [ stepId ]
+
         InParallel trees ->
             List.concatMap (activeStepIds model) (Array.toList trees)
 
@@ -301,6 +325,9 @@ activeStepIds model tree =
         OnError { step, hook } ->
             hooked step hook StepStateErrored
 
+        OnTimeout { step, hook } ->
+            hooked step hook StepStateErrored
+
         Ensure { step, hook } ->
             activeStepIds model step ++ activeStepIds model hook
 
@@ -355,6 +382,9 @@ updateTreeNodeAt id fn tree =
         LoadVar stepId ->
             updateSelf stepId
 
+        Notify stepId ->
+            updateSelf stepId
+
         InParallel trees ->
             InParallel <| Array.map (updateTreeNodeAt id fn) trees
 
@@ -396,6 +426,12 @@ updateTreeNodeAt id fn tree =
                 , hook = updateTreeNodeAt id fn hook
                 }
 
+        OnTimeout { step, hook } ->
+            OnTimeout
+                { step = updateTreeNodeAt id fn step
+                , hook = updateTreeNodeAt id fn hook
+                }
+
         Ensure { step, hook } ->
             Ensure
                 { step = updateTreeNodeAt id fn step
@@ -108,6 +108,9 @@ init buildId hl resources plan =
         Concourse.BuildStepLoadVar _ ->
             step |> initBottom buildId hl resources plan LoadVar
 
+        Concourse.BuildStepNotify _ ->
+            step |> initBottom buildId hl resources plan Notify
+
         Concourse.BuildStepInParallel plans ->
             initMultiStep buildId hl resources plan.id InParallel plans Nothing
 
@@ -152,6 +155,12 @@ init buildId hl resources plan =
                 |> Just
                 |> initMultiStep buildId hl resources plan.id (Retry plan.id) plans
 
+        Concourse.BuildStepWhile plans ->
+            step
+                |> (\s -> { s | tabFocus = startingTab hl (Array.toList plans) })
+                |> Just
+                |> initMultiStep buildId hl resources plan.id (Retry plan.id) plans
+
         Concourse.BuildStepOnSuccess hookedPlan ->
             initHookedStep buildId hl resources OnSuccess hookedPlan
 
@@ -164,6 +173,9 @@ init buildId hl resources plan =
         Concourse.BuildStepOnError hookedPlan ->
             initHookedStep buildId hl resources OnError hookedPlan
 
+        Concourse.BuildStepOnTimeout hookedPlan ->
+            initHookedStep buildId hl resources OnTimeout hookedPlan
+
         Concourse.BuildStepEnsure hookedPlan ->
             initHookedStep buildId hl resources Ensure hookedPlan
 
@@ -592,6 +604,9 @@ viewTree session model tree depth =
         LoadVar stepId ->
             viewStep model session depth stepId
 
+        Notify stepId ->
+            viewStep model session depth stepId
+
         Try subTree ->
             viewTree session model subTree depth
 
@@ -677,6 +692,9 @@ viewTree session model tree depth =
         OnError { step, hook } ->
             viewHooked session "error" model depth step hook
 
+        OnTimeout { step, hook } ->
+            viewHooked session "timeout" model depth step hook
+
         Ensure { step, hook } ->
             viewHooked session "ensure" model depth step hook
 
@@ -1241,6 +1259,9 @@ viewStepHeader step =
         Concourse.BuildStepLoadVar name ->
             simpleHeader "load_var:" Nothing name
 
+        Concourse.BuildStepNotify name ->
+            simpleHeader "notify:" Nothing name
+
         Concourse.BuildStepCheck name _ ->
             simpleHeader "check:" Nothing name
 
@@ -1280,6 +1301,9 @@ viewStepHeader step =
         Concourse.BuildStepOnError _ ->
             Html.text ""
 
+        Concourse.BuildStepOnTimeout _ ->
+            Html.text ""
+
         Concourse.BuildStepEnsure _ ->
             Html.text ""
 
@@ -1289,6 +1313,9 @@ viewStepHeader step =
         Concourse.BuildStepRetry _ ->
             Html.text ""
 
+        Concourse.BuildStepWhile _ ->
+            Html.text ""
+
         Concourse.BuildStepTimeout _ ->
             Html.text ""
 
@@ -1305,6 +1332,9 @@ stepName header =
         Concourse.BuildStepLoadVar name ->
             Just name
 
+        Concourse.BuildStepNotify name ->
+            Just name
+
         Concourse.BuildStepArtifactInput name ->
             Just name
 
@@ -1344,6 +1374,9 @@ stepName header =
         Concourse.BuildStepOnError _ ->
             Nothing
 
+        Concourse.BuildStepOnTimeout _ ->
+            Nothing
+
         Concourse.BuildStepEnsure _ ->
             Nothing
 
@@ -1353,6 +1386,9 @@ stepName header =
         Concourse.BuildStepRetry _ ->
             Nothing
 
+        Concourse.BuildStepWhile _ ->
+            Nothing
+
         Concourse.BuildStepTimeout _ ->
             Nothing
 
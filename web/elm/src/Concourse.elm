@@ -399,6 +399,9 @@ mapBuildPlan fn plan =
                 BuildStepLoadVar _ ->
                     []
 
+                BuildStepNotify _ ->
+                    []
+
                 BuildStepArtifactInput _ ->
                     []
 
@@ -454,6 +457,9 @@ mapBuildPlan fn plan =
                 BuildStepOnError { step, hook } ->
                     mapBuildPlan fn step ++ mapBuildPlan fn hook
 
+                BuildStepOnTimeout { step, hook } ->
+                    mapBuildPlan fn step ++ mapBuildPlan fn hook
+
                 BuildStepEnsure { step, hook } ->
                     mapBuildPlan fn step ++ mapBuildPlan fn hook
 
@@ -463,6 +469,9 @@ mapBuildPlan fn plan =
                 BuildStepRetry plans ->
                     List.concatMap (mapBuildPlan fn) (Array.toList plans)
 
+                BuildStepWhile plans ->
+                    List.concatMap (mapBuildPlan fn) (Array.toList plans)
+
                 BuildStepTimeout step ->
                     mapBuildPlan fn step
            )
@@ -486,6 +495,7 @@ type BuildStep
     = BuildStepTask StepName
     | BuildStepSetPipeline StepName InstanceVars
     | BuildStepLoadVar StepName
+    | BuildStepNotify StepName
     | BuildStepArtifactInput StepName
     | BuildStepCheck StepName (Maybe ImageBuildPlans)
     | BuildStepGet StepName (Maybe ResourceName) (Maybe Version) (Maybe ImageBuildPlans)
@@ -499,9 +509,11 @@ type BuildStep
     | BuildStepOnFailure HookedPlan
     | BuildStepOnAbort HookedPlan
     | BuildStepOnError HookedPlan
+    | BuildStepOnTimeout HookedPlan
     | BuildStepEnsure HookedPlan
     | BuildStepTry BuildPlan
     | BuildStepRetry (Array BuildPlan)
+    | BuildStepWhile (Array BuildPlan)
     | BuildStepTimeout BuildPlan
 
 
@@ -688,18 +700,24 @@ decodeBuildPlan =
                     lazy (\_ -> decodeBuildStepOnAbort)
                 , Json.Decode.field "on_error" <|
                     lazy (\_ -> decodeBuildStepOnError)
+                , Json.Decode.field "on_timeout" <|
+                    lazy (\_ -> decodeBuildStepOnTimeout)
                 , Json.Decode.field "ensure" <|
                     lazy (\_ -> decodeBuildStepEnsure)
                 , Json.Decode.field "try" <|
                     lazy (\_ -> decodeBuildStepTry)
                 , Json.Decode.field "retry" <|
                     lazy (\_ -> decodeBuildStepRetry)
+                , Json.Decode.field "while" <|
+                    lazy (\_ -> decodeBuildStepWhile)
                 , Json.Decode.field "timeout" <|
                     lazy (\_ -> decodeBuildStepTimeout)
                 , Json.Decode.field "set_pipeline" <|
                     lazy (\_ -> decodeBuildSetPipeline)
                 , Json.Decode.field "load_var" <|
                     lazy (\_ -> decodeBuildStepLoadVar)
+                , Json.Decode.field "notify" <|
+                    lazy (\_ -> decodeBuildStepNotify)
                 , Json.Decode.field "across" <|
                     lazy (\_ -> decodeBuildStepAcross)
                 ]
@@ -809,6 +827,15 @@ decodeBuildStepOnError =
         )
 
 
+decodeBuildStepOnTimeout : Json.Decode.Decoder BuildStep
+decodeBuildStepOnTimeout =
+    Json.Decode.map BuildStepOnTimeout
+        (Json.Decode.succeed HookedPlan
+            |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan))
+            |> andMap (Json.Decode.field "on_timeout" <| lazy (\_ -> decodeBuildPlan))
+        )
+
+
 decodeBuildStepEnsure : Json.Decode.Decoder BuildStep
 decodeBuildStepEnsure =
     Json.Decode.map BuildStepEnsure
@@ -830,6 +857,12 @@ decodeBuildStepRetry =
         |> andMap (Json.Decode.array (lazy (\_ -> decodeBuildPlan)))
 
 
+decodeBuildStepWhile : Json.Decode.Decoder BuildStep
+decodeBuildStepWhile =
+    Json.Decode.succeed BuildStepWhile
+        |> andMap (Json.Decode.field "steps" <| Json.Decode.array (lazy (\_ -> decodeBuildPlan)))
+
+
 decodeBuildStepTimeout : Json.Decode.Decoder BuildStep
 decodeBuildStepTimeout =
     Json.Decode.succeed BuildStepTimeout
@@ -849,6 +882,12 @@ decodeBuildStepLoadVar =
         |> andMap (Json.Decode.field "name" Json.Decode.string)
 
 
+decodeBuildStepNotify : Json.Decode.Decoder BuildStep
+decodeBuildStepNotify =
+    Json.Decode.succeed BuildStepNotify
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
 decodeBuildStepAcross : Json.Decode.Decoder BuildStep
 decodeBuildStepAcross =
     Json.Decode.map BuildStepAcross
@@ -7,7 +7,7 @@ module Concourse.BuildEvents exposing
     , decodeOrigin
     )
 
-import Build.StepTree.Models exposing (BuildEvent(..), BuildEventEnvelope, Origin)
+import Build.StepTree.Models exposing (ArtifactScan, BuildEvent(..), BuildEventEnvelope, Origin)
 import Concourse
 import Concourse.BuildStatus
 import Dict
@@ -234,6 +234,80 @@ decodeBuildEvent =
                                 )
                             )
 
+                    "step-phase" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map4 StepPhase
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "phase" Json.Decode.string)
+                                (Json.Decode.field "duration" Json.Decode.float)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "hook-timeout" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map3 HookTimeout
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "duration" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "check-timeout" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map3 CheckTimeout
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "duration" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "build-timeout" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map3 BuildTimeout
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "duration" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "timeout-warning" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map3 TimeoutWarning
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "duration" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "notification-sent" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map3 NotificationSent
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "target" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "rescheduled" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map3 Rescheduled
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "reason" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "artifact-archived" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map4 ArtifactArchived
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "artifact" Json.Decode.string)
+                                (Json.Decode.field "location" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "artifact-scanned" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map3 ArtifactScanned
+                                (Json.Decode.field "origin" decodeOrigin)
+                                decodeArtifactScan
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     unknown ->
                         Json.Decode.fail ("unknown event type: " ++ unknown)
             )
@@ -281,6 +355,21 @@ decodeErrorEvent =
         (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
 
 
+decodeArtifactScan : Json.Decode.Decoder ArtifactScan
+decodeArtifactScan =
+    Json.Decode.map5 ArtifactScan
+        (Json.Decode.field "artifact" Json.Decode.string)
+        (Json.Decode.field "clean" Json.Decode.bool)
+        (Json.Decode.map
+            (Maybe.withDefault [])
+            << Json.Decode.maybe
+         <|
+            Json.Decode.field "findings" (Json.Decode.list Json.Decode.string)
+        )
+        (Json.Decode.maybe <| Json.Decode.field "error" Json.Decode.string)
+        (Json.Decode.field "blocked" Json.Decode.bool)
+
+
 decodeOrigin : Json.Decode.Decoder Origin
 decodeOrigin =
     Json.Decode.map2 Origin
@@ -0,0 +1,82 @@
+module BuildEventsTests exposing (all)
+
+import Build.StepTree.Models exposing (BuildEvent(..))
+import Concourse.BuildEvents exposing (decodeBuildEvent)
+import Expect
+import Json.Decode
+import Test exposing (Test, describe, test)
+import Time
+
+
+all : Test
+all =
+    describe "decodeBuildEvent"
+        [ test "decodes step-phase events" <|
+            \_ ->
+                """{"event":"step-phase","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"phase":"run","duration":1.5}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <| StepPhase origin "run" 1.5 (Time.millisToPosix 1000))
+        , test "decodes hook-timeout events" <|
+            \_ ->
+                """{"event":"hook-timeout","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"duration":"5m0s"}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <| HookTimeout origin "5m0s" (Time.millisToPosix 1000))
+        , test "decodes check-timeout events" <|
+            \_ ->
+                """{"event":"check-timeout","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"duration":"5m0s"}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <| CheckTimeout origin "5m0s" (Time.millisToPosix 1000))
+        , test "decodes build-timeout events" <|
+            \_ ->
+                """{"event":"build-timeout","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"duration":"5m0s"}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <| BuildTimeout origin "5m0s" (Time.millisToPosix 1000))
+        , test "decodes timeout-warning events" <|
+            \_ ->
+                """{"event":"timeout-warning","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"duration":"5m0s"}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <| TimeoutWarning origin "5m0s" (Time.millisToPosix 1000))
+        , test "decodes notification-sent events" <|
+            \_ ->
+                """{"event":"notification-sent","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"target":"https://example.com/hook"}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <| NotificationSent origin "https://example.com/hook" (Time.millisToPosix 1000))
+        , test "decodes rescheduled events" <|
+            \_ ->
+                """{"event":"rescheduled","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"reason":"worker went away"}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <| Rescheduled origin "worker went away" (Time.millisToPosix 1000))
+        , test "decodes artifact-archived events" <|
+            \_ ->
+                """{"event":"artifact-archived","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"artifact":"output","location":"s3://bucket/output.tgz"}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <| ArtifactArchived origin "output" "s3://bucket/output.tgz" (Time.millisToPosix 1000))
+        , test "decodes artifact-scanned events" <|
+            \_ ->
+                """{"event":"artifact-scanned","version":"1.0","data":{"time":1,"origin":{"id":"plan"},"artifact":"output","clean":false,"findings":["CVE-1"],"blocked":true}}"""
+                    |> Json.Decode.decodeString decodeBuildEvent
+                    |> Expect.equal
+                        (Ok <|
+                            ArtifactScanned origin
+                                { artifact = "output"
+                                , clean = False
+                                , findings = [ "CVE-1" ]
+                                , error = Nothing
+                                , blocked = True
+                                }
+                                (Time.millisToPosix 1000)
+                        )
+        ]
+
+
+origin : Build.StepTree.Models.Origin
+origin =
+    { source = "", id = "plan" }
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrChunkedStreamNotFound = errors.New("chunked stream not found")
+var ErrChunkOutOfOrder = errors.New("chunk is past the end of the stream")
+
+// chunkedStreamsDirname is where streams are staged, under the volumes
+// directory, so that they're on the same disk as the volumes they're for.
+const chunkedStreamsDirname = "streams"
+
+// chunkedStreamTTL bounds how long a stream can go without a chunk before
+// it's given up on.
+const chunkedStreamTTL = time.Hour
+
+// chunkedStreams stages volumes streamed in chunks until the last chunk has
+// been received, so that a stream which fails part way through can carry on
+// from the last chunk received rather than starting over.
+type chunkedStreams struct {
+	dir string
+
+	lock    sync.Mutex
+	streams map[string]*chunkedStream
+}
+
+type chunkedStream struct {
+	handle string
+
+	// expires is guarded by the lock on the streams
+	expires time.Time
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+// newChunkedStreams stages streams in `dir`, removing any left behind by a
+// previous run, as they can't be carried on with.
+func newChunkedStreams(dir string) (*chunkedStreams, error) {
+	err := os.RemoveAll(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chunkedStreams{
+		dir:     dir,
+		streams: map[string]*chunkedStream{},
+	}, nil
+}
+
+// open returns the stream into the volume, starting it if `offset` is where
+// streams start.
+func (streams *chunkedStreams) open(id string, handle string, offset int64) (*chunkedStream, error) {
+	now := time.Now()
+
+	streams.lock.Lock()
+	defer streams.lock.Unlock()
+
+	for id, stream := range streams.streams {
+		if now.After(stream.expires) {
+			delete(streams.streams, id)
+			stream.discard()
+		}
+	}
+
+	stream, found := streams.streams[id]
+	if !found {
+		if offset != 0 {
+			return nil, ErrChunkedStreamNotFound
+		}
+
+		file, err := ioutil.TempFile(streams.dir, "stream-")
+		if err != nil {
+			return nil, err
+		}
+
+		stream = &chunkedStream{
+			handle: handle,
+			file:   file,
+		}
+
+		streams.streams[id] = stream
+	}
+
+	if stream.handle != handle {
+		return nil, ErrChunkedStreamNotFound
+	}
+
+	stream.expires = now.Add(chunkedStreamTTL)
+
+	return stream, nil
+}
+
+// remove stops the stream from being carried on with, once its last chunk
+// has been received.
+func (streams *chunkedStreams) remove(id string) {
+	streams.lock.Lock()
+	defer streams.lock.Unlock()
+
+	delete(streams.streams, id)
+}
+
+// write writes the chunk at `offset` to the stream, returning how much of
+// the stream has been received. Whatever the stream already has of the chunk
+// is skipped, as chunks are sent again when the worker can't be heard from.
+func (stream *chunkedStream) write(offset int64, chunk io.Reader) (int64, error) {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+
+	if offset > stream.size {
+		return stream.size, ErrChunkOutOfOrder
+	}
+
+	_, err := io.CopyN(ioutil.Discard, chunk, stream.size-offset)
+	if err != nil {
+		if err == io.EOF {
+			return stream.size, nil
+		}
+
+		return stream.size, err
+	}
+
+	n, err := io.Copy(stream.file, chunk)
+	stream.size += n
+
+	return stream.size, err
+}
+
+// contents returns everything received, from the start of the stream.
+func (stream *chunkedStream) contents() (io.Reader, error) {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+
+	_, err := stream.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.LimitReader(stream.file, stream.size), nil
+}
+
+func (stream *chunkedStream) discard() {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+
+	stream.file.Close()
+	os.Remove(stream.file.Name())
+}
@@ -0,0 +1,15 @@
+// +build !windows
+
+package api
+
+import "syscall"
+
+func diskUsage(path string) (uint64, uint64, error) {
+	var fsStat syscall.Statfs_t
+	err := syscall.Statfs(path, &fsStat)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return fsStat.Blocks * uint64(fsStat.Bsize), fsStat.Bavail * uint64(fsStat.Bsize), nil
+}
@@ -0,0 +1,18 @@
+package api
+
+import "golang.org/x/sys/windows"
+
+func diskUsage(path string) (uint64, uint64, error) {
+	dir, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var free, total, totalFree uint64
+	err = windows.GetDiskFreeSpaceEx(dir, &free, &total, &totalFree)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return total, free, nil
+}
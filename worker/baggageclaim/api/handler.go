@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"path/filepath"
 	"regexp"
 
 	"code.cloudfoundry.org/lager"
@@ -16,14 +17,30 @@ func NewHandler(
 	logger lager.Logger,
 	strategerizer volume.Strategerizer,
 	volumeRepo volume.Repository,
+	volumesDir string,
 	p2pInterfacePattern *regexp.Regexp,
 	p2pInterfaceFamily int,
 	p2pStreamPort uint16,
+	streamBandwidthLimit int,
 ) (http.Handler, error) {
-	volumeServer := NewVolumeServer(
+	streamStats := new(StreamStats)
+
+	volumeServer, err := NewVolumeServer(
 		logger.Session("volume-server"),
 		strategerizer,
 		volumeRepo,
+		streamStats,
+		streamBandwidthLimit,
+		filepath.Join(volumesDir, chunkedStreamsDirname),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	statsServer := NewStatsServer(
+		logger.Session("stats-server"),
+		volumesDir,
+		streamStats,
 	)
 
 	p2pServer := NewP2pServer(
@@ -43,13 +60,23 @@ func NewHandler(
 		baggageclaim.SetProperty:             http.HandlerFunc(volumeServer.SetProperty),
 		baggageclaim.GetPrivileged:           http.HandlerFunc(volumeServer.GetPrivileged),
 		baggageclaim.SetPrivileged:           http.HandlerFunc(volumeServer.SetPrivileged),
+		baggageclaim.GetQuota:                http.HandlerFunc(volumeServer.GetQuota),
+		baggageclaim.GetSize:                 http.HandlerFunc(volumeServer.GetSize),
 		baggageclaim.StreamIn:                http.HandlerFunc(volumeServer.StreamIn),
+		baggageclaim.StreamInChunk:           http.HandlerFunc(volumeServer.StreamInChunk),
 		baggageclaim.StreamOut:               http.HandlerFunc(volumeServer.StreamOut),
 		baggageclaim.StreamP2pOut:            http.HandlerFunc(volumeServer.StreamP2pOut),
+		baggageclaim.CreateP2pKey:            http.HandlerFunc(volumeServer.CreateP2pKey),
+		baggageclaim.GetManifest:             http.HandlerFunc(volumeServer.GetManifest),
+		baggageclaim.StreamDeltaOut:          http.HandlerFunc(volumeServer.StreamDeltaOut),
+		baggageclaim.StreamDeltaIn:           http.HandlerFunc(volumeServer.StreamDeltaIn),
+		baggageclaim.PersistVolume:           http.HandlerFunc(volumeServer.PersistVolume),
 		baggageclaim.DestroyVolume:           http.HandlerFunc(volumeServer.DestroyVolume),
 		baggageclaim.DestroyVolumes:          http.HandlerFunc(volumeServer.DestroyVolumes),
 
 		baggageclaim.GetP2pUrl: http.HandlerFunc(p2pServer.GetP2pUrl),
+
+		baggageclaim.GetStats: http.HandlerFunc(statsServer.GetStats),
 	}
 
 	return rata.NewRouter(baggageclaim.Routes, handlers)
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+func hostLoad() (float64, uint64, uint64, error) {
+	loadavg, err := ioutil.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	fields := strings.Fields(string(loadavg))
+	if len(fields) == 0 {
+		return 0, 0, 0, fmt.Errorf("malformed /proc/loadavg: %q", loadavg)
+	}
+
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed /proc/loadavg: %w", err)
+	}
+
+	total, available, err := memoryUsage()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return load / float64(runtime.NumCPU()), total, available, nil
+}
+
+func memoryUsage() (uint64, uint64, error) {
+	meminfo, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer meminfo.Close()
+
+	var total, available uint64
+
+	scanner := bufio.NewScanner(meminfo)
+	for scanner.Scan() {
+		// e.g. "MemAvailable:   12345678 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		var dest *uint64
+		switch fields[0] {
+		case "MemTotal:":
+			dest = &total
+		case "MemAvailable:":
+			dest = &available
+		default:
+			continue
+		}
+
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("malformed /proc/meminfo: %w", err)
+		}
+
+		*dest = kb * 1024
+	}
+
+	return total, available, scanner.Err()
+}
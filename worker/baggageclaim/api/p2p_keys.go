@@ -0,0 +1,81 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// p2pKeyTTL bounds how long a key can go unused, since the volume is only
+// streamed once the worker streaming it out has compressed all of it.
+const p2pKeyTTL = time.Hour
+
+// p2pKeys holds the keys volumes being streamed in from other workers are
+// encrypted with. Each key is handed out for one stream into one volume.
+type p2pKeys struct {
+	lock sync.Mutex
+	keys map[string]p2pKey
+}
+
+type p2pKey struct {
+	handle  string
+	key     []byte
+	expires time.Time
+}
+
+func newP2pKeys() *p2pKeys {
+	return &p2pKeys{
+		keys: map[string]p2pKey{},
+	}
+}
+
+// create returns a new key for streaming into the volume, and the ID it's
+// taken by.
+func (keys *p2pKeys) create(handle string) (string, []byte, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return "", nil, err
+	}
+
+	key, err := volume.NewP2pKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	for id, key := range keys.keys {
+		if now.After(key.expires) {
+			delete(keys.keys, id)
+		}
+	}
+
+	keys.keys[id.String()] = p2pKey{
+		handle:  handle,
+		key:     key,
+		expires: now.Add(p2pKeyTTL),
+	}
+
+	return id.String(), key, nil
+}
+
+// take returns the key for streaming into the volume, which can't be taken
+// again.
+func (keys *p2pKeys) take(id string, handle string) ([]byte, bool) {
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	key, found := keys.keys[id]
+	if !found || key.handle != handle || time.Now().After(key.expires) {
+		return nil, false
+	}
+
+	delete(keys.keys, id)
+
+	return key.key, true
+}
@@ -1,8 +1,10 @@
 package api_test
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"regexp"
 	"runtime"
 
@@ -14,18 +16,26 @@ import (
 
 var _ = Describe("P2P Server", func() {
 	var (
-		handler http.Handler
-		infc    string
+		handler    http.Handler
+		infc       string
+		volumesDir string
 	)
 
 	JustBeforeEach(func() {
 		var err error
+		volumesDir, err = ioutil.TempDir("", "p2p-server")
+		Expect(err).NotTo(HaveOccurred())
+
 		logger := lagertest.NewTestLogger("p2p-server")
 		re := regexp.MustCompile(infc)
-		handler, err = api.NewHandler(logger, nil, nil, re, 4, 7766)
+		handler, err = api.NewHandler(logger, nil, nil, volumesDir, re, 4, 7766, 0)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	AfterEach(func() {
+		os.RemoveAll(volumesDir)
+	})
+
 	Describe("get p2p url", func() {
 		var (
 			request  *http.Request
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/worker/baggageclaim"
+)
+
+var ErrGetStatsFailed = errors.New("failed to get stats")
+
+// StreamStats counts the volume streams handled by the server.
+type StreamStats struct {
+	streamsIn        uint64
+	streamsOut       uint64
+	streamedInBytes  uint64
+	streamedOutBytes uint64
+}
+
+func (s *StreamStats) countIn(r io.Reader) io.Reader {
+	atomic.AddUint64(&s.streamsIn, 1)
+	return countingReader{Reader: r, count: &s.streamedInBytes}
+}
+
+func (s *StreamStats) countOut(w io.Writer) io.Writer {
+	atomic.AddUint64(&s.streamsOut, 1)
+	return countingWriter{Writer: w, count: &s.streamedOutBytes}
+}
+
+type countingReader struct {
+	io.Reader
+	count *uint64
+}
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	atomic.AddUint64(r.count, uint64(n))
+	return n, err
+}
+
+type countingWriter struct {
+	io.Writer
+	count *uint64
+}
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	atomic.AddUint64(w.count, uint64(n))
+	return n, err
+}
+
+type StatsServer struct {
+	volumesDir  string
+	streamStats *StreamStats
+
+	logger lager.Logger
+}
+
+func NewStatsServer(
+	logger lager.Logger,
+	volumesDir string,
+	streamStats *StreamStats,
+) *StatsServer {
+	return &StatsServer{
+		volumesDir:  volumesDir,
+		streamStats: streamStats,
+		logger:      logger,
+	}
+}
+
+func (ss *StatsServer) GetStats(w http.ResponseWriter, req *http.Request) {
+	hLog := ss.logger.Session("get-stats")
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	stats := baggageclaim.Stats{
+		StreamsIn:        atomic.LoadUint64(&ss.streamStats.streamsIn),
+		StreamsOut:       atomic.LoadUint64(&ss.streamStats.streamsOut),
+		StreamedInBytes:  atomic.LoadUint64(&ss.streamStats.streamedInBytes),
+		StreamedOutBytes: atomic.LoadUint64(&ss.streamStats.streamedOutBytes),
+	}
+
+	var err error
+	if ss.volumesDir != "" {
+		stats.DiskTotalBytes, stats.DiskFreeBytes, err = diskUsage(ss.volumesDir)
+		if err != nil {
+			hLog.Error("failed-to-get-disk-usage", err)
+			RespondWithError(w, ErrGetStatsFailed, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// failing to read the host's load shouldn't hide the rest of the stats
+	stats.CPULoad, stats.MemoryTotalBytes, stats.MemoryAvailableBytes, err = hostLoad()
+	if err != nil {
+		hLog.Error("failed-to-get-host-load", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		hLog.Error("failed-to-encode", err)
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
 	"sync"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/concourse/concourse/worker/baggageclaim/throttle"
 	"github.com/concourse/concourse/worker/baggageclaim/volume"
 	uuid "github.com/nu7hatch/gouuid"
 	"github.com/tedsuo/rata"
+	"golang.org/x/time/rate"
 )
 
 const httpUnprocessableEntity = 422
@@ -26,14 +31,31 @@ var ErrSetPropertyFailed = errors.New("failed to set property on volume")
 var ErrGetPrivilegedFailed = errors.New("failed to get privileged status of volume")
 var ErrSetPrivilegedFailed = errors.New("failed to change privileged status of volume")
 var ErrStreamInFailed = errors.New("failed to stream in to volume")
+var ErrStreamInChunkFailed = errors.New("failed to stream chunk in to volume")
 var ErrStreamOutFailed = errors.New("failed to stream out from volume")
 var ErrStreamOutNotFound = errors.New("no such file or directory")
 var ErrStreamP2pOutFailed = errors.New("failed to stream p2p out from volume")
+var ErrCreateP2pKeyFailed = errors.New("failed to create p2p key for volume")
+var ErrP2pKeyNotFound = errors.New("p2p key not found")
+var ErrGetManifestFailed = errors.New("failed to get manifest of volume")
+var ErrStreamDeltaOutFailed = errors.New("failed to stream delta out from volume")
+var ErrStreamDeltaInFailed = errors.New("failed to stream delta in to volume")
+var ErrPersistVolumeFailed = errors.New("failed to persist volume")
+var ErrGetQuotaFailed = errors.New("failed to get quota of volume")
+var ErrGetSizeFailed = errors.New("failed to get size of volume")
 
 type VolumeServer struct {
 	strategerizer  volume.Strategerizer
 	volumeRepo     volume.Repository
 	volumePromises volume.PromiseList
+	streamStats    *StreamStats
+	p2pKeys        *p2pKeys
+	chunkedStreams *chunkedStreams
+
+	// streamInLimiter and streamOutLimiter are shared by every stream in
+	// and out of the worker, or nil if they aren't limited
+	streamInLimiter  *rate.Limiter
+	streamOutLimiter *rate.Limiter
 
 	logger lager.Logger
 }
@@ -42,13 +64,26 @@ func NewVolumeServer(
 	logger lager.Logger,
 	strategerizer volume.Strategerizer,
 	volumeRepo volume.Repository,
-) *VolumeServer {
-	return &VolumeServer{
-		strategerizer:  strategerizer,
-		volumeRepo:     volumeRepo,
-		volumePromises: volume.NewPromiseList(),
-		logger:         logger,
+	streamStats *StreamStats,
+	streamBandwidthLimit int,
+	chunkedStreamsDir string,
+) (*VolumeServer, error) {
+	chunkedStreams, err := newChunkedStreams(chunkedStreamsDir)
+	if err != nil {
+		return nil, err
 	}
+
+	return &VolumeServer{
+		strategerizer:    strategerizer,
+		volumeRepo:       volumeRepo,
+		volumePromises:   volume.NewPromiseList(),
+		streamStats:      streamStats,
+		p2pKeys:          newP2pKeys(),
+		chunkedStreams:   chunkedStreams,
+		streamInLimiter:  throttle.NewLimiter(streamBandwidthLimit),
+		streamOutLimiter: throttle.NewLimiter(streamBandwidthLimit),
+		logger:           logger,
+	}, nil
 }
 
 func (vs *VolumeServer) CreateVolume(w http.ResponseWriter, req *http.Request) {
@@ -469,7 +504,113 @@ func (vs *VolumeServer) StreamIn(w http.ResponseWriter, req *http.Request) {
 		subPath = queryPath[0]
 	}
 
-	badStream, err := vs.volumeRepo.StreamIn(ctx, handle, subPath, req.Header.Get("Content-Encoding"), req.Body)
+	stream := throttle.Reader(ctx, req.Body, vs.streamInLimiter)
+
+	// volumes streamed from other workers are encrypted with a key handed
+	// out for the stream
+	if keyID := req.URL.Query().Get("key"); keyID != "" {
+		key, found := vs.p2pKeys.take(keyID, handle)
+		if !found {
+			hLog.Info("p2p-key-not-found")
+			RespondWithError(w, ErrP2pKeyNotFound, http.StatusForbidden)
+			return
+		}
+
+		decrypted, err := volume.NewDecryptingReader(key, stream)
+		if err != nil {
+			hLog.Error("failed-to-decrypt", err)
+			RespondWithError(w, ErrStreamInFailed, http.StatusInternalServerError)
+			return
+		}
+
+		stream = decrypted
+	}
+
+	vs.streamIn(ctx, w, req, hLog, handle, subPath, stream)
+}
+
+// StreamInChunk streams a volume into the volume a chunk at a time, so that a
+// stream which fails part way through can be carried on with. The stream is
+// staged until its final chunk, then streamed in like any other.
+func (vs *VolumeServer) StreamInChunk(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+	id := rata.Param(req, "stream")
+
+	hLog := vs.logger.Session("stream-in-chunk", lager.Data{
+		"volume": handle,
+		"stream": id,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	offset, err := strconv.ParseInt(req.URL.Query().Get("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		hLog.Info("invalid-param-offset")
+		RespondWithError(w, ErrStreamInChunkFailed, http.StatusBadRequest)
+		return
+	}
+
+	stream, err := vs.chunkedStreams.open(id, handle, offset)
+	if err != nil {
+		if err == ErrChunkedStreamNotFound {
+			hLog.Info("stream-not-found")
+			RespondWithError(w, ErrChunkedStreamNotFound, http.StatusNotFound)
+			return
+		}
+
+		hLog.Error("failed-to-open-stream", err)
+		RespondWithError(w, ErrStreamInChunkFailed, http.StatusInternalServerError)
+		return
+	}
+
+	size, err := stream.write(offset, throttle.Reader(ctx, req.Body, vs.streamInLimiter))
+	if err != nil {
+		if err == ErrChunkOutOfOrder {
+			hLog.Info("chunk-out-of-order", lager.Data{"offset": offset, "size": size})
+			RespondWithError(w, ErrChunkOutOfOrder, http.StatusConflict)
+			return
+		}
+
+		hLog.Error("failed-to-write-chunk", err)
+		RespondWithError(w, ErrStreamInChunkFailed, http.StatusInternalServerError)
+		return
+	}
+
+	if req.URL.Query().Get("final") != "true" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(baggageclaim.StreamInChunkResponse{Offset: size})
+		if err != nil {
+			hLog.Error("failed-to-encode", err)
+		}
+
+		return
+	}
+
+	vs.chunkedStreams.remove(id)
+	defer stream.discard()
+
+	contents, err := stream.contents()
+	if err != nil {
+		hLog.Error("failed-to-read-stream", err)
+		RespondWithError(w, ErrStreamInFailed, http.StatusInternalServerError)
+		return
+	}
+
+	vs.streamIn(ctx, w, req, hLog, handle, req.URL.Query().Get("path"), contents)
+}
+
+// streamIn streams a volume into the volume, verifying it against the
+// digest sent with the request.
+func (vs *VolumeServer) streamIn(ctx context.Context, w http.ResponseWriter, req *http.Request, hLog lager.Logger, handle string, subPath string, stream io.Reader) {
+	digest := volume.NewDigest()
+	body := io.TeeReader(stream, digest)
+
+	badStream, err := vs.volumeRepo.StreamIn(ctx, handle, subPath, req.Header.Get("Content-Encoding"), vs.streamStats.countIn(body))
 	if err != nil {
 		if err == volume.ErrVolumeDoesNotExist {
 			hLog.Info("volume-not-found")
@@ -484,6 +625,12 @@ func (vs *VolumeServer) StreamIn(w http.ResponseWriter, req *http.Request) {
 		}
 
 		if badStream {
+			if digestErr := verifyDigest(req, body, digest); errors.Is(digestErr, volume.ErrDigestMismatch) {
+				hLog.Info("digest-mismatch", lager.Data{"error": digestErr.Error()})
+				RespondWithError(w, volume.ErrDigestMismatch, http.StatusBadRequest)
+				return
+			}
+
 			hLog.Info("bad-stream-payload", lager.Data{"error": err.Error()})
 			RespondWithError(w, ErrStreamInFailed, http.StatusBadRequest)
 			return
@@ -494,9 +641,68 @@ func (vs *VolumeServer) StreamIn(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	err = verifyDigest(req, body, digest)
+	if err != nil {
+		if errors.Is(err, volume.ErrDigestMismatch) {
+			hLog.Info("digest-mismatch", lager.Data{"error": err.Error()})
+			RespondWithError(w, volume.ErrDigestMismatch, http.StatusBadRequest)
+			return
+		}
+
+		if err == volume.ErrInvalidEncryptedStream {
+			hLog.Info("bad-stream-payload", lager.Data{"error": err.Error()})
+			RespondWithError(w, ErrStreamInFailed, http.StatusBadRequest)
+			return
+		}
+
+		hLog.Error("failed-to-verify-digest", err)
+		RespondWithError(w, ErrStreamInFailed, http.StatusInternalServerError)
+		return
+	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (vs *VolumeServer) CreateP2pKey(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+
+	hLog := vs.logger.Session("create-p2p-key", lager.Data{
+		"volume": handle,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	_, found, err := vs.volumeRepo.GetVolume(ctx, handle)
+	if err != nil {
+		hLog.Error("failed-to-get-volume", err)
+		RespondWithError(w, ErrCreateP2pKeyFailed, http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("volume-not-found")
+		RespondWithError(w, ErrCreateP2pKeyFailed, http.StatusNotFound)
+		return
+	}
+
+	id, key, err := vs.p2pKeys.create(handle)
+	if err != nil {
+		hLog.Error("failed-to-create-p2p-key", err)
+		RespondWithError(w, ErrCreateP2pKeyFailed, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if err := json.NewEncoder(w).Encode(baggageclaim.P2pKeyResponse{ID: id, Key: key}); err != nil {
+		hLog.Error("failed-to-encode", err)
+	}
+}
+
 func (vs *VolumeServer) StreamOut(w http.ResponseWriter, req *http.Request) {
 	handle := rata.Param(req, "handle")
 
@@ -514,7 +720,18 @@ func (vs *VolumeServer) StreamOut(w http.ResponseWriter, req *http.Request) {
 		subPath = queryPath[0]
 	}
 
-	err := vs.volumeRepo.StreamOut(ctx, handle, subPath, req.Header.Get("Accept-Encoding"), w)
+	level, err := compressionLevel(req)
+	if err != nil {
+		hLog.Info("invalid-param-level")
+		RespondWithError(w, ErrStreamOutFailed, http.StatusBadRequest)
+		return
+	}
+
+	// the digest is only known once the volume has been streamed out
+	w.Header().Set("Trailer", volume.DigestHeader)
+	digest := volume.NewDigest()
+
+	err = vs.volumeRepo.StreamOut(ctx, handle, subPath, req.Header.Get("Accept-Encoding"), level, vs.streamStats.countOut(io.MultiWriter(throttle.Writer(ctx, w, vs.streamOutLimiter), digest)))
 	if err != nil {
 		if err == volume.ErrVolumeDoesNotExist {
 			hLog.Info("volume-not-found")
@@ -528,6 +745,12 @@ func (vs *VolumeServer) StreamOut(w http.ResponseWriter, req *http.Request) {
 			return
 		}
 
+		if err == volume.ErrUnsupportedStreamLevel {
+			hLog.Info("unsupported-stream-level")
+			RespondWithError(w, ErrStreamOutFailed, http.StatusBadRequest)
+			return
+		}
+
 		if os.IsNotExist(err) {
 			hLog.Info("source-path-not-found")
 			RespondWithError(w, ErrStreamOutNotFound, http.StatusNotFound)
@@ -538,6 +761,8 @@ func (vs *VolumeServer) StreamOut(w http.ResponseWriter, req *http.Request) {
 		RespondWithError(w, ErrStreamOutFailed, http.StatusInternalServerError)
 		return
 	}
+
+	w.Header().Set(volume.DigestHeader, digest.String())
 }
 
 func (vs *VolumeServer) StreamP2pOut(w http.ResponseWriter, req *http.Request) {
@@ -578,8 +803,32 @@ func (vs *VolumeServer) StreamP2pOut(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	err := vs.volumeRepo.StreamP2pOut(ctx, handle, subPath, encoding, streamInURL)
+	level, err := compressionLevel(req)
+	if err != nil {
+		hLog.Info("invalid-param-level")
+		RespondWithError(w, ErrStreamP2pOutFailed, http.StatusBadRequest)
+		return
+	}
+
+	limit, err := bandwidthLimit(req)
+	if err != nil {
+		hLog.Info("invalid-param-limit")
+		RespondWithError(w, ErrStreamP2pOutFailed, http.StatusBadRequest)
+		return
+	}
+
+	// the volume is streamed no faster than the worker streams out at, nor
+	// than the limit for this stream
+	ctx = throttle.WithLimiters(ctx, vs.streamOutLimiter, throttle.NewLimiter(limit))
+
+	err = vs.volumeRepo.StreamP2pOut(ctx, handle, subPath, encoding, level, streamInURL)
 	if err != nil {
+		if err == volume.ErrDigestMismatch {
+			hLog.Info("peer-digest-mismatch")
+			RespondWithError(w, volume.ErrDigestMismatch, http.StatusInternalServerError)
+			return
+		}
+
 		if err == volume.ErrVolumeDoesNotExist {
 			hLog.Info("volume-not-found")
 			RespondWithError(w, ErrStreamOutNotFound, http.StatusNotFound)
@@ -592,6 +841,12 @@ func (vs *VolumeServer) StreamP2pOut(w http.ResponseWriter, req *http.Request) {
 			return
 		}
 
+		if err == volume.ErrUnsupportedStreamLevel {
+			hLog.Info("unsupported-stream-level")
+			RespondWithError(w, ErrStreamP2pOutFailed, http.StatusBadRequest)
+			return
+		}
+
 		if os.IsNotExist(err) {
 			hLog.Info("source-path-not-found")
 			RespondWithError(w, ErrStreamOutNotFound, http.StatusNotFound)
@@ -604,6 +859,316 @@ func (vs *VolumeServer) StreamP2pOut(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (vs *VolumeServer) GetManifest(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+
+	hLog := vs.logger.Session("get-manifest", lager.Data{
+		"volume": handle,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	manifest, err := vs.volumeRepo.VolumeManifest(ctx, handle)
+	if err != nil {
+		if err == volume.ErrVolumeDoesNotExist {
+			hLog.Info("volume-not-found")
+			RespondWithError(w, ErrGetManifestFailed, http.StatusNotFound)
+			return
+		}
+
+		hLog.Error("failed-to-get-manifest", err)
+		RespondWithError(w, ErrGetManifestFailed, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(manifest); err != nil {
+		hLog.Error("failed-to-encode", err)
+	}
+}
+
+func (vs *VolumeServer) StreamDeltaOut(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+
+	hLog := vs.logger.Session("stream-delta-out", lager.Data{
+		"volume": handle,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	var base volume.Manifest
+	err := json.NewDecoder(req.Body).Decode(&base)
+	if err != nil {
+		hLog.Info("malformed-manifest", lager.Data{"error": err.Error()})
+		RespondWithError(w, ErrStreamDeltaOutFailed, http.StatusBadRequest)
+		return
+	}
+
+	level, err := compressionLevel(req)
+	if err != nil {
+		hLog.Info("invalid-param-level")
+		RespondWithError(w, ErrStreamDeltaOutFailed, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Trailer", volume.DigestHeader)
+	digest := volume.NewDigest()
+
+	err = vs.volumeRepo.StreamDeltaOut(ctx, handle, req.Header.Get("Accept-Encoding"), level, base, vs.streamStats.countOut(io.MultiWriter(throttle.Writer(ctx, w, vs.streamOutLimiter), digest)))
+	if err != nil {
+		if err == volume.ErrVolumeDoesNotExist {
+			hLog.Info("volume-not-found")
+			RespondWithError(w, ErrStreamDeltaOutFailed, http.StatusNotFound)
+			return
+		}
+
+		if err == volume.ErrUnsupportedStreamEncoding || err == volume.ErrUnsupportedStreamLevel {
+			hLog.Info("unsupported-stream-encoding", lager.Data{"error": err.Error()})
+			RespondWithError(w, ErrStreamDeltaOutFailed, http.StatusBadRequest)
+			return
+		}
+
+		hLog.Error("failed-to-stream-delta-out", err)
+		RespondWithError(w, ErrStreamDeltaOutFailed, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set(volume.DigestHeader, digest.String())
+}
+
+func (vs *VolumeServer) StreamDeltaIn(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+
+	hLog := vs.logger.Session("stream-delta-in", lager.Data{
+		"volume": handle,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	base := req.URL.Query().Get("base")
+	if base == "" {
+		hLog.Info("missing-param-base")
+		RespondWithError(w, ErrStreamDeltaInFailed, http.StatusBadRequest)
+		return
+	}
+
+	digest := volume.NewDigest()
+	body := io.TeeReader(throttle.Reader(ctx, req.Body, vs.streamInLimiter), digest)
+
+	badStream, err := vs.volumeRepo.StreamDeltaIn(ctx, handle, base, req.Header.Get("Content-Encoding"), vs.streamStats.countIn(body))
+	if err != nil {
+		if err == volume.ErrVolumeDoesNotExist {
+			hLog.Info("volume-not-found")
+			RespondWithError(w, ErrStreamDeltaInFailed, http.StatusNotFound)
+			return
+		}
+
+		if err == volume.ErrUnsupportedStreamEncoding || err == volume.ErrDeltaBaseMismatch {
+			hLog.Info("unsupported-delta", lager.Data{"error": err.Error()})
+			RespondWithError(w, ErrStreamDeltaInFailed, http.StatusBadRequest)
+			return
+		}
+
+		if badStream {
+			if digestErr := verifyDigest(req, body, digest); errors.Is(digestErr, volume.ErrDigestMismatch) {
+				hLog.Info("digest-mismatch", lager.Data{"error": digestErr.Error()})
+				RespondWithError(w, volume.ErrDigestMismatch, http.StatusBadRequest)
+				return
+			}
+
+			hLog.Info("bad-stream-payload", lager.Data{"error": err.Error()})
+			RespondWithError(w, ErrStreamDeltaInFailed, http.StatusBadRequest)
+			return
+		}
+
+		hLog.Error("failed-to-stream-delta-into-volume", err)
+		RespondWithError(w, ErrStreamDeltaInFailed, http.StatusInternalServerError)
+		return
+	}
+
+	err = verifyDigest(req, body, digest)
+	if err != nil {
+		if errors.Is(err, volume.ErrDigestMismatch) {
+			hLog.Info("digest-mismatch", lager.Data{"error": err.Error()})
+			RespondWithError(w, volume.ErrDigestMismatch, http.StatusBadRequest)
+			return
+		}
+
+		hLog.Error("failed-to-verify-digest", err)
+		RespondWithError(w, ErrStreamDeltaInFailed, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (vs *VolumeServer) PersistVolume(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+
+	hLog := vs.logger.Session("persist-volume", lager.Data{
+		"volume": handle,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	key := req.URL.Query().Get("key")
+	if key == "" {
+		hLog.Info("missing-param-key")
+		RespondWithError(w, ErrPersistVolumeFailed, http.StatusBadRequest)
+		return
+	}
+
+	err := vs.volumeRepo.PersistVolume(ctx, handle, key)
+	if err != nil {
+		if err == volume.ErrVolumeDoesNotExist {
+			hLog.Info("volume-not-found")
+			RespondWithError(w, ErrPersistVolumeFailed, http.StatusNotFound)
+			return
+		}
+
+		if err == volume.ErrPersistenceUnsupported {
+			hLog.Info("persistence-unsupported")
+			RespondWithError(w, err, http.StatusNotImplemented)
+			return
+		}
+
+		hLog.Error("failed-to-persist-volume", err)
+		RespondWithError(w, ErrPersistVolumeFailed, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (vs *VolumeServer) GetQuota(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	handle := rata.Param(req, "handle")
+
+	hLog := vs.logger.Session("get-quota", lager.Data{
+		"volume": handle,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	quota, err := vs.volumeRepo.GetQuota(ctx, handle)
+	if err != nil {
+		if err == volume.ErrVolumeDoesNotExist {
+			hLog.Info("volume-not-found")
+			RespondWithError(w, ErrGetQuotaFailed, http.StatusNotFound)
+			return
+		}
+
+		if err == volume.ErrQuotaUnsupported {
+			hLog.Info("quota-unsupported")
+			RespondWithError(w, err, http.StatusNotImplemented)
+			return
+		}
+
+		hLog.Error("failed-to-get-quota", err)
+		RespondWithError(w, ErrGetQuotaFailed, http.StatusInternalServerError)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(baggageclaim.VolumeQuota{
+		Limit: quota.Limit,
+		Used:  quota.Used,
+	})
+	if err != nil {
+		hLog.Error("failed-to-encode", err)
+	}
+}
+
+func (vs *VolumeServer) GetSize(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	handle := rata.Param(req, "handle")
+
+	hLog := vs.logger.Session("get-size", lager.Data{
+		"volume": handle,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	size, err := vs.volumeRepo.VolumeSize(ctx, handle)
+	if err != nil {
+		if err == volume.ErrVolumeDoesNotExist {
+			hLog.Info("volume-not-found")
+			RespondWithError(w, ErrGetSizeFailed, http.StatusNotFound)
+			return
+		}
+
+		hLog.Error("failed-to-get-size", err)
+		RespondWithError(w, ErrGetSizeFailed, http.StatusInternalServerError)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(baggageclaim.VolumeSize{
+		Size: size,
+	})
+	if err != nil {
+		hLog.Error("failed-to-encode", err)
+	}
+}
+
+// verifyDigest reads the rest of the request body, which the volume may not
+// have needed all of, so that its trailers are received, and checks that it
+// matches the digest it was sent with.
+func verifyDigest(req *http.Request, body io.Reader, digest volume.Digest) error {
+	_, err := io.Copy(ioutil.Discard, body)
+	if err != nil {
+		return err
+	}
+
+	expected := req.Header.Get(volume.DigestHeader)
+	if expected == "" {
+		expected = req.Trailer.Get(volume.DigestHeader)
+	}
+
+	return digest.Verify(expected)
+}
+
+// bandwidthLimit returns the bytes per second a stream is limited to, or 0
+// if it isn't limited.
+func bandwidthLimit(req *http.Request) (int, error) {
+	limit := req.URL.Query().Get("limit")
+	if limit == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(limit)
+}
+
+// compressionLevel returns the level streams are to be compressed at, which
+// is the encoding's default when unspecified.
+func compressionLevel(req *http.Request) (int, error) {
+	level := req.URL.Query().Get("level")
+	if level == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(level)
+}
+
 func (vs *VolumeServer) generateHandle() (string, error) {
 	handle, err := uuid.NewV4()
 	if err != nil {
@@ -635,7 +1200,8 @@ func (vs *VolumeServer) prepareCreate(w http.ResponseWriter, req *http.Request,
 	hLog = hLog.WithData(lager.Data{
 		"handle":     handle,
 		"privileged": request.Privileged,
-		"strategy":   request.Strategy,
+		"strategy":   redactedStrategy(request.Strategy),
+		"quota":      request.Quota,
 	})
 
 	strategy, err := vs.strategerizer.StrategyFor(request)
@@ -648,6 +1214,26 @@ func (vs *VolumeServer) prepareCreate(w http.ResponseWriter, req *http.Request,
 	return request, handle, strategy, hLog, nil
 }
 
+// redactedStrategy is the strategy of a request as it's logged, without the
+// password used to pull registry images.
+func redactedStrategy(strategy *json.RawMessage) interface{} {
+	if strategy == nil {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	err := json.Unmarshal(*strategy, &fields)
+	if err != nil {
+		return nil
+	}
+
+	if _, ok := fields["password"]; ok {
+		fields["password"] = "<redacted>"
+	}
+
+	return fields
+}
+
 func (vs *VolumeServer) doCreate(ctx context.Context, w http.ResponseWriter, request baggageclaim.VolumeRequest, handle string, strategy volume.Strategy, hLog lager.Logger, handlers volumeCreationHandler) (volume.Volume, error) {
 	hLog.Debug("creating")
 
@@ -664,6 +1250,24 @@ func (vs *VolumeServer) doCreate(ctx context.Context, w http.ResponseWriter, req
 		return handlers.creationFailed(w, err)
 	}
 
+	if request.Quota > 0 {
+		err = vs.volumeRepo.SetQuota(ctx, createdVolume.Handle, request.Quota)
+		if err == volume.ErrQuotaUnsupported {
+			// quotas are requested of every worker, so volumes are created
+			// without one on workers which can't enforce them
+			hLog.Info("quota-unsupported")
+		} else if err != nil {
+			hLog.Error("failed-to-set-quota", err)
+
+			destroyErr := vs.volumeRepo.DestroyVolume(ctx, createdVolume.Handle)
+			if destroyErr != nil {
+				hLog.Error("failed-to-destroy-volume-without-quota", destroyErr)
+			}
+
+			return handlers.creationFailed(w, err)
+		}
+	}
+
 	hLog = hLog.WithData(lager.Data{
 		"volume": createdVolume.Handle,
 	})
@@ -679,6 +1283,17 @@ type volumeCreationHandler interface {
 }
 
 func (vs *VolumeServer) creationFailed(w http.ResponseWriter, err error) (volume.Volume, error) {
+	// volumes which can't be hydrated are told apart from ones which failed
+	// to be created, so that they can be created some other way
+	switch err {
+	case volume.ErrVolumeNotPersisted:
+		RespondWithError(w, err, httpUnprocessableEntity)
+		return volume.Volume{}, err
+	case volume.ErrPersistenceUnsupported:
+		RespondWithError(w, err, http.StatusNotImplemented)
+		return volume.Volume{}, err
+	}
+
 	var code int
 	switch err {
 	case volume.ErrParentVolumeNotFound:
@@ -32,8 +32,9 @@ var _ = Describe("Volume Server", func() {
 	var (
 		handler http.Handler
 
-		volumeDir string
-		tempDir   string
+		volumeDir    string
+		tempDir      string
+		volumeDriver volume.Driver
 	)
 
 	BeforeEach(func() {
@@ -48,12 +49,13 @@ var _ = Describe("Volume Server", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		volumeDir = tempDir
+		volumeDriver = &driver.NaiveDriver{}
 	})
 
 	JustBeforeEach(func() {
 		logger := lagertest.NewTestLogger("volume-server")
 
-		fs, err := volume.NewFilesystem(&driver.NaiveDriver{}, volumeDir)
+		fs, err := volume.NewFilesystem(volumeDriver, volumeDir)
 		Expect(err).NotTo(HaveOccurred())
 
 		privilegedNamespacer := &uidgid.UidNamespacer{
@@ -68,15 +70,16 @@ var _ = Describe("Volume Server", func() {
 
 		repo := volume.NewRepository(
 			fs,
+			volumeDriver,
 			volume.NewLockManager(),
 			privilegedNamespacer,
 			unprivilegedNamespacer,
 		)
 
-		strategerizer := volume.NewStrategerizer()
+		strategerizer := volume.NewStrategerizer(volumeDriver, nil, 0)
 
 		re := regexp.MustCompile("eth0")
-		handler, err = api.NewHandler(logger, strategerizer, repo, re, 4, 7766)
+		handler, err = api.NewHandler(logger, strategerizer, repo, volumeDir, re, 4, 7766, 0)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
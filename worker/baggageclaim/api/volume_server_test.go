@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -34,9 +35,11 @@ import (
 var _ = Describe("Volume Server", func() {
 	var (
 		handler http.Handler
+		logger  *lagertest.TestLogger
 
-		volumeDir string
-		tempDir   string
+		volumeDir    string
+		tempDir      string
+		volumeDriver volume.Driver
 	)
 
 	BeforeEach(func() {
@@ -51,12 +54,13 @@ var _ = Describe("Volume Server", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		volumeDir = tempDir
+		volumeDriver = &driver.NaiveDriver{}
 	})
 
 	JustBeforeEach(func() {
-		logger := lagertest.NewTestLogger("volume-server")
+		logger = lagertest.NewTestLogger("volume-server")
 
-		fs, err := volume.NewFilesystem(&driver.NaiveDriver{}, volumeDir)
+		fs, err := volume.NewFilesystem(volumeDriver, volumeDir)
 		Expect(err).NotTo(HaveOccurred())
 
 		var privilegedNamespacer, unprivilegedNamespacer uidgid.Namespacer
@@ -77,15 +81,16 @@ var _ = Describe("Volume Server", func() {
 
 		repo := volume.NewRepository(
 			fs,
+			volumeDriver,
 			volume.NewLockManager(),
 			privilegedNamespacer,
 			unprivilegedNamespacer,
 		)
 
-		strategerizer := volume.NewStrategerizer()
+		strategerizer := volume.NewStrategerizer(volumeDriver, nil, 0)
 
 		re := regexp.MustCompile("lo")
-		handler, err = api.NewHandler(logger, strategerizer, repo, re, 4, 7766)
+		handler, err = api.NewHandler(logger, strategerizer, repo, volumeDir, re, 4, 7766, 0)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -322,6 +327,68 @@ var _ = Describe("Volume Server", func() {
 		})
 	})
 
+	Describe("getting stats", func() {
+		getStats := func() baggageclaim.Stats {
+			request, err := http.NewRequest("GET", "/stats", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(200))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+			var stats baggageclaim.Stats
+			err = json.NewDecoder(recorder.Body).Decode(&stats)
+			Expect(err).NotTo(HaveOccurred())
+
+			return stats
+		}
+
+		It("reports the disk usage of the volumes directory", func() {
+			stats := getStats()
+			Expect(stats.DiskTotalBytes).To(BeNumerically(">", 0))
+			Expect(stats.DiskFreeBytes).To(BeNumerically("<=", stats.DiskTotalBytes))
+		})
+
+		It("counts streams into volumes", func() {
+			body := &bytes.Buffer{}
+			err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+				Handle: "some-handle",
+				Strategy: encStrategy(map[string]string{
+					"type": "empty",
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, _ := http.NewRequest("POST", "/volumes", body)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(201))
+
+			tgzBuffer := new(bytes.Buffer)
+			gzWriter := gzip.NewWriter(tgzBuffer)
+			tarWriter := tar.NewWriter(gzWriter)
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "some-file", Mode: 0600, Size: 4})).To(Succeed())
+			_, err = tarWriter.Write([]byte("data"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(gzWriter.Close()).To(Succeed())
+
+			size := tgzBuffer.Len()
+
+			request, _ = http.NewRequest("PUT", "/volumes/some-handle/stream-in", tgzBuffer)
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			recorder = httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(204))
+
+			stats := getStats()
+			Expect(stats.StreamsIn).To(Equal(uint64(1)))
+			Expect(stats.StreamedInBytes).To(Equal(uint64(size)))
+			Expect(stats.StreamsOut).To(BeZero())
+		})
+	})
+
 	Describe("streaming tar out of a volume", func() {
 		var (
 			myVolume  volume.Volume
@@ -474,6 +541,44 @@ var _ = Describe("Volume Server", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(string(contents)).To(Equal("file-content"))
 				})
+
+				It("creates a tar at the requested compression level", func() {
+					request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-out?path=%s&level=19", myVolume.Handle, "dest-path"), nil)
+					request.Header.Set("Accept-Encoding", string(baggageclaim.ZstdEncoding))
+					recorder := httptest.NewRecorder()
+					handler.ServeHTTP(recorder, request)
+					Expect(recorder.Code).To(Equal(200))
+
+					unpackedDir := filepath.Join(tempDir, "unpacked-dir")
+					err := os.MkdirAll(unpackedDir, os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+					defer os.RemoveAll(unpackedDir)
+
+					zstdReader, err := zstd.NewReader(recorder.Body)
+					Expect(err).NotTo(HaveOccurred())
+					err = tarfs.Extract(zstdReader, unpackedDir)
+					Expect(err).NotTo(HaveOccurred())
+
+					contents, err := ioutil.ReadFile(filepath.Join(unpackedDir, "some-file"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal("file-content"))
+				})
+
+				It("returns 400 when the compression level is out of range", func() {
+					request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-out?path=%s&level=23", myVolume.Handle, "dest-path"), nil)
+					request.Header.Set("Accept-Encoding", string(baggageclaim.ZstdEncoding))
+					recorder := httptest.NewRecorder()
+					handler.ServeHTTP(recorder, request)
+					Expect(recorder.Code).To(Equal(400))
+				})
+
+				It("returns 400 when the compression level is not a number", func() {
+					request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-out?path=%s&level=best", myVolume.Handle, "dest-path"), nil)
+					request.Header.Set("Accept-Encoding", string(baggageclaim.ZstdEncoding))
+					recorder := httptest.NewRecorder()
+					handler.ServeHTTP(recorder, request)
+					Expect(recorder.Code).To(Equal(400))
+				})
 			})
 		})
 
@@ -822,6 +927,27 @@ var _ = Describe("Volume Server", func() {
 			})
 		})
 
+		Context("with a registry image strategy", func() {
+			BeforeEach(func() {
+				body = &bytes.Buffer{}
+				_ = json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+					Handle: "some-handle",
+					Strategy: baggageclaim.RegistryImageStrategy{
+						Repository: "some/image",
+						Digest:     "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						Username:   "some-user",
+						Password:   "some-password",
+					}.Encode(),
+				})
+			})
+
+			It("does not log the registry password", func() {
+				logs := string(logger.Buffer().Contents())
+				Expect(logs).To(ContainSubstring("some-user"))
+				Expect(logs).ToNot(ContainSubstring("some-password"))
+			})
+		})
+
 		Context("when there are no properties given", func() {
 			BeforeEach(func() {
 				body = &bytes.Buffer{}
@@ -1142,6 +1268,33 @@ var _ = Describe("Volume Server", func() {
 			Expect(responseError.Message).To(Equal("no such file or directory"))
 		})
 
+		It("returns 400 when the bandwidth limit is not a number", func() {
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-p2p-out?path=.&streamInURL=some-url&encoding=gzip&limit=fast", myVolume.Handle), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(400))
+		})
+
+		It("streams no faster than the bandwidth limit", func() {
+			filePath := filepath.Join(volumeDir, "live", myVolume.Handle, "volume", "some-file")
+			err := ioutil.WriteFile(filePath, []byte("some-file-content"), os.ModePerm)
+			Expect(err).ToNot(HaveOccurred())
+
+			// the compressed tar is well over twice the limit, which is also
+			// as much as can be streamed at once
+			streamInP2pURL := fmt.Sprintf("%s/volumes/%s/stream-in?path=dest-path", otherWorker.URL, myVolume.Handle)
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-p2p-out?path=some-file&streamInURL=%s&encoding=gzip&limit=50", myVolume.Handle, streamInP2pURL), nil)
+
+			start := time.Now()
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(200))
+
+			Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+			Expect(ioutil.ReadFile(filepath.Join(volumeDir, "live", myVolume.Handle, "volume", "dest-path", "some-file"))).To(Equal([]byte("some-file-content")))
+		})
+
 		Context("when streaming a file", func() {
 			JustBeforeEach(func() {
 				// Create a file in the volume.
@@ -1230,6 +1383,696 @@ var _ = Describe("Volume Server", func() {
 			})
 		})
 	})
+
+	Describe("verifying the digests of streams", func() {
+		var (
+			myVolume  volume.Volume
+			tarBuffer *bytes.Buffer
+			digest    string
+		)
+
+		BeforeEach(func() {
+			tarBuffer = new(bytes.Buffer)
+
+			gzWriter := gzip.NewWriter(tarBuffer)
+			tarWriter := tar.NewWriter(gzWriter)
+
+			err := tarWriter.WriteHeader(&tar.Header{
+				Name: "some-file",
+				Mode: 0600,
+				Size: int64(len("file-content")),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tarWriter.Write([]byte("file-content"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(gzWriter.Close()).To(Succeed())
+
+			digest = fmt.Sprintf("%x", sha256.Sum256(tarBuffer.Bytes()))
+		})
+
+		JustBeforeEach(func() {
+			body := &bytes.Buffer{}
+
+			err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+				Handle: "some-handle",
+				Strategy: encStrategy(map[string]string{
+					"type": "empty",
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("POST", "/volumes", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(201))
+
+			err = json.NewDecoder(recorder.Body).Decode(&myVolume)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("streams in a stream which matches its digest", func() {
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in?path=%s", myVolume.Handle, "dest-path"), tarBuffer)
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			request.Header.Set(volume.DigestHeader, digest)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(204))
+		})
+
+		It("returns 400 when the stream does not match the digest in its header", func() {
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in?path=%s", myVolume.Handle, "dest-path"), tarBuffer)
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			request.Header.Set(volume.DigestHeader, "bogus-digest")
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(400))
+
+			var responseError *api.ErrorResponse
+			err := json.NewDecoder(recorder.Body).Decode(&responseError)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responseError.Message).To(Equal(volume.ErrDigestMismatch.Error()))
+		})
+
+		It("returns 400 when the stream does not match the digest in its trailer", func() {
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in?path=%s", myVolume.Handle, "dest-path"), tarBuffer)
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			request.Trailer = http.Header{volume.DigestHeader: []string{"bogus-digest"}}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(400))
+		})
+
+		It("returns 400 when a corrupted stream does not match its digest", func() {
+			corrupted := tarBuffer.Bytes()
+			corrupted[len(corrupted)/2] ^= 0xff
+
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in?path=%s", myVolume.Handle, "dest-path"), bytes.NewBuffer(corrupted))
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			request.Header.Set(volume.DigestHeader, digest)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(400))
+
+			var responseError *api.ErrorResponse
+			err := json.NewDecoder(recorder.Body).Decode(&responseError)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responseError.Message).To(Equal(volume.ErrDigestMismatch.Error()))
+		})
+
+		It("streams out with the digest in its trailer", func() {
+			streamInRequest, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in?path=%s", myVolume.Handle, "dest-path"), tarBuffer)
+			streamInRequest.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			streamInRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(streamInRecorder, streamInRequest)
+			Expect(streamInRecorder.Code).To(Equal(204))
+
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-out?path=%s", myVolume.Handle, "dest-path"), nil)
+			request.Header.Set("Accept-Encoding", string(baggageclaim.GzipEncoding))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(200))
+
+			response := recorder.Result()
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Trailer.Get(volume.DigestHeader)).To(Equal(fmt.Sprintf("%x", sha256.Sum256(body))))
+		})
+	})
+
+	Describe("streaming encrypted tar files into volumes", func() {
+		var (
+			myVolume  volume.Volume
+			tarBuffer *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			tarBuffer = new(bytes.Buffer)
+
+			gzWriter := gzip.NewWriter(tarBuffer)
+			tarWriter := tar.NewWriter(gzWriter)
+
+			err := tarWriter.WriteHeader(&tar.Header{
+				Name: "some-file",
+				Mode: 0600,
+				Size: int64(len("file-content")),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tarWriter.Write([]byte("file-content"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(gzWriter.Close()).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			body := &bytes.Buffer{}
+
+			err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+				Handle: "some-handle",
+				Strategy: encStrategy(map[string]string{
+					"type": "empty",
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("POST", "/volumes", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(201))
+
+			err = json.NewDecoder(recorder.Body).Decode(&myVolume)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		createKey := func(handle string) (int, baggageclaim.P2pKeyResponse) {
+			request, _ := http.NewRequest("POST", fmt.Sprintf("/volumes/%s/p2p-keys", handle), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			var key baggageclaim.P2pKeyResponse
+			if recorder.Code == http.StatusCreated {
+				err := json.NewDecoder(recorder.Body).Decode(&key)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			return recorder.Code, key
+		}
+
+		encrypt := func(key []byte, plaintext []byte) *bytes.Buffer {
+			encrypted := new(bytes.Buffer)
+
+			writer, err := volume.NewEncryptingWriter(key, encrypted)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = writer.Write(plaintext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+
+			return encrypted
+		}
+
+		streamIn := func(keyID string, body io.Reader) *httptest.ResponseRecorder {
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in?path=dest-path&key=%s", myVolume.Handle, keyID), body)
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		It("decrypts the stream with the key", func() {
+			code, key := createKey(myVolume.Handle)
+			Expect(code).To(Equal(201))
+			Expect(key.Key).To(HaveLen(volume.P2pKeySize))
+
+			recorder := streamIn(key.ID, encrypt(key.Key, tarBuffer.Bytes()))
+			Expect(recorder.Code).To(Equal(204))
+
+			tarContentsPath := filepath.Join(volumeDir, "live", myVolume.Handle, "volume", "dest-path", "some-file")
+			Expect(ioutil.ReadFile(tarContentsPath)).To(Equal([]byte("file-content")))
+		})
+
+		It("only accepts each key once", func() {
+			_, key := createKey(myVolume.Handle)
+
+			recorder := streamIn(key.ID, encrypt(key.Key, tarBuffer.Bytes()))
+			Expect(recorder.Code).To(Equal(204))
+
+			recorder = streamIn(key.ID, encrypt(key.Key, tarBuffer.Bytes()))
+			Expect(recorder.Code).To(Equal(403))
+		})
+
+		It("returns 403 when the key is unknown", func() {
+			recorder := streamIn("bogus-key-id", tarBuffer)
+			Expect(recorder.Code).To(Equal(403))
+		})
+
+		It("returns 400 when the stream was not encrypted with the key", func() {
+			_, key := createKey(myVolume.Handle)
+
+			otherKey, err := volume.NewP2pKey()
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := streamIn(key.ID, encrypt(otherKey, tarBuffer.Bytes()))
+			Expect(recorder.Code).To(Equal(400))
+		})
+
+		It("returns 404 when creating a key for a volume which does not exist", func() {
+			code, _ := createKey("bogus-handle")
+			Expect(code).To(Equal(404))
+		})
+	})
+
+	Describe("streaming tar files into volumes in chunks", func() {
+		var (
+			myVolume  volume.Volume
+			tarBuffer *bytes.Buffer
+			digest    string
+		)
+
+		BeforeEach(func() {
+			tarBuffer = new(bytes.Buffer)
+
+			gzWriter := gzip.NewWriter(tarBuffer)
+			tarWriter := tar.NewWriter(gzWriter)
+
+			err := tarWriter.WriteHeader(&tar.Header{
+				Name: "some-file",
+				Mode: 0600,
+				Size: int64(len("file-content")),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tarWriter.Write([]byte("file-content"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(gzWriter.Close()).To(Succeed())
+
+			digest = fmt.Sprintf("%x", sha256.Sum256(tarBuffer.Bytes()))
+		})
+
+		JustBeforeEach(func() {
+			body := &bytes.Buffer{}
+
+			err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+				Handle: "some-handle",
+				Strategy: encStrategy(map[string]string{
+					"type": "empty",
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("POST", "/volumes", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(201))
+
+			err = json.NewDecoder(recorder.Body).Decode(&myVolume)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		streamInChunk := func(stream string, offset int, chunk []byte, final bool) *httptest.ResponseRecorder {
+			url := fmt.Sprintf("/volumes/%s/stream-in/%s?path=dest-path&offset=%d", myVolume.Handle, stream, offset)
+			if final {
+				url += "&final=true"
+			}
+
+			request, _ := http.NewRequest("PUT", url, bytes.NewReader(chunk))
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			request.Header.Set(volume.DigestHeader, digest)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		receivedOffset := func(recorder *httptest.ResponseRecorder) int64 {
+			Expect(recorder.Code).To(Equal(200))
+
+			var response baggageclaim.StreamInChunkResponse
+			err := json.NewDecoder(recorder.Body).Decode(&response)
+			Expect(err).NotTo(HaveOccurred())
+
+			return response.Offset
+		}
+
+		It("streams in the volume once the final chunk has been received", func() {
+			contents := tarBuffer.Bytes()
+			half := len(contents) / 2
+
+			Expect(receivedOffset(streamInChunk("some-stream", 0, contents[:half], false))).To(Equal(int64(half)))
+
+			destPath := filepath.Join(volumeDir, "live", myVolume.Handle, "volume", "dest-path", "some-file")
+			Expect(destPath).ToNot(BeAnExistingFile())
+
+			Expect(streamInChunk("some-stream", half, contents[half:], true).Code).To(Equal(204))
+			Expect(ioutil.ReadFile(destPath)).To(Equal([]byte("file-content")))
+		})
+
+		It("skips what it already received of chunks which are sent again", func() {
+			contents := tarBuffer.Bytes()
+			half := len(contents) / 2
+
+			Expect(receivedOffset(streamInChunk("some-stream", 0, contents[:half], false))).To(Equal(int64(half)))
+			Expect(receivedOffset(streamInChunk("some-stream", 0, contents[:half+1], false))).To(Equal(int64(half + 1)))
+
+			Expect(streamInChunk("some-stream", half, contents[half:], true).Code).To(Equal(204))
+
+			destPath := filepath.Join(volumeDir, "live", myVolume.Handle, "volume", "dest-path", "some-file")
+			Expect(ioutil.ReadFile(destPath)).To(Equal([]byte("file-content")))
+		})
+
+		It("returns 409 when a chunk is past the end of the stream", func() {
+			contents := tarBuffer.Bytes()
+
+			Expect(receivedOffset(streamInChunk("some-stream", 0, contents[:10], false))).To(Equal(int64(10)))
+			Expect(streamInChunk("some-stream", 20, contents[20:], true).Code).To(Equal(409))
+		})
+
+		It("returns 404 when a chunk is for a stream which was never started", func() {
+			recorder := streamInChunk("some-stream", 10, tarBuffer.Bytes()[10:], true)
+			Expect(recorder.Code).To(Equal(404))
+
+			var responseError *api.ErrorResponse
+			err := json.NewDecoder(recorder.Body).Decode(&responseError)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responseError.Message).To(Equal(api.ErrChunkedStreamNotFound.Error()))
+		})
+
+		It("returns 404 when the stream is carried on with after its final chunk", func() {
+			contents := tarBuffer.Bytes()
+
+			Expect(streamInChunk("some-stream", 0, contents, true).Code).To(Equal(204))
+			Expect(streamInChunk("some-stream", len(contents), nil, true).Code).To(Equal(404))
+		})
+
+		It("returns 400 when the offset is not a number", func() {
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in/some-stream?offset=start", myVolume.Handle), tarBuffer)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(400))
+		})
+
+		It("returns 400 when the stream does not match its digest", func() {
+			digest = "bogus-digest"
+
+			recorder := streamInChunk("some-stream", 0, tarBuffer.Bytes(), true)
+			Expect(recorder.Code).To(Equal(400))
+
+			var responseError *api.ErrorResponse
+			err := json.NewDecoder(recorder.Body).Decode(&responseError)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responseError.Message).To(Equal(volume.ErrDigestMismatch.Error()))
+		})
+	})
+
+	Describe("streaming deltas between volumes", func() {
+		var (
+			createVolume func(handle string) volume.Volume
+			writeFile    func(handle string, path string, content string)
+			volumePath   func(handle string, path string) string
+
+			encoding string
+		)
+
+		BeforeEach(func() {
+			encoding = string(baggageclaim.ZstdEncoding)
+
+			createVolume = func(handle string) volume.Volume {
+				body := &bytes.Buffer{}
+
+				err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+					Handle: handle,
+					Strategy: encStrategy(map[string]string{
+						"type": "empty",
+					}),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, err := http.NewRequest("POST", "/volumes", body)
+				Expect(err).NotTo(HaveOccurred())
+
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+				Expect(recorder.Code).To(Equal(201))
+
+				var created volume.Volume
+				err = json.NewDecoder(recorder.Body).Decode(&created)
+				Expect(err).NotTo(HaveOccurred())
+
+				return created
+			}
+
+			volumePath = func(handle string, path string) string {
+				return filepath.Join(volumeDir, "live", handle, "volume", path)
+			}
+
+			writeFile = func(handle string, path string, content string) {
+				tarBuffer := new(bytes.Buffer)
+
+				gzWriter := gzip.NewWriter(tarBuffer)
+				tarWriter := tar.NewWriter(gzWriter)
+
+				err := tarWriter.WriteHeader(&tar.Header{
+					Name: filepath.Base(path),
+					Mode: 0644,
+					Size: int64(len(content)),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = tarWriter.Write([]byte(content))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tarWriter.Close()).To(Succeed())
+				Expect(gzWriter.Close()).To(Succeed())
+
+				request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in?path=%s", handle, filepath.Dir(path)), tarBuffer)
+				request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+				Expect(recorder.Code).To(Equal(204))
+			}
+		})
+
+		JustBeforeEach(func() {
+			createVolume("base")
+			writeFile("base", "dir/same", "same")
+			writeFile("base", "dir/changed", "old")
+			writeFile("base", "gone", "gone")
+
+			createVolume("src")
+			writeFile("src", "dir/same", "same")
+			writeFile("src", "dir/changed", "new")
+			writeFile("src", "added", "added")
+
+			createVolume("dest")
+		})
+
+		It("recreates the volume from the base", func() {
+			manifestRequest, _ := http.NewRequest("GET", "/volumes/base/manifest", nil)
+			manifestRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(manifestRecorder, manifestRequest)
+			Expect(manifestRecorder.Code).To(Equal(200))
+
+			var manifest volume.Manifest
+			err := json.Unmarshal(manifestRecorder.Body.Bytes(), &manifest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manifest).To(HaveKey("dir/changed"))
+
+			deltaOutRequest, _ := http.NewRequest("PUT", "/volumes/src/stream-delta-out", manifestRecorder.Body)
+			deltaOutRequest.Header.Set("Accept-Encoding", encoding)
+			deltaOutRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(deltaOutRecorder, deltaOutRequest)
+			Expect(deltaOutRecorder.Code).To(Equal(200))
+
+			deltaInRequest, _ := http.NewRequest("PUT", "/volumes/dest/stream-delta-in?base=base", deltaOutRecorder.Body)
+			deltaInRequest.Header.Set("Content-Encoding", encoding)
+			deltaInRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(deltaInRecorder, deltaInRequest)
+			Expect(deltaInRecorder.Code).To(Equal(204))
+
+			Expect(ioutil.ReadFile(volumePath("dest", "dir/same"))).To(Equal([]byte("same")))
+			Expect(ioutil.ReadFile(volumePath("dest", "dir/changed"))).To(Equal([]byte("new")))
+			Expect(ioutil.ReadFile(volumePath("dest", "added"))).To(Equal([]byte("added")))
+			Expect(volumePath("dest", "gone")).NotTo(BeAnExistingFile())
+		})
+
+		It("returns 404 when the volume does not exist", func() {
+			request, _ := http.NewRequest("GET", "/volumes/bogus/manifest", nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(404))
+		})
+
+		It("returns 400 when the manifest is malformed", func() {
+			request, _ := http.NewRequest("PUT", "/volumes/src/stream-delta-out", bytes.NewBufferString("bogus"))
+			request.Header.Set("Accept-Encoding", encoding)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(400))
+		})
+
+		It("returns 400 when the base is missing", func() {
+			request, _ := http.NewRequest("PUT", "/volumes/dest/stream-delta-in", bytes.NewBufferString("bogus"))
+			request.Header.Set("Content-Encoding", encoding)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(400))
+		})
+
+		It("returns 400 when the delta is bad", func() {
+			request, _ := http.NewRequest("PUT", "/volumes/dest/stream-delta-in?base=base", bytes.NewBufferString("bogus"))
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(400))
+		})
+	})
+
+	Describe("persisting and hydrating volumes", func() {
+		var (
+			persistVolume func(handle string, key string) *httptest.ResponseRecorder
+			hydrateVolume func(handle string, key string) *httptest.ResponseRecorder
+		)
+
+		BeforeEach(func() {
+			persistVolume = func(handle string, key string) *httptest.ResponseRecorder {
+				request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/persist?key=%s", handle, key), nil)
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+				return recorder
+			}
+
+			hydrateVolume = func(handle string, key string) *httptest.ResponseRecorder {
+				body := &bytes.Buffer{}
+
+				err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+					Handle:   handle,
+					Strategy: baggageclaim.HydrateStrategy{Key: key}.Encode(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, _ := http.NewRequest("POST", "/volumes", body)
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+				return recorder
+			}
+		})
+
+		JustBeforeEach(func() {
+			body := &bytes.Buffer{}
+
+			err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+				Handle: "src",
+				Strategy: encStrategy(map[string]string{
+					"type": "empty",
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, _ := http.NewRequest("POST", "/volumes", body)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(201))
+
+			err = ioutil.WriteFile(filepath.Join(volumeDir, "live", "src", "volume", "some-file"), []byte("some-content"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the driver persists volumes", func() {
+			BeforeEach(func() {
+				volumeDriver = &persistingDriver{archives: map[string][]byte{}}
+			})
+
+			It("hydrates volumes with what was persisted", func() {
+				Expect(persistVolume("src", "some-key").Code).To(Equal(204))
+				Expect(hydrateVolume("dest", "some-key").Code).To(Equal(201))
+
+				Expect(ioutil.ReadFile(filepath.Join(volumeDir, "live", "dest", "volume", "some-file"))).To(Equal([]byte("some-content")))
+			})
+
+			It("returns 422 when nothing was persisted under the key", func() {
+				recorder := hydrateVolume("dest", "bogus-key")
+				Expect(recorder.Code).To(Equal(422))
+				Expect(recorder.Body).To(ContainSubstring(volume.ErrVolumeNotPersisted.Error()))
+			})
+
+			It("returns 404 when the volume does not exist", func() {
+				Expect(persistVolume("bogus", "some-key").Code).To(Equal(404))
+			})
+
+			It("returns 400 when the key is missing", func() {
+				Expect(persistVolume("src", "").Code).To(Equal(400))
+			})
+		})
+
+		Context("when the driver does not persist volumes", func() {
+			It("returns 501", func() {
+				Expect(persistVolume("src", "some-key").Code).To(Equal(501))
+				Expect(hydrateVolume("dest", "some-key").Code).To(Equal(501))
+			})
+		})
+	})
+
+	Describe("quotas", func() {
+		var (
+			createVolume func(handle string, quota uint64) *httptest.ResponseRecorder
+			getQuota     func(handle string) *httptest.ResponseRecorder
+		)
+
+		BeforeEach(func() {
+			createVolume = func(handle string, quota uint64) *httptest.ResponseRecorder {
+				body := &bytes.Buffer{}
+
+				err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+					Handle: handle,
+					Strategy: encStrategy(map[string]string{
+						"type": "empty",
+					}),
+					Quota: quota,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, _ := http.NewRequest("POST", "/volumes", body)
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+				return recorder
+			}
+
+			getQuota = func(handle string) *httptest.ResponseRecorder {
+				request, _ := http.NewRequest("GET", fmt.Sprintf("/volumes/%s/quota", handle), nil)
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+				return recorder
+			}
+		})
+
+		Context("when the driver limits volumes", func() {
+			BeforeEach(func() {
+				volumeDriver = &limitingDriver{limits: map[string]uint64{}}
+			})
+
+			It("limits volumes created with a quota", func() {
+				Expect(createVolume("some-volume", 1024).Code).To(Equal(201))
+
+				recorder := getQuota("some-volume")
+				Expect(recorder.Code).To(Equal(200))
+
+				var quota baggageclaim.VolumeQuota
+				err := json.NewDecoder(recorder.Body).Decode(&quota)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quota).To(Equal(baggageclaim.VolumeQuota{Limit: 1024}))
+			})
+
+			It("does not limit volumes created without one", func() {
+				Expect(createVolume("some-volume", 0).Code).To(Equal(201))
+				Expect(volumeDriver.(*limitingDriver).limits).To(BeEmpty())
+			})
+
+			It("returns 404 when the volume does not exist", func() {
+				Expect(getQuota("bogus").Code).To(Equal(404))
+			})
+		})
+
+		Context("when the driver does not limit volumes", func() {
+			It("creates volumes without a quota", func() {
+				Expect(createVolume("some-volume", 1024).Code).To(Equal(201))
+			})
+
+			It("returns 501", func() {
+				Expect(createVolume("some-volume", 0).Code).To(Equal(201))
+
+				recorder := getQuota("some-volume")
+				Expect(recorder.Code).To(Equal(501))
+				Expect(recorder.Body).To(ContainSubstring(volume.ErrQuotaUnsupported.Error()))
+			})
+		})
+	})
 })
 
 func encStrategy(strategy map[string]string) *json.RawMessage {
@@ -1240,3 +2083,46 @@ func encStrategy(strategy map[string]string) *json.RawMessage {
 
 	return &msg
 }
+
+// persistingDriver keeps the archives of persisted volumes in memory.
+type persistingDriver struct {
+	driver.NaiveDriver
+
+	archives map[string][]byte
+}
+
+func (driver *persistingDriver) PersistVolume(key string, archive io.Reader) error {
+	content, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return err
+	}
+
+	driver.archives[key] = content
+
+	return nil
+}
+
+func (driver *persistingDriver) PersistedVolume(key string) (io.ReadCloser, error) {
+	content, found := driver.archives[key]
+	if !found {
+		return nil, volume.ErrVolumeNotPersisted
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
+// limitingDriver records the quotas of volumes without enforcing them.
+type limitingDriver struct {
+	driver.NaiveDriver
+
+	limits map[string]uint64
+}
+
+func (driver *limitingDriver) SetVolumeQuota(vol volume.FilesystemVolume, limit uint64) error {
+	driver.limits[vol.Handle()] = limit
+	return nil
+}
+
+func (driver *limitingDriver) VolumeQuota(vol volume.FilesystemVolume) (volume.Quota, error) {
+	return volume.Quota{Limit: driver.limits[vol.Handle()]}, nil
+}
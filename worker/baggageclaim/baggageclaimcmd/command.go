@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/worker/baggageclaim/api"
 	"github.com/concourse/concourse/worker/baggageclaim/uidgid"
 	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/driver"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
 	"github.com/concourse/flag"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
@@ -29,16 +33,34 @@ type BaggageclaimCommand struct {
 	P2pInterfaceNamePattern string `long:"p2p-interface-name-pattern" default:"eth0" description:"Regular expression to match a network interface for p2p streaming"`
 	P2pInterfaceFamily int `long:"p2p-interface-family" default:"4" choice:"4" choice:"6" description:"4 for IPv4 and 6 for IPv6"`
 
+	StreamBandwidthLimit int `long:"stream-bandwidth-limit" default:"0" description:"Maximum rate, in bytes per second, at which volumes are streamed into this worker, and at which they're streamed out of it, across all streams. 0 means no limit."`
+
 	VolumesDir flag.Dir `long:"volumes" required:"true" description:"Directory in which to place volume data."`
 
-	Driver string `long:"driver" default:"detect" choice:"detect" choice:"naive" choice:"btrfs" choice:"overlay" description:"Driver to use for managing volumes."`
+	LayerCacheDir       string        `long:"layer-cache-dir"        description:"Directory in which to cache the layers of images pulled from registries. Defaults to a directory within the volumes directory."`
+	LayerCacheSizeLimit uint64        `long:"layer-cache-size-limit" default:"0" description:"Maximum size, in bytes, of the cached layers of images pulled from registries. The least recently used layers beyond the limit are removed. 0 means no limit."`
+	ImagePullTimeout    time.Duration `long:"image-pull-timeout"     default:"1h" description:"Maximum time to spend pulling an image from a registry. 0 means no limit."`
+
+	Driver string `long:"driver" default:"detect" choice:"detect" choice:"naive" choice:"btrfs" choice:"overlay" choice:"zfs" choice:"windows" description:"Driver to use for managing volumes. The windows driver is the default on Windows."`
 
 	BtrfsBin string `long:"btrfs-bin" default:"btrfs" description:"Path to btrfs binary"`
 	MkfsBin  string `long:"mkfs-bin" default:"mkfs.btrfs" description:"Path to mkfs.btrfs binary"`
 
 	OverlaysDir string `long:"overlays-dir" description:"Path to directory in which to store overlay data"`
 
+	ZfsBin         string `long:"zfs-bin"         default:"zfs" description:"Path to zfs binary"`
+	ZfsDataset     string `long:"zfs-dataset"     description:"ZFS dataset under which to create a dataset for each volume. Required by the zfs driver."`
+	ZfsVolumeQuota string `long:"zfs-volume-quota" description:"Quota to set on each volume's dataset, e.g. 10G. Volumes are not limited by default."`
+	ZfsCompression string `long:"zfs-compression" description:"Compression algorithm to set on each volume's dataset, e.g. lz4. Inherited from the parent dataset by default."`
+
 	DisableUserNamespaces bool `long:"disable-user-namespaces" description:"Disable remapping of user/group IDs in unprivileged volumes."`
+
+	RemapPrivilegedVolumes bool `long:"remap-privileged-volumes" description:"Remap user/group IDs in privileged volumes too. Required when the container runtime runs privileged containers in a user namespace."`
+
+	S3Bucket   string `long:"s3-bucket"   description:"S3 bucket in which to persist volumes, so that they can be hydrated by other workers. Credentials are taken from the environment."`
+	S3Prefix   string `long:"s3-prefix"   description:"Prefix of the keys under which volumes are persisted in the S3 bucket."`
+	S3Region   string `long:"s3-region"   description:"Region of the S3 bucket."`
+	S3Endpoint string `long:"s3-endpoint" description:"Endpoint of an S3-compatible store to use instead of AWS S3."`
 }
 
 func (cmd *BaggageclaimCommand) Execute(args []string) error {
@@ -80,6 +102,12 @@ func (cmd *BaggageclaimCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
+	driver, err = cmd.persistentDriver(driver)
+	if err != nil {
+		logger.Error("failed-to-set-up-persistent-driver", err)
+		return nil, err
+	}
+
 	filesystem, err := volume.NewFilesystem(driver, cmd.VolumesDir.Path())
 	if err != nil {
 		logger.Error("failed-to-initialize-filesystem", err)
@@ -94,11 +122,27 @@ func (cmd *BaggageclaimCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	volumeRepo := volume.NewRepository(
 		filesystem,
+		driver,
 		locker,
 		privilegedNamespacer,
 		unprivilegedNamespacer,
 	)
 
+	if cmd.RemapPrivilegedVolumes {
+		volumeRepo = volume.NewUnprivilegedRepository(volumeRepo)
+	}
+
+	layerCacheDir := cmd.LayerCacheDir
+	if layerCacheDir == "" {
+		layerCacheDir = filepath.Join(cmd.VolumesDir.Path(), "layers")
+	}
+
+	layerCache, err := registry.NewLayerCache(layerCacheDir, cmd.LayerCacheSizeLimit)
+	if err != nil {
+		logger.Error("failed-to-create-layer-cache", err)
+		return nil, err
+	}
+
 	re, err := regexp.Compile(cmd.P2pInterfaceNamePattern)
 	if err != nil {
 		logger.Error("failed-to-compile-p2p-interface-name-pattern", err)
@@ -106,11 +150,13 @@ func (cmd *BaggageclaimCommand) Runner(args []string) (ifrit.Runner, error) {
 	}
 	apiHandler, err := api.NewHandler(
 		logger.Session("api"),
-		volume.NewStrategerizer(),
+		volume.NewStrategerizer(driver, layerCache, cmd.ImagePullTimeout),
 		volumeRepo,
+		cmd.VolumesDir.Path(),
 		re,
 		cmd.P2pInterfaceFamily,
 		cmd.BindPort,
+		cmd.StreamBandwidthLimit,
 	)
 	if err != nil {
 		logger.Fatal("failed-to-create-handler", err)
@@ -161,3 +207,11 @@ func onReady(runner ifrit.Runner, cb func()) ifrit.Runner {
 		}
 	})
 }
+
+func (cmd *BaggageclaimCommand) persistentDriver(localDriver volume.Driver) (volume.Driver, error) {
+	if cmd.S3Bucket == "" {
+		return localDriver, nil
+	}
+
+	return driver.NewS3Driver(localDriver, cmd.S3Bucket, cmd.S3Prefix, cmd.S3Region, cmd.S3Endpoint)
+}
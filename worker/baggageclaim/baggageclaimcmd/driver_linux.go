@@ -81,6 +81,10 @@ func (cmd *BaggageclaimCommand) driver(logger lager.Logger) (volume.Driver, erro
 		return nil, errors.New("overlay driver requires kernel version >= 4.0.0")
 	}
 
+	if cmd.Driver == "zfs" && cmd.ZfsDataset == "" {
+		return nil, errors.New("zfs driver requires a dataset to create volumes under")
+	}
+
 	logger.Info("using-driver", lager.Data{"driver": cmd.Driver})
 
 	var d volume.Driver
@@ -89,6 +93,8 @@ func (cmd *BaggageclaimCommand) driver(logger lager.Logger) (volume.Driver, erro
 		d = driver.NewOverlayDriver(cmd.OverlaysDir)
 	case "btrfs":
 		d = driver.NewBtrFSDriver(logger.Session("driver"), cmd.BtrfsBin)
+	case "zfs":
+		d = driver.NewZFSDriver(logger.Session("driver"), cmd.ZfsBin, cmd.ZfsDataset, cmd.ZfsVolumeQuota, cmd.ZfsCompression)
 	case "naive":
 		d = &driver.NaiveDriver{}
 	default:
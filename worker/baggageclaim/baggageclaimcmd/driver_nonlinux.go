@@ -3,11 +3,21 @@
 package baggageclaimcmd
 
 import (
+	"runtime"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/worker/baggageclaim/volume"
 	"github.com/concourse/concourse/worker/baggageclaim/volume/driver"
 )
 
 func (cmd *BaggageclaimCommand) driver(logger lager.Logger) (volume.Driver, error) {
+	if cmd.Driver == "detect" && runtime.GOOS == "windows" {
+		cmd.Driver = "windows"
+	}
+
+	if cmd.Driver == "windows" {
+		return &driver.WindowsLayerDriver{}, nil
+	}
+
 	return &driver.NaiveDriver{}, nil
 }
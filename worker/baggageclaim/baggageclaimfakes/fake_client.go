@@ -78,6 +78,19 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	StatsStub        func(lager.Logger) (baggageclaim.Stats, error)
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	statsReturns struct {
+		result1 baggageclaim.Stats
+		result2 error
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 baggageclaim.Stats
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,15 +103,16 @@ func (fake *FakeClient) CreateVolume(arg1 lager.Logger, arg2 string, arg3 baggag
 		arg2 string
 		arg3 baggageclaim.VolumeSpec
 	}{arg1, arg2, arg3})
+	stub := fake.CreateVolumeStub
+	fakeReturns := fake.createVolumeReturns
 	fake.recordInvocation("CreateVolume", []interface{}{arg1, arg2, arg3})
 	fake.createVolumeMutex.Unlock()
-	if fake.CreateVolumeStub != nil {
-		return fake.CreateVolumeStub(arg1, arg2, arg3)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.createVolumeReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -154,15 +168,16 @@ func (fake *FakeClient) DestroyVolume(arg1 lager.Logger, arg2 string) error {
 		arg1 lager.Logger
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.DestroyVolumeStub
+	fakeReturns := fake.destroyVolumeReturns
 	fake.recordInvocation("DestroyVolume", []interface{}{arg1, arg2})
 	fake.destroyVolumeMutex.Unlock()
-	if fake.DestroyVolumeStub != nil {
-		return fake.DestroyVolumeStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.destroyVolumeReturns
 	return fakeReturns.result1
 }
 
@@ -220,15 +235,16 @@ func (fake *FakeClient) DestroyVolumes(arg1 lager.Logger, arg2 []string) error {
 		arg1 lager.Logger
 		arg2 []string
 	}{arg1, arg2Copy})
+	stub := fake.DestroyVolumesStub
+	fakeReturns := fake.destroyVolumesReturns
 	fake.recordInvocation("DestroyVolumes", []interface{}{arg1, arg2Copy})
 	fake.destroyVolumesMutex.Unlock()
-	if fake.DestroyVolumesStub != nil {
-		return fake.DestroyVolumesStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.destroyVolumesReturns
 	return fakeReturns.result1
 }
 
@@ -281,15 +297,16 @@ func (fake *FakeClient) ListVolumes(arg1 lager.Logger, arg2 baggageclaim.VolumeP
 		arg1 lager.Logger
 		arg2 baggageclaim.VolumeProperties
 	}{arg1, arg2})
+	stub := fake.ListVolumesStub
+	fakeReturns := fake.listVolumesReturns
 	fake.recordInvocation("ListVolumes", []interface{}{arg1, arg2})
 	fake.listVolumesMutex.Unlock()
-	if fake.ListVolumesStub != nil {
-		return fake.ListVolumesStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.listVolumesReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -345,15 +362,16 @@ func (fake *FakeClient) LookupVolume(arg1 lager.Logger, arg2 string) (baggagecla
 		arg1 lager.Logger
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.LookupVolumeStub
+	fakeReturns := fake.lookupVolumeReturns
 	fake.recordInvocation("LookupVolume", []interface{}{arg1, arg2})
 	fake.lookupVolumeMutex.Unlock()
-	if fake.LookupVolumeStub != nil {
-		return fake.LookupVolumeStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
 	}
-	fakeReturns := fake.lookupVolumeReturns
 	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
@@ -405,6 +423,70 @@ func (fake *FakeClient) LookupVolumeReturnsOnCall(i int, result1 baggageclaim.Vo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) Stats(arg1 lager.Logger) (baggageclaim.Stats, error) {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	stub := fake.StatsStub
+	fakeReturns := fake.statsReturns
+	fake.recordInvocation("Stats", []interface{}{arg1})
+	fake.statsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *FakeClient) StatsCalls(stub func(lager.Logger) (baggageclaim.Stats, error)) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *FakeClient) StatsArgsForCall(i int) lager.Logger {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	argsForCall := fake.statsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) StatsReturns(result1 baggageclaim.Stats, result2 error) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 baggageclaim.Stats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StatsReturnsOnCall(i int, result1 baggageclaim.Stats, result2 error) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 baggageclaim.Stats
+			result2 error
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 baggageclaim.Stats
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -418,6 +500,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listVolumesMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
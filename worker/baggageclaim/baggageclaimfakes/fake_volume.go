@@ -66,6 +66,18 @@ type FakeVolume struct {
 	pathReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PersistStub        func(context.Context, string) error
+	persistMutex       sync.RWMutex
+	persistArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	persistReturns struct {
+		result1 error
+	}
+	persistReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PropertiesStub        func() (baggageclaim.VolumeProperties, error)
 	propertiesMutex       sync.RWMutex
 	propertiesArgsForCall []struct {
@@ -78,6 +90,19 @@ type FakeVolume struct {
 		result1 baggageclaim.VolumeProperties
 		result2 error
 	}
+	QuotaStub        func(context.Context) (baggageclaim.VolumeQuota, error)
+	quotaMutex       sync.RWMutex
+	quotaArgsForCall []struct {
+		arg1 context.Context
+	}
+	quotaReturns struct {
+		result1 baggageclaim.VolumeQuota
+		result2 error
+	}
+	quotaReturnsOnCall map[int]struct {
+		result1 baggageclaim.VolumeQuota
+		result2 error
+	}
 	SetPrivilegedStub        func(bool) error
 	setPrivilegedMutex       sync.RWMutex
 	setPrivilegedArgsForCall []struct {
@@ -101,6 +126,48 @@ type FakeVolume struct {
 	setPropertyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SizeStub        func(context.Context) (uint64, error)
+	sizeMutex       sync.RWMutex
+	sizeArgsForCall []struct {
+		arg1 context.Context
+	}
+	sizeReturns struct {
+		result1 uint64
+		result2 error
+	}
+	sizeReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
+	StreamDeltaInStub        func(context.Context, string, baggageclaim.Encoding, io.Reader) error
+	streamDeltaInMutex       sync.RWMutex
+	streamDeltaInArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 baggageclaim.Encoding
+		arg4 io.Reader
+	}
+	streamDeltaInReturns struct {
+		result1 error
+	}
+	streamDeltaInReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StreamDeltaOutStub        func(context.Context, io.Reader, baggageclaim.Encoding) (io.ReadCloser, error)
+	streamDeltaOutMutex       sync.RWMutex
+	streamDeltaOutArgsForCall []struct {
+		arg1 context.Context
+		arg2 io.Reader
+		arg3 baggageclaim.Encoding
+	}
+	streamDeltaOutReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	streamDeltaOutReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	StreamInStub        func(context.Context, string, baggageclaim.Encoding, io.Reader) error
 	streamInMutex       sync.RWMutex
 	streamInArgsForCall []struct {
@@ -115,6 +182,19 @@ type FakeVolume struct {
 	streamInReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StreamManifestStub        func(context.Context) (io.ReadCloser, error)
+	streamManifestMutex       sync.RWMutex
+	streamManifestArgsForCall []struct {
+		arg1 context.Context
+	}
+	streamManifestReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	streamManifestReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	StreamOutStub        func(context.Context, string, baggageclaim.Encoding) (io.ReadCloser, error)
 	streamOutMutex       sync.RWMutex
 	streamOutArgsForCall []struct {
@@ -423,6 +503,68 @@ func (fake *FakeVolume) PathReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeVolume) Persist(arg1 context.Context, arg2 string) error {
+	fake.persistMutex.Lock()
+	ret, specificReturn := fake.persistReturnsOnCall[len(fake.persistArgsForCall)]
+	fake.persistArgsForCall = append(fake.persistArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PersistStub
+	fakeReturns := fake.persistReturns
+	fake.recordInvocation("Persist", []interface{}{arg1, arg2})
+	fake.persistMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) PersistCallCount() int {
+	fake.persistMutex.RLock()
+	defer fake.persistMutex.RUnlock()
+	return len(fake.persistArgsForCall)
+}
+
+func (fake *FakeVolume) PersistCalls(stub func(context.Context, string) error) {
+	fake.persistMutex.Lock()
+	defer fake.persistMutex.Unlock()
+	fake.PersistStub = stub
+}
+
+func (fake *FakeVolume) PersistArgsForCall(i int) (context.Context, string) {
+	fake.persistMutex.RLock()
+	defer fake.persistMutex.RUnlock()
+	argsForCall := fake.persistArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolume) PersistReturns(result1 error) {
+	fake.persistMutex.Lock()
+	defer fake.persistMutex.Unlock()
+	fake.PersistStub = nil
+	fake.persistReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) PersistReturnsOnCall(i int, result1 error) {
+	fake.persistMutex.Lock()
+	defer fake.persistMutex.Unlock()
+	fake.PersistStub = nil
+	if fake.persistReturnsOnCall == nil {
+		fake.persistReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.persistReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolume) Properties() (baggageclaim.VolumeProperties, error) {
 	fake.propertiesMutex.Lock()
 	ret, specificReturn := fake.propertiesReturnsOnCall[len(fake.propertiesArgsForCall)]
@@ -478,6 +620,70 @@ func (fake *FakeVolume) PropertiesReturnsOnCall(i int, result1 baggageclaim.Volu
 	}{result1, result2}
 }
 
+func (fake *FakeVolume) Quota(arg1 context.Context) (baggageclaim.VolumeQuota, error) {
+	fake.quotaMutex.Lock()
+	ret, specificReturn := fake.quotaReturnsOnCall[len(fake.quotaArgsForCall)]
+	fake.quotaArgsForCall = append(fake.quotaArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.QuotaStub
+	fakeReturns := fake.quotaReturns
+	fake.recordInvocation("Quota", []interface{}{arg1})
+	fake.quotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) QuotaCallCount() int {
+	fake.quotaMutex.RLock()
+	defer fake.quotaMutex.RUnlock()
+	return len(fake.quotaArgsForCall)
+}
+
+func (fake *FakeVolume) QuotaCalls(stub func(context.Context) (baggageclaim.VolumeQuota, error)) {
+	fake.quotaMutex.Lock()
+	defer fake.quotaMutex.Unlock()
+	fake.QuotaStub = stub
+}
+
+func (fake *FakeVolume) QuotaArgsForCall(i int) context.Context {
+	fake.quotaMutex.RLock()
+	defer fake.quotaMutex.RUnlock()
+	argsForCall := fake.quotaArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVolume) QuotaReturns(result1 baggageclaim.VolumeQuota, result2 error) {
+	fake.quotaMutex.Lock()
+	defer fake.quotaMutex.Unlock()
+	fake.QuotaStub = nil
+	fake.quotaReturns = struct {
+		result1 baggageclaim.VolumeQuota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) QuotaReturnsOnCall(i int, result1 baggageclaim.VolumeQuota, result2 error) {
+	fake.quotaMutex.Lock()
+	defer fake.quotaMutex.Unlock()
+	fake.QuotaStub = nil
+	if fake.quotaReturnsOnCall == nil {
+		fake.quotaReturnsOnCall = make(map[int]struct {
+			result1 baggageclaim.VolumeQuota
+			result2 error
+		})
+	}
+	fake.quotaReturnsOnCall[i] = struct {
+		result1 baggageclaim.VolumeQuota
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolume) SetPrivileged(arg1 bool) error {
 	fake.setPrivilegedMutex.Lock()
 	ret, specificReturn := fake.setPrivilegedReturnsOnCall[len(fake.setPrivilegedArgsForCall)]
@@ -599,6 +805,200 @@ func (fake *FakeVolume) SetPropertyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) Size(arg1 context.Context) (uint64, error) {
+	fake.sizeMutex.Lock()
+	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
+	fake.sizeArgsForCall = append(fake.sizeArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.SizeStub
+	fakeReturns := fake.sizeReturns
+	fake.recordInvocation("Size", []interface{}{arg1})
+	fake.sizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) SizeCallCount() int {
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	return len(fake.sizeArgsForCall)
+}
+
+func (fake *FakeVolume) SizeCalls(stub func(context.Context) (uint64, error)) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = stub
+}
+
+func (fake *FakeVolume) SizeArgsForCall(i int) context.Context {
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	argsForCall := fake.sizeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVolume) SizeReturns(result1 uint64, result2 error) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = nil
+	fake.sizeReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) SizeReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = nil
+	if fake.sizeReturnsOnCall == nil {
+		fake.sizeReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.sizeReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) StreamDeltaIn(arg1 context.Context, arg2 string, arg3 baggageclaim.Encoding, arg4 io.Reader) error {
+	fake.streamDeltaInMutex.Lock()
+	ret, specificReturn := fake.streamDeltaInReturnsOnCall[len(fake.streamDeltaInArgsForCall)]
+	fake.streamDeltaInArgsForCall = append(fake.streamDeltaInArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 baggageclaim.Encoding
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.StreamDeltaInStub
+	fakeReturns := fake.streamDeltaInReturns
+	fake.recordInvocation("StreamDeltaIn", []interface{}{arg1, arg2, arg3, arg4})
+	fake.streamDeltaInMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) StreamDeltaInCallCount() int {
+	fake.streamDeltaInMutex.RLock()
+	defer fake.streamDeltaInMutex.RUnlock()
+	return len(fake.streamDeltaInArgsForCall)
+}
+
+func (fake *FakeVolume) StreamDeltaInCalls(stub func(context.Context, string, baggageclaim.Encoding, io.Reader) error) {
+	fake.streamDeltaInMutex.Lock()
+	defer fake.streamDeltaInMutex.Unlock()
+	fake.StreamDeltaInStub = stub
+}
+
+func (fake *FakeVolume) StreamDeltaInArgsForCall(i int) (context.Context, string, baggageclaim.Encoding, io.Reader) {
+	fake.streamDeltaInMutex.RLock()
+	defer fake.streamDeltaInMutex.RUnlock()
+	argsForCall := fake.streamDeltaInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolume) StreamDeltaInReturns(result1 error) {
+	fake.streamDeltaInMutex.Lock()
+	defer fake.streamDeltaInMutex.Unlock()
+	fake.StreamDeltaInStub = nil
+	fake.streamDeltaInReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) StreamDeltaInReturnsOnCall(i int, result1 error) {
+	fake.streamDeltaInMutex.Lock()
+	defer fake.streamDeltaInMutex.Unlock()
+	fake.StreamDeltaInStub = nil
+	if fake.streamDeltaInReturnsOnCall == nil {
+		fake.streamDeltaInReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamDeltaInReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) StreamDeltaOut(arg1 context.Context, arg2 io.Reader, arg3 baggageclaim.Encoding) (io.ReadCloser, error) {
+	fake.streamDeltaOutMutex.Lock()
+	ret, specificReturn := fake.streamDeltaOutReturnsOnCall[len(fake.streamDeltaOutArgsForCall)]
+	fake.streamDeltaOutArgsForCall = append(fake.streamDeltaOutArgsForCall, struct {
+		arg1 context.Context
+		arg2 io.Reader
+		arg3 baggageclaim.Encoding
+	}{arg1, arg2, arg3})
+	stub := fake.StreamDeltaOutStub
+	fakeReturns := fake.streamDeltaOutReturns
+	fake.recordInvocation("StreamDeltaOut", []interface{}{arg1, arg2, arg3})
+	fake.streamDeltaOutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) StreamDeltaOutCallCount() int {
+	fake.streamDeltaOutMutex.RLock()
+	defer fake.streamDeltaOutMutex.RUnlock()
+	return len(fake.streamDeltaOutArgsForCall)
+}
+
+func (fake *FakeVolume) StreamDeltaOutCalls(stub func(context.Context, io.Reader, baggageclaim.Encoding) (io.ReadCloser, error)) {
+	fake.streamDeltaOutMutex.Lock()
+	defer fake.streamDeltaOutMutex.Unlock()
+	fake.StreamDeltaOutStub = stub
+}
+
+func (fake *FakeVolume) StreamDeltaOutArgsForCall(i int) (context.Context, io.Reader, baggageclaim.Encoding) {
+	fake.streamDeltaOutMutex.RLock()
+	defer fake.streamDeltaOutMutex.RUnlock()
+	argsForCall := fake.streamDeltaOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolume) StreamDeltaOutReturns(result1 io.ReadCloser, result2 error) {
+	fake.streamDeltaOutMutex.Lock()
+	defer fake.streamDeltaOutMutex.Unlock()
+	fake.StreamDeltaOutStub = nil
+	fake.streamDeltaOutReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) StreamDeltaOutReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.streamDeltaOutMutex.Lock()
+	defer fake.streamDeltaOutMutex.Unlock()
+	fake.StreamDeltaOutStub = nil
+	if fake.streamDeltaOutReturnsOnCall == nil {
+		fake.streamDeltaOutReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.streamDeltaOutReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolume) StreamIn(arg1 context.Context, arg2 string, arg3 baggageclaim.Encoding, arg4 io.Reader) error {
 	fake.streamInMutex.Lock()
 	ret, specificReturn := fake.streamInReturnsOnCall[len(fake.streamInArgsForCall)]
@@ -662,6 +1062,70 @@ func (fake *FakeVolume) StreamInReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) StreamManifest(arg1 context.Context) (io.ReadCloser, error) {
+	fake.streamManifestMutex.Lock()
+	ret, specificReturn := fake.streamManifestReturnsOnCall[len(fake.streamManifestArgsForCall)]
+	fake.streamManifestArgsForCall = append(fake.streamManifestArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.StreamManifestStub
+	fakeReturns := fake.streamManifestReturns
+	fake.recordInvocation("StreamManifest", []interface{}{arg1})
+	fake.streamManifestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) StreamManifestCallCount() int {
+	fake.streamManifestMutex.RLock()
+	defer fake.streamManifestMutex.RUnlock()
+	return len(fake.streamManifestArgsForCall)
+}
+
+func (fake *FakeVolume) StreamManifestCalls(stub func(context.Context) (io.ReadCloser, error)) {
+	fake.streamManifestMutex.Lock()
+	defer fake.streamManifestMutex.Unlock()
+	fake.StreamManifestStub = stub
+}
+
+func (fake *FakeVolume) StreamManifestArgsForCall(i int) context.Context {
+	fake.streamManifestMutex.RLock()
+	defer fake.streamManifestMutex.RUnlock()
+	argsForCall := fake.streamManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVolume) StreamManifestReturns(result1 io.ReadCloser, result2 error) {
+	fake.streamManifestMutex.Lock()
+	defer fake.streamManifestMutex.Unlock()
+	fake.StreamManifestStub = nil
+	fake.streamManifestReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) StreamManifestReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.streamManifestMutex.Lock()
+	defer fake.streamManifestMutex.Unlock()
+	fake.StreamManifestStub = nil
+	if fake.streamManifestReturnsOnCall == nil {
+		fake.streamManifestReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.streamManifestReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolume) StreamOut(arg1 context.Context, arg2 string, arg3 baggageclaim.Encoding) (io.ReadCloser, error) {
 	fake.streamOutMutex.Lock()
 	ret, specificReturn := fake.streamOutReturnsOnCall[len(fake.streamOutArgsForCall)]
@@ -803,14 +1267,26 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.handleMutex.RUnlock()
 	fake.pathMutex.RLock()
 	defer fake.pathMutex.RUnlock()
+	fake.persistMutex.RLock()
+	defer fake.persistMutex.RUnlock()
 	fake.propertiesMutex.RLock()
 	defer fake.propertiesMutex.RUnlock()
+	fake.quotaMutex.RLock()
+	defer fake.quotaMutex.RUnlock()
 	fake.setPrivilegedMutex.RLock()
 	defer fake.setPrivilegedMutex.RUnlock()
 	fake.setPropertyMutex.RLock()
 	defer fake.setPropertyMutex.RUnlock()
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	fake.streamDeltaInMutex.RLock()
+	defer fake.streamDeltaInMutex.RUnlock()
+	fake.streamDeltaOutMutex.RLock()
+	defer fake.streamDeltaOutMutex.RUnlock()
 	fake.streamInMutex.RLock()
 	defer fake.streamInMutex.RUnlock()
+	fake.streamManifestMutex.RLock()
+	defer fake.streamManifestMutex.RUnlock()
 	fake.streamOutMutex.RLock()
 	defer fake.streamOutMutex.RUnlock()
 	fake.streamP2pOutMutex.RLock()
@@ -13,6 +13,11 @@ type Encoding string
 const GzipEncoding Encoding = "gzip"
 const ZstdEncoding Encoding = "zstd"
 
+// MinZstdLevel and MaxZstdLevel bound the levels volumes can be streamed out
+// with zstd at.
+const MinZstdLevel = 1
+const MaxZstdLevel = 22
+
 //go:generate counterfeiter . Client
 
 // Client represents a client connection to a BaggageClaim server.
@@ -68,6 +73,12 @@ type Client interface {
 	// DestroyVolume returns an error if the volume deletion fails. It does not
 	// return an error if the volume was not found on the server.
 	DestroyVolume(lager.Logger, string) error
+
+	// Stats returns the disk usage and streaming stats of the server.
+	//
+	// You are required to pass in a logger to the call to retain context across
+	// the library boundary.
+	Stats(lager.Logger) (Stats, error)
 }
 
 //go:generate counterfeiter . Volume
@@ -117,6 +128,32 @@ type Volume interface {
 	// StreamP2pOut streams the contents of this volume directly to another
 	// baggageclaim server on the same network.
 	StreamP2pOut(ctx context.Context, path string, streamInURL string, encoding Encoding) error
+
+	// StreamManifest streams a JSON manifest of the volume's contents, which
+	// another volume can stream out the difference from with StreamDeltaOut.
+	StreamManifest(ctx context.Context) (io.ReadCloser, error)
+
+	// StreamDeltaOut streams what differs between the contents of this volume
+	// and those described by a manifest from StreamManifest.
+	StreamDeltaOut(ctx context.Context, manifest io.Reader, encoding Encoding) (io.ReadCloser, error)
+
+	// StreamDeltaIn populates this volume with a copy of the base volume, on
+	// the same server, changed by the delta streamed out of another volume
+	// against the base volume's manifest.
+	StreamDeltaIn(ctx context.Context, base string, encoding Encoding, delta io.Reader) error
+
+	// Persist stores an archive of the volume's contents under the key, so
+	// that a volume can be created from it with a HydrateStrategy on any
+	// server with the same object store. ErrPersistenceUnsupported is
+	// returned if the server's driver doesn't persist volumes.
+	Persist(ctx context.Context, key string) error
+
+	// Quota returns how much of the volume's quota is used. ErrQuotaUnsupported
+	// is returned if the server's driver doesn't limit the size of volumes.
+	Quota(ctx context.Context) (VolumeQuota, error)
+
+	// Size returns the number of bytes the volume takes up.
+	Size(ctx context.Context) (uint64, error)
 }
 
 //go:generate counterfeiter . VolumeFuture
@@ -161,6 +198,11 @@ type VolumeSpec struct {
 	// translation of the files in the volume so that they can be read by a
 	// non-privileged user.
 	Privileged bool
+
+	// Quota is the number of bytes the volume is limited to. It is only
+	// enforced by servers whose driver supports quotas, and is ignored by
+	// others. 0 means no limit.
+	Quota uint64
 }
 
 type Strategy interface {
@@ -212,6 +254,69 @@ func (strategy COWStrategy) Encode() *json.RawMessage {
 	return &msg
 }
 
+// HydrateStrategy creates a volume from the archive persisted under a key by
+// any server with the same object store. Creating it fails with
+// ErrVolumeNotPersisted if there is no such archive.
+type HydrateStrategy struct {
+	Key string
+}
+
+func (strategy HydrateStrategy) Encode() *json.RawMessage {
+	payload, _ := json.Marshal(struct {
+		Type string `json:"type"`
+		Key  string `json:"key"`
+	}{
+		Type: "hydrate",
+		Key:  strategy.Key,
+	})
+
+	msg := json.RawMessage(payload)
+	return &msg
+}
+
+// RegistryImageStrategy creates a volume from an image pulled straight from a
+// registry, pinned to its digest. The image's filesystem is unpacked into
+// rootfs/ and its config is written to metadata.json, just as image resources
+// lay out the images they fetch. The layers of the image are cached by the
+// server, so pulling images which share layers only downloads them once.
+type RegistryImageStrategy struct {
+	// Repository is the repository of the image, e.g. "busybox" or
+	// "registry.example.com/some/image".
+	Repository string
+	// Digest is the sha256 digest of the image's manifest, or of the index
+	// of its manifests for different platforms.
+	Digest string
+
+	// Username and Password authenticate with the registry, if it requires
+	// it.
+	Username string
+	Password string
+
+	// Insecure pulls the image over plain HTTP.
+	Insecure bool
+}
+
+func (strategy RegistryImageStrategy) Encode() *json.RawMessage {
+	payload, _ := json.Marshal(struct {
+		Type       string `json:"type"`
+		Repository string `json:"repository"`
+		Digest     string `json:"digest"`
+		Username   string `json:"username,omitempty"`
+		Password   string `json:"password,omitempty"`
+		Insecure   bool   `json:"insecure,omitempty"`
+	}{
+		Type:       "registry-image",
+		Repository: strategy.Repository,
+		Digest:     strategy.Digest,
+		Username:   strategy.Username,
+		Password:   strategy.Password,
+		Insecure:   strategy.Insecure,
+	})
+
+	msg := json.RawMessage(payload)
+	return &msg
+}
+
 // EmptyStrategy created a new empty volume.
 type EmptyStrategy struct{}
 
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -16,11 +17,21 @@ import (
 
 	"github.com/concourse/concourse/worker/baggageclaim"
 	"github.com/concourse/concourse/worker/baggageclaim/api"
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
 	"github.com/concourse/retryhttp"
+	uuid "github.com/nu7hatch/gouuid"
 )
 
 var ErrVolumeDeletion = errors.New("failed-to-delete-volume")
 
+// errStreamInChunkUnsupported is returned by workers which predate streaming
+// volumes in chunks.
+var errStreamInChunkUnsupported = errors.New("streaming in chunks is not supported")
+
+// streamInChunkSize is how much of a volume is streamed in with each request,
+// and so the most that's streamed again when one fails part way through.
+const streamInChunkSize = 4 * 1024 * 1024
+
 type Client interface {
 	baggageclaim.Client
 }
@@ -32,16 +43,44 @@ type client struct {
 	nestedRoundTripper  http.RoundTripper
 
 	givenHttpClient *http.Client
+
+	zstdLevel int
+
+	streamBandwidthLimit int
 }
 
-func New(apiURL string, nestedRoundTripper http.RoundTripper) Client {
-	return &client{
+type Option func(*client)
+
+// WithZstdLevel makes volumes streamed out with zstd compressed at `level`
+// (1-22), rather than at baggageclaim's default level.
+func WithZstdLevel(level int) Option {
+	return func(c *client) {
+		c.zstdLevel = level
+	}
+}
+
+// WithStreamBandwidthLimit makes workers stream volumes out to other workers
+// no faster than `bytesPerSecond` per stream.
+func WithStreamBandwidthLimit(bytesPerSecond int) Option {
+	return func(c *client) {
+		c.streamBandwidthLimit = bytesPerSecond
+	}
+}
+
+func New(apiURL string, nestedRoundTripper http.RoundTripper, opts ...Option) Client {
+	c := &client{
 		requestGenerator: rata.NewRequestGenerator(apiURL, baggageclaim.Routes),
 
 		retryBackOffFactory: retryhttp.NewExponentialBackOffFactory(60 * time.Minute),
 
 		nestedRoundTripper: nestedRoundTripper,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 func NewWithHTTPClient(apiURL string, httpClient *http.Client) Client {
@@ -77,6 +116,7 @@ func (c *client) CreateVolume(logger lager.Logger, handle string, volumeSpec bag
 		Strategy:   strategy.Encode(),
 		Properties: volumeSpec.Properties,
 		Privileged: volumeSpec.Privileged,
+		Quota:      volumeSpec.Quota,
 	})
 
 	request, _ := c.requestGenerator.CreateRequest(baggageclaim.CreateVolumeAsync, nil, buffer)
@@ -163,6 +203,36 @@ func (c *client) ListVolumes(logger lager.Logger, properties baggageclaim.Volume
 	return volumes, nil
 }
 
+func (c *client) Stats(logger lager.Logger) (baggageclaim.Stats, error) {
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.GetStats, nil, nil)
+	if err != nil {
+		return baggageclaim.Stats{}, err
+	}
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return baggageclaim.Stats{}, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return baggageclaim.Stats{}, getError(response)
+	}
+
+	if header := response.Header.Get("Content-Type"); header != "application/json" {
+		return baggageclaim.Stats{}, fmt.Errorf("unexpected content-type of: %s", header)
+	}
+
+	var stats baggageclaim.Stats
+	err = json.NewDecoder(response.Body).Decode(&stats)
+	if err != nil {
+		return baggageclaim.Stats{}, err
+	}
+
+	return stats, nil
+}
+
 func (c *client) LookupVolume(logger lager.Logger, handle string) (baggageclaim.Volume, bool, error) {
 	volumeResponse, found, err := c.getVolumeResponse(logger, handle)
 	if err != nil {
@@ -238,16 +308,145 @@ func (c *client) newVolume(logger lager.Logger, apiVolume baggageclaim.VolumeRes
 	return volume
 }
 
+// streamIn streams a volume in a chunk at a time, so that it can carry on
+// from the chunk it got to when the connection to the worker is interrupted,
+// rather than streaming the whole volume again.
 func (c *client) streamIn(ctx context.Context, logger lager.Logger, destHandle string, path string, encoding baggageclaim.Encoding, tarContent io.Reader) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	digest := volume.NewDigest()
+	chunk := make([]byte, streamInChunkSize)
+
+	var offset int64
+	for {
+		n, err := io.ReadFull(tarContent, chunk)
+		final := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !final {
+			return err
+		}
+
+		_, _ = digest.Write(chunk[:n])
+
+		err = c.streamInChunk(ctx, logger, destHandle, id.String(), path, encoding, offset, chunk[:n], final, digest)
+		if err == errStreamInChunkUnsupported {
+			return c.streamInWhole(ctx, logger, destHandle, path, encoding, io.MultiReader(bytes.NewReader(chunk[:n]), tarContent))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+
+		offset += int64(n)
+	}
+}
+
+// streamInChunk streams a chunk of a volume in at `offset`, sending it again
+// when the connection to the worker is interrupted. The worker skips however
+// much of it it already received.
+func (c *client) streamInChunk(ctx context.Context, logger lager.Logger, destHandle string, id string, path string, encoding baggageclaim.Encoding, offset int64, chunk []byte, final bool, digest volume.Digest) error {
+	backOff := c.retryBackOffFactory.NewBackOff()
+
+	for {
+		err := c.putStreamInChunk(ctx, logger, destHandle, id, path, encoding, offset, chunk, final, digest)
+		if err == nil || !isInterrupted(err) || ctx.Err() != nil {
+			return err
+		}
+
+		// a negative backoff means it's time to give up
+		wait := backOff.NextBackOff()
+		if wait < 0 {
+			return err
+		}
+
+		logger.Info("retrying-stream-in-chunk", lager.Data{
+			"volume": destHandle,
+			"offset": offset,
+			"error":  err.Error(),
+		})
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *client) putStreamInChunk(ctx context.Context, logger lager.Logger, destHandle string, id string, path string, encoding baggageclaim.Encoding, offset int64, chunk []byte, final bool, digest volume.Digest) error {
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.StreamInChunk, rata.Params{
+		"handle": destHandle,
+		"stream": id,
+	}, bytes.NewReader(chunk))
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{
+		"path":   []string{path},
+		"offset": []string{strconv.FormatInt(offset, 10)},
+	}
+
+	request.Header.Set("Content-Encoding", string(encoding))
+
+	if final {
+		query.Set("final", "true")
+		request.Header.Set(volume.DigestHeader, digest.String())
+	}
+
+	request.URL.RawQuery = query.Encode()
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		if offset == 0 {
+			return errStreamInChunkUnsupported
+		}
+	}
+
+	return getError(response)
+}
+
+// isInterrupted returns whether a request failed because the connection to
+// the worker did, rather than because of anything the worker responded with.
+func isInterrupted(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	return (&retryhttp.DefaultRetryer{}).IsRetryable(err)
+}
+
+// streamInWhole streams a volume in with one request, for workers which
+// predate streaming in chunks.
+func (c *client) streamInWhole(ctx context.Context, logger lager.Logger, destHandle string, path string, encoding baggageclaim.Encoding, tarContent io.Reader) error {
+	trailer := http.Header{volume.DigestHeader: nil}
+
 	request, err := c.requestGenerator.CreateRequest(baggageclaim.StreamIn, rata.Params{
 		"handle": destHandle,
-	}, tarContent)
+	}, newDigestTrailerReader(tarContent, trailer))
 
 	request.URL.RawQuery = url.Values{"path": []string{path}}.Encode()
 	if err != nil {
 		return err
 	}
 	request.Header.Set("Content-Encoding", string(encoding))
+	request.Trailer = trailer
 
 	request = request.WithContext(ctx)
 
@@ -308,9 +507,62 @@ func (c *client) getStreamInP2pUrl(ctx context.Context, logger lager.Logger, des
 	streamInRequest.URL.Scheme = destUrl.Scheme
 	streamInRequest.URL.Host = destUrl.Host
 
+	key, found, err := c.createP2pKey(ctx, logger, destHandle)
+	if err != nil {
+		return "", err
+	}
+
+	if !found {
+		// workers which predate encrypted p2p streaming can only be streamed
+		// to unencrypted
+		logger.Info("p2p-keys-unsupported")
+
+		logger.Debug("get-stream-in-p2p-url", lager.Data{"url": streamInRequest.URL.String()})
+
+		return streamInRequest.URL.String(), nil
+	}
+
+	query := streamInRequest.URL.Query()
+	query.Set("key", key.ID)
+	streamInRequest.URL.RawQuery = query.Encode()
+
 	logger.Debug("get-stream-in-p2p-url", lager.Data{"url": streamInRequest.URL.String()})
 
-	return streamInRequest.URL.String(), nil
+	return volume.WithP2pKey(streamInRequest.URL.String(), key.Key), nil
+}
+
+func (c *client) createP2pKey(ctx context.Context, logger lager.Logger, destHandle string) (baggageclaim.P2pKeyResponse, bool, error) {
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.CreateP2pKey, rata.Params{
+		"handle": destHandle,
+	}, nil)
+	if err != nil {
+		return baggageclaim.P2pKeyResponse{}, false, err
+	}
+
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return baggageclaim.P2pKeyResponse{}, false, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return baggageclaim.P2pKeyResponse{}, false, nil
+	}
+
+	if response.StatusCode != http.StatusCreated {
+		return baggageclaim.P2pKeyResponse{}, false, getError(response)
+	}
+
+	var key baggageclaim.P2pKeyResponse
+	err = json.NewDecoder(response.Body).Decode(&key)
+	if err != nil {
+		return baggageclaim.P2pKeyResponse{}, false, err
+	}
+
+	return key, true, nil
 }
 
 func (c *client) streamOut(ctx context.Context, logger lager.Logger, srcHandle string, encoding baggageclaim.Encoding, path string) (io.ReadCloser, error) {
@@ -318,7 +570,7 @@ func (c *client) streamOut(ctx context.Context, logger lager.Logger, srcHandle s
 		"handle": srcHandle,
 	}, nil)
 
-	request.URL.RawQuery = url.Values{"path": []string{path}}.Encode()
+	request.URL.RawQuery = c.streamOutQuery(encoding, url.Values{"path": []string{path}}).Encode()
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +587,7 @@ func (c *client) streamOut(ctx context.Context, logger lager.Logger, srcHandle s
 		return nil, getError(response)
 	}
 
-	return response.Body, nil
+	return newDigestVerifyingReader(response), nil
 }
 
 func (c *client) streamP2pOut(ctx context.Context, logger lager.Logger, srcHandle string, encoding baggageclaim.Encoding, path string, streamInURL string) error {
@@ -343,11 +595,17 @@ func (c *client) streamP2pOut(ctx context.Context, logger lager.Logger, srcHandl
 		"handle": srcHandle,
 	}, nil)
 
-	request.URL.RawQuery = url.Values{
+	query := c.streamOutQuery(encoding, url.Values{
 		"path":        []string{path},
 		"streamInURL": []string{streamInURL},
 		"encoding":    []string{string(encoding)},
-	}.Encode()
+	})
+
+	if c.streamBandwidthLimit != 0 {
+		query.Set("limit", strconv.Itoa(c.streamBandwidthLimit))
+	}
+
+	request.URL.RawQuery = query.Encode()
 	if err != nil {
 		return err
 	}
@@ -365,6 +623,177 @@ func (c *client) streamP2pOut(ctx context.Context, logger lager.Logger, srcHandl
 	return nil
 }
 
+func (c *client) streamManifest(ctx context.Context, logger lager.Logger, handle string) (io.ReadCloser, error) {
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.GetManifest, rata.Params{
+		"handle": handle,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, getError(response)
+	}
+
+	return response.Body, nil
+}
+
+func (c *client) streamDeltaOut(ctx context.Context, logger lager.Logger, srcHandle string, encoding baggageclaim.Encoding, manifest io.Reader) (io.ReadCloser, error) {
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.StreamDeltaOut, rata.Params{
+		"handle": srcHandle,
+	}, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	request.URL.RawQuery = c.streamOutQuery(encoding, url.Values{}).Encode()
+	request.Header.Set("Accept-Encoding", string(encoding))
+
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, getError(response)
+	}
+
+	return newDigestVerifyingReader(response), nil
+}
+
+func (c *client) streamDeltaIn(ctx context.Context, logger lager.Logger, destHandle string, baseHandle string, encoding baggageclaim.Encoding, delta io.Reader) error {
+	trailer := http.Header{volume.DigestHeader: nil}
+
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.StreamDeltaIn, rata.Params{
+		"handle": destHandle,
+	}, newDigestTrailerReader(delta, trailer))
+	if err != nil {
+		return err
+	}
+
+	request.URL.RawQuery = url.Values{"base": []string{baseHandle}}.Encode()
+	request.Header.Set("Content-Encoding", string(encoding))
+	request.Trailer = trailer
+
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return getError(response)
+}
+
+func (c *client) persistVolume(ctx context.Context, logger lager.Logger, handle string, key string) error {
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.PersistVolume, rata.Params{
+		"handle": handle,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	request.URL.RawQuery = url.Values{"key": []string{key}}.Encode()
+
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return getError(response)
+}
+
+func (c *client) getQuota(ctx context.Context, logger lager.Logger, handle string) (baggageclaim.VolumeQuota, error) {
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.GetQuota, rata.Params{
+		"handle": handle,
+	}, nil)
+	if err != nil {
+		return baggageclaim.VolumeQuota{}, err
+	}
+
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return baggageclaim.VolumeQuota{}, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return baggageclaim.VolumeQuota{}, getError(response)
+	}
+
+	var quota baggageclaim.VolumeQuota
+	err = json.NewDecoder(response.Body).Decode(&quota)
+	if err != nil {
+		return baggageclaim.VolumeQuota{}, err
+	}
+
+	return quota, nil
+}
+
+func (c *client) getSize(ctx context.Context, logger lager.Logger, handle string) (uint64, error) {
+	request, err := c.requestGenerator.CreateRequest(baggageclaim.GetSize, rata.Params{
+		"handle": handle,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient(logger).Do(request)
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, getError(response)
+	}
+
+	var size baggageclaim.VolumeSize
+	err = json.NewDecoder(response.Body).Decode(&size)
+	if err != nil {
+		return 0, err
+	}
+
+	return size.Size, nil
+}
+
+// streamOutQuery adds the compression level to the query of a request to
+// stream out with `encoding`, if one is configured for it.
+func (c *client) streamOutQuery(encoding baggageclaim.Encoding, query url.Values) url.Values {
+	if encoding == baggageclaim.ZstdEncoding && c.zstdLevel != 0 {
+		query.Set("level", strconv.Itoa(c.zstdLevel))
+	}
+
+	return query
+}
+
 func getError(response *http.Response) error {
 	var errorResponse *api.ErrorResponse
 	err := json.NewDecoder(response.Body).Decode(&errorResponse)
@@ -376,6 +805,26 @@ func getError(response *http.Response) error {
 		return baggageclaim.ErrFileNotFound
 	}
 
+	if errorResponse.Message == volume.ErrDigestMismatch.Error() {
+		return baggageclaim.ErrDigestMismatch
+	}
+
+	if errorResponse.Message == api.ErrChunkedStreamNotFound.Error() {
+		return api.ErrChunkedStreamNotFound
+	}
+
+	if errorResponse.Message == volume.ErrVolumeNotPersisted.Error() {
+		return baggageclaim.ErrVolumeNotPersisted
+	}
+
+	if errorResponse.Message == volume.ErrPersistenceUnsupported.Error() {
+		return baggageclaim.ErrPersistenceUnsupported
+	}
+
+	if errorResponse.Message == volume.ErrQuotaUnsupported.Error() {
+		return baggageclaim.ErrQuotaUnsupported
+	}
+
 	if response.StatusCode == 404 {
 		return baggageclaim.ErrVolumeNotFound
 	}
@@ -525,3 +974,58 @@ func (c *client) setProperty(logger lager.Logger, handle string, propertyName st
 
 	return nil
 }
+
+// digestVerifyingReader reads the body of a volume stream, verifying it
+// against the digest in the response's trailers once it's been read in full.
+type digestVerifyingReader struct {
+	io.ReadCloser
+
+	response *http.Response
+	digest   volume.Digest
+}
+
+func newDigestVerifyingReader(response *http.Response) io.ReadCloser {
+	return digestVerifyingReader{
+		ReadCloser: response.Body,
+		response:   response,
+		digest:     volume.NewDigest(),
+	}
+}
+
+func (r digestVerifyingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	_, _ = r.digest.Write(p[:n])
+
+	if err == io.EOF && r.digest.Verify(r.response.Trailer.Get(volume.DigestHeader)) != nil {
+		return n, baggageclaim.ErrDigestMismatch
+	}
+
+	return n, err
+}
+
+// digestTrailerReader reads a volume stream into a request, setting the
+// digest in its trailers once it's been read in full.
+type digestTrailerReader struct {
+	reader  io.Reader
+	trailer http.Header
+	digest  volume.Digest
+}
+
+func newDigestTrailerReader(reader io.Reader, trailer http.Header) io.Reader {
+	return digestTrailerReader{
+		reader:  reader,
+		trailer: trailer,
+		digest:  volume.NewDigest(),
+	}
+}
+
+func (r digestTrailerReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	_, _ = r.digest.Write(p[:n])
+
+	if err == io.EOF {
+		r.trailer.Set(volume.DigestHeader, r.digest.String())
+	}
+
+	return n, err
+}
@@ -0,0 +1,331 @@
+package client_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/concourse/concourse/worker/baggageclaim/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("baggageclaim http client", func() {
+	Context("when configured with a zstd level", func() {
+		var (
+			gServer *ghttp.Server
+			volume  baggageclaim.Volume
+		)
+
+		BeforeEach(func() {
+			gServer = ghttp.NewServer()
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/volumes/some-volume"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, baggageclaim.VolumeResponse{
+						Handle:     "some-volume",
+						Path:       "/some/path",
+						Properties: baggageclaim.VolumeProperties{},
+					}),
+				),
+			)
+
+			c := client.New(gServer.URL(), http.DefaultTransport, client.WithZstdLevel(19))
+
+			var found bool
+			var err error
+			volume, found, err = c.LookupVolume(lager.NewLogger("test"), "some-volume")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			gServer.Close()
+		})
+
+		It("streams out zstd at the level", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-out", "level=19&path=."),
+					ghttp.VerifyHeaderKV("Accept-Encoding", "zstd"),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+			)
+
+			out, err := volume.StreamOut(context.Background(), ".", baggageclaim.ZstdEncoding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out.Close()).To(Succeed())
+		})
+
+		It("streams p2p out zstd at the level", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-p2p-out", "encoding=zstd&level=19&path=.&streamInURL=some-url"),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+			)
+
+			err := volume.StreamP2pOut(context.Background(), ".", "some-url", baggageclaim.ZstdEncoding)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("streams out gzip at its default level", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-out", "path=."),
+					ghttp.VerifyHeaderKV("Accept-Encoding", "gzip"),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+			)
+
+			out, err := volume.StreamOut(context.Background(), ".", baggageclaim.GzipEncoding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out.Close()).To(Succeed())
+		})
+
+		It("streams delta out zstd at the level against the manifest", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-delta-out", "level=19"),
+					ghttp.VerifyHeaderKV("Accept-Encoding", "zstd"),
+					ghttp.VerifyBody([]byte(`{"some-file":{"mode":420}}`)),
+					ghttp.RespondWith(http.StatusOK, "some-delta"),
+				),
+			)
+
+			out, err := volume.StreamDeltaOut(context.Background(), strings.NewReader(`{"some-file":{"mode":420}}`), baggageclaim.ZstdEncoding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ioutil.ReadAll(out)).To(Equal([]byte("some-delta")))
+			Expect(out.Close()).To(Succeed())
+		})
+	})
+
+	Context("when configured with a stream bandwidth limit", func() {
+		var (
+			gServer *ghttp.Server
+			volume  baggageclaim.Volume
+		)
+
+		BeforeEach(func() {
+			gServer = ghttp.NewServer()
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/volumes/some-volume"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, baggageclaim.VolumeResponse{
+						Handle:     "some-volume",
+						Path:       "/some/path",
+						Properties: baggageclaim.VolumeProperties{},
+					}),
+				),
+			)
+
+			c := client.New(gServer.URL(), http.DefaultTransport, client.WithStreamBandwidthLimit(1024))
+
+			var found bool
+			var err error
+			volume, found, err = c.LookupVolume(lager.NewLogger("test"), "some-volume")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			gServer.Close()
+		})
+
+		It("streams p2p out no faster than the limit", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-p2p-out", "encoding=gzip&limit=1024&path=.&streamInURL=some-url"),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+			)
+
+			err := volume.StreamP2pOut(context.Background(), ".", "some-url", baggageclaim.GzipEncoding)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("streaming deltas", func() {
+		var (
+			gServer *ghttp.Server
+			volume  baggageclaim.Volume
+		)
+
+		BeforeEach(func() {
+			gServer = ghttp.NewServer()
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/volumes/some-volume"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, baggageclaim.VolumeResponse{
+						Handle:     "some-volume",
+						Path:       "/some/path",
+						Properties: baggageclaim.VolumeProperties{},
+					}),
+				),
+			)
+
+			c := client.New(gServer.URL(), http.DefaultTransport)
+
+			var found bool
+			var err error
+			volume, found, err = c.LookupVolume(lager.NewLogger("test"), "some-volume")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			gServer.Close()
+		})
+
+		It("streams the manifest", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/volumes/some-volume/manifest"),
+					ghttp.RespondWith(http.StatusOK, "some-manifest"),
+				),
+			)
+
+			out, err := volume.StreamManifest(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ioutil.ReadAll(out)).To(Equal([]byte("some-manifest")))
+			Expect(out.Close()).To(Succeed())
+		})
+
+		It("streams the delta in on top of the base", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-delta-in", "base=some-base"),
+					ghttp.VerifyHeaderKV("Content-Encoding", "gzip"),
+					ghttp.VerifyBody([]byte("some-delta")),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+
+			err := volume.StreamDeltaIn(context.Background(), "some-base", baggageclaim.GzipEncoding, strings.NewReader("some-delta"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the base is namespaced differently", func() {
+			It("returns the error", func() {
+				gServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-delta-in", "base=some-base"),
+						ghttp.RespondWithJSONEncoded(http.StatusBadRequest, map[string]string{
+							"error": "failed to stream delta in to volume",
+						}),
+					),
+				)
+
+				err := volume.StreamDeltaIn(context.Background(), "some-base", baggageclaim.GzipEncoding, strings.NewReader("some-delta"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("persisting and limiting volumes", func() {
+		var (
+			gServer *ghttp.Server
+			volume  baggageclaim.Volume
+		)
+
+		BeforeEach(func() {
+			gServer = ghttp.NewServer()
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/volumes/some-volume"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, baggageclaim.VolumeResponse{
+						Handle:     "some-volume",
+						Path:       "/some/path",
+						Properties: baggageclaim.VolumeProperties{},
+					}),
+				),
+			)
+
+			c := client.New(gServer.URL(), http.DefaultTransport)
+
+			var found bool
+			var err error
+			volume, found, err = c.LookupVolume(lager.NewLogger("test"), "some-volume")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			gServer.Close()
+		})
+
+		It("persists the volume under the key", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/persist", "key=some-key"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+
+			err := volume.Persist(context.Background(), "some-key")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("gets the quota of the volume", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/volumes/some-volume/quota"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, baggageclaim.VolumeQuota{Limit: 1024, Used: 512}),
+				),
+			)
+
+			quota, err := volume.Quota(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quota).To(Equal(baggageclaim.VolumeQuota{Limit: 1024, Used: 512}))
+		})
+
+		It("gets the size of the volume", func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/volumes/some-volume/size"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, baggageclaim.VolumeSize{Size: 2048}),
+				),
+			)
+
+			size, err := volume.Size(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(size).To(Equal(uint64(2048)))
+		})
+
+		Context("when the worker does not limit volumes", func() {
+			It("returns ErrQuotaUnsupported", func() {
+				gServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/volumes/some-volume/quota"),
+						ghttp.RespondWithJSONEncoded(http.StatusNotImplemented, map[string]string{
+							"error": "volume driver does not limit the size of volumes",
+						}),
+					),
+				)
+
+				_, err := volume.Quota(context.Background())
+				Expect(err).To(Equal(baggageclaim.ErrQuotaUnsupported))
+			})
+		})
+
+		Context("when the worker does not persist volumes", func() {
+			It("returns ErrPersistenceUnsupported", func() {
+				gServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/volumes/some-volume/persist", "key=some-key"),
+						ghttp.RespondWithJSONEncoded(http.StatusNotImplemented, map[string]string{
+							"error": "volume driver does not persist volumes",
+						}),
+					),
+				)
+
+				err := volume.Persist(context.Background(), "some-key")
+				Expect(err).To(Equal(baggageclaim.ErrPersistenceUnsupported))
+			})
+		})
+	})
+})
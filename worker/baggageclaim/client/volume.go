@@ -70,3 +70,27 @@ func (cv *clientVolume) GetStreamInP2pUrl(ctx context.Context, path string) (str
 func (cv *clientVolume) StreamP2pOut(ctx context.Context, path, url string, encoding baggageclaim.Encoding) error {
 	return cv.bcClient.streamP2pOut(ctx, cv.logger, cv.handle, encoding, path, url)
 }
+
+func (cv *clientVolume) StreamManifest(ctx context.Context) (io.ReadCloser, error) {
+	return cv.bcClient.streamManifest(ctx, cv.logger, cv.handle)
+}
+
+func (cv *clientVolume) StreamDeltaOut(ctx context.Context, manifest io.Reader, encoding baggageclaim.Encoding) (io.ReadCloser, error) {
+	return cv.bcClient.streamDeltaOut(ctx, cv.logger, cv.handle, encoding, manifest)
+}
+
+func (cv *clientVolume) StreamDeltaIn(ctx context.Context, base string, encoding baggageclaim.Encoding, delta io.Reader) error {
+	return cv.bcClient.streamDeltaIn(ctx, cv.logger, cv.handle, base, encoding, delta)
+}
+
+func (cv *clientVolume) Persist(ctx context.Context, key string) error {
+	return cv.bcClient.persistVolume(ctx, cv.logger, cv.handle, key)
+}
+
+func (cv *clientVolume) Quota(ctx context.Context) (baggageclaim.VolumeQuota, error) {
+	return cv.bcClient.getQuota(ctx, cv.logger, cv.handle)
+}
+
+func (cv *clientVolume) Size(ctx context.Context) (uint64, error) {
+	return cv.bcClient.getSize(ctx, cv.logger, cv.handle)
+}
@@ -3,6 +3,7 @@ package baggageclaim_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -39,7 +40,7 @@ var _ = Describe("Baggage Claim Client", func() {
 			bcServer.Close()
 		})
 
-		mockErrorResponse := func(method string, endpoint string, message string, status int) {
+		mockErrorResponse := func(method string, endpoint interface{}, message string, status int) {
 			response := fmt.Sprintf(`{"error":"%s"}`, message)
 			bcServer.AppendHandlers(
 				ghttp.CombineHandlers(
@@ -219,12 +220,77 @@ var _ = Describe("Baggage Claim Client", func() {
 				Expect(err).ToNot(HaveOccurred())
 			})
 
-			It("streams the volume", func() {
+			chunkPath := MatchRegexp(`^/volumes/some-handle/stream-in/[^/]+$`)
+
+			It("streams the volume in a chunk, with its digest", func() {
+				bodyChan := make(chan []byte, 1)
+
+				bcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", chunkPath, "final=true&offset=0&path=."),
+						ghttp.VerifyHeaderKV("Content-Encoding", "gzip"),
+						ghttp.VerifyHeaderKV(volume.DigestHeader, fmt.Sprintf("%x", sha256.Sum256([]byte("some tar content")))),
+						func(w http.ResponseWriter, r *http.Request) {
+							str, _ := ioutil.ReadAll(r.Body)
+							bodyChan <- str
+						},
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+				err := vol.StreamIn(context.TODO(), ".", baggageclaim.GzipEncoding, strings.NewReader("some tar content"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(bodyChan).To(Receive(Equal([]byte("some tar content"))))
+			})
+
+			It("streams volumes larger than a chunk in many chunks", func() {
+				chunkSize := 4 * 1024 * 1024
+				content := bytes.Repeat([]byte("x"), chunkSize+10)
+
+				paths := make(chan string, 2)
+				bodies := make(chan []byte, 2)
+				recordChunk := func(w http.ResponseWriter, r *http.Request) {
+					body, _ := ioutil.ReadAll(r.Body)
+					paths <- r.URL.Path
+					bodies <- body
+				}
+
+				bcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", chunkPath, "offset=0&path=."),
+						recordChunk,
+						ghttp.RespondWithJSONEncoded(http.StatusOK, baggageclaim.StreamInChunkResponse{Offset: int64(chunkSize)}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", chunkPath, fmt.Sprintf("final=true&offset=%d&path=.", chunkSize)),
+						ghttp.VerifyHeaderKV(volume.DigestHeader, fmt.Sprintf("%x", sha256.Sum256(content))),
+						recordChunk,
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+				err := vol.StreamIn(context.TODO(), ".", baggageclaim.GzipEncoding, bytes.NewReader(content))
+				Expect(err).ToNot(HaveOccurred())
+
+				firstPath := <-paths
+				Expect(<-paths).To(Equal(firstPath))
+				Expect(<-bodies).To(HaveLen(chunkSize))
+				Expect(<-bodies).To(HaveLen(10))
+			})
+
+			It("sends the chunk again when the connection is interrupted", func() {
 				bodyChan := make(chan []byte, 1)
 
 				bcServer.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("PUT", "/volumes/some-handle/stream-in"),
+						ghttp.VerifyRequest("PUT", chunkPath, "final=true&offset=0&path=."),
+						func(w http.ResponseWriter, r *http.Request) {
+							conn, _, err := w.(http.Hijacker).Hijack()
+							Expect(err).ToNot(HaveOccurred())
+							conn.Close()
+						},
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", chunkPath, "final=true&offset=0&path=."),
 						func(w http.ResponseWriter, r *http.Request) {
 							str, _ := ioutil.ReadAll(r.Body)
 							bodyChan <- str
@@ -238,13 +304,47 @@ var _ = Describe("Baggage Claim Client", func() {
 				Expect(bodyChan).To(Receive(Equal([]byte("some tar content"))))
 			})
 
+			Context("when the worker predates streaming in chunks", func() {
+				It("streams the volume in whole, with its digest in the trailer", func() {
+					bodyChan := make(chan []byte, 1)
+					digestChan := make(chan string, 1)
+
+					bcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", chunkPath),
+							ghttp.RespondWith(http.StatusNotFound, "404 page not found"),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/volumes/some-handle/stream-in", "path=."),
+							func(w http.ResponseWriter, r *http.Request) {
+								str, _ := ioutil.ReadAll(r.Body)
+								bodyChan <- str
+								digestChan <- r.Trailer.Get(volume.DigestHeader)
+							},
+							ghttp.RespondWith(http.StatusNoContent, ""),
+						),
+					)
+					err := vol.StreamIn(context.TODO(), ".", baggageclaim.GzipEncoding, strings.NewReader("some tar content"))
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(bodyChan).To(Receive(Equal([]byte("some tar content"))))
+					Expect(digestChan).To(Receive(Equal(fmt.Sprintf("%x", sha256.Sum256([]byte("some tar content"))))))
+				})
+			})
+
 			Context("when unexpected error occurs", func() {
 				It("returns error code and useful message", func() {
-					mockErrorResponse("PUT", "/volumes/some-handle/stream-in", "lost baggage", http.StatusInternalServerError)
+					mockErrorResponse("PUT", chunkPath, "lost baggage", http.StatusInternalServerError)
 					err := vol.StreamIn(context.TODO(), "./some/path/", baggageclaim.GzipEncoding, strings.NewReader("even more tar"))
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(Equal("lost baggage"))
 				})
+
+				It("returns ErrDigestMismatch", func() {
+					mockErrorResponse("PUT", chunkPath, volume.ErrDigestMismatch.Error(), http.StatusBadRequest)
+					err := vol.StreamIn(context.TODO(), "./some/path/", baggageclaim.GzipEncoding, strings.NewReader("even more tar"))
+					Expect(err).To(Equal(baggageclaim.ErrDigestMismatch))
+				})
 			})
 		})
 
@@ -294,6 +394,46 @@ var _ = Describe("Baggage Claim Client", func() {
 				Expect(string(b)).To(Equal("some tar content"))
 			})
 
+			It("verifies the volume against the digest in the trailer", func() {
+				bcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/volumes/some-handle/stream-out"),
+						func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Trailer", volume.DigestHeader)
+							w.Write([]byte("some tar content"))
+							w.Header().Set(volume.DigestHeader, fmt.Sprintf("%x", sha256.Sum256([]byte("some tar content"))))
+						},
+					),
+				)
+				out, err := vol.StreamOut(context.TODO(), ".", baggageclaim.GzipEncoding)
+				Expect(err).NotTo(HaveOccurred())
+
+				b, err := ioutil.ReadAll(out)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(b)).To(Equal("some tar content"))
+			})
+
+			Context("when the volume does not match the digest in the trailer", func() {
+				It("fails to read it", func() {
+					bcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/volumes/some-handle/stream-out"),
+							func(w http.ResponseWriter, r *http.Request) {
+								w.Header().Set("Trailer", volume.DigestHeader)
+								w.Write([]byte("some corrupted tar content"))
+								w.Header().Set(volume.DigestHeader, fmt.Sprintf("%x", sha256.Sum256([]byte("some tar content"))))
+							},
+						),
+					)
+					out, err := vol.StreamOut(context.TODO(), ".", baggageclaim.GzipEncoding)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = ioutil.ReadAll(out)
+					Expect(err).To(Equal(baggageclaim.ErrDigestMismatch))
+				})
+			})
+
 			Context("when error occurs", func() {
 				It("returns API error message", func() {
 					mockErrorResponse("PUT", "/volumes/some-handle/stream-out", "lost baggage", http.StatusInternalServerError)
@@ -400,10 +540,46 @@ var _ = Describe("Baggage Claim Client", func() {
 						),
 					)
 				})
-				It("should get the url", func() {
-					url, err := vol.GetStreamInP2pUrl(context.TODO(), "some-path")
-					Expect(err).ToNot(HaveOccurred())
-					Expect(url).To(Equal("http://some-url/volumes/some-handle/stream-in?path=some-path"))
+
+				Context("when a p2p key is created", func() {
+					BeforeEach(func() {
+						bcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("POST", "/volumes/some-handle/p2p-keys"),
+								ghttp.RespondWithJSONEncoded(http.StatusCreated, baggageclaim.P2pKeyResponse{
+									ID:  "some-key-id",
+									Key: bytes.Repeat([]byte{1}, volume.P2pKeySize),
+								}),
+							),
+						)
+					})
+
+					It("should get the url with the key", func() {
+						url, err := vol.GetStreamInP2pUrl(context.TODO(), "some-path")
+						Expect(err).ToNot(HaveOccurred())
+
+						streamInURL, key, err := volume.SplitP2pKey(url)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(streamInURL).To(Equal("http://some-url/volumes/some-handle/stream-in?key=some-key-id&path=some-path"))
+						Expect(key).To(Equal(bytes.Repeat([]byte{1}, volume.P2pKeySize)))
+					})
+				})
+
+				Context("when the worker does not support p2p keys", func() {
+					BeforeEach(func() {
+						bcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("POST", "/volumes/some-handle/p2p-keys"),
+								ghttp.RespondWith(http.StatusNotFound, "404 page not found"),
+							),
+						)
+					})
+
+					It("should get the url", func() {
+						url, err := vol.GetStreamInP2pUrl(context.TODO(), "some-path")
+						Expect(err).ToNot(HaveOccurred())
+						Expect(url).To(Equal("http://some-url/volumes/some-handle/stream-in?path=some-path"))
+					})
 				})
 			})
 
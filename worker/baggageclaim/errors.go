@@ -4,3 +4,7 @@ import "errors"
 
 var ErrVolumeNotFound = errors.New("volume not found")
 var ErrFileNotFound = errors.New("file not found")
+var ErrDigestMismatch = errors.New("streamed volume does not match its digest")
+var ErrVolumeNotPersisted = errors.New("volume is not persisted")
+var ErrPersistenceUnsupported = errors.New("volume driver does not persist volumes")
+var ErrQuotaUnsupported = errors.New("volume driver does not limit the size of volumes")
@@ -9,6 +9,7 @@ type VolumeRequest struct {
 	Strategy     *json.RawMessage `json:"strategy"`
 	Properties   VolumeProperties `json:"properties"`
 	Privileged   bool             `json:"privileged,omitempty"`
+	Quota        uint64           `json:"quota,omitempty"`
 }
 
 type VolumeResponse struct {
@@ -21,6 +22,19 @@ type VolumeFutureResponse struct {
 	Handle string `json:"handle"`
 }
 
+// P2pKeyResponse is a key to encrypt a volume with when streaming it in from
+// another worker, and the ID to stream it in with.
+type P2pKeyResponse struct {
+	ID  string `json:"id"`
+	Key []byte `json:"key"`
+}
+
+// StreamInChunkResponse is how much of a volume streamed in chunks has been
+// received.
+type StreamInChunkResponse struct {
+	Offset int64 `json:"offset"`
+}
+
 type PropertyRequest struct {
 	Value string `json:"value"`
 }
@@ -28,3 +42,34 @@ type PropertyRequest struct {
 type PrivilegedRequest struct {
 	Value bool `json:"value"`
 }
+
+// VolumeQuota is the size, in bytes, a volume is limited to, which is 0 if it
+// has no limit, and how much of it is used.
+type VolumeQuota struct {
+	Limit uint64 `json:"limit"`
+	Used  uint64 `json:"used"`
+}
+
+// VolumeSize is the number of bytes a volume takes up.
+type VolumeSize struct {
+	Size uint64 `json:"size"`
+}
+
+// Stats describes the disk usage of the volumes directory, the load on the
+// host and the volume streaming performed by the server since it started.
+type Stats struct {
+	DiskTotalBytes uint64 `json:"disk_total_bytes"`
+	DiskFreeBytes  uint64 `json:"disk_free_bytes"`
+
+	// CPULoad is the host's 1-minute load average divided by its number of
+	// CPUs. It and the memory stats are zero on platforms which don't report
+	// them.
+	CPULoad              float64 `json:"cpu_load"`
+	MemoryTotalBytes     uint64  `json:"memory_total_bytes"`
+	MemoryAvailableBytes uint64  `json:"memory_available_bytes"`
+
+	StreamsIn        uint64 `json:"streams_in"`
+	StreamsOut       uint64 `json:"streams_out"`
+	StreamedInBytes  uint64 `json:"streamed_in_bytes"`
+	StreamedOutBytes uint64 `json:"streamed_out_bytes"`
+}
@@ -17,10 +17,23 @@ const (
 	GetPrivileged = "GetPrivileged"
 	SetPrivileged = "SetPrivileged"
 	StreamIn      = "StreamIn"
+	StreamInChunk = "StreamInChunk"
 	StreamOut     = "StreamOut"
 	StreamP2pOut  = "StreamP2pOut"
+	CreateP2pKey  = "CreateP2pKey"
+
+	GetManifest    = "GetManifest"
+	StreamDeltaOut = "StreamDeltaOut"
+	StreamDeltaIn  = "StreamDeltaIn"
+
+	PersistVolume = "PersistVolume"
+
+	GetQuota = "GetQuota"
+	GetSize  = "GetSize"
 
 	GetP2pUrl = "GetP2pUrl"
+
+	GetStats = "GetStats"
 )
 
 var Routes = rata.Routes{
@@ -36,10 +49,20 @@ var Routes = rata.Routes{
 	{Path: "/volumes/:handle/privileged", Method: "GET", Name: GetPrivileged},
 	{Path: "/volumes/:handle/privileged", Method: "PUT", Name: SetPrivileged},
 	{Path: "/volumes/:handle/stream-in", Method: "PUT", Name: StreamIn},
+	{Path: "/volumes/:handle/stream-in/:stream", Method: "PUT", Name: StreamInChunk},
 	{Path: "/volumes/:handle/stream-out", Method: "PUT", Name: StreamOut},
 	{Path: "/volumes/:handle/stream-p2p-out", Method: "PUT", Name: StreamP2pOut},
+	{Path: "/volumes/:handle/p2p-keys", Method: "POST", Name: CreateP2pKey},
+	{Path: "/volumes/:handle/manifest", Method: "GET", Name: GetManifest},
+	{Path: "/volumes/:handle/stream-delta-out", Method: "PUT", Name: StreamDeltaOut},
+	{Path: "/volumes/:handle/stream-delta-in", Method: "PUT", Name: StreamDeltaIn},
+	{Path: "/volumes/:handle/persist", Method: "PUT", Name: PersistVolume},
+	{Path: "/volumes/:handle/quota", Method: "GET", Name: GetQuota},
+	{Path: "/volumes/:handle/size", Method: "GET", Name: GetSize},
 	{Path: "/volumes/destroy", Method: "DELETE", Name: DestroyVolumes},
 	{Path: "/volumes/:handle", Method: "DELETE", Name: DestroyVolume},
 
 	{Path: "/p2p-url", Method: "GET", Name: GetP2pUrl},
+
+	{Path: "/stats", Method: "GET", Name: GetStats},
 }
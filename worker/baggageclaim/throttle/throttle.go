@@ -0,0 +1,153 @@
+// Package throttle limits the rate volumes are streamed at, so that large
+// streams don't saturate the links that checks and heartbeats depend on.
+package throttle
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+type limitersKey struct{}
+
+// NewLimiter returns a limiter allowing `bytesPerSecond`, or nil if it's zero,
+// which means there is no limit. Up to a second's worth of bytes can be
+// streamed at once.
+func NewLimiter(bytesPerSecond int) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), bytesPerSecond)
+}
+
+// WithLimiters returns a context carrying limiters for whatever is streamed
+// on behalf of it, along with any it already carries.
+func WithLimiters(ctx context.Context, limiters ...*rate.Limiter) context.Context {
+	carried := Limiters(ctx)
+
+	all := make([]*rate.Limiter, 0, len(carried)+len(limiters))
+	all = append(all, carried...)
+	all = append(all, limiters...)
+
+	return context.WithValue(ctx, limitersKey{}, all)
+}
+
+// Limiters returns the limiters carried by the context.
+func Limiters(ctx context.Context) []*rate.Limiter {
+	limiters, _ := ctx.Value(limitersKey{}).([]*rate.Limiter)
+	return limiters
+}
+
+// Reader returns a reader which reads from `r` no faster than every one of
+// `limiters` allows. Nil limiters don't limit anything.
+func Reader(ctx context.Context, r io.Reader, limiters ...*rate.Limiter) io.Reader {
+	limiters = nonNil(limiters)
+	if len(limiters) == 0 {
+		return r
+	}
+
+	return &reader{ctx: ctx, reader: r, limiters: limiters}
+}
+
+// Writer returns a writer which writes to `w` no faster than every one of
+// `limiters` allows. Nil limiters don't limit anything.
+func Writer(ctx context.Context, w io.Writer, limiters ...*rate.Limiter) io.Writer {
+	limiters = nonNil(limiters)
+	if len(limiters) == 0 {
+		return w
+	}
+
+	return &writer{ctx: ctx, writer: w, limiters: limiters}
+}
+
+type reader struct {
+	ctx      context.Context
+	reader   io.Reader
+	limiters []*rate.Limiter
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if max := maxChunk(r.limiters); len(p) > max {
+		p = p[:max]
+	}
+
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		waitErr := wait(r.ctx, r.limiters, n)
+		if waitErr != nil {
+			return n, waitErr
+		}
+	}
+
+	return n, err
+}
+
+type writer struct {
+	ctx      context.Context
+	writer   io.Writer
+	limiters []*rate.Limiter
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	max := maxChunk(w.limiters)
+
+	written := 0
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > max {
+			chunk = chunk[:max]
+		}
+
+		err := wait(w.ctx, w.limiters, len(chunk))
+		if err != nil {
+			return written, err
+		}
+
+		n, err := w.writer.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		p = p[n:]
+	}
+
+	return written, nil
+}
+
+func wait(ctx context.Context, limiters []*rate.Limiter, n int) error {
+	for _, limiter := range limiters {
+		err := limiter.WaitN(ctx, n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// maxChunk is the most that can be streamed at once, since waiting for more
+// than a limiter's burst fails.
+func maxChunk(limiters []*rate.Limiter) int {
+	max := limiters[0].Burst()
+	for _, limiter := range limiters[1:] {
+		if limiter.Burst() < max {
+			max = limiter.Burst()
+		}
+	}
+
+	return max
+}
+
+func nonNil(limiters []*rate.Limiter) []*rate.Limiter {
+	var nonNil []*rate.Limiter
+	for _, limiter := range limiters {
+		if limiter != nil {
+			nonNil = append(nonNil, limiter)
+		}
+	}
+
+	return nonNil
+}
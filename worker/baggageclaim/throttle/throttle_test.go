@@ -0,0 +1,102 @@
+package throttle_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"time"
+
+	"github.com/concourse/concourse/worker/baggageclaim/throttle"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/time/rate"
+)
+
+var _ = Describe("Throttle", func() {
+	var (
+		ctx      context.Context
+		contents []byte
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		// a burst's worth, and half as much again
+		contents = bytes.Repeat([]byte("x"), 15000)
+	})
+
+	Describe("NewLimiter", func() {
+		It("returns nil when there is no limit", func() {
+			Expect(throttle.NewLimiter(0)).To(BeNil())
+		})
+
+		It("allows a second's worth of bytes at once", func() {
+			limiter := throttle.NewLimiter(10000)
+			Expect(limiter.Limit()).To(Equal(rate.Limit(10000)))
+			Expect(limiter.Burst()).To(Equal(10000))
+		})
+	})
+
+	Describe("Reader", func() {
+		It("reads everything no faster than the limit", func() {
+			start := time.Now()
+
+			read, err := ioutil.ReadAll(throttle.Reader(ctx, bytes.NewReader(contents), throttle.NewLimiter(10000)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(read).To(Equal(contents))
+
+			Expect(time.Since(start)).To(BeNumerically(">=", 400*time.Millisecond))
+		})
+
+		It("reads no faster than the lowest of the limits", func() {
+			start := time.Now()
+
+			_, err := ioutil.ReadAll(throttle.Reader(ctx, bytes.NewReader(contents), throttle.NewLimiter(1000000), throttle.NewLimiter(10000)))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(time.Since(start)).To(BeNumerically(">=", 400*time.Millisecond))
+		})
+
+		It("returns the reader as it is when there are no limits", func() {
+			r := bytes.NewReader(contents)
+			Expect(throttle.Reader(ctx, r, nil)).To(BeIdenticalTo(r))
+		})
+
+		It("stops waiting when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err := ioutil.ReadAll(throttle.Reader(ctx, bytes.NewReader(contents), throttle.NewLimiter(10000)))
+			Expect(err).To(Equal(context.Canceled))
+		})
+	})
+
+	Describe("Writer", func() {
+		It("writes everything no faster than the limit", func() {
+			start := time.Now()
+
+			written := new(bytes.Buffer)
+			n, err := throttle.Writer(ctx, written, throttle.NewLimiter(10000)).Write(contents)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(Equal(len(contents)))
+			Expect(written.Bytes()).To(Equal(contents))
+
+			Expect(time.Since(start)).To(BeNumerically(">=", 400*time.Millisecond))
+		})
+
+		It("returns the writer as it is when there are no limits", func() {
+			w := new(bytes.Buffer)
+			Expect(throttle.Writer(ctx, w)).To(BeIdenticalTo(w))
+		})
+	})
+
+	Describe("limiters carried by contexts", func() {
+		It("adds to the limiters already carried", func() {
+			first := throttle.NewLimiter(1)
+			second := throttle.NewLimiter(2)
+
+			ctx = throttle.WithLimiters(throttle.WithLimiters(ctx, first), second)
+			Expect(throttle.Limiters(ctx)).To(Equal([]*rate.Limiter{first, second}))
+		})
+	})
+})
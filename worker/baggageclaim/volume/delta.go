@@ -0,0 +1,248 @@
+package volume
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var ErrDeltaBaseMismatch = errors.New("delta base privileges do not match volume")
+
+// maxRemovedListSize bounds the list of removed paths at the start of a delta
+// stream, so that a bad stream can't make us allocate without bound.
+const maxRemovedListSize = 256 * 1024 * 1024
+
+// A Manifest describes the contents of a volume, keyed by their slash
+// separated paths relative to the volume's root.
+//
+// Delta streams are computed against the manifest of a volume on the
+// destination, so that only what differs from it is streamed.
+type Manifest map[string]ManifestEntry
+
+type ManifestEntry struct {
+	Dir bool `json:"dir,omitempty"`
+
+	// Mode holds the permission bits of the entry.
+	Mode uint32 `json:"mode"`
+
+	// Link is the target of a symlink.
+	Link string `json:"link,omitempty"`
+
+	// Digest is the sha256 of a regular file's contents.
+	Digest string `json:"digest,omitempty"`
+}
+
+// unchangedFrom returns whether the entry can be kept as it is in base, which
+// is never the case for directories, whose contents may differ, or for files
+// which aren't identified by their contents or target.
+func (entry ManifestEntry) unchangedFrom(base ManifestEntry) bool {
+	return entry == base && !entry.Dir && (entry.Digest != "" || entry.Link != "")
+}
+
+// BuildManifest walks the directory at `root`, hashing each regular file.
+// Other files, such as devices, have empty entries, which never match.
+func BuildManifest(root string) (Manifest, error) {
+	manifest := Manifest{}
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		if rel == "." {
+			return nil
+		}
+
+		entry := ManifestEntry{
+			Mode: uint32(info.Mode() & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky)),
+		}
+
+		switch {
+		case info.IsDir():
+			entry.Dir = true
+		case info.Mode()&os.ModeSymlink != 0:
+			entry.Link, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			entry.Digest, err = fileDigest(path)
+			if err != nil {
+				return err
+			}
+		}
+
+		manifest[filepath.ToSlash(rel)] = entry
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// Delta compares the manifest of a volume to that of `base`, returning the
+// paths which have to be streamed to turn base into the volume, and the
+// paths which have to be removed from base beforehand.
+//
+// Directories are always streamed, so that their permissions carry over, but
+// are only removed if they are gone or no longer directories. Anything else
+// which changed is removed before being streamed, so that it replaces what
+// was there regardless of its type. Removed paths only include the top-most
+// path of a removed tree.
+func (manifest Manifest) Delta(base Manifest) ([]string, []string) {
+	changed := []string{}
+	for path, entry := range manifest {
+		if !entry.unchangedFrom(base[path]) {
+			changed = append(changed, path)
+		}
+	}
+
+	removed := []string{}
+	for path, baseEntry := range base {
+		entry, found := manifest[path]
+		if found && (entry.unchangedFrom(baseEntry) || (entry.Dir && baseEntry.Dir)) {
+			continue
+		}
+
+		removed = append(removed, path)
+	}
+
+	// parents sort before their children, so they are created first and
+	// their removal covers their children
+	sort.Strings(changed)
+	sort.Strings(removed)
+
+	return changed, topMost(removed)
+}
+
+func topMost(paths []string) []string {
+	top := []string{}
+	for _, p := range paths {
+		if len(top) > 0 && strings.HasPrefix(p, top[len(top)-1]+"/") {
+			continue
+		}
+
+		top = append(top, p)
+	}
+
+	return top
+}
+
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeRemoved starts a delta stream with the paths to remove from its base:
+// their length as a big-endian uint64, followed by them as JSON.
+func writeRemoved(w io.Writer, removed []string) error {
+	payload, err := json.Marshal(removed)
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint64(len(payload)))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(payload)
+	return err
+}
+
+func readRemoved(r io.Reader) ([]string, error) {
+	var size uint64
+	err := binary.Read(r, binary.BigEndian, &size)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > maxRemovedListSize {
+		return nil, fmt.Errorf("removed paths too large: %d bytes", size)
+	}
+
+	payload := make([]byte, size)
+
+	_, err = io.ReadFull(r, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	err = json.Unmarshal(payload, &removed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range removed {
+		if p == "" || path.IsAbs(p) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+			return nil, fmt.Errorf("invalid removed path: %q", p)
+		}
+	}
+
+	return removed, nil
+}
+
+func (repo *repository) compressor(encoding string, level int, w io.Writer) (io.WriteCloser, error) {
+	switch encoding {
+	case ZstdEncoding:
+		if level != 0 && (level < MinZstdLevel || level > MaxZstdLevel) {
+			return nil, ErrUnsupportedStreamLevel
+		}
+
+		return (&tarZstdStreamer{level: level}).newWriter(w)
+	case GzipEncoding:
+		return gzip.NewWriter(w), nil
+	}
+
+	return nil, ErrUnsupportedStreamEncoding
+}
+
+func (repo *repository) decompressor(encoding string, r io.Reader) (io.ReadCloser, error) {
+	switch encoding {
+	case ZstdEncoding:
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return decoder.IOReadCloser(), nil
+	case GzipEncoding:
+		return gzip.NewReader(r)
+	}
+
+	return nil, ErrUnsupportedStreamEncoding
+}
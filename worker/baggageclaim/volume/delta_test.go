@@ -0,0 +1,119 @@
+package volume_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest", func() {
+	Describe("BuildManifest", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "manifest")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.Mkdir(filepath.Join(dir, "some-dir"), 0750)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "some-dir", "some-file"), []byte("some-content"), 0640)).To(Succeed())
+			Expect(os.Symlink("some-dir/some-file", filepath.Join(dir, "some-link"))).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("describes every entry under the root", func() {
+			manifest, err := volume.BuildManifest(dir)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(manifest).To(Equal(volume.Manifest{
+				"some-dir": {Dir: true, Mode: 0750},
+				"some-dir/some-file": {
+					Mode:   0640,
+					Digest: fmt.Sprintf("%x", sha256.Sum256([]byte("some-content"))),
+				},
+				"some-link": {Mode: 0777, Link: "some-dir/some-file"},
+			}))
+		})
+	})
+
+	Describe("Delta", func() {
+		var (
+			base     volume.Manifest
+			manifest volume.Manifest
+		)
+
+		BeforeEach(func() {
+			base = volume.Manifest{
+				"dir":          {Dir: true, Mode: 0755},
+				"dir/same":     {Mode: 0644, Digest: "same"},
+				"dir/changed":  {Mode: 0644, Digest: "old"},
+				"dir/chmodded": {Mode: 0644, Digest: "chmodded"},
+				"gone":         {Dir: true, Mode: 0755},
+				"gone/file":    {Mode: 0644, Digest: "gone"},
+				"link":         {Mode: 0777, Link: "dir/same"},
+				"file-to-dir":  {Mode: 0644, Digest: "file-to-dir"},
+			}
+
+			manifest = volume.Manifest{
+				"dir":              {Dir: true, Mode: 0755},
+				"dir/same":         {Mode: 0644, Digest: "same"},
+				"dir/changed":      {Mode: 0644, Digest: "new"},
+				"dir/chmodded":     {Mode: 0600, Digest: "chmodded"},
+				"dir/added":        {Mode: 0644, Digest: "added"},
+				"link":             {Mode: 0777, Link: "dir/same"},
+				"file-to-dir":      {Dir: true, Mode: 0755},
+				"file-to-dir/file": {Mode: 0644, Digest: "file"},
+			}
+		})
+
+		It("streams what changed, including every directory", func() {
+			changed, _ := manifest.Delta(base)
+			Expect(changed).To(Equal([]string{
+				"dir",
+				"dir/added",
+				"dir/changed",
+				"dir/chmodded",
+				"file-to-dir",
+				"file-to-dir/file",
+			}))
+		})
+
+		It("removes the top-most path of what's gone or replaced", func() {
+			_, removed := manifest.Delta(base)
+			Expect(removed).To(Equal([]string{
+				"dir/changed",
+				"dir/chmodded",
+				"file-to-dir",
+				"gone",
+			}))
+		})
+
+		Context("when there is no base", func() {
+			It("streams everything and removes nothing", func() {
+				changed, removed := manifest.Delta(volume.Manifest{})
+				Expect(changed).To(HaveLen(len(manifest)))
+				Expect(removed).To(BeEmpty())
+			})
+		})
+
+		Context("when entries have no contents to compare", func() {
+			It("streams them", func() {
+				base = volume.Manifest{"device": {Mode: 0600}}
+				manifest = volume.Manifest{"device": {Mode: 0600}}
+
+				changed, removed := manifest.Delta(base)
+				Expect(changed).To(Equal([]string{"device"}))
+				Expect(removed).To(Equal([]string{"device"}))
+			})
+		})
+	})
+})
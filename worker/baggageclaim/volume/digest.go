@@ -0,0 +1,49 @@
+package volume
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+var ErrDigestMismatch = errors.New("streamed volume does not match its digest")
+
+// DigestHeader carries the sha256 of a compressed volume stream, so that the
+// receiving end can tell whether it got what was sent. It's a trailer when
+// the digest is only known once the stream has been written.
+const DigestHeader = "X-Baggageclaim-Sha256"
+
+// A Digest hashes a volume stream as it's written to it.
+type Digest struct {
+	hash hash.Hash
+}
+
+func NewDigest() Digest {
+	return Digest{hash: sha256.New()}
+}
+
+func (digest Digest) Write(p []byte) (int, error) {
+	return digest.hash.Write(p)
+}
+
+func (digest Digest) String() string {
+	return hex.EncodeToString(digest.hash.Sum(nil))
+}
+
+// Verify returns ErrDigestMismatch if `expected` isn't the digest of the
+// stream. Nothing is verified if it's empty, as senders which predate
+// digests don't send them.
+func (digest Digest) Verify(expected string) error {
+	if expected == "" {
+		return nil
+	}
+
+	actual := digest.String()
+	if actual != expected {
+		return fmt.Errorf("%w: expected sha256 %s, got %s", ErrDigestMismatch, expected, actual)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package volume_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Digest", func() {
+	var digest volume.Digest
+
+	BeforeEach(func() {
+		digest = volume.NewDigest()
+
+		_, err := digest.Write([]byte("some-stream"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("is the sha256 of what was written", func() {
+		Expect(digest.String()).To(Equal(fmt.Sprintf("%x", sha256.Sum256([]byte("some-stream")))))
+	})
+
+	It("verifies the digest of what was written", func() {
+		Expect(digest.Verify(digest.String())).To(Succeed())
+	})
+
+	It("verifies nothing without a digest", func() {
+		Expect(digest.Verify("")).To(Succeed())
+	})
+
+	It("fails to verify any other digest", func() {
+		err := digest.Verify(fmt.Sprintf("%x", sha256.Sum256([]byte("some-other-stream"))))
+		Expect(err).To(MatchError(volume.ErrDigestMismatch))
+	})
+})
@@ -1,5 +1,14 @@
 package volume
 
+import (
+	"errors"
+	"io"
+)
+
+var ErrVolumeNotPersisted = errors.New("volume is not persisted")
+var ErrPersistenceUnsupported = errors.New("volume driver does not persist volumes")
+var ErrQuotaUnsupported = errors.New("volume driver does not limit the size of volumes")
+
 //go:generate counterfeiter . Driver
 
 type Driver interface {
@@ -10,3 +19,40 @@ type Driver interface {
 
 	Recover(Filesystem) error
 }
+
+//go:generate counterfeiter . PersistentDriver
+
+// A PersistentDriver also keeps archives of volumes in an external store, so
+// that their contents outlive the worker they were created on and can be
+// hydrated on another.
+type PersistentDriver interface {
+	Driver
+
+	// PersistVolume stores the gzipped tar archive of a volume's contents
+	// under the key, replacing whatever was stored there before.
+	PersistVolume(key string, archive io.Reader) error
+
+	// PersistedVolume returns the archive stored under the key, or
+	// ErrVolumeNotPersisted if there is none.
+	PersistedVolume(key string) (io.ReadCloser, error)
+}
+
+//go:generate counterfeiter . QuotaDriver
+
+// A QuotaDriver limits how much data can be written to each volume.
+type QuotaDriver interface {
+	Driver
+
+	// SetVolumeQuota limits the volume to the given number of bytes. Writes
+	// which would take the volume over it fail.
+	SetVolumeQuota(vol FilesystemVolume, limit uint64) error
+
+	// VolumeQuota returns the volume's limit, which is 0 if it has none, and
+	// how many bytes of it are used.
+	VolumeQuota(vol FilesystemVolume) (Quota, error)
+}
+
+type Quota struct {
+	Limit uint64
+	Used  uint64
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/worker/baggageclaim/volume"
@@ -81,6 +83,54 @@ func (driver *BtrFSDriver) CreateCopyOnWriteLayer(
 	return err
 }
 
+func (driver *BtrFSDriver) SetVolumeQuota(vol volume.FilesystemVolume, limit uint64) error {
+	// quotas have to be enabled on the filesystem before any subvolume can be
+	// limited. enabling them again once they are is a no-op.
+	_, _, err := driver.run(driver.btrfsBin, "quota", "enable", vol.DataPath())
+	if err != nil {
+		return err
+	}
+
+	_, _, err = driver.run(driver.btrfsBin, "qgroup", "limit", strconv.FormatUint(limit, 10), vol.DataPath())
+	return err
+}
+
+func (driver *BtrFSDriver) VolumeQuota(vol volume.FilesystemVolume) (volume.Quota, error) {
+	stdout, _, err := driver.run(driver.btrfsBin, "qgroup", "show", "-rf", "--raw", vol.DataPath())
+	if err != nil {
+		return volume.Quota{}, err
+	}
+
+	// the qgroup of the subvolume is on the last line, after the headers:
+	//
+	//   qgroupid         rfer         excl     max_rfer
+	//   --------         ----         ----     --------
+	//   0/257           16384        16384      1048576
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return volume.Quota{}, fmt.Errorf("malformed qgroup of %s: %q", vol.DataPath(), lines[len(lines)-1])
+	}
+
+	used, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return volume.Quota{}, fmt.Errorf("malformed qgroup of %s: %w", vol.DataPath(), err)
+	}
+
+	var limit uint64
+	if fields[3] != "none" {
+		limit, err = strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			return volume.Quota{}, fmt.Errorf("malformed qgroup of %s: %w", vol.DataPath(), err)
+		}
+	}
+
+	return volume.Quota{
+		Limit: limit,
+		Used:  used,
+	}, nil
+}
+
 func (driver *BtrFSDriver) run(command string, args ...string) (string, string, error) {
 	cmd := exec.Command(command, args...)
 
@@ -141,6 +141,17 @@ func (driver *OverlayDriver) Recover(fs volume.Filesystem) error {
 	return nil
 }
 
+// SetVolumeQuota limits the volume's layer with a project quota, so only what
+// was written to the volume itself counts towards it, rather than what it
+// shares with its parent.
+func (driver *OverlayDriver) SetVolumeQuota(vol volume.FilesystemVolume, limit uint64) error {
+	return setProjectQuota(driver.layerDir(vol), projectID(vol.Handle()), limit)
+}
+
+func (driver *OverlayDriver) VolumeQuota(vol volume.FilesystemVolume) (volume.Quota, error) {
+	return projectQuota(driver.layerDir(vol), projectID(vol.Handle()))
+}
+
 func (driver *OverlayDriver) findRootParent(child volume.FilesystemVolume,
 	parent volume.FilesystemLiveVolume) (volume.FilesystemLiveVolume, error) {
 	rootParent := parent
@@ -0,0 +1,256 @@
+package driver
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+)
+
+// project quotas limit the space used by every file with the same project ID,
+// wherever it is on the filesystem. they're supported by xfs, and by ext4 when
+// it was created with the project quota feature, provided the filesystem is
+// mounted with project quotas turned on (prjquota).
+const (
+	prjQuota = 2
+
+	qGetQuota = 0x800007
+	qSetQuota = 0x800008
+
+	// limits are set in blocks of this many bytes, unlike usage which is
+	// reported in bytes
+	qifDqblkSize = 1024
+	qifBLimits   = 1
+
+	fsIocFsGetXattr = 0x801c581f
+	fsIocFsSetXattr = 0x401c5820
+
+	fsXflagProjInherit = 0x200
+)
+
+// fsxattr is struct fsxattr from linux/fs.h
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// dqblk is struct if_dqblk from linux/quota.h
+type dqblk struct {
+	bHardLimit uint64
+	bSoftLimit uint64
+	curSpace   uint64
+	iHardLimit uint64
+	iSoftLimit uint64
+	curInodes  uint64
+	bTime      uint64
+	iTime      uint64
+	valid      uint32
+}
+
+// projectID derives the project ID of a volume from its handle. handles are
+// unique, so projects are only shared by volumes in the unlikely event of a
+// hash collision.
+func projectID(handle string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(handle))
+
+	// project 0 is the default project of every file
+	id := hash.Sum32()
+	if id == 0 {
+		id = 1
+	}
+
+	return id
+}
+
+// setProjectQuota assigns everything in the directory to the project, along
+// with everything created in it later, and limits the project to the given
+// number of bytes.
+func setProjectQuota(dir string, id uint32, limit uint64) error {
+	device, err := blockDevice(dir)
+	if err != nil {
+		return err
+	}
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// symlinks and special files can't be opened to be assigned to the
+		// project, but they take up next to no space anyway
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+
+		return setProjectID(path, id, info.IsDir())
+	})
+	if err != nil {
+		return err
+	}
+
+	quota := dqblk{
+		bHardLimit: (limit + qifDqblkSize - 1) / qifDqblkSize,
+		valid:      qifBLimits,
+	}
+
+	return quotactl(qSetQuota, device, id, &quota)
+}
+
+func projectQuota(dir string, id uint32) (volume.Quota, error) {
+	device, err := blockDevice(dir)
+	if err != nil {
+		return volume.Quota{}, err
+	}
+
+	var quota dqblk
+	err = quotactl(qGetQuota, device, id, &quota)
+	if err != nil {
+		return volume.Quota{}, err
+	}
+
+	return volume.Quota{
+		Limit: quota.bHardLimit * qifDqblkSize,
+		Used:  quota.curSpace,
+	}, nil
+}
+
+func setProjectID(path string, id uint32, isDir bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	var attrs fsxattr
+	err = ioctl(file.Fd(), fsIocFsGetXattr, &attrs)
+	if err != nil {
+		return err
+	}
+
+	attrs.projid = id
+
+	// files created in the directory are assigned to its project
+	if isDir {
+		attrs.xflags |= fsXflagProjInherit
+	}
+
+	return ioctl(file.Fd(), fsIocFsSetXattr, &attrs)
+}
+
+func ioctl(fd uintptr, request uintptr, attrs *fsxattr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(unsafe.Pointer(attrs)))
+	if errno != 0 {
+		return quotaError(errno)
+	}
+
+	return nil
+}
+
+func quotactl(cmd int, device string, id uint32, quota *dqblk) error {
+	devicePtr, err := syscall.BytePtrFromString(device)
+	if err != nil {
+		return err
+	}
+
+	_, _, errno := syscall.Syscall6(
+		syscall.SYS_QUOTACTL,
+		uintptr(cmd<<8|prjQuota),
+		uintptr(unsafe.Pointer(devicePtr)),
+		uintptr(id),
+		uintptr(unsafe.Pointer(quota)),
+		0, 0,
+	)
+	if errno != 0 {
+		return quotaError(errno)
+	}
+
+	return nil
+}
+
+// quotaError tells apart filesystems which can't limit projects from ones
+// which failed to.
+func quotaError(errno syscall.Errno) error {
+	switch errno {
+	case syscall.ENOTTY, syscall.EOPNOTSUPP, syscall.ENOSYS, syscall.ESRCH:
+		return volume.ErrQuotaUnsupported
+	default:
+		return errno
+	}
+}
+
+// blockDevice finds the device of the filesystem the directory is on, which
+// quotas are set through.
+func blockDevice(dir string) (string, error) {
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+
+	mountinfo, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return "", err
+	}
+
+	defer mountinfo.Close()
+
+	var mountPoint, device string
+
+	scanner := bufio.NewScanner(mountinfo)
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw
+		fields := strings.Fields(scanner.Text())
+
+		separator := -1
+		for i, field := range fields {
+			if field == "-" {
+				separator = i
+				break
+			}
+		}
+
+		if len(fields) < 5 || separator == -1 || len(fields) < separator+3 {
+			continue
+		}
+
+		point := unescapeMountPath(fields[4])
+		if !isWithin(dir, point) || len(point) < len(mountPoint) {
+			continue
+		}
+
+		mountPoint = point
+		device = fields[separator+2]
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return "", err
+	}
+
+	if device == "" {
+		return "", fmt.Errorf("no filesystem mounted at %s", dir)
+	}
+
+	return device, nil
+}
+
+func isWithin(path string, dir string) bool {
+	return dir == "/" || path == dir || strings.HasPrefix(path, dir+"/")
+}
+
+// unescapeMountPath undoes the octal escaping of spaces, tabs, newlines and
+// backslashes in paths in mountinfo.
+func unescapeMountPath(path string) string {
+	return strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`).Replace(path)
+}
@@ -0,0 +1,99 @@
+package driver
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+)
+
+// S3Driver manages volumes on local disk with another driver, and persists
+// their archives in an S3 bucket, or in a bucket of any S3-compatible store.
+type S3Driver struct {
+	volume.Driver
+
+	bucket   string
+	prefix   string
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+// NewS3Driver wraps the driver so that volumes can be persisted to and
+// hydrated from the bucket. Credentials are taken from the environment.
+func NewS3Driver(driver volume.Driver, bucket string, prefix string, region string, endpoint string) (volume.PersistentDriver, error) {
+	config := aws.NewConfig()
+	if region != "" {
+		config = config.WithRegion(region)
+	}
+
+	if endpoint != "" {
+		config = config.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("create aws session: %w", err)
+	}
+
+	return &S3Driver{
+		Driver: driver,
+
+		bucket:   bucket,
+		prefix:   prefix,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (driver *S3Driver) PersistVolume(key string, archive io.Reader) error {
+	_, err := driver.uploader.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(driver.bucket),
+		Key:         aws.String(path.Join(driver.prefix, key)),
+		Body:        archive,
+		ContentType: aws.String("application/gzip"),
+	})
+
+	return err
+}
+
+func (driver *S3Driver) PersistedVolume(key string) (io.ReadCloser, error) {
+	output, err := driver.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(driver.bucket),
+		Key:    aws.String(path.Join(driver.prefix, key)),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, volume.ErrVolumeNotPersisted
+		}
+
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+// SetVolumeQuota and VolumeQuota limit volumes with the wrapped driver, if it
+// can.
+func (driver *S3Driver) SetVolumeQuota(vol volume.FilesystemVolume, limit uint64) error {
+	quotaDriver, ok := driver.Driver.(volume.QuotaDriver)
+	if !ok {
+		return volume.ErrQuotaUnsupported
+	}
+
+	return quotaDriver.SetVolumeQuota(vol, limit)
+}
+
+func (driver *S3Driver) VolumeQuota(vol volume.FilesystemVolume) (volume.Quota, error) {
+	quotaDriver, ok := driver.Driver.(volume.QuotaDriver)
+	if !ok {
+		return volume.Quota{}, volume.ErrQuotaUnsupported
+	}
+
+	return quotaDriver.VolumeQuota(vol)
+}
@@ -0,0 +1,91 @@
+package driver_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/driver"
+)
+
+var _ = Describe("S3", func() {
+	var (
+		server       *ghttp.Server
+		volumeDriver volume.PersistentDriver
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		os.Setenv("AWS_ACCESS_KEY_ID", "some-access-key")
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "some-secret-key")
+
+		var err error
+		volumeDriver, err = driver.NewS3Driver(&driver.NaiveDriver{}, "some-bucket", "some-prefix", "us-east-1", server.URL())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+
+		os.Unsetenv("AWS_ACCESS_KEY_ID")
+		os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+	})
+
+	Describe("PersistVolume", func() {
+		It("uploads the archive under the prefix", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/some-bucket/some-prefix/some/key"),
+					ghttp.VerifyHeaderKV("Content-Type", "application/gzip"),
+					ghttp.VerifyBody([]byte("some-archive")),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+
+			err := volumeDriver.PersistVolume("some/key", strings.NewReader("some-archive"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error when the upload fails", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusForbidden, ""),
+			)
+
+			err := volumeDriver.PersistVolume("some/key", strings.NewReader("some-archive"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("PersistedVolume", func() {
+		It("streams the archive", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/some-bucket/some-prefix/some/key"),
+					ghttp.RespondWith(http.StatusOK, "some-archive"),
+				),
+			)
+
+			archive, err := volumeDriver.PersistedVolume("some/key")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer archive.Close()
+			Expect(ioutil.ReadAll(archive)).To(Equal([]byte("some-archive")))
+		})
+
+		It("returns ErrVolumeNotPersisted when there is no such archive", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code></Error>`),
+			)
+
+			_, err := volumeDriver.PersistedVolume("some/key")
+			Expect(err).To(Equal(volume.ErrVolumeNotPersisted))
+		})
+	})
+})
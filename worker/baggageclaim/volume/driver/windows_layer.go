@@ -0,0 +1,102 @@
+package driver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/copy"
+)
+
+// layerChainFile is how Windows container layers refer to their parents: it
+// lists their paths, from the top-most layer down to the base layer.
+const layerChainFile = "layerchain.json"
+
+// WindowsLayerDriver creates copy-on-write volumes of Windows container
+// layers as layers of their own, chained to their parents, which the
+// containerd runtime stacks up with hcsshim when the volume is a container's
+// rootfs.
+//
+// Volumes which aren't Windows layers, such as task inputs, are copied as
+// they are by the NaiveDriver, since they are bind mounted as they are.
+type WindowsLayerDriver struct{}
+
+func (driver *WindowsLayerDriver) CreateVolume(vol volume.FilesystemInitVolume) error {
+	return os.Mkdir(vol.DataPath(), 0755)
+}
+
+func (driver *WindowsLayerDriver) DestroyVolume(vol volume.FilesystemVolume) error {
+	return os.RemoveAll(vol.DataPath())
+}
+
+func (driver *WindowsLayerDriver) CreateCopyOnWriteLayer(
+	childVol volume.FilesystemInitVolume,
+	parentVol volume.FilesystemLiveVolume,
+) error {
+	parentPath := parentVol.DataPath()
+
+	parentChain, isLayer, err := readLayerChain(parentPath)
+	if err != nil {
+		return err
+	}
+
+	if !isLayer {
+		return copy.Cp(false, parentPath, childVol.DataPath())
+	}
+
+	err = os.Mkdir(childVol.DataPath(), 0755)
+	if err != nil {
+		return err
+	}
+
+	chain, err := json.Marshal(append([]string{parentPath}, parentChain...))
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(childVol.DataPath(), layerChainFile), chain, 0644)
+}
+
+func (driver *WindowsLayerDriver) Recover(volume.Filesystem) error {
+	// nothing to do
+	return nil
+}
+
+// readLayerChain returns the parents of the layer at `path`, if it is a
+// layer at all: either a base layer, with its files and registry hives, or a
+// layer chained to others.
+func readLayerChain(path string) ([]string, bool, error) {
+	content, err := ioutil.ReadFile(filepath.Join(path, layerChainFile))
+	if err == nil {
+		var chain []string
+		err = json.Unmarshal(content, &chain)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return chain, true, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, false, err
+	}
+
+	for _, dir := range []string{"Files", "Hives"} {
+		info, err := os.Stat(filepath.Join(path, dir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, false, nil
+			}
+
+			return nil, false, err
+		}
+
+		if !info.IsDir() {
+			return nil, false, nil
+		}
+	}
+
+	return []string{}, true, nil
+}
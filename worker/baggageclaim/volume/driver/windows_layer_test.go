@@ -0,0 +1,94 @@
+package driver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/driver"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WindowsLayerDriver", func() {
+	var tmpdir string
+	var fs volume.Filesystem
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "windows-layer-test")
+		Expect(err).ToNot(HaveOccurred())
+
+		fs, err = volume.NewFilesystem(&driver.WindowsLayerDriver{}, tmpdir)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpdir)).To(Succeed())
+	})
+
+	layerChain := func(vol volume.FilesystemVolume) []string {
+		content, err := ioutil.ReadFile(filepath.Join(vol.DataPath(), "layerchain.json"))
+		Expect(err).ToNot(HaveOccurred())
+
+		var chain []string
+		Expect(json.Unmarshal(content, &chain)).To(Succeed())
+
+		return chain
+	}
+
+	Context("when the parent volume is a Windows layer", func() {
+		It("chains copy-on-write volumes to their parents", func() {
+			baseInit, err := fs.NewVolume("base")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.Mkdir(filepath.Join(baseInit.DataPath(), "Files"), 0755)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(baseInit.DataPath(), "Hives"), 0755)).To(Succeed())
+
+			base, err := baseInit.Initialize()
+			Expect(err).ToNot(HaveOccurred())
+
+			childInit, err := base.NewSubvolume("child")
+			Expect(err).ToNot(HaveOccurred())
+
+			child, err := childInit.Initialize()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(layerChain(child)).To(Equal([]string{base.DataPath()}))
+			Expect(filepath.Join(child.DataPath(), "Files")).ToNot(BeADirectory())
+
+			grandchildInit, err := child.NewSubvolume("grandchild")
+			Expect(err).ToNot(HaveOccurred())
+
+			grandchild, err := grandchildInit.Initialize()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(layerChain(grandchild)).To(Equal([]string{child.DataPath(), base.DataPath()}))
+		})
+	})
+
+	Context("when the parent volume is not a Windows layer", func() {
+		It("copies it", func() {
+			parentInit, err := fs.NewVolume("parent")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(parentInit.DataPath(), "some-file"), []byte("hello"), 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			parent, err := parentInit.Initialize()
+			Expect(err).ToNot(HaveOccurred())
+
+			childInit, err := parent.NewSubvolume("child")
+			Expect(err).ToNot(HaveOccurred())
+
+			child, err := childInit.Initialize()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ioutil.ReadFile(filepath.Join(child.DataPath(), "some-file"))).To(Equal([]byte("hello")))
+			Expect(filepath.Join(child.DataPath(), "layerchain.json")).ToNot(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,281 @@
+package driver
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+)
+
+// ZFSDriver creates each volume as a dataset under a parent dataset, and
+// copy-on-write layers as clones of snapshots of their parent's dataset. The
+// datasets are mounted at the volumes' data paths, so their mountpoints are
+// left to the driver rather than to ZFS.
+type ZFSDriver struct {
+	logger  lager.Logger
+	zfsBin  string
+	dataset string
+
+	// quota and compression are set on every volume's dataset, unless empty.
+	quota       string
+	compression string
+}
+
+func NewZFSDriver(
+	logger lager.Logger,
+	zfsBin string,
+	dataset string,
+	quota string,
+	compression string,
+) *ZFSDriver {
+	return &ZFSDriver{
+		logger:      logger,
+		zfsBin:      zfsBin,
+		dataset:     dataset,
+		quota:       quota,
+		compression: compression,
+	}
+}
+
+func (driver *ZFSDriver) CreateVolume(vol volume.FilesystemInitVolume) error {
+	args := append([]string{"create"}, driver.properties()...)
+
+	_, _, err := driver.run(driver.zfsBin, append(args, driver.volumeDataset(vol))...)
+	if err != nil {
+		return err
+	}
+
+	return driver.mount(vol)
+}
+
+func (driver *ZFSDriver) DestroyVolume(vol volume.FilesystemVolume) error {
+	err := syscall.Unmount(vol.DataPath(), 0)
+	// the dataset may have never been mounted, or already been unmounted
+	// by an earlier attempt to destroy it
+	if err != nil && err != syscall.EINVAL && !os.IsNotExist(err) {
+		return err
+	}
+
+	dataset := driver.volumeDataset(vol)
+
+	exists, err := driver.exists(dataset)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		err = driver.promoteClones(dataset)
+		if err != nil {
+			return err
+		}
+
+		// the origin has to be looked up after promoting, as the dataset
+		// becomes a clone of whichever dataset was promoted
+		origin, err := driver.get(dataset, "origin")
+		if err != nil {
+			return err
+		}
+
+		_, _, err = driver.run(driver.zfsBin, "destroy", "-r", dataset)
+		if err != nil {
+			return err
+		}
+
+		if origin != "-" {
+			// the snapshot is destroyed once no other clones depend on it
+			_, _, err = driver.run(driver.zfsBin, "destroy", "-d", origin)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return os.RemoveAll(vol.DataPath())
+}
+
+func (driver *ZFSDriver) CreateCopyOnWriteLayer(
+	childVol volume.FilesystemInitVolume,
+	parentVol volume.FilesystemLiveVolume,
+) error {
+	snapshot := driver.volumeDataset(parentVol) + "@" + childVol.Handle()
+
+	_, _, err := driver.run(driver.zfsBin, "snapshot", snapshot)
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"clone"}, driver.properties()...)
+
+	_, _, err = driver.run(driver.zfsBin, append(args, snapshot, driver.volumeDataset(childVol))...)
+	if err != nil {
+		return err
+	}
+
+	return driver.mount(childVol)
+}
+
+func (driver *ZFSDriver) Recover(fs volume.Filesystem) error {
+	vols, err := fs.ListVolumes()
+	if err != nil {
+		return err
+	}
+
+	for _, vol := range vols {
+		err = driver.mount(vol)
+		if err != nil {
+			return fmt.Errorf("recover mount: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (driver *ZFSDriver) SetVolumeQuota(vol volume.FilesystemVolume, limit uint64) error {
+	_, _, err := driver.run(driver.zfsBin, "set", "quota="+strconv.FormatUint(limit, 10), driver.volumeDataset(vol))
+	return err
+}
+
+func (driver *ZFSDriver) VolumeQuota(vol volume.FilesystemVolume) (volume.Quota, error) {
+	stdout, _, err := driver.run(driver.zfsBin, "get", "-Hp", "-o", "value", "quota,used", driver.volumeDataset(vol))
+	if err != nil {
+		return volume.Quota{}, err
+	}
+
+	values := strings.Fields(stdout)
+	if len(values) != 2 {
+		return volume.Quota{}, fmt.Errorf("malformed quota of %s: %q", driver.volumeDataset(vol), stdout)
+	}
+
+	// a quota of 0 means there is none
+	limit, err := strconv.ParseUint(values[0], 10, 64)
+	if err != nil {
+		return volume.Quota{}, fmt.Errorf("malformed quota of %s: %w", driver.volumeDataset(vol), err)
+	}
+
+	used, err := strconv.ParseUint(values[1], 10, 64)
+	if err != nil {
+		return volume.Quota{}, fmt.Errorf("malformed usage of %s: %w", driver.volumeDataset(vol), err)
+	}
+
+	return volume.Quota{
+		Limit: limit,
+		Used:  used,
+	}, nil
+}
+
+// promoteClones promotes the clones of the dataset's snapshots, so that the
+// dataset no longer has any dependents and can be destroyed before the
+// volumes which were created from it.
+func (driver *ZFSDriver) promoteClones(dataset string) error {
+	for {
+		stdout, _, err := driver.run(driver.zfsBin, "list", "-H", "-t", "snapshot", "-d", "1", "-o", "clones", dataset)
+		if err != nil {
+			return err
+		}
+
+		var clone string
+		for _, clones := range strings.Split(strings.TrimSpace(stdout), "\n") {
+			if clones != "" && clones != "-" {
+				clone = strings.Split(clones, ",")[0]
+				break
+			}
+		}
+
+		if clone == "" {
+			return nil
+		}
+
+		_, _, err = driver.run(driver.zfsBin, "promote", clone)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (driver *ZFSDriver) mount(vol volume.FilesystemVolume) error {
+	err := os.MkdirAll(vol.DataPath(), 0755)
+	if err != nil {
+		return err
+	}
+
+	return syscall.Mount(driver.volumeDataset(vol), vol.DataPath(), "zfs", 0, "")
+}
+
+func (driver *ZFSDriver) exists(dataset string) (bool, error) {
+	stdout, _, err := driver.run(driver.zfsBin, "list", "-H", "-t", "filesystem", "-d", "1", "-o", "name", driver.dataset)
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if name == dataset {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (driver *ZFSDriver) get(dataset string, property string) (string, error) {
+	stdout, _, err := driver.run(driver.zfsBin, "get", "-H", "-o", "value", property, dataset)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+func (driver *ZFSDriver) properties() []string {
+	properties := []string{"-o", "mountpoint=legacy"}
+
+	if driver.quota != "" {
+		properties = append(properties, "-o", "quota="+driver.quota)
+	}
+
+	if driver.compression != "" {
+		properties = append(properties, "-o", "compression="+driver.compression)
+	}
+
+	return properties
+}
+
+func (driver *ZFSDriver) volumeDataset(vol volume.FilesystemVolume) string {
+	return driver.dataset + "/" + vol.Handle()
+}
+
+func (driver *ZFSDriver) run(command string, args ...string) (string, string, error) {
+	cmd := exec.Command(command, args...)
+
+	logger := driver.logger.Session("run-command", lager.Data{
+		"command": command,
+		"args":    args,
+	})
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+
+	loggerData := lager.Data{
+		"stdout": stdout.String(),
+		"stderr": stderr.String(),
+	}
+
+	if err != nil {
+		logger.Error("failed", err, loggerData)
+		return "", "", fmt.Errorf("%s: %w: %s", command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	logger.Debug("ran", loggerData)
+
+	return stdout.String(), stderr.String(), nil
+}
@@ -0,0 +1,128 @@
+package driver_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/driver"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ZFS", func() {
+	var (
+		tempDir   string
+		poolName  string
+		zfsDriver *driver.ZFSDriver
+		volumeFs  volume.Filesystem
+	)
+
+	BeforeEach(func() {
+		_, err := exec.LookPath("zpool")
+		if err != nil {
+			Skip("zfs is not installed")
+		}
+
+		tempDir, err = ioutil.TempDir("", "baggageclaim_zfs_test")
+		Expect(err).NotTo(HaveOccurred())
+
+		imagePath := filepath.Join(tempDir, "image.img")
+		image, err := os.Create(imagePath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(image.Truncate(256 * 1024 * 1024)).To(Succeed())
+		Expect(image.Close()).To(Succeed())
+
+		poolName = fmt.Sprintf("baggageclaim-test-%d", GinkgoParallelNode())
+
+		output, err := exec.Command("zpool", "create", "-m", "none", poolName, imagePath).CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+
+		zfsDriver = driver.NewZFSDriver(lagertest.NewTestLogger("zfs"), "zfs", poolName, "64M", "lz4")
+
+		volumeFs, err = volume.NewFilesystem(zfsDriver, filepath.Join(tempDir, "volumes"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		output, err := exec.Command("zpool", "destroy", "-f", poolName).CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	zfsGet := func(property string, handle string) string {
+		output, err := exec.Command("zfs", "get", "-H", "-o", "value", property, poolName+"/"+handle).CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+		return string(output)
+	}
+
+	It("creates volumes as datasets with the quota and compression", func() {
+		vol, err := volumeFs.NewVolume("some-volume")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(zfsGet("quota", "some-volume")).To(Equal("64M\n"))
+		Expect(zfsGet("compression", "some-volume")).To(Equal("lz4\n"))
+
+		Expect(vol.Destroy()).To(Succeed())
+
+		_, err = exec.Command("zfs", "list", poolName+"/some-volume").CombinedOutput()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("limits volumes to their quotas", func() {
+		vol, err := volumeFs.NewVolume("some-volume")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(zfsDriver.SetVolumeQuota(vol, 32*1024*1024)).To(Succeed())
+
+		quota, err := zfsDriver.VolumeQuota(vol)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quota.Limit).To(Equal(uint64(32 * 1024 * 1024)))
+
+		// incompressible, so the compression doesn't keep it under the quota
+		content := make([]byte, 48*1024*1024)
+		rand.Read(content)
+
+		err = ioutil.WriteFile(filepath.Join(vol.DataPath(), "some-file"), content, 0644)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("creates copy-on-write layers which can outlive their parents", func() {
+		parentInit, err := volumeFs.NewVolume("parent")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = ioutil.WriteFile(filepath.Join(parentInit.DataPath(), "some-file"), []byte("parent"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		parent, err := parentInit.Initialize()
+		Expect(err).NotTo(HaveOccurred())
+
+		childInit, err := parent.NewSubvolume("child")
+		Expect(err).NotTo(HaveOccurred())
+
+		child, err := childInit.Initialize()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ioutil.ReadFile(filepath.Join(child.DataPath(), "some-file"))).To(Equal([]byte("parent")))
+
+		err = ioutil.WriteFile(filepath.Join(child.DataPath(), "some-file"), []byte("child"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ioutil.ReadFile(filepath.Join(parent.DataPath(), "some-file"))).To(Equal([]byte("parent")))
+
+		Expect(parent.Destroy()).To(Succeed())
+		Expect(ioutil.ReadFile(filepath.Join(child.DataPath(), "some-file"))).To(Equal([]byte("child")))
+
+		Expect(child.Destroy()).To(Succeed())
+
+		output, err := exec.Command("zfs", "list", "-H", "-r", "-t", "all", "-o", "name", poolName).CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+		Expect(string(output)).To(Equal(poolName + "\n"))
+	})
+})
@@ -0,0 +1,257 @@
+package volume
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+var ErrInvalidEncryptedStream = errors.New("invalid encrypted stream")
+
+// P2pKeySize is the size of the keys volumes are encrypted with when they're
+// streamed from one worker to another, for AES-256.
+const P2pKeySize = 32
+
+// p2pKeyFragment is how the key is passed to the worker streaming out the
+// volume, in the fragment of the URL it streams to, which isn't sent along
+// with the request.
+const p2pKeyFragment = "key="
+
+const (
+	encryptedFrameSize = 64 * 1024
+
+	// finalFrame is set on the length of the last frame of a stream, so that
+	// a stream which was cut short can be told apart from one which ended
+	finalFrame = 1 << 31
+)
+
+func NewP2pKey() ([]byte, error) {
+	key := make([]byte, P2pKeySize)
+
+	_, err := rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// WithP2pKey adds `key` to a P2P stream-in URL.
+func WithP2pKey(streamInURL string, key []byte) string {
+	return streamInURL + "#" + p2pKeyFragment + base64.RawURLEncoding.EncodeToString(key)
+}
+
+// SplitP2pKey returns the URL to stream a volume to, without its key, and the
+// key to encrypt the volume with, if there is one.
+func SplitP2pKey(streamInURL string) (string, []byte, error) {
+	u, err := url.Parse(streamInURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !strings.HasPrefix(u.Fragment, p2pKeyFragment) {
+		return streamInURL, nil, nil
+	}
+
+	key, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(u.Fragment, p2pKeyFragment))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(key) != P2pKeySize {
+		return "", nil, fmt.Errorf("invalid p2p key size: %d", len(key))
+	}
+
+	u.Fragment = ""
+
+	return u.String(), key, nil
+}
+
+// NewEncryptingWriter encrypts what's written to it with AES-GCM, in frames
+// which can be decrypted as they are read. Closing it writes the last frame.
+//
+// Frame nonces are counted up from zero, which is only safe because every
+// key is used for one stream.
+func NewEncryptingWriter(key []byte, w io.Writer) (io.WriteCloser, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptingWriter{
+		aead:   aead,
+		writer: w,
+		buffer: make([]byte, 0, encryptedFrameSize),
+	}, nil
+}
+
+// NewDecryptingReader decrypts a stream written by an encrypting writer,
+// returning ErrInvalidEncryptedStream if it has been tampered with or cut
+// short.
+func NewDecryptingReader(key []byte, r io.Reader) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptingReader{
+		aead:   aead,
+		reader: r,
+	}, nil
+}
+
+func encrypt(key []byte, dest io.Writer, src io.Reader) error {
+	writer, err := NewEncryptingWriter(key, dest)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, src)
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func frameNonce(aead cipher.AEAD, counter uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], counter)
+	return nonce
+}
+
+type encryptingWriter struct {
+	aead    cipher.AEAD
+	writer  io.Writer
+	buffer  []byte
+	counter uint64
+}
+
+func (w *encryptingWriter) Write(p []byte) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		n := copy(w.buffer[len(w.buffer):cap(w.buffer)], p)
+		w.buffer = w.buffer[:len(w.buffer)+n]
+		p = p[n:]
+		written += n
+
+		// the last frame is only written on close, so a full frame is held
+		// back until there's more to write
+		if len(w.buffer) == cap(w.buffer) && len(p) > 0 {
+			err := w.writeFrame(false)
+			if err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+func (w *encryptingWriter) Close() error {
+	return w.writeFrame(true)
+}
+
+func (w *encryptingWriter) writeFrame(final bool) error {
+	length := uint32(len(w.buffer))
+	if final {
+		length |= finalFrame
+	}
+
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, length)
+
+	frame := w.aead.Seal(header, frameNonce(w.aead, w.counter), w.buffer, header)
+	w.counter++
+	w.buffer = w.buffer[:0]
+
+	_, err := w.writer.Write(frame)
+	return err
+}
+
+type decryptingReader struct {
+	aead    cipher.AEAD
+	reader  io.Reader
+	counter uint64
+
+	frame []byte
+	done  bool
+}
+
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.frame) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		err := r.readFrame()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.frame)
+	r.frame = r.frame[n:]
+
+	return n, nil
+}
+
+func (r *decryptingReader) readFrame() error {
+	header := make([]byte, 4)
+
+	_, err := io.ReadFull(r.reader, header)
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return ErrInvalidEncryptedStream
+		}
+
+		return err
+	}
+
+	length := binary.BigEndian.Uint32(header)
+	final := length&finalFrame != 0
+	length &^= finalFrame
+
+	if length > encryptedFrameSize {
+		return ErrInvalidEncryptedStream
+	}
+
+	ciphertext := make([]byte, int(length)+r.aead.Overhead())
+
+	_, err = io.ReadFull(r.reader, ciphertext)
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return ErrInvalidEncryptedStream
+		}
+
+		return err
+	}
+
+	plaintext, err := r.aead.Open(ciphertext[:0], frameNonce(r.aead, r.counter), ciphertext, header)
+	if err != nil {
+		return ErrInvalidEncryptedStream
+	}
+
+	r.counter++
+	r.frame = plaintext
+	r.done = final
+
+	return nil
+}
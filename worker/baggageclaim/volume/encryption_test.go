@@ -0,0 +1,111 @@
+package volume_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encryption", func() {
+	var key []byte
+
+	BeforeEach(func() {
+		var err error
+		key, err = volume.NewP2pKey()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(key).To(HaveLen(volume.P2pKeySize))
+	})
+
+	encrypt := func(plaintext []byte) []byte {
+		encrypted := new(bytes.Buffer)
+
+		writer, err := volume.NewEncryptingWriter(key, encrypted)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = writer.Write(plaintext)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+
+		return encrypted.Bytes()
+	}
+
+	decrypt := func(key []byte, ciphertext []byte) ([]byte, error) {
+		reader, err := volume.NewDecryptingReader(key, bytes.NewReader(ciphertext))
+		Expect(err).ToNot(HaveOccurred())
+
+		return ioutil.ReadAll(reader)
+	}
+
+	DescribeTable("decrypts what was encrypted",
+		func(size int) {
+			plaintext := make([]byte, size)
+			rand.Read(plaintext)
+
+			ciphertext := encrypt(plaintext)
+			if size > 0 {
+				Expect(ciphertext).ToNot(ContainSubstring(string(plaintext)))
+			}
+
+			decrypted, err := decrypt(key, ciphertext)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decrypted).To(Equal(plaintext))
+		},
+		Entry("nothing", 0),
+		Entry("less than a frame", 100),
+		Entry("a frame", 64*1024),
+		Entry("more than a frame", 64*1024+1),
+		Entry("many frames", 3*64*1024+100),
+	)
+
+	It("fails to decrypt with another key", func() {
+		otherKey, err := volume.NewP2pKey()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = decrypt(otherKey, encrypt([]byte("some-content")))
+		Expect(err).To(Equal(volume.ErrInvalidEncryptedStream))
+	})
+
+	It("fails to decrypt a stream which was tampered with", func() {
+		ciphertext := encrypt([]byte("some-content"))
+		ciphertext[len(ciphertext)-1] ^= 0xff
+
+		_, err := decrypt(key, ciphertext)
+		Expect(err).To(Equal(volume.ErrInvalidEncryptedStream))
+	})
+
+	It("fails to decrypt a stream which was cut short", func() {
+		ciphertext := encrypt(make([]byte, 2*64*1024))
+
+		// drop the last frame, which only holds what's left after the first
+		_, err := decrypt(key, ciphertext[:4+64*1024+16])
+		Expect(err).To(Equal(volume.ErrInvalidEncryptedStream))
+	})
+
+	Describe("p2p keys in stream-in URLs", func() {
+		It("splits the key from the URL", func() {
+			url := volume.WithP2pKey("http://some-url/volumes/some-handle/stream-in?key=some-id", key)
+
+			streamInURL, splitKey, err := volume.SplitP2pKey(url)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(streamInURL).To(Equal("http://some-url/volumes/some-handle/stream-in?key=some-id"))
+			Expect(splitKey).To(Equal(key))
+		})
+
+		It("returns URLs without a key as they are", func() {
+			streamInURL, splitKey, err := volume.SplitP2pKey("http://some-url/volumes/some-handle/stream-in")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(streamInURL).To(Equal("http://some-url/volumes/some-handle/stream-in"))
+			Expect(splitKey).To(BeNil())
+		})
+
+		It("fails to split keys of the wrong size", func() {
+			_, _, err := volume.SplitP2pKey(volume.WithP2pKey("http://some-url", []byte("short")))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package volume
+
+import "code.cloudfoundry.org/lager"
+
+// HydrateStrategy creates a volume from the archive persisted under Key by
+// the driver, which is nil if the driver doesn't persist volumes.
+type HydrateStrategy struct {
+	Key    string
+	Driver PersistentDriver
+}
+
+func (strategy HydrateStrategy) Materialize(logger lager.Logger, handle string, fs Filesystem, streamer Streamer) (FilesystemInitVolume, error) {
+	if strategy.Driver == nil {
+		return nil, ErrPersistenceUnsupported
+	}
+
+	archive, err := strategy.Driver.PersistedVolume(strategy.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	defer archive.Close()
+
+	initVolume, err := fs.NewVolume(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	// the archive was persisted with the user and group IDs of the volume's
+	// contents as they are seen from its containers, just like imported
+	// archives, so it's namespaced by the repository once it's streamed in
+	invalid, err := streamer.In(archive, initVolume.DataPath(), true)
+	if err != nil {
+		if invalid {
+			logger.Info("malformed-archive", lager.Data{
+				"error": err.Error(),
+			})
+		} else {
+			logger.Error("failed-to-stream-in", err)
+		}
+
+		initVolume.Destroy()
+
+		return nil, err
+	}
+
+	return initVolume, nil
+}
@@ -0,0 +1,503 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+const (
+	MediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	MediaTypeOCILayer          = "application/vnd.oci.image.layer.v1.tar"
+	MediaTypeOCILayerGzip      = "application/vnd.oci.image.layer.v1.tar+gzip"
+	MediaTypeDockerLayerGzip   = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+	MediaTypeDockerForeignGzip = "application/vnd.docker.image.rootfs.foreign.diff.tar.gzip"
+)
+
+var manifestMediaTypes = strings.Join([]string{
+	MediaTypeOCIManifest,
+	MediaTypeOCIIndex,
+	MediaTypeDockerManifest,
+	MediaTypeDockerList,
+}, ", ")
+
+const dockerHubRegistry = "registry-1.docker.io"
+
+// manifests and configs are read into memory, so they're limited to sizes
+// far beyond what any real image has.
+const (
+	maxManifestSize = 4 * 1024 * 1024
+	maxConfigSize   = 16 * 1024 * 1024
+)
+
+var ErrDigestMismatch = errors.New("content does not match its digest")
+
+// ErrNotFound is returned when the registry has no such manifest or blob.
+var ErrNotFound = errors.New("not found")
+
+var digestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+var tagRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
+// ValidateDigest returns an error unless the digest is a sha256 digest, which
+// is the only algorithm images are pulled by.
+func ValidateDigest(digest string) error {
+	if !digestRegex.MatchString(digest) {
+		return fmt.Errorf("invalid digest %q: must be sha256:<64 hex characters>", digest)
+	}
+
+	return nil
+}
+
+// Descriptor points to content in a registry by its digest.
+type Descriptor struct {
+	MediaType string    `json:"mediaType"`
+	Digest    string    `json:"digest"`
+	Size      int64     `json:"size"`
+	Platform  *Platform `json:"platform,omitempty"`
+
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// Manifest is an image manifest, or an index of the manifests of an image
+// built for different platforms.
+type Manifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    Descriptor   `json:"config"`
+	Layers    []Descriptor `json:"layers"`
+	Manifests []Descriptor `json:"manifests"`
+}
+
+// Reference locates a repository in a registry.
+type Reference struct {
+	Registry   string
+	Repository string
+}
+
+// ParseRepository parses a repository the way the docker CLI does, so that
+// e.g. "busybox" is looked up on Docker Hub as "library/busybox", and
+// "registry.example.com:5000/some/image" on registry.example.com:5000.
+func ParseRepository(repository string) (Reference, error) {
+	if repository == "" {
+		return Reference{}, errors.New("no repository given")
+	}
+
+	if strings.Contains(repository, "@") {
+		return Reference{}, fmt.Errorf("repository %q must not contain a tag or digest", repository)
+	}
+
+	parts := strings.SplitN(repository, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return Reference{
+			Registry:   parts[0],
+			Repository: parts[1],
+		}, nil
+	}
+
+	if len(parts) == 1 {
+		repository = "library/" + repository
+	}
+
+	return Reference{
+		Registry:   dockerHubRegistry,
+		Repository: repository,
+	}, nil
+}
+
+// Client pulls content from a repository, authenticating with the registry
+// as it asks.
+type Client struct {
+	httpClient *http.Client
+	reference  Reference
+	scheme     string
+
+	username string
+	password string
+
+	// authorization is sent with each request once the registry has asked
+	// for it.
+	authorization string
+}
+
+// NewClient constructs a client for the repository. insecure registries are
+// talked to over plain HTTP.
+func NewClient(httpClient *http.Client, reference Reference, username string, password string, insecure bool) *Client {
+	scheme := "https"
+	if insecure {
+		scheme = "http"
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		reference:  reference,
+		scheme:     scheme,
+		username:   username,
+		password:   password,
+	}
+}
+
+// Manifest fetches the image manifest with the given digest. If the digest is
+// of an index, the manifest for the worker's platform is fetched from it.
+func (client *Client) Manifest(ctx context.Context, digest string) (Manifest, error) {
+	manifest, err := client.manifest(ctx, digest)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	if len(manifest.Manifests) == 0 {
+		return manifest, nil
+	}
+
+	for _, descriptor := range manifest.Manifests {
+		if descriptor.Platform != nil &&
+			descriptor.Platform.OS == "linux" &&
+			descriptor.Platform.Architecture == runtime.GOARCH {
+			return client.manifest(ctx, descriptor.Digest)
+		}
+	}
+
+	return Manifest{}, fmt.Errorf("image %s has no manifest for linux/%s", digest, runtime.GOARCH)
+}
+
+// Blob fetches the blob with the given digest, which is verified as it's
+// read: reading the last of it fails with ErrDigestMismatch if it doesn't
+// match.
+func (client *Client) Blob(ctx context.Context, digest string) (io.ReadCloser, error) {
+	err := ValidateDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.get(ctx, "blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &verifyingReader{
+		ReadCloser: response.Body,
+		hash:       sha256.New(),
+		digest:     digest,
+	}, nil
+}
+
+// ReadBlob reads the blob with the given digest in full, for blobs small
+// enough to keep in memory, like image configs.
+func (client *Client) ReadBlob(ctx context.Context, digest string) ([]byte, error) {
+	blob, err := client.Blob(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer blob.Close()
+
+	payload, err := readLimited(blob, maxConfigSize)
+	if err != nil {
+		return nil, fmt.Errorf("read blob %s: %w", digest, err)
+	}
+
+	return payload, nil
+}
+
+// TaggedManifest fetches the manifest the tag points to. Unlike manifests
+// fetched by digest, it can't be verified, so anything it refers to must be.
+func (client *Client) TaggedManifest(ctx context.Context, tag string) (Manifest, error) {
+	if !tagRegex.MatchString(tag) {
+		return Manifest{}, fmt.Errorf("invalid tag %q", tag)
+	}
+
+	response, err := client.get(ctx, "manifests/"+tag, manifestMediaTypes)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	defer response.Body.Close()
+
+	payload, err := readLimited(response.Body, maxManifestSize)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("read manifest %s: %w", tag, err)
+	}
+
+	var manifest Manifest
+	err = json.Unmarshal(payload, &manifest)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("malformed manifest %s: %w", tag, err)
+	}
+
+	return manifest, nil
+}
+
+func (client *Client) manifest(ctx context.Context, digest string) (Manifest, error) {
+	err := ValidateDigest(digest)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	response, err := client.get(ctx, "manifests/"+digest, manifestMediaTypes)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	defer response.Body.Close()
+
+	payload, err := readLimited(&verifyingReader{
+		ReadCloser: response.Body,
+		hash:       sha256.New(),
+		digest:     digest,
+	}, maxManifestSize)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("read manifest %s: %w", digest, err)
+	}
+
+	var manifest Manifest
+	err = json.Unmarshal(payload, &manifest)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("malformed manifest %s: %w", digest, err)
+	}
+
+	return manifest, nil
+}
+
+func (client *Client) get(ctx context.Context, path string, accept string) (*http.Response, error) {
+	endpoint := fmt.Sprintf("%s://%s/v2/%s/%s", client.scheme, client.reference.Registry, client.reference.Repository, path)
+
+	response, err := client.do(ctx, endpoint, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized && client.authorization == "" {
+		challenge := response.Header.Get("WWW-Authenticate")
+		response.Body.Close()
+
+		err = client.authenticate(ctx, challenge)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err = client.do(ctx, endpoint, accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, fmt.Errorf("get %s: %w", endpoint, ErrNotFound)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(response.Body, 1024))
+		response.Body.Close()
+		return nil, fmt.Errorf("get %s: %s: %s", endpoint, response.Status, strings.TrimSpace(string(body)))
+	}
+
+	return response, nil
+}
+
+func (client *Client) do(ctx context.Context, endpoint string, accept string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+
+	if client.authorization != "" {
+		request.Header.Set("Authorization", client.authorization)
+	}
+
+	return client.httpClient.Do(request)
+}
+
+// authenticate answers the registry's challenge, either by sending the
+// credentials directly, or by exchanging them (if any) for a token.
+func (client *Client) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if client.username == "" {
+			return fmt.Errorf("registry %s requires credentials", client.reference.Registry)
+		}
+
+		request, _ := http.NewRequest(http.MethodGet, "/", nil)
+		request.SetBasicAuth(client.username, client.password)
+		client.authorization = request.Header.Get("Authorization")
+
+		return nil
+
+	case "bearer":
+		token, err := client.token(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		client.authorization = "Bearer " + token
+
+		return nil
+
+	default:
+		return fmt.Errorf("registry %s asked for unsupported authentication %q", client.reference.Registry, challenge)
+	}
+}
+
+func (client *Client) token(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("registry %s sent a malformed token realm %q", client.reference.Registry, params["realm"])
+	}
+
+	query := realm.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + client.reference.Repository + ":pull"
+	}
+	query.Set("scope", scope)
+
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	if client.username != "" {
+		request.SetBasicAuth(client.username, client.password)
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get token from %s: %s", realm.Host, response.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("malformed token from %s: %w", realm.Host, err)
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+
+	return body.AccessToken, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header such as
+//
+//	Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], params
+	}
+
+	rest := parts[1]
+	for rest != "" {
+		rest = strings.TrimLeft(rest, " ,")
+
+		eq := strings.Index(rest, "=")
+		if eq == -1 {
+			break
+		}
+
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end == -1 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.Index(rest, ",")
+			if end == -1 {
+				value, rest = rest, ""
+			} else {
+				value, rest = rest[:end], rest[end:]
+			}
+		}
+
+		params[key] = value
+	}
+
+	return parts[0], params
+}
+
+// verifyingReader hashes what's read from it, and fails at the end of it if
+// the hash doesn't match the digest.
+type verifyingReader struct {
+	io.ReadCloser
+	hash   hash.Hash
+	digest string
+}
+
+func (reader *verifyingReader) Read(p []byte) (int, error) {
+	n, err := reader.ReadCloser.Read(p)
+	reader.hash.Write(p[:n])
+
+	if err == io.EOF {
+		actual := "sha256:" + hex.EncodeToString(reader.hash.Sum(nil))
+		if actual != reader.digest {
+			return n, fmt.Errorf("%w: expected %s, got %s", ErrDigestMismatch, reader.digest, actual)
+		}
+	}
+
+	return n, err
+}
+
+// readLimited reads all of the reader, unless there's more than limit bytes
+// of it.
+func readLimited(reader io.Reader, limit int64) ([]byte, error) {
+	payload, err := ioutil.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(payload)) > limit {
+		return nil, fmt.Errorf("larger than %d bytes", limit)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,138 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry/registrytest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseRepository", func() {
+	It("looks up official images on Docker Hub", func() {
+		Expect(registry.ParseRepository("busybox")).To(Equal(registry.Reference{
+			Registry:   "registry-1.docker.io",
+			Repository: "library/busybox",
+		}))
+	})
+
+	It("looks up namespaced images on Docker Hub", func() {
+		Expect(registry.ParseRepository("concourse/registry-image-resource")).To(Equal(registry.Reference{
+			Registry:   "registry-1.docker.io",
+			Repository: "concourse/registry-image-resource",
+		}))
+	})
+
+	It("looks up images on the registry they're prefixed with", func() {
+		Expect(registry.ParseRepository("registry.example.com:5000/some/image")).To(Equal(registry.Reference{
+			Registry:   "registry.example.com:5000",
+			Repository: "some/image",
+		}))
+	})
+
+	It("does not allow tags or digests", func() {
+		_, err := registry.ParseRepository("busybox@sha256:abc")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Client", func() {
+	var (
+		reg    *registrytest.Registry
+		client *registry.Client
+
+		config   []byte
+		layer    []byte
+		manifest string
+	)
+
+	BeforeEach(func() {
+		reg = registrytest.NewRegistry()
+
+		config = []byte(`{"config":{"Env":["FOO=bar"]}}`)
+		layer = registrytest.Layer(map[string]string{"some-file": "some-content"})
+		manifest = reg.AddImage(config, layer)
+	})
+
+	JustBeforeEach(func() {
+		client = registry.NewClient(http.DefaultClient, registry.Reference{
+			Registry:   reg.Host(),
+			Repository: "some/image",
+		}, "some-user", "some-password", true)
+	})
+
+	AfterEach(func() {
+		reg.Close()
+	})
+
+	It("fetches manifests and blobs by digest", func() {
+		fetched, err := client.Manifest(context.Background(), manifest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetched.Layers).To(HaveLen(1))
+
+		fetchedConfig, err := client.ReadBlob(context.Background(), fetched.Config.Digest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetchedConfig).To(Equal(config))
+
+		blob, err := client.Blob(context.Background(), fetched.Layers[0].Digest)
+		Expect(err).NotTo(HaveOccurred())
+		defer blob.Close()
+
+		Expect(ioutil.ReadAll(blob)).To(Equal(layer))
+	})
+
+	It("fails if the content does not match its digest", func() {
+		digest := reg.AddBlob([]byte("some-content"), "application/octet-stream")
+		reg.Replace(digest, []byte("other-content"))
+
+		_, err := client.ReadBlob(context.Background(), digest)
+		Expect(err).To(MatchError(ContainSubstring(registry.ErrDigestMismatch.Error())))
+	})
+
+	It("fetches manifests by tag", func() {
+		reg.Tag("some-tag", manifest)
+
+		fetched, err := client.TaggedManifest(context.Background(), "some-tag")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetched.Layers).To(HaveLen(1))
+	})
+
+	It("fails with ErrNotFound if there is no such manifest", func() {
+		_, err := client.TaggedManifest(context.Background(), "missing-tag")
+		Expect(errors.Is(err, registry.ErrNotFound)).To(BeTrue())
+	})
+
+	It("fails on digests which aren't sha256", func() {
+		_, err := client.Manifest(context.Background(), "md5:abc")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("when the registry requires a bearer token", func() {
+		BeforeEach(func() {
+			reg.Token = "some-token"
+			reg.Username = "some-user"
+			reg.Password = "some-password"
+		})
+
+		It("gets one with the credentials", func() {
+			_, err := client.Manifest(context.Background(), manifest)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the credentials are wrong", func() {
+			BeforeEach(func() {
+				reg.Password = "other-password"
+			})
+
+			It("fails", func() {
+				_, err := client.Manifest(context.Background(), manifest)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
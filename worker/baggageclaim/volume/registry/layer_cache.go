@@ -0,0 +1,170 @@
+package registry
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// LayerCache keeps the layers of images pulled from registries on disk,
+// keyed by their digests, so that images sharing layers, or pulled onto the
+// same worker again, only have to download them once.
+type LayerCache struct {
+	dir string
+
+	// limit is the number of bytes the cached layers may take up before the
+	// least recently used ones are removed. 0 means no limit.
+	limit uint64
+
+	trimLock sync.Mutex
+}
+
+func NewLayerCache(dir string, limit uint64) (*LayerCache, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LayerCache{
+		dir:   dir,
+		limit: limit,
+	}, nil
+}
+
+// Open opens the cached layer with the given digest, first caching it with
+// fetch if it isn't already. fetch is expected to verify what it returns
+// against the digest, as the Client does.
+func (cache *LayerCache) Open(digest string, fetch func() (io.ReadCloser, error)) (*os.File, error) {
+	err := ValidateDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	path := cache.path(digest)
+
+	layer, err := os.Open(path)
+	if err == nil {
+		// the modification time is used as the last time the layer was used
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+
+		return layer, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	err = cache.fetch(path, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+// Trim removes the least recently used layers until the rest fit within the
+// cache's limit. Layers that are open, e.g. while they're being unpacked,
+// stay readable until they're closed.
+func (cache *LayerCache) Trim(logger lager.Logger) error {
+	if cache.limit == 0 {
+		return nil
+	}
+
+	cache.trimLock.Lock()
+	defer cache.trimLock.Unlock()
+
+	infos, err := ioutil.ReadDir(cache.dir)
+	if err != nil {
+		return err
+	}
+
+	var layers []os.FileInfo
+	var size uint64
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+
+		layers = append(layers, info)
+		size += uint64(info.Size())
+	}
+
+	sort.Slice(layers, func(i, j int) bool {
+		return layers[i].ModTime().Before(layers[j].ModTime())
+	})
+
+	for _, layer := range layers {
+		if size <= cache.limit {
+			break
+		}
+
+		err := os.Remove(filepath.Join(cache.dir, layer.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		logger.Debug("removed-layer", lager.Data{
+			"layer": layer.Name(),
+			"size":  layer.Size(),
+		})
+
+		size -= uint64(layer.Size())
+	}
+
+	return nil
+}
+
+// fetch downloads the layer to a temporary file, which is only moved into
+// place once all of it has been verified, so that a failed or concurrent
+// download never leaves a partial layer behind.
+func (cache *LayerCache) fetch(path string, fetch func() (io.ReadCloser, error)) error {
+	blob, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	defer blob.Close()
+
+	tmp, err := ioutil.TempFile(cache.dir, ".download-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, blob)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		// a concurrent download of the same layer may have won the race,
+		// which only fails the rename on some platforms
+		if _, statErr := os.Stat(path); statErr == nil {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (cache *LayerCache) path(digest string) string {
+	return filepath.Join(cache.dir, strings.Replace(digest, ":", "-", 1))
+}
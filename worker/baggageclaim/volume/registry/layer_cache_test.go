@@ -0,0 +1,102 @@
+package registry_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LayerCache", func() {
+	const (
+		digestA = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		digestB = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	)
+
+	var (
+		dir   string
+		cache *registry.LayerCache
+
+		fetches int
+	)
+
+	fetchContent := func(content string) func() (io.ReadCloser, error) {
+		return func() (io.ReadCloser, error) {
+			fetches++
+			return ioutil.NopCloser(bytes.NewBufferString(content)), nil
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "layer-cache")
+		Expect(err).NotTo(HaveOccurred())
+
+		fetches = 0
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		cache, err = registry.NewLayerCache(dir, 10)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("only fetches each layer once", func() {
+		for i := 0; i < 2; i++ {
+			layer, err := cache.Open(digestA, fetchContent("layer-a"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ioutil.ReadAll(layer)).To(Equal([]byte("layer-a")))
+			Expect(layer.Close()).To(Succeed())
+		}
+
+		Expect(fetches).To(Equal(1))
+	})
+
+	It("does not cache layers which fail to download", func() {
+		_, err := cache.Open(digestA, func() (io.ReadCloser, error) {
+			return nil, errors.New("nope")
+		})
+		Expect(err).To(HaveOccurred())
+
+		Expect(ioutil.ReadDir(dir)).To(BeEmpty())
+	})
+
+	It("refuses invalid digests", func() {
+		_, err := cache.Open("sha256:../../etc/passwd", fetchContent("nope"))
+		Expect(err).To(HaveOccurred())
+		Expect(fetches).To(BeZero())
+	})
+
+	Describe("Trim", func() {
+		It("removes the least recently used layers until the rest fit within the limit", func() {
+			layer, err := cache.Open(digestA, fetchContent("layer-a"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(layer.Close()).To(Succeed())
+
+			past := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(filepath.Join(dir, "sha256-"+digestA[7:]), past, past)).To(Succeed())
+
+			layer, err = cache.Open(digestB, fetchContent("layer-b"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(layer.Close()).To(Succeed())
+
+			Expect(cache.Trim(lagertest.NewTestLogger("test"))).To(Succeed())
+
+			Expect(filepath.Join(dir, "sha256-"+digestA[7:])).NotTo(BeAnExistingFile())
+			Expect(filepath.Join(dir, "sha256-"+digestB[7:])).To(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,209 @@
+package registrytest
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
+)
+
+// Registry is a registry which serves the content added to it under any
+// repository. If Token is set, it requires bearer tokens, which it hands out
+// in exchange for Username and Password.
+type Registry struct {
+	*httptest.Server
+
+	Token    string
+	Username string
+	Password string
+
+	// Delay is how long the registry waits before responding to a request.
+	Delay time.Duration
+
+	lock      sync.Mutex
+	content   map[string][]byte
+	mediaType map[string]string
+	requests  map[string]int
+	tags      map[string]string
+}
+
+func NewRegistry() *Registry {
+	reg := &Registry{
+		content:   map[string][]byte{},
+		mediaType: map[string]string{},
+		requests:  map[string]int{},
+		tags:      map[string]string{},
+	}
+
+	reg.Server = httptest.NewServer(http.HandlerFunc(reg.serve))
+
+	return reg
+}
+
+// Host is the address the registry is reachable at, which repositories are
+// prefixed with.
+func (reg *Registry) Host() string {
+	serverURL, _ := url.Parse(reg.URL)
+	return serverURL.Host
+}
+
+// AddBlob adds content to the registry, returning its digest.
+func (reg *Registry) AddBlob(content []byte, mediaType string) string {
+	hash := sha256.Sum256(content)
+	digest := "sha256:" + hex.EncodeToString(hash[:])
+
+	reg.lock.Lock()
+	reg.content[digest] = content
+	reg.mediaType[digest] = mediaType
+	reg.lock.Unlock()
+
+	return digest
+}
+
+// AddImage adds an image made up of the config and the gzipped layers,
+// returning the digest of its manifest.
+func (reg *Registry) AddImage(config []byte, layers ...[]byte) string {
+	manifest := registry.Manifest{
+		MediaType: registry.MediaTypeOCIManifest,
+		Config: registry.Descriptor{
+			MediaType: "application/vnd.oci.image.config.v1+json",
+			Digest:    reg.AddBlob(config, "application/octet-stream"),
+			Size:      int64(len(config)),
+		},
+	}
+
+	for _, layer := range layers {
+		manifest.Layers = append(manifest.Layers, registry.Descriptor{
+			MediaType: registry.MediaTypeOCILayerGzip,
+			Digest:    reg.AddBlob(layer, "application/octet-stream"),
+			Size:      int64(len(layer)),
+		})
+	}
+
+	payload, _ := json.Marshal(struct {
+		SchemaVersion int `json:"schemaVersion"`
+		registry.Manifest
+	}{2, manifest})
+
+	return reg.AddBlob(payload, registry.MediaTypeOCIManifest)
+}
+
+// Tag points the tag at the content with the digest.
+func (reg *Registry) Tag(tag string, digest string) {
+	reg.lock.Lock()
+	reg.tags[tag] = digest
+	reg.lock.Unlock()
+}
+
+// Replace serves other content under the digest, which clients should refuse.
+func (reg *Registry) Replace(digest string, content []byte) {
+	reg.lock.Lock()
+	reg.content[digest] = content
+	reg.lock.Unlock()
+}
+
+// Requests returns how many times the content with the digest was fetched.
+func (reg *Registry) Requests(digest string) int {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+
+	return reg.requests[digest]
+}
+
+func (reg *Registry) serve(w http.ResponseWriter, r *http.Request) {
+	if reg.Delay > 0 {
+		select {
+		case <-time.After(reg.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if r.URL.Path == "/token" {
+		username, password, _ := r.BasicAuth()
+		if username != reg.Username || password != reg.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"token": reg.Token})
+		return
+	}
+
+	if reg.Token != "" && r.Header.Get("Authorization") != "Bearer "+reg.Token {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+reg.URL+`/token",service="registrytest"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// /v2/<repository>/(manifests|blobs)/<digest or tag>
+	parts := strings.Split(r.URL.Path, "/")
+	digest := parts[len(parts)-1]
+
+	reg.lock.Lock()
+	if tagged, ok := reg.tags[digest]; ok {
+		digest = tagged
+	}
+	content, found := reg.content[digest]
+	mediaType := reg.mediaType[digest]
+	reg.requests[digest]++
+	reg.lock.Unlock()
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Write(content)
+}
+
+// Layer builds a gzipped layer out of the files, which are keyed by path. A
+// path ending in / is a directory.
+func Layer(files map[string]string) []byte {
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	buf := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, "/") {
+			tarWriter.WriteHeader(&tar.Header{
+				Name:     path,
+				Typeflag: tar.TypeDir,
+				Mode:     0755,
+			})
+			continue
+		}
+
+		tarWriter.WriteHeader(&tar.Header{
+			Name:     path,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(files[path])),
+		})
+		tarWriter.Write([]byte(files[path]))
+	}
+
+	tarWriter.Close()
+	gzipWriter.Close()
+
+	return buf.Bytes()
+}
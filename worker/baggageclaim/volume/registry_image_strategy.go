@@ -0,0 +1,313 @@
+package volume
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
+)
+
+var ErrRegistryImagesUnsupported = errors.New("server does not pull images from registries")
+
+const (
+	whiteoutPrefix = ".wh."
+	opaqueWhiteout = ".wh..wh..opq"
+)
+
+// RegistryImageStrategy creates a volume from an image pulled from a registry
+// by its digest. The volume is laid out like the images fetched by image
+// resources: the image's filesystem is unpacked into rootfs/, and its config
+// is written to metadata.json.
+type RegistryImageStrategy struct {
+	Repository string
+	Digest     string
+	Username   string
+	Password   string
+	Insecure   bool
+
+	// Timeout bounds how long pulling the image may take. There's no limit
+	// if it's zero.
+	Timeout time.Duration
+
+	// Layers caches the image's layers. The server doesn't pull images if
+	// it's nil.
+	Layers *registry.LayerCache
+}
+
+// registryHTTPClient pulls images from registries. Registries which accept
+// connections but never respond are given up on, even when the pull has no
+// timeout.
+var registryHTTPClient = &http.Client{Transport: registryTransport()}
+
+func registryTransport() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = time.Minute
+	return transport
+}
+
+func (strategy RegistryImageStrategy) Materialize(logger lager.Logger, handle string, fs Filesystem, streamer Streamer) (FilesystemInitVolume, error) {
+	if strategy.Layers == nil {
+		return nil, ErrRegistryImagesUnsupported
+	}
+
+	logger = logger.Session("pull-image", lager.Data{
+		"repository": strategy.Repository,
+		"digest":     strategy.Digest,
+	})
+
+	reference, err := registry.ParseRepository(strategy.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	// the request that created the volume may be gone by the time the image
+	// is pulled, if it was created asynchronously, so the pull is bounded by
+	// its own timeout instead
+	ctx := context.Background()
+	if strategy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, strategy.Timeout)
+		defer cancel()
+	}
+
+	client := registry.NewClient(registryHTTPClient, reference, strategy.Username, strategy.Password, strategy.Insecure)
+
+	manifest, err := client.Manifest(ctx, strategy.Digest)
+	if err != nil {
+		logger.Error("failed-to-get-manifest", err)
+		return nil, err
+	}
+
+	config, err := client.ReadBlob(ctx, manifest.Config.Digest)
+	if err != nil {
+		logger.Error("failed-to-get-config", err)
+		return nil, err
+	}
+
+	initVolume, err := fs.NewVolume(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	err = strategy.unpack(ctx, logger, client, manifest, config, initVolume.DataPath(), streamer)
+	if err != nil {
+		initVolume.Destroy()
+		return nil, err
+	}
+
+	err = strategy.Layers.Trim(logger)
+	if err != nil {
+		logger.Error("failed-to-trim-layer-cache", err)
+	}
+
+	return initVolume, nil
+}
+
+func (strategy RegistryImageStrategy) unpack(ctx context.Context, logger lager.Logger, client *registry.Client, manifest registry.Manifest, config []byte, dest string, streamer Streamer) error {
+	rootfs := filepath.Join(dest, "rootfs")
+
+	err := os.MkdirAll(rootfs, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range manifest.Layers {
+		digest := layer.Digest
+
+		blob, err := strategy.Layers.Open(digest, func() (io.ReadCloser, error) {
+			logger.Debug("downloading-layer", lager.Data{"layer": digest, "size": layer.Size})
+			return client.Blob(ctx, digest)
+		})
+		if err != nil {
+			logger.Error("failed-to-get-layer", err, lager.Data{"layer": digest})
+			return err
+		}
+
+		err = unpackLayer(logger, blob, layer.MediaType, rootfs, streamer)
+		blob.Close()
+		if err != nil {
+			logger.Error("failed-to-unpack-layer", err, lager.Data{"layer": digest})
+			return fmt.Errorf("unpack layer %s: %w", digest, err)
+		}
+	}
+
+	return ioutil.WriteFile(filepath.Join(dest, "metadata.json"), config, 0644)
+}
+
+// unpackLayer applies the layer to the rootfs: files deleted by the layer,
+// which it marks with whiteouts, are removed from the layers beneath it, and
+// the rest of the layer is unpacked on top of them.
+func unpackLayer(logger lager.Logger, layer *os.File, mediaType string, rootfs string, streamer Streamer) error {
+	var gzipped bool
+	switch mediaType {
+	case registry.MediaTypeOCILayerGzip, registry.MediaTypeDockerLayerGzip, registry.MediaTypeDockerForeignGzip:
+		gzipped = true
+	case registry.MediaTypeOCILayer:
+		gzipped = false
+	default:
+		return fmt.Errorf("unsupported layer media type %q", mediaType)
+	}
+
+	whiteouts, err := layerWhiteouts(layer, gzipped)
+	if err != nil {
+		return err
+	}
+
+	for _, whiteout := range whiteouts {
+		err := applyWhiteout(rootfs, whiteout)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = layer.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	stream := io.Reader(layer)
+	if !gzipped {
+		compressed := gzipStream(layer)
+		defer compressed.Close()
+
+		stream = compressed
+	}
+
+	// the layer is streamed in as though it were privileged, as with imported
+	// archives; the repository namespaces the volume once it's materialized
+	invalid, err := streamer.In(stream, rootfs, true)
+	if err != nil {
+		if invalid {
+			logger.Info("malformed-layer", lager.Data{
+				"error": err.Error(),
+			})
+		}
+
+		return err
+	}
+
+	// the whiteouts themselves were unpacked along with the rest of the layer
+	for _, whiteout := range whiteouts {
+		_ = os.Remove(filepath.Join(rootfs, whiteout))
+	}
+
+	return nil
+}
+
+// layerWhiteouts lists the paths of the whiteouts in the layer.
+func layerWhiteouts(layer io.Reader, gzipped bool) ([]string, error) {
+	if gzipped {
+		gzipReader, err := gzip.NewReader(layer)
+		if err != nil {
+			return nil, err
+		}
+
+		defer gzipReader.Close()
+
+		layer = gzipReader
+	}
+
+	var whiteouts []string
+
+	tarReader := tar.NewReader(layer)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return whiteouts, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean("/" + header.Name)
+		if strings.HasPrefix(path.Base(name), whiteoutPrefix) {
+			whiteouts = append(whiteouts, name)
+		}
+	}
+}
+
+// applyWhiteout removes what the whiteout hides: everything in its directory
+// if it's opaque, or else the file it's named after.
+func applyWhiteout(rootfs string, whiteout string) error {
+	dir, name := path.Split(whiteout)
+
+	dirPath, ok := resolveWithin(rootfs, dir)
+	if !ok {
+		return nil
+	}
+
+	if name == opaqueWhiteout {
+		entries, err := ioutil.ReadDir(dirPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		for _, entry := range entries {
+			err := os.RemoveAll(filepath.Join(dirPath, entry.Name()))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return os.RemoveAll(filepath.Join(dirPath, strings.TrimPrefix(name, whiteoutPrefix)))
+}
+
+// resolveWithin joins the directory to the rootfs, unless any part of it is
+// a symlink, which could point outside of the rootfs.
+func resolveWithin(rootfs string, dir string) (string, bool) {
+	resolved := rootfs
+
+	for _, part := range strings.Split(strings.Trim(dir, "/"), "/") {
+		if part == "" {
+			continue
+		}
+
+		resolved = filepath.Join(resolved, part)
+
+		info, err := os.Lstat(resolved)
+		if err != nil || info.Mode()&os.ModeSymlink != 0 {
+			return "", false
+		}
+	}
+
+	return resolved, true
+}
+
+// gzipStream compresses an uncompressed layer, as the streamer only unpacks
+// gzipped archives.
+func gzipStream(layer io.Reader) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	go func() {
+		gzipWriter := gzip.NewWriter(writer)
+
+		_, err := io.Copy(gzipWriter, layer)
+		if err == nil {
+			err = gzipWriter.Close()
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return reader
+}
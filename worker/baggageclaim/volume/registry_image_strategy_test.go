@@ -0,0 +1,154 @@
+package volume_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/concourse/worker/baggageclaim/volume"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/driver"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry/registrytest"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/volumefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RegistryImageStrategy", func() {
+	var (
+		tempDir string
+		reg     *registrytest.Registry
+		layers  *registry.LayerCache
+
+		fs           Filesystem
+		fakeStreamer *volumefakes.FakeStreamer
+
+		strategy RegistryImageStrategy
+		config   []byte
+		layerA   []byte
+		layerB   []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "registry-image-strategy")
+		Expect(err).NotTo(HaveOccurred())
+
+		fs, err = NewFilesystem(&driver.NaiveDriver{}, filepath.Join(tempDir, "volumes"))
+		Expect(err).NotTo(HaveOccurred())
+
+		layers, err = registry.NewLayerCache(filepath.Join(tempDir, "layers"), 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeStreamer = new(volumefakes.FakeStreamer)
+		fakeStreamer.InStub = func(stream io.Reader, dest string, privileged bool) (bool, error) {
+			tar := exec.Command("tar", "-xzf", "-", "-C", dest)
+			tar.Stdin = stream
+			return false, tar.Run()
+		}
+
+		reg = registrytest.NewRegistry()
+
+		config = []byte(`{"config":{"Env":["FOO=bar"]}}`)
+
+		layerA = registrytest.Layer(map[string]string{
+			"etc/":               "",
+			"etc/some-file":      "some-content",
+			"etc/deleted-file":   "deleted",
+			"opaque/":            "",
+			"opaque/hidden-file": "hidden",
+		})
+
+		layerB = registrytest.Layer(map[string]string{
+			"etc/":                 "",
+			"etc/.wh.deleted-file": "",
+			"etc/other-file":       "other-content",
+			"opaque/":              "",
+			"opaque/.wh..wh..opq":  "",
+			"opaque/revealed-file": "revealed",
+		})
+
+		strategy = RegistryImageStrategy{
+			Repository: reg.Host() + "/some/image",
+			Digest:     reg.AddImage(config, layerA, layerB),
+			Insecure:   true,
+			Layers:     layers,
+		}
+	})
+
+	AfterEach(func() {
+		reg.Close()
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	It("unpacks the image's layers into the rootfs, applying whiteouts", func() {
+		initVolume, err := strategy.Materialize(lagertest.NewTestLogger("test"), "some-volume", fs, fakeStreamer)
+		Expect(err).NotTo(HaveOccurred())
+
+		rootfs := filepath.Join(initVolume.DataPath(), "rootfs")
+		Expect(ioutil.ReadFile(filepath.Join(rootfs, "etc", "some-file"))).To(Equal([]byte("some-content")))
+		Expect(ioutil.ReadFile(filepath.Join(rootfs, "etc", "other-file"))).To(Equal([]byte("other-content")))
+		Expect(ioutil.ReadFile(filepath.Join(rootfs, "opaque", "revealed-file"))).To(Equal([]byte("revealed")))
+
+		Expect(filepath.Join(rootfs, "etc", "deleted-file")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(rootfs, "etc", ".wh.deleted-file")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(rootfs, "opaque", "hidden-file")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(rootfs, "opaque", ".wh..wh..opq")).NotTo(BeAnExistingFile())
+
+		Expect(ioutil.ReadFile(filepath.Join(initVolume.DataPath(), "metadata.json"))).To(Equal(config))
+	})
+
+	It("only downloads each layer once", func() {
+		_, err := strategy.Materialize(lagertest.NewTestLogger("test"), "some-volume", fs, fakeStreamer)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = strategy.Materialize(lagertest.NewTestLogger("test"), "other-volume", fs, fakeStreamer)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(reg.Requests(reg.AddBlob(layerA, "application/octet-stream"))).To(Equal(1))
+		Expect(reg.Requests(reg.AddBlob(layerB, "application/octet-stream"))).To(Equal(1))
+	})
+
+	Context("when a layer does not match its digest", func() {
+		BeforeEach(func() {
+			reg.Replace(reg.AddBlob(layerB, "application/octet-stream"), layerA)
+		})
+
+		It("fails without leaving a volume behind", func() {
+			_, err := strategy.Materialize(lagertest.NewTestLogger("test"), "some-volume", fs, fakeStreamer)
+			Expect(err).To(MatchError(ContainSubstring(registry.ErrDigestMismatch.Error())))
+
+			Expect(fs.ListVolumes()).To(BeEmpty())
+		})
+	})
+
+	Context("when the registry doesn't respond within the timeout", func() {
+		BeforeEach(func() {
+			reg.Delay = time.Minute
+			strategy.Timeout = 100 * time.Millisecond
+		})
+
+		It("gives up on pulling the image", func() {
+			_, err := strategy.Materialize(lagertest.NewTestLogger("test"), "some-volume", fs, fakeStreamer)
+			Expect(err).To(MatchError(ContainSubstring("context deadline exceeded")))
+
+			Expect(fs.ListVolumes()).To(BeEmpty())
+		})
+	})
+
+	Context("when the server has no layer cache", func() {
+		BeforeEach(func() {
+			strategy.Layers = nil
+		})
+
+		It("does not pull the image", func() {
+			_, err := strategy.Materialize(lagertest.NewTestLogger("test"), "some-volume", fs, fakeStreamer)
+			Expect(err).To(Equal(ErrRegistryImagesUnsupported))
+		})
+	})
+})
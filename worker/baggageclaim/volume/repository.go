@@ -3,6 +3,7 @@ package volume
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -12,16 +13,22 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/worker/baggageclaim/throttle"
 	"github.com/concourse/concourse/worker/baggageclaim/uidgid"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/copy"
 )
 
 var ErrVolumeDoesNotExist = errors.New("volume does not exist")
 var ErrVolumeIsCorrupted = errors.New("volume is corrupted")
 var ErrUnsupportedStreamEncoding = errors.New("unsupported stream encoding")
+var ErrUnsupportedStreamLevel = errors.New("unsupported stream compression level")
 
 const GzipEncoding string = "gzip"
 const ZstdEncoding string = "zstd"
 
+const MinZstdLevel = 1
+const MaxZstdLevel = 22
+
 //go:generate counterfeiter . Repository
 
 type Repository interface {
@@ -36,15 +43,27 @@ type Repository interface {
 	SetPrivileged(ctx context.Context, handle string, privileged bool) error
 
 	StreamIn(ctx context.Context, handle string, path string, encoding string, stream io.Reader) (bool, error)
-	StreamOut(ctx context.Context, handle string, path string, encoding string, dest io.Writer) error
+	StreamOut(ctx context.Context, handle string, path string, encoding string, level int, dest io.Writer) error
+
+	StreamP2pOut(ctx context.Context, handle string, path string, encoding string, level int, streamInURL string) error
 
-	StreamP2pOut(ctx context.Context, handle string, path string, encoding string, streamInURL string) error
+	VolumeManifest(ctx context.Context, handle string) (Manifest, error)
+	StreamDeltaOut(ctx context.Context, handle string, encoding string, level int, base Manifest, dest io.Writer) error
+	StreamDeltaIn(ctx context.Context, handle string, baseHandle string, encoding string, stream io.Reader) (bool, error)
 
 	VolumeParent(ctx context.Context, handle string) (Volume, bool, error)
+
+	PersistVolume(ctx context.Context, handle string, key string) error
+
+	SetQuota(ctx context.Context, handle string, limit uint64) error
+	GetQuota(ctx context.Context, handle string) (Quota, error)
+
+	VolumeSize(ctx context.Context, handle string) (uint64, error)
 }
 
 type repository struct {
 	filesystem Filesystem
+	driver     Driver
 
 	locker LockManager
 
@@ -55,12 +74,14 @@ type repository struct {
 
 func NewRepository(
 	filesystem Filesystem,
+	driver Driver,
 	locker LockManager,
 	privilegedNamespacer uidgid.Namespacer,
 	unprivilegedNamespacer uidgid.Namespacer,
 ) Repository {
 	return &repository{
 		filesystem: filesystem,
+		driver:     driver,
 		locker:     locker,
 
 		gzipStreamer: &tarGzipStreamer{
@@ -412,10 +433,12 @@ func (repo *repository) StreamIn(ctx context.Context, handle string, path string
 	return false, ErrUnsupportedStreamEncoding
 }
 
-func (repo *repository) StreamOut(ctx context.Context, handle string, path string, encoding string, dest io.Writer) error {
+func (repo *repository) StreamOut(ctx context.Context, handle string, path string, encoding string, level int, dest io.Writer) error {
 	logger := lagerctx.FromContext(ctx).Session("stream-in", lager.Data{
 		"volume":   handle,
 		"sub-path": path,
+		"encoding": encoding,
+		"level":    level,
 	})
 
 	volume, found, err := repo.filesystem.LookupVolume(handle)
@@ -441,26 +464,31 @@ func (repo *repository) StreamOut(ctx context.Context, handle string, path strin
 		return err
 	}
 
-	switch encoding {
-	case ZstdEncoding:
-		return repo.zstdStreamer.Out(dest, srcPath, isPrivileged)
-	case GzipEncoding:
-		return repo.gzipStreamer.Out(dest, srcPath, isPrivileged)
+	streamer, err := repo.outStreamer(encoding, level)
+	if err != nil {
+		return err
 	}
 
-	return ErrUnsupportedStreamEncoding
+	return streamer.Out(dest, srcPath, isPrivileged)
 }
 
-func (repo *repository) StreamP2pOut(ctx context.Context, handle string, path string, encoding string, streamInURL string) error {
+func (repo *repository) StreamP2pOut(ctx context.Context, handle string, path string, encoding string, level int, streamInURL string) error {
 	logger := lagerctx.FromContext(ctx).Session("stream-p2p-out", lager.Data{
 		"volume":   handle,
 		"sub-path": path,
 		"encoding": encoding,
+		"level":    level,
 	})
 
 	logger.Debug("start")
 	defer logger.Debug("done")
 
+	streamInURL, key, err := SplitP2pKey(streamInURL)
+	if err != nil {
+		logger.Error("failed-to-parse-stream-in-url", err)
+		return err
+	}
+
 	volume, found, err := repo.filesystem.LookupVolume(handle)
 	if err != nil {
 		logger.Error("failed-to-lookup-volume", err)
@@ -484,45 +512,272 @@ func (repo *repository) StreamP2pOut(ctx context.Context, handle string, path st
 		return err
 	}
 
-	buffer := new(bytes.Buffer)
-	switch encoding {
-	case ZstdEncoding:
-		err = repo.zstdStreamer.Out(buffer, srcPath, isPrivileged)
-	case GzipEncoding:
-		err = repo.gzipStreamer.Out(buffer, srcPath, isPrivileged)
-	default:
-		return ErrUnsupportedStreamEncoding
+	streamer, err := repo.outStreamer(encoding, level)
+	if err != nil {
+		return err
 	}
+
+	buffer := new(bytes.Buffer)
+	err = streamer.Out(buffer, srcPath, isPrivileged)
 	if err != nil {
 		logger.Error("failed-to-compress-volume", err)
 		return err
 	}
 
-	logger.Debug("p2p-streaming-start", lager.Data{"streamInURL": streamInURL})
+	digest := NewDigest()
+	_, _ = digest.Write(buffer.Bytes())
+
+	var body io.Reader = buffer
+	length := buffer.Len()
+	if key != nil {
+		encrypted := new(bytes.Buffer)
+
+		err = encrypt(key, encrypted, buffer)
+		if err != nil {
+			logger.Error("failed-to-encrypt-volume", err)
+			return err
+		}
+
+		body = encrypted
+		length = encrypted.Len()
+	}
+
+	logger.Debug("p2p-streaming-start", lager.Data{"streamInURL": streamInURL, "encrypted": key != nil})
 
 	client := &http.Client{}
-	req, err := http.NewRequest(http.MethodPut, streamInURL, buffer)
+	req, err := http.NewRequest(http.MethodPut, streamInURL, throttle.Reader(ctx, body, throttle.Limiters(ctx)...))
 	if err != nil {
 		logger.Error("failed-to-create-p2p-request", err)
 		return err
 	}
 
+	req.ContentLength = int64(length)
+
 	req.Header.Set("Content-Encoding", encoding)
+	req.Header.Set(DigestHeader, digest.String())
 	resp, err := client.Do(req)
 	if err != nil {
 		logger.Error("failed-to-streaming-to-peer", err)
 		return err
 	}
 
+	defer resp.Body.Close()
+
 	logger.Debug("p2p-streaming-end", lager.Data{"code": resp.StatusCode})
 
 	if resp.StatusCode == http.StatusNoContent {
 		return nil
 	}
 
+	if resp.StatusCode == http.StatusBadRequest {
+		var errResponse struct {
+			Message string `json:"error"`
+		}
+
+		if json.NewDecoder(resp.Body).Decode(&errResponse) == nil && errResponse.Message == ErrDigestMismatch.Error() {
+			logger.Info("peer-digest-mismatch")
+			return ErrDigestMismatch
+		}
+	}
+
 	return fmt.Errorf("p2p streaming error %d", resp.StatusCode)
 }
 
+func (repo *repository) VolumeManifest(ctx context.Context, handle string) (Manifest, error) {
+	logger := lagerctx.FromContext(ctx).Session("volume-manifest", lager.Data{
+		"volume": handle,
+	})
+
+	volume, found, err := repo.filesystem.LookupVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return nil, err
+	}
+
+	if !found {
+		logger.Info("volume-not-found")
+		return nil, ErrVolumeDoesNotExist
+	}
+
+	manifest, err := BuildManifest(volume.DataPath())
+	if err != nil {
+		logger.Error("failed-to-build-manifest", err)
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// StreamDeltaOut streams what differs between the volume and the one
+// described by `base`: the paths to remove from it, followed by a tar of the
+// paths to add to it, compressed with `encoding`.
+func (repo *repository) StreamDeltaOut(ctx context.Context, handle string, encoding string, level int, base Manifest, dest io.Writer) error {
+	logger := lagerctx.FromContext(ctx).Session("stream-delta-out", lager.Data{
+		"volume":   handle,
+		"encoding": encoding,
+		"level":    level,
+	})
+
+	volume, found, err := repo.filesystem.LookupVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return err
+	}
+
+	if !found {
+		logger.Info("volume-not-found")
+		return ErrVolumeDoesNotExist
+	}
+
+	isPrivileged, err := volume.LoadPrivileged()
+	if err != nil {
+		logger.Error("failed-to-check-if-volume-is-privileged", err)
+		return err
+	}
+
+	manifest, err := BuildManifest(volume.DataPath())
+	if err != nil {
+		logger.Error("failed-to-build-manifest", err)
+		return err
+	}
+
+	changed, removed := manifest.Delta(base)
+
+	logger.Debug("computed-delta", lager.Data{
+		"changed": len(changed),
+		"removed": len(removed),
+		"total":   len(manifest),
+	})
+
+	compressor, err := repo.compressor(encoding, level, dest)
+	if err != nil {
+		return err
+	}
+
+	err = writeRemoved(compressor, removed)
+	if err != nil {
+		_ = compressor.Close()
+		return err
+	}
+
+	// the root is streamed too, so that its permissions carry over
+	err = tarPaths(repo.namespacer(false), isPrivileged, volume.DataPath(), append([]string{"."}, changed...), compressor)
+	if err != nil {
+		_ = compressor.Close()
+		return err
+	}
+
+	return compressor.Close()
+}
+
+// StreamDeltaIn populates the volume with a copy of the base volume, applying
+// the delta streamed out of another volume against its manifest.
+func (repo *repository) StreamDeltaIn(ctx context.Context, handle string, baseHandle string, encoding string, stream io.Reader) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("stream-delta-in", lager.Data{
+		"volume":   handle,
+		"base":     baseHandle,
+		"encoding": encoding,
+	})
+
+	volume, found, err := repo.filesystem.LookupVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Info("volume-not-found")
+		return false, ErrVolumeDoesNotExist
+	}
+
+	baseVolume, found, err := repo.filesystem.LookupVolume(baseHandle)
+	if err != nil {
+		logger.Error("failed-to-lookup-base-volume", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Info("base-volume-not-found")
+		return false, ErrVolumeDoesNotExist
+	}
+
+	privileged, err := volume.LoadPrivileged()
+	if err != nil {
+		logger.Error("failed-to-check-if-volume-is-privileged", err)
+		return false, err
+	}
+
+	basePrivileged, err := baseVolume.LoadPrivileged()
+	if err != nil {
+		logger.Error("failed-to-check-if-base-volume-is-privileged", err)
+		return false, err
+	}
+
+	// files are copied from the base as they are, so their ownership is
+	// only right if both volumes are namespaced the same way
+	if privileged != basePrivileged {
+		logger.Info("base-privileges-mismatch")
+		return false, ErrDeltaBaseMismatch
+	}
+
+	decompressor, err := repo.decompressor(encoding, stream)
+	if err != nil {
+		if err == ErrUnsupportedStreamEncoding {
+			return false, err
+		}
+
+		return true, err
+	}
+
+	defer decompressor.Close()
+
+	removed, err := readRemoved(decompressor)
+	if err != nil {
+		logger.Info("failed-to-read-removed-paths", lager.Data{"error": err.Error()})
+		return true, err
+	}
+
+	err = copy.Cp(false, baseVolume.DataPath(), volume.DataPath())
+	if err != nil {
+		logger.Error("failed-to-copy-base-volume", err)
+		return false, err
+	}
+
+	for _, path := range removed {
+		err = os.RemoveAll(filepath.Join(volume.DataPath(), filepath.FromSlash(path)))
+		if err != nil {
+			logger.Error("failed-to-remove-path", err, lager.Data{"path": path})
+			return false, err
+		}
+	}
+
+	return untar(repo.namespacer(false), privileged, volume.DataPath(), decompressor)
+}
+
+// outStreamer returns the streamer which compresses streams with `encoding`.
+// The compression level only applies to zstd, with 0 meaning its default.
+func (repo *repository) outStreamer(encoding string, level int) (Streamer, error) {
+	switch encoding {
+	case ZstdEncoding:
+		if level == 0 {
+			return repo.zstdStreamer, nil
+		}
+
+		if level < MinZstdLevel || level > MaxZstdLevel {
+			return nil, ErrUnsupportedStreamLevel
+		}
+
+		return &tarZstdStreamer{
+			namespacer: repo.namespacer(false),
+			level:      level,
+		}, nil
+	case GzipEncoding:
+		return repo.gzipStreamer, nil
+	}
+
+	return nil, ErrUnsupportedStreamEncoding
+}
+
 func (repo *repository) VolumeParent(ctx context.Context, handle string) (Volume, bool, error) {
 	logger := lagerctx.FromContext(ctx).Session("volume-parent")
 
@@ -556,6 +811,177 @@ func (repo *repository) VolumeParent(ctx context.Context, handle string) (Volume
 	return volume, true, nil
 }
 
+// PersistVolume stores the archive of a volume's contents under the key with
+// the driver, if it persists volumes, so that it can be hydrated on another
+// worker with a HydrateStrategy.
+func (repo *repository) PersistVolume(ctx context.Context, handle string, key string) error {
+	logger := lagerctx.FromContext(ctx).Session("persist-volume", lager.Data{
+		"volume": handle,
+		"key":    key,
+	})
+
+	persistentDriver, ok := repo.driver.(PersistentDriver)
+	if !ok {
+		return ErrPersistenceUnsupported
+	}
+
+	volume, found, err := repo.filesystem.LookupVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return err
+	}
+
+	if !found {
+		logger.Info("volume-not-found")
+		return ErrVolumeDoesNotExist
+	}
+
+	isPrivileged, err := volume.LoadPrivileged()
+	if err != nil {
+		logger.Error("failed-to-check-if-volume-is-privileged", err)
+		return err
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(repo.gzipStreamer.Out(writer, volume.DataPath(), isPrivileged))
+	}()
+
+	err = persistentDriver.PersistVolume(key, reader)
+
+	// stops the archive from being streamed out if the driver gave up on it
+	reader.Close()
+
+	if err != nil {
+		logger.Error("failed-to-persist-volume", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repo *repository) SetQuota(ctx context.Context, handle string, limit uint64) error {
+	repo.locker.Lock(handle)
+	defer repo.locker.Unlock(handle)
+
+	logger := lagerctx.FromContext(ctx).Session("set-quota", lager.Data{
+		"volume": handle,
+		"limit":  limit,
+	})
+
+	quotaDriver, ok := repo.driver.(QuotaDriver)
+	if !ok {
+		return ErrQuotaUnsupported
+	}
+
+	volume, found, err := repo.filesystem.LookupVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return err
+	}
+
+	if !found {
+		logger.Info("volume-not-found")
+		return ErrVolumeDoesNotExist
+	}
+
+	err = quotaDriver.SetVolumeQuota(volume, limit)
+	if err != nil {
+		logger.Error("failed-to-set-volume-quota", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repo *repository) GetQuota(ctx context.Context, handle string) (Quota, error) {
+	repo.locker.Lock(handle)
+	defer repo.locker.Unlock(handle)
+
+	logger := lagerctx.FromContext(ctx).Session("get-quota", lager.Data{
+		"volume": handle,
+	})
+
+	quotaDriver, ok := repo.driver.(QuotaDriver)
+	if !ok {
+		return Quota{}, ErrQuotaUnsupported
+	}
+
+	volume, found, err := repo.filesystem.LookupVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return Quota{}, err
+	}
+
+	if !found {
+		logger.Info("volume-not-found")
+		return Quota{}, ErrVolumeDoesNotExist
+	}
+
+	quota, err := quotaDriver.VolumeQuota(volume)
+	if err != nil {
+		logger.Error("failed-to-get-volume-quota", err)
+		return Quota{}, err
+	}
+
+	return quota, nil
+}
+
+// VolumeSize returns the number of bytes the volume takes up. Drivers which
+// limit the size of volumes already keep track of it; for the others, the
+// sizes of the files in the volume are added up.
+func (repo *repository) VolumeSize(ctx context.Context, handle string) (uint64, error) {
+	repo.locker.Lock(handle)
+	defer repo.locker.Unlock(handle)
+
+	logger := lagerctx.FromContext(ctx).Session("volume-size", lager.Data{
+		"volume": handle,
+	})
+
+	volume, found, err := repo.filesystem.LookupVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return 0, err
+	}
+
+	if !found {
+		logger.Info("volume-not-found")
+		return 0, ErrVolumeDoesNotExist
+	}
+
+	if quotaDriver, ok := repo.driver.(QuotaDriver); ok {
+		quota, err := quotaDriver.VolumeQuota(volume)
+		if err == nil {
+			return quota.Used, nil
+		}
+
+		if err != ErrQuotaUnsupported {
+			logger.Error("failed-to-get-volume-quota", err)
+			return 0, err
+		}
+	}
+
+	var size uint64
+	err = filepath.Walk(volume.DataPath(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			size += uint64(info.Size())
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed-to-walk-volume", err)
+		return 0, err
+	}
+
+	return size, nil
+}
+
 func (repo *repository) volumeFrom(liveVolume FilesystemLiveVolume) (Volume, error) {
 	properties, err := liveVolume.LoadProperties()
 	if err != nil {
@@ -574,3 +1000,24 @@ func (repo *repository) volumeFrom(liveVolume FilesystemLiveVolume) (Volume, err
 		Privileged: isPrivileged,
 	}, nil
 }
+
+// NewUnprivilegedRepository wraps a Repository so that every volume is
+// created and kept unprivileged, i.e. with its user and group IDs remapped,
+// no matter what is requested. This is needed on workers which run privileged
+// containers in a user namespace, where root in the container is not root on
+// the host.
+func NewUnprivilegedRepository(repo Repository) Repository {
+	return unprivilegedRepository{repo}
+}
+
+type unprivilegedRepository struct {
+	Repository
+}
+
+func (repo unprivilegedRepository) CreateVolume(ctx context.Context, handle string, strategy Strategy, properties Properties, _ bool) (Volume, error) {
+	return repo.Repository.CreateVolume(ctx, handle, strategy, properties, false)
+}
+
+func (repo unprivilegedRepository) SetPrivileged(ctx context.Context, handle string, _ bool) error {
+	return repo.Repository.SetPrivileged(ctx, handle, false)
+}
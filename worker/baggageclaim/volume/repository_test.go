@@ -1,11 +1,14 @@
 package volume_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"github.com/concourse/go-archive/tgzfs"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -15,6 +18,7 @@ import (
 	"github.com/concourse/concourse/worker/baggageclaim/uidgid/uidgidfakes"
 	"github.com/concourse/concourse/worker/baggageclaim/volume"
 	"github.com/concourse/concourse/worker/baggageclaim/volume/volumefakes"
+	"github.com/klauspost/compress/zstd"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -22,6 +26,7 @@ import (
 var _ = Describe("Repository", func() {
 	var (
 		fakeFilesystem             *volumefakes.FakeFilesystem
+		fakeDriver                 *volumefakes.FakePersistentDriver
 		fakeLocker                 *volumefakes.FakeLockManager
 		fakePrivilegedNamespacer   *uidgidfakes.FakeNamespacer
 		fakeUnprivilegedNamespacer *uidgidfakes.FakeNamespacer
@@ -31,12 +36,14 @@ var _ = Describe("Repository", func() {
 
 	BeforeEach(func() {
 		fakeFilesystem = new(volumefakes.FakeFilesystem)
+		fakeDriver = new(volumefakes.FakePersistentDriver)
 		fakeLocker = new(volumefakes.FakeLockManager)
 		fakePrivilegedNamespacer = new(uidgidfakes.FakeNamespacer)
 		fakeUnprivilegedNamespacer = new(uidgidfakes.FakeNamespacer)
 
 		repository = volume.NewRepository(
 			fakeFilesystem,
+			fakeDriver,
 			fakeLocker,
 			fakePrivilegedNamespacer,
 			fakeUnprivilegedNamespacer,
@@ -1100,10 +1107,21 @@ var _ = Describe("Repository", func() {
 			streamErr          error
 			serverCalled       bool
 			serverResponseCode int
+			serverResponseBody string
 			serverReadBytes    []byte
+			serverDigest       string
+			serverQuery        string
+			p2pKey             []byte
 			tempFile           *os.File
+			encoding           string
+			level              int
 		)
 		BeforeEach(func() {
+			encoding = volume.GzipEncoding
+			level = 0
+			serverResponseBody = ""
+			p2pKey = nil
+
 			var err error
 			tempFile, err = ioutil.TempFile("", "StreamP2pOutTest")
 			Expect(err).ToNot(HaveOccurred())
@@ -1121,14 +1139,23 @@ var _ = Describe("Repository", func() {
 			serverCalled = false
 			serverReadBytes = nil
 			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(serverResponseCode)
 				serverCalled = true
 
 				var err error
 				serverReadBytes, err = ioutil.ReadAll(r.Body)
 				Expect(err).ToNot(HaveOccurred())
+				serverDigest = r.Header.Get(volume.DigestHeader)
+				serverQuery = r.URL.RawQuery
+
+				w.WriteHeader(serverResponseCode)
+				w.Write([]byte(serverResponseBody))
 			}))
-			streamErr = repository.StreamP2pOut(context.Background(), "some-handle", filepath.Base(tempFile.Name()), volume.GzipEncoding, server.URL)
+			streamInURL := server.URL + "/?key=some-key-id"
+			if p2pKey != nil {
+				streamInURL = volume.WithP2pKey(streamInURL, p2pKey)
+			}
+
+			streamErr = repository.StreamP2pOut(context.Background(), "some-handle", filepath.Base(tempFile.Name()), encoding, level, streamInURL)
 		})
 
 		Context("when lookup volume fails", func() {
@@ -1218,8 +1245,454 @@ var _ = Describe("Repository", func() {
 						n := len(serverReadBytes)
 						Expect(serverReadBytes[:n]).To(Equal(b.Bytes()[:n]))
 					})
+					It("remote should receive the digest of the bytes", func() {
+						Expect(serverDigest).To(Equal(fmt.Sprintf("%x", sha256.Sum256(serverReadBytes))))
+					})
+				})
+
+				Context("when the stream-in URL has a p2p key", func() {
+					BeforeEach(func() {
+						serverResponseCode = http.StatusNoContent
+
+						var err error
+						p2pKey, err = volume.NewP2pKey()
+						Expect(err).ToNot(HaveOccurred())
+					})
+					It("remote should receive the encrypted bytes", func() {
+						Expect(streamErr).ToNot(HaveOccurred())
+						Expect(serverQuery).To(Equal("key=some-key-id"))
+
+						reader, err := volume.NewDecryptingReader(p2pKey, bytes.NewReader(serverReadBytes))
+						Expect(err).ToNot(HaveOccurred())
+
+						decrypted, err := ioutil.ReadAll(reader)
+						Expect(err).ToNot(HaveOccurred())
+
+						b := new(bytes.Buffer)
+						tgzfs.Compress(b, filepath.Dir(tempFile.Name()), filepath.Base(tempFile.Name()))
+						Expect(decrypted).To(Equal(b.Bytes()))
+						Expect(serverDigest).To(Equal(fmt.Sprintf("%x", sha256.Sum256(decrypted))))
+					})
+				})
+
+				Context("remote returns a digest mismatch", func() {
+					BeforeEach(func() {
+						serverResponseCode = http.StatusBadRequest
+						serverResponseBody = `{"error":"streamed volume does not match its digest"}`
+					})
+					It("should fail with the mismatch", func() {
+						Expect(streamErr).To(Equal(volume.ErrDigestMismatch))
+					})
+				})
+
+				Context("when streaming zstd at a compression level", func() {
+					BeforeEach(func() {
+						encoding = volume.ZstdEncoding
+						level = 19
+						serverResponseCode = http.StatusNoContent
+					})
+
+					It("remote should receive the zstd stream", func() {
+						Expect(streamErr).ToNot(HaveOccurred())
+
+						decoder, err := zstd.NewReader(bytes.NewReader(serverReadBytes))
+						Expect(err).ToNot(HaveOccurred())
+						defer decoder.Close()
+
+						header, err := tar.NewReader(decoder).Next()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(header.Name).To(Equal(filepath.Base(tempFile.Name())))
+					})
+
+					Context("when the level is out of range", func() {
+						BeforeEach(func() {
+							level = volume.MaxZstdLevel + 1
+						})
+
+						It("should fail", func() {
+							Expect(streamErr).To(Equal(volume.ErrUnsupportedStreamLevel))
+						})
+
+						It("should not http request", func() {
+							Expect(serverCalled).To(BeFalse())
+						})
+					})
 				})
 			})
 		})
 	})
+
+	Describe("StreamDeltaOut and StreamDeltaIn", func() {
+		var (
+			srcDir, baseDir, destDir string
+			privileged               bool
+			basePrivileged           bool
+
+			delta        *bytes.Buffer
+			streamOutErr error
+		)
+
+		BeforeEach(func() {
+			var err error
+			srcDir, err = ioutil.TempDir("", "delta-src")
+			Expect(err).ToNot(HaveOccurred())
+			baseDir, err = ioutil.TempDir("", "delta-base")
+			Expect(err).ToNot(HaveOccurred())
+			destDir, err = ioutil.TempDir("", "delta-dest")
+			Expect(err).ToNot(HaveOccurred())
+
+			privileged = true
+			basePrivileged = true
+
+			Expect(os.Mkdir(filepath.Join(baseDir, "dir"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(baseDir, "dir", "same"), []byte("same"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(baseDir, "dir", "changed"), []byte("old"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(baseDir, "gone"), []byte("gone"), 0644)).To(Succeed())
+
+			Expect(os.Mkdir(filepath.Join(srcDir, "dir"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(srcDir, "dir", "same"), []byte("same"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(srcDir, "dir", "changed"), []byte("new"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(srcDir, "added"), []byte("added"), 0644)).To(Succeed())
+
+			fakeFilesystem.LookupVolumeStub = func(handle string) (volume.FilesystemLiveVolume, bool, error) {
+				fakeVolume := new(volumefakes.FakeFilesystemLiveVolume)
+				switch handle {
+				case "src":
+					fakeVolume.DataPathReturns(srcDir)
+					fakeVolume.LoadPrivilegedReturns(true, nil)
+				case "base":
+					fakeVolume.DataPathReturns(baseDir)
+					fakeVolume.LoadPrivilegedReturns(basePrivileged, nil)
+				case "dest":
+					fakeVolume.DataPathReturns(destDir)
+					fakeVolume.LoadPrivilegedReturns(privileged, nil)
+				default:
+					return nil, false, nil
+				}
+
+				return fakeVolume, true, nil
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(srcDir)
+			os.RemoveAll(baseDir)
+			os.RemoveAll(destDir)
+		})
+
+		JustBeforeEach(func() {
+			manifest, err := repository.VolumeManifest(context.Background(), "base")
+			Expect(err).ToNot(HaveOccurred())
+
+			delta = new(bytes.Buffer)
+			streamOutErr = repository.StreamDeltaOut(context.Background(), "src", volume.ZstdEncoding, 0, manifest, delta)
+		})
+
+		It("recreates the volume from the base and the delta", func() {
+			Expect(streamOutErr).ToNot(HaveOccurred())
+
+			badStream, err := repository.StreamDeltaIn(context.Background(), "dest", "base", volume.ZstdEncoding, delta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(badStream).To(BeFalse())
+
+			srcManifest, err := volume.BuildManifest(srcDir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volume.BuildManifest(destDir)).To(Equal(srcManifest))
+		})
+
+		It("only streams what changed", func() {
+			Expect(streamOutErr).ToNot(HaveOccurred())
+
+			decoder, err := zstd.NewReader(delta)
+			Expect(err).ToNot(HaveOccurred())
+			defer decoder.Close()
+
+			content, err := ioutil.ReadAll(decoder)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).ToNot(ContainSubstring("dir/same"))
+			Expect(string(content)).To(ContainSubstring("dir/changed"))
+			Expect(string(content)).To(ContainSubstring("gone"))
+		})
+
+		Context("when the base is namespaced differently", func() {
+			BeforeEach(func() {
+				basePrivileged = false
+			})
+
+			It("fails without touching the volume", func() {
+				_, err := repository.StreamDeltaIn(context.Background(), "dest", "base", volume.ZstdEncoding, delta)
+				Expect(err).To(Equal(volume.ErrDeltaBaseMismatch))
+
+				Expect(ioutil.ReadDir(destDir)).To(BeEmpty())
+			})
+		})
+
+		Context("when the base does not exist", func() {
+			It("fails", func() {
+				_, err := repository.StreamDeltaIn(context.Background(), "dest", "bogus", volume.ZstdEncoding, delta)
+				Expect(err).To(Equal(volume.ErrVolumeDoesNotExist))
+			})
+		})
+
+		Context("when the delta is bad", func() {
+			It("reports a bad stream", func() {
+				badStream, err := repository.StreamDeltaIn(context.Background(), "dest", "base", volume.GzipEncoding, bytes.NewBufferString("bogus"))
+				Expect(err).To(HaveOccurred())
+				Expect(badStream).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("PersistVolume and hydrating", func() {
+		var srcDir, destDir string
+
+		BeforeEach(func() {
+			var err error
+			srcDir, err = ioutil.TempDir("", "persist-src")
+			Expect(err).ToNot(HaveOccurred())
+			destDir, err = ioutil.TempDir("", "persist-dest")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.Mkdir(filepath.Join(srcDir, "dir"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(srcDir, "dir", "file"), []byte("some-content"), 0644)).To(Succeed())
+
+			fakeFilesystem.LookupVolumeStub = func(handle string) (volume.FilesystemLiveVolume, bool, error) {
+				if handle != "src" {
+					return nil, false, nil
+				}
+
+				fakeVolume := new(volumefakes.FakeFilesystemLiveVolume)
+				fakeVolume.DataPathReturns(srcDir)
+				fakeVolume.LoadPrivilegedReturns(true, nil)
+				return fakeVolume, true, nil
+			}
+
+			fakeInitVolume := new(volumefakes.FakeFilesystemInitVolume)
+			fakeInitVolume.DataPathReturns(destDir)
+			fakeInitVolume.InitializeReturns(new(volumefakes.FakeFilesystemLiveVolume), nil)
+			fakeFilesystem.NewVolumeReturns(fakeInitVolume, nil)
+
+			persisted := map[string][]byte{}
+			fakeDriver.PersistVolumeStub = func(key string, archive io.Reader) error {
+				content, err := ioutil.ReadAll(archive)
+				if err != nil {
+					return err
+				}
+
+				persisted[key] = content
+				return nil
+			}
+			fakeDriver.PersistedVolumeStub = func(key string) (io.ReadCloser, error) {
+				content, found := persisted[key]
+				if !found {
+					return nil, volume.ErrVolumeNotPersisted
+				}
+
+				return ioutil.NopCloser(bytes.NewReader(content)), nil
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(srcDir)
+			os.RemoveAll(destDir)
+		})
+
+		It("hydrates a volume with what was persisted", func() {
+			err := repository.PersistVolume(context.Background(), "src", "some-key")
+			Expect(err).ToNot(HaveOccurred())
+
+			strategy := volume.HydrateStrategy{Key: "some-key", Driver: fakeDriver}
+			_, err = repository.CreateVolume(context.Background(), "dest", strategy, volume.Properties{}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			srcManifest, err := volume.BuildManifest(srcDir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volume.BuildManifest(destDir)).To(Equal(srcManifest))
+
+			Expect(fakeUnprivilegedNamespacer.NamespacePathCallCount()).To(Equal(1))
+		})
+
+		Context("when nothing was persisted under the key", func() {
+			It("fails to hydrate a volume", func() {
+				strategy := volume.HydrateStrategy{Key: "some-key", Driver: fakeDriver}
+				_, err := repository.CreateVolume(context.Background(), "dest", strategy, volume.Properties{}, false)
+				Expect(err).To(Equal(volume.ErrVolumeNotPersisted))
+				Expect(fakeFilesystem.NewVolumeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the volume does not exist", func() {
+			It("fails to persist it", func() {
+				err := repository.PersistVolume(context.Background(), "bogus", "some-key")
+				Expect(err).To(Equal(volume.ErrVolumeDoesNotExist))
+				Expect(fakeDriver.PersistVolumeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the driver does not persist volumes", func() {
+			BeforeEach(func() {
+				repository = volume.NewRepository(
+					fakeFilesystem,
+					new(volumefakes.FakeDriver),
+					fakeLocker,
+					fakePrivilegedNamespacer,
+					fakeUnprivilegedNamespacer,
+				)
+			})
+
+			It("fails to persist the volume", func() {
+				err := repository.PersistVolume(context.Background(), "src", "some-key")
+				Expect(err).To(Equal(volume.ErrPersistenceUnsupported))
+			})
+
+			It("fails to hydrate a volume", func() {
+				_, err := repository.CreateVolume(context.Background(), "dest", volume.HydrateStrategy{Key: "some-key"}, volume.Properties{}, false)
+				Expect(err).To(Equal(volume.ErrPersistenceUnsupported))
+			})
+		})
+	})
+
+	Describe("quotas", func() {
+		var fakeQuotaDriver *volumefakes.FakeQuotaDriver
+		var fakeVolume *volumefakes.FakeFilesystemLiveVolume
+
+		BeforeEach(func() {
+			fakeQuotaDriver = new(volumefakes.FakeQuotaDriver)
+
+			repository = volume.NewRepository(
+				fakeFilesystem,
+				fakeQuotaDriver,
+				fakeLocker,
+				fakePrivilegedNamespacer,
+				fakeUnprivilegedNamespacer,
+			)
+
+			fakeVolume = new(volumefakes.FakeFilesystemLiveVolume)
+			fakeFilesystem.LookupVolumeStub = func(handle string) (volume.FilesystemLiveVolume, bool, error) {
+				if handle != "some-volume" {
+					return nil, false, nil
+				}
+
+				return fakeVolume, true, nil
+			}
+		})
+
+		It("limits the volume with the driver", func() {
+			err := repository.SetQuota(context.Background(), "some-volume", 1024)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeQuotaDriver.SetVolumeQuotaCallCount()).To(Equal(1))
+			vol, limit := fakeQuotaDriver.SetVolumeQuotaArgsForCall(0)
+			Expect(vol).To(Equal(fakeVolume))
+			Expect(limit).To(Equal(uint64(1024)))
+		})
+
+		It("returns the volume's quota from the driver", func() {
+			fakeQuotaDriver.VolumeQuotaReturns(volume.Quota{Limit: 1024, Used: 512}, nil)
+
+			quota, err := repository.GetQuota(context.Background(), "some-volume")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quota).To(Equal(volume.Quota{Limit: 1024, Used: 512}))
+			Expect(fakeQuotaDriver.VolumeQuotaArgsForCall(0)).To(Equal(fakeVolume))
+		})
+
+		Context("when the volume does not exist", func() {
+			It("returns ErrVolumeDoesNotExist", func() {
+				err := repository.SetQuota(context.Background(), "bogus", 1024)
+				Expect(err).To(Equal(volume.ErrVolumeDoesNotExist))
+
+				_, err = repository.GetQuota(context.Background(), "bogus")
+				Expect(err).To(Equal(volume.ErrVolumeDoesNotExist))
+
+				_, err = repository.VolumeSize(context.Background(), "bogus")
+				Expect(err).To(Equal(volume.ErrVolumeDoesNotExist))
+			})
+		})
+
+		Context("when the driver does not limit volumes", func() {
+			BeforeEach(func() {
+				repository = volume.NewRepository(
+					fakeFilesystem,
+					new(volumefakes.FakeDriver),
+					fakeLocker,
+					fakePrivilegedNamespacer,
+					fakeUnprivilegedNamespacer,
+				)
+			})
+
+			It("returns ErrQuotaUnsupported", func() {
+				err := repository.SetQuota(context.Background(), "some-volume", 1024)
+				Expect(err).To(Equal(volume.ErrQuotaUnsupported))
+
+				_, err = repository.GetQuota(context.Background(), "some-volume")
+				Expect(err).To(Equal(volume.ErrQuotaUnsupported))
+			})
+
+			It("sizes the volume by adding up the sizes of its files", func() {
+				dataPath, err := ioutil.TempDir("", "volume-size")
+				Expect(err).ToNot(HaveOccurred())
+				defer os.RemoveAll(dataPath)
+
+				Expect(os.Mkdir(filepath.Join(dataPath, "some-dir"), 0755)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(dataPath, "some-file"), make([]byte, 100), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(dataPath, "some-dir", "other-file"), make([]byte, 50), 0644)).To(Succeed())
+
+				fakeVolume.DataPathReturns(dataPath)
+
+				size, err := repository.VolumeSize(context.Background(), "some-volume")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(size).To(Equal(uint64(150)))
+			})
+		})
+
+		It("sizes the volume by its usage from the driver", func() {
+			fakeQuotaDriver.VolumeQuotaReturns(volume.Quota{Limit: 1024, Used: 512}, nil)
+
+			size, err := repository.VolumeSize(context.Background(), "some-volume")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(size).To(Equal(uint64(512)))
+		})
+	})
+})
+
+var _ = Describe("UnprivilegedRepository", func() {
+	var (
+		fakeRepository *volumefakes.FakeRepository
+
+		repository volume.Repository
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(volumefakes.FakeRepository)
+
+		repository = volume.NewUnprivilegedRepository(fakeRepository)
+	})
+
+	It("creates privileged volumes as unprivileged", func() {
+		_, err := repository.CreateVolume(context.TODO(), "some-handle", new(volumefakes.FakeStrategy), volume.Properties{}, true)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeRepository.CreateVolumeCallCount()).To(Equal(1))
+		_, handle, _, _, privileged := fakeRepository.CreateVolumeArgsForCall(0)
+		Expect(handle).To(Equal("some-handle"))
+		Expect(privileged).To(BeFalse())
+	})
+
+	It("keeps volumes unprivileged when asked to make them privileged", func() {
+		err := repository.SetPrivileged(context.TODO(), "some-handle", true)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeRepository.SetPrivilegedCallCount()).To(Equal(1))
+		_, handle, privileged := fakeRepository.SetPrivilegedArgsForCall(0)
+		Expect(handle).To(Equal("some-handle"))
+		Expect(privileged).To(BeFalse())
+	})
+
+	It("passes other calls through", func() {
+		fakeRepository.GetPrivilegedReturns(false, nil)
+
+		_, err := repository.GetPrivileged(context.TODO(), "some-handle")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeRepository.GetPrivilegedCallCount()).To(Equal(1))
+	})
 })
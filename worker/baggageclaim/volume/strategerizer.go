@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/registry"
 )
 
 type Strategerizer interface {
@@ -16,15 +18,29 @@ const (
 	StrategyEmpty       = "empty"
 	StrategyCopyOnWrite = "cow"
 	StrategyImport      = "import"
+	StrategyHydrate     = "hydrate"
+	StrategyRegistry    = "registry-image"
 )
 
 var ErrNoStrategy = errors.New("no strategy given")
 var ErrUnknownStrategy = errors.New("unknown strategy")
 
-type strategerizer struct{}
+type strategerizer struct {
+	driver      Driver
+	layers      *registry.LayerCache
+	pullTimeout time.Duration
+}
 
-func NewStrategerizer() Strategerizer {
-	return &strategerizer{}
+// NewStrategerizer constructs a Strategerizer for volumes managed by the
+// driver. layers caches the layers of images pulled from registries; images
+// can't be pulled if it's nil. Pulling an image is given up on after
+// pullTimeout, unless it's zero.
+func NewStrategerizer(driver Driver, layers *registry.LayerCache, pullTimeout time.Duration) Strategerizer {
+	return &strategerizer{
+		driver:      driver,
+		layers:      layers,
+		pullTimeout: pullTimeout,
+	}
 }
 
 func (s *strategerizer) StrategyFor(request baggageclaim.VolumeRequest) (Strategy, error) {
@@ -57,6 +73,38 @@ func (s *strategerizer) StrategyFor(request baggageclaim.VolumeRequest) (Strateg
 			Path:           path,
 			FollowSymlinks: followSymlinks,
 		}
+	case StrategyHydrate:
+		key, _ := strategyInfo["key"].(string)
+		persistentDriver, _ := s.driver.(PersistentDriver)
+		strategy = HydrateStrategy{
+			Key:    key,
+			Driver: persistentDriver,
+		}
+	case StrategyRegistry:
+		var registryImage struct {
+			Repository string `json:"repository"`
+			Digest     string `json:"digest"`
+			Username   string `json:"username"`
+			Password   string `json:"password"`
+			Insecure   bool   `json:"insecure"`
+		}
+		err := json.Unmarshal(*request.Strategy, &registryImage)
+		if err != nil {
+			return nil, fmt.Errorf("malformed strategy: %s", err)
+		}
+		err = registry.ValidateDigest(registryImage.Digest)
+		if err != nil {
+			return nil, err
+		}
+		strategy = RegistryImageStrategy{
+			Repository: registryImage.Repository,
+			Digest:     registryImage.Digest,
+			Username:   registryImage.Username,
+			Password:   registryImage.Password,
+			Insecure:   registryImage.Insecure,
+			Timeout:    s.pullTimeout,
+			Layers:     s.layers,
+		}
 	default:
 		return nil, ErrUnknownStrategy
 	}
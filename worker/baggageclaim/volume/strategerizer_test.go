@@ -4,6 +4,8 @@ import (
 	"github.com/concourse/concourse/worker/baggageclaim"
 	"github.com/concourse/concourse/worker/baggageclaim/baggageclaimfakes"
 	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/driver"
+	"github.com/concourse/concourse/worker/baggageclaim/volume/volumefakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -15,7 +17,7 @@ var _ = Describe("Strategerizer", func() {
 	)
 
 	BeforeEach(func() {
-		strategerizer = volume.NewStrategerizer()
+		strategerizer = volume.NewStrategerizer(&driver.NaiveDriver{}, nil, 0)
 	})
 
 	Describe("StrategyFor", func() {
@@ -102,5 +104,66 @@ var _ = Describe("Strategerizer", func() {
 				Expect(strategy).To(Equal(volume.COWStrategy{ParentHandle: "parent-handle"}))
 			})
 		})
+
+		Context("with a hydrate strategy", func() {
+			BeforeEach(func() {
+				request.Strategy = baggageclaim.HydrateStrategy{Key: "some-key"}.Encode()
+			})
+
+			It("constructs a hydrate strategy without a driver", func() {
+				Expect(strategyForErr).ToNot(HaveOccurred())
+				Expect(strategy).To(Equal(volume.HydrateStrategy{Key: "some-key"}))
+			})
+
+			Context("when the driver persists volumes", func() {
+				var fakeDriver *volumefakes.FakePersistentDriver
+
+				BeforeEach(func() {
+					fakeDriver = new(volumefakes.FakePersistentDriver)
+					strategerizer = volume.NewStrategerizer(fakeDriver, nil, 0)
+				})
+
+				It("constructs a hydrate strategy with the driver", func() {
+					Expect(strategyForErr).ToNot(HaveOccurred())
+					Expect(strategy).To(Equal(volume.HydrateStrategy{Key: "some-key", Driver: fakeDriver}))
+				})
+			})
+		})
+
+		Context("with a registry image strategy", func() {
+			const digest = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+			BeforeEach(func() {
+				request.Strategy = baggageclaim.RegistryImageStrategy{
+					Repository: "some/image",
+					Digest:     digest,
+					Username:   "some-user",
+					Password:   "some-password",
+				}.Encode()
+			})
+
+			It("constructs a registry image strategy", func() {
+				Expect(strategyForErr).ToNot(HaveOccurred())
+				Expect(strategy).To(Equal(volume.RegistryImageStrategy{
+					Repository: "some/image",
+					Digest:     digest,
+					Username:   "some-user",
+					Password:   "some-password",
+				}))
+			})
+
+			Context("when the digest is invalid", func() {
+				BeforeEach(func() {
+					request.Strategy = baggageclaim.RegistryImageStrategy{
+						Repository: "some/image",
+						Digest:     "latest",
+					}.Encode()
+				})
+
+				It("fails", func() {
+					Expect(strategyForErr).To(HaveOccurred())
+				})
+			})
+		})
 	})
 })
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/concourse/concourse/worker/baggageclaim/uidgid"
+	"github.com/klauspost/compress/zstd"
 )
 
 //go:generate counterfeiter . Streamer
@@ -15,8 +16,20 @@ type Streamer interface {
 
 type tarZstdStreamer struct {
 	namespacer uidgid.Namespacer
+
+	// level is the zstd compression level (1-22) streams are compressed at,
+	// or 0 for the encoder's default.
+	level int
 }
 
 type tarGzipStreamer struct {
 	namespacer uidgid.Namespacer
 }
+
+func (streamer *tarZstdStreamer) newWriter(w io.Writer) (*zstd.Encoder, error) {
+	if streamer.level == 0 {
+		return zstd.NewWriter(w)
+	}
+
+	return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(streamer.level)))
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/concourse/concourse/worker/baggageclaim/uidgid"
 	"github.com/klauspost/compress/zstd"
@@ -66,7 +67,7 @@ func (streamer *tarZstdStreamer) Out(tzstOutput io.Writer, src string, privilege
 
 	defer dirFd.Close()
 
-	zstdCompressor, err := zstd.NewWriter(tzstOutput)
+	zstdCompressor, err := streamer.newWriter(tzstOutput)
 	if err != nil {
 		return err
 	}
@@ -146,6 +147,49 @@ func (streamer *tarGzipStreamer) Out(w io.Writer, src string, privileged bool) e
 	return nil
 }
 
+// tarPaths writes a tar of `paths` within `dir` to w, without recursing into
+// directories.
+func tarPaths(namespacer uidgid.Namespacer, privileged bool, dir string, paths []string, w io.Writer) error {
+	tarCommand, dirFd, err := tarCmd(namespacer, privileged, dir, "-cf", "-", "--no-recursion", "--null", "-T", "-")
+	if err != nil {
+		return err
+	}
+
+	defer dirFd.Close()
+
+	tarCommand.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
+	tarCommand.Stdout = w
+	tarCommand.Stderr = os.Stderr
+
+	return tarCommand.Run()
+}
+
+// untar extracts the tar streamed from r into dest, returning whether the
+// stream was bad if it fails.
+func untar(namespacer uidgid.Namespacer, privileged bool, dest string, r io.Reader) (bool, error) {
+	tarCommand, dirFd, err := tarCmd(namespacer, privileged, dest, "-xf", "-")
+	if err != nil {
+		return false, err
+	}
+
+	defer dirFd.Close()
+
+	tarCommand.Stdin = r
+	tarCommand.Stdout = os.Stderr
+	tarCommand.Stderr = os.Stderr
+
+	err = tarCommand.Run()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return true, err
+		}
+
+		return false, err
+	}
+
+	return false, nil
+}
+
 func tarCmd(namespacer uidgid.Namespacer, privileged bool, dir string, args ...string) (*exec.Cmd, *os.File, error) {
 	// 'tar' may run as MAX_UID in order to remap UIDs when streaming into an
 	// unprivileged volume. this may cause permission issues when exec'ing as it
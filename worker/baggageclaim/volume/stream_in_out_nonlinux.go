@@ -3,10 +3,12 @@
 package volume
 
 import (
+	"archive/tar"
 	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/concourse/concourse/worker/baggageclaim/uidgid"
 	"github.com/concourse/go-archive/tarfs"
 	"github.com/concourse/go-archive/tgzfs"
 	"github.com/klauspost/compress/zstd"
@@ -73,7 +75,7 @@ func (streamer *tarZstdStreamer) Out(w io.Writer, src string, privileged bool) e
 		tarPath = filepath.Base(src)
 	}
 
-	zstdStreamWriter, err := zstd.NewWriter(w)
+	zstdStreamWriter, err := streamer.newWriter(w)
 	if err != nil {
 		return err
 	}
@@ -91,3 +93,73 @@ func (streamer *tarZstdStreamer) Out(w io.Writer, src string, privileged bool) e
 
 	return nil
 }
+
+// tarPaths writes a tar of `paths` within `dir` to w, without recursing into
+// directories.
+func tarPaths(namespacer uidgid.Namespacer, privileged bool, dir string, paths []string, w io.Writer) error {
+	tarWriter := tar.NewWriter(w)
+
+	for _, path := range paths {
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+
+		info, err := os.Lstat(fullPath)
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(fullPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		header.Name = path
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			err = copyFile(tarWriter, fullPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tarWriter.Close()
+}
+
+func copyFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// untar extracts the tar streamed from r into dest, returning whether the
+// stream was bad if it fails.
+func untar(namespacer uidgid.Namespacer, privileged bool, dest string, r io.Reader) (bool, error) {
+	err := tarfs.Extract(r, dest)
+	if err != nil {
+		return true, err
+	}
+
+	return false, nil
+}
@@ -65,6 +65,20 @@ type FakeRepository struct {
 		result1 bool
 		result2 error
 	}
+	GetQuotaStub        func(context.Context, string) (volume.Quota, error)
+	getQuotaMutex       sync.RWMutex
+	getQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getQuotaReturns struct {
+		result1 volume.Quota
+		result2 error
+	}
+	getQuotaReturnsOnCall map[int]struct {
+		result1 volume.Quota
+		result2 error
+	}
 	GetVolumeStub        func(context.Context, string) (volume.Volume, bool, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
@@ -97,6 +111,19 @@ type FakeRepository struct {
 		result2 []string
 		result3 error
 	}
+	PersistVolumeStub        func(context.Context, string, string) error
+	persistVolumeMutex       sync.RWMutex
+	persistVolumeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	persistVolumeReturns struct {
+		result1 error
+	}
+	persistVolumeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetPrivilegedStub        func(context.Context, string, bool) error
 	setPrivilegedMutex       sync.RWMutex
 	setPrivilegedArgsForCall []struct {
@@ -124,6 +151,52 @@ type FakeRepository struct {
 	setPropertyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetQuotaStub        func(context.Context, string, uint64) error
+	setQuotaMutex       sync.RWMutex
+	setQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 uint64
+	}
+	setQuotaReturns struct {
+		result1 error
+	}
+	setQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StreamDeltaInStub        func(context.Context, string, string, string, io.Reader) (bool, error)
+	streamDeltaInMutex       sync.RWMutex
+	streamDeltaInArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 io.Reader
+	}
+	streamDeltaInReturns struct {
+		result1 bool
+		result2 error
+	}
+	streamDeltaInReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	StreamDeltaOutStub        func(context.Context, string, string, int, volume.Manifest, io.Writer) error
+	streamDeltaOutMutex       sync.RWMutex
+	streamDeltaOutArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 volume.Manifest
+		arg6 io.Writer
+	}
+	streamDeltaOutReturns struct {
+		result1 error
+	}
+	streamDeltaOutReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StreamInStub        func(context.Context, string, string, string, io.Reader) (bool, error)
 	streamInMutex       sync.RWMutex
 	streamInArgsForCall []struct {
@@ -141,14 +214,15 @@ type FakeRepository struct {
 		result1 bool
 		result2 error
 	}
-	StreamOutStub        func(context.Context, string, string, string, io.Writer) error
+	StreamOutStub        func(context.Context, string, string, string, int, io.Writer) error
 	streamOutMutex       sync.RWMutex
 	streamOutArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-		arg5 io.Writer
+		arg5 int
+		arg6 io.Writer
 	}
 	streamOutReturns struct {
 		result1 error
@@ -156,14 +230,15 @@ type FakeRepository struct {
 	streamOutReturnsOnCall map[int]struct {
 		result1 error
 	}
-	StreamP2pOutStub        func(context.Context, string, string, string, string) error
+	StreamP2pOutStub        func(context.Context, string, string, string, int, string) error
 	streamP2pOutMutex       sync.RWMutex
 	streamP2pOutArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-		arg5 string
+		arg5 int
+		arg6 string
 	}
 	streamP2pOutReturns struct {
 		result1 error
@@ -171,6 +246,20 @@ type FakeRepository struct {
 	streamP2pOutReturnsOnCall map[int]struct {
 		result1 error
 	}
+	VolumeManifestStub        func(context.Context, string) (volume.Manifest, error)
+	volumeManifestMutex       sync.RWMutex
+	volumeManifestArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	volumeManifestReturns struct {
+		result1 volume.Manifest
+		result2 error
+	}
+	volumeManifestReturnsOnCall map[int]struct {
+		result1 volume.Manifest
+		result2 error
+	}
 	VolumeParentStub        func(context.Context, string) (volume.Volume, bool, error)
 	volumeParentMutex       sync.RWMutex
 	volumeParentArgsForCall []struct {
@@ -187,6 +276,20 @@ type FakeRepository struct {
 		result2 bool
 		result3 error
 	}
+	VolumeSizeStub        func(context.Context, string) (uint64, error)
+	volumeSizeMutex       sync.RWMutex
+	volumeSizeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	volumeSizeReturns struct {
+		result1 uint64
+		result2 error
+	}
+	volumeSizeReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -444,6 +547,71 @@ func (fake *FakeRepository) GetPrivilegedReturnsOnCall(i int, result1 bool, resu
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) GetQuota(arg1 context.Context, arg2 string) (volume.Quota, error) {
+	fake.getQuotaMutex.Lock()
+	ret, specificReturn := fake.getQuotaReturnsOnCall[len(fake.getQuotaArgsForCall)]
+	fake.getQuotaArgsForCall = append(fake.getQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetQuotaStub
+	fakeReturns := fake.getQuotaReturns
+	fake.recordInvocation("GetQuota", []interface{}{arg1, arg2})
+	fake.getQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetQuotaCallCount() int {
+	fake.getQuotaMutex.RLock()
+	defer fake.getQuotaMutex.RUnlock()
+	return len(fake.getQuotaArgsForCall)
+}
+
+func (fake *FakeRepository) GetQuotaCalls(stub func(context.Context, string) (volume.Quota, error)) {
+	fake.getQuotaMutex.Lock()
+	defer fake.getQuotaMutex.Unlock()
+	fake.GetQuotaStub = stub
+}
+
+func (fake *FakeRepository) GetQuotaArgsForCall(i int) (context.Context, string) {
+	fake.getQuotaMutex.RLock()
+	defer fake.getQuotaMutex.RUnlock()
+	argsForCall := fake.getQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetQuotaReturns(result1 volume.Quota, result2 error) {
+	fake.getQuotaMutex.Lock()
+	defer fake.getQuotaMutex.Unlock()
+	fake.GetQuotaStub = nil
+	fake.getQuotaReturns = struct {
+		result1 volume.Quota
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetQuotaReturnsOnCall(i int, result1 volume.Quota, result2 error) {
+	fake.getQuotaMutex.Lock()
+	defer fake.getQuotaMutex.Unlock()
+	fake.GetQuotaStub = nil
+	if fake.getQuotaReturnsOnCall == nil {
+		fake.getQuotaReturnsOnCall = make(map[int]struct {
+			result1 volume.Quota
+			result2 error
+		})
+	}
+	fake.getQuotaReturnsOnCall[i] = struct {
+		result1 volume.Quota
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) GetVolume(arg1 context.Context, arg2 string) (volume.Volume, bool, error) {
 	fake.getVolumeMutex.Lock()
 	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
@@ -578,6 +746,69 @@ func (fake *FakeRepository) ListVolumesReturnsOnCall(i int, result1 volume.Volum
 	}{result1, result2, result3}
 }
 
+func (fake *FakeRepository) PersistVolume(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.persistVolumeMutex.Lock()
+	ret, specificReturn := fake.persistVolumeReturnsOnCall[len(fake.persistVolumeArgsForCall)]
+	fake.persistVolumeArgsForCall = append(fake.persistVolumeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PersistVolumeStub
+	fakeReturns := fake.persistVolumeReturns
+	fake.recordInvocation("PersistVolume", []interface{}{arg1, arg2, arg3})
+	fake.persistVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) PersistVolumeCallCount() int {
+	fake.persistVolumeMutex.RLock()
+	defer fake.persistVolumeMutex.RUnlock()
+	return len(fake.persistVolumeArgsForCall)
+}
+
+func (fake *FakeRepository) PersistVolumeCalls(stub func(context.Context, string, string) error) {
+	fake.persistVolumeMutex.Lock()
+	defer fake.persistVolumeMutex.Unlock()
+	fake.PersistVolumeStub = stub
+}
+
+func (fake *FakeRepository) PersistVolumeArgsForCall(i int) (context.Context, string, string) {
+	fake.persistVolumeMutex.RLock()
+	defer fake.persistVolumeMutex.RUnlock()
+	argsForCall := fake.persistVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) PersistVolumeReturns(result1 error) {
+	fake.persistVolumeMutex.Lock()
+	defer fake.persistVolumeMutex.Unlock()
+	fake.PersistVolumeStub = nil
+	fake.persistVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) PersistVolumeReturnsOnCall(i int, result1 error) {
+	fake.persistVolumeMutex.Lock()
+	defer fake.persistVolumeMutex.Unlock()
+	fake.PersistVolumeStub = nil
+	if fake.persistVolumeReturnsOnCall == nil {
+		fake.persistVolumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.persistVolumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) SetPrivileged(arg1 context.Context, arg2 string, arg3 bool) error {
 	fake.setPrivilegedMutex.Lock()
 	ret, specificReturn := fake.setPrivilegedReturnsOnCall[len(fake.setPrivilegedArgsForCall)]
@@ -703,6 +934,203 @@ func (fake *FakeRepository) SetPropertyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) SetQuota(arg1 context.Context, arg2 string, arg3 uint64) error {
+	fake.setQuotaMutex.Lock()
+	ret, specificReturn := fake.setQuotaReturnsOnCall[len(fake.setQuotaArgsForCall)]
+	fake.setQuotaArgsForCall = append(fake.setQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 uint64
+	}{arg1, arg2, arg3})
+	stub := fake.SetQuotaStub
+	fakeReturns := fake.setQuotaReturns
+	fake.recordInvocation("SetQuota", []interface{}{arg1, arg2, arg3})
+	fake.setQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) SetQuotaCallCount() int {
+	fake.setQuotaMutex.RLock()
+	defer fake.setQuotaMutex.RUnlock()
+	return len(fake.setQuotaArgsForCall)
+}
+
+func (fake *FakeRepository) SetQuotaCalls(stub func(context.Context, string, uint64) error) {
+	fake.setQuotaMutex.Lock()
+	defer fake.setQuotaMutex.Unlock()
+	fake.SetQuotaStub = stub
+}
+
+func (fake *FakeRepository) SetQuotaArgsForCall(i int) (context.Context, string, uint64) {
+	fake.setQuotaMutex.RLock()
+	defer fake.setQuotaMutex.RUnlock()
+	argsForCall := fake.setQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) SetQuotaReturns(result1 error) {
+	fake.setQuotaMutex.Lock()
+	defer fake.setQuotaMutex.Unlock()
+	fake.SetQuotaStub = nil
+	fake.setQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SetQuotaReturnsOnCall(i int, result1 error) {
+	fake.setQuotaMutex.Lock()
+	defer fake.setQuotaMutex.Unlock()
+	fake.SetQuotaStub = nil
+	if fake.setQuotaReturnsOnCall == nil {
+		fake.setQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) StreamDeltaIn(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 io.Reader) (bool, error) {
+	fake.streamDeltaInMutex.Lock()
+	ret, specificReturn := fake.streamDeltaInReturnsOnCall[len(fake.streamDeltaInArgsForCall)]
+	fake.streamDeltaInArgsForCall = append(fake.streamDeltaInArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 io.Reader
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.StreamDeltaInStub
+	fakeReturns := fake.streamDeltaInReturns
+	fake.recordInvocation("StreamDeltaIn", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.streamDeltaInMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) StreamDeltaInCallCount() int {
+	fake.streamDeltaInMutex.RLock()
+	defer fake.streamDeltaInMutex.RUnlock()
+	return len(fake.streamDeltaInArgsForCall)
+}
+
+func (fake *FakeRepository) StreamDeltaInCalls(stub func(context.Context, string, string, string, io.Reader) (bool, error)) {
+	fake.streamDeltaInMutex.Lock()
+	defer fake.streamDeltaInMutex.Unlock()
+	fake.StreamDeltaInStub = stub
+}
+
+func (fake *FakeRepository) StreamDeltaInArgsForCall(i int) (context.Context, string, string, string, io.Reader) {
+	fake.streamDeltaInMutex.RLock()
+	defer fake.streamDeltaInMutex.RUnlock()
+	argsForCall := fake.streamDeltaInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeRepository) StreamDeltaInReturns(result1 bool, result2 error) {
+	fake.streamDeltaInMutex.Lock()
+	defer fake.streamDeltaInMutex.Unlock()
+	fake.StreamDeltaInStub = nil
+	fake.streamDeltaInReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) StreamDeltaInReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.streamDeltaInMutex.Lock()
+	defer fake.streamDeltaInMutex.Unlock()
+	fake.StreamDeltaInStub = nil
+	if fake.streamDeltaInReturnsOnCall == nil {
+		fake.streamDeltaInReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.streamDeltaInReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) StreamDeltaOut(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 volume.Manifest, arg6 io.Writer) error {
+	fake.streamDeltaOutMutex.Lock()
+	ret, specificReturn := fake.streamDeltaOutReturnsOnCall[len(fake.streamDeltaOutArgsForCall)]
+	fake.streamDeltaOutArgsForCall = append(fake.streamDeltaOutArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 volume.Manifest
+		arg6 io.Writer
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.StreamDeltaOutStub
+	fakeReturns := fake.streamDeltaOutReturns
+	fake.recordInvocation("StreamDeltaOut", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.streamDeltaOutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) StreamDeltaOutCallCount() int {
+	fake.streamDeltaOutMutex.RLock()
+	defer fake.streamDeltaOutMutex.RUnlock()
+	return len(fake.streamDeltaOutArgsForCall)
+}
+
+func (fake *FakeRepository) StreamDeltaOutCalls(stub func(context.Context, string, string, int, volume.Manifest, io.Writer) error) {
+	fake.streamDeltaOutMutex.Lock()
+	defer fake.streamDeltaOutMutex.Unlock()
+	fake.StreamDeltaOutStub = stub
+}
+
+func (fake *FakeRepository) StreamDeltaOutArgsForCall(i int) (context.Context, string, string, int, volume.Manifest, io.Writer) {
+	fake.streamDeltaOutMutex.RLock()
+	defer fake.streamDeltaOutMutex.RUnlock()
+	argsForCall := fake.streamDeltaOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeRepository) StreamDeltaOutReturns(result1 error) {
+	fake.streamDeltaOutMutex.Lock()
+	defer fake.streamDeltaOutMutex.Unlock()
+	fake.StreamDeltaOutStub = nil
+	fake.streamDeltaOutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) StreamDeltaOutReturnsOnCall(i int, result1 error) {
+	fake.streamDeltaOutMutex.Lock()
+	defer fake.streamDeltaOutMutex.Unlock()
+	fake.StreamDeltaOutStub = nil
+	if fake.streamDeltaOutReturnsOnCall == nil {
+		fake.streamDeltaOutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamDeltaOutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) StreamIn(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 io.Reader) (bool, error) {
 	fake.streamInMutex.Lock()
 	ret, specificReturn := fake.streamInReturnsOnCall[len(fake.streamInArgsForCall)]
@@ -770,7 +1198,7 @@ func (fake *FakeRepository) StreamInReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) StreamOut(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 io.Writer) error {
+func (fake *FakeRepository) StreamOut(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 int, arg6 io.Writer) error {
 	fake.streamOutMutex.Lock()
 	ret, specificReturn := fake.streamOutReturnsOnCall[len(fake.streamOutArgsForCall)]
 	fake.streamOutArgsForCall = append(fake.streamOutArgsForCall, struct {
@@ -778,12 +1206,13 @@ func (fake *FakeRepository) StreamOut(arg1 context.Context, arg2 string, arg3 st
 		arg2 string
 		arg3 string
 		arg4 string
-		arg5 io.Writer
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("StreamOut", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg5 int
+		arg6 io.Writer
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("StreamOut", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.streamOutMutex.Unlock()
 	if fake.StreamOutStub != nil {
-		return fake.StreamOutStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.StreamOutStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -798,17 +1227,17 @@ func (fake *FakeRepository) StreamOutCallCount() int {
 	return len(fake.streamOutArgsForCall)
 }
 
-func (fake *FakeRepository) StreamOutCalls(stub func(context.Context, string, string, string, io.Writer) error) {
+func (fake *FakeRepository) StreamOutCalls(stub func(context.Context, string, string, string, int, io.Writer) error) {
 	fake.streamOutMutex.Lock()
 	defer fake.streamOutMutex.Unlock()
 	fake.StreamOutStub = stub
 }
 
-func (fake *FakeRepository) StreamOutArgsForCall(i int) (context.Context, string, string, string, io.Writer) {
+func (fake *FakeRepository) StreamOutArgsForCall(i int) (context.Context, string, string, string, int, io.Writer) {
 	fake.streamOutMutex.RLock()
 	defer fake.streamOutMutex.RUnlock()
 	argsForCall := fake.streamOutArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeRepository) StreamOutReturns(result1 error) {
@@ -834,7 +1263,7 @@ func (fake *FakeRepository) StreamOutReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeRepository) StreamP2pOut(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
+func (fake *FakeRepository) StreamP2pOut(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 int, arg6 string) error {
 	fake.streamP2pOutMutex.Lock()
 	ret, specificReturn := fake.streamP2pOutReturnsOnCall[len(fake.streamP2pOutArgsForCall)]
 	fake.streamP2pOutArgsForCall = append(fake.streamP2pOutArgsForCall, struct {
@@ -842,12 +1271,13 @@ func (fake *FakeRepository) StreamP2pOut(arg1 context.Context, arg2 string, arg3
 		arg2 string
 		arg3 string
 		arg4 string
-		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("StreamP2pOut", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg5 int
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("StreamP2pOut", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.streamP2pOutMutex.Unlock()
 	if fake.StreamP2pOutStub != nil {
-		return fake.StreamP2pOutStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.StreamP2pOutStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -862,17 +1292,17 @@ func (fake *FakeRepository) StreamP2pOutCallCount() int {
 	return len(fake.streamP2pOutArgsForCall)
 }
 
-func (fake *FakeRepository) StreamP2pOutCalls(stub func(context.Context, string, string, string, string) error) {
+func (fake *FakeRepository) StreamP2pOutCalls(stub func(context.Context, string, string, string, int, string) error) {
 	fake.streamP2pOutMutex.Lock()
 	defer fake.streamP2pOutMutex.Unlock()
 	fake.StreamP2pOutStub = stub
 }
 
-func (fake *FakeRepository) StreamP2pOutArgsForCall(i int) (context.Context, string, string, string, string) {
+func (fake *FakeRepository) StreamP2pOutArgsForCall(i int) (context.Context, string, string, string, int, string) {
 	fake.streamP2pOutMutex.RLock()
 	defer fake.streamP2pOutMutex.RUnlock()
 	argsForCall := fake.streamP2pOutArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeRepository) StreamP2pOutReturns(result1 error) {
@@ -898,6 +1328,71 @@ func (fake *FakeRepository) StreamP2pOutReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) VolumeManifest(arg1 context.Context, arg2 string) (volume.Manifest, error) {
+	fake.volumeManifestMutex.Lock()
+	ret, specificReturn := fake.volumeManifestReturnsOnCall[len(fake.volumeManifestArgsForCall)]
+	fake.volumeManifestArgsForCall = append(fake.volumeManifestArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.VolumeManifestStub
+	fakeReturns := fake.volumeManifestReturns
+	fake.recordInvocation("VolumeManifest", []interface{}{arg1, arg2})
+	fake.volumeManifestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) VolumeManifestCallCount() int {
+	fake.volumeManifestMutex.RLock()
+	defer fake.volumeManifestMutex.RUnlock()
+	return len(fake.volumeManifestArgsForCall)
+}
+
+func (fake *FakeRepository) VolumeManifestCalls(stub func(context.Context, string) (volume.Manifest, error)) {
+	fake.volumeManifestMutex.Lock()
+	defer fake.volumeManifestMutex.Unlock()
+	fake.VolumeManifestStub = stub
+}
+
+func (fake *FakeRepository) VolumeManifestArgsForCall(i int) (context.Context, string) {
+	fake.volumeManifestMutex.RLock()
+	defer fake.volumeManifestMutex.RUnlock()
+	argsForCall := fake.volumeManifestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) VolumeManifestReturns(result1 volume.Manifest, result2 error) {
+	fake.volumeManifestMutex.Lock()
+	defer fake.volumeManifestMutex.Unlock()
+	fake.VolumeManifestStub = nil
+	fake.volumeManifestReturns = struct {
+		result1 volume.Manifest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) VolumeManifestReturnsOnCall(i int, result1 volume.Manifest, result2 error) {
+	fake.volumeManifestMutex.Lock()
+	defer fake.volumeManifestMutex.Unlock()
+	fake.VolumeManifestStub = nil
+	if fake.volumeManifestReturnsOnCall == nil {
+		fake.volumeManifestReturnsOnCall = make(map[int]struct {
+			result1 volume.Manifest
+			result2 error
+		})
+	}
+	fake.volumeManifestReturnsOnCall[i] = struct {
+		result1 volume.Manifest
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) VolumeParent(arg1 context.Context, arg2 string) (volume.Volume, bool, error) {
 	fake.volumeParentMutex.Lock()
 	ret, specificReturn := fake.volumeParentReturnsOnCall[len(fake.volumeParentArgsForCall)]
@@ -965,6 +1460,71 @@ func (fake *FakeRepository) VolumeParentReturnsOnCall(i int, result1 volume.Volu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeRepository) VolumeSize(arg1 context.Context, arg2 string) (uint64, error) {
+	fake.volumeSizeMutex.Lock()
+	ret, specificReturn := fake.volumeSizeReturnsOnCall[len(fake.volumeSizeArgsForCall)]
+	fake.volumeSizeArgsForCall = append(fake.volumeSizeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.VolumeSizeStub
+	fakeReturns := fake.volumeSizeReturns
+	fake.recordInvocation("VolumeSize", []interface{}{arg1, arg2})
+	fake.volumeSizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) VolumeSizeCallCount() int {
+	fake.volumeSizeMutex.RLock()
+	defer fake.volumeSizeMutex.RUnlock()
+	return len(fake.volumeSizeArgsForCall)
+}
+
+func (fake *FakeRepository) VolumeSizeCalls(stub func(context.Context, string) (uint64, error)) {
+	fake.volumeSizeMutex.Lock()
+	defer fake.volumeSizeMutex.Unlock()
+	fake.VolumeSizeStub = stub
+}
+
+func (fake *FakeRepository) VolumeSizeArgsForCall(i int) (context.Context, string) {
+	fake.volumeSizeMutex.RLock()
+	defer fake.volumeSizeMutex.RUnlock()
+	argsForCall := fake.volumeSizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) VolumeSizeReturns(result1 uint64, result2 error) {
+	fake.volumeSizeMutex.Lock()
+	defer fake.volumeSizeMutex.Unlock()
+	fake.VolumeSizeStub = nil
+	fake.volumeSizeReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) VolumeSizeReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.volumeSizeMutex.Lock()
+	defer fake.volumeSizeMutex.Unlock()
+	fake.VolumeSizeStub = nil
+	if fake.volumeSizeReturnsOnCall == nil {
+		fake.volumeSizeReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.volumeSizeReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -976,22 +1536,36 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.destroyVolumeAndDescendantsMutex.RUnlock()
 	fake.getPrivilegedMutex.RLock()
 	defer fake.getPrivilegedMutex.RUnlock()
+	fake.getQuotaMutex.RLock()
+	defer fake.getQuotaMutex.RUnlock()
 	fake.getVolumeMutex.RLock()
 	defer fake.getVolumeMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.persistVolumeMutex.RLock()
+	defer fake.persistVolumeMutex.RUnlock()
 	fake.setPrivilegedMutex.RLock()
 	defer fake.setPrivilegedMutex.RUnlock()
 	fake.setPropertyMutex.RLock()
 	defer fake.setPropertyMutex.RUnlock()
+	fake.setQuotaMutex.RLock()
+	defer fake.setQuotaMutex.RUnlock()
+	fake.streamDeltaInMutex.RLock()
+	defer fake.streamDeltaInMutex.RUnlock()
+	fake.streamDeltaOutMutex.RLock()
+	defer fake.streamDeltaOutMutex.RUnlock()
 	fake.streamInMutex.RLock()
 	defer fake.streamInMutex.RUnlock()
 	fake.streamOutMutex.RLock()
 	defer fake.streamOutMutex.RUnlock()
 	fake.streamP2pOutMutex.RLock()
 	defer fake.streamP2pOutMutex.RUnlock()
+	fake.volumeManifestMutex.RLock()
+	defer fake.volumeManifestMutex.RUnlock()
 	fake.volumeParentMutex.RLock()
 	defer fake.volumeParentMutex.RUnlock()
+	fake.volumeSizeMutex.RLock()
+	defer fake.volumeSizeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
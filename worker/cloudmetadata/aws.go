@@ -0,0 +1,86 @@
+package cloudmetadata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const AWSMetadataURL = "http://169.254.169.254"
+
+// AWS reads metadata from the EC2 instance metadata service, using IMDSv2.
+// The team and tags are only found if the instance's tags are exposed in its
+// metadata.
+type AWS struct {
+	URL    string
+	Client *http.Client
+}
+
+func (aws *AWS) Instance(ctx context.Context) (Instance, error) {
+	header, err := aws.tokenHeader(ctx)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	id, found, err := aws.get(ctx, header, "instance-id")
+	if err != nil {
+		return Instance{}, err
+	}
+
+	if !found {
+		return Instance{}, fmt.Errorf("instance id not found in metadata")
+	}
+
+	lifecycle, _, err := aws.get(ctx, header, "instance-life-cycle")
+	if err != nil {
+		return Instance{}, err
+	}
+
+	team, _, err := aws.get(ctx, header, "tags/instance/"+TeamKey)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	tags, _, err := aws.get(ctx, header, "tags/instance/"+TagsKey)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	return Instance{
+		Name:      id,
+		Team:      team,
+		Tags:      splitTags(tags),
+		Ephemeral: lifecycle == "spot",
+	}, nil
+}
+
+// TerminationNotice checks for a spot instance interruption notice, which is
+// given two minutes before the instance is reclaimed.
+func (aws *AWS) TerminationNotice(ctx context.Context) (bool, error) {
+	header, err := aws.tokenHeader(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, found, err := aws.get(ctx, header, "spot/instance-action")
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (aws *AWS) tokenHeader(ctx context.Context) (http.Header, error) {
+	token, _, err := get(ctx, aws.Client, http.MethodPut, aws.URL+"/latest/api/token", http.Header{
+		"X-Aws-Ec2-Metadata-Token-Ttl-Seconds": {"60"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get metadata token: %w", err)
+	}
+
+	return http.Header{"X-Aws-Ec2-Metadata-Token": {token}}, nil
+}
+
+func (aws *AWS) get(ctx context.Context, header http.Header, path string) (string, bool, error) {
+	return get(ctx, aws.Client, http.MethodGet, aws.URL+"/latest/meta-data/"+path, header)
+}
@@ -0,0 +1,90 @@
+package cloudmetadata_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/concourse/concourse/worker/cloudmetadata"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("AWS", func() {
+	var (
+		server   *ghttp.Server
+		provider *cloudmetadata.AWS
+	)
+
+	metadata := func(path string, status int, body string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/latest/meta-data/"+path),
+			ghttp.VerifyHeaderKV("X-Aws-Ec2-Metadata-Token", "some-token"),
+			ghttp.RespondWith(status, body),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		server.RouteToHandler("PUT", "/latest/api/token", ghttp.CombineHandlers(
+			ghttp.VerifyHeaderKV("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", "60"),
+			ghttp.RespondWith(http.StatusOK, "some-token"),
+		))
+
+		provider = &cloudmetadata.AWS{URL: server.URL(), Client: http.DefaultClient}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Instance", func() {
+		It("derives the worker from the instance and its tags", func() {
+			server.AppendHandlers(
+				metadata("instance-id", http.StatusOK, "i-1234"),
+				metadata("instance-life-cycle", http.StatusOK, "spot"),
+				metadata("tags/instance/concourse-team", http.StatusOK, "some-team"),
+				metadata("tags/instance/concourse-tags", http.StatusOK, "gpu, large"),
+			)
+
+			instance, err := provider.Instance(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance).To(Equal(cloudmetadata.Instance{
+				Name:      "i-1234",
+				Team:      "some-team",
+				Tags:      []string{"gpu", "large"},
+				Ephemeral: true,
+			}))
+		})
+
+		It("leaves out tags which are not set", func() {
+			server.AppendHandlers(
+				metadata("instance-id", http.StatusOK, "i-1234"),
+				metadata("instance-life-cycle", http.StatusOK, "on-demand"),
+				metadata("tags/instance/concourse-team", http.StatusNotFound, ""),
+				metadata("tags/instance/concourse-tags", http.StatusNotFound, ""),
+			)
+
+			instance, err := provider.Instance(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance).To(Equal(cloudmetadata.Instance{Name: "i-1234"}))
+		})
+	})
+
+	Describe("TerminationNotice", func() {
+		It("is given once a spot interruption is scheduled", func() {
+			server.AppendHandlers(
+				metadata("spot/instance-action", http.StatusNotFound, ""),
+				metadata("spot/instance-action", http.StatusOK, `{"action":"terminate","time":"2021-09-18T08:22:00Z"}`),
+			)
+
+			notice, err := provider.TerminationNotice(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notice).To(BeFalse())
+
+			notice, err = provider.TerminationNotice(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notice).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package cloudmetadata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const GCPMetadataURL = "http://metadata.google.internal"
+
+// GCP reads metadata from the Compute Engine metadata server. The team and
+// tags are read from the instance's custom metadata attributes.
+type GCP struct {
+	URL    string
+	Client *http.Client
+}
+
+func (gcp *GCP) Instance(ctx context.Context) (Instance, error) {
+	name, found, err := gcp.get(ctx, "name")
+	if err != nil {
+		return Instance{}, err
+	}
+
+	if !found {
+		return Instance{}, fmt.Errorf("instance name not found in metadata")
+	}
+
+	preemptible, _, err := gcp.get(ctx, "scheduling/preemptible")
+	if err != nil {
+		return Instance{}, err
+	}
+
+	team, _, err := gcp.get(ctx, "attributes/"+TeamKey)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	tags, _, err := gcp.get(ctx, "attributes/"+TagsKey)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	return Instance{
+		Name:      name,
+		Team:      team,
+		Tags:      splitTags(tags),
+		Ephemeral: preemptible == "TRUE",
+	}, nil
+}
+
+// TerminationNotice checks whether the instance has been preempted, which
+// happens 30 seconds before it is stopped.
+func (gcp *GCP) TerminationNotice(ctx context.Context) (bool, error) {
+	preempted, _, err := gcp.get(ctx, "preempted")
+	if err != nil {
+		return false, err
+	}
+
+	return preempted == "TRUE", nil
+}
+
+func (gcp *GCP) get(ctx context.Context, path string) (string, bool, error) {
+	return get(ctx, gcp.Client, http.MethodGet, gcp.URL+"/computeMetadata/v1/instance/"+path, http.Header{
+		"Metadata-Flavor": {"Google"},
+	})
+}
@@ -0,0 +1,80 @@
+package cloudmetadata_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/concourse/concourse/worker/cloudmetadata"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("GCP", func() {
+	var (
+		server   *ghttp.Server
+		provider *cloudmetadata.GCP
+	)
+
+	metadata := func(path string, status int, body string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/computeMetadata/v1/instance/"+path),
+			ghttp.VerifyHeaderKV("Metadata-Flavor", "Google"),
+			ghttp.RespondWith(status, body),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		provider = &cloudmetadata.GCP{URL: server.URL(), Client: http.DefaultClient}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Instance", func() {
+		It("derives the worker from the instance and its attributes", func() {
+			server.AppendHandlers(
+				metadata("name", http.StatusOK, "some-instance"),
+				metadata("scheduling/preemptible", http.StatusOK, "TRUE"),
+				metadata("attributes/concourse-team", http.StatusOK, "some-team"),
+				metadata("attributes/concourse-tags", http.StatusNotFound, ""),
+			)
+
+			instance, err := provider.Instance(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance).To(Equal(cloudmetadata.Instance{
+				Name:      "some-instance",
+				Team:      "some-team",
+				Ephemeral: true,
+			}))
+		})
+
+		It("errors when the metadata server fails", func() {
+			server.AppendHandlers(
+				metadata("name", http.StatusInternalServerError, ""),
+			)
+
+			_, err := provider.Instance(context.Background())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("TerminationNotice", func() {
+		It("is given once the instance is preempted", func() {
+			server.AppendHandlers(
+				metadata("preempted", http.StatusOK, "FALSE"),
+				metadata("preempted", http.StatusOK, "TRUE"),
+			)
+
+			notice, err := provider.TerminationNotice(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notice).To(BeFalse())
+
+			notice, err = provider.TerminationNotice(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notice).To(BeTrue())
+		})
+	})
+})
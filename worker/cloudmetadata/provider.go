@@ -0,0 +1,99 @@
+// Package cloudmetadata reads the configuration of a worker running on a cloud
+// instance from the instance's metadata, and watches for notice of the
+// instance being terminated.
+package cloudmetadata
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TeamKey and TagsKey name the instance tags (on AWS) or metadata attributes
+// (on GCP) holding the team the worker is assigned to and a comma-separated
+// list of its tags.
+const (
+	TeamKey = "concourse-team"
+	TagsKey = "concourse-tags"
+)
+
+// Instance is the worker configuration derived from instance metadata.
+type Instance struct {
+	Name string
+	Team string
+	Tags []string
+
+	// Ephemeral is set for instances which may be reclaimed at any time,
+	// i.e. spot or preemptible instances.
+	Ephemeral bool
+}
+
+type Provider interface {
+	Instance(context.Context) (Instance, error)
+
+	// TerminationNotice returns true once the instance has been scheduled
+	// for termination.
+	TerminationNotice(context.Context) (bool, error)
+}
+
+// NewProvider returns the Provider for the named cloud, either "aws" or
+// "gcp".
+func NewProvider(name string) (Provider, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	switch name {
+	case "aws":
+		return &AWS{URL: AWSMetadataURL, Client: client}, nil
+	case "gcp":
+		return &GCP{URL: GCPMetadataURL, Client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown cloud provider: %s", name)
+	}
+}
+
+// get fetches a metadata value, returning false if it is not set.
+func get(ctx context.Context, client *http.Client, method string, url string, header http.Header) (string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return "", false, err
+	}
+
+	req.Header = header
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("%s %s: unexpected status %d", method, url, res.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", false, err
+	}
+
+	return strings.TrimSpace(string(body)), true, nil
+}
+
+func splitTags(tags string) []string {
+	var split []string
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			split = append(split, tag)
+		}
+	}
+
+	return split
+}
@@ -0,0 +1,13 @@
+package cloudmetadata_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCloudMetadata(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cloud Metadata Suite")
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/tedsuo/rata"
+	"golang.org/x/crypto/ssh"
+)
+
+// Enroll exchanges the configured enrollment token for authorization of the
+// worker's public key, after which the TSA will accept the key. Enrolling the
+// same key with the same token again is a no-op, so this is safe to run every
+// time the worker starts.
+func (config TSAConfig) Enroll(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	if config.EnrollmentURL.URL == nil {
+		return errors.New("an enrollment url must be given along with the enrollment token")
+	}
+
+	signer, err := ssh.NewSignerFromKey(config.WorkerPrivateKey.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("create signer from worker key: %w", err)
+	}
+
+	payload, err := json.Marshal(atc.WorkerEnrollmentRequest{
+		Token:     config.EnrollmentToken,
+		PublicKey: string(ssh.MarshalAuthorizedKey(signer.PublicKey())),
+	})
+	if err != nil {
+		return err
+	}
+
+	request, err := rata.NewRequestGenerator(config.EnrollmentURL.String(), atc.Routes).
+		CreateRequest(atc.EnrollWorker, nil, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(response.Body)
+		return fmt.Errorf("bad response (%d): %s", response.StatusCode, string(body))
+	}
+
+	var key atc.EnrolledWorkerKey
+	err = json.NewDecoder(response.Body).Decode(&key)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("enrolled", lager.Data{"team": key.Team, "tags": key.Tags})
+
+	return nil
+}
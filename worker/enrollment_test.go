@@ -0,0 +1,92 @@
+package worker_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/flag"
+	"github.com/onsi/gomega/ghttp"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/concourse/concourse/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Enroll", func() {
+	var (
+		fakeATC *ghttp.Server
+		config  TSAConfig
+		ctx     context.Context
+
+		expectedKey string
+	)
+
+	BeforeEach(func() {
+		fakeATC = ghttp.NewServer()
+
+		privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		expectedKey = string(ssh.MarshalAuthorizedKey(publicKey))
+
+		atcURL, err := url.Parse(fakeATC.URL())
+		Expect(err).NotTo(HaveOccurred())
+
+		config = TSAConfig{
+			WorkerPrivateKey: &flag.PrivateKey{PrivateKey: privateKey},
+			EnrollmentToken:  "some-token",
+			EnrollmentURL:    flag.URL{URL: atcURL},
+		}
+
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("enroll"))
+	})
+
+	AfterEach(func() {
+		fakeATC.Close()
+	})
+
+	It("exchanges the token for authorization of the worker's key", func() {
+		fakeATC.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", "/api/v1/worker_enrollments"),
+			ghttp.VerifyJSONRepresenting(atc.WorkerEnrollmentRequest{
+				Token:     "some-token",
+				PublicKey: expectedKey,
+			}),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, atc.EnrolledWorkerKey{PublicKey: expectedKey}),
+		))
+
+		Expect(config.Enroll(ctx)).To(Succeed())
+		Expect(fakeATC.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	Context("when the token is rejected", func() {
+		BeforeEach(func() {
+			fakeATC.AppendHandlers(ghttp.RespondWith(http.StatusUnauthorized, nil))
+		})
+
+		It("errors", func() {
+			Expect(config.Enroll(ctx)).To(MatchError(ContainSubstring("401")))
+		})
+	})
+
+	Context("when no enrollment url is given", func() {
+		BeforeEach(func() {
+			config.EnrollmentURL = flag.URL{}
+		})
+
+		It("errors", func() {
+			Expect(config.Enroll(ctx)).To(HaveOccurred())
+			Expect(fakeATC.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
@@ -16,8 +16,11 @@ import (
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
 	"github.com/containerd/containerd/errdefs"
+	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
+const windowsPlatform = "windows"
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 var _ garden.Backend = (*GardenBackend)(nil)
@@ -31,6 +34,13 @@ type GardenBackend struct {
 	rootfsManager RootfsManager
 	userNamespace UserNamespace
 	initBinPath   string
+	platform      string
+
+	networkProfiles []NetworkProfile
+	privilegedMode  bespec.PrivilegedMode
+
+	securityProfile      SecurityProfile
+	teamSecurityProfiles map[string]SecurityProfile
 
 	maxContainers  int
 	requestTimeout time.Duration
@@ -102,6 +112,25 @@ func WithInitBinPath(initBinPath string) GardenBackendOpt {
 	}
 }
 
+// WithPrivilegedMode configures how privileged containers are isolated from
+// the host. By default they run as root on the host.
+//
+func WithPrivilegedMode(mode bespec.PrivilegedMode) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.privilegedMode = mode
+	}
+}
+
+// WithPlatform configures the platform of the containers created by the
+// backend. By default they are Linux containers; "windows" creates
+// process-isolated Windows containers instead.
+//
+func WithPlatform(platform string) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.platform = platform
+	}
+}
+
 // NewGardenBackend instantiates a GardenBackend with tweakable configurations passed as Config.
 //
 func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b GardenBackend, err error) {
@@ -134,6 +163,10 @@ func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b
 		b.killer = NewKiller()
 	}
 
+	if b.privilegedMode == "" {
+		b.privilegedMode = bespec.FullPrivilegedMode
+	}
+
 	if b.rootfsManager == nil {
 		b.rootfsManager = NewRootfsManager()
 	}
@@ -164,6 +197,13 @@ func (b *GardenBackend) Start() (err error) {
 		return fmt.Errorf("setup host network failed: %w", err)
 	}
 
+	for _, profile := range b.networkProfiles {
+		err = profile.Network.SetupHostNetwork()
+		if err != nil {
+			return fmt.Errorf("setup host network failed: %w", err)
+		}
+	}
+
 	return
 }
 
@@ -195,7 +235,7 @@ func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container,
 		return nil, fmt.Errorf("new container: %w", err)
 	}
 
-	err = b.startTask(ctx, cont)
+	err = b.startTask(ctx, cont, gdnSpec.Properties)
 	if err != nil {
 		return nil, fmt.Errorf("starting task: %w", err)
 	}
@@ -220,23 +260,27 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 		return nil, fmt.Errorf("checking container capacity: %w", err)
 	}
 
-	maxUid, maxGid, err := b.userNamespace.MaxValidIds()
+	oci, err := b.ociSpec(gdnSpec)
 	if err != nil {
-		return nil, fmt.Errorf("getting uid and gid maps: %w", err)
+		return nil, err
 	}
 
-	oci, err := bespec.OciSpec(b.initBinPath, gdnSpec, maxUid, maxGid)
-	if err != nil {
-		return nil, fmt.Errorf("garden spec to oci spec: %w", err)
-	}
+	network := b.networkFor(gdnSpec.Properties)
 
-	netMounts, err := b.network.SetupMounts(gdnSpec.Handle)
+	netMounts, err := network.SetupMounts(gdnSpec.Handle)
 	if err != nil {
 		return nil, fmt.Errorf("network setup mounts: %w", err)
 	}
 
 	oci.Mounts = append(oci.Mounts, netMounts...)
 
+	if specNetwork, ok := network.(SpecNetwork); ok && gdnSpec.Properties[HermeticKey] != "true" {
+		err = specNetwork.SetupSpec(gdnSpec.Handle, oci)
+		if err != nil {
+			return nil, fmt.Errorf("network setup spec: %w", err)
+		}
+	}
+
 	labels, err := propertiesToLabels(gdnSpec.Properties)
 	if err != nil {
 		return nil, fmt.Errorf("convert properties to labels: %w", err)
@@ -244,15 +288,45 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 	return b.client.NewContainer(ctx, gdnSpec.Handle, labels, oci)
 }
 
-func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container) error {
-	task, err := cont.NewTask(ctx, cio.NullIO, containerd.WithNoNewKeyring)
+func (b *GardenBackend) ociSpec(gdnSpec garden.ContainerSpec) (*specs.Spec, error) {
+	if b.platform == windowsPlatform {
+		oci, err := bespec.OciWindowsSpec(b.initBinPath, gdnSpec)
+		if err != nil {
+			return nil, fmt.Errorf("garden spec to oci spec: %w", err)
+		}
+
+		return oci, nil
+	}
+
+	maxUid, maxGid, err := b.userNamespace.MaxValidIds()
 	if err != nil {
-		return fmt.Errorf("new task: %w", err)
+		return nil, fmt.Errorf("getting uid and gid maps: %w", err)
+	}
+
+	oci, err := bespec.OciSpec(b.initBinPath, gdnSpec, maxUid, maxGid, b.privilegedMode)
+	if err != nil {
+		return nil, fmt.Errorf("garden spec to oci spec: %w", err)
 	}
 
-	err = b.network.Add(ctx, task, cont.ID())
+	b.applySecurityProfile(oci, gdnSpec)
+
+	return oci, nil
+}
+
+// startTask starts the container's init task. Unless the container is
+// hermetic, it is added to its network; otherwise its network namespace is
+// left with only a loopback interface.
+func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container, properties garden.Properties) error {
+	task, err := cont.NewTask(ctx, cio.NullIO, newTaskOpts...)
 	if err != nil {
-		return fmt.Errorf("network add: %w", err)
+		return fmt.Errorf("new task: %w", err)
+	}
+
+	if properties[HermeticKey] != "true" {
+		err = b.networkFor(properties).Add(ctx, task, cont.ID())
+		if err != nil {
+			return fmt.Errorf("network add: %w", err)
+		}
 	}
 
 	return task.Start(ctx)
@@ -291,9 +365,17 @@ func (b *GardenBackend) Destroy(handle string) error {
 		return fmt.Errorf("gracefully killing task: %w", err)
 	}
 
-	err = b.network.Remove(ctx, task, handle)
+	labels, err := container.Labels(ctx)
 	if err != nil {
-		return fmt.Errorf("network remove: %w", err)
+		return fmt.Errorf("labels retrieval: %w", err)
+	}
+
+	properties := labelsToProperties(labels)
+	if properties[HermeticKey] != "true" {
+		err = b.networkFor(properties).Remove(ctx, task, handle)
+		if err != nil {
+			return fmt.Errorf("network remove: %w", err)
+		}
 	}
 
 	_, err = task.Delete(ctx, containerd.WithProcessKill)
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -146,6 +147,223 @@ func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	s.Equal("handle", cont.Handle())
 }
 
+func (s *BackendSuite) TestCreateContainerAddsNetwork() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, s.network.AddCallCount())
+}
+
+func (s *BackendSuite) TestCreateHermeticContainerSkipsNetwork() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	spec := minimumValidGdnSpec
+	spec.Properties = garden.Properties{runtime.HermeticKey: "true"}
+
+	_, err := s.backend.Create(spec)
+	s.NoError(err)
+
+	s.Equal(0, s.network.AddCallCount())
+	s.Equal(1, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerAddsNetworkOfProfile() {
+	teamNetwork := new(runtimefakes.FakeNetwork)
+	pipelineNetwork := new(runtimefakes.FakeNetwork)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithNetworkProfiles(
+			runtime.NetworkProfile{Network: teamNetwork, Teams: []string{"some-team"}},
+			runtime.NetworkProfile{Network: pipelineNetwork, Pipelines: []string{"some-team/some-pipeline"}},
+		),
+	)
+	s.NoError(err)
+
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	spec := minimumValidGdnSpec
+	spec.Properties = garden.Properties{
+		runtime.TeamKey:     "some-team",
+		runtime.PipelineKey: "some-pipeline",
+	}
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+	s.Equal(1, pipelineNetwork.AddCallCount())
+
+	spec.Properties = garden.Properties{
+		runtime.TeamKey:     "some-team",
+		runtime.PipelineKey: "other-pipeline",
+	}
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+	s.Equal(1, teamNetwork.AddCallCount())
+
+	spec.Properties = garden.Properties{runtime.TeamKey: "other-team"}
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+	s.Equal(1, s.network.AddCallCount())
+}
+
+func (s *BackendSuite) TestCreatePrivilegedContainerInUserNamespace() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithPrivilegedMode(bespec.UserNamespacePrivilegedMode),
+	)
+	s.NoError(err)
+
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.userns.MaxValidIdsReturns(1000, 1000, nil)
+
+	spec := minimumValidGdnSpec
+	spec.Privileged = true
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Contains(oci.Linux.Namespaces, specs.LinuxNamespace{Type: specs.UserNamespace})
+	s.Equal(bespec.OciIDMappings(false, 1000), oci.Linux.UIDMappings)
+}
+
+func (s *BackendSuite) TestCreateContainerAppliesSecurityProfiles() {
+	globalSeccomp := &specs.LinuxSeccomp{DefaultAction: specs.ActErrno}
+	teamSeccomp := &specs.LinuxSeccomp{DefaultAction: specs.ActKill}
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithSecurityProfile(runtime.SecurityProfile{
+			Seccomp:  globalSeccomp,
+			AppArmor: "global-profile",
+		}),
+		runtime.WithTeamSecurityProfiles(map[string]runtime.SecurityProfile{
+			"some-team": {Seccomp: teamSeccomp},
+		}),
+	)
+	s.NoError(err)
+
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	spec := minimumValidGdnSpec
+	spec.Properties = garden.Properties{runtime.TeamKey: "other-team"}
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Equal(globalSeccomp, oci.Linux.Seccomp)
+	s.Equal("global-profile", oci.Process.ApparmorProfile)
+
+	spec.Properties = garden.Properties{runtime.TeamKey: "some-team"}
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+
+	_, _, _, oci = s.client.NewContainerArgsForCall(1)
+	s.Equal(teamSeccomp, oci.Linux.Seccomp)
+	s.Empty(oci.Process.ApparmorProfile)
+
+	spec.Privileged = true
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+
+	_, _, _, oci = s.client.NewContainerArgsForCall(2)
+	s.Nil(oci.Linux.Seccomp)
+	s.Empty(oci.Process.ApparmorProfile)
+}
+
+func (s *BackendSuite) TestCreateWindowsContainer() {
+	network := new(runtimefakes.FakeSpecNetwork)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithPlatform("windows"),
+		runtime.WithSecurityProfile(runtime.SecurityProfile{AppArmor: "some-profile"}),
+	)
+	s.NoError(err)
+
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	spec := minimumValidGdnSpec
+	spec.RootFSPath = "raw://" + s.T().TempDir()
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+
+	s.Equal(0, s.userns.MaxValidIdsCallCount())
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Nil(oci.Linux)
+	s.NotNil(oci.Windows)
+	s.Empty(oci.Process.ApparmorProfile)
+
+	s.Equal(1, network.SetupSpecCallCount())
+	handle, networkOci := network.SetupSpecArgsForCall(0)
+	s.Equal(spec.Handle, handle)
+	s.Equal(oci, networkOci)
+
+	spec.Properties = garden.Properties{runtime.HermeticKey: "true"}
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+
+	s.Equal(1, network.SetupSpecCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerSpecNetworkFailure() {
+	network := new(runtimefakes.FakeSpecNetwork)
+	network.SetupSpecReturns(errors.New("no namespace"))
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(network),
+		runtime.WithUserNamespace(s.userns),
+	)
+	s.NoError(err)
+
+	_, err = backend.Create(minimumValidGdnSpec)
+	s.EqualError(errors.Unwrap(err), "network setup spec: no namespace")
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
 func (s *BackendSuite) TestCreateMaxContainersReached() {
 	backend, err := runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
@@ -484,6 +702,62 @@ func (s *BackendSuite) TestDestroySucceeds() {
 	s.NoError(err)
 }
 
+func (s *BackendSuite) TestDestroyHermeticContainerSkipsNetwork() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	s.client.GetContainerReturns(fakeContainer, nil)
+	fakeContainer.TaskReturns(fakeTask, nil)
+	fakeContainer.LabelsReturns(map[string]string{runtime.HermeticKey + ".0": "true"}, nil)
+
+	err := s.backend.Destroy("some handle")
+	s.NoError(err)
+
+	s.Equal(0, s.network.RemoveCallCount())
+}
+
+func (s *BackendSuite) TestDestroyRemovesNetworkOfProfile() {
+	teamNetwork := new(runtimefakes.FakeNetwork)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithNetworkProfiles(
+			runtime.NetworkProfile{Network: teamNetwork, Teams: []string{"some-team"}},
+		),
+	)
+	s.NoError(err)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	s.client.GetContainerReturns(fakeContainer, nil)
+	fakeContainer.TaskReturns(fakeTask, nil)
+	fakeContainer.LabelsReturns(map[string]string{runtime.TeamKey + ".0": "some-team"}, nil)
+
+	err = backend.Destroy("some handle")
+	s.NoError(err)
+
+	s.Equal(1, teamNetwork.RemoveCallCount())
+	s.Equal(0, s.network.RemoveCallCount())
+}
+
+func (s *BackendSuite) TestStartSetsUpNetworksOfProfiles() {
+	teamNetwork := new(runtimefakes.FakeNetwork)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithNetworkProfiles(
+			runtime.NetworkProfile{Network: teamNetwork, Teams: []string{"some-team"}},
+		),
+	)
+	s.NoError(err)
+
+	err = backend.Start()
+	s.NoError(err)
+	s.Equal(1, s.network.SetupHostNetworkCallCount())
+	s.Equal(1, teamNetwork.SetupHostNetworkCallCount())
+}
+
 func (s *BackendSuite) TestStartInitsClientAndSetsUpRestrictedNetworks() {
 	err := s.backend.Start()
 	s.NoError(err)
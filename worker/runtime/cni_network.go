@@ -35,6 +35,11 @@ type CNINetworkConfig struct {
 	// MTU is the MTU of the bridge network interface.
 	//
 	MTU int
+
+	// AdminChainName is the iptables chain which the network's firewall
+	// rules are added to. Defaults to CONCOURSE-OPERATOR.
+	//
+	AdminChainName string
 }
 
 const (
@@ -91,10 +96,18 @@ func (c CNINetworkConfig) ToJSON() string {
 }`
 
 	return fmt.Sprintf(networksConfListFormat,
-		c.NetworkName, c.BridgeName, c.Subnet, ipTablesAdminChainName,
+		c.NetworkName, c.BridgeName, c.Subnet, c.adminChainName(),
 	)
 }
 
+func (c CNINetworkConfig) adminChainName() string {
+	if c.AdminChainName == "" {
+		return ipTablesAdminChainName
+	}
+
+	return c.AdminChainName
+}
+
 // CNINetworkOpt defines a functional option that when applied, modifies the
 // configuration of a CNINetwork.
 //
@@ -120,6 +133,15 @@ func WithNameServers(nameservers []string) CNINetworkOpt {
 	}
 }
 
+// WithSearchDomains sets the search domains to be configured for the
+// /etc/resolv.conf inside the containers, replacing those of the host.
+//
+func WithSearchDomains(domains []string) CNINetworkOpt {
+	return func(n *cniNetwork) {
+		n.searchDomains = domains
+	}
+}
+
 // WithCNIClient is an implementor of the CNI interface for reaching out to CNI
 // plugins.
 //
@@ -171,6 +193,26 @@ func WithAllowHostAccess() CNINetworkOpt {
 	}
 }
 
+// WithSubnetScopedRules makes the rules restricting access to networks only
+// apply to traffic from the network's own subnet, so that several networks on
+// the host can each restrict different networks.
+//
+func WithSubnetScopedRules() CNINetworkOpt {
+	return func(n *cniNetwork) {
+		n.subnetScopedRules = true
+	}
+}
+
+// WithSharedInputChain leaves flushing the host's INPUT chain to another
+// network on the host, so that both networks' rules restricting access to the
+// host are kept.
+//
+func WithSharedInputChain() CNINetworkOpt {
+	return func(n *cniNetwork) {
+		n.sharedInputChain = true
+	}
+}
+
 // WithIptables allows for a custom implementation of the iptables.Iptables interface
 // to be provided.
 func WithIptables(ipt iptables.Iptables) CNINetworkOpt {
@@ -197,9 +239,12 @@ type cniNetwork struct {
 	store              FileStore
 	config             CNINetworkConfig
 	nameServers        []string
+	searchDomains      []string
 	binariesDir        string
 	restrictedNetworks []string
 	allowHostAccess    bool
+	subnetScopedRules  bool
+	sharedInputChain   bool
 	ipt                iptables.Iptables
 }
 
@@ -323,20 +368,27 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 const filterTable = "filter"
 
 func (n cniNetwork) setupRestrictedNetworks() error {
-	err := n.ipt.CreateChainOrFlushIfExists(filterTable, ipTablesAdminChainName)
+	adminChainName := n.config.adminChainName()
+
+	err := n.ipt.CreateChainOrFlushIfExists(filterTable, adminChainName)
 	if err != nil {
 		return fmt.Errorf("create chain or flush if exists failed: %w", err)
 	}
 
 	// Optimization that allows packets of ESTABLISHED and RELATED connections to go through without further rule matching
-	err = n.ipt.AppendRule(filterTable, ipTablesAdminChainName, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT")
+	err = n.ipt.AppendRule(filterTable, adminChainName, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT")
 	if err != nil {
 		return fmt.Errorf("appending accept rule for RELATED & ESTABLISHED connections failed: %w", err)
 	}
 
+	var source []string
+	if n.subnetScopedRules {
+		source = []string{"-s", n.config.Subnet}
+	}
+
 	for _, restrictedNetwork := range n.restrictedNetworks {
 		// Create REJECT rule in admin chain
-		err = n.ipt.AppendRule(filterTable, ipTablesAdminChainName, "-d", restrictedNetwork, "-j", "REJECT")
+		err = n.ipt.AppendRule(filterTable, adminChainName, append(source, "-d", restrictedNetwork, "-j", "REJECT")...)
 		if err != nil {
 			return fmt.Errorf("appending reject rule for restricted network %s failed: %w", restrictedNetwork, err)
 		}
@@ -353,18 +405,33 @@ func (n cniNetwork) generateResolvConfContents() ([]byte, error) {
 		resolvConfEntries, err = ParseHostResolveConf("/etc/resolv.conf")
 	}
 
+	if len(n.searchDomains) > 0 {
+		var entries []string
+		for _, entry := range resolvConfEntries {
+			if strings.HasPrefix(entry, "search") || strings.HasPrefix(entry, "domain") {
+				continue
+			}
+
+			entries = append(entries, entry)
+		}
+
+		resolvConfEntries = append(entries, "search "+strings.Join(n.searchDomains, " "))
+	}
+
 	contents = strings.Join(resolvConfEntries, "\n") + "\n"
 
 	return []byte(contents), err
 }
 
 func (n cniNetwork) restrictHostAccess() error {
-	err := n.ipt.CreateChainOrFlushIfExists(filterTable, "INPUT")
-	if err != nil {
-		return fmt.Errorf("create chain or flush if exists failed: %w", err)
+	if !n.sharedInputChain {
+		err := n.ipt.CreateChainOrFlushIfExists(filterTable, "INPUT")
+		if err != nil {
+			return fmt.Errorf("create chain or flush if exists failed: %w", err)
+		}
 	}
 
-	err = n.ipt.AppendRule(filterTable, "INPUT", "-i", n.config.BridgeName, "-j", "REJECT", "--reject-with", "icmp-host-prohibited")
+	err := n.ipt.AppendRule(filterTable, "INPUT", "-i", n.config.BridgeName, "-j", "REJECT", "--reject-with", "icmp-host-prohibited")
 	if err != nil {
 		return fmt.Errorf("error appending iptables rule: %w", err)
 	}
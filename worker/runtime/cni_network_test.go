@@ -146,6 +146,23 @@ func (s *CNINetworkSuite) TestSetupMountsCallsStoreWithNameServers() {
 	s.Equal(resolvConfContents, []byte("nameserver 6.6.7.7\nnameserver 1.2.3.4\n"))
 }
 
+func (s *CNINetworkSuite) TestSetupMountsCallsStoreWithSearchDomains() {
+	network, err := runtime.NewCNINetwork(
+		runtime.WithDefaultsForTesting(),
+		runtime.WithCNIFileStore(s.store),
+		runtime.WithNameServers([]string{"6.6.7.7"}),
+		runtime.WithSearchDomains([]string{"dc1.example.com", "example.com"}),
+		runtime.WithIptables(s.iptables),
+	)
+	s.NoError(err)
+
+	_, err = network.SetupMounts("some-handle")
+	s.NoError(err)
+
+	_, resolvConfContents := s.store.CreateArgsForCall(2)
+	s.Equal(resolvConfContents, []byte("nameserver 6.6.7.7\nsearch dc1.example.com example.com\n"))
+}
+
 func (s *CNINetworkSuite) TestSetupMountsCallsStoreWithoutNameServers() {
 	network, err := runtime.NewCNINetwork(
 		runtime.WithDefaultsForTesting(),
@@ -218,6 +235,25 @@ func (s *CNINetworkSuite) TestSetupHostNetwork() {
 			expectedChainName: "CONCOURSE-OPERATOR",
 			expectedRuleSpec:  []string{"-d", "8.8.8.8", "-j", "REJECT"},
 		},
+		"adds rule to the configured admin chain to reject IP 1.1.1.1 from the network's subnet": {
+			cniNetworkSetup: func() (runtime.Network, error) {
+				return runtime.NewCNINetwork(
+					runtime.WithDefaultsForTesting(),
+					runtime.WithCNINetworkConfig(runtime.CNINetworkConfig{
+						BridgeName:     "concourse1",
+						NetworkName:    "concourse-isolated",
+						Subnet:         "10.90.0.0/16",
+						AdminChainName: "CONCOURSE-OPERATOR-1",
+					}),
+					runtime.WithRestrictedNetworks([]string{"1.1.1.1"}),
+					runtime.WithSubnetScopedRules(),
+					runtime.WithIptables(s.iptables),
+				)
+			},
+			expectedTableName: "filter",
+			expectedChainName: "CONCOURSE-OPERATOR-1",
+			expectedRuleSpec:  []string{"-s", "10.90.0.0/16", "-d", "1.1.1.1", "-j", "REJECT"},
+		},
 		"flushes the INPUT chain": {
 			cniNetworkSetup: func() (runtime.Network, error) {
 				return runtime.NewCNINetwork(
@@ -276,6 +312,28 @@ func (s *CNINetworkSuite) TestSetupHostNetwork() {
 	}
 }
 
+func (s *CNINetworkSuite) TestSetupHostNetworkWithSharedInputChain() {
+	network, err := runtime.NewCNINetwork(
+		runtime.WithDefaultsForTesting(),
+		runtime.WithSharedInputChain(),
+		runtime.WithIptables(s.iptables),
+	)
+	s.NoError(err)
+
+	err = network.SetupHostNetwork()
+	s.NoError(err)
+
+	for i := 0; i < s.iptables.CreateChainOrFlushIfExistsCallCount(); i++ {
+		_, chainName := s.iptables.CreateChainOrFlushIfExistsArgsForCall(i)
+		s.NotEqual("INPUT", chainName)
+	}
+
+	s.Equal(2, s.iptables.AppendRuleCallCount())
+	_, chainName, rulespec := s.iptables.AppendRuleArgsForCall(1)
+	s.Equal("INPUT", chainName)
+	s.Equal([]string{"-i", "concourse0", "-j", "REJECT", "--reject-with", "icmp-host-prohibited"}, rulespec)
+}
+
 func (s *CNINetworkSuite) TestAddNilTask() {
 	err := s.network.Add(context.Background(), nil, "container-handle")
 	s.EqualError(err, "nil task")
@@ -19,6 +19,10 @@ const (
 	Path          = "PATH=/usr/local/bin:/usr/bin:/bin"
 
 	GraceTimeKey = "garden.grace-time"
+
+	// HermeticKey is the property marking a container to be created without
+	// networking.
+	HermeticKey = "concourse:hermetic"
 )
 
 type UserNotFoundError struct {
@@ -94,9 +98,14 @@ func (c *Container) Run(
 		return nil, err
 	}
 
-	err = c.rootfsManager.SetupCwd(containerSpec.Root.Path, procSpec.Cwd)
-	if err != nil {
-		return nil, fmt.Errorf("setup cwd: %w", err)
+	// the rootfs of Windows containers is only assembled from its layers
+	// once they are running, so their working directories are left to the
+	// process to create
+	if containerSpec.Windows == nil {
+		err = c.rootfsManager.SetupCwd(containerSpec.Root.Path, procSpec.Cwd)
+		if err != nil {
+			return nil, fmt.Errorf("setup cwd: %w", err)
+		}
 	}
 
 	task, err := c.container.Task(ctx, nil)
@@ -363,6 +372,9 @@ func (c *Container) setupContainerdProcSpec(gdnProcSpec garden.ProcessSpec, cont
 	cwd := gdnProcSpec.Dir
 	if cwd == "" {
 		cwd = "/"
+		if containerSpec.Windows != nil {
+			cwd = `C:\`
+		}
 	}
 
 	procSpec.Cwd = cwd
@@ -378,6 +390,16 @@ func (c *Container) setupContainerdProcSpec(gdnProcSpec garden.ProcessSpec, cont
 		}
 	}
 
+	if containerSpec.Windows != nil {
+		// Windows users are looked up by name when the process is started,
+		// and Windows images come with their own PATH
+		if gdnProcSpec.User != "" {
+			procSpec.User = specs.User{Username: gdnProcSpec.User}
+		}
+
+		return *procSpec, nil
+	}
+
 	if gdnProcSpec.User != "" {
 		var ok bool
 		var err error
@@ -293,6 +293,28 @@ func (s *ContainerSuite) TestRunWithNonRootUserHasUserPath() {
 	s.True(userEnvVarSet)
 }
 
+func (s *ContainerSuite) TestRunInWindowsContainer() {
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Process: &specs.Process{},
+		Root:    &specs.Root{},
+		Windows: &specs.Windows{},
+	}, nil)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.ExecReturns(s.containerdProcess, nil)
+
+	_, err := s.container.Run(garden.ProcessSpec{User: "ContainerAdministrator"}, garden.ProcessIO{})
+	s.NoError(err)
+
+	s.Equal(0, s.rootfsManager.SetupCwdCallCount())
+	s.Equal(0, s.rootfsManager.LookupUserCallCount())
+
+	_, _, procSpec, _ := s.containerdTask.ExecArgsForCall(0)
+	s.Equal(specs.User{Username: "ContainerAdministrator"}, procSpec.User)
+	s.Equal(`C:\`, procSpec.Cwd)
+	s.Empty(procSpec.Env)
+}
+
 func (s *ContainerSuite) TestRunWithUserLookupErrors() {
 	s.containerdContainer.SpecReturns(&specs.Spec{
 		Process: &specs.Process{},
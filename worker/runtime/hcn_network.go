@@ -0,0 +1,128 @@
+// +build windows
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Microsoft/hcsshim/hcn"
+	"github.com/containerd/containerd"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// hcnNetwork connects Windows containers to an existing Host Networking
+// Service network, such as the "nat" network Windows sets up when its
+// containers feature is enabled.
+//
+// Each container gets an endpoint on the network, named after the
+// container's handle, in a network namespace of its own.
+//
+type hcnNetwork struct {
+	network    string
+	dnsServers []string
+}
+
+var _ SpecNetwork = (*hcnNetwork)(nil)
+
+// NewHCNNetwork instantiates a network which connects Windows containers to
+// the HNS network named `network`.
+//
+func NewHCNNetwork(network string, dnsServers []string) *hcnNetwork {
+	return &hcnNetwork{
+		network:    network,
+		dnsServers: dnsServers,
+	}
+}
+
+func (n hcnNetwork) SetupHostNetwork() error {
+	_, err := hcn.GetNetworkByName(n.network)
+	if err != nil {
+		return fmt.Errorf("get network %s: %w", n.network, err)
+	}
+
+	return nil
+}
+
+func (n hcnNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
+	return nil, nil
+}
+
+func (n hcnNetwork) SetupSpec(handle string, oci *specs.Spec) error {
+	network, err := hcn.GetNetworkByName(n.network)
+	if err != nil {
+		return fmt.Errorf("get network %s: %w", n.network, err)
+	}
+
+	endpoint, err := network.CreateEndpoint(&hcn.HostComputeEndpoint{
+		Name:          handle,
+		Dns:           hcn.Dns{ServerList: n.dnsServers},
+		SchemaVersion: hcn.V2SchemaVersion(),
+	})
+	if err != nil {
+		return fmt.Errorf("create endpoint: %w", err)
+	}
+
+	namespace, err := hcn.NewNamespace(hcn.NamespaceTypeHost).Create()
+	if err != nil {
+		return fmt.Errorf("create namespace: %w", err)
+	}
+
+	err = hcn.AddNamespaceEndpoint(namespace.Id, endpoint.Id)
+	if err != nil {
+		return fmt.Errorf("add endpoint to namespace: %w", err)
+	}
+
+	if oci.Windows == nil {
+		oci.Windows = &specs.Windows{}
+	}
+
+	oci.Windows.Network = &specs.WindowsNetwork{
+		NetworkNamespace: namespace.Id,
+	}
+
+	return nil
+}
+
+// Add does nothing, as containers join their namespace when their task is
+// created.
+//
+func (n hcnNetwork) Add(ctx context.Context, task containerd.Task, containerHandle string) error {
+	return nil
+}
+
+func (n hcnNetwork) Remove(ctx context.Context, task containerd.Task, handle string) error {
+	endpoint, err := hcn.GetEndpointByName(handle)
+	if err != nil {
+		if hcn.IsNotFoundError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("get endpoint: %w", err)
+	}
+
+	err = endpoint.Delete()
+	if err != nil {
+		return fmt.Errorf("delete endpoint: %w", err)
+	}
+
+	if endpoint.HostComputeNamespace == "" {
+		return nil
+	}
+
+	namespace, err := hcn.GetNamespaceByID(endpoint.HostComputeNamespace)
+	if err != nil {
+		if hcn.IsNotFoundError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("get namespace: %w", err)
+	}
+
+	err = namespace.Delete()
+	if err != nil {
+		return fmt.Errorf("delete namespace: %w", err)
+	}
+
+	return nil
+}
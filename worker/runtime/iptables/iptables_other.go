@@ -0,0 +1,12 @@
+// +build !linux
+
+package iptables
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func New() (Iptables, error) {
+	return nil, fmt.Errorf("iptables are not supported on %s", runtime.GOOS)
+}
@@ -27,3 +27,18 @@ type Network interface {
 	//
 	Remove(ctx context.Context, task containerd.Task, handle string) (err error)
 }
+
+//counterfeiter:generate . SpecNetwork
+
+// SpecNetwork is a Network which has to be configured in the OCI spec of a
+// container before its task is created, as is the case on Windows, where
+// containers join a network namespace when they are created.
+//
+type SpecNetwork interface {
+	Network
+
+	// SetupSpec prepares the network of a container and points its spec
+	// at it.
+	//
+	SetupSpec(handle string, oci *specs.Spec) (err error)
+}
@@ -0,0 +1,71 @@
+package runtime
+
+import (
+	"code.cloudfoundry.org/garden"
+)
+
+const (
+	// TeamKey and PipelineKey are the properties naming the team and pipeline
+	// a container belongs to, used to choose its network profile.
+	TeamKey     = "concourse:team"
+	PipelineKey = "concourse:pipeline"
+)
+
+// NetworkProfile is a network which the containers of some teams or
+// pipelines are put into, instead of the default network.
+//
+type NetworkProfile struct {
+	Network Network
+
+	// Teams are the names of the teams whose containers are put into the
+	// network.
+	//
+	Teams []string
+
+	// Pipelines are the pipelines whose containers are put into the network,
+	// in the form TEAM/PIPELINE. They take precedence over Teams.
+	//
+	Pipelines []string
+}
+
+// WithNetworkProfiles configures the networks used by the backend for the
+// containers of particular teams or pipelines. Other containers use the
+// network configured by WithNetwork.
+//
+func WithNetworkProfiles(profiles ...NetworkProfile) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.networkProfiles = profiles
+	}
+}
+
+// networkFor chooses the network of a container with the given properties.
+//
+func (b *GardenBackend) networkFor(properties garden.Properties) Network {
+	team, pipeline := properties[TeamKey], properties[PipelineKey]
+
+	if pipeline != "" {
+		for _, profile := range b.networkProfiles {
+			if containsString(profile.Pipelines, team+"/"+pipeline) {
+				return profile.Network
+			}
+		}
+	}
+
+	for _, profile := range b.networkProfiles {
+		if containsString(profile.Teams, team) {
+			return profile.Network
+		}
+	}
+
+	return b.network
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package runtime
+
+import (
+	"code.cloudfoundry.org/garden"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// SecurityProfile is the seccomp and AppArmor confinement of unprivileged
+// containers. Privileged containers are never confined.
+//
+type SecurityProfile struct {
+	// Seccomp replaces the default seccomp profile when set.
+	//
+	Seccomp *specs.LinuxSeccomp
+
+	// AppArmor is the name of an AppArmor profile loaded on the host which
+	// containers are run under. When empty, no AppArmor profile is applied.
+	//
+	AppArmor string
+}
+
+// WithSecurityProfile configures the security profile applied to the
+// unprivileged containers of every team without one of its own.
+//
+func WithSecurityProfile(profile SecurityProfile) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.securityProfile = profile
+	}
+}
+
+// WithTeamSecurityProfiles configures the security profiles applied to the
+// unprivileged containers of particular teams, keyed by team name.
+//
+func WithTeamSecurityProfiles(profiles map[string]SecurityProfile) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.teamSecurityProfiles = profiles
+	}
+}
+
+// applySecurityProfile confines an unprivileged container according to the
+// profile of its team, falling back to the global one.
+//
+func (b *GardenBackend) applySecurityProfile(oci *specs.Spec, gdnSpec garden.ContainerSpec) {
+	if gdnSpec.Privileged {
+		return
+	}
+
+	profile, found := b.teamSecurityProfiles[gdnSpec.Properties[TeamKey]]
+	if !found {
+		profile = b.securityProfile
+	}
+
+	if profile.Seccomp != nil {
+		oci.Linux.Seccomp = profile.Seccomp
+	}
+
+	if profile.AppArmor != "" {
+		oci.Process.ApparmorProfile = profile.AppArmor
+	}
+}
@@ -0,0 +1,79 @@
+package spec
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// GPUsKey is the property setting the number of GPUs requested by a
+// container.
+const GPUsKey = "concourse:gpus"
+
+// GPUDevicesGlob matches the device nodes of the host's GPUs, along with
+// those needed to drive them.
+var GPUDevicesGlob = "/dev/nvidia*"
+
+// OciGPUDevices gives a container which requests GPUs through its GPUsKey
+// property the devices matching `glob`, along with the cgroup rules allowing
+// access to them.
+//
+// GPUs are not partitioned between containers: the number of GPUs requested
+// is only used to place the container on a worker with enough of them, and
+// every such container on the worker gets all of its GPUs.
+//
+func OciGPUDevices(glob string, properties garden.Properties) ([]specs.LinuxDevice, []specs.LinuxDeviceCgroup, error) {
+	value, found := properties[GPUsKey]
+	if !found {
+		return nil, nil, nil
+	}
+
+	gpus, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s property: %w", GPUsKey, err)
+	}
+
+	if gpus <= 0 {
+		return nil, nil, nil
+	}
+
+	paths, err := filepath.Glob(glob)
+	if err != nil {
+		return nil, nil, fmt.Errorf("glob %s: %w", glob, err)
+	}
+
+	if len(paths) == 0 {
+		return nil, nil, fmt.Errorf("no gpu devices found matching %s", glob)
+	}
+
+	var (
+		devices []specs.LinuxDevice
+		rules   []specs.LinuxDeviceCgroup
+	)
+
+	for _, path := range paths {
+		device, isDevice, err := gpuDevice(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !isDevice {
+			// e.g. the /dev/nvidia-caps directory
+			continue
+		}
+
+		devices = append(devices, device)
+		rules = append(rules, specs.LinuxDeviceCgroup{
+			Allow:  true,
+			Type:   device.Type,
+			Major:  intRef(device.Major),
+			Minor:  intRef(device.Minor),
+			Access: "rwm",
+		})
+	}
+
+	return devices, rules, nil
+}
@@ -0,0 +1,31 @@
+package spec
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+)
+
+// gpuDevice describes the character device at `path`, reporting whether it
+// is one at all.
+//
+func gpuDevice(path string) (specs.LinuxDevice, bool, error) {
+	var stat unix.Stat_t
+	err := unix.Stat(path, &stat)
+	if err != nil {
+		return specs.LinuxDevice{}, false, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	if stat.Mode&unix.S_IFMT != unix.S_IFCHR {
+		return specs.LinuxDevice{}, false, nil
+	}
+
+	return specs.LinuxDevice{
+		Path:     path,
+		Type:     "c",
+		Major:    int64(unix.Major(uint64(stat.Rdev))),
+		Minor:    int64(unix.Minor(uint64(stat.Rdev))),
+		FileMode: &worldReadWrite,
+	}, true, nil
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package spec
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func gpuDevice(path string) (specs.LinuxDevice, bool, error) {
+	return specs.LinuxDevice{}, false, fmt.Errorf("gpu devices are not supported on %s", runtime.GOOS)
+}
@@ -1,8 +1,26 @@
 package spec
 
-import "github.com/opencontainers/runtime-spec/specs-go"
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 
-const DefaultInitBinPath = "/usr/local/concourse/bin/init"
+	"code.cloudfoundry.org/garden"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	DefaultInitBinPath = "/usr/local/concourse/bin/init"
+
+	// TmpfsKey is the property listing the tmpfs mounts for a container.
+	TmpfsKey = "concourse:tmpfs"
+
+	// ShmSizeKey is the property setting the size of a container's /dev/shm,
+	// in bytes.
+	ShmSizeKey = "concourse:shm-size"
+)
 
 var (
 	DefaultContainerMounts = []specs.Mount{
@@ -80,3 +98,80 @@ func clearReadOnly(m *specs.Mount) {
 	}
 	m.Options = opt
 }
+
+// TmpfsMount is a tmpfs mount requested for a container through its
+// TmpfsKey property, as a JSON list.
+type TmpfsMount struct {
+	Path string `json:"path"`
+	Size uint64 `json:"size,omitempty"`
+}
+
+// OciTmpfsMounts converts the tmpfs mounts requested through the container's
+// properties to oci spec mounts.
+//
+func OciTmpfsMounts(properties garden.Properties) (mounts []specs.Mount, err error) {
+	value, found := properties[TmpfsKey]
+	if !found {
+		return
+	}
+
+	var tmpfsMounts []TmpfsMount
+	err = json.Unmarshal([]byte(value), &tmpfsMounts)
+	if err != nil {
+		err = fmt.Errorf("invalid %s property: %w", TmpfsKey, err)
+		return
+	}
+
+	for _, tmpfsMount := range tmpfsMounts {
+		if !filepath.IsAbs(tmpfsMount.Path) {
+			err = fmt.Errorf("tmpfs path %q must be absolute", tmpfsMount.Path)
+			return
+		}
+
+		options := []string{"nosuid", "nodev", "mode=1777"}
+		if tmpfsMount.Size > 0 {
+			options = append(options, fmt.Sprintf("size=%d", tmpfsMount.Size))
+		}
+
+		mounts = append(mounts, specs.Mount{
+			Destination: tmpfsMount.Path,
+			Type:        "tmpfs",
+			Source:      "tmpfs",
+			Options:     options,
+		})
+	}
+
+	return
+}
+
+// OciShmSize sets the size of the /dev/shm mount to the one requested through
+// the container's ShmSizeKey property, if any.
+//
+func OciShmSize(mounts []specs.Mount, properties garden.Properties) error {
+	value, found := properties[ShmSizeKey]
+	if !found {
+		return nil
+	}
+
+	size, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s property: %w", ShmSizeKey, err)
+	}
+
+	for i, mount := range mounts {
+		if mount.Destination != "/dev/shm" {
+			continue
+		}
+
+		options := make([]string, 0, len(mount.Options)+1)
+		for _, option := range mount.Options {
+			if !strings.HasPrefix(option, "size=") {
+				options = append(options, option)
+			}
+		}
+
+		mounts[i].Options = append(options, fmt.Sprintf("size=%d", size))
+	}
+
+	return nil
+}
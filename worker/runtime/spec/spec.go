@@ -25,9 +25,26 @@ func swapLimitEnabled() bool {
 	return err == nil
 }
 
+// PrivilegedMode determines how privileged containers are isolated from the
+// host.
+//
+type PrivilegedMode string
+
+const (
+	// FullPrivilegedMode runs privileged containers as root on the host.
+	//
+	FullPrivilegedMode PrivilegedMode = "full"
+
+	// UserNamespacePrivilegedMode runs privileged containers with all
+	// capabilities, but in a user namespace which maps root in the container
+	// to an unprivileged user on the host, like unprivileged containers.
+	//
+	UserNamespacePrivilegedMode PrivilegedMode = "userns"
+)
+
 // OciSpec converts a given `garden` container specification to an OCI spec.
 //
-func OciSpec(initBinPath string, gdn garden.ContainerSpec, maxUid, maxGid uint32) (oci *specs.Spec, err error) {
+func OciSpec(initBinPath string, gdn garden.ContainerSpec, maxUid, maxGid uint32, privilegedMode PrivilegedMode) (oci *specs.Spec, err error) {
 	if gdn.Handle == "" {
 		err = fmt.Errorf("handle must be specified")
 		return
@@ -49,11 +66,23 @@ func OciSpec(initBinPath string, gdn garden.ContainerSpec, maxUid, maxGid uint32
 		return
 	}
 
+	var tmpfsMounts []specs.Mount
+	tmpfsMounts, err = OciTmpfsMounts(gdn.Properties)
+	if err != nil {
+		return
+	}
+
+	mounts = append(mounts, tmpfsMounts...)
+
+	// containers running as root on the host are the only ones without a
+	// user namespace
+	hostRoot := gdn.Privileged && privilegedMode != UserNamespacePrivilegedMode
+
 	resources := OciResources(gdn.Limits, isSwapLimitEnabled)
-	cgroupsPath := OciCgroupsPath(baseCgroupsPath, gdn.Handle, gdn.Privileged)
+	cgroupsPath := OciCgroupsPath(baseCgroupsPath, gdn.Handle, hostRoot)
 
 	oci = merge(
-		defaultGardenOciSpec(initBinPath, gdn.Privileged, maxUid, maxGid),
+		defaultGardenOciSpec(initBinPath, gdn.Privileged, hostRoot, maxUid, maxGid),
 		&specs.Spec{
 			Version:  specs.Version,
 			Hostname: gdn.Handle,
@@ -70,6 +99,22 @@ func OciSpec(initBinPath string, gdn garden.ContainerSpec, maxUid, maxGid uint32
 		},
 	)
 
+	err = OciShmSize(oci.Mounts, gdn.Properties)
+	if err != nil {
+		return
+	}
+
+	var (
+		gpuDevices []specs.LinuxDevice
+		gpuRules   []specs.LinuxDeviceCgroup
+	)
+	gpuDevices, gpuRules, err = OciGPUDevices(GPUDevicesGlob, gdn.Properties)
+	if err != nil {
+		return
+	}
+
+	oci.Linux.Devices = append(oci.Linux.Devices, gpuDevices...)
+	oci.Linux.Resources.Devices = append(oci.Linux.Resources.Devices, gpuRules...)
 	return
 }
 
@@ -189,12 +234,16 @@ func OciCgroupsPath(basePath, handle string, privileged bool) string {
 // defaultGardenOciSpec represents a default set of properties necessary in
 // order to satisfy the garden interface.
 //
+// Privileged containers get all capabilities and devices, but only those
+// running as root on the host (`hostRoot`) skip the user namespace and get
+// writable /sys and cgroup mounts.
+//
 // ps.: this spec is NOT completed - it must be merged with more properties to
 // form a properly working container.
 //
-func defaultGardenOciSpec(initBinPath string, privileged bool, maxUid, maxGid uint32) *specs.Spec {
+func defaultGardenOciSpec(initBinPath string, privileged, hostRoot bool, maxUid, maxGid uint32) *specs.Spec {
 	var (
-		namespaces   = OciNamespaces(privileged)
+		namespaces   = OciNamespaces(hostRoot)
 		capabilities = OciCapabilities(privileged)
 	)
 
@@ -210,10 +259,10 @@ func defaultGardenOciSpec(initBinPath string, privileged bool, maxUid, maxGid ui
 				Devices: AnyContainerDevices,
 			},
 			Devices:     Devices(privileged),
-			UIDMappings: OciIDMappings(privileged, maxUid),
-			GIDMappings: OciIDMappings(privileged, maxGid),
+			UIDMappings: OciIDMappings(hostRoot, maxUid),
+			GIDMappings: OciIDMappings(hostRoot, maxGid),
 		},
-		Mounts: ContainerMounts(privileged, initBinPath),
+		Mounts: ContainerMounts(hostRoot, initBinPath),
 	}
 
 	if !privileged {
@@ -1,6 +1,8 @@
 package spec_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"code.cloudfoundry.org/garden"
@@ -83,7 +85,7 @@ func (s *SpecSuite) TestContainerSpecValidations() {
 		},
 	} {
 		s.T().Run(tc.desc, func(t *testing.T) {
-			_, err := spec.OciSpec(spec.DefaultInitBinPath, tc.spec, dummyMaxUid, dummyMaxGid)
+			_, err := spec.OciSpec(spec.DefaultInitBinPath, tc.spec, dummyMaxUid, dummyMaxGid, spec.FullPrivilegedMode)
 			s.Error(err)
 		})
 	}
@@ -184,6 +186,103 @@ func (s *SpecSuite) TestOciSpecBindMounts() {
 	}
 }
 
+func (s *SpecSuite) TestOciTmpfsMounts() {
+	for _, tc := range []struct {
+		desc       string
+		properties garden.Properties
+		expected   []specs.Mount
+		succeeds   bool
+	}{
+		{
+			desc:     "no property",
+			succeeds: true,
+		},
+		{
+			desc:       "invalid property",
+			succeeds:   false,
+			properties: garden.Properties{spec.TmpfsKey: "{"},
+		},
+		{
+			desc:       "non-absolute path",
+			succeeds:   false,
+			properties: garden.Properties{spec.TmpfsKey: `[{"path":"tmp"}]`},
+		},
+		{
+			desc:       "with and w/out size",
+			succeeds:   true,
+			properties: garden.Properties{spec.TmpfsKey: `[{"path":"/tmp"},{"path":"/cache","size":1024}]`},
+			expected: []specs.Mount{
+				{
+					Destination: "/tmp",
+					Type:        "tmpfs",
+					Source:      "tmpfs",
+					Options:     []string{"nosuid", "nodev", "mode=1777"},
+				},
+				{
+					Destination: "/cache",
+					Type:        "tmpfs",
+					Source:      "tmpfs",
+					Options:     []string{"nosuid", "nodev", "mode=1777", "size=1024"},
+				},
+			},
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			actual, err := spec.OciTmpfsMounts(tc.properties)
+			if !tc.succeeds {
+				s.Error(err)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tc.expected, actual)
+		})
+	}
+}
+
+func (s *SpecSuite) TestOciShmSize() {
+	mounts := []specs.Mount{
+		{
+			Destination: "/dev/shm",
+			Type:        "tmpfs",
+			Source:      "shm",
+			Options:     []string{"nosuid", "size=65536k"},
+		},
+	}
+
+	err := spec.OciShmSize(mounts, garden.Properties{spec.ShmSizeKey: "1073741824"})
+	s.NoError(err)
+	s.Equal([]string{"nosuid", "size=1073741824"}, mounts[0].Options)
+
+	err = spec.OciShmSize(mounts, garden.Properties{spec.ShmSizeKey: "lots"})
+	s.Error(err)
+}
+
+func (s *SpecSuite) TestOciGPUDevices() {
+	devices, rules, err := spec.OciGPUDevices("/dev/nul[l]", garden.Properties{})
+	s.NoError(err)
+	s.Empty(devices)
+	s.Empty(rules)
+
+	devices, rules, err = spec.OciGPUDevices("/dev/nul[l]", garden.Properties{spec.GPUsKey: "1"})
+	s.NoError(err)
+	s.Len(devices, 1)
+	s.Equal("/dev/null", devices[0].Path)
+	s.Equal("c", devices[0].Type)
+	s.Equal(int64(1), devices[0].Major)
+	s.Equal(int64(3), devices[0].Minor)
+	s.Len(rules, 1)
+	s.True(rules[0].Allow)
+	s.Equal(int64(1), *rules[0].Major)
+	s.Equal(int64(3), *rules[0].Minor)
+
+	_, _, err = spec.OciGPUDevices("/dev/no-such-gpu*", garden.Properties{spec.GPUsKey: "1"})
+	s.Error(err)
+
+	_, _, err = spec.OciGPUDevices("/dev/nul[l]", garden.Properties{spec.GPUsKey: "some"})
+	s.Error(err)
+}
+
 func (s *SpecSuite) TestOciNamespaces() {
 	for _, tc := range []struct {
 		desc       string
@@ -520,10 +619,82 @@ func (s *SpecSuite) TestContainerSpec() {
 		},
 	} {
 		s.T().Run(tc.desc, func(t *testing.T) {
-			actual, err := spec.OciSpec(spec.DefaultInitBinPath, tc.gdn, dummyMaxUid, dummyMaxGid)
+			actual, err := spec.OciSpec(spec.DefaultInitBinPath, tc.gdn, dummyMaxUid, dummyMaxGid, spec.FullPrivilegedMode)
 			s.NoError(err)
 
 			tc.check(actual)
 		})
 	}
 }
+
+func (s *SpecSuite) TestContainerSpecWithUserNamespacePrivilegedMode() {
+	oci, err := spec.OciSpec(spec.DefaultInitBinPath, garden.ContainerSpec{
+		Handle: "handle", RootFSPath: "raw:///rootfs",
+		Privileged: true,
+	}, 1000, 1000, spec.UserNamespacePrivilegedMode)
+	s.NoError(err)
+
+	s.Equal(spec.UnprivilegedContainerNamespaces, oci.Linux.Namespaces)
+	s.Equal(spec.OciIDMappings(false, 1000), oci.Linux.UIDMappings)
+	s.Equal(spec.OciIDMappings(false, 1000), oci.Linux.GIDMappings)
+	s.Equal(spec.ContainerMounts(false, spec.DefaultInitBinPath), oci.Mounts)
+	s.Equal("garden/handle", oci.Linux.CgroupsPath)
+
+	s.Equal(spec.PrivilegedContainerCapabilities, *oci.Process.Capabilities)
+	s.Empty(oci.Linux.Seccomp)
+}
+
+func (s *SpecSuite) TestOciWindowsSpec() {
+	rootfs := s.T().TempDir()
+
+	s.NoError(ioutil.WriteFile(
+		filepath.Join(rootfs, spec.LayerChainFile),
+		[]byte(`["/layers/top","/layers/base"]`),
+		0644,
+	))
+
+	oci, err := spec.OciWindowsSpec(`/concourse/bin/init.exe`, garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw://" + rootfs,
+		Env:        []string{"FOO=bar"},
+		BindMounts: []garden.BindMount{
+			{SrcPath: `C:\volumes\input`, DstPath: `C:\build\input`, Mode: garden.BindMountModeRO},
+			{SrcPath: `C:\volumes\output`, DstPath: `C:\build\output`, Mode: garden.BindMountModeRW},
+		},
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{Weight: 20000},
+			Memory: garden.MemoryLimits{LimitInBytes: 1024},
+		},
+	})
+	s.NoError(err)
+
+	s.Nil(oci.Linux)
+	s.Equal([]string{"/layers/top", "/layers/base", rootfs}, oci.Windows.LayerFolders)
+	s.Equal([]string{spec.WindowsInitDir + `\init.exe`}, oci.Process.Args)
+	s.Equal([]string{"FOO=bar"}, oci.Process.Env)
+	s.Equal("ContainerUser", oci.Process.User.Username)
+	s.Equal([]specs.Mount{
+		{Source: `C:\volumes\input`, Destination: `C:\build\input`, Options: []string{"ro"}},
+		{Source: `C:\volumes\output`, Destination: `C:\build\output`},
+		{Source: "/concourse/bin", Destination: spec.WindowsInitDir, Options: []string{"ro"}},
+	}, oci.Mounts)
+	s.Equal(uint16(10000), *oci.Windows.Resources.CPU.Shares)
+	s.Equal(uint64(1024), *oci.Windows.Resources.Memory.Limit)
+
+	oci, err = spec.OciWindowsSpec(`/concourse/bin/init.exe`, garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw://" + s.T().TempDir(),
+		Privileged: true,
+	})
+	s.NoError(err)
+
+	s.Len(oci.Windows.LayerFolders, 1)
+	s.Equal("ContainerAdministrator", oci.Process.User.Username)
+	s.Nil(oci.Windows.Resources.CPU)
+	s.Nil(oci.Windows.Resources.Memory)
+
+	_, err = spec.OciWindowsSpec(`/concourse/bin/init.exe`, garden.ContainerSpec{
+		RootFSPath: "raw://" + rootfs,
+	})
+	s.Error(err)
+}
@@ -0,0 +1,177 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// LayerChainFile lists, from the top-most down to the base, the parent
+	// layers of a Windows container layer.
+	//
+	LayerChainFile = "layerchain.json"
+
+	// WindowsInitDir is where the directory holding the init binary is
+	// mounted in Windows containers, which can only bind mount directories.
+	//
+	WindowsInitDir = `C:\concourse-init`
+
+	windowsDefaultCwd = `C:\`
+
+	// Windows containers run as one of the two users which every Windows
+	// base image provides.
+	//
+	windowsUser       = "ContainerUser"
+	windowsPrivileged = "ContainerAdministrator"
+
+	maxWindowsCPUShares = 10000
+)
+
+// OciWindowsSpec converts a given `garden` container specification to the OCI
+// spec of a process-isolated Windows container.
+//
+// The rootfs of the container must be a Windows layer, such as a
+// copy-on-write volume of an image created by baggageclaim's windows driver:
+// it is used as the container's scratch layer, on top of the layers listed in
+// its LayerChainFile.
+//
+func OciWindowsSpec(initBinPath string, gdn garden.ContainerSpec) (oci *specs.Spec, err error) {
+	if gdn.Handle == "" {
+		err = fmt.Errorf("handle must be specified")
+		return
+	}
+
+	if gdn.RootFSPath == "" {
+		gdn.RootFSPath = gdn.Image.URI
+	}
+
+	var rootfs string
+	rootfs, err = rootfsDir(gdn.RootFSPath)
+	if err != nil {
+		return
+	}
+
+	var layers []string
+	layers, err = ReadLayerChain(rootfs)
+	if err != nil {
+		return
+	}
+
+	var mounts []specs.Mount
+	mounts, err = OciWindowsBindMounts(gdn.BindMounts)
+	if err != nil {
+		return
+	}
+
+	mounts = append(mounts, specs.Mount{
+		Source:      filepath.Dir(initBinPath),
+		Destination: WindowsInitDir,
+		Options:     []string{"ro"},
+	})
+
+	user := windowsUser
+	if gdn.Privileged {
+		user = windowsPrivileged
+	}
+
+	oci = &specs.Spec{
+		Version:  specs.Version,
+		Hostname: gdn.Handle,
+		Process: &specs.Process{
+			Args: []string{WindowsInitDir + `\` + filepath.Base(initBinPath)},
+			Env:  gdn.Env,
+			Cwd:  windowsDefaultCwd,
+			User: specs.User{Username: user},
+		},
+		Root:        &specs.Root{},
+		Mounts:      mounts,
+		Annotations: map[string]string(gdn.Properties),
+		Windows: &specs.Windows{
+			LayerFolders: append(layers, rootfs),
+			Resources:    OciWindowsResources(gdn.Limits),
+		},
+	}
+
+	return
+}
+
+// ReadLayerChain lists the parent layers of the Windows layer at `layer`,
+// which has none if it has no LayerChainFile.
+//
+func ReadLayerChain(layer string) ([]string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(layer, LayerChainFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+
+		return nil, fmt.Errorf("read layer chain: %w", err)
+	}
+
+	var chain []string
+	err = json.Unmarshal(content, &chain)
+	if err != nil {
+		return nil, fmt.Errorf("parse layer chain: %w", err)
+	}
+
+	return chain, nil
+}
+
+// OciWindowsBindMounts converts garden bindmounts to the mounts of a Windows
+// container. Windows has no notion of propagation, so only the read-only
+// option carries over.
+//
+func OciWindowsBindMounts(bindMounts []garden.BindMount) (mounts []specs.Mount, err error) {
+	for _, bindMount := range bindMounts {
+		if bindMount.SrcPath == "" || bindMount.DstPath == "" {
+			err = fmt.Errorf("src and dst must not be empty")
+			return
+		}
+
+		mount := specs.Mount{
+			Source:      bindMount.SrcPath,
+			Destination: bindMount.DstPath,
+		}
+
+		if bindMount.Mode == garden.BindMountModeRO {
+			mount.Options = []string{"ro"}
+		}
+
+		mounts = append(mounts, mount)
+	}
+
+	return
+}
+
+// OciWindowsResources converts garden limits to the resources of a Windows
+// container, capping CPU shares to the 10000 Windows allows.
+//
+func OciWindowsResources(limits garden.Limits) *specs.WindowsResources {
+	resources := &specs.WindowsResources{}
+
+	shares := limits.CPU.LimitInShares
+	if limits.CPU.Weight > 0 {
+		shares = limits.CPU.Weight
+	}
+
+	if shares > 0 {
+		if shares > maxWindowsCPUShares {
+			shares = maxWindowsCPUShares
+		}
+
+		windowsShares := uint16(shares)
+		resources.CPU = &specs.WindowsCPUResources{Shares: &windowsShares}
+	}
+
+	if limit := limits.Memory.LimitInBytes; limit > 0 {
+		resources.Memory = &specs.WindowsMemoryResources{Limit: &limit}
+	}
+
+	return resources
+}
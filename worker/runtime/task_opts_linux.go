@@ -0,0 +1,8 @@
+package runtime
+
+import "github.com/containerd/containerd"
+
+// newTaskOpts keeps the init process of containers from getting a new
+// session keyring, which would count against the host's keyring quota.
+//
+var newTaskOpts = []containerd.NewTaskOpts{containerd.WithNoNewKeyring}
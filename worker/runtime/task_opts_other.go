@@ -0,0 +1,7 @@
+// +build !linux
+
+package runtime
+
+import "github.com/containerd/containerd"
+
+var newTaskOpts []containerd.NewTaskOpts
@@ -0,0 +1,311 @@
+// Package sandbox provides a Garden backend which isolates the processes of
+// each container on darwin workers, where there are no containers to run them
+// in.
+//
+// Every container is leased one of a pool of user accounts, which its
+// processes run as, confined by a sandbox-exec profile to its own scratch
+// directory and the volumes mounted into it.
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+)
+
+// ErrNoUsersAvailable is returned when creating a container while every user
+// account is leased to another one.
+var ErrNoUsersAvailable = errors.New("no sandbox users available")
+
+const (
+	// SudoPath and SandboxExecPath are what processes are run through to
+	// switch to the container's user and confine them to its profile.
+	SudoPath        = "/usr/bin/sudo"
+	SandboxExecPath = "/usr/bin/sandbox-exec"
+
+	profileFile = "sandbox.sb"
+	rootDir     = "root"
+	homeDir     = "home"
+	tmpDir      = "tmp"
+)
+
+type Config struct {
+	// Users are the accounts leased to containers, one container at a time.
+	Users []string
+
+	// ScratchDir is where the scratch directories of containers are created.
+	ScratchDir string
+
+	// PrivateDirs can't be read by any container, except for the volumes
+	// mounted into it, e.g. the worker's work dir and volumes dir.
+	PrivateDirs []string
+}
+
+// Backend wraps a Garden backend, e.g. Houdini's, which runs processes on the
+// host.
+type Backend struct {
+	garden.Backend
+
+	config Config
+
+	lock      sync.Mutex
+	sandboxes map[string]*sandbox
+}
+
+type sandbox struct {
+	user     string
+	uid, gid int
+	dir      string
+}
+
+func NewBackend(backend garden.Backend, config Config) *Backend {
+	return &Backend{
+		Backend:   backend,
+		config:    config,
+		sandboxes: map[string]*sandbox{},
+	}
+}
+
+func (b *Backend) Create(spec garden.ContainerSpec) (garden.Container, error) {
+	// privileged containers opt out of the sandbox, running as the worker
+	// user like they would without it
+	if spec.Privileged {
+		return b.Backend.Create(spec)
+	}
+
+	if spec.Handle == "" {
+		return nil, errors.New("handle must be specified")
+	}
+
+	sb, err := b.lease(spec.Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := b.create(sb, spec)
+	if err != nil {
+		b.release(spec.Handle)
+		_ = os.RemoveAll(sb.dir)
+		return nil, err
+	}
+
+	return container, nil
+}
+
+func (b *Backend) create(sb *sandbox, spec garden.ContainerSpec) (garden.Container, error) {
+	err := os.MkdirAll(sb.dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("create scratch dir: %w", err)
+	}
+
+	writable := []string{}
+	for _, dir := range []string{rootDir, homeDir, tmpDir} {
+		path := filepath.Join(sb.dir, dir)
+
+		err := sb.mkdir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		writable = append(writable, path)
+	}
+
+	// without an image, the process runs in its scratch dir rather than one
+	// shared with the worker
+	if spec.RootFSPath == "" && spec.Image.URI == "" {
+		spec.RootFSPath = "raw://" + filepath.Join(sb.dir, rootDir)
+	}
+
+	readable := []string{}
+
+	for _, mount := range spec.BindMounts {
+		if mount.Mode == garden.BindMountModeRO {
+			readable = append(readable, mount.SrcPath)
+			continue
+		}
+
+		err := sb.chownAll(mount.SrcPath)
+		if err != nil {
+			return nil, err
+		}
+
+		writable = append(writable, mount.SrcPath)
+	}
+
+	if path := rootfsPath(spec); path != "" {
+		writable = append(writable, path)
+	}
+
+	profile := Profile(writable, readable, append([]string{b.config.ScratchDir}, b.config.PrivateDirs...))
+
+	err = ioutil.WriteFile(filepath.Join(sb.dir, profileFile), []byte(profile), 0644)
+	if err != nil {
+		return nil, fmt.Errorf("write profile: %w", err)
+	}
+
+	container, err := b.Backend.Create(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Container{Container: container, sandbox: sb}, nil
+}
+
+func (b *Backend) Destroy(handle string) error {
+	err := b.Backend.Destroy(handle)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	sb, found := b.sandboxes[handle]
+	b.lock.Unlock()
+
+	if found {
+		err = os.RemoveAll(sb.dir)
+		if err != nil {
+			return fmt.Errorf("remove scratch dir: %w", err)
+		}
+
+		b.release(handle)
+	}
+
+	return nil
+}
+
+func (b *Backend) Lookup(handle string) (garden.Container, error) {
+	container, err := b.Backend.Lookup(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.wrap(container), nil
+}
+
+func (b *Backend) Containers(properties garden.Properties) ([]garden.Container, error) {
+	containers, err := b.Backend.Containers(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, container := range containers {
+		containers[i] = b.wrap(container)
+	}
+
+	return containers, nil
+}
+
+func (b *Backend) wrap(container garden.Container) garden.Container {
+	b.lock.Lock()
+	sb, found := b.sandboxes[container.Handle()]
+	b.lock.Unlock()
+
+	if !found {
+		return container
+	}
+
+	return &Container{Container: container, sandbox: sb}
+}
+
+func (b *Backend) lease(handle string) (*sandbox, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, found := b.sandboxes[handle]; found {
+		return nil, fmt.Errorf("handle already exists: %s", handle)
+	}
+
+	leased := map[string]bool{}
+	for _, sb := range b.sandboxes {
+		leased[sb.user] = true
+	}
+
+	for _, name := range b.config.Users {
+		if leased[name] {
+			continue
+		}
+
+		account, err := user.Lookup(name)
+		if err != nil {
+			return nil, fmt.Errorf("lookup user %s: %w", name, err)
+		}
+
+		uid, err := strconv.Atoi(account.Uid)
+		if err != nil {
+			return nil, fmt.Errorf("uid of user %s: %w", name, err)
+		}
+
+		gid, err := strconv.Atoi(account.Gid)
+		if err != nil {
+			return nil, fmt.Errorf("gid of user %s: %w", name, err)
+		}
+
+		sb := &sandbox{
+			user: name,
+			uid:  uid,
+			gid:  gid,
+			dir:  filepath.Join(b.config.ScratchDir, handle),
+		}
+
+		b.sandboxes[handle] = sb
+
+		return sb, nil
+	}
+
+	return nil, ErrNoUsersAvailable
+}
+
+func (b *Backend) release(handle string) {
+	b.lock.Lock()
+	delete(b.sandboxes, handle)
+	b.lock.Unlock()
+}
+
+func (sb *sandbox) mkdir(path string) error {
+	err := os.Mkdir(path, 0700)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+
+	return sb.chown(path)
+}
+
+func (sb *sandbox) chown(path string) error {
+	err := os.Lchown(path, sb.uid, sb.gid)
+	if err != nil {
+		return fmt.Errorf("chown %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (sb *sandbox) chownAll(root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		return sb.chown(path)
+	})
+}
+
+func rootfsPath(spec garden.ContainerSpec) string {
+	uri := spec.RootFSPath
+	if uri == "" {
+		uri = spec.Image.URI
+	}
+
+	if !strings.HasPrefix(uri, "raw://") {
+		return ""
+	}
+
+	return strings.TrimPrefix(uri, "raw://")
+}
@@ -0,0 +1,157 @@
+package sandbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden/gardenfakes"
+	"github.com/concourse/concourse/worker/sandbox"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backend", func() {
+	var (
+		tmpdir        string
+		scratchDir    string
+		volume        string
+		username      string
+		fakeBackend   *gardenfakes.FakeBackend
+		fakeContainer *gardenfakes.FakeContainer
+		backend       *sandbox.Backend
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "sandbox")
+		Expect(err).ToNot(HaveOccurred())
+
+		// profiles refer to real paths, e.g. /private/var rather than /var
+		tmpdir, err = filepath.EvalSymlinks(tmpdir)
+		Expect(err).ToNot(HaveOccurred())
+
+		scratchDir = filepath.Join(tmpdir, "scratch")
+		Expect(os.Mkdir(scratchDir, 0755)).To(Succeed())
+
+		volume = filepath.Join(tmpdir, "volume")
+		Expect(os.Mkdir(volume, 0755)).To(Succeed())
+
+		// containers can only be leased the current user without root
+		current, err := user.Current()
+		Expect(err).ToNot(HaveOccurred())
+		username = current.Username
+
+		fakeContainer = new(gardenfakes.FakeContainer)
+		fakeContainer.HandleReturns("some-handle")
+
+		fakeBackend = new(gardenfakes.FakeBackend)
+		fakeBackend.CreateReturns(fakeContainer, nil)
+		fakeBackend.LookupReturns(fakeContainer, nil)
+
+		backend = sandbox.NewBackend(fakeBackend, sandbox.Config{
+			Users:       []string{username},
+			ScratchDir:  scratchDir,
+			PrivateDirs: []string{tmpdir},
+		})
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpdir)).To(Succeed())
+	})
+
+	Describe("Create", func() {
+		It("runs containers without an image in a scratch dir of their own", func() {
+			_, err := backend.Create(garden.ContainerSpec{
+				Handle: "some-handle",
+				BindMounts: []garden.BindMount{
+					{SrcPath: volume, DstPath: "/some/output", Mode: garden.BindMountModeRW},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			root := filepath.Join(scratchDir, "some-handle", "root")
+			Expect(root).To(BeADirectory())
+
+			spec := fakeBackend.CreateArgsForCall(0)
+			Expect(spec.RootFSPath).To(Equal("raw://" + root))
+
+			profile, err := ioutil.ReadFile(filepath.Join(scratchDir, "some-handle", "sandbox.sb"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(profile)).To(ContainSubstring(`(subpath "` + root + `")`))
+			Expect(string(profile)).To(ContainSubstring(`(subpath "` + volume + `")`))
+		})
+
+		It("leases each user to one container at a time", func() {
+			_, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = backend.Create(garden.ContainerSpec{Handle: "other-handle"})
+			Expect(err).To(Equal(sandbox.ErrNoUsersAvailable))
+
+			Expect(backend.Destroy("some-handle")).To(Succeed())
+			Expect(filepath.Join(scratchDir, "some-handle")).ToNot(BeADirectory())
+
+			_, err = backend.Create(garden.ContainerSpec{Handle: "other-handle"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("releases the user when the container can't be created", func() {
+			fakeBackend.CreateReturns(nil, garden.ContainerNotFoundError{})
+
+			_, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).To(HaveOccurred())
+			Expect(filepath.Join(scratchDir, "some-handle")).ToNot(BeADirectory())
+
+			fakeBackend.CreateReturns(fakeContainer, nil)
+
+			_, err = backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does not sandbox privileged containers", func() {
+			container, err := backend.Create(garden.ContainerSpec{Handle: "some-handle", Privileged: true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(container).To(Equal(fakeContainer))
+			Expect(filepath.Join(scratchDir, "some-handle")).ToNot(BeADirectory())
+		})
+	})
+
+	Describe("running processes", func() {
+		BeforeEach(func() {
+			_, err := backend.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("runs them as the container's user in its sandbox", func() {
+			container, err := backend.Lookup("some-handle")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = container.Run(garden.ProcessSpec{
+				Path: "/bin/echo",
+				Args: []string{"hello"},
+				Env:  []string{"FOO=bar"},
+			}, garden.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			dir := filepath.Join(scratchDir, "some-handle")
+
+			spec, _ := fakeContainer.RunArgsForCall(0)
+			Expect(spec.Path).To(Equal(sandbox.SudoPath))
+			Expect(spec.Args).To(Equal([]string{
+				"-n", "-E", "-u", username, "--",
+				sandbox.SandboxExecPath, "-f", filepath.Join(dir, "sandbox.sb"),
+				"/bin/echo", "hello",
+			}))
+			Expect(spec.Env).To(Equal([]string{
+				"FOO=bar",
+				"USER=" + username,
+				"HOME=" + filepath.Join(dir, "home"),
+				"TMPDIR=" + filepath.Join(dir, "tmp"),
+			}))
+		})
+	})
+})
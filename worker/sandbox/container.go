@@ -0,0 +1,41 @@
+package sandbox
+
+import (
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+)
+
+// Container runs processes as the user leased to it, confined by its
+// sandbox profile.
+type Container struct {
+	garden.Container
+
+	sandbox *sandbox
+}
+
+func (c *Container) Run(spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+	return c.Container.Run(c.sandbox.processSpec(spec), processIO)
+}
+
+// processSpec runs a process through sudo, as the container's user, and
+// sandbox-exec, keeping its environment and giving it a home and temporary
+// directory of its own.
+func (sb *sandbox) processSpec(spec garden.ProcessSpec) garden.ProcessSpec {
+	args := []string{
+		"-n", "-E", "-u", sb.user, "--",
+		SandboxExecPath, "-f", filepath.Join(sb.dir, profileFile),
+		spec.Path,
+	}
+
+	spec.Args = append(args, spec.Args...)
+	spec.Path = SudoPath
+	spec.User = ""
+	spec.Env = append(spec.Env,
+		"USER="+sb.user,
+		"HOME="+filepath.Join(sb.dir, homeDir),
+		"TMPDIR="+filepath.Join(sb.dir, tmpDir),
+	)
+
+	return spec
+}
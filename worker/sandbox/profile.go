@@ -0,0 +1,57 @@
+package sandbox
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Profile generates the sandbox-exec profile of a container: its processes
+// may only write to the `writable` paths, and may read anything but the
+// `private` paths, unless they are also readable or writable.
+//
+// Paths are resolved through any symlinks, since the sandbox matches against
+// real paths, e.g. /private/var rather than /var.
+func Profile(writable, readable, private []string) string {
+	var profile strings.Builder
+
+	fmt.Fprintln(&profile, "(version 1)")
+	fmt.Fprintln(&profile, "(allow default)")
+
+	fmt.Fprintln(&profile, "(deny file-write*)")
+	fmt.Fprintln(&profile, "(allow file-write*")
+	fmt.Fprintln(&profile, `  (literal "/dev/null")`)
+	fmt.Fprintln(&profile, `  (literal "/dev/zero")`)
+	fmt.Fprintln(&profile, `  (literal "/dev/dtracehelper")`)
+	fmt.Fprintln(&profile, `  (regex #"^/dev/tty")`)
+	fmt.Fprintln(&profile, `  (regex #"^/dev/fd/")`)
+	writeSubpaths(&profile, writable)
+	fmt.Fprintln(&profile, ")")
+
+	if len(private) > 0 {
+		fmt.Fprintln(&profile, "(deny file-read*")
+		writeSubpaths(&profile, private)
+		fmt.Fprintln(&profile, ")")
+
+		fmt.Fprintln(&profile, "(allow file-read*")
+		writeSubpaths(&profile, append(writable, readable...))
+		fmt.Fprintln(&profile, ")")
+	}
+
+	return profile.String()
+}
+
+func writeSubpaths(profile *strings.Builder, paths []string) {
+	for _, path := range paths {
+		fmt.Fprintf(profile, "  (subpath %q)\n", realPath(path))
+	}
+}
+
+func realPath(path string) string {
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	return real
+}
@@ -0,0 +1,29 @@
+package sandbox_test
+
+import (
+	"github.com/concourse/concourse/worker/sandbox"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profile", func() {
+	It("only allows writing to writable paths", func() {
+		profile := sandbox.Profile([]string{"/scratch/handle/root"}, nil, nil)
+
+		Expect(profile).To(ContainSubstring("(deny file-write*)\n(allow file-write*\n"))
+		Expect(profile).To(ContainSubstring(`  (subpath "/scratch/handle/root")`))
+		Expect(profile).ToNot(ContainSubstring("file-read*"))
+	})
+
+	It("denies reading private paths, except for readable and writable ones", func() {
+		profile := sandbox.Profile(
+			[]string{"/volumes/output"},
+			[]string{"/volumes/input"},
+			[]string{"/volumes"},
+		)
+
+		Expect(profile).To(ContainSubstring("(deny file-read*\n  (subpath \"/volumes\")\n)"))
+		Expect(profile).To(ContainSubstring("(allow file-read*\n  (subpath \"/volumes/output\")\n  (subpath \"/volumes/input\")\n)"))
+	})
+})
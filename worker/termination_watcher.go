@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+)
+
+//counterfeiter:generate . TerminationNotifier
+type TerminationNotifier interface {
+	TerminationNotice(context.Context) (bool, error)
+}
+
+// TerminationWatcher is an ifrit.Runner that polls for notice of the worker's
+// instance being terminated, e.g. a spot instance being reclaimed. On notice
+// it retires the worker, so that no more containers are placed on it and
+// running ones get the grace period to finish, and then deletes the worker so
+// that the ATC doesn't wait for it to stall.
+type TerminationWatcher struct {
+	logger      lager.Logger
+	interval    time.Duration
+	gracePeriod time.Duration
+	tsaClient   TSAClient
+	notifier    TerminationNotifier
+}
+
+func NewTerminationWatcher(
+	logger lager.Logger,
+	pollInterval time.Duration,
+	gracePeriod time.Duration,
+	tsaClient TSAClient,
+	notifier TerminationNotifier,
+) *TerminationWatcher {
+	return &TerminationWatcher{
+		logger:      logger,
+		interval:    pollInterval,
+		gracePeriod: gracePeriod,
+		tsaClient:   tsaClient,
+		notifier:    notifier,
+	}
+}
+
+func (watcher *TerminationWatcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ticker := time.NewTicker(watcher.interval)
+	defer ticker.Stop()
+
+	close(ready)
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx := lagerctx.NewContext(context.Background(), watcher.logger)
+
+			terminating, err := watcher.notifier.TerminationNotice(ctx)
+			if err != nil {
+				watcher.logger.Error("failed-to-check-for-termination-notice", err)
+				continue
+			}
+
+			if terminating {
+				return watcher.drain(signals)
+			}
+
+		case sig := <-signals:
+			watcher.logger.Info("watch-cancelled-by-signal", lager.Data{"signal": sig})
+			return nil
+		}
+	}
+}
+
+func (watcher *TerminationWatcher) drain(signals <-chan os.Signal) error {
+	logger := watcher.logger.Session("drain")
+	ctx := lagerctx.NewContext(context.Background(), logger)
+
+	logger.Info("received-termination-notice", lager.Data{"grace-period": watcher.gracePeriod.String()})
+
+	err := watcher.tsaClient.Retire(ctx)
+	if err != nil {
+		logger.Error("failed-to-retire-worker", err)
+	}
+
+	timer := time.NewTimer(watcher.gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case sig := <-signals:
+		logger.Info("drain-cancelled-by-signal", lager.Data{"signal": sig})
+	}
+
+	logger.Info("deleting-worker")
+
+	err = watcher.tsaClient.Delete(ctx)
+	if err != nil {
+		logger.Error("failed-to-delete-worker", err)
+		return err
+	}
+
+	return nil
+}
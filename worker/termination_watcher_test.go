@@ -0,0 +1,102 @@
+package worker_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker"
+	"github.com/concourse/concourse/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("TerminationWatcher", func() {
+	var (
+		fakeClient   *workerfakes.FakeTSAClient
+		fakeNotifier *workerfakes.FakeTerminationNotifier
+
+		gracePeriod time.Duration
+
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(workerfakes.FakeTSAClient)
+		fakeNotifier = new(workerfakes.FakeTerminationNotifier)
+
+		gracePeriod = 100 * time.Millisecond
+	})
+
+	JustBeforeEach(func() {
+		watcher := worker.NewTerminationWatcher(
+			lagertest.NewTestLogger("test"),
+			10*time.Millisecond,
+			gracePeriod,
+			fakeClient,
+			fakeNotifier,
+		)
+
+		process = ifrit.Background(watcher)
+		Eventually(process.Ready()).Should(BeClosed())
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	Context("until a termination notice is given", func() {
+		BeforeEach(func() {
+			fakeNotifier.TerminationNoticeReturns(false, nil)
+		})
+
+		It("keeps polling without draining the worker", func() {
+			Eventually(fakeNotifier.TerminationNoticeCallCount).Should(BeNumerically(">", 2))
+			Expect(fakeClient.RetireCallCount()).To(BeZero())
+			Expect(fakeClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when polling fails", func() {
+		BeforeEach(func() {
+			fakeNotifier.TerminationNoticeReturns(false, errors.New("nope"))
+		})
+
+		It("keeps polling", func() {
+			Eventually(fakeNotifier.TerminationNoticeCallCount).Should(BeNumerically(">", 2))
+			Consistently(process.Wait()).ShouldNot(Receive())
+		})
+	})
+
+	Context("when a termination notice is given", func() {
+		BeforeEach(func() {
+			fakeNotifier.TerminationNoticeReturnsOnCall(1, true, nil)
+		})
+
+		It("retires the worker, then deletes it after the grace period", func() {
+			Eventually(fakeClient.RetireCallCount).Should(Equal(1))
+
+			retiredAt := time.Now()
+			Eventually(fakeClient.DeleteCallCount).Should(Equal(1))
+			Expect(time.Since(retiredAt)).To(BeNumerically(">=", gracePeriod/2))
+
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+
+		Context("when signalled during the grace period", func() {
+			BeforeEach(func() {
+				gracePeriod = time.Hour
+			})
+
+			It("deletes the worker straight away", func() {
+				Eventually(fakeClient.RetireCallCount).Should(Equal(1))
+
+				process.Signal(os.Interrupt)
+				Eventually(fakeClient.DeleteCallCount).Should(Equal(1))
+			})
+		})
+	})
+})
@@ -10,6 +10,9 @@ type TSAConfig struct {
 	Hosts            []string            `long:"host" default:"127.0.0.1:2222" description:"TSA host to forward the worker through. Can be specified multiple times."`
 	PublicKey        flag.AuthorizedKeys `long:"public-key" description:"File containing a public key to expect from the TSA."`
 	WorkerPrivateKey *flag.PrivateKey    `long:"worker-private-key" required:"true" description:"File containing the private key to use when authenticating to the TSA."`
+
+	EnrollmentToken string   `long:"enrollment-token" description:"Enrollment token issued by an admin, exchanged for authorization of the worker's key instead of pre-authorizing it on the TSA."`
+	EnrollmentURL   flag.URL `long:"enrollment-url" description:"External URL of the web node with which to exchange the enrollment token. Required when an enrollment token is given."`
 }
 
 func (config TSAConfig) Client(worker atc.Worker) *tsa.Client {
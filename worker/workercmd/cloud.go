@@ -0,0 +1,55 @@
+package workercmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/worker/cloudmetadata"
+)
+
+type CloudMetadataConfig struct {
+	Provider string `long:"provider" choice:"aws" choice:"gcp" description:"Cloud provider whose instance metadata to derive the worker's name, team, tags and whether it is ephemeral from, unless configured explicitly. The worker is retired when the instance is about to be terminated."`
+
+	TerminationPollInterval time.Duration `long:"termination-poll-interval" default:"5s"  description:"Interval on which to poll the instance metadata for a termination notice."`
+	TerminationGracePeriod  time.Duration `long:"termination-grace-period"  default:"90s" description:"Duration to let running containers finish after a termination notice before deleting the worker."`
+}
+
+// configureWorker fills in the worker's configuration from the instance
+// metadata. Explicitly configured values take precedence, while tags are
+// added to those configured.
+func (config CloudMetadataConfig) configureWorker(logger lager.Logger, worker *WorkerConfig) (cloudmetadata.Provider, error) {
+	provider, err := cloudmetadata.NewProvider(config.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	instance, err := provider.Instance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get instance metadata: %w", err)
+	}
+
+	logger.Info("loaded-instance-metadata", lager.Data{
+		"name":      instance.Name,
+		"team":      instance.Team,
+		"tags":      instance.Tags,
+		"ephemeral": instance.Ephemeral,
+	})
+
+	if worker.Name == "" {
+		worker.Name = instance.Name
+	}
+
+	if worker.TeamName == "" {
+		worker.TeamName = instance.Team
+	}
+
+	worker.Tags = append(worker.Tags, instance.Tags...)
+	worker.Ephemeral = worker.Ephemeral || instance.Ephemeral
+
+	return provider, nil
+}
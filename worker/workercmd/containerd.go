@@ -3,6 +3,7 @@
 package workercmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -18,8 +19,11 @@ import (
 	"github.com/concourse/concourse/worker/network"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
+	"sigs.k8s.io/yaml"
 )
 
 const containerdNamespace = "concourse"
@@ -60,16 +64,33 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 ) (ifrit.Runner, error) {
 	const graceTime = 0
 
-	cniNetwork, err := cmd.buildUpNetworkOpts(logger, dnsServers)
+	profiles, err := cmd.loadNetworkProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load network profiles: %w", err)
+	}
+
+	cniNetwork, err := cmd.buildUpNetworkOpts(logger, dnsServers, profiles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CNI network opts: %w", err)
 	}
 
-	backendOpts, err := cmd.buildUpBackendOpts(logger, cniNetwork)
+	networkProfiles, err := cmd.buildUpNetworkProfiles(logger, dnsServers, profiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network profiles: %w", err)
+	}
+
+	backendOpts, err := cmd.buildUpBackendOpts(logger, cniNetwork, networkProfiles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create contianerd backend opts: %w", err)
 	}
 
+	securityOpts, err := cmd.buildUpSecurityOpts(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load security profiles: %w", err)
+	}
+
+	backendOpts = append(backendOpts, securityOpts...)
+
 	gardenBackend, err := runtime.NewGardenBackend(
 		libcontainerd.New(containerdAddr, containerdNamespace, cmd.Containerd.RequestTimeout),
 		backendOpts...,
@@ -87,7 +108,7 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 	), nil
 }
 
-func (cmd *WorkerCommand) buildUpNetworkOpts(logger lager.Logger, dnsServers []string) (runtime.Network, error) {
+func (cmd *WorkerCommand) buildUpNetworkOpts(logger lager.Logger, dnsServers []string, profiles []networkProfileConfig) (runtime.Network, error) {
 	logger.Debug("create-cni-network-opts")
 	if cmd.Containerd.CNIPluginsDir == "" {
 		pluginsDir := concourseCmd.DiscoverAsset("bin")
@@ -106,8 +127,23 @@ func (cmd *WorkerCommand) buildUpNetworkOpts(logger lager.Logger, dnsServers []s
 		networkOpts = append(networkOpts, runtime.WithNameServers(dnsServers))
 	}
 
-	if len(cmd.Containerd.Network.RestrictedNetworks) > 0 {
-		networkOpts = append(networkOpts, runtime.WithRestrictedNetworks(cmd.Containerd.Network.RestrictedNetworks))
+	if len(cmd.Containerd.Network.DNSSearchDomains) > 0 {
+		networkOpts = append(networkOpts, runtime.WithSearchDomains(cmd.Containerd.Network.DNSSearchDomains))
+	}
+
+	restrictedNetworks := cmd.Containerd.Network.RestrictedNetworks
+	if len(profiles) > 0 {
+		// keep the default network's containers away from the profiles'
+		// networks, and keep its rules from applying to them
+		for _, profile := range profiles {
+			restrictedNetworks = append(restrictedNetworks, profile.Pool)
+		}
+
+		networkOpts = append(networkOpts, runtime.WithSubnetScopedRules())
+	}
+
+	if len(restrictedNetworks) > 0 {
+		networkOpts = append(networkOpts, runtime.WithRestrictedNetworks(restrictedNetworks))
 	}
 
 	// DNS proxy won't work without allowing access to host network
@@ -116,9 +152,7 @@ func (cmd *WorkerCommand) buildUpNetworkOpts(logger lager.Logger, dnsServers []s
 	}
 
 	networkConfig := runtime.DefaultCNINetworkConfig
-	if cmd.Containerd.Network.Pool != "" {
-		networkConfig.Subnet = cmd.Containerd.Network.Pool
-	}
+	networkConfig.Subnet = cmd.Containerd.defaultPool()
 	var err error
 	networkConfig.MTU, err = cmd.Containerd.mtu()
 	if err != nil {
@@ -129,7 +163,226 @@ func (cmd *WorkerCommand) buildUpNetworkOpts(logger lager.Logger, dnsServers []s
 	return runtime.NewCNINetwork(networkOpts...)
 }
 
-func (cmd *WorkerCommand) buildUpBackendOpts(logger lager.Logger, cniNetwork runtime.Network) ([]runtime.GardenBackendOpt, error) {
+// networkProfileConfig is an entry of the file given by
+// --containerd-network-profiles.
+type networkProfileConfig struct {
+	Name               string   `json:"name"`
+	Pool               string   `json:"pool"`
+	DNSServers         []string `json:"dns_servers,omitempty"`
+	DNSSearchDomains   []string `json:"dns_search_domains,omitempty"`
+	RestrictedNetworks []string `json:"restricted_networks,omitempty"`
+	Teams              []string `json:"teams,omitempty"`
+	Pipelines          []string `json:"pipelines,omitempty"`
+}
+
+func (cmd *WorkerCommand) loadNetworkProfiles() ([]networkProfileConfig, error) {
+	path := cmd.Containerd.Network.Profiles.Path()
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []networkProfileConfig
+	err = yaml.Unmarshal(content, &profiles)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	pools := []string{cmd.Containerd.defaultPool()}
+	names := map[string]bool{}
+	for _, profile := range profiles {
+		if profile.Name == "" {
+			return nil, fmt.Errorf("network profile is missing a name")
+		}
+
+		if names[profile.Name] {
+			return nil, fmt.Errorf("network profile '%s' is defined more than once", profile.Name)
+		}
+		names[profile.Name] = true
+
+		_, pool, err := net.ParseCIDR(profile.Pool)
+		if err != nil {
+			return nil, fmt.Errorf("network profile '%s' has an invalid pool: %w", profile.Name, err)
+		}
+
+		for _, other := range pools {
+			_, otherPool, err := net.ParseCIDR(other)
+			if err != nil {
+				return nil, fmt.Errorf("invalid network pool '%s': %w", other, err)
+			}
+
+			if pool.Contains(otherPool.IP) || otherPool.Contains(pool.IP) {
+				return nil, fmt.Errorf("network profile '%s' has a pool overlapping %s", profile.Name, other)
+			}
+		}
+
+		pools = append(pools, profile.Pool)
+	}
+
+	return profiles, nil
+}
+
+func (cmd *WorkerCommand) buildUpNetworkProfiles(logger lager.Logger, dnsServers []string, profiles []networkProfileConfig) ([]runtime.NetworkProfile, error) {
+	logger.Debug("create-network-profiles")
+
+	mtu, err := cmd.Containerd.mtu()
+	if err != nil {
+		return nil, fmt.Errorf("container MTU: %w", err)
+	}
+
+	networkProfiles := []runtime.NetworkProfile{}
+	for i, profile := range profiles {
+		restrictedNetworks := append([]string{cmd.Containerd.defaultPool()}, cmd.Containerd.Network.RestrictedNetworks...)
+		restrictedNetworks = append(restrictedNetworks, profile.RestrictedNetworks...)
+		for j, other := range profiles {
+			if i != j {
+				restrictedNetworks = append(restrictedNetworks, other.Pool)
+			}
+		}
+
+		nameServers := dnsServers
+		if len(profile.DNSServers) > 0 {
+			nameServers = profile.DNSServers
+		}
+
+		searchDomains := cmd.Containerd.Network.DNSSearchDomains
+		if len(profile.DNSSearchDomains) > 0 {
+			searchDomains = profile.DNSSearchDomains
+		}
+
+		networkOpts := []runtime.CNINetworkOpt{
+			runtime.WithCNIBinariesDir(cmd.Containerd.CNIPluginsDir),
+			runtime.WithCNIFileStore(runtime.FileStoreWithWorkDir(cmd.WorkDir.Path())),
+			runtime.WithRestrictedNetworks(restrictedNetworks),
+			runtime.WithSubnetScopedRules(),
+			runtime.WithSharedInputChain(),
+			runtime.WithCNINetworkConfig(runtime.CNINetworkConfig{
+				BridgeName:     fmt.Sprintf("concourse%d", i+1),
+				NetworkName:    "concourse-" + profile.Name,
+				Subnet:         profile.Pool,
+				MTU:            mtu,
+				AdminChainName: fmt.Sprintf("CONCOURSE-OPERATOR-%d", i+1),
+			}),
+		}
+
+		if len(nameServers) > 0 {
+			networkOpts = append(networkOpts, runtime.WithNameServers(nameServers))
+		}
+
+		if len(searchDomains) > 0 {
+			networkOpts = append(networkOpts, runtime.WithSearchDomains(searchDomains))
+		}
+
+		if cmd.Containerd.Network.AllowHostAccess || cmd.Containerd.Network.DNS.Enable {
+			networkOpts = append(networkOpts, runtime.WithAllowHostAccess())
+		}
+
+		network, err := runtime.NewCNINetwork(networkOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("network profile '%s': %w", profile.Name, err)
+		}
+
+		networkProfiles = append(networkProfiles, runtime.NetworkProfile{
+			Network:   network,
+			Teams:     profile.Teams,
+			Pipelines: profile.Pipelines,
+		})
+	}
+
+	return networkProfiles, nil
+}
+
+// securityProfileConfig is an entry of the file given by
+// --containerd-team-security-profiles.
+type securityProfileConfig struct {
+	Teams    []string `json:"teams"`
+	Seccomp  string   `json:"seccomp,omitempty"`
+	AppArmor string   `json:"apparmor,omitempty"`
+}
+
+func (cmd *WorkerCommand) buildUpSecurityOpts(logger lager.Logger) ([]runtime.GardenBackendOpt, error) {
+	logger.Debug("create-security-profiles")
+
+	security := cmd.Containerd.Security
+
+	globalProfile := runtime.SecurityProfile{
+		AppArmor: security.AppArmorProfile,
+	}
+
+	if security.SeccompProfile.Path() != "" {
+		seccomp, err := loadSeccompProfile(security.SeccompProfile.Path())
+		if err != nil {
+			return nil, err
+		}
+
+		globalProfile.Seccomp = seccomp
+	}
+
+	opts := []runtime.GardenBackendOpt{
+		runtime.WithSecurityProfile(globalProfile),
+	}
+
+	path := security.TeamProfiles.Path()
+	if path == "" {
+		return opts, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []securityProfileConfig
+	err = yaml.Unmarshal(content, &configs)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	teamProfiles := map[string]runtime.SecurityProfile{}
+	for _, config := range configs {
+		profile := runtime.SecurityProfile{
+			AppArmor: config.AppArmor,
+		}
+
+		if config.Seccomp != "" {
+			profile.Seccomp, err = loadSeccompProfile(config.Seccomp)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, team := range config.Teams {
+			if _, found := teamProfiles[team]; found {
+				return nil, fmt.Errorf("team '%s' has more than one security profile", team)
+			}
+
+			teamProfiles[team] = profile
+		}
+	}
+
+	return append(opts, runtime.WithTeamSecurityProfiles(teamProfiles)), nil
+}
+
+func loadSeccompProfile(path string) (*specs.LinuxSeccomp, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var seccomp specs.LinuxSeccomp
+	err = json.Unmarshal(content, &seccomp)
+	if err != nil {
+		return nil, fmt.Errorf("parse seccomp profile %s: %w", path, err)
+	}
+
+	return &seccomp, nil
+}
+
+func (cmd *WorkerCommand) buildUpBackendOpts(logger lager.Logger, cniNetwork runtime.Network, networkProfiles []runtime.NetworkProfile) ([]runtime.GardenBackendOpt, error) {
 	logger.Debug("create-containerd-backendOpts")
 
 	if cmd.Containerd.InitBin == "" {
@@ -142,9 +395,11 @@ func (cmd *WorkerCommand) buildUpBackendOpts(logger lager.Logger, cniNetwork run
 
 	return []runtime.GardenBackendOpt{
 		runtime.WithNetwork(cniNetwork),
+		runtime.WithNetworkProfiles(networkProfiles...),
 		runtime.WithRequestTimeout(cmd.Containerd.RequestTimeout),
 		runtime.WithMaxContainers(cmd.Containerd.MaxContainers),
 		runtime.WithInitBinPath(cmd.Containerd.InitBin),
+		runtime.WithPrivilegedMode(cmd.Containerd.PrivilegedMode),
 	}, nil
 }
 
@@ -164,6 +419,12 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 		return nil, err
 	}
 
+	if cmd.Containerd.PrivilegedMode == bespec.UserNamespacePrivilegedMode {
+		// root in privileged containers is not root on the host, so their
+		// volumes have to be remapped like those of unprivileged containers
+		cmd.Baggageclaim.RemapPrivilegedVolumes = true
+	}
+
 	if cmd.Containerd.Config.Path() != "" {
 		config = cmd.Containerd.Config.Path()
 	} else {
@@ -263,6 +524,14 @@ func (cmd ContainerdRuntime) externalIP() (net.IP, error) {
 	return net.ParseIP(localIP), nil
 }
 
+func (cmd ContainerdRuntime) defaultPool() string {
+	if cmd.Network.Pool != "" {
+		return cmd.Network.Pool
+	}
+
+	return runtime.DefaultCNINetworkConfig.Subnet
+}
+
 func (cmd ContainerdRuntime) mtu() (int, error) {
 	if cmd.Network.MTU != 0 {
 		return cmd.Network.MTU, nil
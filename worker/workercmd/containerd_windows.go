@@ -0,0 +1,108 @@
+package workercmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	concourseCmd "github.com/concourse/concourse/cmd"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/libcontainerd"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+)
+
+const containerdNamespace = "concourse"
+
+// containerdRunner spawns a containerd and a Garden server process which runs
+// process-isolated Windows containers through it, stacking up their layers
+// with hcsshim.
+func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, error) {
+	const pipe = `\\.\pipe\concourse-containerd`
+
+	var (
+		root  = filepath.Join(cmd.WorkDir.Path(), "containerd")
+		state = filepath.Join(cmd.WorkDir.Path(), "containerd-state")
+		bin   = "containerd.exe"
+	)
+
+	err := os.MkdirAll(root, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Containerd.Bin != "" {
+		bin = cmd.Containerd.Bin
+	}
+
+	args := []string{
+		"--address=" + pipe,
+		"--root=" + root,
+		"--state=" + state,
+	}
+
+	if cmd.Containerd.Config.Path() != "" {
+		args = append(args, "--config="+cmd.Containerd.Config.Path())
+	}
+
+	command := exec.Command(bin, args...)
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	if cmd.Containerd.InitBin == "" {
+		initBin := concourseCmd.DiscoverAsset(`bin\init.exe`)
+		if initBin == "" {
+			return nil, fmt.Errorf("could not find init binary. Try setting the --containerd-init-bin flag")
+		}
+		cmd.Containerd.InitBin = initBin
+	}
+
+	gardenBackend, err := runtime.NewGardenBackend(
+		libcontainerd.New(pipe, containerdNamespace, cmd.Containerd.RequestTimeout),
+		runtime.WithPlatform("windows"),
+		runtime.WithNetwork(runtime.NewHCNNetwork(
+			cmd.Containerd.Network.HNSNetwork,
+			cmd.Containerd.Network.DNSServers,
+		)),
+		runtime.WithRequestTimeout(cmd.Containerd.RequestTimeout),
+		runtime.WithMaxContainers(cmd.Containerd.MaxContainers),
+		runtime.WithInitBinPath(cmd.Containerd.InitBin),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("containerd containerd init: %w", err)
+	}
+
+	members := grouper.Members{
+		{
+			Name: "containerd",
+			Runner: CmdRunner{
+				Cmd: command,
+				Ready: func() bool {
+					client := libcontainerd.New(pipe, containerdNamespace, cmd.Containerd.RequestTimeout)
+					err := client.Init()
+					if err != nil {
+						logger.Info("failed-to-connect-to-containerd", lager.Data{"error": err.Error()})
+					}
+					return err == nil
+				},
+				Timeout: 10 * time.Second,
+			},
+		},
+		{
+			Name: "containerd-garden-backend",
+			Runner: newGardenServerRunner(
+				"tcp",
+				cmd.bindAddr(),
+				0,
+				&gardenBackend,
+				logger,
+			),
+		},
+	}
+
+	// Using the Ordered strategy to ensure containerd is up before the garden server is started
+	return grouper.NewOrdered(os.Interrupt, members), nil
+}
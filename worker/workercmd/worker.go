@@ -1,6 +1,7 @@
 package workercmd
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -8,12 +9,14 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/gclient"
 	concourseCmd "github.com/concourse/concourse/cmd"
 	"github.com/concourse/concourse/worker"
 	"github.com/concourse/concourse/worker/baggageclaim/baggageclaimcmd"
 	bclient "github.com/concourse/concourse/worker/baggageclaim/client"
+	"github.com/concourse/concourse/worker/cloudmetadata"
 	"github.com/concourse/flag"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
@@ -48,6 +51,8 @@ type WorkerCommand struct {
 
 	ConnectionDrainTimeout time.Duration `long:"connection-drain-timeout" default:"1h" description:"Duration after which a worker should give up draining forwarded connections on shutdown."`
 
+	Cloud CloudMetadataConfig `group:"Cloud Metadata" namespace:"cloud"`
+
 	RuntimeConfiguration `group:"Runtime Configuration"`
 
 	// This refers to flags relevant to the operation of the Guardian runtime.
@@ -81,6 +86,15 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	logger, _ := cmd.Logger.Logger("worker")
 
+	var cloudProvider cloudmetadata.Provider
+	if cmd.Cloud.Provider != "" {
+		var err error
+		cloudProvider, err = cmd.Cloud.configureWorker(logger.Session("cloud-metadata"), &cmd.Worker)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	atcWorker, gardenServerRunner, err := cmd.gardenServerRunner(logger.Session("garden"))
 	if err != nil {
 		return nil, err
@@ -100,6 +114,13 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		cmd.HealthCheckTimeout,
 	)
 
+	if cmd.TSA.EnrollmentToken != "" {
+		err := cmd.TSA.Enroll(lagerctx.NewContext(context.Background(), logger.Session("enroll")))
+		if err != nil {
+			return nil, fmt.Errorf("failed to enroll worker key: %w", err)
+		}
+	}
+
 	tsaClient := cmd.TSA.Client(atcWorker)
 
 	beaconRunner := worker.NewBeaconRunner(
@@ -210,6 +231,22 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		},
 	}...)
 
+	if cloudProvider != nil {
+		members = append(members, grouper.Member{
+			Name: "termination-watcher",
+			Runner: concourseCmd.NewLoggingRunner(
+				logger.Session("termination-watcher"),
+				worker.NewTerminationWatcher(
+					logger.Session("termination-watcher"),
+					cmd.Cloud.TerminationPollInterval,
+					cmd.Cloud.TerminationGracePeriod,
+					tsaClient,
+					cloudProvider,
+				),
+			),
+		})
+	}
+
 	return grouper.NewParallel(os.Interrupt, members), nil
 }
 
@@ -17,6 +17,11 @@ type WorkerConfig struct {
 
 	Ephemeral bool `long:"ephemeral" description:"If set, the worker will be immediately removed upon stalling."`
 
+	GPUs int `long:"gpus" description:"Number of GPUs which the worker can mount into containers. Only supported by the containerd runtime."`
+
+	CPUCapacity    uint64 `long:"cpu-capacity"    description:"CPU shares which containers may request on the worker, at 1024 shares per CPU, for the bin-packing placement strategy. Defaults to the worker's CPUs on Linux."`
+	MemoryCapacity uint64 `long:"memory-capacity" description:"Bytes of memory which containers may request on the worker, for the bin-packing placement strategy. Defaults to the worker's total memory on Linux."`
+
 	Version string `long:"version" hidden:"true" description:"Version of the worker. This is normally baked in to the binary, so this flag is hidden."`
 }
 
@@ -31,5 +36,9 @@ func (c WorkerConfig) Worker() atc.Worker {
 		HTTPSProxyURL: c.HTTPSProxy,
 		NoProxy:       c.NoProxy,
 		Ephemeral:     c.Ephemeral,
+		GPUs:          c.GPUs,
+
+		CPUCapacity:    c.CPUCapacity,
+		MemoryCapacity: c.MemoryCapacity,
 	}
 }
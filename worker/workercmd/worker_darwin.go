@@ -0,0 +1,104 @@
+package workercmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/worker/sandbox"
+	"github.com/jessevdk/go-flags"
+	"github.com/tedsuo/ifrit"
+	"github.com/vito/houdini"
+)
+
+type RuntimeConfiguration struct {
+	Runtime string `long:"runtime" default:"houdini" choice:"houdini" choice:"sandbox" description:"Runtime to use with the worker. Please note that Houdini is insecure and runs every process as the worker user, while sandbox runs the processes of each container as a user of its own, confined by sandbox-exec."`
+
+	Sandbox SandboxRuntime `group:"Sandbox Configuration" namespace:"sandbox"`
+}
+
+type GuardianRuntime struct {
+	RequestTimeout time.Duration `long:"request-timeout" default:"5m" description:"How long to wait for requests to the Garden server to complete. 0 means no timeout."`
+}
+
+type ContainerdRuntime struct {
+}
+
+type SandboxRuntime struct {
+	Users []string `long:"user" description:"User account to run the processes of containers as, leased to one container at a time, so it caps the number of containers. Can be specified multiple times. Switching to them requires running the worker as root."`
+}
+
+type Certs struct{}
+
+const houdiniRuntime = "houdini"
+const sandboxRuntime = "sandbox"
+
+func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Command) {
+	// created in the work-dir
+	command.FindOptionByLongName(prefix + "baggageclaim-volumes").Required = false
+}
+
+func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, ifrit.Runner, error) {
+	worker := cmd.Worker.Worker()
+	worker.Platform = runtime.GOOS
+	var err error
+	worker.Name, err = cmd.workerName()
+	if err != nil {
+		return atc.Worker{}, nil, err
+	}
+
+	var runner ifrit.Runner
+
+	switch cmd.Runtime {
+	case houdiniRuntime:
+		runner, err = cmd.houdiniRunner(logger)
+	case sandboxRuntime:
+		runner, err = cmd.sandboxRunner(logger)
+	default:
+		err = fmt.Errorf("unsupported Runtime :%s", cmd.Runtime)
+	}
+
+	if err != nil {
+		return atc.Worker{}, nil, err
+	}
+
+	return worker, runner, nil
+}
+
+// sandboxRunner runs a Houdini backend whose processes run as the user leased
+// to their container, in a scratch dir of its own, and can't read the rest of
+// the work dir, including the volumes of other containers.
+func (cmd *WorkerCommand) sandboxRunner(logger lager.Logger) (ifrit.Runner, error) {
+	if len(cmd.Sandbox.Users) == 0 {
+		return nil, errors.New("the sandbox runtime requires at least one --sandbox-user")
+	}
+
+	depotDir := filepath.Join(cmd.WorkDir.Path(), "containers")
+	scratchDir := filepath.Join(cmd.WorkDir.Path(), "sandboxes")
+
+	for _, dir := range []string{depotDir, scratchDir} {
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %s", dir, err)
+		}
+	}
+
+	backend := sandbox.NewBackend(houdini.NewBackend(depotDir), sandbox.Config{
+		Users:       cmd.Sandbox.Users,
+		ScratchDir:  scratchDir,
+		PrivateDirs: []string{cmd.WorkDir.Path()},
+	})
+
+	return newGardenServerRunner(
+		"tcp",
+		cmd.bindAddr(),
+		0,
+		backend,
+		logger,
+	), nil
+}
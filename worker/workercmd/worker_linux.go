@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	goruntime "runtime"
 	"strings"
 	"time"
 
@@ -15,9 +16,11 @@ import (
 	"github.com/concourse/concourse/atc"
 	concourseCmd "github.com/concourse/concourse/cmd"
 	"github.com/concourse/concourse/worker/network"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
 	"github.com/concourse/flag"
 	"github.com/jessevdk/go-flags"
 	"github.com/tedsuo/ifrit"
+	"golang.org/x/sys/unix"
 )
 
 type Certs struct {
@@ -49,13 +52,23 @@ type ContainerdRuntime struct {
 		//TODO can DNSConfig be simplifed to just a bool rather than struct with a bool?
 		DNS                DNSConfig `group:"DNS Proxy Configuration" namespace:"dns-proxy"`
 		DNSServers         []string  `long:"dns-server" description:"DNS server IP address to use instead of automatically determined servers. Can be specified multiple times."`
+		DNSSearchDomains   []string  `long:"dns-search-domain" description:"Search domain to use instead of those of the host. Can be specified multiple times."`
 		RestrictedNetworks []string  `long:"restricted-network" description:"Network ranges to which traffic from containers will be restricted. Can be specified multiple times."`
 		Pool               string    `long:"network-pool" default:"10.80.0.0/16" description:"Network range to use for dynamically allocated container subnets."`
 		MTU                int       `long:"mtu" description:"MTU size for container network interfaces. Defaults to the MTU of the interface used for outbound access by the host."`
 		AllowHostAccess    bool      `long:"allow-host-access" description:"Allow containers to reach the host's network. This is turned off by default."`
+		Profiles           flag.File `long:"network-profiles" description:"Path to a YAML file listing networks which the containers of particular teams or pipelines are put into instead of the default network."`
 	} `group:"Container Networking"`
 
+	Security struct {
+		SeccompProfile  flag.File `long:"seccomp-profile" description:"Path to an OCI seccomp profile (JSON) to apply to unprivileged containers instead of the default one. Give tagged workers their own profiles to vary them by tag."`
+		AppArmorProfile string    `long:"apparmor-profile" description:"Name of an AppArmor profile loaded on the host to run unprivileged containers under."`
+		TeamProfiles    flag.File `long:"team-security-profiles" description:"Path to a YAML file listing seccomp and AppArmor profiles to apply to the unprivileged containers of particular teams instead."`
+	} `group:"Container Security"`
+
 	MaxContainers int `long:"max-containers" default:"250" description:"Max container capacity. 0 means no limit."`
+
+	PrivilegedMode bespec.PrivilegedMode `long:"privileged-mode" default:"full" choice:"full" choice:"userns" description:"How to run privileged containers: as root on the host (full), or with all capabilities inside a user namespace which maps root to an unprivileged host user (userns)."`
 }
 
 type DNSConfig struct {
@@ -89,6 +102,17 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 	worker := cmd.Worker.Worker()
 	worker.Platform = "linux"
 
+	if worker.CPUCapacity == 0 {
+		worker.CPUCapacity = uint64(goruntime.NumCPU()) * 1024
+	}
+
+	if worker.MemoryCapacity == 0 {
+		var info unix.Sysinfo_t
+		if err := unix.Sysinfo(&info); err == nil {
+			worker.MemoryCapacity = uint64(info.Totalram) * uint64(info.Unit)
+		}
+	}
+
 	if cmd.Certs.Dir != "" {
 		worker.CertsPath = &cmd.Certs.Dir
 	}
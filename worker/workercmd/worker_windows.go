@@ -0,0 +1,72 @@
+package workercmd
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/flag"
+	"github.com/jessevdk/go-flags"
+	"github.com/tedsuo/ifrit"
+)
+
+type RuntimeConfiguration struct {
+	Runtime string `long:"runtime" default:"houdini" choice:"containerd" choice:"houdini" description:"Runtime to use with the worker. Please note that Houdini is insecure and doesn't run 'tasks' in containers."`
+}
+
+type GuardianRuntime struct {
+	RequestTimeout time.Duration `long:"request-timeout" default:"5m" description:"How long to wait for requests to the Garden server to complete. 0 means no timeout."`
+}
+
+type ContainerdRuntime struct {
+	Config         flag.File     `long:"config"     description:"Path to a config file to use for the Containerd daemon."`
+	Bin            string        `long:"bin"        description:"Path to a containerd executable (non-absolute names get resolved from $PATH)."`
+	InitBin        string        `long:"init-bin"   description:"Path to a Windows executable which waits until it is signalled, run as the init process of containers. By default will search within the concourse/bin directory the concourse binary is in."`
+	RequestTimeout time.Duration `long:"request-timeout" default:"5m" description:"How long to wait for requests to Containerd to complete. 0 means no timeout."`
+
+	Network struct {
+		HNSNetwork string   `long:"hns-network" default:"nat" description:"Name of the Host Networking Service network to connect containers to."`
+		DNSServers []string `long:"dns-server" description:"DNS server IP address to give containers. Can be specified multiple times."`
+	} `group:"Container Networking"`
+
+	MaxContainers int `long:"max-containers" default:"250" description:"Max container capacity. 0 means no limit."`
+}
+
+type Certs struct{}
+
+const containerdRuntime = "containerd"
+const houdiniRuntime = "houdini"
+
+func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Command) {
+	// created in the work-dir
+	command.FindOptionByLongName(prefix + "baggageclaim-volumes").Required = false
+}
+
+func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, ifrit.Runner, error) {
+	worker := cmd.Worker.Worker()
+	worker.Platform = runtime.GOOS
+	var err error
+	worker.Name, err = cmd.workerName()
+	if err != nil {
+		return atc.Worker{}, nil, err
+	}
+
+	var runner ifrit.Runner
+
+	switch cmd.Runtime {
+	case houdiniRuntime:
+		runner, err = cmd.houdiniRunner(logger)
+	case containerdRuntime:
+		runner, err = cmd.containerdRunner(logger)
+	default:
+		err = fmt.Errorf("unsupported Runtime :%s", cmd.Runtime)
+	}
+
+	if err != nil {
+		return atc.Worker{}, nil, err
+	}
+
+	return worker, runner, nil
+}